@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"miltechserver/bootstrap"
+	"miltechserver/dataversion"
+	"time"
+)
+
+func init() {
+	adminTasks["set-db-date"] = task{
+		usage:   "DATE",
+		summary: "record a FedLog load so every replica flushes its reference caches",
+		run:     setDBDate,
+	}
+}
+
+// Running servers pick up the new version within DATA_VERSION_POLL_SECONDS.
+
+func setDBDate(ctx context.Context, _ *bootstrap.Env, db *sql.DB, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: set-db-date DATE")
+	}
+	if _, err := time.Parse(time.DateOnly, args[0]); err != nil {
+		return fmt.Errorf("DATE must be YYYY-MM-DD: %w", err)
+	}
+
+	v, err := dataversion.Record(ctx, db, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("recorded FedLog load %s as data version %d\n", v.DBDate, v.Serial)
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"miltechserver/bootstrap"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+func init() {
+	adminTasks["grant-role"] = task{
+		usage:   "UID ROLE [GRANTED_BY]",
+		summary: "grant an application role (site_admin, moderator) to a user",
+		run:     grantRole,
+	}
+	adminTasks["revoke-role"] = task{
+		usage:   "UID ROLE",
+		summary: "revoke an application role from a user",
+		run:     revokeRole,
+	}
+	adminTasks["list-roles"] = task{
+		usage:   "",
+		summary: "list every user holding an application role",
+		run:     listRoles,
+	}
+}
+
+// Running servers cache roles for USER_PROFILE_CACHE_TTL, so grants and
+// revocations take effect once that expires.
+
+func grantRole(ctx context.Context, _ *bootstrap.Env, db *sql.DB, args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return fmt.Errorf("usage: grant-role UID ROLE [GRANTED_BY]")
+	}
+	uid, role := args[0], args[1]
+	if err := validateRole(role); err != nil {
+		return err
+	}
+	var grantedBy sql.NullString
+	if len(args) == 3 {
+		grantedBy = sql.NullString{String: args[2], Valid: true}
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO user_roles (user_id, role, granted_by) VALUES ($1, $2, $3) ON CONFLICT (user_id, role) DO NOTHING`,
+		uid, role, grantedBy)
+	if err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+	fmt.Printf("granted %s to %s\n", role, uid)
+	return nil
+}
+
+func revokeRole(ctx context.Context, _ *bootstrap.Env, db *sql.DB, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: revoke-role UID ROLE")
+	}
+	result, err := db.ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1 AND role = $2`, args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("%s does not hold %s", args[0], args[1])
+	}
+	fmt.Printf("revoked %s from %s\n", args[1], args[0])
+	return nil
+}
+
+func listRoles(ctx context.Context, _ *bootstrap.Env, db *sql.DB, _ []string) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT r.user_id, COALESCE(u.username, ''), r.role, COALESCE(r.granted_by, ''), r.granted_at
+		FROM user_roles r
+		LEFT JOIN users u ON u.uid = r.user_id
+		ORDER BY r.role, r.user_id`)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER ID\tUSERNAME\tROLE\tGRANTED BY\tGRANTED AT")
+	for rows.Next() {
+		var uid, username, role, grantedBy string
+		var grantedAt time.Time
+		if err := rows.Scan(&uid, &username, &role, &grantedBy, &grantedAt); err != nil {
+			return fmt.Errorf("failed to scan role: %w", err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", uid, username, role, grantedBy, grantedAt.Format(time.RFC3339))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func validateRole(role string) error {
+	switch role {
+	case bootstrap.RoleSiteAdmin, bootstrap.RoleModerator:
+		return nil
+	default:
+		return fmt.Errorf("unknown role %q (want %s or %s)", role, bootstrap.RoleSiteAdmin, bootstrap.RoleModerator)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"miltechserver/bootstrap"
+	"miltechserver/identity"
+	"time"
+)
+
+func init() {
+	adminTasks["issue-token"] = task{
+		usage:   "-uid UID -email EMAIL [-name NAME] [-ttl 24h]",
+		summary: "mint a bearer token from the local issuer (AUTH_PROVIDER=local only)",
+		run:     issueToken,
+	}
+}
+
+func issueToken(_ context.Context, env *bootstrap.Env, _ *sql.DB, args []string) error {
+	flags := flag.NewFlagSet("issue-token", flag.ContinueOnError)
+	uid := flags.String("uid", "", "user ID (token subject)")
+	email := flags.String("email", "", "email address")
+	name := flags.String("name", "", "display name")
+	ttl := flags.Duration("ttl", 24*time.Hour, "token lifetime")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *uid == "" || *email == "" {
+		return errors.New("-uid and -email are required")
+	}
+	if env.AuthProvider != "local" {
+		return fmt.Errorf("AUTH_PROVIDER is %q; tokens from the local issuer would be rejected", env.AuthProvider)
+	}
+
+	issuer, err := identity.NewLocalIssuerFromEnv(env)
+	if err != nil {
+		return err
+	}
+	token, err := issuer.Issue(bootstrap.User{UserID: *uid, Email: *email, Username: *name}, *ttl)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(token)
+	return nil
+}
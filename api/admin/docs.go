@@ -0,0 +1,12 @@
+package admin
+
+import (
+	"slices"
+
+	"miltechserver/api/admin/scheduled_jobs"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(
+	scheduled_jobs.Docs,
+)
@@ -0,0 +1,27 @@
+// Package admin serves the site administration API under /auth/admin. Every
+// route requires the site_admin role.
+package admin
+
+import (
+	"database/sql"
+	"miltechserver/api/admin/scheduled_jobs"
+	"miltechserver/api/middleware"
+	"miltechserver/bootstrap"
+	"miltechserver/jobs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Dependencies struct {
+	DB   *sql.DB
+	Jobs *jobs.Scheduler
+}
+
+// RegisterRoutes mounts the admin group on authRouter, which must already
+// authenticate the user.
+func RegisterRoutes(deps Dependencies, authRouter *gin.RouterGroup) {
+	adminRoutes := authRouter.Group("/admin", middleware.RequireRole(bootstrap.RoleSiteAdmin))
+
+	jobsService := scheduled_jobs.NewService(scheduled_jobs.NewRepository(deps.DB), deps.Jobs)
+	scheduled_jobs.RegisterRoutes(adminRoutes, jobsService)
+}
@@ -0,0 +1,42 @@
+package scheduled_jobs
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"miltechserver/jobs"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+// Paths are relative to the admin group.
+var Docs = []openapi.Section{{
+	Tag: "Admin Jobs",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listJobs",
+			Method:   http.MethodGet,
+			Path:     "/admin/jobs",
+			Auth:     true,
+			Summary:  "Lists the scheduled jobs with their next and latest runs (site admins only)",
+			Response: []JobStatus{},
+		},
+		{
+			ID:       "getJobRuns",
+			Method:   http.MethodGet,
+			Path:     "/admin/jobs/:name/runs",
+			Auth:     true,
+			Summary:  "Returns a job's run history, newest first (site admins only)",
+			Query:    pagination.Query,
+			Response: pagination.Page[jobs.Run]{},
+		},
+		{
+			ID:       "triggerJob",
+			Method:   http.MethodPost,
+			Path:     "/admin/jobs/:name/run",
+			Auth:     true,
+			Summary:  "Starts a run of the job now and returns it without waiting for it to finish; 409 if it is already running (site admins only)",
+			Status:   http.StatusAccepted,
+			Response: jobs.Run{},
+		},
+	},
+}}
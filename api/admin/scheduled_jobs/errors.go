@@ -0,0 +1,9 @@
+package scheduled_jobs
+
+import "miltechserver/api/apperror"
+
+var (
+	ErrJobNotFound = apperror.NotFound("job_not_found", "no job with that name is registered")
+	ErrJobRunning  = apperror.Conflict("job_running", "the job is already running")
+	ErrStopping    = apperror.Conflict("scheduler_stopping", "the server is shutting down")
+)
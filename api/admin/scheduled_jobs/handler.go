@@ -0,0 +1,76 @@
+package scheduled_jobs
+
+import (
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+	"miltechserver/bootstrap"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultRunLimit = 50
+
+type Handler struct {
+	service Service
+}
+
+// ListJobs returns every registered job with its schedule and latest run
+func (handler *Handler) ListJobs(c *gin.Context) {
+	statuses, err := handler.service.ListJobs(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    statuses,
+	})
+}
+
+// GetJobRuns returns a page of a job's run history
+func (handler *Handler) GetJobRuns(c *gin.Context) {
+	params, err := pagination.FromQuery(c, defaultRunLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	runs, err := handler.service.GetJobRuns(c.Request.Context(), c.Param("name"), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    runs,
+	})
+}
+
+// TriggerJob starts a run of a job and returns it without waiting for it to
+// finish
+func (handler *Handler) TriggerJob(c *gin.Context) {
+	ctxUser, _ := c.Get("user")
+	user, ok := ctxUser.(*bootstrap.User)
+	if !ok || user == nil {
+		c.Error(apperror.ErrUnauthenticated)
+		return
+	}
+
+	run, err := handler.service.TriggerJob(c.Request.Context(), user, c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.StandardResponse{
+		Status:  http.StatusAccepted,
+		Message: "Job started",
+		Data:    run,
+	})
+}
@@ -0,0 +1,12 @@
+package scheduled_jobs
+
+import (
+	"context"
+	"miltechserver/api/pagination"
+	"miltechserver/jobs"
+)
+
+type Repository interface {
+	GetLatestRuns(ctx context.Context, names []string) (map[string]jobs.Run, error)
+	GetRunsPage(ctx context.Context, name string, params pagination.Params) (pagination.Page[jobs.Run], error)
+}
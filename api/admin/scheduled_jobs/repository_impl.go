@@ -0,0 +1,119 @@
+package scheduled_jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"miltechserver/api/pagination"
+	"miltechserver/jobs"
+
+	"github.com/lib/pq"
+)
+
+type RepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *RepositoryImpl {
+	return &RepositoryImpl{db: db}
+}
+
+const runColumns = `r.id, r.job_name, r.trigger, r.triggered_by, r.instance, r.status, r.error,
+	r.started_at, r.finished_at, r.duration_ms`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRun(row scanner) (jobs.Run, error) {
+	var run jobs.Run
+	err := row.Scan(
+		&run.ID,
+		&run.JobName,
+		&run.Trigger,
+		&run.TriggeredBy,
+		&run.Instance,
+		&run.Status,
+		&run.Error,
+		&run.StartedAt,
+		&run.FinishedAt,
+		&run.DurationMS,
+	)
+	return run, err
+}
+
+// GetLatestRuns returns the most recent run of each named job that has run.
+func (repo *RepositoryImpl) GetLatestRuns(ctx context.Context, names []string) (map[string]jobs.Run, error) {
+	rows, err := repo.db.QueryContext(ctx, `
+		SELECT `+runColumns+`
+		FROM unnest($1::text[]) AS j(name)
+		CROSS JOIN LATERAL (
+			SELECT * FROM job_runs WHERE job_name = j.name ORDER BY id DESC LIMIT 1
+		) r`, pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest job runs: %w", err)
+	}
+	defer rows.Close()
+
+	latest := map[string]jobs.Run{}
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job run: %w", err)
+		}
+		latest[run.JobName] = run
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job runs: %w", err)
+	}
+	return latest, nil
+}
+
+func (repo *RepositoryImpl) GetRunsPage(ctx context.Context, name string, params pagination.Params) (pagination.Page[jobs.Run], error) {
+	var afterID int64
+	after, err := params.After(&afterID)
+	if err != nil {
+		return pagination.Page[jobs.Run]{}, err
+	}
+
+	query := `SELECT ` + runColumns + ` FROM job_runs r WHERE r.job_name = $1`
+	args := []any{name}
+	if after {
+		args = append(args, afterID)
+		query += fmt.Sprintf(` AND r.id < $%d`, len(args))
+	}
+	args = append(args, params.Fetch())
+	query += fmt.Sprintf(` ORDER BY r.id DESC LIMIT $%d`, len(args))
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return pagination.Page[jobs.Run]{}, fmt.Errorf("failed to get job runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []jobs.Run
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return pagination.Page[jobs.Run]{}, fmt.Errorf("failed to scan job run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Page[jobs.Run]{}, fmt.Errorf("error iterating job runs: %w", err)
+	}
+
+	page := pagination.NewPage(runs, params, func(run jobs.Run) []any {
+		return []any{run.ID}
+	})
+	if !params.IncludeTotal {
+		return page, nil
+	}
+
+	var count int
+	err = repo.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM job_runs WHERE job_name = $1`, name).Scan(&count)
+	if err != nil {
+		return pagination.Page[jobs.Run]{}, fmt.Errorf("failed to count job runs: %w", err)
+	}
+	return page.WithTotal(count), nil
+}
@@ -0,0 +1,13 @@
+package scheduled_jobs
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, service Service) {
+	handler := Handler{service: service}
+
+	router.GET("/jobs", handler.ListJobs)
+	router.GET("/jobs/:name/runs", handler.GetJobRuns)
+	router.POST("/jobs/:name/run", handler.TriggerJob)
+}
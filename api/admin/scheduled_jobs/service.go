@@ -0,0 +1,26 @@
+package scheduled_jobs
+
+import (
+	"context"
+	"miltechserver/api/pagination"
+	"miltechserver/bootstrap"
+	"miltechserver/jobs"
+)
+
+// JobStatus is a registered job and its most recent run, if it has run.
+type JobStatus struct {
+	jobs.Info
+	LastRun *jobs.Run `json:"last_run"`
+}
+
+type Service interface {
+	ListJobs(ctx context.Context) ([]JobStatus, error)
+	GetJobRuns(ctx context.Context, name string, params pagination.Params) (pagination.Page[jobs.Run], error)
+	TriggerJob(ctx context.Context, user *bootstrap.User, name string) (jobs.Run, error)
+}
+
+// Scheduler is the part of jobs.Scheduler the admin API uses.
+type Scheduler interface {
+	Jobs() []jobs.Info
+	Trigger(ctx context.Context, name string, triggeredBy string) (jobs.Run, error)
+}
@@ -0,0 +1,81 @@
+package scheduled_jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"miltechserver/api/pagination"
+	"miltechserver/bootstrap"
+	"miltechserver/jobs"
+)
+
+type ServiceImpl struct {
+	repo      Repository
+	scheduler Scheduler
+}
+
+func NewService(repo Repository, scheduler Scheduler) *ServiceImpl {
+	return &ServiceImpl{
+		repo:      repo,
+		scheduler: scheduler,
+	}
+}
+
+// ListJobs returns every registered job with its latest run.
+func (service *ServiceImpl) ListJobs(ctx context.Context) ([]JobStatus, error) {
+	infos := service.scheduler.Jobs()
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		names[i] = info.Name
+	}
+
+	latest, err := service.repo.GetLatestRuns(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]JobStatus, len(infos))
+	for i, info := range infos {
+		statuses[i] = JobStatus{Info: info}
+		if run, ok := latest[info.Name]; ok {
+			statuses[i].LastRun = &run
+		}
+	}
+	return statuses, nil
+}
+
+// GetJobRuns returns the runs of a registered job, newest first.
+func (service *ServiceImpl) GetJobRuns(ctx context.Context, name string, params pagination.Params) (pagination.Page[jobs.Run], error) {
+	if !service.registered(name) {
+		return pagination.Page[jobs.Run]{}, ErrJobNotFound
+	}
+	return service.repo.GetRunsPage(ctx, name, params)
+}
+
+// TriggerJob starts a run of the job now and returns it once recorded.
+func (service *ServiceImpl) TriggerJob(ctx context.Context, user *bootstrap.User, name string) (jobs.Run, error) {
+	run, err := service.scheduler.Trigger(ctx, name, user.UserID)
+	switch {
+	case errors.Is(err, jobs.ErrUnknownJob):
+		return jobs.Run{}, ErrJobNotFound
+	case errors.Is(err, jobs.ErrJobRunning):
+		return jobs.Run{}, ErrJobRunning
+	case errors.Is(err, jobs.ErrStopped):
+		return jobs.Run{}, ErrStopping
+	case err != nil:
+		return jobs.Run{}, fmt.Errorf("failed to trigger job %s: %w", name, err)
+	}
+
+	slog.Info("Job triggered manually", "job", name, "run_id", run.ID, "user_id", user.UserID)
+	return run, nil
+}
+
+func (service *ServiceImpl) registered(name string) bool {
+	for _, info := range service.scheduler.Jobs() {
+		if info.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package scheduled_jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"miltechserver/api/pagination"
+	"miltechserver/bootstrap"
+	"miltechserver/jobs"
+
+	"github.com/stretchr/testify/require"
+)
+
+type schedulerStub struct {
+	infos      []jobs.Info
+	triggerErr error
+}
+
+func (stub *schedulerStub) Jobs() []jobs.Info { return stub.infos }
+
+func (stub *schedulerStub) Trigger(_ context.Context, name string, triggeredBy string) (jobs.Run, error) {
+	if stub.triggerErr != nil {
+		return jobs.Run{}, stub.triggerErr
+	}
+	return jobs.Run{ID: 7, JobName: name, Trigger: jobs.TriggerManual, TriggeredBy: &triggeredBy, Status: jobs.StatusRunning}, nil
+}
+
+type repositoryStub struct {
+	latest map[string]jobs.Run
+	pages  int
+}
+
+func (stub *repositoryStub) GetLatestRuns(context.Context, []string) (map[string]jobs.Run, error) {
+	return stub.latest, nil
+}
+
+func (stub *repositoryStub) GetRunsPage(context.Context, string, pagination.Params) (pagination.Page[jobs.Run], error) {
+	stub.pages++
+	return pagination.Page[jobs.Run]{Items: []jobs.Run{}}, nil
+}
+
+var registered = []jobs.Info{
+	{Name: "analytics-daily-rollup", Schedule: "5 0 * * *", NextRun: time.Date(2026, 10, 18, 0, 5, 0, 0, time.UTC)},
+	{Name: "job-runs-prune", Schedule: "30 3 * * *", NextRun: time.Date(2026, 10, 18, 3, 30, 0, 0, time.UTC)},
+}
+
+func TestListJobsAddsLatestRun(t *testing.T) {
+	repo := &repositoryStub{latest: map[string]jobs.Run{
+		"job-runs-prune": {ID: 3, JobName: "job-runs-prune", Status: jobs.StatusSucceeded},
+	}}
+	service := NewService(repo, &schedulerStub{infos: registered})
+
+	statuses, err := service.ListJobs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	require.Nil(t, statuses[0].LastRun, "a job that never ran has no last run")
+	require.Equal(t, int64(3), statuses[1].LastRun.ID)
+	require.Equal(t, "30 3 * * *", statuses[1].Schedule)
+}
+
+func TestGetJobRunsRequiresRegisteredJob(t *testing.T) {
+	repo := &repositoryStub{}
+	service := NewService(repo, &schedulerStub{infos: registered})
+
+	_, err := service.GetJobRuns(context.Background(), "missing", pagination.Params{Limit: 10})
+	require.ErrorIs(t, err, ErrJobNotFound)
+	require.Zero(t, repo.pages)
+
+	_, err = service.GetJobRuns(context.Background(), "job-runs-prune", pagination.Params{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, repo.pages)
+}
+
+func TestTriggerJob(t *testing.T) {
+	user := &bootstrap.User{UserID: "admin-1"}
+
+	run, err := NewService(&repositoryStub{}, &schedulerStub{infos: registered}).
+		TriggerJob(context.Background(), user, "job-runs-prune")
+	require.NoError(t, err)
+	require.Equal(t, "admin-1", *run.TriggeredBy)
+
+	for schedulerErr, want := range map[error]error{
+		jobs.ErrUnknownJob: ErrJobNotFound,
+		jobs.ErrJobRunning: ErrJobRunning,
+		jobs.ErrStopped:    ErrStopping,
+	} {
+		service := NewService(&repositoryStub{}, &schedulerStub{triggerErr: schedulerErr})
+		_, err := service.TriggerJob(context.Background(), user, "job-runs-prune")
+		require.ErrorIs(t, err, want)
+	}
+
+	service := NewService(&repositoryStub{}, &schedulerStub{triggerErr: errors.New("connection refused")})
+	_, err = service.TriggerJob(context.Background(), user, "job-runs-prune")
+	require.ErrorContains(t, err, "connection refused")
+}
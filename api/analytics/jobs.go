@@ -0,0 +1,29 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"miltechserver/jobs"
+)
+
+// RegisterJobs schedules the daily rollup of the analytics counters.
+func RegisterJobs(scheduler *jobs.Scheduler, db *sql.DB) {
+	repo := NewRepository(db)
+	scheduler.Register(jobs.Job{
+		Name:     "analytics-daily-rollup",
+		Schedule: "5 0 * * *",
+		Run: func(ctx context.Context) error {
+			// Shortly after midnight UTC the totals are those at the end of yesterday
+			day := time.Now().UTC().AddDate(0, 0, -1)
+			rows, err := repo.RollupDailyCounters(ctx, day)
+			if err != nil {
+				return err
+			}
+			slog.Info("Rolled up analytics counters", "day", day.Format(time.DateOnly), "counters", rows)
+			return nil
+		},
+	})
+}
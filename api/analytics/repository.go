@@ -1,5 +1,11 @@
 package analytics
 
+import (
+	"context"
+	"time"
+)
+
 type Repository interface {
-	IncrementCounter(eventType string, entityKey string, entityLabel string) error
+	IncrementCounter(ctx context.Context, eventType string, entityKey string, entityLabel string) error
+	RollupDailyCounters(ctx context.Context, day time.Time) (int64, error)
 }
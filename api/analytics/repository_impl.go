@@ -1,6 +1,7 @@
 package analytics
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -19,7 +20,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) IncrementCounter(eventType string, entityKey string, entityLabel string) error {
+func (repo *RepositoryImpl) IncrementCounter(ctx context.Context, eventType string, entityKey string, entityLabel string) error {
 	now := time.Now()
 
 	stmt := table.AnalyticsEventCounters.INSERT(
@@ -49,10 +50,42 @@ func (repo *RepositoryImpl) IncrementCounter(eventType string, entityKey string,
 		),
 	)
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("failed to increment analytics counter: %w", err)
 	}
 
 	return nil
 }
+
+// RollupDailyCounters snapshots every counter that changed since its last
+// snapshot into analytics_daily_counters for day, with the growth since
+// then. Rolling up the same day again replaces its rows.
+func (repo *RepositoryImpl) RollupDailyCounters(ctx context.Context, day time.Time) (int64, error) {
+	result, err := repo.db.ExecContext(ctx, `
+		INSERT INTO analytics_daily_counters (day, event_type, entity_key, entity_label, total, count)
+		SELECT $1::date, c.event_type, c.entity_key, c.entity_label, c.count, c.count - COALESCE(prev.total, 0)
+		FROM analytics_event_counters c
+		LEFT JOIN LATERAL (
+			SELECT p.total FROM analytics_daily_counters p
+			WHERE p.event_type = c.event_type AND p.entity_key = c.entity_key AND p.day < $1::date
+			ORDER BY p.day DESC
+			LIMIT 1
+		) prev ON true
+		WHERE prev.total IS NULL OR prev.total <> c.count
+		ON CONFLICT (day, event_type, entity_key) DO UPDATE SET
+			entity_label = EXCLUDED.entity_label,
+			total = EXCLUDED.total,
+			count = EXCLUDED.count`,
+		day.Format(time.DateOnly))
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up analytics counters: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
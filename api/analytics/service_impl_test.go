@@ -1,8 +1,10 @@
 package analytics
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -15,13 +17,17 @@ type repoStub struct {
 	err                 error
 }
 
-func (r *repoStub) IncrementCounter(eventType, entityKey, entityLabel string) error {
+func (r *repoStub) IncrementCounter(_ context.Context, eventType, entityKey, entityLabel string) error {
 	r.capturedEventType = eventType
 	r.capturedEntityKey = entityKey
 	r.capturedEntityLabel = entityLabel
 	return r.err
 }
 
+func (r *repoStub) RollupDailyCounters(context.Context, time.Time) (int64, error) {
+	return 0, r.err
+}
+
 func TestFormatPSMagLabel(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -65,7 +71,7 @@ func TestIncrementPSMagDownload_StandardFilename(t *testing.T) {
 	repo := &repoStub{}
 	svc := NewService(repo)
 
-	err := svc.IncrementPSMagDownload("PS_Magazine_Issue_004_September_1951.pdf")
+	err := svc.IncrementPSMagDownload(context.Background(), "PS_Magazine_Issue_004_September_1951.pdf")
 
 	require.NoError(t, err)
 	require.Equal(t, "ps_mag_download", repo.capturedEventType)
@@ -77,7 +83,7 @@ func TestIncrementPSMagDownload_EmptyFilename(t *testing.T) {
 	repo := &repoStub{}
 	svc := NewService(repo)
 
-	err := svc.IncrementPSMagDownload("")
+	err := svc.IncrementPSMagDownload(context.Background(), "")
 
 	require.NoError(t, err)
 	require.Empty(t, repo.capturedEventType) // repo must not be called for empty input
@@ -87,7 +93,7 @@ func TestIncrementPSMagDownload_WhitespaceOnlyFilename(t *testing.T) {
 	repo := &repoStub{}
 	svc := NewService(repo)
 
-	err := svc.IncrementPSMagDownload("   ")
+	err := svc.IncrementPSMagDownload(context.Background(), "   ")
 
 	require.NoError(t, err)
 	require.Empty(t, repo.capturedEventType) // repo must not be called for whitespace-only input
@@ -97,7 +103,7 @@ func TestIncrementPSMagDownload_RepoError(t *testing.T) {
 	repo := &repoStub{err: errors.New("db down")}
 	svc := NewService(repo)
 
-	err := svc.IncrementPSMagDownload("PS_Magazine_Issue_001_January_1951.pdf")
+	err := svc.IncrementPSMagDownload(context.Background(), "PS_Magazine_Issue_001_January_1951.pdf")
 
 	require.Error(t, err)
 }
@@ -0,0 +1,206 @@
+// Package apperror is the error taxonomy shared by every feature package.
+// Services return *Error values (usually package-level sentinels built with
+// the constructors below); the ErrorHandler middleware renders them as
+// RFC 7807 application/problem+json with a stable machine-readable code.
+//
+// Errors that are not *Error are treated as internal failures: they are
+// logged and rendered as a generic 500 so driver or SQL details never reach
+// clients. The exception is an expired or cancelled request context, which
+// renders as a 504 timeout or a 499 cancellation.
+package apperror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Kind classifies an error and determines its HTTP status.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindConflict
+	KindRateLimited
+	KindUpstream
+	// KindTimeout is a request that ran past its deadline.
+	KindTimeout
+	// KindCanceled is a request whose client went away before it finished.
+	KindCanceled
+)
+
+// StatusClientClosedRequest is the non-standard status (borrowed from nginx)
+// recorded for requests the client abandoned. The client never sees it.
+const StatusClientClosedRequest = 499
+
+// Status returns the HTTP status code for the kind.
+func (k Kind) Status() int {
+	switch k {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindConflict:
+		return http.StatusConflict
+	case KindRateLimited:
+		return http.StatusTooManyRequests
+	case KindUpstream:
+		return http.StatusBadGateway
+	case KindTimeout:
+		return http.StatusGatewayTimeout
+	case KindCanceled:
+		return StatusClientClosedRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// String returns the kind's stable name, also used as the fallback code.
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not_found"
+	case KindValidation:
+		return "validation_failed"
+	case KindUnauthorized:
+		return "unauthorized"
+	case KindForbidden:
+		return "forbidden"
+	case KindConflict:
+		return "conflict"
+	case KindRateLimited:
+		return "rate_limited"
+	case KindUpstream:
+		return "upstream_failure"
+	case KindTimeout:
+		return "request_timeout"
+	case KindCanceled:
+		return "request_canceled"
+	default:
+		return "internal_error"
+	}
+}
+
+// FieldError describes one invalid request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a classified application error.
+type Error struct {
+	Kind Kind
+	// Code is stable and machine-readable, e.g. "shop_not_found". Clients
+	// switch on it; never change an existing code.
+	Code string
+	// Message is safe to show to API clients.
+	Message string
+	Fields  []FieldError
+	// Err is the underlying cause. It is logged but never sent to clients.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches any *Error with the same kind and code, so a sentinel still
+// matches after WithCause or WithFields has copied it.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Code == e.Code
+}
+
+// WithCause returns a copy of e that wraps cause.
+func (e *Error) WithCause(cause error) *Error {
+	cp := *e
+	cp.Err = cause
+	return &cp
+}
+
+// WithFields returns a copy of e carrying per-field validation details.
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	cp := *e
+	cp.Fields = append(append([]FieldError(nil), e.Fields...), fields...)
+	return &cp
+}
+
+// New returns an error of the given kind. An empty code falls back to the
+// kind's name.
+func New(kind Kind, code string, message string) *Error {
+	if code == "" {
+		code = kind.String()
+	}
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NotFound(code string, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+func Validation(code string, message string, fields ...FieldError) *Error {
+	e := New(KindValidation, code, message)
+	e.Fields = fields
+	return e
+}
+
+func Unauthorized(code string, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+func Forbidden(code string, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+func Conflict(code string, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func RateLimited(code string, message string) *Error {
+	return New(KindRateLimited, code, message)
+}
+
+// Upstream reports a failure in a dependency such as blob storage or Firebase.
+func Upstream(code string, message string, cause error) *Error {
+	e := New(KindUpstream, code, message)
+	e.Err = cause
+	return e
+}
+
+// Internal wraps an unexpected failure. The cause is logged, not rendered.
+func Internal(cause error) *Error {
+	e := New(KindInternal, "", "internal server error")
+	e.Err = cause
+	return e
+}
+
+// As returns the *Error in err's chain. Expired or cancelled request contexts
+// are classified as timeouts and cancellations; anything else is internal.
+func As(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrRequestTimeout.WithCause(err)
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrRequestCanceled.WithCause(err)
+	}
+	return Internal(err)
+}
@@ -0,0 +1,104 @@
+package apperror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+var errWidgetNotFound = NotFound("widget_not_found", "widget not found")
+
+func TestSentinelMatchesAfterWrappingAndCopying(t *testing.T) {
+	cause := errors.New("sql: no rows")
+	wrapped := fmt.Errorf("loading widget: %w", errWidgetNotFound.WithCause(cause))
+
+	require.ErrorIs(t, wrapped, errWidgetNotFound)
+	require.ErrorIs(t, wrapped, cause)
+	require.Equal(t, KindNotFound, As(wrapped).Kind)
+}
+
+func TestAsClassifiesUnknownErrorsAsInternal(t *testing.T) {
+	appErr := As(errors.New("pq: connection reset"))
+
+	require.Equal(t, KindInternal, appErr.Kind)
+	require.Equal(t, "internal_error", appErr.Code)
+}
+
+func TestAsClassifiesContextErrors(t *testing.T) {
+	timeout := As(fmt.Errorf("querying widgets: %w", context.DeadlineExceeded))
+	require.Equal(t, KindTimeout, timeout.Kind)
+	require.Equal(t, "request_timeout", timeout.Code)
+	require.Equal(t, http.StatusGatewayTimeout, timeout.Kind.Status())
+
+	canceled := As(fmt.Errorf("querying widgets: %w", context.Canceled))
+	require.Equal(t, KindCanceled, canceled.Kind)
+	require.Equal(t, StatusClientClosedRequest, canceled.Kind.Status())
+}
+
+func TestFromBindingReportsFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	type request struct {
+		Name  string `json:"name" binding:"required"`
+		Count int    `json:"count" binding:"min=1"`
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"count":0}`))
+	var req request
+	appErr := FromBinding(c.ShouldBindJSON(&req))
+
+	require.ErrorIs(t, appErr, ErrInvalidRequest)
+	require.Equal(t, []FieldError{
+		{Field: "name", Message: "is required"},
+		{Field: "count", Message: "must be at least 1"},
+	}, appErr.Fields)
+}
+
+func TestFromBindingMalformedJSON(t *testing.T) {
+	var req struct{}
+	err := json.Unmarshal([]byte(`{"name":`), &req)
+
+	require.Equal(t, "malformed_body", FromBinding(err).Code)
+}
+
+func TestRenderWritesProblemJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{name: "sentinel", err: errWidgetNotFound, status: http.StatusNotFound, code: "widget_not_found", detail: "widget not found"},
+		{name: "wrapped", err: fmt.Errorf("ctx: %w", Forbidden("not_owner", "not your widget")), status: http.StatusForbidden, code: "not_owner", detail: "not your widget"},
+		{name: "internal", err: errors.New("pq: password authentication failed"), status: http.StatusInternalServerError, code: "internal_error", detail: "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodGet, "/widgets/7", nil)
+
+			Render(c, tt.err)
+
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+			var problem Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			require.Equal(t, tt.code, problem.Code)
+			require.Equal(t, tt.detail, problem.Detail)
+			require.Equal(t, "/widgets/7", problem.Instance)
+			require.Equal(t, "/problems/"+tt.code, problem.Type)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrInvalidRequest is the generic validation error for malformed requests.
+var ErrInvalidRequest = Validation("invalid_request", "request is invalid")
+
+// FromBinding converts a gin ShouldBind* error into a validation error with
+// one FieldError per failed constraint.
+func FromBinding(err error) *Error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, FieldError{
+				Field:   fieldName(fieldErr),
+				Message: constraintMessage(fieldErr),
+			})
+		}
+		return ErrInvalidRequest.WithFields(fields...).WithCause(err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return ErrInvalidRequest.WithFields(FieldError{
+			Field:   typeErr.Field,
+			Message: "must be " + typeErr.Type.String(),
+		}).WithCause(err)
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return Validation("malformed_body", "request body is not valid JSON").WithCause(err)
+	}
+
+	return ErrInvalidRequest.WithCause(err)
+}
+
+// fieldName returns the dotted field path without the top-level struct name,
+// e.g. "items[0].niin".
+func fieldName(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if _, rest, ok := strings.Cut(namespace, "."); ok {
+		return lowerFirst(rest)
+	}
+	return lowerFirst(fieldErr.Field())
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func constraintMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return fmt.Sprintf("must be at least %s", fieldErr.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fieldErr.Param())
+	case "len":
+		return fmt.Sprintf("must have length %s", fieldErr.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fieldErr.Param())
+	case "email":
+		return "must be a valid email address"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	default:
+		return fmt.Sprintf("failed %q validation", fieldErr.Tag())
+	}
+}
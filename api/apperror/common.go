@@ -0,0 +1,26 @@
+package apperror
+
+// Errors shared by handlers that have no package-specific sentinel.
+var (
+	ErrUnauthenticated = Unauthorized("unauthorized", "unauthorized")
+	ErrNoItemsFound    = NotFound("no_items_found", "no item(s) found")
+	ErrRequestTimeout  = New(KindTimeout, "", "request timed out")
+	ErrRequestCanceled = New(KindCanceled, "", "request canceled")
+)
+
+// MissingParameter reports a required path, query or form parameter that was
+// not supplied.
+func MissingParameter(name string) *Error {
+	return Validation("missing_parameter", name+" is required", FieldError{
+		Field:   name,
+		Message: "is required",
+	})
+}
+
+// InvalidParameter reports a parameter that was supplied but could not be used.
+func InvalidParameter(name string, message string) *Error {
+	return Validation("invalid_parameter", message, FieldError{
+		Field:   name,
+		Message: message,
+	})
+}
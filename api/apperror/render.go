@@ -0,0 +1,53 @@
+package apperror
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of every error response.
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document. Code and Errors are
+// extension members.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// ProblemFor builds the problem document for err. Internal errors get a
+// generic detail so causes never leak to clients.
+func ProblemFor(err error, instance string) Problem {
+	appErr := As(err)
+	status := appErr.Kind.Status()
+	return Problem{
+		Type:     "/problems/" + appErr.Code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   appErr.Message,
+		Instance: instance,
+		Code:     appErr.Code,
+		Errors:   appErr.Fields,
+	}
+}
+
+// Render writes err as problem+json and aborts the handler chain. Server-side
+// failures are logged with their cause.
+func Render(c *gin.Context, err error) {
+	problem := ProblemFor(err, c.Request.URL.Path)
+	if problem.Status == StatusClientClosedRequest {
+		slog.Info("Request canceled by client", "method", c.Request.Method, "path", c.FullPath())
+	} else if problem.Status >= http.StatusInternalServerError {
+		slog.Error("Request failed", "method", c.Request.Method, "path", c.FullPath(), "code", problem.Code, "error", err)
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
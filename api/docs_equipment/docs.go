@@ -0,0 +1,75 @@
+package docs_equipment
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Equipment Details",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listEquipmentDetails",
+			Method:   http.MethodGet,
+			Path:     "/equipment-details",
+			Summary:  "List equipment details",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.DocsEquipmentDetails]{},
+		},
+		{
+			ID:       "listEquipmentFamilies",
+			Method:   http.MethodGet,
+			Path:     "/equipment-details/families",
+			Summary:  "List equipment families",
+			Response: FamiliesResponse{},
+		},
+		{
+			ID:       "listEquipmentDetailsByFamily",
+			Method:   http.MethodGet,
+			Path:     "/equipment-details/family/:family",
+			Summary:  "List equipment details by family",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.DocsEquipmentDetails]{},
+		},
+		{
+			ID:       "searchEquipmentDetails",
+			Method:   http.MethodGet,
+			Path:     "/equipment-details/search",
+			Summary:  "Search equipment details",
+			Query:    append([]openapi.Param{{Name: "q", Required: true}}, pagination.Query...),
+			Response: pagination.Page[model.DocsEquipmentDetails]{},
+		},
+		{
+			ID:       "listImageFamilies",
+			Method:   http.MethodGet,
+			Path:     "/equipment-details/images/families",
+			Summary:  "List image families",
+			Response: ImageFamiliesResponse{},
+		},
+		{
+			ID:       "listFamilyImages",
+			Method:   http.MethodGet,
+			Path:     "/equipment-details/images/family/:family",
+			Summary:  "List family images",
+			Response: FamilyImagesResponse{},
+		},
+		{
+			ID:       "getFamilyImageURLs",
+			Method:   http.MethodGet,
+			Path:     "/equipment-details/images/family/:family/urls",
+			Summary:  "Get family image URLs",
+			Response: FamilyImageURLsResponse{},
+		},
+		{
+			ID:       "generateImageDownloadURL",
+			Method:   http.MethodGet,
+			Path:     "/equipment-details/images/download",
+			Summary:  "Generate image download URL",
+			Query:    []openapi.Param{{Name: "blob_path"}},
+			Response: ImageDownloadResponse{},
+		},
+	},
+}}
@@ -1,15 +1,13 @@
 package docs_equipment
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrNotFound        = errors.New("no equipment details found")
-	ErrEmptyParam      = errors.New("required parameter is empty")
-	ErrInvalidPage     = errors.New("page must be greater than 0")
-	ErrEmptyBlobPath   = errors.New("blob path cannot be empty")
-	ErrInvalidBlobPath = errors.New("invalid blob path: must start with docs_equipment/images/")
-	ErrInvalidFileType = errors.New("invalid file type: only image files are allowed")
-	ErrImageNotFound   = errors.New("image not found")
-	ErrBlobListFailed  = errors.New("failed to list blobs from Azure")
-	ErrSASGenFailed    = errors.New("failed to generate download URL")
+	ErrEmptyParam      = apperror.Validation("required_parameter_empty", "required parameter is empty")
+	ErrEmptyBlobPath   = apperror.Validation("blob_path_empty", "blob path cannot be empty")
+	ErrInvalidBlobPath = apperror.Validation("invalid_blob_path", "invalid blob path: must start with docs_equipment/images/")
+	ErrInvalidFileType = apperror.Validation("invalid_file_type", "invalid file type: only image files are allowed")
+	ErrImageNotFound   = apperror.NotFound("image_not_found", "image not found")
+	ErrBlobListFailed  = apperror.Upstream("blob_list_failed", "failed to list blobs from Azure", nil)
+	ErrSASGenFailed    = apperror.Upstream("download_url_failed", "failed to generate download URL", nil)
 )
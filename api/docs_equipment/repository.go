@@ -1,9 +1,16 @@
 package docs_equipment
 
+import (
+	"context"
+
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+)
+
 // Repository defines database operations for equipment details.
 type Repository interface {
-	GetAllPaginated(page int) (EquipmentDetailsPageResponse, error)
-	GetFamilies() (FamiliesResponse, error)
-	GetByFamilyPaginated(family string, page int) (EquipmentDetailsPageResponse, error)
-	SearchPaginated(query string, page int) (EquipmentDetailsPageResponse, error)
+	GetAllPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error)
+	GetFamilies(ctx context.Context) (FamiliesResponse, error)
+	GetByFamilyPaginated(ctx context.Context, family string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error)
+	SearchPaginated(ctx context.Context, query string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error)
 }
@@ -1,16 +1,16 @@
 package docs_equipment
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"math"
+	"slices"
 	"strings"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
 )
 
-const pageSize = 40
-
 type repositoryImpl struct {
 	db *sql.DB
 }
@@ -46,49 +46,67 @@ func collectItems(rows *sql.Rows) ([]model.DocsEquipmentDetails, error) {
 	return items, nil
 }
 
-func buildPageResponse(items []model.DocsEquipmentDetails, page, totalCount int) EquipmentDetailsPageResponse {
-	totalPages := int(math.Ceil(float64(totalCount) / float64(pageSize)))
-	return EquipmentDetailsPageResponse{
-		Items:      items,
-		Count:      len(items),
-		Page:       page,
-		TotalPages: totalPages,
-		IsLastPage: page >= totalPages,
+// listPage reads the page of equipment matching where that follows
+// params.Cursor, in id order. where may be empty; args are its arguments,
+// numbered from $1.
+func (r *repositoryImpl) listPage(ctx context.Context, where string, args []any, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
+	var afterID int64
+	hasCursor, err := params.After(&afterID)
+	if err != nil {
+		return pagination.Page[model.DocsEquipmentDetails]{}, err
 	}
-}
 
-func (r *repositoryImpl) GetAllPaginated(page int) (EquipmentDetailsPageResponse, error) {
-	if page < 1 {
-		return EquipmentDetailsPageResponse{}, ErrInvalidPage
+	var conditions []string
+	if where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+	queryArgs := slices.Clone(args)
+	if hasCursor {
+		queryArgs = append(queryArgs, afterID)
+		conditions = append(conditions, fmt.Sprintf("id > $%d", len(queryArgs)))
+	}
+	query := selectAll
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
-	offset := int64(pageSize) * int64(page-1)
+	queryArgs = append(queryArgs, params.Fetch())
+	query += fmt.Sprintf(" ORDER BY id LIMIT $%d", len(queryArgs))
 
-	query := selectAll + ` ORDER BY id LIMIT $1 OFFSET $2`
-	rows, err := r.db.Query(query, pageSize, offset)
+	rows, err := r.db.QueryContext(ctx, query, queryArgs...)
 	if err != nil {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("failed to query equipment details: %w", err)
+		return pagination.Page[model.DocsEquipmentDetails]{}, fmt.Errorf("failed to query equipment details: %w", err)
 	}
 	defer rows.Close()
 
 	items, err := collectItems(rows)
 	if err != nil {
-		return EquipmentDetailsPageResponse{}, err
+		return pagination.Page[model.DocsEquipmentDetails]{}, err
 	}
 
-	var totalCount int
-	if err := r.db.QueryRow(`SELECT COUNT(*) FROM docs_equipment_details`).Scan(&totalCount); err != nil {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("failed to count equipment details: %w", err)
+	page := pagination.NewPage(items, params, func(item model.DocsEquipmentDetails) []any {
+		return []any{item.ID}
+	})
+	if !params.IncludeTotal {
+		return page, nil
 	}
 
-	if len(items) == 0 {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("no equipment found: %w", ErrNotFound)
+	countQuery := `SELECT COUNT(*) FROM docs_equipment_details`
+	if where != "" {
+		countQuery += " WHERE " + where
+	}
+	var totalCount int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return pagination.Page[model.DocsEquipmentDetails]{}, fmt.Errorf("failed to count equipment details: %w", err)
 	}
+	return page.WithTotal(totalCount), nil
+}
 
-	return buildPageResponse(items, page, totalCount), nil
+func (r *repositoryImpl) GetAllPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
+	return r.listPage(ctx, "", nil, params)
 }
 
-func (r *repositoryImpl) GetFamilies() (FamiliesResponse, error) {
-	rows, err := r.db.Query(`SELECT DISTINCT family FROM docs_equipment_details WHERE family IS NOT NULL ORDER BY family`)
+func (r *repositoryImpl) GetFamilies(ctx context.Context) (FamiliesResponse, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT family FROM docs_equipment_details WHERE family IS NOT NULL ORDER BY family`)
 	if err != nil {
 		return FamiliesResponse{}, fmt.Errorf("failed to query families: %w", err)
 	}
@@ -109,69 +127,19 @@ func (r *repositoryImpl) GetFamilies() (FamiliesResponse, error) {
 	return FamiliesResponse{Families: families, Count: len(families)}, nil
 }
 
-func (r *repositoryImpl) GetByFamilyPaginated(family string, page int) (EquipmentDetailsPageResponse, error) {
+func (r *repositoryImpl) GetByFamilyPaginated(ctx context.Context, family string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
 	if strings.TrimSpace(family) == "" {
-		return EquipmentDetailsPageResponse{}, ErrEmptyParam
-	}
-	if page < 1 {
-		return EquipmentDetailsPageResponse{}, ErrInvalidPage
+		return pagination.Page[model.DocsEquipmentDetails]{}, ErrEmptyParam
 	}
-	offset := int64(pageSize) * int64(page-1)
 
-	query := selectAll + ` WHERE LOWER(family) = LOWER($1) ORDER BY id LIMIT $2 OFFSET $3`
-	rows, err := r.db.Query(query, strings.TrimSpace(family), pageSize, offset)
-	if err != nil {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("failed to query by family: %w", err)
-	}
-	defer rows.Close()
-
-	items, err := collectItems(rows)
-	if err != nil {
-		return EquipmentDetailsPageResponse{}, err
-	}
-
-	var totalCount int
-	if err := r.db.QueryRow(`SELECT COUNT(*) FROM docs_equipment_details WHERE LOWER(family) = LOWER($1)`, strings.TrimSpace(family)).Scan(&totalCount); err != nil {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("failed to count by family: %w", err)
-	}
-
-	if len(items) == 0 {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("no equipment found for family: %w", ErrNotFound)
-	}
-
-	return buildPageResponse(items, page, totalCount), nil
+	return r.listPage(ctx, `LOWER(family) = LOWER($1)`, []any{strings.TrimSpace(family)}, params)
 }
 
-func (r *repositoryImpl) SearchPaginated(query string, page int) (EquipmentDetailsPageResponse, error) {
+func (r *repositoryImpl) SearchPaginated(ctx context.Context, query string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
 	if strings.TrimSpace(query) == "" {
-		return EquipmentDetailsPageResponse{}, ErrEmptyParam
-	}
-	if page < 1 {
-		return EquipmentDetailsPageResponse{}, ErrInvalidPage
+		return pagination.Page[model.DocsEquipmentDetails]{}, ErrEmptyParam
 	}
-	offset := int64(pageSize) * int64(page-1)
 	searchPattern := "%" + strings.TrimSpace(query) + "%"
 
-	stmt := selectAll + ` WHERE model ILIKE $1 OR lin ILIKE $1 ORDER BY id LIMIT $2 OFFSET $3`
-	rows, err := r.db.Query(stmt, searchPattern, pageSize, offset)
-	if err != nil {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("failed to search: %w", err)
-	}
-	defer rows.Close()
-
-	items, err := collectItems(rows)
-	if err != nil {
-		return EquipmentDetailsPageResponse{}, err
-	}
-
-	var totalCount int
-	if err := r.db.QueryRow(`SELECT COUNT(*) FROM docs_equipment_details WHERE model ILIKE $1 OR lin ILIKE $1`, searchPattern).Scan(&totalCount); err != nil {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("failed to count search results: %w", err)
-	}
-
-	if len(items) == 0 {
-		return EquipmentDetailsPageResponse{}, fmt.Errorf("no equipment found matching query: %w", ErrNotFound)
-	}
-
-	return buildPageResponse(items, page, totalCount), nil
+	return r.listPage(ctx, `model ILIKE $1 OR lin ILIKE $1`, []any{searchPattern}, params)
 }
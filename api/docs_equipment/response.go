@@ -1,16 +1,5 @@
 package docs_equipment
 
-import "miltechserver/.gen/miltech_ng/public/model"
-
-// EquipmentDetailsPageResponse — paginated equipment list (matches EIC pattern).
-type EquipmentDetailsPageResponse struct {
-	Items      []model.DocsEquipmentDetails `json:"items"`
-	Count      int                          `json:"count"`
-	Page       int                          `json:"page"`
-	TotalPages int                          `json:"total_pages"`
-	IsLastPage bool                         `json:"is_last_page"`
-}
-
 // FamiliesResponse — unique family values from the DB.
 type FamiliesResponse struct {
 	Families []string `json:"families"`
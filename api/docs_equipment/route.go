@@ -2,23 +2,28 @@ package docs_equipment
 
 import (
 	"database/sql"
-	"errors"
 	"log/slog"
 	"net/http"
-	"strconv"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/middleware"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
+	"miltechserver/ratelimit"
+	"miltechserver/storage"
 )
 
+// defaultLimit is the equipment list page size when the client sends no limit.
+const defaultLimit = 40
+
 // Dependencies holds external resources needed by this package.
 type Dependencies struct {
-	DB         *sql.DB
-	BlobClient *azblob.Client
+	DB      *sql.DB
+	Store   storage.ObjectStore
+	Limiter *ratelimit.Limiter
 }
 
 // Handler holds the service dependency.
@@ -29,12 +34,12 @@ type Handler struct {
 // RegisterRoutes wires docs_equipment routes into the public router group.
 func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
 	repo := NewRepository(deps.DB)
-	svc := NewService(repo, deps.BlobClient)
-	registerHandlers(router, svc)
+	svc := NewService(repo, deps.Store)
+	registerHandlers(router, svc, deps.Limiter)
 }
 
 // registerHandlers is the internal wiring function used directly by tests.
-func registerHandlers(router *gin.RouterGroup, svc Service) {
+func registerHandlers(router *gin.RouterGroup, svc Service, limiter *ratelimit.Limiter) {
 	handler := Handler{service: svc}
 
 	// Data endpoints
@@ -46,24 +51,21 @@ func registerHandlers(router *gin.RouterGroup, svc Service) {
 	// Image endpoints
 	router.GET("/equipment-details/images/families", handler.listImageFamilies)
 	router.GET("/equipment-details/images/family/:family", handler.listFamilyImages)
-	router.GET("/equipment-details/images/family/:family/urls", middleware.RateLimiter(), handler.getFamilyImageURLs)
-	router.GET("/equipment-details/images/download", middleware.RateLimiter(), handler.generateImageDownloadURL)
+	downloads := middleware.RateLimit(limiter, middleware.DownloadPolicy, middleware.ByClientIP)
+	router.GET("/equipment-details/images/family/:family/urls", downloads, handler.getFamilyImageURLs)
+	router.GET("/equipment-details/images/download", downloads, handler.generateImageDownloadURL)
 }
 
 func (h *Handler) getAllPaginated(c *gin.Context) {
-	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	data, err := h.service.GetAllPaginated(page)
+	data, err := h.service.GetAllPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
@@ -71,9 +73,9 @@ func (h *Handler) getAllPaginated(c *gin.Context) {
 }
 
 func (h *Handler) getFamilies(c *gin.Context) {
-	data, err := h.service.GetFamilies()
+	data, err := h.service.GetFamilies(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -83,23 +85,19 @@ func (h *Handler) getFamilies(c *gin.Context) {
 func (h *Handler) getByFamily(c *gin.Context) {
 	family := c.Param("family")
 	if strings.TrimSpace(family) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Family parameter is required"})
+		c.Error(apperror.MissingParameter("family"))
 		return
 	}
 
-	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	data, err := h.service.GetByFamilyPaginated(family, page)
+	data, err := h.service.GetByFamilyPaginated(c.Request.Context(), family, params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
@@ -109,23 +107,19 @@ func (h *Handler) getByFamily(c *gin.Context) {
 func (h *Handler) search(c *gin.Context) {
 	q := c.Query("q")
 	if strings.TrimSpace(q) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Search query (q) is required"})
+		c.Error(apperror.MissingParameter("q"))
 		return
 	}
 
-	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	data, err := h.service.SearchPaginated(q, page)
+	data, err := h.service.SearchPaginated(c.Request.Context(), q, params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
@@ -133,13 +127,10 @@ func (h *Handler) search(c *gin.Context) {
 }
 
 func (h *Handler) listImageFamilies(c *gin.Context) {
-	data, err := h.service.ListImageFamilies()
+	data, err := h.service.ListImageFamilies(c.Request.Context())
 	if err != nil {
 		slog.Error("Failed to list image families", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to list image families",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -149,17 +140,14 @@ func (h *Handler) listImageFamilies(c *gin.Context) {
 func (h *Handler) listFamilyImages(c *gin.Context) {
 	family := c.Param("family")
 	if strings.TrimSpace(family) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Family parameter is required"})
+		c.Error(apperror.MissingParameter("family"))
 		return
 	}
 
-	data, err := h.service.ListFamilyImages(family)
+	data, err := h.service.ListFamilyImages(c.Request.Context(), family)
 	if err != nil {
 		slog.Error("Failed to list family images", "error", err, "family", family)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to list images",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -169,21 +157,14 @@ func (h *Handler) listFamilyImages(c *gin.Context) {
 func (h *Handler) getFamilyImageURLs(c *gin.Context) {
 	family := c.Param("family")
 	if strings.TrimSpace(family) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Family parameter is required"})
+		c.Error(apperror.MissingParameter("family"))
 		return
 	}
 
 	data, err := h.service.GetFamilyImageURLs(c.Request.Context(), family)
 	if err != nil {
-		if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Family parameter is required"})
-		} else {
-			slog.Error("Failed to get family image URLs", "error", err, "family", family)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":   "Failed to generate image URLs",
-				"details": err.Error(),
-			})
-		}
+		slog.Error("Failed to get family image URLs", "error", err, "family", family)
+		c.Error(err)
 		return
 	}
 
@@ -195,23 +176,7 @@ func (h *Handler) generateImageDownloadURL(c *gin.Context) {
 
 	result, err := h.service.GenerateImageDownloadURL(c.Request.Context(), blobPath)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrImageNotFound):
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Image not found",
-				"details": "The requested image does not exist",
-			})
-		case errors.Is(err, ErrEmptyBlobPath), errors.Is(err, ErrInvalidBlobPath), errors.Is(err, ErrInvalidFileType):
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid request",
-				"details": err.Error(),
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":   "Failed to generate download URL",
-				"details": err.Error(),
-			})
-		}
+		c.Error(err)
 		return
 	}
 
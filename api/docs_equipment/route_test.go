@@ -9,11 +9,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/middleware"
+	"miltechserver/api/pagination"
 )
 
 // serviceStub implements Service for handler tests.
 type serviceStub struct {
-	pageResp     EquipmentDetailsPageResponse
+	pageResp     pagination.Page[model.DocsEquipmentDetails]
 	familiesResp FamiliesResponse
 	imgFamilies  *ImageFamiliesResponse
 	imgList      *FamilyImagesResponse
@@ -22,22 +26,22 @@ type serviceStub struct {
 	err          error
 }
 
-func (s *serviceStub) GetAllPaginated(page int) (EquipmentDetailsPageResponse, error) {
+func (s *serviceStub) GetAllPaginated(_ context.Context, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
 	return s.pageResp, s.err
 }
-func (s *serviceStub) GetFamilies() (FamiliesResponse, error) {
+func (s *serviceStub) GetFamilies(_ context.Context) (FamiliesResponse, error) {
 	return s.familiesResp, s.err
 }
-func (s *serviceStub) GetByFamilyPaginated(family string, page int) (EquipmentDetailsPageResponse, error) {
+func (s *serviceStub) GetByFamilyPaginated(_ context.Context, family string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
 	return s.pageResp, s.err
 }
-func (s *serviceStub) SearchPaginated(query string, page int) (EquipmentDetailsPageResponse, error) {
+func (s *serviceStub) SearchPaginated(_ context.Context, query string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
 	return s.pageResp, s.err
 }
-func (s *serviceStub) ListImageFamilies() (*ImageFamiliesResponse, error) {
+func (s *serviceStub) ListImageFamilies(_ context.Context) (*ImageFamiliesResponse, error) {
 	return s.imgFamilies, s.err
 }
-func (s *serviceStub) ListFamilyImages(family string) (*FamilyImagesResponse, error) {
+func (s *serviceStub) ListFamilyImages(_ context.Context, family string) (*FamilyImagesResponse, error) {
 	return s.imgList, s.err
 }
 func (s *serviceStub) GenerateImageDownloadURL(_ context.Context, _ string) (*ImageDownloadResponse, error) {
@@ -50,7 +54,8 @@ func (s *serviceStub) GetFamilyImageURLs(_ context.Context, _ string) (*FamilyIm
 func newTestRouter(stub *serviceStub) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	registerHandlers(router.Group("/api/v1"), stub)
+	router.Use(middleware.ErrorHandler)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 	return router
 }
 
@@ -62,30 +67,30 @@ func doRequest(router *gin.Engine, method, path string) *httptest.ResponseRecord
 }
 
 func TestGetAllPaginatedSuccess(t *testing.T) {
-	stub := &serviceStub{pageResp: EquipmentDetailsPageResponse{Count: 40, Page: 1}}
-	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details?page=1")
+	stub := &serviceStub{pageResp: pagination.Page[model.DocsEquipmentDetails]{Items: []model.DocsEquipmentDetails{}}}
+	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details")
 	require.Equal(t, http.StatusOK, resp.Code)
 }
 
-func TestGetAllPaginatedInvalidPage(t *testing.T) {
+func TestGetAllPaginatedInvalidLimit(t *testing.T) {
 	stub := &serviceStub{}
-	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details?page=0")
+	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details?limit=0")
 	require.Equal(t, http.StatusBadRequest, resp.Code)
 
-	resp = doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details?page=abc")
+	resp = doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details?limit=abc")
 	require.Equal(t, http.StatusBadRequest, resp.Code)
 }
 
 func TestGetAllPaginatedError(t *testing.T) {
 	stub := &serviceStub{err: errors.New("db down")}
-	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details?page=1")
+	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details")
 	require.Equal(t, http.StatusInternalServerError, resp.Code)
 }
 
-func TestGetAllPaginatedNotFound(t *testing.T) {
-	stub := &serviceStub{err: ErrNotFound}
-	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details?page=1")
-	require.Equal(t, http.StatusNotFound, resp.Code)
+func TestGetAllPaginatedInvalidCursor(t *testing.T) {
+	stub := &serviceStub{err: pagination.ErrInvalidCursor}
+	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details?cursor=bad")
+	require.Equal(t, http.StatusBadRequest, resp.Code)
 }
 
 func TestGetFamiliesSuccess(t *testing.T) {
@@ -101,14 +106,14 @@ func TestGetFamiliesError(t *testing.T) {
 }
 
 func TestGetByFamilySuccess(t *testing.T) {
-	stub := &serviceStub{pageResp: EquipmentDetailsPageResponse{Count: 5, Page: 1}}
-	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details/family/aircraft?page=1")
+	stub := &serviceStub{pageResp: pagination.Page[model.DocsEquipmentDetails]{Items: []model.DocsEquipmentDetails{}}}
+	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details/family/aircraft")
 	require.Equal(t, http.StatusOK, resp.Code)
 }
 
-func TestGetByFamilyInvalidPage(t *testing.T) {
+func TestGetByFamilyInvalidLimit(t *testing.T) {
 	stub := &serviceStub{}
-	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details/family/aircraft?page=abc")
+	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details/family/aircraft?limit=abc")
 	require.Equal(t, http.StatusBadRequest, resp.Code)
 }
 
@@ -119,17 +124,11 @@ func TestSearchMissingQuery(t *testing.T) {
 }
 
 func TestSearchSuccess(t *testing.T) {
-	stub := &serviceStub{pageResp: EquipmentDetailsPageResponse{Count: 2, Page: 1}}
-	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details/search?q=AH-64&page=1")
+	stub := &serviceStub{pageResp: pagination.Page[model.DocsEquipmentDetails]{Items: []model.DocsEquipmentDetails{}}}
+	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details/search?q=AH-64")
 	require.Equal(t, http.StatusOK, resp.Code)
 }
 
-func TestSearchNotFound(t *testing.T) {
-	stub := &serviceStub{err: ErrNotFound}
-	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details/search?q=NOTHING&page=1")
-	require.Equal(t, http.StatusNotFound, resp.Code)
-}
-
 func TestListImageFamiliesSuccess(t *testing.T) {
 	stub := &serviceStub{imgFamilies: &ImageFamiliesResponse{Count: 1}}
 	resp := doRequest(newTestRouter(stub), http.MethodGet, "/api/v1/equipment-details/images/families")
@@ -1,18 +1,23 @@
 package docs_equipment
 
-import "context"
+import (
+	"context"
+
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+)
 
 // Service provides methods for equipment details data and image operations.
 type Service interface {
 	// DB operations
-	GetAllPaginated(page int) (EquipmentDetailsPageResponse, error)
-	GetFamilies() (FamiliesResponse, error)
-	GetByFamilyPaginated(family string, page int) (EquipmentDetailsPageResponse, error)
-	SearchPaginated(query string, page int) (EquipmentDetailsPageResponse, error)
+	GetAllPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error)
+	GetFamilies(ctx context.Context) (FamiliesResponse, error)
+	GetByFamilyPaginated(ctx context.Context, family string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error)
+	SearchPaginated(ctx context.Context, query string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error)
 
 	// Blob operations
-	ListImageFamilies() (*ImageFamiliesResponse, error)
-	ListFamilyImages(family string) (*FamilyImagesResponse, error)
+	ListImageFamilies(ctx context.Context) (*ImageFamiliesResponse, error)
+	ListFamilyImages(ctx context.Context, family string) (*FamilyImagesResponse, error)
 	GetFamilyImageURLs(ctx context.Context, family string) (*FamilyImageURLsResponse, error)
 	GenerateImageDownloadURL(ctx context.Context, blobPath string) (*ImageDownloadResponse, error)
 }
@@ -8,17 +8,15 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
-
-	"miltechserver/api/library/shared"
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+	"miltechserver/storage"
 )
 
 const (
-	containerName = "library"
-	imagePrefix   = "docs_equipment/images/"
+	containerName  = "library"
+	imagePrefix    = "docs_equipment/images/"
+	downloadURLTTL = 1 * time.Hour
 )
 
 var allowedImageExts = map[string]bool{
@@ -26,28 +24,28 @@ var allowedImageExts = map[string]bool{
 }
 
 type serviceImpl struct {
-	repo       Repository
-	blobClient *azblob.Client
+	repo  Repository
+	store storage.ObjectStore
 }
 
-func NewService(repo Repository, blobClient *azblob.Client) Service {
-	return &serviceImpl{repo: repo, blobClient: blobClient}
+func NewService(repo Repository, store storage.ObjectStore) Service {
+	return &serviceImpl{repo: repo, store: store}
 }
 
-func (s *serviceImpl) GetAllPaginated(page int) (EquipmentDetailsPageResponse, error) {
-	return s.repo.GetAllPaginated(page)
+func (s *serviceImpl) GetAllPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
+	return s.repo.GetAllPaginated(ctx, params)
 }
 
-func (s *serviceImpl) GetFamilies() (FamiliesResponse, error) {
-	return s.repo.GetFamilies()
+func (s *serviceImpl) GetFamilies(ctx context.Context) (FamiliesResponse, error) {
+	return s.repo.GetFamilies(ctx)
 }
 
-func (s *serviceImpl) GetByFamilyPaginated(family string, page int) (EquipmentDetailsPageResponse, error) {
-	return s.repo.GetByFamilyPaginated(strings.TrimSpace(family), page)
+func (s *serviceImpl) GetByFamilyPaginated(ctx context.Context, family string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
+	return s.repo.GetByFamilyPaginated(ctx, strings.TrimSpace(family), params)
 }
 
-func (s *serviceImpl) SearchPaginated(query string, page int) (EquipmentDetailsPageResponse, error) {
-	return s.repo.SearchPaginated(strings.TrimSpace(query), page)
+func (s *serviceImpl) SearchPaginated(ctx context.Context, query string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
+	return s.repo.SearchPaginated(ctx, strings.TrimSpace(query), params)
 }
 
 func isImageFile(name string) bool {
@@ -55,85 +53,59 @@ func isImageFile(name string) bool {
 	return allowedImageExts[ext]
 }
 
-func (s *serviceImpl) ListImageFamilies() (*ImageFamiliesResponse, error) {
-	ctx := context.Background()
-	containerClient := s.blobClient.ServiceClient().NewContainerClient(containerName)
-	prefix := imagePrefix
-	pager := containerClient.NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{
-		Prefix: &prefix,
-	})
+func (s *serviceImpl) ListImageFamilies(ctx context.Context) (*ImageFamiliesResponse, error) {
+	prefixes, err := s.store.ListPrefixes(ctx, containerName, imagePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	}
 
 	var families []ImageFamilyFolder
-	for pager.More() {
-		page, err := pager.NextPage(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
-		}
-		for _, p := range page.Segment.BlobPrefixes {
-			if p.Name == nil {
-				continue
-			}
-			fullPath := *p.Name
-			name := strings.TrimPrefix(fullPath, imagePrefix)
-			name = strings.TrimSuffix(name, "/")
-			if name == "" {
-				continue
-			}
-			displayName := strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(name, "-", " "), "_", " "))
-			families = append(families, ImageFamilyFolder{
-				Name:        name,
-				FullPath:    fullPath,
-				DisplayName: displayName,
-			})
+	for _, fullPath := range prefixes {
+		name := strings.TrimPrefix(fullPath, imagePrefix)
+		name = strings.TrimSuffix(name, "/")
+		if name == "" {
+			continue
 		}
+		displayName := strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(name, "-", " "), "_", " "))
+		families = append(families, ImageFamilyFolder{
+			Name:        name,
+			FullPath:    fullPath,
+			DisplayName: displayName,
+		})
 	}
 
 	return &ImageFamiliesResponse{Families: families, Count: len(families)}, nil
 }
 
-func (s *serviceImpl) ListFamilyImages(family string) (*FamilyImagesResponse, error) {
+func (s *serviceImpl) ListFamilyImages(ctx context.Context, family string) (*FamilyImagesResponse, error) {
 	if strings.TrimSpace(family) == "" {
 		return nil, ErrEmptyParam
 	}
-	ctx := context.Background()
-	containerClient := s.blobClient.ServiceClient().NewContainerClient(containerName)
 	prefix := imagePrefix + strings.TrimSpace(family) + "/"
-	pager := containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
-		Prefix: &prefix,
-	})
+	blobs, err := s.store.List(ctx, containerName, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	}
 
 	var images []ImageItem
-	for pager.More() {
-		pg, err := pager.NextPage(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	for _, blob := range blobs {
+		blobPath := blob.Name
+		parts := strings.Split(blobPath, "/")
+		fileName := parts[len(parts)-1]
+		if !isImageFile(fileName) {
+			slog.Debug("Skipping non-image blob", "blobPath", blobPath)
+			continue
 		}
-		for _, blob := range pg.Segment.BlobItems {
-			if blob.Name == nil {
-				continue
-			}
-			blobPath := *blob.Name
-			parts := strings.Split(blobPath, "/")
-			fileName := parts[len(parts)-1]
-			if !isImageFile(fileName) {
-				slog.Debug("Skipping non-image blob", "blobPath", blobPath)
-				continue
-			}
-			var sizeBytes int64
-			if blob.Properties != nil && blob.Properties.ContentLength != nil {
-				sizeBytes = *blob.Properties.ContentLength
-			}
-			var lastModified string
-			if blob.Properties != nil && blob.Properties.LastModified != nil {
-				lastModified = blob.Properties.LastModified.Format(time.RFC3339)
-			}
-			images = append(images, ImageItem{
-				Name:         fileName,
-				BlobPath:     blobPath,
-				SizeBytes:    sizeBytes,
-				LastModified: lastModified,
-			})
+		var lastModified string
+		if !blob.LastModified.IsZero() {
+			lastModified = blob.LastModified.Format(time.RFC3339)
 		}
+		images = append(images, ImageItem{
+			Name:         fileName,
+			BlobPath:     blobPath,
+			SizeBytes:    blob.Size,
+			LastModified: lastModified,
+		})
 	}
 
 	return &FamilyImagesResponse{
@@ -143,9 +115,9 @@ func (s *serviceImpl) ListFamilyImages(family string) (*FamilyImagesResponse, er
 	}, nil
 }
 
-// GetFamilyImageURLs lists all images in a family folder and generates SAS download
-// URLs for every image in a single call. It fetches one User Delegation Key from
-// Azure AD and signs all blob paths locally, avoiding the N+1 credential call that
+// GetFamilyImageURLs lists all images in a family folder and generates signed download
+// URLs for every image in a single call. The Azure store caches its User Delegation
+// Key, so signing every blob path costs one Azure AD call rather than the N+1 that
 // would result from calling GenerateImageDownloadURL per image.
 func (s *serviceImpl) GetFamilyImageURLs(ctx context.Context, family string) (*FamilyImageURLsResponse, error) {
 	family = strings.TrimSpace(family)
@@ -153,8 +125,8 @@ func (s *serviceImpl) GetFamilyImageURLs(ctx context.Context, family string) (*F
 		return nil, ErrEmptyParam
 	}
 
-	// Step 1: List all image blobs in the family folder (one Azure Storage call).
-	imageList, err := s.ListFamilyImages(family)
+	// Step 1: List all image blobs in the family folder (one storage call).
+	imageList, err := s.ListFamilyImages(ctx, family)
 	if err != nil {
 		return nil, err
 	}
@@ -168,51 +140,28 @@ func (s *serviceImpl) GetFamilyImageURLs(ctx context.Context, family string) (*F
 		}, nil
 	}
 
-	// Step 2: Obtain a single User Delegation Key (one Azure AD call).
-	expiresAt := time.Now().UTC().Add(1 * time.Hour)
-	svcClient := s.blobClient.ServiceClient()
-
-	strPtr := func(s string) *string { return &s }
-	udk, err := svcClient.GetUserDelegationCredential(
-		ctx,
-		service.KeyInfo{
-			Start:  strPtr(time.Now().UTC().Add(-15 * time.Minute).Format(time.RFC3339)),
-			Expiry: strPtr(expiresAt.Format(time.RFC3339)),
-		},
-		nil,
-	)
-	if err != nil {
-		slog.Error("Failed to get user delegation credential for batch signing",
-			"family", family, "error", err)
-		return nil, fmt.Errorf("%w: %v", ErrSASGenFailed, err)
-	}
-
-	// Step 3: Sign each blob path locally (pure CPU, no network calls).
-	permissions := sas.BlobPermissions{Read: true}
+	// Step 2: Sign each blob path. Only the first signature may need a network call.
+	expiresAt := time.Now().UTC().Add(downloadURLTTL)
 	items := make([]ImageURLItem, 0, len(imageList.Images))
 
-	for _, img := range imageList.Images {
-		bc := svcClient.NewContainerClient(containerName).NewBlobClient(img.BlobPath)
-
-		sasQueryParams, signErr := sas.BlobSignatureValues{
-			Protocol:      sas.ProtocolHTTPS,
-			ExpiryTime:    expiresAt,
-			Permissions:   permissions.String(),
-			ContainerName: containerName,
-			BlobName:      img.BlobPath,
-		}.SignWithUserDelegation(udk)
+	for i, img := range imageList.Images {
+		signed, signErr := s.store.SignedURL(ctx, containerName, img.BlobPath, downloadURLTTL)
 		if signErr != nil {
+			if i == 0 {
+				slog.Error("Failed to sign first image URL for batch signing",
+					"family", family, "error", signErr)
+				return nil, fmt.Errorf("%w: %v", ErrSASGenFailed, signErr)
+			}
 			slog.Warn("Failed to sign SAS for image, skipping",
 				"blobPath", img.BlobPath, "error", signErr)
 			continue
 		}
-
-		downloadURL := fmt.Sprintf("%s?%s", bc.URL(), sasQueryParams.Encode())
+		expiresAt = signed.ExpiresAt
 
 		items = append(items, ImageURLItem{
 			Name:         img.Name,
 			BlobPath:     img.BlobPath,
-			DownloadURL:  downloadURL,
+			DownloadURL:  signed.URL,
 			SizeBytes:    img.SizeBytes,
 			LastModified: img.LastModified,
 		})
@@ -238,13 +187,12 @@ func (s *serviceImpl) GenerateImageDownloadURL(ctx context.Context, blobPath str
 		return nil, ErrInvalidFileType
 	}
 
-	blobClient := s.blobClient.ServiceClient().NewContainerClient(containerName).NewBlobClient(blobPath)
-	if _, err := blobClient.GetProperties(ctx, nil); err != nil {
+	if _, err := s.store.Stat(ctx, containerName, blobPath); err != nil {
 		slog.Error("Equipment image blob not found", "blobPath", blobPath, "error", err)
 		return nil, fmt.Errorf("%w: %v", ErrImageNotFound, err)
 	}
 
-	sasResult, err := shared.GenerateBlobSASURL(ctx, s.blobClient, containerName, blobPath)
+	sasResult, err := s.store.SignedURL(ctx, containerName, blobPath, downloadURLTTL)
 	if err != nil {
 		slog.Error("Failed to generate SAS for equipment image", "blobPath", blobPath, "error", err)
 		return nil, fmt.Errorf("%w: %v", ErrSASGenFailed, err)
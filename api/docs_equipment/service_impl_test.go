@@ -1,9 +1,13 @@
 package docs_equipment
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -11,32 +15,32 @@ import (
 type captureRepo struct {
 	family string
 	query  string
-	page   int
+	params pagination.Params
 }
 
-func (r *captureRepo) GetAllPaginated(page int) (EquipmentDetailsPageResponse, error) {
-	r.page = page
-	return EquipmentDetailsPageResponse{}, nil
+func (r *captureRepo) GetAllPaginated(_ context.Context, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
+	r.params = params
+	return pagination.Page[model.DocsEquipmentDetails]{}, nil
 }
-func (r *captureRepo) GetFamilies() (FamiliesResponse, error) {
+func (r *captureRepo) GetFamilies(_ context.Context) (FamiliesResponse, error) {
 	return FamiliesResponse{Families: []string{"aircraft"}, Count: 1}, nil
 }
-func (r *captureRepo) GetByFamilyPaginated(family string, page int) (EquipmentDetailsPageResponse, error) {
+func (r *captureRepo) GetByFamilyPaginated(_ context.Context, family string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
 	r.family = family
-	r.page = page
-	return EquipmentDetailsPageResponse{}, nil
+	r.params = params
+	return pagination.Page[model.DocsEquipmentDetails]{}, nil
 }
-func (r *captureRepo) SearchPaginated(query string, page int) (EquipmentDetailsPageResponse, error) {
+func (r *captureRepo) SearchPaginated(_ context.Context, query string, params pagination.Params) (pagination.Page[model.DocsEquipmentDetails], error) {
 	r.query = query
-	r.page = page
-	return EquipmentDetailsPageResponse{}, nil
+	r.params = params
+	return pagination.Page[model.DocsEquipmentDetails]{}, nil
 }
 
 func TestServiceTrimsFamily(t *testing.T) {
 	repo := &captureRepo{}
 	svc := NewService(repo, nil)
 
-	_, err := svc.GetByFamilyPaginated("  aircraft  ", 1)
+	_, err := svc.GetByFamilyPaginated(context.Background(), "  aircraft  ", pagination.Params{Limit: 40})
 	require.NoError(t, err)
 	require.Equal(t, "aircraft", repo.family)
 }
@@ -45,19 +49,19 @@ func TestServiceTrimsSearch(t *testing.T) {
 	repo := &captureRepo{}
 	svc := NewService(repo, nil)
 
-	_, err := svc.SearchPaginated("  AH-64  ", 2)
+	_, err := svc.SearchPaginated(context.Background(), "  AH-64  ", pagination.Params{Cursor: "abc", Limit: 40})
 	require.NoError(t, err)
 	require.Equal(t, strings.TrimSpace("  AH-64  "), repo.query)
-	require.Equal(t, 2, repo.page)
+	require.Equal(t, "abc", repo.params.Cursor)
 }
 
 func TestServiceDelegatesGetAll(t *testing.T) {
 	repo := &captureRepo{}
 	svc := NewService(repo, nil)
 
-	_, err := svc.GetAllPaginated(3)
+	_, err := svc.GetAllPaginated(context.Background(), pagination.Params{Limit: 3, IncludeTotal: true})
 	require.NoError(t, err)
-	require.Equal(t, 3, repo.page)
+	require.Equal(t, pagination.Params{Limit: 3, IncludeTotal: true}, repo.params)
 }
 
 func TestIsImageFile(t *testing.T) {
@@ -0,0 +1,45 @@
+package eic
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "EIC",
+	Operations: []openapi.Operation{
+		{
+			ID:       "lookupEICByNIIN",
+			Method:   http.MethodGet,
+			Path:     "/eic/niin/:niin",
+			Summary:  "Look up EIC records by NIIN",
+			Response: response.EICSearchResponse{},
+		},
+		{
+			ID:       "lookupEICByLIN",
+			Method:   http.MethodGet,
+			Path:     "/eic/lin/:lin",
+			Summary:  "Look up EIC records by LIN",
+			Response: response.EICSearchResponse{},
+		},
+		{
+			ID:       "lookupEICByFSC",
+			Method:   http.MethodGet,
+			Path:     "/eic/fsc/:fsc",
+			Summary:  "Look up EIC records by FSC",
+			Query:    pagination.Query,
+			Response: pagination.Page[response.EICConsolidatedItem]{},
+		},
+		{
+			ID:       "listEIC",
+			Method:   http.MethodGet,
+			Path:     "/eic/items",
+			Summary:  "List EIC records",
+			Query:    append([]openapi.Param{{Name: "search"}}, pagination.Query...),
+			Response: pagination.Page[response.EICConsolidatedItem]{},
+		},
+	},
+}}
@@ -1,9 +1,8 @@
 package eic
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrNotFound    = errors.New("no EIC items found")
-	ErrEmptyParam  = errors.New("required parameter is empty")
-	ErrInvalidPage = errors.New("page number must be greater than 0")
+	ErrNotFound   = apperror.NotFound("eic_items_not_found", "no EIC items found")
+	ErrEmptyParam = apperror.Validation("required_parameter_empty", "required parameter is empty")
 )
@@ -1,9 +1,8 @@
 package eic
 
-func selectColumns() string {
-	return `
-SELECT
-	inc, fsc, niin, eic, lin, nomen, model, eicc, ecc, cmdtycd, reported, dahr,
+// consolidatedColumns are the columns a consolidated item is grouped by. Rows
+// that differ only in uoeic and mrc collapse into one item.
+const consolidatedColumns = `inc, fsc, niin, eic, lin, nomen, model, eicc, ecc, cmdtycd, reported, dahr,
 	publvl1, pubno1, pubdate1, pubchg1, pubcgdt1,
 	publcl2, pubno2, pubdate2, pubchg2, pubcgdt2,
 	publvl3, pubno3, pubdate3, pubchg3, pubcgdt3,
@@ -16,7 +15,16 @@ SELECT
 	wpnrec, sernotrk, orf, aoap, gainloss, usage, urm1, urm2,
 	uom1, uom2, uom3, mau1, uom4, mau2,
 	warranty, rbm, sos, erc, eslvl, oslin, lcc, nounabb,
-	curfmc, prevfmc, bstat1, bstat2, matcat, itemmgr, eos, sorts, status, lst_updt,
+	curfmc, prevfmc, bstat1, bstat2, matcat, itemmgr, eos, sorts, status, lst_updt`
+
+// groupKey is unique per consolidated item within a NIIN, which makes
+// (niin, groupKey) the sort key of paginated lists.
+const groupKey = `md5(ROW(` + consolidatedColumns + `)::text)`
+
+func selectColumns() string {
+	return `
+SELECT
+	` + consolidatedColumns + `,
 	array_agg(DISTINCT uoeic ORDER BY uoeic) as uoeic_array,
 	array_agg(DISTINCT mrc ORDER BY mrc) as mrc_array,
 	COUNT(*) as variant_count
@@ -24,21 +32,22 @@ FROM eic
 `
 }
 
+// selectPageColumns is selectColumns with the groupKey of each item as an
+// extra, last column.
+func selectPageColumns() string {
+	return `
+SELECT
+	` + consolidatedColumns + `,
+	array_agg(DISTINCT uoeic ORDER BY uoeic) as uoeic_array,
+	array_agg(DISTINCT mrc ORDER BY mrc) as mrc_array,
+	COUNT(*) as variant_count,
+	` + groupKey + ` as group_key
+FROM eic
+`
+}
+
 func groupByColumns() string {
 	return `
-GROUP BY inc, fsc, niin, eic, lin, nomen, model, eicc, ecc, cmdtycd, reported, dahr,
-	publvl1, pubno1, pubdate1, pubchg1, pubcgdt1,
-	publcl2, pubno2, pubdate2, pubchg2, pubcgdt2,
-	publvl3, pubno3, pubdate3, pubchg3, pubcgdt3,
-	publvl4, pubno4, pubdate4, pubchg4, pubcgdt4,
-	publvl5, pubno5, pubdate5, pubchg5, pubcgdt5,
-	publvl6, pubno6, pubdate6, pubchg6, pubcgdt6,
-	publvl7, pubno7, pubdate7, pubchg7, pubcgdt7,
-	pubremks, eqpmcsa, eqpmcsb, eqpmcsc, eqpmcsd, eqpmcse, eqpmcsf,
-	eqpmcsg, eqpmcsh, eqpmcsi, eqpmcsj, eqpmcsk, eqpmcsl,
-	wpnrec, sernotrk, orf, aoap, gainloss, usage, urm1, urm2,
-	uom1, uom2, uom3, mau1, uom4, mau2,
-	warranty, rbm, sos, erc, eslvl, oslin, lcc, nounabb,
-	curfmc, prevfmc, bstat1, bstat2, matcat, itemmgr, eos, sorts, status, lst_updt
+GROUP BY ` + consolidatedColumns + `
 `
 }
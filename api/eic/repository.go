@@ -1,10 +1,14 @@
 package eic
 
-import "miltechserver/api/response"
+import (
+	"context"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+)
 
 type Repository interface {
-	GetByNIIN(niin string) ([]response.EICConsolidatedItem, error)
-	GetByLIN(lin string) ([]response.EICConsolidatedItem, error)
-	GetByFSCPaginated(fsc string, page int) (response.EICPageResponse, error)
-	GetAllPaginated(page int, search string) (response.EICPageResponse, error)
+	GetByNIIN(ctx context.Context, niin string) ([]response.EICConsolidatedItem, error)
+	GetByLIN(ctx context.Context, lin string) ([]response.EICConsolidatedItem, error)
+	GetByFSCPaginated(ctx context.Context, fsc string, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error)
+	GetAllPaginated(ctx context.Context, search string, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error)
 }
@@ -1,17 +1,17 @@
 package eic
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	"math"
+	"slices"
 	"strings"
 
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 )
 
-const eicReturnCount = int64(40)
-
 type repository struct {
 	db *sql.DB
 }
@@ -20,7 +20,7 @@ func NewRepository(db *sql.DB) Repository {
 	return &repository{db: db}
 }
 
-func (repo *repository) GetByNIIN(niin string) ([]response.EICConsolidatedItem, error) {
+func (repo *repository) GetByNIIN(ctx context.Context, niin string) ([]response.EICConsolidatedItem, error) {
 	if strings.TrimSpace(niin) == "" {
 		return nil, ErrEmptyParam
 	}
@@ -30,7 +30,7 @@ func (repo *repository) GetByNIIN(niin string) ([]response.EICConsolidatedItem,
 WHERE niin = $1
 ` + groupByColumns()
 
-	rows, err := repo.db.Query(query, strings.TrimSpace(niin))
+	rows, err := repo.db.QueryContext(ctx, query, strings.TrimSpace(niin))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query consolidated EIC data by NIIN: %w", err)
 	}
@@ -55,7 +55,7 @@ WHERE niin = $1
 	return consolidatedData, nil
 }
 
-func (repo *repository) GetByLIN(lin string) ([]response.EICConsolidatedItem, error) {
+func (repo *repository) GetByLIN(ctx context.Context, lin string) ([]response.EICConsolidatedItem, error) {
 	if strings.TrimSpace(lin) == "" {
 		return nil, ErrEmptyParam
 	}
@@ -65,7 +65,7 @@ func (repo *repository) GetByLIN(lin string) ([]response.EICConsolidatedItem, er
 WHERE lin = $1
 ` + groupByColumns()
 
-	rows, err := repo.db.Query(query, strings.TrimSpace(lin))
+	rows, err := repo.db.QueryContext(ctx, query, strings.TrimSpace(lin))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query consolidated EIC data by LIN: %w", err)
 	}
@@ -90,152 +90,121 @@ WHERE lin = $1
 	return consolidatedData, nil
 }
 
-func (repo *repository) GetByFSCPaginated(fsc string, page int) (response.EICPageResponse, error) {
+func (repo *repository) GetByFSCPaginated(ctx context.Context, fsc string, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error) {
 	if strings.TrimSpace(fsc) == "" {
-		return response.EICPageResponse{}, ErrEmptyParam
-	}
-
-	if page < 1 {
-		return response.EICPageResponse{}, ErrInvalidPage
+		return pagination.Page[response.EICConsolidatedItem]{}, ErrEmptyParam
 	}
 
-	var consolidatedData []response.EICConsolidatedItem
-	offset := eicReturnCount * int64(page-1)
-
-	query := selectColumns() + `
-WHERE fsc = $1
-` + groupByColumns() + `
-LIMIT $2 OFFSET $3
-`
-
-	rows, err := repo.db.Query(query, strings.TrimSpace(fsc), eicReturnCount, offset)
+	page, err := repo.listPage(ctx, `fsc = $1`, []any{strings.TrimSpace(fsc)}, params)
 	if err != nil {
-		return response.EICPageResponse{}, fmt.Errorf("failed to query consolidated EIC data by FSC: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		item, err := scanConsolidatedItem(rows)
-		if err != nil {
-			return response.EICPageResponse{}, fmt.Errorf("failed to scan consolidated EIC data: %w", err)
-		}
-		consolidatedData = append(consolidatedData, item)
-	}
-
-	if err := rows.Err(); err != nil {
-		return response.EICPageResponse{}, fmt.Errorf("failed to query consolidated EIC data by FSC: %w", err)
+		return pagination.Page[response.EICConsolidatedItem]{}, fmt.Errorf("failed to list consolidated EIC data by FSC: %w", err)
 	}
+	return page, nil
+}
 
-	countQuery := `
-SELECT COUNT(*) FROM (
-	SELECT 1
-	FROM eic
-	WHERE fsc = $1
-` + groupByColumns() + `
-) AS consolidated_count
-`
+func (repo *repository) GetAllPaginated(ctx context.Context, search string, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error) {
+	searchTerm := strings.TrimSpace(search)
 
-	var totalCount int
-	if err := repo.db.QueryRow(countQuery, strings.TrimSpace(fsc)).Scan(&totalCount); err != nil {
-		return response.EICPageResponse{}, fmt.Errorf("failed to get total consolidated count for FSC: %w", err)
+	var whereClause string
+	var args []any
+	if searchTerm != "" {
+		whereClause = `niin ILIKE $1
+		OR lin ILIKE $1
+		OR fsc ILIKE $1
+		OR nomen ILIKE $1
+		OR model ILIKE $1
+		OR eic ILIKE $1
+		OR EXISTS (SELECT 1 FROM unnest(array_agg(DISTINCT uoeic)) AS u(val) WHERE u.val ILIKE $1)`
+		args = append(args, "%"+searchTerm+"%")
 	}
 
-	if len(consolidatedData) == 0 {
-		return response.EICPageResponse{}, fmt.Errorf("no EIC items found for the specified FSC: %w", ErrNotFound)
+	page, err := repo.listPage(ctx, whereClause, args, params)
+	if err != nil {
+		return pagination.Page[response.EICConsolidatedItem]{}, fmt.Errorf("failed to list consolidated EIC data: %w", err)
 	}
+	return page, nil
+}
 
-	totalPages := int(math.Ceil(float64(totalCount) / float64(eicReturnCount)))
-	return response.EICPageResponse{
-		Items:      consolidatedData,
-		Count:      len(consolidatedData),
-		Page:       page,
-		TotalPages: totalPages,
-		IsLastPage: page >= totalPages,
-	}, nil
+// pageItem is a consolidated item with the groupKey it is paginated by.
+type pageItem struct {
+	item     response.EICConsolidatedItem
+	groupKey string
 }
 
-func (repo *repository) GetAllPaginated(page int, search string) (response.EICPageResponse, error) {
-	if page < 1 {
-		return response.EICPageResponse{}, ErrInvalidPage
+// listPage reads the page of consolidated items matching where that follows
+// params.Cursor. where may be empty; args are its arguments, numbered from $1.
+func (repo *repository) listPage(ctx context.Context, where string, args []any, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error) {
+	var afterNIIN, afterGroupKey string
+	hasCursor, err := params.After(&afterNIIN, &afterGroupKey)
+	if err != nil {
+		return pagination.Page[response.EICConsolidatedItem]{}, err
 	}
 
-	var consolidatedData []response.EICConsolidatedItem
-	offset := eicReturnCount * int64(page-1)
-	searchTerm := strings.TrimSpace(search)
-
-	var whereClause string
-	var args []interface{}
-	argIndex := 1
-
-	if searchTerm != "" {
-		searchPattern := "%" + searchTerm + "%"
-		whereClause = `WHERE niin ILIKE $` + fmt.Sprintf("%d", argIndex) + `
-		OR lin ILIKE $` + fmt.Sprintf("%d", argIndex) + `
-		OR fsc ILIKE $` + fmt.Sprintf("%d", argIndex) + `
-		OR nomen ILIKE $` + fmt.Sprintf("%d", argIndex) + `
-		OR model ILIKE $` + fmt.Sprintf("%d", argIndex) + `
-		OR eic ILIKE $` + fmt.Sprintf("%d", argIndex) + `
-		OR EXISTS (SELECT 1 FROM unnest(array_agg(DISTINCT uoeic)) AS u(val) WHERE u.val ILIKE $` + fmt.Sprintf("%d", argIndex) + `)`
-		args = append(args, searchPattern)
-		argIndex++
+	var filter string
+	if where != "" {
+		filter = "WHERE (" + where + ")"
 	}
 
-	query := selectColumns() + `
-` + whereClause + `
-` + groupByColumns() + `
-LIMIT $` + fmt.Sprintf("%d", argIndex) + ` OFFSET $` + fmt.Sprintf("%d", argIndex+1)
+	whereClause, having := filter, ""
+	queryArgs := slices.Clone(args)
+	if hasCursor {
+		queryArgs = append(queryArgs, afterNIIN, afterGroupKey)
+		niinArg, groupKeyArg := len(queryArgs)-1, len(queryArgs)
+		// The WHERE bound lets the niin index skip earlier items before grouping.
+		bound := fmt.Sprintf("niin >= $%d", niinArg)
+		if whereClause == "" {
+			whereClause = "WHERE " + bound
+		} else {
+			whereClause += " AND " + bound
+		}
+		having = fmt.Sprintf("HAVING (niin, %s) > ($%d, $%d)\n", groupKey, niinArg, groupKeyArg)
+	}
+	queryArgs = append(queryArgs, params.Fetch())
 
-	args = append(args, eicReturnCount, offset)
+	query := selectPageColumns() + whereClause + groupByColumns() + having +
+		fmt.Sprintf("ORDER BY niin, group_key\nLIMIT $%d\n", len(queryArgs))
 
-	rows, err := repo.db.Query(query, args...)
+	rows, err := repo.db.QueryContext(ctx, query, queryArgs...)
 	if err != nil {
-		return response.EICPageResponse{}, fmt.Errorf("failed to query consolidated EIC data: %w", err)
+		return pagination.Page[response.EICConsolidatedItem]{}, err
 	}
 	defer rows.Close()
 
+	var items []pageItem
 	for rows.Next() {
-		item, err := scanConsolidatedItem(rows)
+		var row pageItem
+		row.item, err = scanConsolidatedItem(rows, &row.groupKey)
 		if err != nil {
-			return response.EICPageResponse{}, fmt.Errorf("failed to scan consolidated EIC data: %w", err)
+			return pagination.Page[response.EICConsolidatedItem]{}, fmt.Errorf("failed to scan consolidated EIC data: %w", err)
 		}
-		consolidatedData = append(consolidatedData, item)
+		items = append(items, row)
 	}
-
 	if err := rows.Err(); err != nil {
-		return response.EICPageResponse{}, fmt.Errorf("failed to query consolidated EIC data: %w", err)
+		return pagination.Page[response.EICConsolidatedItem]{}, err
+	}
+
+	page := pagination.Map(pagination.NewPage(items, params, func(row pageItem) []any {
+		return []any{row.item.Niin, row.groupKey}
+	}), func(row pageItem) response.EICConsolidatedItem {
+		return row.item
+	})
+	if !params.IncludeTotal {
+		return page, nil
 	}
 
 	countQuery := `
 SELECT COUNT(*) FROM (
 	SELECT 1
 	FROM eic
-	` + whereClause + `
+	` + filter + `
 ` + groupByColumns() + `
 ) AS consolidated_count
 `
-
-	var countArgs []interface{}
-	if searchTerm != "" {
-		countArgs = []interface{}{searchTerm}
-	}
-
 	var totalCount int
-	if err := repo.db.QueryRow(countQuery, countArgs...).Scan(&totalCount); err != nil {
-		return response.EICPageResponse{}, fmt.Errorf("failed to get total consolidated count: %w", err)
+	if err := repo.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return pagination.Page[response.EICConsolidatedItem]{}, fmt.Errorf("failed to get total consolidated count: %w", err)
 	}
-
-	if len(consolidatedData) == 0 {
-		return response.EICPageResponse{}, fmt.Errorf("no EIC items found for the specified criteria: %w", ErrNotFound)
-	}
-
-	totalPages := int(math.Ceil(float64(totalCount) / float64(eicReturnCount)))
-	return response.EICPageResponse{
-		Items:      consolidatedData,
-		Count:      len(consolidatedData),
-		Page:       page,
-		TotalPages: totalPages,
-		IsLastPage: page >= totalPages,
-	}, nil
+	return page.WithTotal(totalCount), nil
 }
 
 func isNotFoundError(err error) bool {
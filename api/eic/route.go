@@ -2,15 +2,18 @@ package eic
 
 import (
 	"database/sql"
-	"errors"
-	"strconv"
 	"strings"
 
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 
 	"github.com/gin-gonic/gin"
 )
 
+// defaultLimit is the EIC list page size when the client sends no limit.
+const defaultLimit = 40
+
 type Dependencies struct {
 	DB *sql.DB
 }
@@ -38,17 +41,13 @@ func (handler *Handler) lookupByNIIN(c *gin.Context) {
 	niin := c.Param("niin")
 
 	if strings.TrimSpace(niin) == "" {
-		c.JSON(400, gin.H{"error": "NIIN parameter is required"})
+		c.Error(apperror.MissingParameter("niin"))
 		return
 	}
 
-	consolidatedData, err := handler.service.LookupByNIIN(niin)
+	consolidatedData, err := handler.service.LookupByNIIN(c.Request.Context(), niin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(404, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(500, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
@@ -66,17 +65,13 @@ func (handler *Handler) lookupByLIN(c *gin.Context) {
 	lin := c.Param("lin")
 
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(400, gin.H{"error": "LIN parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
 
-	consolidatedData, err := handler.service.LookupByLIN(lin)
+	consolidatedData, err := handler.service.LookupByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(404, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(500, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
@@ -92,26 +87,21 @@ func (handler *Handler) lookupByLIN(c *gin.Context) {
 
 func (handler *Handler) lookupByFSCPaginated(c *gin.Context) {
 	fsc := c.Param("fsc")
-	pageStr := c.DefaultQuery("page", "1")
 
 	if strings.TrimSpace(fsc) == "" {
-		c.JSON(400, gin.H{"error": "FSC parameter is required"})
+		c.Error(apperror.MissingParameter("fsc"))
 		return
 	}
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(400, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	eicData, err := handler.service.LookupByFSCPaginated(fsc, page)
+	eicData, err := handler.service.LookupByFSCPaginated(c.Request.Context(), fsc, params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(404, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(500, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
@@ -123,22 +113,17 @@ func (handler *Handler) lookupByFSCPaginated(c *gin.Context) {
 }
 
 func (handler *Handler) lookupAllPaginated(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
 	search := c.Query("search")
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(400, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	eicData, err := handler.service.LookupAllPaginated(page, search)
+	eicData, err := handler.service.LookupAllPaginated(c.Request.Context(), search, params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(404, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(500, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
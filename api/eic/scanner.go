@@ -8,9 +8,11 @@ import (
 	"github.com/lib/pq"
 )
 
-func scanConsolidatedItem(rows *sql.Rows) (response.EICConsolidatedItem, error) {
+// scanConsolidatedItem scans a row of selectColumns. extra receives any
+// columns selected after them.
+func scanConsolidatedItem(rows *sql.Rows, extra ...any) (response.EICConsolidatedItem, error) {
 	var item response.EICConsolidatedItem
-	err := rows.Scan(
+	dest := []any{
 		&item.Inc, &item.Fsc, &item.Niin, &item.Eic, &item.Lin, &item.Nomen, &item.Model,
 		&item.Eicc, &item.Ecc, &item.Cmdtycd, &item.Reported, &item.Dahr,
 		&item.Publvl1, &item.Pubno1, &item.Pubdate1, &item.Pubchg1, &item.Pubcgdt1,
@@ -29,6 +31,7 @@ func scanConsolidatedItem(rows *sql.Rows) (response.EICConsolidatedItem, error)
 		&item.Lcc, &item.Nounabb, &item.Curfmc, &item.Prevfmc, &item.Bstat1, &item.Bstat2,
 		&item.Matcat, &item.Itemmgr, &item.Eos, &item.Sorts, &item.Status, &item.LstUpdt,
 		pq.Array(&item.UoeicArray), pq.Array(&item.MrcArray), &item.VariantCount,
-	)
+	}
+	err := rows.Scan(append(dest, extra...)...)
 	return item, err
 }
@@ -1,8 +1,10 @@
 package eic
 
 import (
+	"context"
 	"strings"
 
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 )
 
@@ -14,9 +16,9 @@ func NewService(repository Repository) Service {
 	return &service{repository: repository}
 }
 
-func (svc *service) LookupByNIIN(niin string) ([]response.EICConsolidatedItem, error) {
+func (svc *service) LookupByNIIN(ctx context.Context, niin string) ([]response.EICConsolidatedItem, error) {
 	niinTrimmed := strings.TrimSpace(strings.ToUpper(niin))
-	consolidatedData, err := svc.repository.GetByNIIN(niinTrimmed)
+	consolidatedData, err := svc.repository.GetByNIIN(ctx, niinTrimmed)
 	if err != nil {
 		return nil, err
 	}
@@ -24,9 +26,9 @@ func (svc *service) LookupByNIIN(niin string) ([]response.EICConsolidatedItem, e
 	return consolidatedData, nil
 }
 
-func (svc *service) LookupByLIN(lin string) ([]response.EICConsolidatedItem, error) {
+func (svc *service) LookupByLIN(ctx context.Context, lin string) ([]response.EICConsolidatedItem, error) {
 	linTrimmed := strings.TrimSpace(strings.ToUpper(lin))
-	consolidatedData, err := svc.repository.GetByLIN(linTrimmed)
+	consolidatedData, err := svc.repository.GetByLIN(ctx, linTrimmed)
 	if err != nil {
 		return nil, err
 	}
@@ -34,21 +36,21 @@ func (svc *service) LookupByLIN(lin string) ([]response.EICConsolidatedItem, err
 	return consolidatedData, nil
 }
 
-func (svc *service) LookupByFSCPaginated(fsc string, page int) (response.EICPageResponse, error) {
+func (svc *service) LookupByFSCPaginated(ctx context.Context, fsc string, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error) {
 	fscTrimmed := strings.TrimSpace(strings.ToUpper(fsc))
-	eicData, err := svc.repository.GetByFSCPaginated(fscTrimmed, page)
+	eicData, err := svc.repository.GetByFSCPaginated(ctx, fscTrimmed, params)
 	if err != nil {
-		return response.EICPageResponse{}, err
+		return pagination.Page[response.EICConsolidatedItem]{}, err
 	}
 
 	return eicData, nil
 }
 
-func (svc *service) LookupAllPaginated(page int, search string) (response.EICPageResponse, error) {
+func (svc *service) LookupAllPaginated(ctx context.Context, search string, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error) {
 	searchTrimmed := strings.TrimSpace(search)
-	eicData, err := svc.repository.GetAllPaginated(page, searchTrimmed)
+	eicData, err := svc.repository.GetAllPaginated(ctx, searchTrimmed, params)
 	if err != nil {
-		return response.EICPageResponse{}, err
+		return pagination.Page[response.EICConsolidatedItem]{}, err
 	}
 
 	return eicData, nil
@@ -0,0 +1,23 @@
+package calendar
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Equipment Services",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getEquipmentServiceCalendar",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/equipment-services/calendar",
+			Auth:     true,
+			Summary:  "Get the equipment service calendar",
+			Query:    []openapi.Param{{Name: "start_date", Required: true}, {Name: "end_date", Required: true}, {Name: "equipment_id"}},
+			Response: response.CalendarServicesResponse{},
+		},
+	},
+}}
@@ -1,6 +1,7 @@
 package calendar
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -21,7 +22,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetInDateRange(user *bootstrap.User, shopID string, startDate, endDate time.Time, equipmentID *string) ([]model.EquipmentServices, error) {
+func (repo *RepositoryImpl) GetInDateRange(ctx context.Context, user *bootstrap.User, shopID string, startDate, endDate time.Time, equipmentID *string) ([]model.EquipmentServices, error) {
 	conditions := []postgres.BoolExpression{
 		EquipmentServices.ShopID.EQ(String(shopID)),
 		EquipmentServices.ServiceDate.IS_NOT_NULL(),
@@ -43,7 +44,7 @@ func (repo *RepositoryImpl) GetInDateRange(user *bootstrap.User, shopID string,
 		ORDER_BY(EquipmentServices.ServiceDate.ASC())
 
 	var services []model.EquipmentServices
-	err := stmt.Query(repo.db, &services)
+	err := stmt.QueryContext(ctx, repo.db, &services)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get services in date range: %w", err)
 	}
@@ -3,6 +3,7 @@ package calendar
 import (
 	"log/slog"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
@@ -23,25 +24,25 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getCalendar(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	var req request.GetCalendarServicesRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		slog.Info("invalid query parameters", "error", err)
-		c.JSON(400, gin.H{"message": "invalid query parameters", "details": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	services, err := handler.service.GetCalendarServices(user, shopID, req)
+	services, err := handler.service.GetCalendarServices(c.Request.Context(), user, shopID, req)
 	if err != nil {
 		c.Error(err)
 		return
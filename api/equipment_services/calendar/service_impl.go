@@ -1,9 +1,11 @@
 package calendar
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
@@ -24,32 +26,32 @@ func NewService(repo Repository, authorization *shared.Authorization, usernameRe
 	}
 }
 
-func (service *ServiceImpl) GetCalendarServices(user *bootstrap.User, shopID string, req request.GetCalendarServicesRequest) (*response.CalendarServicesResponse, error) {
+func (service *ServiceImpl) GetCalendarServices(ctx context.Context, user *bootstrap.User, shopID string, req request.GetCalendarServicesRequest) (*response.CalendarServicesResponse, error) {
 	if user == nil {
 		return nil, shared.ErrUnauthorizedUser
 	}
 
-	if err := service.authorization.RequireShopMember(user, shopID); err != nil {
+	if err := service.authorization.RequireShopMember(ctx, user, shopID); err != nil {
 		return nil, err
 	}
 
 	startDate, err := time.Parse(time.RFC3339, req.StartDate)
 	if err != nil {
-		return nil, fmt.Errorf("invalid start_date format: %w", err)
+		return nil, apperror.InvalidParameter("start_date", "invalid start_date format").WithCause(err)
 	}
 
 	endDate, err := time.Parse(time.RFC3339, req.EndDate)
 	if err != nil {
-		return nil, fmt.Errorf("invalid end_date format: %w", err)
+		return nil, apperror.InvalidParameter("end_date", "invalid end_date format").WithCause(err)
 	}
 
-	services, err := service.repo.GetInDateRange(user, shopID, startDate, endDate, req.EquipmentID)
+	services, err := service.repo.GetInDateRange(ctx, user, shopID, startDate, endDate, req.EquipmentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get services in date range: %w", err)
 	}
 
 	usernameCache := shared.NewUsernameCache(service.usernameResolver)
-	responseServices := shared.MapServicesToResponses(services, usernameCache)
+	responseServices := shared.MapServicesToResponses(ctx, services, usernameCache)
 
 	return &response.CalendarServicesResponse{
 		DateRange: struct {
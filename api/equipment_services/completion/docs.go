@@ -0,0 +1,24 @@
+package completion
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Equipment Services",
+	Operations: []openapi.Operation{
+		{
+			ID:       "completeEquipmentService",
+			Method:   http.MethodPost,
+			Path:     "/shops/:shop_id/equipment-services/:service_id/complete",
+			Auth:     true,
+			Summary:  "Complete an equipment service",
+			Request:  request.CompleteEquipmentServiceRequest{},
+			Response: response.EquipmentServiceResponse{},
+		},
+	},
+}}
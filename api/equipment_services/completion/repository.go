@@ -1,6 +1,7 @@
 package completion
 
 import (
+	"context"
 	"time"
 
 	"miltechserver/.gen/miltech_ng/public/model"
@@ -8,5 +9,6 @@ import (
 )
 
 type Repository interface {
-	Complete(user *bootstrap.User, serviceID string, completionDate *time.Time) (*model.EquipmentServices, error)
+	GetByID(ctx context.Context, user *bootstrap.User, serviceID string) (*model.EquipmentServices, error)
+	Complete(ctx context.Context, user *bootstrap.User, serviceID string, completionDate *time.Time) (*model.EquipmentServices, error)
 }
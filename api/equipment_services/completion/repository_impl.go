@@ -1,6 +1,7 @@
 package completion
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
@@ -9,6 +10,7 @@ import (
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
@@ -21,7 +23,21 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) Complete(user *bootstrap.User, serviceID string, completionDate *time.Time) (*model.EquipmentServices, error) {
+func (repo *RepositoryImpl) GetByID(ctx context.Context, user *bootstrap.User, serviceID string) (*model.EquipmentServices, error) {
+	stmt := SELECT(EquipmentServices.AllColumns).FROM(EquipmentServices).WHERE(
+		EquipmentServices.ID.EQ(String(serviceID)),
+	)
+
+	var service model.EquipmentServices
+	err := stmt.QueryContext(ctx, repo.db, &service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment service: %w", err)
+	}
+
+	return &service, nil
+}
+
+func (repo *RepositoryImpl) Complete(ctx context.Context, user *bootstrap.User, serviceID string, completionDate *time.Time) (*model.EquipmentServices, error) {
 	now := time.Now()
 	if completionDate == nil {
 		completionDate = &now
@@ -43,7 +59,7 @@ func (repo *RepositoryImpl) Complete(user *bootstrap.User, serviceID string, com
 	).RETURNING(EquipmentServices.AllColumns)
 
 	var completedService model.EquipmentServices
-	err := stmt.Query(repo.db, &completedService)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &completedService)
 	if err != nil {
 		return nil, fmt.Errorf("failed to complete equipment service: %w", err)
 	}
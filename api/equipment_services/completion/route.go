@@ -3,6 +3,7 @@ package completion
 import (
 	"log/slog"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
@@ -23,7 +24,7 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) complete(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
@@ -32,23 +33,23 @@ func (handler *Handler) complete(c *gin.Context) {
 	serviceID := c.Param("service_id")
 
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	if serviceID == "" {
-		c.JSON(400, gin.H{"message": "service_id is required"})
+		c.Error(apperror.MissingParameter("service_id"))
 		return
 	}
 
 	var req request.CompleteEquipmentServiceRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request", "details": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	completedService, err := handler.service.Complete(user, shopID, serviceID, req)
+	completedService, err := handler.service.Complete(c.Request.Context(), user, shopID, serviceID, req)
 	if err != nil {
 		c.Error(err)
 		return
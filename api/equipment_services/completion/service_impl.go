@@ -1,35 +1,43 @@
 package completion
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
+	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/audit"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 )
 
 type ServiceImpl struct {
 	repo             Repository
 	authorization    *shared.Authorization
 	usernameResolver shared.UsernameResolver
+	audit            audit.Recorder
+	events           events.Publisher
 }
 
-func NewService(repo Repository, authorization *shared.Authorization, usernameResolver shared.UsernameResolver) *ServiceImpl {
+func NewService(repo Repository, authorization *shared.Authorization, usernameResolver shared.UsernameResolver, recorder audit.Recorder, publisher events.Publisher) *ServiceImpl {
 	return &ServiceImpl{
 		repo:             repo,
 		authorization:    authorization,
 		usernameResolver: usernameResolver,
+		audit:            recorder,
+		events:           publisher,
 	}
 }
 
-func (service *ServiceImpl) Complete(user *bootstrap.User, shopID, serviceID string, req request.CompleteEquipmentServiceRequest) (*response.EquipmentServiceResponse, error) {
+func (service *ServiceImpl) Complete(ctx context.Context, user *bootstrap.User, shopID, serviceID string, req request.CompleteEquipmentServiceRequest) (*response.EquipmentServiceResponse, error) {
 	if user == nil {
 		return nil, shared.ErrUnauthorizedUser
 	}
 
-	canModify, err := service.authorization.CanUserModifyService(user, shopID, serviceID)
+	canModify, err := service.authorization.CanUserModifyService(ctx, user, shopID, serviceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify modify permissions: %w", err)
 	}
@@ -37,13 +45,43 @@ func (service *ServiceImpl) Complete(user *bootstrap.User, shopID, serviceID str
 		return nil, shared.ErrModifyDenied
 	}
 
-	completedService, err := service.repo.Complete(user, serviceID, req.CompletionDate)
+	currentService, err := service.repo.GetByID(ctx, user, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment service: %w", err)
+	}
+
+	var completedService *model.EquipmentServices
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		completedService, err = service.repo.Complete(ctx, user, serviceID, req.CompletionDate)
+		if err != nil {
+			return err
+		}
+		err := service.events.Publish(ctx, events.EquipmentServiceCompleted{
+			ShopID:         completedService.ShopID,
+			ServiceID:      completedService.ID,
+			EquipmentID:    completedService.EquipmentID,
+			CompletedBy:    user.UserID,
+			CompletionDate: completedService.CompletionDate,
+		})
+		if err != nil {
+			return err
+		}
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     completedService.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityEquipmentService,
+			EntityID:   serviceID,
+			Action:     audit.ActionComplete,
+			Before:     currentService,
+			After:      completedService,
+		})
+	})
 	if err != nil {
 		slog.Error("Failed to complete equipment service", "error", err, "service_id", serviceID, "user_id", user.UserID)
 		return nil, fmt.Errorf("failed to complete equipment service: %w", err)
 	}
 
-	username, err := service.usernameResolver.GetUsernameByUserID(completedService.CreatedBy)
+	username, err := service.usernameResolver.GetUsernameByUserID(ctx, completedService.CreatedBy)
 	if err != nil {
 		slog.Warn("Failed to get username, using fallback", "user_id", completedService.CreatedBy, "error", err)
 		username = "Unknown User"
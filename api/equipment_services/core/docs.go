@@ -0,0 +1,51 @@
+package core
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Equipment Services",
+	Operations: []openapi.Operation{
+		{
+			ID:       "createEquipmentService",
+			Method:   http.MethodPost,
+			Path:     "/shops/:shop_id/equipment-services",
+			Auth:     true,
+			Summary:  "Create an equipment service",
+			Request:  request.CreateEquipmentServiceRequest{},
+			Status:   http.StatusCreated,
+			Response: response.EquipmentServiceResponse{},
+		},
+		{
+			ID:       "getEquipmentService",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/equipment-services/:service_id",
+			Auth:     true,
+			Summary:  "Get an equipment service",
+			Response: response.EquipmentServiceResponse{},
+		},
+		{
+			ID:       "updateEquipmentService",
+			Method:   http.MethodPut,
+			Path:     "/shops/:shop_id/equipment-services/:service_id",
+			Auth:     true,
+			Summary:  "Update an equipment service",
+			Request:  request.UpdateEquipmentServiceRequest{},
+			Response: response.EquipmentServiceResponse{},
+		},
+		{
+			ID:       "deleteEquipmentService",
+			Method:   http.MethodDelete,
+			Path:     "/shops/:shop_id/equipment-services/:service_id",
+			Auth:     true,
+			Summary:  "Delete an equipment service",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+	},
+}}
@@ -1,13 +1,14 @@
 package core
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
 
 type Repository interface {
-	Create(user *bootstrap.User, service model.EquipmentServices) (*model.EquipmentServices, error)
-	GetByID(user *bootstrap.User, serviceID string) (*model.EquipmentServices, error)
-	Update(user *bootstrap.User, service model.EquipmentServices) (*model.EquipmentServices, error)
-	Delete(user *bootstrap.User, serviceID string) error
+	Create(ctx context.Context, user *bootstrap.User, service model.EquipmentServices) (*model.EquipmentServices, error)
+	GetByID(ctx context.Context, user *bootstrap.User, serviceID string) (*model.EquipmentServices, error)
+	Update(ctx context.Context, user *bootstrap.User, service model.EquipmentServices) (*model.EquipmentServices, error)
+	Delete(ctx context.Context, user *bootstrap.User, serviceID string) error
 }
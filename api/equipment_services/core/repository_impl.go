@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
@@ -22,7 +24,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) Create(user *bootstrap.User, service model.EquipmentServices) (*model.EquipmentServices, error) {
+func (repo *RepositoryImpl) Create(ctx context.Context, user *bootstrap.User, service model.EquipmentServices) (*model.EquipmentServices, error) {
 	stmt := EquipmentServices.INSERT(
 		EquipmentServices.ID,
 		EquipmentServices.ShopID,
@@ -40,7 +42,7 @@ func (repo *RepositoryImpl) Create(user *bootstrap.User, service model.Equipment
 	).MODEL(service).RETURNING(EquipmentServices.AllColumns)
 
 	var createdService model.EquipmentServices
-	err := stmt.Query(repo.db, &createdService)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &createdService)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create equipment service: %w", err)
 	}
@@ -49,13 +51,13 @@ func (repo *RepositoryImpl) Create(user *bootstrap.User, service model.Equipment
 	return &createdService, nil
 }
 
-func (repo *RepositoryImpl) GetByID(user *bootstrap.User, serviceID string) (*model.EquipmentServices, error) {
+func (repo *RepositoryImpl) GetByID(ctx context.Context, user *bootstrap.User, serviceID string) (*model.EquipmentServices, error) {
 	stmt := SELECT(EquipmentServices.AllColumns).FROM(EquipmentServices).WHERE(
 		EquipmentServices.ID.EQ(String(serviceID)),
 	)
 
 	var service model.EquipmentServices
-	err := stmt.Query(repo.db, &service)
+	err := stmt.QueryContext(ctx, repo.db, &service)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get equipment service: %w", err)
 	}
@@ -63,7 +65,7 @@ func (repo *RepositoryImpl) GetByID(user *bootstrap.User, serviceID string) (*mo
 	return &service, nil
 }
 
-func (repo *RepositoryImpl) Update(user *bootstrap.User, service model.EquipmentServices) (*model.EquipmentServices, error) {
+func (repo *RepositoryImpl) Update(ctx context.Context, user *bootstrap.User, service model.EquipmentServices) (*model.EquipmentServices, error) {
 	now := time.Now()
 	service.UpdatedAt = now
 
@@ -84,7 +86,7 @@ func (repo *RepositoryImpl) Update(user *bootstrap.User, service model.Equipment
 	).RETURNING(EquipmentServices.AllColumns)
 
 	var updatedService model.EquipmentServices
-	err := stmt.Query(repo.db, &updatedService)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &updatedService)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update equipment service: %w", err)
 	}
@@ -93,7 +95,7 @@ func (repo *RepositoryImpl) Update(user *bootstrap.User, service model.Equipment
 	return &updatedService, nil
 }
 
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, serviceID string) error {
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, serviceID string) error {
 	stmt := EquipmentServices.DELETE().WHERE(
 		EquipmentServices.ID.EQ(String(serviceID)).
 			AND(EquipmentServices.ShopID.IN(
@@ -101,7 +103,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, serviceID string) error
 			)),
 	)
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete equipment service: %w", err)
 	}
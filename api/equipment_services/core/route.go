@@ -3,6 +3,7 @@ package core
 import (
 	"log/slog"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
@@ -26,25 +27,25 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) create(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	var req request.CreateEquipmentServiceRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request", "details": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	createdService, err := handler.service.Create(user, req)
+	createdService, err := handler.service.Create(c.Request.Context(), user, req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -60,7 +61,7 @@ func (handler *Handler) create(c *gin.Context) {
 func (handler *Handler) getByID(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
@@ -69,16 +70,16 @@ func (handler *Handler) getByID(c *gin.Context) {
 	serviceID := c.Param("service_id")
 
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	if serviceID == "" {
-		c.JSON(400, gin.H{"message": "service_id is required"})
+		c.Error(apperror.MissingParameter("service_id"))
 		return
 	}
 
-	service, err := handler.service.GetByID(user, shopID, serviceID)
+	service, err := handler.service.GetByID(c.Request.Context(), user, shopID, serviceID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -94,7 +95,7 @@ func (handler *Handler) getByID(c *gin.Context) {
 func (handler *Handler) update(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
@@ -103,25 +104,25 @@ func (handler *Handler) update(c *gin.Context) {
 	serviceID := c.Param("service_id")
 
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	if serviceID == "" {
-		c.JSON(400, gin.H{"message": "service_id is required"})
+		c.Error(apperror.MissingParameter("service_id"))
 		return
 	}
 
 	var req request.UpdateEquipmentServiceRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request", "details": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	req.ServiceID = serviceID
 
-	updatedService, err := handler.service.Update(user, shopID, req)
+	updatedService, err := handler.service.Update(c.Request.Context(), user, shopID, req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -137,7 +138,7 @@ func (handler *Handler) update(c *gin.Context) {
 func (handler *Handler) delete(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
@@ -146,16 +147,16 @@ func (handler *Handler) delete(c *gin.Context) {
 	serviceID := c.Param("service_id")
 
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	if serviceID == "" {
-		c.JSON(400, gin.H{"message": "service_id is required"})
+		c.Error(apperror.MissingParameter("service_id"))
 		return
 	}
 
-	err = handler.service.Delete(user, shopID, serviceID)
+	err = handler.service.Delete(c.Request.Context(), user, shopID, serviceID)
 	if err != nil {
 		c.Error(err)
 		return
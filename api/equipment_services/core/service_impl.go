@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/audit"
 	"miltechserver/bootstrap"
 
 	"github.com/google/uuid"
@@ -18,17 +20,19 @@ type ServiceImpl struct {
 	repo             Repository
 	authorization    *shared.Authorization
 	usernameResolver shared.UsernameResolver
+	audit            audit.Recorder
 }
 
-func NewService(repo Repository, authorization *shared.Authorization, usernameResolver shared.UsernameResolver) *ServiceImpl {
+func NewService(repo Repository, authorization *shared.Authorization, usernameResolver shared.UsernameResolver, recorder audit.Recorder) *ServiceImpl {
 	return &ServiceImpl{
 		repo:             repo,
 		authorization:    authorization,
 		usernameResolver: usernameResolver,
+		audit:            recorder,
 	}
 }
 
-func (service *ServiceImpl) Create(user *bootstrap.User, req request.CreateEquipmentServiceRequest) (*response.EquipmentServiceResponse, error) {
+func (service *ServiceImpl) Create(ctx context.Context, user *bootstrap.User, req request.CreateEquipmentServiceRequest) (*response.EquipmentServiceResponse, error) {
 	if user == nil {
 		return nil, shared.ErrUnauthorizedUser
 	}
@@ -37,13 +41,13 @@ func (service *ServiceImpl) Create(user *bootstrap.User, req request.CreateEquip
 		return nil, shared.ErrServiceHoursNegative
 	}
 
-	shopID, err := service.authorization.GetShopIDForEquipment(user, req.EquipmentID)
+	shopID, err := service.authorization.GetShopIDForEquipment(ctx, user, req.EquipmentID)
 	if err != nil {
 		return nil, fmt.Errorf("equipment access validation failed: %w", err)
 	}
 
 	if req.ListID != "" {
-		listShopID, err := service.authorization.GetShopIDForList(user, req.ListID)
+		listShopID, err := service.authorization.GetShopIDForList(ctx, user, req.ListID)
 		if err != nil {
 			return nil, fmt.Errorf("list access validation failed: %w", err)
 		}
@@ -78,13 +82,28 @@ func (service *ServiceImpl) Create(user *bootstrap.User, req request.CreateEquip
 		equipmentService.CompletionDate = nil
 	}
 
-	createdService, err := service.repo.Create(user, equipmentService)
+	var createdService *model.EquipmentServices
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		createdService, err = service.repo.Create(ctx, user, equipmentService)
+		if err != nil {
+			slog.Error("Failed to create equipment service", "error", err, "user_id", user.UserID)
+			return fmt.Errorf("failed to create equipment service: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     createdService.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityEquipmentService,
+			EntityID:   createdService.ID,
+			Action:     audit.ActionCreate,
+			After:      createdService,
+		})
+	})
 	if err != nil {
-		slog.Error("Failed to create equipment service", "error", err, "user_id", user.UserID)
-		return nil, fmt.Errorf("failed to create equipment service: %w", err)
+		return nil, err
 	}
 
-	username, err := service.usernameResolver.GetUsernameByUserID(createdService.CreatedBy)
+	username, err := service.usernameResolver.GetUsernameByUserID(ctx, createdService.CreatedBy)
 	if err != nil {
 		slog.Warn("Failed to get username, using fallback", "user_id", createdService.CreatedBy, "error", err)
 		username = "Unknown User"
@@ -95,22 +114,22 @@ func (service *ServiceImpl) Create(user *bootstrap.User, req request.CreateEquip
 	return &result, nil
 }
 
-func (service *ServiceImpl) GetByID(user *bootstrap.User, shopID, serviceID string) (*response.EquipmentServiceResponse, error) {
+func (service *ServiceImpl) GetByID(ctx context.Context, user *bootstrap.User, shopID, serviceID string) (*response.EquipmentServiceResponse, error) {
 	if user == nil {
 		return nil, shared.ErrUnauthorizedUser
 	}
 
-	_, err := service.authorization.RequireServiceAccessByID(user, serviceID)
+	_, err := service.authorization.RequireServiceAccessByID(ctx, user, serviceID)
 	if err != nil {
 		return nil, err
 	}
 
-	equipmentService, err := service.repo.GetByID(user, serviceID)
+	equipmentService, err := service.repo.GetByID(ctx, user, serviceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get equipment service: %w", err)
 	}
 
-	username, err := service.usernameResolver.GetUsernameByUserID(equipmentService.CreatedBy)
+	username, err := service.usernameResolver.GetUsernameByUserID(ctx, equipmentService.CreatedBy)
 	if err != nil {
 		username = "Unknown User"
 	}
@@ -119,7 +138,7 @@ func (service *ServiceImpl) GetByID(user *bootstrap.User, shopID, serviceID stri
 	return &result, nil
 }
 
-func (service *ServiceImpl) Update(user *bootstrap.User, shopID string, req request.UpdateEquipmentServiceRequest) (*response.EquipmentServiceResponse, error) {
+func (service *ServiceImpl) Update(ctx context.Context, user *bootstrap.User, shopID string, req request.UpdateEquipmentServiceRequest) (*response.EquipmentServiceResponse, error) {
 	if user == nil {
 		return nil, shared.ErrUnauthorizedUser
 	}
@@ -128,7 +147,7 @@ func (service *ServiceImpl) Update(user *bootstrap.User, shopID string, req requ
 		return nil, shared.ErrServiceHoursNegative
 	}
 
-	canModify, err := service.authorization.CanUserModifyService(user, shopID, req.ServiceID)
+	canModify, err := service.authorization.CanUserModifyService(ctx, user, shopID, req.ServiceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify modify permissions: %w", err)
 	}
@@ -136,6 +155,11 @@ func (service *ServiceImpl) Update(user *bootstrap.User, shopID string, req requ
 		return nil, shared.ErrModifyDenied
 	}
 
+	currentService, err := service.repo.GetByID(ctx, user, req.ServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment service: %w", err)
+	}
+
 	now := time.Now()
 	updateService := model.EquipmentServices{
 		ID:           req.ServiceID,
@@ -158,13 +182,29 @@ func (service *ServiceImpl) Update(user *bootstrap.User, shopID string, req requ
 		updateService.CompletionDate = nil
 	}
 
-	updatedService, err := service.repo.Update(user, updateService)
+	var updatedService *model.EquipmentServices
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		updatedService, err = service.repo.Update(ctx, user, updateService)
+		if err != nil {
+			slog.Error("Failed to update equipment service", "error", err, "service_id", req.ServiceID, "user_id", user.UserID)
+			return fmt.Errorf("failed to update equipment service: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     updatedService.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityEquipmentService,
+			EntityID:   updatedService.ID,
+			Action:     audit.ActionUpdate,
+			Before:     currentService,
+			After:      updatedService,
+		})
+	})
 	if err != nil {
-		slog.Error("Failed to update equipment service", "error", err, "service_id", req.ServiceID, "user_id", user.UserID)
-		return nil, fmt.Errorf("failed to update equipment service: %w", err)
+		return nil, err
 	}
 
-	username, err := service.usernameResolver.GetUsernameByUserID(updatedService.CreatedBy)
+	username, err := service.usernameResolver.GetUsernameByUserID(ctx, updatedService.CreatedBy)
 	if err != nil {
 		username = "Unknown User"
 	}
@@ -173,12 +213,12 @@ func (service *ServiceImpl) Update(user *bootstrap.User, shopID string, req requ
 	return &result, nil
 }
 
-func (service *ServiceImpl) Delete(user *bootstrap.User, shopID, serviceID string) error {
+func (service *ServiceImpl) Delete(ctx context.Context, user *bootstrap.User, shopID, serviceID string) error {
 	if user == nil {
 		return shared.ErrUnauthorizedUser
 	}
 
-	canDelete, err := service.authorization.CanUserDeleteService(user, shopID, serviceID)
+	canDelete, err := service.authorization.CanUserDeleteService(ctx, user, shopID, serviceID)
 	if err != nil {
 		return fmt.Errorf("failed to verify delete permissions: %w", err)
 	}
@@ -186,10 +226,29 @@ func (service *ServiceImpl) Delete(user *bootstrap.User, shopID, serviceID strin
 		return shared.ErrDeleteDenied
 	}
 
-	err = service.repo.Delete(user, serviceID)
+	currentService, err := service.repo.GetByID(ctx, user, serviceID)
+	if err != nil {
+		return fmt.Errorf("failed to get equipment service: %w", err)
+	}
+
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.Delete(ctx, user, serviceID)
+		if err != nil {
+			slog.Error("Failed to delete equipment service", "error", err, "service_id", serviceID, "user_id", user.UserID)
+			return fmt.Errorf("failed to delete equipment service: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     currentService.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityEquipmentService,
+			EntityID:   serviceID,
+			Action:     audit.ActionDelete,
+			Before:     currentService,
+		})
+	})
 	if err != nil {
-		slog.Error("Failed to delete equipment service", "error", err, "service_id", serviceID, "user_id", user.UserID)
-		return fmt.Errorf("failed to delete equipment service: %w", err)
+		return err
 	}
 
 	slog.Info("Equipment service deleted successfully", "service_id", serviceID, "user_id", user.UserID)
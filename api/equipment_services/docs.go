@@ -0,0 +1,14 @@
+package equipment_services
+
+import (
+	"slices"
+
+	"miltechserver/api/equipment_services/calendar"
+	"miltechserver/api/equipment_services/completion"
+	"miltechserver/api/equipment_services/core"
+	"miltechserver/api/equipment_services/queries"
+	"miltechserver/api/equipment_services/status"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(core.Docs, completion.Docs, queries.Docs, calendar.Docs, status.Docs)
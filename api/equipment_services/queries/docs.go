@@ -0,0 +1,32 @@
+package queries
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Equipment Services",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listEquipmentServices",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/equipment-services",
+			Auth:     true,
+			Summary:  "List the equipment services of a shop",
+			Query:    []openapi.Param{{Name: "equipment_id"}, {Name: "start_date"}, {Name: "end_date"}, {Name: "service_type"}, {Name: "is_completed", Type: "boolean"}, {Name: "status"}, {Name: "limit", Type: "integer"}, {Name: "offset", Type: "integer"}},
+			Response: response.PaginatedEquipmentServicesResponse{},
+		},
+		{
+			ID:       "listEquipmentServicesByEquipment",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/equipment/:equipment_id/services",
+			Auth:     true,
+			Summary:  "List the services of a piece of equipment",
+			Query:    []openapi.Param{{Name: "equipment_id"}, {Name: "start_date"}, {Name: "end_date"}, {Name: "service_type"}, {Name: "is_completed", Type: "boolean"}, {Name: "status"}, {Name: "limit", Type: "integer"}, {Name: "offset", Type: "integer"}},
+			Response: response.PaginatedEquipmentServicesResponse{},
+		},
+	},
+}}
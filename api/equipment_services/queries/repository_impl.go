@@ -1,6 +1,7 @@
 package queries
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -22,7 +23,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetByShop(user *bootstrap.User, shopID string, filters request.GetEquipmentServicesRequest) ([]model.EquipmentServices, int64, error) {
+func (repo *RepositoryImpl) GetByShop(ctx context.Context, user *bootstrap.User, shopID string, filters request.GetEquipmentServicesRequest) ([]model.EquipmentServices, int64, error) {
 	conditions := []postgres.BoolExpression{
 		EquipmentServices.ShopID.EQ(String(shopID)),
 	}
@@ -65,7 +66,7 @@ func (repo *RepositoryImpl) GetByShop(user *bootstrap.User, shopID string, filte
 
 	countQuery, countArgs := countStmt.Sql()
 	var totalCount int64
-	err := repo.db.QueryRow(countQuery, countArgs...).Scan(&totalCount)
+	err := repo.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count services: %w", err)
 	}
@@ -82,7 +83,7 @@ func (repo *RepositoryImpl) GetByShop(user *bootstrap.User, shopID string, filte
 		OFFSET(int64(filters.Offset))
 
 	var services []model.EquipmentServices
-	err = dataStmt.Query(repo.db, &services)
+	err = dataStmt.QueryContext(ctx, repo.db, &services)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get services: %w", err)
 	}
@@ -90,7 +91,7 @@ func (repo *RepositoryImpl) GetByShop(user *bootstrap.User, shopID string, filte
 	return services, totalCount, nil
 }
 
-func (repo *RepositoryImpl) GetByEquipment(user *bootstrap.User, equipmentID string, limit, offset int, startDate, endDate *time.Time) ([]model.EquipmentServices, int64, error) {
+func (repo *RepositoryImpl) GetByEquipment(ctx context.Context, user *bootstrap.User, equipmentID string, limit, offset int, startDate, endDate *time.Time) ([]model.EquipmentServices, int64, error) {
 	conditions := []postgres.BoolExpression{
 		EquipmentServices.EquipmentID.EQ(String(equipmentID)),
 	}
@@ -112,7 +113,7 @@ func (repo *RepositoryImpl) GetByEquipment(user *bootstrap.User, equipmentID str
 
 	countQuery, countArgs := countStmt.Sql()
 	var totalCount int64
-	err := repo.db.QueryRow(countQuery, countArgs...).Scan(&totalCount)
+	err := repo.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count services: %w", err)
 	}
@@ -129,7 +130,7 @@ func (repo *RepositoryImpl) GetByEquipment(user *bootstrap.User, equipmentID str
 		OFFSET(int64(offset))
 
 	var services []model.EquipmentServices
-	err = dataStmt.Query(repo.db, &services)
+	err = dataStmt.QueryContext(ctx, repo.db, &services)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get services: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"time"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
@@ -25,25 +26,25 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getByShop(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	var req request.GetEquipmentServicesRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		slog.Info("invalid query parameters", "error", err)
-		c.JSON(400, gin.H{"message": "invalid query parameters", "details": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	services, err := handler.service.GetByShop(user, shopID, req)
+	services, err := handler.service.GetByShop(c.Request.Context(), user, shopID, req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -59,21 +60,21 @@ func (handler *Handler) getByShop(c *gin.Context) {
 func (handler *Handler) getByEquipment(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	equipmentID := c.Param("equipment_id")
 	if equipmentID == "" {
-		c.JSON(400, gin.H{"message": "equipment_id is required"})
+		c.Error(apperror.MissingParameter("equipment_id"))
 		return
 	}
 
 	var req request.GetEquipmentServicesRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		slog.Info("invalid query parameters", "error", err)
-		c.JSON(400, gin.H{"message": "invalid query parameters", "details": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -81,7 +82,7 @@ func (handler *Handler) getByEquipment(c *gin.Context) {
 	if req.StartDate != nil {
 		parsed, err := time.Parse(time.RFC3339, *req.StartDate)
 		if err != nil {
-			c.JSON(400, gin.H{"message": "invalid start_date format", "details": err.Error()})
+			c.Error(apperror.InvalidParameter("start_date", "invalid start_date format").WithCause(err))
 			return
 		}
 		startDate = &parsed
@@ -90,13 +91,13 @@ func (handler *Handler) getByEquipment(c *gin.Context) {
 	if req.EndDate != nil {
 		parsed, err := time.Parse(time.RFC3339, *req.EndDate)
 		if err != nil {
-			c.JSON(400, gin.H{"message": "invalid end_date format", "details": err.Error()})
+			c.Error(apperror.InvalidParameter("end_date", "invalid end_date format").WithCause(err))
 			return
 		}
 		endDate = &parsed
 	}
 
-	services, err := handler.service.GetByEquipment(user, equipmentID, req.Limit, req.Offset, startDate, endDate)
+	services, err := handler.service.GetByEquipment(c.Request.Context(), user, equipmentID, req.Limit, req.Offset, startDate, endDate)
 	if err != nil {
 		c.Error(err)
 		return
@@ -1,6 +1,7 @@
 package queries
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -24,22 +25,22 @@ func NewService(repo Repository, authorization *shared.Authorization, usernameRe
 	}
 }
 
-func (service *ServiceImpl) GetByShop(user *bootstrap.User, shopID string, req request.GetEquipmentServicesRequest) (*response.PaginatedEquipmentServicesResponse, error) {
+func (service *ServiceImpl) GetByShop(ctx context.Context, user *bootstrap.User, shopID string, req request.GetEquipmentServicesRequest) (*response.PaginatedEquipmentServicesResponse, error) {
 	if user == nil {
 		return nil, shared.ErrUnauthorizedUser
 	}
 
-	if err := service.authorization.RequireShopMember(user, shopID); err != nil {
+	if err := service.authorization.RequireShopMember(ctx, user, shopID); err != nil {
 		return nil, err
 	}
 
-	services, totalCount, err := service.repo.GetByShop(user, shopID, req)
+	services, totalCount, err := service.repo.GetByShop(ctx, user, shopID, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get equipment services: %w", err)
 	}
 
 	usernameCache := shared.NewUsernameCache(service.usernameResolver)
-	responseServices := shared.MapServicesToResponses(services, usernameCache)
+	responseServices := shared.MapServicesToResponses(ctx, services, usernameCache)
 
 	return &response.PaginatedEquipmentServicesResponse{
 		Services:   responseServices,
@@ -48,23 +49,23 @@ func (service *ServiceImpl) GetByShop(user *bootstrap.User, shopID string, req r
 	}, nil
 }
 
-func (service *ServiceImpl) GetByEquipment(user *bootstrap.User, equipmentID string, limit, offset int, startDate, endDate *time.Time) (*response.PaginatedEquipmentServicesResponse, error) {
+func (service *ServiceImpl) GetByEquipment(ctx context.Context, user *bootstrap.User, equipmentID string, limit, offset int, startDate, endDate *time.Time) (*response.PaginatedEquipmentServicesResponse, error) {
 	if user == nil {
 		return nil, shared.ErrUnauthorizedUser
 	}
 
-	_, err := service.authorization.GetShopIDForEquipment(user, equipmentID)
+	_, err := service.authorization.GetShopIDForEquipment(ctx, user, equipmentID)
 	if err != nil {
 		return nil, fmt.Errorf("equipment access validation failed: %w", err)
 	}
 
-	services, totalCount, err := service.repo.GetByEquipment(user, equipmentID, limit, offset, startDate, endDate)
+	services, totalCount, err := service.repo.GetByEquipment(ctx, user, equipmentID, limit, offset, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get services by equipment: %w", err)
 	}
 
 	usernameCache := shared.NewUsernameCache(service.usernameResolver)
-	responseServices := shared.MapServicesToResponses(services, usernameCache)
+	responseServices := shared.MapServicesToResponses(ctx, services, usernameCache)
 
 	return &response.PaginatedEquipmentServicesResponse{
 		Services:   responseServices,
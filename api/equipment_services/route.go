@@ -11,17 +11,23 @@ import (
 	"miltechserver/api/equipment_services/queries"
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/equipment_services/status"
+	"miltechserver/api/shops/audit"
 	shopsShared "miltechserver/api/shops/shared"
+	"miltechserver/events"
+	"miltechserver/jobs"
 )
 
 type Dependencies struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Events *events.Bus
+	Jobs   *jobs.Scheduler
 }
 
 func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
 	shopAuth := shopsShared.NewShopAuthorization(deps.DB)
 	authorization := shared.NewAuthorization(deps.DB, shopAuth)
 	usernameResolver := shared.NewUsernameRepository(deps.DB)
+	auditLog := audit.NewLog(deps.DB, audit.NewRepository(deps.DB))
 
 	coreRepo := core.NewRepository(deps.DB)
 	queriesRepo := queries.NewRepository(deps.DB)
@@ -29,15 +35,17 @@ func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
 	statusRepo := status.NewRepository(deps.DB)
 	completionRepo := completion.NewRepository(deps.DB)
 
-	coreService := core.NewService(coreRepo, authorization, usernameResolver)
+	coreService := core.NewService(coreRepo, authorization, usernameResolver, auditLog)
 	queriesService := queries.NewService(queriesRepo, authorization, usernameResolver)
 	calendarService := calendar.NewService(calendarRepo, authorization, usernameResolver)
 	statusService := status.NewService(statusRepo, authorization, usernameResolver)
-	completionService := completion.NewService(completionRepo, authorization, usernameResolver)
+	completionService := completion.NewService(completionRepo, authorization, usernameResolver, auditLog, deps.Events)
 
 	core.RegisterRoutes(router, coreService)
 	queries.RegisterRoutes(router, queriesService)
 	calendar.RegisterRoutes(router, calendarService)
 	status.RegisterRoutes(router, statusService)
 	completion.RegisterRoutes(router, completionService)
+
+	status.RegisterJobs(deps.Jobs, statusRepo, deps.Events)
 }
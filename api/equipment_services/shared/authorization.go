@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -36,8 +37,8 @@ func NewAuthorization(db *sql.DB, shopAuth shopsShared.ShopAuthorization) *Autho
 	return &Authorization{db: db, shopAuth: shopAuth}
 }
 
-func (auth *Authorization) RequireShopMember(user *bootstrap.User, shopID string) error {
-	isMember, err := auth.shopAuth.IsUserMemberOfShop(user, shopID)
+func (auth *Authorization) RequireShopMember(ctx context.Context, user *bootstrap.User, shopID string) error {
+	isMember, err := auth.shopAuth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify shop membership: %w", err)
 	}
@@ -47,7 +48,7 @@ func (auth *Authorization) RequireShopMember(user *bootstrap.User, shopID string
 	return nil
 }
 
-func (auth *Authorization) GetShopIDForEquipment(user *bootstrap.User, equipmentID string) (string, error) {
+func (auth *Authorization) GetShopIDForEquipment(ctx context.Context, user *bootstrap.User, equipmentID string) (string, error) {
 	stmt := SELECT(ShopVehicle.ShopID).FROM(
 		ShopVehicle.
 			INNER_JOIN(ShopMembers, ShopMembers.ShopID.EQ(ShopVehicle.ShopID)),
@@ -57,7 +58,7 @@ func (auth *Authorization) GetShopIDForEquipment(user *bootstrap.User, equipment
 	)
 
 	var result shopIDResult
-	err := stmt.Query(auth.db, &result)
+	err := stmt.QueryContext(ctx, auth.db, &result)
 	if err != nil {
 		return "", fmt.Errorf("%w: %w", ErrEquipmentNotFound, err)
 	}
@@ -65,7 +66,7 @@ func (auth *Authorization) GetShopIDForEquipment(user *bootstrap.User, equipment
 	return result.ShopID, nil
 }
 
-func (auth *Authorization) GetShopIDForList(user *bootstrap.User, listID string) (string, error) {
+func (auth *Authorization) GetShopIDForList(ctx context.Context, user *bootstrap.User, listID string) (string, error) {
 	stmt := SELECT(ShopLists.ShopID).FROM(
 		ShopLists.
 			INNER_JOIN(ShopMembers, ShopMembers.ShopID.EQ(ShopLists.ShopID)),
@@ -75,7 +76,7 @@ func (auth *Authorization) GetShopIDForList(user *bootstrap.User, listID string)
 	)
 
 	var result listShopIDResult
-	err := stmt.Query(auth.db, &result)
+	err := stmt.QueryContext(ctx, auth.db, &result)
 	if err != nil {
 		return "", fmt.Errorf("%w: %w", ErrListNotFound, err)
 	}
@@ -83,26 +84,26 @@ func (auth *Authorization) GetShopIDForList(user *bootstrap.User, listID string)
 	return result.ShopID, nil
 }
 
-func (auth *Authorization) RequireServiceAccessByID(user *bootstrap.User, serviceID string) (string, error) {
+func (auth *Authorization) RequireServiceAccessByID(ctx context.Context, user *bootstrap.User, serviceID string) (string, error) {
 	stmt := SELECT(EquipmentServices.ShopID).FROM(EquipmentServices).WHERE(
 		EquipmentServices.ID.EQ(String(serviceID)),
 	)
 
 	var result serviceShopIDResult
-	err := stmt.Query(auth.db, &result)
+	err := stmt.QueryContext(ctx, auth.db, &result)
 	if err != nil {
 		return "", fmt.Errorf("%w: %w", ErrServiceNotFound, err)
 	}
 
-	if err := auth.RequireShopMember(user, result.ShopID); err != nil {
+	if err := auth.RequireShopMember(ctx, user, result.ShopID); err != nil {
 		return "", err
 	}
 
 	return result.ShopID, nil
 }
 
-func (auth *Authorization) CanUserModifyService(user *bootstrap.User, shopID, serviceID string) (bool, error) {
-	isAdmin, err := auth.shopAuth.IsUserShopAdmin(user, shopID)
+func (auth *Authorization) CanUserModifyService(ctx context.Context, user *bootstrap.User, shopID, serviceID string) (bool, error) {
+	isAdmin, err := auth.shopAuth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return false, err
 	}
@@ -110,21 +111,21 @@ func (auth *Authorization) CanUserModifyService(user *bootstrap.User, shopID, se
 		return true, nil
 	}
 
-	return auth.isServiceOwner(user, serviceID)
+	return auth.isServiceOwner(ctx, user, serviceID)
 }
 
-func (auth *Authorization) CanUserDeleteService(user *bootstrap.User, shopID, serviceID string) (bool, error) {
-	return auth.CanUserModifyService(user, shopID, serviceID)
+func (auth *Authorization) CanUserDeleteService(ctx context.Context, user *bootstrap.User, shopID, serviceID string) (bool, error) {
+	return auth.CanUserModifyService(ctx, user, shopID, serviceID)
 }
 
-func (auth *Authorization) isServiceOwner(user *bootstrap.User, serviceID string) (bool, error) {
+func (auth *Authorization) isServiceOwner(ctx context.Context, user *bootstrap.User, serviceID string) (bool, error) {
 	stmt := SELECT(COUNT(STAR)).FROM(EquipmentServices).WHERE(
 		EquipmentServices.ID.EQ(String(serviceID)).
 			AND(EquipmentServices.CreatedBy.EQ(String(user.UserID))),
 	)
 
 	var result countResult
-	err := stmt.Query(auth.db, &result)
+	err := stmt.QueryContext(ctx, auth.db, &result)
 	if err != nil {
 		return false, fmt.Errorf("failed to validate service ownership: %w", err)
 	}
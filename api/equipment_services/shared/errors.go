@@ -1,15 +1,15 @@
 package shared
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrUnauthorizedUser     = errors.New("unauthorized user")
-	ErrServiceHoursNegative = errors.New("service_hours must be non-negative")
-	ErrAccessDenied         = errors.New("access denied: user is not a member of this shop")
-	ErrModifyDenied         = errors.New("access denied: only service creators or shop admins can modify services")
-	ErrDeleteDenied         = errors.New("access denied: only service creators or shop admins can delete services")
-	ErrShopMismatch         = errors.New("equipment and list must belong to the same shop")
-	ErrEquipmentNotFound    = errors.New("equipment not found or access denied")
-	ErrListNotFound         = errors.New("list not found or access denied")
-	ErrServiceNotFound      = errors.New("service not found")
+	ErrUnauthorizedUser     = apperror.Unauthorized("unauthorized", "unauthorized user")
+	ErrServiceHoursNegative = apperror.Validation("service_hours_negative", "service_hours must be non-negative")
+	ErrAccessDenied         = apperror.Forbidden("shop_access_denied", "access denied: user is not a member of this shop")
+	ErrModifyDenied         = apperror.Forbidden("service_modify_denied", "access denied: only service creators or shop admins can modify services")
+	ErrDeleteDenied         = apperror.Forbidden("service_delete_denied", "access denied: only service creators or shop admins can delete services")
+	ErrShopMismatch         = apperror.Validation("shop_mismatch", "equipment and list must belong to the same shop")
+	ErrEquipmentNotFound    = apperror.NotFound("equipment_not_found", "equipment not found or access denied")
+	ErrListNotFound         = apperror.NotFound("list_not_found", "list not found or access denied")
+	ErrServiceNotFound      = apperror.NotFound("service_not_found", "service not found")
 )
@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"context"
 	"database/sql"
 	"log/slog"
 
@@ -12,7 +13,7 @@ import (
 )
 
 type UsernameResolver interface {
-	GetUsernameByUserID(userID string) (string, error)
+	GetUsernameByUserID(ctx context.Context, userID string) (string, error)
 }
 
 type UsernameRepository struct {
@@ -27,11 +28,11 @@ func NewUsernameRepository(db *sql.DB) *UsernameRepository {
 	return &UsernameRepository{db: db}
 }
 
-func (repo *UsernameRepository) GetUsernameByUserID(userID string) (string, error) {
+func (repo *UsernameRepository) GetUsernameByUserID(ctx context.Context, userID string) (string, error) {
 	stmt := SELECT(Users.Username).FROM(Users).WHERE(Users.UID.EQ(String(userID)))
 
 	var result usernameResult
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		slog.Warn("Failed to get username for user", "user_id", userID, "error", err)
 		return "Unknown User", nil
@@ -56,7 +57,7 @@ func NewUsernameCache(resolver UsernameResolver) *UsernameCache {
 	}
 }
 
-func (cache *UsernameCache) GetUsernameByUserID(userID string) (string, error) {
+func (cache *UsernameCache) GetUsernameByUserID(ctx context.Context, userID string) (string, error) {
 	if userID == "" {
 		return "Unknown User", nil
 	}
@@ -65,7 +66,7 @@ func (cache *UsernameCache) GetUsernameByUserID(userID string) (string, error) {
 		return username, nil
 	}
 
-	username, err := cache.resolver.GetUsernameByUserID(userID)
+	username, err := cache.resolver.GetUsernameByUserID(ctx, userID)
 	if username == "" {
 		username = "Unknown User"
 	}
@@ -93,10 +94,10 @@ func MapServiceToResponse(svc model.EquipmentServices, username string) response
 	}
 }
 
-func MapServicesToResponses(services []model.EquipmentServices, resolver UsernameResolver) []response.EquipmentServiceResponse {
+func MapServicesToResponses(ctx context.Context, services []model.EquipmentServices, resolver UsernameResolver) []response.EquipmentServiceResponse {
 	responses := make([]response.EquipmentServiceResponse, len(services))
 	for i, svc := range services {
-		username, _ := resolver.GetUsernameByUserID(svc.CreatedBy)
+		username, _ := resolver.GetUsernameByUserID(ctx, svc.CreatedBy)
 		if username == "" {
 			username = "Unknown User"
 		}
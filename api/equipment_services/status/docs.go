@@ -0,0 +1,32 @@
+package status
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Equipment Services",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listOverdueEquipmentServices",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/equipment-services/overdue",
+			Auth:     true,
+			Summary:  "List overdue equipment services",
+			Query:    []openapi.Param{{Name: "equipment_id"}, {Name: "limit", Type: "integer"}},
+			Response: response.OverdueServicesResponse{},
+		},
+		{
+			ID:       "listEquipmentServicesDueSoon",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/equipment-services/due-soon",
+			Auth:     true,
+			Summary:  "List equipment services due soon",
+			Query:    []openapi.Param{{Name: "days_ahead", Type: "integer"}, {Name: "equipment_id"}, {Name: "limit", Type: "integer"}},
+			Response: response.DueSoonServicesResponse{},
+		},
+	},
+}}
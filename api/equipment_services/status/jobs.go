@@ -0,0 +1,45 @@
+package status
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"miltechserver/events"
+	"miltechserver/jobs"
+)
+
+// overdueLookback bounds how long ago a service may have fallen overdue and
+// still be announced, so the first run after deploying does not announce
+// every service that has been overdue for months.
+const overdueLookback = 7 * 24 * time.Hour
+
+// RegisterJobs registers the job that announces services falling overdue.
+func RegisterJobs(scheduler *jobs.Scheduler, repo Repository, publisher events.Publisher) {
+	scheduler.Register(jobs.Job{
+		Name:     "equipment-services-overdue",
+		Schedule: "*/15 * * * *",
+		Run: func(ctx context.Context) error {
+			var count int
+			err := publisher.InTx(ctx, func(ctx context.Context) error {
+				overdue, err := repo.MarkNewlyOverdue(ctx, time.Now().Add(-overdueLookback))
+				if err != nil {
+					return err
+				}
+				count = len(overdue)
+				published := make([]events.Event, len(overdue))
+				for i, service := range overdue {
+					published[i] = service
+				}
+				return publisher.Publish(ctx, published...)
+			})
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				slog.Info("Announced overdue equipment services", "services", count)
+			}
+			return nil
+		},
+	})
+}
@@ -1,8 +1,11 @@
 package status
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
+	"time"
 )
 
 type ServiceWithDays struct {
@@ -11,6 +14,7 @@ type ServiceWithDays struct {
 }
 
 type Repository interface {
-	GetOverdue(user *bootstrap.User, shopID string, equipmentID *string, limit int) ([]ServiceWithDays, error)
-	GetDueSoon(user *bootstrap.User, shopID string, daysAhead int, equipmentID *string, limit int) ([]ServiceWithDays, error)
+	GetOverdue(ctx context.Context, user *bootstrap.User, shopID string, equipmentID *string, limit int) ([]ServiceWithDays, error)
+	GetDueSoon(ctx context.Context, user *bootstrap.User, shopID string, daysAhead int, equipmentID *string, limit int) ([]ServiceWithDays, error)
+	MarkNewlyOverdue(ctx context.Context, since time.Time) ([]events.EquipmentServiceOverdue, error)
 }
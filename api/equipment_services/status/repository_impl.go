@@ -1,6 +1,7 @@
 package status
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -8,6 +9,8 @@ import (
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
+	"miltechserver/events"
 
 	"github.com/go-jet/jet/v2/postgres"
 	. "github.com/go-jet/jet/v2/postgres"
@@ -21,7 +24,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetOverdue(user *bootstrap.User, shopID string, equipmentID *string, limit int) ([]ServiceWithDays, error) {
+func (repo *RepositoryImpl) GetOverdue(ctx context.Context, user *bootstrap.User, shopID string, equipmentID *string, limit int) ([]ServiceWithDays, error) {
 	conditions := []postgres.BoolExpression{
 		EquipmentServices.ShopID.EQ(String(shopID)),
 		EquipmentServices.ServiceDate.IS_NOT_NULL(),
@@ -51,7 +54,7 @@ func (repo *RepositoryImpl) GetOverdue(user *bootstrap.User, shopID string, equi
 		DaysOverdue int `sql:"days_overdue"`
 	}
 
-	err := stmt.Query(repo.db, &results)
+	err := stmt.QueryContext(ctx, repo.db, &results)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get overdue services: %w", err)
 	}
@@ -67,7 +70,7 @@ func (repo *RepositoryImpl) GetOverdue(user *bootstrap.User, shopID string, equi
 	return services, nil
 }
 
-func (repo *RepositoryImpl) GetDueSoon(user *bootstrap.User, shopID string, daysAhead int, equipmentID *string, limit int) ([]ServiceWithDays, error) {
+func (repo *RepositoryImpl) GetDueSoon(ctx context.Context, user *bootstrap.User, shopID string, daysAhead int, equipmentID *string, limit int) ([]ServiceWithDays, error) {
 	futureDate := time.Now().AddDate(0, 0, daysAhead)
 
 	conditions := []postgres.BoolExpression{
@@ -100,7 +103,7 @@ func (repo *RepositoryImpl) GetDueSoon(user *bootstrap.User, shopID string, days
 		DaysUntilDue int `sql:"days_until_due"`
 	}
 
-	err := stmt.Query(repo.db, &results)
+	err := stmt.QueryContext(ctx, repo.db, &results)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get due soon services: %w", err)
 	}
@@ -115,3 +118,46 @@ func (repo *RepositoryImpl) GetDueSoon(user *bootstrap.User, shopID string, days
 
 	return services, nil
 }
+
+// MarkNewlyOverdue records every incomplete service whose date passed after
+// since and has not been marked at that date, and returns them. It runs in
+// the caller's transaction, so the marks commit with the events describing
+// them.
+func (repo *RepositoryImpl) MarkNewlyOverdue(ctx context.Context, since time.Time) ([]events.EquipmentServiceOverdue, error) {
+	rows, err := dbtx.From(ctx, repo.db).QueryContext(ctx, `
+		WITH marked AS (
+			INSERT INTO equipment_service_overdue_marks (service_id, service_date)
+			SELECT id, service_date FROM equipment_services
+			WHERE NOT is_completed AND service_date < NOW() AND service_date >= $1
+			ON CONFLICT DO NOTHING
+			RETURNING service_id
+		)
+		SELECT s.id, s.shop_id, s.equipment_id, s.service_type, s.description, s.service_date
+		FROM equipment_services s
+		JOIN marked ON marked.service_id = s.id
+		ORDER BY s.service_date`, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark overdue services: %w", err)
+	}
+	defer rows.Close()
+
+	var overdue []events.EquipmentServiceOverdue
+	for rows.Next() {
+		var service events.EquipmentServiceOverdue
+		if err := rows.Scan(
+			&service.ServiceID,
+			&service.ShopID,
+			&service.EquipmentID,
+			&service.ServiceType,
+			&service.Description,
+			&service.ServiceDate,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan overdue service: %w", err)
+		}
+		overdue = append(overdue, service)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating overdue services: %w", err)
+	}
+	return overdue, nil
+}
@@ -3,6 +3,7 @@ package status
 import (
 	"log/slog"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/equipment_services/shared"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
@@ -24,25 +25,25 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getOverdue(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	var req request.GetOverdueServicesRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		slog.Info("invalid query parameters", "error", err)
-		c.JSON(400, gin.H{"message": "invalid query parameters", "details": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	services, err := handler.service.GetOverdue(user, shopID, req)
+	services, err := handler.service.GetOverdue(c.Request.Context(), user, shopID, req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -58,25 +59,25 @@ func (handler *Handler) getOverdue(c *gin.Context) {
 func (handler *Handler) getDueSoon(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	var req request.GetDueSoonServicesRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		slog.Info("invalid query parameters", "error", err)
-		c.JSON(400, gin.H{"message": "invalid query parameters", "details": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	services, err := handler.service.GetDueSoon(user, shopID, req)
+	services, err := handler.service.GetDueSoon(c.Request.Context(), user, shopID, req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -0,0 +1,88 @@
+package item_comments
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Item Comments",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listItemComments",
+			Method:   http.MethodGet,
+			Path:     "/items/:niin/comments",
+			Summary:  "List the comments on an item",
+			Response: []CommentResponse{},
+		},
+		{
+			ID:       "createItemComment",
+			Method:   http.MethodPost,
+			Path:     "/items/:niin/comments",
+			Auth:     true,
+			Summary:  "Comment on an item",
+			Request:  CreateRequest{},
+			Status:   http.StatusCreated,
+			Response: CommentResponse{},
+		},
+		{
+			ID:       "updateItemComment",
+			Method:   http.MethodPut,
+			Path:     "/items/:niin/comments/:comment_id",
+			Auth:     true,
+			Summary:  "Update an item comment",
+			Request:  UpdateRequest{},
+			Response: CommentResponse{},
+		},
+		{
+			ID:       "deleteItemComment",
+			Method:   http.MethodDelete,
+			Path:     "/items/:niin/comments/:comment_id",
+			Auth:     true,
+			Summary:  "Delete an item comment",
+			Response: CommentResponse{},
+		},
+		{
+			ID:       "flagItemComment",
+			Method:   http.MethodPost,
+			Path:     "/items/:niin/comments/:comment_id/flags",
+			Auth:     true,
+			Summary:  "Flag an item comment",
+			Response: openapi.Object{"comment_id": ""},
+		},
+	},
+}}
+
+// ModerationDocs describes the routes RegisterModerationRoutes registers.
+var ModerationDocs = []openapi.Section{{
+	Tag: "Moderation",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listFlaggedItemComments",
+			Method:   http.MethodGet,
+			Path:     "/moderation/item-comments/flagged",
+			Auth:     true,
+			Summary:  "List flagged item comments, the longest-flagged first (moderators only)",
+			Query:    pagination.Query,
+			Response: pagination.Page[FlaggedComment]{},
+		},
+		{
+			ID:       "dismissItemCommentFlags",
+			Method:   http.MethodDelete,
+			Path:     "/moderation/item-comments/:comment_id/flags",
+			Auth:     true,
+			Summary:  "Dismiss the flags on an item comment, keeping the comment (moderators only)",
+			Response: openapi.Object{"comment_id": ""},
+		},
+		{
+			ID:       "removeItemComment",
+			Method:   http.MethodDelete,
+			Path:     "/moderation/item-comments/:comment_id",
+			Auth:     true,
+			Summary:  "Replace an item comment's text with a removal notice and dismiss its flags (moderators only)",
+			Response: openapi.Object{"comment_id": ""},
+		},
+	},
+}}
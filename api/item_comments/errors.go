@@ -1,12 +1,12 @@
 package item_comments
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrInvalidNiin     = errors.New("invalid NIIN")
-	ErrInvalidText     = errors.New("invalid comment text")
-	ErrCommentNotFound = errors.New("comment not found")
-	ErrUnauthorized    = errors.New("unauthorized user")
-	ErrForbidden       = errors.New("user not authorized")
-	ErrInvalidParent   = errors.New("invalid parent comment")
+	ErrInvalidNiin     = apperror.Validation("invalid_niin", "invalid NIIN")
+	ErrInvalidText     = apperror.Validation("invalid_comment_text", "invalid comment text")
+	ErrCommentNotFound = apperror.NotFound("comment_not_found", "comment not found")
+	ErrUnauthorized    = apperror.Unauthorized("unauthorized", "unauthorized user")
+	ErrForbidden       = apperror.Forbidden("forbidden", "user not authorized")
+	ErrInvalidParent   = apperror.Validation("invalid_parent_comment", "invalid parent comment")
 )
@@ -1,15 +1,19 @@
 package item_comments
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
 
 	"github.com/google/uuid"
 )
 
 type Repository interface {
-	GetCommentsByNiin(niin string) ([]CommentWithAuthor, error)
-	GetCommentByID(commentID uuid.UUID) (*model.ItemComments, error)
-	CreateComment(comment model.ItemComments) (*model.ItemComments, error)
-	UpdateCommentText(commentID uuid.UUID, text string) (*model.ItemComments, error)
-	FlagComment(flag model.ItemCommentFlags) error
+	GetCommentsByNiin(ctx context.Context, niin string) ([]CommentWithAuthor, error)
+	GetCommentByID(ctx context.Context, commentID uuid.UUID) (*model.ItemComments, error)
+	CreateComment(ctx context.Context, comment model.ItemComments) (*model.ItemComments, error)
+	UpdateCommentText(ctx context.Context, commentID uuid.UUID, text string) (*model.ItemComments, error)
+	FlagComment(ctx context.Context, flag model.ItemCommentFlags) error
+	GetFlaggedPage(ctx context.Context, params pagination.Params) (pagination.Page[FlaggedComment], error)
+	ClearFlags(ctx context.Context, commentID uuid.UUID) error
 }
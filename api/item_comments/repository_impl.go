@@ -1,12 +1,14 @@
 package item_comments
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/pagination"
 
 	. "github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
@@ -21,7 +23,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetCommentsByNiin(niin string) ([]CommentWithAuthor, error) {
+func (repo *RepositoryImpl) GetCommentsByNiin(ctx context.Context, niin string) ([]CommentWithAuthor, error) {
 	rawSQL := `
 		SELECT
 			c.id,
@@ -38,7 +40,7 @@ func (repo *RepositoryImpl) GetCommentsByNiin(niin string) ([]CommentWithAuthor,
 		ORDER BY c.created_at ASC
 	`
 
-	rows, err := repo.db.Query(rawSQL, niin)
+	rows, err := repo.db.QueryContext(ctx, rawSQL, niin)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments by NIIN: %w", err)
 	}
@@ -112,7 +114,7 @@ func (repo *RepositoryImpl) GetCommentsByNiin(niin string) ([]CommentWithAuthor,
 	return comments, nil
 }
 
-func (repo *RepositoryImpl) GetCommentByID(commentID uuid.UUID) (*model.ItemComments, error) {
+func (repo *RepositoryImpl) GetCommentByID(ctx context.Context, commentID uuid.UUID) (*model.ItemComments, error) {
 	stmt := SELECT(
 		table.ItemComments.AllColumns,
 	).FROM(
@@ -122,7 +124,7 @@ func (repo *RepositoryImpl) GetCommentByID(commentID uuid.UUID) (*model.ItemComm
 	)
 
 	var comment model.ItemComments
-	err := stmt.Query(repo.db, &comment)
+	err := stmt.QueryContext(ctx, repo.db, &comment)
 	if err != nil {
 		if err == qrm.ErrNoRows {
 			return nil, nil
@@ -133,7 +135,7 @@ func (repo *RepositoryImpl) GetCommentByID(commentID uuid.UUID) (*model.ItemComm
 	return &comment, nil
 }
 
-func (repo *RepositoryImpl) CreateComment(comment model.ItemComments) (*model.ItemComments, error) {
+func (repo *RepositoryImpl) CreateComment(ctx context.Context, comment model.ItemComments) (*model.ItemComments, error) {
 	parentValue := NULL
 	if comment.ParentID != nil {
 		parentValue = UUID(*comment.ParentID)
@@ -154,7 +156,7 @@ func (repo *RepositoryImpl) CreateComment(comment model.ItemComments) (*model.It
 	)
 
 	var created model.ItemComments
-	err := stmt.Query(repo.db, &created)
+	err := stmt.QueryContext(ctx, repo.db, &created)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
@@ -162,7 +164,104 @@ func (repo *RepositoryImpl) CreateComment(comment model.ItemComments) (*model.It
 	return &created, nil
 }
 
-func (repo *RepositoryImpl) UpdateCommentText(commentID uuid.UUID, text string) (*model.ItemComments, error) {
+// GetFlaggedPage reads flagged comments, the longest-flagged first.
+func (repo *RepositoryImpl) GetFlaggedPage(ctx context.Context, params pagination.Params) (pagination.Page[FlaggedComment], error) {
+	var afterFlaggedAt time.Time
+	var afterID string
+	after, err := params.After(&afterFlaggedAt, &afterID)
+	if err != nil {
+		return pagination.Page[FlaggedComment]{}, err
+	}
+
+	query := `
+		WITH flagged AS (
+			SELECT comment_id, COUNT(*) AS flag_count, MIN(created_at) AS first_flagged_at
+			FROM item_comment_flags
+			GROUP BY comment_id
+		)
+		SELECT c.id, c.comment_niin, c.author_id, c.text, c.parent_id, c.created_at,
+			u.username, f.flag_count, f.first_flagged_at
+		FROM flagged f
+		JOIN item_comments c ON c.id = f.comment_id
+		LEFT JOIN users u ON c.author_id = u.uid`
+	var args []any
+	if after {
+		args = append(args, afterFlaggedAt, afterID)
+		query += ` WHERE (f.first_flagged_at, c.id::text) > ($1, $2)`
+	}
+	args = append(args, params.Fetch())
+	query += fmt.Sprintf(` ORDER BY f.first_flagged_at, c.id::text LIMIT $%d`, len(args))
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return pagination.Page[FlaggedComment]{}, fmt.Errorf("failed to get flagged comments: %w", err)
+	}
+	defer rows.Close()
+
+	var flagged []FlaggedComment
+	for rows.Next() {
+		var (
+			comment  model.ItemComments
+			parentID sql.NullString
+			username sql.NullString
+			item     FlaggedComment
+		)
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.CommentNiin,
+			&comment.AuthorID,
+			&comment.Text,
+			&parentID,
+			&comment.CreatedAt,
+			&username,
+			&item.FlagCount,
+			&item.FirstFlaggedAt,
+		); err != nil {
+			return pagination.Page[FlaggedComment]{}, fmt.Errorf("failed to scan flagged comment: %w", err)
+		}
+		if parentID.Valid {
+			parsed, err := uuid.Parse(parentID.String)
+			if err != nil {
+				return pagination.Page[FlaggedComment]{}, fmt.Errorf("failed to parse parent_id: %w", err)
+			}
+			comment.ParentID = &parsed
+		}
+
+		var authorDisplayName *string
+		if username.Valid {
+			authorDisplayName = &username.String
+		}
+		item.CommentResponse = mapCommentWithAuthor(CommentWithAuthor{ItemComments: comment, AuthorDisplayName: authorDisplayName})
+		flagged = append(flagged, item)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Page[FlaggedComment]{}, fmt.Errorf("error iterating flagged comments: %w", err)
+	}
+
+	page := pagination.NewPage(flagged, params, func(item FlaggedComment) []any {
+		return []any{item.FirstFlaggedAt, item.ID}
+	})
+	if !params.IncludeTotal {
+		return page, nil
+	}
+
+	var count int
+	err = repo.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT comment_id) FROM item_comment_flags`).Scan(&count)
+	if err != nil {
+		return pagination.Page[FlaggedComment]{}, fmt.Errorf("failed to count flagged comments: %w", err)
+	}
+	return page.WithTotal(count), nil
+}
+
+func (repo *RepositoryImpl) ClearFlags(ctx context.Context, commentID uuid.UUID) error {
+	_, err := repo.db.ExecContext(ctx, `DELETE FROM item_comment_flags WHERE comment_id = $1`, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to clear comment flags: %w", err)
+	}
+	return nil
+}
+
+func (repo *RepositoryImpl) UpdateCommentText(ctx context.Context, commentID uuid.UUID, text string) (*model.ItemComments, error) {
 	now := time.Now()
 
 	stmt := table.ItemComments.UPDATE().
@@ -174,7 +273,7 @@ func (repo *RepositoryImpl) UpdateCommentText(commentID uuid.UUID, text string)
 		RETURNING(table.ItemComments.AllColumns)
 
 	var updated model.ItemComments
-	err := stmt.Query(repo.db, &updated)
+	err := stmt.QueryContext(ctx, repo.db, &updated)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update comment: %w", err)
 	}
@@ -182,7 +281,7 @@ func (repo *RepositoryImpl) UpdateCommentText(commentID uuid.UUID, text string)
 	return &updated, nil
 }
 
-func (repo *RepositoryImpl) FlagComment(flag model.ItemCommentFlags) error {
+func (repo *RepositoryImpl) FlagComment(ctx context.Context, flag model.ItemCommentFlags) error {
 	stmt := table.ItemCommentFlags.INSERT(
 		table.ItemCommentFlags.CommentID,
 		table.ItemCommentFlags.FlaggerID,
@@ -194,7 +293,7 @@ func (repo *RepositoryImpl) FlagComment(flag model.ItemCommentFlags) error {
 		table.ItemCommentFlags.FlaggerID,
 	).DO_NOTHING()
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("failed to flag comment: %w", err)
 	}
@@ -2,15 +2,18 @@ package item_comments
 
 import (
 	"database/sql"
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
 )
 
+const defaultFlaggedLimit = 50
+
 type Dependencies struct {
 	DB *sql.DB
 }
@@ -25,6 +28,20 @@ func RegisterRoutes(deps Dependencies, publicGroup, authGroup *gin.RouterGroup)
 	registerHandlers(publicGroup, authGroup, svc)
 }
 
+// RegisterModerationRoutes mounts the moderator routes on moderationGroup,
+// which must already require the moderator role.
+func RegisterModerationRoutes(deps Dependencies, moderationGroup *gin.RouterGroup) {
+	registerModerationHandlers(moderationGroup, NewService(NewRepository(deps.DB)))
+}
+
+func registerModerationHandlers(moderationGroup *gin.RouterGroup, svc Service) {
+	handler := Handler{service: svc}
+
+	moderationGroup.GET("/item-comments/flagged", handler.getFlaggedComments)
+	moderationGroup.DELETE("/item-comments/:comment_id/flags", handler.dismissFlags)
+	moderationGroup.DELETE("/item-comments/:comment_id", handler.removeComment)
+}
+
 func registerHandlers(publicGroup, authGroup *gin.RouterGroup, svc Service) {
 	handler := Handler{service: svc}
 
@@ -38,14 +55,9 @@ func registerHandlers(publicGroup, authGroup *gin.RouterGroup, svc Service) {
 
 func (handler *Handler) getCommentsByNiin(c *gin.Context) {
 	niin := c.Param("niin")
-	comments, err := handler.service.GetCommentsByNiin(niin)
+	comments, err := handler.service.GetCommentsByNiin(c.Request.Context(), niin)
 	if err != nil {
-		if respondError(c, err, []errorCase{
-			{target: ErrInvalidNiin, status: http.StatusBadRequest, message: "invalid NIIN"},
-		}) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -66,21 +78,13 @@ func (handler *Handler) createComment(c *gin.Context) {
 
 	var req CreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	comment, err := handler.service.CreateComment(currentUser, niin, req.Text, req.ParentID)
+	comment, err := handler.service.CreateComment(c.Request.Context(), currentUser, niin, req.Text, req.ParentID)
 	if err != nil {
-		if respondError(c, err, []errorCase{
-			{target: ErrInvalidNiin, status: http.StatusBadRequest, message: "invalid NIIN"},
-			{target: ErrInvalidText, status: http.StatusBadRequest, message: "invalid comment text"},
-			{target: ErrInvalidParent, status: http.StatusBadRequest, message: "invalid parent comment"},
-			{target: ErrUnauthorized, status: http.StatusUnauthorized, message: "unauthorized"},
-		}) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -102,22 +106,13 @@ func (handler *Handler) updateComment(c *gin.Context) {
 
 	var req UpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	comment, err := handler.service.UpdateComment(currentUser, niin, commentID, req.Text)
+	comment, err := handler.service.UpdateComment(c.Request.Context(), currentUser, niin, commentID, req.Text)
 	if err != nil {
-		if respondError(c, err, []errorCase{
-			{target: ErrInvalidNiin, status: http.StatusBadRequest, message: "invalid NIIN"},
-			{target: ErrInvalidText, status: http.StatusBadRequest, message: "invalid comment text"},
-			{target: ErrCommentNotFound, status: http.StatusNotFound, message: "comment not found"},
-			{target: ErrForbidden, status: http.StatusForbidden, message: "forbidden"},
-			{target: ErrUnauthorized, status: http.StatusUnauthorized, message: "unauthorized"},
-		}) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -137,17 +132,9 @@ func (handler *Handler) deleteComment(c *gin.Context) {
 	niin := c.Param("niin")
 	commentID := c.Param("comment_id")
 
-	comment, err := handler.service.DeleteComment(currentUser, niin, commentID)
+	comment, err := handler.service.DeleteComment(c.Request.Context(), currentUser, niin, commentID)
 	if err != nil {
-		if respondError(c, err, []errorCase{
-			{target: ErrInvalidNiin, status: http.StatusBadRequest, message: "invalid NIIN"},
-			{target: ErrCommentNotFound, status: http.StatusNotFound, message: "comment not found"},
-			{target: ErrForbidden, status: http.StatusForbidden, message: "forbidden"},
-			{target: ErrUnauthorized, status: http.StatusUnauthorized, message: "unauthorized"},
-		}) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -167,16 +154,9 @@ func (handler *Handler) flagComment(c *gin.Context) {
 	niin := c.Param("niin")
 	commentID := c.Param("comment_id")
 
-	err := handler.service.FlagComment(currentUser, niin, commentID)
+	err := handler.service.FlagComment(c.Request.Context(), currentUser, niin, commentID)
 	if err != nil {
-		if respondError(c, err, []errorCase{
-			{target: ErrInvalidNiin, status: http.StatusBadRequest, message: "invalid NIIN"},
-			{target: ErrCommentNotFound, status: http.StatusNotFound, message: "comment not found"},
-			{target: ErrUnauthorized, status: http.StatusUnauthorized, message: "unauthorized"},
-		}) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -187,35 +167,68 @@ func (handler *Handler) flagComment(c *gin.Context) {
 	})
 }
 
+func (handler *Handler) getFlaggedComments(c *gin.Context) {
+	params, err := pagination.FromQuery(c, defaultFlaggedLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	flagged, err := handler.service.GetFlaggedComments(c.Request.Context(), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "Flagged comments retrieved",
+		Data:    flagged,
+	})
+}
+
+func (handler *Handler) dismissFlags(c *gin.Context) {
+	commentID := c.Param("comment_id")
+
+	if err := handler.service.DismissFlags(c.Request.Context(), commentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "Comment flags dismissed",
+		Data:    gin.H{"comment_id": commentID},
+	})
+}
+
+func (handler *Handler) removeComment(c *gin.Context) {
+	commentID := c.Param("comment_id")
+
+	if err := handler.service.RemoveComment(c.Request.Context(), commentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "Comment removed",
+		Data:    gin.H{"comment_id": commentID},
+	})
+}
+
 func getUser(c *gin.Context) (*bootstrap.User, bool) {
 	user, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return nil, false
 	}
 
 	currentUser, ok := user.(*bootstrap.User)
 	if !ok || currentUser == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return nil, false
 	}
 
 	return currentUser, true
 }
-
-type errorCase struct {
-	target  error
-	status  int
-	message string
-}
-
-func respondError(c *gin.Context, err error, cases []errorCase) bool {
-	for _, entry := range cases {
-		if errors.Is(err, entry.target) {
-			c.JSON(entry.status, gin.H{"message": entry.message})
-			return true
-		}
-	}
-
-	return false
-}
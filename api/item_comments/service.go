@@ -1,11 +1,20 @@
 package item_comments
 
-import "miltechserver/bootstrap"
+import (
+	"context"
+	"miltechserver/api/pagination"
+	"miltechserver/bootstrap"
+)
 
 type Service interface {
-	GetCommentsByNiin(niin string) ([]CommentResponse, error)
-	CreateComment(user *bootstrap.User, niin string, text string, parentID *string) (*CommentResponse, error)
-	UpdateComment(user *bootstrap.User, niin string, commentID string, text string) (*CommentResponse, error)
-	DeleteComment(user *bootstrap.User, niin string, commentID string) (*CommentResponse, error)
-	FlagComment(user *bootstrap.User, niin string, commentID string) error
+	GetCommentsByNiin(ctx context.Context, niin string) ([]CommentResponse, error)
+	CreateComment(ctx context.Context, user *bootstrap.User, niin string, text string, parentID *string) (*CommentResponse, error)
+	UpdateComment(ctx context.Context, user *bootstrap.User, niin string, commentID string, text string) (*CommentResponse, error)
+	DeleteComment(ctx context.Context, user *bootstrap.User, niin string, commentID string) (*CommentResponse, error)
+	FlagComment(ctx context.Context, user *bootstrap.User, niin string, commentID string) error
+
+	// Moderation. Only moderators reach these.
+	GetFlaggedComments(ctx context.Context, params pagination.Params) (pagination.Page[FlaggedComment], error)
+	DismissFlags(ctx context.Context, commentID string) error
+	RemoveComment(ctx context.Context, commentID string) error
 }
@@ -1,9 +1,11 @@
 package item_comments
 
 import (
+	"context"
 	"strings"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
 	"miltechserver/bootstrap"
 
 	"github.com/google/uuid"
@@ -11,6 +13,8 @@ import (
 
 const deletedCommentText = "Deleted by user"
 
+const removedCommentText = "Removed by moderator"
+
 type ServiceImpl struct {
 	repo Repository
 }
@@ -19,13 +23,13 @@ func NewService(repo Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo}
 }
 
-func (service *ServiceImpl) GetCommentsByNiin(niin string) ([]CommentResponse, error) {
+func (service *ServiceImpl) GetCommentsByNiin(ctx context.Context, niin string) ([]CommentResponse, error) {
 	normalized, err := validateNiin(niin)
 	if err != nil {
 		return nil, err
 	}
 
-	comments, err := service.repo.GetCommentsByNiin(normalized)
+	comments, err := service.repo.GetCommentsByNiin(ctx, normalized)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +42,7 @@ func (service *ServiceImpl) GetCommentsByNiin(niin string) ([]CommentResponse, e
 	return result, nil
 }
 
-func (service *ServiceImpl) CreateComment(user *bootstrap.User, niin string, text string, parentID *string) (*CommentResponse, error) {
+func (service *ServiceImpl) CreateComment(ctx context.Context, user *bootstrap.User, niin string, text string, parentID *string) (*CommentResponse, error) {
 	if user == nil {
 		return nil, ErrUnauthorized
 	}
@@ -59,7 +63,7 @@ func (service *ServiceImpl) CreateComment(user *bootstrap.User, niin string, tex
 			return nil, ErrInvalidParent
 		}
 
-		parent, parentErr := service.repo.GetCommentByID(parsed)
+		parent, parentErr := service.repo.GetCommentByID(ctx, parsed)
 		if parentErr != nil {
 			return nil, parentErr
 		}
@@ -76,7 +80,7 @@ func (service *ServiceImpl) CreateComment(user *bootstrap.User, niin string, tex
 		ParentID:    parentUUID,
 	}
 
-	created, err := service.repo.CreateComment(comment)
+	created, err := service.repo.CreateComment(ctx, comment)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +89,7 @@ func (service *ServiceImpl) CreateComment(user *bootstrap.User, niin string, tex
 	return &resp, nil
 }
 
-func (service *ServiceImpl) UpdateComment(user *bootstrap.User, niin string, commentID string, text string) (*CommentResponse, error) {
+func (service *ServiceImpl) UpdateComment(ctx context.Context, user *bootstrap.User, niin string, commentID string, text string) (*CommentResponse, error) {
 	if user == nil {
 		return nil, ErrUnauthorized
 	}
@@ -104,7 +108,7 @@ func (service *ServiceImpl) UpdateComment(user *bootstrap.User, niin string, com
 		return nil, ErrCommentNotFound
 	}
 
-	existing, err := service.repo.GetCommentByID(commentUUID)
+	existing, err := service.repo.GetCommentByID(ctx, commentUUID)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +119,7 @@ func (service *ServiceImpl) UpdateComment(user *bootstrap.User, niin string, com
 		return nil, ErrForbidden
 	}
 
-	updated, err := service.repo.UpdateCommentText(commentUUID, text)
+	updated, err := service.repo.UpdateCommentText(ctx, commentUUID, text)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +128,7 @@ func (service *ServiceImpl) UpdateComment(user *bootstrap.User, niin string, com
 	return &resp, nil
 }
 
-func (service *ServiceImpl) DeleteComment(user *bootstrap.User, niin string, commentID string) (*CommentResponse, error) {
+func (service *ServiceImpl) DeleteComment(ctx context.Context, user *bootstrap.User, niin string, commentID string) (*CommentResponse, error) {
 	if user == nil {
 		return nil, ErrUnauthorized
 	}
@@ -139,7 +143,7 @@ func (service *ServiceImpl) DeleteComment(user *bootstrap.User, niin string, com
 		return nil, ErrCommentNotFound
 	}
 
-	existing, err := service.repo.GetCommentByID(commentUUID)
+	existing, err := service.repo.GetCommentByID(ctx, commentUUID)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +154,7 @@ func (service *ServiceImpl) DeleteComment(user *bootstrap.User, niin string, com
 		return nil, ErrForbidden
 	}
 
-	updated, err := service.repo.UpdateCommentText(commentUUID, deletedCommentText)
+	updated, err := service.repo.UpdateCommentText(ctx, commentUUID, deletedCommentText)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +163,7 @@ func (service *ServiceImpl) DeleteComment(user *bootstrap.User, niin string, com
 	return &resp, nil
 }
 
-func (service *ServiceImpl) FlagComment(user *bootstrap.User, niin string, commentID string) error {
+func (service *ServiceImpl) FlagComment(ctx context.Context, user *bootstrap.User, niin string, commentID string) error {
 	if user == nil {
 		return ErrUnauthorized
 	}
@@ -174,7 +178,7 @@ func (service *ServiceImpl) FlagComment(user *bootstrap.User, niin string, comme
 		return ErrCommentNotFound
 	}
 
-	existing, err := service.repo.GetCommentByID(commentUUID)
+	existing, err := service.repo.GetCommentByID(ctx, commentUUID)
 	if err != nil {
 		return err
 	}
@@ -187,7 +191,49 @@ func (service *ServiceImpl) FlagComment(user *bootstrap.User, niin string, comme
 		FlaggerID: user.UserID,
 	}
 
-	return service.repo.FlagComment(flag)
+	return service.repo.FlagComment(ctx, flag)
+}
+
+func (service *ServiceImpl) GetFlaggedComments(ctx context.Context, params pagination.Params) (pagination.Page[FlaggedComment], error) {
+	return service.repo.GetFlaggedPage(ctx, params)
+}
+
+func (service *ServiceImpl) DismissFlags(ctx context.Context, commentID string) error {
+	commentUUID, err := service.existingComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	return service.repo.ClearFlags(ctx, commentUUID)
+}
+
+// RemoveComment replaces the comment's text, as its author deleting it does,
+// and clears its flags so it leaves the moderation queue.
+func (service *ServiceImpl) RemoveComment(ctx context.Context, commentID string) error {
+	commentUUID, err := service.existingComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := service.repo.UpdateCommentText(ctx, commentUUID, removedCommentText); err != nil {
+		return err
+	}
+	return service.repo.ClearFlags(ctx, commentUUID)
+}
+
+func (service *ServiceImpl) existingComment(ctx context.Context, commentID string) (uuid.UUID, error) {
+	commentUUID, err := uuid.Parse(commentID)
+	if err != nil {
+		return uuid.UUID{}, ErrCommentNotFound
+	}
+
+	existing, err := service.repo.GetCommentByID(ctx, commentUUID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if existing == nil {
+		return uuid.UUID{}, ErrCommentNotFound
+	}
+	return commentUUID, nil
 }
 
 func validateNiin(niin string) (string, error) {
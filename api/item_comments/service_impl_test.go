@@ -1,9 +1,11 @@
 package item_comments
 
 import (
+	"context"
 	"testing"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
 	"miltechserver/bootstrap"
 
 	"github.com/google/uuid"
@@ -11,34 +13,44 @@ import (
 )
 
 type captureRepository struct {
-	getNiin       string
-	commentByID   *model.ItemComments
+	getNiin        string
+	commentByID    *model.ItemComments
 	commentByIDErr error
-	created       *model.ItemComments
-	updated       *model.ItemComments
+	created        *model.ItemComments
+	updated        *model.ItemComments
+	cleared        *uuid.UUID
 }
 
-func (repo *captureRepository) GetCommentsByNiin(niin string) ([]CommentWithAuthor, error) {
+func (repo *captureRepository) GetCommentsByNiin(_ context.Context, niin string) ([]CommentWithAuthor, error) {
 	repo.getNiin = niin
 	return []CommentWithAuthor{}, nil
 }
 
-func (repo *captureRepository) GetCommentByID(commentID uuid.UUID) (*model.ItemComments, error) {
+func (repo *captureRepository) GetCommentByID(_ context.Context, commentID uuid.UUID) (*model.ItemComments, error) {
 	return repo.commentByID, repo.commentByIDErr
 }
 
-func (repo *captureRepository) CreateComment(comment model.ItemComments) (*model.ItemComments, error) {
+func (repo *captureRepository) CreateComment(_ context.Context, comment model.ItemComments) (*model.ItemComments, error) {
 	repo.created = &comment
 	return &comment, nil
 }
 
-func (repo *captureRepository) UpdateCommentText(commentID uuid.UUID, text string) (*model.ItemComments, error) {
+func (repo *captureRepository) UpdateCommentText(_ context.Context, commentID uuid.UUID, text string) (*model.ItemComments, error) {
 	comment := model.ItemComments{ID: commentID, Text: text}
 	repo.updated = &comment
 	return &comment, nil
 }
 
-func (repo *captureRepository) FlagComment(flag model.ItemCommentFlags) error {
+func (repo *captureRepository) FlagComment(_ context.Context, flag model.ItemCommentFlags) error {
+	return nil
+}
+
+func (repo *captureRepository) GetFlaggedPage(_ context.Context, params pagination.Params) (pagination.Page[FlaggedComment], error) {
+	return pagination.Page[FlaggedComment]{}, nil
+}
+
+func (repo *captureRepository) ClearFlags(_ context.Context, commentID uuid.UUID) error {
+	repo.cleared = &commentID
 	return nil
 }
 
@@ -46,7 +58,7 @@ func TestGetCommentsByNiinNormalizes(t *testing.T) {
 	repo := &captureRepository{}
 	svc := NewService(repo)
 
-	_, err := svc.GetCommentsByNiin(" 123456789 ")
+	_, err := svc.GetCommentsByNiin(context.Background(), " 123456789 ")
 	require.NoError(t, err)
 	require.Equal(t, "123456789", repo.getNiin)
 }
@@ -56,7 +68,7 @@ func TestCreateCommentValidatesText(t *testing.T) {
 	svc := NewService(repo)
 	user := &bootstrap.User{UserID: "user-1", Username: "test"}
 
-	_, err := svc.CreateComment(user, "123456789", "", nil)
+	_, err := svc.CreateComment(context.Background(), user, "123456789", "", nil)
 	require.ErrorIs(t, err, ErrInvalidText)
 }
 
@@ -66,7 +78,7 @@ func TestCreateCommentValidatesParent(t *testing.T) {
 	user := &bootstrap.User{UserID: "user-1", Username: "test"}
 
 	parentID := "not-a-uuid"
-	_, err := svc.CreateComment(user, "123456789", "hi", &parentID)
+	_, err := svc.CreateComment(context.Background(), user, "123456789", "hi", &parentID)
 	require.ErrorIs(t, err, ErrInvalidParent)
 }
 
@@ -82,7 +94,7 @@ func TestUpdateCommentForbidden(t *testing.T) {
 	svc := NewService(repo)
 	user := &bootstrap.User{UserID: "user-1", Username: "test"}
 
-	_, err := svc.UpdateComment(user, "123456789", commentID.String(), "text")
+	_, err := svc.UpdateComment(context.Background(), user, "123456789", commentID.String(), "text")
 	require.ErrorIs(t, err, ErrForbidden)
 }
 
@@ -98,7 +110,7 @@ func TestUpdateCommentInvalidText(t *testing.T) {
 	svc := NewService(repo)
 	user := &bootstrap.User{UserID: "user-1", Username: "test"}
 
-	_, err := svc.UpdateComment(user, "123456789", commentID.String(), "")
+	_, err := svc.UpdateComment(context.Background(), user, "123456789", commentID.String(), "")
 	require.ErrorIs(t, err, ErrInvalidText)
 }
 
@@ -107,6 +119,33 @@ func TestUpdateCommentInvalidID(t *testing.T) {
 	svc := NewService(repo)
 	user := &bootstrap.User{UserID: "user-1", Username: "test"}
 
-	_, err := svc.UpdateComment(user, "123456789", "not-a-uuid", "text")
+	_, err := svc.UpdateComment(context.Background(), user, "123456789", "not-a-uuid", "text")
 	require.ErrorIs(t, err, ErrCommentNotFound)
 }
+
+func TestDismissFlagsKeepsComment(t *testing.T) {
+	commentID := uuid.New()
+	repo := &captureRepository{commentByID: &model.ItemComments{ID: commentID, Text: "original"}}
+	svc := NewService(repo)
+
+	require.NoError(t, svc.DismissFlags(context.Background(), commentID.String()))
+	require.Equal(t, &commentID, repo.cleared)
+	require.Nil(t, repo.updated)
+}
+
+func TestRemoveCommentReplacesTextAndClearsFlags(t *testing.T) {
+	commentID := uuid.New()
+	repo := &captureRepository{commentByID: &model.ItemComments{ID: commentID, Text: "original"}}
+	svc := NewService(repo)
+
+	require.NoError(t, svc.RemoveComment(context.Background(), commentID.String()))
+	require.Equal(t, removedCommentText, repo.updated.Text)
+	require.Equal(t, &commentID, repo.cleared)
+}
+
+func TestModerationNotFound(t *testing.T) {
+	svc := NewService(&captureRepository{})
+
+	require.ErrorIs(t, svc.DismissFlags(context.Background(), "not-a-uuid"), ErrCommentNotFound)
+	require.ErrorIs(t, svc.RemoveComment(context.Background(), uuid.New().String()), ErrCommentNotFound)
+}
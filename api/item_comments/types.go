@@ -29,6 +29,13 @@ type CommentResponse struct {
 	CreatedAt         time.Time `json:"created_at"`
 }
 
+// FlaggedComment is a comment waiting in the moderation queue.
+type FlaggedComment struct {
+	CommentResponse
+	FlagCount      int       `json:"flag_count"`
+	FirstFlaggedAt time.Time `json:"first_flagged_at"`
+}
+
 // Internal types
 
 type CommentWithAuthor struct {
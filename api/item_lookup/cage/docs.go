@@ -0,0 +1,21 @@
+package cage
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Item Lookup",
+	Operations: []openapi.Operation{
+		{
+			ID:       "lookupCAGE",
+			Method:   http.MethodGet,
+			Path:     "/lookup/cage/:cage",
+			Summary:  "Look up CAGE addresses by CAGE code",
+			Response: []model.CageAddress{},
+		},
+	},
+}}
@@ -1,6 +1,7 @@
 package cage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -20,7 +21,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) SearchByCode(cage string) ([]model.CageAddress, error) {
+func (repo *RepositoryImpl) SearchByCode(ctx context.Context, cage string) ([]model.CageAddress, error) {
 	if strings.TrimSpace(cage) == "" {
 		return nil, shared.ErrEmptyParam
 	}
@@ -31,7 +32,7 @@ func (repo *RepositoryImpl) SearchByCode(cage string) ([]model.CageAddress, erro
 	).FROM(table.CageAddress).
 		WHERE(table.CageAddress.CageCode.EQ(String(cage)))
 
-	err := stmt.Query(repo.db, &cageData)
+	err := stmt.QueryContext(ctx, repo.db, &cageData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query CAGE address data: %w", err)
 	}
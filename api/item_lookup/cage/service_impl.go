@@ -1,6 +1,7 @@
 package cage
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"strings"
 )
@@ -13,6 +14,6 @@ func NewService(repo Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo}
 }
 
-func (service *ServiceImpl) LookupByCode(cage string) ([]model.CageAddress, error) {
-	return service.repo.SearchByCode(strings.ToUpper(cage))
+func (service *ServiceImpl) LookupByCode(ctx context.Context, cage string) ([]model.CageAddress, error) {
+	return service.repo.SearchByCode(ctx, strings.ToUpper(cage))
 }
@@ -0,0 +1,13 @@
+package item_lookup
+
+import (
+	"slices"
+
+	"miltechserver/api/item_lookup/cage"
+	"miltechserver/api/item_lookup/lin"
+	"miltechserver/api/item_lookup/substitute"
+	"miltechserver/api/item_lookup/uoc"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(lin.Docs, uoc.Docs, cage.Docs, substitute.Docs)
@@ -0,0 +1,52 @@
+package lin
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Item Lookup",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listLINs",
+			Method:   http.MethodGet,
+			Path:     "/lookup/lin",
+			Summary:  "List LINs",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.LookupLinNiinMat]{},
+		},
+		{
+			ID:       "lookupLINByNIIN",
+			Method:   http.MethodGet,
+			Path:     "/lookup/lin/by-niin/:niin",
+			Summary:  "Look up LINs by NIIN",
+			Response: response.LINPageResponse{},
+		},
+		{
+			ID:       "lookupLINByNIINLegacy",
+			Method:   http.MethodGet,
+			Path:     "/lookup/lin/lin/:niin",
+			Summary:  "Look up LINs by NIIN (legacy path)",
+			Response: response.LINPageResponse{},
+		},
+		{
+			ID:       "lookupNIINByLIN",
+			Method:   http.MethodGet,
+			Path:     "/lookup/niin/by-lin/:lin",
+			Summary:  "Look up NIINs by LIN",
+			Response: response.LINPageResponse{},
+		},
+		{
+			ID:       "lookupNIINByLINLegacy",
+			Method:   http.MethodGet,
+			Path:     "/lookup/lin/niin/:lin",
+			Summary:  "Look up NIINs by LIN (legacy path)",
+			Response: response.LINPageResponse{},
+		},
+	},
+}}
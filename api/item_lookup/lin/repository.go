@@ -1,12 +1,13 @@
 package lin
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
-	"miltechserver/api/response"
+	"miltechserver/api/pagination"
 )
 
 type Repository interface {
-	SearchByPage(page int) (response.LINPageResponse, error)
-	SearchByNIIN(niin string) ([]model.LookupLinNiinMat, error)
-	SearchNIINByLIN(lin string) ([]model.LookupLinNiinMat, error)
+	SearchPage(ctx context.Context, params pagination.Params) (pagination.Page[model.LookupLinNiinMat], error)
+	SearchByNIIN(ctx context.Context, niin string) ([]model.LookupLinNiinMat, error)
+	SearchNIINByLIN(ctx context.Context, lin string) ([]model.LookupLinNiinMat, error)
 }
@@ -1,14 +1,15 @@
 package lin
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/.gen/miltech_ng/public/view"
 	"miltechserver/api/item_lookup/shared"
-	"miltechserver/api/response"
+	"miltechserver/api/pagination"
+	"miltechserver/cache"
 	"strings"
-	"sync"
 	"time"
 
 	. "github.com/go-jet/jet/v2/postgres"
@@ -17,55 +18,54 @@ import (
 const countCacheTTL = 15 * 24 * time.Hour
 
 type RepositoryImpl struct {
-	db         *sql.DB
-	countMu    sync.RWMutex
-	countCache int
-	countSetAt time.Time
+	db *sql.DB
+	// count holds the total number of LINs, which only changes with a FedLog load
+	count *cache.Cache[struct{}, int]
 }
 
 func NewRepository(db *sql.DB) *RepositoryImpl {
-	return &RepositoryImpl{db: db}
-}
-
-func (repo *RepositoryImpl) getCachedCount() (int, bool) {
-	repo.countMu.RLock()
-	defer repo.countMu.RUnlock()
-
-	if repo.countCache > 0 && time.Since(repo.countSetAt) < countCacheTTL {
-		return repo.countCache, true
+	return &RepositoryImpl{
+		db:    db,
+		count: cache.New[struct{}, int](cache.Options{Name: "lin_count", Capacity: 1, TTL: countCacheTTL}),
 	}
-	return 0, false
 }
 
-func (repo *RepositoryImpl) setCachedCount(count int) {
-	repo.countMu.Lock()
-	defer repo.countMu.Unlock()
-
-	repo.countCache = count
-	repo.countSetAt = time.Now()
+// FlushCache drops the cached LIN count, for a new FedLog load.
+func (repo *RepositoryImpl) FlushCache() {
+	repo.count.Purge()
 }
 
-func (repo *RepositoryImpl) SearchByPage(page int) (response.LINPageResponse, error) {
-	if page < 1 {
-		return response.LINPageResponse{}, shared.ErrInvalidPage
+func (repo *RepositoryImpl) SearchPage(ctx context.Context, params pagination.Params) (pagination.Page[model.LookupLinNiinMat], error) {
+	var afterLIN, afterNIIN string
+	after, err := params.After(&afterLIN, &afterNIIN)
+	if err != nil {
+		return pagination.Page[model.LookupLinNiinMat]{}, err
 	}
 
 	var linData []model.LookupLinNiinMat
-	offset := shared.CalculateOffset(page, shared.DefaultPageSize)
 	stmt := SELECT(
 		view.LookupLinNiinMat.AllColumns,
 	).FROM(view.LookupLinNiinMat).
 		ORDER_BY(view.LookupLinNiinMat.Lin.ASC(), view.LookupLinNiinMat.Niin.ASC()).
-		LIMIT(shared.DefaultPageSize).
-		OFFSET(offset)
+		LIMIT(params.Fetch())
+	if after {
+		stmt = stmt.WHERE(ROW(view.LookupLinNiinMat.Lin, view.LookupLinNiinMat.Niin).
+			GT(ROW(String(afterLIN), String(afterNIIN))))
+	}
 
-	err := stmt.Query(repo.db, &linData)
+	err = stmt.QueryContext(ctx, repo.db, &linData)
 	if err != nil {
-		return response.LINPageResponse{}, fmt.Errorf("failed to query LIN data: %w", err)
+		return pagination.Page[model.LookupLinNiinMat]{}, fmt.Errorf("failed to query LIN data: %w", err)
 	}
 
-	totalCount, ok := repo.getCachedCount()
-	if !ok {
+	page := pagination.NewPage(linData, params, func(item model.LookupLinNiinMat) []any {
+		return []any{item.Lin, item.Niin}
+	})
+	if !params.IncludeTotal {
+		return page, nil
+	}
+
+	totalCount, err := repo.count.GetOrLoad(ctx, struct{}{}, func(ctx context.Context) (int, error) {
 		var count struct {
 			Count int
 		}
@@ -74,30 +74,19 @@ func (repo *RepositoryImpl) SearchByPage(page int) (response.LINPageResponse, er
 			COUNT(view.LookupLinNiinMat.Lin),
 		).FROM(view.LookupLinNiinMat)
 
-		err = countStmt.Query(repo.db, &count)
-		if err != nil {
-			return response.LINPageResponse{}, fmt.Errorf("failed to get total LIN count: %w", err)
+		if err := countStmt.QueryContext(ctx, repo.db, &count); err != nil {
+			return 0, fmt.Errorf("failed to get total LIN count: %w", err)
 		}
-
-		totalCount = count.Count
-		repo.setCachedCount(totalCount)
-	}
-
-	if len(linData) == 0 {
-		return response.LINPageResponse{}, shared.ErrNotFound
+		return count.Count, nil
+	})
+	if err != nil {
+		return pagination.Page[model.LookupLinNiinMat]{}, err
 	}
 
-	totalPages := shared.CalculateTotalPages(totalCount, shared.DefaultPageSize)
-	return response.LINPageResponse{
-		Lins:       linData,
-		Count:      totalCount,
-		Page:       page,
-		TotalPages: totalPages,
-		IsLastPage: page >= totalPages,
-	}, nil
+	return page.WithTotal(totalCount), nil
 }
 
-func (repo *RepositoryImpl) SearchByNIIN(niin string) ([]model.LookupLinNiinMat, error) {
+func (repo *RepositoryImpl) SearchByNIIN(ctx context.Context, niin string) ([]model.LookupLinNiinMat, error) {
 	if strings.TrimSpace(niin) == "" {
 		return nil, shared.ErrEmptyParam
 	}
@@ -108,7 +97,7 @@ func (repo *RepositoryImpl) SearchByNIIN(niin string) ([]model.LookupLinNiinMat,
 		FROM(view.LookupLinNiinMat).
 		WHERE(view.LookupLinNiinMat.Niin.LIKE(String("%" + niin + "%")))
 
-	err := stmt.Query(repo.db, &linData)
+	err := stmt.QueryContext(ctx, repo.db, &linData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query LIN data by NIIN: %w", err)
 	}
@@ -120,7 +109,7 @@ func (repo *RepositoryImpl) SearchByNIIN(niin string) ([]model.LookupLinNiinMat,
 	return linData, nil
 }
 
-func (repo *RepositoryImpl) SearchNIINByLIN(lin string) ([]model.LookupLinNiinMat, error) {
+func (repo *RepositoryImpl) SearchNIINByLIN(ctx context.Context, lin string) ([]model.LookupLinNiinMat, error) {
 	if strings.TrimSpace(lin) == "" {
 		return nil, shared.ErrEmptyParam
 	}
@@ -131,7 +120,7 @@ func (repo *RepositoryImpl) SearchNIINByLIN(lin string) ([]model.LookupLinNiinMa
 		FROM(view.LookupLinNiinMat).
 		WHERE(view.LookupLinNiinMat.Lin.LIKE(String("%" + lin + "%")))
 
-	err := stmt.Query(repo.db, &linData)
+	err := stmt.QueryContext(ctx, repo.db, &linData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query NIIN data by LIN: %w", err)
 	}
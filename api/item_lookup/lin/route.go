@@ -1,23 +1,21 @@
 package lin
 
 import (
-	"strconv"
-
 	"miltechserver/api/item_lookup/shared"
+	"miltechserver/api/pagination"
 
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/lin", func(c *gin.Context) {
-		pageStr := c.Query("page")
-		page, err := strconv.Atoi(pageStr)
+		params, err := pagination.FromQuery(c, shared.DefaultLimit)
 		if err != nil {
-			shared.HandleError(c, shared.ErrInvalidPage)
+			shared.HandleError(c, err)
 			return
 		}
 
-		linData, err := service.LookupByPage(page)
+		linData, err := service.LookupPage(c.Request.Context(), params)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
@@ -29,7 +27,7 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/lin/by-niin/:niin", func(c *gin.Context) {
 		niin := c.Param("niin")
 
-		linData, err := service.LookupByNIIN(niin)
+		linData, err := service.LookupByNIIN(c.Request.Context(), niin)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
@@ -41,7 +39,7 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/lin/lin/:niin", func(c *gin.Context) {
 		niin := c.Param("niin")
 
-		linData, err := service.LookupByNIIN(niin)
+		linData, err := service.LookupByNIIN(c.Request.Context(), niin)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
@@ -53,7 +51,7 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/niin/by-lin/:lin", func(c *gin.Context) {
 		lin := c.Param("lin")
 
-		niinData, err := service.LookupNIINByLIN(lin)
+		niinData, err := service.LookupNIINByLIN(c.Request.Context(), lin)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
@@ -65,7 +63,7 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/lin/niin/:lin", func(c *gin.Context) {
 		lin := c.Param("lin")
 
-		niinData, err := service.LookupNIINByLIN(lin)
+		niinData, err := service.LookupNIINByLIN(c.Request.Context(), lin)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
@@ -1,9 +1,14 @@
 package lin
 
-import "miltechserver/api/response"
+import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+)
 
 type Service interface {
-	LookupByPage(page int) (response.LINPageResponse, error)
-	LookupByNIIN(niin string) (response.LINPageResponse, error)
-	LookupNIINByLIN(lin string) (response.LINPageResponse, error)
+	LookupPage(ctx context.Context, params pagination.Params) (pagination.Page[model.LookupLinNiinMat], error)
+	LookupByNIIN(ctx context.Context, niin string) (response.LINPageResponse, error)
+	LookupNIINByLIN(ctx context.Context, lin string) (response.LINPageResponse, error)
 }
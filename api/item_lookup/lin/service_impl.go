@@ -1,6 +1,9 @@
 package lin
 
 import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 	"strings"
 )
@@ -13,12 +16,12 @@ func NewService(repo Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo}
 }
 
-func (service *ServiceImpl) LookupByPage(page int) (response.LINPageResponse, error) {
-	return service.repo.SearchByPage(page)
+func (service *ServiceImpl) LookupPage(ctx context.Context, params pagination.Params) (pagination.Page[model.LookupLinNiinMat], error) {
+	return service.repo.SearchPage(ctx, params)
 }
 
-func (service *ServiceImpl) LookupByNIIN(niin string) (response.LINPageResponse, error) {
-	linData, err := service.repo.SearchByNIIN(niin)
+func (service *ServiceImpl) LookupByNIIN(ctx context.Context, niin string) (response.LINPageResponse, error) {
+	linData, err := service.repo.SearchByNIIN(ctx, niin)
 	if err != nil {
 		return response.LINPageResponse{}, err
 	}
@@ -32,8 +35,8 @@ func (service *ServiceImpl) LookupByNIIN(niin string) (response.LINPageResponse,
 	}, nil
 }
 
-func (service *ServiceImpl) LookupNIINByLIN(lin string) (response.LINPageResponse, error) {
-	linData, err := service.repo.SearchNIINByLIN(strings.ToUpper(lin))
+func (service *ServiceImpl) LookupNIINByLIN(ctx context.Context, lin string) (response.LINPageResponse, error) {
+	linData, err := service.repo.SearchNIINByLIN(ctx, strings.ToUpper(lin))
 	if err != nil {
 		return response.LINPageResponse{}, err
 	}
@@ -7,16 +7,19 @@ import (
 	"miltechserver/api/item_lookup/lin"
 	"miltechserver/api/item_lookup/substitute"
 	"miltechserver/api/item_lookup/uoc"
+	"miltechserver/dataversion"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Dependencies struct {
-	DB *sql.DB
+	DB          *sql.DB
+	DataVersion *dataversion.Watcher
 }
 
 func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
 	linRepo := lin.NewRepository(deps.DB)
+	deps.DataVersion.OnChange("item_lookup LIN count", linRepo.FlushCache)
 	linService := lin.NewService(linRepo)
 	lin.RegisterRoutes(router, linService)
 
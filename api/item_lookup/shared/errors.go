@@ -1,9 +1,8 @@
 package shared
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrNotFound    = errors.New("no items found")
-	ErrEmptyParam  = errors.New("required parameter is empty")
-	ErrInvalidPage = errors.New("page number must be greater than 0")
+	ErrNotFound   = apperror.NotFound("no_items_found", "no items found")
+	ErrEmptyParam = apperror.Validation("required_parameter_empty", "required parameter is empty")
 )
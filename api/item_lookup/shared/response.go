@@ -1,7 +1,6 @@
 package shared
 
 import (
-	"errors"
 	"net/http"
 
 	"miltechserver/api/response"
@@ -9,16 +8,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// HandleError records err for middleware.ErrorHandler, which renders it as
+// problem+json.
 func HandleError(c *gin.Context, err error) {
-	if errors.Is(err, ErrNotFound) {
-		c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		return
-	}
-	if errors.Is(err, ErrEmptyParam) || errors.Is(err, ErrInvalidPage) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+	c.Error(err)
 }
 
 func WriteSuccess(c *gin.Context, data interface{}) {
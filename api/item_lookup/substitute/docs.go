@@ -0,0 +1,21 @@
+package substitute
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Item Lookup",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listSubstituteLINs",
+			Method:   http.MethodGet,
+			Path:     "/lookup/substitute-lin",
+			Summary:  "List substitute LINs",
+			Response: []model.ArmySubstituteLin{},
+		},
+	},
+}}
@@ -1,7 +1,10 @@
 package substitute
 
-import "miltechserver/.gen/miltech_ng/public/model"
+import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+)
 
 type Repository interface {
-	SearchAll() ([]model.ArmySubstituteLin, error)
+	SearchAll(ctx context.Context) ([]model.ArmySubstituteLin, error)
 }
@@ -1,6 +1,7 @@
 package substitute
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -19,13 +20,13 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) SearchAll() ([]model.ArmySubstituteLin, error) {
+func (repo *RepositoryImpl) SearchAll(ctx context.Context) ([]model.ArmySubstituteLin, error) {
 	var substituteData []model.ArmySubstituteLin
 	stmt := SELECT(
 		table.ArmySubstituteLin.AllColumns,
 	).FROM(table.ArmySubstituteLin)
 
-	err := stmt.Query(repo.db, &substituteData)
+	err := stmt.QueryContext(ctx, repo.db, &substituteData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query substitute LIN data: %w", err)
 	}
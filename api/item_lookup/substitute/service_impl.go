@@ -1,6 +1,9 @@
 package substitute
 
-import "miltechserver/.gen/miltech_ng/public/model"
+import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+)
 
 type ServiceImpl struct {
 	repo Repository
@@ -10,6 +13,6 @@ func NewService(repo Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo}
 }
 
-func (service *ServiceImpl) LookupAll() ([]model.ArmySubstituteLin, error) {
-	return service.repo.SearchAll()
+func (service *ServiceImpl) LookupAll(ctx context.Context) ([]model.ArmySubstituteLin, error) {
+	return service.repo.SearchAll(ctx)
 }
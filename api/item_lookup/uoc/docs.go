@@ -0,0 +1,45 @@
+package uoc
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Item Lookup",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listUOCs",
+			Method:   http.MethodGet,
+			Path:     "/lookup/uoc",
+			Summary:  "List UOCs",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.LookupUoc]{},
+		},
+		{
+			ID:       "lookupUOC",
+			Method:   http.MethodGet,
+			Path:     "/lookup/uoc/:uoc",
+			Summary:  "Look up a UOC",
+			Response: response.UOCPageResponse{},
+		},
+		{
+			ID:       "lookupUOCByModel",
+			Method:   http.MethodGet,
+			Path:     "/lookup/uoc/by-model/:model",
+			Summary:  "Look up UOCs by model",
+			Response: response.UOCPageResponse{},
+		},
+		{
+			ID:       "lookupUOCByModelLegacy",
+			Method:   http.MethodGet,
+			Path:     "/lookup/uoc/model/:model",
+			Summary:  "Look up UOCs by model (legacy path)",
+			Response: response.UOCPageResponse{},
+		},
+	},
+}}
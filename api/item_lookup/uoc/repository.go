@@ -1,12 +1,13 @@
 package uoc
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
-	"miltechserver/api/response"
+	"miltechserver/api/pagination"
 )
 
 type Repository interface {
-	SearchByPage(page int) (response.UOCPageResponse, error)
-	SearchSpecific(uoc string) ([]model.LookupUoc, error)
-	SearchByModel(model string) ([]model.LookupUoc, error)
+	SearchPage(ctx context.Context, params pagination.Params) (pagination.Page[model.LookupUoc], error)
+	SearchSpecific(ctx context.Context, uoc string) ([]model.LookupUoc, error)
+	SearchByModel(ctx context.Context, model string) ([]model.LookupUoc, error)
 }
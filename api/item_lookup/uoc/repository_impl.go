@@ -1,6 +1,7 @@
 package uoc
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -8,7 +9,7 @@ import (
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/api/item_lookup/shared"
-	"miltechserver/api/response"
+	"miltechserver/api/pagination"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
@@ -21,22 +22,34 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) SearchByPage(page int) (response.UOCPageResponse, error) {
-	if page < 1 {
-		return response.UOCPageResponse{}, shared.ErrInvalidPage
+func (repo *RepositoryImpl) SearchPage(ctx context.Context, params pagination.Params) (pagination.Page[model.LookupUoc], error) {
+	var afterUOC, afterModel string
+	after, err := params.After(&afterUOC, &afterModel)
+	if err != nil {
+		return pagination.Page[model.LookupUoc]{}, err
 	}
 
 	var uocData []model.LookupUoc
-	offset := shared.CalculateOffset(page, shared.DefaultPageSize)
 	stmt := SELECT(
 		table.LookupUoc.AllColumns,
 	).FROM(table.LookupUoc).
-		LIMIT(shared.DefaultPageSize).
-		OFFSET(offset)
+		ORDER_BY(table.LookupUoc.Uoc.ASC(), table.LookupUoc.Model.ASC()).
+		LIMIT(params.Fetch())
+	if after {
+		stmt = stmt.WHERE(ROW(table.LookupUoc.Uoc, table.LookupUoc.Model).
+			GT(ROW(String(afterUOC), String(afterModel))))
+	}
 
-	err := stmt.Query(repo.db, &uocData)
+	err = stmt.QueryContext(ctx, repo.db, &uocData)
 	if err != nil {
-		return response.UOCPageResponse{}, fmt.Errorf("failed to query UOC data: %w", err)
+		return pagination.Page[model.LookupUoc]{}, fmt.Errorf("failed to query UOC data: %w", err)
+	}
+
+	page := pagination.NewPage(uocData, params, func(item model.LookupUoc) []any {
+		return []any{item.Uoc, item.Model}
+	})
+	if !params.IncludeTotal {
+		return page, nil
 	}
 
 	var count struct {
@@ -47,26 +60,15 @@ func (repo *RepositoryImpl) SearchByPage(page int) (response.UOCPageResponse, er
 		COUNT(table.LookupUoc.Uoc),
 	).FROM(table.LookupUoc)
 
-	err = countStmt.Query(repo.db, &count)
+	err = countStmt.QueryContext(ctx, repo.db, &count)
 	if err != nil {
-		return response.UOCPageResponse{}, fmt.Errorf("failed to get total UOC count: %w", err)
-	}
-
-	if len(uocData) == 0 {
-		return response.UOCPageResponse{}, shared.ErrNotFound
+		return pagination.Page[model.LookupUoc]{}, fmt.Errorf("failed to get total UOC count: %w", err)
 	}
 
-	totalPages := shared.CalculateTotalPages(count.Count, shared.DefaultPageSize)
-	return response.UOCPageResponse{
-		UOCs:       uocData,
-		Count:      count.Count,
-		Page:       page,
-		TotalPages: totalPages,
-		IsLastPage: page >= totalPages,
-	}, nil
+	return page.WithTotal(count.Count), nil
 }
 
-func (repo *RepositoryImpl) SearchSpecific(uoc string) ([]model.LookupUoc, error) {
+func (repo *RepositoryImpl) SearchSpecific(ctx context.Context, uoc string) ([]model.LookupUoc, error) {
 	if strings.TrimSpace(uoc) == "" {
 		return nil, shared.ErrEmptyParam
 	}
@@ -77,7 +79,7 @@ func (repo *RepositoryImpl) SearchSpecific(uoc string) ([]model.LookupUoc, error
 	).FROM(table.LookupUoc).
 		WHERE(table.LookupUoc.Uoc.EQ(String(uoc)))
 
-	err := stmt.Query(repo.db, &uocData)
+	err := stmt.QueryContext(ctx, repo.db, &uocData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query specific UOC: %w", err)
 	}
@@ -89,7 +91,7 @@ func (repo *RepositoryImpl) SearchSpecific(uoc string) ([]model.LookupUoc, error
 	return uocData, nil
 }
 
-func (repo *RepositoryImpl) SearchByModel(vehicleModel string) ([]model.LookupUoc, error) {
+func (repo *RepositoryImpl) SearchByModel(ctx context.Context, vehicleModel string) ([]model.LookupUoc, error) {
 	if strings.TrimSpace(vehicleModel) == "" {
 		return nil, shared.ErrEmptyParam
 	}
@@ -100,7 +102,7 @@ func (repo *RepositoryImpl) SearchByModel(vehicleModel string) ([]model.LookupUo
 	).FROM(table.LookupUoc).
 		WHERE(table.LookupUoc.Model.LIKE(String("%" + vehicleModel + "%")))
 
-	err := stmt.Query(repo.db, &uocData)
+	err := stmt.QueryContext(ctx, repo.db, &uocData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query UOC by model: %w", err)
 	}
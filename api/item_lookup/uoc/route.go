@@ -1,23 +1,21 @@
 package uoc
 
 import (
-	"strconv"
-
 	"miltechserver/api/item_lookup/shared"
+	"miltechserver/api/pagination"
 
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/uoc", func(c *gin.Context) {
-		pageStr := c.Query("page")
-		page, err := strconv.Atoi(pageStr)
+		params, err := pagination.FromQuery(c, shared.DefaultLimit)
 		if err != nil {
-			shared.HandleError(c, shared.ErrInvalidPage)
+			shared.HandleError(c, err)
 			return
 		}
 
-		uocData, err := service.LookupByPage(page)
+		uocData, err := service.LookupPage(c.Request.Context(), params)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
@@ -29,7 +27,7 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/uoc/:uoc", func(c *gin.Context) {
 		uoc := c.Param("uoc")
 
-		uocData, err := service.LookupSpecific(uoc)
+		uocData, err := service.LookupSpecific(c.Request.Context(), uoc)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
@@ -41,7 +39,7 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/uoc/by-model/:model", func(c *gin.Context) {
 		model := c.Param("model")
 
-		uocData, err := service.LookupByModel(model)
+		uocData, err := service.LookupByModel(c.Request.Context(), model)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
@@ -53,7 +51,7 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 	router.GET("/lookup/uoc/model/:model", func(c *gin.Context) {
 		model := c.Param("model")
 
-		uocData, err := service.LookupByModel(model)
+		uocData, err := service.LookupByModel(c.Request.Context(), model)
 		if err != nil {
 			shared.HandleError(c, err)
 			return
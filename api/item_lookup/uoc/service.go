@@ -1,9 +1,14 @@
 package uoc
 
-import "miltechserver/api/response"
+import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+)
 
 type Service interface {
-	LookupByPage(page int) (response.UOCPageResponse, error)
-	LookupSpecific(uoc string) (response.UOCPageResponse, error)
-	LookupByModel(model string) (response.UOCPageResponse, error)
+	LookupPage(ctx context.Context, params pagination.Params) (pagination.Page[model.LookupUoc], error)
+	LookupSpecific(ctx context.Context, uoc string) (response.UOCPageResponse, error)
+	LookupByModel(ctx context.Context, model string) (response.UOCPageResponse, error)
 }
@@ -1,6 +1,9 @@
 package uoc
 
 import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 	"strings"
 )
@@ -13,12 +16,12 @@ func NewService(repo Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo}
 }
 
-func (service *ServiceImpl) LookupByPage(page int) (response.UOCPageResponse, error) {
-	return service.repo.SearchByPage(page)
+func (service *ServiceImpl) LookupPage(ctx context.Context, params pagination.Params) (pagination.Page[model.LookupUoc], error) {
+	return service.repo.SearchPage(ctx, params)
 }
 
-func (service *ServiceImpl) LookupSpecific(uoc string) (response.UOCPageResponse, error) {
-	uocData, err := service.repo.SearchSpecific(strings.ToUpper(uoc))
+func (service *ServiceImpl) LookupSpecific(ctx context.Context, uoc string) (response.UOCPageResponse, error) {
+	uocData, err := service.repo.SearchSpecific(ctx, strings.ToUpper(uoc))
 	if err != nil {
 		return response.UOCPageResponse{}, err
 	}
@@ -32,8 +35,8 @@ func (service *ServiceImpl) LookupSpecific(uoc string) (response.UOCPageResponse
 	}, nil
 }
 
-func (service *ServiceImpl) LookupByModel(model string) (response.UOCPageResponse, error) {
-	uocData, err := service.repo.SearchByModel(strings.ToUpper(model))
+func (service *ServiceImpl) LookupByModel(ctx context.Context, model string) (response.UOCPageResponse, error) {
+	uocData, err := service.repo.SearchByModel(ctx, strings.ToUpper(model))
 	if err != nil {
 		return response.UOCPageResponse{}, err
 	}
@@ -0,0 +1,33 @@
+package detailed
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Item Query",
+	Operations: []openapi.Operation{
+		{
+			ID:      "getItemDetailed",
+			Method:  http.MethodGet,
+			Path:    "/queries/items/detailed",
+			Summary: "Get the detailed record of an item, or the sections named in sections",
+			Query: []openapi.Param{
+				{Name: "niin"},
+				{Name: "sections", Description: "Comma-separated sections to return, default all: " + sectionList()},
+			},
+			Response: response.DetailedResponse{},
+		},
+		{
+			ID:       "getItemDetailedSection",
+			Method:   http.MethodGet,
+			Path:     "/queries/items/detailed/:section",
+			Summary:  "Get one section of the detailed record of an item",
+			Query:    []openapi.Param{{Name: "niin"}},
+			Response: response.DetailedResponse{},
+		},
+	},
+}}
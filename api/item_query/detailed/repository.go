@@ -2,10 +2,17 @@ package detailed
 
 import (
 	"context"
-
-	"miltechserver/api/response"
 )
 
 type Repository interface {
-	GetDetailedItemData(ctx context.Context, niin string) (response.DetailedResponse, error)
+	// GetDetailedSection runs the queries of one section for a NIIN. Missing
+	// rows are reported as an empty section, not an error.
+	GetDetailedSection(ctx context.Context, niin string, section Section) (SectionResult, error)
+}
+
+// SectionResult is one section of an item's record. Data holds the
+// section's details value.
+type SectionResult struct {
+	Data  any
+	Empty bool
 }
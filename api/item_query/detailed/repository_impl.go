@@ -3,13 +3,13 @@ package detailed
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
 
 	"github.com/go-jet/jet/v2/qrm"
-	"golang.org/x/sync/errgroup"
+	"go.opentelemetry.io/otel/trace"
 
-	"miltechserver/api/item_query/detailed/queries"
-	"miltechserver/api/response"
+	"miltechserver/tracing"
 )
 
 type RepositoryImpl struct {
@@ -20,120 +20,34 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{Db: db}
 }
 
-// GetDetailedItemData fetches comprehensive item data by NIIN using parallel queries.
-// All 10 query functions execute concurrently, reducing response time from ~45 sequential
-// round-trips to the latency of the slowest query function (~8 parallel round-trips max).
-// Errors in individual queries are logged but don't fail the entire request - partial data is returned.
-// Uses plain errgroup (no context) to prevent cascading cancellations when tables have no data.
-func (repo *RepositoryImpl) GetDetailedItemData(ctx context.Context, niin string) (response.DetailedResponse, error) {
-	var result response.DetailedResponse
-	var g errgroup.Group
-
-	g.Go(func() error {
-		data, err := queries.GetAmdfData(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("amdf", niin, err)
-			return nil // Continue with partial data
-		}
-		result.Amdf = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetArmyPackagingAndFreight(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("army_packaging", niin, err)
-			return nil
-		}
-		result.ArmyPackagingAndFreight = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetSarsscat(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("sarsscat", niin, err)
-			return nil
-		}
-		result.Sarsscat = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetIdentification(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("identification", niin, err)
-			return nil
-		}
-		result.Identification = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetManagement(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("management", niin, err)
-			return nil
-		}
-		result.Management = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetReference(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("reference", niin, err)
-			return nil
-		}
-		result.Reference = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetFreight(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("freight", niin, err)
-			return nil
-		}
-		result.Freight = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetPackaging(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("packaging", niin, err)
-			return nil
-		}
-		result.Packaging = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetCharacteristics(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("characteristics", niin, err)
-			return nil
-		}
-		result.Characteristics = data
-		return nil
-	})
-
-	g.Go(func() error {
-		data, err := queries.GetDisposition(ctx, repo.Db, niin)
-		if err != nil {
-			repo.logQueryError("disposition", niin, err)
-			return nil
-		}
-		result.Disposition = data
-		return nil
-	})
-
-	// Wait for all goroutines to complete
-	// Since we return nil from all goroutines, this will never return an error
-	_ = g.Wait()
+// GetDetailedSection fetches one section of an item by NIIN. Sections with several query groups
+// run them in parallel inside their query function; the service runs the requested sections
+// concurrently, so the full record costs the latency of the slowest section (~8 parallel
+// round-trips max) rather than ~45 sequential round-trips.
+// Each query function gets its own span so slow sections show up in traces.
+func (repo *RepositoryImpl) GetDetailedSection(ctx context.Context, niin string, section Section) (SectionResult, error) {
+	query := sectionQueries[section]
+	ctx, span := tracing.Start(ctx, query.span)
+	data, err := query.fetch(ctx, repo.Db, niin)
+	endQuerySpan(span, err)
+	repo.logQueryError(string(section), niin, err)
+
+	switch {
+	case errors.Is(err, qrm.ErrNoRows):
+		return SectionResult{Data: data, Empty: true}, nil
+	case err != nil:
+		return SectionResult{}, err
+	}
+	return SectionResult{Data: data, Empty: isEmpty(data)}, nil
+}
 
-	return result, nil
+// endQuerySpan ends a query function's span. Missing rows are an expected
+// outcome here, not a failure, so they are not recorded as span errors.
+func endQuerySpan(span trace.Span, err error) {
+	if errors.Is(err, qrm.ErrNoRows) {
+		err = nil
+	}
+	tracing.End(span, err)
 }
 
 func (repo *RepositoryImpl) logQueryError(source string, niin string, err error) {
@@ -141,7 +55,7 @@ func (repo *RepositoryImpl) logQueryError(source string, niin string, err error)
 		return
 	}
 	// Don't log "no rows" as an error - it's expected when tables don't have data for this NIIN
-	if err == qrm.ErrNoRows {
+	if errors.Is(err, qrm.ErrNoRows) {
 		slog.Debug("No data found", "source", source, "niin", niin)
 		return
 	}
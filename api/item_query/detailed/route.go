@@ -19,16 +19,40 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func registerHandlers(router *gin.RouterGroup, service Service) {
 	handler := Handler{service: service}
 	router.GET("/queries/items/detailed", handler.findDetailed)
+	router.GET("/queries/items/detailed/:section", handler.findSection)
 }
 
+// findDetailed returns the sections named in ?sections=, or all of them.
 func (handler *Handler) findDetailed(c *gin.Context) {
+	sections, err := ParseSections(c.Query("sections"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	handler.respond(c, sections)
+}
+
+func (handler *Handler) findSection(c *gin.Context) {
+	section := Section(c.Param("section"))
+	if _, ok := sectionQueries[section]; !ok {
+		c.Error(ErrSectionNotFound)
+		return
+	}
+	handler.respond(c, []Section{section})
+}
+
+func (handler *Handler) respond(c *gin.Context, sections []Section) {
 	ctx := c.Request.Context()
 	niin := c.Query("niin")
-	itemData, err := handler.service.FindDetailedItem(ctx, niin)
+	itemData, err := handler.service.FindDetailedItem(ctx, niin, sections)
 	if err != nil {
 		c.Error(err)
 		return
 	}
+	// A partial result must not be reused in place of a complete one
+	if len(itemData.Meta.Failed) > 0 {
+		c.Header("Cache-Control", "no-store")
+	}
 
 	c.JSON(http.StatusOK, response.StandardResponse{
 		Status:  http.StatusOK,
@@ -15,11 +15,13 @@ import (
 )
 
 type serviceStub struct {
-	resp response.DetailedResponse
-	err  error
+	resp     response.DetailedResponse
+	err      error
+	sections []Section
 }
 
-func (s *serviceStub) FindDetailedItem(ctx context.Context, niin string) (response.DetailedResponse, error) {
+func (s *serviceStub) FindDetailedItem(ctx context.Context, niin string, sections []Section) (response.DetailedResponse, error) {
+	s.sections = sections
 	return s.resp, s.err
 }
 
@@ -40,6 +42,44 @@ func TestFindDetailedSuccess(t *testing.T) {
 	require.Equal(t, "", payload["message"])
 	_, ok := payload["data"].(map[string]any)
 	require.True(t, ok)
+	require.Equal(t, AllSections, stub.sections)
+}
+
+func TestFindDetailedSelectsSections(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	stub := &serviceStub{}
+
+	registerHandlers(router.Group("/api/v1"), stub)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/queries/items/detailed?niin=123&sections=reference,amdf,reference", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, []Section{SectionAmdf, SectionReference}, stub.sections)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/queries/items/detailed?niin=123&sections=amdf,bogus", nil))
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestFindSection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	stub := &serviceStub{}
+
+	registerHandlers(router.Group("/api/v1"), stub)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/queries/items/detailed/freight?niin=123", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, []Section{SectionFreight}, stub.sections)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/queries/items/detailed/bogus?niin=123", nil))
+	require.Equal(t, http.StatusNotFound, resp.Code)
+	require.Contains(t, resp.Body.String(), `"code":"section_not_found"`)
 }
 
 func TestFindDetailedErrorUsesMiddleware(t *testing.T) {
@@ -69,3 +109,16 @@ func decodeJSON(t *testing.T, body []byte) map[string]any {
 	require.NoError(t, json.Unmarshal(body, &payload))
 	return payload
 }
+
+func TestFindDetailedPartialResultIsNotCached(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	stub := &serviceStub{resp: response.DetailedResponse{Meta: response.DetailedMeta{Failed: []string{"amdf"}}}}
+
+	registerHandlers(router.Group("/api/v1"), stub)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/v1/queries/items/detailed?niin=123", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "no-store", resp.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,163 @@
+package detailed
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/details"
+	"miltechserver/api/item_query/detailed/queries"
+	"miltechserver/api/response"
+)
+
+// Section names one query group of the detailed item record.
+type Section string
+
+const (
+	SectionAmdf            Section = "amdf"
+	SectionArmyPackaging   Section = "army_packaging"
+	SectionSarsscat        Section = "sarsscat"
+	SectionIdentification  Section = "identification"
+	SectionManagement      Section = "management"
+	SectionReference       Section = "reference"
+	SectionFreight         Section = "freight"
+	SectionPackaging       Section = "packaging"
+	SectionCharacteristics Section = "characteristics"
+	SectionDisposition     Section = "disposition"
+)
+
+// AllSections is every section, in response order. A request that names no
+// sections gets all of them.
+var AllSections = []Section{
+	SectionAmdf,
+	SectionArmyPackaging,
+	SectionSarsscat,
+	SectionIdentification,
+	SectionManagement,
+	SectionReference,
+	SectionFreight,
+	SectionPackaging,
+	SectionCharacteristics,
+	SectionDisposition,
+}
+
+var (
+	ErrUnknownSection  = apperror.InvalidParameter("sections", "unknown section; valid sections are "+sectionList())
+	ErrSectionNotFound = apperror.NotFound("section_not_found", "unknown section; valid sections are "+sectionList())
+)
+
+// sectionQuery binds a section to its query function and its field of the
+// response. Values travel between the two as any.
+type sectionQuery struct {
+	span  string
+	fetch func(ctx context.Context, db *sql.DB, niin string) (any, error)
+	set   func(resp *response.DetailedResponse, data any)
+}
+
+func query[T any](span string, fetch func(context.Context, *sql.DB, string) (T, error), field func(*response.DetailedResponse) **T) sectionQuery {
+	return sectionQuery{
+		span: span,
+		fetch: func(ctx context.Context, db *sql.DB, niin string) (any, error) {
+			return fetch(ctx, db, niin)
+		},
+		set: func(resp *response.DetailedResponse, data any) {
+			value := data.(T)
+			*field(resp) = &value
+		},
+	}
+}
+
+var sectionQueries = map[Section]sectionQuery{
+	SectionAmdf: query("queries.GetAmdfData", queries.GetAmdfData, func(r *response.DetailedResponse) **details.Amdf {
+		return &r.Amdf
+	}),
+	SectionArmyPackaging: query("queries.GetArmyPackagingAndFreight", queries.GetArmyPackagingAndFreight, func(r *response.DetailedResponse) **details.ArmyPackagingAndFreight {
+		return &r.ArmyPackagingAndFreight
+	}),
+	SectionSarsscat: query("queries.GetSarsscat", queries.GetSarsscat, func(r *response.DetailedResponse) **details.Sarsscat {
+		return &r.Sarsscat
+	}),
+	SectionIdentification: query("queries.GetIdentification", queries.GetIdentification, func(r *response.DetailedResponse) **details.Identification {
+		return &r.Identification
+	}),
+	SectionManagement: query("queries.GetManagement", queries.GetManagement, func(r *response.DetailedResponse) **details.Management {
+		return &r.Management
+	}),
+	SectionReference: query("queries.GetReference", queries.GetReference, func(r *response.DetailedResponse) **details.Reference {
+		return &r.Reference
+	}),
+	SectionFreight: query("queries.GetFreight", queries.GetFreight, func(r *response.DetailedResponse) **details.Freight {
+		return &r.Freight
+	}),
+	SectionPackaging: query("queries.GetPackaging", queries.GetPackaging, func(r *response.DetailedResponse) **details.Packaging {
+		return &r.Packaging
+	}),
+	SectionCharacteristics: query("queries.GetCharacteristics", queries.GetCharacteristics, func(r *response.DetailedResponse) **details.Characteristics {
+		return &r.Characteristics
+	}),
+	SectionDisposition: query("queries.GetDisposition", queries.GetDisposition, func(r *response.DetailedResponse) **details.Disposition {
+		return &r.Disposition
+	}),
+}
+
+// ParseSections reads a comma-separated list of section names. An empty list
+// selects every section. The result is in AllSections order, without
+// duplicates.
+func ParseSections(raw string) ([]Section, error) {
+	if strings.TrimSpace(raw) == "" {
+		return AllSections, nil
+	}
+
+	selected := map[Section]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		section := Section(strings.TrimSpace(name))
+		if _, ok := sectionQueries[section]; !ok {
+			return nil, ErrUnknownSection
+		}
+		selected[section] = true
+	}
+
+	var sections []Section
+	for _, section := range AllSections {
+		if selected[section] {
+			sections = append(sections, section)
+		}
+	}
+	return sections, nil
+}
+
+func sectionList() string {
+	names := make([]string, len(AllSections))
+	for i, section := range AllSections {
+		names[i] = string(section)
+	}
+	return strings.Join(names, ", ")
+}
+
+// isEmpty reports whether a section holds no data: every field is a zero
+// value, an empty slice or map, or a struct that is itself empty.
+func isEmpty(data any) bool {
+	return emptyValue(reflect.ValueOf(data))
+}
+
+func emptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil() || emptyValue(v.Elem())
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if !emptyValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return v.IsZero()
+	}
+}
@@ -7,5 +7,7 @@ import (
 )
 
 type Service interface {
-	FindDetailedItem(ctx context.Context, niin string) (response.DetailedResponse, error)
+	// FindDetailedItem returns the given sections of an item's detailed
+	// record. It fails only when every section's query failed.
+	FindDetailedItem(ctx context.Context, niin string, sections []Section) (response.DetailedResponse, error)
 }
@@ -1,36 +1,91 @@
 package detailed
 
 import (
+	"cmp"
 	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
 
 	"miltechserver/api/response"
+	"miltechserver/cache"
 )
 
+// sectionKey identifies one cached section of one item.
+type sectionKey struct {
+	niin    string
+	section Section
+}
+
 type ServiceImpl struct {
 	repo  Repository
-	cache *Cache
+	cache *cache.Cache[sectionKey, SectionResult]
 }
 
 func NewService(repo Repository) *ServiceImpl {
 	return &ServiceImpl{
-		repo:  repo,
-		cache: NewCache(24 * 60 * 60), // 24 hour TTL in seconds
+		repo: repo,
+		// Ten sections per item, so about 2000 items
+		cache: cache.New[sectionKey, SectionResult](cache.Options{
+			Name:     "item_detailed",
+			Capacity: 20_000,
+			TTL:      24 * time.Hour,
+		}),
 	}
 }
 
-func (service *ServiceImpl) FindDetailedItem(ctx context.Context, niin string) (response.DetailedResponse, error) {
-	// Check cache first
-	if cached, ok := service.cache.Get(niin); ok {
-		return cached, nil
+// CacheStatus reports cache warmness for the readiness probe. An empty cache
+// is expected after a restart, so it never fails.
+func (service *ServiceImpl) CacheStatus(context.Context) (map[string]any, error) {
+	stats := service.cache.Stats()
+	return map[string]any{"warm": stats.Entries > 0, "entries": stats.Entries, "stats": stats}, nil
+}
+
+// FlushCache drops every cached section, for a new FedLog load.
+func (service *ServiceImpl) FlushCache() {
+	service.cache.Purge()
+}
+
+// FindDetailedItem loads each requested section through the cache, so only
+// uncached sections are queried, and concurrent requests for the same item
+// share one query per section. Failed sections are not cached.
+func (service *ServiceImpl) FindDetailedItem(ctx context.Context, niin string, sections []Section) (response.DetailedResponse, error) {
+	results := make([]SectionResult, len(sections))
+	errs := make([]error, len(sections))
+
+	// Plain errgroup (no context) so one failed section doesn't cancel the others
+	var g errgroup.Group
+	for i, section := range sections {
+		g.Go(func() error {
+			results[i], errs[i] = service.cache.GetOrLoad(ctx, sectionKey{niin, section}, func(ctx context.Context) (SectionResult, error) {
+				return service.repo.GetDetailedSection(ctx, niin, section)
+			})
+			return nil
+		})
 	}
+	_ = g.Wait()
 
-	// Cache miss - fetch from database
-	data, err := service.repo.GetDetailedItemData(ctx, niin)
-	if err != nil {
-		return response.DetailedResponse{}, err
+	data := response.DetailedResponse{Meta: response.DetailedMeta{Empty: []string{}, Failed: []string{}}}
+	var firstErr error
+	for i, section := range sections {
+		switch {
+		case errs[i] != nil:
+			data.Meta.Failed = append(data.Meta.Failed, string(section))
+			firstErr = cmp.Or(firstErr, errs[i])
+			continue
+		case results[i].Empty:
+			data.Meta.Empty = append(data.Meta.Empty, string(section))
+		}
+		sectionQueries[section].set(&data, results[i].Data)
 	}
 
-	// Store in cache
-	service.cache.Set(niin, data)
+	// A response with every section failed carries nothing, so surface the
+	// failure; an expired or cancelled request is never a partial success.
+	if firstErr != nil && len(data.Meta.Failed) == len(sections) {
+		return response.DetailedResponse{}, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return response.DetailedResponse{}, err
+	}
 	return data, nil
 }
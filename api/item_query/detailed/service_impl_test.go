@@ -3,48 +3,99 @@ package detailed
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
-	"miltechserver/api/response"
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/details"
 )
 
+type stubSection struct {
+	result SectionResult
+	err    error
+}
+
 type repoStub struct {
-	resp response.DetailedResponse
-	err  error
+	mu       sync.Mutex
+	sections map[Section]stubSection
+	calls    []Section
 }
 
-func (r *repoStub) GetDetailedItemData(ctx context.Context, niin string) (response.DetailedResponse, error) {
-	return r.resp, r.err
+func (r *repoStub) GetDetailedSection(ctx context.Context, niin string, section Section) (SectionResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, section)
+	return r.sections[section].result, r.sections[section].err
 }
 
 func TestFindDetailedItemReturnsRepoData(t *testing.T) {
-	stub := &repoStub{resp: response.DetailedResponse{}}
+	stub := &repoStub{sections: map[Section]stubSection{
+		SectionAmdf:    {result: SectionResult{Data: details.Amdf{}, Empty: true}},
+		SectionFreight: {result: SectionResult{Data: details.Freight{}}},
+	}}
+	svc := NewService(stub)
+
+	data, err := svc.FindDetailedItem(context.Background(), "123", []Section{SectionAmdf, SectionFreight})
+	require.NoError(t, err)
+	require.NotNil(t, data.Amdf)
+	require.NotNil(t, data.Freight)
+	require.Nil(t, data.Reference)
+	require.Equal(t, []string{"amdf"}, data.Meta.Empty)
+	require.Empty(t, data.Meta.Failed)
+}
+
+func TestFindDetailedItemReportsFailedSections(t *testing.T) {
+	stub := &repoStub{sections: map[Section]stubSection{
+		SectionAmdf:    {err: errors.New("boom")},
+		SectionFreight: {result: SectionResult{Data: details.Freight{}}},
+	}}
 	svc := NewService(stub)
 
-	_, err := svc.FindDetailedItem(context.Background(), "123")
+	data, err := svc.FindDetailedItem(context.Background(), "123", []Section{SectionAmdf, SectionFreight})
+	require.NoError(t, err)
+	require.Nil(t, data.Amdf)
+	require.NotNil(t, data.Freight)
+	require.Equal(t, []string{"amdf"}, data.Meta.Failed)
+
+	// The failed section is queried again; the successful one is cached.
+	stub.calls = nil
+	_, err = svc.FindDetailedItem(context.Background(), "123", []Section{SectionAmdf, SectionFreight})
 	require.NoError(t, err)
+	require.Equal(t, []Section{SectionAmdf}, stub.calls)
 }
 
-func TestFindDetailedItemReturnsRepoError(t *testing.T) {
-	stub := &repoStub{err: errors.New("boom")}
+func TestFindDetailedItemReturnsErrorWhenEverySectionFails(t *testing.T) {
+	stub := &repoStub{sections: map[Section]stubSection{
+		SectionAmdf: {err: errors.New("boom")},
+	}}
 	svc := NewService(stub)
 
-	_, err := svc.FindDetailedItem(context.Background(), "123")
-	require.Error(t, err)
+	_, err := svc.FindDetailedItem(context.Background(), "123", []Section{SectionAmdf})
+	require.EqualError(t, err, "boom")
 }
 
-func TestFindDetailedItemUsesCacheOnHit(t *testing.T) {
-	stub := &repoStub{resp: response.DetailedResponse{}}
+func TestFindDetailedItemUsesCachePerSection(t *testing.T) {
+	stub := &repoStub{sections: map[Section]stubSection{
+		SectionAmdf:      {result: SectionResult{Data: details.Amdf{}}},
+		SectionReference: {result: SectionResult{Data: details.Reference{}}},
+	}}
 	svc := NewService(stub)
 
 	// First call - cache miss, hits repo
-	_, err := svc.FindDetailedItem(context.Background(), "123")
+	_, err := svc.FindDetailedItem(context.Background(), "123", []Section{SectionAmdf})
 	require.NoError(t, err)
 
-	// Second call - should hit cache, not repo
-	stub.err = errors.New("should not be called")
-	_, err = svc.FindDetailedItem(context.Background(), "123")
-	require.NoError(t, err) // No error because we hit cache
+	// Second call - only the section not cached yet reaches the repo
+	data, err := svc.FindDetailedItem(context.Background(), "123", []Section{SectionAmdf, SectionReference})
+	require.NoError(t, err)
+	require.NotNil(t, data.Amdf)
+	require.NotNil(t, data.Reference)
+	require.Equal(t, []Section{SectionAmdf, SectionReference}, stub.calls)
+}
+
+func TestIsEmpty(t *testing.T) {
+	require.True(t, isEmpty(details.Reference{ReferenceAndPartNumber: []model.FlisReference{}}))
+	require.False(t, isEmpty(details.Reference{ReferenceAndPartNumber: []model.FlisReference{{}}}))
 }
@@ -0,0 +1,12 @@
+package item_query
+
+import (
+	"slices"
+
+	"miltechserver/api/item_query/detailed"
+	"miltechserver/api/item_query/help"
+	"miltechserver/api/item_query/short"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(short.Docs, detailed.Docs, help.Docs)
@@ -0,0 +1,22 @@
+package help
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Item Query",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getItemHelp",
+			Method:   http.MethodGet,
+			Path:     "/queries/items/help",
+			Summary:  "Look up a help code",
+			Query:    []openapi.Param{{Name: "code"}},
+			Response: model.Help{},
+		},
+	},
+}}
@@ -1,8 +1,8 @@
 package help
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrHelpNotFound = errors.New("help not found")
-	ErrInvalidCode  = errors.New("invalid help code")
+	ErrHelpNotFound = apperror.NotFound("help_not_found", "help not found")
+	ErrInvalidCode  = apperror.Validation("invalid_help_code", "invalid help code")
 )
@@ -1,6 +1,7 @@
 package help
 
 import (
+	"context"
 	"database/sql"
 
 	"miltechserver/.gen/miltech_ng/public/model"
@@ -17,7 +18,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) FindByCode(code string) ([]model.Help, error) {
+func (repo *RepositoryImpl) FindByCode(ctx context.Context, code string) ([]model.Help, error) {
 	var rows []model.Help
 
 	stmt := SELECT(
@@ -26,7 +27,7 @@ func (repo *RepositoryImpl) FindByCode(code string) ([]model.Help, error) {
 		WHERE(Help.Code.EQ(String(code))).
 		ORDER_BY(Help.Description.ASC())
 
-	err := stmt.Query(repo.db, &rows)
+	err := stmt.QueryContext(ctx, repo.db, &rows)
 	if err != nil || len(rows) == 0 {
 		return []model.Help{}, ErrHelpNotFound
 	}
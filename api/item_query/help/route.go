@@ -1,7 +1,6 @@
 package help
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -24,16 +23,9 @@ func registerHandlers(router *gin.RouterGroup, service Service) {
 
 func (handler *Handler) findByCode(c *gin.Context) {
 	code := c.Query("code")
-	result, err := handler.service.FindByCode(code)
+	result, err := handler.service.FindByCode(c.Request.Context(), code)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrInvalidCode):
-			c.JSON(http.StatusBadRequest, gin.H{"message": "code is required"})
-		case errors.Is(err, ErrHelpNotFound):
-			c.JSON(http.StatusNotFound, response.EmptyResponseMessage())
-		default:
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package help
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/middleware"
 )
 
 type serviceStub struct {
@@ -18,13 +20,14 @@ type serviceStub struct {
 	err  error
 }
 
-func (s *serviceStub) FindByCode(string) (model.Help, error) {
+func (s *serviceStub) FindByCode(context.Context, string) (model.Help, error) {
 	return s.resp, s.err
 }
 
 func TestFindByCodeSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{resp: model.Help{Code: "AB12", Description: "Help text"}}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -45,6 +48,7 @@ func TestFindByCodeSuccess(t *testing.T) {
 func TestFindByCodeBadRequest(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{err: ErrInvalidCode}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -59,6 +63,7 @@ func TestFindByCodeBadRequest(t *testing.T) {
 func TestFindByCodeNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{err: ErrHelpNotFound}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -73,6 +78,7 @@ func TestFindByCodeNotFound(t *testing.T) {
 func TestFindByCodeServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{err: errors.New("boom")}
 
 	registerHandlers(router.Group("/api/v1"), stub)
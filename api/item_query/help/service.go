@@ -1,7 +1,10 @@
 package help
 
-import "miltechserver/.gen/miltech_ng/public/model"
+import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+)
 
 type Service interface {
-	FindByCode(code string) (model.Help, error)
+	FindByCode(ctx context.Context, code string) (model.Help, error)
 }
@@ -9,10 +9,16 @@ import (
 	"miltechserver/api/item_query/detailed"
 	"miltechserver/api/item_query/help"
 	"miltechserver/api/item_query/short"
+	"miltechserver/bootstrap"
+	"miltechserver/dataversion"
+	"miltechserver/health"
 )
 
 type Dependencies struct {
-	DB *sql.DB
+	DB          *sql.DB
+	Lifecycle   *bootstrap.Lifecycle
+	Health      *health.Registry
+	DataVersion *dataversion.Watcher
 }
 
 func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
@@ -21,10 +27,13 @@ func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
 	shortRepo := short.NewRepository(deps.DB)
 	shortService := short.NewService(shortRepo, analyticsService)
 	short.RegisterRoutes(router, shortService)
+	deps.Lifecycle.OnStop("item_query analytics queue", shortService.Stop)
 
 	detailedRepo := detailed.NewRepository(deps.DB)
 	detailedService := detailed.NewService(detailedRepo)
 	detailed.RegisterRoutes(router, detailedService)
+	deps.Health.Register("cache.item_detailed", false, detailedService.CacheStatus)
+	deps.DataVersion.OnChange("item_query detailed cache", detailedService.FlushCache)
 
 	helpRepo := help.NewRepository(deps.DB)
 	helpService := help.NewService(helpRepo)
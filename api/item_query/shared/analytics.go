@@ -1,11 +1,13 @@
 package shared
 
+import "context"
+
 type AnalyticsTracker interface {
-	IncrementItemSearchSuccess(niin string, nomenclature string) error
+	IncrementItemSearchSuccess(ctx context.Context, niin string, nomenclature string) error
 }
 
 type NoOpTracker struct{}
 
-func (NoOpTracker) IncrementItemSearchSuccess(string, string) error {
+func (NoOpTracker) IncrementItemSearchSuccess(context.Context, string, string) error {
 	return nil
 }
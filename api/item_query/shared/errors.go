@@ -1,7 +1,7 @@
 package shared
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrNoItemsFound = errors.New("no items found")
+	ErrNoItemsFound = apperror.NotFound("no_items_found", "no items found")
 )
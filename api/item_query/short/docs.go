@@ -0,0 +1,22 @@
+package short
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Item Query",
+	Operations: []openapi.Operation{
+		{
+			ID:       "searchItemsShort",
+			Method:   http.MethodGet,
+			Path:     "/queries/items/initial",
+			Summary:  "Search items by NIIN or part number",
+			Query:    []openapi.Param{{Name: "method"}, {Name: "value"}, {Name: "cancelled"}},
+			Response: []model.NiinLookup{},
+		},
+	},
+}}
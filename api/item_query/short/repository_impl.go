@@ -1,6 +1,7 @@
 package short
 
 import (
+	"context"
 	"database/sql"
 	"strings"
 
@@ -20,7 +21,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{Db: db}
 }
 
-func (repo *RepositoryImpl) ShortItemSearchNiin(niin string) (model.NiinLookup, error) {
+func (repo *RepositoryImpl) ShortItemSearchNiin(ctx context.Context, niin string) (model.NiinLookup, error) {
 	item := model.NiinLookup{}
 
 	stmt := SELECT(
@@ -28,7 +29,7 @@ func (repo *RepositoryImpl) ShortItemSearchNiin(niin string) (model.NiinLookup,
 	).FROM(view.NiinLookup).
 		WHERE(view.NiinLookup.Niin.EQ(String(niin)))
 
-	err := stmt.Query(repo.Db, &item)
+	err := stmt.QueryContext(ctx, repo.Db, &item)
 	if err != nil || item.Niin == nil || *item.Niin == "" {
 		return model.NiinLookup{}, shared.ErrNoItemsFound
 	}
@@ -36,7 +37,7 @@ func (repo *RepositoryImpl) ShortItemSearchNiin(niin string) (model.NiinLookup,
 	return item, nil
 }
 
-func (repo *RepositoryImpl) ShortItemSearchPart(part string) ([]model.NiinLookup, error) {
+func (repo *RepositoryImpl) ShortItemSearchPart(ctx context.Context, part string) ([]model.NiinLookup, error) {
 	var items []model.NiinLookup
 
 	stmt := SELECT(
@@ -46,7 +47,7 @@ func (repo *RepositoryImpl) ShortItemSearchPart(part string) ([]model.NiinLookup
 			INNER_JOIN(PartNumber, view.NiinLookup.Niin.EQ(PartNumber.Niin)),
 	).WHERE(PartNumber.PartNumber.EQ(String(strings.ToUpper(part))))
 
-	err := stmt.Query(repo.Db, &items)
+	err := stmt.QueryContext(ctx, repo.Db, &items)
 	if err != nil || len(items) == 0 {
 		return []model.NiinLookup{}, shared.ErrNoItemsFound
 	}
@@ -58,7 +59,7 @@ func (repo *RepositoryImpl) ShortItemSearchPart(part string) ([]model.NiinLookup
 // cancelled_niin column contains the given niin as a substring. The column
 // may store multiple NIINs in a single text field, so a LIKE contains query
 // is used rather than an equality check.
-func (repo *RepositoryImpl) ShortItemSearchCancelledNiin(niin string) ([]model.Nsn, error) {
+func (repo *RepositoryImpl) ShortItemSearchCancelledNiin(ctx context.Context, niin string) ([]model.Nsn, error) {
 	var items []model.Nsn
 
 	stmt := SELECT(
@@ -67,7 +68,7 @@ func (repo *RepositoryImpl) ShortItemSearchCancelledNiin(niin string) ([]model.N
 	).FROM(Nsn).
 		WHERE(Nsn.CancelledNiin.LIKE(String("%" + niin + "%")))
 
-	err := stmt.Query(repo.Db, &items)
+	err := stmt.QueryContext(ctx, repo.Db, &items)
 	if err != nil || len(items) == 0 {
 		return []model.Nsn{}, shared.ErrNoItemsFound
 	}
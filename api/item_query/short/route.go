@@ -1,12 +1,10 @@
 package short
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
-	"miltechserver/api/item_query/shared"
 	"miltechserver/api/response"
 )
 
@@ -32,13 +30,9 @@ func (handler *Handler) findShort(c *gin.Context) {
 		// When cancelled=true, use the two-step fallback path. Any other value
 		// (absent, "false", etc.) falls through to the original behaviour.
 		if c.Query("cancelled") == "true" {
-			results, err := handler.service.FindShortByNiinCancelled(value)
+			results, err := handler.service.FindShortByNiinCancelled(c.Request.Context(), value)
 			if err != nil {
-				if errors.Is(err, shared.ErrNoItemsFound) {
-					c.JSON(http.StatusNotFound, response.EmptyResponseMessage())
-					return
-				}
-				c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+				c.Error(err)
 				return
 			}
 			c.JSON(http.StatusOK, response.StandardResponse{
@@ -50,13 +44,9 @@ func (handler *Handler) findShort(c *gin.Context) {
 		}
 
 		// Original path — unchanged.
-		result, err := handler.service.FindShortByNiin(value)
+		result, err := handler.service.FindShortByNiin(c.Request.Context(), value)
 		if err != nil {
-			if errors.Is(err, shared.ErrNoItemsFound) {
-				c.JSON(http.StatusNotFound, response.EmptyResponseMessage())
-				return
-			}
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+			c.Error(err)
 			return
 		}
 		c.JSON(http.StatusOK, response.StandardResponse{
@@ -66,13 +56,9 @@ func (handler *Handler) findShort(c *gin.Context) {
 		})
 
 	case "part":
-		result, err := handler.service.FindShortByPart(value)
+		result, err := handler.service.FindShortByPart(c.Request.Context(), value)
 		if err != nil {
-			if errors.Is(err, shared.ErrNoItemsFound) {
-				c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-				return
-			}
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+			c.Error(err)
 			return
 		}
 		c.JSON(http.StatusOK, response.StandardResponse{
@@ -1,6 +1,7 @@
 package short
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -11,7 +12,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/item_query/shared"
+	"miltechserver/api/middleware"
 )
 
 type serviceStub struct {
@@ -23,15 +26,15 @@ type serviceStub struct {
 	cancelledErr  error
 }
 
-func (s *serviceStub) FindShortByNiin(string) (model.NiinLookup, error) {
+func (s *serviceStub) FindShortByNiin(context.Context, string) (model.NiinLookup, error) {
 	return s.niinResp, s.niinErr
 }
 
-func (s *serviceStub) FindShortByPart(string) ([]model.NiinLookup, error) {
+func (s *serviceStub) FindShortByPart(context.Context, string) ([]model.NiinLookup, error) {
 	return s.partResp, s.partErr
 }
 
-func (s *serviceStub) FindShortByNiinCancelled(string) ([]model.NiinLookup, error) {
+func (s *serviceStub) FindShortByNiinCancelled(context.Context, string) ([]model.NiinLookup, error) {
 	return s.cancelledResp, s.cancelledErr
 }
 
@@ -40,6 +43,7 @@ func (s *serviceStub) FindShortByNiinCancelled(string) ([]model.NiinLookup, erro
 func TestFindShortNiinNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{niinErr: shared.ErrNoItemsFound}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -49,11 +53,15 @@ func TestFindShortNiinNotFound(t *testing.T) {
 	router.ServeHTTP(resp, req)
 
 	require.Equal(t, http.StatusNotFound, resp.Code)
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+	payload := decodeJSON(t, resp.Body.Bytes())
+	require.Equal(t, "no_items_found", payload["code"])
 }
 
 func TestFindShortPartNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{partErr: shared.ErrNoItemsFound}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -68,6 +76,7 @@ func TestFindShortPartNotFound(t *testing.T) {
 func TestFindShortNiinServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{niinErr: errors.New("boom")}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -82,6 +91,7 @@ func TestFindShortNiinServerError(t *testing.T) {
 func TestFindShortPartSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	niin := "013469317"
 	stub := &serviceStub{partResp: []model.NiinLookup{{Niin: &niin}}}
 
@@ -102,6 +112,7 @@ func TestFindShortPartSuccess(t *testing.T) {
 func TestFindShortNiinSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	niin := "013469317"
 	stub := &serviceStub{niinResp: model.NiinLookup{Niin: &niin}}
 
@@ -122,6 +133,7 @@ func TestFindShortNiinSuccess(t *testing.T) {
 func TestFindShortUnknownMethodNoResponse(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -136,6 +148,7 @@ func TestFindShortUnknownMethodNoResponse(t *testing.T) {
 func TestFindShortNiinMissingValueUsesService(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{niinErr: shared.ErrNoItemsFound}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -154,6 +167,7 @@ func TestFindShortNiinMissingValueUsesService(t *testing.T) {
 func TestFindShortNiinCancelledSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	niin := "987654321"
 	stub := &serviceStub{cancelledResp: []model.NiinLookup{{Niin: &niin}}}
 
@@ -175,6 +189,7 @@ func TestFindShortNiinCancelledSuccess(t *testing.T) {
 func TestFindShortNiinCancelledNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{cancelledErr: shared.ErrNoItemsFound}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -191,6 +206,7 @@ func TestFindShortNiinCancelledNotFound(t *testing.T) {
 func TestFindShortNiinCancelledServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{cancelledErr: errors.New("db down")}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -208,6 +224,7 @@ func TestFindShortNiinCancelledServerError(t *testing.T) {
 func TestFindShortNiinCancelledFalseUsesOriginalPath(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{niinErr: shared.ErrNoItemsFound}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -1,12 +1,15 @@
 package short
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"strings"
+	"sync"
 
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/api/item_query/shared"
+	"miltechserver/metrics"
 )
 
 type analyticsEvent struct {
@@ -18,29 +21,59 @@ type ServiceImpl struct {
 	repo       Repository
 	analytics  shared.AnalyticsTracker
 	analyticsQ chan analyticsEvent
+	// queueMu guards analyticsQ against sends after Stop has closed it.
+	queueMu       sync.RWMutex
+	queueClosed   bool
+	analyticsDone chan struct{}
+	queueMetrics  *metrics.QueueGauge
 }
 
 func NewService(repo Repository, analytics shared.AnalyticsTracker) *ServiceImpl {
 	s := &ServiceImpl{
-		repo:       repo,
-		analytics:  analytics,
-		analyticsQ: make(chan analyticsEvent, 100),
+		repo:          repo,
+		analytics:     analytics,
+		analyticsQ:    make(chan analyticsEvent, 100),
+		analyticsDone: make(chan struct{}),
+		queueMetrics:  metrics.NewQueueGauge("item_search_analytics"),
 	}
 	go s.processAnalytics()
 	return s
 }
 
 // processAnalytics runs in the background, processing analytics events without blocking requests.
+// It exits once Stop closes the queue and every buffered event has been written.
 func (s *ServiceImpl) processAnalytics() {
+	defer close(s.analyticsDone)
 	for event := range s.analyticsQ {
-		if err := s.analytics.IncrementItemSearchSuccess(event.niin, event.nomenclature); err != nil {
+		s.queueMetrics.SetDepth(len(s.analyticsQ))
+		if err := s.analytics.IncrementItemSearchSuccess(context.Background(), event.niin, event.nomenclature); err != nil {
 			slog.Warn("Failed to increment analytics for item search", "niin", event.niin, "error", err)
 		}
 	}
 }
 
-func (service *ServiceImpl) FindShortByNiin(niin string) (model.NiinLookup, error) {
-	val, err := service.repo.ShortItemSearchNiin(niin)
+// Stop closes the analytics queue and waits for buffered events to be flushed.
+// Events tracked after Stop are dropped. Returns ctx.Err() if the flush does not
+// finish in time.
+func (s *ServiceImpl) Stop(ctx context.Context) error {
+	s.queueMu.Lock()
+	if !s.queueClosed {
+		s.queueClosed = true
+		close(s.analyticsQ)
+	}
+	s.queueMu.Unlock()
+
+	select {
+	case <-s.analyticsDone:
+		return nil
+	case <-ctx.Done():
+		slog.Warn("Analytics queue not fully flushed before shutdown", "remaining", len(s.analyticsQ))
+		return ctx.Err()
+	}
+}
+
+func (service *ServiceImpl) FindShortByNiin(ctx context.Context, niin string) (model.NiinLookup, error) {
+	val, err := service.repo.ShortItemSearchNiin(ctx, niin)
 	if err != nil {
 		return model.NiinLookup{}, err
 	}
@@ -51,8 +84,8 @@ func (service *ServiceImpl) FindShortByNiin(niin string) (model.NiinLookup, erro
 	return val, nil
 }
 
-func (service *ServiceImpl) FindShortByPart(part string) ([]model.NiinLookup, error) {
-	results, err := service.repo.ShortItemSearchPart(part)
+func (service *ServiceImpl) FindShortByPart(ctx context.Context, part string) ([]model.NiinLookup, error) {
+	results, err := service.repo.ShortItemSearchPart(ctx, part)
 	if err != nil {
 		return []model.NiinLookup{}, err
 	}
@@ -82,9 +115,9 @@ func (service *ServiceImpl) FindShortByPart(part string) ([]model.NiinLookup, er
 // Only when the primary search finds nothing does it fall back to querying
 // nsn.cancelled_niin for the given NIIN, then re-queries niin_lookup for
 // each unique canonical NIIN found there.
-func (service *ServiceImpl) FindShortByNiinCancelled(niin string) ([]model.NiinLookup, error) {
+func (service *ServiceImpl) FindShortByNiinCancelled(ctx context.Context, niin string) ([]model.NiinLookup, error) {
 	// Step 1: Primary niin_lookup search — identical to FindShortByNiin.
-	val, err := service.repo.ShortItemSearchNiin(niin)
+	val, err := service.repo.ShortItemSearchNiin(ctx, niin)
 	if err == nil {
 		normalizedNiin := normalizeNiinPointer(val.Niin, niin)
 		nomenclature := normalizeNiinPointer(val.ItemName, "")
@@ -98,7 +131,7 @@ func (service *ServiceImpl) FindShortByNiinCancelled(niin string) ([]model.NiinL
 	}
 
 	// Step 3: Search nsn.cancelled_niin for the given NIIN.
-	cancelledMatches, err := service.repo.ShortItemSearchCancelledNiin(niin)
+	cancelledMatches, err := service.repo.ShortItemSearchCancelledNiin(ctx, niin)
 	if err != nil {
 		return []model.NiinLookup{}, err
 	}
@@ -117,7 +150,7 @@ func (service *ServiceImpl) FindShortByNiinCancelled(niin string) ([]model.NiinL
 		}
 		seen[canonicalNiin] = struct{}{}
 
-		lookup, lookupErr := service.repo.ShortItemSearchNiin(canonicalNiin)
+		lookup, lookupErr := service.repo.ShortItemSearchNiin(ctx, canonicalNiin)
 		if lookupErr != nil {
 			// Canonical NIIN exists in nsn but has no niin_lookup entry — skip it.
 			continue
@@ -156,9 +189,19 @@ func (service *ServiceImpl) trackItemSearchSuccess(niin string, nomenclature str
 	if service.analytics == nil || niin == "" {
 		return
 	}
+
+	service.queueMu.RLock()
+	defer service.queueMu.RUnlock()
+	if service.queueClosed {
+		service.queueMetrics.Dropped(metrics.DropStopped)
+		slog.Warn("Analytics queue stopped, dropping event", "niin", niin)
+		return
+	}
 	select {
 	case service.analyticsQ <- analyticsEvent{niin: niin, nomenclature: nomenclature}:
+		service.queueMetrics.SetDepth(len(service.analyticsQ))
 	default:
+		service.queueMetrics.Dropped(metrics.DropFull)
 		slog.Warn("Analytics queue full, dropping event", "niin", niin)
 	}
 }
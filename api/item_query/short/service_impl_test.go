@@ -1,6 +1,7 @@
 package short
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -29,7 +30,7 @@ type niinCall struct {
 	err  error
 }
 
-func (r *repoStub) ShortItemSearchNiin(string) (model.NiinLookup, error) {
+func (r *repoStub) ShortItemSearchNiin(context.Context, string) (model.NiinLookup, error) {
 	if len(r.niinRespByCall) > 0 {
 		call := r.niinRespByCall[0]
 		r.niinRespByCall = r.niinRespByCall[1:]
@@ -38,11 +39,11 @@ func (r *repoStub) ShortItemSearchNiin(string) (model.NiinLookup, error) {
 	return r.niinResp, r.niinErr
 }
 
-func (r *repoStub) ShortItemSearchPart(string) ([]model.NiinLookup, error) {
+func (r *repoStub) ShortItemSearchPart(context.Context, string) ([]model.NiinLookup, error) {
 	return r.partResp, r.partErr
 }
 
-func (r *repoStub) ShortItemSearchCancelledNiin(string) ([]model.Nsn, error) {
+func (r *repoStub) ShortItemSearchCancelledNiin(context.Context, string) ([]model.Nsn, error) {
 	return r.cancelledResp, r.cancelledErr
 }
 
@@ -51,7 +52,7 @@ type analyticsStub struct {
 	fail  bool
 }
 
-func (a *analyticsStub) IncrementItemSearchSuccess(niin string, nomenclature string) error {
+func (a *analyticsStub) IncrementItemSearchSuccess(_ context.Context, niin string, nomenclature string) error {
 	a.calls = append(a.calls, niin+":"+nomenclature)
 	if a.fail {
 		return errors.New("analytics down")
@@ -68,7 +69,7 @@ func TestFindShortByNiinTracksAnalytics(t *testing.T) {
 	analytics := &analyticsStub{}
 	svc := NewService(stub, analytics)
 
-	result, err := svc.FindShortByNiin(niin)
+	result, err := svc.FindShortByNiin(context.Background(), niin)
 	require.NoError(t, err)
 	require.Equal(t, niin, deref(result.Niin))
 
@@ -84,7 +85,7 @@ func TestFindShortByPartTracksUniqueNiins(t *testing.T) {
 	analytics := &analyticsStub{}
 	svc := NewService(stub, analytics)
 
-	results, err := svc.FindShortByPart("part")
+	results, err := svc.FindShortByPart(context.Background(), "part")
 	require.NoError(t, err)
 	require.Len(t, results, 2)
 
@@ -93,11 +94,28 @@ func TestFindShortByPartTracksUniqueNiins(t *testing.T) {
 	require.Len(t, analytics.calls, 1)
 }
 
+func TestStopFlushesQueuedAnalytics(t *testing.T) {
+	analytics := &analyticsStub{}
+	svc := NewService(&repoStub{}, analytics)
+
+	for _, niin := range []string{"1", "2", "3"} {
+		svc.trackItemSearchSuccess(niin, "Widget")
+	}
+
+	require.NoError(t, svc.Stop(context.Background()))
+	require.Len(t, analytics.calls, 3)
+
+	// Tracking after Stop drops the event instead of panicking on the closed queue.
+	svc.trackItemSearchSuccess("4", "Widget")
+	require.Len(t, analytics.calls, 3)
+	require.NoError(t, svc.Stop(context.Background()))
+}
+
 func TestFindShortByNiinReturnsRepoError(t *testing.T) {
 	stub := &repoStub{niinErr: shared.ErrNoItemsFound}
 	svc := NewService(stub, &analyticsStub{})
 
-	_, err := svc.FindShortByNiin("bad")
+	_, err := svc.FindShortByNiin(context.Background(), "bad")
 	require.ErrorIs(t, err, shared.ErrNoItemsFound)
 }
 
@@ -108,7 +126,7 @@ func TestFindShortByNiinAnalyticsFailureDoesNotFail(t *testing.T) {
 	analytics := &analyticsStub{fail: true}
 	svc := NewService(stub, analytics)
 
-	result, err := svc.FindShortByNiin(niin)
+	result, err := svc.FindShortByNiin(context.Background(), niin)
 	require.NoError(t, err)
 	require.Equal(t, niin, deref(result.Niin))
 
@@ -128,7 +146,7 @@ func TestFindShortByNiinCancelledPrimaryHit(t *testing.T) {
 	analytics := &analyticsStub{}
 	svc := NewService(stub, analytics)
 
-	results, err := svc.FindShortByNiinCancelled(niin)
+	results, err := svc.FindShortByNiinCancelled(context.Background(), niin)
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 	require.Equal(t, niin, deref(results[0].Niin))
@@ -157,7 +175,7 @@ func TestFindShortByNiinCancelledFallbackSucceeds(t *testing.T) {
 	analytics := &analyticsStub{}
 	svc := NewService(stub, analytics)
 
-	results, err := svc.FindShortByNiinCancelled("OLD123")
+	results, err := svc.FindShortByNiinCancelled(context.Background(), "OLD123")
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 	require.Equal(t, canonicalNiin, deref(results[0].Niin))
@@ -175,7 +193,7 @@ func TestFindShortByNiinCancelledBothMiss(t *testing.T) {
 	}
 	svc := NewService(stub, &analyticsStub{})
 
-	_, err := svc.FindShortByNiinCancelled("UNKNOWN")
+	_, err := svc.FindShortByNiinCancelled(context.Background(), "UNKNOWN")
 	require.ErrorIs(t, err, shared.ErrNoItemsFound)
 }
 
@@ -196,7 +214,7 @@ func TestFindShortByNiinCancelledAllCanonicalSkipped(t *testing.T) {
 	}
 	svc := NewService(stub, &analyticsStub{})
 
-	_, err := svc.FindShortByNiinCancelled("GHOST")
+	_, err := svc.FindShortByNiinCancelled(context.Background(), "GHOST")
 	require.ErrorIs(t, err, shared.ErrNoItemsFound)
 }
 
@@ -220,7 +238,7 @@ func TestFindShortByNiinCancelledDeduplicatesCanonical(t *testing.T) {
 	analytics := &analyticsStub{}
 	svc := NewService(stub, analytics)
 
-	results, err := svc.FindShortByNiinCancelled("OLD")
+	results, err := svc.FindShortByNiinCancelled(context.Background(), "OLD")
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 
@@ -235,7 +253,7 @@ func TestFindShortByNiinCancelledPrimaryUnexpectedError(t *testing.T) {
 	stub := &repoStub{niinErr: unexpected}
 	svc := NewService(stub, &analyticsStub{})
 
-	_, err := svc.FindShortByNiinCancelled("any")
+	_, err := svc.FindShortByNiinCancelled(context.Background(), "any")
 	require.ErrorIs(t, err, unexpected)
 }
 
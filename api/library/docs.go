@@ -0,0 +1,38 @@
+package library
+
+import (
+	"miltechserver/api/library/pmcs_sbs"
+	"miltechserver/api/library/ps_mag"
+	"miltechserver/api/openapi"
+	"net/http"
+	"slices"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat([]openapi.Section{{
+	Tag: "Library",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getPMCSVehicles",
+			Method:   http.MethodGet,
+			Path:     "/library/pmcs/vehicles",
+			Summary:  "Returns a list of all available PMCS vehicle folders",
+			Response: PMCSVehiclesResponse{},
+		},
+		{
+			ID:       "getPMCSDocuments",
+			Method:   http.MethodGet,
+			Path:     "/library/pmcs/:vehicle/documents",
+			Summary:  "Returns a list of all PDF documents for a specific vehicle",
+			Response: DocumentsListResponse{},
+		},
+		{
+			ID:       "generateLibraryDownloadURL",
+			Method:   http.MethodGet,
+			Path:     "/library/download",
+			Summary:  "Returns a time-limited SAS URL for downloading a document",
+			Query:    []openapi.Param{{Name: "blob_path", Required: true}},
+			Response: DownloadURLResponse{},
+		},
+	},
+}}, pmcs_sbs.Docs, ps_mag.Docs)
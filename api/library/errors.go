@@ -1,13 +1,13 @@
 package library
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrEmptyVehicleName = errors.New("vehicle name cannot be empty")
-	ErrEmptyBlobPath    = errors.New("blob path cannot be empty")
-	ErrInvalidBlobPath  = errors.New("invalid blob path: must start with pmcs/ or bii/")
-	ErrInvalidFileType  = errors.New("invalid file type: only PDF files can be downloaded")
-	ErrDocumentNotFound = errors.New("document not found")
-	ErrBlobListFailed   = errors.New("failed to list blobs")
-	ErrSASGenFailed     = errors.New("failed to generate download URL")
+	ErrEmptyVehicleName = apperror.Validation("vehicle_name_empty", "vehicle name cannot be empty")
+	ErrEmptyBlobPath    = apperror.Validation("blob_path_empty", "blob path cannot be empty")
+	ErrInvalidBlobPath  = apperror.Validation("invalid_blob_path", "invalid blob path: must start with pmcs/ or bii/")
+	ErrInvalidFileType  = apperror.Validation("invalid_file_type", "invalid file type: only PDF files can be downloaded")
+	ErrDocumentNotFound = apperror.NotFound("document_not_found", "document not found")
+	ErrBlobListFailed   = apperror.Upstream("blob_list_failed", "failed to list blobs", nil)
+	ErrSASGenFailed     = apperror.Upstream("download_url_failed", "failed to generate download URL", nil)
 )
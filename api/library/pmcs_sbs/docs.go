@@ -0,0 +1,44 @@
+package pmcs_sbs
+
+import (
+	"encoding/json"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Library",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listPMCSSBSFolders",
+			Method:   http.MethodGet,
+			Path:     "/library/pmcs-sbs/folders",
+			Summary:  "Returns all top-level folders in the PMCS SBS library",
+			Response: FoldersListResponse{},
+		},
+		{
+			ID:       "listPMCSSBSFiles",
+			Method:   http.MethodGet,
+			Path:     "/library/pmcs-sbs/:folder/files",
+			Summary:  "Returns all JSON files in a specific PMCS SBS folder",
+			Response: FilesListResponse{},
+		},
+		{
+			ID:       "getPMCSSBSFileContent",
+			Method:   http.MethodGet,
+			Path:     "/library/pmcs-sbs/content",
+			Summary:  "Fetches a JSON blob from Azure and returns its raw content",
+			Query:    []openapi.Param{{Name: "blob_path", Required: true}},
+			Response: json.RawMessage{},
+		},
+		{
+			ID:          "getPMCSSBSImage",
+			Method:      http.MethodGet,
+			Path:        "/library/pmcs-sbs/image",
+			Summary:     "Fetches a guide item PNG from Azure and streams its raw bytes",
+			Query:       []openapi.Param{{Name: "blob_path", Required: true}, {Name: "image_name", Required: true}},
+			ContentType: "image/png",
+		},
+	},
+}}
@@ -1,19 +1,17 @@
 package pmcs_sbs
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrEmptyFolderName  = errors.New("folder name cannot be empty")
-	ErrEmptyBlobPath    = errors.New("blob path cannot be empty")
-	ErrEmptyImageName   = errors.New("image name cannot be empty")
-	ErrInvalidBlobPath  = errors.New("invalid blob path: must start with pmcs_sbs/")
-	ErrInvalidImageName = errors.New(
-		"invalid image name: must be an extensionless PNG basename",
-	)
-	ErrInvalidFileType = errors.New("invalid file type: only JSON files are accessible")
-	ErrFileNotFound    = errors.New("file not found")
-	ErrBlobListFailed  = errors.New("failed to list blobs")
-	ErrBlobReadFailed  = errors.New("failed to read blob content")
-	ErrInvalidJSON     = errors.New("blob content is not valid JSON")
-	ErrBlobTooLarge    = errors.New("blob content exceeds maximum allowed size")
+	ErrEmptyFolderName  = apperror.Validation("folder_name_empty", "folder name cannot be empty")
+	ErrEmptyBlobPath    = apperror.Validation("blob_path_empty", "blob path cannot be empty")
+	ErrEmptyImageName   = apperror.Validation("image_name_empty", "image name cannot be empty")
+	ErrInvalidBlobPath  = apperror.Validation("invalid_blob_path", "invalid blob path: must start with pmcs_sbs/")
+	ErrInvalidImageName = apperror.Validation("invalid_image_name", "invalid image name: must be an extensionless PNG basename")
+	ErrInvalidFileType  = apperror.Validation("invalid_file_type", "invalid file type: only JSON files are accessible")
+	ErrFileNotFound     = apperror.NotFound("file_not_found", "file not found")
+	ErrBlobListFailed   = apperror.Upstream("blob_list_failed", "failed to list blobs", nil)
+	ErrBlobReadFailed   = apperror.Upstream("blob_read_failed", "failed to read blob content", nil)
+	ErrInvalidJSON      = apperror.New(apperror.KindInternal, "invalid_blob_json", "blob content is not valid JSON")
+	ErrBlobTooLarge     = apperror.New(apperror.KindInternal, "blob_too_large", "blob content exceeds maximum allowed size")
 )
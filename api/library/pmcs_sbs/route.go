@@ -7,11 +7,13 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/middleware"
 	"miltechserver/api/response"
+	"miltechserver/ratelimit"
+	"miltechserver/storage"
 )
 
 // Handler holds the pmcs_sbs service dependency.
@@ -21,19 +23,20 @@ type Handler struct {
 
 // RegisterHandlers wires pmcs_sbs routes into the public router group.
 // Called from api/library/route.go.
-func RegisterHandlers(publicGroup *gin.RouterGroup, blobClient *azblob.Client) {
-	svc := NewService(blobClient)
-	registerHandlers(publicGroup, svc)
+func RegisterHandlers(publicGroup *gin.RouterGroup, store storage.ObjectStore, limiter *ratelimit.Limiter) {
+	svc := NewService(store)
+	registerHandlers(publicGroup, svc, limiter)
 }
 
 // registerHandlers is the internal wiring function used directly by tests.
-func registerHandlers(publicGroup *gin.RouterGroup, svc Service) {
+func registerHandlers(publicGroup *gin.RouterGroup, svc Service, limiter *ratelimit.Limiter) {
 	h := Handler{service: svc}
 	publicGroup.GET("/library/pmcs-sbs/folders", h.getFolders)
 	publicGroup.GET("/library/pmcs-sbs/:folder/files", h.getFiles)
-	// Rate-limited: each IP is allowed a burst of 10 requests, sustained at 2 req/s.
-	publicGroup.GET("/library/pmcs-sbs/content", middleware.RateLimiter(), h.getFileContent)
-	publicGroup.GET("/library/pmcs-sbs/image", middleware.RateLimiter(), h.getImage)
+	// Rate-limited per client IP by middleware.DownloadPolicy.
+	downloads := middleware.RateLimit(limiter, middleware.DownloadPolicy, middleware.ByClientIP)
+	publicGroup.GET("/library/pmcs-sbs/content", downloads, h.getFileContent)
+	publicGroup.GET("/library/pmcs-sbs/image", downloads, h.getImage)
 }
 
 // getFolders returns all top-level folders in the PMCS SBS library.
@@ -44,9 +47,7 @@ func (h *Handler) getFolders(c *gin.Context) {
 	folders, err := h.service.GetFolders(c.Request.Context())
 	if err != nil {
 		slog.Error("Failed to retrieve PMCS SBS folders", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve PMCS SBS folders",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -63,18 +64,14 @@ func (h *Handler) getFiles(c *gin.Context) {
 
 	if strings.TrimSpace(folderName) == "" {
 		slog.Warn("GetPMCSSBSFiles called with empty folder name")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Folder name is required",
-		})
+		c.Error(apperror.MissingParameter("folder"))
 		return
 	}
 
 	files, err := h.service.GetFiles(c.Request.Context(), folderName)
 	if err != nil {
 		slog.Error("Failed to retrieve PMCS SBS files", "error", err, "folder", folderName)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve PMCS SBS files",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -91,9 +88,7 @@ func (h *Handler) getFileContent(c *gin.Context) {
 
 	if strings.TrimSpace(blobPath) == "" {
 		slog.Warn("GetPMCSSBSFileContent called with empty blob_path")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "blob_path query parameter is required",
-		})
+		c.Error(apperror.MissingParameter("blob_path"))
 		return
 	}
 
@@ -102,22 +97,12 @@ func (h *Handler) getFileContent(c *gin.Context) {
 		switch {
 		case errors.Is(err, ErrFileNotFound):
 			slog.Warn("PMCS SBS file not found", "blobPath", blobPath, "error", err)
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "File not found",
-				"details": "The requested file does not exist or is not accessible",
-			})
 		case errors.Is(err, ErrEmptyBlobPath), errors.Is(err, ErrInvalidBlobPath), errors.Is(err, ErrInvalidFileType):
 			slog.Warn("Invalid blob path for PMCS SBS content", "blobPath", blobPath, "error", err)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid request",
-				"details": err.Error(),
-			})
 		default:
 			slog.Error("Failed to retrieve PMCS SBS file content", "error", err, "blobPath", blobPath)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to retrieve file content",
-			})
 		}
+		c.Error(err)
 		return
 	}
 
@@ -135,17 +120,13 @@ func (h *Handler) getImage(c *gin.Context) {
 
 	if strings.TrimSpace(blobPath) == "" {
 		slog.Warn("GetPMCSSBSImage called with empty blob_path", "imageName", imageName)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "blob_path query parameter is required",
-		})
+		c.Error(apperror.MissingParameter("blob_path"))
 		return
 	}
 
 	if strings.TrimSpace(imageName) == "" {
 		slog.Warn("GetPMCSSBSImage called with empty image_name", "blobPath", blobPath)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "image_name query parameter is required",
-		})
+		c.Error(apperror.MissingParameter("image_name"))
 		return
 	}
 
@@ -154,33 +135,21 @@ func (h *Handler) getImage(c *gin.Context) {
 		switch {
 		case errors.Is(err, ErrFileNotFound):
 			slog.Warn("PMCS SBS image not found", "blobPath", blobPath, "imageName", imageName, "error", err)
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Image not found",
-				"details": "The requested image does not exist or is not accessible",
-			})
 		case errors.Is(err, ErrEmptyBlobPath),
 			errors.Is(err, ErrInvalidBlobPath),
 			errors.Is(err, ErrInvalidFileType),
 			errors.Is(err, ErrEmptyImageName),
 			errors.Is(err, ErrInvalidImageName):
 			slog.Warn("Invalid request for PMCS SBS image", "blobPath", blobPath, "imageName", imageName, "error", err)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid request",
-				"details": err.Error(),
-			})
 		default:
 			slog.Error("Failed to retrieve PMCS SBS image", "error", err, "blobPath", blobPath, "imageName", imageName)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to retrieve image",
-			})
 		}
+		c.Error(err)
 		return
 	}
 	if image == nil || image.Body == nil {
 		slog.Error("PMCS SBS image download returned empty response", "blobPath", blobPath, "imageName", imageName)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve image",
-		})
+		c.Error(ErrBlobReadFailed)
 		return
 	}
 	defer image.Body.Close()
@@ -12,6 +12,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/middleware"
 )
 
 // serviceStub implements Service for handler testing.
@@ -68,13 +71,14 @@ func (s *capturingStub) GetImage(_ context.Context, blobPath string, imageName s
 func TestGetFoldersSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{
 		foldersResp: &FoldersListResponse{
 			Folders: []FolderResponse{{Name: "hmmwv", FullPath: "pmcs_sbs/hmmwv/", DisplayName: "HMMWV"}},
 			Count:   1,
 		},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/folders", nil)
 	resp := httptest.NewRecorder()
@@ -86,8 +90,9 @@ func TestGetFoldersSuccess(t *testing.T) {
 func TestGetFoldersEmptyResult(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{foldersResp: &FoldersListResponse{Folders: []FolderResponse{}, Count: 0}}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/folders", nil)
 	resp := httptest.NewRecorder()
@@ -99,14 +104,15 @@ func TestGetFoldersEmptyResult(t *testing.T) {
 func TestGetFoldersServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{foldersErr: ErrBlobListFailed}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/folders", nil)
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusBadGateway, resp.Code)
 }
 
 // --- GetFiles ---
@@ -114,6 +120,7 @@ func TestGetFoldersServiceError(t *testing.T) {
 func TestGetFilesSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{
 		filesResp: &FilesListResponse{
 			FolderName: "hmmwv",
@@ -121,7 +128,7 @@ func TestGetFilesSuccess(t *testing.T) {
 			Count:      1,
 		},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/hmmwv/files", nil)
 	resp := httptest.NewRecorder()
@@ -133,8 +140,9 @@ func TestGetFilesSuccess(t *testing.T) {
 func TestGetFilesEmptyFolder(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{filesResp: &FilesListResponse{FolderName: "empty", Files: []FileResponse{}, Count: 0}}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/empty/files", nil)
 	resp := httptest.NewRecorder()
@@ -146,14 +154,15 @@ func TestGetFilesEmptyFolder(t *testing.T) {
 func TestGetFilesServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{filesErr: ErrBlobListFailed}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/hmmwv/files", nil)
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusBadGateway, resp.Code)
 }
 
 // --- GetFileContent ---
@@ -161,8 +170,9 @@ func TestGetFilesServiceError(t *testing.T) {
 func TestGetFileContentSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{contentResp: json.RawMessage(`{"key":"value"}`)}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/content?blob_path=pmcs_sbs/hmmwv/file.json", nil)
 	resp := httptest.NewRecorder()
@@ -174,8 +184,9 @@ func TestGetFileContentSuccess(t *testing.T) {
 func TestGetFileContentMissingBlobPath(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/content", nil)
 	resp := httptest.NewRecorder()
@@ -187,8 +198,9 @@ func TestGetFileContentMissingBlobPath(t *testing.T) {
 func TestGetFileContentNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{contentErr: ErrFileNotFound}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/content?blob_path=pmcs_sbs/hmmwv/missing.json", nil)
 	resp := httptest.NewRecorder()
@@ -200,8 +212,9 @@ func TestGetFileContentNotFound(t *testing.T) {
 func TestGetFileContentInvalidPath(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{contentErr: ErrInvalidBlobPath}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/content?blob_path=pmcs/bad.json", nil)
 	resp := httptest.NewRecorder()
@@ -213,8 +226,9 @@ func TestGetFileContentInvalidPath(t *testing.T) {
 func TestGetFileContentInvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{contentErr: ErrInvalidJSON}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/content?blob_path=pmcs_sbs/hmmwv/corrupt.json", nil)
 	resp := httptest.NewRecorder()
@@ -226,8 +240,9 @@ func TestGetFileContentInvalidJSON(t *testing.T) {
 func TestGetFileContentReadFailed(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{contentErr: errors.New("network error")}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/content?blob_path=pmcs_sbs/hmmwv/file.json", nil)
 	resp := httptest.NewRecorder()
@@ -239,10 +254,11 @@ func TestGetFileContentReadFailed(t *testing.T) {
 func TestGetFilesPassesFolderName(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &capturingStub{
 		serviceStub: serviceStub{filesResp: &FilesListResponse{FolderName: "hmmwv", Files: []FileResponse{}, Count: 0}},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/hmmwv/files", nil)
 	resp := httptest.NewRecorder()
@@ -255,10 +271,11 @@ func TestGetFilesPassesFolderName(t *testing.T) {
 func TestGetFileContentPassesBlobPath(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &capturingStub{
 		serviceStub: serviceStub{contentResp: json.RawMessage(`{}`)},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs-sbs/content?blob_path=pmcs_sbs/hmmwv/file.json", nil)
 	resp := httptest.NewRecorder()
@@ -279,6 +296,7 @@ func newImageRequest(target string, remoteAddr string) *http.Request {
 func TestGetImageSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &capturingStub{
 		serviceStub: serviceStub{
 			imageResp: &ImageDownload{
@@ -290,7 +308,7 @@ func TestGetImageSuccess(t *testing.T) {
 			},
 		},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := newImageRequest("/api/v1/library/pmcs-sbs/image?blob_path=pmcs_sbs/hmmwv/file.json&image_name=Before_12", "198.51.100.10:1234")
 	resp := httptest.NewRecorder()
@@ -307,29 +325,29 @@ func TestGetImageSuccess(t *testing.T) {
 func TestGetImageMissingBlobPath(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &capturingStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := newImageRequest("/api/v1/library/pmcs-sbs/image?image_name=Before_12", "198.51.100.11:1234")
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	require.Equal(t, http.StatusBadRequest, resp.Code)
-	require.JSONEq(t, `{"error":"blob_path query parameter is required"}`, resp.Body.String())
+	requireProblem(t, resp, http.StatusBadRequest, "missing_parameter")
 }
 
 func TestGetImageMissingImageName(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &capturingStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := newImageRequest("/api/v1/library/pmcs-sbs/image?blob_path=pmcs_sbs/hmmwv/file.json", "198.51.100.12:1234")
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	require.Equal(t, http.StatusBadRequest, resp.Code)
-	require.JSONEq(t, `{"error":"image_name query parameter is required"}`, resp.Body.String())
+	requireProblem(t, resp, http.StatusBadRequest, "missing_parameter")
 }
 
 func TestGetImageInvalidRequestErrors(t *testing.T) {
@@ -345,17 +363,17 @@ func TestGetImageInvalidRequestErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
 			router := gin.New()
+			router.Use(middleware.ErrorHandler)
 			stub := &capturingStub{
 				serviceStub: serviceStub{imageErr: tc.err},
 			}
-			registerHandlers(router.Group("/api/v1"), stub)
+			registerHandlers(router.Group("/api/v1"), stub, nil)
 
 			req := newImageRequest("/api/v1/library/pmcs-sbs/image?blob_path=pmcs_sbs/hmmwv/file.json&image_name=Before_12", "198.51.100.13:1234")
 			resp := httptest.NewRecorder()
 			router.ServeHTTP(resp, req)
 
-			require.Equal(t, http.StatusBadRequest, resp.Code)
-			require.JSONEq(t, `{"error":"Invalid request","details":"`+tc.err.Error()+`"}`, resp.Body.String())
+			requireProblem(t, resp, http.StatusBadRequest, apperror.As(tc.err).Code)
 		})
 	}
 }
@@ -363,36 +381,36 @@ func TestGetImageInvalidRequestErrors(t *testing.T) {
 func TestGetImageNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &capturingStub{
 		serviceStub: serviceStub{imageErr: ErrFileNotFound},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := newImageRequest("/api/v1/library/pmcs-sbs/image?blob_path=pmcs_sbs/hmmwv/file.json&image_name=Before_12", "198.51.100.14:1234")
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	require.Equal(t, http.StatusNotFound, resp.Code)
-	require.JSONEq(t, `{"error":"Image not found","details":"The requested image does not exist or is not accessible"}`, resp.Body.String())
+	requireProblem(t, resp, http.StatusNotFound, "file_not_found")
 }
 
 func TestGetImageGenericError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &capturingStub{
 		serviceStub: serviceStub{imageErr: errors.New("network error")},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := newImageRequest("/api/v1/library/pmcs-sbs/image?blob_path=pmcs_sbs/hmmwv/file.json&image_name=Before_12", "198.51.100.15:1234")
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
-	require.JSONEq(t, `{"error":"Failed to retrieve image"}`, resp.Body.String())
+	requireProblem(t, resp, http.StatusInternalServerError, "internal_error")
 }
 
-func TestGetImageNilDownloadResponseReturnsGenericError(t *testing.T) {
+func TestGetImageNilDownloadResponseReturnsUpstreamError(t *testing.T) {
 	tests := []struct {
 		name      string
 		imageResp *ImageDownload
@@ -405,10 +423,11 @@ func TestGetImageNilDownloadResponseReturnsGenericError(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
 			router := gin.New()
+			router.Use(middleware.ErrorHandler)
 			stub := &capturingStub{
 				serviceStub: serviceStub{imageResp: tc.imageResp},
 			}
-			registerHandlers(router.Group("/api/v1"), stub)
+			registerHandlers(router.Group("/api/v1"), stub, nil)
 
 			req := newImageRequest("/api/v1/library/pmcs-sbs/image?blob_path=pmcs_sbs/hmmwv/file.json&image_name=Before_12", "198.51.100.16:1234")
 			resp := httptest.NewRecorder()
@@ -416,8 +435,7 @@ func TestGetImageNilDownloadResponseReturnsGenericError(t *testing.T) {
 			require.NotPanics(t, func() {
 				router.ServeHTTP(resp, req)
 			})
-			require.Equal(t, http.StatusInternalServerError, resp.Code)
-			require.JSONEq(t, `{"error":"Failed to retrieve image"}`, resp.Body.String())
+			requireProblem(t, resp, http.StatusBadGateway, "blob_read_failed")
 		})
 	}
 }
@@ -425,6 +443,7 @@ func TestGetImageNilDownloadResponseReturnsGenericError(t *testing.T) {
 func TestGetImagePassesBlobPathAndImageName(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &capturingStub{
 		serviceStub: serviceStub{
 			imageResp: &ImageDownload{
@@ -435,7 +454,7 @@ func TestGetImagePassesBlobPathAndImageName(t *testing.T) {
 			},
 		},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := newImageRequest("/api/v1/library/pmcs-sbs/image?blob_path=%20pmcs_sbs%2Fhmmwv%2Ffile.json%20&image_name=%20Before_12%20", "198.51.100.16:1234")
 	resp := httptest.NewRecorder()
@@ -445,3 +464,13 @@ func TestGetImagePassesBlobPathAndImageName(t *testing.T) {
 	require.Equal(t, " pmcs_sbs/hmmwv/file.json ", stub.capturedImageBlobPath)
 	require.Equal(t, " Before_12 ", stub.capturedImageName)
 }
+
+func requireProblem(t *testing.T, resp *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	require.Equal(t, status, resp.Code)
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+
+	var problem apperror.Problem
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem))
+	require.Equal(t, code, problem.Code)
+}
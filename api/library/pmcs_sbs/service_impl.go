@@ -3,6 +3,7 @@ package pmcs_sbs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,9 +11,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"miltechserver/storage"
 )
 
 const (
@@ -21,14 +20,14 @@ const (
 	maxBlobBytes         = 10 << 20 // 10 MB
 )
 
-// ServiceImpl holds the Azure blob client for all blob operations.
+// ServiceImpl holds the object store used for all blob operations.
 type ServiceImpl struct {
-	blobClient *azblob.Client
+	store storage.ObjectStore
 }
 
-// NewService creates a Service backed by the given Azure blob client.
-func NewService(blobClient *azblob.Client) Service {
-	return &ServiceImpl{blobClient: blobClient}
+// NewService creates a Service backed by the given object store.
+func NewService(store storage.ObjectStore) Service {
+	return &ServiceImpl{store: store}
 }
 
 // GetFolders retrieves all top-level folders from pmcs_sbs/ in Azure Blob Storage.
@@ -37,40 +36,27 @@ func (s *ServiceImpl) GetFolders(ctx context.Context) (*FoldersListResponse, err
 		"container", LibraryContainerName,
 		"prefix", PMCSSBSPrefix)
 
-	containerClient := s.blobClient.ServiceClient().NewContainerClient(LibraryContainerName)
-	prefix := PMCSSBSPrefix
-	pager := containerClient.NewListBlobsHierarchyPager(
-		"/",
-		&container.ListBlobsHierarchyOptions{Prefix: &prefix},
-	)
+	prefixes, err := s.store.ListPrefixes(ctx, LibraryContainerName, PMCSSBSPrefix)
+	if err != nil {
+		slog.Error("Failed to list PMCS SBS folders from Azure Blob Storage",
+			"error", err,
+			"container", LibraryContainerName,
+			"prefix", PMCSSBSPrefix)
+		return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	}
 
 	folders := []FolderResponse{}
 
-	for pager.More() {
-		page, err := pager.NextPage(ctx)
-		if err != nil {
-			slog.Error("Failed to list PMCS SBS folders from Azure Blob Storage",
-				"error", err,
-				"container", LibraryContainerName,
-				"prefix", PMCSSBSPrefix)
-			return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
-		}
-
-		for _, p := range page.Segment.BlobPrefixes {
-			if p.Name == nil {
-				continue
-			}
-			fullPath := *p.Name
-			folderName := strings.TrimSuffix(strings.TrimPrefix(fullPath, PMCSSBSPrefix), "/")
-			if folderName == "" {
-				continue
-			}
-			folders = append(folders, FolderResponse{
-				Name:        folderName,
-				FullPath:    fullPath,
-				DisplayName: formatDisplayName(folderName),
-			})
+	for _, fullPath := range prefixes {
+		folderName := strings.TrimSuffix(strings.TrimPrefix(fullPath, PMCSSBSPrefix), "/")
+		if folderName == "" {
+			continue
 		}
+		folders = append(folders, FolderResponse{
+			Name:        folderName,
+			FullPath:    fullPath,
+			DisplayName: formatDisplayName(folderName),
+		})
 	}
 
 	slog.Info("Successfully fetched PMCS SBS folders",
@@ -95,49 +81,35 @@ func (s *ServiceImpl) GetFiles(ctx context.Context, folderName string) (*FilesLi
 		"container", LibraryContainerName,
 		"folderPrefix", folderPrefix)
 
-	containerClient := s.blobClient.ServiceClient().NewContainerClient(LibraryContainerName)
-	pager := containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
-		Prefix: &folderPrefix,
-	})
+	blobs, err := s.store.List(ctx, LibraryContainerName, folderPrefix)
+	if err != nil {
+		slog.Error("Failed to list PMCS SBS files from Azure Blob Storage",
+			"error", err,
+			"container", LibraryContainerName,
+			"folderPrefix", folderPrefix)
+		return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	}
 
 	files := []FileResponse{}
 
-	for pager.More() {
-		page, err := pager.NextPage(ctx)
-		if err != nil {
-			slog.Error("Failed to list PMCS SBS files from Azure Blob Storage",
-				"error", err,
-				"container", LibraryContainerName,
-				"folderPrefix", folderPrefix)
-			return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	for _, blob := range blobs {
+		blobPath := blob.Name
+		if !strings.HasSuffix(strings.ToLower(blobPath), ".json") {
+			slog.Debug("Skipping non-JSON file", "blobPath", blobPath)
+			continue
 		}
 
-		for _, blob := range page.Segment.BlobItems {
-			if blob.Name == nil {
-				continue
-			}
-			blobPath := *blob.Name
-			if !strings.HasSuffix(strings.ToLower(blobPath), ".json") {
-				slog.Debug("Skipping non-JSON file", "blobPath", blobPath)
-				continue
-			}
-
-			var sizeBytes int64
-			if blob.Properties != nil && blob.Properties.ContentLength != nil {
-				sizeBytes = *blob.Properties.ContentLength
-			}
-			var lastModified string
-			if blob.Properties != nil && blob.Properties.LastModified != nil {
-				lastModified = blob.Properties.LastModified.Format(time.RFC3339)
-			}
-
-			files = append(files, FileResponse{
-				Name:         extractFileName(blobPath),
-				BlobPath:     blobPath,
-				SizeBytes:    sizeBytes,
-				LastModified: lastModified,
-			})
+		var lastModified string
+		if !blob.LastModified.IsZero() {
+			lastModified = blob.LastModified.Format(time.RFC3339)
 		}
+
+		files = append(files, FileResponse{
+			Name:         extractFileName(blobPath),
+			BlobPath:     blobPath,
+			SizeBytes:    blob.Size,
+			LastModified: lastModified,
+		})
 	}
 
 	slog.Info("Successfully fetched PMCS SBS files",
@@ -160,8 +132,7 @@ func (s *ServiceImpl) GetFileContent(ctx context.Context, blobPath string) (json
 		"container", LibraryContainerName,
 		"blobPath", blobPath)
 
-	blobClient := s.blobClient.ServiceClient().NewContainerClient(LibraryContainerName).NewBlobClient(blobPath)
-	downloadResponse, err := blobClient.DownloadStream(ctx, nil)
+	downloadResponse, err := s.store.Stream(ctx, LibraryContainerName, blobPath)
 	if err != nil {
 		slog.Error("Failed to download PMCS SBS file", "error", err, "blobPath", blobPath)
 		return nil, fmt.Errorf("%w: %v", ErrFileNotFound, err)
@@ -218,8 +189,7 @@ func (s *ServiceImpl) GetImage(ctx context.Context, guideBlobPath string, imageN
 		"imageName", cleanedImageName,
 		"imageBlobPath", imageBlobPath)
 
-	blobClient := s.blobClient.ServiceClient().NewContainerClient(LibraryContainerName).NewBlobClient(imageBlobPath)
-	downloadResponse, err := blobClient.DownloadStream(ctx, nil)
+	downloadResponse, err := s.store.Stream(ctx, LibraryContainerName, imageBlobPath)
 	if err != nil {
 		slog.Error("Failed to download PMCS SBS image",
 			"container", LibraryContainerName,
@@ -233,10 +203,7 @@ func (s *ServiceImpl) GetImage(ctx context.Context, guideBlobPath string, imageN
 		return nil, fmt.Errorf("%w: %v", ErrBlobReadFailed, err)
 	}
 
-	contentLength := int64(-1)
-	if downloadResponse.ContentLength != nil {
-		contentLength = *downloadResponse.ContentLength
-	}
+	contentLength := downloadResponse.ContentLength
 
 	slog.Info("Successfully started PMCS SBS image download",
 		"container", LibraryContainerName,
@@ -314,7 +281,7 @@ func buildImageBlobPath(guideBlobPath string, imageName string) (string, error)
 }
 
 func isBlobNotFoundError(err error) bool {
-	return bloberror.HasCode(err, bloberror.BlobNotFound)
+	return errors.Is(err, storage.ErrNotFound)
 }
 
 // formatDisplayName converts folder names to human-readable display names.
@@ -3,11 +3,12 @@ package pmcs_sbs
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/stretchr/testify/require"
+
+	"miltechserver/storage"
 )
 
 func TestFormatDisplayName(t *testing.T) {
@@ -168,13 +169,13 @@ func TestIsBlobNotFoundError(t *testing.T) {
 		want bool
 	}{
 		{
-			name: "blob not found response error",
-			err:  &azcore.ResponseError{ErrorCode: string(bloberror.BlobNotFound)},
+			name: "store not found error",
+			err:  fmt.Errorf("failed to download from blob storage: %w", storage.ErrNotFound),
 			want: true,
 		},
 		{
-			name: "authentication failure response error",
-			err:  &azcore.ResponseError{ErrorCode: string(bloberror.AuthenticationFailed)},
+			name: "store invalid name error",
+			err:  fmt.Errorf("failed to download from blob storage: %w", storage.ErrInvalidName),
 			want: false,
 		},
 		{
@@ -1,44 +1,55 @@
 package ps_mag
 
 import (
-	"sync"
+	"context"
+	"slices"
 	"time"
+
+	"miltechserver/cache"
 )
 
-// issueCache is a single-entry TTL cache for the full ps-mag issue list.
-// Thread-safe for concurrent access.
+// issueCache holds the full ps-mag issue list as the single entry of a
+// shared cache. It copies the list on the way in and out, so callers may
+// sort and slice it freely.
 type issueCache struct {
-	mu        sync.RWMutex
-	issues    []PSMagIssueResponse
-	expiresAt time.Time
-	ttl       time.Duration
+	issues *cache.Cache[struct{}, []PSMagIssueResponse]
 }
 
 func newIssueCache(ttl time.Duration) *issueCache {
-	return &issueCache{ttl: ttl}
+	return &issueCache{issues: cache.New[struct{}, []PSMagIssueResponse](cache.Options{
+		Name:     "ps_mag_issues",
+		Capacity: 1,
+		TTL:      ttl,
+	})}
 }
 
 // get returns a copy of the cached issue list and true if the cache is warm and
 // not expired. Returns nil and false on a cache miss.
 func (c *issueCache) get() ([]PSMagIssueResponse, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	if c.issues == nil || time.Now().After(c.expiresAt) {
+	issues, ok := c.issues.Get(struct{}{})
+	if !ok {
 		return nil, false
 	}
-	// Return a copy so callers cannot mutate the cached slice.
-	cp := make([]PSMagIssueResponse, len(c.issues))
-	copy(cp, c.issues)
-	return cp, true
+	return slices.Clone(issues), true
+}
+
+// load returns a copy of the cached issue list, calling fetch on a miss.
+// Concurrent misses share one fetch.
+func (c *issueCache) load(ctx context.Context, fetch func(ctx context.Context) ([]PSMagIssueResponse, error)) ([]PSMagIssueResponse, error) {
+	issues, err := c.issues.GetOrLoad(ctx, struct{}{}, fetch)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(issues), nil
+}
+
+// status reports whether the issue list is cached.
+func (c *issueCache) status() map[string]any {
+	stats := c.issues.Stats()
+	return map[string]any{"warm": stats.Entries > 0, "stats": stats}
 }
 
 // set stores a defensive copy of issues in the cache and resets the expiry clock.
-// The copy is made before acquiring the lock to keep the critical section minimal.
 func (c *issueCache) set(issues []PSMagIssueResponse) {
-	cp := make([]PSMagIssueResponse, len(issues))
-	copy(cp, issues)
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.issues = cp
-	c.expiresAt = time.Now().Add(c.ttl)
+	c.issues.Set(struct{}{}, slices.Clone(issues))
 }
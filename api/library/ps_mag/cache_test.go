@@ -1,6 +1,7 @@
 package ps_mag
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -70,3 +71,21 @@ func TestIssueCache_SetDoesNotAliasCallerSlice(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "original.pdf", got[0].Name)
 }
+
+func TestIssueCache_LoadFetchesOnMissOnly(t *testing.T) {
+	c := newIssueCache(5 * time.Minute)
+	fetches := 0
+	fetch := func(context.Context) ([]PSMagIssueResponse, error) {
+		fetches++
+		return []PSMagIssueResponse{{Name: "original.pdf"}}, nil
+	}
+
+	got, err := c.load(context.Background(), fetch)
+	require.NoError(t, err)
+	got[0].Name = "mutated.pdf"
+
+	got, err = c.load(context.Background(), fetch)
+	require.NoError(t, err)
+	require.Equal(t, "original.pdf", got[0].Name)
+	require.Equal(t, 1, fetches)
+}
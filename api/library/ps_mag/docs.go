@@ -0,0 +1,37 @@
+package ps_mag
+
+import (
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Library",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listPSMagIssues",
+			Method:   http.MethodGet,
+			Path:     "/library/ps-mag/issues",
+			Summary:  "Returns a paginated list of PS Magazine issues",
+			Query:    []openapi.Param{{Name: "page", Type: "integer"}, {Name: "order"}, {Name: "year", Type: "integer"}, {Name: "issue", Type: "integer"}},
+			Response: PSMagIssuesResponse{},
+		},
+		{
+			ID:       "searchPSMagSummaries",
+			Method:   http.MethodGet,
+			Path:     "/library/ps-mag/search",
+			Summary:  "Returns PS Magazine issues whose summary contains the query phrase",
+			Query:    []openapi.Param{{Name: "q"}, {Name: "page", Type: "integer"}},
+			Response: PSMagSearchResponse{},
+		},
+		{
+			ID:       "generatePSMagDownloadURL",
+			Method:   http.MethodGet,
+			Path:     "/library/ps-mag/download",
+			Summary:  "Returns a time-limited SAS URL for downloading a PS Magazine issue",
+			Query:    []openapi.Param{{Name: "blob_path"}},
+			Response: DownloadURLResponse{},
+		},
+	},
+}}
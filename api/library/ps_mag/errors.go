@@ -1,15 +1,15 @@
 package ps_mag
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrEmptyBlobPath   = errors.New("blob path cannot be empty")
-	ErrInvalidBlobPath = errors.New("invalid blob path: must start with ps-mag/")
-	ErrInvalidFileType = errors.New("invalid file type: only PDF files can be downloaded")
-	ErrIssueNotFound   = errors.New("issue not found")
-	ErrBlobListFailed  = errors.New("failed to list blobs from Azure")
-	ErrSASGenFailed    = errors.New("failed to generate download URL")
-	ErrInvalidPage     = errors.New("page must be greater than 0")
-	ErrInvalidOrder    = errors.New("order must be 'asc' or 'desc'")
-	ErrQueryTooShort   = errors.New("search query must be at least 3 characters")
+	ErrEmptyBlobPath   = apperror.Validation("blob_path_empty", "blob path cannot be empty")
+	ErrInvalidBlobPath = apperror.Validation("invalid_blob_path", "invalid blob path: must start with ps-mag/")
+	ErrInvalidFileType = apperror.Validation("invalid_file_type", "invalid file type: only PDF files can be downloaded")
+	ErrIssueNotFound   = apperror.NotFound("issue_not_found", "issue not found")
+	ErrBlobListFailed  = apperror.Upstream("blob_list_failed", "failed to list blobs from Azure", nil)
+	ErrSASGenFailed    = apperror.Upstream("download_url_failed", "failed to generate download URL", nil)
+	ErrInvalidPage     = apperror.Validation("invalid_page", "page must be greater than 0")
+	ErrInvalidOrder    = apperror.Validation("invalid_order", "order must be 'asc' or 'desc'")
+	ErrQueryTooShort   = apperror.Validation("query_too_short", "search query must be at least 3 characters")
 )
@@ -1,5 +1,7 @@
 package ps_mag
 
+import "context"
+
 // summaryRow holds a single row from ps_mag_summaries.
 // It is an internal type used between the repository and service layers.
 type summaryRow struct {
@@ -12,5 +14,5 @@ type Repository interface {
 	// SearchSummaries returns paginated rows from ps_mag_summaries whose summary
 	// contains phrase (case-insensitive), plus the total count of matching rows.
 	// page is 1-indexed. pageSize controls how many rows are returned.
-	SearchSummaries(phrase string, page, pageSize int) ([]summaryRow, int, error)
+	SearchSummaries(ctx context.Context, phrase string, page, pageSize int) ([]summaryRow, int, error)
 }
@@ -1,6 +1,7 @@
 package ps_mag
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -29,12 +30,12 @@ func escapeLIKEPattern(s string) string {
 // SearchSummaries returns rows from ps_mag_summaries whose summary contains
 // phrase (ILIKE, case-insensitive), paginated by page/pageSize.
 // Also returns the total count of matching rows for pagination metadata.
-func (r *RepositoryImpl) SearchSummaries(phrase string, page, pageSize int) ([]summaryRow, int, error) {
+func (r *RepositoryImpl) SearchSummaries(ctx context.Context, phrase string, page, pageSize int) ([]summaryRow, int, error) {
 	pattern := "%" + escapeLIKEPattern(phrase) + "%"
 	offset := (page - 1) * pageSize
 
 	var total int
-	err := r.db.QueryRow(
+	err := r.db.QueryRowContext(ctx,
 		`SELECT COUNT(*) FROM ps_mag_summaries WHERE summary ILIKE $1`,
 		pattern,
 	).Scan(&total)
@@ -42,7 +43,7 @@ func (r *RepositoryImpl) SearchSummaries(phrase string, page, pageSize int) ([]s
 		return nil, 0, fmt.Errorf("count summaries: %w", err)
 	}
 
-	rows, err := r.db.Query(
+	rows, err := r.db.QueryContext(ctx,
 		`SELECT file_name, summary
 		 FROM ps_mag_summaries
 		 WHERE summary ILIKE $1
@@ -1,6 +1,7 @@
 package ps_mag
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -27,7 +28,7 @@ func TestRepositorySearchSummaries_ReturnsResults(t *testing.T) {
 
 	// This test assumes ps_mag_summaries has at least one row.
 	// If the table is empty the test will pass with count=0 (not a failure).
-	rows, total, err := repo.SearchSummaries("the", 1, 30)
+	rows, total, err := repo.SearchSummaries(context.Background(), "the", 1, 30)
 
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, total, 0)
@@ -38,7 +39,7 @@ func TestRepositorySearchSummaries_NoMatch(t *testing.T) {
 	db := openTestDB(t)
 	repo := NewRepository(db)
 
-	rows, total, err := repo.SearchSummaries("zzz_no_match_xyz_999", 1, 30)
+	rows, total, err := repo.SearchSummaries(context.Background(), "zzz_no_match_xyz_999", 1, 30)
 
 	require.NoError(t, err)
 	require.Equal(t, 0, total)
@@ -50,7 +51,7 @@ func TestRepositorySearchSummaries_PageTwo(t *testing.T) {
 	repo := NewRepository(db)
 
 	// Page 2 with page size 1 — may return empty if only 1 match exists.
-	_, _, err := repo.SearchSummaries("the", 2, 1)
+	_, _, err := repo.SearchSummaries(context.Background(), "the", 2, 1)
 	require.NoError(t, err)
 }
 
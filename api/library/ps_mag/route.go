@@ -8,12 +8,15 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 
 	"miltechserver/api/analytics"
+	"miltechserver/api/apperror"
 	"miltechserver/api/middleware"
 	"miltechserver/api/response"
+	"miltechserver/health"
+	"miltechserver/ratelimit"
+	"miltechserver/storage"
 )
 
 // Handler holds the ps_mag service dependency.
@@ -21,20 +24,22 @@ type Handler struct {
 	service Service
 }
 
-// RegisterHandlers wires ps_mag routes into the public router group.
+// RegisterHandlers wires ps_mag routes into the public router group and
+// reports the issue cache to the readiness probe.
 // Called from api/library/route.go.
-func RegisterHandlers(publicGroup *gin.RouterGroup, blobClient *azblob.Client, db *sql.DB, analyticsService analytics.Service) {
-	svc := NewService(blobClient, db, analyticsService)
-	registerHandlers(publicGroup, svc)
+func RegisterHandlers(publicGroup *gin.RouterGroup, store storage.ObjectStore, db *sql.DB, analyticsService analytics.Service, checks *health.Registry, limiter *ratelimit.Limiter) {
+	svc := NewService(store, db, analyticsService).(*ServiceImpl)
+	checks.Register("cache.ps_mag_issues", false, svc.CacheStatus)
+	registerHandlers(publicGroup, svc, limiter)
 }
 
 // registerHandlers is the internal wiring function used directly by tests.
-func registerHandlers(publicGroup *gin.RouterGroup, svc Service) {
+func registerHandlers(publicGroup *gin.RouterGroup, svc Service, limiter *ratelimit.Limiter) {
 	handler := Handler{service: svc}
 	publicGroup.GET("/library/ps-mag/issues", handler.listIssues)
 	publicGroup.GET("/library/ps-mag/search", handler.searchSummaries)
-	// Rate-limited: each IP is allowed a burst of 10 requests, sustained at 2 req/s.
-	publicGroup.GET("/library/ps-mag/download", middleware.RateLimiter(), handler.generateDownloadURL)
+	// Rate-limited per client IP by middleware.DownloadPolicy.
+	publicGroup.GET("/library/ps-mag/download", middleware.RateLimit(limiter, middleware.DownloadPolicy, middleware.ByClientIP), handler.generateDownloadURL)
 }
 
 // listIssues returns a paginated list of PS Magazine issues.
@@ -45,18 +50,12 @@ func (h *Handler) listIssues(c *gin.Context) {
 
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request",
-			"details": ErrInvalidPage.Error(),
-		})
+		c.Error(ErrInvalidPage)
 		return
 	}
 
 	if o := strings.ToLower(order); o != "asc" && o != "desc" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request",
-			"details": ErrInvalidOrder.Error(),
-		})
+		c.Error(ErrInvalidOrder)
 		return
 	}
 
@@ -64,10 +63,7 @@ func (h *Handler) listIssues(c *gin.Context) {
 	if yearStr := c.Query("year"); yearStr != "" {
 		y, err := strconv.Atoi(yearStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid request",
-				"details": "year must be a valid integer",
-			})
+			c.Error(apperror.InvalidParameter("year", "year must be a valid integer"))
 			return
 		}
 		year = &y
@@ -77,10 +73,7 @@ func (h *Handler) listIssues(c *gin.Context) {
 	if issueStr := c.Query("issue"); issueStr != "" {
 		i, err := strconv.Atoi(issueStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid request",
-				"details": "issue must be a valid integer",
-			})
+			c.Error(apperror.InvalidParameter("issue", "issue must be a valid integer"))
 			return
 		}
 		issueNumber = &i
@@ -92,9 +85,7 @@ func (h *Handler) listIssues(c *gin.Context) {
 	result, err := h.service.ListIssues(c.Request.Context(), page, order, year, issueNumber)
 	if err != nil {
 		slog.Error("Failed to list PS Magazine issues", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to list issues",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -111,31 +102,23 @@ func (h *Handler) listIssues(c *gin.Context) {
 func (h *Handler) searchSummaries(c *gin.Context) {
 	q := strings.TrimSpace(c.Query("q"))
 	if len(q) < 3 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request",
-			"details": ErrQueryTooShort.Error(),
-		})
+		c.Error(ErrQueryTooShort)
 		return
 	}
 
 	pageStr := c.DefaultQuery("page", "1")
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request",
-			"details": ErrInvalidPage.Error(),
-		})
+		c.Error(ErrInvalidPage)
 		return
 	}
 
 	slog.Info("SearchPSMagSummaries endpoint called", "query", q, "page", page)
 
-	result, err := h.service.SearchSummaries(q, page)
+	result, err := h.service.SearchSummaries(c.Request.Context(), q, page)
 	if err != nil {
 		slog.Error("Failed to search PS Magazine summaries", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to search summaries",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -157,22 +140,12 @@ func (h *Handler) generateDownloadURL(c *gin.Context) {
 		switch {
 		case errors.Is(err, ErrIssueNotFound):
 			slog.Warn("PS Magazine issue not found", "blobPath", blobPath, "error", err)
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Issue not found",
-				"details": "The requested issue does not exist or is not accessible",
-			})
 		case errors.Is(err, ErrEmptyBlobPath), errors.Is(err, ErrInvalidBlobPath), errors.Is(err, ErrInvalidFileType):
 			slog.Warn("Invalid blob path for PS Magazine download", "blobPath", blobPath, "error", err)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid request",
-				"details": err.Error(),
-			})
 		default:
 			slog.Error("Failed to generate PS Magazine download URL", "error", err, "blobPath", blobPath)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to generate download URL",
-			})
 		}
+		c.Error(err)
 		return
 	}
 
@@ -9,6 +9,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+
+	"miltechserver/api/middleware"
 )
 
 type serviceStub struct {
@@ -30,13 +32,14 @@ func (s *serviceStub) GenerateDownloadURL(_ context.Context, blobPath string) (*
 	return &DownloadURLResponse{BlobPath: blobPath, DownloadURL: "https://example.com/sas", ExpiresAt: "2099-01-01T00:00:00Z"}, nil
 }
 
-func (s *serviceStub) SearchSummaries(query string, page int) (*PSMagSearchResponse, error) {
+func (s *serviceStub) SearchSummaries(_ context.Context, query string, page int) (*PSMagSearchResponse, error) {
 	return s.searchResp, s.searchErr
 }
 
 func TestListIssuesSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{
 		listResp: &PSMagIssuesResponse{
 			Issues:     []PSMagIssueResponse{},
@@ -47,7 +50,7 @@ func TestListIssuesSuccess(t *testing.T) {
 			Order:      "asc",
 		},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues", nil)
 	resp := httptest.NewRecorder()
@@ -59,10 +62,11 @@ func TestListIssuesSuccess(t *testing.T) {
 func TestListIssuesDefaultParams(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{
 		listResp: &PSMagIssuesResponse{Issues: []PSMagIssueResponse{}, Count: 0, TotalCount: 0, Page: 1, TotalPages: 1, Order: "asc"},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	// No params — should default to page=1, order=asc
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues", nil)
@@ -75,8 +79,9 @@ func TestListIssuesDefaultParams(t *testing.T) {
 func TestListIssuesInvalidPage(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues?page=0", nil)
 	resp := httptest.NewRecorder()
@@ -88,8 +93,9 @@ func TestListIssuesInvalidPage(t *testing.T) {
 func TestListIssuesNonNumericPage(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues?page=abc", nil)
 	resp := httptest.NewRecorder()
@@ -101,8 +107,9 @@ func TestListIssuesNonNumericPage(t *testing.T) {
 func TestListIssuesInvalidOrder(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues?order=sideways", nil)
 	resp := httptest.NewRecorder()
@@ -114,8 +121,9 @@ func TestListIssuesInvalidOrder(t *testing.T) {
 func TestListIssuesInvalidYearParam(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues?year=notanumber", nil)
 	resp := httptest.NewRecorder()
@@ -127,8 +135,9 @@ func TestListIssuesInvalidYearParam(t *testing.T) {
 func TestListIssuesInvalidIssueParam(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues?issue=notanumber", nil)
 	resp := httptest.NewRecorder()
@@ -140,8 +149,9 @@ func TestListIssuesInvalidIssueParam(t *testing.T) {
 func TestDownloadSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{} // no downloadErr = stub returns success automatically
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/download?blob_path=ps-mag/PS_Magazine_Issue_495_February_1994.pdf", nil)
 	resp := httptest.NewRecorder()
@@ -153,8 +163,9 @@ func TestDownloadSuccess(t *testing.T) {
 func TestDownloadMissingBlobPath(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{downloadErr: ErrEmptyBlobPath}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/download", nil)
 	resp := httptest.NewRecorder()
@@ -166,8 +177,9 @@ func TestDownloadMissingBlobPath(t *testing.T) {
 func TestDownloadNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{downloadErr: ErrIssueNotFound}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/download?blob_path=ps-mag/missing.pdf", nil)
 	resp := httptest.NewRecorder()
@@ -179,8 +191,9 @@ func TestDownloadNotFound(t *testing.T) {
 func TestDownloadInvalidPath(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{downloadErr: ErrInvalidBlobPath}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/download?blob_path=pmcs/bad.pdf", nil)
 	resp := httptest.NewRecorder()
@@ -192,21 +205,23 @@ func TestDownloadInvalidPath(t *testing.T) {
 func TestDownloadServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{downloadErr: ErrSASGenFailed}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/download?blob_path=ps-mag/test.pdf", nil)
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusBadGateway, resp.Code)
 }
 
 func TestSearchSummariesMissingQuery(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/search", nil)
 	resp := httptest.NewRecorder()
@@ -218,8 +233,9 @@ func TestSearchSummariesMissingQuery(t *testing.T) {
 func TestSearchSummariesQueryTooShort(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/search?q=ab", nil)
 	resp := httptest.NewRecorder()
@@ -231,8 +247,9 @@ func TestSearchSummariesQueryTooShort(t *testing.T) {
 func TestSearchSummariesInvalidPage(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/search?q=oil&page=bad", nil)
 	resp := httptest.NewRecorder()
@@ -244,8 +261,9 @@ func TestSearchSummariesInvalidPage(t *testing.T) {
 func TestSearchSummariesPageZero(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/search?q=oil&page=0", nil)
 	resp := httptest.NewRecorder()
@@ -257,6 +275,7 @@ func TestSearchSummariesPageZero(t *testing.T) {
 func TestSearchSummariesSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{
 		searchResp: &PSMagSearchResponse{
 			Results: []PSMagSearchResult{
@@ -272,7 +291,7 @@ func TestSearchSummariesSuccess(t *testing.T) {
 			Query:      "oil",
 		},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/search?q=oil", nil)
 	resp := httptest.NewRecorder()
@@ -284,8 +303,9 @@ func TestSearchSummariesSuccess(t *testing.T) {
 func TestSearchSummariesServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{searchErr: errors.New("db failure")}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/search?q=oil", nil)
 	resp := httptest.NewRecorder()
@@ -297,6 +317,7 @@ func TestSearchSummariesServiceError(t *testing.T) {
 func TestListIssuesResponseHasCacheControlHeader(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{
 		listResp: &PSMagIssuesResponse{
 			Issues:     []PSMagIssueResponse{},
@@ -304,7 +325,7 @@ func TestListIssuesResponseHasCacheControlHeader(t *testing.T) {
 			Order:      "asc",
 		},
 	}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues", nil)
 	resp := httptest.NewRecorder()
@@ -317,8 +338,9 @@ func TestListIssuesResponseHasCacheControlHeader(t *testing.T) {
 func TestListIssuesServiceErrorHasNoCacheControlHeader(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{listErr: errors.New("azure unavailable")}
-	registerHandlers(router.Group("/api/v1"), stub)
+	registerHandlers(router.Group("/api/v1"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/ps-mag/issues", nil)
 	resp := httptest.NewRecorder()
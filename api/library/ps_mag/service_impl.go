@@ -12,11 +12,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
-
 	"miltechserver/api/analytics"
-	"miltechserver/api/library/shared"
+	"miltechserver/storage"
 )
 
 const (
@@ -24,28 +21,35 @@ const (
 	PSMagPrefix        = "ps-mag/"
 	PageSize           = 50
 	SearchPageSize     = 30
+	downloadURLTTL     = 1 * time.Hour
 )
 
 var issueRegex = regexp.MustCompile(`^PS_Magazine_Issue_(\d+)_([A-Za-z]+)_(\d{4})\.pdf$`)
 
 type ServiceImpl struct {
-	blobClient *azblob.Client
-	repo       Repository
-	cache      *issueCache
-	analytics  analytics.Service
+	store     storage.ObjectStore
+	repo      Repository
+	cache     *issueCache
+	analytics analytics.Service
 }
 
-// NewService creates a Service backed by blobClient for blob operations and
+// NewService creates a Service backed by store for blob operations and
 // db for summary search queries.
-func NewService(blobClient *azblob.Client, db *sql.DB, analyticsService analytics.Service) Service {
+func NewService(store storage.ObjectStore, db *sql.DB, analyticsService analytics.Service) Service {
 	return &ServiceImpl{
-		blobClient: blobClient,
-		repo:       NewRepository(db),
-		cache:      newIssueCache(10 * time.Minute),
-		analytics:  analyticsService,
+		store:     store,
+		repo:      NewRepository(db),
+		cache:     newIssueCache(10 * time.Minute),
+		analytics: analyticsService,
 	}
 }
 
+// CacheStatus reports issue list cache warmness for the readiness probe.
+// A cold cache is expected after a restart, so it never fails.
+func (s *ServiceImpl) CacheStatus(context.Context) (map[string]any, error) {
+	return s.cache.status(), nil
+}
+
 // parseIssueFilename extracts issue metadata from a PS Magazine filename.
 // Returns false if the name does not match the expected convention.
 func parseIssueFilename(name string) (issueNumber int, month string, year int, ok bool) {
@@ -116,63 +120,49 @@ func paginateIssues(issues []PSMagIssueResponse, page, pageSize int) (pageItems
 	return issues[start:end], totalPages
 }
 
-// listAllIssues fetches every blob under ps-mag/ and parses metadata from filenames.
+// listAllIssues returns every issue under ps-mag/.
 // Results are cached for 10 minutes; the cache is shared across all requests.
-// Blobs that do not match the filename convention are silently skipped.
 func (s *ServiceImpl) listAllIssues(ctx context.Context) ([]PSMagIssueResponse, error) {
-	if cached, ok := s.cache.get(); ok {
-		return cached, nil
-	}
+	return s.cache.load(ctx, s.fetchAllIssues)
+}
 
-	containerClient := s.blobClient.ServiceClient().NewContainerClient(PSMagContainerName)
-	prefix := PSMagPrefix
-	pager := containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
-		Prefix: &prefix,
-	})
+// fetchAllIssues lists every blob under ps-mag/ and parses metadata from filenames.
+// Blobs that do not match the filename convention are silently skipped.
+func (s *ServiceImpl) fetchAllIssues(ctx context.Context) ([]PSMagIssueResponse, error) {
+	blobs, err := s.store.List(ctx, PSMagContainerName, PSMagPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	}
 
 	issues := make([]PSMagIssueResponse, 0, 512)
 
-	for pager.More() {
-		page, err := pager.NextPage(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	for _, blob := range blobs {
+		blobPath := blob.Name
+		parts := strings.Split(blobPath, "/")
+		fileName := parts[len(parts)-1]
+
+		issueNum, month, year, ok := parseIssueFilename(fileName)
+		if !ok {
+			slog.Debug("Skipping non-matching ps-mag blob", "blobPath", blobPath)
+			continue
 		}
-		for _, blob := range page.Segment.BlobItems {
-			if blob.Name == nil {
-				continue
-			}
-			blobPath := *blob.Name
-			parts := strings.Split(blobPath, "/")
-			fileName := parts[len(parts)-1]
-
-			issueNum, month, year, ok := parseIssueFilename(fileName)
-			if !ok {
-				slog.Debug("Skipping non-matching ps-mag blob", "blobPath", blobPath)
-				continue
-			}
-
-			var sizeBytes int64
-			if blob.Properties != nil && blob.Properties.ContentLength != nil {
-				sizeBytes = *blob.Properties.ContentLength
-			}
-			var lastModified string
-			if blob.Properties != nil && blob.Properties.LastModified != nil {
-				lastModified = blob.Properties.LastModified.Format(time.RFC3339)
-			}
-
-			issues = append(issues, PSMagIssueResponse{
-				Name:         fileName,
-				BlobPath:     blobPath,
-				IssueNumber:  issueNum,
-				Month:        month,
-				Year:         year,
-				SizeBytes:    sizeBytes,
-				LastModified: lastModified,
-			})
+
+		var lastModified string
+		if !blob.LastModified.IsZero() {
+			lastModified = blob.LastModified.Format(time.RFC3339)
 		}
+
+		issues = append(issues, PSMagIssueResponse{
+			Name:         fileName,
+			BlobPath:     blobPath,
+			IssueNumber:  issueNum,
+			Month:        month,
+			Year:         year,
+			SizeBytes:    blob.Size,
+			LastModified: lastModified,
+		})
 	}
 
-	s.cache.set(issues)
 	return issues, nil
 }
 
@@ -214,7 +204,7 @@ func (s *ServiceImpl) ListIssues(ctx context.Context, page int, order string, ye
 
 // SearchSummaries returns a paginated list of PS Magazine issues whose summaries
 // contain query. Only the lines matching query are returned per file.
-func (s *ServiceImpl) SearchSummaries(query string, page int) (*PSMagSearchResponse, error) {
+func (s *ServiceImpl) SearchSummaries(ctx context.Context, query string, page int) (*PSMagSearchResponse, error) {
 	if len(strings.TrimSpace(query)) < 3 {
 		return nil, ErrQueryTooShort
 	}
@@ -222,7 +212,7 @@ func (s *ServiceImpl) SearchSummaries(query string, page int) (*PSMagSearchRespo
 		return nil, ErrInvalidPage
 	}
 
-	rows, totalCount, err := s.repo.SearchSummaries(query, page, SearchPageSize)
+	rows, totalCount, err := s.repo.SearchSummaries(ctx, query, page, SearchPageSize)
 	if err != nil {
 		return nil, fmt.Errorf("search summaries: %w", err)
 	}
@@ -285,13 +275,12 @@ func (s *ServiceImpl) GenerateDownloadURL(ctx context.Context, blobPath string)
 		return nil, ErrInvalidFileType
 	}
 
-	blobClient := s.blobClient.ServiceClient().NewContainerClient(PSMagContainerName).NewBlobClient(blobPath)
-	if _, err := blobClient.GetProperties(ctx, nil); err != nil {
+	if _, err := s.store.Stat(ctx, PSMagContainerName, blobPath); err != nil {
 		slog.Error("PS Magazine blob not found", "blobPath", blobPath, "error", err)
 		return nil, fmt.Errorf("%w: %v", ErrIssueNotFound, err)
 	}
 
-	sasResult, err := shared.GenerateBlobSASURL(ctx, s.blobClient, PSMagContainerName, blobPath)
+	sasResult, err := s.store.SignedURL(ctx, PSMagContainerName, blobPath, downloadURLTTL)
 	if err != nil {
 		slog.Error("Failed to generate SAS token for PS Magazine", "blobPath", blobPath, "error", err)
 		return nil, fmt.Errorf("%w: %v", ErrSASGenFailed, err)
@@ -301,7 +290,7 @@ func (s *ServiceImpl) GenerateDownloadURL(ctx context.Context, blobPath string)
 		"blobPath", blobPath,
 		"expiresAt", sasResult.ExpiresAt.Format(time.RFC3339))
 
-	if trackErr := s.trackPSMagDownload(blobPath); trackErr != nil {
+	if trackErr := s.trackPSMagDownload(ctx, blobPath); trackErr != nil {
 		slog.Warn("Failed to track PS Mag download analytics",
 			"blobPath", blobPath,
 			"error", trackErr)
@@ -316,7 +305,7 @@ func (s *ServiceImpl) GenerateDownloadURL(ctx context.Context, blobPath string)
 
 // trackPSMagDownload extracts the filename from blobPath and records a download
 // event via the analytics service. It is a no-op when analytics is nil.
-func (s *ServiceImpl) trackPSMagDownload(blobPath string) error {
+func (s *ServiceImpl) trackPSMagDownload(ctx context.Context, blobPath string) error {
 	if s.analytics == nil {
 		return nil
 	}
@@ -325,5 +314,5 @@ func (s *ServiceImpl) trackPSMagDownload(blobPath string) error {
 	if strings.TrimSpace(filename) == "" {
 		return nil
 	}
-	return s.analytics.IncrementPSMagDownload(filename)
+	return s.analytics.IncrementPSMagDownload(ctx, filename)
 }
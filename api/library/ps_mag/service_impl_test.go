@@ -238,7 +238,7 @@ type repoStub struct {
 	err   error
 }
 
-func (r *repoStub) SearchSummaries(_ string, _, _ int) ([]summaryRow, int, error) {
+func (r *repoStub) SearchSummaries(_ context.Context, _ string, _, _ int) ([]summaryRow, int, error) {
 	return r.rows, r.total, r.err
 }
 
@@ -251,7 +251,7 @@ func TestServiceSearchSummaries_ReturnsMatchingLines(t *testing.T) {
 	}
 	svc := &ServiceImpl{repo: stub}
 
-	resp, err := svc.SearchSummaries("oil", 1)
+	resp, err := svc.SearchSummaries(context.Background(), "oil", 1)
 
 	require.NoError(t, err)
 	require.Equal(t, 1, resp.TotalCount)
@@ -264,7 +264,7 @@ func TestServiceSearchSummaries_EmptyResults(t *testing.T) {
 	stub := &repoStub{rows: nil, total: 0}
 	svc := &ServiceImpl{repo: stub}
 
-	resp, err := svc.SearchSummaries("oil", 1)
+	resp, err := svc.SearchSummaries(context.Background(), "oil", 1)
 
 	require.NoError(t, err)
 	require.Equal(t, 0, resp.TotalCount)
@@ -274,7 +274,7 @@ func TestServiceSearchSummaries_EmptyResults(t *testing.T) {
 func TestServiceSearchSummaries_QueryTooShort(t *testing.T) {
 	svc := &ServiceImpl{repo: &repoStub{}}
 
-	_, err := svc.SearchSummaries("ab", 1)
+	_, err := svc.SearchSummaries(context.Background(), "ab", 1)
 
 	require.ErrorIs(t, err, ErrQueryTooShort)
 }
@@ -282,7 +282,7 @@ func TestServiceSearchSummaries_QueryTooShort(t *testing.T) {
 func TestServiceSearchSummaries_InvalidPage(t *testing.T) {
 	svc := &ServiceImpl{repo: &repoStub{}}
 
-	_, err := svc.SearchSummaries("oil", 0)
+	_, err := svc.SearchSummaries(context.Background(), "oil", 0)
 
 	require.ErrorIs(t, err, ErrInvalidPage)
 }
@@ -291,7 +291,7 @@ func TestServiceSearchSummaries_RepoError(t *testing.T) {
 	stub := &repoStub{err: errors.New("db down")}
 	svc := &ServiceImpl{repo: stub}
 
-	_, err := svc.SearchSummaries("oil", 1)
+	_, err := svc.SearchSummaries(context.Background(), "oil", 1)
 
 	require.Error(t, err)
 }
@@ -304,7 +304,7 @@ func TestServiceSearchSummaries_Pagination(t *testing.T) {
 	}
 	svc := &ServiceImpl{repo: stub}
 
-	resp, err := svc.SearchSummaries("oil", 2)
+	resp, err := svc.SearchSummaries(context.Background(), "oil", 2)
 
 	require.NoError(t, err)
 	require.Equal(t, 35, resp.TotalCount)
@@ -313,8 +313,8 @@ func TestServiceSearchSummaries_Pagination(t *testing.T) {
 }
 
 func TestListIssues_UsesCacheOnSecondCall(t *testing.T) {
-	// Build a ServiceImpl with a warm cache and a nil blobClient.
-	// If listAllIssues tries to use blobClient it will panic — proving the cache
+	// Build a ServiceImpl with a warm cache and a nil store.
+	// If listAllIssues tries to use the store it will panic — proving the cache
 	// was bypassed. If it succeeds the cache was used.
 	cached := []PSMagIssueResponse{
 		{Name: "PS_Magazine_Issue_1_January_1951.pdf", IssueNumber: 1, Month: "January", Year: 1951},
@@ -323,9 +323,9 @@ func TestListIssues_UsesCacheOnSecondCall(t *testing.T) {
 	c.set(cached)
 
 	svc := &ServiceImpl{
-		blobClient: nil, // panics if called
-		repo:       &repoStub{},
-		cache:      c,
+		store: nil, // panics if called
+		repo:  &repoStub{},
+		cache: c,
 	}
 
 	result, err := svc.ListIssues(context.Background(), 1, "asc", nil, nil)
@@ -340,10 +340,10 @@ type analyticsStub struct {
 	err              error
 }
 
-func (a *analyticsStub) IncrementItemSearchSuccess(_, _ string) error  { return nil }
-func (a *analyticsStub) IncrementPMCSManualDownload(_, _ string) error { return nil }
-func (a *analyticsStub) IncrementCounter(_, _, _ string) error         { return nil }
-func (a *analyticsStub) IncrementPSMagDownload(filename string) error {
+func (a *analyticsStub) IncrementItemSearchSuccess(_ context.Context, _, _ string) error  { return nil }
+func (a *analyticsStub) IncrementPMCSManualDownload(_ context.Context, _, _ string) error { return nil }
+func (a *analyticsStub) IncrementCounter(_ context.Context, _, _, _ string) error         { return nil }
+func (a *analyticsStub) IncrementPSMagDownload(_ context.Context, filename string) error {
 	a.capturedFilename = filename
 	return a.err
 }
@@ -358,7 +358,7 @@ func TestTrackPSMagDownload_CallsAnalytics(t *testing.T) {
 		cache:     newIssueCache(5 * time.Minute),
 	}
 
-	err := svc.trackPSMagDownload("ps-mag/PS_Magazine_Issue_004_September_1951.pdf")
+	err := svc.trackPSMagDownload(context.Background(), "ps-mag/PS_Magazine_Issue_004_September_1951.pdf")
 
 	require.NoError(t, err)
 	require.Equal(t, "PS_Magazine_Issue_004_September_1951.pdf", stub.capturedFilename)
@@ -368,7 +368,7 @@ func TestTrackPSMagDownload_NilAnalytics(t *testing.T) {
 	svc := &ServiceImpl{cache: newIssueCache(5 * time.Minute)}
 
 	// Must not panic when analytics is nil.
-	err := svc.trackPSMagDownload("ps-mag/PS_Magazine_Issue_004_September_1951.pdf")
+	err := svc.trackPSMagDownload(context.Background(), "ps-mag/PS_Magazine_Issue_004_September_1951.pdf")
 
 	require.NoError(t, err)
 }
@@ -380,7 +380,7 @@ func TestTrackPSMagDownload_EmptyFilename(t *testing.T) {
 		cache:     newIssueCache(5 * time.Minute),
 	}
 
-	err := svc.trackPSMagDownload("ps-mag/")
+	err := svc.trackPSMagDownload(context.Background(), "ps-mag/")
 
 	require.NoError(t, err)
 	require.Empty(t, stub.capturedFilename) // analytics must not be called for empty filename
@@ -394,7 +394,7 @@ func TestTrackPSMagDownload_AnalyticsReturnsError(t *testing.T) {
 	}
 
 	// trackPSMagDownload surfaces the error so GenerateDownloadURL can log it.
-	err := svc.trackPSMagDownload("ps-mag/PS_Magazine_Issue_004_September_1951.pdf")
+	err := svc.trackPSMagDownload(context.Background(), "ps-mag/PS_Magazine_Issue_004_September_1951.pdf")
 
 	require.Error(t, err)
 }
@@ -7,22 +7,27 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 
 	"miltechserver/api/analytics"
+	"miltechserver/api/apperror"
 	"miltechserver/api/library/pmcs_sbs"
 	"miltechserver/api/library/ps_mag"
 	"miltechserver/api/middleware"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
+	"miltechserver/health"
+	"miltechserver/ratelimit"
+	"miltechserver/storage"
 )
 
 type Dependencies struct {
-	DB         *sql.DB
-	BlobClient *azblob.Client
-	Env        *bootstrap.Env
-	Analytics  analytics.Service
+	DB        *sql.DB
+	Store     storage.ObjectStore
+	Env       *bootstrap.Env
+	Analytics analytics.Service
+	Health    *health.Registry
+	Limiter   *ratelimit.Limiter
 }
 
 type Handler struct {
@@ -30,19 +35,19 @@ type Handler struct {
 }
 
 func RegisterRoutes(deps Dependencies, publicGroup, authGroup *gin.RouterGroup) {
-	svc := NewService(deps.BlobClient, deps.Env, deps.Analytics)
-	registerHandlers(publicGroup, authGroup, svc)
-	ps_mag.RegisterHandlers(publicGroup, deps.BlobClient, deps.DB, deps.Analytics)
-	pmcs_sbs.RegisterHandlers(publicGroup, deps.BlobClient)
+	svc := NewService(deps.Store, deps.Env, deps.Analytics)
+	registerHandlers(publicGroup, authGroup, svc, deps.Limiter)
+	ps_mag.RegisterHandlers(publicGroup, deps.Store, deps.DB, deps.Analytics, deps.Health, deps.Limiter)
+	pmcs_sbs.RegisterHandlers(publicGroup, deps.Store, deps.Limiter)
 }
 
-func registerHandlers(publicGroup, authGroup *gin.RouterGroup, svc Service) {
+func registerHandlers(publicGroup, authGroup *gin.RouterGroup, svc Service, limiter *ratelimit.Limiter) {
 	handler := Handler{service: svc}
 
 	publicGroup.GET("/library/pmcs/vehicles", handler.getPMCSVehicles)
 	publicGroup.GET("/library/pmcs/:vehicle/documents", handler.getPMCSDocuments)
-	// Rate-limited: each IP is allowed a burst of 10 requests, sustained at 2 req/s.
-	publicGroup.GET("/library/download", middleware.RateLimiter(), handler.generateDownloadURL)
+	// Rate-limited per client IP by middleware.DownloadPolicy.
+	publicGroup.GET("/library/download", middleware.RateLimit(limiter, middleware.DownloadPolicy, middleware.ByClientIP), handler.generateDownloadURL)
 
 	// Future public routes:
 	// publicGroup.GET("/library/bii/categories", handler.getBIICategories)
@@ -61,12 +66,10 @@ func registerHandlers(publicGroup, authGroup *gin.RouterGroup, svc Service) {
 func (handler *Handler) getPMCSVehicles(c *gin.Context) {
 	slog.Info("GetPMCSVehicles endpoint called")
 
-	vehicles, err := handler.service.GetPMCSVehicles()
+	vehicles, err := handler.service.GetPMCSVehicles(c.Request.Context())
 	if err != nil {
 		slog.Error("Failed to retrieve PMCS vehicles", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve PMCS vehicles",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -83,20 +86,16 @@ func (handler *Handler) getPMCSDocuments(c *gin.Context) {
 
 	if strings.TrimSpace(vehicleName) == "" {
 		slog.Warn("GetPMCSDocuments called with empty vehicle name")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Vehicle name is required",
-		})
+		c.Error(apperror.MissingParameter("vehicle"))
 		return
 	}
 
-	documents, err := handler.service.GetPMCSDocuments(vehicleName)
+	documents, err := handler.service.GetPMCSDocuments(c.Request.Context(), vehicleName)
 	if err != nil {
 		slog.Error("Failed to retrieve PMCS documents",
 			"error", err,
 			"vehicle", vehicleName)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve PMCS documents",
-		})
+		c.Error(err)
 		return
 	}
 
@@ -116,9 +115,7 @@ func (handler *Handler) generateDownloadURL(c *gin.Context) {
 
 	if strings.TrimSpace(blobPath) == "" {
 		slog.Warn("GenerateDownloadURL called with empty blob_path")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "blob_path query parameter is required",
-		})
+		c.Error(apperror.MissingParameter("blob_path"))
 		return
 	}
 
@@ -129,26 +126,16 @@ func (handler *Handler) generateDownloadURL(c *gin.Context) {
 			slog.Warn("Document not found for download",
 				"blobPath", blobPath,
 				"error", err)
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Document not found",
-				"details": "The requested document does not exist or is not accessible",
-			})
 		case errors.Is(err, ErrInvalidBlobPath), errors.Is(err, ErrInvalidFileType), errors.Is(err, ErrEmptyBlobPath):
 			slog.Warn("Invalid blob path for download",
 				"blobPath", blobPath,
 				"error", err)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid request",
-				"details": err.Error(),
-			})
 		default:
 			slog.Error("Failed to generate download URL",
 				"error", err,
 				"blobPath", blobPath)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to generate download URL",
-			})
 		}
+		c.Error(err)
 		return
 	}
 
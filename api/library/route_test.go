@@ -9,6 +9,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+
+	"miltechserver/api/middleware"
 )
 
 type serviceStub struct {
@@ -20,11 +22,11 @@ type serviceStub struct {
 	downloadErr  error
 }
 
-func (s *serviceStub) GetPMCSVehicles() (*PMCSVehiclesResponse, error) {
+func (s *serviceStub) GetPMCSVehicles(_ context.Context) (*PMCSVehiclesResponse, error) {
 	return s.vehiclesResp, s.vehiclesErr
 }
 
-func (s *serviceStub) GetPMCSDocuments(vehicleName string) (*DocumentsListResponse, error) {
+func (s *serviceStub) GetPMCSDocuments(_ context.Context, vehicleName string) (*DocumentsListResponse, error) {
 	return s.docsResp, s.docsErr
 }
 
@@ -35,9 +37,10 @@ func (s *serviceStub) GenerateDownloadURL(_ context.Context, blobPath string) (*
 func TestGetPMCSVehiclesSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{vehiclesResp: &PMCSVehiclesResponse{Vehicles: []VehicleFolderResponse{}, Count: 0}}
 
-	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub)
+	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs/vehicles", nil)
 	resp := httptest.NewRecorder()
@@ -49,9 +52,10 @@ func TestGetPMCSVehiclesSuccess(t *testing.T) {
 func TestGetPMCSDocumentsRequiresVehicle(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{}
 
-	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub)
+	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/pmcs/%20/documents", nil)
 	resp := httptest.NewRecorder()
@@ -63,9 +67,10 @@ func TestGetPMCSDocumentsRequiresVehicle(t *testing.T) {
 func TestGenerateDownloadURLNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{downloadErr: ErrDocumentNotFound}
 
-	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub)
+	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/download?blob_path=pmcs/test.pdf", nil)
 	resp := httptest.NewRecorder()
@@ -77,9 +82,10 @@ func TestGenerateDownloadURLNotFound(t *testing.T) {
 func TestGenerateDownloadURLInvalid(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{downloadErr: ErrInvalidBlobPath}
 
-	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub)
+	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/download?blob_path=bad", nil)
 	resp := httptest.NewRecorder()
@@ -91,9 +97,10 @@ func TestGenerateDownloadURLInvalid(t *testing.T) {
 func TestGenerateDownloadURLServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{downloadErr: errors.New("boom")}
 
-	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub)
+	registerHandlers(router.Group("/api/v1"), router.Group("/api/v1/auth"), stub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/download?blob_path=pmcs/test.pdf", nil)
 	resp := httptest.NewRecorder()
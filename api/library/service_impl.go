@@ -8,86 +8,66 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
-
 	"miltechserver/api/analytics"
-	"miltechserver/api/library/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/storage"
 )
 
 const (
 	LibraryContainerName = "library"
 	PMCSPrefix           = "pmcs/"
+	downloadURLTTL       = 1 * time.Hour
 )
 
 type ServiceImpl struct {
-	blobClient *azblob.Client
-	env        *bootstrap.Env
-	analytics  analytics.Service
+	store     storage.ObjectStore
+	env       *bootstrap.Env
+	analytics analytics.Service
 }
 
 func NewService(
-	blobClient *azblob.Client,
+	store storage.ObjectStore,
 	env *bootstrap.Env,
 	analyticsService analytics.Service,
 ) Service {
 	return &ServiceImpl{
-		blobClient: blobClient,
-		env:        env,
-		analytics:  analyticsService,
+		store:     store,
+		env:       env,
+		analytics: analyticsService,
 	}
 }
 
 // GetPMCSVehicles retrieves all vehicle folders from the PMCS library in Azure Blob Storage.
-func (s *ServiceImpl) GetPMCSVehicles() (*PMCSVehiclesResponse, error) {
-	ctx := context.Background()
-
+func (s *ServiceImpl) GetPMCSVehicles(ctx context.Context) (*PMCSVehiclesResponse, error) {
 	slog.Info("Fetching PMCS vehicles from Azure Blob Storage",
 		"container", LibraryContainerName,
 		"prefix", PMCSPrefix)
 
-	containerClient := s.blobClient.ServiceClient().NewContainerClient(LibraryContainerName)
-	prefix := PMCSPrefix
-	pager := containerClient.NewListBlobsHierarchyPager(
-		"/",
-		&container.ListBlobsHierarchyOptions{
-			Prefix: &prefix,
-		},
-	)
+	prefixes, err := s.store.ListPrefixes(ctx, LibraryContainerName, PMCSPrefix)
+	if err != nil {
+		slog.Error("Failed to list PMCS vehicles from Azure Blob Storage",
+			"error", err,
+			"container", LibraryContainerName,
+			"prefix", PMCSPrefix)
+		return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	}
 
 	vehicles := []VehicleFolderResponse{}
 
-	for pager.More() {
-		page, err := pager.NextPage(ctx)
-		if err != nil {
-			slog.Error("Failed to list PMCS vehicles from Azure Blob Storage",
-				"error", err,
-				"container", LibraryContainerName,
-				"prefix", PMCSPrefix)
-			return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	for _, fullPath := range prefixes {
+		vehicleName := strings.TrimPrefix(fullPath, PMCSPrefix)
+		vehicleName = strings.TrimSuffix(vehicleName, "/")
+		if vehicleName == "" {
+			continue
 		}
 
-		for _, prefix := range page.Segment.BlobPrefixes {
-			if prefix.Name == nil {
-				continue
-			}
-
-			fullPath := *prefix.Name
-			vehicleName := strings.TrimPrefix(fullPath, PMCSPrefix)
-			vehicleName = strings.TrimSuffix(vehicleName, "/")
-			if vehicleName == "" {
-				continue
-			}
-
-			displayName := formatDisplayName(vehicleName)
-
-			vehicles = append(vehicles, VehicleFolderResponse{
-				Name:        vehicleName,
-				FullPath:    fullPath,
-				DisplayName: displayName,
-			})
-		}
+		displayName := formatDisplayName(vehicleName)
+
+		vehicles = append(vehicles, VehicleFolderResponse{
+			Name:        vehicleName,
+			FullPath:    fullPath,
+			DisplayName: displayName,
+		})
 	}
 
 	slog.Info("Successfully fetched PMCS vehicles",
@@ -101,9 +81,7 @@ func (s *ServiceImpl) GetPMCSVehicles() (*PMCSVehiclesResponse, error) {
 }
 
 // GetPMCSDocuments retrieves all PDF documents from a vehicle folder in Azure Blob Storage.
-func (s *ServiceImpl) GetPMCSDocuments(vehicleName string) (*DocumentsListResponse, error) {
-	ctx := context.Background()
-
+func (s *ServiceImpl) GetPMCSDocuments(ctx context.Context, vehicleName string) (*DocumentsListResponse, error) {
 	if strings.TrimSpace(vehicleName) == "" {
 		return nil, ErrEmptyVehicleName
 	}
@@ -115,53 +93,37 @@ func (s *ServiceImpl) GetPMCSDocuments(vehicleName string) (*DocumentsListRespon
 		"vehiclePrefix", vehiclePrefix,
 		"vehicleName", vehicleName)
 
-	containerClient := s.blobClient.ServiceClient().NewContainerClient(LibraryContainerName)
-	pager := containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
-		Prefix: &vehiclePrefix,
-	})
+	blobs, err := s.store.List(ctx, LibraryContainerName, vehiclePrefix)
+	if err != nil {
+		slog.Error("Failed to list PMCS documents from Azure Blob Storage",
+			"error", err,
+			"container", LibraryContainerName,
+			"vehiclePrefix", vehiclePrefix)
+		return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	}
 
 	documents := []DocumentResponse{}
 
-	for pager.More() {
-		page, err := pager.NextPage(ctx)
-		if err != nil {
-			slog.Error("Failed to list PMCS documents from Azure Blob Storage",
-				"error", err,
-				"container", LibraryContainerName,
-				"vehiclePrefix", vehiclePrefix)
-			return nil, fmt.Errorf("%w: %v", ErrBlobListFailed, err)
+	for _, blob := range blobs {
+		blobPath := blob.Name
+		if !strings.HasSuffix(strings.ToLower(blobPath), ".pdf") {
+			slog.Debug("Skipping non-PDF file", "blobPath", blobPath)
+			continue
 		}
 
-		for _, blob := range page.Segment.BlobItems {
-			if blob.Name == nil {
-				continue
-			}
-
-			blobPath := *blob.Name
-			if !strings.HasSuffix(strings.ToLower(blobPath), ".pdf") {
-				slog.Debug("Skipping non-PDF file", "blobPath", blobPath)
-				continue
-			}
-
-			fileName := extractFileName(blobPath)
-
-			var sizeBytes int64
-			if blob.Properties != nil && blob.Properties.ContentLength != nil {
-				sizeBytes = *blob.Properties.ContentLength
-			}
-
-			var lastModified string
-			if blob.Properties != nil && blob.Properties.LastModified != nil {
-				lastModified = blob.Properties.LastModified.Format(time.RFC3339)
-			}
-
-			documents = append(documents, DocumentResponse{
-				Name:         fileName,
-				BlobPath:     blobPath,
-				SizeBytes:    sizeBytes,
-				LastModified: lastModified,
-			})
+		fileName := extractFileName(blobPath)
+
+		var lastModified string
+		if !blob.LastModified.IsZero() {
+			lastModified = blob.LastModified.Format(time.RFC3339)
 		}
+
+		documents = append(documents, DocumentResponse{
+			Name:         fileName,
+			BlobPath:     blobPath,
+			SizeBytes:    blob.Size,
+			LastModified: lastModified,
+		})
 	}
 
 	slog.Info("Successfully fetched PMCS documents",
@@ -208,15 +170,14 @@ func (s *ServiceImpl) GenerateDownloadURL(ctx context.Context, blobPath string)
 		"blobPath", blobPath)
 
 	// Verify the blob exists before signing a token for it.
-	blobClient := s.blobClient.ServiceClient().NewContainerClient(LibraryContainerName).NewBlobClient(blobPath)
-	if _, err := blobClient.GetProperties(ctx, nil); err != nil {
+	if _, err := s.store.Stat(ctx, LibraryContainerName, blobPath); err != nil {
 		slog.Error("Blob not found or not accessible",
 			"error", err,
 			"blobPath", blobPath)
 		return nil, fmt.Errorf("%w: %v", ErrDocumentNotFound, err)
 	}
 
-	sasResult, err := shared.GenerateBlobSASURL(ctx, s.blobClient, LibraryContainerName, blobPath)
+	sasResult, err := s.store.SignedURL(ctx, LibraryContainerName, blobPath, downloadURLTTL)
 	if err != nil {
 		slog.Error("Failed to generate SAS token",
 			"error", err,
@@ -228,7 +189,7 @@ func (s *ServiceImpl) GenerateDownloadURL(ctx context.Context, blobPath string)
 		"blobPath", blobPath,
 		"expiresAt", sasResult.ExpiresAt.Format(time.RFC3339))
 
-	if analyticsErr := s.trackPMCSDownload(blobPath); analyticsErr != nil {
+	if analyticsErr := s.trackPMCSDownload(ctx, blobPath); analyticsErr != nil {
 		slog.Warn("Failed to increment analytics for PMCS download", "blobPath", blobPath, "error", analyticsErr)
 	}
 
@@ -249,7 +210,7 @@ func extractFileName(blobPath string) string {
 	return parts[len(parts)-1]
 }
 
-func (s *ServiceImpl) trackPMCSDownload(blobPath string) error {
+func (s *ServiceImpl) trackPMCSDownload(ctx context.Context, blobPath string) error {
 	if s.analytics == nil {
 		return nil
 	}
@@ -273,7 +234,7 @@ func (s *ServiceImpl) trackPMCSDownload(blobPath string) error {
 		displayName = baseName
 	}
 
-	return s.analytics.IncrementPMCSManualDownload(baseName, displayName)
+	return s.analytics.IncrementPMCSManualDownload(ctx, baseName, displayName)
 }
 
 func extractPMCSEquipmentName(blobPath string) (string, bool) {
@@ -0,0 +1,15 @@
+package material_images
+
+import (
+	"slices"
+
+	"miltechserver/api/material_images/flags"
+	"miltechserver/api/material_images/images"
+	"miltechserver/api/material_images/votes"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(images.Docs, flags.Docs, votes.Docs)
+
+// ModerationDocs describes the routes RegisterModerationRoutes registers.
+var ModerationDocs = flags.ModerationDocs
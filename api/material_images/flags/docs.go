@@ -0,0 +1,68 @@
+package flags
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Material Images",
+	Operations: []openapi.Operation{
+		{
+			ID:       "flagMaterialImage",
+			Method:   http.MethodPost,
+			Path:     "/material-images/:image_id/flag",
+			Auth:     true,
+			Summary:  "Flag a material image",
+			Request:  request.FlagImageRequest{},
+			Response: response.ImageFlagResponse{},
+			Raw:      true,
+		},
+		{
+			ID:       "listMaterialImageFlags",
+			Method:   http.MethodGet,
+			Path:     "/material-images/:image_id/flags",
+			Auth:     true,
+			Summary:  "List the flags on a material image",
+			Response: openapi.Object{"flags": []model.MaterialImagesFlags{}},
+			Raw:      true,
+		},
+	},
+}}
+
+// ModerationDocs describes the routes RegisterModerationRoutes registers.
+var ModerationDocs = []openapi.Section{{
+	Tag: "Moderation",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listFlaggedMaterialImages",
+			Method:   http.MethodGet,
+			Path:     "/moderation/material-images/flagged",
+			Auth:     true,
+			Summary:  "List flagged material images, the longest-flagged first (moderators only)",
+			Query:    pagination.Query,
+			Response: pagination.Page[response.FlaggedImageResponse]{},
+		},
+		{
+			ID:       "dismissMaterialImageFlags",
+			Method:   http.MethodDelete,
+			Path:     "/moderation/material-images/:image_id/flags",
+			Auth:     true,
+			Summary:  "Dismiss the flags on a material image, keeping the image (moderators only)",
+			Response: openapi.Object{"image_id": ""},
+		},
+		{
+			ID:       "removeMaterialImage",
+			Method:   http.MethodDelete,
+			Path:     "/moderation/material-images/:image_id",
+			Auth:     true,
+			Summary:  "Remove a material image whoever uploaded it (moderators only)",
+			Response: openapi.Object{"image_id": ""},
+		},
+	},
+}}
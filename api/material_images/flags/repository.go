@@ -1,9 +1,16 @@
 package flags
 
-import "miltechserver/.gen/miltech_ng/public/model"
+import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+)
 
 type Repository interface {
-	Create(flag model.MaterialImagesFlags) error
-	GetByImage(imageID string) ([]model.MaterialImagesFlags, error)
-	CountByImage(imageID string) (int, error)
+	Create(ctx context.Context, flag model.MaterialImagesFlags) error
+	GetByImage(ctx context.Context, imageID string) ([]model.MaterialImagesFlags, error)
+	CountByImage(ctx context.Context, imageID string) (int, error)
+	GetFlaggedPage(ctx context.Context, params pagination.Params) (pagination.Page[response.FlaggedImageResponse], error)
+	DeleteByImage(ctx context.Context, imageID string) error
 }
@@ -1,14 +1,18 @@
 package flags
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	. "github.com/go-jet/jet/v2/postgres"
 	"github.com/google/uuid"
 
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
 )
 
 type RepositoryImpl struct {
@@ -19,7 +23,7 @@ func NewRepository(db *sql.DB) Repository {
 	return &RepositoryImpl{db: db}
 }
 
-func (r *RepositoryImpl) Create(flag model.MaterialImagesFlags) error {
+func (r *RepositoryImpl) Create(ctx context.Context, flag model.MaterialImagesFlags) error {
 	stmt := MaterialImagesFlags.INSERT(
 		MaterialImagesFlags.ImageID,
 		MaterialImagesFlags.UserID,
@@ -32,7 +36,7 @@ func (r *RepositoryImpl) Create(flag model.MaterialImagesFlags) error {
 		flag.Description,
 	)
 
-	_, err := stmt.Exec(r.db)
+	_, err := stmt.ExecContext(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to create flag: %w", err)
 	}
@@ -40,7 +44,7 @@ func (r *RepositoryImpl) Create(flag model.MaterialImagesFlags) error {
 	return nil
 }
 
-func (r *RepositoryImpl) GetByImage(imageID string) ([]model.MaterialImagesFlags, error) {
+func (r *RepositoryImpl) GetByImage(ctx context.Context, imageID string) ([]model.MaterialImagesFlags, error) {
 	stmt := SELECT(
 		MaterialImagesFlags.AllColumns,
 	).FROM(
@@ -52,7 +56,7 @@ func (r *RepositoryImpl) GetByImage(imageID string) ([]model.MaterialImagesFlags
 	)
 
 	var flags []model.MaterialImagesFlags
-	err := stmt.Query(r.db, &flags)
+	err := stmt.QueryContext(ctx, r.db, &flags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get flags: %w", err)
 	}
@@ -60,7 +64,7 @@ func (r *RepositoryImpl) GetByImage(imageID string) ([]model.MaterialImagesFlags
 	return flags, nil
 }
 
-func (r *RepositoryImpl) CountByImage(imageID string) (int, error) {
+func (r *RepositoryImpl) CountByImage(ctx context.Context, imageID string) (int, error) {
 	stmt := SELECT(
 		COUNT(MaterialImagesFlags.ID).AS("count"),
 	).FROM(
@@ -72,10 +76,107 @@ func (r *RepositoryImpl) CountByImage(imageID string) (int, error) {
 	var count struct {
 		Count int32 `sql:"count"`
 	}
-	err := stmt.Query(r.db, &count)
+	err := stmt.QueryContext(ctx, r.db, &count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get flag count: %w", err)
 	}
 
 	return int(count.Count), nil
 }
+
+// GetFlaggedPage reads the active flagged images, the longest-flagged first.
+func (r *RepositoryImpl) GetFlaggedPage(ctx context.Context, params pagination.Params) (pagination.Page[response.FlaggedImageResponse], error) {
+	var afterFlaggedAt time.Time
+	var afterID string
+	after, err := params.After(&afterFlaggedAt, &afterID)
+	if err != nil {
+		return pagination.Page[response.FlaggedImageResponse]{}, err
+	}
+
+	rawSQL := `
+		WITH flagged AS (
+			SELECT image_id, COUNT(*) AS flag_count, MIN(created_at) AS first_flagged_at
+			FROM material_images_flags
+			GROUP BY image_id
+		)
+		SELECT
+			mi.id::text,
+			mi.niin,
+			mi.user_id,
+			COALESCE(u.username, 'Unknown') as username,
+			mi.original_filename,
+			mi.upload_date,
+			f.flag_count,
+			f.first_flagged_at
+		FROM flagged f
+		JOIN material_images mi ON mi.id = f.image_id AND mi.is_active = true
+		LEFT JOIN users u ON mi.user_id = u.uid`
+	var args []any
+	if after {
+		args = append(args, afterFlaggedAt, afterID)
+		rawSQL += ` WHERE (f.first_flagged_at, mi.id::text) > ($1, $2)`
+	}
+	args = append(args, params.Fetch())
+	rawSQL += fmt.Sprintf(` ORDER BY f.first_flagged_at, mi.id::text LIMIT $%d`, len(args))
+
+	rows, err := r.db.QueryContext(ctx, rawSQL, args...)
+	if err != nil {
+		return pagination.Page[response.FlaggedImageResponse]{}, fmt.Errorf("failed to get flagged images: %w", err)
+	}
+	defer rows.Close()
+
+	var flagged []response.FlaggedImageResponse
+	for rows.Next() {
+		var img response.FlaggedImageResponse
+		err := rows.Scan(
+			&img.ID,
+			&img.NIIN,
+			&img.UserID,
+			&img.Username,
+			&img.OriginalFilename,
+			&img.UploadDate,
+			&img.FlagCount,
+			&img.FirstFlaggedAt,
+		)
+		if err != nil {
+			return pagination.Page[response.FlaggedImageResponse]{}, fmt.Errorf("failed to scan flagged image: %w", err)
+		}
+		flagged = append(flagged, img)
+	}
+	if err = rows.Err(); err != nil {
+		return pagination.Page[response.FlaggedImageResponse]{}, fmt.Errorf("error iterating flagged images: %w", err)
+	}
+
+	page := pagination.NewPage(flagged, params, func(img response.FlaggedImageResponse) []any {
+		return []any{img.FirstFlaggedAt, img.ID}
+	})
+	if !params.IncludeTotal {
+		return page, nil
+	}
+
+	countSQL := `
+		SELECT COUNT(DISTINCT f.image_id)
+		FROM material_images_flags f
+		JOIN material_images mi ON mi.id = f.image_id AND mi.is_active = true
+	`
+
+	var count int
+	err = r.db.QueryRowContext(ctx, countSQL).Scan(&count)
+	if err != nil {
+		return pagination.Page[response.FlaggedImageResponse]{}, fmt.Errorf("failed to count flagged images: %w", err)
+	}
+	return page.WithTotal(count), nil
+}
+
+func (r *RepositoryImpl) DeleteByImage(ctx context.Context, imageID string) error {
+	stmt := MaterialImagesFlags.DELETE().WHERE(
+		MaterialImagesFlags.ImageID.EQ(UUID(uuid.MustParse(imageID))),
+	)
+
+	_, err := stmt.ExecContext(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to delete flags: %w", err)
+	}
+
+	return nil
+}
@@ -1,17 +1,20 @@
 package flags
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/material_images/images"
 	"miltechserver/api/material_images/shared"
+	"miltechserver/api/pagination"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 )
 
+const defaultFlaggedLimit = 50
+
 type Handler struct {
 	service       Service
 	imagesService images.Service
@@ -24,10 +27,20 @@ func RegisterRoutes(authRouter *gin.RouterGroup, service Service, imagesService
 	authRouter.GET("/material-images/:image_id/flags", handler.getFlags)
 }
 
+// RegisterModerationRoutes mounts the moderator routes on moderationGroup,
+// which must already require the moderator role.
+func RegisterModerationRoutes(moderationGroup *gin.RouterGroup, service Service, imagesService images.Service) {
+	handler := Handler{service: service, imagesService: imagesService}
+
+	moderationGroup.GET("/material-images/flagged", handler.getFlagged)
+	moderationGroup.DELETE("/material-images/:image_id/flags", handler.dismiss)
+	moderationGroup.DELETE("/material-images/:image_id", handler.remove)
+}
+
 func (h *Handler) flag(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return
 	}
 
@@ -35,21 +48,17 @@ func (h *Handler) flag(c *gin.Context) {
 
 	var req request.FlagImageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request: %v", err)})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = h.service.Flag(user, imageID, req.Reason, req.Description)
+	err = h.service.Flag(c.Request.Context(), user, imageID, req.Reason, req.Description)
 	if err != nil {
-		if err.Error() == "you have already flagged this image" {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	updatedImage, err := h.imagesService.GetByID(imageID, user)
+	updatedImage, err := h.imagesService.GetByID(c.Request.Context(), imageID, user)
 	var flagCount int
 	var isFlagged bool
 	if err == nil {
@@ -68,11 +77,61 @@ func (h *Handler) flag(c *gin.Context) {
 func (h *Handler) getFlags(c *gin.Context) {
 	imageID := c.Param("image_id")
 
-	flags, err := h.service.GetByImage(imageID)
+	flags, err := h.service.GetByImage(c.Request.Context(), imageID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve flags"})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"flags": flags})
 }
+
+func (h *Handler) getFlagged(c *gin.Context) {
+	params, err := pagination.FromQuery(c, defaultFlaggedLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	flagged, err := h.service.GetFlagged(c.Request.Context(), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "Flagged images retrieved",
+		Data:    flagged,
+	})
+}
+
+func (h *Handler) dismiss(c *gin.Context) {
+	imageID := c.Param("image_id")
+
+	if err := h.service.Dismiss(c.Request.Context(), imageID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "Image flags dismissed",
+		Data:    gin.H{"image_id": imageID},
+	})
+}
+
+func (h *Handler) remove(c *gin.Context) {
+	imageID := c.Param("image_id")
+
+	if err := h.imagesService.Remove(c.Request.Context(), imageID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "Image removed",
+		Data:    gin.H{"image_id": imageID},
+	})
+}
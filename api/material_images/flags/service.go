@@ -1,11 +1,16 @@
 package flags
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
 	"miltechserver/bootstrap"
 )
 
 type Service interface {
-	Flag(user *bootstrap.User, imageID string, reason string, description string) error
-	GetByImage(imageID string) ([]model.MaterialImagesFlags, error)
+	Flag(ctx context.Context, user *bootstrap.User, imageID string, reason string, description string) error
+	GetByImage(ctx context.Context, imageID string) ([]model.MaterialImagesFlags, error)
+	GetFlagged(ctx context.Context, params pagination.Params) (pagination.Page[response.FlaggedImageResponse], error)
+	Dismiss(ctx context.Context, imageID string) error
 }
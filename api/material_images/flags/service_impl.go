@@ -1,12 +1,17 @@
 package flags
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/material_images/images"
+	"miltechserver/api/material_images/shared"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
 	"miltechserver/bootstrap"
 )
 
@@ -22,7 +27,7 @@ func NewService(repo Repository, imagesRepo images.Repository) Service {
 	}
 }
 
-func (s *ServiceImpl) Flag(user *bootstrap.User, imageID string, reason string, description string) error {
+func (s *ServiceImpl) Flag(ctx context.Context, user *bootstrap.User, imageID string, reason string, description string) error {
 	validReasons := map[string]bool{
 		"Incorrect Item": true,
 		"Inappropriate":  true,
@@ -31,21 +36,21 @@ func (s *ServiceImpl) Flag(user *bootstrap.User, imageID string, reason string,
 	}
 
 	if !validReasons[reason] {
-		return fmt.Errorf("invalid flag reason: %s", reason)
+		return shared.ErrInvalidReason
 	}
 
-	image, err := s.imagesRepo.GetByID(imageID)
+	image, err := s.imagesRepo.GetByID(ctx, imageID)
 	if err != nil {
 		return fmt.Errorf("failed to get image: %w", err)
 	}
 
 	if image == nil {
-		return fmt.Errorf("image not found")
+		return shared.ErrImageNotFound
 	}
 
 	imageUUID, err := uuid.Parse(imageID)
 	if err != nil {
-		return fmt.Errorf("invalid image ID: %w", err)
+		return apperror.InvalidParameter("image_id", "invalid image ID").WithCause(err)
 	}
 
 	var descPtr *string
@@ -61,21 +66,21 @@ func (s *ServiceImpl) Flag(user *bootstrap.User, imageID string, reason string,
 		Description: descPtr,
 	}
 
-	err = s.repo.Create(flag)
+	err = s.repo.Create(ctx, flag)
 	if err != nil {
 		if err.Error() == "pq: duplicate key value violates unique constraint \"unique_user_image_flag\"" {
-			return fmt.Errorf("you have already flagged this image")
+			return shared.ErrAlreadyFlagged
 		}
 		return fmt.Errorf("failed to flag image: %w", err)
 	}
 
-	flagCount, err := s.repo.CountByImage(imageID)
+	flagCount, err := s.repo.CountByImage(ctx, imageID)
 	if err != nil {
 		return fmt.Errorf("failed to get flags: %w", err)
 	}
 
 	isFlagged := flagCount >= 1
-	err = s.imagesRepo.UpdateFlags(imageID, flagCount, isFlagged)
+	err = s.imagesRepo.UpdateFlags(ctx, imageID, flagCount, isFlagged)
 	if err != nil {
 		return fmt.Errorf("failed to update image flag status: %w", err)
 	}
@@ -83,11 +88,43 @@ func (s *ServiceImpl) Flag(user *bootstrap.User, imageID string, reason string,
 	return nil
 }
 
-func (s *ServiceImpl) GetByImage(imageID string) ([]model.MaterialImagesFlags, error) {
-	flags, err := s.repo.GetByImage(imageID)
+func (s *ServiceImpl) GetByImage(ctx context.Context, imageID string) ([]model.MaterialImagesFlags, error) {
+	flags, err := s.repo.GetByImage(ctx, imageID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get flags: %w", err)
 	}
 
 	return flags, nil
 }
+
+func (s *ServiceImpl) GetFlagged(ctx context.Context, params pagination.Params) (pagination.Page[response.FlaggedImageResponse], error) {
+	return s.repo.GetFlaggedPage(ctx, params)
+}
+
+// Dismiss clears the flags on an image, keeping the image.
+func (s *ServiceImpl) Dismiss(ctx context.Context, imageID string) error {
+	if _, err := uuid.Parse(imageID); err != nil {
+		return shared.ErrImageNotFound
+	}
+
+	image, err := s.imagesRepo.GetByID(ctx, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to get image: %w", err)
+	}
+
+	if image == nil || !image.IsActive {
+		return shared.ErrImageNotFound
+	}
+
+	err = s.repo.DeleteByImage(ctx, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to dismiss flags: %w", err)
+	}
+
+	err = s.imagesRepo.UpdateFlags(ctx, imageID, 0, false)
+	if err != nil {
+		return fmt.Errorf("failed to update image flag status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"miltechserver/api/material_images/shared"
+	"miltechserver/jobs"
+	"miltechserver/storage"
+)
+
+// orphanGracePeriod keeps recent blobs out of cleanup: Upload writes the
+// blob before the row that references it.
+const orphanGracePeriod = 24 * time.Hour
+
+// OrphanedBlobsJob returns the job that deletes image blobs no active image
+// references. They are left behind when saving an upload's record fails
+// after the blob was written, or when deleting a deleted image's blob fails.
+func OrphanedBlobsJob(repo Repository, store storage.ObjectStore) jobs.Job {
+	blobStorage := shared.NewBlobStorage(store)
+	return jobs.Job{
+		Name:     "material-images-orphaned-blobs",
+		Schedule: "0 4 * * *",
+		Run: func(ctx context.Context) error {
+			deleted, err := deleteOrphanedBlobs(ctx, repo, blobStorage, time.Now())
+			if err != nil {
+				return err
+			}
+			slog.Info("Deleted orphaned material image blobs", "deleted", deleted)
+			return nil
+		},
+	}
+}
+
+func deleteOrphanedBlobs(ctx context.Context, repo Repository, blobStorage *shared.BlobStorage, now time.Time) (int, error) {
+	// List blobs before reading the rows, so a blob uploaded in between is
+	// either too recent to delete or already referenced.
+	blobs, err := blobStorage.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	active, err := repo.GetActiveBlobNames(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, blob := range blobs {
+		if active[blob.Name] || now.Sub(blob.LastModified) < orphanGracePeriod {
+			continue
+		}
+		err := blobStorage.Delete(ctx, blob.Name)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return deleted, fmt.Errorf("failed to delete orphaned blob %s: %w", blob.Name, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
@@ -0,0 +1,61 @@
+package images
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Material Images",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listMaterialImagesByNIIN",
+			Method:   http.MethodGet,
+			Path:     "/material-images/niin/:niin",
+			Summary:  "List the material images of a NIIN",
+			Query:    []openapi.Param{{Name: "page", Type: "integer"}, {Name: "page_size", Type: "integer"}},
+			Response: response.PaginatedImagesResponse{},
+			Raw:      true,
+		},
+		{
+			ID:       "getMaterialImage",
+			Method:   http.MethodGet,
+			Path:     "/material-images/:image_id",
+			Summary:  "Get a material image",
+			Response: response.MaterialImageResponse{},
+			Raw:      true,
+		},
+		{
+			ID:       "uploadMaterialImage",
+			Method:   http.MethodPost,
+			Path:     "/material-images/upload",
+			Auth:     true,
+			Summary:  "Upload a material image",
+			Form:     []openapi.Param{{Name: "niin", Required: true}, {Name: "image", Type: "file", Required: true}},
+			Status:   http.StatusCreated,
+			Response: response.ImageUploadResponse{},
+			Raw:      true,
+		},
+		{
+			ID:       "deleteMaterialImage",
+			Method:   http.MethodDelete,
+			Path:     "/material-images/:image_id",
+			Auth:     true,
+			Summary:  "Delete a material image",
+			Response: openapi.Object{"success": false, "message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "listMaterialImagesByUser",
+			Method:   http.MethodGet,
+			Path:     "/material-images/user/:user_id",
+			Auth:     true,
+			Summary:  "List the material images uploaded by a user",
+			Query:    []openapi.Param{{Name: "page", Type: "integer"}, {Name: "page_size", Type: "integer"}},
+			Response: response.PaginatedImagesResponse{},
+			Raw:      true,
+		},
+	},
+}}
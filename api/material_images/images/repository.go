@@ -1,6 +1,7 @@
 package images
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
@@ -11,11 +12,12 @@ type ImageWithUser struct {
 }
 
 type Repository interface {
-	Create(user *bootstrap.User, image model.MaterialImages) (*model.MaterialImages, error)
-	GetByID(imageID string) (*model.MaterialImages, error)
-	GetByNIIN(niin string, limit int, offset int) ([]ImageWithUser, int64, error)
-	GetByUser(userID string, limit int, offset int) ([]ImageWithUser, int64, error)
-	UpdateFlags(imageID string, flagCount int, isFlagged bool) error
-	Delete(imageID string) error
-	GetUsernameByUserID(userID string) (string, error)
+	Create(ctx context.Context, user *bootstrap.User, image model.MaterialImages) (*model.MaterialImages, error)
+	GetByID(ctx context.Context, imageID string) (*model.MaterialImages, error)
+	GetByNIIN(ctx context.Context, niin string, limit int, offset int) ([]ImageWithUser, int64, error)
+	GetByUser(ctx context.Context, userID string, limit int, offset int) ([]ImageWithUser, int64, error)
+	UpdateFlags(ctx context.Context, imageID string, flagCount int, isFlagged bool) error
+	Delete(ctx context.Context, imageID string) error
+	GetUsernameByUserID(ctx context.Context, userID string) (string, error)
+	GetActiveBlobNames(ctx context.Context) (map[string]bool, error)
 }
@@ -1,6 +1,7 @@
 package images
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -22,7 +23,7 @@ func NewRepository(db *sql.DB) Repository {
 	return &RepositoryImpl{db: db}
 }
 
-func (r *RepositoryImpl) Create(user *bootstrap.User, image model.MaterialImages) (*model.MaterialImages, error) {
+func (r *RepositoryImpl) Create(ctx context.Context, user *bootstrap.User, image model.MaterialImages) (*model.MaterialImages, error) {
 	stmt := MaterialImages.INSERT(
 		MaterialImages.Niin,
 		MaterialImages.UserID,
@@ -42,7 +43,7 @@ func (r *RepositoryImpl) Create(user *bootstrap.User, image model.MaterialImages
 	).RETURNING(MaterialImages.AllColumns)
 
 	var createdImage model.MaterialImages
-	err := stmt.Query(r.db, &createdImage)
+	err := stmt.QueryContext(ctx, r.db, &createdImage)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create image: %w", err)
 	}
@@ -50,7 +51,7 @@ func (r *RepositoryImpl) Create(user *bootstrap.User, image model.MaterialImages
 	return &createdImage, nil
 }
 
-func (r *RepositoryImpl) GetByID(imageID string) (*model.MaterialImages, error) {
+func (r *RepositoryImpl) GetByID(ctx context.Context, imageID string) (*model.MaterialImages, error) {
 	stmt := SELECT(
 		MaterialImages.AllColumns,
 	).FROM(
@@ -60,7 +61,7 @@ func (r *RepositoryImpl) GetByID(imageID string) (*model.MaterialImages, error)
 	)
 
 	var image model.MaterialImages
-	err := stmt.Query(r.db, &image)
+	err := stmt.QueryContext(ctx, r.db, &image)
 	if err != nil {
 		if err == qrm.ErrNoRows {
 			return nil, nil
@@ -71,7 +72,7 @@ func (r *RepositoryImpl) GetByID(imageID string) (*model.MaterialImages, error)
 	return &image, nil
 }
 
-func (r *RepositoryImpl) GetByNIIN(niin string, limit int, offset int) ([]ImageWithUser, int64, error) {
+func (r *RepositoryImpl) GetByNIIN(ctx context.Context, niin string, limit int, offset int) ([]ImageWithUser, int64, error) {
 	rawSQL := `
 		SELECT 
 			mi.id,
@@ -99,7 +100,7 @@ func (r *RepositoryImpl) GetByNIIN(niin string, limit int, offset int) ([]ImageW
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.Query(rawSQL, niin, limit, offset)
+	rows, err := r.db.QueryContext(ctx, rawSQL, niin, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get images by NIIN: %w", err)
 	}
@@ -149,7 +150,7 @@ func (r *RepositoryImpl) GetByNIIN(niin string, limit int, offset int) ([]ImageW
 	`
 
 	var count int64
-	err = r.db.QueryRow(countSQL, niin).Scan(&count)
+	err = r.db.QueryRowContext(ctx, countSQL, niin).Scan(&count)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get image count: %w", err)
 	}
@@ -157,7 +158,7 @@ func (r *RepositoryImpl) GetByNIIN(niin string, limit int, offset int) ([]ImageW
 	return imagesWithUsers, count, nil
 }
 
-func (r *RepositoryImpl) GetByUser(userID string, limit int, offset int) ([]ImageWithUser, int64, error) {
+func (r *RepositoryImpl) GetByUser(ctx context.Context, userID string, limit int, offset int) ([]ImageWithUser, int64, error) {
 	rawSQL := `
 		SELECT 
 			mi.id,
@@ -185,7 +186,7 @@ func (r *RepositoryImpl) GetByUser(userID string, limit int, offset int) ([]Imag
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.Query(rawSQL, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, rawSQL, userID, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get images by user: %w", err)
 	}
@@ -235,7 +236,7 @@ func (r *RepositoryImpl) GetByUser(userID string, limit int, offset int) ([]Imag
 	`
 
 	var count int64
-	err = r.db.QueryRow(countSQL, userID).Scan(&count)
+	err = r.db.QueryRowContext(ctx, countSQL, userID).Scan(&count)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get image count: %w", err)
 	}
@@ -243,7 +244,7 @@ func (r *RepositoryImpl) GetByUser(userID string, limit int, offset int) ([]Imag
 	return imagesWithUsers, count, nil
 }
 
-func (r *RepositoryImpl) UpdateFlags(imageID string, flagCount int, isFlagged bool) error {
+func (r *RepositoryImpl) UpdateFlags(ctx context.Context, imageID string, flagCount int, isFlagged bool) error {
 	stmt := MaterialImages.UPDATE(
 		MaterialImages.FlagCount,
 		MaterialImages.IsFlagged,
@@ -256,7 +257,7 @@ func (r *RepositoryImpl) UpdateFlags(imageID string, flagCount int, isFlagged bo
 		MaterialImages.ID.EQ(UUID(uuid.MustParse(imageID))),
 	)
 
-	_, err := stmt.Exec(r.db)
+	_, err := stmt.ExecContext(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to update image flags: %w", err)
 	}
@@ -264,7 +265,7 @@ func (r *RepositoryImpl) UpdateFlags(imageID string, flagCount int, isFlagged bo
 	return nil
 }
 
-func (r *RepositoryImpl) Delete(imageID string) error {
+func (r *RepositoryImpl) Delete(ctx context.Context, imageID string) error {
 	stmt := MaterialImages.UPDATE(
 		MaterialImages.IsActive,
 		MaterialImages.UpdatedAt,
@@ -275,7 +276,7 @@ func (r *RepositoryImpl) Delete(imageID string) error {
 		MaterialImages.ID.EQ(UUID(uuid.MustParse(imageID))),
 	)
 
-	_, err := stmt.Exec(r.db)
+	_, err := stmt.ExecContext(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to delete image: %w", err)
 	}
@@ -283,7 +284,7 @@ func (r *RepositoryImpl) Delete(imageID string) error {
 	return nil
 }
 
-func (r *RepositoryImpl) GetUsernameByUserID(userID string) (string, error) {
+func (r *RepositoryImpl) GetUsernameByUserID(ctx context.Context, userID string) (string, error) {
 	stmt := SELECT(
 		Users.Username,
 	).FROM(
@@ -293,7 +294,7 @@ func (r *RepositoryImpl) GetUsernameByUserID(userID string) (string, error) {
 	)
 
 	var user model.Users
-	err := stmt.Query(r.db, &user)
+	err := stmt.QueryContext(ctx, r.db, &user)
 	if err != nil {
 		if err == qrm.ErrNoRows {
 			return "Unknown", nil
@@ -307,3 +308,27 @@ func (r *RepositoryImpl) GetUsernameByUserID(userID string) (string, error) {
 
 	return *user.Username, nil
 }
+
+// GetActiveBlobNames returns the blob names of every image that has not been
+// deleted.
+func (r *RepositoryImpl) GetActiveBlobNames(ctx context.Context) (map[string]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT blob_name FROM material_images WHERE is_active = true`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active blob names: %w", err)
+	}
+	defer rows.Close()
+
+	names := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan blob name: %w", err)
+		}
+		names[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blob names: %w", err)
+	}
+
+	return names, nil
+}
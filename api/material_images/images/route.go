@@ -1,25 +1,37 @@
 package images
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"time"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/material_images/shared"
+	"miltechserver/api/middleware"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
+	"miltechserver/identity"
+	"miltechserver/ratelimit"
 )
 
+// UploadPolicy allows each user three uploads per NIIN per hour.
+var UploadPolicy = ratelimit.Policy{Name: "material_image_upload", Limit: 3, Window: time.Hour}
+
+const refundTimeout = 5 * time.Second
+
 type Handler struct {
 	service Service
+	limiter *ratelimit.Limiter
 }
 
-func RegisterRoutes(publicRouter *gin.RouterGroup, authRouter *gin.RouterGroup, service Service, authClient *auth.Client) {
-	_ = authClient
-	handler := Handler{service: service}
+func RegisterRoutes(publicRouter *gin.RouterGroup, authRouter *gin.RouterGroup, service Service, verifier identity.TokenVerifier, limiter *ratelimit.Limiter) {
+	_ = verifier
+	handler := Handler{service: service, limiter: limiter}
 
 	publicRouter.GET("/material-images/niin/:niin", handler.getByNIIN)
 	publicRouter.GET("/material-images/:image_id", handler.getByID)
@@ -32,24 +44,44 @@ func RegisterRoutes(publicRouter *gin.RouterGroup, authRouter *gin.RouterGroup,
 func (h *Handler) upload(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return
 	}
 
 	niin := c.PostForm("niin")
 	if niin == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "NIIN is required"})
+		c.Error(apperror.MissingParameter("niin"))
 		return
 	}
 
 	if len(niin) != 9 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "NIIN must be exactly 9 characters"})
+		c.Error(shared.ErrInvalidNIIN)
+		return
+	}
+
+	// Counted before the file is read, so rejected clients never upload it,
+	// and refunded unless the upload succeeds: only stored images count.
+	limitKey := user.UserID + ":" + niin
+	limit, err := h.limiter.Allow(c.Request.Context(), UploadPolicy, limitKey)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	uploaded := false
+	defer func() {
+		if !uploaded {
+			h.refundUpload(c.Request.Context(), limitKey, limit)
+		}
+	}()
+	middleware.SetRateLimitHeaders(c, UploadPolicy, limit, time.Now())
+	if !limit.Allowed {
+		c.Error(apperror.RateLimited(shared.ErrRateLimited.Code, fmt.Sprintf("rate limit exceeded. You can upload another image for this NIIN after %s", limit.Reset.Format(time.RFC3339))))
 		return
 	}
 
 	file, header, err := c.Request.FormFile("image")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to get uploaded file"})
+		c.Error(apperror.MissingParameter("image").WithCause(err))
 		return
 	}
 	defer file.Close()
@@ -57,15 +89,16 @@ func (h *Handler) upload(c *gin.Context) {
 	imageData := make([]byte, header.Size)
 	_, err = file.Read(imageData)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file data"})
+		c.Error(err)
 		return
 	}
 
-	image, err := h.service.Upload(user, niin, imageData, header.Filename)
+	image, err := h.service.Upload(c.Request.Context(), user, niin, imageData, header.Filename)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
+	uploaded = true
 
 	c.JSON(http.StatusCreated, response.ImageUploadResponse{
 		Success: true,
@@ -89,25 +122,35 @@ func (h *Handler) upload(c *gin.Context) {
 	})
 }
 
+// refundUpload takes back an upload counted against UploadPolicy that did not
+// store an image. It runs after the request may have been cancelled.
+func (h *Handler) refundUpload(ctx context.Context, key string, limit ratelimit.Result) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refundTimeout)
+	defer cancel()
+	if err := h.limiter.Refund(ctx, UploadPolicy, key, limit); err != nil {
+		slog.Warn("Failed to refund material image upload", "key", key, "error", err)
+	}
+}
+
 func (h *Handler) getByNIIN(c *gin.Context) {
 	niin := c.Param("niin")
 	if len(niin) != 9 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid NIIN format"})
+		c.Error(shared.ErrInvalidNIIN)
 		return
 	}
 
 	var req request.GetImagesRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid query parameters: %v", err)})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	var currentUser *bootstrap.User
 	currentUser = shared.GetOptionalUserFromContext(c)
 
-	images, totalCount, err := h.service.GetByNIIN(niin, req.Page, req.PageSize, currentUser)
+	images, totalCount, err := h.service.GetByNIIN(c.Request.Context(), niin, req.Page, req.PageSize, currentUser)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve images"})
+		c.Error(err)
 		return
 	}
 
@@ -127,7 +170,7 @@ func (h *Handler) getByUser(c *gin.Context) {
 
 	var req request.GetImagesRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid query parameters: %v", err)})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -136,9 +179,9 @@ func (h *Handler) getByUser(c *gin.Context) {
 		currentUser = user.(*bootstrap.User)
 	}
 
-	images, totalCount, err := h.service.GetByUser(userID, req.Page, req.PageSize, currentUser)
+	images, totalCount, err := h.service.GetByUser(c.Request.Context(), userID, req.Page, req.PageSize, currentUser)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve images"})
+		c.Error(err)
 		return
 	}
 
@@ -161,9 +204,9 @@ func (h *Handler) getByID(c *gin.Context) {
 		currentUser = user.(*bootstrap.User)
 	}
 
-	image, err := h.service.GetByID(imageID, currentUser)
+	image, err := h.service.GetByID(c.Request.Context(), imageID, currentUser)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
+		c.Error(err)
 		return
 	}
 
@@ -173,19 +216,15 @@ func (h *Handler) getByID(c *gin.Context) {
 func (h *Handler) delete(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return
 	}
 
 	imageID := c.Param("image_id")
 
-	err = h.service.Delete(user, imageID)
+	err = h.service.Delete(c.Request.Context(), user, imageID)
 	if err != nil {
-		if err.Error() == "unauthorized: you can only delete your own images" {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete image"})
+		c.Error(err)
 		return
 	}
 
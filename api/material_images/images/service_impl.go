@@ -1,40 +1,38 @@
 package images
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/google/uuid"
 
 	"miltechserver/.gen/miltech_ng/public/model"
-	"miltechserver/api/material_images/ratelimit"
 	"miltechserver/api/material_images/shared"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
+	"miltechserver/storage"
 )
 
 type VoteRepository interface {
-	GetUserVote(imageID string, userID string) (*model.MaterialImagesVotes, error)
+	GetUserVote(ctx context.Context, imageID string, userID string) (*model.MaterialImagesVotes, error)
 }
 
 // ServiceImpl implements image operations.
 type ServiceImpl struct {
-	repo          Repository
-	rateLimitRepo ratelimit.Repository
-	blobStorage   *shared.BlobStorage
-	env           *bootstrap.Env
-	voteRepo      VoteRepository
+	repo        Repository
+	blobStorage *shared.BlobStorage
+	env         *bootstrap.Env
+	voteRepo    VoteRepository
 }
 
-func NewService(repo Repository, rateLimitRepo ratelimit.Repository, voteRepo VoteRepository, blobClient *azblob.Client, env *bootstrap.Env) Service {
+func NewService(repo Repository, voteRepo VoteRepository, store storage.ObjectStore, env *bootstrap.Env) Service {
 	return &ServiceImpl{
-		repo:          repo,
-		rateLimitRepo: rateLimitRepo,
-		blobStorage:   shared.NewBlobStorage(blobClient),
-		env:           env,
-		voteRepo:      voteRepo,
+		repo:        repo,
+		blobStorage: shared.NewBlobStorage(store),
+		env:         env,
+		voteRepo:    voteRepo,
 	}
 }
 
@@ -46,18 +44,9 @@ func (s *ServiceImpl) canUserDeleteImage(imageUserID string, currentUser *bootst
 	return imageUserID == currentUser.UserID
 }
 
-func (s *ServiceImpl) Upload(user *bootstrap.User, niin string, imageData []byte, filename string) (*model.MaterialImages, error) {
+func (s *ServiceImpl) Upload(ctx context.Context, user *bootstrap.User, niin string, imageData []byte, filename string) (*model.MaterialImages, error) {
 	if len(niin) != 9 {
-		return nil, fmt.Errorf("NIIN must be exactly 9 characters")
-	}
-
-	canUpload, nextAllowedTime, err := s.rateLimitRepo.CheckLimit(user.UserID, niin)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check upload limit: %w", err)
-	}
-
-	if !canUpload {
-		return nil, fmt.Errorf("rate limit exceeded. You can upload another image for this NIIN after %s", nextAllowedTime.Format(time.RFC3339))
+		return nil, shared.ErrInvalidNIIN
 	}
 
 	ext := filepath.Ext(filename)
@@ -67,12 +56,12 @@ func (s *ServiceImpl) Upload(user *bootstrap.User, niin string, imageData []byte
 
 	imageID := uuid.New()
 	blobName := fmt.Sprintf("%s%s", imageID.String(), ext)
-	err = s.blobStorage.Upload(blobName, imageData)
+	err := s.blobStorage.Upload(ctx, blobName, imageData)
 	if err != nil {
 		return nil, err
 	}
 
-	blobURL := s.blobStorage.GetURL(blobName, s.env.BlobAccountName)
+	blobURL := s.blobStorage.GetURL(blobName)
 
 	contentType := "image/jpeg"
 	switch ext {
@@ -101,34 +90,26 @@ func (s *ServiceImpl) Upload(user *bootstrap.User, niin string, imageData []byte
 		UpvoteCount:      0,
 	}
 
-	createdImage, err := s.repo.Create(user, image)
+	createdImage, err := s.repo.Create(ctx, user, image)
 	if err != nil {
-		_ = s.blobStorage.Delete(blobName)
+		_ = s.blobStorage.Delete(ctx, blobName)
 		return nil, fmt.Errorf("failed to save image record: %w", err)
 	}
 
-	err = s.rateLimitRepo.UpdateLimit(user.UserID, niin)
-	if err != nil {
-		fmt.Printf("ERROR: failed to update upload limit: %v\n", err)
-		_ = s.repo.Delete(createdImage.ID.String())
-		_ = s.blobStorage.Delete(blobName)
-		return nil, fmt.Errorf("failed to update upload rate limit: %w", err)
-	}
-
 	return createdImage, nil
 }
 
-func (s *ServiceImpl) GetByNIIN(niin string, page int, pageSize int, currentUser *bootstrap.User) ([]response.MaterialImageResponse, int64, error) {
+func (s *ServiceImpl) GetByNIIN(ctx context.Context, niin string, page int, pageSize int, currentUser *bootstrap.User) ([]response.MaterialImageResponse, int64, error) {
 	offset := (page - 1) * pageSize
 
-	imagesWithUsers, totalCount, err := s.repo.GetByNIIN(niin, pageSize, offset)
+	imagesWithUsers, totalCount, err := s.repo.GetByNIIN(ctx, niin, pageSize, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get images: %w", err)
 	}
 
 	responseImages := make([]response.MaterialImageResponse, len(imagesWithUsers))
 	for i, imgWithUser := range imagesWithUsers {
-		imageData, err := s.blobStorage.Download(imgWithUser.BlobName)
+		imageData, err := s.blobStorage.Download(ctx, imgWithUser.BlobName)
 		if err != nil {
 			fmt.Printf("Warning: failed to download image data for %s: %v\n", imgWithUser.BlobName, err)
 			imageData = []byte{}
@@ -139,7 +120,7 @@ func (s *ServiceImpl) GetByNIIN(niin string, page int, pageSize int, currentUser
 			username = *imgWithUser.Username
 		}
 
-		userVote := s.getUserVote(imgWithUser.ID.String(), currentUser)
+		userVote := s.getUserVote(ctx, imgWithUser.ID.String(), currentUser)
 
 		responseImages[i] = response.MaterialImageResponse{
 			ID:               imgWithUser.ID.String(),
@@ -163,17 +144,17 @@ func (s *ServiceImpl) GetByNIIN(niin string, page int, pageSize int, currentUser
 	return responseImages, totalCount, nil
 }
 
-func (s *ServiceImpl) GetByUser(userID string, page int, pageSize int, currentUser *bootstrap.User) ([]response.MaterialImageResponse, int64, error) {
+func (s *ServiceImpl) GetByUser(ctx context.Context, userID string, page int, pageSize int, currentUser *bootstrap.User) ([]response.MaterialImageResponse, int64, error) {
 	offset := (page - 1) * pageSize
 
-	imagesWithUsers, totalCount, err := s.repo.GetByUser(userID, pageSize, offset)
+	imagesWithUsers, totalCount, err := s.repo.GetByUser(ctx, userID, pageSize, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get images: %w", err)
 	}
 
 	responseImages := make([]response.MaterialImageResponse, len(imagesWithUsers))
 	for i, imgWithUser := range imagesWithUsers {
-		imageData, err := s.blobStorage.Download(imgWithUser.BlobName)
+		imageData, err := s.blobStorage.Download(ctx, imgWithUser.BlobName)
 		if err != nil {
 			fmt.Printf("Warning: failed to download image data for %s: %v\n", imgWithUser.BlobName, err)
 			imageData = []byte{}
@@ -184,7 +165,7 @@ func (s *ServiceImpl) GetByUser(userID string, page int, pageSize int, currentUs
 			username = *imgWithUser.Username
 		}
 
-		userVote := s.getUserVote(imgWithUser.ID.String(), currentUser)
+		userVote := s.getUserVote(ctx, imgWithUser.ID.String(), currentUser)
 
 		responseImages[i] = response.MaterialImageResponse{
 			ID:               imgWithUser.ID.String(),
@@ -208,28 +189,28 @@ func (s *ServiceImpl) GetByUser(userID string, page int, pageSize int, currentUs
 	return responseImages, totalCount, nil
 }
 
-func (s *ServiceImpl) GetByID(imageID string, currentUser *bootstrap.User) (*response.MaterialImageResponse, error) {
-	image, err := s.repo.GetByID(imageID)
+func (s *ServiceImpl) GetByID(ctx context.Context, imageID string, currentUser *bootstrap.User) (*response.MaterialImageResponse, error) {
+	image, err := s.repo.GetByID(ctx, imageID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get image: %w", err)
 	}
 
 	if image == nil {
-		return nil, fmt.Errorf("image not found")
+		return nil, shared.ErrImageNotFound
 	}
 
-	imageData, err := s.blobStorage.Download(image.BlobName)
+	imageData, err := s.blobStorage.Download(ctx, image.BlobName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download image data: %w", err)
 	}
 
-	username, err := s.repo.GetUsernameByUserID(image.UserID)
+	username, err := s.repo.GetUsernameByUserID(ctx, image.UserID)
 	if err != nil {
 		fmt.Printf("Warning: failed to get username for user %s: %v\n", image.UserID, err)
 		username = "Unknown"
 	}
 
-	userVote := s.getUserVote(image.ID.String(), currentUser)
+	userVote := s.getUserVote(ctx, image.ID.String(), currentUser)
 
 	responseImage := &response.MaterialImageResponse{
 		ID:               image.ID.String(),
@@ -252,26 +233,48 @@ func (s *ServiceImpl) GetByID(imageID string, currentUser *bootstrap.User) (*res
 	return responseImage, nil
 }
 
-func (s *ServiceImpl) Delete(user *bootstrap.User, imageID string) error {
-	image, err := s.repo.GetByID(imageID)
+func (s *ServiceImpl) Delete(ctx context.Context, user *bootstrap.User, imageID string) error {
+	image, err := s.repo.GetByID(ctx, imageID)
 	if err != nil {
 		return fmt.Errorf("failed to get image: %w", err)
 	}
 
 	if image == nil {
-		return fmt.Errorf("image not found")
+		return shared.ErrImageNotFound
 	}
 
 	if image.UserID != user.UserID {
-		return fmt.Errorf("unauthorized: you can only delete your own images")
+		return shared.ErrForbidden
+	}
+
+	return s.deactivate(ctx, image)
+}
+
+// Remove deletes an image whoever uploaded it. It is for moderators.
+func (s *ServiceImpl) Remove(ctx context.Context, imageID string) error {
+	if _, err := uuid.Parse(imageID); err != nil {
+		return shared.ErrImageNotFound
+	}
+
+	image, err := s.repo.GetByID(ctx, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to get image: %w", err)
+	}
+
+	if image == nil || !image.IsActive {
+		return shared.ErrImageNotFound
 	}
 
-	err = s.repo.Delete(imageID)
+	return s.deactivate(ctx, image)
+}
+
+func (s *ServiceImpl) deactivate(ctx context.Context, image *model.MaterialImages) error {
+	err := s.repo.Delete(ctx, image.ID.String())
 	if err != nil {
 		return fmt.Errorf("failed to delete image: %w", err)
 	}
 
-	err = s.blobStorage.Delete(image.BlobName)
+	err = s.blobStorage.Delete(ctx, image.BlobName)
 	if err != nil {
 		fmt.Printf("Warning: failed to delete blob %s from Azure storage: %v\n", image.BlobName, err)
 	}
@@ -279,12 +282,12 @@ func (s *ServiceImpl) Delete(user *bootstrap.User, imageID string) error {
 	return nil
 }
 
-func (s *ServiceImpl) getUserVote(imageID string, currentUser *bootstrap.User) *string {
+func (s *ServiceImpl) getUserVote(ctx context.Context, imageID string, currentUser *bootstrap.User) *string {
 	if currentUser == nil || s.voteRepo == nil {
 		return nil
 	}
 
-	vote, err := s.voteRepo.GetUserVote(imageID, currentUser.UserID)
+	vote, err := s.voteRepo.GetUserVote(ctx, imageID, currentUser.UserID)
 	if err != nil {
 		fmt.Printf("Warning: failed to get user vote for image %s: %v\n", imageID, err)
 		return nil
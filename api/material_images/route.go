@@ -3,35 +3,50 @@ package material_images
 import (
 	"database/sql"
 
-	"firebase.google.com/go/v4/auth"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 
 	"miltechserver/api/material_images/flags"
 	"miltechserver/api/material_images/images"
-	"miltechserver/api/material_images/ratelimit"
 	"miltechserver/api/material_images/votes"
 	"miltechserver/bootstrap"
+	"miltechserver/identity"
+	"miltechserver/jobs"
+	"miltechserver/ratelimit"
+	"miltechserver/storage"
 )
 
 type Dependencies struct {
-	DB         *sql.DB
-	BlobClient *azblob.Client
-	Env        *bootstrap.Env
-	AuthClient *auth.Client
+	DB       *sql.DB
+	Store    storage.ObjectStore
+	Env      *bootstrap.Env
+	Verifier identity.TokenVerifier
+	Limiter  *ratelimit.Limiter
+	Jobs     *jobs.Scheduler
 }
 
 func RegisterRoutes(deps Dependencies, publicRouter *gin.RouterGroup, authRouter *gin.RouterGroup) {
-	rateLimitRepo := ratelimit.NewRepository(deps.DB)
 	imagesRepo := images.NewRepository(deps.DB)
 	votesRepo := votes.NewRepository(deps.DB)
 	flagsRepo := flags.NewRepository(deps.DB)
 
-	imagesService := images.NewService(imagesRepo, rateLimitRepo, votesRepo, deps.BlobClient, deps.Env)
+	imagesService := images.NewService(imagesRepo, votesRepo, deps.Store, deps.Env)
 	votesService := votes.NewService(votesRepo, imagesRepo)
 	flagsService := flags.NewService(flagsRepo, imagesRepo)
 
-	images.RegisterRoutes(publicRouter, authRouter, imagesService, deps.AuthClient)
+	images.RegisterRoutes(publicRouter, authRouter, imagesService, deps.Verifier, deps.Limiter)
 	votes.RegisterRoutes(authRouter, votesService, imagesService)
 	flags.RegisterRoutes(authRouter, flagsService, imagesService)
+
+	deps.Jobs.Register(images.OrphanedBlobsJob(imagesRepo, deps.Store))
+}
+
+// RegisterModerationRoutes mounts the flag review routes on moderationGroup,
+// which must already require the moderator role.
+func RegisterModerationRoutes(deps Dependencies, moderationGroup *gin.RouterGroup) {
+	imagesRepo := images.NewRepository(deps.DB)
+
+	imagesService := images.NewService(imagesRepo, votes.NewRepository(deps.DB), deps.Store, deps.Env)
+	flagsService := flags.NewService(flags.NewRepository(deps.DB), imagesRepo)
+
+	flags.RegisterModerationRoutes(moderationGroup, flagsService, imagesService)
 }
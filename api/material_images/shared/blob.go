@@ -3,31 +3,28 @@ package shared
 import (
 	"context"
 	"fmt"
-	"io"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"miltechserver/storage"
 )
 
 const ContainerName = "material-images"
 
-// BlobStorage provides utilities for Azure Blob Storage operations.
+// BlobStorage provides utilities for material image object storage operations.
 type BlobStorage struct {
-	client *azblob.Client
+	store storage.ObjectStore
 }
 
-func NewBlobStorage(client *azblob.Client) *BlobStorage {
-	return &BlobStorage{client: client}
+func NewBlobStorage(store storage.ObjectStore) *BlobStorage {
+	return &BlobStorage{store: store}
 }
 
 // Upload stores image data and returns the blob name.
-func (b *BlobStorage) Upload(blobName string, imageData []byte) error {
-	if b.client == nil {
+func (b *BlobStorage) Upload(ctx context.Context, blobName string, imageData []byte) error {
+	if b.store == nil {
 		return nil
 	}
 
-	ctx := context.Background()
-
-	_, err := b.client.UploadBuffer(ctx, ContainerName, blobName, imageData, nil)
+	err := b.store.Put(ctx, ContainerName, blobName, imageData)
 	if err != nil {
 		return fmt.Errorf("failed to upload to blob storage: %w", err)
 	}
@@ -36,42 +33,47 @@ func (b *BlobStorage) Upload(blobName string, imageData []byte) error {
 }
 
 // Delete removes an image from blob storage.
-func (b *BlobStorage) Delete(blobName string) error {
-	if b.client == nil {
+func (b *BlobStorage) Delete(ctx context.Context, blobName string) error {
+	if b.store == nil {
 		return nil
 	}
 
-	ctx := context.Background()
-	_, err := b.client.DeleteBlob(ctx, ContainerName, blobName, nil)
-	return err
+	return b.store.Delete(ctx, ContainerName, blobName)
 }
 
-// Download retrieves the blob data.
-func (b *BlobStorage) Download(blobName string) ([]byte, error) {
-	if b.client == nil {
-		return []byte{}, nil
+// List returns every stored image blob.
+func (b *BlobStorage) List(ctx context.Context) ([]storage.ObjectInfo, error) {
+	if b.store == nil {
+		return nil, nil
 	}
 
-	ctx := context.Background()
-	response, err := b.client.DownloadStream(ctx, ContainerName, blobName, nil)
+	blobs, err := b.store.List(ctx, ContainerName, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to download image from blob storage: %w", err)
+		return nil, fmt.Errorf("failed to list image blobs: %w", err)
 	}
-	defer response.Body.Close()
 
-	data, err := io.ReadAll(response.Body)
+	return blobs, nil
+}
+
+// Download retrieves the blob data.
+func (b *BlobStorage) Download(ctx context.Context, blobName string) ([]byte, error) {
+	if b.store == nil {
+		return []byte{}, nil
+	}
+
+	data, err := b.store.Get(ctx, ContainerName, blobName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read image data: %w", err)
+		return nil, fmt.Errorf("failed to download image from blob storage: %w", err)
 	}
 
 	return data, nil
 }
 
 // GetURL returns the full URL for a blob.
-func (b *BlobStorage) GetURL(blobName string, accountName string) string {
-	if blobName == "" {
+func (b *BlobStorage) GetURL(blobName string) string {
+	if blobName == "" || b.store == nil {
 		return ""
 	}
 
-	return fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s", accountName, ContainerName, blobName)
+	return b.store.URL(ContainerName, blobName)
 }
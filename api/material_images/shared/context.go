@@ -1,7 +1,6 @@
 package shared
 
 import (
-	"errors"
 	"miltechserver/bootstrap"
 
 	"github.com/gin-gonic/gin"
@@ -10,12 +9,12 @@ import (
 func GetUserFromContext(c *gin.Context) (*bootstrap.User, error) {
 	ctxUser, ok := c.Get("user")
 	if !ok {
-		return nil, errors.New("unauthorized")
+		return nil, ErrUnauthorized
 	}
 
 	user, ok := ctxUser.(*bootstrap.User)
 	if !ok || user == nil {
-		return nil, errors.New("unauthorized")
+		return nil, ErrUnauthorized
 	}
 
 	return user, nil
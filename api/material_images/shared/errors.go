@@ -1,15 +1,15 @@
 package shared
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrImageNotFound  = errors.New("image not found")
-	ErrUnauthorized   = errors.New("unauthorized")
-	ErrForbidden      = errors.New("unauthorized: you can only delete your own images")
-	ErrInvalidNIIN    = errors.New("NIIN must be exactly 9 characters")
-	ErrRateLimited    = errors.New("rate limit exceeded")
-	ErrInvalidVote    = errors.New("invalid vote type")
-	ErrVoteNotFound   = errors.New("vote not found")
-	ErrInvalidReason  = errors.New("invalid flag reason")
-	ErrAlreadyFlagged = errors.New("you have already flagged this image")
+	ErrImageNotFound  = apperror.NotFound("image_not_found", "image not found")
+	ErrUnauthorized   = apperror.Unauthorized("unauthorized", "unauthorized")
+	ErrForbidden      = apperror.Forbidden("not_image_owner", "unauthorized: you can only delete your own images")
+	ErrInvalidNIIN    = apperror.Validation("invalid_niin", "NIIN must be exactly 9 characters")
+	ErrRateLimited    = apperror.RateLimited("rate_limited", "rate limit exceeded")
+	ErrInvalidVote    = apperror.Validation("invalid_vote", "invalid vote type")
+	ErrVoteNotFound   = apperror.NotFound("vote_not_found", "vote not found")
+	ErrInvalidReason  = apperror.Validation("invalid_flag_reason", "invalid flag reason")
+	ErrAlreadyFlagged = apperror.Conflict("already_flagged", "you have already flagged this image")
 )
@@ -0,0 +1,33 @@
+package votes
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Material Images",
+	Operations: []openapi.Operation{
+		{
+			ID:       "voteOnMaterialImage",
+			Method:   http.MethodPost,
+			Path:     "/material-images/:image_id/vote",
+			Auth:     true,
+			Summary:  "Vote on a material image",
+			Request:  request.VoteImageRequest{},
+			Response: openapi.Object{"success": false, "message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "removeMaterialImageVote",
+			Method:   http.MethodDelete,
+			Path:     "/material-images/:image_id/vote",
+			Auth:     true,
+			Summary:  "Remove a material image vote",
+			Response: openapi.Object{"success": false, "message": ""},
+			Raw:      true,
+		},
+	},
+}}
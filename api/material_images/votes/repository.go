@@ -1,10 +1,13 @@
 package votes
 
-import "miltechserver/.gen/miltech_ng/public/model"
+import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+)
 
 type Repository interface {
-	Upsert(vote model.MaterialImagesVotes) error
-	Delete(imageID string, userID string) error
-	GetUserVote(imageID string, userID string) (*model.MaterialImagesVotes, error)
-	UpdateImageCounts(imageID string) error
+	Upsert(ctx context.Context, vote model.MaterialImagesVotes) error
+	Delete(ctx context.Context, imageID string, userID string) error
+	GetUserVote(ctx context.Context, imageID string, userID string) (*model.MaterialImagesVotes, error)
+	UpdateImageCounts(ctx context.Context, imageID string) error
 }
@@ -1,6 +1,7 @@
 package votes
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -21,7 +22,7 @@ func NewRepository(db *sql.DB) Repository {
 	return &RepositoryImpl{db: db}
 }
 
-func (r *RepositoryImpl) Upsert(vote model.MaterialImagesVotes) error {
+func (r *RepositoryImpl) Upsert(ctx context.Context, vote model.MaterialImagesVotes) error {
 	stmt := MaterialImagesVotes.INSERT(
 		MaterialImagesVotes.ImageID,
 		MaterialImagesVotes.UserID,
@@ -40,7 +41,7 @@ func (r *RepositoryImpl) Upsert(vote model.MaterialImagesVotes) error {
 		),
 	)
 
-	_, err := stmt.Exec(r.db)
+	_, err := stmt.ExecContext(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to upsert vote: %w", err)
 	}
@@ -48,13 +49,13 @@ func (r *RepositoryImpl) Upsert(vote model.MaterialImagesVotes) error {
 	return nil
 }
 
-func (r *RepositoryImpl) Delete(imageID string, userID string) error {
+func (r *RepositoryImpl) Delete(ctx context.Context, imageID string, userID string) error {
 	stmt := MaterialImagesVotes.DELETE().WHERE(
 		MaterialImagesVotes.ImageID.EQ(UUID(uuid.MustParse(imageID))).
 			AND(MaterialImagesVotes.UserID.EQ(String(userID))),
 	)
 
-	_, err := stmt.Exec(r.db)
+	_, err := stmt.ExecContext(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to delete vote: %w", err)
 	}
@@ -62,7 +63,7 @@ func (r *RepositoryImpl) Delete(imageID string, userID string) error {
 	return nil
 }
 
-func (r *RepositoryImpl) GetUserVote(imageID string, userID string) (*model.MaterialImagesVotes, error) {
+func (r *RepositoryImpl) GetUserVote(ctx context.Context, imageID string, userID string) (*model.MaterialImagesVotes, error) {
 	stmt := SELECT(
 		MaterialImagesVotes.AllColumns,
 	).FROM(
@@ -73,7 +74,7 @@ func (r *RepositoryImpl) GetUserVote(imageID string, userID string) (*model.Mate
 	)
 
 	var vote model.MaterialImagesVotes
-	err := stmt.Query(r.db, &vote)
+	err := stmt.QueryContext(ctx, r.db, &vote)
 	if err != nil {
 		if err == qrm.ErrNoRows {
 			return nil, nil
@@ -84,7 +85,7 @@ func (r *RepositoryImpl) GetUserVote(imageID string, userID string) (*model.Mate
 	return &vote, nil
 }
 
-func (r *RepositoryImpl) UpdateImageCounts(imageID string) error {
+func (r *RepositoryImpl) UpdateImageCounts(ctx context.Context, imageID string) error {
 	upvoteStmt := SELECT(
 		COUNT(MaterialImagesVotes.ImageID).AS("count"),
 	).FROM(
@@ -97,7 +98,7 @@ func (r *RepositoryImpl) UpdateImageCounts(imageID string) error {
 	var upvoteCount struct {
 		Count int32 `sql:"count"`
 	}
-	err := upvoteStmt.Query(r.db, &upvoteCount)
+	err := upvoteStmt.QueryContext(ctx, r.db, &upvoteCount)
 	if err != nil {
 		return fmt.Errorf("failed to get upvote count: %w", err)
 	}
@@ -114,7 +115,7 @@ func (r *RepositoryImpl) UpdateImageCounts(imageID string) error {
 	var downvoteCount struct {
 		Count int32 `sql:"count"`
 	}
-	err = downvoteStmt.Query(r.db, &downvoteCount)
+	err = downvoteStmt.QueryContext(ctx, r.db, &downvoteCount)
 	if err != nil {
 		return fmt.Errorf("failed to get downvote count: %w", err)
 	}
@@ -131,7 +132,7 @@ func (r *RepositoryImpl) UpdateImageCounts(imageID string) error {
 		MaterialImages.ID.EQ(UUID(uuid.MustParse(imageID))),
 	)
 
-	_, err = updateStmt.Exec(r.db)
+	_, err = updateStmt.ExecContext(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to update vote counts: %w", err)
 	}
@@ -1,11 +1,11 @@
 package votes
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/material_images/images"
 	"miltechserver/api/material_images/shared"
 	"miltechserver/api/request"
@@ -27,7 +27,7 @@ func RegisterRoutes(authRouter *gin.RouterGroup, service Service, imagesService
 func (h *Handler) vote(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return
 	}
 
@@ -35,17 +35,17 @@ func (h *Handler) vote(c *gin.Context) {
 
 	var req request.VoteImageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request: %v", err)})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = h.service.Vote(user, imageID, req.VoteType)
+	err = h.service.Vote(c.Request.Context(), user, imageID, req.VoteType)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	updatedImage, err := h.imagesService.GetByID(imageID, user)
+	updatedImage, err := h.imagesService.GetByID(c.Request.Context(), imageID, user)
 	if err != nil {
 		c.JSON(http.StatusOK, gin.H{"success": true, "message": "Vote recorded successfully"})
 		return
@@ -63,19 +63,19 @@ func (h *Handler) vote(c *gin.Context) {
 func (h *Handler) removeVote(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return
 	}
 
 	imageID := c.Param("image_id")
 
-	err = h.service.RemoveVote(user, imageID)
+	err = h.service.RemoveVote(c.Request.Context(), user, imageID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove vote"})
+		c.Error(err)
 		return
 	}
 
-	updatedImage, err := h.imagesService.GetByID(imageID, user)
+	updatedImage, err := h.imagesService.GetByID(c.Request.Context(), imageID, user)
 	if err != nil {
 		c.JSON(http.StatusOK, gin.H{"success": true, "message": "Vote removed successfully"})
 		return
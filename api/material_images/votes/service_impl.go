@@ -1,12 +1,15 @@
 package votes
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/material_images/images"
+	"miltechserver/api/material_images/shared"
 	"miltechserver/bootstrap"
 )
 
@@ -22,23 +25,23 @@ func NewService(repo Repository, imagesRepo images.Repository) Service {
 	}
 }
 
-func (s *ServiceImpl) Vote(user *bootstrap.User, imageID string, voteType string) error {
+func (s *ServiceImpl) Vote(ctx context.Context, user *bootstrap.User, imageID string, voteType string) error {
 	if voteType != "upvote" && voteType != "downvote" {
-		return fmt.Errorf("invalid vote type: %s", voteType)
+		return shared.ErrInvalidVote
 	}
 
-	image, err := s.imagesRepo.GetByID(imageID)
+	image, err := s.imagesRepo.GetByID(ctx, imageID)
 	if err != nil {
 		return fmt.Errorf("failed to get image: %w", err)
 	}
 
 	if image == nil {
-		return fmt.Errorf("image not found")
+		return shared.ErrImageNotFound
 	}
 
 	imageUUID, err := uuid.Parse(imageID)
 	if err != nil {
-		return fmt.Errorf("invalid image ID: %w", err)
+		return apperror.InvalidParameter("image_id", "invalid image ID").WithCause(err)
 	}
 
 	vote := model.MaterialImagesVotes{
@@ -47,12 +50,12 @@ func (s *ServiceImpl) Vote(user *bootstrap.User, imageID string, voteType string
 		VoteType: voteType,
 	}
 
-	err = s.repo.Upsert(vote)
+	err = s.repo.Upsert(ctx, vote)
 	if err != nil {
 		return fmt.Errorf("failed to save vote: %w", err)
 	}
 
-	err = s.repo.UpdateImageCounts(imageID)
+	err = s.repo.UpdateImageCounts(ctx, imageID)
 	if err != nil {
 		return fmt.Errorf("failed to update vote counts: %w", err)
 	}
@@ -60,13 +63,13 @@ func (s *ServiceImpl) Vote(user *bootstrap.User, imageID string, voteType string
 	return nil
 }
 
-func (s *ServiceImpl) RemoveVote(user *bootstrap.User, imageID string) error {
-	err := s.repo.Delete(imageID, user.UserID)
+func (s *ServiceImpl) RemoveVote(ctx context.Context, user *bootstrap.User, imageID string) error {
+	err := s.repo.Delete(ctx, imageID, user.UserID)
 	if err != nil {
 		return fmt.Errorf("failed to remove vote: %w", err)
 	}
 
-	err = s.repo.UpdateImageCounts(imageID)
+	err = s.repo.UpdateImageCounts(ctx, imageID)
 	if err != nil {
 		return fmt.Errorf("failed to update vote counts: %w", err)
 	}
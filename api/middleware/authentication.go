@@ -1,74 +1,57 @@
 package middleware
 
 import (
-	"context"
+	"errors"
 	"log/slog"
-	"miltechserver/bootstrap"
+	"miltechserver/api/apperror"
+	"miltechserver/identity"
 	"strings"
 	"time"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
 )
 
-func AuthenticationMiddleware(client *auth.Client) gin.HandlerFunc {
+func AuthenticationMiddleware(verifier identity.TokenVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
 
 		header := c.Request.Header.Get("Authorization")
 		if header == "" {
 			slog.Error("", "auth_error", "no authorization header found")
-			c.JSON(401, gin.H{"message": "No Authorization header found"})
-			c.Abort()
+			apperror.Render(c, ErrMissingAuthorization)
 			return
 		}
-		idToken := strings.Split(header, "Bearer ")
-		if len(idToken) != 2 || len(idToken) == 0 {
+		tokenID, ok := bearerToken(header)
+		if !ok {
 			slog.Error("", "auth_error", "invalid authorization header")
-			c.JSON(401, gin.H{"message": "Invalid Authorization header"})
-			c.Abort()
+			apperror.Render(c, ErrInvalidAuthorization)
 			return
 		}
 
-		tokenID := idToken[1]
-
-		token, err := client.VerifyIDToken(context.Background(), tokenID)
+		user, err := verifier.Verify(c.Request.Context(), tokenID)
 		if err != nil {
+			if errors.Is(err, identity.ErrMissingEmail) {
+				slog.Error("", "auth_error", "email not found in token")
+				apperror.Render(c, ErrTokenMissingEmail)
+				return
+			}
 			slog.Error("Invalid token: ", "auth_error", err)
-			c.JSON(401, gin.H{"message": "Invalid token"})
-			c.Abort()
+			apperror.Render(c, ErrInvalidToken)
 			return
 		}
 
 		slog.Info("Auth process completed ", "auth_time", time.Since(startTime))
 
-		ProcessToken(c, client, token)
-		//c.Next()
+		c.Set("user", user)
+		c.Next()
 	}
 }
 
-func ProcessToken(c *gin.Context, auth *auth.Client, token *auth.Token) {
-	email, ok := token.Claims["email"].(string)
-	if !ok {
-		slog.Error("", "auth_error", "email not found in token")
-		c.JSON(401, gin.H{"message": "Email not found in token"})
-		c.Abort()
-		return
-	}
-	username, err := auth.GetUser(context.Background(), token.UID)
-	if err != nil {
-		slog.Error("Error getting user: ", "error", err)
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header.
+func bearerToken(header string) (string, bool) {
+	parts := strings.Split(header, "Bearer ")
+	if len(parts) != 2 || parts[1] == "" {
+		return "", false
 	}
-
-	// role, ok := token.Claims["role"].(string)
-
-	user := &bootstrap.User{
-		UserID:   token.UID,
-		Username: username.DisplayName,
-		Email:    email,
-	}
-	c.Set("user", user)
-
-	c.Next()
-
+	return parts[1], true
 }
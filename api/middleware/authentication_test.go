@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"miltechserver/bootstrap"
+	"miltechserver/identity"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIssuer(t *testing.T) *identity.LocalIssuer {
+	t.Helper()
+	issuer, err := identity.NewLocalIssuer(identity.LocalConfig{HMACSecret: []byte("test-secret")})
+	require.NoError(t, err)
+	return issuer
+}
+
+// newAuthTestRouter serves /test behind handler and echoes the authenticated user ID.
+func newAuthTestRouter(handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/test", handler, func(c *gin.Context) {
+		user, exists := c.Get("user")
+		if !exists {
+			c.String(http.StatusOK, "anonymous")
+			return
+		}
+		c.String(http.StatusOK, user.(*bootstrap.User).UserID)
+	})
+	return router
+}
+
+func serveWithToken(router *gin.Engine, header string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestAuthenticationMiddleware(t *testing.T) {
+	issuer := newTestIssuer(t)
+	router := newAuthTestRouter(AuthenticationMiddleware(issuer))
+
+	token, err := issuer.Issue(bootstrap.User{UserID: "user-1", Email: "a@example.com"}, time.Minute)
+	require.NoError(t, err)
+	resp := serveWithToken(router, "Bearer "+token)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "user-1", resp.Body.String())
+
+	require.Equal(t, http.StatusUnauthorized, serveWithToken(router, "").Code)
+	require.Equal(t, http.StatusUnauthorized, serveWithToken(router, token).Code)
+	require.Equal(t, http.StatusUnauthorized, serveWithToken(router, "Bearer garbage").Code)
+
+	noEmail, err := issuer.Issue(bootstrap.User{UserID: "user-1"}, time.Minute)
+	require.NoError(t, err)
+	resp = serveWithToken(router, "Bearer "+noEmail)
+	require.Equal(t, http.StatusUnauthorized, resp.Code)
+	require.Contains(t, resp.Body.String(), "Email not found in token")
+}
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	issuer := newTestIssuer(t)
+	router := newAuthTestRouter(OptionalAuthMiddleware(issuer))
+
+	token, err := issuer.Issue(bootstrap.User{UserID: "user-1", Email: "a@example.com"}, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "user-1", serveWithToken(router, "Bearer "+token).Body.String())
+	require.Equal(t, "anonymous", serveWithToken(router, "").Body.String())
+	require.Equal(t, "anonymous", serveWithToken(router, "Bearer garbage").Body.String())
+}
@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"miltechserver/dataversion"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReferenceCacheControl lets clients reuse a reference response for an hour
+// before revalidating it. Revalidation is cheap: it is answered with 304
+// without reaching the handler.
+const ReferenceCacheControl = "public, max-age=3600"
+
+// dbDateLayouts are the DB_DATE formats Last-Modified can be derived from
+// when no load has been recorded.
+var dbDateLayouts = []string{time.RFC3339, time.DateOnly, "01/02/2006", "20060102"}
+
+// servingSince is when this process started. Last-Modified is never earlier,
+// so a deploy that changes a response shape moves it forward just as the
+// build revision changes the ETag.
+var servingSince = time.Now().UTC().Truncate(time.Second)
+
+// ConditionalGet serves GET and HEAD requests for FedLog reference data
+// conditionally. That data only changes with a new FedLog load, which changes
+// the version returned by version, so the ETag is derived from that version
+// and the request alone, and a matching If-None-Match (or, without one, an
+// If-Modified-Since no older than Last-Modified) is answered with 304 before
+// the handler runs. Last-Modified is when the version was loaded, or the DB
+// date for the DB_DATE fallback, and never before the process started, so a
+// reload or a deploy moves it forward like the ETag. If-None-Match: * is
+// answered with 304 only once the handler has found the resource. Successful
+// responses carry the ETag, Last-Modified and Cache-Control headers; errors
+// carry none of them, and neither does a response whose handler set its own
+// Cache-Control, such as a partial result. An empty DB date disables the
+// middleware.
+func ConditionalGet(version func() dataversion.Version) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		current := version()
+		date := current.DBDate
+		method := c.Request.Method
+		if date == "" || (method != http.MethodGet && method != http.MethodHead) {
+			c.Next()
+			return
+		}
+		lastModified, hasLastModified := referenceLastModified(current)
+
+		headers := http.Header{}
+		headers.Set("ETag", referenceETag(current, c.Request))
+		headers.Set("Cache-Control", ReferenceCacheControl)
+		if hasLastModified {
+			headers.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		}
+
+		if notModified(c.Request, headers.Get("ETag"), lastModified, hasLastModified) {
+			for key, values := range headers {
+				c.Writer.Header()[key] = values
+			}
+			c.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+
+		c.Writer = &conditionalWriter{ResponseWriter: c.Writer, headers: headers, matchAny: matchesAny(c.Request)}
+		c.Next()
+	}
+}
+
+// referenceETag is a strong ETag over the data version, the build and the
+// request's path and query. The version's serial is included so a reload
+// with the same DB date invalidates it, and the build so a deploy that
+// changes a response shape does not keep answering 304 for the old one.
+func referenceETag(v dataversion.Version, r *http.Request) string {
+	version := v.DBDate + "\n" + strconv.FormatInt(v.Serial, 10)
+	sum := sha256.Sum256([]byte(version + "\n" + buildRevision + "\n" + r.URL.Path + "?" + r.URL.Query().Encode()))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func notModified(r *http.Request, etag string, lastModified time.Time, hasLastModified bool) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag {
+				return true
+			}
+		}
+		// If-Modified-Since is ignored when If-None-Match is present (RFC 9110 13.1.3).
+		return false
+	}
+
+	if !hasLastModified {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !lastModified.After(since)
+}
+
+// matchesAny reports whether r's If-None-Match is *, which matches any
+// current representation. Only the handler knows whether one exists, so it is
+// answered by conditionalWriter.
+func matchesAny(r *http.Request) bool {
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		if strings.TrimSpace(candidate) == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceLastModified is when v was loaded, falling back to its DB date
+// when no load has been recorded, and no earlier than servingSince.
+func referenceLastModified(v dataversion.Version) (time.Time, bool) {
+	modified, ok := parseDBDate(v.DBDate)
+	if !v.LoadedAt.IsZero() {
+		// HTTP dates have second precision.
+		modified, ok = v.LoadedAt.UTC().Truncate(time.Second), true
+	}
+	if !ok {
+		return time.Time{}, false
+	}
+	if servingSince.After(modified) {
+		modified = servingSince
+	}
+	return modified, true
+}
+
+func parseDBDate(dbDate string) (time.Time, bool) {
+	for _, layout := range dbDateLayouts {
+		if t, err := time.Parse(layout, dbDate); err == nil {
+			// HTTP dates have second precision.
+			return t.UTC().Truncate(time.Second), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// conditionalWriter adds the caching headers to the response just before it
+// is written, and only when it is a 200 the handler left uncached. Such a
+// response to If-None-Match: * is turned into a 304 and its body dropped.
+type conditionalWriter struct {
+	gin.ResponseWriter
+	headers     http.Header
+	matchAny    bool
+	stamped     bool
+	notModified bool
+}
+
+func (w *conditionalWriter) stamp() {
+	if w.stamped || w.Written() {
+		return
+	}
+	w.stamped = true
+	if w.Status() != http.StatusOK || w.Header().Get("Cache-Control") != "" {
+		return
+	}
+	for key, values := range w.headers {
+		w.Header()[key] = values
+	}
+	if w.matchAny {
+		w.notModified = true
+		w.Header().Del("Content-Type")
+		w.Header().Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+	}
+}
+
+func (w *conditionalWriter) WriteHeaderNow() {
+	w.stamp()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *conditionalWriter) Write(data []byte) (int, error) {
+	w.stamp()
+	if w.notModified {
+		w.ResponseWriter.WriteHeaderNow()
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *conditionalWriter) WriteString(s string) (int, error) {
+	w.stamp()
+	if w.notModified {
+		w.ResponseWriter.WriteHeaderNow()
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+var buildRevision = func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}()
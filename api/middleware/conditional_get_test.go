@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+	"miltechserver/dataversion"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func conditionalRouter(dbDate string, calls *int) *gin.Engine {
+	return versionedRouter(dataversion.Version{DBDate: dbDate, Serial: 1}, calls)
+}
+
+func versionedRouter(version dataversion.Version, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler)
+	group := router.Group("", ConditionalGet(func() dataversion.Version { return version }))
+	group.GET("/items", func(c *gin.Context) {
+		*calls++
+		c.JSON(http.StatusOK, gin.H{"niin": c.Query("niin")})
+	})
+	group.GET("/partial", func(c *gin.Context) {
+		*calls++
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	group.GET("/missing", func(c *gin.Context) {
+		*calls++
+		c.Error(apperror.NotFound("item_not_found", "no item"))
+	})
+	return router
+}
+
+// servedSince pretends the process started at start for the rest of the test.
+func servedSince(t *testing.T, start time.Time) {
+	previous := servingSince
+	servingSince = start
+	t.Cleanup(func() { servingSince = previous })
+}
+
+func serve(router *gin.Engine, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestConditionalGetAnswersMatchingETagWithoutHandler(t *testing.T) {
+	servedSince(t, time.Time{})
+	calls := 0
+	router := conditionalRouter("2026-09-30", &calls)
+
+	first := serve(router, "/items?niin=1&b=2", nil)
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	require.Equal(t, ReferenceCacheControl, first.Header().Get("Cache-Control"))
+	require.Equal(t, "Wed, 30 Sep 2026 00:00:00 GMT", first.Header().Get("Last-Modified"))
+
+	// Query parameter order does not change the ETag.
+	second := serve(router, "/items?b=2&niin=1", http.Header{"If-None-Match": {`"other", ` + etag}})
+	require.Equal(t, http.StatusNotModified, second.Code)
+	require.Empty(t, second.Body.String())
+	require.Equal(t, etag, second.Header().Get("ETag"))
+	require.Equal(t, 1, calls)
+
+	other := serve(router, "/items?niin=2", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusOK, other.Code)
+	require.NotEqual(t, etag, other.Header().Get("ETag"))
+}
+
+func TestConditionalGetETagChangesWithDBDate(t *testing.T) {
+	calls := 0
+	etag := serve(conditionalRouter("2026-09-30", &calls), "/items", nil).Header().Get("ETag")
+
+	rec := serve(conditionalRouter("2026-10-31", &calls), "/items", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestConditionalGetETagChangesWithReload(t *testing.T) {
+	calls := 0
+	first := dataversion.Version{DBDate: "2026-09-30", Serial: 1}
+	etag := serve(versionedRouter(first, &calls), "/items", nil).Header().Get("ETag")
+
+	reloaded := dataversion.Version{DBDate: "2026-09-30", Serial: 2}
+	rec := serve(versionedRouter(reloaded, &calls), "/items", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestConditionalGetMatchesAnyOnlyExistingResources(t *testing.T) {
+	calls := 0
+	router := conditionalRouter("2026-09-30", &calls)
+
+	rec := serve(router, "/items", http.Header{"If-None-Match": {"*"}})
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+	require.NotEmpty(t, rec.Header().Get("ETag"))
+	require.Equal(t, 1, calls)
+
+	rec = serve(router, "/missing", http.Header{"If-None-Match": {"*"}})
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Empty(t, rec.Header().Get("ETag"))
+	require.Equal(t, 2, calls)
+}
+
+func TestConditionalGetIfModifiedSince(t *testing.T) {
+	servedSince(t, time.Time{})
+	calls := 0
+	router := conditionalRouter("2026-09-30", &calls)
+
+	rec := serve(router, "/items", http.Header{"If-Modified-Since": {"Thu, 01 Oct 2026 00:00:00 GMT"}})
+	require.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = serve(router, "/items", http.Header{"If-Modified-Since": {"Tue, 29 Sep 2026 00:00:00 GMT"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// If-None-Match wins over If-Modified-Since.
+	rec = serve(router, "/items", http.Header{
+		"If-None-Match":     {`"stale"`},
+		"If-Modified-Since": {"Thu, 01 Oct 2026 00:00:00 GMT"},
+	})
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestConditionalGetLastModifiedFollowsReload(t *testing.T) {
+	servedSince(t, time.Time{})
+	calls := 0
+	loadedAt := time.Date(2026, 10, 2, 8, 15, 0, 0, time.UTC)
+	first := dataversion.Version{DBDate: "2026-09-30", Serial: 1, LoadedAt: loadedAt}
+	rec := serve(versionedRouter(first, &calls), "/items", nil)
+	require.Equal(t, "Fri, 02 Oct 2026 08:15:00 GMT", rec.Header().Get("Last-Modified"))
+
+	since := http.Header{"If-Modified-Since": {rec.Header().Get("Last-Modified")}}
+	require.Equal(t, http.StatusNotModified, serve(versionedRouter(first, &calls), "/items", since).Code)
+
+	// A reload with the same DB date is newer than what the client holds
+	reloaded := dataversion.Version{DBDate: "2026-09-30", Serial: 2, LoadedAt: loadedAt.Add(time.Hour)}
+	rec = serve(versionedRouter(reloaded, &calls), "/items", since)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "Fri, 02 Oct 2026 09:15:00 GMT", rec.Header().Get("Last-Modified"))
+}
+
+func TestConditionalGetLastModifiedNotBeforeDeploy(t *testing.T) {
+	deployed := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	servedSince(t, deployed)
+	calls := 0
+	version := dataversion.Version{DBDate: "2026-09-30", Serial: 1, LoadedAt: time.Date(2026, 10, 2, 8, 15, 0, 0, time.UTC)}
+
+	rec := serve(versionedRouter(version, &calls), "/items", http.Header{"If-Modified-Since": {"Sat, 03 Oct 2026 00:00:00 GMT"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, deployed.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+}
+
+func TestConditionalGetLeavesErrorsAndHandlerCachingAlone(t *testing.T) {
+	calls := 0
+	rec := serve(conditionalRouter("2026-09-30", &calls), "/missing", nil)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Empty(t, rec.Header().Get("ETag"))
+	require.Empty(t, rec.Header().Get("Cache-Control"))
+	require.Empty(t, rec.Header().Get("Last-Modified"))
+
+	rec = serve(conditionalRouter("2026-09-30", &calls), "/partial", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("ETag"))
+	require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+}
+
+func TestConditionalGetDisabledWithoutDBDate(t *testing.T) {
+	calls := 0
+	router := conditionalRouter("", &calls)
+
+	rec := serve(router, "/items", http.Header{"If-None-Match": {"*"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("ETag"))
+	require.Equal(t, 1, calls)
+}
@@ -1,31 +1,20 @@
 package middleware
 
 import (
+	"miltechserver/api/apperror"
+
 	"github.com/gin-gonic/gin"
-	"log"
-	"miltechserver/api/response"
-	"strings"
 )
 
-// TODO: Don't think this really does anything anymore.
+// ErrorHandler renders the last error a handler attached with c.Error as
+// application/problem+json. Status and code come from the apperror taxonomy;
+// unclassified errors become a logged, generic 500. Handlers that already
+// wrote a response are left alone.
 func ErrorHandler(c *gin.Context) {
 	c.Next()
 
-	for _, err := range c.Errors {
-		log.Default().Println(err)
-		if strings.Contains(err.Error(), "no item found") {
-			c.JSON(404, response.StandardResponse{
-				Status:  404,
-				Message: err.Error(),
-				Data:    nil,
-			})
-			return
-		} else {
-			c.JSON(500, response.StandardResponse{
-				Status:  500,
-				Message: err.Error(),
-				Data:    nil,
-			})
-		}
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
 	}
+	apperror.Render(c, c.Errors.Last().Err)
 }
@@ -0,0 +1,14 @@
+package middleware
+
+import "miltechserver/api/apperror"
+
+var (
+	ErrMissingAuthorization = apperror.Unauthorized("missing_authorization", "No Authorization header found")
+	ErrInvalidAuthorization = apperror.Unauthorized("invalid_authorization", "Invalid Authorization header")
+	ErrTokenMissingEmail    = apperror.Unauthorized("token_missing_email", "Email not found in token")
+	ErrInvalidToken         = apperror.Unauthorized("invalid_token", "Invalid token")
+	ErrUnauthenticated      = apperror.ErrUnauthenticated
+	ErrRoleRequired         = apperror.Forbidden("role_required", "forbidden")
+	ErrRateLimited          = apperror.RateLimited("rate_limited", "Too many requests. Please wait before retrying.")
+	ErrRouteNotFound        = apperror.NotFound("route_not_found", "API route not found")
+)
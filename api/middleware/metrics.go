@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"time"
+
+	"miltechserver/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware records request counts and latency keyed by the matched
+// route template (c.FullPath()), so /items/123 and /items/456 share a series.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		metrics.ObserveRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
+}
@@ -1,17 +1,15 @@
 package middleware
 
 import (
-	"context"
 	"log/slog"
-	"strings"
+	"miltechserver/identity"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
 )
 
 // OptionalAuthMiddleware attempts to verify the Bearer token.
-// If valid, sets *bootstrap.User in context via ProcessToken. If missing or invalid, continues without user.
-func OptionalAuthMiddleware(client *auth.Client) gin.HandlerFunc {
+// If valid, sets *bootstrap.User in context. If missing or invalid, continues without user.
+func OptionalAuthMiddleware(verifier identity.TokenVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		header := c.Request.Header.Get("Authorization")
 		if header == "" {
@@ -19,20 +17,20 @@ func OptionalAuthMiddleware(client *auth.Client) gin.HandlerFunc {
 			return
 		}
 
-		parts := strings.Split(header, "Bearer ")
-		if len(parts) != 2 {
+		tokenID, ok := bearerToken(header)
+		if !ok {
 			c.Next()
 			return
 		}
 
-		tokenID := parts[1]
-		token, err := client.VerifyIDToken(context.Background(), tokenID)
+		user, err := verifier.Verify(c.Request.Context(), tokenID)
 		if err != nil {
 			slog.Debug("Optional auth: invalid token", "error", err)
 			c.Next()
 			return
 		}
 
-		ProcessToken(c, client, token)
+		c.Set("user", user)
+		c.Next()
 	}
 }
@@ -1,48 +1,103 @@
 package middleware
 
 import (
+	"fmt"
 	"log/slog"
-	"net/http"
-	"sync"
+	"strconv"
+	"time"
+
+	"miltechserver/api/apperror"
+	"miltechserver/bootstrap"
+	"miltechserver/ratelimit"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/time/rate"
 )
 
-// ipLimiter holds a per-IP token-bucket rate limiter.
-type ipLimiter struct {
-	limiter *rate.Limiter
+// DownloadPolicy guards the routes that mint blob download URLs. It allows
+// about 2 requests a second per client, with bursts of up to 10.
+var DownloadPolicy = ratelimit.Policy{Name: "downloads", Limit: 10, Window: 5 * time.Second}
+
+// UserPolicy guards every authenticated route. It allows each user about 10
+// requests a second, enough for the app's bursts when it opens a screen.
+var UserPolicy = ratelimit.Policy{Name: "user", Limit: 600, Window: time.Minute}
+
+// ShopPolicy guards every route under /shops/:shop_id, shared by the shop's
+// members, so one busy shop cannot crowd out the rest.
+var ShopPolicy = ratelimit.Policy{Name: "shop", Limit: 1200, Window: time.Minute}
+
+// RateLimitKey picks the bucket a request is counted in. An empty key leaves
+// the request uncounted.
+type RateLimitKey func(c *gin.Context) string
+
+// ByClientIP counts requests per client IP.
+func ByClientIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
 }
 
-var (
-	ipLimiters sync.Map // map[string]*ipLimiter
-)
+// ByUser counts requests per authenticated user, falling back to the client
+// IP for anonymous requests.
+func ByUser(c *gin.Context) string {
+	if ctxUser, ok := c.Get("user"); ok {
+		if user, ok := ctxUser.(*bootstrap.User); ok && user != nil {
+			return "user:" + user.UserID
+		}
+	}
+	return ByClientIP(c)
+}
 
-// getLimiter returns (or creates) a rate limiter for the given IP address.
-func getLimiter(ip string) *rate.Limiter {
-	v, _ := ipLimiters.LoadOrStore(ip, &ipLimiter{
-		// 2 tokens/second sustained, burst of up to 10 requests.
-		limiter: rate.NewLimiter(2, 10),
-	})
-	return v.(*ipLimiter).limiter
+// ByShop counts requests per shop named by the :shop_id route parameter, so
+// every member of a shop shares one budget. Routes without one are not
+// counted.
+func ByShop(c *gin.Context) string {
+	if shopID := c.Param("shop_id"); shopID != "" {
+		return "shop:" + shopID
+	}
+	return ""
 }
 
-// RateLimiter returns a Gin middleware that limits requests per client IP.
-// Sustained rate: 2 req/s. Burst: 10 requests.
-// Responds with 429 Too Many Requests when the bucket is exhausted.
-func RateLimiter() gin.HandlerFunc {
+// RateLimit counts each request against policy in the bucket chosen by key
+// and rejects it with 429 once the bucket is full. Every counted response
+// carries the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers; when several policies count a request they
+// describe the one with the least remaining. If the store fails the request
+// is let through.
+func RateLimit(limiter *ratelimit.Limiter, policy ratelimit.Policy, key RateLimitKey) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		limiter := getLimiter(ip)
-
-		if !limiter.Allow() {
-			slog.Warn("Rate limit exceeded", "ip", ip, "path", c.FullPath())
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error": "Too many requests. Please wait before retrying.",
-			})
+		bucket := key(c)
+		if bucket == "" {
+			c.Next()
+			return
+		}
+		result, err := limiter.Allow(c.Request.Context(), policy, bucket)
+		if err != nil {
+			slog.Error("Rate limit check failed", "policy", policy.Name, "error", err)
+			c.Next()
+			return
+		}
+
+		if remaining, err := strconv.Atoi(c.Writer.Header().Get("RateLimit-Remaining")); err != nil || !result.Allowed || result.Remaining < remaining {
+			SetRateLimitHeaders(c, policy, result, time.Now())
+		}
+		if !result.Allowed {
+			slog.Warn("Rate limit exceeded", "policy", policy.Name, "ip", c.ClientIP(), "path", c.FullPath())
+			apperror.Render(c, ErrRateLimited)
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// SetRateLimitHeaders writes the RateLimit-* headers for a counted request,
+// plus Retry-After when it was rejected. Handlers that check a policy
+// themselves use it to report the same headers as the middleware.
+func SetRateLimitHeaders(c *gin.Context, policy ratelimit.Policy, result ratelimit.Result, now time.Time) {
+	reset := strconv.Itoa(int(result.RetryAfter(now).Seconds()))
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", reset)
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Window.Seconds())))
+	if !result.Allowed {
+		c.Header("Retry-After", reset)
+	}
+}
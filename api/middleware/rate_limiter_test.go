@@ -4,51 +4,110 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+	"miltechserver/bootstrap"
+	"miltechserver/ratelimit"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 )
 
-func TestRateLimiterAllowsNormalTraffic(t *testing.T) {
+func newRateLimitedRouter(policy ratelimit.Policy, key RateLimitKey) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	router.GET("/test", RateLimiter(), func(c *gin.Context) {
+	limiter := ratelimit.New(ratelimit.NewMemoryStore(), 0)
+	router.GET("/test", RateLimit(limiter, policy, key), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/shops/:shop_id", func(c *gin.Context) {
+		c.Set("user", &bootstrap.User{UserID: c.GetHeader("X-User-ID")})
+	}, RateLimit(limiter, policy, key), func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
+	return router
+}
+
+func doRateLimitedRequest(router *gin.Engine, path, remoteAddr, userID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-User-ID", userID)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
 
-	// Within the burst limit (10), all requests should succeed.
-	for i := 0; i < 5; i++ {
-		req := httptest.NewRequest(http.MethodGet, "/test", nil)
-		req.RemoteAddr = "192.0.2.1:1234"
-		resp := httptest.NewRecorder()
-		router.ServeHTTP(resp, req)
+func TestRateLimitAllowsTrafficWithinLimit(t *testing.T) {
+	router := newRateLimitedRouter(DownloadPolicy, ByClientIP)
+
+	for i := 0; i < DownloadPolicy.Limit; i++ {
+		resp := doRateLimitedRequest(router, "/test", "192.0.2.1:1234", "")
 		require.Equal(t, http.StatusOK, resp.Code, "request %d should be allowed", i+1)
 	}
 }
 
-func TestRateLimiterBlocksAfterBurst(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-	router := gin.New()
-	router.GET("/test", RateLimiter(), func(c *gin.Context) {
-		c.Status(http.StatusOK)
-	})
+func TestRateLimitBlocksAfterLimit(t *testing.T) {
+	policy := ratelimit.Policy{Name: "test", Limit: 2, Window: time.Hour}
+	router := newRateLimitedRouter(policy, ByClientIP)
+
+	first := doRateLimitedRequest(router, "/test", "192.0.2.99:5678", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	require.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "2;w=3600", first.Header().Get("RateLimit-Policy"))
+	require.NotEmpty(t, first.Header().Get("RateLimit-Reset"))
 
-	// Use a unique IP so this test has its own fresh limiter.
-	uniqueIP := "192.0.2.99:5678"
+	doRateLimitedRequest(router, "/test", "192.0.2.99:5678", "")
+	resp := doRateLimitedRequest(router, "/test", "192.0.2.99:5678", "")
 
-	// Exhaust the burst (10 tokens).
-	for i := 0; i < 10; i++ {
-		req := httptest.NewRequest(http.MethodGet, "/test", nil)
-		req.RemoteAddr = uniqueIP
-		resp := httptest.NewRecorder()
-		router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+	require.Equal(t, "0", resp.Header().Get("RateLimit-Remaining"))
+	require.NotEmpty(t, resp.Header().Get("Retry-After"))
+
+	other := doRateLimitedRequest(router, "/test", "192.0.2.100:5678", "")
+	require.Equal(t, http.StatusOK, other.Code)
+}
+
+func TestRateLimitByUserAndShop(t *testing.T) {
+	policy := ratelimit.Policy{Name: "test", Limit: 1, Window: time.Hour}
+
+	byUser := newRateLimitedRouter(policy, ByUser)
+	require.Equal(t, http.StatusOK, doRateLimitedRequest(byUser, "/shops/a", "192.0.2.1:1", "user-1").Code)
+	require.Equal(t, http.StatusOK, doRateLimitedRequest(byUser, "/shops/a", "192.0.2.1:1", "user-2").Code)
+	require.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(byUser, "/shops/b", "192.0.2.2:1", "user-1").Code)
+
+	byShop := newRateLimitedRouter(policy, ByShop)
+	require.Equal(t, http.StatusOK, doRateLimitedRequest(byShop, "/shops/a", "192.0.2.1:1", "user-1").Code)
+	require.Equal(t, http.StatusOK, doRateLimitedRequest(byShop, "/shops/b", "192.0.2.1:1", "user-1").Code)
+	require.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(byShop, "/shops/a", "192.0.2.1:1", "user-2").Code)
+}
+
+func TestRateLimitByShopSkipsRoutesWithoutShop(t *testing.T) {
+	policy := ratelimit.Policy{Name: "test", Limit: 1, Window: time.Hour}
+	router := newRateLimitedRouter(policy, ByShop)
+
+	for range 3 {
+		resp := doRateLimitedRequest(router, "/test", "192.0.2.1:1", "user-1")
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Empty(t, resp.Header().Get("RateLimit-Limit"))
 	}
+}
 
-	// The 11th request should be rate-limited.
-	req := httptest.NewRequest(http.MethodGet, "/test", nil)
-	req.RemoteAddr = uniqueIP
-	resp := httptest.NewRecorder()
-	router.ServeHTTP(resp, req)
+func TestRateLimitHeadersDescribeTheTightestPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	limiter := ratelimit.New(ratelimit.NewMemoryStore(), 0)
+	tight := ratelimit.Policy{Name: "tight", Limit: 2, Window: time.Minute}
+	loose := ratelimit.Policy{Name: "loose", Limit: 100, Window: time.Minute}
+	router.GET("/test",
+		RateLimit(limiter, tight, ByClientIP),
+		RateLimit(limiter, loose, ByClientIP),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
 
-	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	resp := doRateLimitedRequest(router, "/test", "192.0.2.1:1", "")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "2", resp.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", resp.Header().Get("RateLimit-Remaining"))
 }
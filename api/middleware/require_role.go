@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"log/slog"
+	"miltechserver/api/apperror"
+	"miltechserver/bootstrap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole rejects requests whose user holds none of roles. It must run
+// after AuthenticationMiddleware. Site admins pass every role check.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctxUser, exists := c.Get("user")
+		user, ok := ctxUser.(*bootstrap.User)
+		if !exists || !ok || user == nil {
+			apperror.Render(c, ErrUnauthenticated)
+			return
+		}
+
+		if !user.HasRole(roles...) {
+			slog.Warn("Role check failed", "user_id", user.UserID, "required", roles, "held", user.Roles, "path", c.FullPath())
+			apperror.Render(c, ErrRoleRequired)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"miltechserver/bootstrap"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		user *bootstrap.User
+		want int
+	}{
+		{name: "no user", user: nil, want: http.StatusUnauthorized},
+		{name: "no roles", user: &bootstrap.User{UserID: "u1"}, want: http.StatusForbidden},
+		{name: "moderator", user: &bootstrap.User{UserID: "u1", Roles: []string{bootstrap.RoleModerator}}, want: http.StatusOK},
+		{name: "site admin", user: &bootstrap.User{UserID: "u1", Roles: []string{bootstrap.RoleSiteAdmin}}, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/moderate", func(c *gin.Context) {
+				if tt.user != nil {
+					c.Set("user", tt.user)
+				}
+			}, RequireRole(bootstrap.RoleModerator), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/moderate", nil))
+			require.Equal(t, tt.want, resp.Code)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout gives every request a deadline of d. Handlers pass
+// c.Request.Context() to services and repositories, so queries for a request
+// that runs too long, or whose client disconnects, are cancelled and their
+// pool connections released. A non-positive d disables the deadline.
+//
+// Requests matched to one of the streams route paths are left without a
+// deadline: they are meant to stay open, and end when the client disconnects
+// or the server drains them on shutdown. Streams are chosen by route, never by
+// request headers, so a client cannot lift the deadline from other routes.
+func Timeout(d time.Duration, streams ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if d <= 0 || slices.Contains(streams, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutSetsRequestDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Timeout(time.Minute))
+
+	var deadline time.Time
+	var hasDeadline bool
+	router.GET("/test", func(c *gin.Context) {
+		deadline, hasDeadline = c.Request.Context().Deadline()
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	require.True(t, hasDeadline)
+	require.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+func TestTimeoutDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Timeout(0))
+
+	hasDeadline := true
+	router.GET("/test", func(c *gin.Context) {
+		_, hasDeadline = c.Request.Context().Deadline()
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	require.False(t, hasDeadline)
+}
+
+func TestTimeoutSkipsOnlyStreamRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Timeout(time.Minute, "/shops/:shop_id/stream"))
+
+	deadlines := map[string]bool{}
+	record := func(c *gin.Context) {
+		_, deadlines[c.FullPath()] = c.Request.Context().Deadline()
+	}
+	router.GET("/shops/:shop_id/stream", record)
+	router.GET("/shops/:shop_id", record)
+
+	for _, path := range []string{"/shops/shop-1/stream", "/shops/shop-1"} {
+		// Streaming headers do not lift the deadline from other routes
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set("Upgrade", "websocket")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.False(t, deadlines["/shops/:shop_id/stream"])
+	require.True(t, deadlines["/shops/:shop_id"])
+}
+
+func TestTimeoutRendersGatewayTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler, Timeout(10*time.Millisecond))
+	router.GET("/slow", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.Error(c.Request.Context().Err())
+	})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	require.Equal(t, http.StatusGatewayTimeout, resp.Code)
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+	require.Contains(t, resp.Body.String(), `"code":"request_timeout"`)
+}
@@ -0,0 +1,16 @@
+package moderation
+
+import (
+	"slices"
+
+	"miltechserver/api/item_comments"
+	"miltechserver/api/material_images"
+	"miltechserver/api/user_suggestions"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(
+	material_images.ModerationDocs,
+	item_comments.ModerationDocs,
+	user_suggestions.ModerationDocs,
+)
@@ -0,0 +1,32 @@
+// Package moderation serves the content moderation API under
+// /auth/moderation. Every route requires the moderator role, which site
+// admins also hold.
+package moderation
+
+import (
+	"database/sql"
+	"miltechserver/api/item_comments"
+	"miltechserver/api/material_images"
+	"miltechserver/api/middleware"
+	"miltechserver/api/user_suggestions"
+	"miltechserver/bootstrap"
+	"miltechserver/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Dependencies struct {
+	DB    *sql.DB
+	Store storage.ObjectStore
+	Env   *bootstrap.Env
+}
+
+// RegisterRoutes mounts the moderation group on authRouter, which must
+// already authenticate the user.
+func RegisterRoutes(deps Dependencies, authRouter *gin.RouterGroup) {
+	moderationRoutes := authRouter.Group("/moderation", middleware.RequireRole(bootstrap.RoleModerator, bootstrap.RoleSiteAdmin))
+
+	material_images.RegisterModerationRoutes(material_images.Dependencies{DB: deps.DB, Store: deps.Store, Env: deps.Env}, moderationRoutes)
+	item_comments.RegisterModerationRoutes(item_comments.Dependencies{DB: deps.DB}, moderationRoutes)
+	user_suggestions.RegisterModerationRoutes(user_suggestions.Dependencies{DB: deps.DB}, moderationRoutes)
+}
@@ -0,0 +1,54 @@
+package moderation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"miltechserver/api/middleware"
+	"miltechserver/bootstrap"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// moderationRouter registers the moderation routes behind a stand-in for the
+// authentication middleware that sets user. The role check rejects every
+// request in these tests, so the handlers never reach the nil database.
+func moderationRouter(user *bootstrap.User) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	authRoutes := router.Group("/api/v1/auth", func(c *gin.Context) {
+		if user != nil {
+			c.Set("user", user)
+		}
+	})
+	RegisterRoutes(Dependencies{}, authRoutes)
+	return router
+}
+
+func TestModerationRoutesRequireModerator(t *testing.T) {
+	tests := []struct {
+		name string
+		user *bootstrap.User
+		want int
+	}{
+		{name: "no user", user: nil, want: http.StatusUnauthorized},
+		{name: "plain user", user: &bootstrap.User{UserID: "u1"}, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := moderationRouter(tt.user)
+
+			routes := router.Routes()
+			require.NotEmpty(t, routes)
+			for _, route := range routes {
+				resp := httptest.NewRecorder()
+				router.ServeHTTP(resp, httptest.NewRequest(route.Method, route.Path, nil))
+				require.Equal(t, tt.want, resp.Code, "%s %s", route.Method, route.Path)
+			}
+		})
+	}
+}
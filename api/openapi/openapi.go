@@ -0,0 +1,227 @@
+// Package openapi builds the OpenAPI 3.1 document served at /api/docs.
+//
+// Every feature package describes the routes its RegisterRoutes registers in
+// a Docs table next to them. Request and response schemas are reflected from
+// the Go types the handlers bind and return, so the document follows the code
+// instead of drifting like the hand-written markdown under docs/api. The route
+// package assembles the tables and a test fails when a registered route is
+// missing from them.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"miltechserver/api/apperror"
+)
+
+// Version is the OpenAPI version the document targets.
+const Version = "3.1.0"
+
+const (
+	publicPrefix = "/api/v1"
+	authPrefix   = "/api/v1/auth"
+)
+
+// Viewer is the HTML page served at /api/docs. It renders the document from
+// /api/docs/openapi.json.
+//
+//go:embed viewer.html
+var Viewer []byte
+
+// Param describes a query parameter or a multipart form field.
+type Param struct {
+	Name string
+	// Type is "string" (the default), "integer", "number", "boolean", or
+	// "file" for form uploads.
+	Type        string
+	Required    bool
+	Description string
+}
+
+// Operation describes one route.
+type Operation struct {
+	// ID is the operationId client generators name methods after. It must be
+	// unique across the document.
+	ID     string
+	Method string
+	// Path is the gin route relative to /api/v1, or to /api/v1/auth when Auth
+	// is set. Path parameters are documented from it.
+	Path    string
+	Auth    bool
+	Summary string
+	Query   []Param
+	// Form describes a multipart/form-data body.
+	Form []Param
+	// Request is a value of the JSON body type.
+	Request any
+	// Status is the success status; zero means 200.
+	Status int
+	// Response is a value of the type returned in the StandardResponse data
+	// field, or of the whole body when Raw is set. Nil documents no data.
+	Response any
+	Raw      bool
+	// ContentType replaces application/json for binary responses.
+	ContentType string
+}
+
+// Section groups the operations of one feature under a tag.
+type Section struct {
+	Tag        string
+	Operations []Operation
+}
+
+// Object describes an ad-hoc JSON object such as a gin.H payload. Each value
+// is an example whose type becomes the property's schema.
+type Object map[string]any
+
+// FullPath returns the gin route the operation is registered under.
+func (op Operation) FullPath() string {
+	if op.Auth {
+		return authPrefix + op.Path
+	}
+	return publicPrefix + op.Path
+}
+
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// New builds the document for sections.
+func New(info Info, sections []Section) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	schemas := newSchemaSet()
+	schemas.collect(apperror.Problem{})
+	for _, section := range sections {
+		for _, op := range section.Operations {
+			for _, v := range []any{op.Request, op.Response} {
+				schemas.collect(v)
+			}
+		}
+	}
+	schemas.name()
+	doc.Components.Responses = map[string]*Response{
+		"Problem": {
+			Description: "Error described as RFC 7807 problem details",
+			Content:     map[string]MediaType{apperror.ContentType: {Schema: schemas.schema(apperror.Problem{})}},
+		},
+	}
+
+	tags := map[string]bool{}
+	for _, section := range sections {
+		if !tags[section.Tag] {
+			tags[section.Tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: section.Tag})
+		}
+		for _, op := range section.Operations {
+			path := pathParam.ReplaceAllString(op.FullPath(), "{$1}")
+			if doc.Paths[path] == nil {
+				doc.Paths[path] = PathItem{}
+			}
+			doc.Paths[path][strings.ToLower(op.Method)] = operationObject(section.Tag, op, schemas)
+		}
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	doc.Components.Schemas = schemas.components()
+	return doc
+}
+
+func operationObject(tag string, op Operation, schemas *schemaSet) *OperationObject {
+	obj := &OperationObject{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Tags:        []string{tag},
+		Responses:   map[string]*Response{"default": {Ref: "#/components/responses/Problem"}},
+	}
+	if op.Auth {
+		obj.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	for _, match := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+		obj.Parameters = append(obj.Parameters, ParameterObject{
+			Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+	for _, param := range op.Query {
+		obj.Parameters = append(obj.Parameters, ParameterObject{
+			Name: param.Name, In: "query", Required: param.Required, Description: param.Description, Schema: paramSchema(param),
+		})
+	}
+
+	switch {
+	case op.Request != nil:
+		obj.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: schemas.schema(op.Request)}},
+		}
+	case len(op.Form) > 0:
+		form := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for _, param := range op.Form {
+			form.Properties[param.Name] = paramSchema(param)
+			if param.Required {
+				form.Required = append(form.Required, param.Name)
+			}
+		}
+		obj.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"multipart/form-data": {Schema: form}},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	switch {
+	case status == http.StatusNoContent, op.Raw && op.Response == nil:
+	case op.ContentType != "":
+		success.Content = map[string]MediaType{op.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+	case op.Raw:
+		success.Content = map[string]MediaType{"application/json": {Schema: schemas.schema(op.Response)}}
+	default:
+		success.Content = map[string]MediaType{"application/json": {Schema: envelope(schemas.schema(op.Response))}}
+	}
+	obj.Responses[strconv.Itoa(status)] = success
+	return obj
+}
+
+// envelope wraps data in the StandardResponse shape every handler writes.
+func envelope(data *Schema) *Schema {
+	env := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"status":  {Type: "integer"},
+			"message": {Type: "string"},
+		},
+		Required: []string{"status", "message"},
+	}
+	if data != nil {
+		env.Properties["data"] = data
+		env.Required = append(env.Required, "data")
+	}
+	return env
+}
+
+func paramSchema(param Param) *Schema {
+	switch param.Type {
+	case "":
+		return &Schema{Type: "string"}
+	case "file":
+		return &Schema{Type: "string", Format: "binary"}
+	default:
+		return &Schema{Type: param.Type}
+	}
+}
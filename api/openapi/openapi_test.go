@@ -0,0 +1,120 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type audit struct {
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type widget struct {
+	audit
+	ID       uuid.UUID         `json:"id"`
+	Name     string            `json:"name" binding:"required"`
+	Count    int32             `json:"count,omitempty"`
+	Parts    []part            `json:"parts"`
+	Parent   *part             `json:"parent"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Extra    json.RawMessage   `json:"extra,omitempty"`
+	Internal string            `json:"-"`
+	NoTag    bool
+	hidden   string
+}
+
+type part struct {
+	SKU string `json:"sku"`
+}
+
+func newTestDocument(ops ...Operation) *Document {
+	return New(Info{Title: "test", Version: "1"}, []Section{{Tag: "Widgets", Operations: ops}})
+}
+
+func TestSchemaFollowsJSONEncoding(t *testing.T) {
+	doc := newTestDocument(Operation{ID: "getWidget", Method: http.MethodGet, Path: "/widgets/:id", Response: widget{}})
+
+	schema := doc.Components.Schemas["widget"]
+	require.NotNil(t, schema)
+	require.ElementsMatch(t, []string{"created_at", "updated_at", "id", "name", "count", "parts", "parent", "labels", "extra", "NoTag"}, keys(schema.Properties))
+	require.Equal(t, []string{"NoTag", "created_at", "id", "name", "parts"}, schema.Required)
+
+	require.Equal(t, &Schema{Type: "string", Format: "date-time"}, schema.Properties["created_at"])
+	require.Equal(t, []string{"string", "null"}, schema.Properties["updated_at"].Type)
+	require.Equal(t, &Schema{Type: "string", Format: "uuid"}, schema.Properties["id"])
+	require.Equal(t, "#/components/schemas/part", schema.Properties["parts"].Items.Ref)
+	require.Equal(t, "#/components/schemas/part", schema.Properties["parent"].OneOf[0].Ref)
+	require.Equal(t, "null", schema.Properties["parent"].OneOf[1].Type)
+	require.Equal(t, "string", schema.Properties["labels"].AdditionalProperties.Type)
+	require.Equal(t, &Schema{}, schema.Properties["extra"])
+	require.Contains(t, doc.Components.Schemas, "part")
+}
+
+func TestOperationDocumentsParametersAndEnvelope(t *testing.T) {
+	doc := newTestDocument(
+		Operation{
+			ID:       "updateWidget",
+			Method:   http.MethodPut,
+			Path:     "/shops/:shop_id/widgets/:id",
+			Auth:     true,
+			Query:    []Param{{Name: "page", Type: "integer"}},
+			Request:  part{},
+			Response: widget{},
+		},
+		Operation{ID: "deleteWidget", Method: http.MethodDelete, Path: "/widgets/:id", Status: http.StatusNoContent},
+		Operation{ID: "widgetMessage", Method: http.MethodGet, Path: "/widgets/message", Raw: true, Response: Object{"message": ""}},
+	)
+
+	op := doc.Operation(http.MethodPut, "/api/v1/auth/shops/:shop_id/widgets/:id")
+	require.NotNil(t, op)
+	require.Equal(t, []string{"Widgets"}, op.Tags)
+	require.Equal(t, []map[string][]string{{"bearerAuth": {}}}, op.Security)
+	require.Len(t, op.Parameters, 3)
+	require.Equal(t, "shop_id", op.Parameters[0].Name)
+	require.Equal(t, "path", op.Parameters[0].In)
+	require.Equal(t, "query", op.Parameters[2].In)
+	require.Equal(t, "integer", op.Parameters[2].Schema.Type)
+	require.Equal(t, "#/components/schemas/part", op.RequestBody.Content["application/json"].Schema.Ref)
+
+	body := op.Responses["200"].Content["application/json"].Schema
+	require.Equal(t, []string{"status", "message", "data"}, body.Required)
+	require.Equal(t, "#/components/schemas/widget", body.Properties["data"].Ref)
+	require.Equal(t, "#/components/responses/Problem", op.Responses["default"].Ref)
+
+	deleted := doc.Operation(http.MethodDelete, "/api/v1/widgets/:id")
+	require.Nil(t, deleted.Security)
+	require.Nil(t, deleted.Responses["204"].Content)
+
+	raw := doc.Operation(http.MethodGet, "/api/v1/widgets/message").Responses["200"].Content["application/json"].Schema
+	require.Equal(t, []string{"message"}, raw.Required)
+	require.Equal(t, "string", raw.Properties["message"].Type)
+}
+
+func TestSchemaNamesQualifyClashingTypes(t *testing.T) {
+	type part struct {
+		Name string `json:"name"`
+	}
+	doc := newTestDocument(
+		Operation{ID: "a", Method: http.MethodGet, Path: "/a", Response: widget{}},
+		Operation{ID: "b", Method: http.MethodGet, Path: "/b", Response: part{}},
+	)
+
+	require.NotContains(t, doc.Components.Schemas, "part")
+	require.Contains(t, doc.Components.Schemas, "part1")
+	require.Contains(t, doc.Components.Schemas, "part2")
+	require.Contains(t, doc.Components.Schemas, "Problem")
+}
+
+func keys(m map[string]*Schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,288 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshaler     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler     = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	typeArgQualifiers = regexp.MustCompile(`[A-Za-z0-9_./-]+\.`)
+)
+
+// schemaSet reflects Go types into JSON Schemas the way encoding/json would
+// marshal them. Named structs become shared components, referenced by name.
+type schemaSet struct {
+	names map[reflect.Type]string
+	order []reflect.Type
+}
+
+func newSchemaSet() *schemaSet {
+	return &schemaSet{names: map[reflect.Type]string{}}
+}
+
+// collect records the named structs reachable from v. Every type must be
+// collected before name is called.
+func (s *schemaSet) collect(v any) {
+	if v == nil {
+		return
+	}
+	if obj, ok := v.(Object); ok {
+		for _, value := range obj {
+			s.collect(value)
+		}
+		return
+	}
+	s.walk(reflect.TypeOf(v))
+}
+
+func (s *schemaSet) walk(t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if opaque(t) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		s.walk(t.Elem())
+	case reflect.Struct:
+		if t.Name() != "" {
+			if _, seen := s.names[t]; seen {
+				return
+			}
+			s.names[t] = ""
+			s.order = append(s.order, t)
+		}
+		for i := 0; i < t.NumField(); i++ {
+			s.walk(t.Field(i).Type)
+		}
+	}
+}
+
+// name assigns component names: the bare type name when it is unique,
+// otherwise prefixed with the package name, then with the full package path.
+func (s *schemaSet) name() {
+	namers := []func(reflect.Type) string{
+		func(t reflect.Type) string { return typeName(t) },
+		func(t reflect.Type) string {
+			pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+			return exportName(pkg) + typeName(t)
+		},
+		func(t reflect.Type) string {
+			var b strings.Builder
+			for _, part := range strings.FieldsFunc(t.PkgPath(), func(r rune) bool { return r == '/' || r == '.' || r == '-' }) {
+				b.WriteString(exportName(part))
+			}
+			return b.String() + typeName(t)
+		},
+	}
+
+	pending := s.order
+	for _, namer := range namers {
+		counts := map[string]int{}
+		for _, t := range pending {
+			counts[namer(t)]++
+		}
+		var clashes []reflect.Type
+		for _, t := range pending {
+			if name := namer(t); counts[name] == 1 {
+				s.names[t] = name
+			} else {
+				clashes = append(clashes, t)
+			}
+		}
+		pending = clashes
+	}
+	// Only types local to the same function can still clash.
+	for i, t := range pending {
+		s.names[t] = typeName(t) + strconv.Itoa(i+1)
+	}
+}
+
+func (s *schemaSet) components() map[string]*Schema {
+	components := make(map[string]*Schema, len(s.order))
+	for _, t := range s.order {
+		components[s.names[t]] = s.structSchema(t)
+	}
+	return components
+}
+
+// schema returns the schema for the type of v, or nil when v is nil.
+func (s *schemaSet) schema(v any) *Schema {
+	if v == nil {
+		return nil
+	}
+	if obj, ok := v.(Object); ok {
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for name, value := range obj {
+			schema.Properties[name] = s.schema(value)
+			schema.Required = append(schema.Required, name)
+		}
+		sort.Strings(schema.Required)
+		return schema
+	}
+	return s.typeSchema(reflect.TypeOf(v))
+}
+
+func (s *schemaSet) typeSchema(t reflect.Type) *Schema {
+	if t.Kind() != reflect.Pointer {
+		return s.valueSchema(t)
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	schema := s.valueSchema(t)
+	switch typ := schema.Type.(type) {
+	case string:
+		schema.Type = []string{typ, "null"}
+	case nil:
+		if schema.Ref != "" {
+			return &Schema{OneOf: []*Schema{schema, {Type: "null"}}}
+		}
+	}
+	return schema
+}
+
+func (s *schemaSet) valueSchema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Name() == "UUID" && strings.HasSuffix(t.PkgPath(), "/uuid"):
+		return &Schema{Type: "string", Format: "uuid"}
+	case t == rawMessageType:
+		return &Schema{}
+	case implements(t, textMarshaler):
+		return &Schema{Type: "string"}
+	case implements(t, jsonMarshaler):
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if name, ok := s.names[t]; ok && name != "" {
+			return &Schema{Ref: "#/components/schemas/" + name}
+		}
+		return s.structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema describes the object encoding/json writes for t, including
+// the promoted fields of embedded structs.
+func (s *schemaSet) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := s.structSchema(embedded)
+				for prop, propSchema := range inner.Properties {
+					schema.Properties[prop] = propSchema
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		propSchema := s.typeSchema(field.Type)
+		if hasOption(opts, "string") {
+			propSchema = &Schema{Type: "string"}
+		}
+		schema.Properties[name] = propSchema
+
+		optional := hasOption(opts, "omitempty") || field.Type.Kind() == reflect.Pointer
+		if !optional || strings.Contains(field.Tag.Get("binding"), "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+func opaque(t reflect.Type) bool {
+	return t == timeType || t == rawMessageType || implements(t, textMarshaler) || implements(t, jsonMarshaler)
+}
+
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+func hasOption(opts string, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName is t's name with generic type arguments reduced to their bare
+// names, e.g. Page[miltechserver/api/x.Item] becomes PageItem.
+func typeName(t reflect.Type) string {
+	name := typeArgQualifiers.ReplaceAllString(t.Name(), "")
+	return strings.NewReplacer("[", "", "]", "", ",", "", "*", "", " ", "").Replace(name)
+}
+
+func exportName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
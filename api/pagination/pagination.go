@@ -0,0 +1,145 @@
+// Package pagination is the cursor contract shared by list endpoints.
+//
+// A list is read in the order of a unique sort key. Each page carries an
+// opaque cursor holding the key of its last item, and the next page is
+// fetched with WHERE key > cursor instead of OFFSET, so deep pages cost the
+// same as the first. Repositories fetch Params.Fetch rows and NewPage trims
+// the extra row into the next cursor.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxLimit bounds the limit a client may ask for.
+const MaxLimit = 200
+
+var ErrInvalidCursor = apperror.Validation("invalid_cursor", "cursor is not valid for this list")
+
+// Params are the cursor and page size a client asked for.
+type Params struct {
+	// Cursor is the next_cursor of the previous page; empty for the first page.
+	Cursor string
+	Limit  int
+	// IncludeTotal asks for the total number of items in the list, which
+	// costs a COUNT query on most lists.
+	IncludeTotal bool
+}
+
+// FromQuery reads the cursor, limit and include_total query parameters.
+// defaultLimit is used when limit is absent.
+func FromQuery(c *gin.Context, defaultLimit int) (Params, error) {
+	params := Params{Cursor: c.Query("cursor"), Limit: defaultLimit}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return Params{}, apperror.InvalidParameter("limit", fmt.Sprintf("limit must be between 1 and %d", MaxLimit))
+		}
+		params.Limit = limit
+	}
+
+	if raw := c.Query("include_total"); raw != "" {
+		includeTotal, err := strconv.ParseBool(raw)
+		if err != nil {
+			return Params{}, apperror.InvalidParameter("include_total", "include_total must be true or false")
+		}
+		params.IncludeTotal = includeTotal
+	}
+
+	return params, nil
+}
+
+// Fetch is the number of rows a repository should read: one more than the
+// limit, so NewPage can tell whether another page follows.
+func (p Params) Fetch() int64 {
+	return int64(p.Limit) + 1
+}
+
+// After decodes the cursor into key, which must point at the same types, in
+// the same order, as the key function given to NewPage. It reports false for
+// the first page.
+func (p Params) After(key ...any) (bool, error) {
+	if p.Cursor == "" {
+		return false, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil {
+		return false, ErrInvalidCursor
+	}
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil || len(values) != len(key) {
+		return false, ErrInvalidCursor
+	}
+	for i, value := range values {
+		if err := json.Unmarshal(value, key[i]); err != nil {
+			return false, ErrInvalidCursor
+		}
+	}
+	return true, nil
+}
+
+// Page is the envelope every cursor-paginated endpoint returns.
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor is null on the last page.
+	NextCursor *string `json:"next_cursor"`
+	// Total is only present when the client asked for it.
+	Total *int `json:"total,omitempty"`
+}
+
+// NewPage builds a page from rows read with Params.Fetch. key returns the
+// sort key of an item, in ORDER BY order.
+func NewPage[T any](rows []T, params Params, key func(T) []any) Page[T] {
+	page := Page[T]{Items: rows}
+	if len(rows) > params.Limit {
+		page.Items = rows[:params.Limit]
+		cursor := encode(key(page.Items[len(page.Items)-1]))
+		page.NextCursor = &cursor
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return page
+}
+
+// WithTotal returns the page with its total set.
+func (p Page[T]) WithTotal(total int) Page[T] {
+	p.Total = &total
+	return p
+}
+
+// Map converts the items of p with f, keeping its cursor and total. It lets a
+// repository page over rows that carry their sort key alongside the item.
+func Map[T, U any](p Page[T], f func(T) U) Page[U] {
+	mapped := Page[U]{Items: make([]U, len(p.Items)), NextCursor: p.NextCursor, Total: p.Total}
+	for i, item := range p.Items {
+		mapped.Items[i] = f(item)
+	}
+	return mapped
+}
+
+func encode(key []any) string {
+	raw, err := json.Marshal(key)
+	if err != nil {
+		// Keys are strings, numbers and times, which always marshal.
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Query documents the parameters FromQuery reads, for openapi.Operation.
+var Query = []openapi.Param{
+	{Name: "cursor", Description: "next_cursor of the previous page; omit for the first page"},
+	{Name: "limit", Type: "integer", Description: fmt.Sprintf("Items per page, at most %d", MaxLimit)},
+	{Name: "include_total", Type: "boolean", Description: "Include the total number of items"},
+}
@@ -0,0 +1,96 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type row struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+func rowKey(r row) []any {
+	return []any{r.CreatedAt, r.Name}
+}
+
+func queryContext(query string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/items?"+query, nil)
+	return c
+}
+
+func TestNewPageCarriesCursorToNextPage(t *testing.T) {
+	created := time.Date(2026, 10, 1, 12, 30, 0, 123456000, time.UTC)
+	rows := []row{{"a", created}, {"b", created}, {"c", created}}
+	params := Params{Limit: 2}
+
+	page := NewPage(rows[:params.Fetch()], params, rowKey)
+	require.Len(t, page.Items, 2)
+	require.NotNil(t, page.NextCursor)
+	require.Nil(t, page.Total)
+
+	next := Params{Cursor: *page.NextCursor, Limit: 2}
+	var after time.Time
+	var name string
+	ok, err := next.After(&after, &name)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.True(t, created.Equal(after))
+	require.Equal(t, "b", name)
+
+	last := NewPage(rows[2:], next, rowKey).WithTotal(3)
+	require.Equal(t, []row{{"c", created}}, last.Items)
+	require.Nil(t, last.NextCursor)
+	require.Equal(t, 3, *last.Total)
+}
+
+func TestNewPageEmptyListHasNoItemsOrCursor(t *testing.T) {
+	page := NewPage[row](nil, Params{Limit: 10}, rowKey)
+	require.NotNil(t, page.Items)
+	require.Empty(t, page.Items)
+	require.Nil(t, page.NextCursor)
+}
+
+func TestMapKeepsCursorAndTotal(t *testing.T) {
+	rows := []row{{"a", time.Time{}}, {"b", time.Time{}}}
+	page := NewPage(rows, Params{Limit: 1}, rowKey).WithTotal(2)
+
+	names := Map(page, func(r row) string { return r.Name })
+	require.Equal(t, []string{"a"}, names.Items)
+	require.Equal(t, page.NextCursor, names.NextCursor)
+	require.Equal(t, 2, *names.Total)
+}
+
+func TestAfterRejectsForeignCursors(t *testing.T) {
+	ok, err := Params{}.After(new(string))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for _, cursor := range []string{"not base64!", "bm90IGpzb24", encode([]any{"a", "b"}), encode([]any{42})} {
+		_, err := Params{Cursor: cursor}.After(new(string))
+		require.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+}
+
+func TestFromQuery(t *testing.T) {
+	params, err := FromQuery(queryContext(""), 40)
+	require.NoError(t, err)
+	require.Equal(t, Params{Limit: 40}, params)
+
+	params, err = FromQuery(queryContext("cursor=abc&limit=5&include_total=true"), 40)
+	require.NoError(t, err)
+	require.Equal(t, Params{Cursor: "abc", Limit: 5, IncludeTotal: true}, params)
+
+	for _, query := range []string{"limit=0", "limit=201", "limit=ten", "include_total=maybe"} {
+		_, err := FromQuery(queryContext(query), 40)
+		require.Equal(t, apperror.KindValidation, apperror.As(err).Kind, query)
+	}
+}
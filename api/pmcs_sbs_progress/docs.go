@@ -0,0 +1,104 @@
+package pmcs_sbs_progress
+
+import (
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "PMCS SBS Progress",
+	Operations: []openapi.Operation{
+		{
+			ID:       "upsertInspection",
+			Method:   http.MethodPut,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id",
+			Auth:     true,
+			Summary:  "Upsert inspection",
+			Request:  InspectionRequest{},
+			Response: InspectionResponse{},
+		},
+		{
+			ID:       "getInspection",
+			Method:   http.MethodGet,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id",
+			Auth:     true,
+			Summary:  "Get inspection",
+			Response: InspectionResponse{},
+		},
+		{
+			ID:       "deleteInspection",
+			Method:   http.MethodDelete,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id",
+			Auth:     true,
+			Summary:  "Delete inspection",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "listInspections",
+			Method:   http.MethodGet,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs",
+			Auth:     true,
+			Summary:  "List inspections",
+			Query:    []openapi.Param{{Name: "guide_manual"}, {Name: "limit", Type: "integer"}, {Name: "offset", Type: "integer"}},
+			Response: InspectionListResponse{},
+		},
+		{
+			ID:       "upsertFault",
+			Method:   http.MethodPut,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id/faults",
+			Auth:     true,
+			Summary:  "Upsert fault",
+			Request:  FaultRequest{},
+			Response: FaultResponse{},
+		},
+		{
+			ID:       "deleteFault",
+			Method:   http.MethodDelete,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id/faults",
+			Auth:     true,
+			Summary:  "Delete fault",
+			Request:  DeleteFaultRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "deleteFaults",
+			Method:   http.MethodDelete,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id/faults/bulk",
+			Auth:     true,
+			Summary:  "Delete faults",
+			Request:  BulkDeleteFaultRequest{},
+			Response: openapi.Object{"message": "", "requested_count": 0, "deleted_count": 0},
+			Raw:      true,
+		},
+		{
+			ID:       "createPMCSComment",
+			Method:   http.MethodPost,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id/comments",
+			Auth:     true,
+			Summary:  "Comment on a PMCS step",
+			Request:  CreateCommentRequest{},
+			Status:   http.StatusCreated,
+			Response: CommentResponse{},
+		},
+		{
+			ID:       "updatePMCSComment",
+			Method:   http.MethodPut,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id/comments/:comment_id",
+			Auth:     true,
+			Summary:  "Update a PMCS comment",
+			Request:  UpdateCommentRequest{},
+			Response: CommentResponse{},
+		},
+		{
+			ID:       "deletePMCSComment",
+			Method:   http.MethodDelete,
+			Path:     "/pmcs-sbs/equipment/:equipment_id/pmcs/:pmcs_id/comments/:comment_id",
+			Auth:     true,
+			Summary:  "Delete a PMCS comment",
+			Response: CommentResponse{},
+		},
+	},
+}}
@@ -1,18 +1,18 @@
 package pmcs_sbs_progress
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrInvalidID          = errors.New("invalid id")
-	ErrInvalidPmcsID      = errors.New("invalid pmcs id")
-	ErrInvalidGuideManual = errors.New("invalid guide manual")
-	ErrInvalidRequest     = errors.New("invalid request")
-	ErrInvalidStatus      = errors.New("invalid fault status")
-	ErrUnauthorized       = errors.New("unauthorized")
-	ErrNotFound           = errors.New("pmcs sbs equipment not found")
-	ErrInspectionNotFound = errors.New("pmcs sbs inspection not found")
-	ErrInspectionConflict = errors.New("pmcs sbs inspection conflict")
-	ErrInvalidCommentText = errors.New("invalid comment text")
-	ErrCommentNotFound    = errors.New("pmcs sbs comment not found")
-	ErrForbidden          = errors.New("user not authorized")
+	ErrInvalidID          = apperror.Validation("invalid_id", "invalid id")
+	ErrInvalidPmcsID      = apperror.Validation("invalid_pmcs_id", "invalid pmcs id")
+	ErrInvalidGuideManual = apperror.Validation("invalid_guide_manual", "invalid guide manual")
+	ErrInvalidRequest     = apperror.Validation("invalid_request", "invalid request")
+	ErrInvalidStatus      = apperror.Validation("invalid_fault_status", "invalid fault status")
+	ErrUnauthorized       = apperror.Unauthorized("unauthorized", "unauthorized")
+	ErrNotFound           = apperror.NotFound("equipment_not_found", "pmcs sbs equipment not found")
+	ErrInspectionNotFound = apperror.NotFound("inspection_not_found", "pmcs sbs inspection not found")
+	ErrInspectionConflict = apperror.Conflict("inspection_conflict", "pmcs sbs inspection conflict")
+	ErrInvalidCommentText = apperror.Validation("invalid_comment_text", "invalid comment text")
+	ErrCommentNotFound    = apperror.NotFound("comment_not_found", "pmcs sbs comment not found")
+	ErrForbidden          = apperror.Forbidden("forbidden", "user not authorized")
 )
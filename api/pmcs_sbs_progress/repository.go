@@ -1,6 +1,7 @@
 package pmcs_sbs_progress
 
 import (
+	"context"
 	"time"
 
 	"miltechserver/.gen/miltech_ng/public/model"
@@ -10,19 +11,20 @@ import (
 )
 
 type Repository interface {
-	EnsureInspection(user *bootstrap.User, inspection model.PmcsSbsInspections) (*model.PmcsSbsInspections, error)
-	GetInspection(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) (*InspectionDetail, []model.PmcsSbsFaults, []CommentWithAuthor, error)
-	ListInspections(user *bootstrap.User, equipmentID string, guideManual string, limit int, offset int) ([]InspectionSummary, error)
-	DeleteInspection(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) error
-	LookupUsername(userID string) (*string, error)
-
-	UpsertFault(user *bootstrap.User, inspection model.PmcsSbsInspections, fault model.PmcsSbsFaults) (*model.PmcsSbsFaults, error)
-	DeleteFault(user *bootstrap.User, equipmentID string, key FaultKey) error
-	DeleteFaults(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, keys []FaultKey) (int64, error)
-
-	CreateComment(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, text string) (*CommentWithAuthor, error)
-	GetComment(commentID uuid.UUID) (*CommentWithAuthor, error)
-	UpdateComment(commentID uuid.UUID, text string) (*CommentWithAuthor, error)
+	EnsureInspection(ctx context.Context, user *bootstrap.User, inspection model.PmcsSbsInspections) (*model.PmcsSbsInspections, error)
+	GetInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) (*InspectionDetail, []model.PmcsSbsFaults, []CommentWithAuthor, error)
+	ListInspections(ctx context.Context, user *bootstrap.User, equipmentID string, guideManual string, limit int, offset int) ([]InspectionSummary, error)
+	DeleteInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) error
+	LookupUsername(ctx context.Context, userID string) (*string, error)
+	GetVehicleShopID(ctx context.Context, equipmentID string) (string, error)
+
+	UpsertFault(ctx context.Context, user *bootstrap.User, inspection model.PmcsSbsInspections, fault model.PmcsSbsFaults) (*model.PmcsSbsFaults, error)
+	DeleteFault(ctx context.Context, user *bootstrap.User, equipmentID string, key FaultKey) error
+	DeleteFaults(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, keys []FaultKey) (int64, error)
+
+	CreateComment(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, text string) (*CommentWithAuthor, error)
+	GetComment(ctx context.Context, commentID uuid.UUID) (*CommentWithAuthor, error)
+	UpdateComment(ctx context.Context, commentID uuid.UUID, text string) (*CommentWithAuthor, error)
 }
 
 type InspectionDetail struct {
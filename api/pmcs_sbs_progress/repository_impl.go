@@ -1,6 +1,7 @@
 package pmcs_sbs_progress
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	. "github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
@@ -23,15 +25,15 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) EnsureInspection(user *bootstrap.User, inspection model.PmcsSbsInspections) (*model.PmcsSbsInspections, error) {
-	if err := repo.requireVehicleAccess(user, inspection.EquipmentID); err != nil {
+func (repo *RepositoryImpl) EnsureInspection(ctx context.Context, user *bootstrap.User, inspection model.PmcsSbsInspections) (*model.PmcsSbsInspections, error) {
+	if err := repo.requireVehicleAccess(ctx, user, inspection.EquipmentID); err != nil {
 		return nil, err
 	}
-	return ensureInspection(repo.db, inspection)
+	return ensureInspection(ctx, repo.db, inspection)
 }
 
-func (repo *RepositoryImpl) GetInspection(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) (*InspectionDetail, []model.PmcsSbsFaults, []CommentWithAuthor, error) {
-	if err := repo.requireVehicleAccess(user, equipmentID); err != nil {
+func (repo *RepositoryImpl) GetInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) (*InspectionDetail, []model.PmcsSbsFaults, []CommentWithAuthor, error) {
+	if err := repo.requireVehicleAccess(ctx, user, equipmentID); err != nil {
 		return nil, nil, nil, err
 	}
 
@@ -49,7 +51,7 @@ func (repo *RepositoryImpl) GetInspection(user *bootstrap.User, equipmentID stri
 				AND(PmcsSbsInspections.EquipmentID.EQ(String(equipmentID))),
 		)
 
-	if err := stmt.Query(repo.db, &row); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &row); err != nil {
 		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, qrm.ErrNoRows) {
 			return nil, nil, nil, ErrInspectionNotFound
 		}
@@ -62,7 +64,7 @@ func (repo *RepositoryImpl) GetInspection(user *bootstrap.User, equipmentID stri
 		WHERE(PmcsSbsFaults.PmcsID.EQ(UUID(pmcsID))).
 		ORDER_BY(PmcsSbsFaults.SectionID.ASC(), PmcsSbsFaults.ItemIndex.ASC())
 
-	if err := faultsStmt.Query(repo.db, &faults); err != nil {
+	if err := faultsStmt.QueryContext(ctx, repo.db, &faults); err != nil {
 		return nil, nil, nil, fmt.Errorf("list pmcs sbs inspection faults: %w", err)
 	}
 
@@ -78,7 +80,7 @@ func (repo *RepositoryImpl) GetInspection(user *bootstrap.User, equipmentID stri
 		WHERE(PmcsSbsInspectionComments.PmcsID.EQ(UUID(pmcsID))).
 		ORDER_BY(PmcsSbsInspectionComments.CreatedAt.ASC())
 
-	if err := commentsStmt.Query(repo.db, &comments); err != nil {
+	if err := commentsStmt.QueryContext(ctx, repo.db, &comments); err != nil {
 		return nil, nil, nil, fmt.Errorf("list pmcs sbs inspection comments: %w", err)
 	}
 
@@ -93,8 +95,8 @@ func (repo *RepositoryImpl) GetInspection(user *bootstrap.User, equipmentID stri
 	return &InspectionDetail{PmcsSbsInspections: row.PmcsSbsInspections, PerformedByUsername: row.PerformedByUsername}, faults, commentsWithAuthor, nil
 }
 
-func (repo *RepositoryImpl) ListInspections(user *bootstrap.User, equipmentID string, guideManual string, limit int, offset int) ([]InspectionSummary, error) {
-	if err := repo.requireVehicleAccess(user, equipmentID); err != nil {
+func (repo *RepositoryImpl) ListInspections(ctx context.Context, user *bootstrap.User, equipmentID string, guideManual string, limit int, offset int) ([]InspectionSummary, error) {
+	if err := repo.requireVehicleAccess(ctx, user, equipmentID); err != nil {
 		return nil, err
 	}
 
@@ -117,7 +119,7 @@ func (repo *RepositoryImpl) ListInspections(user *bootstrap.User, equipmentID st
 		LIMIT(int64(limit)).
 		OFFSET(int64(offset))
 
-	if err := stmt.Query(repo.db, &inspections); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &inspections); err != nil {
 		return nil, fmt.Errorf("list pmcs sbs inspections: %w", err)
 	}
 	if len(inspections) == 0 {
@@ -140,7 +142,7 @@ func (repo *RepositoryImpl) ListInspections(user *bootstrap.User, equipmentID st
 		WHERE(PmcsSbsFaults.PmcsID.IN(ids...)).
 		GROUP_BY(PmcsSbsFaults.PmcsID)
 
-	if err := countStmt.Query(repo.db, &counts); err != nil {
+	if err := countStmt.QueryContext(ctx, repo.db, &counts); err != nil {
 		return nil, fmt.Errorf("count pmcs sbs faults: %w", err)
 	}
 
@@ -160,7 +162,7 @@ func (repo *RepositoryImpl) ListInspections(user *bootstrap.User, equipmentID st
 		WHERE(PmcsSbsInspectionComments.PmcsID.IN(ids...)).
 		GROUP_BY(PmcsSbsInspectionComments.PmcsID)
 
-	if err := commentCountStmt.Query(repo.db, &commentCounts); err != nil {
+	if err := commentCountStmt.QueryContext(ctx, repo.db, &commentCounts); err != nil {
 		return nil, fmt.Errorf("count pmcs sbs inspection comments: %w", err)
 	}
 
@@ -185,8 +187,8 @@ func (repo *RepositoryImpl) ListInspections(user *bootstrap.User, equipmentID st
 	return summaries, nil
 }
 
-func (repo *RepositoryImpl) DeleteInspection(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) error {
-	if err := repo.requireVehicleAccess(user, equipmentID); err != nil {
+func (repo *RepositoryImpl) DeleteInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) error {
+	if err := repo.requireVehicleAccess(ctx, user, equipmentID); err != nil {
 		return err
 	}
 
@@ -195,7 +197,7 @@ func (repo *RepositoryImpl) DeleteInspection(user *bootstrap.User, equipmentID s
 			PmcsSbsInspections.ID.EQ(UUID(pmcsID)).
 				AND(PmcsSbsInspections.EquipmentID.EQ(String(equipmentID))),
 		).
-		Exec(repo.db)
+		ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("delete pmcs sbs inspection: %w", err)
 	}
@@ -209,24 +211,32 @@ func (repo *RepositoryImpl) DeleteInspection(user *bootstrap.User, equipmentID s
 	return nil
 }
 
-func (repo *RepositoryImpl) UpsertFault(user *bootstrap.User, inspection model.PmcsSbsInspections, fault model.PmcsSbsFaults) (*model.PmcsSbsFaults, error) {
-	if err := repo.requireVehicleAccess(user, inspection.EquipmentID); err != nil {
+func (repo *RepositoryImpl) UpsertFault(ctx context.Context, user *bootstrap.User, inspection model.PmcsSbsInspections, fault model.PmcsSbsFaults) (*model.PmcsSbsFaults, error) {
+	if err := repo.requireVehicleAccess(ctx, user, inspection.EquipmentID); err != nil {
 		return nil, err
 	}
 
-	tx, err := repo.db.Begin()
-	if err != nil {
-		return nil, fmt.Errorf("begin upsert pmcs sbs fault transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	savedInspection, err := ensureInspection(tx, inspection)
+	var saved *model.PmcsSbsFaults
+	err := dbtx.Run(ctx, repo.db, func(ctx context.Context) error {
+		tx := dbtx.From(ctx, repo.db)
+		savedInspection, err := ensureInspection(ctx, tx, inspection)
+		if err != nil {
+			return err
+		}
+		saved, err = upsertFault(ctx, tx, savedInspection.ID, fault)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	return saved, nil
+}
 
+// upsertFault records fault on inspection pmcsID, replacing the fault at the
+// same section and item.
+func upsertFault(ctx context.Context, queryable qrm.Queryable, pmcsID uuid.UUID, fault model.PmcsSbsFaults) (*model.PmcsSbsFaults, error) {
 	now := time.Now().UTC()
-	fault.PmcsID = savedInspection.ID
+	fault.PmcsID = pmcsID
 	if fault.CreatedAt.IsZero() {
 		fault.CreatedAt = now
 	}
@@ -265,21 +275,17 @@ func (repo *RepositoryImpl) UpsertFault(user *bootstrap.User, inspection model.P
 	)).RETURNING(PmcsSbsFaults.AllColumns)
 
 	var saved model.PmcsSbsFaults
-	if err := stmt.Query(tx, &saved); err != nil {
+	if err := stmt.QueryContext(ctx, queryable, &saved); err != nil {
 		return nil, fmt.Errorf("upsert pmcs sbs fault: %w", err)
 	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("commit upsert pmcs sbs fault transaction: %w", err)
-	}
 	return &saved, nil
 }
 
-func (repo *RepositoryImpl) DeleteFault(user *bootstrap.User, equipmentID string, key FaultKey) error {
-	if err := repo.requireVehicleAccess(user, equipmentID); err != nil {
+func (repo *RepositoryImpl) DeleteFault(ctx context.Context, user *bootstrap.User, equipmentID string, key FaultKey) error {
+	if err := repo.requireVehicleAccess(ctx, user, equipmentID); err != nil {
 		return err
 	}
-	if err := repo.requireInspectionOwnership(repo.db, equipmentID, key.PmcsID); err != nil {
+	if err := repo.requireInspectionOwnership(ctx, repo.db, equipmentID, key.PmcsID); err != nil {
 		return err
 	}
 
@@ -289,17 +295,17 @@ func (repo *RepositoryImpl) DeleteFault(user *bootstrap.User, equipmentID string
 				AND(PmcsSbsFaults.SectionID.EQ(String(key.SectionID))).
 				AND(PmcsSbsFaults.ItemIndex.EQ(Int32(key.ItemIndex))),
 		).
-		Exec(repo.db); err != nil {
+		ExecContext(ctx, repo.db); err != nil {
 		return fmt.Errorf("delete pmcs sbs fault: %w", err)
 	}
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteFaults(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, keys []FaultKey) (int64, error) {
-	if err := repo.requireVehicleAccess(user, equipmentID); err != nil {
+func (repo *RepositoryImpl) DeleteFaults(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, keys []FaultKey) (int64, error) {
+	if err := repo.requireVehicleAccess(ctx, user, equipmentID); err != nil {
 		return 0, err
 	}
-	if err := repo.requireInspectionOwnership(repo.db, equipmentID, pmcsID); err != nil {
+	if err := repo.requireInspectionOwnership(ctx, repo.db, equipmentID, pmcsID); err != nil {
 		return 0, err
 	}
 	if len(keys) == 0 {
@@ -316,7 +322,7 @@ func (repo *RepositoryImpl) DeleteFaults(user *bootstrap.User, equipmentID strin
 			PmcsSbsFaults.PmcsID.EQ(UUID(pmcsID)).
 				AND(ROW(PmcsSbsFaults.SectionID, PmcsSbsFaults.ItemIndex).IN(keyRows...)),
 		).
-		Exec(repo.db)
+		ExecContext(ctx, repo.db)
 	if err != nil {
 		return 0, fmt.Errorf("bulk delete pmcs sbs faults: %w", err)
 	}
@@ -327,11 +333,11 @@ func (repo *RepositoryImpl) DeleteFaults(user *bootstrap.User, equipmentID strin
 	return deletedCount, nil
 }
 
-func (repo *RepositoryImpl) CreateComment(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, text string) (*CommentWithAuthor, error) {
-	if err := repo.requireVehicleAccess(user, equipmentID); err != nil {
+func (repo *RepositoryImpl) CreateComment(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, text string) (*CommentWithAuthor, error) {
+	if err := repo.requireVehicleAccess(ctx, user, equipmentID); err != nil {
 		return nil, err
 	}
-	if err := repo.requireInspectionOwnership(repo.db, equipmentID, pmcsID); err != nil {
+	if err := repo.requireInspectionOwnership(ctx, repo.db, equipmentID, pmcsID); err != nil {
 		return nil, err
 	}
 
@@ -346,7 +352,7 @@ func (repo *RepositoryImpl) CreateComment(user *bootstrap.User, equipmentID stri
 	).RETURNING(PmcsSbsInspectionComments.AllColumns)
 
 	var created model.PmcsSbsInspectionComments
-	if err := stmt.Query(repo.db, &created); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &created); err != nil {
 		return nil, fmt.Errorf("create pmcs sbs inspection comment: %w", err)
 	}
 
@@ -354,7 +360,7 @@ func (repo *RepositoryImpl) CreateComment(user *bootstrap.User, equipmentID stri
 	return &CommentWithAuthor{PmcsSbsInspectionComments: created, AuthorUsername: &username}, nil
 }
 
-func (repo *RepositoryImpl) GetComment(commentID uuid.UUID) (*CommentWithAuthor, error) {
+func (repo *RepositoryImpl) GetComment(ctx context.Context, commentID uuid.UUID) (*CommentWithAuthor, error) {
 	var row struct {
 		model.PmcsSbsInspectionComments
 		AuthorUsername *string `sql:"author_username"`
@@ -366,7 +372,7 @@ func (repo *RepositoryImpl) GetComment(commentID uuid.UUID) (*CommentWithAuthor,
 		FROM(PmcsSbsInspectionComments.LEFT_JOIN(Users, Users.UID.EQ(PmcsSbsInspectionComments.AuthorID))).
 		WHERE(PmcsSbsInspectionComments.ID.EQ(UUID(commentID)))
 
-	if err := stmt.Query(repo.db, &row); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &row); err != nil {
 		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, qrm.ErrNoRows) {
 			return nil, ErrCommentNotFound
 		}
@@ -375,7 +381,7 @@ func (repo *RepositoryImpl) GetComment(commentID uuid.UUID) (*CommentWithAuthor,
 	return &CommentWithAuthor{PmcsSbsInspectionComments: row.PmcsSbsInspectionComments, AuthorUsername: row.AuthorUsername}, nil
 }
 
-func (repo *RepositoryImpl) UpdateComment(commentID uuid.UUID, text string) (*CommentWithAuthor, error) {
+func (repo *RepositoryImpl) UpdateComment(ctx context.Context, commentID uuid.UUID, text string) (*CommentWithAuthor, error) {
 	now := time.Now().UTC()
 
 	stmt := PmcsSbsInspectionComments.UPDATE().
@@ -387,21 +393,21 @@ func (repo *RepositoryImpl) UpdateComment(commentID uuid.UUID, text string) (*Co
 		RETURNING(PmcsSbsInspectionComments.AllColumns)
 
 	var updated model.PmcsSbsInspectionComments
-	if err := stmt.Query(repo.db, &updated); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &updated); err != nil {
 		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, qrm.ErrNoRows) {
 			return nil, ErrCommentNotFound
 		}
 		return nil, fmt.Errorf("update pmcs sbs inspection comment: %w", err)
 	}
 
-	username, err := repo.LookupUsername(updated.AuthorID)
+	username, err := repo.LookupUsername(ctx, updated.AuthorID)
 	if err != nil {
 		return nil, err
 	}
 	return &CommentWithAuthor{PmcsSbsInspectionComments: updated, AuthorUsername: username}, nil
 }
 
-func (repo *RepositoryImpl) requireVehicleAccess(user *bootstrap.User, equipmentID string) error {
+func (repo *RepositoryImpl) requireVehicleAccess(ctx context.Context, user *bootstrap.User, equipmentID string) error {
 	if user == nil {
 		return ErrUnauthorized
 	}
@@ -420,7 +426,7 @@ func (repo *RepositoryImpl) requireVehicleAccess(user *bootstrap.User, equipment
 	var rows []struct {
 		Exists int `sql:"exists"`
 	}
-	if err := stmt.Query(repo.db, &rows); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &rows); err != nil {
 		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, qrm.ErrNoRows) {
 			return ErrNotFound
 		}
@@ -432,7 +438,22 @@ func (repo *RepositoryImpl) requireVehicleAccess(user *bootstrap.User, equipment
 	return nil
 }
 
-func (repo *RepositoryImpl) requireInspectionOwnership(queryable qrm.Queryable, equipmentID string, pmcsID uuid.UUID) error {
+func (repo *RepositoryImpl) GetVehicleShopID(ctx context.Context, equipmentID string) (string, error) {
+	stmt := SELECT(ShopVehicle.ShopID).
+		FROM(ShopVehicle).
+		WHERE(ShopVehicle.ID.EQ(String(equipmentID)))
+
+	var vehicle model.ShopVehicle
+	if err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &vehicle); err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("get pmcs sbs vehicle shop: %w", err)
+	}
+	return vehicle.ShopID, nil
+}
+
+func (repo *RepositoryImpl) requireInspectionOwnership(ctx context.Context, queryable qrm.Queryable, equipmentID string, pmcsID uuid.UUID) error {
 	stmt := SELECT(Int(1).AS("exists")).
 		FROM(PmcsSbsInspections).
 		WHERE(
@@ -444,7 +465,7 @@ func (repo *RepositoryImpl) requireInspectionOwnership(queryable qrm.Queryable,
 	var rows []struct {
 		Exists int `sql:"exists"`
 	}
-	if err := stmt.Query(queryable, &rows); err != nil {
+	if err := stmt.QueryContext(ctx, queryable, &rows); err != nil {
 		return fmt.Errorf("authorize pmcs sbs inspection access: %w", err)
 	}
 	if len(rows) == 0 {
@@ -453,7 +474,7 @@ func (repo *RepositoryImpl) requireInspectionOwnership(queryable qrm.Queryable,
 	return nil
 }
 
-func (repo *RepositoryImpl) LookupUsername(userID string) (*string, error) {
+func (repo *RepositoryImpl) LookupUsername(ctx context.Context, userID string) (*string, error) {
 	var row struct {
 		Username *string `sql:"username"`
 	}
@@ -462,7 +483,7 @@ func (repo *RepositoryImpl) LookupUsername(userID string) (*string, error) {
 		WHERE(Users.UID.EQ(String(userID))).
 		LIMIT(1)
 
-	if err := stmt.Query(repo.db, &row); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &row); err != nil {
 		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, qrm.ErrNoRows) {
 			return nil, nil
 		}
@@ -477,7 +498,7 @@ func (repo *RepositoryImpl) LookupUsername(userID string) (*string, error) {
 // ErrInspectionConflict. queryable is either *sql.DB (standalone calls) or
 // *sql.Tx (the implicit-creation path inside UpsertFault) — both satisfy
 // qrm.Queryable.
-func ensureInspection(queryable qrm.Queryable, inspection model.PmcsSbsInspections) (*model.PmcsSbsInspections, error) {
+func ensureInspection(ctx context.Context, queryable qrm.Queryable, inspection model.PmcsSbsInspections) (*model.PmcsSbsInspections, error) {
 	now := time.Now().UTC()
 	var performedByExpr Expression = NULL
 	if inspection.PerformedBy != nil {
@@ -518,7 +539,7 @@ func ensureInspection(queryable qrm.Queryable, inspection model.PmcsSbsInspectio
 	).RETURNING(PmcsSbsInspections.AllColumns)
 
 	var saved model.PmcsSbsInspections
-	if err := stmt.Query(queryable, &saved); err != nil {
+	if err := stmt.QueryContext(ctx, queryable, &saved); err != nil {
 		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, qrm.ErrNoRows) {
 			return nil, ErrInspectionConflict
 		}
@@ -2,18 +2,19 @@ package pmcs_sbs_progress
 
 import (
 	"database/sql"
-	"errors"
-	"log/slog"
 	"net/http"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Dependencies struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Events *events.Bus
 }
 
 type Handler struct {
@@ -22,7 +23,7 @@ type Handler struct {
 
 func RegisterRoutes(deps Dependencies, group *gin.RouterGroup) {
 	repo := NewRepository(deps.DB)
-	svc := NewService(repo)
+	svc := NewService(repo, deps.Events)
 	registerHandlers(group, svc)
 }
 
@@ -49,11 +50,11 @@ func (handler Handler) upsertInspection(c *gin.Context) {
 
 	var req InspectionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	result, err := handler.service.EnsureInspection(user, c.Param("equipment_id"), c.Param("pmcs_id"), req)
+	result, err := handler.service.EnsureInspection(c.Request.Context(), user, c.Param("equipment_id"), c.Param("pmcs_id"), req)
 	if err != nil {
 		respondServiceError(c, err)
 		return
@@ -68,7 +69,7 @@ func (handler Handler) getInspection(c *gin.Context) {
 		return
 	}
 
-	result, err := handler.service.GetInspection(user, c.Param("equipment_id"), c.Param("pmcs_id"))
+	result, err := handler.service.GetInspection(c.Request.Context(), user, c.Param("equipment_id"), c.Param("pmcs_id"))
 	if err != nil {
 		respondServiceError(c, err)
 		return
@@ -83,7 +84,7 @@ func (handler Handler) deleteInspection(c *gin.Context) {
 		return
 	}
 
-	if err := handler.service.DeleteInspection(user, c.Param("equipment_id"), c.Param("pmcs_id")); err != nil {
+	if err := handler.service.DeleteInspection(c.Request.Context(), user, c.Param("equipment_id"), c.Param("pmcs_id")); err != nil {
 		respondServiceError(c, err)
 		return
 	}
@@ -99,11 +100,11 @@ func (handler Handler) listInspections(c *gin.Context) {
 
 	var req ListInspectionsRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid query parameters"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	result, err := handler.service.ListInspections(user, c.Param("equipment_id"), req)
+	result, err := handler.service.ListInspections(c.Request.Context(), user, c.Param("equipment_id"), req)
 	if err != nil {
 		respondServiceError(c, err)
 		return
@@ -120,11 +121,11 @@ func (handler Handler) upsertFault(c *gin.Context) {
 
 	var req FaultRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	result, err := handler.service.UpsertFault(user, c.Param("equipment_id"), c.Param("pmcs_id"), req)
+	result, err := handler.service.UpsertFault(c.Request.Context(), user, c.Param("equipment_id"), c.Param("pmcs_id"), req)
 	if err != nil {
 		respondServiceError(c, err)
 		return
@@ -141,11 +142,11 @@ func (handler Handler) deleteFault(c *gin.Context) {
 
 	var req DeleteFaultRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	if err := handler.service.DeleteFault(user, c.Param("equipment_id"), c.Param("pmcs_id"), req); err != nil {
+	if err := handler.service.DeleteFault(c.Request.Context(), user, c.Param("equipment_id"), c.Param("pmcs_id"), req); err != nil {
 		respondServiceError(c, err)
 		return
 	}
@@ -161,11 +162,11 @@ func (handler Handler) deleteFaults(c *gin.Context) {
 
 	var req BulkDeleteFaultRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	result, err := handler.service.DeleteFaults(user, c.Param("equipment_id"), c.Param("pmcs_id"), req)
+	result, err := handler.service.DeleteFaults(c.Request.Context(), user, c.Param("equipment_id"), c.Param("pmcs_id"), req)
 	if err != nil {
 		respondServiceError(c, err)
 		return
@@ -186,11 +187,11 @@ func (handler Handler) createComment(c *gin.Context) {
 
 	var req CreateCommentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	result, err := handler.service.CreateComment(user, c.Param("equipment_id"), c.Param("pmcs_id"), req)
+	result, err := handler.service.CreateComment(c.Request.Context(), user, c.Param("equipment_id"), c.Param("pmcs_id"), req)
 	if err != nil {
 		respondServiceError(c, err)
 		return
@@ -207,11 +208,11 @@ func (handler Handler) updateComment(c *gin.Context) {
 
 	var req UpdateCommentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	result, err := handler.service.UpdateComment(user, c.Param("comment_id"), req)
+	result, err := handler.service.UpdateComment(c.Request.Context(), user, c.Param("comment_id"), req)
 	if err != nil {
 		respondServiceError(c, err)
 		return
@@ -226,7 +227,7 @@ func (handler Handler) deleteComment(c *gin.Context) {
 		return
 	}
 
-	result, err := handler.service.DeleteComment(user, c.Param("comment_id"))
+	result, err := handler.service.DeleteComment(c.Request.Context(), user, c.Param("comment_id"))
 	if err != nil {
 		respondServiceError(c, err)
 		return
@@ -238,13 +239,13 @@ func (handler Handler) deleteComment(c *gin.Context) {
 func getUser(c *gin.Context) (*bootstrap.User, bool) {
 	value, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return nil, false
 	}
 
 	user, ok := value.(*bootstrap.User)
 	if !ok || user == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return nil, false
 	}
 
@@ -252,28 +253,5 @@ func getUser(c *gin.Context) (*bootstrap.User, bool) {
 }
 
 func respondServiceError(c *gin.Context, err error) {
-	switch {
-	case errors.Is(err, ErrUnauthorized):
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
-	case errors.Is(err, ErrInvalidID),
-		errors.Is(err, ErrInvalidPmcsID),
-		errors.Is(err, ErrInvalidGuideManual),
-		errors.Is(err, ErrInvalidRequest),
-		errors.Is(err, ErrInvalidStatus),
-		errors.Is(err, ErrInvalidCommentText):
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
-	case errors.Is(err, ErrInspectionConflict):
-		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
-	case errors.Is(err, ErrForbidden):
-		c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
-	case errors.Is(err, ErrNotFound):
-		c.JSON(http.StatusNotFound, gin.H{"message": "pmcs sbs equipment not found"})
-	case errors.Is(err, ErrInspectionNotFound):
-		c.JSON(http.StatusNotFound, gin.H{"message": "pmcs sbs inspection not found"})
-	case errors.Is(err, ErrCommentNotFound):
-		c.JSON(http.StatusNotFound, gin.H{"message": "pmcs sbs comment not found"})
-	default:
-		slog.Error("PMCS SBS fault handler failed", "error", err)
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-	}
+	c.Error(err)
 }
@@ -2,6 +2,7 @@ package pmcs_sbs_progress
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"miltechserver/api/middleware"
 	"miltechserver/bootstrap"
 
 	"github.com/gin-gonic/gin"
@@ -32,7 +34,7 @@ type serviceStub struct {
 	capturedRequest     interface{}
 }
 
-func (s *serviceStub) EnsureInspection(user *bootstrap.User, equipmentID string, pmcsID string, req InspectionRequest) (*InspectionResponse, error) {
+func (s *serviceStub) EnsureInspection(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req InspectionRequest) (*InspectionResponse, error) {
 	s.capturedUser = user
 	s.capturedEquipmentID = equipmentID
 	s.capturedPmcsID = pmcsID
@@ -40,28 +42,28 @@ func (s *serviceStub) EnsureInspection(user *bootstrap.User, equipmentID string,
 	return s.inspectionResp, s.err
 }
 
-func (s *serviceStub) GetInspection(user *bootstrap.User, equipmentID string, pmcsID string) (*InspectionResponse, error) {
+func (s *serviceStub) GetInspection(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID string) (*InspectionResponse, error) {
 	s.capturedUser = user
 	s.capturedEquipmentID = equipmentID
 	s.capturedPmcsID = pmcsID
 	return s.inspectionResp, s.err
 }
 
-func (s *serviceStub) ListInspections(user *bootstrap.User, equipmentID string, req ListInspectionsRequest) (*InspectionListResponse, error) {
+func (s *serviceStub) ListInspections(_ context.Context, user *bootstrap.User, equipmentID string, req ListInspectionsRequest) (*InspectionListResponse, error) {
 	s.capturedUser = user
 	s.capturedEquipmentID = equipmentID
 	s.capturedRequest = req
 	return s.listResp, s.err
 }
 
-func (s *serviceStub) DeleteInspection(user *bootstrap.User, equipmentID string, pmcsID string) error {
+func (s *serviceStub) DeleteInspection(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID string) error {
 	s.capturedUser = user
 	s.capturedEquipmentID = equipmentID
 	s.capturedPmcsID = pmcsID
 	return s.err
 }
 
-func (s *serviceStub) UpsertFault(user *bootstrap.User, equipmentID string, pmcsID string, req FaultRequest) (*FaultResponse, error) {
+func (s *serviceStub) UpsertFault(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req FaultRequest) (*FaultResponse, error) {
 	s.capturedUser = user
 	s.capturedEquipmentID = equipmentID
 	s.capturedPmcsID = pmcsID
@@ -69,7 +71,7 @@ func (s *serviceStub) UpsertFault(user *bootstrap.User, equipmentID string, pmcs
 	return s.faultResp, s.err
 }
 
-func (s *serviceStub) DeleteFault(user *bootstrap.User, equipmentID string, pmcsID string, req DeleteFaultRequest) error {
+func (s *serviceStub) DeleteFault(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req DeleteFaultRequest) error {
 	s.capturedUser = user
 	s.capturedEquipmentID = equipmentID
 	s.capturedPmcsID = pmcsID
@@ -77,7 +79,7 @@ func (s *serviceStub) DeleteFault(user *bootstrap.User, equipmentID string, pmcs
 	return s.err
 }
 
-func (s *serviceStub) DeleteFaults(user *bootstrap.User, equipmentID string, pmcsID string, req BulkDeleteFaultRequest) (*BulkDeleteFaultResponse, error) {
+func (s *serviceStub) DeleteFaults(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req BulkDeleteFaultRequest) (*BulkDeleteFaultResponse, error) {
 	s.capturedUser = user
 	s.capturedEquipmentID = equipmentID
 	s.capturedPmcsID = pmcsID
@@ -85,7 +87,7 @@ func (s *serviceStub) DeleteFaults(user *bootstrap.User, equipmentID string, pmc
 	return s.bulkDeleteResp, s.err
 }
 
-func (s *serviceStub) CreateComment(user *bootstrap.User, equipmentID string, pmcsID string, req CreateCommentRequest) (*CommentResponse, error) {
+func (s *serviceStub) CreateComment(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req CreateCommentRequest) (*CommentResponse, error) {
 	s.capturedUser = user
 	s.capturedEquipmentID = equipmentID
 	s.capturedPmcsID = pmcsID
@@ -93,14 +95,14 @@ func (s *serviceStub) CreateComment(user *bootstrap.User, equipmentID string, pm
 	return s.commentResp, s.err
 }
 
-func (s *serviceStub) UpdateComment(user *bootstrap.User, commentID string, req UpdateCommentRequest) (*CommentResponse, error) {
+func (s *serviceStub) UpdateComment(_ context.Context, user *bootstrap.User, commentID string, req UpdateCommentRequest) (*CommentResponse, error) {
 	s.capturedUser = user
 	s.capturedCommentID = commentID
 	s.capturedRequest = req
 	return s.commentResp, s.err
 }
 
-func (s *serviceStub) DeleteComment(user *bootstrap.User, commentID string) (*CommentResponse, error) {
+func (s *serviceStub) DeleteComment(_ context.Context, user *bootstrap.User, commentID string) (*CommentResponse, error) {
 	s.capturedUser = user
 	s.capturedCommentID = commentID
 	return s.commentResp, s.err
@@ -128,6 +130,7 @@ func TestHandlersRejectInvalidAuthContext(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			router := gin.New()
+			router.Use(middleware.ErrorHandler)
 			group := router.Group("/api/v1/auth")
 			group.Use(func(c *gin.Context) {
 				c.Set("user", tc.value)
@@ -348,6 +351,7 @@ func TestServiceErrorMapping(t *testing.T) {
 func newRouteTestRouter(stub Service) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	group := router.Group("/api/v1/auth")
 	group.Use(func(c *gin.Context) {
 		userID := c.GetHeader("X-User-ID")
@@ -1,18 +1,21 @@
 package pmcs_sbs_progress
 
-import "miltechserver/bootstrap"
+import (
+	"context"
+	"miltechserver/bootstrap"
+)
 
 type Service interface {
-	EnsureInspection(user *bootstrap.User, equipmentID string, pmcsID string, req InspectionRequest) (*InspectionResponse, error)
-	GetInspection(user *bootstrap.User, equipmentID string, pmcsID string) (*InspectionResponse, error)
-	ListInspections(user *bootstrap.User, equipmentID string, req ListInspectionsRequest) (*InspectionListResponse, error)
-	DeleteInspection(user *bootstrap.User, equipmentID string, pmcsID string) error
+	EnsureInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req InspectionRequest) (*InspectionResponse, error)
+	GetInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string) (*InspectionResponse, error)
+	ListInspections(ctx context.Context, user *bootstrap.User, equipmentID string, req ListInspectionsRequest) (*InspectionListResponse, error)
+	DeleteInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string) error
 
-	UpsertFault(user *bootstrap.User, equipmentID string, pmcsID string, req FaultRequest) (*FaultResponse, error)
-	DeleteFault(user *bootstrap.User, equipmentID string, pmcsID string, req DeleteFaultRequest) error
-	DeleteFaults(user *bootstrap.User, equipmentID string, pmcsID string, req BulkDeleteFaultRequest) (*BulkDeleteFaultResponse, error)
+	UpsertFault(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req FaultRequest) (*FaultResponse, error)
+	DeleteFault(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req DeleteFaultRequest) error
+	DeleteFaults(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req BulkDeleteFaultRequest) (*BulkDeleteFaultResponse, error)
 
-	CreateComment(user *bootstrap.User, equipmentID string, pmcsID string, req CreateCommentRequest) (*CommentResponse, error)
-	UpdateComment(user *bootstrap.User, commentID string, req UpdateCommentRequest) (*CommentResponse, error)
-	DeleteComment(user *bootstrap.User, commentID string) (*CommentResponse, error)
+	CreateComment(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req CreateCommentRequest) (*CommentResponse, error)
+	UpdateComment(ctx context.Context, user *bootstrap.User, commentID string, req UpdateCommentRequest) (*CommentResponse, error)
+	DeleteComment(ctx context.Context, user *bootstrap.User, commentID string) (*CommentResponse, error)
 }
@@ -1,6 +1,7 @@
 package pmcs_sbs_progress
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strings"
@@ -8,16 +9,18 @@ import (
 
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 
 	"github.com/google/uuid"
 )
 
 type ServiceImpl struct {
 	repository Repository
+	events     events.Publisher
 }
 
-func NewService(repository Repository) *ServiceImpl {
-	return &ServiceImpl{repository: repository}
+func NewService(repository Repository, publisher events.Publisher) *ServiceImpl {
+	return &ServiceImpl{repository: repository, events: publisher}
 }
 
 const maxBulkDeleteFaults = 100
@@ -26,7 +29,7 @@ const maxNotesLength = 4000
 const maxCommentTextLength = 2000
 const deletedCommentText = "Deleted by user"
 
-func (service *ServiceImpl) EnsureInspection(user *bootstrap.User, equipmentID string, pmcsID string, req InspectionRequest) (*InspectionResponse, error) {
+func (service *ServiceImpl) EnsureInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req InspectionRequest) (*InspectionResponse, error) {
 	if !hasAuthenticatedUser(user) {
 		return nil, ErrUnauthorized
 	}
@@ -34,11 +37,11 @@ func (service *ServiceImpl) EnsureInspection(user *bootstrap.User, equipmentID s
 	if err != nil {
 		return nil, err
 	}
-	saved, err := service.repository.EnsureInspection(user, inspection)
+	saved, err := service.repository.EnsureInspection(ctx, user, inspection)
 	if err != nil {
 		return nil, err
 	}
-	performedByUsername, err := service.resolvePerformedByUsername(user, saved.PerformedBy)
+	performedByUsername, err := service.resolvePerformedByUsername(ctx, user, saved.PerformedBy)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +54,7 @@ func (service *ServiceImpl) EnsureInspection(user *bootstrap.User, equipmentID s
 // already on the auth token (bootstrap.User.Username). Only when a save
 // touches an inspection whose sticky owner is a *different* user does this
 // fall back to a single-row lookup.
-func (service *ServiceImpl) resolvePerformedByUsername(user *bootstrap.User, performedBy *string) (*string, error) {
+func (service *ServiceImpl) resolvePerformedByUsername(ctx context.Context, user *bootstrap.User, performedBy *string) (*string, error) {
 	if performedBy == nil {
 		return nil, nil
 	}
@@ -59,10 +62,10 @@ func (service *ServiceImpl) resolvePerformedByUsername(user *bootstrap.User, per
 		username := user.Username
 		return &username, nil
 	}
-	return service.repository.LookupUsername(*performedBy)
+	return service.repository.LookupUsername(ctx, *performedBy)
 }
 
-func (service *ServiceImpl) GetInspection(user *bootstrap.User, equipmentID string, pmcsID string) (*InspectionResponse, error) {
+func (service *ServiceImpl) GetInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string) (*InspectionResponse, error) {
 	if !hasAuthenticatedUser(user) {
 		return nil, ErrUnauthorized
 	}
@@ -75,7 +78,7 @@ func (service *ServiceImpl) GetInspection(user *bootstrap.User, equipmentID stri
 		return nil, err
 	}
 
-	detail, faults, comments, err := service.repository.GetInspection(user, trimmedEquipmentID, parsedPmcsID)
+	detail, faults, comments, err := service.repository.GetInspection(ctx, user, trimmedEquipmentID, parsedPmcsID)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +86,7 @@ func (service *ServiceImpl) GetInspection(user *bootstrap.User, equipmentID stri
 	return &resp, nil
 }
 
-func (service *ServiceImpl) ListInspections(user *bootstrap.User, equipmentID string, req ListInspectionsRequest) (*InspectionListResponse, error) {
+func (service *ServiceImpl) ListInspections(ctx context.Context, user *bootstrap.User, equipmentID string, req ListInspectionsRequest) (*InspectionListResponse, error) {
 	if !hasAuthenticatedUser(user) {
 		return nil, ErrUnauthorized
 	}
@@ -109,7 +112,7 @@ func (service *ServiceImpl) ListInspections(user *bootstrap.User, equipmentID st
 		offset = 0
 	}
 
-	summaries, err := service.repository.ListInspections(user, trimmedEquipmentID, guideManual, limit, offset)
+	summaries, err := service.repository.ListInspections(ctx, user, trimmedEquipmentID, guideManual, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +132,7 @@ func (service *ServiceImpl) ListInspections(user *bootstrap.User, equipmentID st
 	return &InspectionListResponse{Inspections: responses, Count: len(responses)}, nil
 }
 
-func (service *ServiceImpl) DeleteInspection(user *bootstrap.User, equipmentID string, pmcsID string) error {
+func (service *ServiceImpl) DeleteInspection(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string) error {
 	if !hasAuthenticatedUser(user) {
 		return ErrUnauthorized
 	}
@@ -141,10 +144,10 @@ func (service *ServiceImpl) DeleteInspection(user *bootstrap.User, equipmentID s
 	if err != nil {
 		return err
 	}
-	return service.repository.DeleteInspection(user, trimmedEquipmentID, parsedPmcsID)
+	return service.repository.DeleteInspection(ctx, user, trimmedEquipmentID, parsedPmcsID)
 }
 
-func (service *ServiceImpl) UpsertFault(user *bootstrap.User, equipmentID string, pmcsID string, req FaultRequest) (*FaultResponse, error) {
+func (service *ServiceImpl) UpsertFault(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req FaultRequest) (*FaultResponse, error) {
 	if !hasAuthenticatedUser(user) {
 		return nil, ErrUnauthorized
 	}
@@ -152,7 +155,26 @@ func (service *ServiceImpl) UpsertFault(user *bootstrap.User, equipmentID string
 	if err != nil {
 		return nil, err
 	}
-	saved, err := service.repository.UpsertFault(user, inspection, fault)
+	var saved *model.PmcsSbsFaults
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		saved, err = service.repository.UpsertFault(ctx, user, inspection, fault)
+		if err != nil {
+			return err
+		}
+		shopID, err := service.repository.GetVehicleShopID(ctx, inspection.EquipmentID)
+		if err != nil {
+			return err
+		}
+		return service.events.Publish(ctx, events.PMCSFaultRecorded{
+			ShopID:      shopID,
+			EquipmentID: inspection.EquipmentID,
+			PmcsID:      saved.PmcsID.String(),
+			SectionID:   saved.SectionID,
+			ItemIndex:   saved.ItemIndex,
+			Status:      saved.Status,
+			RecordedBy:  user.UserID,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +182,7 @@ func (service *ServiceImpl) UpsertFault(user *bootstrap.User, equipmentID string
 	return &resp, nil
 }
 
-func (service *ServiceImpl) DeleteFault(user *bootstrap.User, equipmentID string, pmcsID string, req DeleteFaultRequest) error {
+func (service *ServiceImpl) DeleteFault(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req DeleteFaultRequest) error {
 	if !hasAuthenticatedUser(user) {
 		return ErrUnauthorized
 	}
@@ -172,10 +194,10 @@ func (service *ServiceImpl) DeleteFault(user *bootstrap.User, equipmentID string
 	if err != nil {
 		return err
 	}
-	return service.repository.DeleteFault(user, trimmedEquipmentID, key)
+	return service.repository.DeleteFault(ctx, user, trimmedEquipmentID, key)
 }
 
-func (service *ServiceImpl) DeleteFaults(user *bootstrap.User, equipmentID string, pmcsID string, req BulkDeleteFaultRequest) (*BulkDeleteFaultResponse, error) {
+func (service *ServiceImpl) DeleteFaults(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req BulkDeleteFaultRequest) (*BulkDeleteFaultResponse, error) {
 	if !hasAuthenticatedUser(user) {
 		return nil, ErrUnauthorized
 	}
@@ -183,14 +205,14 @@ func (service *ServiceImpl) DeleteFaults(user *bootstrap.User, equipmentID strin
 	if err != nil {
 		return nil, err
 	}
-	deletedCount, err := service.repository.DeleteFaults(user, trimmedEquipmentID, parsedPmcsID, keys)
+	deletedCount, err := service.repository.DeleteFaults(ctx, user, trimmedEquipmentID, parsedPmcsID, keys)
 	if err != nil {
 		return nil, err
 	}
 	return &BulkDeleteFaultResponse{RequestedCount: len(keys), DeletedCount: int(deletedCount)}, nil
 }
 
-func (service *ServiceImpl) CreateComment(user *bootstrap.User, equipmentID string, pmcsID string, req CreateCommentRequest) (*CommentResponse, error) {
+func (service *ServiceImpl) CreateComment(ctx context.Context, user *bootstrap.User, equipmentID string, pmcsID string, req CreateCommentRequest) (*CommentResponse, error) {
 	if !hasAuthenticatedUser(user) {
 		return nil, ErrUnauthorized
 	}
@@ -207,7 +229,7 @@ func (service *ServiceImpl) CreateComment(user *bootstrap.User, equipmentID stri
 		return nil, err
 	}
 
-	created, err := service.repository.CreateComment(user, trimmedEquipmentID, parsedPmcsID, text)
+	created, err := service.repository.CreateComment(ctx, user, trimmedEquipmentID, parsedPmcsID, text)
 	if err != nil {
 		return nil, err
 	}
@@ -215,7 +237,7 @@ func (service *ServiceImpl) CreateComment(user *bootstrap.User, equipmentID stri
 	return &resp, nil
 }
 
-func (service *ServiceImpl) UpdateComment(user *bootstrap.User, commentID string, req UpdateCommentRequest) (*CommentResponse, error) {
+func (service *ServiceImpl) UpdateComment(ctx context.Context, user *bootstrap.User, commentID string, req UpdateCommentRequest) (*CommentResponse, error) {
 	if !hasAuthenticatedUser(user) {
 		return nil, ErrUnauthorized
 	}
@@ -228,7 +250,7 @@ func (service *ServiceImpl) UpdateComment(user *bootstrap.User, commentID string
 		return nil, err
 	}
 
-	existing, err := service.repository.GetComment(parsedCommentID)
+	existing, err := service.repository.GetComment(ctx, parsedCommentID)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +258,7 @@ func (service *ServiceImpl) UpdateComment(user *bootstrap.User, commentID string
 		return nil, ErrForbidden
 	}
 
-	updated, err := service.repository.UpdateComment(parsedCommentID, text)
+	updated, err := service.repository.UpdateComment(ctx, parsedCommentID, text)
 	if err != nil {
 		return nil, err
 	}
@@ -244,7 +266,7 @@ func (service *ServiceImpl) UpdateComment(user *bootstrap.User, commentID string
 	return &resp, nil
 }
 
-func (service *ServiceImpl) DeleteComment(user *bootstrap.User, commentID string) (*CommentResponse, error) {
+func (service *ServiceImpl) DeleteComment(ctx context.Context, user *bootstrap.User, commentID string) (*CommentResponse, error) {
 	if !hasAuthenticatedUser(user) {
 		return nil, ErrUnauthorized
 	}
@@ -253,7 +275,7 @@ func (service *ServiceImpl) DeleteComment(user *bootstrap.User, commentID string
 		return nil, ErrCommentNotFound
 	}
 
-	existing, err := service.repository.GetComment(parsedCommentID)
+	existing, err := service.repository.GetComment(ctx, parsedCommentID)
 	if err != nil {
 		return nil, err
 	}
@@ -261,7 +283,7 @@ func (service *ServiceImpl) DeleteComment(user *bootstrap.User, commentID string
 		return nil, ErrForbidden
 	}
 
-	updated, err := service.repository.UpdateComment(parsedCommentID, deletedCommentText)
+	updated, err := service.repository.UpdateComment(ctx, parsedCommentID, deletedCommentText)
 	if err != nil {
 		return nil, err
 	}
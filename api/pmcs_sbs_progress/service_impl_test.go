@@ -1,6 +1,7 @@
 package pmcs_sbs_progress
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -8,6 +9,7 @@ import (
 
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -20,6 +22,7 @@ type repoStub struct {
 	comments       []CommentWithAuthor
 	summaries      []InspectionSummary
 	savedFault     *model.PmcsSbsFaults
+	vehicleShopID  string
 	deletedCount   int64
 	err            error
 
@@ -46,7 +49,7 @@ type repoStub struct {
 	capturedCommentText      string
 }
 
-func (repo *repoStub) EnsureInspection(user *bootstrap.User, inspection model.PmcsSbsInspections) (*model.PmcsSbsInspections, error) {
+func (repo *repoStub) EnsureInspection(_ context.Context, user *bootstrap.User, inspection model.PmcsSbsInspections) (*model.PmcsSbsInspections, error) {
 	repo.capturedUser = user
 	repo.capturedInspection = inspection
 	if repo.inspection != nil {
@@ -55,7 +58,7 @@ func (repo *repoStub) EnsureInspection(user *bootstrap.User, inspection model.Pm
 	return &inspection, repo.err
 }
 
-func (repo *repoStub) GetInspection(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) (*InspectionDetail, []model.PmcsSbsFaults, []CommentWithAuthor, error) {
+func (repo *repoStub) GetInspection(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) (*InspectionDetail, []model.PmcsSbsFaults, []CommentWithAuthor, error) {
 	repo.capturedUser = user
 	repo.capturedEquipmentID = equipmentID
 	repo.capturedPmcsID = pmcsID
@@ -65,13 +68,13 @@ func (repo *repoStub) GetInspection(user *bootstrap.User, equipmentID string, pm
 	return &InspectionDetail{PmcsSbsInspections: *repo.inspection, PerformedByUsername: repo.detailUsername}, repo.faults, repo.comments, repo.err
 }
 
-func (repo *repoStub) LookupUsername(userID string) (*string, error) {
+func (repo *repoStub) LookupUsername(_ context.Context, userID string) (*string, error) {
 	repo.lookupUsernameCalls++
 	repo.capturedLookupUsernameID = userID
 	return repo.lookupUsernameResult, repo.lookupUsernameErr
 }
 
-func (repo *repoStub) ListInspections(user *bootstrap.User, equipmentID string, guideManual string, limit int, offset int) ([]InspectionSummary, error) {
+func (repo *repoStub) ListInspections(_ context.Context, user *bootstrap.User, equipmentID string, guideManual string, limit int, offset int) ([]InspectionSummary, error) {
 	repo.capturedUser = user
 	repo.capturedEquipmentID = equipmentID
 	repo.capturedGuideManual = guideManual
@@ -80,14 +83,14 @@ func (repo *repoStub) ListInspections(user *bootstrap.User, equipmentID string,
 	return repo.summaries, repo.err
 }
 
-func (repo *repoStub) DeleteInspection(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) error {
+func (repo *repoStub) DeleteInspection(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID) error {
 	repo.capturedUser = user
 	repo.capturedEquipmentID = equipmentID
 	repo.capturedPmcsID = pmcsID
 	return repo.err
 }
 
-func (repo *repoStub) UpsertFault(user *bootstrap.User, inspection model.PmcsSbsInspections, fault model.PmcsSbsFaults) (*model.PmcsSbsFaults, error) {
+func (repo *repoStub) UpsertFault(_ context.Context, user *bootstrap.User, inspection model.PmcsSbsInspections, fault model.PmcsSbsFaults) (*model.PmcsSbsFaults, error) {
 	repo.capturedUser = user
 	repo.capturedInspection = inspection
 	repo.capturedFault = fault
@@ -97,14 +100,19 @@ func (repo *repoStub) UpsertFault(user *bootstrap.User, inspection model.PmcsSbs
 	return &fault, repo.err
 }
 
-func (repo *repoStub) DeleteFault(user *bootstrap.User, equipmentID string, key FaultKey) error {
+func (repo *repoStub) GetVehicleShopID(_ context.Context, equipmentID string) (string, error) {
+	repo.capturedEquipmentID = equipmentID
+	return repo.vehicleShopID, repo.err
+}
+
+func (repo *repoStub) DeleteFault(_ context.Context, user *bootstrap.User, equipmentID string, key FaultKey) error {
 	repo.capturedUser = user
 	repo.capturedEquipmentID = equipmentID
 	repo.capturedDelete = key
 	return repo.err
 }
 
-func (repo *repoStub) DeleteFaults(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, keys []FaultKey) (int64, error) {
+func (repo *repoStub) DeleteFaults(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, keys []FaultKey) (int64, error) {
 	repo.capturedUser = user
 	repo.capturedEquipmentID = equipmentID
 	repo.capturedPmcsID = pmcsID
@@ -112,7 +120,7 @@ func (repo *repoStub) DeleteFaults(user *bootstrap.User, equipmentID string, pmc
 	return repo.deletedCount, repo.err
 }
 
-func (repo *repoStub) CreateComment(user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, text string) (*CommentWithAuthor, error) {
+func (repo *repoStub) CreateComment(_ context.Context, user *bootstrap.User, equipmentID string, pmcsID uuid.UUID, text string) (*CommentWithAuthor, error) {
 	repo.capturedUser = user
 	repo.capturedEquipmentID = equipmentID
 	repo.capturedPmcsID = pmcsID
@@ -125,12 +133,12 @@ func (repo *repoStub) CreateComment(user *bootstrap.User, equipmentID string, pm
 	}}, repo.err
 }
 
-func (repo *repoStub) GetComment(commentID uuid.UUID) (*CommentWithAuthor, error) {
+func (repo *repoStub) GetComment(_ context.Context, commentID uuid.UUID) (*CommentWithAuthor, error) {
 	repo.capturedCommentID = commentID
 	return repo.existingComment, repo.err
 }
 
-func (repo *repoStub) UpdateComment(commentID uuid.UUID, text string) (*CommentWithAuthor, error) {
+func (repo *repoStub) UpdateComment(_ context.Context, commentID uuid.UUID, text string) (*CommentWithAuthor, error) {
 	repo.capturedCommentID = commentID
 	repo.capturedCommentText = text
 	if repo.updatedComment != nil {
@@ -139,6 +147,20 @@ func (repo *repoStub) UpdateComment(commentID uuid.UUID, text string) (*CommentW
 	return &CommentWithAuthor{PmcsSbsInspectionComments: model.PmcsSbsInspectionComments{ID: commentID, Text: text}}, repo.err
 }
 
+// publisherStub runs transactions in place and records what is published.
+type publisherStub struct {
+	published []events.Event
+}
+
+func (pub *publisherStub) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (pub *publisherStub) Publish(_ context.Context, published ...events.Event) error {
+	pub.published = append(pub.published, published...)
+	return nil
+}
+
 func requireUser() *bootstrap.User {
 	return &bootstrap.User{UserID: "user-1", Email: "user-1@example.com", Username: "user-1"}
 }
@@ -156,15 +178,15 @@ func samplePmcsID() uuid.UUID {
 }
 
 func TestEnsureInspectionRequiresAuth(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 
-	_, err := svc.EnsureInspection(nil, "vehicle-1", samplePmcsIDStr, InspectionRequest{GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: time.Now()})
+	_, err := svc.EnsureInspection(context.Background(), nil, "vehicle-1", samplePmcsIDStr, InspectionRequest{GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: time.Now()})
 
 	requireServiceError(t, err, ErrUnauthorized)
 }
 
 func TestEnsureInspectionRejectsInvalidValues(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 	now := time.Now()
 
 	cases := []struct {
@@ -183,7 +205,7 @@ func TestEnsureInspectionRejectsInvalidValues(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := svc.EnsureInspection(requireUser(), tc.equipmentID, tc.pmcsID, tc.req)
+			_, err := svc.EnsureInspection(context.Background(), requireUser(), tc.equipmentID, tc.pmcsID, tc.req)
 			requireServiceError(t, err, tc.want)
 		})
 	}
@@ -198,9 +220,9 @@ func TestEnsureInspectionMapsResponse(t *testing.T) {
 		PerformedDate: time.Now().UTC(),
 		PerformedBy:   &performedBy,
 	}}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	resp, err := svc.EnsureInspection(requireUser(), "vehicle-1", samplePmcsIDStr, InspectionRequest{
+	resp, err := svc.EnsureInspection(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, InspectionRequest{
 		GuideManual:   "pmcs_sbs/hmmwv/file.json",
 		PerformedDate: time.Now(),
 	})
@@ -222,10 +244,10 @@ func TestEnsureInspectionResolvesPerformedByUsernameFromCallerWithoutLookup(t *t
 		PerformedDate: time.Now().UTC(),
 		PerformedBy:   &performedBy,
 	}}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 	user := &bootstrap.User{UserID: "user-1", Username: "jsmith"}
 
-	resp, err := svc.EnsureInspection(user, "vehicle-1", samplePmcsIDStr, InspectionRequest{
+	resp, err := svc.EnsureInspection(context.Background(), user, "vehicle-1", samplePmcsIDStr, InspectionRequest{
 		GuideManual:   "pmcs_sbs/hmmwv/file.json",
 		PerformedDate: time.Now(),
 	})
@@ -249,10 +271,10 @@ func TestEnsureInspectionResolvesPerformedByUsernameViaLookupWhenStickyOwnerDiff
 		},
 		lookupUsernameResult: &lookupResult,
 	}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 	user := &bootstrap.User{UserID: "editor-user", Username: "editor"}
 
-	resp, err := svc.EnsureInspection(user, "vehicle-1", samplePmcsIDStr, InspectionRequest{
+	resp, err := svc.EnsureInspection(context.Background(), user, "vehicle-1", samplePmcsIDStr, InspectionRequest{
 		GuideManual:   "pmcs_sbs/hmmwv/file.json",
 		PerformedDate: time.Now(),
 	})
@@ -265,9 +287,9 @@ func TestEnsureInspectionResolvesPerformedByUsernameViaLookupWhenStickyOwnerDiff
 }
 
 func TestGetInspectionRejectsInvalidPmcsID(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 
-	_, err := svc.GetInspection(requireUser(), "vehicle-1", "not-a-uuid")
+	_, err := svc.GetInspection(context.Background(), requireUser(), "vehicle-1", "not-a-uuid")
 
 	requireServiceError(t, err, ErrInvalidPmcsID)
 }
@@ -280,9 +302,9 @@ func TestGetInspectionMapsFaults(t *testing.T) {
 			PmcsID: samplePmcsID(), SectionID: "before", ItemIndex: 0, ItemNo: "1", Status: "x", FaultText: "leak", CreatedAt: now, UpdatedAt: now,
 		}},
 	}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	resp, err := svc.GetInspection(requireUser(), "vehicle-1", samplePmcsIDStr)
+	resp, err := svc.GetInspection(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr)
 
 	require.NoError(t, err)
 	require.Equal(t, samplePmcsID(), stub.capturedPmcsID)
@@ -292,9 +314,9 @@ func TestGetInspectionMapsFaults(t *testing.T) {
 
 func TestListInspectionsAppliesDefaultLimitAndOffset(t *testing.T) {
 	stub := &repoStub{summaries: []InspectionSummary{}}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	_, err := svc.ListInspections(requireUser(), "vehicle-1", ListInspectionsRequest{})
+	_, err := svc.ListInspections(context.Background(), requireUser(), "vehicle-1", ListInspectionsRequest{})
 
 	require.NoError(t, err)
 	require.Equal(t, 1000, stub.capturedLimit)
@@ -302,9 +324,9 @@ func TestListInspectionsAppliesDefaultLimitAndOffset(t *testing.T) {
 }
 
 func TestListInspectionsValidatesGuideManualFilterWhenProvided(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 
-	_, err := svc.ListInspections(requireUser(), "vehicle-1", ListInspectionsRequest{GuideManual: "pmcs/hmmwv/file.json"})
+	_, err := svc.ListInspections(context.Background(), requireUser(), "vehicle-1", ListInspectionsRequest{GuideManual: "pmcs/hmmwv/file.json"})
 
 	requireServiceError(t, err, ErrInvalidGuideManual)
 }
@@ -316,9 +338,9 @@ func TestListInspectionsMapsSummaries(t *testing.T) {
 	stub := &repoStub{summaries: []InspectionSummary{
 		{ID: samplePmcsID(), GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: now, FaultCount: 2, CreatedAt: now, PerformedBy: &performedBy, PerformedByUsername: &performedByUsername},
 	}}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	resp, err := svc.ListInspections(requireUser(), "vehicle-1", ListInspectionsRequest{Limit: 10, Offset: 0})
+	resp, err := svc.ListInspections(context.Background(), requireUser(), "vehicle-1", ListInspectionsRequest{Limit: 10, Offset: 0})
 
 	require.NoError(t, err)
 	require.Equal(t, 1, resp.Count)
@@ -330,18 +352,18 @@ func TestListInspectionsMapsSummaries(t *testing.T) {
 }
 
 func TestDeleteInspectionValidatesPmcsID(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 
-	err := svc.DeleteInspection(requireUser(), "vehicle-1", "not-a-uuid")
+	err := svc.DeleteInspection(context.Background(), requireUser(), "vehicle-1", "not-a-uuid")
 
 	requireServiceError(t, err, ErrInvalidPmcsID)
 }
 
 func TestDeleteInspectionPassesParsedID(t *testing.T) {
 	stub := &repoStub{}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	err := svc.DeleteInspection(requireUser(), " vehicle-1 ", samplePmcsIDStr)
+	err := svc.DeleteInspection(context.Background(), requireUser(), " vehicle-1 ", samplePmcsIDStr)
 
 	require.NoError(t, err)
 	require.Equal(t, "vehicle-1", stub.capturedEquipmentID)
@@ -349,7 +371,7 @@ func TestDeleteInspectionPassesParsedID(t *testing.T) {
 }
 
 func TestUpsertFaultRejectsInvalidValues(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 	baseReq := func() FaultRequest {
 		return FaultRequest{GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: time.Now(), SectionID: "before", ItemIndex: 0, ItemNo: "1", Status: "X", FaultText: "leak"}
 	}
@@ -373,7 +395,7 @@ func TestUpsertFaultRejectsInvalidValues(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := svc.UpsertFault(requireUser(), tc.equipmentID, tc.pmcsID, tc.mutate(baseReq()))
+			_, err := svc.UpsertFault(context.Background(), requireUser(), tc.equipmentID, tc.pmcsID, tc.mutate(baseReq()))
 			requireServiceError(t, err, tc.want)
 		})
 	}
@@ -381,7 +403,7 @@ func TestUpsertFaultRejectsInvalidValues(t *testing.T) {
 
 func TestUpsertFaultAcceptsAllowedStatuses(t *testing.T) {
 	stub := &repoStub{}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 	cases := []struct {
 		input string
 		want  string
@@ -396,7 +418,7 @@ func TestUpsertFaultAcceptsAllowedStatuses(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.input, func(t *testing.T) {
-			_, err := svc.UpsertFault(requireUser(), "vehicle-1", samplePmcsIDStr, FaultRequest{
+			_, err := svc.UpsertFault(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, FaultRequest{
 				GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: time.Now(), SectionID: "before", ItemIndex: 0, ItemNo: "1", Status: tc.input, FaultText: "leak",
 			})
 			require.NoError(t, err)
@@ -409,10 +431,11 @@ func TestUpsertFaultReturnsMappedResponse(t *testing.T) {
 	now := time.Now().UTC()
 	stub := &repoStub{savedFault: &model.PmcsSbsFaults{
 		PmcsID: samplePmcsID(), SectionID: "before", ItemIndex: 0, ItemNo: "1", Status: "x", FaultText: "leak", CreatedAt: now, UpdatedAt: now,
-	}}
-	svc := NewService(stub)
+	}, vehicleShopID: "shop-1"}
+	publisher := &publisherStub{}
+	svc := NewService(stub, publisher)
 
-	resp, err := svc.UpsertFault(requireUser(), "vehicle-1", samplePmcsIDStr, FaultRequest{
+	resp, err := svc.UpsertFault(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, FaultRequest{
 		GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: now, SectionID: "before", ItemIndex: 0, ItemNo: "1", Status: "X", FaultText: "leak",
 	})
 
@@ -421,13 +444,35 @@ func TestUpsertFaultReturnsMappedResponse(t *testing.T) {
 	require.Equal(t, samplePmcsID(), stub.capturedInspection.ID)
 	require.Equal(t, "x", stub.capturedFault.Status)
 	require.Equal(t, samplePmcsID(), resp.PmcsID)
+	require.Equal(t, []events.Event{events.PMCSFaultRecorded{
+		ShopID:      "shop-1",
+		EquipmentID: "vehicle-1",
+		PmcsID:      samplePmcsIDStr,
+		SectionID:   "before",
+		ItemIndex:   0,
+		Status:      "x",
+		RecordedBy:  "user-1",
+	}}, publisher.published)
+}
+
+func TestUpsertFaultPublishesNothingOnFailure(t *testing.T) {
+	stub := &repoStub{err: ErrNotFound}
+	publisher := &publisherStub{}
+	svc := NewService(stub, publisher)
+
+	_, err := svc.UpsertFault(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, FaultRequest{
+		GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: time.Now(), SectionID: "before", ItemIndex: 0, ItemNo: "1", Status: "X", FaultText: "leak",
+	})
+
+	requireServiceError(t, err, ErrNotFound)
+	require.Empty(t, publisher.published)
 }
 
 func TestDeleteFaultPassesValidatedKey(t *testing.T) {
 	stub := &repoStub{}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	err := svc.DeleteFault(requireUser(), " vehicle-1 ", samplePmcsIDStr, DeleteFaultRequest{SectionID: " before ", ItemIndex: 0})
+	err := svc.DeleteFault(context.Background(), requireUser(), " vehicle-1 ", samplePmcsIDStr, DeleteFaultRequest{SectionID: " before ", ItemIndex: 0})
 
 	require.NoError(t, err)
 	require.Equal(t, "vehicle-1", stub.capturedEquipmentID)
@@ -437,9 +482,9 @@ func TestDeleteFaultPassesValidatedKey(t *testing.T) {
 
 func TestDeleteFaultsPassesValidatedKeysAndCounts(t *testing.T) {
 	stub := &repoStub{deletedCount: 1}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	resp, err := svc.DeleteFaults(requireUser(), " vehicle-1 ", samplePmcsIDStr, BulkDeleteFaultRequest{
+	resp, err := svc.DeleteFaults(context.Background(), requireUser(), " vehicle-1 ", samplePmcsIDStr, BulkDeleteFaultRequest{
 		Faults: []BulkDeleteFaultItemRequest{
 			{SectionID: " before ", ItemIndex: 0},
 			{SectionID: " after ", ItemIndex: 2},
@@ -457,9 +502,9 @@ func TestDeleteFaultsPassesValidatedKeysAndCounts(t *testing.T) {
 }
 
 func TestDeleteFaultsRequiresAuth(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 
-	_, err := svc.DeleteFaults(nil, "vehicle-1", samplePmcsIDStr, BulkDeleteFaultRequest{
+	_, err := svc.DeleteFaults(context.Background(), nil, "vehicle-1", samplePmcsIDStr, BulkDeleteFaultRequest{
 		Faults: []BulkDeleteFaultItemRequest{{SectionID: "before", ItemIndex: 0}},
 	})
 
@@ -467,7 +512,7 @@ func TestDeleteFaultsRequiresAuth(t *testing.T) {
 }
 
 func TestValidateBulkDeleteFaultRequestRejectsInvalidValues(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 	validFaults := []BulkDeleteFaultItemRequest{{SectionID: "before", ItemIndex: 0}}
 	tooManyFaults := make([]BulkDeleteFaultItemRequest, maxBulkDeleteFaults+1)
 	for i := range tooManyFaults {
@@ -500,10 +545,10 @@ func TestValidateBulkDeleteFaultRequestRejectsInvalidValues(t *testing.T) {
 
 func TestEnsureInspectionTrimsAndClearsNotes(t *testing.T) {
 	stub := &repoStub{}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
 	blank := "   "
-	_, err := svc.EnsureInspection(requireUser(), "vehicle-1", samplePmcsIDStr, InspectionRequest{
+	_, err := svc.EnsureInspection(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, InspectionRequest{
 		GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: time.Now(), Notes: &blank,
 	})
 
@@ -511,7 +556,7 @@ func TestEnsureInspectionTrimsAndClearsNotes(t *testing.T) {
 	require.Nil(t, stub.capturedInspection.Notes)
 
 	padded := "  looks fine  "
-	_, err = svc.EnsureInspection(requireUser(), "vehicle-1", samplePmcsIDStr, InspectionRequest{
+	_, err = svc.EnsureInspection(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, InspectionRequest{
 		GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: time.Now(), Notes: &padded,
 	})
 
@@ -521,10 +566,10 @@ func TestEnsureInspectionTrimsAndClearsNotes(t *testing.T) {
 }
 
 func TestEnsureInspectionRejectsOverlongNotes(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 	tooLong := strings.Repeat("a", maxNotesLength+1)
 
-	_, err := svc.EnsureInspection(requireUser(), "vehicle-1", samplePmcsIDStr, InspectionRequest{
+	_, err := svc.EnsureInspection(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, InspectionRequest{
 		GuideManual: "pmcs_sbs/hmmwv/file.json", PerformedDate: time.Now(), Notes: &tooLong,
 	})
 
@@ -542,9 +587,9 @@ func TestGetInspectionMapsNotesAndComments(t *testing.T) {
 			AuthorUsername:            &authorUsername,
 		}},
 	}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	resp, err := svc.GetInspection(requireUser(), "vehicle-1", samplePmcsIDStr)
+	resp, err := svc.GetInspection(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr)
 
 	require.NoError(t, err)
 	require.NotNil(t, resp.Notes)
@@ -556,15 +601,15 @@ func TestGetInspectionMapsNotesAndComments(t *testing.T) {
 }
 
 func TestCreateCommentRequiresAuth(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 
-	_, err := svc.CreateComment(nil, "vehicle-1", samplePmcsIDStr, CreateCommentRequest{Text: "hello"})
+	_, err := svc.CreateComment(context.Background(), nil, "vehicle-1", samplePmcsIDStr, CreateCommentRequest{Text: "hello"})
 
 	requireServiceError(t, err, ErrUnauthorized)
 }
 
 func TestCreateCommentRejectsInvalidText(t *testing.T) {
-	svc := NewService(&repoStub{})
+	svc := NewService(&repoStub{}, &publisherStub{})
 
 	cases := []struct {
 		name string
@@ -576,7 +621,7 @@ func TestCreateCommentRejectsInvalidText(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := svc.CreateComment(requireUser(), "vehicle-1", samplePmcsIDStr, CreateCommentRequest{Text: tc.text})
+			_, err := svc.CreateComment(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, CreateCommentRequest{Text: tc.text})
 			requireServiceError(t, err, ErrInvalidCommentText)
 		})
 	}
@@ -584,9 +629,9 @@ func TestCreateCommentRejectsInvalidText(t *testing.T) {
 
 func TestCreateCommentTrimsTextAndPassesThrough(t *testing.T) {
 	stub := &repoStub{}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	resp, err := svc.CreateComment(requireUser(), "vehicle-1", samplePmcsIDStr, CreateCommentRequest{Text: "  looks good  "})
+	resp, err := svc.CreateComment(context.Background(), requireUser(), "vehicle-1", samplePmcsIDStr, CreateCommentRequest{Text: "  looks good  "})
 
 	require.NoError(t, err)
 	require.Equal(t, "looks good", stub.capturedCommentText)
@@ -598,9 +643,9 @@ func TestUpdateCommentRequiresAuthorship(t *testing.T) {
 	stub := &repoStub{existingComment: &CommentWithAuthor{
 		PmcsSbsInspectionComments: model.PmcsSbsInspectionComments{ID: samplePmcsID(), AuthorID: "someone-else", Text: "original"},
 	}}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	_, err := svc.UpdateComment(requireUser(), samplePmcsIDStr, UpdateCommentRequest{Text: "edited"})
+	_, err := svc.UpdateComment(context.Background(), requireUser(), samplePmcsIDStr, UpdateCommentRequest{Text: "edited"})
 
 	requireServiceError(t, err, ErrForbidden)
 }
@@ -609,9 +654,9 @@ func TestUpdateCommentSucceedsForAuthor(t *testing.T) {
 	stub := &repoStub{existingComment: &CommentWithAuthor{
 		PmcsSbsInspectionComments: model.PmcsSbsInspectionComments{ID: samplePmcsID(), AuthorID: "user-1", Text: "original"},
 	}}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	_, err := svc.UpdateComment(requireUser(), samplePmcsIDStr, UpdateCommentRequest{Text: "edited"})
+	_, err := svc.UpdateComment(context.Background(), requireUser(), samplePmcsIDStr, UpdateCommentRequest{Text: "edited"})
 
 	require.NoError(t, err)
 	require.Equal(t, "edited", stub.capturedCommentText)
@@ -621,9 +666,9 @@ func TestDeleteCommentRequiresAuthorshipAndUsesSentinelText(t *testing.T) {
 	stub := &repoStub{existingComment: &CommentWithAuthor{
 		PmcsSbsInspectionComments: model.PmcsSbsInspectionComments{ID: samplePmcsID(), AuthorID: "user-1", Text: "original"},
 	}}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	_, err := svc.DeleteComment(requireUser(), samplePmcsIDStr)
+	_, err := svc.DeleteComment(context.Background(), requireUser(), samplePmcsIDStr)
 
 	require.NoError(t, err)
 	require.Equal(t, deletedCommentText, stub.capturedCommentText)
@@ -633,9 +678,9 @@ func TestDeleteCommentRejectsNonAuthor(t *testing.T) {
 	stub := &repoStub{existingComment: &CommentWithAuthor{
 		PmcsSbsInspectionComments: model.PmcsSbsInspectionComments{ID: samplePmcsID(), AuthorID: "someone-else", Text: "original"},
 	}}
-	svc := NewService(stub)
+	svc := NewService(stub, &publisherStub{})
 
-	_, err := svc.DeleteComment(requireUser(), samplePmcsIDStr)
+	_, err := svc.DeleteComment(context.Background(), requireUser(), samplePmcsIDStr)
 
 	requireServiceError(t, err, ErrForbidden)
 }
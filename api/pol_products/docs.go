@@ -0,0 +1,20 @@
+package pol_products
+
+import (
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "POL Products",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listPOLProducts",
+			Method:   http.MethodGet,
+			Path:     "/pol-products",
+			Summary:  "List POL products",
+			Response: PolProductsResponse{},
+		},
+	},
+}}
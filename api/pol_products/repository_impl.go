@@ -1,6 +1,7 @@
 package pol_products
 
 import (
+	"context"
 	"database/sql"
 
 	"miltechserver/.gen/miltech_ng/public/model"
@@ -17,13 +18,13 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetPolProducts() (PolProductsResponse, error) {
+func (repo *RepositoryImpl) GetPolProducts(ctx context.Context) (PolProductsResponse, error) {
 	var products []model.PolProducts
 	stmt := SELECT(
 		table.PolProducts.AllColumns,
 	).FROM(table.PolProducts)
 
-	if err := stmt.Query(repo.db, &products); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &products); err != nil {
 		return PolProductsResponse{}, err
 	}
 
@@ -29,9 +29,9 @@ func registerHandlers(router *gin.RouterGroup, svc Service) {
 }
 
 func (handler *Handler) getPolProducts(c *gin.Context) {
-	data, err := handler.service.GetPolProducts()
+	data, err := handler.service.GetPolProducts(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package pol_products
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+
+	"miltechserver/api/middleware"
 )
 
 type serviceStub struct {
@@ -15,13 +18,14 @@ type serviceStub struct {
 	err  error
 }
 
-func (s *serviceStub) GetPolProducts() (PolProductsResponse, error) {
+func (s *serviceStub) GetPolProducts(_ context.Context) (PolProductsResponse, error) {
 	return s.resp, s.err
 }
 
 func TestGetPolProductsSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{resp: PolProductsResponse{Count: 239}}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -36,6 +40,7 @@ func TestGetPolProductsSuccess(t *testing.T) {
 func TestGetPolProductsError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{err: errors.New("db error")}
 
 	registerHandlers(router.Group("/api/v1"), stub)
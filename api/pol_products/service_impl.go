@@ -1,5 +1,7 @@
 package pol_products
 
+import "context"
+
 type ServiceImpl struct {
 	repo Repository
 }
@@ -8,6 +10,6 @@ func NewService(repo Repository) Service {
 	return &ServiceImpl{repo: repo}
 }
 
-func (service *ServiceImpl) GetPolProducts() (PolProductsResponse, error) {
-	return service.repo.GetPolProducts()
+func (service *ServiceImpl) GetPolProducts(ctx context.Context) (PolProductsResponse, error) {
+	return service.repo.GetPolProducts(ctx)
 }
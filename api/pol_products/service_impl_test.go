@@ -1,6 +1,7 @@
 package pol_products
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -12,7 +13,7 @@ type repoStub struct {
 	err  error
 }
 
-func (r *repoStub) GetPolProducts() (PolProductsResponse, error) {
+func (r *repoStub) GetPolProducts(_ context.Context) (PolProductsResponse, error) {
 	return r.resp, r.err
 }
 
@@ -20,7 +21,7 @@ func TestServiceReturnsProducts(t *testing.T) {
 	repo := &repoStub{resp: PolProductsResponse{Count: 239}}
 	svc := NewService(repo)
 
-	resp, err := svc.GetPolProducts()
+	resp, err := svc.GetPolProducts(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, 239, resp.Count)
 }
@@ -29,6 +30,6 @@ func TestServiceReturnsError(t *testing.T) {
 	repo := &repoStub{err: errors.New("db down")}
 	svc := NewService(repo)
 
-	_, err := svc.GetPolProducts()
+	_, err := svc.GetPolProducts(context.Background())
 	require.Error(t, err)
 }
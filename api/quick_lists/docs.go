@@ -0,0 +1,34 @@
+package quick_lists
+
+import (
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Quick Lists",
+	Operations: []openapi.Operation{
+		{
+			ID:       "queryQuickListClothing",
+			Method:   http.MethodGet,
+			Path:     "/quick-lists/clothing",
+			Summary:  "Query quick list clothing",
+			Response: QuickListsClothingResponse{},
+		},
+		{
+			ID:       "queryQuickListWheels",
+			Method:   http.MethodGet,
+			Path:     "/quick-lists/wheels",
+			Summary:  "Query quick list wheels",
+			Response: QuickListsWheelsResponse{},
+		},
+		{
+			ID:       "queryQuickListBatteries",
+			Method:   http.MethodGet,
+			Path:     "/quick-lists/batteries",
+			Summary:  "Query quick list batteries",
+			Response: QuickListsBatteryResponse{},
+		},
+	},
+}}
@@ -1,6 +1,7 @@
 package quick_lists
 
 import (
+	"context"
 	"database/sql"
 
 	"miltechserver/.gen/miltech_ng/public/model"
@@ -17,13 +18,13 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetQuickListClothing() (QuickListsClothingResponse, error) {
+func (repo *RepositoryImpl) GetQuickListClothing(ctx context.Context) (QuickListsClothingResponse, error) {
 	var clothingData []model.QuickListClothing
 	stmt := SELECT(
 		table.QuickListClothing.AllColumns,
 	).FROM(table.QuickListClothing)
 
-	if err := stmt.Query(repo.db, &clothingData); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &clothingData); err != nil {
 		return QuickListsClothingResponse{}, err
 	}
 
@@ -33,13 +34,13 @@ func (repo *RepositoryImpl) GetQuickListClothing() (QuickListsClothingResponse,
 	}, nil
 }
 
-func (repo *RepositoryImpl) GetQuickListWheels() (QuickListsWheelsResponse, error) {
+func (repo *RepositoryImpl) GetQuickListWheels(ctx context.Context) (QuickListsWheelsResponse, error) {
 	var wheelsData []model.QuickListWheelTires
 	stmt := SELECT(
 		table.QuickListWheelTires.AllColumns,
 	).FROM(table.QuickListWheelTires)
 
-	if err := stmt.Query(repo.db, &wheelsData); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &wheelsData); err != nil {
 		return QuickListsWheelsResponse{}, err
 	}
 
@@ -49,13 +50,13 @@ func (repo *RepositoryImpl) GetQuickListWheels() (QuickListsWheelsResponse, erro
 	}, nil
 }
 
-func (repo *RepositoryImpl) GetQuickListBatteries() (QuickListsBatteryResponse, error) {
+func (repo *RepositoryImpl) GetQuickListBatteries(ctx context.Context) (QuickListsBatteryResponse, error) {
 	var batteriesData []model.QuickListBattery
 	stmt := SELECT(
 		table.QuickListBattery.AllColumns,
 	).FROM(table.QuickListBattery)
 
-	if err := stmt.Query(repo.db, &batteriesData); err != nil {
+	if err := stmt.QueryContext(ctx, repo.db, &batteriesData); err != nil {
 		return QuickListsBatteryResponse{}, err
 	}
 
@@ -32,9 +32,9 @@ func registerHandlers(router *gin.RouterGroup, svc Service) {
 }
 
 func (handler *Handler) queryQuickListClothing(c *gin.Context) {
-	clothingData, err := handler.service.GetQuickListClothing()
+	clothingData, err := handler.service.GetQuickListClothing(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -46,9 +46,9 @@ func (handler *Handler) queryQuickListClothing(c *gin.Context) {
 }
 
 func (handler *Handler) queryQuickListWheels(c *gin.Context) {
-	wheelsData, err := handler.service.GetQuickListWheels()
+	wheelsData, err := handler.service.GetQuickListWheels(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -60,9 +60,9 @@ func (handler *Handler) queryQuickListWheels(c *gin.Context) {
 }
 
 func (handler *Handler) queryQuickListBatteries(c *gin.Context) {
-	batteriesData, err := handler.service.GetQuickListBatteries()
+	batteriesData, err := handler.service.GetQuickListBatteries(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package quick_lists
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+
+	"miltechserver/api/middleware"
 )
 
 type serviceStub struct {
@@ -19,21 +22,22 @@ type serviceStub struct {
 	batteriesErr  error
 }
 
-func (s *serviceStub) GetQuickListClothing() (QuickListsClothingResponse, error) {
+func (s *serviceStub) GetQuickListClothing(_ context.Context) (QuickListsClothingResponse, error) {
 	return s.clothingResp, s.clothingErr
 }
 
-func (s *serviceStub) GetQuickListWheels() (QuickListsWheelsResponse, error) {
+func (s *serviceStub) GetQuickListWheels(_ context.Context) (QuickListsWheelsResponse, error) {
 	return s.wheelsResp, s.wheelsErr
 }
 
-func (s *serviceStub) GetQuickListBatteries() (QuickListsBatteryResponse, error) {
+func (s *serviceStub) GetQuickListBatteries(_ context.Context) (QuickListsBatteryResponse, error) {
 	return s.batteriesResp, s.batteriesErr
 }
 
 func TestQuickListsClothingSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{clothingResp: QuickListsClothingResponse{Count: 0}}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -48,6 +52,7 @@ func TestQuickListsClothingSuccess(t *testing.T) {
 func TestQuickListsWheelsError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{wheelsErr: errors.New("db error")}
 
 	registerHandlers(router.Group("/api/v1"), stub)
@@ -62,6 +67,7 @@ func TestQuickListsWheelsError(t *testing.T) {
 func TestQuickListsBatteriesSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	stub := &serviceStub{batteriesResp: QuickListsBatteryResponse{Count: 2}}
 
 	registerHandlers(router.Group("/api/v1"), stub)
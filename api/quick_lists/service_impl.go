@@ -1,5 +1,7 @@
 package quick_lists
 
+import "context"
+
 type ServiceImpl struct {
 	repo Repository
 }
@@ -8,24 +10,24 @@ func NewService(repo Repository) Service {
 	return &ServiceImpl{repo: repo}
 }
 
-func (service *ServiceImpl) GetQuickListClothing() (QuickListsClothingResponse, error) {
-	clothingData, err := service.repo.GetQuickListClothing()
+func (service *ServiceImpl) GetQuickListClothing(ctx context.Context) (QuickListsClothingResponse, error) {
+	clothingData, err := service.repo.GetQuickListClothing(ctx)
 	if err != nil {
 		return QuickListsClothingResponse{}, err
 	}
 	return clothingData, nil
 }
 
-func (service *ServiceImpl) GetQuickListWheels() (QuickListsWheelsResponse, error) {
-	wheelsData, err := service.repo.GetQuickListWheels()
+func (service *ServiceImpl) GetQuickListWheels(ctx context.Context) (QuickListsWheelsResponse, error) {
+	wheelsData, err := service.repo.GetQuickListWheels(ctx)
 	if err != nil {
 		return QuickListsWheelsResponse{}, err
 	}
 	return wheelsData, nil
 }
 
-func (service *ServiceImpl) GetQuickListBatteries() (QuickListsBatteryResponse, error) {
-	batteriesData, err := service.repo.GetQuickListBatteries()
+func (service *ServiceImpl) GetQuickListBatteries(ctx context.Context) (QuickListsBatteryResponse, error) {
+	batteriesData, err := service.repo.GetQuickListBatteries(ctx)
 	if err != nil {
 		return QuickListsBatteryResponse{}, err
 	}
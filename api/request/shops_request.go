@@ -177,13 +177,6 @@ type RemoveListItemBatchRequest struct {
 	ItemIDs []string `json:"item_ids" binding:"required"`
 }
 
-type GetShopMessagesPaginatedRequest struct {
-	Page     int     `form:"page,default=1" binding:"omitempty,min=1"`
-	Limit    int     `form:"limit,default=20" binding:"omitempty,min=1,max=100"`
-	BeforeID *string `form:"before_id" binding:"omitempty"`
-	AfterID  *string `form:"after_id" binding:"omitempty"`
-}
-
 type UpdateAdminOnlyListsRequest struct {
 	AdminOnlyLists bool `json:"admin_only_lists" binding:"required"`
 }
@@ -202,3 +195,34 @@ type UpdateShopSettingsRequest struct {
 	AdminOnlyLists *bool `json:"admin_only_lists,omitempty"`
 	// Future settings will be added here as optional pointers
 }
+
+// Shop Webhooks
+
+type CreateShopWebhookRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	EventTypes  []string `json:"event_types" binding:"required"`
+	Description *string  `json:"description"`
+}
+
+// UpdateShopWebhookRequest is a partial update; omitted fields keep their
+// value
+type UpdateShopWebhookRequest struct {
+	URL         *string             `json:"url,omitempty"`
+	EventTypes  []string            `json:"event_types,omitempty"`
+	Description NullableStringField `json:"description"`
+	Active      *bool               `json:"active,omitempty"`
+}
+
+// Shop Push Preferences
+
+// UpdateShopPushPreferencesRequest is a partial update; omitted fields keep
+// their value. Quiet hours are "HH:MM" in time_zone and are set or cleared
+// together.
+type UpdateShopPushPreferencesRequest struct {
+	Messages             *bool               `json:"messages,omitempty"`
+	VehicleNotifications *bool               `json:"vehicle_notifications,omitempty"`
+	OverdueServices      *bool               `json:"overdue_services,omitempty"`
+	QuietHoursStart      NullableStringField `json:"quiet_hours_start"`
+	QuietHoursEnd        NullableStringField `json:"quiet_hours_end"`
+	TimeZone             *string             `json:"time_zone,omitempty"`
+}
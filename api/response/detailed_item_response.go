@@ -4,15 +4,28 @@ import (
 	"miltechserver/api/details"
 )
 
+// DetailedResponse is the detailed record of an item. Only the requested
+// sections are present; a section whose query failed is absent and listed in
+// Meta.Failed, and a section with no data is present with zero values and
+// listed in Meta.Empty.
 type DetailedResponse struct {
-	Amdf                    details.Amdf
-	ArmyPackagingAndFreight details.ArmyPackagingAndFreight
-	Characteristics         details.Characteristics
-	Disposition             details.Disposition
-	Freight                 details.Freight
-	Identification          details.Identification
-	Management              details.Management
-	Packaging               details.Packaging
-	Reference               details.Reference
-	Sarsscat                details.Sarsscat
+	Amdf                    *details.Amdf                    `json:",omitempty"`
+	ArmyPackagingAndFreight *details.ArmyPackagingAndFreight `json:",omitempty"`
+	Characteristics         *details.Characteristics         `json:",omitempty"`
+	Disposition             *details.Disposition             `json:",omitempty"`
+	Freight                 *details.Freight                 `json:",omitempty"`
+	Identification          *details.Identification          `json:",omitempty"`
+	Management              *details.Management              `json:",omitempty"`
+	Packaging               *details.Packaging               `json:",omitempty"`
+	Reference               *details.Reference               `json:",omitempty"`
+	Sarsscat                *details.Sarsscat                `json:",omitempty"`
+	Meta                    DetailedMeta                     `json:"meta"`
+}
+
+// DetailedMeta tells clients why a requested section has no data.
+type DetailedMeta struct {
+	// Empty lists sections that were queried and found no rows.
+	Empty []string `json:"empty"`
+	// Failed lists sections whose query returned an error.
+	Failed []string `json:"failed"`
 }
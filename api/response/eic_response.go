@@ -103,21 +103,6 @@ type EICConsolidatedItem struct {
 	VariantCount int      `json:"variant_count"`
 }
 
-// EICPageResponse represents the response structure for paginated EIC queries.
-// Used for GET /api/eic/items and GET /api/eic/fsc/{fsc} endpoints.
-// \param Items - the consolidated EIC data retrieved from the database.
-// \param Count - the total count of EIC items on this page.
-// \param Page - the current page number.
-// \param TotalPages - the total number of pages.
-// \param IsLastPage - indicates if this is the last page.
-type EICPageResponse struct {
-	Items      []EICConsolidatedItem `json:"items"`
-	Count      int                   `json:"count"`
-	Page       int                   `json:"page"`
-	TotalPages int                   `json:"total_pages"`
-	IsLastPage bool                  `json:"is_last_page"`
-}
-
 // EICSearchResponse represents the response structure for non-paginated EIC queries.
 // Used for GET /api/eic/niin/{niin} and GET /api/eic/lin/{lin} endpoints.
 // \param Count - the total count of consolidated EIC items found.
@@ -54,20 +54,6 @@ type ShopListItemWithUsername struct {
 	UnitOfMeasure   *string    `json:"unit_of_measure"`
 }
 
-type PaginationMetadata struct {
-	Page       int  `json:"page"`
-	Limit      int  `json:"limit"`
-	TotalPages int  `json:"total_pages"`
-	HasNext    bool `json:"has_next"`
-	HasPrev    bool `json:"has_prev"`
-}
-
-type PaginatedShopMessagesResponse struct {
-	Messages   []model.ShopMessages `json:"messages"`
-	Pagination *PaginationMetadata  `json:"pagination,omitempty"`
-	NextCursor *string              `json:"next_cursor,omitempty"`
-}
-
 // ShopDetailResponse includes shop data with calculated statistics
 // Used by GetShopByID endpoint to provide comprehensive shop information
 type ShopDetailResponse struct {
@@ -0,0 +1,107 @@
+package route
+
+import (
+	"encoding/json"
+	"miltechserver/api/admin"
+	"miltechserver/api/docs_equipment"
+	"miltechserver/api/eic"
+	"miltechserver/api/equipment_services"
+	"miltechserver/api/item_comments"
+	"miltechserver/api/item_lookup"
+	"miltechserver/api/item_query"
+	"miltechserver/api/library"
+	"miltechserver/api/material_images"
+	"miltechserver/api/moderation"
+	"miltechserver/api/openapi"
+	"miltechserver/api/pmcs_sbs_progress"
+	"miltechserver/api/pol_products"
+	"miltechserver/api/quick_lists"
+	"miltechserver/api/sb_700_20"
+	"miltechserver/api/shops"
+	"miltechserver/api/tmde"
+	"miltechserver/api/user_devices"
+	"miltechserver/api/user_general"
+	"miltechserver/api/user_saves"
+	"miltechserver/api/user_suggestions"
+	"miltechserver/api/user_vehicles"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// generalDocs describes the routes of NewGeneralRouter and NewGeneralQueriesRouter.
+var generalDocs = []openapi.Section{{
+	Tag: "General",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getVersion",
+			Method:   http.MethodGet,
+			Path:     "/version",
+			Summary:  "Get the current mobile app version",
+			Response: "",
+		},
+		{
+			ID:       "getDBDate",
+			Method:   http.MethodGet,
+			Path:     "/general/db_date",
+			Summary:  "Get the date of the FedLog load currently served",
+			Response: "",
+		},
+	},
+}}
+
+// APIDocs returns the OpenAPI document for every route under /api/v1 and
+// /api/v1/auth.
+func APIDocs() *openapi.Document {
+	return openapi.New(openapi.Info{
+		Title:       "MilTech API",
+		Version:     "v1",
+		Description: "Errors are returned as RFC 7807 problem details. Routes under /api/v1/auth require a Firebase ID token. FedLog reference routes return ETag and Last-Modified headers and answer If-None-Match and If-Modified-Since with 304.",
+	}, apiSections())
+}
+
+// apiSections collects the Docs of every package Setup registers routes from.
+// A package that registers routes adds its Docs here.
+func apiSections() []openapi.Section {
+	return slices.Concat(
+		generalDocs,
+		item_query.Docs,
+		item_lookup.Docs,
+		quick_lists.Docs,
+		pol_products.Docs,
+		eic.Docs,
+		tmde.Docs,
+		sb_700_20.Docs,
+		docs_equipment.Docs,
+		user_saves.Docs,
+		user_general.Docs,
+		user_vehicles.Docs,
+		user_devices.Docs,
+		shops.Docs,
+		equipment_services.Docs,
+		pmcs_sbs_progress.Docs,
+		item_comments.Docs,
+		user_suggestions.Docs,
+		admin.Docs,
+		moderation.Docs,
+		material_images.Docs,
+		library.Docs,
+	)
+}
+
+// NewDocsRouter serves the OpenAPI document at /api/docs/openapi.json and a
+// viewer for it at /api/docs. The document is built once, at startup.
+func NewDocsRouter(router *gin.Engine) {
+	document, err := json.Marshal(APIDocs())
+	if err != nil {
+		panic(err)
+	}
+
+	router.GET("/api/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.Viewer)
+	})
+	router.GET("/api/docs/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", document)
+	})
+}
@@ -0,0 +1,71 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAPIDocsDescribeEveryRoute fails when a route is registered without a
+// Docs entry, or a Docs entry outlives its route. Describe the route in the
+// Docs table of the package that registers it.
+func TestAPIDocsDescribeEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Setup(router, Dependencies{})
+
+	registered := map[string]bool{}
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, "/api/v1/") || strings.HasPrefix(route.Path, "/api/v1/test/") {
+			continue
+		}
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	described := map[string]bool{}
+	ids := map[string]string{}
+	for _, section := range apiSections() {
+		require.NotEmpty(t, section.Tag)
+		for _, op := range section.Operations {
+			key := op.Method + " " + op.FullPath()
+			require.False(t, described[key], "%s is described twice", key)
+			described[key] = true
+
+			require.NotEmpty(t, op.ID, "%s has no operation ID", key)
+			require.NotContains(t, ids, op.ID, "operation ID %q is used by %s and %s", op.ID, ids[op.ID], key)
+			ids[op.ID] = key
+		}
+	}
+
+	for key := range registered {
+		require.True(t, described[key], "%s is not described in the OpenAPI document", key)
+	}
+	for key := range described {
+		require.True(t, registered[key], "%s is described but not registered", key)
+	}
+}
+
+func TestDocsRouterServesDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewDocsRouter(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &document))
+	require.Equal(t, "3.1.0", document["openapi"])
+	require.Contains(t, document["paths"], "/api/v1/auth/shops/{shop_id}")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+}
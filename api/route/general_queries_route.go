@@ -3,16 +3,17 @@ package route
 import (
 	"github.com/gin-gonic/gin"
 	"miltechserver/api/response"
-	"miltechserver/bootstrap"
+	"miltechserver/dataversion"
 )
 
-func NewGeneralQueriesRouter(group *gin.RouterGroup, env *bootstrap.Env) {
+func NewGeneralQueriesRouter(group *gin.RouterGroup, dataVersion *dataversion.Watcher) {
 
+	// Read on every request, so a new FedLog load shows up without a restart
 	group.GET("/general/db_date", func(c *gin.Context) {
 		c.JSON(200, response.StandardResponse{
 			Status:  200,
 			Message: "FedLog Database Date",
-			Data:    env.DBDate,
+			Data:    dataVersion.DBDate(),
 		})
 	})
 
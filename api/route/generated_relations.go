@@ -0,0 +1,81 @@
+package route
+
+import (
+	"miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/.gen/miltech_ng/public/view"
+	"miltechserver/health"
+)
+
+// generatedRelations are the jet tables and views the code queries. The
+// schema readiness check confirms the database still has every column they
+// were generated with; a table or view newly queried through jet belongs here.
+func generatedRelations() []health.Relation {
+	return []health.Relation{
+		health.Generated(table.AirForceManagement, table.AirForceManagement.AllColumns),
+		health.Generated(table.AmdfBilling, table.AmdfBilling.AllColumns),
+		health.Generated(table.AmdfCredit, table.AmdfCredit.AllColumns),
+		health.Generated(table.AmdfFreight, table.AmdfFreight.AllColumns),
+		health.Generated(table.AmdfIAndS, table.AmdfIAndS.AllColumns),
+		health.Generated(table.AmdfManagement, table.AmdfManagement.AllColumns),
+		health.Generated(table.AmdfMatcat, table.AmdfMatcat.AllColumns),
+		health.Generated(table.AmdfPhrase, table.AmdfPhrase.AllColumns),
+		health.Generated(table.AnalyticsEventCounters, table.AnalyticsEventCounters.AllColumns),
+		health.Generated(table.ArmyFreight, table.ArmyFreight.AllColumns),
+		health.Generated(table.ArmyLineItemNumber, table.ArmyLineItemNumber.AllColumns),
+		health.Generated(table.ArmyManagement, table.ArmyManagement.AllColumns),
+		health.Generated(table.ArmyMasterDataFile, table.ArmyMasterDataFile.AllColumns),
+		health.Generated(table.ArmyPackSupplementalInstruct, table.ArmyPackSupplementalInstruct.AllColumns),
+		health.Generated(table.ArmyPackaging1, table.ArmyPackaging1.AllColumns),
+		health.Generated(table.ArmyPackaging2, table.ArmyPackaging2.AllColumns),
+		health.Generated(table.ArmyPackagingAndFreight, table.ArmyPackagingAndFreight.AllColumns),
+		health.Generated(table.ArmyPackagingSpecialInstruct, table.ArmyPackagingSpecialInstruct.AllColumns),
+		health.Generated(table.ArmySarsscat, table.ArmySarsscat.AllColumns),
+		health.Generated(table.ArmySubstituteLin, table.ArmySubstituteLin.AllColumns),
+		health.Generated(table.CageAddress, table.CageAddress.AllColumns),
+		health.Generated(table.CageStatusAndType, table.CageStatusAndType.AllColumns),
+		health.Generated(table.ColloquialName, table.ColloquialName.AllColumns),
+		health.Generated(table.ComponentEndItem, table.ComponentEndItem.AllColumns),
+		health.Generated(table.Disposition, table.Disposition.AllColumns),
+		health.Generated(table.DssWeightAndCube, table.DssWeightAndCube.AllColumns),
+		health.Generated(table.FaaManagement, table.FaaManagement.AllColumns),
+		health.Generated(table.FlisCancelledNiin, table.FlisCancelledNiin.AllColumns),
+		health.Generated(table.FlisFreight, table.FlisFreight.AllColumns),
+		health.Generated(table.FlisIdentification, table.FlisIdentification.AllColumns),
+		health.Generated(table.FlisItemCharacteristics, table.FlisItemCharacteristics.AllColumns),
+		health.Generated(table.FlisManagement, table.FlisManagement.AllColumns),
+		health.Generated(table.FlisManagementID, table.FlisManagementID.AllColumns),
+		health.Generated(table.FlisPackaging1, table.FlisPackaging1.AllColumns),
+		health.Generated(table.FlisPackaging2, table.FlisPackaging2.AllColumns),
+		health.Generated(table.FlisPhrase, table.FlisPhrase.AllColumns),
+		health.Generated(table.FlisReference, table.FlisReference.AllColumns),
+		health.Generated(table.FlisStandardization, table.FlisStandardization.AllColumns),
+		health.Generated(table.ItemCommentFlags, table.ItemCommentFlags.AllColumns),
+		health.Generated(table.ItemComments, table.ItemComments.AllColumns),
+		health.Generated(table.LookupUoc, table.LookupUoc.AllColumns),
+		health.Generated(table.MarineCorpsManagement, table.MarineCorpsManagement.AllColumns),
+		health.Generated(table.MoeRule, table.MoeRule.AllColumns),
+		health.Generated(table.NavyManagement, table.NavyManagement.AllColumns),
+		health.Generated(table.PolProducts, table.PolProducts.AllColumns),
+		health.Generated(table.QuickListBattery, table.QuickListBattery.AllColumns),
+		health.Generated(table.QuickListClothing, table.QuickListClothing.AllColumns),
+		health.Generated(table.QuickListWheelTires, table.QuickListWheelTires.AllColumns),
+		health.Generated(table.Sb70020AppB, table.Sb70020AppB.AllColumns),
+		health.Generated(table.Sb70020AppC, table.Sb70020AppC.AllColumns),
+		health.Generated(table.Sb70020AppD, table.Sb70020AppD.AllColumns),
+		health.Generated(table.Sb70020AppE, table.Sb70020AppE.AllColumns),
+		health.Generated(table.Sb70020AppF, table.Sb70020AppF.AllColumns),
+		health.Generated(table.Sb70020AppG, table.Sb70020AppG.AllColumns),
+		health.Generated(table.Sb70020AppH1, table.Sb70020AppH1.AllColumns),
+		health.Generated(table.Sb70020AppH2, table.Sb70020AppH2.AllColumns),
+		health.Generated(table.Sb70020AppI, table.Sb70020AppI.AllColumns),
+		health.Generated(table.Sb70020AppJ, table.Sb70020AppJ.AllColumns),
+		health.Generated(table.Sb70020Chp4, table.Sb70020Chp4.AllColumns),
+		health.Generated(table.Sb70020Chp6, table.Sb70020Chp6.AllColumns),
+		health.Generated(table.Sb70020Chp8, table.Sb70020Chp8.AllColumns),
+		health.Generated(table.UserSuggestions, table.UserSuggestions.AllColumns),
+		health.Generated(table.Users, table.Users.AllColumns),
+		health.Generated(view.LookupLinNiinMat, view.LookupLinNiinMat.AllColumns),
+		health.Generated(view.NiinLookup, view.NiinLookup.AllColumns),
+		health.Generated(view.TmdeIntervalMat, view.TmdeIntervalMat.AllColumns),
+	}
+}
@@ -0,0 +1,37 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"miltechserver/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds a /readyz probe so a hung dependency reports as
+// failed instead of stalling the orchestrator.
+const readinessTimeout = 5 * time.Second
+
+// NewHealthRouter serves the orchestrator probes:
+//   - /healthz (liveness) answers as long as the process can serve HTTP
+//   - /readyz (readiness) runs every registered check and returns 503 when a
+//     critical dependency is down
+func NewHealthRouter(router *gin.Engine, checks *health.Registry) {
+	router.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+	})
+
+	router.GET("/readyz", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+		defer cancel()
+
+		report := checks.Run(ctx)
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report)
+	})
+}
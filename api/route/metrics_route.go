@@ -0,0 +1,33 @@
+package route
+
+import (
+	"crypto/subtle"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/middleware"
+	"miltechserver/bootstrap"
+	"miltechserver/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewMetricsRouter serves Prometheus metrics at /metrics. When METRICS_TOKEN
+// is set, scrapers must send it as a bearer token.
+func NewMetricsRouter(router *gin.Engine, env *bootstrap.Env) {
+	handler := gin.WrapH(metrics.Handler())
+	token := ""
+	if env != nil {
+		token = env.MetricsToken
+	}
+
+	router.GET("/metrics", func(c *gin.Context) {
+		if token != "" {
+			given := c.GetHeader("Authorization")
+			if subtle.ConstantTimeCompare([]byte(given), []byte("Bearer "+token)) != 1 {
+				apperror.Render(c, middleware.ErrUnauthenticated)
+				return
+			}
+		}
+		handler(c)
+	})
+}
@@ -2,7 +2,9 @@ package route
 
 import (
 	"database/sql"
+	"miltechserver/api/admin"
 	"miltechserver/api/analytics"
+	"miltechserver/api/apperror"
 	"miltechserver/api/docs_equipment"
 	"miltechserver/api/eic"
 	"miltechserver/api/equipment_services"
@@ -12,79 +14,168 @@ import (
 	"miltechserver/api/library"
 	"miltechserver/api/material_images"
 	"miltechserver/api/middleware"
+	"miltechserver/api/moderation"
 	"miltechserver/api/pmcs_sbs_progress"
 	"miltechserver/api/pol_products"
 	"miltechserver/api/quick_lists"
 	"miltechserver/api/sb_700_20"
+	"miltechserver/api/shops/stream"
 	"miltechserver/api/tmde"
+	"miltechserver/api/user_devices"
 	"miltechserver/api/user_general"
 	"miltechserver/api/user_saves"
 	"miltechserver/api/user_suggestions"
 	"miltechserver/api/user_vehicles"
 	"miltechserver/bootstrap"
-	"net/http"
+	"miltechserver/dataversion"
+	"miltechserver/events"
+	"miltechserver/health"
+	"miltechserver/identity"
+	"miltechserver/jobs"
+	"miltechserver/push"
+	"miltechserver/ratelimit"
+	"miltechserver/realtime"
+	"miltechserver/storage"
 	"strings"
+	"time"
 
-	"firebase.google.com/go/v4/auth"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 )
 
-func Setup(db *sql.DB, router *gin.Engine, authClient *auth.Client, env *bootstrap.Env, blobClient *azblob.Client) {
+// authPrefix is where the authenticated routes are registered.
+const authPrefix = "/api/v1/auth"
+
+// Dependencies are the shared services the feature routers are built from.
+type Dependencies struct {
+	DB        *sql.DB
+	Verifier  identity.TokenVerifier
+	Env       *bootstrap.Env
+	Store     storage.ObjectStore
+	Lifecycle *bootstrap.Lifecycle
+	Profiles  *identity.ProfileCache
+	Health    *health.Registry
+	Limiter   *ratelimit.Limiter
+	// RequestLimiter counts the user and shop policies every authenticated
+	// request is checked against; it keeps its counters in memory
+	RequestLimiter *ratelimit.Limiter
+	// DataVersion reports the loaded FedLog dataset; reference caches flush
+	// when it changes
+	DataVersion *dataversion.Watcher
+	// Jobs is the scheduler packages register their background jobs with
+	Jobs *jobs.Scheduler
+	// Events is the domain event bus services publish to and packages
+	// subscribe to
+	Events *events.Bus
+	// Push sends mobile push notifications
+	Push push.Sender
+	// Stream fans shop events out to the members connected to this replica
+	Stream *realtime.Hub
+}
+
+func Setup(router *gin.Engine, deps Dependencies) {
+	db, verifier, env, store := deps.DB, deps.Verifier, deps.Env, deps.Store
+
+	// Probes are registered before the middleware so they are neither traced nor counted
+	NewHealthRouter(router, deps.Health)
+	deps.Health.Register("database", true, health.Database(db))
+	deps.Health.Register("schema", true, health.Schema(db, generatedRelations()...))
+	if env != nil {
+		deps.Health.Register("object_store", true, health.ObjectStore(store, env.HealthBlobContainer))
+	}
+
+	// Registered first so every group below, including static assets, is traced and
+	// measured, every error attached with c.Error is rendered as problem+json, and
+	// every request carries a deadline
+	var requestTimeout time.Duration
+	if env != nil {
+		requestTimeout = time.Duration(env.ContextTimeout) * time.Second
+	}
+	router.Use(middleware.TracingMiddleware(), middleware.MetricsMiddleware(), middleware.ErrorHandler, middleware.Timeout(requestTimeout, authPrefix+stream.Path))
+	NewMetricsRouter(router, env)
+	NewDocsRouter(router)
+
 	v1Route := router.Group("/api/v1")
-	v1Route.Use(middleware.ErrorHandler)
 
 	testRoutes := router.Group("/api/v1/test")
-	testRoutes.Use(middleware.AuthenticationMiddleware(authClient))
+	testRoutes.Use(middleware.AuthenticationMiddleware(verifier))
 	NewTestRouter(db, testRoutes)
 
 	v1Route.Use(middleware.LoggerMiddleware())
 	// All Public Routes
 	NewGeneralRouter(v1Route, env)
-	NewGeneralQueriesRouter(v1Route, env)
-	item_query.RegisterRoutes(item_query.Dependencies{DB: db}, v1Route)
-	item_lookup.RegisterRoutes(item_lookup.Dependencies{DB: db}, v1Route)
-	quick_lists.RegisterRoutes(quick_lists.Dependencies{DB: db}, v1Route)
-	pol_products.RegisterRoutes(pol_products.Dependencies{DB: db}, v1Route)
-	eic.RegisterRoutes(eic.Dependencies{DB: db}, v1Route)
-	tmde.RegisterRoutes(tmde.Dependencies{DB: db}, v1Route)
-	sb_700_20.RegisterRoutes(sb_700_20.Dependencies{DB: db}, v1Route)
-	docs_equipment.RegisterRoutes(docs_equipment.Dependencies{DB: db, BlobClient: blobClient}, v1Route)
+	NewGeneralQueriesRouter(v1Route, deps.DataVersion)
+
+	// FedLog reference data only changes with the data version, so it is served conditionally
+	referenceRoute := v1Route.Group("", middleware.ConditionalGet(deps.DataVersion.Current))
+	item_query.RegisterRoutes(item_query.Dependencies{
+		DB:          db,
+		Lifecycle:   deps.Lifecycle,
+		Health:      deps.Health,
+		DataVersion: deps.DataVersion,
+	}, referenceRoute)
+	item_lookup.RegisterRoutes(item_lookup.Dependencies{DB: db, DataVersion: deps.DataVersion}, referenceRoute)
+	quick_lists.RegisterRoutes(quick_lists.Dependencies{DB: db}, referenceRoute)
+	pol_products.RegisterRoutes(pol_products.Dependencies{DB: db}, referenceRoute)
+	eic.RegisterRoutes(eic.Dependencies{DB: db}, referenceRoute)
+	tmde.RegisterRoutes(tmde.Dependencies{DB: db}, referenceRoute)
+	sb_700_20.RegisterRoutes(sb_700_20.Dependencies{DB: db}, referenceRoute)
+	docs_equipment.RegisterRoutes(docs_equipment.Dependencies{DB: db, Store: store, Limiter: deps.Limiter}, v1Route)
 
 	// All Authenticated Routes
-	authRoutes := router.Group("/api/v1/auth")
-	authRoutes.Use(middleware.AuthenticationMiddleware(authClient))
+	authRoutes := router.Group(authPrefix)
+	authRoutes.Use(
+		middleware.AuthenticationMiddleware(verifier),
+		middleware.RateLimit(deps.RequestLimiter, middleware.UserPolicy, middleware.ByUser),
+		middleware.RateLimit(deps.RequestLimiter, middleware.ShopPolicy, middleware.ByShop),
+	)
 	user_saves.RegisterRoutes(user_saves.Dependencies{
-		DB:         db,
-		BlobClient: blobClient,
-		Env:        env,
+		DB:    db,
+		Store: store,
+		Env:   env,
 	}, authRoutes)
-	user_general.RegisterRoutes(user_general.Dependencies{DB: db}, authRoutes)
+	user_general.RegisterRoutes(user_general.Dependencies{DB: db, Profiles: deps.Profiles}, authRoutes)
 	user_vehicles.RegisterRoutes(user_vehicles.Dependencies{DB: db}, authRoutes)
-	NewShopsRouter(db, blobClient, env, authRoutes)
-	equipment_services.RegisterRoutes(equipment_services.Dependencies{DB: db}, authRoutes)
-	pmcs_sbs_progress.RegisterRoutes(pmcs_sbs_progress.Dependencies{DB: db}, authRoutes)
+	user_devices.RegisterRoutes(user_devices.Dependencies{DB: db}, authRoutes)
+	NewShopsRouter(db, store, env, deps.Events, deps.Jobs, deps.Push, deps.Stream, authRoutes)
+	equipment_services.RegisterRoutes(equipment_services.Dependencies{DB: db, Events: deps.Events, Jobs: deps.Jobs}, authRoutes)
+	pmcs_sbs_progress.RegisterRoutes(pmcs_sbs_progress.Dependencies{DB: db, Events: deps.Events}, authRoutes)
 	item_comments.RegisterRoutes(item_comments.Dependencies{DB: db}, v1Route, authRoutes)
 	user_suggestions.RegisterRoutes(user_suggestions.Dependencies{
-		DB:         db,
-		AuthClient: authClient,
+		DB:       db,
+		Verifier: verifier,
 	}, v1Route, authRoutes)
 
+	// Site admin routes
+	admin.RegisterRoutes(admin.Dependencies{DB: db, Jobs: deps.Jobs}, authRoutes)
+
+	// Moderator routes
+	moderation.RegisterRoutes(moderation.Dependencies{DB: db, Store: store, Env: env}, authRoutes)
+
 	// Mixed Routes (both public and authenticated endpoints)
 	material_images.RegisterRoutes(material_images.Dependencies{
-		DB:         db,
-		BlobClient: blobClient,
-		Env:        env,
-		AuthClient: authClient,
+		DB:       db,
+		Store:    store,
+		Env:      env,
+		Verifier: verifier,
+		Limiter:  deps.Limiter,
+		Jobs:     deps.Jobs,
 	}, v1Route, authRoutes)
 	analyticsService := analytics.New(db)
+	analytics.RegisterJobs(deps.Jobs, db)
 	library.RegisterRoutes(library.Dependencies{
-		DB:         db,
-		BlobClient: blobClient,
-		Env:        env,
-		Analytics:  analyticsService,
+		DB:        db,
+		Store:     store,
+		Env:       env,
+		Analytics: analyticsService,
+		Health:    deps.Health,
+		Limiter:   deps.Limiter,
 	}, v1Route, authRoutes)
 
+	// The local object store serves its own signed URLs; Azure serves SAS URLs directly.
+	if localStore, ok := store.(*storage.LocalStore); ok {
+		localStore.RegisterRoutes(router)
+	}
+
 	// Serve static assets (CSS, JS, images, etc.)
 	router.Static("/_app", "./static/_app")
 	router.Static("/assets", "./static/assets")
@@ -101,7 +192,7 @@ func Setup(db *sql.DB, router *gin.Engine, authClient *auth.Client, env *bootstr
 	router.NoRoute(func(c *gin.Context) {
 		// Don't serve the SPA for API routes
 		if strings.HasPrefix(c.Request.URL.Path, "/api") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "API route not found"})
+			apperror.Render(c, middleware.ErrRouteNotFound)
 			return
 		}
 		// Serve the SPA for all other routes
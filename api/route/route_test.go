@@ -1,8 +1,16 @@
 package route
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"miltechserver/bootstrap"
+	"miltechserver/dataversion"
+	"miltechserver/health"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -12,7 +20,7 @@ func TestSetupRegistersPmcsSbsFaultRoutesUnderAuth(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
-	Setup(nil, router, nil, nil, nil)
+	Setup(router, Dependencies{})
 
 	requireRouteRegistered(t, router, http.MethodGet, "/api/v1/auth/pmcs-sbs/equipment/:equipment_id/faults")
 	requireRouteRegistered(t, router, http.MethodPut, "/api/v1/auth/pmcs-sbs/equipment/:equipment_id/faults")
@@ -24,7 +32,7 @@ func TestSetupRegistersPmcsSbsImageRoute(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
-	Setup(nil, router, nil, nil, nil)
+	Setup(router, Dependencies{})
 
 	requireRouteRegistered(t, router, http.MethodGet, "/api/v1/library/pmcs-sbs/image")
 }
@@ -39,3 +47,65 @@ func requireRouteRegistered(t *testing.T, router *gin.Engine, method string, pat
 	}
 	require.Failf(t, "route not registered", "%s %s", method, path)
 }
+
+func TestMetricsRouteRequiresConfiguredToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewMetricsRouter(router, &bootstrap.Env{MetricsToken: "scrape-secret"})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer scrape-secret")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "go_goroutines")
+}
+
+func TestHealthRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	checks := health.NewRegistry()
+	checks.Register("database", true, func(context.Context) (map[string]any, error) {
+		return nil, errors.New("connection refused")
+	})
+	NewHealthRouter(router, checks)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Contains(t, rec.Body.String(), `"error":"connection refused"`)
+}
+
+func TestReferenceRoutesAnswerConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Setup(router, Dependencies{DataVersion: dataversion.New(nil, "2026-09-30")})
+
+	for _, path := range []string{"/api/v1/pol-products", "/api/v1/lookup/lin", "/api/v1/sb700-20/app-b/list"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		// Last-Modified is no earlier than the process start
+		req.Header.Set("If-Modified-Since", time.Now().UTC().Format(http.TimeFormat))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNotModified, rec.Code, path)
+	}
+}
+
+func TestDBDateFollowsDataVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewGeneralQueriesRouter(router.Group("/api/v1"), dataversion.New(nil, "2026-09-30"))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/general/db_date", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"data":"2026-09-30"`)
+}
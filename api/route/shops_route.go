@@ -4,15 +4,23 @@ import (
 	"database/sql"
 	"miltechserver/api/shops"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
+	"miltechserver/jobs"
+	"miltechserver/push"
+	"miltechserver/realtime"
+	"miltechserver/storage"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 )
 
-func NewShopsRouter(db *sql.DB, blobClient *azblob.Client, env *bootstrap.Env, group *gin.RouterGroup) {
+func NewShopsRouter(db *sql.DB, store storage.ObjectStore, env *bootstrap.Env, bus *events.Bus, scheduler *jobs.Scheduler, sender push.Sender, hub *realtime.Hub, group *gin.RouterGroup) {
 	shops.RegisterRoutes(shops.Dependencies{
-		DB:         db,
-		BlobClient: blobClient,
-		Env:        env,
+		DB:     db,
+		Store:  store,
+		Env:    env,
+		Events: bus,
+		Jobs:   scheduler,
+		Push:   sender,
+		Stream: hub,
 	}, group)
 }
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"net/http"
 
+	"miltechserver/api/apperror"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -12,7 +14,7 @@ func NewTestRouter(db *sql.DB, group *gin.RouterGroup) {
 	group.GET("/", func(c *gin.Context) {
 		user, ok := c.Get("user")
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+			c.Error(apperror.ErrUnauthenticated)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"message": "You have access to this route", "user": user})
@@ -0,0 +1,259 @@
+package sb_700_20
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "SB 700-20",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listAppB",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-b/list",
+			Summary:  "List SB 700-20 appendix B",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppB]{},
+		},
+		{
+			ID:       "searchAppB",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-b/search/:lin",
+			Summary:  "Search SB 700-20 appendix B",
+			Response: []model.Sb70020AppB{},
+		},
+		{
+			ID:       "listAppC",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-c/list",
+			Summary:  "List SB 700-20 appendix C",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppC]{},
+		},
+		{
+			ID:       "searchAppC",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-c/search/:lin",
+			Summary:  "Search SB 700-20 appendix C",
+			Response: model.Sb70020AppC{},
+		},
+		{
+			ID:       "listAppD",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-d/list",
+			Summary:  "List SB 700-20 appendix D",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppD]{},
+		},
+		{
+			ID:       "searchAppD",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-d/search/:lin",
+			Summary:  "Search SB 700-20 appendix D",
+			Response: []model.Sb70020AppD{},
+		},
+		{
+			ID:       "listAppE",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-e/list",
+			Summary:  "List SB 700-20 appendix E",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppE]{},
+		},
+		{
+			ID:       "searchAppE",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-e/search/:lin",
+			Summary:  "Search SB 700-20 appendix E",
+			Response: []model.Sb70020AppE{},
+		},
+		{
+			ID:       "listAppF",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-f/list",
+			Summary:  "List SB 700-20 appendix F",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppF]{},
+		},
+		{
+			ID:       "searchAppF",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-f/search/:lin",
+			Summary:  "Search SB 700-20 appendix F",
+			Response: model.Sb70020AppF{},
+		},
+		{
+			ID:       "listAppG",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-g/list",
+			Summary:  "List SB 700-20 appendix G",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppG]{},
+		},
+		{
+			ID:       "searchAppG",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-g/search/:lin",
+			Summary:  "Search SB 700-20 appendix G",
+			Response: model.Sb70020AppG{},
+		},
+		{
+			ID:       "listAppH1",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-h1/list",
+			Summary:  "List SB 700-20 appendix H1",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppH1]{},
+		},
+		{
+			ID:       "searchAppH1",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-h1/search/:lin",
+			Summary:  "Search SB 700-20 appendix H1",
+			Response: []model.Sb70020AppH1{},
+		},
+		{
+			ID:       "listAppH2",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-h2/list",
+			Summary:  "List SB 700-20 appendix H2",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppH2]{},
+		},
+		{
+			ID:       "searchAppH2",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-h2/search/:lin",
+			Summary:  "Search SB 700-20 appendix H2",
+			Response: []model.Sb70020AppH2{},
+		},
+		{
+			ID:       "listAppI",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-i/list",
+			Summary:  "List SB 700-20 appendix I",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppI]{},
+		},
+		{
+			ID:       "searchAppI",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-i/search/:lin",
+			Summary:  "Search SB 700-20 appendix I",
+			Response: model.Sb70020AppI{},
+		},
+		{
+			ID:       "listAppJ",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-j/list",
+			Summary:  "List SB 700-20 appendix J",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020AppJ]{},
+		},
+		{
+			ID:       "searchAppJ",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-j/search/:lin",
+			Summary:  "Search SB 700-20 appendix J",
+			Response: model.Sb70020AppJ{},
+		},
+		{
+			ID:       "listChp4",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-4/list",
+			Summary:  "List SB 700-20 chapter 4",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020Chp4]{},
+		},
+		{
+			ID:       "searchChp4",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-4/search/:lin",
+			Summary:  "Search SB 700-20 chapter 4",
+			Response: model.Sb70020Chp4{},
+		},
+		{
+			ID:       "listChp6",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-6/list",
+			Summary:  "List SB 700-20 chapter 6",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020Chp6]{},
+		},
+		{
+			ID:       "searchChp6",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-6/search/:lin",
+			Summary:  "Search SB 700-20 chapter 6",
+			Response: []model.Sb70020Chp6{},
+		},
+		{
+			ID:       "listChp8",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-8/list",
+			Summary:  "List SB 700-20 chapter 8",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.Sb70020Chp8]{},
+		},
+		{
+			ID:       "searchChp8",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-8/search/:lin",
+			Summary:  "Search SB 700-20 chapter 8",
+			Response: []model.Sb70020Chp8{},
+		},
+		{
+			ID:       "searchAppEByNewLIN",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-e/search-new-lin/:new_lin",
+			Summary:  "Search SB 700-20 appendix E by new LIN",
+			Response: []model.Sb70020AppE{},
+		},
+		{
+			ID:       "searchAppGByNewLIN",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-g/search-new-lin/:new_lin",
+			Summary:  "Search SB 700-20 appendix G by new LIN",
+			Response: []model.Sb70020AppG{},
+		},
+		{
+			ID:       "searchAppH1BySubLIN",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-h1/search-sublin/:sublin",
+			Summary:  "Search SB 700-20 appendix H1 by substitute LIN",
+			Response: []model.Sb70020AppH1{},
+		},
+		{
+			ID:       "searchAppH2BySubLIN",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/app-h2/search-sublin/:sublin",
+			Summary:  "Search SB 700-20 appendix H2 by substitute LIN",
+			Response: []model.Sb70020AppH2{},
+		},
+		{
+			ID:       "searchChp4ByRIC",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-4/search-ric/:ric",
+			Summary:  "Search SB 700-20 chapter 4 by RIC",
+			Response: []model.Sb70020Chp4{},
+		},
+		{
+			ID:       "searchChp6ByRIC",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-6/search-ric/:ric",
+			Summary:  "Search SB 700-20 chapter 6 by RIC",
+			Response: []model.Sb70020Chp6{},
+		},
+		{
+			ID:       "searchChp8ByRIC",
+			Method:   http.MethodGet,
+			Path:     "/sb700-20/chp-8/search-ric/:ric",
+			Summary:  "Search SB 700-20 chapter 8 by RIC",
+			Response: []model.Sb70020Chp8{},
+		},
+	},
+}}
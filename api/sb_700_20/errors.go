@@ -1,9 +1,8 @@
 package sb_700_20
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrNotFound    = errors.New("no records found")
-	ErrEmptyParam  = errors.New("required parameter is empty")
-	ErrInvalidPage = errors.New("page number must be greater than 0")
+	ErrNotFound   = apperror.NotFound("no_records_found", "no records found")
+	ErrEmptyParam = apperror.Validation("required_parameter_empty", "required parameter is empty")
 )
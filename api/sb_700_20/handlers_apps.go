@@ -1,32 +1,25 @@
 package sb_700_20
 
 import (
-	"errors"
 	"net/http"
-	"strconv"
 	"strings"
 
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 
 	"github.com/gin-gonic/gin"
 )
 
 func (h *Handler) listAppB(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppBPaginated(page)
+	data, err := h.service.GetAppBPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -35,39 +28,26 @@ func (h *Handler) listAppB(c *gin.Context) {
 func (h *Handler) searchAppB(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	items, err := h.service.GetAppBByLIN(lin)
+	items, err := h.service.GetAppBByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
 }
 
 func (h *Handler) listAppC(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppCPaginated(page)
+	data, err := h.service.GetAppCPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -76,39 +56,26 @@ func (h *Handler) listAppC(c *gin.Context) {
 func (h *Handler) searchAppC(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	item, err := h.service.GetAppCByLIN(lin)
+	item, err := h.service.GetAppCByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: item})
 }
 
 func (h *Handler) listAppD(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppDPaginated(page)
+	data, err := h.service.GetAppDPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -117,39 +84,26 @@ func (h *Handler) listAppD(c *gin.Context) {
 func (h *Handler) searchAppD(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	items, err := h.service.GetAppDByLIN(lin)
+	items, err := h.service.GetAppDByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
 }
 
 func (h *Handler) listAppE(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppEPaginated(page)
+	data, err := h.service.GetAppEPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -158,39 +112,26 @@ func (h *Handler) listAppE(c *gin.Context) {
 func (h *Handler) searchAppE(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	items, err := h.service.GetAppEByLIN(lin)
+	items, err := h.service.GetAppEByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
 }
 
 func (h *Handler) listAppF(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppFPaginated(page)
+	data, err := h.service.GetAppFPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -199,39 +140,26 @@ func (h *Handler) listAppF(c *gin.Context) {
 func (h *Handler) searchAppF(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	item, err := h.service.GetAppFByLIN(lin)
+	item, err := h.service.GetAppFByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: item})
 }
 
 func (h *Handler) listAppG(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppGPaginated(page)
+	data, err := h.service.GetAppGPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -240,39 +168,26 @@ func (h *Handler) listAppG(c *gin.Context) {
 func (h *Handler) searchAppG(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	item, err := h.service.GetAppGByLIN(lin)
+	item, err := h.service.GetAppGByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: item})
 }
 
 func (h *Handler) listAppH1(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppH1Paginated(page)
+	data, err := h.service.GetAppH1Paginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -281,39 +196,26 @@ func (h *Handler) listAppH1(c *gin.Context) {
 func (h *Handler) searchAppH1(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	items, err := h.service.GetAppH1ByLIN(lin)
+	items, err := h.service.GetAppH1ByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
 }
 
 func (h *Handler) listAppH2(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppH2Paginated(page)
+	data, err := h.service.GetAppH2Paginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -322,39 +224,26 @@ func (h *Handler) listAppH2(c *gin.Context) {
 func (h *Handler) searchAppH2(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	items, err := h.service.GetAppH2ByLIN(lin)
+	items, err := h.service.GetAppH2ByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
 }
 
 func (h *Handler) listAppI(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppIPaginated(page)
+	data, err := h.service.GetAppIPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -363,39 +252,26 @@ func (h *Handler) listAppI(c *gin.Context) {
 func (h *Handler) searchAppI(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	item, err := h.service.GetAppIByLIN(lin)
+	item, err := h.service.GetAppIByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: item})
 }
 
 func (h *Handler) listAppJ(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetAppJPaginated(page)
+	data, err := h.service.GetAppJPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -404,18 +280,12 @@ func (h *Handler) listAppJ(c *gin.Context) {
 func (h *Handler) searchAppJ(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	item, err := h.service.GetAppJByLIN(lin)
+	item, err := h.service.GetAppJByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: item})
@@ -424,18 +294,12 @@ func (h *Handler) searchAppJ(c *gin.Context) {
 func (h *Handler) searchAppEByNewLIN(c *gin.Context) {
 	newLin := c.Param("new_lin")
 	if strings.TrimSpace(newLin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "new_lin parameter is required"})
+		c.Error(apperror.MissingParameter("new_lin"))
 		return
 	}
-	items, err := h.service.GetAppEByNewLIN(newLin)
+	items, err := h.service.GetAppEByNewLIN(c.Request.Context(), newLin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "new_lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
@@ -444,18 +308,12 @@ func (h *Handler) searchAppEByNewLIN(c *gin.Context) {
 func (h *Handler) searchAppGByNewLIN(c *gin.Context) {
 	newLin := c.Param("new_lin")
 	if strings.TrimSpace(newLin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "new_lin parameter is required"})
+		c.Error(apperror.MissingParameter("new_lin"))
 		return
 	}
-	items, err := h.service.GetAppGByNewLIN(newLin)
+	items, err := h.service.GetAppGByNewLIN(c.Request.Context(), newLin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "new_lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
@@ -464,18 +322,12 @@ func (h *Handler) searchAppGByNewLIN(c *gin.Context) {
 func (h *Handler) searchAppH1BySubLIN(c *gin.Context) {
 	sublin := c.Param("sublin")
 	if strings.TrimSpace(sublin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "sublin parameter is required"})
+		c.Error(apperror.MissingParameter("sublin"))
 		return
 	}
-	items, err := h.service.GetAppH1BySubLIN(sublin)
+	items, err := h.service.GetAppH1BySubLIN(c.Request.Context(), sublin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "sublin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
@@ -484,18 +336,12 @@ func (h *Handler) searchAppH1BySubLIN(c *gin.Context) {
 func (h *Handler) searchAppH2BySubLIN(c *gin.Context) {
 	sublin := c.Param("sublin")
 	if strings.TrimSpace(sublin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "sublin parameter is required"})
+		c.Error(apperror.MissingParameter("sublin"))
 		return
 	}
-	items, err := h.service.GetAppH2BySubLIN(sublin)
+	items, err := h.service.GetAppH2BySubLIN(c.Request.Context(), sublin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "sublin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
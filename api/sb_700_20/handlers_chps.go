@@ -1,32 +1,25 @@
 package sb_700_20
 
 import (
-	"errors"
 	"net/http"
-	"strconv"
 	"strings"
 
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 
 	"github.com/gin-gonic/gin"
 )
 
 func (h *Handler) listChp4(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetChp4Paginated(page)
+	data, err := h.service.GetChp4Paginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -35,39 +28,26 @@ func (h *Handler) listChp4(c *gin.Context) {
 func (h *Handler) searchChp4(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	item, err := h.service.GetChp4ByLIN(lin)
+	item, err := h.service.GetChp4ByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: item})
 }
 
 func (h *Handler) listChp6(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetChp6Paginated(page)
+	data, err := h.service.GetChp6Paginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -76,39 +56,26 @@ func (h *Handler) listChp6(c *gin.Context) {
 func (h *Handler) searchChp6(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	items, err := h.service.GetChp6ByLIN(lin)
+	items, err := h.service.GetChp6ByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
 }
 
 func (h *Handler) listChp8(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	data, err := h.service.GetChp8Paginated(page)
+	data, err := h.service.GetChp8Paginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrInvalidPage) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: data})
@@ -117,18 +84,12 @@ func (h *Handler) listChp8(c *gin.Context) {
 func (h *Handler) searchChp8(c *gin.Context) {
 	lin := c.Param("lin")
 	if strings.TrimSpace(lin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
+		c.Error(apperror.MissingParameter("lin"))
 		return
 	}
-	items, err := h.service.GetChp8ByLIN(lin)
+	items, err := h.service.GetChp8ByLIN(c.Request.Context(), lin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "lin parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
@@ -137,18 +98,12 @@ func (h *Handler) searchChp8(c *gin.Context) {
 func (h *Handler) searchChp4ByRIC(c *gin.Context) {
 	ric := c.Param("ric")
 	if strings.TrimSpace(ric) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ric parameter is required"})
+		c.Error(apperror.MissingParameter("ric"))
 		return
 	}
-	items, err := h.service.GetChp4ByRIC(ric)
+	items, err := h.service.GetChp4ByRIC(c.Request.Context(), ric)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "ric parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
@@ -157,18 +112,12 @@ func (h *Handler) searchChp4ByRIC(c *gin.Context) {
 func (h *Handler) searchChp6ByRIC(c *gin.Context) {
 	ric := c.Param("ric")
 	if strings.TrimSpace(ric) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ric parameter is required"})
+		c.Error(apperror.MissingParameter("ric"))
 		return
 	}
-	items, err := h.service.GetChp6ByRIC(ric)
+	items, err := h.service.GetChp6ByRIC(c.Request.Context(), ric)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "ric parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
@@ -177,18 +126,12 @@ func (h *Handler) searchChp6ByRIC(c *gin.Context) {
 func (h *Handler) searchChp8ByRIC(c *gin.Context) {
 	ric := c.Param("ric")
 	if strings.TrimSpace(ric) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ric parameter is required"})
+		c.Error(apperror.MissingParameter("ric"))
 		return
 	}
-	items, err := h.service.GetChp8ByRIC(ric)
+	items, err := h.service.GetChp8ByRIC(c.Request.Context(), ric)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else if errors.Is(err, ErrEmptyParam) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "ric parameter is required"})
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, response.StandardResponse{Status: http.StatusOK, Data: items})
@@ -1,15 +1,17 @@
 package sb_700_20
 
 import (
+	"context"
 	"strings"
 
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/pagination"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
 
-func (r *repository) GetAppBByLIN(lin string) ([]model.Sb70020AppB, error) {
+func (r *repository) GetAppBByLIN(ctx context.Context, lin string) ([]model.Sb70020AppB, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return nil, ErrEmptyParam
@@ -18,7 +20,7 @@ func (r *repository) GetAppBByLIN(lin string) ([]model.Sb70020AppB, error) {
 	stmt := SELECT(table.Sb70020AppB.AllColumns).
 		FROM(table.Sb70020AppB).
 		WHERE(table.Sb70020AppB.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -27,38 +29,13 @@ func (r *repository) GetAppBByLIN(lin string) ([]model.Sb70020AppB, error) {
 	return results, nil
 }
 
-func (r *repository) GetAppBPaginated(page int) (PageResponse[model.Sb70020AppB], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppB]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppB
-	stmt := SELECT(table.Sb70020AppB.AllColumns).
-		FROM(table.Sb70020AppB).
-		ORDER_BY(table.Sb70020AppB.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppB]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppB]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppB)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppB]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppB]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppBPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppB], error) {
+	return listPage(ctx, r.db, table.Sb70020AppB, table.Sb70020AppB.AllColumns, params, func(item model.Sb70020AppB) []any {
+		return []any{item.Lin, item.Nsn}
+	}, table.Sb70020AppB.Lin, table.Sb70020AppB.Nsn)
 }
 
-func (r *repository) GetAppCByLIN(lin string) (model.Sb70020AppC, error) {
+func (r *repository) GetAppCByLIN(ctx context.Context, lin string) (model.Sb70020AppC, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return model.Sb70020AppC{}, ErrEmptyParam
@@ -67,7 +44,7 @@ func (r *repository) GetAppCByLIN(lin string) (model.Sb70020AppC, error) {
 	stmt := SELECT(table.Sb70020AppC.AllColumns).
 		FROM(table.Sb70020AppC).
 		WHERE(table.Sb70020AppC.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return model.Sb70020AppC{}, err
 	}
 	if len(results) == 0 {
@@ -76,38 +53,13 @@ func (r *repository) GetAppCByLIN(lin string) (model.Sb70020AppC, error) {
 	return results[0], nil
 }
 
-func (r *repository) GetAppCPaginated(page int) (PageResponse[model.Sb70020AppC], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppC]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppC
-	stmt := SELECT(table.Sb70020AppC.AllColumns).
-		FROM(table.Sb70020AppC).
-		ORDER_BY(table.Sb70020AppC.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppC]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppC]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppC)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppC]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppC]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppCPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppC], error) {
+	return listPage(ctx, r.db, table.Sb70020AppC, table.Sb70020AppC.AllColumns, params, func(item model.Sb70020AppC) []any {
+		return []any{item.Lin}
+	}, table.Sb70020AppC.Lin)
 }
 
-func (r *repository) GetAppDByLIN(lin string) ([]model.Sb70020AppD, error) {
+func (r *repository) GetAppDByLIN(ctx context.Context, lin string) ([]model.Sb70020AppD, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return nil, ErrEmptyParam
@@ -116,7 +68,7 @@ func (r *repository) GetAppDByLIN(lin string) ([]model.Sb70020AppD, error) {
 	stmt := SELECT(table.Sb70020AppD.AllColumns).
 		FROM(table.Sb70020AppD).
 		WHERE(table.Sb70020AppD.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -125,38 +77,13 @@ func (r *repository) GetAppDByLIN(lin string) ([]model.Sb70020AppD, error) {
 	return results, nil
 }
 
-func (r *repository) GetAppDPaginated(page int) (PageResponse[model.Sb70020AppD], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppD]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppD
-	stmt := SELECT(table.Sb70020AppD.AllColumns).
-		FROM(table.Sb70020AppD).
-		ORDER_BY(table.Sb70020AppD.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppD]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppD]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppD)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppD]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppD]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppDPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppD], error) {
+	return listPage(ctx, r.db, table.Sb70020AppD, table.Sb70020AppD.AllColumns, params, func(item model.Sb70020AppD) []any {
+		return []any{item.Lin, item.Nsn}
+	}, table.Sb70020AppD.Lin, table.Sb70020AppD.Nsn)
 }
 
-func (r *repository) GetAppEByLIN(lin string) ([]model.Sb70020AppE, error) {
+func (r *repository) GetAppEByLIN(ctx context.Context, lin string) ([]model.Sb70020AppE, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return nil, ErrEmptyParam
@@ -165,7 +92,7 @@ func (r *repository) GetAppEByLIN(lin string) ([]model.Sb70020AppE, error) {
 	stmt := SELECT(table.Sb70020AppE.AllColumns).
 		FROM(table.Sb70020AppE).
 		WHERE(table.Sb70020AppE.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -174,38 +101,13 @@ func (r *repository) GetAppEByLIN(lin string) ([]model.Sb70020AppE, error) {
 	return results, nil
 }
 
-func (r *repository) GetAppEPaginated(page int) (PageResponse[model.Sb70020AppE], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppE]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppE
-	stmt := SELECT(table.Sb70020AppE.AllColumns).
-		FROM(table.Sb70020AppE).
-		ORDER_BY(table.Sb70020AppE.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppE]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppE]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppE)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppE]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppE]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppEPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppE], error) {
+	return listPage(ctx, r.db, table.Sb70020AppE, table.Sb70020AppE.AllColumns, params, func(item model.Sb70020AppE) []any {
+		return []any{item.Lin, item.Nsn}
+	}, table.Sb70020AppE.Lin, table.Sb70020AppE.Nsn)
 }
 
-func (r *repository) GetAppFByLIN(lin string) (model.Sb70020AppF, error) {
+func (r *repository) GetAppFByLIN(ctx context.Context, lin string) (model.Sb70020AppF, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return model.Sb70020AppF{}, ErrEmptyParam
@@ -214,7 +116,7 @@ func (r *repository) GetAppFByLIN(lin string) (model.Sb70020AppF, error) {
 	stmt := SELECT(table.Sb70020AppF.AllColumns).
 		FROM(table.Sb70020AppF).
 		WHERE(table.Sb70020AppF.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return model.Sb70020AppF{}, err
 	}
 	if len(results) == 0 {
@@ -223,38 +125,13 @@ func (r *repository) GetAppFByLIN(lin string) (model.Sb70020AppF, error) {
 	return results[0], nil
 }
 
-func (r *repository) GetAppFPaginated(page int) (PageResponse[model.Sb70020AppF], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppF]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppF
-	stmt := SELECT(table.Sb70020AppF.AllColumns).
-		FROM(table.Sb70020AppF).
-		ORDER_BY(table.Sb70020AppF.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppF]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppF]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppF)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppF]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppF]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppFPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppF], error) {
+	return listPage(ctx, r.db, table.Sb70020AppF, table.Sb70020AppF.AllColumns, params, func(item model.Sb70020AppF) []any {
+		return []any{item.Lin}
+	}, table.Sb70020AppF.Lin)
 }
 
-func (r *repository) GetAppGByLIN(lin string) (model.Sb70020AppG, error) {
+func (r *repository) GetAppGByLIN(ctx context.Context, lin string) (model.Sb70020AppG, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return model.Sb70020AppG{}, ErrEmptyParam
@@ -263,7 +140,7 @@ func (r *repository) GetAppGByLIN(lin string) (model.Sb70020AppG, error) {
 	stmt := SELECT(table.Sb70020AppG.AllColumns).
 		FROM(table.Sb70020AppG).
 		WHERE(table.Sb70020AppG.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return model.Sb70020AppG{}, err
 	}
 	if len(results) == 0 {
@@ -272,39 +149,14 @@ func (r *repository) GetAppGByLIN(lin string) (model.Sb70020AppG, error) {
 	return results[0], nil
 }
 
-func (r *repository) GetAppGPaginated(page int) (PageResponse[model.Sb70020AppG], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppG]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppG
-	stmt := SELECT(table.Sb70020AppG.AllColumns).
-		FROM(table.Sb70020AppG).
-		ORDER_BY(table.Sb70020AppG.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppG]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppG]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppG)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppG]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppG]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppGPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppG], error) {
+	return listPage(ctx, r.db, table.Sb70020AppG, table.Sb70020AppG.AllColumns, params, func(item model.Sb70020AppG) []any {
+		return []any{item.Lin}
+	}, table.Sb70020AppG.Lin)
 }
 
 // app_h1 and app_h2 search by lin_zmm_lin, not lin
-func (r *repository) GetAppH1ByLIN(lin string) ([]model.Sb70020AppH1, error) {
+func (r *repository) GetAppH1ByLIN(ctx context.Context, lin string) ([]model.Sb70020AppH1, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return nil, ErrEmptyParam
@@ -313,7 +165,7 @@ func (r *repository) GetAppH1ByLIN(lin string) ([]model.Sb70020AppH1, error) {
 	stmt := SELECT(table.Sb70020AppH1.AllColumns).
 		FROM(table.Sb70020AppH1).
 		WHERE(table.Sb70020AppH1.LinZmmLin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -322,38 +174,13 @@ func (r *repository) GetAppH1ByLIN(lin string) ([]model.Sb70020AppH1, error) {
 	return results, nil
 }
 
-func (r *repository) GetAppH1Paginated(page int) (PageResponse[model.Sb70020AppH1], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppH1]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppH1
-	stmt := SELECT(table.Sb70020AppH1.AllColumns).
-		FROM(table.Sb70020AppH1).
-		ORDER_BY(table.Sb70020AppH1.LinZmmLin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppH1]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppH1]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppH1)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppH1]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppH1]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppH1Paginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppH1], error) {
+	return listPage(ctx, r.db, table.Sb70020AppH1, table.Sb70020AppH1.AllColumns, params, func(item model.Sb70020AppH1) []any {
+		return []any{item.LinZmmLin, item.LinZmmSublin}
+	}, table.Sb70020AppH1.LinZmmLin, table.Sb70020AppH1.LinZmmSublin)
 }
 
-func (r *repository) GetAppH2ByLIN(lin string) ([]model.Sb70020AppH2, error) {
+func (r *repository) GetAppH2ByLIN(ctx context.Context, lin string) ([]model.Sb70020AppH2, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return nil, ErrEmptyParam
@@ -362,7 +189,7 @@ func (r *repository) GetAppH2ByLIN(lin string) ([]model.Sb70020AppH2, error) {
 	stmt := SELECT(table.Sb70020AppH2.AllColumns).
 		FROM(table.Sb70020AppH2).
 		WHERE(table.Sb70020AppH2.LinZmmLin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -371,38 +198,13 @@ func (r *repository) GetAppH2ByLIN(lin string) ([]model.Sb70020AppH2, error) {
 	return results, nil
 }
 
-func (r *repository) GetAppH2Paginated(page int) (PageResponse[model.Sb70020AppH2], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppH2]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppH2
-	stmt := SELECT(table.Sb70020AppH2.AllColumns).
-		FROM(table.Sb70020AppH2).
-		ORDER_BY(table.Sb70020AppH2.LinZmmLin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppH2]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppH2]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppH2)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppH2]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppH2]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppH2Paginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppH2], error) {
+	return listPage(ctx, r.db, table.Sb70020AppH2, table.Sb70020AppH2.AllColumns, params, func(item model.Sb70020AppH2) []any {
+		return []any{item.LinZmmLin, item.LinZmmsublin}
+	}, table.Sb70020AppH2.LinZmmLin, table.Sb70020AppH2.LinZmmsublin)
 }
 
-func (r *repository) GetAppIByLIN(lin string) (model.Sb70020AppI, error) {
+func (r *repository) GetAppIByLIN(ctx context.Context, lin string) (model.Sb70020AppI, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return model.Sb70020AppI{}, ErrEmptyParam
@@ -411,7 +213,7 @@ func (r *repository) GetAppIByLIN(lin string) (model.Sb70020AppI, error) {
 	stmt := SELECT(table.Sb70020AppI.AllColumns).
 		FROM(table.Sb70020AppI).
 		WHERE(table.Sb70020AppI.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return model.Sb70020AppI{}, err
 	}
 	if len(results) == 0 {
@@ -420,38 +222,13 @@ func (r *repository) GetAppIByLIN(lin string) (model.Sb70020AppI, error) {
 	return results[0], nil
 }
 
-func (r *repository) GetAppIPaginated(page int) (PageResponse[model.Sb70020AppI], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppI]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppI
-	stmt := SELECT(table.Sb70020AppI.AllColumns).
-		FROM(table.Sb70020AppI).
-		ORDER_BY(table.Sb70020AppI.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppI]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppI]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppI)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppI]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppI]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppIPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppI], error) {
+	return listPage(ctx, r.db, table.Sb70020AppI, table.Sb70020AppI.AllColumns, params, func(item model.Sb70020AppI) []any {
+		return []any{item.Lin}
+	}, table.Sb70020AppI.Lin)
 }
 
-func (r *repository) GetAppJByLIN(lin string) (model.Sb70020AppJ, error) {
+func (r *repository) GetAppJByLIN(ctx context.Context, lin string) (model.Sb70020AppJ, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return model.Sb70020AppJ{}, ErrEmptyParam
@@ -460,7 +237,7 @@ func (r *repository) GetAppJByLIN(lin string) (model.Sb70020AppJ, error) {
 	stmt := SELECT(table.Sb70020AppJ.AllColumns).
 		FROM(table.Sb70020AppJ).
 		WHERE(table.Sb70020AppJ.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return model.Sb70020AppJ{}, err
 	}
 	if len(results) == 0 {
@@ -469,38 +246,13 @@ func (r *repository) GetAppJByLIN(lin string) (model.Sb70020AppJ, error) {
 	return results[0], nil
 }
 
-func (r *repository) GetAppJPaginated(page int) (PageResponse[model.Sb70020AppJ], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020AppJ]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020AppJ
-	stmt := SELECT(table.Sb70020AppJ.AllColumns).
-		FROM(table.Sb70020AppJ).
-		ORDER_BY(table.Sb70020AppJ.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020AppJ]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020AppJ]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020AppJ)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020AppJ]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020AppJ]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetAppJPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020AppJ], error) {
+	return listPage(ctx, r.db, table.Sb70020AppJ, table.Sb70020AppJ.AllColumns, params, func(item model.Sb70020AppJ) []any {
+		return []any{item.Lin}
+	}, table.Sb70020AppJ.Lin)
 }
 
-func (r *repository) GetAppEByNewLIN(newLin string) ([]model.Sb70020AppE, error) {
+func (r *repository) GetAppEByNewLIN(ctx context.Context, newLin string) ([]model.Sb70020AppE, error) {
 	newLin = strings.TrimSpace(newLin)
 	if newLin == "" {
 		return nil, ErrEmptyParam
@@ -509,7 +261,7 @@ func (r *repository) GetAppEByNewLIN(newLin string) ([]model.Sb70020AppE, error)
 	stmt := SELECT(table.Sb70020AppE.AllColumns).
 		FROM(table.Sb70020AppE).
 		WHERE(table.Sb70020AppE.NewLin.EQ(String(newLin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -518,7 +270,7 @@ func (r *repository) GetAppEByNewLIN(newLin string) ([]model.Sb70020AppE, error)
 	return results, nil
 }
 
-func (r *repository) GetAppGByNewLIN(newLin string) ([]model.Sb70020AppG, error) {
+func (r *repository) GetAppGByNewLIN(ctx context.Context, newLin string) ([]model.Sb70020AppG, error) {
 	newLin = strings.TrimSpace(newLin)
 	if newLin == "" {
 		return nil, ErrEmptyParam
@@ -527,7 +279,7 @@ func (r *repository) GetAppGByNewLIN(newLin string) ([]model.Sb70020AppG, error)
 	stmt := SELECT(table.Sb70020AppG.AllColumns).
 		FROM(table.Sb70020AppG).
 		WHERE(table.Sb70020AppG.NewLin.EQ(String(newLin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -536,7 +288,7 @@ func (r *repository) GetAppGByNewLIN(newLin string) ([]model.Sb70020AppG, error)
 	return results, nil
 }
 
-func (r *repository) GetAppH1BySubLIN(sublin string) ([]model.Sb70020AppH1, error) {
+func (r *repository) GetAppH1BySubLIN(ctx context.Context, sublin string) ([]model.Sb70020AppH1, error) {
 	sublin = strings.TrimSpace(sublin)
 	if sublin == "" {
 		return nil, ErrEmptyParam
@@ -545,7 +297,7 @@ func (r *repository) GetAppH1BySubLIN(sublin string) ([]model.Sb70020AppH1, erro
 	stmt := SELECT(table.Sb70020AppH1.AllColumns).
 		FROM(table.Sb70020AppH1).
 		WHERE(table.Sb70020AppH1.LinZmmSublin.EQ(String(sublin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -554,7 +306,7 @@ func (r *repository) GetAppH1BySubLIN(sublin string) ([]model.Sb70020AppH1, erro
 	return results, nil
 }
 
-func (r *repository) GetAppH2BySubLIN(sublin string) ([]model.Sb70020AppH2, error) {
+func (r *repository) GetAppH2BySubLIN(ctx context.Context, sublin string) ([]model.Sb70020AppH2, error) {
 	sublin = strings.TrimSpace(sublin)
 	if sublin == "" {
 		return nil, ErrEmptyParam
@@ -563,7 +315,7 @@ func (r *repository) GetAppH2BySubLIN(sublin string) ([]model.Sb70020AppH2, erro
 	stmt := SELECT(table.Sb70020AppH2.AllColumns).
 		FROM(table.Sb70020AppH2).
 		WHERE(table.Sb70020AppH2.LinZmmsublin.EQ(String(sublin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -1,15 +1,17 @@
 package sb_700_20
 
 import (
+	"context"
 	"strings"
 
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/pagination"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
 
-func (r *repository) GetChp4ByLIN(lin string) (model.Sb70020Chp4, error) {
+func (r *repository) GetChp4ByLIN(ctx context.Context, lin string) (model.Sb70020Chp4, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return model.Sb70020Chp4{}, ErrEmptyParam
@@ -18,7 +20,7 @@ func (r *repository) GetChp4ByLIN(lin string) (model.Sb70020Chp4, error) {
 	stmt := SELECT(table.Sb70020Chp4.AllColumns).
 		FROM(table.Sb70020Chp4).
 		WHERE(table.Sb70020Chp4.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return model.Sb70020Chp4{}, err
 	}
 	if len(results) == 0 {
@@ -27,38 +29,13 @@ func (r *repository) GetChp4ByLIN(lin string) (model.Sb70020Chp4, error) {
 	return results[0], nil
 }
 
-func (r *repository) GetChp4Paginated(page int) (PageResponse[model.Sb70020Chp4], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020Chp4]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020Chp4
-	stmt := SELECT(table.Sb70020Chp4.AllColumns).
-		FROM(table.Sb70020Chp4).
-		ORDER_BY(table.Sb70020Chp4.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020Chp4]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020Chp4]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020Chp4)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020Chp4]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020Chp4]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetChp4Paginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020Chp4], error) {
+	return listPage(ctx, r.db, table.Sb70020Chp4, table.Sb70020Chp4.AllColumns, params, func(item model.Sb70020Chp4) []any {
+		return []any{item.Lin}
+	}, table.Sb70020Chp4.Lin)
 }
 
-func (r *repository) GetChp6ByLIN(lin string) ([]model.Sb70020Chp6, error) {
+func (r *repository) GetChp6ByLIN(ctx context.Context, lin string) ([]model.Sb70020Chp6, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return nil, ErrEmptyParam
@@ -67,7 +44,7 @@ func (r *repository) GetChp6ByLIN(lin string) ([]model.Sb70020Chp6, error) {
 	stmt := SELECT(table.Sb70020Chp6.AllColumns).
 		FROM(table.Sb70020Chp6).
 		WHERE(table.Sb70020Chp6.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -76,38 +53,13 @@ func (r *repository) GetChp6ByLIN(lin string) ([]model.Sb70020Chp6, error) {
 	return results, nil
 }
 
-func (r *repository) GetChp6Paginated(page int) (PageResponse[model.Sb70020Chp6], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020Chp6]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020Chp6
-	stmt := SELECT(table.Sb70020Chp6.AllColumns).
-		FROM(table.Sb70020Chp6).
-		ORDER_BY(table.Sb70020Chp6.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020Chp6]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020Chp6]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020Chp6)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020Chp6]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020Chp6]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetChp6Paginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020Chp6], error) {
+	return listPage(ctx, r.db, table.Sb70020Chp6, table.Sb70020Chp6.AllColumns, params, func(item model.Sb70020Chp6) []any {
+		return []any{item.Lin, item.CurrentMcn}
+	}, table.Sb70020Chp6.Lin, table.Sb70020Chp6.CurrentMcn)
 }
 
-func (r *repository) GetChp8ByLIN(lin string) ([]model.Sb70020Chp8, error) {
+func (r *repository) GetChp8ByLIN(ctx context.Context, lin string) ([]model.Sb70020Chp8, error) {
 	lin = strings.TrimSpace(lin)
 	if lin == "" {
 		return nil, ErrEmptyParam
@@ -116,7 +68,7 @@ func (r *repository) GetChp8ByLIN(lin string) ([]model.Sb70020Chp8, error) {
 	stmt := SELECT(table.Sb70020Chp8.AllColumns).
 		FROM(table.Sb70020Chp8).
 		WHERE(table.Sb70020Chp8.Lin.EQ(String(lin)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -125,38 +77,13 @@ func (r *repository) GetChp8ByLIN(lin string) ([]model.Sb70020Chp8, error) {
 	return results, nil
 }
 
-func (r *repository) GetChp8Paginated(page int) (PageResponse[model.Sb70020Chp8], error) {
-	if page < 1 {
-		return PageResponse[model.Sb70020Chp8]{}, ErrInvalidPage
-	}
-	offset := pageSize * int64(page-1)
-	var items []model.Sb70020Chp8
-	stmt := SELECT(table.Sb70020Chp8.AllColumns).
-		FROM(table.Sb70020Chp8).
-		ORDER_BY(table.Sb70020Chp8.Lin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
-	if err := stmt.Query(r.db, &items); err != nil {
-		return PageResponse[model.Sb70020Chp8]{}, err
-	}
-	if len(items) == 0 {
-		return PageResponse[model.Sb70020Chp8]{}, ErrNotFound
-	}
-	var dest struct {
-		Count int64 `sql:"count"`
-	}
-	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(table.Sb70020Chp8)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return PageResponse[model.Sb70020Chp8]{}, err
-	}
-	totalPages := int((dest.Count + pageSize - 1) / pageSize)
-	return PageResponse[model.Sb70020Chp8]{
-		Items: items, Count: len(items), Page: page,
-		TotalPages: totalPages, IsLastPage: page >= totalPages,
-	}, nil
+func (r *repository) GetChp8Paginated(ctx context.Context, params pagination.Params) (pagination.Page[model.Sb70020Chp8], error) {
+	return listPage(ctx, r.db, table.Sb70020Chp8, table.Sb70020Chp8.AllColumns, params, func(item model.Sb70020Chp8) []any {
+		return []any{item.Lin, item.CurrentMcn}
+	}, table.Sb70020Chp8.Lin, table.Sb70020Chp8.CurrentMcn)
 }
 
-func (r *repository) GetChp4ByRIC(ric string) ([]model.Sb70020Chp4, error) {
+func (r *repository) GetChp4ByRIC(ctx context.Context, ric string) ([]model.Sb70020Chp4, error) {
 	ric = strings.TrimSpace(ric)
 	if ric == "" {
 		return nil, ErrEmptyParam
@@ -165,7 +92,7 @@ func (r *repository) GetChp4ByRIC(ric string) ([]model.Sb70020Chp4, error) {
 	stmt := SELECT(table.Sb70020Chp4.AllColumns).
 		FROM(table.Sb70020Chp4).
 		WHERE(table.Sb70020Chp4.Ric.EQ(String(ric)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -174,7 +101,7 @@ func (r *repository) GetChp4ByRIC(ric string) ([]model.Sb70020Chp4, error) {
 	return results, nil
 }
 
-func (r *repository) GetChp6ByRIC(ric string) ([]model.Sb70020Chp6, error) {
+func (r *repository) GetChp6ByRIC(ctx context.Context, ric string) ([]model.Sb70020Chp6, error) {
 	ric = strings.TrimSpace(ric)
 	if ric == "" {
 		return nil, ErrEmptyParam
@@ -183,7 +110,7 @@ func (r *repository) GetChp6ByRIC(ric string) ([]model.Sb70020Chp6, error) {
 	stmt := SELECT(table.Sb70020Chp6.AllColumns).
 		FROM(table.Sb70020Chp6).
 		WHERE(table.Sb70020Chp6.Ric.EQ(String(ric)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
@@ -192,7 +119,7 @@ func (r *repository) GetChp6ByRIC(ric string) ([]model.Sb70020Chp6, error) {
 	return results, nil
 }
 
-func (r *repository) GetChp8ByRIC(ric string) ([]model.Sb70020Chp8, error) {
+func (r *repository) GetChp8ByRIC(ctx context.Context, ric string) ([]model.Sb70020Chp8, error) {
 	ric = strings.TrimSpace(ric)
 	if ric == "" {
 		return nil, ErrEmptyParam
@@ -201,7 +128,7 @@ func (r *repository) GetChp8ByRIC(ric string) ([]model.Sb70020Chp8, error) {
 	stmt := SELECT(table.Sb70020Chp8.AllColumns).
 		FROM(table.Sb70020Chp8).
 		WHERE(table.Sb70020Chp8.Ric.EQ(String(ric)))
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return nil, err
 	}
 	if len(results) == 0 {
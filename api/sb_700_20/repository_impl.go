@@ -1,8 +1,13 @@
 package sb_700_20
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
 
-const pageSize = int64(100)
+	"miltechserver/api/pagination"
+
+	. "github.com/go-jet/jet/v2/postgres"
+)
 
 type repository struct {
 	db *sql.DB
@@ -11,3 +16,54 @@ type repository struct {
 func NewRepository(db *sql.DB) Repository {
 	return &repository{db: db}
 }
+
+// listPage reads the page of from that follows params.Cursor, ordered by
+// keys, which together must be unique in the table. key returns the values of
+// keys for an item, in the same order.
+func listPage[T any](ctx context.Context, db *sql.DB, from Table, columns ColumnList, params pagination.Params, key func(T) []any, keys ...ColumnString) (pagination.Page[T], error) {
+	after := make([]string, len(keys))
+	targets := make([]any, len(keys))
+	for i := range after {
+		targets[i] = &after[i]
+	}
+	hasCursor, err := params.After(targets...)
+	if err != nil {
+		return pagination.Page[T]{}, err
+	}
+
+	orderBy := make([]OrderByClause, len(keys))
+	keyColumns := make([]Expression, len(keys))
+	keyValues := make([]Expression, len(keys))
+	for i, column := range keys {
+		orderBy[i] = column.ASC()
+		keyColumns[i] = column
+		keyValues[i] = String(after[i])
+	}
+
+	stmt := SELECT(columns).
+		FROM(from).
+		ORDER_BY(orderBy...).
+		LIMIT(params.Fetch())
+	if hasCursor {
+		stmt = stmt.WHERE(ROW(keyColumns...).GT(ROW(keyValues...)))
+	}
+
+	var items []T
+	if err := stmt.QueryContext(ctx, db, &items); err != nil {
+		return pagination.Page[T]{}, err
+	}
+
+	page := pagination.NewPage(items, params, key)
+	if !params.IncludeTotal {
+		return page, nil
+	}
+
+	var dest struct {
+		Count int64 `sql:"count"`
+	}
+	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(from)
+	if err := countStmt.QueryContext(ctx, db, &dest); err != nil {
+		return pagination.Page[T]{}, err
+	}
+	return page.WithTotal(int(dest.Count)), nil
+}
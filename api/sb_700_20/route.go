@@ -6,6 +6,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// defaultLimit is the list page size when the client sends no limit.
+const defaultLimit = 100
+
 type Dependencies struct {
 	DB *sql.DB
 }
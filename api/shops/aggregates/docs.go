@@ -0,0 +1,54 @@
+package aggregates
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shops",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getBootstrap",
+			Method:   http.MethodGet,
+			Path:     "/shops/bootstrap",
+			Auth:     true,
+			Summary:  "Get bootstrap",
+			Response: response.ShopsBootstrapResponse{},
+		},
+		{
+			ID:       "getShopSnapshot",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/snapshot",
+			Auth:     true,
+			Summary:  "Get shop snapshot",
+			Response: response.ShopSnapshotResponse{},
+		},
+		{
+			ID:       "getListsWithItems",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/lists-with-items",
+			Auth:     true,
+			Summary:  "Get lists with items",
+			Response: response.ShopListsWithItemsResponse{},
+		},
+		{
+			ID:       "getVehicleMaintenanceSnapshot",
+			Method:   http.MethodGet,
+			Path:     "/shops/vehicles/:vehicle_id/maintenance-snapshot",
+			Auth:     true,
+			Summary:  "Get vehicle maintenance snapshot",
+			Response: response.VehicleMaintenanceSnapshotResponse{},
+		},
+		{
+			ID:       "getEquipmentPmcsHistory",
+			Method:   http.MethodGet,
+			Path:     "/shops/equipment-pmcs-history",
+			Auth:     true,
+			Summary:  "Get equipment PMCS history",
+			Response: response.EquipmentPmcsHistoryResponse{},
+		},
+	},
+}}
@@ -1,11 +1,11 @@
 package aggregates
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrUnauthorized         = errors.New("unauthorized")
-	ErrAccessDenied         = errors.New("access denied")
-	ErrInvalidLimit         = errors.New("invalid limit")
-	ErrInvalidInclude       = errors.New("invalid include")
-	ErrAggregateUnavailable = errors.New("failed to retrieve shops aggregate")
+	ErrUnauthorized         = apperror.Unauthorized("unauthorized", "unauthorized")
+	ErrAccessDenied         = apperror.Forbidden("shop_access_denied", "access denied")
+	ErrInvalidLimit         = apperror.Validation("invalid_limit", "invalid limit")
+	ErrInvalidInclude       = apperror.Validation("invalid_include", "invalid include")
+	ErrAggregateUnavailable = apperror.New(apperror.KindInternal, "aggregate_unavailable", "failed to retrieve shops aggregate")
 )
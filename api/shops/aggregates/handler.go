@@ -1,11 +1,11 @@
 package aggregates
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
 
@@ -25,7 +25,7 @@ func getUser(c *gin.Context) (*bootstrap.User, bool) {
 func (handler Handler) getListsWithItems(c *gin.Context) {
 	user, ok := getUser(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		writeAggregateError(c, ErrUnauthorized)
 		return
 	}
 
@@ -50,7 +50,7 @@ func (handler Handler) getListsWithItems(c *gin.Context) {
 func (handler Handler) getVehicleMaintenanceSnapshot(c *gin.Context) {
 	user, ok := getUser(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		writeAggregateError(c, ErrUnauthorized)
 		return
 	}
 
@@ -75,7 +75,7 @@ func (handler Handler) getVehicleMaintenanceSnapshot(c *gin.Context) {
 func (handler Handler) getShopSnapshot(c *gin.Context) {
 	user, ok := getUser(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		writeAggregateError(c, ErrUnauthorized)
 		return
 	}
 
@@ -100,7 +100,7 @@ func (handler Handler) getShopSnapshot(c *gin.Context) {
 func (handler Handler) getBootstrap(c *gin.Context) {
 	user, ok := getUser(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		writeAggregateError(c, ErrUnauthorized)
 		return
 	}
 
@@ -125,7 +125,7 @@ func (handler Handler) getBootstrap(c *gin.Context) {
 func (handler Handler) getEquipmentPmcsHistory(c *gin.Context) {
 	user, ok := getUser(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		writeAggregateError(c, ErrUnauthorized)
 		return
 	}
 
@@ -283,19 +283,12 @@ func parseOptionalIntQuery(c *gin.Context, key string) (int, error) {
 	return value, nil
 }
 
+// writeAggregateError renders err inline rather than through c.Error: these
+// routes sit behind gzip, whose writer is closed by the time
+// middleware.ErrorHandler runs.
 func writeAggregateError(c *gin.Context, err error) {
-	switch {
-	case errors.Is(err, ErrUnauthorized):
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
-	case errors.Is(err, ErrAccessDenied):
-		c.JSON(http.StatusForbidden, gin.H{"message": "access denied"})
-	case errors.Is(err, ErrInvalidLimit), errors.Is(err, ErrInvalidInclude):
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
-	default:
-		c.JSON(http.StatusInternalServerError, response.StandardResponse{
-			Status:  http.StatusInternalServerError,
-			Message: ErrAggregateUnavailable.Error(),
-			Data:    nil,
-		})
+	if apperror.As(err).Kind == apperror.KindInternal {
+		err = ErrAggregateUnavailable.WithCause(err)
 	}
+	apperror.Render(c, err)
 }
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"miltechserver/api/middleware"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
 
@@ -41,6 +42,7 @@ func (s serviceStub) GetEquipmentPmcsHistory(context.Context, *bootstrap.User) (
 func TestListsWithItemsRequiresUser(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	RegisterRoutes(router.Group("/api/v1/auth"), serviceStub{})
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/shops/shop-1/lists-with-items", nil)
@@ -52,6 +54,7 @@ func TestListsWithItemsRequiresUser(t *testing.T) {
 func TestListsWithItemsReturnsStandardResponse(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	router.Use(func(c *gin.Context) {
 		c.Set("user", &bootstrap.User{UserID: "user-1"})
 		c.Next()
@@ -92,6 +95,7 @@ func TestListsWithItemsRejectsInvalidSuppliedLimitValues(t *testing.T) {
 		t.Run(path, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
 			router := gin.New()
+			router.Use(middleware.ErrorHandler)
 			router.Use(func(c *gin.Context) {
 				c.Set("user", &bootstrap.User{UserID: "user-1"})
 				c.Next()
@@ -126,6 +130,7 @@ func TestVehicleMaintenanceSnapshotRejectsInvalidSuppliedLimitValues(t *testing.
 		t.Run(path, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
 			router := gin.New()
+			router.Use(middleware.ErrorHandler)
 			router.Use(func(c *gin.Context) {
 				c.Set("user", &bootstrap.User{UserID: "user-1"})
 				c.Next()
@@ -144,6 +149,7 @@ func TestVehicleMaintenanceSnapshotRejectsInvalidSuppliedLimitValues(t *testing.
 func TestEquipmentPmcsHistoryRequiresUser(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	RegisterRoutes(router.Group("/api/v1/auth"), serviceStub{})
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/shops/equipment-pmcs-history", nil)
@@ -155,6 +161,7 @@ func TestEquipmentPmcsHistoryRequiresUser(t *testing.T) {
 func TestEquipmentPmcsHistoryReturnsStandardResponse(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	router.Use(func(c *gin.Context) {
 		c.Set("user", &bootstrap.User{UserID: "user-1"})
 		c.Next()
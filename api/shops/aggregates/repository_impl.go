@@ -12,6 +12,7 @@ import (
 	"miltechserver/api/response"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/tracing"
 
 	. "github.com/go-jet/jet/v2/postgres"
 	"github.com/google/uuid"
@@ -442,8 +443,12 @@ LIMIT NULLIF($2, 0)`
 	return services, nil
 }
 
+// GetShopSnapshot assembles the requested snapshot sections one query at a
+// time. Each section gets its own span so slow sections show up in traces.
 func (repo *RepositoryImpl) GetShopSnapshot(ctx context.Context, user *bootstrap.User, shopID string, options ShopSnapshotOptions) (*response.ShopSnapshotResponse, error) {
-	summary, err := repo.getShopSnapshotSummary(ctx, user, shopID)
+	sectionCtx, span := tracing.Start(ctx, "aggregates.getShopSnapshotSummary")
+	summary, err := repo.getShopSnapshotSummary(sectionCtx, user, shopID)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -469,45 +474,57 @@ func (repo *RepositoryImpl) GetShopSnapshot(ctx context.Context, user *bootstrap
 	}
 
 	if includes["vehicles"] {
-		vehicles, err := repo.getShopSnapshotVehicles(ctx, user, shopID, options.VehiclesLimit)
+		sectionCtx, span := tracing.Start(ctx, "aggregates.getShopSnapshotVehicles")
+		vehicles, err := repo.getShopSnapshotVehicles(sectionCtx, user, shopID, options.VehiclesLimit)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, err
 		}
 		result.Vehicles = vehicles
 	}
 	if includes["lists"] {
-		lists, err := repo.GetListsWithItems(ctx, user, shopID, ListTreeLimits{
+		sectionCtx, span := tracing.Start(ctx, "aggregates.GetListsWithItems")
+		lists, err := repo.GetListsWithItems(sectionCtx, user, shopID, ListTreeLimits{
 			ListsLimit:        options.ListsLimit,
 			ItemsLimitPerList: options.ItemsLimitPerList,
 		})
+		tracing.End(span, err)
 		if err != nil {
 			return nil, err
 		}
 		result.Lists = lists
 	}
 	if includes["notifications"] {
-		notifications, err := repo.getShopNotificationsWithItems(ctx, user, shopID, options.NotificationsLimit, options.NotificationItemsLimit)
+		sectionCtx, span := tracing.Start(ctx, "aggregates.getShopNotificationsWithItems")
+		notifications, err := repo.getShopNotificationsWithItems(sectionCtx, user, shopID, options.NotificationsLimit, options.NotificationItemsLimit)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, err
 		}
 		result.Notifications = notifications
 	}
 	if includes["messages"] {
-		messages, err := repo.getShopSnapshotMessages(ctx, user, shopID, options.MessageLimit)
+		sectionCtx, span := tracing.Start(ctx, "aggregates.getShopSnapshotMessages")
+		messages, err := repo.getShopSnapshotMessages(sectionCtx, user, shopID, options.MessageLimit)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, err
 		}
 		result.Messages = messages
 	}
 	if includes["services"] {
-		services, err := repo.getShopSnapshotServices(ctx, user, shopID, options.ServicesLimit)
+		sectionCtx, span := tracing.Start(ctx, "aggregates.getShopSnapshotServices")
+		services, err := repo.getShopSnapshotServices(sectionCtx, user, shopID, options.ServicesLimit)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, err
 		}
 		result.Services = services
 	}
 	if includes["changes"] {
-		changes, err := repo.getShopSnapshotRecentChanges(ctx, user, shopID, options.ChangesLimit)
+		sectionCtx, span := tracing.Start(ctx, "aggregates.getShopSnapshotRecentChanges")
+		changes, err := repo.getShopSnapshotRecentChanges(sectionCtx, user, shopID, options.ChangesLimit)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, err
 		}
@@ -53,8 +53,8 @@ func optionalLimitPtr(value int) *int {
 	return &value
 }
 
-func (s *ServiceImpl) requireShopMember(user *bootstrap.User, shopID string) error {
-	if err := s.auth.RequireShopMember(user, shopID); err != nil {
+func (s *ServiceImpl) requireShopMember(ctx context.Context, user *bootstrap.User, shopID string) error {
+	if err := s.auth.RequireShopMember(ctx, user, shopID); err != nil {
 		if errors.Is(err, shared.ErrShopAccessDenied) {
 			return fmt.Errorf("%w: %w", ErrAccessDenied, err)
 		}
@@ -67,7 +67,7 @@ func (s *ServiceImpl) GetListsWithItems(ctx context.Context, user *bootstrap.Use
 	if user == nil {
 		return nil, ErrUnauthorized
 	}
-	if err := s.requireShopMember(user, shopID); err != nil {
+	if err := s.requireShopMember(ctx, user, shopID); err != nil {
 		return nil, err
 	}
 	limits = normalizeListTreeLimits(limits)
@@ -167,7 +167,7 @@ func (s *ServiceImpl) GetShopSnapshot(ctx context.Context, user *bootstrap.User,
 	if user == nil {
 		return nil, ErrUnauthorized
 	}
-	if err := s.requireShopMember(user, shopID); err != nil {
+	if err := s.requireShopMember(ctx, user, shopID); err != nil {
 		return nil, err
 	}
 	options.VehiclesLimit = normalizeOptionalLimit(options.VehiclesLimit, maxVehiclesLimit)
@@ -17,35 +17,35 @@ type authStubForService struct {
 	requireShopMemberErr error
 }
 
-func (a authStubForService) IsUserMemberOfShop(*bootstrap.User, string) (bool, error) {
+func (a authStubForService) IsUserMemberOfShop(context.Context, *bootstrap.User, string) (bool, error) {
 	return false, errors.New("unexpected IsUserMemberOfShop call")
 }
 
-func (a authStubForService) IsUserShopAdmin(*bootstrap.User, string) (bool, error) {
+func (a authStubForService) IsUserShopAdmin(context.Context, *bootstrap.User, string) (bool, error) {
 	return false, errors.New("unexpected IsUserShopAdmin call")
 }
 
-func (a authStubForService) GetUserRoleInShop(*bootstrap.User, string) (string, error) {
+func (a authStubForService) GetUserRoleInShop(context.Context, *bootstrap.User, string) (string, error) {
 	return "", errors.New("unexpected GetUserRoleInShop call")
 }
 
-func (a authStubForService) CanUserModifyVehicle(*bootstrap.User, string) (bool, error) {
+func (a authStubForService) CanUserModifyVehicle(context.Context, *bootstrap.User, string) (bool, error) {
 	return false, errors.New("unexpected CanUserModifyVehicle call")
 }
 
-func (a authStubForService) CanUserModifyList(*bootstrap.User, string) (bool, error) {
+func (a authStubForService) CanUserModifyList(context.Context, *bootstrap.User, string) (bool, error) {
 	return false, errors.New("unexpected CanUserModifyList call")
 }
 
-func (a authStubForService) CanUserModifyNotification(*bootstrap.User, string) (bool, error) {
+func (a authStubForService) CanUserModifyNotification(context.Context, *bootstrap.User, string) (bool, error) {
 	return false, errors.New("unexpected CanUserModifyNotification call")
 }
 
-func (a authStubForService) RequireShopMember(*bootstrap.User, string) error {
+func (a authStubForService) RequireShopMember(context.Context, *bootstrap.User, string) error {
 	return a.requireShopMemberErr
 }
 
-func (a authStubForService) RequireShopAdmin(*bootstrap.User, string) error {
+func (a authStubForService) RequireShopAdmin(context.Context, *bootstrap.User, string) error {
 	return errors.New("unexpected RequireShopAdmin call")
 }
 
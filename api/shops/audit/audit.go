@@ -0,0 +1,143 @@
+// Package audit is the append-only log of shop-scoped mutations. Services
+// call Recorder.Record in the same transaction as each change, with the
+// entity's state before and after it, so an event is stored exactly when its
+// change commits; shop admins read the log from GET /shops/:shop_id/audit.
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"miltechserver/dbtx"
+	"time"
+)
+
+// EntityType names the kind of record an event changed.
+type EntityType string
+
+const (
+	EntityShop                EntityType = "shop"
+	EntityShopSettings        EntityType = "shop_settings"
+	EntityShopMember          EntityType = "shop_member"
+	EntityInviteCode          EntityType = "invite_code"
+	EntityShopList            EntityType = "shop_list"
+	EntityShopListItem        EntityType = "shop_list_item"
+	EntityShopVehicle         EntityType = "shop_vehicle"
+	EntityVehicleNotification EntityType = "vehicle_notification"
+	EntityNotificationItem    EntityType = "notification_item"
+	EntityEquipmentService    EntityType = "equipment_service"
+	EntityWebhook             EntityType = "webhook"
+)
+
+// EntityTypes lists every entity type, for validating filters.
+var EntityTypes = []EntityType{
+	EntityShop,
+	EntityShopSettings,
+	EntityShopMember,
+	EntityInviteCode,
+	EntityShopList,
+	EntityShopListItem,
+	EntityShopVehicle,
+	EntityVehicleNotification,
+	EntityNotificationItem,
+	EntityEquipmentService,
+	EntityWebhook,
+}
+
+// Action is what happened to the entity.
+type Action string
+
+const (
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionDelete     Action = "delete"
+	ActionJoin       Action = "join"
+	ActionLeave      Action = "leave"
+	ActionRemove     Action = "remove"
+	ActionPromote    Action = "promote"
+	ActionDeactivate Action = "deactivate"
+	ActionComplete   Action = "complete"
+	ActionReopen     Action = "reopen"
+)
+
+// Actions lists every action, for validating filters.
+var Actions = []Action{
+	ActionCreate,
+	ActionUpdate,
+	ActionDelete,
+	ActionJoin,
+	ActionLeave,
+	ActionRemove,
+	ActionPromote,
+	ActionDeactivate,
+	ActionComplete,
+	ActionReopen,
+}
+
+// Entry is one mutation to record. Before is nil for creations and After is
+// nil for deletions; both are stored as JSON.
+type Entry struct {
+	ShopID     string
+	ActorID    string
+	EntityType EntityType
+	EntityID   string
+	Action     Action
+	Before     any
+	After      any
+}
+
+// Recorder records mutations. A service runs the mutation and Record in one
+// InTx, so a failed record rolls the mutation back and a rolled-back
+// mutation leaves no event.
+type Recorder interface {
+	// InTx runs fn in a transaction, see dbtx.Run.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// Record stores entry in the transaction ctx carries.
+	Record(ctx context.Context, entry Entry) error
+}
+
+// Log is the Recorder backed by the audit_events table.
+type Log struct {
+	db   *sql.DB
+	repo Repository
+}
+
+func NewLog(db *sql.DB, repo Repository) *Log {
+	return &Log{db: db, repo: repo}
+}
+
+func (log *Log) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return dbtx.Run(ctx, log.db, fn)
+}
+
+func (log *Log) Record(ctx context.Context, entry Entry) error {
+	if err := log.repo.InsertEvent(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record %s %s audit event: %w", entry.EntityType, entry.Action, err)
+	}
+	return nil
+}
+
+// Event is a recorded mutation as returned by the audit endpoint.
+type Event struct {
+	ID            int64           `json:"id"`
+	ShopID        string          `json:"shop_id"`
+	ActorID       string          `json:"actor_id"`
+	ActorUsername *string         `json:"actor_username"`
+	EntityType    EntityType      `json:"entity_type"`
+	EntityID      string          `json:"entity_id"`
+	Action        Action          `json:"action"`
+	Before        json.RawMessage `json:"before"`
+	After         json.RawMessage `json:"after"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Filter narrows a shop's events. Zero fields match everything.
+type Filter struct {
+	EntityType EntityType
+	EntityID   string
+	ActorID    string
+	Action     Action
+	Since      time.Time
+	Until      time.Time
+}
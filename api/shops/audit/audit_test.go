@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type repositoryStub struct {
+	Repository
+	insert func(context.Context, Entry) error
+}
+
+func (stub repositoryStub) InsertEvent(ctx context.Context, entry Entry) error {
+	return stub.insert(ctx, entry)
+}
+
+func TestRecordPassesTransactionContext(t *testing.T) {
+	type key struct{}
+	var recorded []Entry
+	log := NewLog(nil, repositoryStub{insert: func(ctx context.Context, entry Entry) error {
+		require.Equal(t, "tx", ctx.Value(key{}))
+		recorded = append(recorded, entry)
+		return nil
+	}})
+
+	ctx := context.WithValue(context.Background(), key{}, "tx")
+	err := log.Record(ctx, Entry{ShopID: "shop-1", ActorID: "user-1", EntityType: EntityShopVehicle, EntityID: "vehicle-1", Action: ActionDelete})
+
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	require.Equal(t, "vehicle-1", recorded[0].EntityID)
+}
+
+func TestRecordReturnsInsertErrors(t *testing.T) {
+	insertErr := errors.New("connection refused")
+	log := NewLog(nil, repositoryStub{insert: func(context.Context, Entry) error {
+		return insertErr
+	}})
+
+	err := log.Record(context.Background(), Entry{ShopID: "shop-1", EntityType: EntityShop, Action: ActionCreate})
+	require.ErrorIs(t, err, insertErr)
+}
+
+func TestMarshalState(t *testing.T) {
+	type vehicle struct {
+		Admin string `json:"admin"`
+	}
+
+	state, err := marshalState(nil)
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	state, err = marshalState((*vehicle)(nil))
+	require.NoError(t, err)
+	require.Nil(t, state, "a typed nil is stored as NULL, not as JSON null")
+
+	state, err = marshalState(&vehicle{Admin: "A-12"})
+	require.NoError(t, err)
+	require.Equal(t, `{"admin":"A-12"}`, state)
+}
+
+func TestFilterConditionsNumberPlaceholders(t *testing.T) {
+	where := filterConditions("shop-1", Filter{EntityType: EntityShopList, Action: ActionUpdate})
+	where.add("e.id < $%d", int64(10))
+
+	require.Equal(t, "e.shop_id = $1 AND e.entity_type = $2 AND e.action = $3 AND e.id < $4", where.String())
+	require.Equal(t, []any{"shop-1", EntityShopList, ActionUpdate, int64(10)}, where.args)
+}
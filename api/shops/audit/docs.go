@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"net/http"
+	"slices"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Audit",
+	Operations: []openapi.Operation{
+		{
+			ID:      "getShopAuditEvents",
+			Method:  http.MethodGet,
+			Path:    "/shops/:shop_id/audit",
+			Auth:    true,
+			Summary: "Returns the shop's audit log, newest first (shop admins only)",
+			Query: slices.Concat(pagination.Query, []openapi.Param{
+				{Name: "entity_type", Description: "Only events for this entity type, e.g. shop_vehicle or shop_list_item"},
+				{Name: "entity_id", Description: "Only events for this entity"},
+				{Name: "actor_id", Description: "Only events by this user"},
+				{Name: "action", Description: "Only this action, e.g. create, update or delete"},
+				{Name: "since", Description: "Only events at or after this RFC 3339 time"},
+				{Name: "until", Description: "Only events before this RFC 3339 time"},
+			}),
+			Response: pagination.Page[Event]{},
+		},
+	},
+}}
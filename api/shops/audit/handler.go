@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"log/slog"
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
+	"miltechserver/api/response"
+	"miltechserver/bootstrap"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultEventLimit = 50
+
+type Handler struct {
+	service Service
+}
+
+// GetShopAuditEvents returns a page of the shop's audit log
+func (handler *Handler) GetShopAuditEvents(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	if shopID == "" {
+		c.Error(apperror.MissingParameter("shop_id"))
+		return
+	}
+
+	params, err := pagination.FromQuery(c, defaultEventLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	filter, err := filterFromQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	service := handler.service
+	events, err := service.GetShopAuditEvents(c.Request.Context(), user, shopID, filter, params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, response.StandardResponse{
+		Status:  200,
+		Message: "",
+		Data:    events,
+	})
+}
+
+// filterFromQuery reads the entity_type, entity_id, actor_id, action, since
+// and until query parameters.
+func filterFromQuery(c *gin.Context) (Filter, error) {
+	filter := Filter{
+		EntityType: EntityType(c.Query("entity_type")),
+		EntityID:   c.Query("entity_id"),
+		ActorID:    c.Query("actor_id"),
+		Action:     Action(c.Query("action")),
+	}
+
+	if filter.EntityType != "" && !slices.Contains(EntityTypes, filter.EntityType) {
+		return Filter{}, apperror.InvalidParameter("entity_type", "unknown entity_type")
+	}
+	if filter.Action != "" && !slices.Contains(Actions, filter.Action) {
+		return Filter{}, apperror.InvalidParameter("action", "unknown action")
+	}
+
+	bounds := []struct {
+		name  string
+		field *time.Time
+	}{{"since", &filter.Since}, {"until", &filter.Until}}
+	for _, bound := range bounds {
+		raw := c.Query(bound.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return Filter{}, apperror.InvalidParameter(bound.name, bound.name+" must be an RFC 3339 timestamp")
+		}
+		*bound.field = parsed
+	}
+
+	return filter, nil
+}
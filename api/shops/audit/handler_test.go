@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"miltechserver/api/middleware"
+	"miltechserver/api/pagination"
+	"miltechserver/bootstrap"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type serviceStub struct {
+	filter Filter
+	params pagination.Params
+}
+
+func (stub *serviceStub) GetShopAuditEvents(_ context.Context, _ *bootstrap.User, _ string, filter Filter, params pagination.Params) (pagination.Page[Event], error) {
+	stub.filter, stub.params = filter, params
+	return pagination.Page[Event]{Items: []Event{}}, nil
+}
+
+func auditRouter(service Service) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	router.Use(func(c *gin.Context) { c.Set("user", &bootstrap.User{UserID: "user-1"}); c.Next() })
+	RegisterRoutes(router.Group(""), service)
+	return router
+}
+
+func TestGetShopAuditEventsPassesFilter(t *testing.T) {
+	service := &serviceStub{}
+	req := httptest.NewRequest(http.MethodGet,
+		"/shops/shop-1/audit?entity_type=shop_vehicle&action=delete&actor_id=user-2&since=2026-10-01T00:00:00Z&limit=10", nil)
+	resp := httptest.NewRecorder()
+	auditRouter(service).ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, Filter{
+		EntityType: EntityShopVehicle,
+		ActorID:    "user-2",
+		Action:     ActionDelete,
+		Since:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}, service.filter)
+	require.Equal(t, 10, service.params.Limit)
+}
+
+func TestGetShopAuditEventsRejectsInvalidFilter(t *testing.T) {
+	for _, query := range []string{"entity_type=spaceship", "action=explode", "until=yesterday"} {
+		req := httptest.NewRequest(http.MethodGet, "/shops/shop-1/audit?"+query, nil)
+		resp := httptest.NewRecorder()
+		auditRouter(&serviceStub{}).ServeHTTP(resp, req)
+		require.Equal(t, http.StatusBadRequest, resp.Code, query)
+	}
+}
@@ -0,0 +1,12 @@
+package audit
+
+import (
+	"context"
+	"miltechserver/api/pagination"
+)
+
+type Repository interface {
+	// InsertEvent stores entry in the transaction ctx carries, if any.
+	InsertEvent(ctx context.Context, entry Entry) error
+	GetShopEventsPage(ctx context.Context, shopID string, filter Filter, params pagination.Params) (pagination.Page[Event], error)
+}
@@ -0,0 +1,163 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"miltechserver/api/pagination"
+	"miltechserver/dbtx"
+	"strings"
+)
+
+type RepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *RepositoryImpl {
+	return &RepositoryImpl{db: db}
+}
+
+func (repo *RepositoryImpl) InsertEvent(ctx context.Context, entry Entry) error {
+	before, err := marshalState(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalState(entry.After)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbtx.From(ctx, repo.db).ExecContext(ctx, `
+		INSERT INTO audit_events (shop_id, actor_id, entity_type, entity_id, action, before, after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		entry.ShopID, entry.ActorID, entry.EntityType, entry.EntityID, entry.Action, before, after)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+	return nil
+}
+
+// marshalState returns state as a JSON string, or nil for SQL NULL when
+// there is no state.
+func marshalState(state any) (any, error) {
+	if state == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+	if string(raw) == "null" {
+		return nil, nil
+	}
+	return string(raw), nil
+}
+
+// conditions builds a WHERE clause with numbered placeholders.
+type conditions struct {
+	clauses []string
+	args    []any
+}
+
+func (c *conditions) add(clause string, arg any) {
+	c.args = append(c.args, arg)
+	c.clauses = append(c.clauses, fmt.Sprintf(clause, len(c.args)))
+}
+
+func (c *conditions) String() string {
+	return strings.Join(c.clauses, " AND ")
+}
+
+// filterConditions selects the shop's events that match filter.
+func filterConditions(shopID string, filter Filter) *conditions {
+	c := &conditions{}
+	c.add("e.shop_id = $%d", shopID)
+	if filter.EntityType != "" {
+		c.add("e.entity_type = $%d", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		c.add("e.entity_id = $%d", filter.EntityID)
+	}
+	if filter.ActorID != "" {
+		c.add("e.actor_id = $%d", filter.ActorID)
+	}
+	if filter.Action != "" {
+		c.add("e.action = $%d", filter.Action)
+	}
+	if !filter.Since.IsZero() {
+		c.add("e.created_at >= $%d", filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		c.add("e.created_at < $%d", filter.Until.UTC())
+	}
+	return c
+}
+
+func (repo *RepositoryImpl) GetShopEventsPage(ctx context.Context, shopID string, filter Filter, params pagination.Params) (pagination.Page[Event], error) {
+	var afterID int64
+	after, err := params.After(&afterID)
+	if err != nil {
+		return pagination.Page[Event]{}, err
+	}
+
+	where := filterConditions(shopID, filter)
+	if after {
+		where.add("e.id < $%d", afterID)
+	}
+	args := append(where.args, params.Fetch())
+
+	query := fmt.Sprintf(`
+		SELECT e.id, e.shop_id, e.actor_id, u.username, e.entity_type, e.entity_id,
+		       e.action, e.before, e.after, e.created_at
+		FROM audit_events e
+		LEFT JOIN users u ON u.uid = e.actor_id
+		WHERE %s
+		ORDER BY e.id DESC
+		LIMIT $%d`, where, len(args))
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return pagination.Page[Event]{}, fmt.Errorf("failed to get audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		err := rows.Scan(
+			&event.ID,
+			&event.ShopID,
+			&event.ActorID,
+			&event.ActorUsername,
+			&event.EntityType,
+			&event.EntityID,
+			&event.Action,
+			&event.Before,
+			&event.After,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return pagination.Page[Event]{}, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Page[Event]{}, fmt.Errorf("error iterating audit events: %w", err)
+	}
+
+	page := pagination.NewPage(events, params, func(event Event) []any {
+		return []any{event.ID}
+	})
+	if !params.IncludeTotal {
+		return page, nil
+	}
+
+	total := filterConditions(shopID, filter)
+	var count int
+	err = repo.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM audit_events e WHERE %s`, total), total.args...).Scan(&count)
+	if err != nil {
+		return pagination.Page[Event]{}, fmt.Errorf("failed to count audit events: %w", err)
+	}
+	return page.WithTotal(count), nil
+}
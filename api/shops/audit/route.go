@@ -0,0 +1,10 @@
+package audit
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, service Service) {
+	handler := Handler{service: service}
+	router.GET("/shops/:shop_id/audit", handler.GetShopAuditEvents)
+}
@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"context"
+	"miltechserver/api/pagination"
+	"miltechserver/bootstrap"
+)
+
+type Service interface {
+	GetShopAuditEvents(ctx context.Context, user *bootstrap.User, shopID string, filter Filter, params pagination.Params) (pagination.Page[Event], error)
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"miltechserver/api/pagination"
+	"miltechserver/api/shops/shared"
+	"miltechserver/bootstrap"
+)
+
+type ServiceImpl struct {
+	repo Repository
+	auth shared.ShopAuthorization
+}
+
+func NewService(repo Repository, auth shared.ShopAuthorization) *ServiceImpl {
+	return &ServiceImpl{
+		repo: repo,
+		auth: auth,
+	}
+}
+
+// GetShopAuditEvents returns the shop's events, newest first. Only shop
+// admins may read the audit log.
+func (service *ServiceImpl) GetShopAuditEvents(ctx context.Context,
+	user *bootstrap.User,
+	shopID string,
+	filter Filter,
+	params pagination.Params,
+) (pagination.Page[Event], error) {
+	if user == nil {
+		return pagination.Page[Event]{}, shared.ErrUnauthorizedUser
+	}
+
+	if err := service.auth.RequireShopAdmin(ctx, user, shopID); err != nil {
+		return pagination.Page[Event]{}, err
+	}
+
+	page, err := service.repo.GetShopEventsPage(ctx, shopID, filter, params)
+	if err != nil {
+		return pagination.Page[Event]{}, fmt.Errorf("failed to get shop audit events: %w", err)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shops",
+	Operations: []openapi.Operation{
+		{
+			ID:       "createShop",
+			Method:   http.MethodPost,
+			Path:     "/shops",
+			Auth:     true,
+			Summary:  "Handles shop creation",
+			Request:  request.CreateShopRequest{},
+			Status:   http.StatusCreated,
+			Response: model.Shops{},
+		},
+		{
+			ID:       "getUserShops",
+			Method:   http.MethodGet,
+			Path:     "/shops",
+			Auth:     true,
+			Summary:  "Returns all shops for the authenticated user",
+			Response: []model.Shops{},
+		},
+		{
+			ID:       "getShopEquipmentOverview",
+			Method:   http.MethodGet,
+			Path:     "/shops/equipment/overview",
+			Auth:     true,
+			Summary:  "Get shop equipment overview",
+			Response: response.ShopEquipmentOverviewResponse{},
+		},
+		{
+			ID:       "getUserDataWithShops",
+			Method:   http.MethodGet,
+			Path:     "/shops/user-data",
+			Auth:     true,
+			Summary:  "Returns user data along with all shops they are a part of",
+			Response: response.UserShopsResponse{},
+		},
+		{
+			ID:       "getShopByID",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id",
+			Auth:     true,
+			Summary:  "Returns a specific shop by ID",
+			Response: response.ShopDetailResponse{},
+		},
+		{
+			ID:       "updateShop",
+			Method:   http.MethodPut,
+			Path:     "/shops/:shop_id",
+			Auth:     true,
+			Summary:  "Handles shop updates",
+			Request:  request.UpdateShopRequest{},
+			Response: model.Shops{},
+		},
+		{
+			ID:       "deleteShop",
+			Method:   http.MethodDelete,
+			Path:     "/shops/:shop_id",
+			Auth:     true,
+			Summary:  "Handles shop deletion",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+	},
+}}
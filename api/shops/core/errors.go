@@ -1,5 +1,5 @@
 package core
 
-import "errors"
+import "miltechserver/api/apperror"
 
-var ErrShopEquipmentOverviewUnavailable = errors.New("failed to retrieve shop equipment overview")
+var ErrShopEquipmentOverviewUnavailable = apperror.New(apperror.KindInternal, "equipment_overview_unavailable", "failed to retrieve shop equipment overview")
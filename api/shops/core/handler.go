@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -21,18 +22,16 @@ func (handler *Handler) GetShopEquipmentOverview(c *gin.Context) {
 	startedAt := time.Now()
 	ctxUser, ok := c.Get("user")
 	user, userOK := ctxUser.(*bootstrap.User)
+	// Errors are rendered inline because this route sits behind gzip, whose
+	// writer is closed by the time middleware.ErrorHandler runs.
 	if !ok || !userOK || user == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		apperror.Render(c, apperror.ErrUnauthenticated)
 		return
 	}
 
 	overview, err := handler.service.GetShopEquipmentOverview(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.StandardResponse{
-			Status:  http.StatusInternalServerError,
-			Message: ErrShopEquipmentOverviewUnavailable.Error(),
-			Data:    nil,
-		})
+		apperror.Render(c, ErrShopEquipmentOverviewUnavailable.WithCause(err))
 		return
 	}
 
@@ -63,15 +62,15 @@ func (handler *Handler) CreateShop(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.CreateShopRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -86,7 +85,7 @@ func (handler *Handler) CreateShop(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdShop, err := service.CreateShop(user, shop)
+	createdShop, err := service.CreateShop(c.Request.Context(), user, shop)
 	if err != nil {
 		c.Error(err)
 		return
@@ -105,19 +104,19 @@ func (handler *Handler) DeleteShop(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.DeleteShop(user, shopID)
+	err := service.DeleteShop(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -132,15 +131,15 @@ func (handler *Handler) GetUserShops(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	service := handler.service
-	shops, err := service.GetShopsByUser(user)
+	shops, err := service.GetShopsByUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -157,13 +156,13 @@ func (handler *Handler) GetUserDataWithShops(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	service := handler.service
-	userShopsData, err := service.GetUserDataWithShops(user)
+	userShopsData, err := service.GetUserDataWithShops(c.Request.Context(), user)
 	if err != nil {
 		c.Error(err)
 		return
@@ -182,19 +181,19 @@ func (handler *Handler) GetShopByID(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	shop, err := service.GetShopByID(user, shopID)
+	shop, err := service.GetShopByID(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -213,21 +212,21 @@ func (handler *Handler) UpdateShop(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	var req request.UpdateShopRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -238,7 +237,7 @@ func (handler *Handler) UpdateShop(c *gin.Context) {
 	}
 
 	service := handler.service
-	updatedShop, err := service.UpdateShop(user, shop)
+	updatedShop, err := service.UpdateShop(c.Request.Context(), user, shop)
 	if err != nil {
 		c.Error(err)
 		return
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/middleware"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -40,13 +41,10 @@ func TestGetShopEquipmentOverviewReturnsGenericServerError(t *testing.T) {
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 	require.Equal(t, http.StatusInternalServerError, resp.Code)
-	var standardResponse response.StandardResponse
-	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &standardResponse))
-	require.Equal(t, response.StandardResponse{
-		Status:  http.StatusInternalServerError,
-		Message: ErrShopEquipmentOverviewUnavailable.Error(),
-		Data:    nil,
-	}, standardResponse)
+	var problem apperror.Problem
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem))
+	require.Equal(t, "equipment_overview_unavailable", problem.Code)
+	require.Equal(t, ErrShopEquipmentOverviewUnavailable.Message, problem.Detail)
 	require.NotContains(t, resp.Body.String(), "database")
 }
 
@@ -75,12 +73,9 @@ func TestGetShopEquipmentOverviewWritesGenericGzipErrorResponse(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, gzipReader.Close())
 
-	var standardResponse response.StandardResponse
-	require.NoError(t, json.Unmarshal(decompressedBody, &standardResponse))
-	require.Equal(t, response.StandardResponse{
-		Status:  http.StatusInternalServerError,
-		Message: ErrShopEquipmentOverviewUnavailable.Error(),
-		Data:    nil,
-	}, standardResponse)
+	var problem apperror.Problem
+	require.NoError(t, json.Unmarshal(decompressedBody, &problem))
+	require.Equal(t, "equipment_overview_unavailable", problem.Code)
+	require.Equal(t, http.StatusInternalServerError, problem.Status)
 	require.NotContains(t, string(decompressedBody), "database")
 }
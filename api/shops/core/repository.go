@@ -8,13 +8,13 @@ import (
 )
 
 type Repository interface {
-	CreateShop(user *bootstrap.User, shop model.Shops) (*model.Shops, error)
-	UpdateShop(user *bootstrap.User, shop model.Shops) (*model.Shops, error)
-	DeleteShop(user *bootstrap.User, shopID string) error
-	GetShopsByUser(user *bootstrap.User) ([]model.Shops, error)
-	GetShopByID(user *bootstrap.User, shopID string) (*response.ShopDetailResponse, error)
-	GetShopsWithStatsForUser(user *bootstrap.User) ([]response.ShopWithStats, error)
+	CreateShop(ctx context.Context, user *bootstrap.User, shop model.Shops) (*model.Shops, error)
+	UpdateShop(ctx context.Context, user *bootstrap.User, shop model.Shops) (*model.Shops, error)
+	DeleteShop(ctx context.Context, user *bootstrap.User, shopID string) error
+	GetShopsByUser(ctx context.Context, user *bootstrap.User) ([]model.Shops, error)
+	GetShopByID(ctx context.Context, user *bootstrap.User, shopID string) (*response.ShopDetailResponse, error)
+	GetShopsWithStatsForUser(ctx context.Context, user *bootstrap.User) ([]response.ShopWithStats, error)
 	GetShopEquipmentOverview(ctx context.Context, user *bootstrap.User) ([]response.ShopEquipmentOverview, error)
-	AddMemberToShop(user *bootstrap.User, shopID string, role string) error
-	DeleteShopMessageBlobs(shopID string) error
+	AddMemberToShop(ctx context.Context, user *bootstrap.User, shopID string, role string) error
+	DeleteShopMessageBlobs(ctx context.Context, shopID string) error
 }
@@ -3,18 +3,18 @@ package core
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
+	"miltechserver/storage"
 	"sync/atomic"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	. "github.com/go-jet/jet/v2/postgres"
 	"golang.org/x/sync/errgroup"
 )
@@ -26,20 +26,20 @@ const (
 )
 
 type RepositoryImpl struct {
-	db         *sql.DB
-	blobClient *azblob.Client
-	env        *bootstrap.Env
+	db    *sql.DB
+	store storage.ObjectStore
+	env   *bootstrap.Env
 }
 
-func NewRepository(db *sql.DB, blobClient *azblob.Client, env *bootstrap.Env) *RepositoryImpl {
+func NewRepository(db *sql.DB, store storage.ObjectStore, env *bootstrap.Env) *RepositoryImpl {
 	return &RepositoryImpl{
-		db:         db,
-		blobClient: blobClient,
-		env:        env,
+		db:    db,
+		store: store,
+		env:   env,
 	}
 }
 
-func (repo *RepositoryImpl) CreateShop(user *bootstrap.User, shop model.Shops) (*model.Shops, error) {
+func (repo *RepositoryImpl) CreateShop(ctx context.Context, user *bootstrap.User, shop model.Shops) (*model.Shops, error) {
 	stmt := Shops.INSERT(
 		Shops.ID,
 		Shops.Name,
@@ -50,7 +50,7 @@ func (repo *RepositoryImpl) CreateShop(user *bootstrap.User, shop model.Shops) (
 	).MODEL(shop).RETURNING(Shops.AllColumns)
 
 	var createdShop model.Shops
-	err := stmt.Query(repo.db, &createdShop)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &createdShop)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create shop: %w", err)
 	}
@@ -59,7 +59,7 @@ func (repo *RepositoryImpl) CreateShop(user *bootstrap.User, shop model.Shops) (
 	return &createdShop, nil
 }
 
-func (repo *RepositoryImpl) UpdateShop(user *bootstrap.User, shop model.Shops) (*model.Shops, error) {
+func (repo *RepositoryImpl) UpdateShop(ctx context.Context, user *bootstrap.User, shop model.Shops) (*model.Shops, error) {
 	now := time.Now()
 	shop.UpdatedAt = &now
 
@@ -91,7 +91,7 @@ func (repo *RepositoryImpl) UpdateShop(user *bootstrap.User, shop model.Shops) (
 	).RETURNING(Shops.AllColumns)
 
 	var updatedShop model.Shops
-	err := stmt.Query(repo.db, &updatedShop)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &updatedShop)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update shop: %w", err)
 	}
@@ -100,13 +100,13 @@ func (repo *RepositoryImpl) UpdateShop(user *bootstrap.User, shop model.Shops) (
 	return &updatedShop, nil
 }
 
-func (repo *RepositoryImpl) DeleteShop(user *bootstrap.User, shopID string) error {
+func (repo *RepositoryImpl) DeleteShop(ctx context.Context, user *bootstrap.User, shopID string) error {
 	stmt := Shops.DELETE().WHERE(
 		Shops.ID.EQ(String(shopID)).
 			AND(Shops.CreatedBy.EQ(String(user.UserID))),
 	)
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete shop: %w", err)
 	}
@@ -117,14 +117,14 @@ func (repo *RepositoryImpl) DeleteShop(user *bootstrap.User, shopID string) erro
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("shop not found or user not authorized to delete")
+		return apperror.NotFound("shop_not_found", "shop not found or user not authorized to delete")
 	}
 
 	slog.Info("Shop deleted from database", "shop_id", shopID, "deleted_by", user.UserID)
 	return nil
 }
 
-func (repo *RepositoryImpl) GetShopsByUser(user *bootstrap.User) ([]model.Shops, error) {
+func (repo *RepositoryImpl) GetShopsByUser(ctx context.Context, user *bootstrap.User) ([]model.Shops, error) {
 	stmt := SELECT(Shops.AllColumns).
 		FROM(
 			Shops.
@@ -134,7 +134,7 @@ func (repo *RepositoryImpl) GetShopsByUser(user *bootstrap.User) ([]model.Shops,
 		ORDER_BY(Shops.CreatedAt.DESC())
 
 	var shops []model.Shops
-	err := stmt.Query(repo.db, &shops)
+	err := stmt.QueryContext(ctx, repo.db, &shops)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shops for user: %w", err)
 	}
@@ -182,7 +182,7 @@ func (repo *RepositoryImpl) GetShopEquipmentOverview(
 	return shops, nil
 }
 
-func (repo *RepositoryImpl) GetShopByID(user *bootstrap.User, shopID string) (*response.ShopDetailResponse, error) {
+func (repo *RepositoryImpl) GetShopByID(ctx context.Context, user *bootstrap.User, shopID string) (*response.ShopDetailResponse, error) {
 	rawSQL := `
 		SELECT
 			s.id,
@@ -224,7 +224,7 @@ func (repo *RepositoryImpl) GetShopByID(user *bootstrap.User, shopID string) (*r
 	`
 
 	var result response.ShopDetailResponse
-	err := repo.db.QueryRow(rawSQL, shopID, user.UserID).Scan(
+	err := repo.db.QueryRowContext(ctx, rawSQL, shopID, user.UserID).Scan(
 		&result.ID,
 		&result.Name,
 		&result.Details,
@@ -244,7 +244,7 @@ func (repo *RepositoryImpl) GetShopByID(user *bootstrap.User, shopID string) (*r
 	return &result, nil
 }
 
-func (repo *RepositoryImpl) GetShopsWithStatsForUser(user *bootstrap.User) ([]response.ShopWithStats, error) {
+func (repo *RepositoryImpl) GetShopsWithStatsForUser(ctx context.Context, user *bootstrap.User) ([]response.ShopWithStats, error) {
 	rawSQL := `
 		WITH user_shops AS (
 			SELECT
@@ -289,7 +289,7 @@ func (repo *RepositoryImpl) GetShopsWithStatsForUser(user *bootstrap.User) ([]re
 		ORDER BY us.created_at DESC
 	`
 
-	rows, err := repo.db.Query(rawSQL, user.UserID)
+	rows, err := repo.db.QueryContext(ctx, rawSQL, user.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shops with stats: %w", err)
 	}
@@ -334,7 +334,7 @@ func (repo *RepositoryImpl) GetShopsWithStatsForUser(user *bootstrap.User) ([]re
 	return results, nil
 }
 
-func (repo *RepositoryImpl) AddMemberToShop(user *bootstrap.User, shopID string, role string) error {
+func (repo *RepositoryImpl) AddMemberToShop(ctx context.Context, user *bootstrap.User, shopID string, role string) error {
 	curTime := time.Now().UTC()
 	member := model.ShopMembers{
 		ID:       fmt.Sprintf("%s_%s", shopID, user.UserID),
@@ -354,7 +354,7 @@ func (repo *RepositoryImpl) AddMemberToShop(user *bootstrap.User, shopID string,
 		ON_CONFLICT(ShopMembers.ShopID, ShopMembers.UserID).
 		DO_UPDATE(SET(ShopMembers.Role.SET(String(role))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to add member to shop: %w", err)
 	}
@@ -363,54 +363,42 @@ func (repo *RepositoryImpl) AddMemberToShop(user *bootstrap.User, shopID string,
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteShopMessageBlobs(shopID string) error {
-	if repo.blobClient == nil {
+func (repo *RepositoryImpl) DeleteShopMessageBlobs(ctx context.Context, shopID string) error {
+	if repo.store == nil {
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), blobOperationTimeout)
+	// Runs after the shop is deleted, so finish even if the client goes away.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), blobOperationTimeout)
 	defer cancel()
 
-	containerClient := repo.blobClient.ServiceClient().NewContainerClient(shopMessageImagesContainer)
-
 	prefix := fmt.Sprintf("%s/", shopID)
-	pager := containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
-		Prefix: &prefix,
-	})
+	blobs, err := repo.store.List(ctx, shopMessageImagesContainer, prefix)
+	if err != nil {
+		slog.Warn("Failed to list blobs for shop deletion", "shop_id", shopID, "error", err)
+	}
 
 	var deletedCount int64
 	var errorCount int64
 	group, groupCtx := errgroup.WithContext(ctx)
 	group.SetLimit(maxConcurrentBlobDeletes)
 
-	for pager.More() {
-		page, err := pager.NextPage(groupCtx)
-		if err != nil {
-			slog.Warn("Failed to list blobs for shop deletion", "shop_id", shopID, "error", err)
-			break
-		}
-
-		for _, blob := range page.Segment.BlobItems {
-			if blob.Name == nil {
-				continue
+	for _, blob := range blobs {
+		blobName := blob.Name
+		group.Go(func() error {
+			err := repo.store.Delete(groupCtx, shopMessageImagesContainer, blobName)
+			if err != nil {
+				slog.Warn("Failed to delete shop message blob",
+					"shop_id", shopID,
+					"blob_name", blobName,
+					"error", err)
+				atomic.AddInt64(&errorCount, 1)
+				return nil
 			}
 
-			blobName := *blob.Name
-			group.Go(func() error {
-				_, err := repo.blobClient.DeleteBlob(groupCtx, shopMessageImagesContainer, blobName, nil)
-				if err != nil {
-					slog.Warn("Failed to delete shop message blob",
-						"shop_id", shopID,
-						"blob_name", blobName,
-						"error", err)
-					atomic.AddInt64(&errorCount, 1)
-					return nil
-				}
-
-				atomic.AddInt64(&deletedCount, 1)
-				return nil
-			})
-		}
+			atomic.AddInt64(&deletedCount, 1)
+			return nil
+		})
 	}
 
 	if err := group.Wait(); err != nil {
@@ -9,11 +9,11 @@ import (
 )
 
 type ShopService interface {
-	CreateShop(user *bootstrap.User, shop model.Shops) (*model.Shops, error)
-	UpdateShop(user *bootstrap.User, shop model.Shops) (*model.Shops, error)
-	DeleteShop(user *bootstrap.User, shopID string) error
-	GetShopsByUser(user *bootstrap.User) ([]model.Shops, error)
-	GetShopByID(user *bootstrap.User, shopID string) (*response.ShopDetailResponse, error)
-	GetUserDataWithShops(user *bootstrap.User) (*response.UserShopsResponse, error)
+	CreateShop(ctx context.Context, user *bootstrap.User, shop model.Shops) (*model.Shops, error)
+	UpdateShop(ctx context.Context, user *bootstrap.User, shop model.Shops) (*model.Shops, error)
+	DeleteShop(ctx context.Context, user *bootstrap.User, shopID string) error
+	GetShopsByUser(ctx context.Context, user *bootstrap.User) ([]model.Shops, error)
+	GetShopByID(ctx context.Context, user *bootstrap.User, shopID string) (*response.ShopDetailResponse, error)
+	GetUserDataWithShops(ctx context.Context, user *bootstrap.User) (*response.UserShopsResponse, error)
 	GetShopEquipmentOverview(ctx context.Context, user *bootstrap.User) (*response.ShopEquipmentOverviewResponse, error)
 }
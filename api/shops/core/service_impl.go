@@ -2,40 +2,45 @@ package core
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type ServiceImpl struct {
-	repo Repository
-	auth shared.ShopAuthorization
+	repo  Repository
+	auth  shared.ShopAuthorization
+	audit audit.Recorder
 }
 
-func NewService(repo Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, auth shared.ShopAuthorization, recorder audit.Recorder) *ServiceImpl {
 	return &ServiceImpl{
-		repo: repo,
-		auth: auth,
+		repo:  repo,
+		auth:  auth,
+		audit: recorder,
 	}
 }
 
 func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
 	return &ServiceImpl{
-		repo: service.repo,
-		auth: auth,
+		repo:  service.repo,
+		auth:  auth,
+		audit: service.audit,
 	}
 }
 
-func (service *ServiceImpl) CreateShop(user *bootstrap.User, shop model.Shops) (*model.Shops, error) {
+func (service *ServiceImpl) CreateShop(ctx context.Context, user *bootstrap.User, shop model.Shops) (*model.Shops, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
 	shop.ID = uuid.New().String()
@@ -44,67 +49,123 @@ func (service *ServiceImpl) CreateShop(user *bootstrap.User, shop model.Shops) (
 	shop.CreatedAt = &now
 	shop.UpdatedAt = &now
 
-	createdShop, err := service.repo.CreateShop(user, shop)
-	if err != nil {
-		slog.Error("Failed to create shop", "error", err, "user_id", user.UserID)
-		return nil, fmt.Errorf("failed to create shop: %w", err)
-	}
+	var createdShop *model.Shops
+	err := service.audit.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		createdShop, err = service.repo.CreateShop(ctx, user, shop)
+		if err != nil {
+			slog.Error("Failed to create shop", "error", err, "user_id", user.UserID)
+			return fmt.Errorf("failed to create shop: %w", err)
+		}
+
+		err = service.repo.AddMemberToShop(ctx, user, shop.ID, "admin")
+		if err != nil {
+			slog.Error("Failed to add creator as admin to shop", "error", err, "user_id", user.UserID, "shop_id", shop.ID)
+			return fmt.Errorf("failed to add creator as admin: %w", err)
+		}
 
-	err = service.repo.AddMemberToShop(user, shop.ID, "admin")
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     shop.ID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShop,
+			EntityID:   shop.ID,
+			Action:     audit.ActionCreate,
+			After:      createdShop,
+		})
+	})
 	if err != nil {
-		slog.Error("Failed to add creator as admin to shop", "error", err, "user_id", user.UserID, "shop_id", shop.ID)
+		return nil, err
 	}
 
 	slog.Info("Shop created successfully", "user_id", user.UserID, "shop_id", shop.ID, "shop_name", shop.Name)
 	return createdShop, nil
 }
 
-func (service *ServiceImpl) UpdateShop(user *bootstrap.User, shop model.Shops) (*model.Shops, error) {
+func (service *ServiceImpl) UpdateShop(ctx context.Context, user *bootstrap.User, shop model.Shops) (*model.Shops, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shop.ID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shop.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isAdmin {
-		return nil, errors.New("access denied: only shop admins can update shops")
+		return nil, apperror.Forbidden("shop_admin_required", "access denied: only shop admins can update shops")
 	}
 
-	updatedShop, err := service.repo.UpdateShop(user, shop)
+	currentShop, err := service.repo.GetShopByID(ctx, user, shop.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current shop: %w", err)
+	}
+
+	var updatedShop *model.Shops
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		updatedShop, err = service.repo.UpdateShop(ctx, user, shop)
+		if err != nil {
+			return fmt.Errorf("failed to update shop: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     shop.ID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShop,
+			EntityID:   shop.ID,
+			Action:     audit.ActionUpdate,
+			Before:     currentShop.Shops,
+			After:      updatedShop,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update shop: %w", err)
+		return nil, err
 	}
 
 	slog.Info("Shop updated successfully", "user_id", user.UserID, "shop_id", shop.ID, "shop_name", shop.Name)
 	return updatedShop, nil
 }
 
-func (service *ServiceImpl) DeleteShop(user *bootstrap.User, shopID string) error {
+func (service *ServiceImpl) DeleteShop(ctx context.Context, user *bootstrap.User, shopID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isAdmin {
-		return errors.New("only shop administrators can delete shops")
+		return apperror.Forbidden("shop_admin_required", "only shop administrators can delete shops")
 	}
 
-	return service.deleteShopWithBlobCleanup(user, shopID)
+	currentShop, err := service.repo.GetShopByID(ctx, user, shopID)
+	if err != nil {
+		return fmt.Errorf("failed to get current shop: %w", err)
+	}
+
+	return service.audit.InTx(ctx, func(ctx context.Context) error {
+		if err := service.deleteShopWithBlobCleanup(ctx, user, shopID); err != nil {
+			return err
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     shopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShop,
+			EntityID:   shopID,
+			Action:     audit.ActionDelete,
+			Before:     currentShop.Shops,
+		})
+	})
 }
 
-func (service *ServiceImpl) GetShopsByUser(user *bootstrap.User) ([]model.Shops, error) {
+func (service *ServiceImpl) GetShopsByUser(ctx context.Context, user *bootstrap.User) ([]model.Shops, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	shops, err := service.repo.GetShopsByUser(user)
+	shops, err := service.repo.GetShopsByUser(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shops: %w", err)
 	}
@@ -116,21 +177,21 @@ func (service *ServiceImpl) GetShopsByUser(user *bootstrap.User) ([]model.Shops,
 	return shops, nil
 }
 
-func (service *ServiceImpl) GetShopByID(user *bootstrap.User, shopID string) (*response.ShopDetailResponse, error) {
+func (service *ServiceImpl) GetShopByID(ctx context.Context, user *bootstrap.User, shopID string) (*response.ShopDetailResponse, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	shop, err := service.repo.GetShopByID(user, shopID)
+	shop, err := service.repo.GetShopByID(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop: %w", err)
 	}
@@ -138,12 +199,12 @@ func (service *ServiceImpl) GetShopByID(user *bootstrap.User, shopID string) (*r
 	return shop, nil
 }
 
-func (service *ServiceImpl) GetUserDataWithShops(user *bootstrap.User) (*response.UserShopsResponse, error) {
+func (service *ServiceImpl) GetUserDataWithShops(ctx context.Context, user *bootstrap.User) (*response.UserShopsResponse, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	shopsWithStats, err := service.repo.GetShopsWithStatsForUser(user)
+	shopsWithStats, err := service.repo.GetShopsWithStatsForUser(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user shops with stats: %w", err)
 	}
@@ -159,7 +220,7 @@ func (service *ServiceImpl) GetUserDataWithShops(user *bootstrap.User) (*respons
 
 func (service *ServiceImpl) GetShopEquipmentOverview(ctx context.Context, user *bootstrap.User) (*response.ShopEquipmentOverviewResponse, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
 	shops, err := service.repo.GetShopEquipmentOverview(ctx, user)
@@ -184,20 +245,23 @@ func (service *ServiceImpl) GetShopEquipmentOverview(ctx context.Context, user *
 
 // deleteShopWithBlobCleanup is a private helper that deletes a shop and cleans up associated blobs
 // This is used by both DeleteShop (admin deletion) and LeaveShop (last member deletion)
-func (service *ServiceImpl) deleteShopWithBlobCleanup(user *bootstrap.User, shopID string) error {
-	err := service.repo.DeleteShop(user, shopID)
+// Inside a transaction the blobs are only deleted once it commits
+func (service *ServiceImpl) deleteShopWithBlobCleanup(ctx context.Context, user *bootstrap.User, shopID string) error {
+	err := service.repo.DeleteShop(ctx, user, shopID)
 	if err != nil {
 		slog.Error("Failed to delete shop", "error", err, "user_id", user.UserID, "shop_id", shopID)
 		return fmt.Errorf("failed to delete shop: %w", err)
 	}
 
-	err = service.repo.DeleteShopMessageBlobs(shopID)
-	if err != nil {
-		slog.Warn("Failed to delete shop message blobs during shop deletion",
-			"shop_id", shopID,
-			"user_id", user.UserID,
-			"error", err)
-	}
+	dbtx.AfterCommit(ctx, func() {
+		err := service.repo.DeleteShopMessageBlobs(ctx, shopID)
+		if err != nil {
+			slog.Warn("Failed to delete shop message blobs during shop deletion",
+				"shop_id", shopID,
+				"user_id", user.UserID,
+				"error", err)
+		}
+	})
 
 	slog.Info("Shop deleted successfully", "user_id", user.UserID, "shop_id", shopID)
 	return nil
@@ -30,7 +30,7 @@ func TestGetShopEquipmentOverview(t *testing.T) {
 			{ID: "shop-2", Equipment: []response.ShopEquipmentSummary{{ID: "equipment-1"}}},
 		}, nil
 	}}
-	service := NewService(repository, nil)
+	service := NewService(repository, nil, nil)
 
 	result, err := service.GetShopEquipmentOverview(requestContext, &bootstrap.User{UserID: "user-1"})
 	require.NoError(t, err)
@@ -41,7 +41,7 @@ func TestGetShopEquipmentOverview(t *testing.T) {
 }
 
 func TestGetShopEquipmentOverviewRejectsMissingUser(t *testing.T) {
-	service := NewService(overviewRepositoryStub{}, nil)
+	service := NewService(overviewRepositoryStub{}, nil, nil)
 	result, err := service.GetShopEquipmentOverview(context.Background(), nil)
 	require.Nil(t, result)
 	require.EqualError(t, err, "unauthorized user")
@@ -51,7 +51,7 @@ func TestGetShopEquipmentOverviewHidesRepositoryError(t *testing.T) {
 	repository := overviewRepositoryStub{getOverview: func(context.Context, *bootstrap.User) ([]response.ShopEquipmentOverview, error) {
 		return nil, errors.New("database host and query details")
 	}}
-	service := NewService(repository, nil)
+	service := NewService(repository, nil, nil)
 	result, err := service.GetShopEquipmentOverview(context.Background(), &bootstrap.User{UserID: "user-1"})
 	require.Nil(t, result)
 	require.ErrorIs(t, err, ErrShopEquipmentOverviewUnavailable)
@@ -0,0 +1,42 @@
+package shops
+
+import (
+	"slices"
+
+	"miltechserver/api/shops/aggregates"
+	"miltechserver/api/shops/audit"
+	"miltechserver/api/shops/core"
+	"miltechserver/api/shops/lists"
+	listitems "miltechserver/api/shops/lists/items"
+	"miltechserver/api/shops/members"
+	"miltechserver/api/shops/members/invites"
+	"miltechserver/api/shops/messages"
+	"miltechserver/api/shops/mobilepush"
+	"miltechserver/api/shops/settings"
+	"miltechserver/api/shops/stream"
+	"miltechserver/api/shops/vehicles"
+	"miltechserver/api/shops/vehicles/notifications"
+	notificationchanges "miltechserver/api/shops/vehicles/notifications/changes"
+	notificationitems "miltechserver/api/shops/vehicles/notifications/items"
+	"miltechserver/api/shops/webhooks"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(
+	core.Docs,
+	aggregates.Docs,
+	settings.Docs,
+	members.Docs,
+	invites.Docs,
+	messages.Docs,
+	lists.Docs,
+	listitems.Docs,
+	vehicles.Docs,
+	notifications.Docs,
+	notificationitems.Docs,
+	notificationchanges.Docs,
+	audit.Docs,
+	webhooks.Docs,
+	mobilepush.Docs,
+	stream.Docs,
+)
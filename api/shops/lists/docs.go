@@ -0,0 +1,61 @@
+package lists
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Lists",
+	Operations: []openapi.Operation{
+		{
+			ID:       "createShopList",
+			Method:   http.MethodPost,
+			Path:     "/shops/lists",
+			Auth:     true,
+			Summary:  "Creates a new list for a shop",
+			Request:  request.CreateShopListRequest{},
+			Status:   http.StatusCreated,
+			Response: response.ShopListWithUsername{},
+		},
+		{
+			ID:       "getShopLists",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/lists",
+			Auth:     true,
+			Summary:  "Returns all lists for a shop with creator usernames",
+			Response: []response.ShopListWithUsername{},
+		},
+		{
+			ID:       "getShopListByID",
+			Method:   http.MethodGet,
+			Path:     "/shops/lists/:list_id",
+			Auth:     true,
+			Summary:  "Returns a specific list by ID",
+			Response: response.ShopListWithUsername{},
+		},
+		{
+			ID:       "updateShopList",
+			Method:   http.MethodPut,
+			Path:     "/shops/lists",
+			Auth:     true,
+			Summary:  "Updates an existing shop list",
+			Request:  request.UpdateShopListRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "deleteShopList",
+			Method:   http.MethodDelete,
+			Path:     "/shops/lists",
+			Auth:     true,
+			Summary:  "Deletes a shop list",
+			Request:  request.DeleteShopListRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+	},
+}}
@@ -3,6 +3,7 @@ package lists
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -22,15 +23,15 @@ func (handler *Handler) CreateShopList(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.CreateShopListRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -40,7 +41,7 @@ func (handler *Handler) CreateShopList(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdList, err := service.CreateShopList(user, list)
+	createdList, err := service.CreateShopList(c.Request.Context(), user, list)
 	if err != nil {
 		c.Error(err)
 		return
@@ -59,19 +60,19 @@ func (handler *Handler) GetShopLists(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	lists, err := service.GetShopLists(user, shopID)
+	lists, err := service.GetShopLists(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -90,19 +91,19 @@ func (handler *Handler) GetShopListByID(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	listID := c.Param("list_id")
 	if listID == "" {
-		c.JSON(400, gin.H{"message": "list_id is required"})
+		c.Error(apperror.MissingParameter("list_id"))
 		return
 	}
 
 	service := handler.service
-	list, err := service.GetShopListByID(user, listID)
+	list, err := service.GetShopListByID(c.Request.Context(), user, listID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -121,15 +122,15 @@ func (handler *Handler) UpdateShopList(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.UpdateShopListRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -139,7 +140,7 @@ func (handler *Handler) UpdateShopList(c *gin.Context) {
 	}
 
 	service := handler.service
-	err := service.UpdateShopList(user, list)
+	err := service.UpdateShopList(c.Request.Context(), user, list)
 	if err != nil {
 		c.Error(err)
 		return
@@ -154,20 +155,20 @@ func (handler *Handler) DeleteShopList(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.DeleteShopListRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	err := service.DeleteShopList(user, req.ListID)
+	err := service.DeleteShopList(c.Request.Context(), user, req.ListID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -0,0 +1,73 @@
+package items
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Lists",
+	Operations: []openapi.Operation{
+		{
+			ID:       "addListItem",
+			Method:   http.MethodPost,
+			Path:     "/shops/lists/items",
+			Auth:     true,
+			Summary:  "Adds an item to a shop list",
+			Request:  request.AddListItemRequest{},
+			Status:   http.StatusCreated,
+			Response: response.ShopListItemWithUsername{},
+		},
+		{
+			ID:       "getListItems",
+			Method:   http.MethodGet,
+			Path:     "/shops/lists/:list_id/items",
+			Auth:     true,
+			Summary:  "Returns all items for a list with added by usernames",
+			Response: []response.ShopListItemWithUsername{},
+		},
+		{
+			ID:       "updateListItem",
+			Method:   http.MethodPut,
+			Path:     "/shops/lists/items",
+			Auth:     true,
+			Summary:  "Updates an existing list item",
+			Request:  request.UpdateListItemRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "removeListItem",
+			Method:   http.MethodDelete,
+			Path:     "/shops/lists/items",
+			Auth:     true,
+			Summary:  "Removes an item from a list",
+			Request:  request.RemoveListItemRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "addListItemBatch",
+			Method:   http.MethodPost,
+			Path:     "/shops/lists/items/bulk",
+			Auth:     true,
+			Summary:  "Adds multiple items to a list",
+			Request:  request.AddListItemBatchRequest{},
+			Status:   http.StatusCreated,
+			Response: []response.ShopListItemWithUsername{},
+		},
+		{
+			ID:       "removeListItemBatch",
+			Method:   http.MethodDelete,
+			Path:     "/shops/lists/items/bulk",
+			Auth:     true,
+			Summary:  "Removes multiple items from lists",
+			Request:  request.RemoveListItemBatchRequest{},
+			Response: openapi.Object{"message": "", "count": 0},
+			Raw:      true,
+		},
+	},
+}}
@@ -3,6 +3,7 @@ package items
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -22,15 +23,15 @@ func (handler *Handler) AddListItem(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.AddListItemRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -44,7 +45,7 @@ func (handler *Handler) AddListItem(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdItem, err := service.AddListItem(user, item)
+	createdItem, err := service.AddListItem(c.Request.Context(), user, item)
 	if err != nil {
 		c.Error(err)
 		return
@@ -63,19 +64,19 @@ func (handler *Handler) GetListItems(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	listID := c.Param("list_id")
 	if listID == "" {
-		c.JSON(400, gin.H{"message": "list_id is required"})
+		c.Error(apperror.MissingParameter("list_id"))
 		return
 	}
 
 	service := handler.service
-	items, err := service.GetListItems(user, listID)
+	items, err := service.GetListItems(c.Request.Context(), user, listID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -94,15 +95,15 @@ func (handler *Handler) UpdateListItem(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.UpdateListItemRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -116,7 +117,7 @@ func (handler *Handler) UpdateListItem(c *gin.Context) {
 	}
 
 	service := handler.service
-	err := service.UpdateListItem(user, item)
+	err := service.UpdateListItem(c.Request.Context(), user, item)
 	if err != nil {
 		c.Error(err)
 		return
@@ -131,20 +132,20 @@ func (handler *Handler) RemoveListItem(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.RemoveListItemRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	err := service.RemoveListItem(user, req.ItemID)
+	err := service.RemoveListItem(c.Request.Context(), user, req.ItemID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -159,15 +160,15 @@ func (handler *Handler) AddListItemBatch(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.AddListItemBatchRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -185,7 +186,7 @@ func (handler *Handler) AddListItemBatch(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdItems, err := service.AddListItemBatch(user, items)
+	createdItems, err := service.AddListItemBatch(c.Request.Context(), user, items)
 	if err != nil {
 		c.Error(err)
 		return
@@ -204,20 +205,20 @@ func (handler *Handler) RemoveListItemBatch(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.RemoveListItemBatchRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	err := service.RemoveListItemBatch(user, req.ItemIDs)
+	err := service.RemoveListItemBatch(c.Request.Context(), user, req.ItemIDs)
 	if err != nil {
 		c.Error(err)
 		return
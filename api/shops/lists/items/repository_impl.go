@@ -1,13 +1,15 @@
 package items
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	"github.com/go-jet/jet/v2/postgres"
 	. "github.com/go-jet/jet/v2/postgres"
@@ -21,7 +23,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) AddListItem(user *bootstrap.User, item model.ShopListItems) (*response.ShopListItemWithUsername, error) {
+func (repo *RepositoryImpl) AddListItem(ctx context.Context, user *bootstrap.User, item model.ShopListItems) (*response.ShopListItemWithUsername, error) {
 	stmt := ShopListItems.INSERT(
 		ShopListItems.ID,
 		ShopListItems.ListID,
@@ -35,7 +37,7 @@ func (repo *RepositoryImpl) AddListItem(user *bootstrap.User, item model.ShopLis
 		ShopListItems.UnitOfMeasure,
 	).MODEL(item)
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to add list item: %w", err)
 	}
@@ -64,7 +66,7 @@ func (repo *RepositoryImpl) AddListItem(user *bootstrap.User, item model.ShopLis
 		AddedByUsername *string `sql:"added_by_username"`
 	}
 
-	err = selectStmt.Query(repo.db, &result)
+	err = selectStmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get created list item with username: %w", err)
 	}
@@ -86,7 +88,7 @@ func (repo *RepositoryImpl) AddListItem(user *bootstrap.User, item model.ShopLis
 	return createdItemWithUsername, nil
 }
 
-func (repo *RepositoryImpl) GetListItems(user *bootstrap.User, listID string) ([]response.ShopListItemWithUsername, error) {
+func (repo *RepositoryImpl) GetListItems(ctx context.Context, user *bootstrap.User, listID string) ([]response.ShopListItemWithUsername, error) {
 	stmt := SELECT(
 		ShopListItems.ID,
 		ShopListItems.ListID,
@@ -111,7 +113,7 @@ func (repo *RepositoryImpl) GetListItems(user *bootstrap.User, listID string) ([
 		AddedByUsername *string `sql:"added_by_username"`
 	}
 
-	err := stmt.Query(repo.db, &results)
+	err := stmt.QueryContext(ctx, repo.db, &results)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get list items with usernames: %w", err)
 	}
@@ -136,16 +138,16 @@ func (repo *RepositoryImpl) GetListItems(user *bootstrap.User, listID string) ([
 	return items, nil
 }
 
-func (repo *RepositoryImpl) GetListItemByID(user *bootstrap.User, itemID string) (*model.ShopListItems, error) {
+func (repo *RepositoryImpl) GetListItemByID(ctx context.Context, user *bootstrap.User, itemID string) (*model.ShopListItems, error) {
 	stmt := SELECT(ShopListItems.AllColumns).
 		FROM(ShopListItems).
 		WHERE(ShopListItems.ID.EQ(String(itemID)))
 
 	var item model.ShopListItems
-	err := stmt.Query(repo.db, &item)
+	err := stmt.QueryContext(ctx, repo.db, &item)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("list item not found")
+			return nil, shared.ErrListItemNotFound
 		}
 		return nil, fmt.Errorf("failed to get list item: %w", err)
 	}
@@ -153,7 +155,7 @@ func (repo *RepositoryImpl) GetListItemByID(user *bootstrap.User, itemID string)
 	return &item, nil
 }
 
-func (repo *RepositoryImpl) UpdateListItem(user *bootstrap.User, item model.ShopListItems) error {
+func (repo *RepositoryImpl) UpdateListItem(ctx context.Context, user *bootstrap.User, item model.ShopListItems) error {
 	stmt := ShopListItems.UPDATE(
 		ShopListItems.Niin,
 		ShopListItems.Nomenclature,
@@ -164,7 +166,7 @@ func (repo *RepositoryImpl) UpdateListItem(user *bootstrap.User, item model.Shop
 	).MODEL(item).
 		WHERE(ShopListItems.ID.EQ(String(item.ID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to update list item: %w", err)
 	}
@@ -175,17 +177,17 @@ func (repo *RepositoryImpl) UpdateListItem(user *bootstrap.User, item model.Shop
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("list item not found")
+		return shared.ErrListItemNotFound
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) RemoveListItem(user *bootstrap.User, itemID string) error {
+func (repo *RepositoryImpl) RemoveListItem(ctx context.Context, user *bootstrap.User, itemID string) error {
 	stmt := ShopListItems.DELETE().
 		WHERE(ShopListItems.ID.EQ(String(itemID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to remove list item: %w", err)
 	}
@@ -196,13 +198,13 @@ func (repo *RepositoryImpl) RemoveListItem(user *bootstrap.User, itemID string)
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("list item not found")
+		return shared.ErrListItemNotFound
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) AddListItemBatch(user *bootstrap.User, items []model.ShopListItems) ([]response.ShopListItemWithUsername, error) {
+func (repo *RepositoryImpl) AddListItemBatch(ctx context.Context, user *bootstrap.User, items []model.ShopListItems) ([]response.ShopListItemWithUsername, error) {
 	if len(items) == 0 {
 		return []response.ShopListItemWithUsername{}, nil
 	}
@@ -220,7 +222,7 @@ func (repo *RepositoryImpl) AddListItemBatch(user *bootstrap.User, items []model
 		ShopListItems.UnitOfMeasure,
 	).MODELS(items)
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to add list items: %w", err)
 	}
@@ -254,7 +256,7 @@ func (repo *RepositoryImpl) AddListItemBatch(user *bootstrap.User, items []model
 		AddedByUsername *string `sql:"added_by_username"`
 	}
 
-	err = selectStmt.Query(repo.db, &results)
+	err = selectStmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &results)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get created list items with usernames: %w", err)
 	}
@@ -279,7 +281,7 @@ func (repo *RepositoryImpl) AddListItemBatch(user *bootstrap.User, items []model
 	return createdItemsWithUsername, nil
 }
 
-func (repo *RepositoryImpl) RemoveListItemBatch(user *bootstrap.User, itemIDs []string) error {
+func (repo *RepositoryImpl) RemoveListItemBatch(ctx context.Context, user *bootstrap.User, itemIDs []string) error {
 	if len(itemIDs) == 0 {
 		return nil
 	}
@@ -292,7 +294,7 @@ func (repo *RepositoryImpl) RemoveListItemBatch(user *bootstrap.User, itemIDs []
 	stmt := ShopListItems.DELETE().
 		WHERE(ShopListItems.ID.IN(expressions...))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to remove list items: %w", err)
 	}
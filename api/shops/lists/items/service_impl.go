@@ -1,15 +1,18 @@
 package items
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/lists"
 	"miltechserver/api/shops/settings"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,14 +23,18 @@ type ServiceImpl struct {
 	listRepo     lists.Repository
 	settingsRepo settings.Repository
 	auth         shared.ShopAuthorization
+	audit        audit.Recorder
+	events       events.Publisher
 }
 
-func NewService(repo Repository, listRepo lists.Repository, settingsRepo settings.Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, listRepo lists.Repository, settingsRepo settings.Repository, auth shared.ShopAuthorization, recorder audit.Recorder, publisher events.Publisher) *ServiceImpl {
 	return &ServiceImpl{
 		repo:         repo,
 		listRepo:     listRepo,
 		settingsRepo: settingsRepo,
 		auth:         auth,
+		audit:        recorder,
+		events:       publisher,
 	}
 }
 
@@ -37,34 +44,36 @@ func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) sha
 		listRepo:     service.listRepo,
 		settingsRepo: service.settingsRepo,
 		auth:         auth,
+		audit:        service.audit,
+		events:       service.events,
 	}
 }
 
-func (service *ServiceImpl) AddListItem(user *bootstrap.User, item model.ShopListItems) (*response.ShopListItemWithUsername, error) {
+func (service *ServiceImpl) AddListItem(ctx context.Context, user *bootstrap.User, item model.ShopListItems) (*response.ShopListItemWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	list, err := service.listRepo.GetShopListByID(user, item.ListID)
+	list, err := service.listRepo.GetShopListByID(ctx, user, item.ListID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get list: %w", err)
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, list.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, list.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	canModify, err := service.canUserModifyListWithAdminOnlyCheck(user, list.ShopID)
+	canModify, err := service.canUserModifyListWithAdminOnlyCheck(ctx, user, list.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify list modification permissions: %w", err)
 	}
 	if !canModify {
-		return nil, errors.New("access denied: insufficient permissions to modify list items")
+		return nil, shared.ErrListItemsAccessDenied
 	}
 
 	item.ID = uuid.New().String()
@@ -73,35 +82,45 @@ func (service *ServiceImpl) AddListItem(user *bootstrap.User, item model.ShopLis
 	item.CreatedAt = now
 	item.UpdatedAt = now
 
-	createdItem, err := service.repo.AddListItem(user, item)
+	var createdItem *response.ShopListItemWithUsername
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		createdItem, err = service.repo.AddListItem(ctx, user, item)
+		if err != nil {
+			return fmt.Errorf("failed to add list item: %w", err)
+		}
+		if err := service.publishChange(ctx, user, list, events.ListItemsAdded, item.ID); err != nil {
+			return err
+		}
+		return service.recordItem(ctx, user, list.ShopID, item.ID, audit.ActionCreate, nil, createdItem)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to add list item: %w", err)
+		return nil, err
 	}
 
 	slog.Info("List item added", "user_id", user.UserID, "list_id", item.ListID, "item_id", item.ID)
 	return createdItem, nil
 }
 
-func (service *ServiceImpl) GetListItems(user *bootstrap.User, listID string) ([]response.ShopListItemWithUsername, error) {
+func (service *ServiceImpl) GetListItems(ctx context.Context, user *bootstrap.User, listID string) ([]response.ShopListItemWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	list, err := service.listRepo.GetShopListByID(user, listID)
+	list, err := service.listRepo.GetShopListByID(ctx, user, listID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get list: %w", err)
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, list.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, list.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	items, err := service.repo.GetListItems(user, listID)
+	items, err := service.repo.GetListItems(ctx, user, listID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get list items with usernames: %w", err)
 	}
@@ -113,119 +132,143 @@ func (service *ServiceImpl) GetListItems(user *bootstrap.User, listID string) ([
 	return items, nil
 }
 
-func (service *ServiceImpl) UpdateListItem(user *bootstrap.User, item model.ShopListItems) error {
+func (service *ServiceImpl) UpdateListItem(ctx context.Context, user *bootstrap.User, item model.ShopListItems) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	currentItem, err := service.repo.GetListItemByID(user, item.ID)
+	currentItem, err := service.repo.GetListItemByID(ctx, user, item.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get item: %w", err)
 	}
 
-	list, err := service.listRepo.GetShopListByID(user, currentItem.ListID)
+	list, err := service.listRepo.GetShopListByID(ctx, user, currentItem.ListID)
 	if err != nil {
 		return fmt.Errorf("failed to get list: %w", err)
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, list.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, list.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("access denied: user is not a member of this shop")
+		return shared.ErrNotShopMember
 	}
 
-	canModify, err := service.canUserModifyListWithAdminOnlyCheck(user, list.ShopID)
+	canModify, err := service.canUserModifyListWithAdminOnlyCheck(ctx, user, list.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify list modification permissions: %w", err)
 	}
 	if !canModify {
-		return errors.New("access denied: insufficient permissions to modify list items")
+		return shared.ErrListItemsAccessDenied
 	}
 
 	item.UpdatedAt = time.Now()
 
-	err = service.repo.UpdateListItem(user, item)
+	updatedItem := *currentItem
+	updatedItem.Niin = item.Niin
+	updatedItem.Nomenclature = item.Nomenclature
+	updatedItem.Quantity = item.Quantity
+	updatedItem.UpdatedAt = item.UpdatedAt
+	updatedItem.Nickname = item.Nickname
+	updatedItem.UnitOfMeasure = item.UnitOfMeasure
+
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.UpdateListItem(ctx, user, item); err != nil {
+			return fmt.Errorf("failed to update list item: %w", err)
+		}
+		if err := service.publishChange(ctx, user, list, events.ListItemsUpdated, item.ID); err != nil {
+			return err
+		}
+		return service.recordItem(ctx, user, list.ShopID, item.ID, audit.ActionUpdate, currentItem, updatedItem)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update list item: %w", err)
+		return err
 	}
 
 	slog.Info("List item updated", "user_id", user.UserID, "item_id", item.ID)
 	return nil
 }
 
-func (service *ServiceImpl) RemoveListItem(user *bootstrap.User, itemID string) error {
+func (service *ServiceImpl) RemoveListItem(ctx context.Context, user *bootstrap.User, itemID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	item, err := service.repo.GetListItemByID(user, itemID)
+	item, err := service.repo.GetListItemByID(ctx, user, itemID)
 	if err != nil {
 		return fmt.Errorf("failed to get item: %w", err)
 	}
 
-	list, err := service.listRepo.GetShopListByID(user, item.ListID)
+	list, err := service.listRepo.GetShopListByID(ctx, user, item.ListID)
 	if err != nil {
 		return fmt.Errorf("failed to get list: %w", err)
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, list.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, list.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("access denied: user is not a member of this shop")
+		return shared.ErrNotShopMember
 	}
 
-	canModify, err := service.canUserModifyListWithAdminOnlyCheck(user, list.ShopID)
+	canModify, err := service.canUserModifyListWithAdminOnlyCheck(ctx, user, list.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify list modification permissions: %w", err)
 	}
 	if !canModify {
-		return errors.New("access denied: insufficient permissions to modify list items")
-	}
-
-	err = service.repo.RemoveListItem(user, itemID)
+		return shared.ErrListItemsAccessDenied
+	}
+
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.RemoveListItem(ctx, user, itemID); err != nil {
+			return fmt.Errorf("failed to remove list item: %w", err)
+		}
+		if err := service.publishChange(ctx, user, list, events.ListItemsRemoved, itemID); err != nil {
+			return err
+		}
+		return service.recordItem(ctx, user, list.ShopID, itemID, audit.ActionDelete, item, nil)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to remove list item: %w", err)
+		return err
 	}
 
 	slog.Info("List item removed", "user_id", user.UserID, "item_id", itemID)
 	return nil
 }
 
-func (service *ServiceImpl) AddListItemBatch(user *bootstrap.User, items []model.ShopListItems) ([]response.ShopListItemWithUsername, error) {
+func (service *ServiceImpl) AddListItemBatch(ctx context.Context, user *bootstrap.User, items []model.ShopListItems) ([]response.ShopListItemWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
 	if len(items) == 0 {
-		return []response.ShopListItemWithUsername{}, errors.New("no items to add")
+		return []response.ShopListItemWithUsername{}, apperror.Validation("no_items", "no items to add")
 	}
 
-	list, err := service.listRepo.GetShopListByID(user, items[0].ListID)
+	list, err := service.listRepo.GetShopListByID(ctx, user, items[0].ListID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get list: %w", err)
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, list.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, list.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	canModify, err := service.canUserModifyListWithAdminOnlyCheck(user, list.ShopID)
+	canModify, err := service.canUserModifyListWithAdminOnlyCheck(ctx, user, list.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify list modification permissions: %w", err)
 	}
 	if !canModify {
-		return nil, errors.New("access denied: insufficient permissions to modify list items")
+		return nil, shared.ErrListItemsAccessDenied
 	}
 
 	now := time.Now()
@@ -236,65 +279,136 @@ func (service *ServiceImpl) AddListItemBatch(user *bootstrap.User, items []model
 		items[i].UpdatedAt = now
 	}
 
-	createdItems, err := service.repo.AddListItemBatch(user, items)
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+	}
+
+	var createdItems []response.ShopListItemWithUsername
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		createdItems, err = service.repo.AddListItemBatch(ctx, user, items)
+		if err != nil {
+			return fmt.Errorf("failed to add list items: %w", err)
+		}
+		if err := service.publishChange(ctx, user, list, events.ListItemsAdded, itemIDs...); err != nil {
+			return err
+		}
+		for _, createdItem := range createdItems {
+			if err := service.recordItem(ctx, user, list.ShopID, createdItem.ID, audit.ActionCreate, nil, createdItem); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to add list items: %w", err)
+		return nil, err
 	}
 
 	slog.Info("List items added", "user_id", user.UserID, "list_id", items[0].ListID, "count", len(createdItems))
 	return createdItems, nil
 }
 
-func (service *ServiceImpl) RemoveListItemBatch(user *bootstrap.User, itemIDs []string) error {
+func (service *ServiceImpl) RemoveListItemBatch(ctx context.Context, user *bootstrap.User, itemIDs []string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
 	if len(itemIDs) == 0 {
-		return errors.New("no items to remove")
+		return apperror.Validation("no_items", "no items to remove")
 	}
 
-	firstItem, err := service.repo.GetListItemByID(user, itemIDs[0])
+	firstItem, err := service.repo.GetListItemByID(ctx, user, itemIDs[0])
 	if err != nil {
 		return fmt.Errorf("failed to get item: %w", err)
 	}
 
-	list, err := service.listRepo.GetShopListByID(user, firstItem.ListID)
+	list, err := service.listRepo.GetShopListByID(ctx, user, firstItem.ListID)
 	if err != nil {
 		return fmt.Errorf("failed to get list: %w", err)
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, list.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, list.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("access denied: user is not a member of this shop")
+		return shared.ErrNotShopMember
 	}
 
-	canModify, err := service.canUserModifyListWithAdminOnlyCheck(user, list.ShopID)
+	canModify, err := service.canUserModifyListWithAdminOnlyCheck(ctx, user, list.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify list modification permissions: %w", err)
 	}
 	if !canModify {
-		return errors.New("access denied: insufficient permissions to modify list items")
+		return shared.ErrListItemsAccessDenied
 	}
 
-	err = service.repo.RemoveListItemBatch(user, itemIDs)
+	// One read of the list gives the audit log every item's state before removal
+	listItems, err := service.repo.GetListItems(ctx, user, list.ID)
 	if err != nil {
-		return fmt.Errorf("failed to remove list items: %w", err)
+		return fmt.Errorf("failed to get list items: %w", err)
+	}
+
+	before := make(map[string]response.ShopListItemWithUsername, len(listItems))
+	for _, item := range listItems {
+		before[item.ID] = item
+	}
+
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.RemoveListItemBatch(ctx, user, itemIDs); err != nil {
+			return fmt.Errorf("failed to remove list items: %w", err)
+		}
+		if err := service.publishChange(ctx, user, list, events.ListItemsRemoved, itemIDs...); err != nil {
+			return err
+		}
+		for _, itemID := range itemIDs {
+			var state any
+			if item, ok := before[itemID]; ok {
+				state = item
+			}
+			if err := service.recordItem(ctx, user, list.ShopID, itemID, audit.ActionDelete, state, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	slog.Info("List items removed", "user_id", user.UserID, "count", len(itemIDs))
 	return nil
 }
 
+// publishChange publishes a change to list's items in the transaction in ctx.
+func (service *ServiceImpl) publishChange(ctx context.Context, user *bootstrap.User, list *response.ShopListWithUsername, change string, itemIDs ...string) error {
+	return service.events.Publish(ctx, events.ListItemsChanged{
+		ShopID:    list.ShopID,
+		ListID:    list.ID,
+		ItemIDs:   itemIDs,
+		Change:    change,
+		ChangedBy: user.UserID,
+	})
+}
+
+func (service *ServiceImpl) recordItem(ctx context.Context, user *bootstrap.User, shopID, itemID string, action audit.Action, before, after any) error {
+	return service.audit.Record(ctx, audit.Entry{
+		ShopID:     shopID,
+		ActorID:    user.UserID,
+		EntityType: audit.EntityShopListItem,
+		EntityID:   itemID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	})
+}
+
 // canUserModifyListWithAdminOnlyCheck checks if user can modify lists based on shop's admin_only_lists setting
 // If admin_only_lists is true, only shop admins can modify lists
 // If admin_only_lists is false, all shop members can modify lists
-func (service *ServiceImpl) canUserModifyListWithAdminOnlyCheck(user *bootstrap.User, shopID string) (bool, error) {
-	adminOnlyLists, err := service.settingsRepo.GetShopAdminOnlyListsSetting(shopID)
+func (service *ServiceImpl) canUserModifyListWithAdminOnlyCheck(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
+	adminOnlyLists, err := service.settingsRepo.GetShopAdminOnlyListsSetting(ctx, shopID)
 	if err != nil {
 		return false, fmt.Errorf("failed to get admin_only_lists setting: %w", err)
 	}
@@ -303,7 +417,7 @@ func (service *ServiceImpl) canUserModifyListWithAdminOnlyCheck(user *bootstrap.
 		return true, nil
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return false, fmt.Errorf("failed to verify admin status: %w", err)
 	}
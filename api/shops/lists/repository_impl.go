@@ -1,13 +1,15 @@
 package lists
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
@@ -20,7 +22,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) CreateShopList(user *bootstrap.User, list model.ShopLists) (*response.ShopListWithUsername, error) {
+func (repo *RepositoryImpl) CreateShopList(ctx context.Context, user *bootstrap.User, list model.ShopLists) (*response.ShopListWithUsername, error) {
 	stmt := ShopLists.INSERT(
 		ShopLists.ID,
 		ShopLists.ShopID,
@@ -30,7 +32,7 @@ func (repo *RepositoryImpl) CreateShopList(user *bootstrap.User, list model.Shop
 		ShopLists.UpdatedAt,
 	).MODEL(list)
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create shop list: %w", err)
 	}
@@ -55,7 +57,7 @@ func (repo *RepositoryImpl) CreateShopList(user *bootstrap.User, list model.Shop
 		CreatedByUsername *string `sql:"created_by_username"`
 	}
 
-	err = selectStmt.Query(repo.db, &result)
+	err = selectStmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get created shop list with username: %w", err)
 	}
@@ -73,7 +75,7 @@ func (repo *RepositoryImpl) CreateShopList(user *bootstrap.User, list model.Shop
 	return createdListWithUsername, nil
 }
 
-func (repo *RepositoryImpl) GetShopLists(user *bootstrap.User, shopID string) ([]response.ShopListWithUsername, error) {
+func (repo *RepositoryImpl) GetShopLists(ctx context.Context, user *bootstrap.User, shopID string) ([]response.ShopListWithUsername, error) {
 	stmt := SELECT(
 		ShopLists.ID,
 		ShopLists.ShopID,
@@ -94,7 +96,7 @@ func (repo *RepositoryImpl) GetShopLists(user *bootstrap.User, shopID string) ([
 		CreatedByUsername *string `sql:"created_by_username"`
 	}
 
-	err := stmt.Query(repo.db, &results)
+	err := stmt.QueryContext(ctx, repo.db, &results)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop lists with usernames: %w", err)
 	}
@@ -115,7 +117,7 @@ func (repo *RepositoryImpl) GetShopLists(user *bootstrap.User, shopID string) ([
 	return lists, nil
 }
 
-func (repo *RepositoryImpl) GetShopListByID(user *bootstrap.User, listID string) (*response.ShopListWithUsername, error) {
+func (repo *RepositoryImpl) GetShopListByID(ctx context.Context, user *bootstrap.User, listID string) (*response.ShopListWithUsername, error) {
 	stmt := SELECT(
 		ShopLists.ID,
 		ShopLists.ShopID,
@@ -136,10 +138,10 @@ func (repo *RepositoryImpl) GetShopListByID(user *bootstrap.User, listID string)
 		CreatedByUsername *string `sql:"created_by_username"`
 	}
 
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("shop list not found")
+			return nil, shared.ErrShopListNotFound
 		}
 		return nil, fmt.Errorf("failed to get shop list: %w", err)
 	}
@@ -157,14 +159,14 @@ func (repo *RepositoryImpl) GetShopListByID(user *bootstrap.User, listID string)
 	return listWithUsername, nil
 }
 
-func (repo *RepositoryImpl) UpdateShopList(user *bootstrap.User, list model.ShopLists) error {
+func (repo *RepositoryImpl) UpdateShopList(ctx context.Context, user *bootstrap.User, list model.ShopLists) error {
 	stmt := ShopLists.UPDATE(
 		ShopLists.Description,
 		ShopLists.UpdatedAt,
 	).MODEL(list).
 		WHERE(ShopLists.ID.EQ(String(list.ID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to update shop list: %w", err)
 	}
@@ -175,17 +177,17 @@ func (repo *RepositoryImpl) UpdateShopList(user *bootstrap.User, list model.Shop
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("shop list not found")
+		return shared.ErrShopListNotFound
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteShopList(user *bootstrap.User, listID string) error {
+func (repo *RepositoryImpl) DeleteShopList(ctx context.Context, user *bootstrap.User, listID string) error {
 	stmt := ShopLists.DELETE().
 		WHERE(ShopLists.ID.EQ(String(listID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete shop list: %w", err)
 	}
@@ -196,7 +198,7 @@ func (repo *RepositoryImpl) DeleteShopList(user *bootstrap.User, listID string)
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("shop list not found")
+		return shared.ErrShopListNotFound
 	}
 
 	return nil
@@ -1,11 +1,13 @@
 package lists
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/settings"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
@@ -18,13 +20,15 @@ type ServiceImpl struct {
 	repo         Repository
 	settingsRepo settings.Repository
 	auth         shared.ShopAuthorization
+	audit        audit.Recorder
 }
 
-func NewService(repo Repository, settingsRepo settings.Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, settingsRepo settings.Repository, auth shared.ShopAuthorization, recorder audit.Recorder) *ServiceImpl {
 	return &ServiceImpl{
 		repo:         repo,
 		settingsRepo: settingsRepo,
 		auth:         auth,
+		audit:        recorder,
 	}
 }
 
@@ -33,29 +37,30 @@ func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) sha
 		repo:         service.repo,
 		settingsRepo: service.settingsRepo,
 		auth:         auth,
+		audit:        service.audit,
 	}
 }
 
-func (service *ServiceImpl) CreateShopList(user *bootstrap.User, list model.ShopLists) (*response.ShopListWithUsername, error) {
+func (service *ServiceImpl) CreateShopList(ctx context.Context, user *bootstrap.User, list model.ShopLists) (*response.ShopListWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, list.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, list.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	canModify, err := service.canUserModifyListWithAdminOnlyCheck(user, list.ShopID)
+	canModify, err := service.canUserModifyListWithAdminOnlyCheck(ctx, user, list.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify list modification permissions: %w", err)
 	}
 	if !canModify {
-		return nil, errors.New("access denied: insufficient permissions to create lists")
+		return nil, apperror.Forbidden("list_access_denied", "access denied: insufficient permissions to create lists")
 	}
 
 	list.ID = uuid.New().String()
@@ -64,30 +69,45 @@ func (service *ServiceImpl) CreateShopList(user *bootstrap.User, list model.Shop
 	list.CreatedAt = now
 	list.UpdatedAt = now
 
-	createdList, err := service.repo.CreateShopList(user, list)
+	var createdList *response.ShopListWithUsername
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		createdList, err = service.repo.CreateShopList(ctx, user, list)
+		if err != nil {
+			return fmt.Errorf("failed to create shop list: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     list.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShopList,
+			EntityID:   list.ID,
+			Action:     audit.ActionCreate,
+			After:      createdList,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create shop list: %w", err)
+		return nil, err
 	}
 
 	slog.Info("Shop list created", "user_id", user.UserID, "shop_id", list.ShopID, "list_id", list.ID)
 	return createdList, nil
 }
 
-func (service *ServiceImpl) GetShopLists(user *bootstrap.User, shopID string) ([]response.ShopListWithUsername, error) {
+func (service *ServiceImpl) GetShopLists(ctx context.Context, user *bootstrap.User, shopID string) ([]response.ShopListWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	lists, err := service.repo.GetShopLists(user, shopID)
+	lists, err := service.repo.GetShopLists(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop lists with usernames: %w", err)
 	}
@@ -99,78 +119,110 @@ func (service *ServiceImpl) GetShopLists(user *bootstrap.User, shopID string) ([
 	return lists, nil
 }
 
-func (service *ServiceImpl) GetShopListByID(user *bootstrap.User, listID string) (*response.ShopListWithUsername, error) {
+func (service *ServiceImpl) GetShopListByID(ctx context.Context, user *bootstrap.User, listID string) (*response.ShopListWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	list, err := service.repo.GetShopListByID(user, listID)
+	list, err := service.repo.GetShopListByID(ctx, user, listID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop list: %w", err)
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, list.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, list.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	return list, nil
 }
 
-func (service *ServiceImpl) UpdateShopList(user *bootstrap.User, list model.ShopLists) error {
+func (service *ServiceImpl) UpdateShopList(ctx context.Context, user *bootstrap.User, list model.ShopLists) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	currentList, err := service.repo.GetShopListByID(user, list.ID)
+	currentList, err := service.repo.GetShopListByID(ctx, user, list.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get list: %w", err)
 	}
 
-	canModify, err := service.canUserModifyListWithAdminOnlyCheck(user, currentList.ShopID)
+	canModify, err := service.canUserModifyListWithAdminOnlyCheck(ctx, user, currentList.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify list modification permissions: %w", err)
 	}
 	if !canModify {
-		return errors.New("access denied: insufficient permissions to modify lists")
+		return apperror.Forbidden("list_access_denied", "access denied: insufficient permissions to modify lists")
 	}
 
 	list.UpdatedAt = time.Now()
 
-	err = service.repo.UpdateShopList(user, list)
+	updatedList := *currentList
+	updatedList.Description = list.Description
+	updatedList.UpdatedAt = &list.UpdatedAt
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.UpdateShopList(ctx, user, list)
+		if err != nil {
+			return fmt.Errorf("failed to update shop list: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     currentList.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShopList,
+			EntityID:   list.ID,
+			Action:     audit.ActionUpdate,
+			Before:     currentList,
+			After:      updatedList,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update shop list: %w", err)
+		return err
 	}
 
 	slog.Info("Shop list updated", "user_id", user.UserID, "list_id", list.ID)
 	return nil
 }
 
-func (service *ServiceImpl) DeleteShopList(user *bootstrap.User, listID string) error {
+func (service *ServiceImpl) DeleteShopList(ctx context.Context, user *bootstrap.User, listID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	list, err := service.repo.GetShopListByID(user, listID)
+	list, err := service.repo.GetShopListByID(ctx, user, listID)
 	if err != nil {
 		return fmt.Errorf("failed to get list: %w", err)
 	}
 
-	canDelete, err := service.canUserModifyListWithAdminOnlyCheck(user, list.ShopID)
+	canDelete, err := service.canUserModifyListWithAdminOnlyCheck(ctx, user, list.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify list modification permissions: %w", err)
 	}
 	if !canDelete {
-		return errors.New("access denied: insufficient permissions to delete lists")
-	}
-
-	err = service.repo.DeleteShopList(user, listID)
+		return apperror.Forbidden("list_access_denied", "access denied: insufficient permissions to delete lists")
+	}
+
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.DeleteShopList(ctx, user, listID)
+		if err != nil {
+			return fmt.Errorf("failed to delete shop list: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     list.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShopList,
+			EntityID:   listID,
+			Action:     audit.ActionDelete,
+			Before:     list,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete shop list: %w", err)
+		return err
 	}
 
 	slog.Info("Shop list deleted", "user_id", user.UserID, "list_id", listID)
@@ -180,8 +232,8 @@ func (service *ServiceImpl) DeleteShopList(user *bootstrap.User, listID string)
 // canUserModifyListWithAdminOnlyCheck checks if user can modify lists based on shop's admin_only_lists setting
 // If admin_only_lists is true, only shop admins can modify lists
 // If admin_only_lists is false, all shop members can modify lists
-func (service *ServiceImpl) canUserModifyListWithAdminOnlyCheck(user *bootstrap.User, shopID string) (bool, error) {
-	adminOnlyLists, err := service.settingsRepo.GetShopAdminOnlyListsSetting(shopID)
+func (service *ServiceImpl) canUserModifyListWithAdminOnlyCheck(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
+	adminOnlyLists, err := service.settingsRepo.GetShopAdminOnlyListsSetting(ctx, shopID)
 	if err != nil {
 		return false, fmt.Errorf("failed to get admin_only_lists setting: %w", err)
 	}
@@ -190,7 +242,7 @@ func (service *ServiceImpl) canUserModifyListWithAdminOnlyCheck(user *bootstrap.
 		return true, nil
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return false, fmt.Errorf("failed to verify admin status: %w", err)
 	}
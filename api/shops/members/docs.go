@@ -0,0 +1,62 @@
+package members
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Members",
+	Operations: []openapi.Operation{
+		{
+			ID:       "joinShopViaInviteCode",
+			Method:   http.MethodPost,
+			Path:     "/shops/join",
+			Auth:     true,
+			Summary:  "Allows a user to join a shop using an invite code",
+			Request:  request.JoinShopRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "leaveShop",
+			Method:   http.MethodDelete,
+			Path:     "/shops/:shop_id/leave",
+			Auth:     true,
+			Summary:  "Allows a user to leave a shop",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "removeMemberFromShop",
+			Method:   http.MethodDelete,
+			Path:     "/shops/members/remove",
+			Auth:     true,
+			Summary:  "Allows admins to remove members from a shop",
+			Request:  request.RemoveMemberRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "promoteMemberToAdmin",
+			Method:   http.MethodPut,
+			Path:     "/shops/members/promote",
+			Auth:     true,
+			Summary:  "Allows admins to promote members to admin role",
+			Request:  request.PromoteMemberRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "getShopMembers",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/members",
+			Auth:     true,
+			Summary:  "Returns all members of a shop",
+			Response: []response.ShopMemberWithUsername{},
+		},
+	},
+}}
@@ -2,6 +2,7 @@ package members
 
 import (
 	"log/slog"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -21,20 +22,20 @@ func (handler *Handler) JoinShopViaInviteCode(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.JoinShopRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	err := service.JoinShopViaInviteCode(user, req.InviteCode)
+	err := service.JoinShopViaInviteCode(c.Request.Context(), user, req.InviteCode)
 	if err != nil {
 		c.Error(err)
 		return
@@ -49,19 +50,19 @@ func (handler *Handler) LeaveShop(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.LeaveShop(user, shopID)
+	err := service.LeaveShop(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -76,20 +77,20 @@ func (handler *Handler) RemoveMemberFromShop(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.RemoveMemberRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	err := service.RemoveMemberFromShop(user, req.ShopID, req.TargetUserID)
+	err := service.RemoveMemberFromShop(c.Request.Context(), user, req.ShopID, req.TargetUserID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -104,20 +105,20 @@ func (handler *Handler) PromoteMemberToAdmin(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.PromoteMemberRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	err := service.PromoteMemberToAdmin(user, req.ShopID, req.TargetUserID)
+	err := service.PromoteMemberToAdmin(c.Request.Context(), user, req.ShopID, req.TargetUserID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -132,19 +133,19 @@ func (handler *Handler) GetShopMembers(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	members, err := service.GetShopMembers(user, shopID)
+	members, err := service.GetShopMembers(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
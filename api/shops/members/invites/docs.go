@@ -0,0 +1,51 @@
+package invites
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Members",
+	Operations: []openapi.Operation{
+		{
+			ID:       "generateInviteCode",
+			Method:   http.MethodPost,
+			Path:     "/shops/invite-codes",
+			Auth:     true,
+			Summary:  "Creates a new invite code for a shop",
+			Request:  request.GenerateInviteCodeRequest{},
+			Status:   http.StatusCreated,
+			Response: model.ShopInviteCodes{},
+		},
+		{
+			ID:       "getInviteCodesByShop",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/invite-codes",
+			Auth:     true,
+			Summary:  "Returns all invite codes for a shop",
+			Response: []model.ShopInviteCodes{},
+		},
+		{
+			ID:       "deactivateInviteCode",
+			Method:   http.MethodDelete,
+			Path:     "/shops/invite-codes/:code_id",
+			Auth:     true,
+			Summary:  "Deactivates an invite code",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "deleteInviteCode",
+			Method:   http.MethodDelete,
+			Path:     "/shops/invite-codes/:code_id/delete",
+			Auth:     true,
+			Summary:  "Permanently deletes an invite code",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+	},
+}}
@@ -2,6 +2,7 @@ package invites
 
 import (
 	"log/slog"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -21,20 +22,20 @@ func (handler *Handler) GenerateInviteCode(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.GenerateInviteCodeRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	code, err := service.GenerateInviteCode(user, req.ShopID)
+	code, err := service.GenerateInviteCode(c.Request.Context(), user, req.ShopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -53,19 +54,19 @@ func (handler *Handler) GetInviteCodesByShop(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	codes, err := service.GetInviteCodesByShop(user, shopID)
+	codes, err := service.GetInviteCodesByShop(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -84,19 +85,19 @@ func (handler *Handler) DeactivateInviteCode(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	codeID := c.Param("code_id")
 	if codeID == "" {
-		c.JSON(400, gin.H{"message": "code_id is required"})
+		c.Error(apperror.MissingParameter("code_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.DeactivateInviteCode(user, codeID)
+	err := service.DeactivateInviteCode(c.Request.Context(), user, codeID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -111,19 +112,19 @@ func (handler *Handler) DeleteInviteCode(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	codeID := c.Param("code_id")
 	if codeID == "" {
-		c.JSON(400, gin.H{"message": "code_id is required"})
+		c.Error(apperror.MissingParameter("code_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.DeleteInviteCode(user, codeID)
+	err := service.DeleteInviteCode(c.Request.Context(), user, codeID)
 	if err != nil {
 		c.Error(err)
 		return
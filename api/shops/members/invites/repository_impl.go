@@ -1,13 +1,15 @@
 package invites
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
@@ -20,7 +22,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) CreateInviteCode(user *bootstrap.User, inviteCode model.ShopInviteCodes) (*model.ShopInviteCodes, error) {
+func (repo *RepositoryImpl) CreateInviteCode(ctx context.Context, user *bootstrap.User, inviteCode model.ShopInviteCodes) (*model.ShopInviteCodes, error) {
 	stmt := ShopInviteCodes.INSERT(
 		ShopInviteCodes.ID,
 		ShopInviteCodes.ShopID,
@@ -31,7 +33,7 @@ func (repo *RepositoryImpl) CreateInviteCode(user *bootstrap.User, inviteCode mo
 	).MODEL(inviteCode).RETURNING(ShopInviteCodes.AllColumns)
 
 	var createdCode model.ShopInviteCodes
-	err := stmt.Query(repo.db, &createdCode)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &createdCode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create invite code: %w", err)
 	}
@@ -39,13 +41,13 @@ func (repo *RepositoryImpl) CreateInviteCode(user *bootstrap.User, inviteCode mo
 	return &createdCode, nil
 }
 
-func (repo *RepositoryImpl) GetInviteCodeByCode(code string) (*model.ShopInviteCodes, error) {
+func (repo *RepositoryImpl) GetInviteCodeByCode(ctx context.Context, code string) (*model.ShopInviteCodes, error) {
 	stmt := SELECT(ShopInviteCodes.AllColumns).
 		FROM(ShopInviteCodes).
 		WHERE(ShopInviteCodes.Code.EQ(String(code)))
 
 	var inviteCode model.ShopInviteCodes
-	err := stmt.Query(repo.db, &inviteCode)
+	err := stmt.QueryContext(ctx, repo.db, &inviteCode)
 	if err != nil {
 		return nil, fmt.Errorf("invite code not found: %w", err)
 	}
@@ -53,13 +55,13 @@ func (repo *RepositoryImpl) GetInviteCodeByCode(code string) (*model.ShopInviteC
 	return &inviteCode, nil
 }
 
-func (repo *RepositoryImpl) GetInviteCodeByID(codeID string) (*model.ShopInviteCodes, error) {
+func (repo *RepositoryImpl) GetInviteCodeByID(ctx context.Context, codeID string) (*model.ShopInviteCodes, error) {
 	stmt := SELECT(ShopInviteCodes.AllColumns).
 		FROM(ShopInviteCodes).
 		WHERE(ShopInviteCodes.ID.EQ(String(codeID)))
 
 	var inviteCode model.ShopInviteCodes
-	err := stmt.Query(repo.db, &inviteCode)
+	err := stmt.QueryContext(ctx, repo.db, &inviteCode)
 	if err != nil {
 		return nil, fmt.Errorf("invite code not found: %w", err)
 	}
@@ -67,14 +69,14 @@ func (repo *RepositoryImpl) GetInviteCodeByID(codeID string) (*model.ShopInviteC
 	return &inviteCode, nil
 }
 
-func (repo *RepositoryImpl) GetInviteCodesByShop(user *bootstrap.User, shopID string) ([]model.ShopInviteCodes, error) {
+func (repo *RepositoryImpl) GetInviteCodesByShop(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopInviteCodes, error) {
 	stmt := SELECT(ShopInviteCodes.AllColumns).
 		FROM(ShopInviteCodes).
 		WHERE(ShopInviteCodes.ShopID.EQ(String(shopID))).
 		ORDER_BY(ShopInviteCodes.CreatedAt.DESC())
 
 	var codes []model.ShopInviteCodes
-	err := stmt.Query(repo.db, &codes)
+	err := stmt.QueryContext(ctx, repo.db, &codes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invite codes: %w", err)
 	}
@@ -82,14 +84,14 @@ func (repo *RepositoryImpl) GetInviteCodesByShop(user *bootstrap.User, shopID st
 	return codes, nil
 }
 
-func (repo *RepositoryImpl) DeactivateInviteCode(user *bootstrap.User, codeID string) error {
+func (repo *RepositoryImpl) DeactivateInviteCode(ctx context.Context, user *bootstrap.User, codeID string) error {
 	stmt := ShopInviteCodes.UPDATE(
 		ShopInviteCodes.IsActive,
 	).SET(
 		ShopInviteCodes.IsActive.SET(Bool(false)),
 	).WHERE(ShopInviteCodes.ID.EQ(String(codeID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to deactivate invite code: %w", err)
 	}
@@ -100,16 +102,16 @@ func (repo *RepositoryImpl) DeactivateInviteCode(user *bootstrap.User, codeID st
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("invite code not found")
+		return shared.ErrInviteCodeNotFound
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteInviteCode(user *bootstrap.User, codeID string) error {
+func (repo *RepositoryImpl) DeleteInviteCode(ctx context.Context, user *bootstrap.User, codeID string) error {
 	stmt := ShopInviteCodes.DELETE().WHERE(ShopInviteCodes.ID.EQ(String(codeID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete invite code: %w", err)
 	}
@@ -120,7 +122,7 @@ func (repo *RepositoryImpl) DeleteInviteCode(user *bootstrap.User, codeID string
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("invite code not found")
+		return shared.ErrInviteCodeNotFound
 	}
 
 	slog.Info("Invite code deleted from database", "code_id", codeID, "deleted_by", user.UserID)
@@ -1,13 +1,14 @@
 package invites
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
 
 type Service interface {
-	GenerateInviteCode(user *bootstrap.User, shopID string) (*model.ShopInviteCodes, error)
-	GetInviteCodesByShop(user *bootstrap.User, shopID string) ([]model.ShopInviteCodes, error)
-	DeactivateInviteCode(user *bootstrap.User, codeID string) error
-	DeleteInviteCode(user *bootstrap.User, codeID string) error
+	GenerateInviteCode(ctx context.Context, user *bootstrap.User, shopID string) (*model.ShopInviteCodes, error)
+	GetInviteCodesByShop(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopInviteCodes, error)
+	DeactivateInviteCode(ctx context.Context, user *bootstrap.User, codeID string) error
+	DeleteInviteCode(ctx context.Context, user *bootstrap.User, codeID string) error
 }
@@ -1,12 +1,14 @@
 package invites
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
 	"strings"
@@ -16,36 +18,39 @@ import (
 )
 
 type ServiceImpl struct {
-	repo Repository
-	auth shared.ShopAuthorization
+	repo  Repository
+	auth  shared.ShopAuthorization
+	audit audit.Recorder
 }
 
-func NewService(repo Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, auth shared.ShopAuthorization, recorder audit.Recorder) *ServiceImpl {
 	return &ServiceImpl{
-		repo: repo,
-		auth: auth,
+		repo:  repo,
+		auth:  auth,
+		audit: recorder,
 	}
 }
 
 func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
 	return &ServiceImpl{
-		repo: service.repo,
-		auth: auth,
+		repo:  service.repo,
+		auth:  auth,
+		audit: service.audit,
 	}
 }
 
-func (service *ServiceImpl) GenerateInviteCode(user *bootstrap.User, shopID string) (*model.ShopInviteCodes, error) {
+func (service *ServiceImpl) GenerateInviteCode(ctx context.Context, user *bootstrap.User, shopID string) (*model.ShopInviteCodes, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	code, err := generateShortCode()
@@ -64,30 +69,45 @@ func (service *ServiceImpl) GenerateInviteCode(user *bootstrap.User, shopID stri
 	now := time.Now()
 	inviteCode.CreatedAt = &now
 
-	createdCode, err := service.repo.CreateInviteCode(user, inviteCode)
+	var createdCode *model.ShopInviteCodes
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		createdCode, err = service.repo.CreateInviteCode(ctx, user, inviteCode)
+		if err != nil {
+			return fmt.Errorf("failed to create invite code: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     shopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityInviteCode,
+			EntityID:   createdCode.ID,
+			Action:     audit.ActionCreate,
+			After:      createdCode,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create invite code: %w", err)
+		return nil, err
 	}
 
 	slog.Info("Invite code generated", "user_id", user.UserID, "shop_id", shopID, "code", code)
 	return createdCode, nil
 }
 
-func (service *ServiceImpl) GetInviteCodesByShop(user *bootstrap.User, shopID string) ([]model.ShopInviteCodes, error) {
+func (service *ServiceImpl) GetInviteCodesByShop(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopInviteCodes, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	codes, err := service.repo.GetInviteCodesByShop(user, shopID)
+	codes, err := service.repo.GetInviteCodesByShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invite codes: %w", err)
 	}
@@ -99,56 +119,87 @@ func (service *ServiceImpl) GetInviteCodesByShop(user *bootstrap.User, shopID st
 	return codes, nil
 }
 
-func (service *ServiceImpl) DeactivateInviteCode(user *bootstrap.User, codeID string) error {
+func (service *ServiceImpl) DeactivateInviteCode(ctx context.Context, user *bootstrap.User, codeID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	inviteCode, err := service.repo.GetInviteCodeByID(codeID)
+	inviteCode, err := service.repo.GetInviteCodeByID(ctx, codeID)
 	if err != nil {
 		return fmt.Errorf("failed to get invite code: %w", err)
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, inviteCode.ShopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, inviteCode.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isAdmin {
-		return errors.New("only shop administrators can deactivate invite codes")
-	}
-
-	err = service.repo.DeactivateInviteCode(user, codeID)
+		return apperror.Forbidden("shop_admin_required", "only shop administrators can deactivate invite codes")
+	}
+
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.DeactivateInviteCode(ctx, user, codeID)
+		if err != nil {
+			return fmt.Errorf("failed to deactivate invite code: %w", err)
+		}
+
+		deactivatedCode := *inviteCode
+		deactivatedCode.IsActive = func() *bool { b := false; return &b }()
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     inviteCode.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityInviteCode,
+			EntityID:   codeID,
+			Action:     audit.ActionDeactivate,
+			Before:     inviteCode,
+			After:      deactivatedCode,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to deactivate invite code: %w", err)
+		return err
 	}
 
 	slog.Info("Invite code deactivated", "user_id", user.UserID, "code_id", codeID)
 	return nil
 }
 
-func (service *ServiceImpl) DeleteInviteCode(user *bootstrap.User, codeID string) error {
+func (service *ServiceImpl) DeleteInviteCode(ctx context.Context, user *bootstrap.User, codeID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	inviteCode, err := service.repo.GetInviteCodeByID(codeID)
+	inviteCode, err := service.repo.GetInviteCodeByID(ctx, codeID)
 	if err != nil {
 		return fmt.Errorf("failed to get invite code: %w", err)
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, inviteCode.ShopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, inviteCode.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isAdmin {
-		return errors.New("only shop administrators can delete invite codes")
-	}
-
-	err = service.repo.DeleteInviteCode(user, codeID)
+		return apperror.Forbidden("shop_admin_required", "only shop administrators can delete invite codes")
+	}
+
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.DeleteInviteCode(ctx, user, codeID)
+		if err != nil {
+			return fmt.Errorf("failed to delete invite code: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     inviteCode.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityInviteCode,
+			EntityID:   codeID,
+			Action:     audit.ActionDelete,
+			Before:     inviteCode,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete invite code: %w", err)
+		return err
 	}
 
 	slog.Info("Invite code deleted", "user_id", user.UserID, "code_id", codeID)
@@ -3,18 +3,19 @@ package members
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
+	"miltechserver/storage"
 	"sync/atomic"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	. "github.com/go-jet/jet/v2/postgres"
 	"golang.org/x/sync/errgroup"
 )
@@ -26,20 +27,20 @@ const (
 )
 
 type RepositoryImpl struct {
-	db         *sql.DB
-	blobClient *azblob.Client
-	env        *bootstrap.Env
+	db    *sql.DB
+	store storage.ObjectStore
+	env   *bootstrap.Env
 }
 
-func NewRepository(db *sql.DB, blobClient *azblob.Client, env *bootstrap.Env) *RepositoryImpl {
+func NewRepository(db *sql.DB, store storage.ObjectStore, env *bootstrap.Env) *RepositoryImpl {
 	return &RepositoryImpl{
-		db:         db,
-		blobClient: blobClient,
-		env:        env,
+		db:    db,
+		store: store,
+		env:   env,
 	}
 }
 
-func (repo *RepositoryImpl) IsUserShopAdmin(user *bootstrap.User, shopID string) (bool, error) {
+func (repo *RepositoryImpl) IsUserShopAdmin(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	stmt := SELECT(Int(1).AS("exists")).
 		FROM(ShopMembers).
 		WHERE(
@@ -52,7 +53,7 @@ func (repo *RepositoryImpl) IsUserShopAdmin(user *bootstrap.User, shopID string)
 	var result []struct {
 		Exists int `sql:"exists"`
 	}
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		return false, fmt.Errorf("failed to check admin status: %w", err)
 	}
@@ -60,7 +61,7 @@ func (repo *RepositoryImpl) IsUserShopAdmin(user *bootstrap.User, shopID string)
 	return len(result) > 0, nil
 }
 
-func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID string) (bool, error) {
+func (repo *RepositoryImpl) IsUserMemberOfShop(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	stmt := SELECT(Int(1).AS("exists")).
 		FROM(ShopMembers).
 		WHERE(
@@ -72,7 +73,7 @@ func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID stri
 	var result []struct {
 		Exists int `sql:"exists"`
 	}
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		return false, fmt.Errorf("failed to check membership: %w", err)
 	}
@@ -81,7 +82,7 @@ func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID stri
 }
 
 // Shop Member Operations
-func (repo *RepositoryImpl) AddMemberToShop(user *bootstrap.User, shopID string, role string) error {
+func (repo *RepositoryImpl) AddMemberToShop(ctx context.Context, user *bootstrap.User, shopID string, role string) error {
 	curTime := time.Now().UTC()
 	member := model.ShopMembers{
 		ID:       fmt.Sprintf("%s_%s", shopID, user.UserID),
@@ -101,7 +102,7 @@ func (repo *RepositoryImpl) AddMemberToShop(user *bootstrap.User, shopID string,
 		ON_CONFLICT(ShopMembers.ShopID, ShopMembers.UserID).
 		DO_UPDATE(SET(ShopMembers.Role.SET(String(role))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to add member to shop: %w", err)
 	}
@@ -110,14 +111,14 @@ func (repo *RepositoryImpl) AddMemberToShop(user *bootstrap.User, shopID string,
 	return nil
 }
 
-func (repo *RepositoryImpl) RemoveMemberFromShop(user *bootstrap.User, shopID string, targetUserID string) error {
+func (repo *RepositoryImpl) RemoveMemberFromShop(ctx context.Context, user *bootstrap.User, shopID string, targetUserID string) error {
 	stmt := ShopMembers.DELETE().
 		WHERE(
 			ShopMembers.ShopID.EQ(String(shopID)).
 				AND(ShopMembers.UserID.EQ(String(targetUserID))),
 		)
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to remove member from shop: %w", err)
 	}
@@ -128,14 +129,14 @@ func (repo *RepositoryImpl) RemoveMemberFromShop(user *bootstrap.User, shopID st
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("member not found in shop")
+		return shared.ErrMemberNotInShop
 	}
 
 	slog.Info("Member removed from shop", "shop_id", shopID, "removed_user_id", targetUserID, "removed_by", user.UserID)
 	return nil
 }
 
-func (repo *RepositoryImpl) UpdateMemberRole(user *bootstrap.User, shopID string, targetUserID string, newRole string) error {
+func (repo *RepositoryImpl) UpdateMemberRole(ctx context.Context, user *bootstrap.User, shopID string, targetUserID string, newRole string) error {
 	stmt := ShopMembers.UPDATE(
 		ShopMembers.Role,
 	).SET(
@@ -145,7 +146,7 @@ func (repo *RepositoryImpl) UpdateMemberRole(user *bootstrap.User, shopID string
 			AND(ShopMembers.UserID.EQ(String(targetUserID))),
 	)
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to update member role: %w", err)
 	}
@@ -156,14 +157,14 @@ func (repo *RepositoryImpl) UpdateMemberRole(user *bootstrap.User, shopID string
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("member not found in shop")
+		return shared.ErrMemberNotInShop
 	}
 
 	slog.Info("Member role updated", "shop_id", shopID, "target_user_id", targetUserID, "new_role", newRole, "updated_by", user.UserID)
 	return nil
 }
 
-func (repo *RepositoryImpl) GetShopMembers(user *bootstrap.User, shopID string) ([]response.ShopMemberWithUsername, error) {
+func (repo *RepositoryImpl) GetShopMembers(ctx context.Context, user *bootstrap.User, shopID string) ([]response.ShopMemberWithUsername, error) {
 	rawSQL := `
 		SELECT 
 			sm.id,
@@ -178,7 +179,7 @@ func (repo *RepositoryImpl) GetShopMembers(user *bootstrap.User, shopID string)
 		ORDER BY sm.joined_at ASC
 	`
 
-	rows, err := repo.db.Query(rawSQL, shopID)
+	rows, err := repo.db.QueryContext(ctx, rawSQL, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop members: %w", err)
 	}
@@ -201,7 +202,7 @@ func (repo *RepositoryImpl) GetShopMembers(user *bootstrap.User, shopID string)
 	return members, nil
 }
 
-func (repo *RepositoryImpl) GetShopMemberCount(user *bootstrap.User, shopID string) (int64, error) {
+func (repo *RepositoryImpl) GetShopMemberCount(ctx context.Context, user *bootstrap.User, shopID string) (int64, error) {
 	stmt := SELECT(COUNT(ShopMembers.ID).AS("count")).
 		FROM(ShopMembers).
 		WHERE(ShopMembers.ShopID.EQ(String(shopID)))
@@ -209,7 +210,7 @@ func (repo *RepositoryImpl) GetShopMemberCount(user *bootstrap.User, shopID stri
 	var result struct {
 		Count int64 `sql:"primary_key"`
 	}
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get member count: %w", err)
 	}
@@ -217,13 +218,13 @@ func (repo *RepositoryImpl) GetShopMemberCount(user *bootstrap.User, shopID stri
 	return result.Count, nil
 }
 
-func (repo *RepositoryImpl) DeleteShop(user *bootstrap.User, shopID string) error {
+func (repo *RepositoryImpl) DeleteShop(ctx context.Context, user *bootstrap.User, shopID string) error {
 	stmt := Shops.DELETE().WHERE(
 		Shops.ID.EQ(String(shopID)).
 			AND(Shops.CreatedBy.EQ(String(user.UserID))),
 	)
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete shop: %w", err)
 	}
@@ -234,61 +235,49 @@ func (repo *RepositoryImpl) DeleteShop(user *bootstrap.User, shopID string) erro
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("shop not found or user not authorized to delete")
+		return apperror.NotFound("shop_not_found", "shop not found or user not authorized to delete")
 	}
 
 	slog.Info("Shop deleted from database", "shop_id", shopID, "deleted_by", user.UserID)
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteShopMessageBlobs(shopID string) error {
-	if repo.blobClient == nil {
+func (repo *RepositoryImpl) DeleteShopMessageBlobs(ctx context.Context, shopID string) error {
+	if repo.store == nil {
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), blobOperationTimeout)
+	// Runs after the shop is deleted, so finish even if the client goes away.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), blobOperationTimeout)
 	defer cancel()
 
-	containerClient := repo.blobClient.ServiceClient().NewContainerClient(shopMessageImagesContainer)
-
 	prefix := fmt.Sprintf("%s/", shopID)
-	pager := containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
-		Prefix: &prefix,
-	})
+	blobs, err := repo.store.List(ctx, shopMessageImagesContainer, prefix)
+	if err != nil {
+		slog.Warn("Failed to list blobs for shop deletion", "shop_id", shopID, "error", err)
+	}
 
 	var deletedCount int64
 	var errorCount int64
 	group, groupCtx := errgroup.WithContext(ctx)
 	group.SetLimit(maxConcurrentBlobDeletes)
 
-	for pager.More() {
-		page, err := pager.NextPage(groupCtx)
-		if err != nil {
-			slog.Warn("Failed to list blobs for shop deletion", "shop_id", shopID, "error", err)
-			break
-		}
-
-		for _, blob := range page.Segment.BlobItems {
-			if blob.Name == nil {
-				continue
+	for _, blob := range blobs {
+		blobName := blob.Name
+		group.Go(func() error {
+			err := repo.store.Delete(groupCtx, shopMessageImagesContainer, blobName)
+			if err != nil {
+				slog.Warn("Failed to delete shop message blob",
+					"shop_id", shopID,
+					"blob_name", blobName,
+					"error", err)
+				atomic.AddInt64(&errorCount, 1)
+				return nil
 			}
 
-			blobName := *blob.Name
-			group.Go(func() error {
-				_, err := repo.blobClient.DeleteBlob(groupCtx, shopMessageImagesContainer, blobName, nil)
-				if err != nil {
-					slog.Warn("Failed to delete shop message blob",
-						"shop_id", shopID,
-						"blob_name", blobName,
-						"error", err)
-					atomic.AddInt64(&errorCount, 1)
-					return nil
-				}
-
-				atomic.AddInt64(&deletedCount, 1)
-				return nil
-			})
-		}
+			atomic.AddInt64(&deletedCount, 1)
+			return nil
+		})
 	}
 
 	if err := group.Wait(); err != nil {
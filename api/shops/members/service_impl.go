@@ -1,26 +1,34 @@
 package members
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log/slog"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/members/invites"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
+	"miltechserver/events"
 )
 
 type ServiceImpl struct {
 	repo       Repository
 	inviteRepo invites.Repository
 	auth       shared.ShopAuthorization
+	audit      audit.Recorder
+	events     events.Publisher
 }
 
-func NewService(repo Repository, inviteRepo invites.Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, inviteRepo invites.Repository, auth shared.ShopAuthorization, recorder audit.Recorder, publisher events.Publisher) *ServiceImpl {
 	return &ServiceImpl{
 		repo:       repo,
 		inviteRepo: inviteRepo,
 		auth:       auth,
+		audit:      recorder,
+		events:     publisher,
 	}
 }
 
@@ -29,70 +37,109 @@ func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) sha
 		repo:       service.repo,
 		inviteRepo: service.inviteRepo,
 		auth:       auth,
+		audit:      service.audit,
+		events:     service.events,
 	}
 }
 
-func (service *ServiceImpl) JoinShopViaInviteCode(user *bootstrap.User, inviteCode string) error {
+func (service *ServiceImpl) JoinShopViaInviteCode(ctx context.Context, user *bootstrap.User, inviteCode string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	code, err := service.inviteRepo.GetInviteCodeByCode(inviteCode)
+	code, err := service.inviteRepo.GetInviteCodeByCode(ctx, inviteCode)
 	if err != nil {
-		return fmt.Errorf("invalid invite code: %w", err)
+		return shared.ErrInviteCodeInvalid.WithCause(err)
 	}
 
 	if code.IsActive != nil && !*code.IsActive {
-		return errors.New("invite code is inactive")
+		return shared.ErrInviteCodeInactive
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, code.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, code.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to check membership: %w", err)
 	}
 
 	if isMember {
-		return errors.New("user is already a member of this shop")
+		return shared.ErrAlreadyMember
 	}
 
-	err = service.repo.AddMemberToShop(user, code.ShopID, "member")
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.AddMemberToShop(ctx, user, code.ShopID, "member"); err != nil {
+			return fmt.Errorf("failed to add member to shop: %w", err)
+		}
+		err := service.events.Publish(ctx, events.MemberJoined{
+			ShopID: code.ShopID,
+			UserID: user.UserID,
+			Role:   "member",
+		})
+		if err != nil {
+			return err
+		}
+		return service.recordMember(ctx, user, code.ShopID, user.UserID, audit.ActionJoin, nil,
+			&auditMember{UserID: user.UserID, Role: "member"})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to add member to shop: %w", err)
+		return err
 	}
 
 	slog.Info("User joined shop via invite code", "user_id", user.UserID, "shop_id", code.ShopID, "invite_code", inviteCode)
 	return nil
 }
 
-func (service *ServiceImpl) LeaveShop(user *bootstrap.User, shopID string) error {
+func (service *ServiceImpl) LeaveShop(ctx context.Context, user *bootstrap.User, shopID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("user is not a member of this shop")
+		return apperror.Forbidden("not_shop_member", "user is not a member of this shop")
 	}
 
-	memberCount, err := service.repo.GetShopMemberCount(user, shopID)
+	memberCount, err := service.repo.GetShopMemberCount(ctx, user, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to get member count: %w", err)
 	}
 
 	if memberCount == 1 {
-		err = service.deleteShopWithBlobCleanup(user, shopID)
+		err = service.events.InTx(ctx, func(ctx context.Context) error {
+			if err := service.deleteShopWithBlobCleanup(ctx, user, shopID); err != nil {
+				return fmt.Errorf("failed to delete shop: %w", err)
+			}
+			err := service.recordMember(ctx, user, shopID, user.UserID, audit.ActionLeave, &auditMember{UserID: user.UserID}, nil)
+			if err != nil {
+				return err
+			}
+			return service.audit.Record(ctx, audit.Entry{
+				ShopID:     shopID,
+				ActorID:    user.UserID,
+				EntityType: audit.EntityShop,
+				EntityID:   shopID,
+				Action:     audit.ActionDelete,
+			})
+		})
 		if err != nil {
-			return fmt.Errorf("failed to delete shop: %w", err)
+			return err
 		}
 		slog.Info("Shop deleted as last member left", "user_id", user.UserID, "shop_id", shopID)
 	} else {
-		err = service.repo.RemoveMemberFromShop(user, shopID, user.UserID)
+		err = service.events.InTx(ctx, func(ctx context.Context) error {
+			if err := service.repo.RemoveMemberFromShop(ctx, user, shopID, user.UserID); err != nil {
+				return fmt.Errorf("failed to leave shop: %w", err)
+			}
+			if err := service.events.Publish(ctx, events.MemberLeft{ShopID: shopID, UserID: user.UserID}); err != nil {
+				return err
+			}
+			return service.recordMember(ctx, user, shopID, user.UserID, audit.ActionLeave, &auditMember{UserID: user.UserID}, nil)
+		})
 		if err != nil {
-			return fmt.Errorf("failed to leave shop: %w", err)
+			return err
 		}
 		slog.Info("User left shop", "user_id", user.UserID, "shop_id", shopID)
 	}
@@ -100,48 +147,57 @@ func (service *ServiceImpl) LeaveShop(user *bootstrap.User, shopID string) error
 	return nil
 }
 
-func (service *ServiceImpl) RemoveMemberFromShop(user *bootstrap.User, shopID string, targetUserID string) error {
+func (service *ServiceImpl) RemoveMemberFromShop(ctx context.Context, user *bootstrap.User, shopID string, targetUserID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isAdmin {
-		return errors.New("only shop administrators can remove members")
+		return apperror.Forbidden("shop_admin_required", "only shop administrators can remove members")
 	}
 
 	if user.UserID == targetUserID {
-		return errors.New("use leave shop endpoint to remove yourself")
+		return apperror.Conflict("cannot_remove_self", "use leave shop endpoint to remove yourself")
 	}
 
-	err = service.repo.RemoveMemberFromShop(user, shopID, targetUserID)
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.RemoveMemberFromShop(ctx, user, shopID, targetUserID); err != nil {
+			return fmt.Errorf("failed to remove member: %w", err)
+		}
+		err := service.events.Publish(ctx, events.MemberRemoved{ShopID: shopID, UserID: targetUserID, RemovedBy: user.UserID})
+		if err != nil {
+			return err
+		}
+		return service.recordMember(ctx, user, shopID, targetUserID, audit.ActionRemove, &auditMember{UserID: targetUserID}, nil)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to remove member: %w", err)
+		return err
 	}
 
 	slog.Info("Member removed from shop", "admin_user_id", user.UserID, "removed_user_id", targetUserID, "shop_id", shopID)
 	return nil
 }
 
-func (service *ServiceImpl) GetShopMembers(user *bootstrap.User, shopID string) ([]response.ShopMemberWithUsername, error) {
+func (service *ServiceImpl) GetShopMembers(ctx context.Context, user *bootstrap.User, shopID string) ([]response.ShopMemberWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	members, err := service.repo.GetShopMembers(user, shopID)
+	members, err := service.repo.GetShopMembers(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop members: %w", err)
 	}
@@ -153,54 +209,98 @@ func (service *ServiceImpl) GetShopMembers(user *bootstrap.User, shopID string)
 	return members, nil
 }
 
-func (service *ServiceImpl) PromoteMemberToAdmin(user *bootstrap.User, shopID string, targetUserID string) error {
+func (service *ServiceImpl) PromoteMemberToAdmin(ctx context.Context, user *bootstrap.User, shopID string, targetUserID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isAdmin {
-		return errors.New("only shop administrators can promote members")
+		return apperror.Forbidden("shop_admin_required", "only shop administrators can promote members")
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(&bootstrap.User{UserID: targetUserID}, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, &bootstrap.User{UserID: targetUserID}, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify target user membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("target user is not a member of this shop")
+		return shared.ErrTargetNotShopMember
 	}
 
-	err = service.repo.UpdateMemberRole(user, shopID, targetUserID, "admin")
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.UpdateMemberRole(ctx, user, shopID, targetUserID, "admin"); err != nil {
+			return fmt.Errorf("failed to promote member to admin: %w", err)
+		}
+		err := service.events.Publish(ctx, events.MemberPromoted{
+			ShopID:     shopID,
+			UserID:     targetUserID,
+			Role:       "admin",
+			PromotedBy: user.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		return service.recordMember(ctx, user, shopID, targetUserID, audit.ActionPromote,
+			&auditMember{UserID: targetUserID}, &auditMember{UserID: targetUserID, Role: "admin"})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to promote member to admin: %w", err)
+		return err
 	}
 
 	slog.Info("Member promoted to admin", "admin_user_id", user.UserID, "promoted_user_id", targetUserID, "shop_id", shopID)
 	return nil
 }
 
+// auditMember is a membership as recorded in the audit log. The invite code a
+// member joined with is left out: shop admins read the log, and a code still
+// active would let anyone who sees it join.
+type auditMember struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role,omitempty"`
+}
+
+func (service *ServiceImpl) recordMember(ctx context.Context,
+	user *bootstrap.User,
+	shopID string,
+	memberID string,
+	action audit.Action,
+	before, after *auditMember,
+) error {
+	return service.audit.Record(ctx, audit.Entry{
+		ShopID:     shopID,
+		ActorID:    user.UserID,
+		EntityType: audit.EntityShopMember,
+		EntityID:   memberID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	})
+}
+
 // deleteShopWithBlobCleanup is a private helper that deletes a shop and cleans up associated blobs
 // This is used by both DeleteShop (admin deletion) and LeaveShop (last member deletion)
-func (service *ServiceImpl) deleteShopWithBlobCleanup(user *bootstrap.User, shopID string) error {
-	err := service.repo.DeleteShop(user, shopID)
+// Inside a transaction the blobs are only deleted once it commits
+func (service *ServiceImpl) deleteShopWithBlobCleanup(ctx context.Context, user *bootstrap.User, shopID string) error {
+	err := service.repo.DeleteShop(ctx, user, shopID)
 	if err != nil {
 		slog.Error("Failed to delete shop", "error", err, "user_id", user.UserID, "shop_id", shopID)
 		return fmt.Errorf("failed to delete shop: %w", err)
 	}
 
-	err = service.repo.DeleteShopMessageBlobs(shopID)
-	if err != nil {
-		slog.Warn("Failed to delete shop message blobs during shop deletion",
-			"shop_id", shopID,
-			"user_id", user.UserID,
-			"error", err)
-	}
+	dbtx.AfterCommit(ctx, func() {
+		err := service.repo.DeleteShopMessageBlobs(ctx, shopID)
+		if err != nil {
+			slog.Warn("Failed to delete shop message blobs during shop deletion",
+				"shop_id", shopID,
+				"user_id", user.UserID,
+				"error", err)
+		}
+	})
 
 	slog.Info("Shop deleted successfully", "user_id", user.UserID, "shop_id", shopID)
 	return nil
@@ -0,0 +1,82 @@
+package messages
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"miltechserver/api/request"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Messages",
+	Operations: []openapi.Operation{
+		{
+			ID:       "createShopMessage",
+			Method:   http.MethodPost,
+			Path:     "/shops/messages",
+			Auth:     true,
+			Summary:  "Creates a new message in the shop chat",
+			Request:  request.CreateShopMessageRequest{},
+			Status:   http.StatusCreated,
+			Response: model.ShopMessages{},
+		},
+		{
+			ID:       "getShopMessages",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/messages",
+			Auth:     true,
+			Summary:  "Returns all messages for a shop",
+			Response: []model.ShopMessages{},
+		},
+		{
+			ID:       "getShopMessagesPaginated",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/messages/paginated",
+			Auth:     true,
+			Summary:  "Returns a page of a shop's messages, newest first",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.ShopMessages]{},
+		},
+		{
+			ID:       "updateShopMessage",
+			Method:   http.MethodPut,
+			Path:     "/shops/messages",
+			Auth:     true,
+			Summary:  "Updates an existing shop message",
+			Request:  request.UpdateShopMessageRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "deleteShopMessage",
+			Method:   http.MethodDelete,
+			Path:     "/shops/messages/:message_id",
+			Auth:     true,
+			Summary:  "Deletes a shop message",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "uploadMessageImage",
+			Method:   http.MethodPost,
+			Path:     "/shops/messages/image/upload",
+			Auth:     true,
+			Summary:  "Handles image upload for shop messages",
+			Query:    []openapi.Param{{Name: "shop_id", Description: "Required unless sent as a form field"}},
+			Form:     []openapi.Param{{Name: "shop_id"}, {Name: "file", Type: "file", Required: true}},
+			Response: openapi.Object{"message_id": "", "shop_id": "", "image_url": "", "file_extension": ""},
+		},
+		{
+			ID:       "deleteMessageImage",
+			Method:   http.MethodDelete,
+			Path:     "/shops/messages/image/:message_id",
+			Auth:     true,
+			Summary:  "Handles deletion of orphaned message images",
+			Query:    []openapi.Param{{Name: "shop_id", Required: true}},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+	},
+}}
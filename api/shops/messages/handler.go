@@ -1,9 +1,10 @@
 package messages
 
 import (
-	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -11,6 +12,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// defaultMessageLimit is the message page size when the client sends no limit.
+const defaultMessageLimit = 20
+
 type Handler struct {
 	service Service
 }
@@ -23,15 +27,15 @@ func (handler *Handler) CreateShopMessage(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.CreateShopMessageRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -42,7 +46,7 @@ func (handler *Handler) CreateShopMessage(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdMessage, err := service.CreateShopMessage(user, message)
+	createdMessage, err := service.CreateShopMessage(c.Request.Context(), user, message)
 	if err != nil {
 		c.Error(err)
 		return
@@ -61,19 +65,19 @@ func (handler *Handler) GetShopMessages(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	messages, err := service.GetShopMessages(user, shopID)
+	messages, err := service.GetShopMessages(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -86,44 +90,31 @@ func (handler *Handler) GetShopMessages(c *gin.Context) {
 	})
 }
 
-// GetShopMessagesPaginated returns paginated messages for a shop
+// GetShopMessagesPaginated returns a page of a shop's messages, newest first
 func (handler *Handler) GetShopMessagesPaginated(c *gin.Context) {
 	ctxUser, ok := c.Get("user")
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
-	var req request.GetShopMessagesPaginatedRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
-		slog.Info("invalid query parameters", "error", err)
-		c.JSON(400, gin.H{"message": "invalid query parameters"})
-		return
-	}
-
-	// Set defaults if not provided
-	if req.Page == 0 {
-		req.Page = 1
-	}
-	if req.Limit == 0 {
-		req.Limit = 20
-	}
-	if req.BeforeID != nil && req.AfterID != nil {
-		c.JSON(400, gin.H{"message": "before_id and after_id cannot be used together"})
+	params, err := pagination.FromQuery(c, defaultMessageLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	service := handler.service
-	paginatedMessages, err := service.GetShopMessagesPaginated(user, shopID, req)
+	paginatedMessages, err := service.GetShopMessagesPaginated(c.Request.Context(), user, shopID, params)
 	if err != nil {
 		c.Error(err)
 		return
@@ -132,7 +123,7 @@ func (handler *Handler) GetShopMessagesPaginated(c *gin.Context) {
 	c.JSON(200, response.StandardResponse{
 		Status:  200,
 		Message: "",
-		Data:    *paginatedMessages,
+		Data:    paginatedMessages,
 	})
 }
 
@@ -142,15 +133,15 @@ func (handler *Handler) UpdateShopMessage(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.UpdateShopMessageRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -160,7 +151,7 @@ func (handler *Handler) UpdateShopMessage(c *gin.Context) {
 	}
 
 	service := handler.service
-	err := service.UpdateShopMessage(user, message)
+	err := service.UpdateShopMessage(c.Request.Context(), user, message)
 	if err != nil {
 		c.Error(err)
 		return
@@ -175,19 +166,19 @@ func (handler *Handler) DeleteShopMessage(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	messageID := c.Param("message_id")
 	if messageID == "" {
-		c.JSON(400, gin.H{"message": "message_id is required"})
+		c.Error(apperror.MissingParameter("message_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.DeleteShopMessage(user, messageID)
+	err := service.DeleteShopMessage(c.Request.Context(), user, messageID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -202,7 +193,7 @@ func (handler *Handler) UploadMessageImage(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
@@ -213,7 +204,7 @@ func (handler *Handler) UploadMessageImage(c *gin.Context) {
 		shopID = c.PostForm("shop_id")
 	}
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
@@ -221,14 +212,14 @@ func (handler *Handler) UploadMessageImage(c *gin.Context) {
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		slog.Error("Error getting uploaded file", "error", err)
-		c.JSON(400, gin.H{"message": "failed to get uploaded file"})
+		c.Error(apperror.MissingParameter("file").WithCause(err))
 		return
 	}
 	defer file.Close()
 
 	// Check file size before reading
 	if header.Size > 5*1024*1024 { // 5MB
-		c.JSON(400, gin.H{"message": "file size exceeds maximum allowed size of 5MB"})
+		c.Error(apperror.InvalidParameter("file", "file size exceeds maximum allowed size of 5MB"))
 		return
 	}
 
@@ -237,7 +228,7 @@ func (handler *Handler) UploadMessageImage(c *gin.Context) {
 	_, err = file.Read(imageData)
 	if err != nil {
 		slog.Error("Error reading file data", "error", err)
-		c.JSON(500, gin.H{"message": "failed to read file data"})
+		c.Error(err)
 		return
 	}
 
@@ -246,10 +237,10 @@ func (handler *Handler) UploadMessageImage(c *gin.Context) {
 
 	// Upload to blob storage
 	service := handler.service
-	messageID, fileExtension, imageURL, err := service.UploadMessageImage(user, shopID, imageData, contentType)
+	messageID, fileExtension, imageURL, err := service.UploadMessageImage(c.Request.Context(), user, shopID, imageData, contentType)
 	if err != nil {
 		slog.Error("Error uploading image to blob storage", "error", err)
-		c.JSON(500, gin.H{"message": fmt.Sprintf("failed to upload image: %v", err)})
+		c.Error(err)
 		return
 	}
 
@@ -271,25 +262,25 @@ func (handler *Handler) DeleteMessageImage(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	messageID := c.Param("message_id")
 	if messageID == "" {
-		c.JSON(400, gin.H{"message": "message_id is required"})
+		c.Error(apperror.MissingParameter("message_id"))
 		return
 	}
 
 	shopID := c.Query("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.DeleteMessageImage(user, shopID, messageID)
+	err := service.DeleteMessageImage(c.Request.Context(), user, shopID, messageID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -1,22 +1,22 @@
 package messages
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
 	"miltechserver/bootstrap"
-	"time"
 )
 
 type Repository interface {
-	CreateShopMessage(user *bootstrap.User, message model.ShopMessages) (*model.ShopMessages, error)
-	GetShopMessages(user *bootstrap.User, shopID string) ([]model.ShopMessages, error)
-	GetShopMessagesPaginated(user *bootstrap.User, shopID string, offset int, limit int) ([]model.ShopMessages, error)
-	GetShopMessagesByCursor(user *bootstrap.User, shopID string, cursorTime time.Time, isBefore bool, limit int) ([]model.ShopMessages, error)
-	GetShopMessagesCount(user *bootstrap.User, shopID string) (int64, error)
-	UpdateShopMessage(user *bootstrap.User, message model.ShopMessages) error
-	DeleteShopMessage(user *bootstrap.User, messageID string) error
-	GetShopMessageByID(user *bootstrap.User, messageID string) (*model.ShopMessages, error)
-	UploadMessageImage(user *bootstrap.User, messageID string, shopID string, imageData []byte, contentType string) (string, string, error)
-	DeleteMessageImageBlob(user *bootstrap.User, messageID string, shopID string) error
-	DeleteBlobByURL(messageText string) error
-	DeleteShopMessageBlobs(shopID string) error
+	CreateShopMessage(ctx context.Context, user *bootstrap.User, message model.ShopMessages) (*model.ShopMessages, error)
+	GetShopMessages(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopMessages, error)
+	GetShopMessagesPage(ctx context.Context, user *bootstrap.User, shopID string, params pagination.Params) (pagination.Page[model.ShopMessages], error)
+	GetShopMessagesCount(ctx context.Context, user *bootstrap.User, shopID string) (int64, error)
+	UpdateShopMessage(ctx context.Context, user *bootstrap.User, message model.ShopMessages) error
+	DeleteShopMessage(ctx context.Context, user *bootstrap.User, messageID string) error
+	GetShopMessageByID(ctx context.Context, user *bootstrap.User, messageID string) (*model.ShopMessages, error)
+	UploadMessageImage(ctx context.Context, user *bootstrap.User, messageID string, shopID string, imageData []byte, contentType string) (string, string, error)
+	DeleteMessageImageBlob(ctx context.Context, user *bootstrap.User, messageID string, shopID string) error
+	DeleteBlobByURL(ctx context.Context, messageText string) error
+	DeleteShopMessageBlobs(ctx context.Context, shopID string) error
 }
@@ -8,15 +8,18 @@ import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
+	"miltechserver/storage"
 	"net/http"
 	"regexp"
 	"strings"
 	"sync/atomic"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	. "github.com/go-jet/jet/v2/postgres"
 	"golang.org/x/sync/errgroup"
 )
@@ -28,20 +31,20 @@ const (
 )
 
 type RepositoryImpl struct {
-	db         *sql.DB
-	blobClient *azblob.Client
-	env        *bootstrap.Env
+	db    *sql.DB
+	store storage.ObjectStore
+	env   *bootstrap.Env
 }
 
-func NewRepository(db *sql.DB, blobClient *azblob.Client, env *bootstrap.Env) *RepositoryImpl {
+func NewRepository(db *sql.DB, store storage.ObjectStore, env *bootstrap.Env) *RepositoryImpl {
 	return &RepositoryImpl{
-		db:         db,
-		blobClient: blobClient,
-		env:        env,
+		db:    db,
+		store: store,
+		env:   env,
 	}
 }
 
-func (repo *RepositoryImpl) CreateShopMessage(user *bootstrap.User, message model.ShopMessages) (*model.ShopMessages, error) {
+func (repo *RepositoryImpl) CreateShopMessage(ctx context.Context, user *bootstrap.User, message model.ShopMessages) (*model.ShopMessages, error) {
 	stmt := ShopMessages.INSERT(
 		ShopMessages.ID,
 		ShopMessages.ShopID,
@@ -54,7 +57,7 @@ func (repo *RepositoryImpl) CreateShopMessage(user *bootstrap.User, message mode
 	).MODEL(message).RETURNING(ShopMessages.AllColumns)
 
 	var createdMessage model.ShopMessages
-	err := stmt.Query(repo.db, &createdMessage)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &createdMessage)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create shop message: %w", err)
 	}
@@ -62,14 +65,14 @@ func (repo *RepositoryImpl) CreateShopMessage(user *bootstrap.User, message mode
 	return &createdMessage, nil
 }
 
-func (repo *RepositoryImpl) GetShopMessages(user *bootstrap.User, shopID string) ([]model.ShopMessages, error) {
+func (repo *RepositoryImpl) GetShopMessages(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopMessages, error) {
 	stmt := SELECT(ShopMessages.AllColumns).
 		FROM(ShopMessages).
 		WHERE(ShopMessages.ShopID.EQ(String(shopID))).
 		ORDER_BY(ShopMessages.CreatedAt.ASC())
 
 	var messages []model.ShopMessages
-	err := stmt.Query(repo.db, &messages)
+	err := stmt.QueryContext(ctx, repo.db, &messages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop messages: %w", err)
 	}
@@ -77,48 +80,40 @@ func (repo *RepositoryImpl) GetShopMessages(user *bootstrap.User, shopID string)
 	return messages, nil
 }
 
-func (repo *RepositoryImpl) GetShopMessagesPaginated(user *bootstrap.User, shopID string, offset int, limit int) ([]model.ShopMessages, error) {
-	stmt := SELECT(ShopMessages.AllColumns).
-		FROM(ShopMessages).
-		WHERE(ShopMessages.ShopID.EQ(String(shopID))).
-		ORDER_BY(ShopMessages.CreatedAt.DESC()).
-		LIMIT(int64(limit)).
-		OFFSET(int64(offset))
-
-	var messages []model.ShopMessages
-	err := stmt.Query(repo.db, &messages)
+// GetShopMessagesPage reads the shop's messages newest first, starting after
+// params.Cursor.
+func (repo *RepositoryImpl) GetShopMessagesPage(ctx context.Context, user *bootstrap.User, shopID string, params pagination.Params) (pagination.Page[model.ShopMessages], error) {
+	var afterCreatedAt time.Time
+	var afterID string
+	after, err := params.After(&afterCreatedAt, &afterID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get paginated shop messages: %w", err)
+		return pagination.Page[model.ShopMessages]{}, err
 	}
 
-	return messages, nil
-}
-
-func (repo *RepositoryImpl) GetShopMessagesByCursor(user *bootstrap.User, shopID string, cursorTime time.Time, isBefore bool, limit int) ([]model.ShopMessages, error) {
-	condition := ShopMessages.CreatedAt.LT(TimestampzT(cursorTime))
-	if !isBefore {
-		condition = ShopMessages.CreatedAt.GT(TimestampzT(cursorTime))
+	condition := ShopMessages.ShopID.EQ(String(shopID))
+	if after {
+		condition = condition.AND(ROW(ShopMessages.CreatedAt, ShopMessages.ID).
+			LT(ROW(TimestampzT(afterCreatedAt), String(afterID))))
 	}
 
 	stmt := SELECT(ShopMessages.AllColumns).
 		FROM(ShopMessages).
-		WHERE(
-			ShopMessages.ShopID.EQ(String(shopID)).
-				AND(condition),
-		).
-		ORDER_BY(ShopMessages.CreatedAt.DESC()).
-		LIMIT(int64(limit))
+		WHERE(condition).
+		ORDER_BY(ShopMessages.CreatedAt.DESC(), ShopMessages.ID.DESC()).
+		LIMIT(params.Fetch())
 
 	var messages []model.ShopMessages
-	err := stmt.Query(repo.db, &messages)
+	err = stmt.QueryContext(ctx, repo.db, &messages)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cursor-based shop messages: %w", err)
+		return pagination.Page[model.ShopMessages]{}, fmt.Errorf("failed to get paginated shop messages: %w", err)
 	}
 
-	return messages, nil
+	return pagination.NewPage(messages, params, func(message model.ShopMessages) []any {
+		return []any{message.CreatedAt, message.ID}
+	}), nil
 }
 
-func (repo *RepositoryImpl) GetShopMessagesCount(user *bootstrap.User, shopID string) (int64, error) {
+func (repo *RepositoryImpl) GetShopMessagesCount(ctx context.Context, user *bootstrap.User, shopID string) (int64, error) {
 	stmt := SELECT(COUNT(ShopMessages.ID)).
 		FROM(ShopMessages).
 		WHERE(ShopMessages.ShopID.EQ(String(shopID)))
@@ -126,7 +121,7 @@ func (repo *RepositoryImpl) GetShopMessagesCount(user *bootstrap.User, shopID st
 	var result struct {
 		Count int64 `sql:"primary_key"`
 	}
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get shop messages count: %w", err)
 	}
@@ -134,7 +129,7 @@ func (repo *RepositoryImpl) GetShopMessagesCount(user *bootstrap.User, shopID st
 	return result.Count, nil
 }
 
-func (repo *RepositoryImpl) UpdateShopMessage(user *bootstrap.User, message model.ShopMessages) error {
+func (repo *RepositoryImpl) UpdateShopMessage(ctx context.Context, user *bootstrap.User, message model.ShopMessages) error {
 	stmt := ShopMessages.UPDATE(
 		ShopMessages.Message,
 		ShopMessages.UpdatedAt,
@@ -148,7 +143,7 @@ func (repo *RepositoryImpl) UpdateShopMessage(user *bootstrap.User, message mode
 			AND(ShopMessages.UserID.EQ(String(user.UserID))),
 	)
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to update shop message: %w", err)
 	}
@@ -159,13 +154,13 @@ func (repo *RepositoryImpl) UpdateShopMessage(user *bootstrap.User, message mode
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("message not found or user not authorized to update")
+		return apperror.NotFound("message_not_found", "message not found or user not authorized to update")
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteShopMessage(user *bootstrap.User, messageID string) error {
+func (repo *RepositoryImpl) DeleteShopMessage(ctx context.Context, user *bootstrap.User, messageID string) error {
 	stmt := ShopMessages.DELETE().
 		WHERE(
 			ShopMessages.ID.EQ(String(messageID)).
@@ -184,7 +179,7 @@ func (repo *RepositoryImpl) DeleteShopMessage(user *bootstrap.User, messageID st
 				),
 		)
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete shop message: %w", err)
 	}
@@ -195,22 +190,22 @@ func (repo *RepositoryImpl) DeleteShopMessage(user *bootstrap.User, messageID st
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("message not found or user not authorized to delete")
+		return apperror.NotFound("message_not_found", "message not found or user not authorized to delete")
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) GetShopMessageByID(user *bootstrap.User, messageID string) (*model.ShopMessages, error) {
+func (repo *RepositoryImpl) GetShopMessageByID(ctx context.Context, user *bootstrap.User, messageID string) (*model.ShopMessages, error) {
 	stmt := SELECT(ShopMessages.AllColumns).
 		FROM(ShopMessages).
 		WHERE(ShopMessages.ID.EQ(String(messageID)))
 
 	var message model.ShopMessages
-	err := stmt.Query(repo.db, &message)
+	err := stmt.QueryContext(ctx, repo.db, &message)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("message not found")
+			return nil, apperror.NotFound("message_not_found", "message not found")
 		}
 		return nil, fmt.Errorf("failed to get message: %w", err)
 	}
@@ -218,16 +213,14 @@ func (repo *RepositoryImpl) GetShopMessageByID(user *bootstrap.User, messageID s
 	return &message, nil
 }
 
-func (repo *RepositoryImpl) UploadMessageImage(user *bootstrap.User, messageID string, shopID string, imageData []byte, contentType string) (string, string, error) {
-	ctx := context.Background()
-
+func (repo *RepositoryImpl) UploadMessageImage(ctx context.Context, user *bootstrap.User, messageID string, shopID string, imageData []byte, contentType string) (string, string, error) {
 	// Verify user is a member of the shop
-	isMember, err := repo.isUserMemberOfShop(user, shopID)
+	isMember, err := repo.isUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to verify membership: %w", err)
 	}
 	if !isMember {
-		return "", "", errors.New("access denied: user is not a member of this shop")
+		return "", "", shared.ErrNotShopMember
 	}
 
 	if contentType == "" {
@@ -238,27 +231,24 @@ func (repo *RepositoryImpl) UploadMessageImage(user *bootstrap.User, messageID s
 
 	blobName := fmt.Sprintf("%s/%s%s", shopID, messageID, fileExtension)
 
-	_, err = repo.blobClient.UploadBuffer(ctx, shopMessageImagesContainer, blobName, imageData, nil)
+	err = repo.store.Put(ctx, shopMessageImagesContainer, blobName, imageData)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to upload image: %w", err)
 	}
 
-	blobURL := fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s",
-		repo.env.BlobAccountName, shopMessageImagesContainer, blobName)
+	blobURL := repo.store.URL(shopMessageImagesContainer, blobName)
 
 	slog.Info("shop message image uploaded successfully", "user_id", user.UserID, "message_id", messageID, "shop_id", shopID, "blob_url", blobURL)
 	return fileExtension, blobURL, nil
 }
 
-func (repo *RepositoryImpl) DeleteMessageImageBlob(user *bootstrap.User, messageID string, shopID string) error {
-	ctx := context.Background()
-
-	isMember, err := repo.isUserMemberOfShop(user, shopID)
+func (repo *RepositoryImpl) DeleteMessageImageBlob(ctx context.Context, user *bootstrap.User, messageID string, shopID string) error {
+	isMember, err := repo.isUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 	if !isMember {
-		return errors.New("access denied: user is not a member of this shop")
+		return shared.ErrNotShopMember
 	}
 
 	extensions := []string{".jpg", ".png", ".gif", ".webp"}
@@ -266,7 +256,7 @@ func (repo *RepositoryImpl) DeleteMessageImageBlob(user *bootstrap.User, message
 
 	for _, ext := range extensions {
 		blobName := fmt.Sprintf("%s/%s%s", shopID, messageID, ext)
-		_, err := repo.blobClient.DeleteBlob(ctx, shopMessageImagesContainer, blobName, nil)
+		err := repo.store.Delete(ctx, shopMessageImagesContainer, blobName)
 		if err == nil {
 			deleted = true
 			slog.Info("shop message image blob deleted successfully", "message_id", messageID, "shop_id", shopID, "extension", ext)
@@ -281,7 +271,7 @@ func (repo *RepositoryImpl) DeleteMessageImageBlob(user *bootstrap.User, message
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteBlobByURL(messageText string) error {
+func (repo *RepositoryImpl) DeleteBlobByURL(ctx context.Context, messageText string) error {
 	if messageText == "" {
 		return nil
 	}
@@ -291,15 +281,13 @@ func (repo *RepositoryImpl) DeleteBlobByURL(messageText string) error {
 		return nil
 	}
 
-	ctx := context.Background()
-
 	blobName, err := parseBlobNameFromURL(imageURL, shopMessageImagesContainer)
 	if err != nil {
 		slog.Warn("Failed to parse blob name from URL", "url", imageURL, "error", err)
 		return nil
 	}
 
-	_, err = repo.blobClient.DeleteBlob(ctx, shopMessageImagesContainer, blobName, nil)
+	err = repo.store.Delete(ctx, shopMessageImagesContainer, blobName)
 	if err != nil {
 		slog.Warn("Failed to delete blob from Azure", "blob_name", blobName, "error", err)
 		return nil
@@ -309,50 +297,38 @@ func (repo *RepositoryImpl) DeleteBlobByURL(messageText string) error {
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteShopMessageBlobs(shopID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), blobOperationTimeout)
+func (repo *RepositoryImpl) DeleteShopMessageBlobs(ctx context.Context, shopID string) error {
+	// Runs after the shop is deleted, so finish even if the client goes away.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), blobOperationTimeout)
 	defer cancel()
 
-	containerClient := repo.blobClient.ServiceClient().NewContainerClient(shopMessageImagesContainer)
-
 	prefix := fmt.Sprintf("%s/", shopID)
-	pager := containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
-		Prefix: &prefix,
-	})
+	blobs, err := repo.store.List(ctx, shopMessageImagesContainer, prefix)
+	if err != nil {
+		slog.Warn("Failed to list blobs for shop deletion", "shop_id", shopID, "error", err)
+	}
 
 	var deletedCount int64
 	var errorCount int64
 	group, groupCtx := errgroup.WithContext(ctx)
 	group.SetLimit(maxConcurrentBlobDeletes)
 
-	for pager.More() {
-		page, err := pager.NextPage(groupCtx)
-		if err != nil {
-			slog.Warn("Failed to list blobs for shop deletion", "shop_id", shopID, "error", err)
-			break
-		}
-
-		for _, blob := range page.Segment.BlobItems {
-			if blob.Name == nil {
-				continue
+	for _, blob := range blobs {
+		blobName := blob.Name
+		group.Go(func() error {
+			err := repo.store.Delete(groupCtx, shopMessageImagesContainer, blobName)
+			if err != nil {
+				slog.Warn("Failed to delete shop message blob",
+					"shop_id", shopID,
+					"blob_name", blobName,
+					"error", err)
+				atomic.AddInt64(&errorCount, 1)
+				return nil
 			}
 
-			blobName := *blob.Name
-			group.Go(func() error {
-				_, err := repo.blobClient.DeleteBlob(groupCtx, shopMessageImagesContainer, blobName, nil)
-				if err != nil {
-					slog.Warn("Failed to delete shop message blob",
-						"shop_id", shopID,
-						"blob_name", blobName,
-						"error", err)
-					atomic.AddInt64(&errorCount, 1)
-					return nil
-				}
-
-				atomic.AddInt64(&deletedCount, 1)
-				return nil
-			})
-		}
+			atomic.AddInt64(&deletedCount, 1)
+			return nil
+		})
 	}
 
 	if err := group.Wait(); err != nil {
@@ -384,7 +360,7 @@ func getFileExtensionFromMIME(contentType string) string {
 }
 
 func extractImageURLFromMessage(messageText string) string {
-	re := regexp.MustCompile(`\[IMAGE:(https://[^]]+)\]`)
+	re := regexp.MustCompile(`\[IMAGE:(https?://[^]]+)\]`)
 	matches := re.FindStringSubmatch(messageText)
 	if len(matches) > 1 {
 		return matches[1]
@@ -411,7 +387,7 @@ func parseBlobNameFromURL(url string, expectedContainer string) (string, error)
 	return blobName, nil
 }
 
-func (repo *RepositoryImpl) isUserMemberOfShop(user *bootstrap.User, shopID string) (bool, error) {
+func (repo *RepositoryImpl) isUserMemberOfShop(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	stmt := SELECT(Int(1).AS("exists")).
 		FROM(ShopMembers).
 		WHERE(
@@ -423,7 +399,7 @@ func (repo *RepositoryImpl) isUserMemberOfShop(user *bootstrap.User, shopID stri
 	var result []struct {
 		Exists int `sql:"exists"`
 	}
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		return false, fmt.Errorf("failed to check membership: %w", err)
 	}
@@ -1,14 +1,15 @@
 package messages
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
-	"miltechserver/api/request"
-	"miltechserver/api/response"
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,36 +20,39 @@ const (
 )
 
 type ServiceImpl struct {
-	repo Repository
-	auth shared.ShopAuthorization
+	repo   Repository
+	auth   shared.ShopAuthorization
+	events events.Publisher
 }
 
-func NewService(repo Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, auth shared.ShopAuthorization, publisher events.Publisher) *ServiceImpl {
 	return &ServiceImpl{
-		repo: repo,
-		auth: auth,
+		repo:   repo,
+		auth:   auth,
+		events: publisher,
 	}
 }
 
 func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
 	return &ServiceImpl{
-		repo: service.repo,
-		auth: auth,
+		repo:   service.repo,
+		auth:   auth,
+		events: service.events,
 	}
 }
 
-func (service *ServiceImpl) CreateShopMessage(user *bootstrap.User, message model.ShopMessages) (*model.ShopMessages, error) {
+func (service *ServiceImpl) CreateShopMessage(ctx context.Context, user *bootstrap.User, message model.ShopMessages) (*model.ShopMessages, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, message.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, message.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	message.ID = uuid.New().String()
@@ -58,30 +62,42 @@ func (service *ServiceImpl) CreateShopMessage(user *bootstrap.User, message mode
 	message.UpdatedAt = &now
 	message.IsEdited = func() *bool { b := false; return &b }()
 
-	createdMessage, err := service.repo.CreateShopMessage(user, message)
+	var createdMessage *model.ShopMessages
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		createdMessage, err = service.repo.CreateShopMessage(ctx, user, message)
+		if err != nil {
+			return fmt.Errorf("failed to create shop message: %w", err)
+		}
+		return service.events.Publish(ctx, events.MessageCreated{
+			ShopID:    createdMessage.ShopID,
+			MessageID: createdMessage.ID,
+			AuthorID:  createdMessage.UserID,
+			ParentID:  createdMessage.ParentID,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create shop message: %w", err)
+		return nil, err
 	}
 
 	slog.Info("Shop message created", "user_id", user.UserID, "shop_id", message.ShopID, "message_id", message.ID)
 	return createdMessage, nil
 }
 
-func (service *ServiceImpl) GetShopMessages(user *bootstrap.User, shopID string) ([]model.ShopMessages, error) {
+func (service *ServiceImpl) GetShopMessages(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopMessages, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	messages, err := service.repo.GetShopMessages(user, shopID)
+	messages, err := service.repo.GetShopMessages(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop messages: %w", err)
 	}
@@ -93,117 +109,45 @@ func (service *ServiceImpl) GetShopMessages(user *bootstrap.User, shopID string)
 	return messages, nil
 }
 
-func (service *ServiceImpl) GetShopMessagesPaginated(user *bootstrap.User, shopID string, req request.GetShopMessagesPaginatedRequest) (*response.PaginatedShopMessagesResponse, error) {
+func (service *ServiceImpl) GetShopMessagesPaginated(ctx context.Context, user *bootstrap.User, shopID string, params pagination.Params) (pagination.Page[model.ShopMessages], error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
-	}
-
-	if req.Page < 1 {
-		req.Page = 1
-	}
-	if req.Limit < 1 || req.Limit > 100 {
-		req.Limit = 20
+		return pagination.Page[model.ShopMessages]{}, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify membership: %w", err)
+		return pagination.Page[model.ShopMessages]{}, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
-	}
-
-	if req.BeforeID != nil && req.AfterID != nil {
-		return nil, errors.New("before_id and after_id cannot be used together")
-	}
-
-	if req.BeforeID != nil || req.AfterID != nil {
-		cursorID := req.BeforeID
-		isBefore := true
-		if req.AfterID != nil {
-			cursorID = req.AfterID
-			isBefore = false
-		}
-
-		cursorMessage, err := service.repo.GetShopMessageByID(user, *cursorID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load cursor message: %w", err)
-		}
-		if cursorMessage.ShopID != shopID {
-			return nil, errors.New("cursor message does not belong to this shop")
-		}
-		if cursorMessage.CreatedAt == nil {
-			return nil, errors.New("cursor message missing created_at")
-		}
-
-		messages, err := service.repo.GetShopMessagesByCursor(user, shopID, *cursorMessage.CreatedAt, isBefore, req.Limit+1)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get cursor-based shop messages: %w", err)
-		}
-
-		hasMore := len(messages) > req.Limit
-		if hasMore {
-			messages = messages[:req.Limit]
-		}
-
-		if messages == nil {
-			messages = []model.ShopMessages{}
-		}
-
-		var nextCursor *string
-		if hasMore && len(messages) > 0 {
-			lastMessageID := messages[len(messages)-1].ID
-			nextCursor = &lastMessageID
-		}
-
-		return &response.PaginatedShopMessagesResponse{
-			Messages:   messages,
-			Pagination: nil,
-			NextCursor: nextCursor,
-		}, nil
+		return pagination.Page[model.ShopMessages]{}, shared.ErrNotShopMember
 	}
 
-	offset := (req.Page - 1) * req.Limit
-
-	messages, err := service.repo.GetShopMessagesPaginated(user, shopID, offset, req.Limit)
+	page, err := service.repo.GetShopMessagesPage(ctx, user, shopID, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get paginated shop messages: %w", err)
-	}
-
-	totalCount, err := service.repo.GetShopMessagesCount(user, shopID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get shop messages count: %w", err)
-	}
-
-	totalPages := int((totalCount + int64(req.Limit) - 1) / int64(req.Limit))
-	if totalPages == 0 {
-		totalPages = 1
+		return pagination.Page[model.ShopMessages]{}, fmt.Errorf("failed to get paginated shop messages: %w", err)
 	}
 
-	paginationMetadata := response.PaginationMetadata{
-		Page:       req.Page,
-		Limit:      req.Limit,
-		TotalPages: totalPages,
-		HasNext:    req.Page < totalPages,
-		HasPrev:    req.Page > 1,
+	if !params.IncludeTotal {
+		return page, nil
 	}
 
-	if messages == nil {
-		messages = []model.ShopMessages{}
-	}
-
-	paginatedResponse := &response.PaginatedShopMessagesResponse{
-		Messages:   messages,
-		Pagination: &paginationMetadata,
+	totalCount, err := service.repo.GetShopMessagesCount(ctx, user, shopID)
+	if err != nil {
+		return pagination.Page[model.ShopMessages]{}, fmt.Errorf("failed to get shop messages count: %w", err)
 	}
 
-	return paginatedResponse, nil
+	return page.WithTotal(int(totalCount)), nil
 }
 
-func (service *ServiceImpl) UpdateShopMessage(user *bootstrap.User, message model.ShopMessages) error {
+func (service *ServiceImpl) UpdateShopMessage(ctx context.Context, user *bootstrap.User, message model.ShopMessages) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
+	}
+
+	current, err := service.repo.GetShopMessageByID(ctx, user, message.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get shop message: %w", err)
 	}
 
 	message.UserID = user.UserID
@@ -211,32 +155,50 @@ func (service *ServiceImpl) UpdateShopMessage(user *bootstrap.User, message mode
 	message.UpdatedAt = &now
 	message.IsEdited = func() *bool { b := true; return &b }()
 
-	err := service.repo.UpdateShopMessage(user, message)
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.UpdateShopMessage(ctx, user, message); err != nil {
+			return fmt.Errorf("failed to update shop message: %w", err)
+		}
+		return service.events.Publish(ctx, events.MessageUpdated{
+			ShopID:    current.ShopID,
+			MessageID: message.ID,
+			AuthorID:  user.UserID,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update shop message: %w", err)
+		return err
 	}
 
 	slog.Info("Shop message updated", "user_id", user.UserID, "message_id", message.ID)
 	return nil
 }
 
-func (service *ServiceImpl) DeleteShopMessage(user *bootstrap.User, messageID string) error {
+func (service *ServiceImpl) DeleteShopMessage(ctx context.Context, user *bootstrap.User, messageID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	message, err := service.repo.GetShopMessageByID(user, messageID)
+	message, err := service.repo.GetShopMessageByID(ctx, user, messageID)
 	if err != nil {
 		return fmt.Errorf("failed to get shop message: %w", err)
 	}
 
-	err = service.repo.DeleteShopMessage(user, messageID)
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.DeleteShopMessage(ctx, user, messageID); err != nil {
+			return fmt.Errorf("failed to delete shop message: %w", err)
+		}
+		return service.events.Publish(ctx, events.MessageDeleted{
+			ShopID:    message.ShopID,
+			MessageID: messageID,
+			DeletedBy: user.UserID,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete shop message: %w", err)
+		return err
 	}
 
 	if message != nil && message.Message != "" {
-		err = service.repo.DeleteBlobByURL(message.Message)
+		err = service.repo.DeleteBlobByURL(ctx, message.Message)
 		if err != nil {
 			slog.Warn("Failed to delete blob during message deletion",
 				"message_id", messageID,
@@ -249,22 +211,22 @@ func (service *ServiceImpl) DeleteShopMessage(user *bootstrap.User, messageID st
 	return nil
 }
 
-func (service *ServiceImpl) UploadMessageImage(user *bootstrap.User, shopID string, imageData []byte, contentType string) (string, string, string, error) {
+func (service *ServiceImpl) UploadMessageImage(ctx context.Context, user *bootstrap.User, shopID string, imageData []byte, contentType string) (string, string, string, error) {
 	if user == nil {
-		return "", "", "", errors.New("unauthorized user")
+		return "", "", "", shared.ErrUnauthorizedUser
 	}
 
 	if len(imageData) > maxImageSize {
-		return "", "", "", fmt.Errorf("image size exceeds maximum allowed size of %d bytes", maxImageSize)
+		return "", "", "", apperror.Validation("image_too_large", fmt.Sprintf("image size exceeds maximum allowed size of %d bytes", maxImageSize))
 	}
 
 	if len(imageData) == 0 {
-		return "", "", "", errors.New("image data is empty")
+		return "", "", "", apperror.Validation("empty_image", "image data is empty")
 	}
 
 	messageID := uuid.New().String()
 
-	fileExtension, imageURL, err := service.repo.UploadMessageImage(user, messageID, shopID, imageData, contentType)
+	fileExtension, imageURL, err := service.repo.UploadMessageImage(ctx, user, messageID, shopID, imageData, contentType)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to upload message image: %w", err)
 	}
@@ -273,12 +235,12 @@ func (service *ServiceImpl) UploadMessageImage(user *bootstrap.User, shopID stri
 	return messageID, fileExtension, imageURL, nil
 }
 
-func (service *ServiceImpl) DeleteMessageImage(user *bootstrap.User, shopID string, messageID string) error {
+func (service *ServiceImpl) DeleteMessageImage(ctx context.Context, user *bootstrap.User, shopID string, messageID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	err := service.repo.DeleteMessageImageBlob(user, messageID, shopID)
+	err := service.repo.DeleteMessageImageBlob(ctx, user, messageID, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to delete message image: %w", err)
 	}
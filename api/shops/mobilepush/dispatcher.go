@@ -0,0 +1,149 @@
+package mobilepush
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"miltechserver/api/shops/shared"
+	"miltechserver/events"
+	"miltechserver/push"
+)
+
+// SubscriberName is the Dispatcher's name on the event bus. It is stored
+// with every outbox event, so it must not change.
+const SubscriberName = "shop-push"
+
+// maxBodyLength is where notification bodies are cut; phones truncate
+// long ones anyway.
+const maxBodyLength = 140
+
+// Dispatcher turns shop events into push notifications.
+type Dispatcher struct {
+	repo   Repository
+	sender push.Sender
+	now    func() time.Time
+}
+
+func NewDispatcher(repo Repository, sender push.Sender) *Dispatcher {
+	return &Dispatcher{repo: repo, sender: sender, now: time.Now}
+}
+
+// Subscribe registers the Dispatcher on bus for the events it pushes.
+func (d *Dispatcher) Subscribe(bus *events.Bus) {
+	bus.Subscribe(SubscriberName, d.Handle,
+		events.TypeMessageCreated,
+		events.TypeVehicleNotificationCreated,
+		events.TypeEquipmentServiceOverdue,
+	)
+}
+
+// Handle pushes env to the members who want it. The bus retries the event
+// only if the push service could not be reached at all; devices that fail
+// individually are not retried, so nobody is notified twice.
+func (d *Dispatcher) Handle(ctx context.Context, env events.Envelope) error {
+	event, err := env.Decode()
+	if err != nil {
+		return err
+	}
+	shopName, err := d.repo.GetShopName(ctx, env.ShopID)
+	if errors.Is(err, shared.ErrShopNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch event := event.(type) {
+	case *events.MessageCreated:
+		author, text, err := d.repo.GetMessage(ctx, event.MessageID)
+		if errors.Is(err, ErrMessageNotFound) {
+			// Deleted before it could be announced
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		body := text
+		if author != "" {
+			body = author + ": " + text
+		}
+		return d.notify(ctx, env, KindMessages, event.AuthorID, push.Notification{
+			Title: shopName,
+			Body:  truncate(body),
+			Data:  map[string]string{"message_id": event.MessageID},
+		})
+	case *events.VehicleNotificationCreated:
+		return d.notify(ctx, env, KindVehicleNotifications, event.CreatedBy, push.Notification{
+			Title: shopName,
+			Body:  truncate("New vehicle notification: " + event.Title),
+			Data:  map[string]string{"vehicle_id": event.VehicleID, "notification_id": event.NotificationID},
+		})
+	case *events.EquipmentServiceOverdue:
+		return d.notify(ctx, env, KindOverdueServices, "", push.Notification{
+			Title: shopName,
+			Body:  truncate(fmt.Sprintf("Overdue %s: %s", event.ServiceType, event.Description)),
+			Data:  map[string]string{"service_id": event.ServiceID, "equipment_id": event.EquipmentID},
+		})
+	}
+	return nil
+}
+
+// notify sends notification to the devices of members, other than actorID,
+// who want kind and are outside their quiet hours, and forgets devices the
+// push service reports invalid.
+func (d *Dispatcher) notify(ctx context.Context, env events.Envelope, kind Kind, actorID string, notification push.Notification) error {
+	recipients, err := d.repo.GetRecipients(ctx, env.ShopID, actorID, kind)
+	if err != nil {
+		return err
+	}
+
+	now := d.now()
+	var tokens []string
+	quiet := 0
+	for _, recipient := range recipients {
+		if recipient.quietAt(now) {
+			quiet++
+			continue
+		}
+		tokens = append(tokens, recipient.Token)
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	notification.Data["type"] = env.Type
+	notification.Data["shop_id"] = env.ShopID
+	result, err := d.sender.Send(ctx, tokens, notification)
+	if err != nil {
+		return err
+	}
+	if len(result.Invalid) > 0 {
+		if err := d.repo.DeleteDeviceTokens(ctx, result.Invalid); err != nil {
+			slog.Error("Failed to forget invalid device tokens", "error", err, "tokens", len(result.Invalid))
+		}
+	}
+	slog.Info("Push notification sent",
+		"event_id", env.ID,
+		"event_type", env.Type,
+		"shop_id", env.ShopID,
+		"sent", result.Sent,
+		"failed", result.Failed,
+		"invalid", len(result.Invalid),
+		"quiet", quiet)
+	return nil
+}
+
+// truncate cuts body to maxBodyLength characters, marking the cut.
+func truncate(body string) string {
+	body = strings.TrimSpace(body)
+	if utf8.RuneCountInString(body) <= maxBodyLength {
+		return body
+	}
+	runes := []rune(body)
+	return strings.TrimSpace(string(runes[:maxBodyLength-1])) + "…"
+}
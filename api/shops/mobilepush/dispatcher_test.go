@@ -0,0 +1,175 @@
+package mobilepush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/request"
+	"miltechserver/events"
+	"miltechserver/push"
+
+	"github.com/stretchr/testify/require"
+)
+
+type repositoryStub struct {
+	Repository
+	recipients map[Kind][]Recipient
+	messages   map[string][2]string
+	deleted    []string
+	askedKind  Kind
+	askedActor string
+}
+
+func (stub *repositoryStub) GetShopName(_ context.Context, shopID string) (string, error) {
+	return "Alpha Company Motor Pool", nil
+}
+
+func (stub *repositoryStub) GetMessage(_ context.Context, messageID string) (string, string, error) {
+	message, ok := stub.messages[messageID]
+	if !ok {
+		return "", "", ErrMessageNotFound
+	}
+	return message[0], message[1], nil
+}
+
+func (stub *repositoryStub) GetRecipients(_ context.Context, _ string, exceptUserID string, kind Kind) ([]Recipient, error) {
+	stub.askedKind, stub.askedActor = kind, exceptUserID
+	return stub.recipients[kind], nil
+}
+
+func (stub *repositoryStub) DeleteDeviceTokens(_ context.Context, tokens []string) error {
+	stub.deleted = append(stub.deleted, tokens...)
+	return nil
+}
+
+func envelope(t *testing.T, event events.Event) events.Envelope {
+	t.Helper()
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	return events.Envelope{ID: 7, Type: event.EventType(), ShopID: event.EventShopID(), Payload: payload, Attempt: 1}
+}
+
+func minute(clock string) *int {
+	m, _ := push.ParseClock(clock)
+	return &m
+}
+
+func TestDispatcherPushesMessages(t *testing.T) {
+	repo := &repositoryStub{
+		messages: map[string][2]string{"message-1": {"sgt.rivera", "Motor pool closes at 1600 today"}},
+		recipients: map[Kind][]Recipient{KindMessages: {
+			{UserID: "user-2", Token: "phone", TimeZone: "UTC"},
+			{UserID: "user-2", Token: "stale", TimeZone: "UTC"},
+			{UserID: "user-3", Token: "asleep", QuietStart: minute("22:00"), QuietEnd: minute("06:00"), TimeZone: "America/Chicago"},
+		}},
+	}
+	sender := &push.Fake{Invalid: map[string]bool{"stale": true}}
+	dispatcher := NewDispatcher(repo, sender)
+	// 23:30 in Chicago
+	dispatcher.now = func() time.Time { return time.Date(2026, 10, 18, 4, 30, 0, 0, time.UTC) }
+
+	err := dispatcher.Handle(context.Background(),
+		envelope(t, events.MessageCreated{ShopID: "shop-1", MessageID: "message-1", AuthorID: "user-1"}))
+	require.NoError(t, err)
+	require.Equal(t, KindMessages, repo.askedKind)
+	require.Equal(t, "user-1", repo.askedActor)
+
+	deliveries := sender.Deliveries()
+	require.Len(t, deliveries, 1)
+	require.Equal(t, []string{"phone", "stale"}, deliveries[0].Tokens)
+	require.Equal(t, push.Notification{
+		Title: "Alpha Company Motor Pool",
+		Body:  "sgt.rivera: Motor pool closes at 1600 today",
+		Data: map[string]string{
+			"type":       events.TypeMessageCreated,
+			"shop_id":    "shop-1",
+			"message_id": "message-1",
+		},
+	}, deliveries[0].Notification)
+	require.Equal(t, []string{"stale"}, repo.deleted)
+}
+
+func TestDispatcherPushesOverdueServicesToEveryone(t *testing.T) {
+	repo := &repositoryStub{recipients: map[Kind][]Recipient{KindOverdueServices: {{UserID: "user-1", Token: "phone"}}}}
+	sender := &push.Fake{}
+
+	err := NewDispatcher(repo, sender).Handle(context.Background(), envelope(t, events.EquipmentServiceOverdue{
+		ShopID: "shop-1", ServiceID: "service-1", EquipmentID: "vehicle-1",
+		ServiceType: "annual", Description: strings.Repeat("Replace fuel filter. ", 20),
+	}))
+	require.NoError(t, err)
+	require.Empty(t, repo.askedActor)
+
+	notification := sender.Deliveries()[0].Notification
+	require.True(t, strings.HasPrefix(notification.Body, "Overdue annual: Replace fuel filter."))
+	require.LessOrEqual(t, len([]rune(notification.Body)), maxBodyLength)
+	require.True(t, strings.HasSuffix(notification.Body, "…"))
+	require.Equal(t, "service-1", notification.Data["service_id"])
+}
+
+func TestDispatcherSkipsDeletedMessages(t *testing.T) {
+	sender := &push.Fake{}
+	err := NewDispatcher(&repositoryStub{}, sender).Handle(context.Background(),
+		envelope(t, events.MessageCreated{ShopID: "shop-1", MessageID: "gone", AuthorID: "user-1"}))
+	require.NoError(t, err)
+	require.Empty(t, sender.Deliveries())
+}
+
+func TestDispatcherRetriesWhenPushServiceIsDown(t *testing.T) {
+	repo := &repositoryStub{recipients: map[Kind][]Recipient{KindVehicleNotifications: {{UserID: "user-2", Token: "phone"}}}}
+	sender := &push.Fake{Err: errors.New("fcm unavailable")}
+
+	err := NewDispatcher(repo, sender).Handle(context.Background(), envelope(t, events.VehicleNotificationCreated{
+		ShopID: "shop-1", VehicleID: "vehicle-1", NotificationID: "notification-1", Title: "Oil leak", CreatedBy: "user-1",
+	}))
+	require.EqualError(t, err, "fcm unavailable")
+}
+
+func TestApplyUpdate(t *testing.T) {
+	off := false
+	chicago := "America/Chicago"
+	start, end := "22:00", "6:30"
+	prefs, err := applyUpdate(defaultPreferences("shop-1"), request.UpdateShopPushPreferencesRequest{
+		Messages:        &off,
+		TimeZone:        &chicago,
+		QuietHoursStart: request.NullableStringField{Set: true, Value: &start},
+		QuietHoursEnd:   request.NullableStringField{Set: true, Value: &end},
+	})
+	require.NoError(t, err)
+	require.False(t, prefs.Messages)
+	require.True(t, prefs.VehicleNotifications)
+	require.Equal(t, "America/Chicago", prefs.TimeZone)
+	require.Equal(t, "22:00", *prefs.QuietHoursStart)
+	require.Equal(t, "06:30", *prefs.QuietHoursEnd)
+
+	// Clearing quiet hours
+	cleared, err := applyUpdate(prefs, request.UpdateShopPushPreferencesRequest{
+		QuietHoursStart: request.NullableStringField{Set: true},
+		QuietHoursEnd:   request.NullableStringField{Set: true},
+	})
+	require.NoError(t, err)
+	require.Nil(t, cleared.QuietHoursStart)
+	require.Nil(t, cleared.QuietHoursEnd)
+
+	invalid := map[string]request.UpdateShopPushPreferencesRequest{
+		"time_zone":         {TimeZone: ptr("Mars/Olympus_Mons")},
+		"quiet_hours_start": {QuietHoursStart: request.NullableStringField{Set: true, Value: ptr("22:00")}},
+		"quiet_hours_end":   {QuietHoursStart: request.NullableStringField{Set: true, Value: ptr("22:00")}, QuietHoursEnd: request.NullableStringField{Set: true, Value: ptr("25:00")}},
+	}
+	for field, req := range invalid {
+		_, err := applyUpdate(defaultPreferences("shop-1"), req)
+		var appErr *apperror.Error
+		require.ErrorAs(t, err, &appErr, field)
+		require.Equal(t, apperror.KindValidation, appErr.Kind, field)
+		require.Equal(t, field, appErr.Fields[0].Field)
+	}
+}
+
+func ptr(value string) *string {
+	return &value
+}
@@ -0,0 +1,31 @@
+package mobilepush
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Push Preferences",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getShopPushPreferences",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/push-preferences",
+			Auth:     true,
+			Summary:  "Returns which push notifications the caller gets from the shop, and their quiet hours",
+			Response: Preferences{},
+		},
+		{
+			ID:       "updateShopPushPreferences",
+			Method:   http.MethodPut,
+			Path:     "/shops/:shop_id/push-preferences",
+			Auth:     true,
+			Summary:  "Turns the caller's message, vehicle notification and overdue service pushes from the shop on or off, and sets or clears their quiet hours",
+			Request:  request.UpdateShopPushPreferencesRequest{},
+			Response: Preferences{},
+		},
+	},
+}}
@@ -0,0 +1,5 @@
+package mobilepush
+
+import "miltechserver/api/apperror"
+
+var ErrMessageNotFound = apperror.NotFound("message_not_found", "message not found")
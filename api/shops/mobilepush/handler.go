@@ -0,0 +1,84 @@
+package mobilepush
+
+import (
+	"log/slog"
+	"miltechserver/api/apperror"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"miltechserver/bootstrap"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+// GetPreferences returns the caller's push preferences for the shop
+func (handler *Handler) GetPreferences(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	if shopID == "" {
+		c.Error(apperror.MissingParameter("shop_id"))
+		return
+	}
+
+	service := handler.service
+	prefs, err := service.GetPreferences(c.Request.Context(), user, shopID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, response.StandardResponse{
+		Status:  200,
+		Message: "",
+		Data:    prefs,
+	})
+}
+
+// UpdatePreferences changes the caller's push preferences for the shop
+func (handler *Handler) UpdatePreferences(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	if shopID == "" {
+		c.Error(apperror.MissingParameter("shop_id"))
+		return
+	}
+
+	var req request.UpdateShopPushPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.Info("invalid request", "error", err)
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	service := handler.service
+	prefs, err := service.UpdatePreferences(c.Request.Context(), user, shopID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, response.StandardResponse{
+		Status:  200,
+		Message: "Push preferences updated successfully",
+		Data:    prefs,
+	})
+}
@@ -0,0 +1,71 @@
+// Package mobilepush pushes shop activity to members' phones. The
+// Dispatcher subscribes to the event bus and sends new messages, vehicle
+// notifications and overdue equipment services to the devices members
+// registered through /user/devices, honouring each member's per-shop
+// preferences and quiet hours. Members manage their preferences at
+// /shops/:shop_id/push-preferences.
+package mobilepush
+
+import (
+	"miltechserver/push"
+	"time"
+)
+
+// Kind is a category of push a member can turn off. Its value is the
+// preference's column in shop_push_preferences.
+type Kind string
+
+const (
+	KindMessages             Kind = "messages"
+	KindVehicleNotifications Kind = "vehicle_notifications"
+	KindOverdueServices      Kind = "overdue_services"
+)
+
+// Preferences are a member's push settings for one shop. Members who never
+// saved any get every kind and no quiet hours.
+type Preferences struct {
+	ShopID               string `json:"shop_id"`
+	Messages             bool   `json:"messages"`
+	VehicleNotifications bool   `json:"vehicle_notifications"`
+	OverdueServices      bool   `json:"overdue_services"`
+	// QuietHoursStart and QuietHoursEnd are "HH:MM" in TimeZone; both are
+	// set or neither is.
+	QuietHoursStart *string `json:"quiet_hours_start"`
+	QuietHoursEnd   *string `json:"quiet_hours_end"`
+	// TimeZone is an IANA name such as "America/Chicago".
+	TimeZone string `json:"time_zone"`
+}
+
+func defaultPreferences(shopID string) Preferences {
+	return Preferences{
+		ShopID:               shopID,
+		Messages:             true,
+		VehicleNotifications: true,
+		OverdueServices:      true,
+		TimeZone:             "UTC",
+	}
+}
+
+// Recipient is one device of a member who wants a kind of push.
+type Recipient struct {
+	UserID string
+	Token  string
+	// QuietStart and QuietEnd are minutes after midnight, nil without
+	// quiet hours.
+	QuietStart *int
+	QuietEnd   *int
+	TimeZone   string
+}
+
+// quietAt reports whether r is in quiet hours at now. An unknown time zone,
+// which validation should have prevented, is treated as UTC.
+func (r Recipient) quietAt(now time.Time) bool {
+	if r.QuietStart == nil || r.QuietEnd == nil {
+		return false
+	}
+	location, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	return push.QuietHours{Start: *r.QuietStart, End: *r.QuietEnd, Location: location}.Contains(now)
+}
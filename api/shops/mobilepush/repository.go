@@ -0,0 +1,19 @@
+package mobilepush
+
+import (
+	"context"
+)
+
+type Repository interface {
+	GetPreferences(ctx context.Context, userID string, shopID string) (*Preferences, error)
+	SavePreferences(ctx context.Context, userID string, prefs Preferences) (*Preferences, error)
+
+	// GetRecipients returns the devices of the shop's members, other than
+	// exceptUserID, who have kind turned on.
+	GetRecipients(ctx context.Context, shopID string, exceptUserID string, kind Kind) ([]Recipient, error)
+	DeleteDeviceTokens(ctx context.Context, tokens []string) error
+
+	GetShopName(ctx context.Context, shopID string) (string, error)
+	// GetMessage returns a shop message's text and its author's username.
+	GetMessage(ctx context.Context, messageID string) (author string, text string, err error)
+}
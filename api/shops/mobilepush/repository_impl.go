@@ -0,0 +1,162 @@
+package mobilepush
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"miltechserver/api/shops/shared"
+	"miltechserver/push"
+
+	"github.com/lib/pq"
+)
+
+type RepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *RepositoryImpl {
+	return &RepositoryImpl{db: db}
+}
+
+func (repo *RepositoryImpl) GetPreferences(ctx context.Context, userID string, shopID string) (*Preferences, error) {
+	prefs := defaultPreferences(shopID)
+	var quietStart, quietEnd sql.NullInt32
+	err := repo.db.QueryRowContext(ctx, `
+		SELECT messages, vehicle_notifications, overdue_services, quiet_start_minute, quiet_end_minute, time_zone
+		FROM shop_push_preferences
+		WHERE user_id = $1 AND shop_id = $2`, userID, shopID).Scan(
+		&prefs.Messages,
+		&prefs.VehicleNotifications,
+		&prefs.OverdueServices,
+		&quietStart,
+		&quietEnd,
+		&prefs.TimeZone,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &prefs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get push preferences: %w", err)
+	}
+	if quietStart.Valid && quietEnd.Valid {
+		start, end := push.FormatClock(int(quietStart.Int32)), push.FormatClock(int(quietEnd.Int32))
+		prefs.QuietHoursStart, prefs.QuietHoursEnd = &start, &end
+	}
+	return &prefs, nil
+}
+
+// SavePreferences stores prefs, whose quiet hours the caller has validated.
+func (repo *RepositoryImpl) SavePreferences(ctx context.Context, userID string, prefs Preferences) (*Preferences, error) {
+	var quietStart, quietEnd *int
+	if prefs.QuietHoursStart != nil && prefs.QuietHoursEnd != nil {
+		start, err := push.ParseClock(*prefs.QuietHoursStart)
+		if err != nil {
+			return nil, err
+		}
+		end, err := push.ParseClock(*prefs.QuietHoursEnd)
+		if err != nil {
+			return nil, err
+		}
+		quietStart, quietEnd = &start, &end
+	}
+
+	_, err := repo.db.ExecContext(ctx, `
+		INSERT INTO shop_push_preferences
+			(user_id, shop_id, messages, vehicle_notifications, overdue_services, quiet_start_minute, quiet_end_minute, time_zone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, shop_id) DO UPDATE SET
+			messages = EXCLUDED.messages,
+			vehicle_notifications = EXCLUDED.vehicle_notifications,
+			overdue_services = EXCLUDED.overdue_services,
+			quiet_start_minute = EXCLUDED.quiet_start_minute,
+			quiet_end_minute = EXCLUDED.quiet_end_minute,
+			time_zone = EXCLUDED.time_zone,
+			updated_at = now()`,
+		userID, prefs.ShopID, prefs.Messages, prefs.VehicleNotifications, prefs.OverdueServices,
+		quietStart, quietEnd, prefs.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save push preferences: %w", err)
+	}
+	return repo.GetPreferences(ctx, userID, prefs.ShopID)
+}
+
+// kindColumns guards the column GetRecipients interpolates.
+var kindColumns = map[Kind]string{
+	KindMessages:             "messages",
+	KindVehicleNotifications: "vehicle_notifications",
+	KindOverdueServices:      "overdue_services",
+}
+
+func (repo *RepositoryImpl) GetRecipients(ctx context.Context, shopID string, exceptUserID string, kind Kind) ([]Recipient, error) {
+	column, ok := kindColumns[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown push kind %q", kind)
+	}
+	rows, err := repo.db.QueryContext(ctx, `
+		SELECT t.user_id, t.token, p.quiet_start_minute, p.quiet_end_minute, COALESCE(p.time_zone, 'UTC')
+		FROM shop_members m
+		JOIN user_device_tokens t ON t.user_id = m.user_id
+		LEFT JOIN shop_push_preferences p ON p.user_id = m.user_id AND p.shop_id = m.shop_id
+		WHERE m.shop_id = $1 AND m.user_id <> $2 AND COALESCE(p.`+column+`, TRUE)`,
+		shopID, exceptUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get push recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []Recipient
+	for rows.Next() {
+		var recipient Recipient
+		var quietStart, quietEnd sql.NullInt32
+		if err := rows.Scan(&recipient.UserID, &recipient.Token, &quietStart, &quietEnd, &recipient.TimeZone); err != nil {
+			return nil, fmt.Errorf("failed to scan push recipient: %w", err)
+		}
+		if quietStart.Valid && quietEnd.Valid {
+			start, end := int(quietStart.Int32), int(quietEnd.Int32)
+			recipient.QuietStart, recipient.QuietEnd = &start, &end
+		}
+		recipients = append(recipients, recipient)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating push recipients: %w", err)
+	}
+	return recipients, nil
+}
+
+func (repo *RepositoryImpl) DeleteDeviceTokens(ctx context.Context, tokens []string) error {
+	_, err := repo.db.ExecContext(ctx,
+		`DELETE FROM user_device_tokens WHERE token = ANY($1)`, pq.Array(tokens))
+	if err != nil {
+		return fmt.Errorf("failed to delete device tokens: %w", err)
+	}
+	return nil
+}
+
+func (repo *RepositoryImpl) GetShopName(ctx context.Context, shopID string) (string, error) {
+	var name string
+	err := repo.db.QueryRowContext(ctx, `SELECT name FROM shops WHERE id = $1`, shopID).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", shared.ErrShopNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get shop name: %w", err)
+	}
+	return name, nil
+}
+
+func (repo *RepositoryImpl) GetMessage(ctx context.Context, messageID string) (string, string, error) {
+	var author, text string
+	err := repo.db.QueryRowContext(ctx, `
+		SELECT COALESCE(u.username, ''), m.message
+		FROM shop_messages m
+		LEFT JOIN users u ON u.uid = m.user_id
+		WHERE m.id = $1`, messageID).Scan(&author, &text)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", ErrMessageNotFound
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get shop message: %w", err)
+	}
+	return author, text, nil
+}
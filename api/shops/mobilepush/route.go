@@ -0,0 +1,11 @@
+package mobilepush
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, service Service) {
+	handler := Handler{service: service}
+	router.GET("/shops/:shop_id/push-preferences", handler.GetPreferences)
+	router.PUT("/shops/:shop_id/push-preferences", handler.UpdatePreferences)
+}
@@ -0,0 +1,12 @@
+package mobilepush
+
+import (
+	"context"
+	"miltechserver/api/request"
+	"miltechserver/bootstrap"
+)
+
+type Service interface {
+	GetPreferences(ctx context.Context, user *bootstrap.User, shopID string) (*Preferences, error)
+	UpdatePreferences(ctx context.Context, user *bootstrap.User, shopID string, req request.UpdateShopPushPreferencesRequest) (*Preferences, error)
+}
@@ -0,0 +1,126 @@
+package mobilepush
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"miltechserver/api/apperror"
+	"miltechserver/api/request"
+	"miltechserver/api/shops/shared"
+	"miltechserver/bootstrap"
+	"miltechserver/push"
+	"strings"
+	"time"
+)
+
+type ServiceImpl struct {
+	repo Repository
+	auth shared.ShopAuthorization
+}
+
+func NewService(repo Repository, auth shared.ShopAuthorization) *ServiceImpl {
+	return &ServiceImpl{repo: repo, auth: auth}
+}
+
+func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
+	return &ServiceImpl{repo: service.repo, auth: auth}
+}
+
+// GetPreferences returns the user's push settings for a shop they belong to.
+func (service *ServiceImpl) GetPreferences(ctx context.Context, user *bootstrap.User, shopID string) (*Preferences, error) {
+	if user == nil {
+		return nil, shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopMember(ctx, user, shopID); err != nil {
+		return nil, err
+	}
+	return service.repo.GetPreferences(ctx, user.UserID, shopID)
+}
+
+func (service *ServiceImpl) UpdatePreferences(ctx context.Context,
+	user *bootstrap.User,
+	shopID string,
+	req request.UpdateShopPushPreferencesRequest,
+) (*Preferences, error) {
+	if user == nil {
+		return nil, shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopMember(ctx, user, shopID); err != nil {
+		return nil, err
+	}
+
+	current, err := service.repo.GetPreferences(ctx, user.UserID, shopID)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := applyUpdate(*current, req)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := service.repo.SavePreferences(ctx, user.UserID, prefs)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Push preferences updated", "user_id", user.UserID, "shop_id", shopID)
+	return updated, nil
+}
+
+// applyUpdate returns prefs with req applied, or a validation error.
+func applyUpdate(prefs Preferences, req request.UpdateShopPushPreferencesRequest) (Preferences, error) {
+	if req.Messages != nil {
+		prefs.Messages = *req.Messages
+	}
+	if req.VehicleNotifications != nil {
+		prefs.VehicleNotifications = *req.VehicleNotifications
+	}
+	if req.OverdueServices != nil {
+		prefs.OverdueServices = *req.OverdueServices
+	}
+	if req.TimeZone != nil {
+		timeZone := strings.TrimSpace(*req.TimeZone)
+		// "" and "Local" load, but mean the server's zone rather than the user's
+		if _, err := time.LoadLocation(timeZone); err != nil || timeZone == "" || timeZone == "Local" {
+			return prefs, apperror.Validation("invalid_time_zone", "unknown time zone",
+				apperror.FieldError{Field: "time_zone", Message: "must be an IANA time zone such as America/Chicago"})
+		}
+		prefs.TimeZone = timeZone
+	}
+
+	if req.QuietHoursStart.Set != req.QuietHoursEnd.Set {
+		return prefs, apperror.Validation("invalid_quiet_hours", "quiet hours need both a start and an end",
+			apperror.FieldError{Field: "quiet_hours_start", Message: "must be set together with quiet_hours_end"})
+	}
+	if req.QuietHoursStart.Set {
+		prefs.QuietHoursStart, prefs.QuietHoursEnd = req.QuietHoursStart.Value, req.QuietHoursEnd.Value
+	}
+	if (prefs.QuietHoursStart == nil) != (prefs.QuietHoursEnd == nil) {
+		return prefs, apperror.Validation("invalid_quiet_hours", "quiet hours need both a start and an end",
+			apperror.FieldError{Field: "quiet_hours_start", Message: "must be set together with quiet_hours_end"})
+	}
+	if prefs.QuietHoursStart != nil {
+		start, err := push.ParseClock(*prefs.QuietHoursStart)
+		if err != nil {
+			return prefs, quietHoursError("quiet_hours_start", err)
+		}
+		end, err := push.ParseClock(*prefs.QuietHoursEnd)
+		if err != nil {
+			return prefs, quietHoursError("quiet_hours_end", err)
+		}
+		if start == end {
+			return prefs, apperror.Validation("invalid_quiet_hours", "quiet hours must not start and end at the same time",
+				apperror.FieldError{Field: "quiet_hours_end", Message: "must differ from quiet_hours_start"})
+		}
+		// Normalised, so "7:05" is stored and returned as "07:05"
+		startClock, endClock := push.FormatClock(start), push.FormatClock(end)
+		prefs.QuietHoursStart, prefs.QuietHoursEnd = &startClock, &endClock
+	}
+	return prefs, nil
+}
+
+func quietHoursError(field string, err error) error {
+	return apperror.Validation("invalid_quiet_hours", "invalid quiet hours",
+		apperror.FieldError{Field: field, Message: fmt.Sprint(err)})
+}
+
+var _ Service = (*ServiceImpl)(nil)
@@ -3,58 +3,98 @@ package shops
 import (
 	"database/sql"
 	"miltechserver/api/shops/aggregates"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/core"
 	"miltechserver/api/shops/lists"
 	listitems "miltechserver/api/shops/lists/items"
 	"miltechserver/api/shops/members"
 	"miltechserver/api/shops/members/invites"
 	"miltechserver/api/shops/messages"
+	"miltechserver/api/shops/mobilepush"
 	"miltechserver/api/shops/settings"
 	"miltechserver/api/shops/shared"
+	"miltechserver/api/shops/stream"
 	"miltechserver/api/shops/vehicles"
 	"miltechserver/api/shops/vehicles/notifications"
 	notificationchanges "miltechserver/api/shops/vehicles/notifications/changes"
 	notificationitems "miltechserver/api/shops/vehicles/notifications/items"
+	"miltechserver/api/shops/webhooks"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
+	"miltechserver/jobs"
+	"miltechserver/push"
+	"miltechserver/realtime"
+	"miltechserver/storage"
+	"miltechserver/webhook"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 )
 
 type Dependencies struct {
-	DB         *sql.DB
-	BlobClient *azblob.Client
-	Env        *bootstrap.Env
+	DB     *sql.DB
+	Store  storage.ObjectStore
+	Env    *bootstrap.Env
+	Events *events.Bus
+	Jobs   *jobs.Scheduler
+	Push   push.Sender
+	Stream *realtime.Hub
 }
 
 func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
 	authorization := shared.NewShopAuthorization(deps.DB)
 
+	auditRepository := audit.NewRepository(deps.DB)
+	auditLog := audit.NewLog(deps.DB, auditRepository)
+
 	aggregatesRepository := aggregates.NewRepository(deps.DB)
-	coreRepository := core.NewRepository(deps.DB, deps.BlobClient, deps.Env)
+	coreRepository := core.NewRepository(deps.DB, deps.Store, deps.Env)
 	settingsRepository := settings.NewRepository(deps.DB)
-	membersRepository := members.NewRepository(deps.DB, deps.BlobClient, deps.Env)
+	membersRepository := members.NewRepository(deps.DB, deps.Store, deps.Env)
 	inviteRepository := invites.NewRepository(deps.DB)
 	listRepository := lists.NewRepository(deps.DB)
 	listItemsRepository := listitems.NewRepository(deps.DB)
-	messagesRepository := messages.NewRepository(deps.DB, deps.BlobClient, deps.Env)
+	messagesRepository := messages.NewRepository(deps.DB, deps.Store, deps.Env)
 	vehiclesRepository := vehicles.NewRepository(deps.DB)
 	notificationsRepository := notifications.NewRepository(deps.DB)
 	notificationItemsRepository := notificationitems.NewRepository(deps.DB)
 	notificationChangesRepository := notificationchanges.NewRepository(deps.DB)
+	webhooksRepository := webhooks.NewRepository(deps.DB)
+	mobilePushRepository := mobilepush.NewRepository(deps.DB)
+	streamRepository := stream.NewRepository(deps.DB)
 
 	aggregatesService := aggregates.NewService(aggregatesRepository, authorization)
-	coreService := core.NewService(coreRepository, authorization)
-	settingsService := settings.NewService(settingsRepository, authorization)
-	membersService := members.NewService(membersRepository, inviteRepository, authorization)
-	inviteService := invites.NewService(inviteRepository, authorization)
-	listsService := lists.NewService(listRepository, settingsRepository, authorization)
-	listItemsService := listitems.NewService(listItemsRepository, listRepository, settingsRepository, authorization)
-	messagesService := messages.NewService(messagesRepository, authorization)
-	vehiclesService := vehicles.NewService(vehiclesRepository, authorization)
-	notificationsService := notifications.NewService(notificationsRepository, authorization)
-	notificationItemsService := notificationitems.NewService(notificationItemsRepository)
+	coreService := core.NewService(coreRepository, authorization, auditLog)
+	settingsService := settings.NewService(settingsRepository, authorization, auditLog)
+	membersService := members.NewService(membersRepository, inviteRepository, authorization, auditLog, deps.Events)
+	inviteService := invites.NewService(inviteRepository, authorization, auditLog)
+	listsService := lists.NewService(listRepository, settingsRepository, authorization, auditLog)
+	listItemsService := listitems.NewService(listItemsRepository, listRepository, settingsRepository, authorization, auditLog, deps.Events)
+	messagesService := messages.NewService(messagesRepository, authorization, deps.Events)
+	vehiclesService := vehicles.NewService(vehiclesRepository, authorization, auditLog, deps.Events)
+	notificationsService := notifications.NewService(notificationsRepository, authorization, auditLog, deps.Events)
+	notificationItemsService := notificationitems.NewService(notificationItemsRepository, auditLog, deps.Events)
 	notificationChangesService := notificationchanges.NewService(notificationChangesRepository)
+	auditService := audit.NewService(auditRepository, authorization)
+
+	// Local development posts to http endpoints on the same machine
+	allowPrivateWebhooks := deps.Env != nil && deps.Env.WebhookAllowPrivateURLs
+	webhookDeliverer := webhooks.NewDeliverer(webhooksRepository, webhook.NewSender(allowPrivateWebhooks))
+	webhookDeliverer.Subscribe(deps.Events)
+	webhooks.RegisterJobs(deps.Jobs, webhooksRepository)
+	pushSender := deps.Push
+	if pushSender == nil {
+		pushSender = push.Log{}
+	}
+	mobilepush.NewDispatcher(mobilePushRepository, pushSender).Subscribe(deps.Events)
+	mobilePushService := mobilepush.NewService(mobilePushRepository, authorization)
+
+	webhooksService := webhooks.NewService(webhooksRepository, authorization, auditLog, webhookDeliverer, allowPrivateWebhooks)
+
+	hub := deps.Stream
+	if hub == nil {
+		hub = realtime.NewHub()
+	}
+	streamService := stream.NewService(streamRepository, authorization, hub)
 
 	aggregates.RegisterRoutes(router, aggregatesService)
 	core.RegisterRoutes(router, coreService)
@@ -68,4 +108,8 @@ func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
 	notificationchanges.RegisterRoutes(router, notificationChangesService)
 	lists.RegisterRoutes(router, listsService)
 	listitems.RegisterRoutes(router, listItemsService)
+	audit.RegisterRoutes(router, auditService)
+	webhooks.RegisterRoutes(router, webhooksService)
+	mobilepush.RegisterRoutes(router, mobilePushService)
+	stream.RegisterRoutes(router, streamService)
 }
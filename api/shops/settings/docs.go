@@ -0,0 +1,56 @@
+package settings
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Settings",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getShopSettings",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/settings",
+			Auth:     true,
+			Summary:  "Returns all settings for a shop",
+			Response: request.ShopSettings{},
+		},
+		{
+			ID:       "updateShopSettings",
+			Method:   http.MethodPut,
+			Path:     "/shops/:shop_id/settings",
+			Auth:     true,
+			Summary:  "Updates one or more shop settings (admin only)",
+			Request:  request.UpdateShopSettingsRequest{},
+			Response: request.ShopSettings{},
+		},
+		{
+			ID:       "getShopAdminOnlyListsSetting",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/settings/admin-only-lists",
+			Auth:     true,
+			Summary:  "Returns the admin_only_lists setting for a shop",
+			Response: openapi.Object{"shop_id": "", "admin_only_lists": false},
+		},
+		{
+			ID:       "updateShopAdminOnlyListsSetting",
+			Method:   http.MethodPut,
+			Path:     "/shops/:shop_id/settings/admin-only-lists",
+			Auth:     true,
+			Summary:  "Updates the admin_only_lists setting for a shop (admin only)",
+			Request:  request.UpdateAdminOnlyListsRequest{},
+			Response: openapi.Object{"shop_id": "", "admin_only_lists": false},
+		},
+		{
+			ID:       "checkUserIsShopAdmin",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/is-admin",
+			Auth:     true,
+			Summary:  "Checks if the current user is an admin for the specified shop",
+			Response: openapi.Object{"shop_id": "", "user_id": "", "is_admin": false},
+		},
+	},
+}}
@@ -2,6 +2,7 @@ package settings
 
 import (
 	"log/slog"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -21,19 +22,19 @@ func (handler *Handler) GetShopAdminOnlyListsSetting(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	adminOnlyLists, err := service.GetShopAdminOnlyListsSetting(user, shopID)
+	adminOnlyLists, err := service.GetShopAdminOnlyListsSetting(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -55,26 +56,26 @@ func (handler *Handler) UpdateShopAdminOnlyListsSetting(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	var req request.UpdateAdminOnlyListsRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	err := service.UpdateShopAdminOnlyListsSetting(user, shopID, req.AdminOnlyLists)
+	err := service.UpdateShopAdminOnlyListsSetting(c.Request.Context(), user, shopID, req.AdminOnlyLists)
 	if err != nil {
 		c.Error(err)
 		return
@@ -96,19 +97,19 @@ func (handler *Handler) CheckUserIsShopAdmin(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	isAdmin, err := service.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.IsUserShopAdmin(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -133,19 +134,19 @@ func (handler *Handler) GetShopSettings(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	settings, err := service.GetShopSettings(user, shopID)
+	settings, err := service.GetShopSettings(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -164,33 +165,33 @@ func (handler *Handler) UpdateShopSettings(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	var req request.UpdateShopSettingsRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	// Validate that at least one setting is being updated
 	if req.AdminOnlyLists == nil {
 		// Future settings will be checked here with OR conditions
-		c.JSON(400, gin.H{"message": "at least one setting must be provided"})
+		c.Error(apperror.Validation("no_settings_provided", "at least one setting must be provided"))
 		return
 	}
 
 	service := handler.service
-	updatedSettings, err := service.UpdateShopSettings(user, shopID, req)
+	updatedSettings, err := service.UpdateShopSettings(c.Request.Context(), user, shopID, req)
 	if err != nil {
 		c.Error(err)
 		return
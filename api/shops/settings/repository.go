@@ -1,10 +1,13 @@
 package settings
 
-import "miltechserver/api/request"
+import (
+	"context"
+	"miltechserver/api/request"
+)
 
 type Repository interface {
-	GetShopAdminOnlyListsSetting(shopID string) (bool, error)
-	UpdateShopAdminOnlyListsSetting(shopID string, adminOnlyLists bool) error
-	GetShopSettings(shopID string) (*request.ShopSettings, error)
-	UpdateShopSettings(shopID string, updates request.UpdateShopSettingsRequest) error
+	GetShopAdminOnlyListsSetting(ctx context.Context, shopID string) (bool, error)
+	UpdateShopAdminOnlyListsSetting(ctx context.Context, shopID string, adminOnlyLists bool) error
+	GetShopSettings(ctx context.Context, shopID string) (*request.ShopSettings, error)
+	UpdateShopSettings(ctx context.Context, shopID string, updates request.UpdateShopSettingsRequest) error
 }
@@ -1,13 +1,16 @@
 package settings
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
+	"miltechserver/api/shops/shared"
+	"miltechserver/dbtx"
 	"strings"
 	"time"
 
@@ -23,16 +26,16 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 }
 
 // GetShopAdminOnlyListsSetting retrieves the admin_only_lists setting for a shop
-func (repo *RepositoryImpl) GetShopAdminOnlyListsSetting(shopID string) (bool, error) {
+func (repo *RepositoryImpl) GetShopAdminOnlyListsSetting(ctx context.Context, shopID string) (bool, error) {
 	stmt := SELECT(Shops.AllColumns).
 		FROM(Shops).
 		WHERE(Shops.ID.EQ(String(shopID)))
 
 	var shop model.Shops
-	err := stmt.Query(repo.db, &shop)
+	err := stmt.QueryContext(ctx, repo.db, &shop)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return false, errors.New("shop not found")
+			return false, shared.ErrShopNotFound
 		}
 		return false, fmt.Errorf("failed to get admin_only_lists setting: %w", err)
 	}
@@ -41,7 +44,7 @@ func (repo *RepositoryImpl) GetShopAdminOnlyListsSetting(shopID string) (bool, e
 }
 
 // UpdateShopAdminOnlyListsSetting updates the admin_only_lists setting for a shop
-func (repo *RepositoryImpl) UpdateShopAdminOnlyListsSetting(shopID string, adminOnlyLists bool) error {
+func (repo *RepositoryImpl) UpdateShopAdminOnlyListsSetting(ctx context.Context, shopID string, adminOnlyLists bool) error {
 	now := time.Now()
 
 	stmt := Shops.UPDATE(
@@ -54,7 +57,7 @@ func (repo *RepositoryImpl) UpdateShopAdminOnlyListsSetting(shopID string, admin
 		Shops.ID.EQ(String(shopID)),
 	)
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to update admin_only_lists setting: %w", err)
 	}
@@ -65,7 +68,7 @@ func (repo *RepositoryImpl) UpdateShopAdminOnlyListsSetting(shopID string, admin
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("shop not found")
+		return shared.ErrShopNotFound
 	}
 
 	slog.Info("Shop admin_only_lists setting updated", "shop_id", shopID, "admin_only_lists", adminOnlyLists)
@@ -73,16 +76,16 @@ func (repo *RepositoryImpl) UpdateShopAdminOnlyListsSetting(shopID string, admin
 }
 
 // GetShopSettings retrieves all settings for a shop
-func (repo *RepositoryImpl) GetShopSettings(shopID string) (*request.ShopSettings, error) {
+func (repo *RepositoryImpl) GetShopSettings(ctx context.Context, shopID string) (*request.ShopSettings, error) {
 	stmt := SELECT(Shops.AllColumns).
 		FROM(Shops).
 		WHERE(Shops.ID.EQ(String(shopID)))
 
 	var shop model.Shops
-	err := stmt.Query(repo.db, &shop)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &shop)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("shop not found")
+			return nil, shared.ErrShopNotFound
 		}
 		return nil, fmt.Errorf("failed to get shop settings: %w", err)
 	}
@@ -95,11 +98,11 @@ func (repo *RepositoryImpl) GetShopSettings(shopID string) (*request.ShopSetting
 }
 
 // UpdateShopSettings updates shop settings with support for partial updates
-func (repo *RepositoryImpl) UpdateShopSettings(shopID string, updates request.UpdateShopSettingsRequest) error {
+func (repo *RepositoryImpl) UpdateShopSettings(ctx context.Context, shopID string, updates request.UpdateShopSettingsRequest) error {
 	now := time.Now()
 
 	if updates.AdminOnlyLists == nil {
-		return errors.New("no settings to update")
+		return apperror.Validation("no_settings_provided", "no settings to update")
 	}
 
 	updateBuilder := Shops.UPDATE(Shops.UpdatedAt)
@@ -115,7 +118,7 @@ func (repo *RepositoryImpl) UpdateShopSettings(shopID string, updates request.Up
 
 	stmt := setClause.WHERE(Shops.ID.EQ(String(shopID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to update shop settings: %w", err)
 	}
@@ -126,7 +129,7 @@ func (repo *RepositoryImpl) UpdateShopSettings(shopID string, updates request.Up
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("shop not found")
+		return shared.ErrShopNotFound
 	}
 
 	slog.Info("Shop settings updated", "shop_id", shopID, "updates", formatSettingsUpdate(updates))
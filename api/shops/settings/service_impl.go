@@ -1,50 +1,55 @@
 package settings
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log/slog"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
 )
 
 type ServiceImpl struct {
-	repo Repository
-	auth shared.ShopAuthorization
+	repo  Repository
+	auth  shared.ShopAuthorization
+	audit audit.Recorder
 }
 
-func NewService(repo Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, auth shared.ShopAuthorization, recorder audit.Recorder) *ServiceImpl {
 	return &ServiceImpl{
-		repo: repo,
-		auth: auth,
+		repo:  repo,
+		auth:  auth,
+		audit: recorder,
 	}
 }
 
 func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
 	return &ServiceImpl{
-		repo: service.repo,
-		auth: auth,
+		repo:  service.repo,
+		auth:  auth,
+		audit: service.audit,
 	}
 }
 
 // GetShopAdminOnlyListsSetting returns the admin_only_lists setting for a shop
 // Any shop member can read this setting
-func (service *ServiceImpl) GetShopAdminOnlyListsSetting(user *bootstrap.User, shopID string) (bool, error) {
+func (service *ServiceImpl) GetShopAdminOnlyListsSetting(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	if user == nil {
-		return false, errors.New("unauthorized user")
+		return false, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return false, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return false, errors.New("access denied: user is not a member of this shop")
+		return false, shared.ErrNotShopMember
 	}
 
-	adminOnlyLists, err := service.repo.GetShopAdminOnlyListsSetting(shopID)
+	adminOnlyLists, err := service.repo.GetShopAdminOnlyListsSetting(ctx, shopID)
 	if err != nil {
 		return false, fmt.Errorf("failed to get admin_only_lists setting: %w", err)
 	}
@@ -55,23 +60,36 @@ func (service *ServiceImpl) GetShopAdminOnlyListsSetting(user *bootstrap.User, s
 
 // UpdateShopAdminOnlyListsSetting updates the admin_only_lists setting for a shop
 // Only shop admins can modify this setting
-func (service *ServiceImpl) UpdateShopAdminOnlyListsSetting(user *bootstrap.User, shopID string, adminOnlyLists bool) error {
+func (service *ServiceImpl) UpdateShopAdminOnlyListsSetting(ctx context.Context, user *bootstrap.User, shopID string, adminOnlyLists bool) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isAdmin {
-		return errors.New("access denied: only shop administrators can modify this setting")
+		return apperror.Forbidden("shop_admin_required", "access denied: only shop administrators can modify this setting")
 	}
 
-	err = service.repo.UpdateShopAdminOnlyListsSetting(shopID, adminOnlyLists)
+	currentSettings, err := service.repo.GetShopSettings(ctx, shopID)
 	if err != nil {
-		return fmt.Errorf("failed to update admin_only_lists setting: %w", err)
+		return fmt.Errorf("failed to get current settings: %w", err)
+	}
+
+	updatedSettings := *currentSettings
+	updatedSettings.AdminOnlyLists = adminOnlyLists
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.UpdateShopAdminOnlyListsSetting(ctx, shopID, adminOnlyLists)
+		if err != nil {
+			return fmt.Errorf("failed to update admin_only_lists setting: %w", err)
+		}
+		return service.recordSettingsUpdate(ctx, user, shopID, currentSettings, &updatedSettings)
+	})
+	if err != nil {
+		return err
 	}
 
 	slog.Info("Shop admin_only_lists setting updated by admin", "user_id", user.UserID, "shop_id", shopID, "admin_only_lists", adminOnlyLists)
@@ -79,12 +97,12 @@ func (service *ServiceImpl) UpdateShopAdminOnlyListsSetting(user *bootstrap.User
 }
 
 // IsUserShopAdmin checks if the authenticated user is an admin for the specified shop
-func (service *ServiceImpl) IsUserShopAdmin(user *bootstrap.User, shopID string) (bool, error) {
+func (service *ServiceImpl) IsUserShopAdmin(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	if user == nil {
-		return false, errors.New("unauthorized user")
+		return false, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return false, fmt.Errorf("failed to verify shop membership: %w", err)
 	}
@@ -93,7 +111,7 @@ func (service *ServiceImpl) IsUserShopAdmin(user *bootstrap.User, shopID string)
 		return false, nil
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return false, fmt.Errorf("failed to verify admin status: %w", err)
 	}
@@ -103,21 +121,21 @@ func (service *ServiceImpl) IsUserShopAdmin(user *bootstrap.User, shopID string)
 
 // GetShopSettings returns all settings for a shop
 // Any shop member can read settings
-func (service *ServiceImpl) GetShopSettings(user *bootstrap.User, shopID string) (*request.ShopSettings, error) {
+func (service *ServiceImpl) GetShopSettings(ctx context.Context, user *bootstrap.User, shopID string) (*request.ShopSettings, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	settings, err := service.repo.GetShopSettings(shopID)
+	settings, err := service.repo.GetShopSettings(ctx, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop settings: %w", err)
 	}
@@ -128,30 +146,55 @@ func (service *ServiceImpl) GetShopSettings(user *bootstrap.User, shopID string)
 
 // UpdateShopSettings updates one or more shop settings (admin only)
 // Supports partial updates - only provided fields are modified
-func (service *ServiceImpl) UpdateShopSettings(user *bootstrap.User, shopID string, updates request.UpdateShopSettingsRequest) (*request.ShopSettings, error) {
+func (service *ServiceImpl) UpdateShopSettings(ctx context.Context, user *bootstrap.User, shopID string, updates request.UpdateShopSettingsRequest) (*request.ShopSettings, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isAdmin, err := service.auth.IsUserShopAdmin(user, shopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isAdmin {
-		return nil, errors.New("access denied: only shop administrators can modify settings")
+		return nil, apperror.Forbidden("shop_admin_required", "access denied: only shop administrators can modify settings")
 	}
 
-	err = service.repo.UpdateShopSettings(shopID, updates)
+	currentSettings, err := service.repo.GetShopSettings(ctx, shopID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update shop settings: %w", err)
+		return nil, fmt.Errorf("failed to get current settings: %w", err)
 	}
 
-	updatedSettings, err := service.repo.GetShopSettings(shopID)
+	var updatedSettings *request.ShopSettings
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.UpdateShopSettings(ctx, shopID, updates)
+		if err != nil {
+			return fmt.Errorf("failed to update shop settings: %w", err)
+		}
+
+		updatedSettings, err = service.repo.GetShopSettings(ctx, shopID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch updated settings: %w", err)
+		}
+
+		return service.recordSettingsUpdate(ctx, user, shopID, currentSettings, updatedSettings)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch updated settings: %w", err)
+		return nil, err
 	}
 
 	slog.Info("Shop settings updated by admin", "user_id", user.UserID, "shop_id", shopID)
 	return updatedSettings, nil
 }
+
+func (service *ServiceImpl) recordSettingsUpdate(ctx context.Context, user *bootstrap.User, shopID string, before, after *request.ShopSettings) error {
+	return service.audit.Record(ctx, audit.Entry{
+		ShopID:     shopID,
+		ActorID:    user.UserID,
+		EntityType: audit.EntityShopSettings,
+		EntityID:   shopID,
+		Action:     audit.ActionUpdate,
+		Before:     before,
+		After:      after,
+	})
+}
@@ -1,27 +1,28 @@
 package shared
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/apperror"
 	"miltechserver/bootstrap"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
 
 type ShopAuthorization interface {
-	IsUserMemberOfShop(user *bootstrap.User, shopID string) (bool, error)
-	IsUserShopAdmin(user *bootstrap.User, shopID string) (bool, error)
-	GetUserRoleInShop(user *bootstrap.User, shopID string) (string, error)
+	IsUserMemberOfShop(ctx context.Context, user *bootstrap.User, shopID string) (bool, error)
+	IsUserShopAdmin(ctx context.Context, user *bootstrap.User, shopID string) (bool, error)
+	GetUserRoleInShop(ctx context.Context, user *bootstrap.User, shopID string) (string, error)
 
-	CanUserModifyVehicle(user *bootstrap.User, vehicleID string) (bool, error)
-	CanUserModifyList(user *bootstrap.User, listID string) (bool, error)
-	CanUserModifyNotification(user *bootstrap.User, notificationID string) (bool, error)
+	CanUserModifyVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) (bool, error)
+	CanUserModifyList(ctx context.Context, user *bootstrap.User, listID string) (bool, error)
+	CanUserModifyNotification(ctx context.Context, user *bootstrap.User, notificationID string) (bool, error)
 
-	RequireShopMember(user *bootstrap.User, shopID string) error
-	RequireShopAdmin(user *bootstrap.User, shopID string) error
+	RequireShopMember(ctx context.Context, user *bootstrap.User, shopID string) error
+	RequireShopAdmin(ctx context.Context, user *bootstrap.User, shopID string) error
 }
 
 type AuthorizationAware interface {
@@ -36,7 +37,7 @@ func NewShopAuthorization(db *sql.DB) *ShopAuthorizationImpl {
 	return &ShopAuthorizationImpl{db: db}
 }
 
-func (auth *ShopAuthorizationImpl) IsUserMemberOfShop(user *bootstrap.User, shopID string) (bool, error) {
+func (auth *ShopAuthorizationImpl) IsUserMemberOfShop(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	stmt := SELECT(Int(1).AS("exists")).
 		FROM(ShopMembers).
 		WHERE(
@@ -48,7 +49,7 @@ func (auth *ShopAuthorizationImpl) IsUserMemberOfShop(user *bootstrap.User, shop
 	var result []struct {
 		Exists int `sql:"exists"`
 	}
-	err := stmt.Query(auth.db, &result)
+	err := stmt.QueryContext(ctx, auth.db, &result)
 	if err != nil {
 		return false, fmt.Errorf("failed to check membership: %w", err)
 	}
@@ -56,7 +57,7 @@ func (auth *ShopAuthorizationImpl) IsUserMemberOfShop(user *bootstrap.User, shop
 	return len(result) > 0, nil
 }
 
-func (auth *ShopAuthorizationImpl) IsUserShopAdmin(user *bootstrap.User, shopID string) (bool, error) {
+func (auth *ShopAuthorizationImpl) IsUserShopAdmin(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	stmt := SELECT(Int(1).AS("exists")).
 		FROM(ShopMembers).
 		WHERE(
@@ -69,7 +70,7 @@ func (auth *ShopAuthorizationImpl) IsUserShopAdmin(user *bootstrap.User, shopID
 	var result []struct {
 		Exists int `sql:"exists"`
 	}
-	err := stmt.Query(auth.db, &result)
+	err := stmt.QueryContext(ctx, auth.db, &result)
 	if err != nil {
 		return false, fmt.Errorf("failed to check admin status: %w", err)
 	}
@@ -77,7 +78,7 @@ func (auth *ShopAuthorizationImpl) IsUserShopAdmin(user *bootstrap.User, shopID
 	return len(result) > 0, nil
 }
 
-func (auth *ShopAuthorizationImpl) GetUserRoleInShop(user *bootstrap.User, shopID string) (string, error) {
+func (auth *ShopAuthorizationImpl) GetUserRoleInShop(ctx context.Context, user *bootstrap.User, shopID string) (string, error) {
 	stmt := SELECT(ShopMembers.Role).
 		FROM(ShopMembers).
 		WHERE(
@@ -86,10 +87,10 @@ func (auth *ShopAuthorizationImpl) GetUserRoleInShop(user *bootstrap.User, shopI
 		)
 
 	var role string
-	err := stmt.Query(auth.db, &role)
+	err := stmt.QueryContext(ctx, auth.db, &role)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return "", errors.New("user is not a member of this shop")
+			return "", apperror.Forbidden("not_shop_member", "user is not a member of this shop")
 		}
 		return "", fmt.Errorf("failed to get user role: %w", err)
 	}
@@ -97,7 +98,7 @@ func (auth *ShopAuthorizationImpl) GetUserRoleInShop(user *bootstrap.User, shopI
 	return role, nil
 }
 
-func (auth *ShopAuthorizationImpl) CanUserModifyVehicle(user *bootstrap.User, vehicleID string) (bool, error) {
+func (auth *ShopAuthorizationImpl) CanUserModifyVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) (bool, error) {
 	stmt := SELECT(
 		ShopVehicle.ID,
 		ShopVehicle.ShopID,
@@ -106,7 +107,7 @@ func (auth *ShopAuthorizationImpl) CanUserModifyVehicle(user *bootstrap.User, ve
 		WHERE(ShopVehicle.ID.EQ(String(vehicleID)))
 
 	var vehicle model.ShopVehicle
-	err := stmt.Query(auth.db, &vehicle)
+	err := stmt.QueryContext(ctx, auth.db, &vehicle)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, ErrVehicleNotFound
@@ -119,10 +120,10 @@ func (auth *ShopAuthorizationImpl) CanUserModifyVehicle(user *bootstrap.User, ve
 		return true, nil
 	}
 
-	return auth.IsUserShopAdmin(user, vehicle.ShopID)
+	return auth.IsUserShopAdmin(ctx, user, vehicle.ShopID)
 }
 
-func (auth *ShopAuthorizationImpl) CanUserModifyList(user *bootstrap.User, listID string) (bool, error) {
+func (auth *ShopAuthorizationImpl) CanUserModifyList(ctx context.Context, user *bootstrap.User, listID string) (bool, error) {
 	stmt := SELECT(
 		ShopLists.ID,
 		ShopLists.ShopID,
@@ -130,7 +131,7 @@ func (auth *ShopAuthorizationImpl) CanUserModifyList(user *bootstrap.User, listI
 		WHERE(ShopLists.ID.EQ(String(listID)))
 
 	var list model.ShopLists
-	err := stmt.Query(auth.db, &list)
+	err := stmt.QueryContext(ctx, auth.db, &list)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, ErrListNotFound
@@ -138,7 +139,7 @@ func (auth *ShopAuthorizationImpl) CanUserModifyList(user *bootstrap.User, listI
 		return false, fmt.Errorf("failed to get list: %w", err)
 	}
 
-	adminOnlyLists, err := auth.getShopAdminOnlyListsSetting(list.ShopID)
+	adminOnlyLists, err := auth.getShopAdminOnlyListsSetting(ctx, list.ShopID)
 	if err != nil {
 		return false, err
 	}
@@ -147,10 +148,10 @@ func (auth *ShopAuthorizationImpl) CanUserModifyList(user *bootstrap.User, listI
 		return true, nil
 	}
 
-	return auth.IsUserShopAdmin(user, list.ShopID)
+	return auth.IsUserShopAdmin(ctx, user, list.ShopID)
 }
 
-func (auth *ShopAuthorizationImpl) CanUserModifyNotification(user *bootstrap.User, notificationID string) (bool, error) {
+func (auth *ShopAuthorizationImpl) CanUserModifyNotification(ctx context.Context, user *bootstrap.User, notificationID string) (bool, error) {
 	stmt := SELECT(
 		ShopVehicleNotifications.ID,
 		ShopVehicleNotifications.ShopID,
@@ -158,7 +159,7 @@ func (auth *ShopAuthorizationImpl) CanUserModifyNotification(user *bootstrap.Use
 		WHERE(ShopVehicleNotifications.ID.EQ(String(notificationID)))
 
 	var notification model.ShopVehicleNotifications
-	err := stmt.Query(auth.db, &notification)
+	err := stmt.QueryContext(ctx, auth.db, &notification)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, ErrNotificationNotFound
@@ -166,11 +167,11 @@ func (auth *ShopAuthorizationImpl) CanUserModifyNotification(user *bootstrap.Use
 		return false, fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	return auth.IsUserMemberOfShop(user, notification.ShopID)
+	return auth.IsUserMemberOfShop(ctx, user, notification.ShopID)
 }
 
-func (auth *ShopAuthorizationImpl) RequireShopMember(user *bootstrap.User, shopID string) error {
-	isMember, err := auth.IsUserMemberOfShop(user, shopID)
+func (auth *ShopAuthorizationImpl) RequireShopMember(ctx context.Context, user *bootstrap.User, shopID string) error {
+	isMember, err := auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return err
 	}
@@ -180,8 +181,8 @@ func (auth *ShopAuthorizationImpl) RequireShopMember(user *bootstrap.User, shopI
 	return nil
 }
 
-func (auth *ShopAuthorizationImpl) RequireShopAdmin(user *bootstrap.User, shopID string) error {
-	isAdmin, err := auth.IsUserShopAdmin(user, shopID)
+func (auth *ShopAuthorizationImpl) RequireShopAdmin(ctx context.Context, user *bootstrap.User, shopID string) error {
+	isAdmin, err := auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return err
 	}
@@ -191,7 +192,7 @@ func (auth *ShopAuthorizationImpl) RequireShopAdmin(user *bootstrap.User, shopID
 	return nil
 }
 
-func (auth *ShopAuthorizationImpl) getShopAdminOnlyListsSetting(shopID string) (bool, error) {
+func (auth *ShopAuthorizationImpl) getShopAdminOnlyListsSetting(ctx context.Context, shopID string) (bool, error) {
 	stmt := SELECT(Shops.AdminOnlyLists).
 		FROM(Shops).
 		WHERE(Shops.ID.EQ(String(shopID)))
@@ -199,7 +200,7 @@ func (auth *ShopAuthorizationImpl) getShopAdminOnlyListsSetting(shopID string) (
 	var result struct {
 		AdminOnlyLists bool
 	}
-	err := stmt.Query(auth.db, &result)
+	err := stmt.QueryContext(ctx, auth.db, &result)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, ErrShopNotFound
@@ -1,27 +1,31 @@
 package shared
 
 import (
-	"fmt"
-	"sync"
+	"context"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"miltechserver/bootstrap"
+	"miltechserver/cache"
 )
 
 const cachedAuthorizationKey = "cached_authorization"
 
+// CachedAuthorization memoises a ShopAuthorization for one request, so a
+// handler and the services it calls check each permission once. Errors are
+// not cached.
 type CachedAuthorization struct {
-	inner       ShopAuthorization
-	mu          sync.RWMutex
-	boolCache   map[string]bool
-	stringCache map[string]string
+	inner  ShopAuthorization
+	checks *cache.Cache[string, bool]
+	roles  *cache.Cache[string, string]
 }
 
 func NewCachedAuthorization(inner ShopAuthorization) *CachedAuthorization {
+	// Request scoped, so neither bounded nor expiring
 	return &CachedAuthorization{
-		inner:       inner,
-		boolCache:   make(map[string]bool),
-		stringCache: make(map[string]string),
+		inner:  inner,
+		checks: cache.New[string, bool](cache.Options{Name: "shop_authorization"}),
+		roles:  cache.New[string, string](cache.Options{Name: "shop_authorization"}),
 	}
 }
 
@@ -36,135 +40,47 @@ func CachedAuthorizationFromContext(c *gin.Context, factory func() ShopAuthoriza
 }
 
 func (auth *CachedAuthorization) cacheKey(operation string, parts ...string) string {
-	if len(parts) == 0 {
-		return operation
-	}
-
-	key := operation
-	for _, part := range parts {
-		key = fmt.Sprintf("%s:%s", key, part)
-	}
-	return key
+	return strings.Join(append([]string{operation}, parts...), ":")
 }
 
-func (auth *CachedAuthorization) getBool(key string) (bool, bool) {
-	auth.mu.RLock()
-	defer auth.mu.RUnlock()
-	val, ok := auth.boolCache[key]
-	return val, ok
+func (auth *CachedAuthorization) IsUserMemberOfShop(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
+	return auth.checks.GetOrLoad(ctx, auth.cacheKey("member", shopID, user.UserID), func(ctx context.Context) (bool, error) {
+		return auth.inner.IsUserMemberOfShop(ctx, user, shopID)
+	})
 }
 
-func (auth *CachedAuthorization) setBool(key string, val bool) {
-	auth.mu.Lock()
-	defer auth.mu.Unlock()
-	auth.boolCache[key] = val
+func (auth *CachedAuthorization) IsUserShopAdmin(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
+	return auth.checks.GetOrLoad(ctx, auth.cacheKey("admin", shopID, user.UserID), func(ctx context.Context) (bool, error) {
+		return auth.inner.IsUserShopAdmin(ctx, user, shopID)
+	})
 }
 
-func (auth *CachedAuthorization) getString(key string) (string, bool) {
-	auth.mu.RLock()
-	defer auth.mu.RUnlock()
-	val, ok := auth.stringCache[key]
-	return val, ok
+func (auth *CachedAuthorization) GetUserRoleInShop(ctx context.Context, user *bootstrap.User, shopID string) (string, error) {
+	return auth.roles.GetOrLoad(ctx, auth.cacheKey("role", shopID, user.UserID), func(ctx context.Context) (string, error) {
+		return auth.inner.GetUserRoleInShop(ctx, user, shopID)
+	})
 }
 
-func (auth *CachedAuthorization) setString(key string, val string) {
-	auth.mu.Lock()
-	defer auth.mu.Unlock()
-	auth.stringCache[key] = val
+func (auth *CachedAuthorization) CanUserModifyVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) (bool, error) {
+	return auth.checks.GetOrLoad(ctx, auth.cacheKey("modify_vehicle", vehicleID, user.UserID), func(ctx context.Context) (bool, error) {
+		return auth.inner.CanUserModifyVehicle(ctx, user, vehicleID)
+	})
 }
 
-func (auth *CachedAuthorization) IsUserMemberOfShop(user *bootstrap.User, shopID string) (bool, error) {
-	key := auth.cacheKey("member", shopID, user.UserID)
-	if cached, ok := auth.getBool(key); ok {
-		return cached, nil
-	}
-
-	val, err := auth.inner.IsUserMemberOfShop(user, shopID)
-	if err != nil {
-		return false, err
-	}
-
-	auth.setBool(key, val)
-	return val, nil
+func (auth *CachedAuthorization) CanUserModifyList(ctx context.Context, user *bootstrap.User, listID string) (bool, error) {
+	return auth.checks.GetOrLoad(ctx, auth.cacheKey("modify_list", listID, user.UserID), func(ctx context.Context) (bool, error) {
+		return auth.inner.CanUserModifyList(ctx, user, listID)
+	})
 }
 
-func (auth *CachedAuthorization) IsUserShopAdmin(user *bootstrap.User, shopID string) (bool, error) {
-	key := auth.cacheKey("admin", shopID, user.UserID)
-	if cached, ok := auth.getBool(key); ok {
-		return cached, nil
-	}
-
-	val, err := auth.inner.IsUserShopAdmin(user, shopID)
-	if err != nil {
-		return false, err
-	}
-
-	auth.setBool(key, val)
-	return val, nil
-}
-
-func (auth *CachedAuthorization) GetUserRoleInShop(user *bootstrap.User, shopID string) (string, error) {
-	key := auth.cacheKey("role", shopID, user.UserID)
-	if cached, ok := auth.getString(key); ok {
-		return cached, nil
-	}
-
-	val, err := auth.inner.GetUserRoleInShop(user, shopID)
-	if err != nil {
-		return "", err
-	}
-
-	auth.setString(key, val)
-	return val, nil
-}
-
-func (auth *CachedAuthorization) CanUserModifyVehicle(user *bootstrap.User, vehicleID string) (bool, error) {
-	key := auth.cacheKey("modify_vehicle", vehicleID, user.UserID)
-	if cached, ok := auth.getBool(key); ok {
-		return cached, nil
-	}
-
-	val, err := auth.inner.CanUserModifyVehicle(user, vehicleID)
-	if err != nil {
-		return false, err
-	}
-
-	auth.setBool(key, val)
-	return val, nil
-}
-
-func (auth *CachedAuthorization) CanUserModifyList(user *bootstrap.User, listID string) (bool, error) {
-	key := auth.cacheKey("modify_list", listID, user.UserID)
-	if cached, ok := auth.getBool(key); ok {
-		return cached, nil
-	}
-
-	val, err := auth.inner.CanUserModifyList(user, listID)
-	if err != nil {
-		return false, err
-	}
-
-	auth.setBool(key, val)
-	return val, nil
-}
-
-func (auth *CachedAuthorization) CanUserModifyNotification(user *bootstrap.User, notificationID string) (bool, error) {
-	key := auth.cacheKey("modify_notification", notificationID, user.UserID)
-	if cached, ok := auth.getBool(key); ok {
-		return cached, nil
-	}
-
-	val, err := auth.inner.CanUserModifyNotification(user, notificationID)
-	if err != nil {
-		return false, err
-	}
-
-	auth.setBool(key, val)
-	return val, nil
+func (auth *CachedAuthorization) CanUserModifyNotification(ctx context.Context, user *bootstrap.User, notificationID string) (bool, error) {
+	return auth.checks.GetOrLoad(ctx, auth.cacheKey("modify_notification", notificationID, user.UserID), func(ctx context.Context) (bool, error) {
+		return auth.inner.CanUserModifyNotification(ctx, user, notificationID)
+	})
 }
 
-func (auth *CachedAuthorization) RequireShopMember(user *bootstrap.User, shopID string) error {
-	isMember, err := auth.IsUserMemberOfShop(user, shopID)
+func (auth *CachedAuthorization) RequireShopMember(ctx context.Context, user *bootstrap.User, shopID string) error {
+	isMember, err := auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return err
 	}
@@ -174,8 +90,8 @@ func (auth *CachedAuthorization) RequireShopMember(user *bootstrap.User, shopID
 	return nil
 }
 
-func (auth *CachedAuthorization) RequireShopAdmin(user *bootstrap.User, shopID string) error {
-	isAdmin, err := auth.IsUserShopAdmin(user, shopID)
+func (auth *CachedAuthorization) RequireShopAdmin(ctx context.Context, user *bootstrap.User, shopID string) error {
+	isAdmin, err := auth.IsUserShopAdmin(ctx, user, shopID)
 	if err != nil {
 		return err
 	}
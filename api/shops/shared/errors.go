@@ -1,38 +1,51 @@
 package shared
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrShopNotFound      = errors.New("shop not found")
-	ErrShopAccessDenied  = errors.New("access denied: not a member of this shop")
-	ErrShopAdminRequired = errors.New("access denied: admin privileges required")
-	ErrShopCreatorOnly   = errors.New("access denied: only shop creator can perform this action")
+	ErrUnauthorizedUser    = apperror.Unauthorized("unauthorized", "unauthorized user")
+	ErrNotShopMember       = apperror.Forbidden("not_shop_member", "access denied: user is not a member of this shop")
+	ErrTargetNotShopMember = apperror.NotFound("target_not_shop_member", "target user is not a member of this shop")
 )
 
 var (
-	ErrMemberNotFound      = errors.New("member not found")
-	ErrAlreadyMember       = errors.New("user is already a member of this shop")
-	ErrCannotRemoveSelf    = errors.New("cannot remove yourself from shop")
-	ErrCannotRemoveCreator = errors.New("cannot remove shop creator")
+	ErrShopNotFound      = apperror.NotFound("shop_not_found", "shop not found")
+	ErrShopAccessDenied  = apperror.Forbidden("shop_access_denied", "access denied: not a member of this shop")
+	ErrShopAdminRequired = apperror.Forbidden("shop_admin_required", "access denied: admin privileges required")
+	ErrShopCreatorOnly   = apperror.Forbidden("shop_creator_only", "access denied: only shop creator can perform this action")
 )
 
 var (
-	ErrInviteCodeInvalid = errors.New("invalid invite code")
-	ErrInviteCodeExpired = errors.New("invite code has expired")
-	ErrInviteCodeUsed    = errors.New("invite code has already been used")
+	ErrMemberNotFound      = apperror.NotFound("member_not_found", "member not found")
+	ErrAlreadyMember       = apperror.Conflict("already_member", "user is already a member of this shop")
+	ErrCannotRemoveSelf    = apperror.Conflict("cannot_remove_self", "cannot remove yourself from shop")
+	ErrCannotRemoveCreator = apperror.Conflict("cannot_remove_creator", "cannot remove shop creator")
+	ErrMemberNotInShop     = apperror.NotFound("member_not_in_shop", "member not found in shop")
 )
 
 var (
-	ErrVehicleNotFound     = errors.New("vehicle not found")
-	ErrVehicleAccessDenied = errors.New("access denied to vehicle")
+	ErrInviteCodeInvalid  = apperror.NotFound("invite_code_invalid", "invalid invite code")
+	ErrInviteCodeExpired  = apperror.Conflict("invite_code_expired", "invite code has expired")
+	ErrInviteCodeUsed     = apperror.Conflict("invite_code_used", "invite code has already been used")
+	ErrInviteCodeNotFound = apperror.NotFound("invite_code_not_found", "invite code not found")
+	ErrInviteCodeInactive = apperror.Conflict("invite_code_inactive", "invite code is inactive")
 )
 
 var (
-	ErrListNotFound    = errors.New("list not found")
-	ErrListAccessDenied = errors.New("access denied to list")
-	ErrAdminOnlyLists  = errors.New("only admins can create lists in this shop")
+	ErrVehicleNotFound     = apperror.NotFound("vehicle_not_found", "vehicle not found")
+	ErrVehicleAccessDenied = apperror.Forbidden("vehicle_access_denied", "access denied to vehicle")
 )
 
 var (
-	ErrNotificationNotFound = errors.New("notification not found")
+	ErrListNotFound          = apperror.NotFound("list_not_found", "list not found")
+	ErrListAccessDenied      = apperror.Forbidden("list_access_denied", "access denied to list")
+	ErrAdminOnlyLists        = apperror.Forbidden("admin_only_lists", "only admins can create lists in this shop")
+	ErrShopListNotFound      = apperror.NotFound("shop_list_not_found", "shop list not found")
+	ErrListItemNotFound      = apperror.NotFound("list_item_not_found", "list item not found")
+	ErrListItemsAccessDenied = apperror.Forbidden("list_items_access_denied", "access denied: insufficient permissions to modify list items")
+)
+
+var (
+	ErrNotificationNotFound    = apperror.NotFound("notification_not_found", "notification not found")
+	ErrInvalidAttachedShopList = apperror.Validation("invalid_attached_shop_list", "invalid attached_shop_list")
 )
@@ -0,0 +1,27 @@
+package stream
+
+import (
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Stream",
+	Operations: []openapi.Operation{
+		{
+			ID:      "streamShop",
+			Method:  http.MethodGet,
+			Path:    "/shops/:shop_id/stream",
+			Auth:    true,
+			Summary: "Pushes message, vehicle notification, list item and service completion events from the shop as they happen, over a WebSocket when the request is an upgrade and as Server-Sent Events otherwise",
+			Query: []openapi.Param{{
+				Name:        "after",
+				Type:        "integer",
+				Description: "Resume after this event id, replaying what was missed; SSE clients may send Last-Event-ID instead",
+			}},
+			Raw:         true,
+			ContentType: "text/event-stream",
+		},
+	},
+}}
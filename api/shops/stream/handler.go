@@ -0,0 +1,64 @@
+package stream
+
+import (
+	"log/slog"
+	"miltechserver/api/apperror"
+	"miltechserver/bootstrap"
+	"miltechserver/realtime"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+// StreamShop pushes the shop's events to the caller over a WebSocket, or as
+// Server-Sent Events when the request is not an upgrade
+func (handler *Handler) StreamShop(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	if shopID == "" {
+		c.Error(apperror.MissingParameter("shop_id"))
+		return
+	}
+
+	// Browsers resend the last SSE event ID in Last-Event-ID; WebSocket
+	// clients pass it as ?after=
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if after := c.Query("after"); after != "" {
+		lastEventID = after
+	}
+	var after int64
+	if lastEventID != "" {
+		parsed, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || parsed < 0 {
+			c.Error(apperror.InvalidParameter("after", "after must be a stream event id"))
+			return
+		}
+		after = parsed
+	}
+
+	service := handler.service
+	sub, backlog, err := service.OpenStream(c.Request.Context(), user, shopID, after)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer sub.Close()
+
+	if realtime.IsWebSocket(c.Request) {
+		realtime.ServeWebSocket(c.Writer, c.Request, sub, backlog)
+		return
+	}
+	realtime.ServeSSE(c.Writer, c.Request, sub, backlog)
+}
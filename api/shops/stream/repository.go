@@ -0,0 +1,15 @@
+package stream
+
+import (
+	"context"
+	"miltechserver/realtime"
+)
+
+type Repository interface {
+	// GetFramesAfter returns up to limit of the shop's streamed events with
+	// an ID after afterID, oldest first.
+	GetFramesAfter(ctx context.Context, shopID string, afterID int64, limit int) ([]realtime.Frame, error)
+	// GetOldestEventID returns the ID of the oldest event the outbox still
+	// holds, or 0 if it holds none.
+	GetOldestEventID(ctx context.Context) (int64, error)
+}
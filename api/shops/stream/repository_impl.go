@@ -0,0 +1,56 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"miltechserver/realtime"
+
+	"github.com/lib/pq"
+)
+
+type RepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *RepositoryImpl {
+	return &RepositoryImpl{db: db}
+}
+
+func (repo *RepositoryImpl) GetFramesAfter(ctx context.Context, shopID string, afterID int64, limit int) ([]realtime.Frame, error) {
+	rows, err := repo.db.QueryContext(ctx, `
+		SELECT id, event_type, shop_id, payload, occurred_at
+		FROM outbox_events
+		WHERE shop_id = $1 AND id > $2 AND event_type = ANY($3)
+		ORDER BY id
+		LIMIT $4`,
+		shopID, afterID, pq.Array(realtime.Types), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stream events: %w", err)
+	}
+	defer rows.Close()
+
+	frames := []realtime.Frame{}
+	for rows.Next() {
+		var frame realtime.Frame
+		var payload []byte
+		if err := rows.Scan(&frame.ID, &frame.Type, &frame.ShopID, &payload, &frame.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan stream event: %w", err)
+		}
+		frame.Data = payload
+		frames = append(frames, frame)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get stream events: %w", err)
+	}
+	return frames, nil
+}
+
+func (repo *RepositoryImpl) GetOldestEventID(ctx context.Context) (int64, error) {
+	var oldest sql.NullInt64
+	err := repo.db.QueryRowContext(ctx, `SELECT min(id) FROM outbox_events`).Scan(&oldest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get oldest outbox event: %w", err)
+	}
+	return oldest.Int64, nil
+}
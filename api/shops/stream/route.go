@@ -0,0 +1,14 @@
+package stream
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Path is the stream route, relative to the group it is registered on. It is
+// exempt from the request deadline.
+const Path = "/shops/:shop_id/stream"
+
+func RegisterRoutes(router *gin.RouterGroup, service Service) {
+	handler := Handler{service: service}
+	router.GET(Path, handler.StreamShop)
+}
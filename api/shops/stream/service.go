@@ -0,0 +1,14 @@
+package stream
+
+import (
+	"context"
+	"miltechserver/bootstrap"
+	"miltechserver/realtime"
+)
+
+type Service interface {
+	// OpenStream subscribes a member to the shop's stream. A client resuming
+	// after lastEventID also gets the frames it missed, or a resync frame
+	// when they can no longer all be replayed.
+	OpenStream(ctx context.Context, user *bootstrap.User, shopID string, lastEventID int64) (*realtime.Subscription, []realtime.Frame, error)
+}
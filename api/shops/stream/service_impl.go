@@ -0,0 +1,79 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"miltechserver/api/shops/shared"
+	"miltechserver/bootstrap"
+	"miltechserver/realtime"
+)
+
+// maxReplay is how many missed frames a resuming client is sent. One that
+// missed more is told to resync instead.
+const maxReplay = 500
+
+type ServiceImpl struct {
+	repo Repository
+	auth shared.ShopAuthorization
+	hub  *realtime.Hub
+}
+
+func NewService(repo Repository, auth shared.ShopAuthorization, hub *realtime.Hub) *ServiceImpl {
+	return &ServiceImpl{repo: repo, auth: auth, hub: hub}
+}
+
+func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
+	return &ServiceImpl{repo: service.repo, auth: auth, hub: service.hub}
+}
+
+func (service *ServiceImpl) OpenStream(ctx context.Context, user *bootstrap.User, shopID string, lastEventID int64) (*realtime.Subscription, []realtime.Frame, error) {
+	if user == nil {
+		return nil, nil, shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopMember(ctx, user, shopID); err != nil {
+		return nil, nil, err
+	}
+
+	// Subscribed before reading the backlog, so an event committed in
+	// between arrives live if it is not in the backlog
+	sub, err := service.hub.Subscribe(shopID, user.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to subscribe to shop stream: %w", err)
+	}
+	if lastEventID <= 0 {
+		slog.Info("Shop stream opened", "user_id", user.UserID, "shop_id", shopID)
+		return sub, nil, nil
+	}
+
+	backlog, err := service.backlog(ctx, shopID, lastEventID)
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+	slog.Info("Shop stream resumed", "user_id", user.UserID, "shop_id", shopID, "last_event_id", lastEventID, "replayed", len(backlog))
+	return sub, backlog, nil
+}
+
+// backlog returns the frames after lastEventID, or a resync frame if some
+// have been pruned from the outbox or there are too many to replay.
+func (service *ServiceImpl) backlog(ctx context.Context, shopID string, lastEventID int64) ([]realtime.Frame, error) {
+	oldest, err := service.repo.GetOldestEventID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if oldest == 0 || lastEventID < oldest-1 {
+		return []realtime.Frame{realtime.ResyncFrame(shopID)}, nil
+	}
+
+	frames, err := service.repo.GetFramesAfter(ctx, shopID, lastEventID, maxReplay+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(frames) > maxReplay {
+		return []realtime.Frame{realtime.ResyncFrame(shopID)}, nil
+	}
+	return frames, nil
+}
+
+var _ Service = (*ServiceImpl)(nil)
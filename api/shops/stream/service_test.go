@@ -0,0 +1,99 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"miltechserver/api/shops/shared"
+	"miltechserver/bootstrap"
+	"miltechserver/events"
+	"miltechserver/realtime"
+
+	"github.com/stretchr/testify/require"
+)
+
+type repositoryStub struct {
+	oldest int64
+	frames []realtime.Frame
+}
+
+func (stub *repositoryStub) GetFramesAfter(_ context.Context, shopID string, afterID int64, limit int) ([]realtime.Frame, error) {
+	frames := []realtime.Frame{}
+	for _, frame := range stub.frames {
+		if frame.ShopID == shopID && frame.ID > afterID && len(frames) < limit {
+			frames = append(frames, frame)
+		}
+	}
+	return frames, nil
+}
+
+func (stub *repositoryStub) GetOldestEventID(context.Context) (int64, error) {
+	return stub.oldest, nil
+}
+
+// memberAuthorization makes user-member a member of every shop.
+type memberAuthorization struct {
+	shared.ShopAuthorization
+}
+
+func (memberAuthorization) RequireShopMember(_ context.Context, user *bootstrap.User, _ string) error {
+	if user.UserID != "user-member" {
+		return shared.ErrShopAccessDenied
+	}
+	return nil
+}
+
+func TestOpenStreamRequiresMembership(t *testing.T) {
+	hub := realtime.NewHub()
+	service := NewService(&repositoryStub{}, memberAuthorization{}, hub)
+
+	_, _, err := service.OpenStream(context.Background(), nil, "shop-1", 0)
+	require.ErrorIs(t, err, shared.ErrUnauthorizedUser)
+
+	_, _, err = service.OpenStream(context.Background(), &bootstrap.User{UserID: "user-outsider"}, "shop-1", 0)
+	require.ErrorIs(t, err, shared.ErrShopAccessDenied)
+
+	sub, backlog, err := service.OpenStream(context.Background(), &bootstrap.User{UserID: "user-member"}, "shop-1", 0)
+	require.NoError(t, err)
+	defer sub.Close()
+	require.Empty(t, backlog)
+
+	hub.Publish(realtime.Frame{ID: 1, Type: events.TypeMessageCreated, ShopID: "shop-1"})
+	require.Equal(t, int64(1), (<-sub.Frames()).ID)
+}
+
+func TestOpenStreamReplaysMissedFrames(t *testing.T) {
+	repo := &repositoryStub{oldest: 10}
+	for id := int64(10); id < 20; id++ {
+		repo.frames = append(repo.frames, realtime.Frame{ID: id, Type: events.TypeListItemsChanged, ShopID: "shop-1"})
+	}
+	service := NewService(repo, memberAuthorization{}, realtime.NewHub())
+	member := &bootstrap.User{UserID: "user-member"}
+
+	sub, backlog, err := service.OpenStream(context.Background(), member, "shop-1", 16)
+	require.NoError(t, err)
+	sub.Close()
+	require.Len(t, backlog, 3)
+	require.Equal(t, int64(17), backlog[0].ID)
+
+	// Events after 5 have been pruned
+	sub, backlog, err = service.OpenStream(context.Background(), member, "shop-1", 5)
+	require.NoError(t, err)
+	sub.Close()
+	require.Len(t, backlog, 1)
+	require.Equal(t, realtime.TypeResync, backlog[0].Type)
+}
+
+func TestOpenStreamResyncsWhenTooFarBehind(t *testing.T) {
+	repo := &repositoryStub{oldest: 1}
+	for id := int64(1); id <= maxReplay+2; id++ {
+		repo.frames = append(repo.frames, realtime.Frame{ID: id, Type: events.TypeMessageCreated, ShopID: "shop-1"})
+	}
+	service := NewService(repo, memberAuthorization{}, realtime.NewHub())
+
+	sub, backlog, err := service.OpenStream(context.Background(), &bootstrap.User{UserID: "user-member"}, "shop-1", 1)
+	require.NoError(t, err)
+	sub.Close()
+	require.Len(t, backlog, 1)
+	require.Equal(t, realtime.TypeResync, backlog[0].Type)
+}
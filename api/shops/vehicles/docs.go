@@ -0,0 +1,60 @@
+package vehicles
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Vehicles",
+	Operations: []openapi.Operation{
+		{
+			ID:       "createShopVehicle",
+			Method:   http.MethodPost,
+			Path:     "/shops/vehicles",
+			Auth:     true,
+			Summary:  "Creates a new vehicle for a shop",
+			Request:  request.CreateShopVehicleRequest{},
+			Status:   http.StatusCreated,
+			Response: model.ShopVehicle{},
+		},
+		{
+			ID:       "getShopVehicles",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/vehicles",
+			Auth:     true,
+			Summary:  "Returns all vehicles for a shop",
+			Response: []model.ShopVehicle{},
+		},
+		{
+			ID:       "getShopVehicleByID",
+			Method:   http.MethodGet,
+			Path:     "/shops/vehicles/:vehicle_id",
+			Auth:     true,
+			Summary:  "Returns a specific vehicle by ID",
+			Response: model.ShopVehicle{},
+		},
+		{
+			ID:       "updateShopVehicle",
+			Method:   http.MethodPut,
+			Path:     "/shops/vehicles",
+			Auth:     true,
+			Summary:  "Updates an existing shop vehicle",
+			Request:  request.UpdateShopVehicleRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "deleteShopVehicle",
+			Method:   http.MethodDelete,
+			Path:     "/shops/vehicles/:vehicle_id",
+			Auth:     true,
+			Summary:  "Deletes a shop vehicle",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+	},
+}}
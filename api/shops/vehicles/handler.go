@@ -3,6 +3,7 @@ package vehicles
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -22,15 +23,15 @@ func (handler *Handler) CreateShopVehicle(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.CreateShopVehicleRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -47,7 +48,7 @@ func (handler *Handler) CreateShopVehicle(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdVehicle, err := service.CreateShopVehicle(user, vehicle)
+	createdVehicle, err := service.CreateShopVehicle(c.Request.Context(), user, vehicle)
 	if err != nil {
 		c.Error(err)
 		return
@@ -66,19 +67,19 @@ func (handler *Handler) GetShopVehicles(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	vehicles, err := service.GetShopVehicles(user, shopID)
+	vehicles, err := service.GetShopVehicles(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -97,19 +98,19 @@ func (handler *Handler) GetShopVehicleByID(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicle_id")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle_id is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
 	service := handler.service
-	vehicle, err := service.GetShopVehicleByID(user, vehicleID)
+	vehicle, err := service.GetShopVehicleByID(c.Request.Context(), user, vehicleID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -128,15 +129,15 @@ func (handler *Handler) UpdateShopVehicle(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.UpdateShopVehicleRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -155,7 +156,7 @@ func (handler *Handler) UpdateShopVehicle(c *gin.Context) {
 	}
 
 	service := handler.service
-	err := service.UpdateShopVehicle(user, vehicle)
+	err := service.UpdateShopVehicle(c.Request.Context(), user, vehicle)
 	if err != nil {
 		c.Error(err)
 		return
@@ -170,19 +171,19 @@ func (handler *Handler) DeleteShopVehicle(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicle_id")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle_id is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.DeleteShopVehicle(user, vehicleID)
+	err := service.DeleteShopVehicle(c.Request.Context(), user, vehicleID)
 	if err != nil {
 		c.Error(err)
 		return
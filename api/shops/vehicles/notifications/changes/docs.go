@@ -0,0 +1,39 @@
+package changes
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Vehicle Notifications",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getNotificationChangeHistory",
+			Method:   http.MethodGet,
+			Path:     "/shops/notifications/:notification_id/changes",
+			Auth:     true,
+			Summary:  "Returns the complete change history for a notification",
+			Response: []response.NotificationChangeWithUsername{},
+		},
+		{
+			ID:       "getShopNotificationChanges",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/notifications/changes",
+			Auth:     true,
+			Summary:  "Returns recent notification changes for all notifications in a shop",
+			Query:    []openapi.Param{{Name: "limit"}},
+			Response: []response.NotificationChangeWithUsername{},
+		},
+		{
+			ID:       "getVehicleNotificationChanges",
+			Method:   http.MethodGet,
+			Path:     "/shops/vehicles/:vehicle_id/notifications/changes",
+			Auth:     true,
+			Summary:  "Returns all notification changes for a specific vehicle",
+			Response: []response.NotificationChangeWithUsername{},
+		},
+	},
+}}
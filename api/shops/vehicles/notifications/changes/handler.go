@@ -3,6 +3,7 @@ package changes
 import (
 	"fmt"
 	"log/slog"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
 
@@ -21,19 +22,19 @@ func (handler *Handler) GetNotificationChangeHistory(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	notificationID := c.Param("notification_id")
 	if notificationID == "" {
-		c.JSON(400, gin.H{"message": "notification_id is required"})
+		c.Error(apperror.MissingParameter("notification_id"))
 		return
 	}
 
 	service := handler.service
-	changes, err := service.GetNotificationChangeHistory(user, notificationID)
+	changes, err := service.GetNotificationChangeHistory(c.Request.Context(), user, notificationID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -52,14 +53,14 @@ func (handler *Handler) GetShopNotificationChanges(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
@@ -73,7 +74,7 @@ func (handler *Handler) GetShopNotificationChanges(c *gin.Context) {
 	}
 
 	service := handler.service
-	changes, err := service.GetShopNotificationChanges(user, shopID, limit)
+	changes, err := service.GetShopNotificationChanges(c.Request.Context(), user, shopID, limit)
 	if err != nil {
 		c.Error(err)
 		return
@@ -92,19 +93,19 @@ func (handler *Handler) GetVehicleNotificationChanges(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicle_id")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle_id is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
 	service := handler.service
-	changes, err := service.GetVehicleNotificationChanges(user, vehicleID)
+	changes, err := service.GetVehicleNotificationChanges(c.Request.Context(), user, vehicleID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -1,9 +1,10 @@
 package changes
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
 )
 
@@ -15,29 +16,29 @@ func NewService(repo Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo}
 }
 
-func (service *ServiceImpl) GetNotificationChangeHistory(
+func (service *ServiceImpl) GetNotificationChangeHistory(ctx context.Context,
 	user *bootstrap.User,
 	notificationID string,
 ) ([]response.NotificationChangeWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	notification, err := service.repo.GetVehicleNotificationByID(user, notificationID)
+	notification, err := service.repo.GetVehicleNotificationByID(ctx, user, notificationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, notification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, notification.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	changes, err := service.repo.GetNotificationChanges(user, notificationID)
+	changes, err := service.repo.GetNotificationChanges(ctx, user, notificationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification changes: %w", err)
 	}
@@ -45,25 +46,25 @@ func (service *ServiceImpl) GetNotificationChangeHistory(
 	return changes, nil
 }
 
-func (service *ServiceImpl) GetShopNotificationChanges(
+func (service *ServiceImpl) GetShopNotificationChanges(ctx context.Context,
 	user *bootstrap.User,
 	shopID string,
 	limit int,
 ) ([]response.NotificationChangeWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	changes, err := service.repo.GetNotificationChangesByShop(user, shopID, limit)
+	changes, err := service.repo.GetNotificationChangesByShop(ctx, user, shopID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop notification changes: %w", err)
 	}
@@ -71,29 +72,29 @@ func (service *ServiceImpl) GetShopNotificationChanges(
 	return changes, nil
 }
 
-func (service *ServiceImpl) GetVehicleNotificationChanges(
+func (service *ServiceImpl) GetVehicleNotificationChanges(ctx context.Context,
 	user *bootstrap.User,
 	vehicleID string,
 ) ([]response.NotificationChangeWithUsername, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, vehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, vehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, vehicle.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, vehicle.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	changes, err := service.repo.GetNotificationChangesByVehicle(user, vehicleID)
+	changes, err := service.repo.GetNotificationChangesByVehicle(ctx, user, vehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle notification changes: %w", err)
 	}
@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Vehicle Notifications",
+	Operations: []openapi.Operation{
+		{
+			ID:       "createVehicleNotification",
+			Method:   http.MethodPost,
+			Path:     "/shops/vehicles/notifications",
+			Auth:     true,
+			Summary:  "Creates a new notification for a vehicle",
+			Request:  request.CreateVehicleNotificationRequest{},
+			Status:   http.StatusCreated,
+			Response: model.ShopVehicleNotifications{},
+		},
+		{
+			ID:       "getVehicleNotifications",
+			Method:   http.MethodGet,
+			Path:     "/shops/vehicles/:vehicle_id/notifications",
+			Auth:     true,
+			Summary:  "Returns all notifications for a vehicle",
+			Response: []model.ShopVehicleNotifications{},
+		},
+		{
+			ID:       "getVehicleNotificationsWithItems",
+			Method:   http.MethodGet,
+			Path:     "/shops/vehicles/:vehicle_id/notifications-with-items",
+			Auth:     true,
+			Summary:  "Returns all notifications for a vehicle with their items",
+			Response: []response.VehicleNotificationWithItems{},
+		},
+		{
+			ID:       "getShopNotifications",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/notifications",
+			Auth:     true,
+			Summary:  "Returns all notifications for a shop",
+			Response: []model.ShopVehicleNotifications{},
+		},
+		{
+			ID:       "getVehicleNotificationByID",
+			Method:   http.MethodGet,
+			Path:     "/shops/vehicles/notifications/:notification_id",
+			Auth:     true,
+			Summary:  "Returns a specific notification by ID",
+			Response: model.ShopVehicleNotifications{},
+		},
+		{
+			ID:       "updateVehicleNotification",
+			Method:   http.MethodPut,
+			Path:     "/shops/vehicles/notifications",
+			Auth:     true,
+			Summary:  "Updates an existing vehicle notification",
+			Request:  request.UpdateVehicleNotificationRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "deleteVehicleNotification",
+			Method:   http.MethodDelete,
+			Path:     "/shops/vehicles/notifications/:notification_id",
+			Auth:     true,
+			Summary:  "Deletes a vehicle notification",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+	},
+}}
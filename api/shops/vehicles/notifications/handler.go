@@ -3,6 +3,7 @@ package notifications
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -22,15 +23,15 @@ func (handler *Handler) CreateVehicleNotification(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.CreateVehicleNotificationRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -45,7 +46,7 @@ func (handler *Handler) CreateVehicleNotification(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdNotification, err := service.CreateVehicleNotification(user, notification)
+	createdNotification, err := service.CreateVehicleNotification(c.Request.Context(), user, notification)
 	if err != nil {
 		c.Error(err)
 		return
@@ -64,19 +65,19 @@ func (handler *Handler) GetVehicleNotifications(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicle_id")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle_id is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
 	service := handler.service
-	notifications, err := service.GetVehicleNotifications(user, vehicleID)
+	notifications, err := service.GetVehicleNotifications(c.Request.Context(), user, vehicleID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -95,19 +96,19 @@ func (handler *Handler) GetVehicleNotificationsWithItems(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicle_id")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle_id is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
 	service := handler.service
-	notificationsWithItems, err := service.GetVehicleNotificationsWithItems(user, vehicleID)
+	notificationsWithItems, err := service.GetVehicleNotificationsWithItems(c.Request.Context(), user, vehicleID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -126,19 +127,19 @@ func (handler *Handler) GetShopNotifications(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	notifications, err := service.GetShopNotifications(user, shopID)
+	notifications, err := service.GetShopNotifications(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -157,19 +158,19 @@ func (handler *Handler) GetVehicleNotificationByID(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	notificationID := c.Param("notification_id")
 	if notificationID == "" {
-		c.JSON(400, gin.H{"message": "notification_id is required"})
+		c.Error(apperror.MissingParameter("notification_id"))
 		return
 	}
 
 	service := handler.service
-	notification, err := service.GetVehicleNotificationByID(user, notificationID)
+	notification, err := service.GetVehicleNotificationByID(c.Request.Context(), user, notificationID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -188,15 +189,15 @@ func (handler *Handler) UpdateVehicleNotification(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.UpdateVehicleNotificationRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -213,7 +214,7 @@ func (handler *Handler) UpdateVehicleNotification(c *gin.Context) {
 	}
 
 	service := handler.service
-	err := service.UpdateVehicleNotification(user, update)
+	err := service.UpdateVehicleNotification(c.Request.Context(), user, update)
 	if err != nil {
 		c.Error(err)
 		return
@@ -228,19 +229,19 @@ func (handler *Handler) DeleteVehicleNotification(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	notificationID := c.Param("notification_id")
 	if notificationID == "" {
-		c.JSON(400, gin.H{"message": "notification_id is required"})
+		c.Error(apperror.MissingParameter("notification_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.DeleteVehicleNotification(user, notificationID)
+	err := service.DeleteVehicleNotification(c.Request.Context(), user, notificationID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -0,0 +1,70 @@
+package items
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/request"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Vehicle Notifications",
+	Operations: []openapi.Operation{
+		{
+			ID:       "addNotificationItem",
+			Method:   http.MethodPost,
+			Path:     "/shops/notifications/items",
+			Auth:     true,
+			Summary:  "Adds an item to a vehicle notification",
+			Request:  request.AddNotificationItemRequest{},
+			Status:   http.StatusCreated,
+			Response: model.ShopNotificationItems{},
+		},
+		{
+			ID:       "getNotificationItems",
+			Method:   http.MethodGet,
+			Path:     "/shops/notifications/:notification_id/items",
+			Auth:     true,
+			Summary:  "Returns all items for a notification",
+			Response: []model.ShopNotificationItems{},
+		},
+		{
+			ID:       "getShopNotificationItems",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/notification-items",
+			Auth:     true,
+			Summary:  "Returns all notification items for a shop",
+			Response: []model.ShopNotificationItems{},
+		},
+		{
+			ID:       "addNotificationItemList",
+			Method:   http.MethodPost,
+			Path:     "/shops/notifications/items/bulk",
+			Auth:     true,
+			Summary:  "Adds multiple items to a vehicle notification",
+			Request:  request.AddNotificationItemListRequest{},
+			Status:   http.StatusCreated,
+			Response: []model.ShopNotificationItems{},
+		},
+		{
+			ID:       "removeNotificationItem",
+			Method:   http.MethodDelete,
+			Path:     "/shops/notifications/items/:item_id",
+			Auth:     true,
+			Summary:  "Removes an item from a vehicle notification",
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "removeNotificationItemList",
+			Method:   http.MethodDelete,
+			Path:     "/shops/notifications/items/bulk",
+			Auth:     true,
+			Summary:  "Removes multiple items from vehicle notifications",
+			Request:  request.RemoveNotificationItemListRequest{},
+			Response: openapi.Object{"message": "", "count": 0},
+			Raw:      true,
+		},
+	},
+}}
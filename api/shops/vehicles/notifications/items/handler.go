@@ -3,6 +3,7 @@ package items
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/request"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
@@ -22,15 +23,15 @@ func (handler *Handler) AddNotificationItem(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.AddNotificationItemRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -42,7 +43,7 @@ func (handler *Handler) AddNotificationItem(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdItem, err := service.AddNotificationItem(user, item)
+	createdItem, err := service.AddNotificationItem(c.Request.Context(), user, item)
 	if err != nil {
 		c.Error(err)
 		return
@@ -61,19 +62,19 @@ func (handler *Handler) GetNotificationItems(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	notificationID := c.Param("notification_id")
 	if notificationID == "" {
-		c.JSON(400, gin.H{"message": "notification_id is required"})
+		c.Error(apperror.MissingParameter("notification_id"))
 		return
 	}
 
 	service := handler.service
-	items, err := service.GetNotificationItems(user, notificationID)
+	items, err := service.GetNotificationItems(c.Request.Context(), user, notificationID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -92,19 +93,19 @@ func (handler *Handler) GetShopNotificationItems(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	shopID := c.Param("shop_id")
 	if shopID == "" {
-		c.JSON(400, gin.H{"message": "shop_id is required"})
+		c.Error(apperror.MissingParameter("shop_id"))
 		return
 	}
 
 	service := handler.service
-	items, err := service.GetShopNotificationItems(user, shopID)
+	items, err := service.GetShopNotificationItems(c.Request.Context(), user, shopID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -123,15 +124,15 @@ func (handler *Handler) AddNotificationItemList(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.AddNotificationItemListRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -147,7 +148,7 @@ func (handler *Handler) AddNotificationItemList(c *gin.Context) {
 	}
 
 	service := handler.service
-	createdItems, err := service.AddNotificationItemList(user, items)
+	createdItems, err := service.AddNotificationItemList(c.Request.Context(), user, items)
 	if err != nil {
 		c.Error(err)
 		return
@@ -166,19 +167,19 @@ func (handler *Handler) RemoveNotificationItem(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	itemID := c.Param("item_id")
 	if itemID == "" {
-		c.JSON(400, gin.H{"message": "item_id is required"})
+		c.Error(apperror.MissingParameter("item_id"))
 		return
 	}
 
 	service := handler.service
-	err := service.RemoveNotificationItem(user, itemID)
+	err := service.RemoveNotificationItem(c.Request.Context(), user, itemID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -193,20 +194,20 @@ func (handler *Handler) RemoveNotificationItemList(c *gin.Context) {
 	user, _ := ctxUser.(*bootstrap.User)
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var req request.RemoveNotificationItemListRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	service := handler.service
-	err := service.RemoveNotificationItemList(user, req.ItemIDs)
+	err := service.RemoveNotificationItemList(c.Request.Context(), user, req.ItemIDs)
 	if err != nil {
 		c.Error(err)
 		return
@@ -1,12 +1,14 @@
 package items
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/apperror"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
@@ -19,7 +21,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) CreateNotificationItem(user *bootstrap.User, item model.ShopNotificationItems) (*model.ShopNotificationItems, error) {
+func (repo *RepositoryImpl) CreateNotificationItem(ctx context.Context, user *bootstrap.User, item model.ShopNotificationItems) (*model.ShopNotificationItems, error) {
 	stmt := ShopNotificationItems.INSERT(
 		ShopNotificationItems.ID,
 		ShopNotificationItems.ShopID,
@@ -31,7 +33,7 @@ func (repo *RepositoryImpl) CreateNotificationItem(user *bootstrap.User, item mo
 	).MODEL(item).RETURNING(ShopNotificationItems.AllColumns)
 
 	var createdItem model.ShopNotificationItems
-	err := stmt.Query(repo.db, &createdItem)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &createdItem)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create notification item: %w", err)
 	}
@@ -39,14 +41,14 @@ func (repo *RepositoryImpl) CreateNotificationItem(user *bootstrap.User, item mo
 	return &createdItem, nil
 }
 
-func (repo *RepositoryImpl) GetNotificationItems(user *bootstrap.User, notificationID string) ([]model.ShopNotificationItems, error) {
+func (repo *RepositoryImpl) GetNotificationItems(ctx context.Context, user *bootstrap.User, notificationID string) ([]model.ShopNotificationItems, error) {
 	stmt := SELECT(ShopNotificationItems.AllColumns).
 		FROM(ShopNotificationItems).
 		WHERE(ShopNotificationItems.NotificationID.EQ(String(notificationID))).
 		ORDER_BY(ShopNotificationItems.SaveTime.ASC())
 
 	var items []model.ShopNotificationItems
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification items: %w", err)
 	}
@@ -54,14 +56,14 @@ func (repo *RepositoryImpl) GetNotificationItems(user *bootstrap.User, notificat
 	return items, nil
 }
 
-func (repo *RepositoryImpl) GetShopNotificationItems(user *bootstrap.User, shopID string) ([]model.ShopNotificationItems, error) {
+func (repo *RepositoryImpl) GetShopNotificationItems(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopNotificationItems, error) {
 	stmt := SELECT(ShopNotificationItems.AllColumns).
 		FROM(ShopNotificationItems).
 		WHERE(ShopNotificationItems.ShopID.EQ(String(shopID))).
 		ORDER_BY(ShopNotificationItems.SaveTime.DESC())
 
 	var items []model.ShopNotificationItems
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop notification items: %w", err)
 	}
@@ -69,13 +71,13 @@ func (repo *RepositoryImpl) GetShopNotificationItems(user *bootstrap.User, shopI
 	return items, nil
 }
 
-func (repo *RepositoryImpl) GetNotificationItemByID(user *bootstrap.User, itemID string) (*model.ShopNotificationItems, error) {
+func (repo *RepositoryImpl) GetNotificationItemByID(ctx context.Context, user *bootstrap.User, itemID string) (*model.ShopNotificationItems, error) {
 	stmt := SELECT(ShopNotificationItems.AllColumns).
 		FROM(ShopNotificationItems).
 		WHERE(ShopNotificationItems.ID.EQ(String(itemID)))
 
 	var item model.ShopNotificationItems
-	err := stmt.Query(repo.db, &item)
+	err := stmt.QueryContext(ctx, repo.db, &item)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification item: %w", err)
 	}
@@ -83,7 +85,7 @@ func (repo *RepositoryImpl) GetNotificationItemByID(user *bootstrap.User, itemID
 	return &item, nil
 }
 
-func (repo *RepositoryImpl) GetNotificationItemsByIDs(user *bootstrap.User, itemIDs []string) ([]model.ShopNotificationItems, error) {
+func (repo *RepositoryImpl) GetNotificationItemsByIDs(ctx context.Context, user *bootstrap.User, itemIDs []string) ([]model.ShopNotificationItems, error) {
 	if len(itemIDs) == 0 {
 		return []model.ShopNotificationItems{}, nil
 	}
@@ -98,7 +100,7 @@ func (repo *RepositoryImpl) GetNotificationItemsByIDs(user *bootstrap.User, item
 		WHERE(ShopNotificationItems.ID.IN(expressions...))
 
 	var items []model.ShopNotificationItems
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification items: %w", err)
 	}
@@ -106,7 +108,7 @@ func (repo *RepositoryImpl) GetNotificationItemsByIDs(user *bootstrap.User, item
 	return items, nil
 }
 
-func (repo *RepositoryImpl) CreateNotificationItemList(user *bootstrap.User, items []model.ShopNotificationItems) ([]model.ShopNotificationItems, error) {
+func (repo *RepositoryImpl) CreateNotificationItemList(ctx context.Context, user *bootstrap.User, items []model.ShopNotificationItems) ([]model.ShopNotificationItems, error) {
 	if len(items) == 0 {
 		return []model.ShopNotificationItems{}, nil
 	}
@@ -122,7 +124,7 @@ func (repo *RepositoryImpl) CreateNotificationItemList(user *bootstrap.User, ite
 	).MODELS(items).RETURNING(ShopNotificationItems.AllColumns)
 
 	var createdItems []model.ShopNotificationItems
-	err := stmt.Query(repo.db, &createdItems)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &createdItems)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create notification items: %w", err)
 	}
@@ -130,11 +132,11 @@ func (repo *RepositoryImpl) CreateNotificationItemList(user *bootstrap.User, ite
 	return createdItems, nil
 }
 
-func (repo *RepositoryImpl) DeleteNotificationItem(user *bootstrap.User, itemID string) error {
+func (repo *RepositoryImpl) DeleteNotificationItem(ctx context.Context, user *bootstrap.User, itemID string) error {
 	stmt := ShopNotificationItems.DELETE().
 		WHERE(ShopNotificationItems.ID.EQ(String(itemID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete notification item: %w", err)
 	}
@@ -145,13 +147,13 @@ func (repo *RepositoryImpl) DeleteNotificationItem(user *bootstrap.User, itemID
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("notification item not found")
+		return apperror.NotFound("notification_item_not_found", "notification item not found")
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteNotificationItemList(user *bootstrap.User, itemIDs []string) error {
+func (repo *RepositoryImpl) DeleteNotificationItemList(ctx context.Context, user *bootstrap.User, itemIDs []string) error {
 	if len(itemIDs) == 0 {
 		return nil
 	}
@@ -164,7 +166,7 @@ func (repo *RepositoryImpl) DeleteNotificationItemList(user *bootstrap.User, ite
 	stmt := ShopNotificationItems.DELETE().
 		WHERE(ShopNotificationItems.ID.IN(expressions...))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete notification items: %w", err)
 	}
@@ -172,13 +174,13 @@ func (repo *RepositoryImpl) DeleteNotificationItemList(user *bootstrap.User, ite
 	return nil
 }
 
-func (repo *RepositoryImpl) GetVehicleNotificationByID(user *bootstrap.User, notificationID string) (*model.ShopVehicleNotifications, error) {
+func (repo *RepositoryImpl) GetVehicleNotificationByID(ctx context.Context, user *bootstrap.User, notificationID string) (*model.ShopVehicleNotifications, error) {
 	stmt := SELECT(ShopVehicleNotifications.AllColumns).
 		FROM(ShopVehicleNotifications).
 		WHERE(ShopVehicleNotifications.ID.EQ(String(notificationID)))
 
 	var notification model.ShopVehicleNotifications
-	err := stmt.Query(repo.db, &notification)
+	err := stmt.QueryContext(ctx, repo.db, &notification)
 	if err != nil {
 		return nil, fmt.Errorf("vehicle notification not found: %w", err)
 	}
@@ -186,13 +188,13 @@ func (repo *RepositoryImpl) GetVehicleNotificationByID(user *bootstrap.User, not
 	return &notification, nil
 }
 
-func (repo *RepositoryImpl) GetShopVehicleByID(user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error) {
+func (repo *RepositoryImpl) GetShopVehicleByID(ctx context.Context, user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error) {
 	stmt := SELECT(ShopVehicle.AllColumns).
 		FROM(ShopVehicle).
 		WHERE(ShopVehicle.ID.EQ(String(vehicleID)))
 
 	var vehicle model.ShopVehicle
-	err := stmt.Query(repo.db, &vehicle)
+	err := stmt.QueryContext(ctx, repo.db, &vehicle)
 	if err != nil {
 		return nil, fmt.Errorf("shop vehicle not found: %w", err)
 	}
@@ -200,7 +202,7 @@ func (repo *RepositoryImpl) GetShopVehicleByID(user *bootstrap.User, vehicleID s
 	return &vehicle, nil
 }
 
-func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID string) (bool, error) {
+func (repo *RepositoryImpl) IsUserMemberOfShop(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	stmt := SELECT(COUNT(ShopMembers.ID).AS("count")).
 		FROM(ShopMembers).
 		WHERE(
@@ -211,7 +213,7 @@ func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID stri
 	var result struct {
 		Count int64 `sql:"primary_key"`
 	}
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		return false, fmt.Errorf("failed to check membership: %w", err)
 	}
@@ -219,7 +221,7 @@ func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID stri
 	return result.Count > 0, nil
 }
 
-func (repo *RepositoryImpl) CreateNotificationChange(user *bootstrap.User, change model.ShopVehicleNotificationChanges) error {
+func (repo *RepositoryImpl) CreateNotificationChange(ctx context.Context, user *bootstrap.User, change model.ShopVehicleNotificationChanges) error {
 	rawSQL := `
 		INSERT INTO shop_vehicle_notification_changes (
 			notification_id,
@@ -234,7 +236,7 @@ func (repo *RepositoryImpl) CreateNotificationChange(user *bootstrap.User, chang
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := repo.db.Exec(
+	_, err := dbtx.From(ctx, repo.db).ExecContext(ctx,
 		rawSQL,
 		change.NotificationID,
 		change.ShopID,
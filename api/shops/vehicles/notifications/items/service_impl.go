@@ -1,56 +1,73 @@
 package items
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
+	"miltechserver/api/shops/audit"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type ServiceImpl struct {
-	repo Repository
+	repo   Repository
+	audit  audit.Recorder
+	events events.Publisher
 }
 
-func NewService(repo Repository) *ServiceImpl {
-	return &ServiceImpl{repo: repo}
+func NewService(repo Repository, recorder audit.Recorder, publisher events.Publisher) *ServiceImpl {
+	return &ServiceImpl{
+		repo:   repo,
+		audit:  recorder,
+		events: publisher,
+	}
 }
 
-func (service *ServiceImpl) AddNotificationItem(user *bootstrap.User, item model.ShopNotificationItems) (*model.ShopNotificationItems, error) {
+func (service *ServiceImpl) AddNotificationItem(ctx context.Context, user *bootstrap.User, item model.ShopNotificationItems) (*model.ShopNotificationItems, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	notification, err := service.repo.GetVehicleNotificationByID(user, item.NotificationID)
+	notification, err := service.repo.GetVehicleNotificationByID(ctx, user, item.NotificationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, notification.VehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, notification.VehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, notification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, notification.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	item.ID = uuid.New().String()
 	item.ShopID = notification.ShopID
 	item.SaveTime = time.Now()
 
-	createdItem, err := service.repo.CreateNotificationItem(user, item)
+	var createdItem *model.ShopNotificationItems
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		createdItem, err = service.repo.CreateNotificationItem(ctx, user, item)
+		if err != nil {
+			return fmt.Errorf("failed to add notification item: %w", err)
+		}
+		return service.recordItem(ctx, user, notification.ShopID, createdItem.ID, audit.ActionCreate, nil, createdItem)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to add notification item: %w", err)
+		return nil, err
 	}
 
 	fieldChanges, err := buildItemAdditionFieldChanges([]model.ShopNotificationItems{*createdItem})
@@ -59,7 +76,7 @@ func (service *ServiceImpl) AddNotificationItem(user *bootstrap.User, item model
 		fieldChanges = `{"fields_changed": ["items"], "item_count": 1}`
 	}
 
-	service.recordNotificationChange(
+	service.recordNotificationChange(ctx,
 		user,
 		item.NotificationID,
 		notification.ShopID,
@@ -75,26 +92,26 @@ func (service *ServiceImpl) AddNotificationItem(user *bootstrap.User, item model
 	return createdItem, nil
 }
 
-func (service *ServiceImpl) GetNotificationItems(user *bootstrap.User, notificationID string) ([]model.ShopNotificationItems, error) {
+func (service *ServiceImpl) GetNotificationItems(ctx context.Context, user *bootstrap.User, notificationID string) ([]model.ShopNotificationItems, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	notification, err := service.repo.GetVehicleNotificationByID(user, notificationID)
+	notification, err := service.repo.GetVehicleNotificationByID(ctx, user, notificationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, notification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, notification.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	items, err := service.repo.GetNotificationItems(user, notificationID)
+	items, err := service.repo.GetNotificationItems(ctx, user, notificationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification items: %w", err)
 	}
@@ -106,21 +123,21 @@ func (service *ServiceImpl) GetNotificationItems(user *bootstrap.User, notificat
 	return items, nil
 }
 
-func (service *ServiceImpl) GetShopNotificationItems(user *bootstrap.User, shopID string) ([]model.ShopNotificationItems, error) {
+func (service *ServiceImpl) GetShopNotificationItems(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopNotificationItems, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	items, err := service.repo.GetShopNotificationItems(user, shopID)
+	items, err := service.repo.GetShopNotificationItems(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop notification items: %w", err)
 	}
@@ -132,32 +149,32 @@ func (service *ServiceImpl) GetShopNotificationItems(user *bootstrap.User, shopI
 	return items, nil
 }
 
-func (service *ServiceImpl) AddNotificationItemList(user *bootstrap.User, items []model.ShopNotificationItems) ([]model.ShopNotificationItems, error) {
+func (service *ServiceImpl) AddNotificationItemList(ctx context.Context, user *bootstrap.User, items []model.ShopNotificationItems) ([]model.ShopNotificationItems, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
 	if len(items) == 0 {
-		return nil, errors.New("no items to add")
+		return nil, apperror.Validation("no_items", "no items to add")
 	}
 
-	notification, err := service.repo.GetVehicleNotificationByID(user, items[0].NotificationID)
+	notification, err := service.repo.GetVehicleNotificationByID(ctx, user, items[0].NotificationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, notification.VehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, notification.VehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, notification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, notification.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	now := time.Now()
@@ -167,9 +184,22 @@ func (service *ServiceImpl) AddNotificationItemList(user *bootstrap.User, items
 		items[i].SaveTime = now
 	}
 
-	createdItems, err := service.repo.CreateNotificationItemList(user, items)
+	var createdItems []model.ShopNotificationItems
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		createdItems, err = service.repo.CreateNotificationItemList(ctx, user, items)
+		if err != nil {
+			return fmt.Errorf("failed to add notification items: %w", err)
+		}
+		for i := range createdItems {
+			err := service.recordItem(ctx, user, notification.ShopID, createdItems[i].ID, audit.ActionCreate, nil, &createdItems[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to add notification items: %w", err)
+		return nil, err
 	}
 
 	fieldChanges, err := buildItemAdditionFieldChanges(createdItems)
@@ -178,7 +208,7 @@ func (service *ServiceImpl) AddNotificationItemList(user *bootstrap.User, items
 		fieldChanges = fmt.Sprintf(`{"fields_changed": ["items"], "item_count": %d}`, len(createdItems))
 	}
 
-	service.recordNotificationChange(
+	service.recordNotificationChange(ctx,
 		user,
 		items[0].NotificationID,
 		notification.ShopID,
@@ -194,38 +224,44 @@ func (service *ServiceImpl) AddNotificationItemList(user *bootstrap.User, items
 	return createdItems, nil
 }
 
-func (service *ServiceImpl) RemoveNotificationItem(user *bootstrap.User, itemID string) error {
+func (service *ServiceImpl) RemoveNotificationItem(ctx context.Context, user *bootstrap.User, itemID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	item, err := service.repo.GetNotificationItemByID(user, itemID)
+	item, err := service.repo.GetNotificationItemByID(ctx, user, itemID)
 	if err != nil {
 		return fmt.Errorf("failed to get notification item: %w", err)
 	}
 
-	notification, err := service.repo.GetVehicleNotificationByID(user, item.NotificationID)
+	notification, err := service.repo.GetVehicleNotificationByID(ctx, user, item.NotificationID)
 	if err != nil {
 		return fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, notification.VehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, notification.VehicleID)
 	if err != nil {
 		return fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, notification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, notification.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("access denied: user is not a member of this shop")
+		return shared.ErrNotShopMember
 	}
 
-	err = service.repo.DeleteNotificationItem(user, itemID)
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.DeleteNotificationItem(ctx, user, itemID)
+		if err != nil {
+			return fmt.Errorf("failed to remove notification item: %w", err)
+		}
+		return service.recordItem(ctx, user, notification.ShopID, itemID, audit.ActionDelete, item, nil)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to remove notification item: %w", err)
+		return err
 	}
 
 	fieldChanges, err := buildItemRemovalFieldChanges([]model.ShopNotificationItems{*item})
@@ -234,7 +270,7 @@ func (service *ServiceImpl) RemoveNotificationItem(user *bootstrap.User, itemID
 		fieldChanges = `{"fields_changed": ["items"], "item_count": 1}`
 	}
 
-	service.recordNotificationChange(
+	service.recordNotificationChange(ctx,
 		user,
 		item.NotificationID,
 		item.ShopID,
@@ -250,54 +286,66 @@ func (service *ServiceImpl) RemoveNotificationItem(user *bootstrap.User, itemID
 	return nil
 }
 
-func (service *ServiceImpl) RemoveNotificationItemList(user *bootstrap.User, itemIDs []string) error {
+func (service *ServiceImpl) RemoveNotificationItemList(ctx context.Context, user *bootstrap.User, itemIDs []string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
 	if len(itemIDs) == 0 {
-		return errors.New("no items to remove")
+		return apperror.Validation("no_items", "no items to remove")
 	}
 
-	items, err := service.repo.GetNotificationItemsByIDs(user, itemIDs)
+	items, err := service.repo.GetNotificationItemsByIDs(ctx, user, itemIDs)
 	if err != nil {
 		return fmt.Errorf("failed to get notification items: %w", err)
 	}
 
 	if len(items) == 0 {
 		slog.Warn("No notification items found for deletion", "user_id", user.UserID, "requested_count", len(itemIDs))
-		return errors.New("no notification items found")
+		return apperror.NotFound("notification_item_not_found", "no notification items found")
 	}
 
 	firstItem := items[0]
-	notification, err := service.repo.GetVehicleNotificationByID(user, firstItem.NotificationID)
+	notification, err := service.repo.GetVehicleNotificationByID(ctx, user, firstItem.NotificationID)
 	if err != nil {
 		return fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, notification.VehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, notification.VehicleID)
 	if err != nil {
 		return fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, notification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, notification.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("access denied: user is not a member of this shop")
+		return shared.ErrNotShopMember
 	}
 
 	for _, item := range items {
 		if item.NotificationID != firstItem.NotificationID {
-			return errors.New("cannot delete items from multiple notifications in a single operation")
+			return apperror.Validation("mixed_notifications", "cannot delete items from multiple notifications in a single operation")
 		}
 	}
 
-	err = service.repo.DeleteNotificationItemList(user, itemIDs)
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.DeleteNotificationItemList(ctx, user, itemIDs)
+		if err != nil {
+			return fmt.Errorf("failed to remove notification items: %w", err)
+		}
+		for i := range items {
+			err := service.recordItem(ctx, user, notification.ShopID, items[i].ID, audit.ActionDelete, &items[i], nil)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to remove notification items: %w", err)
+		return err
 	}
 
 	fieldChanges, err := buildItemRemovalFieldChanges(items)
@@ -306,7 +354,7 @@ func (service *ServiceImpl) RemoveNotificationItemList(user *bootstrap.User, ite
 		fieldChanges = fmt.Sprintf(`{"fields_changed": ["items"], "item_count": %d}`, len(items))
 	}
 
-	service.recordNotificationChange(
+	service.recordNotificationChange(ctx,
 		user,
 		firstItem.NotificationID,
 		firstItem.ShopID,
@@ -322,6 +370,18 @@ func (service *ServiceImpl) RemoveNotificationItemList(user *bootstrap.User, ite
 	return nil
 }
 
+func (service *ServiceImpl) recordItem(ctx context.Context, user *bootstrap.User, shopID, itemID string, action audit.Action, before, after *model.ShopNotificationItems) error {
+	return service.audit.Record(ctx, audit.Entry{
+		ShopID:     shopID,
+		ActorID:    user.UserID,
+		EntityType: audit.EntityNotificationItem,
+		EntityID:   itemID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	})
+}
+
 // itemAuditInfo represents item details captured in audit trail
 type itemAuditInfo struct {
 	Niin         string `json:"niin"`
@@ -389,7 +449,7 @@ func buildItemRemovalFieldChanges(items []model.ShopNotificationItems) (string,
 	return string(jsonBytes), nil
 }
 
-func (service *ServiceImpl) recordNotificationChange(
+func (service *ServiceImpl) recordNotificationChange(ctx context.Context,
 	user *bootstrap.User,
 	notificationID string,
 	shopID string,
@@ -412,7 +472,18 @@ func (service *ServiceImpl) recordNotificationChange(
 		VehicleAdmin:      &vehicleAdmin,
 	}
 
-	err := service.repo.CreateNotificationChange(user, change)
+	err := service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.CreateNotificationChange(ctx, user, change); err != nil {
+			return err
+		}
+		return service.events.Publish(ctx, events.VehicleNotificationChanged{
+			ShopID:         shopID,
+			VehicleID:      vehicleID,
+			NotificationID: notificationID,
+			ChangeType:     changeType,
+			ChangedBy:      user.UserID,
+		})
+	})
 	if err != nil {
 		slog.Warn("Failed to record notification change", "error", err, "notification_id", notificationID, "change_type", changeType)
 	}
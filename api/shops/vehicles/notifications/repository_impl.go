@@ -1,13 +1,15 @@
 package notifications
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
@@ -16,13 +18,13 @@ type RepositoryImpl struct {
 	db *sql.DB
 }
 
-var errShopListNotFound = errors.New("shop list not found")
+var errShopListNotFound = shared.ErrShopListNotFound
 
 func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) CreateVehicleNotification(user *bootstrap.User, notification model.ShopVehicleNotifications) (*model.ShopVehicleNotifications, error) {
+func (repo *RepositoryImpl) CreateVehicleNotification(ctx context.Context, user *bootstrap.User, notification model.ShopVehicleNotifications) (*model.ShopVehicleNotifications, error) {
 	stmt := ShopVehicleNotifications.INSERT(
 		ShopVehicleNotifications.ID,
 		ShopVehicleNotifications.ShopID,
@@ -37,7 +39,7 @@ func (repo *RepositoryImpl) CreateVehicleNotification(user *bootstrap.User, noti
 	).MODEL(notification).RETURNING(ShopVehicleNotifications.AllColumns)
 
 	var createdNotification model.ShopVehicleNotifications
-	err := stmt.Query(repo.db, &createdNotification)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &createdNotification)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create vehicle notification: %w", err)
 	}
@@ -45,14 +47,14 @@ func (repo *RepositoryImpl) CreateVehicleNotification(user *bootstrap.User, noti
 	return &createdNotification, nil
 }
 
-func (repo *RepositoryImpl) GetVehicleNotifications(user *bootstrap.User, vehicleID string) ([]model.ShopVehicleNotifications, error) {
+func (repo *RepositoryImpl) GetVehicleNotifications(ctx context.Context, user *bootstrap.User, vehicleID string) ([]model.ShopVehicleNotifications, error) {
 	stmt := SELECT(ShopVehicleNotifications.AllColumns).
 		FROM(ShopVehicleNotifications).
 		WHERE(ShopVehicleNotifications.VehicleID.EQ(String(vehicleID))).
 		ORDER_BY(ShopVehicleNotifications.SaveTime.DESC())
 
 	var notifications []model.ShopVehicleNotifications
-	err := stmt.Query(repo.db, &notifications)
+	err := stmt.QueryContext(ctx, repo.db, &notifications)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle notifications: %w", err)
 	}
@@ -60,8 +62,8 @@ func (repo *RepositoryImpl) GetVehicleNotifications(user *bootstrap.User, vehicl
 	return notifications, nil
 }
 
-func (repo *RepositoryImpl) GetVehicleNotificationsWithItems(user *bootstrap.User, vehicleID string) ([]response.VehicleNotificationWithItems, error) {
-	notifications, err := repo.GetVehicleNotifications(user, vehicleID)
+func (repo *RepositoryImpl) GetVehicleNotificationsWithItems(ctx context.Context, user *bootstrap.User, vehicleID string) ([]response.VehicleNotificationWithItems, error) {
+	notifications, err := repo.GetVehicleNotifications(ctx, user, vehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle notifications: %w", err)
 	}
@@ -75,7 +77,7 @@ func (repo *RepositoryImpl) GetVehicleNotificationsWithItems(user *bootstrap.Use
 		notificationIDs[i] = notification.ID
 	}
 
-	allItems, err := repo.GetItemsByNotificationIDs(notificationIDs)
+	allItems, err := repo.GetItemsByNotificationIDs(ctx, notificationIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification items: %w", err)
 	}
@@ -101,7 +103,7 @@ func (repo *RepositoryImpl) GetVehicleNotificationsWithItems(user *bootstrap.Use
 	return result, nil
 }
 
-func (repo *RepositoryImpl) GetItemsByNotificationIDs(notificationIDs []string) ([]model.ShopNotificationItems, error) {
+func (repo *RepositoryImpl) GetItemsByNotificationIDs(ctx context.Context, notificationIDs []string) ([]model.ShopNotificationItems, error) {
 	if len(notificationIDs) == 0 {
 		return []model.ShopNotificationItems{}, nil
 	}
@@ -117,7 +119,7 @@ func (repo *RepositoryImpl) GetItemsByNotificationIDs(notificationIDs []string)
 		ORDER_BY(ShopNotificationItems.SaveTime.ASC())
 
 	var items []model.ShopNotificationItems
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, err
 	}
@@ -125,14 +127,14 @@ func (repo *RepositoryImpl) GetItemsByNotificationIDs(notificationIDs []string)
 	return items, nil
 }
 
-func (repo *RepositoryImpl) GetShopNotifications(user *bootstrap.User, shopID string) ([]model.ShopVehicleNotifications, error) {
+func (repo *RepositoryImpl) GetShopNotifications(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopVehicleNotifications, error) {
 	stmt := SELECT(ShopVehicleNotifications.AllColumns).
 		FROM(ShopVehicleNotifications).
 		WHERE(ShopVehicleNotifications.ShopID.EQ(String(shopID))).
 		ORDER_BY(ShopVehicleNotifications.SaveTime.DESC())
 
 	var notifications []model.ShopVehicleNotifications
-	err := stmt.Query(repo.db, &notifications)
+	err := stmt.QueryContext(ctx, repo.db, &notifications)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop notifications: %w", err)
 	}
@@ -140,13 +142,13 @@ func (repo *RepositoryImpl) GetShopNotifications(user *bootstrap.User, shopID st
 	return notifications, nil
 }
 
-func (repo *RepositoryImpl) GetVehicleNotificationByID(user *bootstrap.User, notificationID string) (*model.ShopVehicleNotifications, error) {
+func (repo *RepositoryImpl) GetVehicleNotificationByID(ctx context.Context, user *bootstrap.User, notificationID string) (*model.ShopVehicleNotifications, error) {
 	stmt := SELECT(ShopVehicleNotifications.AllColumns).
 		FROM(ShopVehicleNotifications).
 		WHERE(ShopVehicleNotifications.ID.EQ(String(notificationID)))
 
 	var notification model.ShopVehicleNotifications
-	err := stmt.Query(repo.db, &notification)
+	err := stmt.QueryContext(ctx, repo.db, &notification)
 	if err != nil {
 		return nil, fmt.Errorf("vehicle notification not found: %w", err)
 	}
@@ -154,7 +156,7 @@ func (repo *RepositoryImpl) GetVehicleNotificationByID(user *bootstrap.User, not
 	return &notification, nil
 }
 
-func (repo *RepositoryImpl) UpdateVehicleNotification(user *bootstrap.User, update VehicleNotificationUpdate) error {
+func (repo *RepositoryImpl) UpdateVehicleNotification(ctx context.Context, user *bootstrap.User, update VehicleNotificationUpdate) error {
 	notification := update.Notification
 
 	var result sql.Result
@@ -171,7 +173,7 @@ func (repo *RepositoryImpl) UpdateVehicleNotification(user *bootstrap.User, upda
 			WHERE id = $7
 		`
 
-		result, err = repo.db.Exec(
+		result, err = dbtx.From(ctx, repo.db).ExecContext(ctx,
 			rawSQL,
 			notification.Title,
 			notification.Description,
@@ -196,7 +198,7 @@ func (repo *RepositoryImpl) UpdateVehicleNotification(user *bootstrap.User, upda
 			ShopVehicleNotifications.LastUpdated.SET(TimestampzT(notification.LastUpdated)),
 		).WHERE(ShopVehicleNotifications.ID.EQ(String(notification.ID)))
 
-		result, err = stmt.Exec(repo.db)
+		result, err = stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	}
 	if err != nil {
 		return fmt.Errorf("failed to update vehicle notification: %w", err)
@@ -208,17 +210,17 @@ func (repo *RepositoryImpl) UpdateVehicleNotification(user *bootstrap.User, upda
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("notification not found")
+		return shared.ErrNotificationNotFound
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteVehicleNotification(user *bootstrap.User, notificationID string) error {
+func (repo *RepositoryImpl) DeleteVehicleNotification(ctx context.Context, user *bootstrap.User, notificationID string) error {
 	stmt := ShopVehicleNotifications.DELETE().
 		WHERE(ShopVehicleNotifications.ID.EQ(String(notificationID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete vehicle notification: %w", err)
 	}
@@ -229,13 +231,13 @@ func (repo *RepositoryImpl) DeleteVehicleNotification(user *bootstrap.User, noti
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("notification not found")
+		return shared.ErrNotificationNotFound
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) CreateNotificationChange(user *bootstrap.User, change model.ShopVehicleNotificationChanges) error {
+func (repo *RepositoryImpl) CreateNotificationChange(ctx context.Context, user *bootstrap.User, change model.ShopVehicleNotificationChanges) error {
 	rawSQL := `
 		INSERT INTO shop_vehicle_notification_changes (
 			notification_id,
@@ -250,7 +252,7 @@ func (repo *RepositoryImpl) CreateNotificationChange(user *bootstrap.User, chang
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := repo.db.Exec(
+	_, err := dbtx.From(ctx, repo.db).ExecContext(ctx,
 		rawSQL,
 		change.NotificationID,
 		change.ShopID,
@@ -269,13 +271,13 @@ func (repo *RepositoryImpl) CreateNotificationChange(user *bootstrap.User, chang
 	return nil
 }
 
-func (repo *RepositoryImpl) GetShopVehicleByID(user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error) {
+func (repo *RepositoryImpl) GetShopVehicleByID(ctx context.Context, user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error) {
 	stmt := SELECT(ShopVehicle.AllColumns).
 		FROM(ShopVehicle).
 		WHERE(ShopVehicle.ID.EQ(String(vehicleID)))
 
 	var vehicle model.ShopVehicle
-	err := stmt.Query(repo.db, &vehicle)
+	err := stmt.QueryContext(ctx, repo.db, &vehicle)
 	if err != nil {
 		return nil, fmt.Errorf("shop vehicle not found: %w", err)
 	}
@@ -283,13 +285,13 @@ func (repo *RepositoryImpl) GetShopVehicleByID(user *bootstrap.User, vehicleID s
 	return &vehicle, nil
 }
 
-func (repo *RepositoryImpl) GetShopListByID(user *bootstrap.User, listID string) (*model.ShopLists, error) {
+func (repo *RepositoryImpl) GetShopListByID(ctx context.Context, user *bootstrap.User, listID string) (*model.ShopLists, error) {
 	stmt := SELECT(ShopLists.AllColumns).
 		FROM(ShopLists).
 		WHERE(ShopLists.ID.EQ(String(listID)))
 
 	var lists []model.ShopLists
-	err := stmt.Query(repo.db, &lists)
+	err := stmt.QueryContext(ctx, repo.db, &lists)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop list: %w", err)
 	}
@@ -301,7 +303,7 @@ func (repo *RepositoryImpl) GetShopListByID(user *bootstrap.User, listID string)
 	return &lists[0], nil
 }
 
-func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID string) (bool, error) {
+func (repo *RepositoryImpl) IsUserMemberOfShop(ctx context.Context, user *bootstrap.User, shopID string) (bool, error) {
 	stmt := SELECT(Int(1).AS("exists")).
 		FROM(ShopMembers).
 		WHERE(
@@ -313,7 +315,7 @@ func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID stri
 	var result []struct {
 		Exists int `sql:"exists"`
 	}
-	err := stmt.Query(repo.db, &result)
+	err := stmt.QueryContext(ctx, repo.db, &result)
 	if err != nil {
 		return false, fmt.Errorf("failed to check membership: %w", err)
 	}
@@ -321,14 +323,14 @@ func (repo *RepositoryImpl) IsUserMemberOfShop(user *bootstrap.User, shopID stri
 	return len(result) > 0, nil
 }
 
-func (repo *RepositoryImpl) GetNotificationItems(user *bootstrap.User, notificationID string) ([]model.ShopNotificationItems, error) {
+func (repo *RepositoryImpl) GetNotificationItems(ctx context.Context, user *bootstrap.User, notificationID string) ([]model.ShopNotificationItems, error) {
 	stmt := SELECT(ShopNotificationItems.AllColumns).
 		FROM(ShopNotificationItems).
 		WHERE(ShopNotificationItems.NotificationID.EQ(String(notificationID))).
 		ORDER_BY(ShopNotificationItems.SaveTime.ASC())
 
 	var items []model.ShopNotificationItems
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification items: %w", err)
 	}
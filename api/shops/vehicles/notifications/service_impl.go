@@ -1,14 +1,18 @@
 package notifications
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 	"strings"
 	"time"
 
@@ -16,67 +20,73 @@ import (
 )
 
 type ServiceImpl struct {
-	repo Repository
-	auth shared.ShopAuthorization
+	repo   Repository
+	auth   shared.ShopAuthorization
+	audit  audit.Recorder
+	events events.Publisher
 }
 
-func NewService(repo Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, auth shared.ShopAuthorization, recorder audit.Recorder, publisher events.Publisher) *ServiceImpl {
 	return &ServiceImpl{
-		repo: repo,
-		auth: auth,
+		repo:   repo,
+		auth:   auth,
+		audit:  recorder,
+		events: publisher,
 	}
 }
 
 func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
 	return &ServiceImpl{
-		repo: service.repo,
-		auth: auth,
+		repo:   service.repo,
+		auth:   auth,
+		audit:  service.audit,
+		events: service.events,
 	}
 }
 
-func (service *ServiceImpl) validateAttachedShopList(user *bootstrap.User, shopID string, attachedShopList *string) error {
+func (service *ServiceImpl) validateAttachedShopList(ctx context.Context, user *bootstrap.User, shopID string, attachedShopList *string) error {
 	if attachedShopList == nil {
 		return nil
 	}
 	if strings.TrimSpace(*attachedShopList) == "" {
-		return errors.New("invalid attached_shop_list")
+		return shared.ErrInvalidAttachedShopList
 	}
 
-	list, err := service.repo.GetShopListByID(user, *attachedShopList)
+	list, err := service.repo.GetShopListByID(ctx, user, *attachedShopList)
 	if err != nil {
 		if errors.Is(err, errShopListNotFound) {
-			return errors.New("invalid attached_shop_list")
+			return shared.ErrInvalidAttachedShopList
 		}
 		return fmt.Errorf("failed to get attached shop list: %w", err)
 	}
 	if list.ShopID != shopID {
-		return errors.New("invalid attached_shop_list")
+		return shared.ErrInvalidAttachedShopList
 	}
 	return nil
 }
 
-func (service *ServiceImpl) CreateVehicleNotification(user *bootstrap.User, notification model.ShopVehicleNotifications) (*model.ShopVehicleNotifications, error) {
+func (service *ServiceImpl) CreateVehicleNotification(ctx context.Context, user *bootstrap.User, notification model.ShopVehicleNotifications) (*model.ShopVehicleNotifications, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, notification.VehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, notification.VehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, vehicle.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, vehicle.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	notification.ID = uuid.New().String()
 	notification.ShopID = vehicle.ShopID
-	if err := service.validateAttachedShopList(user, notification.ShopID, notification.AttachedShopList); err != nil {
+	if err := service.validateAttachedShopList(ctx, user, notification.ShopID, notification.AttachedShopList); err != nil {
 		return nil, err
 	}
 
@@ -93,15 +103,40 @@ func (service *ServiceImpl) CreateVehicleNotification(user *bootstrap.User, noti
 		}
 	}
 	if !isValidType {
-		return nil, errors.New("invalid notification type: must be M1, PM, or MW")
+		return nil, apperror.Validation("invalid_notification_type", "invalid notification type: must be M1, PM, or MW", apperror.FieldError{Field: "type", Message: "must be M1, PM, or MW"})
 	}
 
-	createdNotification, err := service.repo.CreateVehicleNotification(user, notification)
+	var createdNotification *model.ShopVehicleNotifications
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		createdNotification, err = service.repo.CreateVehicleNotification(ctx, user, notification)
+		if err != nil {
+			return fmt.Errorf("failed to create vehicle notification: %w", err)
+		}
+		err := service.events.Publish(ctx, events.VehicleNotificationCreated{
+			ShopID:         createdNotification.ShopID,
+			VehicleID:      createdNotification.VehicleID,
+			NotificationID: createdNotification.ID,
+			Type:           createdNotification.Type,
+			Title:          createdNotification.Title,
+			CreatedBy:      user.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     notification.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityVehicleNotification,
+			EntityID:   notification.ID,
+			Action:     audit.ActionCreate,
+			After:      createdNotification,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create vehicle notification: %w", err)
+		return nil, err
 	}
 
-	service.recordNotificationChange(
+	service.recordNotificationChange(ctx,
 		user,
 		notification.ID,
 		notification.ShopID,
@@ -117,26 +152,26 @@ func (service *ServiceImpl) CreateVehicleNotification(user *bootstrap.User, noti
 	return createdNotification, nil
 }
 
-func (service *ServiceImpl) GetVehicleNotifications(user *bootstrap.User, vehicleID string) ([]model.ShopVehicleNotifications, error) {
+func (service *ServiceImpl) GetVehicleNotifications(ctx context.Context, user *bootstrap.User, vehicleID string) ([]model.ShopVehicleNotifications, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, vehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, vehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, vehicle.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, vehicle.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	notifications, err := service.repo.GetVehicleNotifications(user, vehicleID)
+	notifications, err := service.repo.GetVehicleNotifications(ctx, user, vehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle notifications: %w", err)
 	}
@@ -148,26 +183,26 @@ func (service *ServiceImpl) GetVehicleNotifications(user *bootstrap.User, vehicl
 	return notifications, nil
 }
 
-func (service *ServiceImpl) GetVehicleNotificationsWithItems(user *bootstrap.User, vehicleID string) ([]response.VehicleNotificationWithItems, error) {
+func (service *ServiceImpl) GetVehicleNotificationsWithItems(ctx context.Context, user *bootstrap.User, vehicleID string) ([]response.VehicleNotificationWithItems, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, vehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, vehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, vehicle.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, vehicle.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	notificationsWithItems, err := service.repo.GetVehicleNotificationsWithItems(user, vehicleID)
+	notificationsWithItems, err := service.repo.GetVehicleNotificationsWithItems(ctx, user, vehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle notifications with items: %w", err)
 	}
@@ -179,21 +214,21 @@ func (service *ServiceImpl) GetVehicleNotificationsWithItems(user *bootstrap.Use
 	return notificationsWithItems, nil
 }
 
-func (service *ServiceImpl) GetShopNotifications(user *bootstrap.User, shopID string) ([]model.ShopVehicleNotifications, error) {
+func (service *ServiceImpl) GetShopNotifications(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopVehicleNotifications, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	notifications, err := service.repo.GetShopNotifications(user, shopID)
+	notifications, err := service.repo.GetShopNotifications(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop notifications: %w", err)
 	}
@@ -205,56 +240,56 @@ func (service *ServiceImpl) GetShopNotifications(user *bootstrap.User, shopID st
 	return notifications, nil
 }
 
-func (service *ServiceImpl) GetVehicleNotificationByID(user *bootstrap.User, notificationID string) (*model.ShopVehicleNotifications, error) {
+func (service *ServiceImpl) GetVehicleNotificationByID(ctx context.Context, user *bootstrap.User, notificationID string) (*model.ShopVehicleNotifications, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	notification, err := service.repo.GetVehicleNotificationByID(user, notificationID)
+	notification, err := service.repo.GetVehicleNotificationByID(ctx, user, notificationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicle notification: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, notification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, notification.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	return notification, nil
 }
 
-func (service *ServiceImpl) UpdateVehicleNotification(user *bootstrap.User, update VehicleNotificationUpdate) error {
+func (service *ServiceImpl) UpdateVehicleNotification(ctx context.Context, user *bootstrap.User, update VehicleNotificationUpdate) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
 	notification := update.Notification
 
-	currentNotification, err := service.repo.GetVehicleNotificationByID(user, notification.ID)
+	currentNotification, err := service.repo.GetVehicleNotificationByID(ctx, user, notification.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get current notification: %w", err)
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, currentNotification.VehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, currentNotification.VehicleID)
 	if err != nil {
 		return fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, currentNotification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, currentNotification.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("access denied: user is not a member of this shop")
+		return shared.ErrNotShopMember
 	}
 
 	if update.AttachedShopListSet {
-		if err := service.validateAttachedShopList(user, currentNotification.ShopID, update.AttachedShopList); err != nil {
+		if err := service.validateAttachedShopList(ctx, user, currentNotification.ShopID, update.AttachedShopList); err != nil {
 			return err
 		}
 		update.Notification.AttachedShopList = update.AttachedShopList
@@ -273,13 +308,36 @@ func (service *ServiceImpl) UpdateVehicleNotification(user *bootstrap.User, upda
 
 	changeType := determineChangeType(currentNotification, &notification)
 
+	updatedNotification := *currentNotification
+	updatedNotification.Title = notification.Title
+	updatedNotification.Description = notification.Description
+	updatedNotification.Type = notification.Type
+	updatedNotification.Completed = notification.Completed
+	updatedNotification.LastUpdated = notification.LastUpdated
+	updatedNotification.AttachedShopList = notification.AttachedShopList
+
 	update.Notification = notification
-	err = service.repo.UpdateVehicleNotification(user, update)
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.UpdateVehicleNotification(ctx, user, update)
+		if err != nil {
+			return fmt.Errorf("failed to update vehicle notification: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     currentNotification.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityVehicleNotification,
+			EntityID:   notification.ID,
+			Action:     audit.Action(changeType),
+			Before:     currentNotification,
+			After:      updatedNotification,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update vehicle notification: %w", err)
+		return err
 	}
 
-	service.recordNotificationChange(
+	service.recordNotificationChange(ctx,
 		user,
 		notification.ID,
 		currentNotification.ShopID,
@@ -295,31 +353,31 @@ func (service *ServiceImpl) UpdateVehicleNotification(user *bootstrap.User, upda
 	return nil
 }
 
-func (service *ServiceImpl) DeleteVehicleNotification(user *bootstrap.User, notificationID string) error {
+func (service *ServiceImpl) DeleteVehicleNotification(ctx context.Context, user *bootstrap.User, notificationID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	notification, err := service.repo.GetVehicleNotificationByID(user, notificationID)
+	notification, err := service.repo.GetVehicleNotificationByID(ctx, user, notificationID)
 	if err != nil {
 		return fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, notification.VehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, notification.VehicleID)
 	if err != nil {
 		return fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	isMember, err := service.repo.IsUserMemberOfShop(user, notification.ShopID)
+	isMember, err := service.repo.IsUserMemberOfShop(ctx, user, notification.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return errors.New("access denied: user is not a member of this shop")
+		return shared.ErrNotShopMember
 	}
 
-	service.recordNotificationChange(
+	service.recordNotificationChange(ctx,
 		user,
 		notificationID,
 		notification.ShopID,
@@ -331,17 +389,31 @@ func (service *ServiceImpl) DeleteVehicleNotification(user *bootstrap.User, noti
 		vehicle.Admin,
 	)
 
-	err = service.repo.DeleteVehicleNotification(user, notificationID)
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.DeleteVehicleNotification(ctx, user, notificationID)
+		if err != nil {
+			return fmt.Errorf("failed to delete vehicle notification: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     notification.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityVehicleNotification,
+			EntityID:   notificationID,
+			Action:     audit.ActionDelete,
+			Before:     notification,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete vehicle notification: %w", err)
+		return err
 	}
 
 	slog.Info("Vehicle notification deleted", "user_id", user.UserID, "notification_id", notificationID)
 	return nil
 }
 
-// recordNotificationChange is a helper to record audit trail changes (best-effort)
-func (service *ServiceImpl) recordNotificationChange(
+// recordNotificationChange is a helper to record audit trail changes and publish them (best-effort)
+func (service *ServiceImpl) recordNotificationChange(ctx context.Context,
 	user *bootstrap.User,
 	notificationID string,
 	shopID string,
@@ -364,7 +436,18 @@ func (service *ServiceImpl) recordNotificationChange(
 		VehicleAdmin:      &vehicleAdmin,
 	}
 
-	err := service.repo.CreateNotificationChange(user, change)
+	err := service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.CreateNotificationChange(ctx, user, change); err != nil {
+			return err
+		}
+		return service.events.Publish(ctx, events.VehicleNotificationChanged{
+			ShopID:         shopID,
+			VehicleID:      vehicleID,
+			NotificationID: notificationID,
+			ChangeType:     changeType,
+			ChangedBy:      user.UserID,
+		})
+	})
 	if err != nil {
 		slog.Warn("Failed to record notification change", "error", err, "notification_id", notificationID, "change_type", changeType)
 	}
@@ -410,12 +493,13 @@ func sameStringPtr(left, right *string) bool {
 	return *left == *right
 }
 
+// determineChangeType returns the change type, which is also the audit action.
 func determineChangeType(old, new *model.ShopVehicleNotifications) string {
 	if !old.Completed && new.Completed {
-		return "complete"
+		return string(audit.ActionComplete)
 	}
 	if old.Completed && !new.Completed {
-		return "reopen"
+		return string(audit.ActionReopen)
 	}
-	return "update"
+	return string(audit.ActionUpdate)
 }
@@ -1,15 +1,16 @@
 package vehicles
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
 
 type Repository interface {
-	CreateShopVehicle(user *bootstrap.User, vehicle model.ShopVehicle) (*model.ShopVehicle, error)
-	GetShopVehicles(user *bootstrap.User, shopID string) ([]model.ShopVehicle, error)
-	GetShopVehicleByID(user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error)
-	UpdateShopVehicle(user *bootstrap.User, vehicle model.ShopVehicle) error
-	DeleteShopVehicle(user *bootstrap.User, vehicleID string) error
-	CreateNotificationChange(user *bootstrap.User, change model.ShopVehicleNotificationChanges) error
+	CreateShopVehicle(ctx context.Context, user *bootstrap.User, vehicle model.ShopVehicle) (*model.ShopVehicle, error)
+	GetShopVehicles(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopVehicle, error)
+	GetShopVehicleByID(ctx context.Context, user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error)
+	UpdateShopVehicle(ctx context.Context, user *bootstrap.User, vehicle model.ShopVehicle) error
+	DeleteShopVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) error
+	CreateNotificationChange(ctx context.Context, user *bootstrap.User, change model.ShopVehicleNotificationChanges) error
 }
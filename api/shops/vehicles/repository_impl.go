@@ -1,12 +1,14 @@
 package vehicles
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
+	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/dbtx"
 
 	"github.com/go-jet/jet/v2/postgres"
 	. "github.com/go-jet/jet/v2/postgres"
@@ -20,7 +22,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) CreateShopVehicle(user *bootstrap.User, vehicle model.ShopVehicle) (*model.ShopVehicle, error) {
+func (repo *RepositoryImpl) CreateShopVehicle(ctx context.Context, user *bootstrap.User, vehicle model.ShopVehicle) (*model.ShopVehicle, error) {
 	stmt := ShopVehicle.INSERT(
 		ShopVehicle.ID,
 		ShopVehicle.CreatorID,
@@ -38,7 +40,7 @@ func (repo *RepositoryImpl) CreateShopVehicle(user *bootstrap.User, vehicle mode
 	).MODEL(vehicle).RETURNING(ShopVehicle.AllColumns)
 
 	var createdVehicle model.ShopVehicle
-	err := stmt.Query(repo.db, &createdVehicle)
+	err := stmt.QueryContext(ctx, dbtx.From(ctx, repo.db), &createdVehicle)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create shop vehicle: %w", err)
 	}
@@ -46,14 +48,14 @@ func (repo *RepositoryImpl) CreateShopVehicle(user *bootstrap.User, vehicle mode
 	return &createdVehicle, nil
 }
 
-func (repo *RepositoryImpl) GetShopVehicles(user *bootstrap.User, shopID string) ([]model.ShopVehicle, error) {
+func (repo *RepositoryImpl) GetShopVehicles(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopVehicle, error) {
 	stmt := SELECT(ShopVehicle.AllColumns).
 		FROM(ShopVehicle).
 		WHERE(ShopVehicle.ShopID.EQ(String(shopID))).
 		ORDER_BY(ShopVehicle.SaveTime.DESC())
 
 	var vehicles []model.ShopVehicle
-	err := stmt.Query(repo.db, &vehicles)
+	err := stmt.QueryContext(ctx, repo.db, &vehicles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop vehicles: %w", err)
 	}
@@ -61,13 +63,13 @@ func (repo *RepositoryImpl) GetShopVehicles(user *bootstrap.User, shopID string)
 	return vehicles, nil
 }
 
-func (repo *RepositoryImpl) GetShopVehicleByID(user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error) {
+func (repo *RepositoryImpl) GetShopVehicleByID(ctx context.Context, user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error) {
 	stmt := SELECT(ShopVehicle.AllColumns).
 		FROM(ShopVehicle).
 		WHERE(ShopVehicle.ID.EQ(String(vehicleID)))
 
 	var vehicle model.ShopVehicle
-	err := stmt.Query(repo.db, &vehicle)
+	err := stmt.QueryContext(ctx, repo.db, &vehicle)
 	if err != nil {
 		return nil, fmt.Errorf("shop vehicle not found: %w", err)
 	}
@@ -75,7 +77,7 @@ func (repo *RepositoryImpl) GetShopVehicleByID(user *bootstrap.User, vehicleID s
 	return &vehicle, nil
 }
 
-func (repo *RepositoryImpl) UpdateShopVehicle(user *bootstrap.User, vehicle model.ShopVehicle) error {
+func (repo *RepositoryImpl) UpdateShopVehicle(ctx context.Context, user *bootstrap.User, vehicle model.ShopVehicle) error {
 	setClauses := []postgres.ColumnAssigment{
 		ShopVehicle.Model.SET(String(vehicle.Model)),
 		ShopVehicle.Serial.SET(String(vehicle.Serial)),
@@ -102,7 +104,7 @@ func (repo *RepositoryImpl) UpdateShopVehicle(user *bootstrap.User, vehicle mode
 
 	stmt := ShopVehicle.UPDATE().SET(setArgs[0], setArgs[1:]...).WHERE(ShopVehicle.ID.EQ(String(vehicle.ID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to update shop vehicle: %w", err)
 	}
@@ -113,17 +115,17 @@ func (repo *RepositoryImpl) UpdateShopVehicle(user *bootstrap.User, vehicle mode
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("vehicle not found")
+		return shared.ErrVehicleNotFound
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteShopVehicle(user *bootstrap.User, vehicleID string) error {
+func (repo *RepositoryImpl) DeleteShopVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) error {
 	stmt := ShopVehicle.DELETE().
 		WHERE(ShopVehicle.ID.EQ(String(vehicleID)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, dbtx.From(ctx, repo.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete shop vehicle: %w", err)
 	}
@@ -134,13 +136,13 @@ func (repo *RepositoryImpl) DeleteShopVehicle(user *bootstrap.User, vehicleID st
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("vehicle not found")
+		return shared.ErrVehicleNotFound
 	}
 
 	return nil
 }
 
-func (repo *RepositoryImpl) CreateNotificationChange(user *bootstrap.User, change model.ShopVehicleNotificationChanges) error {
+func (repo *RepositoryImpl) CreateNotificationChange(ctx context.Context, user *bootstrap.User, change model.ShopVehicleNotificationChanges) error {
 	rawSQL := `
 		INSERT INTO shop_vehicle_notification_changes (
 			notification_id,
@@ -155,7 +157,7 @@ func (repo *RepositoryImpl) CreateNotificationChange(user *bootstrap.User, chang
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := repo.db.Exec(
+	_, err := dbtx.From(ctx, repo.db).ExecContext(ctx,
 		rawSQL,
 		change.NotificationID,
 		change.ShopID,
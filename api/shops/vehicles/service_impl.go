@@ -1,49 +1,58 @@
 package vehicles
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
+	"miltechserver/api/shops/audit"
 	"miltechserver/api/shops/shared"
 	"miltechserver/bootstrap"
+	"miltechserver/events"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type ServiceImpl struct {
-	repo Repository
-	auth shared.ShopAuthorization
+	repo   Repository
+	auth   shared.ShopAuthorization
+	audit  audit.Recorder
+	events events.Publisher
 }
 
-func NewService(repo Repository, auth shared.ShopAuthorization) *ServiceImpl {
+func NewService(repo Repository, auth shared.ShopAuthorization, recorder audit.Recorder, publisher events.Publisher) *ServiceImpl {
 	return &ServiceImpl{
-		repo: repo,
-		auth: auth,
+		repo:   repo,
+		auth:   auth,
+		audit:  recorder,
+		events: publisher,
 	}
 }
 
 func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
 	return &ServiceImpl{
-		repo: service.repo,
-		auth: auth,
+		repo:   service.repo,
+		auth:   auth,
+		audit:  service.audit,
+		events: service.events,
 	}
 }
 
-func (service *ServiceImpl) CreateShopVehicle(user *bootstrap.User, vehicle model.ShopVehicle) (*model.ShopVehicle, error) {
+func (service *ServiceImpl) CreateShopVehicle(ctx context.Context, user *bootstrap.User, vehicle model.ShopVehicle) (*model.ShopVehicle, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, vehicle.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, vehicle.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	vehicle.ID = uuid.New().String()
@@ -56,30 +65,45 @@ func (service *ServiceImpl) CreateShopVehicle(user *bootstrap.User, vehicle mode
 		vehicle.Uoc = "UNK"
 	}
 
-	createdVehicle, err := service.repo.CreateShopVehicle(user, vehicle)
+	var createdVehicle *model.ShopVehicle
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		createdVehicle, err = service.repo.CreateShopVehicle(ctx, user, vehicle)
+		if err != nil {
+			return fmt.Errorf("failed to create shop vehicle: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     vehicle.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShopVehicle,
+			EntityID:   vehicle.ID,
+			Action:     audit.ActionCreate,
+			After:      createdVehicle,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create shop vehicle: %w", err)
+		return nil, err
 	}
 
 	slog.Info("Shop vehicle created", "user_id", user.UserID, "shop_id", vehicle.ShopID, "vehicle_id", vehicle.ID)
 	return createdVehicle, nil
 }
 
-func (service *ServiceImpl) GetShopVehicles(user *bootstrap.User, shopID string) ([]model.ShopVehicle, error) {
+func (service *ServiceImpl) GetShopVehicles(ctx context.Context, user *bootstrap.User, shopID string) ([]model.ShopVehicle, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, shopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
-	vehicles, err := service.repo.GetShopVehicles(user, shopID)
+	vehicles, err := service.repo.GetShopVehicles(ctx, user, shopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop vehicles: %w", err)
 	}
@@ -91,34 +115,34 @@ func (service *ServiceImpl) GetShopVehicles(user *bootstrap.User, shopID string)
 	return vehicles, nil
 }
 
-func (service *ServiceImpl) GetShopVehicleByID(user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error) {
+func (service *ServiceImpl) GetShopVehicleByID(ctx context.Context, user *bootstrap.User, vehicleID string) (*model.ShopVehicle, error) {
 	if user == nil {
-		return nil, errors.New("unauthorized user")
+		return nil, shared.ErrUnauthorizedUser
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, vehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, vehicleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shop vehicle: %w", err)
 	}
 
-	isMember, err := service.auth.IsUserMemberOfShop(user, vehicle.ShopID)
+	isMember, err := service.auth.IsUserMemberOfShop(ctx, user, vehicle.ShopID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify membership: %w", err)
 	}
 
 	if !isMember {
-		return nil, errors.New("access denied: user is not a member of this shop")
+		return nil, shared.ErrNotShopMember
 	}
 
 	return vehicle, nil
 }
 
-func (service *ServiceImpl) UpdateShopVehicle(user *bootstrap.User, vehicle model.ShopVehicle) error {
+func (service *ServiceImpl) UpdateShopVehicle(ctx context.Context, user *bootstrap.User, vehicle model.ShopVehicle) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	currentVehicle, err := service.repo.GetShopVehicleByID(user, vehicle.ID)
+	currentVehicle, err := service.repo.GetShopVehicleByID(ctx, user, vehicle.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get current vehicle: %w", err)
 	}
@@ -126,13 +150,13 @@ func (service *ServiceImpl) UpdateShopVehicle(user *bootstrap.User, vehicle mode
 	vehicle.ShopID = currentVehicle.ShopID
 
 	isCreator := currentVehicle.CreatorID == user.UserID
-	isAdmin, err := service.auth.IsUserShopAdmin(user, currentVehicle.ShopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, currentVehicle.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isCreator && !isAdmin {
-		return errors.New("access denied: only vehicle creator or shop admin can update vehicles")
+		return apperror.Forbidden("vehicle_access_denied", "access denied: only vehicle creator or shop admin can update vehicles")
 	}
 
 	if vehicle.Uoc == "" {
@@ -141,33 +165,48 @@ func (service *ServiceImpl) UpdateShopVehicle(user *bootstrap.User, vehicle mode
 
 	vehicle.LastUpdated = time.Now().UTC()
 
-	err = service.repo.UpdateShopVehicle(user, vehicle)
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		err := service.repo.UpdateShopVehicle(ctx, user, vehicle)
+		if err != nil {
+			return fmt.Errorf("failed to update shop vehicle: %w", err)
+		}
+
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     currentVehicle.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShopVehicle,
+			EntityID:   vehicle.ID,
+			Action:     audit.ActionUpdate,
+			Before:     currentVehicle,
+			After:      updatedVehicle(*currentVehicle, vehicle),
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update shop vehicle: %w", err)
+		return err
 	}
 
 	slog.Info("Shop vehicle updated", "user_id", user.UserID, "vehicle_id", vehicle.ID)
 	return nil
 }
 
-func (service *ServiceImpl) DeleteShopVehicle(user *bootstrap.User, vehicleID string) error {
+func (service *ServiceImpl) DeleteShopVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) error {
 	if user == nil {
-		return errors.New("unauthorized user")
+		return shared.ErrUnauthorizedUser
 	}
 
-	vehicle, err := service.repo.GetShopVehicleByID(user, vehicleID)
+	vehicle, err := service.repo.GetShopVehicleByID(ctx, user, vehicleID)
 	if err != nil {
 		return fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
 	isCreator := vehicle.CreatorID == user.UserID
-	isAdmin, err := service.auth.IsUserShopAdmin(user, vehicle.ShopID)
+	isAdmin, err := service.auth.IsUserShopAdmin(ctx, user, vehicle.ShopID)
 	if err != nil {
 		return fmt.Errorf("failed to verify admin status: %w", err)
 	}
 
 	if !isCreator && !isAdmin {
-		return errors.New("access denied: only vehicle creator or shop admin can delete vehicles")
+		return apperror.Forbidden("vehicle_access_denied", "access denied: only vehicle creator or shop admin can delete vehicles")
 	}
 
 	vehicleDeletionChange := model.ShopVehicleNotificationChanges{
@@ -182,20 +221,60 @@ func (service *ServiceImpl) DeleteShopVehicle(user *bootstrap.User, vehicleID st
 		VehicleAdmin:      &vehicle.Admin,
 	}
 
-	err = service.repo.CreateNotificationChange(user, vehicleDeletionChange)
+	err = service.repo.CreateNotificationChange(ctx, user, vehicleDeletionChange)
 	if err != nil {
 		slog.Warn("Failed to record vehicle deletion audit", "error", err, "vehicle_id", vehicleID)
 	}
 
-	err = service.repo.DeleteShopVehicle(user, vehicleID)
+	err = service.events.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.DeleteShopVehicle(ctx, user, vehicleID); err != nil {
+			return fmt.Errorf("failed to delete shop vehicle: %w", err)
+		}
+		err := service.events.Publish(ctx, events.VehicleNotificationChanged{
+			ShopID:     vehicle.ShopID,
+			VehicleID:  vehicleID,
+			ChangeType: vehicleDeletionChange.ChangeType,
+			ChangedBy:  user.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		return service.audit.Record(ctx, audit.Entry{
+			ShopID:     vehicle.ShopID,
+			ActorID:    user.UserID,
+			EntityType: audit.EntityShopVehicle,
+			EntityID:   vehicleID,
+			Action:     audit.ActionDelete,
+			Before:     vehicle,
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete shop vehicle: %w", err)
+		return err
 	}
 
 	slog.Info("Shop vehicle deleted", "user_id", user.UserID, "vehicle_id", vehicleID, "vehicle_admin", vehicle.Admin)
 	return nil
 }
 
+// updatedVehicle applies the fields UpdateShopVehicle writes to current.
+func updatedVehicle(current, update model.ShopVehicle) model.ShopVehicle {
+	current.Model = update.Model
+	current.Serial = update.Serial
+	current.Niin = update.Niin
+	current.Uoc = update.Uoc
+	current.Mileage = update.Mileage
+	current.Hours = update.Hours
+	current.Comment = update.Comment
+	current.LastUpdated = update.LastUpdated
+	if update.TrackedMileage != nil {
+		current.TrackedMileage = update.TrackedMileage
+	}
+	if update.TrackedHours != nil {
+		current.TrackedHours = update.TrackedHours
+	}
+	return current
+}
+
 // buildVehicleDeletionFieldChanges creates field changes JSON for vehicle deletion
 func buildVehicleDeletionFieldChanges(vehicle *model.ShopVehicle) string {
 	type VehicleData struct {
@@ -0,0 +1,133 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"miltechserver/events"
+	"miltechserver/webhook"
+)
+
+// SubscriberName is the Deliverer's name on the event bus. It is stored
+// with every outbox event, so it must not change.
+const SubscriberName = "shop-webhooks"
+
+// logTimeout bounds writing a delivery to the log.
+const logTimeout = 5 * time.Second
+
+// Deliverer posts events to webhooks and logs every attempt.
+type Deliverer struct {
+	repo   Repository
+	sender *webhook.Sender
+}
+
+func NewDeliverer(repo Repository, sender *webhook.Sender) *Deliverer {
+	return &Deliverer{repo: repo, sender: sender}
+}
+
+// Subscribe registers the Deliverer on bus for every event type a webhook
+// can choose.
+func (d *Deliverer) Subscribe(bus *events.Bus) {
+	bus.Subscribe(SubscriberName, d.Handle, EventTypes...)
+}
+
+// Handle posts env to the shop's active webhooks for its type, all at once,
+// skipping those that already have it. It fails if any webhook does, so the
+// bus redelivers the event to the ones still missing it.
+func (d *Deliverer) Handle(ctx context.Context, env events.Envelope) error {
+	if env.ShopID == "" {
+		return nil
+	}
+	webhooks, err := d.repo.GetActiveWebhooksForEvent(ctx, env.ShopID, env.Type)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+	delivered, err := d.repo.GetSucceededWebhookIDs(ctx, env.ID)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload{
+		ID:         env.ID,
+		Type:       env.Type,
+		ShopID:     env.ShopID,
+		OccurredAt: env.OccurredAt,
+		Data:       env.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	errs := make([]error, len(webhooks))
+	var wg sync.WaitGroup
+	for i, hook := range webhooks {
+		if delivered[hook.ID] {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := d.send(ctx, hook, env.ID, env.Type, body, TriggerEvent, env.Attempt); err != nil {
+				errs[i] = fmt.Errorf("webhook %s: %w", hook.ID, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// send posts body to hook and logs the attempt. It returns the logged
+// delivery and the reason the request failed, if it did.
+func (d *Deliverer) send(ctx context.Context,
+	hook Webhook,
+	eventID int64,
+	eventType string,
+	body []byte,
+	trigger string,
+	attempt int,
+) (*Delivery, error) {
+	result := d.sender.Send(ctx, hook.URL, hook.secret, webhook.Message{
+		EventID:   eventID,
+		EventType: eventType,
+		Body:      body,
+	})
+
+	delivery := Delivery{
+		WebhookID:  hook.ID,
+		EventID:    eventID,
+		EventType:  eventType,
+		Trigger:    trigger,
+		Attempt:    attempt,
+		Status:     StatusSucceeded,
+		DurationMS: result.Duration.Milliseconds(),
+		Payload:    body,
+	}
+	if result.StatusCode != 0 {
+		delivery.ResponseStatus = &result.StatusCode
+	}
+	if result.Err != nil {
+		message := result.Err.Error()
+		delivery.Status = StatusFailed
+		delivery.Error = &message
+	}
+
+	// Logged even if the request's context has gone, so the log shows
+	// what the endpoint saw.
+	logCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), logTimeout)
+	defer cancel()
+	logged, err := d.repo.InsertDelivery(logCtx, delivery)
+	if err != nil {
+		slog.Error("Failed to log webhook delivery",
+			"error", err,
+			"webhook_id", hook.ID,
+			"event_id", eventID,
+			"status", delivery.Status)
+		logged = &delivery
+	}
+	return logged, result.Err
+}
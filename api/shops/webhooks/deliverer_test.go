@@ -0,0 +1,287 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/request"
+	"miltechserver/api/shops/audit"
+	"miltechserver/api/shops/shared"
+	"miltechserver/bootstrap"
+	"miltechserver/events"
+	"miltechserver/webhook"
+
+	"github.com/stretchr/testify/require"
+)
+
+// repositoryStub keeps webhooks and their delivery log in memory.
+type repositoryStub struct {
+	Repository
+	mu         sync.Mutex
+	webhooks   []Webhook
+	deliveries []Delivery
+}
+
+func (stub *repositoryStub) GetShopWebhook(_ context.Context, shopID string, webhookID string) (*Webhook, error) {
+	for _, hook := range stub.webhooks {
+		if hook.ShopID == shopID && hook.ID == webhookID {
+			return &hook, nil
+		}
+	}
+	return nil, ErrWebhookNotFound
+}
+
+func (stub *repositoryStub) CountShopWebhooks(_ context.Context, shopID string) (int, error) {
+	count := 0
+	for _, hook := range stub.webhooks {
+		if hook.ShopID == shopID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (stub *repositoryStub) CreateWebhook(_ context.Context, hook Webhook, secret string) (*Webhook, error) {
+	hook.secret = secret
+	stub.webhooks = append(stub.webhooks, hook)
+	return &hook, nil
+}
+
+func (stub *repositoryStub) GetActiveWebhooksForEvent(_ context.Context, shopID string, eventType string) ([]Webhook, error) {
+	var matching []Webhook
+	for _, hook := range stub.webhooks {
+		for _, subscribed := range hook.EventTypes {
+			if hook.ShopID == shopID && hook.Active && subscribed == eventType {
+				matching = append(matching, hook)
+			}
+		}
+	}
+	return matching, nil
+}
+
+func (stub *repositoryStub) GetSucceededWebhookIDs(_ context.Context, eventID int64) (map[string]bool, error) {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	delivered := map[string]bool{}
+	for _, delivery := range stub.deliveries {
+		if delivery.EventID == eventID && delivery.Status == StatusSucceeded {
+			delivered[delivery.WebhookID] = true
+		}
+	}
+	return delivered, nil
+}
+
+func (stub *repositoryStub) InsertDelivery(_ context.Context, delivery Delivery) (*Delivery, error) {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	delivery.ID = int64(len(stub.deliveries) + 1)
+	stub.deliveries = append(stub.deliveries, delivery)
+	return &delivery, nil
+}
+
+func (stub *repositoryStub) GetDelivery(_ context.Context, webhookID string, deliveryID int64) (*Delivery, error) {
+	for _, delivery := range stub.deliveries {
+		if delivery.WebhookID == webhookID && delivery.ID == deliveryID {
+			return &delivery, nil
+		}
+	}
+	return nil, ErrDeliveryNotFound
+}
+
+// endpoint is a local stand-in for a shop's webhook receiver. It records
+// the requests whose signature verifies and answers with status.
+type endpoint struct {
+	mu       sync.Mutex
+	status   int
+	secret   string
+	received []string
+	server   *httptest.Server
+}
+
+func newEndpoint(t *testing.T, secret string, status int) *endpoint {
+	t.Helper()
+	e := &endpoint{status: status, secret: secret}
+	e.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		if !webhook.Verify(e.secret, timestamp, body, r.Header.Get(webhook.HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.received = append(e.received, string(body))
+		w.WriteHeader(e.status)
+	}))
+	t.Cleanup(e.server.Close)
+	return e
+}
+
+func (e *endpoint) setStatus(status int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status = status
+}
+
+func (e *endpoint) count() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.received)
+}
+
+func memberJoined(t *testing.T, id int64, shopID string) events.Envelope {
+	t.Helper()
+	payload, err := json.Marshal(events.MemberJoined{ShopID: shopID, UserID: "user-2", Role: "member"})
+	require.NoError(t, err)
+	return events.Envelope{
+		ID:         id,
+		Type:       events.TypeMemberJoined,
+		ShopID:     shopID,
+		Payload:    payload,
+		OccurredAt: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC),
+		Attempt:    1,
+	}
+}
+
+func TestHandleRetriesOnlyFailedWebhooks(t *testing.T) {
+	healthy := newEndpoint(t, "whsec_healthy", http.StatusOK)
+	flaky := newEndpoint(t, "whsec_flaky", http.StatusServiceUnavailable)
+	other := newEndpoint(t, "whsec_other", http.StatusOK)
+	repo := &repositoryStub{webhooks: []Webhook{
+		{ID: "healthy", ShopID: "shop-1", URL: healthy.server.URL, EventTypes: []string{events.TypeMemberJoined}, Active: true, secret: healthy.secret},
+		{ID: "flaky", ShopID: "shop-1", URL: flaky.server.URL, EventTypes: []string{events.TypeMemberJoined}, Active: true, secret: flaky.secret},
+		{ID: "other-type", ShopID: "shop-1", URL: other.server.URL, EventTypes: []string{events.TypeMemberLeft}, Active: true, secret: other.secret},
+		{ID: "inactive", ShopID: "shop-1", URL: other.server.URL, EventTypes: []string{events.TypeMemberJoined}, secret: other.secret},
+	}}
+	deliverer := NewDeliverer(repo, webhook.NewSender(true))
+	env := memberJoined(t, 42, "shop-1")
+
+	err := deliverer.Handle(context.Background(), env)
+	require.ErrorContains(t, err, "webhook flaky: endpoint returned 503")
+	require.Equal(t, 1, healthy.count())
+	require.Equal(t, 0, other.count())
+	require.Len(t, repo.deliveries, 2)
+
+	var body payload
+	require.NoError(t, json.Unmarshal([]byte(healthy.received[0]), &body))
+	require.Equal(t, int64(42), body.ID)
+	require.Equal(t, events.TypeMemberJoined, body.Type)
+	require.Equal(t, "shop-1", body.ShopID)
+	require.JSONEq(t, string(env.Payload), string(body.Data))
+
+	// The bus redelivers; only the webhook still missing the event is sent it
+	flaky.setStatus(http.StatusNoContent)
+	env.Attempt = 2
+	require.NoError(t, deliverer.Handle(context.Background(), env))
+	require.Equal(t, 1, healthy.count())
+	require.Equal(t, 1, flaky.count())
+
+	last := repo.deliveries[len(repo.deliveries)-1]
+	require.Equal(t, "flaky", last.WebhookID)
+	require.Equal(t, StatusSucceeded, last.Status)
+	require.Equal(t, 2, last.Attempt)
+	require.Equal(t, http.StatusNoContent, *last.ResponseStatus)
+}
+
+func TestHandleIgnoresEventsWithoutShop(t *testing.T) {
+	deliverer := NewDeliverer(&repositoryStub{}, webhook.NewSender(true))
+	require.NoError(t, deliverer.Handle(context.Background(), memberJoined(t, 1, "")))
+}
+
+// adminAuthorization lets user-admin administer every shop.
+type adminAuthorization struct {
+	shared.ShopAuthorization
+}
+
+func (adminAuthorization) RequireShopAdmin(_ context.Context, user *bootstrap.User, _ string) error {
+	if user.UserID != "user-admin" {
+		return shared.ErrShopAdminRequired
+	}
+	return nil
+}
+
+type recorderStub struct{ entries []audit.Entry }
+
+func (stub *recorderStub) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (stub *recorderStub) Record(_ context.Context, entry audit.Entry) error {
+	stub.entries = append(stub.entries, entry)
+	return nil
+}
+
+func TestCreateShopWebhookValidates(t *testing.T) {
+	admin := &bootstrap.User{UserID: "user-admin"}
+	recorder := &recorderStub{}
+	repo := &repositoryStub{}
+	service := NewService(repo, adminAuthorization{}, recorder, nil, false)
+
+	created, err := service.CreateShopWebhook(context.Background(), admin, "shop-1", request.CreateShopWebhookRequest{
+		URL:        " https://tracker.example.mil/hooks ",
+		EventTypes: []string{events.TypeMemberJoined, events.TypeMemberJoined, events.TypePMCSFaultRecorded},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://tracker.example.mil/hooks", created.URL)
+	require.Equal(t, []string{events.TypeMemberJoined, events.TypePMCSFaultRecorded}, created.EventTypes)
+	require.Equal(t, repo.webhooks[0].secret, created.Secret)
+	require.Len(t, recorder.entries, 1)
+	require.Equal(t, audit.EntityWebhook, recorder.entries[0].EntityType)
+
+	for field, req := range map[string]request.CreateShopWebhookRequest{
+		"url":         {URL: "http://tracker.example.mil/hooks", EventTypes: []string{events.TypeMemberJoined}},
+		"event_types": {URL: "https://tracker.example.mil/hooks", EventTypes: []string{events.TypeMessageCreated}},
+	} {
+		_, err := service.CreateShopWebhook(context.Background(), admin, "shop-1", req)
+		var appErr *apperror.Error
+		require.ErrorAs(t, err, &appErr, field)
+		require.Equal(t, apperror.KindValidation, appErr.Kind, field)
+		require.Equal(t, field, appErr.Fields[0].Field)
+	}
+
+	_, err = service.CreateShopWebhook(context.Background(), &bootstrap.User{UserID: "user-member"}, "shop-1",
+		request.CreateShopWebhookRequest{URL: "https://tracker.example.mil/hooks", EventTypes: []string{events.TypeMemberJoined}})
+	require.ErrorIs(t, err, shared.ErrShopAdminRequired)
+}
+
+func TestCreateShopWebhookEnforcesLimit(t *testing.T) {
+	repo := &repositoryStub{}
+	for i := range maxWebhooksPerShop {
+		repo.webhooks = append(repo.webhooks, Webhook{ID: strconv.Itoa(i), ShopID: "shop-1"})
+	}
+	service := NewService(repo, adminAuthorization{}, &recorderStub{}, nil, false)
+
+	_, err := service.CreateShopWebhook(context.Background(), &bootstrap.User{UserID: "user-admin"}, "shop-1",
+		request.CreateShopWebhookRequest{URL: "https://tracker.example.mil/hooks", EventTypes: []string{events.TypeMemberJoined}})
+	require.ErrorIs(t, err, ErrTooManyWebhooks)
+}
+
+func TestReplayDeliveryResendsLoggedPayload(t *testing.T) {
+	receiver := newEndpoint(t, "whsec_replay", http.StatusOK)
+	repo := &repositoryStub{
+		webhooks: []Webhook{{ID: "hook-1", ShopID: "shop-1", URL: receiver.server.URL, Active: true, secret: receiver.secret}},
+		deliveries: []Delivery{{
+			ID: 1, WebhookID: "hook-1", EventID: 42, EventType: events.TypeMemberJoined,
+			Trigger: TriggerEvent, Attempt: 10, Status: StatusFailed, Payload: json.RawMessage(`{"id":42}`),
+		}},
+	}
+	service := NewService(repo, adminAuthorization{}, &recorderStub{}, NewDeliverer(repo, webhook.NewSender(true)), true)
+
+	replayed, err := service.ReplayDelivery(context.Background(), &bootstrap.User{UserID: "user-admin"}, "shop-1", "hook-1", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), replayed.ID)
+	require.Equal(t, TriggerReplay, replayed.Trigger)
+	require.Equal(t, StatusSucceeded, replayed.Status)
+	require.Equal(t, []string{`{"id":42}`}, receiver.received)
+
+	_, err = service.ReplayDelivery(context.Background(), &bootstrap.User{UserID: "user-admin"}, "shop-1", "hook-1", 9)
+	require.ErrorIs(t, err, ErrDeliveryNotFound)
+}
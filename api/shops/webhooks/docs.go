@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"miltechserver/api/request"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Shop Webhooks",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getShopWebhooks",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/webhooks",
+			Auth:     true,
+			Summary:  "Returns the shop's webhooks (shop admins only)",
+			Response: []Webhook{},
+		},
+		{
+			ID:       "createShopWebhook",
+			Method:   http.MethodPost,
+			Path:     "/shops/:shop_id/webhooks",
+			Auth:     true,
+			Summary:  "Registers a webhook for the chosen event types and returns its signing secret, which is shown only once (shop admins only)",
+			Request:  request.CreateShopWebhookRequest{},
+			Status:   http.StatusCreated,
+			Response: CreatedWebhook{},
+		},
+		{
+			ID:       "updateShopWebhook",
+			Method:   http.MethodPut,
+			Path:     "/shops/:shop_id/webhooks/:webhook_id",
+			Auth:     true,
+			Summary:  "Updates a webhook's URL, event types, description or active flag (shop admins only)",
+			Request:  request.UpdateShopWebhookRequest{},
+			Response: Webhook{},
+		},
+		{
+			ID:      "deleteShopWebhook",
+			Method:  http.MethodDelete,
+			Path:    "/shops/:shop_id/webhooks/:webhook_id",
+			Auth:    true,
+			Summary: "Deletes a webhook and its delivery log (shop admins only)",
+		},
+		{
+			ID:       "getShopWebhookDeliveries",
+			Method:   http.MethodGet,
+			Path:     "/shops/:shop_id/webhooks/:webhook_id/deliveries",
+			Auth:     true,
+			Summary:  "Returns the webhook's delivery attempts, newest first (shop admins only)",
+			Query:    pagination.Query,
+			Response: pagination.Page[Delivery]{},
+		},
+		{
+			ID:       "replayShopWebhookDelivery",
+			Method:   http.MethodPost,
+			Path:     "/shops/:shop_id/webhooks/:webhook_id/deliveries/:delivery_id/replay",
+			Auth:     true,
+			Summary:  "Sends a logged delivery's payload to the webhook again, freshly signed, and returns the new delivery (shop admins only)",
+			Response: Delivery{},
+		},
+	},
+}}
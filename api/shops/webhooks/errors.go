@@ -0,0 +1,10 @@
+package webhooks
+
+import "miltechserver/api/apperror"
+
+var (
+	ErrWebhookNotFound  = apperror.NotFound("webhook_not_found", "webhook not found")
+	ErrDeliveryNotFound = apperror.NotFound("webhook_delivery_not_found", "webhook delivery not found")
+	ErrTooManyWebhooks  = apperror.Conflict("too_many_webhooks", "a shop can register at most 10 webhooks")
+	ErrWebhookInactive  = apperror.Conflict("webhook_inactive", "the webhook is inactive")
+)
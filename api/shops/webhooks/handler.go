@@ -0,0 +1,232 @@
+package webhooks
+
+import (
+	"log/slog"
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
+	"miltechserver/api/request"
+	"miltechserver/api/response"
+	"miltechserver/bootstrap"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultDeliveryLimit = 50
+
+type Handler struct {
+	service Service
+}
+
+// GetShopWebhooks returns the shop's registered webhooks
+func (handler *Handler) GetShopWebhooks(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	if shopID == "" {
+		c.Error(apperror.MissingParameter("shop_id"))
+		return
+	}
+
+	service := handler.service
+	webhooks, err := service.GetShopWebhooks(c.Request.Context(), user, shopID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, response.StandardResponse{
+		Status:  200,
+		Message: "",
+		Data:    webhooks,
+	})
+}
+
+// CreateShopWebhook registers a webhook. The response carries the signing
+// secret, which is never returned again.
+func (handler *Handler) CreateShopWebhook(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	if shopID == "" {
+		c.Error(apperror.MissingParameter("shop_id"))
+		return
+	}
+
+	var req request.CreateShopWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.Info("invalid request", "error", err)
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	service := handler.service
+	created, err := service.CreateShopWebhook(c.Request.Context(), user, shopID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(201, response.StandardResponse{
+		Status:  201,
+		Message: "Webhook created successfully",
+		Data:    created,
+	})
+}
+
+// UpdateShopWebhook changes a webhook's URL, event types, description or
+// active flag
+func (handler *Handler) UpdateShopWebhook(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	webhookID := c.Param("webhook_id")
+	if shopID == "" || webhookID == "" {
+		c.Error(apperror.MissingParameter("shop_id and webhook_id"))
+		return
+	}
+
+	var req request.UpdateShopWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.Info("invalid request", "error", err)
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	service := handler.service
+	updated, err := service.UpdateShopWebhook(c.Request.Context(), user, shopID, webhookID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, response.StandardResponse{
+		Status:  200,
+		Message: "Webhook updated successfully",
+		Data:    updated,
+	})
+}
+
+// DeleteShopWebhook deletes a webhook and its delivery log
+func (handler *Handler) DeleteShopWebhook(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	webhookID := c.Param("webhook_id")
+	if shopID == "" || webhookID == "" {
+		c.Error(apperror.MissingParameter("shop_id and webhook_id"))
+		return
+	}
+
+	service := handler.service
+	if err := service.DeleteShopWebhook(c.Request.Context(), user, shopID, webhookID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// GetWebhookDeliveries returns a page of the webhook's delivery log
+func (handler *Handler) GetWebhookDeliveries(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	webhookID := c.Param("webhook_id")
+	if shopID == "" || webhookID == "" {
+		c.Error(apperror.MissingParameter("shop_id and webhook_id"))
+		return
+	}
+
+	params, err := pagination.FromQuery(c, defaultDeliveryLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	service := handler.service
+	deliveries, err := service.GetWebhookDeliveries(c.Request.Context(), user, shopID, webhookID, params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, response.StandardResponse{
+		Status:  200,
+		Message: "",
+		Data:    deliveries,
+	})
+}
+
+// ReplayDelivery sends a logged delivery again
+func (handler *Handler) ReplayDelivery(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	shopID := c.Param("shop_id")
+	webhookID := c.Param("webhook_id")
+	if shopID == "" || webhookID == "" {
+		c.Error(apperror.MissingParameter("shop_id and webhook_id"))
+		return
+	}
+
+	deliveryID, err := strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil || deliveryID <= 0 {
+		c.Error(apperror.InvalidParameter("delivery_id", "delivery_id must be a positive integer"))
+		return
+	}
+
+	service := handler.service
+	delivery, err := service.ReplayDelivery(c.Request.Context(), user, shopID, webhookID, deliveryID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, response.StandardResponse{
+		Status:  200,
+		Message: "",
+		Data:    delivery,
+	})
+}
@@ -0,0 +1,28 @@
+package webhooks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"miltechserver/jobs"
+)
+
+// deliveryRetention is how long the delivery log keeps an attempt.
+const deliveryRetention = 30 * 24 * time.Hour
+
+// RegisterJobs registers the job that prunes the delivery log.
+func RegisterJobs(scheduler *jobs.Scheduler, repo Repository) {
+	scheduler.Register(jobs.Job{
+		Name:     "webhook-deliveries-prune",
+		Schedule: "50 3 * * *",
+		Run: func(ctx context.Context) error {
+			pruned, err := repo.DeleteDeliveriesBefore(ctx, time.Now().Add(-deliveryRetention))
+			if err != nil {
+				return err
+			}
+			slog.Info("Pruned webhook deliveries", "deleted", pruned, "retention", deliveryRetention)
+			return nil
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"context"
+	"miltechserver/api/pagination"
+	"time"
+)
+
+type Repository interface {
+	GetShopWebhooks(ctx context.Context, shopID string) ([]Webhook, error)
+	GetShopWebhook(ctx context.Context, shopID string, webhookID string) (*Webhook, error)
+	CountShopWebhooks(ctx context.Context, shopID string) (int, error)
+	CreateWebhook(ctx context.Context, webhook Webhook, secret string) (*Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook Webhook) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, shopID string, webhookID string) error
+
+	GetActiveWebhooksForEvent(ctx context.Context, shopID string, eventType string) ([]Webhook, error)
+	GetSucceededWebhookIDs(ctx context.Context, eventID int64) (map[string]bool, error)
+
+	InsertDelivery(ctx context.Context, delivery Delivery) (*Delivery, error)
+	GetDelivery(ctx context.Context, webhookID string, deliveryID int64) (*Delivery, error)
+	GetDeliveriesPage(ctx context.Context, webhookID string, params pagination.Params) (pagination.Page[Delivery], error)
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
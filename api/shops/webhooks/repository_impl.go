@@ -0,0 +1,280 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"miltechserver/api/pagination"
+	"miltechserver/dbtx"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *RepositoryImpl {
+	return &RepositoryImpl{db: db}
+}
+
+const webhookColumns = `id, shop_id, url, description, event_types, active, created_by,
+	created_at, updated_at, secret`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(row scanner) (Webhook, error) {
+	var webhook Webhook
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.ShopID,
+		&webhook.URL,
+		&webhook.Description,
+		pq.Array(&webhook.EventTypes),
+		&webhook.Active,
+		&webhook.CreatedBy,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+		&webhook.secret,
+	)
+	return webhook, err
+}
+
+func (repo *RepositoryImpl) queryWebhooks(ctx context.Context, query string, args ...any) ([]Webhook, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (repo *RepositoryImpl) GetShopWebhooks(ctx context.Context, shopID string) ([]Webhook, error) {
+	return repo.queryWebhooks(ctx, `
+		SELECT `+webhookColumns+` FROM shop_webhooks
+		WHERE shop_id = $1
+		ORDER BY created_at`, shopID)
+}
+
+func (repo *RepositoryImpl) GetShopWebhook(ctx context.Context, shopID string, webhookID string) (*Webhook, error) {
+	webhook, err := scanWebhook(repo.db.QueryRowContext(ctx, `
+		SELECT `+webhookColumns+` FROM shop_webhooks
+		WHERE shop_id = $1 AND id = $2`, shopID, webhookID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+func (repo *RepositoryImpl) CountShopWebhooks(ctx context.Context, shopID string) (int, error) {
+	var count int
+	err := repo.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM shop_webhooks WHERE shop_id = $1`, shopID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+	return count, nil
+}
+
+func (repo *RepositoryImpl) CreateWebhook(ctx context.Context, webhook Webhook, secret string) (*Webhook, error) {
+	created, err := scanWebhook(dbtx.From(ctx, repo.db).QueryRowContext(ctx, `
+		INSERT INTO shop_webhooks (id, shop_id, url, description, event_types, active, created_by, secret)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+webhookColumns,
+		webhook.ID, webhook.ShopID, webhook.URL, webhook.Description, pq.Array(webhook.EventTypes),
+		webhook.Active, webhook.CreatedBy, secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return &created, nil
+}
+
+func (repo *RepositoryImpl) UpdateWebhook(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	updated, err := scanWebhook(dbtx.From(ctx, repo.db).QueryRowContext(ctx, `
+		UPDATE shop_webhooks
+		SET url = $3, description = $4, event_types = $5, active = $6, updated_at = now()
+		WHERE shop_id = $1 AND id = $2
+		RETURNING `+webhookColumns,
+		webhook.ShopID, webhook.ID, webhook.URL, webhook.Description, pq.Array(webhook.EventTypes), webhook.Active))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return &updated, nil
+}
+
+func (repo *RepositoryImpl) DeleteWebhook(ctx context.Context, shopID string, webhookID string) error {
+	result, err := dbtx.From(ctx, repo.db).ExecContext(ctx,
+		`DELETE FROM shop_webhooks WHERE shop_id = $1 AND id = $2`, shopID, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if deleted == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (repo *RepositoryImpl) GetActiveWebhooksForEvent(ctx context.Context, shopID string, eventType string) ([]Webhook, error) {
+	return repo.queryWebhooks(ctx, `
+		SELECT `+webhookColumns+` FROM shop_webhooks
+		WHERE shop_id = $1 AND active AND $2 = ANY(event_types)`, shopID, eventType)
+}
+
+// GetSucceededWebhookIDs returns the webhooks that have had the event.
+func (repo *RepositoryImpl) GetSucceededWebhookIDs(ctx context.Context, eventID int64) (map[string]bool, error) {
+	rows, err := repo.db.QueryContext(ctx, `
+		SELECT DISTINCT webhook_id FROM shop_webhook_deliveries
+		WHERE event_id = $1 AND status = 'succeeded'`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivered webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	delivered := map[string]bool{}
+	for rows.Next() {
+		var webhookID string
+		if err := rows.Scan(&webhookID); err != nil {
+			return nil, fmt.Errorf("failed to scan delivered webhook: %w", err)
+		}
+		delivered[webhookID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating delivered webhooks: %w", err)
+	}
+	return delivered, nil
+}
+
+const deliveryColumns = `id, webhook_id, event_id, event_type, trigger, attempt, status,
+	response_status, error, duration_ms, payload, created_at`
+
+func scanDelivery(row scanner) (Delivery, error) {
+	var delivery Delivery
+	var payload []byte
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Trigger,
+		&delivery.Attempt,
+		&delivery.Status,
+		&delivery.ResponseStatus,
+		&delivery.Error,
+		&delivery.DurationMS,
+		&payload,
+		&delivery.CreatedAt,
+	)
+	delivery.Payload = payload
+	return delivery, err
+}
+
+func (repo *RepositoryImpl) InsertDelivery(ctx context.Context, delivery Delivery) (*Delivery, error) {
+	inserted, err := scanDelivery(repo.db.QueryRowContext(ctx, `
+		INSERT INTO shop_webhook_deliveries
+			(webhook_id, event_id, event_type, trigger, attempt, status, response_status, error, duration_ms, payload)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING `+deliveryColumns,
+		delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Trigger, delivery.Attempt,
+		delivery.Status, delivery.ResponseStatus, delivery.Error, delivery.DurationMS, string(delivery.Payload)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert webhook delivery: %w", err)
+	}
+	return &inserted, nil
+}
+
+func (repo *RepositoryImpl) GetDelivery(ctx context.Context, webhookID string, deliveryID int64) (*Delivery, error) {
+	delivery, err := scanDelivery(repo.db.QueryRowContext(ctx, `
+		SELECT `+deliveryColumns+` FROM shop_webhook_deliveries
+		WHERE webhook_id = $1 AND id = $2`, webhookID, deliveryID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+func (repo *RepositoryImpl) GetDeliveriesPage(ctx context.Context, webhookID string, params pagination.Params) (pagination.Page[Delivery], error) {
+	var afterID int64
+	after, err := params.After(&afterID)
+	if err != nil {
+		return pagination.Page[Delivery]{}, err
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM shop_webhook_deliveries WHERE webhook_id = $1`
+	args := []any{webhookID}
+	if after {
+		args = append(args, afterID)
+		query += fmt.Sprintf(` AND id < $%d`, len(args))
+	}
+	args = append(args, params.Fetch())
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return pagination.Page[Delivery]{}, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []Delivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return pagination.Page[Delivery]{}, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Page[Delivery]{}, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	page := pagination.NewPage(deliveries, params, func(delivery Delivery) []any {
+		return []any{delivery.ID}
+	})
+	if !params.IncludeTotal {
+		return page, nil
+	}
+
+	var count int
+	err = repo.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM shop_webhook_deliveries WHERE webhook_id = $1`, webhookID).Scan(&count)
+	if err != nil {
+		return pagination.Page[Delivery]{}, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	return page.WithTotal(count), nil
+}
+
+func (repo *RepositoryImpl) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := repo.db.ExecContext(ctx,
+		`DELETE FROM shop_webhook_deliveries WHERE created_at < $1`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,15 @@
+package webhooks
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, service Service) {
+	handler := Handler{service: service}
+	router.GET("/shops/:shop_id/webhooks", handler.GetShopWebhooks)
+	router.POST("/shops/:shop_id/webhooks", handler.CreateShopWebhook)
+	router.PUT("/shops/:shop_id/webhooks/:webhook_id", handler.UpdateShopWebhook)
+	router.DELETE("/shops/:shop_id/webhooks/:webhook_id", handler.DeleteShopWebhook)
+	router.GET("/shops/:shop_id/webhooks/:webhook_id/deliveries", handler.GetWebhookDeliveries)
+	router.POST("/shops/:shop_id/webhooks/:webhook_id/deliveries/:delivery_id/replay", handler.ReplayDelivery)
+}
@@ -0,0 +1,17 @@
+package webhooks
+
+import (
+	"context"
+	"miltechserver/api/pagination"
+	"miltechserver/api/request"
+	"miltechserver/bootstrap"
+)
+
+type Service interface {
+	GetShopWebhooks(ctx context.Context, user *bootstrap.User, shopID string) ([]Webhook, error)
+	CreateShopWebhook(ctx context.Context, user *bootstrap.User, shopID string, req request.CreateShopWebhookRequest) (*CreatedWebhook, error)
+	UpdateShopWebhook(ctx context.Context, user *bootstrap.User, shopID string, webhookID string, req request.UpdateShopWebhookRequest) (*Webhook, error)
+	DeleteShopWebhook(ctx context.Context, user *bootstrap.User, shopID string, webhookID string) error
+	GetWebhookDeliveries(ctx context.Context, user *bootstrap.User, shopID string, webhookID string, params pagination.Params) (pagination.Page[Delivery], error)
+	ReplayDelivery(ctx context.Context, user *bootstrap.User, shopID string, webhookID string, deliveryID int64) (*Delivery, error)
+}
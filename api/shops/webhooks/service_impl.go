@@ -0,0 +1,302 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
+	"miltechserver/api/request"
+	"miltechserver/api/shops/audit"
+	"miltechserver/api/shops/shared"
+	"miltechserver/bootstrap"
+	"miltechserver/webhook"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const maxDescriptionLength = 200
+
+type ServiceImpl struct {
+	repo      Repository
+	auth      shared.ShopAuthorization
+	audit     audit.Recorder
+	deliverer *Deliverer
+	// allowInsecure accepts http URLs, for local development
+	allowInsecure bool
+}
+
+func NewService(repo Repository, auth shared.ShopAuthorization, recorder audit.Recorder, deliverer *Deliverer, allowInsecure bool) *ServiceImpl {
+	return &ServiceImpl{
+		repo:          repo,
+		auth:          auth,
+		audit:         recorder,
+		deliverer:     deliverer,
+		allowInsecure: allowInsecure,
+	}
+}
+
+func (service *ServiceImpl) WithAuthorization(auth shared.ShopAuthorization) shared.AuthorizationAware {
+	return &ServiceImpl{
+		repo:          service.repo,
+		auth:          auth,
+		audit:         service.audit,
+		deliverer:     service.deliverer,
+		allowInsecure: service.allowInsecure,
+	}
+}
+
+// GetShopWebhooks lists the shop's webhooks. Only shop admins manage
+// webhooks.
+func (service *ServiceImpl) GetShopWebhooks(ctx context.Context, user *bootstrap.User, shopID string) ([]Webhook, error) {
+	if user == nil {
+		return nil, shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopAdmin(ctx, user, shopID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := service.repo.GetShopWebhooks(ctx, shopID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shop webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (service *ServiceImpl) CreateShopWebhook(ctx context.Context, user *bootstrap.User, shopID string, req request.CreateShopWebhookRequest) (*CreatedWebhook, error) {
+	if user == nil {
+		return nil, shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopAdmin(ctx, user, shopID); err != nil {
+		return nil, err
+	}
+
+	hook := Webhook{
+		ID:          uuid.New().String(),
+		ShopID:      shopID,
+		URL:         strings.TrimSpace(req.URL),
+		Description: req.Description,
+		EventTypes:  req.EventTypes,
+		Active:      true,
+		CreatedBy:   user.UserID,
+	}
+	if err := service.validate(&hook); err != nil {
+		return nil, err
+	}
+
+	count, err := service.repo.CountShopWebhooks(ctx, shopID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count shop webhooks: %w", err)
+	}
+	if count >= maxWebhooksPerShop {
+		return nil, ErrTooManyWebhooks
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	var created *Webhook
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		created, err = service.repo.CreateWebhook(ctx, hook, secret)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook: %w", err)
+		}
+		return service.record(ctx, user, created.ID, shopID, audit.ActionCreate, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Shop webhook created", "user_id", user.UserID, "shop_id", shopID, "webhook_id", created.ID)
+	return &CreatedWebhook{Webhook: *created, Secret: secret}, nil
+}
+
+func (service *ServiceImpl) UpdateShopWebhook(ctx context.Context, user *bootstrap.User, shopID string, webhookID string, req request.UpdateShopWebhookRequest) (*Webhook, error) {
+	if user == nil {
+		return nil, shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopAdmin(ctx, user, shopID); err != nil {
+		return nil, err
+	}
+
+	current, err := service.repo.GetShopWebhook(ctx, shopID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	hook := *current
+	if req.URL != nil {
+		hook.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.EventTypes != nil {
+		hook.EventTypes = req.EventTypes
+	}
+	if req.Description.Set {
+		hook.Description = req.Description.Value
+	}
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+	if err := service.validate(&hook); err != nil {
+		return nil, err
+	}
+
+	var updated *Webhook
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		updated, err = service.repo.UpdateWebhook(ctx, hook)
+		if err != nil {
+			return err
+		}
+		return service.record(ctx, user, webhookID, shopID, audit.ActionUpdate, current, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Shop webhook updated", "user_id", user.UserID, "shop_id", shopID, "webhook_id", webhookID)
+	return updated, nil
+}
+
+func (service *ServiceImpl) DeleteShopWebhook(ctx context.Context, user *bootstrap.User, shopID string, webhookID string) error {
+	if user == nil {
+		return shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopAdmin(ctx, user, shopID); err != nil {
+		return err
+	}
+
+	current, err := service.repo.GetShopWebhook(ctx, shopID, webhookID)
+	if err != nil {
+		return err
+	}
+	err = service.audit.InTx(ctx, func(ctx context.Context) error {
+		if err := service.repo.DeleteWebhook(ctx, shopID, webhookID); err != nil {
+			return err
+		}
+		return service.record(ctx, user, webhookID, shopID, audit.ActionDelete, current, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Shop webhook deleted", "user_id", user.UserID, "shop_id", shopID, "webhook_id", webhookID)
+	return nil
+}
+
+// GetWebhookDeliveries returns the webhook's delivery log, newest first.
+func (service *ServiceImpl) GetWebhookDeliveries(ctx context.Context,
+	user *bootstrap.User,
+	shopID string,
+	webhookID string,
+	params pagination.Params,
+) (pagination.Page[Delivery], error) {
+	if user == nil {
+		return pagination.Page[Delivery]{}, shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopAdmin(ctx, user, shopID); err != nil {
+		return pagination.Page[Delivery]{}, err
+	}
+	if _, err := service.repo.GetShopWebhook(ctx, shopID, webhookID); err != nil {
+		return pagination.Page[Delivery]{}, err
+	}
+
+	page, err := service.repo.GetDeliveriesPage(ctx, webhookID, params)
+	if err != nil {
+		return pagination.Page[Delivery]{}, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	return page, nil
+}
+
+// ReplayDelivery sends a logged delivery's payload to the webhook again,
+// with a fresh signature, and returns the new delivery. A failed replay is
+// logged and returned like any other delivery rather than as an error.
+func (service *ServiceImpl) ReplayDelivery(ctx context.Context, user *bootstrap.User, shopID string, webhookID string, deliveryID int64) (*Delivery, error) {
+	if user == nil {
+		return nil, shared.ErrUnauthorizedUser
+	}
+	if err := service.auth.RequireShopAdmin(ctx, user, shopID); err != nil {
+		return nil, err
+	}
+
+	hook, err := service.repo.GetShopWebhook(ctx, shopID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !hook.Active {
+		return nil, ErrWebhookInactive
+	}
+	original, err := service.repo.GetDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	replayed, err := service.deliverer.send(ctx, *hook, original.EventID, original.EventType, original.Payload, TriggerReplay, 1)
+	if err != nil {
+		slog.Info("Webhook replay failed", "webhook_id", webhookID, "delivery_id", deliveryID, "error", err)
+	}
+	return replayed, nil
+}
+
+// validate normalises hook's event types and checks its fields.
+func (service *ServiceImpl) validate(hook *Webhook) error {
+	if err := webhook.ValidateURL(hook.URL, service.allowInsecure); err != nil {
+		message := strings.TrimPrefix(err.Error(), webhook.ErrInvalidURL.Error()+": ")
+		if !errors.Is(err, webhook.ErrInvalidURL) {
+			message = err.Error()
+		}
+		return apperror.Validation("invalid_webhook_url", "invalid webhook url",
+			apperror.FieldError{Field: "url", Message: message})
+	}
+
+	if len(hook.EventTypes) == 0 {
+		return apperror.Validation("invalid_event_types", "choose at least one event type",
+			apperror.FieldError{Field: "event_types", Message: "must not be empty"})
+	}
+	var eventTypes []string
+	for _, eventType := range hook.EventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			return apperror.Validation("invalid_event_types", "unknown event type",
+				apperror.FieldError{Field: "event_types", Message: fmt.Sprintf("unknown event type %q", eventType)})
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	hook.EventTypes = eventTypes
+
+	if hook.Description != nil && len(*hook.Description) > maxDescriptionLength {
+		return apperror.Validation("invalid_description", "description is too long",
+			apperror.FieldError{Field: "description", Message: fmt.Sprintf("must be at most %d characters", maxDescriptionLength)})
+	}
+	return nil
+}
+
+func (service *ServiceImpl) record(ctx context.Context,
+	user *bootstrap.User,
+	webhookID string,
+	shopID string,
+	action audit.Action,
+	before, after *Webhook,
+) error {
+	entry := audit.Entry{
+		ShopID:     shopID,
+		ActorID:    user.UserID,
+		EntityType: audit.EntityWebhook,
+		EntityID:   webhookID,
+		Action:     action,
+	}
+	// Typed nil pointers would be logged as JSON null rather than no state
+	if before != nil {
+		entry.Before = before
+	}
+	if after != nil {
+		entry.After = after
+	}
+	return service.audit.Record(ctx, entry)
+}
+
+var _ Service = (*ServiceImpl)(nil)
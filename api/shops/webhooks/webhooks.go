@@ -0,0 +1,92 @@
+// Package webhooks lets shop admins register HTTPS endpoints that receive
+// the shop's domain events. The Deliverer subscribes to the event bus and
+// posts each event to the shop's matching endpoints, signed with the
+// endpoint's secret, logging every attempt in shop_webhook_deliveries. An
+// endpoint that fails is retried with the bus's backoff until it succeeds;
+// endpoints that already have the event are skipped. Admins can replay any
+// logged delivery.
+package webhooks
+
+import (
+	"encoding/json"
+	"miltechserver/events"
+	"time"
+)
+
+// EventTypes lists the events a webhook can subscribe to. Shop messages are
+// left out: they are members' conversation rather than shop activity.
+var EventTypes = []string{
+	events.TypeMemberJoined,
+	events.TypeMemberLeft,
+	events.TypeMemberRemoved,
+	events.TypeMemberPromoted,
+	events.TypeVehicleNotificationCreated,
+	events.TypeEquipmentServiceCompleted,
+	events.TypeEquipmentServiceOverdue,
+	events.TypePMCSFaultRecorded,
+}
+
+// maxWebhooksPerShop bounds the requests one event can fan out to.
+const maxWebhooksPerShop = 10
+
+// Webhook is a registered endpoint. Its secret is only returned once, when
+// the webhook is created.
+type Webhook struct {
+	ID          string    `json:"id"`
+	ShopID      string    `json:"shop_id"`
+	URL         string    `json:"url"`
+	Description *string   `json:"description"`
+	EventTypes  []string  `json:"event_types"`
+	Active      bool      `json:"active"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	secret string
+}
+
+// CreatedWebhook is a new webhook with the secret receivers verify
+// signatures with.
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// Delivery triggers.
+const (
+	TriggerEvent  = "event"
+	TriggerReplay = "replay"
+)
+
+// Delivery statuses.
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Delivery is one logged request to a webhook.
+type Delivery struct {
+	ID        int64  `json:"id"`
+	WebhookID string `json:"webhook_id"`
+	EventID   int64  `json:"event_id"`
+	EventType string `json:"event_type"`
+	Trigger   string `json:"trigger"`
+	// Attempt is the event's delivery attempt; replays record 1.
+	Attempt        int             `json:"attempt"`
+	Status         string          `json:"status"`
+	ResponseStatus *int            `json:"response_status"`
+	Error          *string         `json:"error"`
+	DurationMS     int64           `json:"duration_ms"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// payload is the JSON body posted to a webhook. Data is the event as
+// published.
+type payload struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	ShopID     string          `json:"shop_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
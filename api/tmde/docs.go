@@ -0,0 +1,30 @@
+package tmde
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"miltechserver/api/pagination"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "TMDE",
+	Operations: []openapi.Operation{
+		{
+			ID:       "lookupTMDEByNIIN",
+			Method:   http.MethodGet,
+			Path:     "/tmde/niin/:niin",
+			Summary:  "Look up TMDE records by NIIN",
+			Response: model.TmdeIntervalMat{},
+		},
+		{
+			ID:       "listTMDE",
+			Method:   http.MethodGet,
+			Path:     "/tmde/requirements",
+			Summary:  "List TMDE records",
+			Query:    pagination.Query,
+			Response: pagination.Page[model.TmdeIntervalMat]{},
+		},
+	},
+}}
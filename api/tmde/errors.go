@@ -1,9 +1,8 @@
 package tmde
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrNotFound    = errors.New("no TMDE requirements found")
-	ErrEmptyParam  = errors.New("required parameter is empty")
-	ErrInvalidPage = errors.New("page number must be greater than 0")
+	ErrNotFound   = apperror.NotFound("tmde_requirements_not_found", "no TMDE requirements found")
+	ErrEmptyParam = apperror.Validation("required_parameter_empty", "required parameter is empty")
 )
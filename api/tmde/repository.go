@@ -1,8 +1,12 @@
 package tmde
 
-import "miltechserver/.gen/miltech_ng/public/model"
+import (
+	"context"
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
+)
 
 type Repository interface {
-	GetByNIIN(niin string) (model.TmdeIntervalMat, error)
-	GetAllPaginated(page int) (TmdePageResponse, error)
+	GetByNIIN(ctx context.Context, niin string) (model.TmdeIntervalMat, error)
+	GetAllPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.TmdeIntervalMat], error)
 }
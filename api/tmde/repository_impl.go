@@ -1,18 +1,17 @@
 package tmde
 
 import (
+	"context"
 	"database/sql"
-	"math"
 	"strings"
 
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/.gen/miltech_ng/public/view"
+	"miltechserver/api/pagination"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
 
-const pageSize = int64(100)
-
 type repository struct {
 	db *sql.DB
 }
@@ -21,7 +20,7 @@ func NewRepository(db *sql.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) GetByNIIN(niin string) (model.TmdeIntervalMat, error) {
+func (r *repository) GetByNIIN(ctx context.Context, niin string) (model.TmdeIntervalMat, error) {
 	if strings.TrimSpace(niin) == "" {
 		return model.TmdeIntervalMat{}, ErrEmptyParam
 	}
@@ -31,7 +30,7 @@ func (r *repository) GetByNIIN(niin string) (model.TmdeIntervalMat, error) {
 		FROM(view.TmdeIntervalMat).
 		WHERE(view.TmdeIntervalMat.Niin.EQ(String(niin)))
 
-	if err := stmt.Query(r.db, &results); err != nil {
+	if err := stmt.QueryContext(ctx, r.db, &results); err != nil {
 		return model.TmdeIntervalMat{}, err
 	}
 
@@ -42,44 +41,40 @@ func (r *repository) GetByNIIN(niin string) (model.TmdeIntervalMat, error) {
 	return results[0], nil
 }
 
-func (r *repository) GetAllPaginated(page int) (TmdePageResponse, error) {
-	if page < 1 {
-		return TmdePageResponse{}, ErrInvalidPage
+func (r *repository) GetAllPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.TmdeIntervalMat], error) {
+	var afterNIIN string
+	after, err := params.After(&afterNIIN)
+	if err != nil {
+		return pagination.Page[model.TmdeIntervalMat]{}, err
 	}
 
-	offset := pageSize * int64(page-1)
-
 	var items []model.TmdeIntervalMat
 	stmt := SELECT(view.TmdeIntervalMat.AllColumns).
 		FROM(view.TmdeIntervalMat).
 		ORDER_BY(view.TmdeIntervalMat.Niin.ASC()).
-		LIMIT(pageSize).
-		OFFSET(offset)
+		LIMIT(params.Fetch())
+	if after {
+		stmt = stmt.WHERE(view.TmdeIntervalMat.Niin.GT(String(afterNIIN)))
+	}
 
-	if err := stmt.Query(r.db, &items); err != nil {
-		return TmdePageResponse{}, err
+	if err := stmt.QueryContext(ctx, r.db, &items); err != nil {
+		return pagination.Page[model.TmdeIntervalMat]{}, err
 	}
 
-	if len(items) == 0 {
-		return TmdePageResponse{}, ErrNotFound
+	page := pagination.NewPage(items, params, func(item model.TmdeIntervalMat) []any {
+		return []any{item.Niin}
+	})
+	if !params.IncludeTotal {
+		return page, nil
 	}
 
 	var dest struct {
 		Count int64
 	}
 	countStmt := SELECT(COUNT(Raw("*")).AS("count")).FROM(view.TmdeIntervalMat)
-	if err := countStmt.Query(r.db, &dest); err != nil {
-		return TmdePageResponse{}, err
+	if err := countStmt.QueryContext(ctx, r.db, &dest); err != nil {
+		return pagination.Page[model.TmdeIntervalMat]{}, err
 	}
-	totalCount := int(dest.Count)
-
-	totalPages := int(math.Ceil(float64(totalCount) / float64(pageSize)))
 
-	return TmdePageResponse{
-		Items:      items,
-		Count:      len(items),
-		Page:       page,
-		TotalPages: totalPages,
-		IsLastPage: page >= totalPages,
-	}, nil
+	return page.WithTotal(int(dest.Count)), nil
 }
@@ -2,16 +2,19 @@ package tmde
 
 import (
 	"database/sql"
-	"errors"
 	"net/http"
-	"strconv"
 	"strings"
 
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 
 	"github.com/gin-gonic/gin"
 )
 
+// defaultLimit is the requirements page size when the client sends no limit.
+const defaultLimit = 100
+
 type Dependencies struct {
 	DB *sql.DB
 }
@@ -36,17 +39,13 @@ func (h *Handler) lookupByNIIN(c *gin.Context) {
 	niin := c.Param("niin")
 
 	if strings.TrimSpace(niin) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "NIIN parameter is required"})
+		c.Error(apperror.MissingParameter("niin"))
 		return
 	}
 
-	item, err := h.service.LookupByNIIN(niin)
+	item, err := h.service.LookupByNIIN(c.Request.Context(), niin)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
@@ -58,21 +57,15 @@ func (h *Handler) lookupByNIIN(c *gin.Context) {
 }
 
 func (h *Handler) listAllPaginated(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	params, err := pagination.FromQuery(c, defaultLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	data, err := h.service.GetAllPaginated(page)
+	data, err := h.service.GetAllPaginated(c.Request.Context(), params)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			c.JSON(http.StatusNotFound, response.NoItemFoundResponseMessage())
-		} else {
-			c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
-		}
+		c.Error(err)
 		return
 	}
 
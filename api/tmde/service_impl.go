@@ -1,9 +1,11 @@
 package tmde
 
 import (
+	"context"
 	"strings"
 
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/pagination"
 )
 
 type service struct {
@@ -14,11 +16,11 @@ func NewService(repo Repository) Service {
 	return &service{repository: repo}
 }
 
-func (s *service) LookupByNIIN(niin string) (model.TmdeIntervalMat, error) {
+func (s *service) LookupByNIIN(ctx context.Context, niin string) (model.TmdeIntervalMat, error) {
 	normalized := strings.TrimSpace(strings.ToUpper(niin))
-	return s.repository.GetByNIIN(normalized)
+	return s.repository.GetByNIIN(ctx, normalized)
 }
 
-func (s *service) GetAllPaginated(page int) (TmdePageResponse, error) {
-	return s.repository.GetAllPaginated(page)
+func (s *service) GetAllPaginated(ctx context.Context, params pagination.Params) (pagination.Page[model.TmdeIntervalMat], error) {
+	return s.repository.GetAllPaginated(ctx, params)
 }
@@ -0,0 +1,33 @@
+package user_devices
+
+import (
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Devices",
+	Operations: []openapi.Operation{
+		{
+			ID:       "registerUserDevice",
+			Method:   http.MethodPost,
+			Path:     "/user/devices",
+			Auth:     true,
+			Summary:  "Registers the device's FCM token for push notifications; a token registered to another user moves to the caller",
+			Request:  RegisterDeviceRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:       "unregisterUserDevice",
+			Method:   http.MethodDelete,
+			Path:     "/user/devices",
+			Auth:     true,
+			Summary:  "Stops push notifications to the device; unknown tokens are ignored",
+			Request:  UnregisterDeviceRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+	},
+}}
@@ -0,0 +1,5 @@
+package user_devices
+
+import "miltechserver/api/apperror"
+
+var ErrInvalidToken = apperror.Validation("invalid_device_token", "device token must not be blank")
@@ -0,0 +1,10 @@
+package user_devices
+
+import (
+	"context"
+)
+
+type Repository interface {
+	RegisterDevice(ctx context.Context, userID string, token string, platform string) error
+	UnregisterDevice(ctx context.Context, userID string, token string) error
+}
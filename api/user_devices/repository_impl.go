@@ -0,0 +1,57 @@
+package user_devices
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// maxDevicesPerUser bounds a user's tokens. Apps register a new token when
+// FCM rotates it without always removing the old one, so the least recently
+// registered beyond this are forgotten.
+const maxDevicesPerUser = 20
+
+type RepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *RepositoryImpl {
+	return &RepositoryImpl{db: db}
+}
+
+// RegisterDevice stores token for userID. A token already registered to
+// another user moves to userID, since a device is signed in to one account
+// at a time.
+func (repo *RepositoryImpl) RegisterDevice(ctx context.Context, userID string, token string, platform string) error {
+	_, err := repo.db.ExecContext(ctx, `
+		INSERT INTO user_device_tokens (token, user_id, platform)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token) DO UPDATE
+		SET user_id = EXCLUDED.user_id, platform = EXCLUDED.platform, last_seen_at = now()`,
+		token, userID, platform)
+	if err != nil {
+		return fmt.Errorf("failed to register device: %w", err)
+	}
+
+	_, err = repo.db.ExecContext(ctx, `
+		DELETE FROM user_device_tokens
+		WHERE user_id = $1 AND token NOT IN (
+			SELECT token FROM user_device_tokens
+			WHERE user_id = $1
+			ORDER BY last_seen_at DESC
+			LIMIT $2
+		)`, userID, maxDevicesPerUser)
+	if err != nil {
+		return fmt.Errorf("failed to trim devices: %w", err)
+	}
+	return nil
+}
+
+func (repo *RepositoryImpl) UnregisterDevice(ctx context.Context, userID string, token string) error {
+	_, err := repo.db.ExecContext(ctx,
+		`DELETE FROM user_device_tokens WHERE user_id = $1 AND token = $2`, userID, token)
+	if err != nil {
+		return fmt.Errorf("failed to unregister device: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package user_devices
+
+type RegisterDeviceRequest struct {
+	Token    string `json:"token" binding:"required,max=4096"`
+	Platform string `json:"platform" binding:"required,oneof=android ios web"`
+}
+
+type UnregisterDeviceRequest struct {
+	Token string `json:"token" binding:"required,max=4096"`
+}
@@ -0,0 +1,82 @@
+package user_devices
+
+import (
+	"database/sql"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+
+	"miltechserver/api/apperror"
+	"miltechserver/bootstrap"
+)
+
+type Dependencies struct {
+	DB *sql.DB
+}
+
+type Handler struct {
+	service Service
+}
+
+func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
+	repo := NewRepository(deps.DB)
+	svc := NewService(repo)
+	registerHandlers(router, svc)
+}
+
+func registerHandlers(router *gin.RouterGroup, svc Service) {
+	handler := Handler{service: svc}
+
+	router.POST("/user/devices", handler.registerDevice)
+	router.DELETE("/user/devices", handler.unregisterDevice)
+}
+
+func (handler *Handler) registerDevice(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	var req RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		slog.Info("Invalid request body", "error", err)
+		return
+	}
+
+	if err := handler.service.RegisterDevice(c.Request.Context(), user, req.Token, req.Platform); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "device registered"})
+}
+
+func (handler *Handler) unregisterDevice(c *gin.Context) {
+	ctxUser, ok := c.Get("user")
+	user, _ := ctxUser.(*bootstrap.User)
+
+	if !ok {
+		c.Error(apperror.ErrUnauthenticated)
+		slog.Info("Unauthorized request")
+		return
+	}
+
+	var req UnregisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		slog.Info("Invalid request body", "error", err)
+		return
+	}
+
+	if err := handler.service.UnregisterDevice(c.Request.Context(), user, req.Token); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "device unregistered"})
+}
@@ -0,0 +1,90 @@
+package user_devices
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"miltechserver/api/middleware"
+	"miltechserver/bootstrap"
+)
+
+type repoStub struct {
+	registered   map[string]string
+	unregistered []string
+}
+
+func (r *repoStub) RegisterDevice(_ context.Context, userID string, token string, platform string) error {
+	r.registered[token] = userID + "/" + platform
+	return nil
+}
+
+func (r *repoStub) UnregisterDevice(_ context.Context, userID string, token string) error {
+	r.unregistered = append(r.unregistered, userID+"/"+token)
+	return nil
+}
+
+func newRouter(repo Repository, user *bootstrap.User) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	group := router.Group("/api/v1/auth")
+	if user != nil {
+		group.Use(func(c *gin.Context) {
+			c.Set("user", user)
+			c.Next()
+		})
+	}
+	registerHandlers(group, NewService(repo))
+	return router
+}
+
+func serve(router *gin.Engine, method string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1/auth/user/devices", bytes.NewBufferString(body))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestRegisterDevice(t *testing.T) {
+	repo := &repoStub{registered: map[string]string{}}
+	router := newRouter(repo, &bootstrap.User{UserID: "user-1"})
+
+	resp := serve(router, http.MethodPost, `{"token":" fcm-token-1 ","platform":"ios"}`)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, map[string]string{"fcm-token-1": "user-1/ios"}, repo.registered)
+
+	for _, body := range []string{
+		`{"token":"fcm-token-2","platform":"blackberry"}`,
+		`{"platform":"android"}`,
+		`{"token":"   ","platform":"android"}`,
+		`{`,
+	} {
+		require.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, body).Code, body)
+	}
+	require.Len(t, repo.registered, 1)
+}
+
+func TestUnregisterDevice(t *testing.T) {
+	repo := &repoStub{}
+	router := newRouter(repo, &bootstrap.User{UserID: "user-1"})
+
+	resp := serve(router, http.MethodDelete, `{"token":"fcm-token-1"}`)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, []string{"user-1/fcm-token-1"}, repo.unregistered)
+}
+
+func TestDevicesRequireUser(t *testing.T) {
+	repo := &repoStub{registered: map[string]string{}}
+	router := newRouter(repo, nil)
+
+	require.Equal(t, http.StatusUnauthorized, serve(router, http.MethodPost, `{"token":"fcm-token-1","platform":"ios"}`).Code)
+	require.Equal(t, http.StatusUnauthorized, serve(router, http.MethodDelete, `{"token":"fcm-token-1"}`).Code)
+	require.Empty(t, repo.registered)
+	require.Empty(t, repo.unregistered)
+}
@@ -0,0 +1,11 @@
+package user_devices
+
+import (
+	"context"
+	"miltechserver/bootstrap"
+)
+
+type Service interface {
+	RegisterDevice(ctx context.Context, user *bootstrap.User, token string, platform string) error
+	UnregisterDevice(ctx context.Context, user *bootstrap.User, token string) error
+}
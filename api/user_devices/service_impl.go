@@ -0,0 +1,47 @@
+package user_devices
+
+import (
+	"context"
+	"log/slog"
+	"miltechserver/api/apperror"
+	"miltechserver/bootstrap"
+	"strings"
+)
+
+type ServiceImpl struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &ServiceImpl{repo: repo}
+}
+
+// RegisterDevice records a device's push token for the user. Apps call it
+// at sign-in and whenever FCM hands them a new token.
+func (service *ServiceImpl) RegisterDevice(ctx context.Context, user *bootstrap.User, token string, platform string) error {
+	if user == nil {
+		return apperror.ErrUnauthenticated
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return ErrInvalidToken
+	}
+	if err := service.repo.RegisterDevice(ctx, user.UserID, token, platform); err != nil {
+		return err
+	}
+	slog.Info("Device registered for push notifications", "user_id", user.UserID, "platform", platform)
+	return nil
+}
+
+// UnregisterDevice stops pushes to a device, e.g. at sign-out. Unknown
+// tokens are ignored so apps can retry freely.
+func (service *ServiceImpl) UnregisterDevice(ctx context.Context, user *bootstrap.User, token string) error {
+	if user == nil {
+		return apperror.ErrUnauthenticated
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return ErrInvalidToken
+	}
+	return service.repo.UnregisterDevice(ctx, user.UserID, token)
+}
@@ -0,0 +1,40 @@
+package user_general
+
+import (
+	"miltechserver/api/auth"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User",
+	Operations: []openapi.Operation{
+		{
+			ID:      "upsertUser",
+			Method:  http.MethodPost,
+			Path:    "/user/general/refresh",
+			Auth:    true,
+			Summary: "Upsert user",
+			Request: auth.UserDto{},
+		},
+		{
+			ID:       "deleteUser",
+			Method:   http.MethodDelete,
+			Path:     "/user/general/delete_user",
+			Auth:     true,
+			Summary:  "Delete user",
+			Request:  DeleteRequest{},
+			Response: openapi.Object{"message": ""},
+			Raw:      true,
+		},
+		{
+			ID:      "updateUserDisplayName",
+			Method:  http.MethodPost,
+			Path:    "/user/general/dn_change",
+			Auth:    true,
+			Summary: "Update user display name",
+			Request: DisplayNameChangeRequest{},
+		},
+	},
+}}
@@ -1,5 +1,5 @@
 package user_general
 
-import "errors"
+import "miltechserver/api/apperror"
 
-var ErrUserNotFound = errors.New("user not found")
+var ErrUserNotFound = apperror.NotFound("user_not_found", "user not found")
@@ -1,6 +1,7 @@
 package user_general
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
@@ -20,7 +21,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) UpsertUser(user *bootstrap.User, userDto auth.UserDto) error {
+func (repo *RepositoryImpl) UpsertUser(ctx context.Context, user *bootstrap.User, userDto auth.UserDto) error {
 	stmt := table.Users.INSERT(
 		table.Users.UID,
 		table.Users.Email,
@@ -42,7 +43,7 @@ func (repo *RepositoryImpl) UpsertUser(user *bootstrap.User, userDto auth.UserDt
 		).
 		RETURNING(table.Users.AllColumns)
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error upserting user: %w", err)
 	}
@@ -51,10 +52,10 @@ func (repo *RepositoryImpl) UpsertUser(user *bootstrap.User, userDto auth.UserDt
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteUser(uid string) error {
+func (repo *RepositoryImpl) DeleteUser(ctx context.Context, uid string) error {
 	stmt := table.Users.DELETE().WHERE(table.Users.UID.EQ(String(uid)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error deleting user: %w", err)
 	}
@@ -72,12 +73,12 @@ func (repo *RepositoryImpl) DeleteUser(uid string) error {
 	return nil
 }
 
-func (repo *RepositoryImpl) UpdateUserDisplayName(uid string, displayName string) error {
+func (repo *RepositoryImpl) UpdateUserDisplayName(ctx context.Context, uid string, displayName string) error {
 	stmt := table.Users.UPDATE(table.Users.Username).
 		SET(String(displayName)).
 		WHERE(table.Users.UID.EQ(String(uid)))
 
-	result, err := stmt.Exec(repo.db)
+	result, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error updating user display name: %w", err)
 	}
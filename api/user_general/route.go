@@ -7,12 +7,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/auth"
 	"miltechserver/bootstrap"
+	"miltechserver/identity"
 )
 
 type Dependencies struct {
-	DB *sql.DB
+	DB       *sql.DB
+	Profiles *identity.ProfileCache
 }
 
 type Handler struct {
@@ -21,7 +24,7 @@ type Handler struct {
 
 func RegisterRoutes(deps Dependencies, router *gin.RouterGroup) {
 	repo := NewRepository(deps.DB)
-	svc := NewService(repo)
+	svc := NewService(repo, deps.Profiles)
 	registerHandlers(router, svc)
 }
 
@@ -39,18 +42,18 @@ func (handler *Handler) upsertUser(c *gin.Context) {
 	userDto := auth.UserDto{}
 
 	if err := c.ShouldBindJSON(&userDto); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		slog.Info("Invalid request body", "error", err)
 		return
 	}
 
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	if err := handler.service.UpsertUser(user, userDto); err != nil {
+	if err := handler.service.UpsertUser(c.Request.Context(), user, userDto); err != nil {
 		c.Error(err)
 		return
 	}
@@ -61,23 +64,21 @@ func (handler *Handler) upsertUser(c *gin.Context) {
 func (handler *Handler) deleteUser(c *gin.Context) {
 	_, ok := c.Get("user")
 	if !ok {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var deleteRequest DeleteRequest
 	if err := c.ShouldBindJSON(&deleteRequest); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		slog.Info("Invalid request body", "error", err)
 		return
 	}
 
-	if err := handler.service.DeleteUser(deleteRequest.UID); err != nil {
+	if err := handler.service.DeleteUser(c.Request.Context(), deleteRequest.UID); err != nil {
 		if errors.Is(err, ErrUserNotFound) {
-			c.JSON(404, gin.H{"message": "user not found"})
 			slog.Info("User not found", "uid", deleteRequest.UID)
-			return
 		}
 		c.Error(err)
 		return
@@ -90,16 +91,16 @@ func (handler *Handler) deleteUser(c *gin.Context) {
 func (handler *Handler) updateUserDisplayName(c *gin.Context) {
 	var displayNameRequest DisplayNameChangeRequest
 	if err := c.ShouldBindJSON(&displayNameRequest); err != nil {
-		c.JSON(404, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		slog.Info("Invalid request body", "error", err)
 		return
 	}
 
-	if err := handler.service.UpdateUserDisplayName(displayNameRequest.UID, displayNameRequest.DisplayName); err != nil {
+	if err := handler.service.UpdateUserDisplayName(c.Request.Context(), displayNameRequest.UID, displayNameRequest.DisplayName); err != nil {
 		if errors.Is(err, ErrUserNotFound) {
 			slog.Info("User not found", "uid", displayNameRequest.UID)
 		}
-		c.JSON(404, gin.H{"message": "failed to update display name"})
+		c.Error(err)
 		slog.Info("Failed to update display name", "uid", displayNameRequest.UID, "error", err)
 		return
 	}
@@ -2,6 +2,7 @@ package user_general
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"miltechserver/api/auth"
+	"miltechserver/api/middleware"
 	"miltechserver/bootstrap"
 )
 
@@ -19,21 +21,22 @@ type serviceStub struct {
 	updateErr error
 }
 
-func (s *serviceStub) UpsertUser(*bootstrap.User, auth.UserDto) error {
+func (s *serviceStub) UpsertUser(context.Context, *bootstrap.User, auth.UserDto) error {
 	return s.upsertErr
 }
 
-func (s *serviceStub) DeleteUser(string) error {
+func (s *serviceStub) DeleteUser(context.Context, string) error {
 	return s.deleteErr
 }
 
-func (s *serviceStub) UpdateUserDisplayName(string, string) error {
+func (s *serviceStub) UpdateUserDisplayName(context.Context, string, string) error {
 	return s.updateErr
 }
 
 func TestUpsertUserUnauthorized(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	group := router.Group("/api/v1/auth")
 	stub := &serviceStub{}
 
@@ -49,6 +52,7 @@ func TestUpsertUserUnauthorized(t *testing.T) {
 func TestUpsertUserBadBody(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	group := router.Group("/api/v1/auth")
 	stub := &serviceStub{}
 
@@ -64,6 +68,7 @@ func TestUpsertUserBadBody(t *testing.T) {
 func TestDeleteUserNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	group := router.Group("/api/v1/auth")
 	group.Use(func(c *gin.Context) {
 		c.Set("user", &bootstrap.User{UserID: "user"})
@@ -83,6 +88,7 @@ func TestDeleteUserNotFound(t *testing.T) {
 func TestUpdateDisplayNameInvalidBody(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	group := router.Group("/api/v1/auth")
 	stub := &serviceStub{}
 
@@ -92,5 +98,5 @@ func TestUpdateDisplayNameInvalidBody(t *testing.T) {
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	require.Equal(t, http.StatusNotFound, resp.Code)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
 }
@@ -1,12 +1,13 @@
 package user_general
 
 import (
+	"context"
 	"miltechserver/api/auth"
 	"miltechserver/bootstrap"
 )
 
 type Service interface {
-	UpsertUser(user *bootstrap.User, userDto auth.UserDto) error
-	DeleteUser(uid string) error
-	UpdateUserDisplayName(uid string, displayName string) error
+	UpsertUser(ctx context.Context, user *bootstrap.User, userDto auth.UserDto) error
+	DeleteUser(ctx context.Context, uid string) error
+	UpdateUserDisplayName(ctx context.Context, uid string, displayName string) error
 }
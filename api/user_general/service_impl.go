@@ -1,26 +1,43 @@
 package user_general
 
 import (
+	"context"
 	"miltechserver/api/auth"
 	"miltechserver/bootstrap"
+	"miltechserver/identity"
 )
 
 type ServiceImpl struct {
-	repo Repository
+	repo     Repository
+	profiles *identity.ProfileCache
 }
 
-func NewService(repo Repository) Service {
-	return &ServiceImpl{repo: repo}
+// NewService wires the user service. profiles may be nil; when set, writes to
+// a user's display name drop their cached profile.
+func NewService(repo Repository, profiles *identity.ProfileCache) Service {
+	return &ServiceImpl{repo: repo, profiles: profiles}
 }
 
-func (service *ServiceImpl) UpsertUser(user *bootstrap.User, userDto auth.UserDto) error {
-	return service.repo.UpsertUser(user, userDto)
+func (service *ServiceImpl) UpsertUser(ctx context.Context, user *bootstrap.User, userDto auth.UserDto) error {
+	if err := service.repo.UpsertUser(ctx, user, userDto); err != nil {
+		return err
+	}
+	service.profiles.Invalidate(userDto.UID)
+	return nil
 }
 
-func (service *ServiceImpl) DeleteUser(uid string) error {
-	return service.repo.DeleteUser(uid)
+func (service *ServiceImpl) DeleteUser(ctx context.Context, uid string) error {
+	if err := service.repo.DeleteUser(ctx, uid); err != nil {
+		return err
+	}
+	service.profiles.Invalidate(uid)
+	return nil
 }
 
-func (service *ServiceImpl) UpdateUserDisplayName(uid string, displayName string) error {
-	return service.repo.UpdateUserDisplayName(uid, displayName)
+func (service *ServiceImpl) UpdateUserDisplayName(ctx context.Context, uid string, displayName string) error {
+	if err := service.repo.UpdateUserDisplayName(ctx, uid, displayName); err != nil {
+		return err
+	}
+	service.profiles.Invalidate(uid)
+	return nil
 }
@@ -1,6 +1,7 @@
 package user_general
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -16,22 +17,22 @@ type repoStub struct {
 	updateErr error
 }
 
-func (r *repoStub) UpsertUser(*bootstrap.User, auth.UserDto) error {
+func (r *repoStub) UpsertUser(context.Context, *bootstrap.User, auth.UserDto) error {
 	return r.upsertErr
 }
 
-func (r *repoStub) DeleteUser(string) error {
+func (r *repoStub) DeleteUser(context.Context, string) error {
 	return r.deleteErr
 }
 
-func (r *repoStub) UpdateUserDisplayName(string, string) error {
+func (r *repoStub) UpdateUserDisplayName(context.Context, string, string) error {
 	return r.updateErr
 }
 
 func TestServiceDeleteUserReturnsError(t *testing.T) {
 	repo := &repoStub{deleteErr: errors.New("boom")}
-	svc := NewService(repo)
+	svc := NewService(repo, nil)
 
-	err := svc.DeleteUser("uid")
+	err := svc.DeleteUser(context.Background(), "uid")
 	require.Error(t, err)
 }
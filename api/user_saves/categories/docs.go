@@ -0,0 +1,45 @@
+package categories
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Saves",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getItemCategoriesByUser",
+			Method:   http.MethodGet,
+			Path:     "/user/saves/item_category",
+			Auth:     true,
+			Summary:  "Get item categories by user",
+			Response: []model.UserItemCategory{},
+		},
+		{
+			ID:      "upsertItemCategoryByUser",
+			Method:  http.MethodPut,
+			Path:    "/user/saves/item_category",
+			Auth:    true,
+			Summary: "Upsert item category by user",
+			Request: model.UserItemCategory{},
+		},
+		{
+			ID:      "deleteItemCategory",
+			Method:  http.MethodDelete,
+			Path:    "/user/saves/item_category",
+			Auth:    true,
+			Summary: "Delete item category",
+			Request: model.UserItemCategory{},
+		},
+		{
+			ID:      "deleteAllItemCategories",
+			Method:  http.MethodDelete,
+			Path:    "/user/saves/item_category/all",
+			Auth:    true,
+			Summary: "Delete all item categories",
+		},
+	},
+}}
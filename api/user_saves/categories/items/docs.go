@@ -0,0 +1,62 @@
+package items
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Saves",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getCategorizedItemsByCategory",
+			Method:   http.MethodGet,
+			Path:     "/user/saves/categorized_items/category",
+			Auth:     true,
+			Summary:  "Get categorized items by category",
+			Request:  model.UserItemCategory{},
+			Response: []model.UserItemsCategorized{},
+		},
+		{
+			ID:       "getCategorizedItemsByUser",
+			Method:   http.MethodGet,
+			Path:     "/user/saves/categorized_items",
+			Auth:     true,
+			Summary:  "Get categorized items by user",
+			Response: []model.UserItemsCategorized{},
+		},
+		{
+			ID:      "upsertCategorizedItemByUser",
+			Method:  http.MethodPut,
+			Path:    "/user/saves/categorized_items/add",
+			Auth:    true,
+			Summary: "Upsert categorized item by user",
+			Request: model.UserItemsCategorized{},
+		},
+		{
+			ID:      "upsertCategorizedItemListByUser",
+			Method:  http.MethodPut,
+			Path:    "/user/saves/categorized_items/addlist",
+			Auth:    true,
+			Summary: "Upsert categorized item list by user",
+			Request: []model.UserItemsCategorized{},
+		},
+		{
+			ID:      "deleteCategorizedItemByCategoryId",
+			Method:  http.MethodDelete,
+			Path:    "/user/saves/categorized_items",
+			Auth:    true,
+			Summary: "Delete categorized item by category id",
+			Request: model.UserItemsCategorized{},
+		},
+		{
+			ID:      "deleteAllCategorizedItems",
+			Method:  http.MethodDelete,
+			Path:    "/user/saves/categorized_items/all",
+			Auth:    true,
+			Summary: "Delete all categorized items",
+		},
+	},
+}}
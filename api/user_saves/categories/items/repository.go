@@ -1,15 +1,16 @@
 package items
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
 
 type Repository interface {
-	GetByCategory(user *bootstrap.User, category model.UserItemCategory) ([]model.UserItemsCategorized, error)
-	GetByUser(user *bootstrap.User) ([]model.UserItemsCategorized, error)
-	Upsert(user *bootstrap.User, item model.UserItemsCategorized) error
-	UpsertBatch(user *bootstrap.User, items []model.UserItemsCategorized) error
-	Delete(user *bootstrap.User, item model.UserItemsCategorized) error
-	DeleteAll(user *bootstrap.User) error
+	GetByCategory(ctx context.Context, user *bootstrap.User, category model.UserItemCategory) ([]model.UserItemsCategorized, error)
+	GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserItemsCategorized, error)
+	Upsert(ctx context.Context, user *bootstrap.User, item model.UserItemsCategorized) error
+	UpsertBatch(ctx context.Context, user *bootstrap.User, items []model.UserItemsCategorized) error
+	Delete(ctx context.Context, user *bootstrap.User, item model.UserItemsCategorized) error
+	DeleteAll(ctx context.Context, user *bootstrap.User) error
 }
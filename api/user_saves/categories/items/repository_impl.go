@@ -1,6 +1,7 @@
 package items
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -20,7 +21,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetByCategory(user *bootstrap.User, category model.UserItemCategory) ([]model.UserItemsCategorized, error) {
+func (repo *RepositoryImpl) GetByCategory(ctx context.Context, user *bootstrap.User, category model.UserItemCategory) ([]model.UserItemsCategorized, error) {
 	var items []model.UserItemsCategorized
 
 	stmt := SELECT(UserItemsCategorized.AllColumns).
@@ -28,7 +29,7 @@ func (repo *RepositoryImpl) GetByCategory(user *bootstrap.User, category model.U
 		WHERE(UserItemsCategorized.UserID.EQ(String(user.UserID)).
 			AND(UserItemsCategorized.CategoryID.EQ(String(category.ID))))
 
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving categorized items for user %s", user.UserID)
 	}
@@ -36,14 +37,14 @@ func (repo *RepositoryImpl) GetByCategory(user *bootstrap.User, category model.U
 	return items, nil
 }
 
-func (repo *RepositoryImpl) GetByUser(user *bootstrap.User) ([]model.UserItemsCategorized, error) {
+func (repo *RepositoryImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserItemsCategorized, error) {
 	var items []model.UserItemsCategorized
 
 	stmt := SELECT(UserItemsCategorized.AllColumns).
 		FROM(UserItemsCategorized).
 		WHERE(UserItemsCategorized.UserID.EQ(String(user.UserID)))
 
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving categorized items for user %s", user.UserID)
 	}
@@ -52,7 +53,7 @@ func (repo *RepositoryImpl) GetByUser(user *bootstrap.User) ([]model.UserItemsCa
 	return items, nil
 }
 
-func (repo *RepositoryImpl) Upsert(user *bootstrap.User, categorizedItem model.UserItemsCategorized) error {
+func (repo *RepositoryImpl) Upsert(ctx context.Context, user *bootstrap.User, categorizedItem model.UserItemsCategorized) error {
 	stmt := UserItemsCategorized.
 		INSERT(
 			UserItemsCategorized.UserID,
@@ -94,7 +95,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, categorizedItem model.U
 		).
 		RETURNING(UserItemsCategorized.AllColumns)
 
-	err := stmt.Query(repo.db, &categorizedItem)
+	err := stmt.QueryContext(ctx, repo.db, &categorizedItem)
 	if err != nil {
 		return errors.New("error saving categorized item: " + err.Error())
 	}
@@ -103,7 +104,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, categorizedItem model.U
 	return nil
 }
 
-func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, categorizedItems []model.UserItemsCategorized) error {
+func (repo *RepositoryImpl) UpsertBatch(ctx context.Context, user *bootstrap.User, categorizedItems []model.UserItemsCategorized) error {
 	var failedNiins []string
 	for _, val := range categorizedItems {
 		stmt := UserItemsCategorized.
@@ -132,7 +133,7 @@ func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, categorizedItems [
 					),
 			)
 
-		err := stmt.Query(repo.db, &categorizedItems)
+		err := stmt.QueryContext(ctx, repo.db, &categorizedItems)
 		if err != nil {
 			failedNiins = append(failedNiins, val.Niin)
 		}
@@ -146,7 +147,7 @@ func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, categorizedItems [
 	return nil
 }
 
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, categorizedItem model.UserItemsCategorized) error {
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, categorizedItem model.UserItemsCategorized) error {
 	stmt := UserItemsCategorized.
 		DELETE().
 		WHERE(
@@ -156,7 +157,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, categorizedItem model.U
 				AND(UserItemsCategorized.ID.EQ(String(categorizedItem.ID))),
 		)
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return errors.New("error deleting categorized item: " + err.Error())
 	}
@@ -165,11 +166,11 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, categorizedItem model.U
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteAll(user *bootstrap.User) error {
+func (repo *RepositoryImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
 	stmt := UserItemsCategorized.DELETE().
 		WHERE(UserItemsCategorized.UserID.EQ(String(user.UserID)))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return errors.New("error deleting all categorized items: " + err.Error())
 	}
@@ -3,6 +3,7 @@ package items
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/api/user_saves/shared"
 
@@ -27,20 +28,20 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getCategorizedItemsByCategory(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var itemCategory model.UserItemCategory
-	if err := c.BindJSON(&itemCategory); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&itemCategory); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	result, err := handler.service.GetByCategory(user, itemCategory)
+	result, err := handler.service.GetByCategory(c.Request.Context(), user, itemCategory)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -54,14 +55,14 @@ func (handler *Handler) getCategorizedItemsByCategory(c *gin.Context) {
 func (handler *Handler) getCategorizedItemsByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	result, err := handler.service.GetByUser(user)
+	result, err := handler.service.GetByUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -75,18 +76,18 @@ func (handler *Handler) getCategorizedItemsByUser(c *gin.Context) {
 func (handler *Handler) deleteCategorizedItemByCategoryId(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var categorizedItem model.UserItemsCategorized
-	if err := c.BindJSON(&categorizedItem); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&categorizedItem); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Delete(user, categorizedItem)
+	err = handler.service.Delete(c.Request.Context(), user, categorizedItem)
 	if err != nil {
 		c.Error(err)
 		return
@@ -98,12 +99,12 @@ func (handler *Handler) deleteCategorizedItemByCategoryId(c *gin.Context) {
 func (handler *Handler) deleteAllCategorizedItems(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	err = handler.service.DeleteAll(user)
+	err = handler.service.DeleteAll(c.Request.Context(), user)
 	if err != nil {
 		c.Error(err)
 		return
@@ -115,18 +116,18 @@ func (handler *Handler) deleteAllCategorizedItems(c *gin.Context) {
 func (handler *Handler) upsertCategorizedItemByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var categorizedItem model.UserItemsCategorized
-	if err := c.BindJSON(&categorizedItem); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&categorizedItem); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Upsert(user, categorizedItem)
+	err = handler.service.Upsert(c.Request.Context(), user, categorizedItem)
 	if err != nil {
 		c.Error(err)
 		return
@@ -138,18 +139,18 @@ func (handler *Handler) upsertCategorizedItemByUser(c *gin.Context) {
 func (handler *Handler) upsertCategorizedItemListByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var categorizedItems []model.UserItemsCategorized
-	if err := c.BindJSON(&categorizedItems); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&categorizedItems); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.UpsertBatch(user, categorizedItems)
+	err = handler.service.UpsertBatch(c.Request.Context(), user, categorizedItems)
 	if err != nil {
 		c.Error(err)
 		return
@@ -1,6 +1,7 @@
 package items
 
 import (
+	"context"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/api/user_saves/images"
@@ -17,59 +18,59 @@ func NewService(repo Repository, imagesRepo images.Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo, imagesRepo: imagesRepo}
 }
 
-func (service *ServiceImpl) GetByCategory(user *bootstrap.User, category model.UserItemCategory) ([]model.UserItemsCategorized, error) {
+func (service *ServiceImpl) GetByCategory(ctx context.Context, user *bootstrap.User, category model.UserItemCategory) ([]model.UserItemsCategorized, error) {
 	if user == nil {
 		return nil, shared.ErrUserNotFound
 	}
 
-	return service.repo.GetByCategory(user, category)
+	return service.repo.GetByCategory(ctx, user, category)
 }
 
-func (service *ServiceImpl) GetByUser(user *bootstrap.User) ([]model.UserItemsCategorized, error) {
+func (service *ServiceImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserItemsCategorized, error) {
 	if user == nil {
 		return nil, shared.ErrUserNotFound
 	}
 
-	return service.repo.GetByUser(user)
+	return service.repo.GetByUser(ctx, user)
 }
 
-func (service *ServiceImpl) Upsert(user *bootstrap.User, item model.UserItemsCategorized) error {
+func (service *ServiceImpl) Upsert(ctx context.Context, user *bootstrap.User, item model.UserItemsCategorized) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	return service.repo.Upsert(user, item)
+	return service.repo.Upsert(ctx, user, item)
 }
 
-func (service *ServiceImpl) UpsertBatch(user *bootstrap.User, items []model.UserItemsCategorized) error {
+func (service *ServiceImpl) UpsertBatch(ctx context.Context, user *bootstrap.User, items []model.UserItemsCategorized) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	return service.repo.UpsertBatch(user, items)
+	return service.repo.UpsertBatch(ctx, user, items)
 }
 
-func (service *ServiceImpl) Delete(user *bootstrap.User, item model.UserItemsCategorized) error {
+func (service *ServiceImpl) Delete(ctx context.Context, user *bootstrap.User, item model.UserItemsCategorized) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
 	if item.Image != nil && *item.Image != "" {
-		err := service.imagesRepo.Delete(user, item.ID, "categorized")
+		err := service.imagesRepo.Delete(ctx, user, item.ID, "categorized")
 		if err != nil {
 			slog.Error("Failed to delete image from blob storage", "error", err, "user_id", user.UserID, "item_id", item.ID)
 		}
 	}
 
-	return service.repo.Delete(user, item)
+	return service.repo.Delete(ctx, user, item)
 }
 
-func (service *ServiceImpl) DeleteAll(user *bootstrap.User) error {
+func (service *ServiceImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	items, err := service.repo.GetByUser(user)
+	items, err := service.repo.GetByUser(ctx, user)
 	if err != nil {
 		slog.Error("Failed to retrieve categorized items with images", "error", err, "user_id", user.UserID)
 	} else {
@@ -78,12 +79,12 @@ func (service *ServiceImpl) DeleteAll(user *bootstrap.User) error {
 				continue
 			}
 
-			err := service.imagesRepo.Delete(user, item.ID, "categorized")
+			err := service.imagesRepo.Delete(ctx, user, item.ID, "categorized")
 			if err != nil {
 				slog.Error("Failed to delete images from blob storage", "error", err, "user_id", user.UserID)
 			}
 		}
 	}
 
-	return service.repo.DeleteAll(user)
+	return service.repo.DeleteAll(ctx, user)
 }
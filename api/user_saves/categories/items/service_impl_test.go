@@ -1,6 +1,7 @@
 package items
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -18,30 +19,30 @@ type itemsRepoStub struct {
 	deleteAll bool
 }
 
-func (repo *itemsRepoStub) GetByCategory(user *bootstrap.User, category model.UserItemCategory) ([]model.UserItemsCategorized, error) {
+func (repo *itemsRepoStub) GetByCategory(_ context.Context, user *bootstrap.User, category model.UserItemCategory) ([]model.UserItemsCategorized, error) {
 	return repo.items, repo.getErr
 }
 
-func (repo *itemsRepoStub) GetByUser(user *bootstrap.User) ([]model.UserItemsCategorized, error) {
+func (repo *itemsRepoStub) GetByUser(_ context.Context, user *bootstrap.User) ([]model.UserItemsCategorized, error) {
 	if repo.getErr != nil {
 		return nil, repo.getErr
 	}
 	return repo.items, nil
 }
 
-func (repo *itemsRepoStub) Upsert(user *bootstrap.User, item model.UserItemsCategorized) error {
+func (repo *itemsRepoStub) Upsert(_ context.Context, user *bootstrap.User, item model.UserItemsCategorized) error {
 	return nil
 }
 
-func (repo *itemsRepoStub) UpsertBatch(user *bootstrap.User, items []model.UserItemsCategorized) error {
+func (repo *itemsRepoStub) UpsertBatch(_ context.Context, user *bootstrap.User, items []model.UserItemsCategorized) error {
 	return nil
 }
 
-func (repo *itemsRepoStub) Delete(user *bootstrap.User, item model.UserItemsCategorized) error {
+func (repo *itemsRepoStub) Delete(_ context.Context, user *bootstrap.User, item model.UserItemsCategorized) error {
 	return nil
 }
 
-func (repo *itemsRepoStub) DeleteAll(user *bootstrap.User) error {
+func (repo *itemsRepoStub) DeleteAll(_ context.Context, user *bootstrap.User) error {
 	repo.deleteAll = true
 	return nil
 }
@@ -50,16 +51,16 @@ type itemsImagesStub struct {
 	deletedIDs []string
 }
 
-func (repo *itemsImagesStub) Upload(user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
+func (repo *itemsImagesStub) Upload(_ context.Context, user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
 	return "", nil
 }
 
-func (repo *itemsImagesStub) Delete(user *bootstrap.User, itemID string, tableType string) error {
+func (repo *itemsImagesStub) Delete(_ context.Context, user *bootstrap.User, itemID string, tableType string) error {
 	repo.deletedIDs = append(repo.deletedIDs, itemID)
 	return nil
 }
 
-func (repo *itemsImagesStub) Get(user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
+func (repo *itemsImagesStub) Get(_ context.Context, user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
 	return nil, "", errors.New("not implemented")
 }
 
@@ -68,7 +69,7 @@ var _ images.Repository = (*itemsImagesStub)(nil)
 func TestServiceImplGetByUserRequiresUser(t *testing.T) {
 	service := NewService(&itemsRepoStub{}, &itemsImagesStub{})
 
-	_, err := service.GetByUser(nil)
+	_, err := service.GetByUser(context.Background(), nil)
 	require.ErrorIs(t, err, shared.ErrUserNotFound)
 }
 
@@ -78,7 +79,7 @@ func TestServiceImplDeleteAllDeletesImages(t *testing.T) {
 	imagesRepo := &itemsImagesStub{}
 	service := NewService(repo, imagesRepo)
 
-	err := service.DeleteAll(&bootstrap.User{UserID: "user"})
+	err := service.DeleteAll(context.Background(), &bootstrap.User{UserID: "user"})
 	require.NoError(t, err)
 	require.True(t, repo.deleteAll)
 	require.Equal(t, []string{"one"}, imagesRepo.deletedIDs)
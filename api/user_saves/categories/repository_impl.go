@@ -1,6 +1,7 @@
 package categories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -20,14 +21,14 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetByUser(user *bootstrap.User) ([]model.UserItemCategory, error) {
+func (repo *RepositoryImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserItemCategory, error) {
 	var categories []model.UserItemCategory
 
 	stmt := SELECT(UserItemCategory.AllColumns).
 		FROM(UserItemCategory).
 		WHERE(UserItemCategory.UserUID.EQ(String(user.UserID)))
 
-	err := stmt.Query(repo.db, &categories)
+	err := stmt.QueryContext(ctx, repo.db, &categories)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving item categories for user %s", user.UserID)
 	}
@@ -36,7 +37,7 @@ func (repo *RepositoryImpl) GetByUser(user *bootstrap.User) ([]model.UserItemCat
 	return categories, nil
 }
 
-func (repo *RepositoryImpl) Upsert(user *bootstrap.User, itemCategory model.UserItemCategory) error {
+func (repo *RepositoryImpl) Upsert(ctx context.Context, user *bootstrap.User, itemCategory model.UserItemCategory) error {
 	stmt := UserItemCategory.
 		INSERT(UserItemCategory.ID, UserItemCategory.UserUID,
 			UserItemCategory.Name, UserItemCategory.Comment,
@@ -55,7 +56,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, itemCategory model.User
 				WHERE(UserItemCategory.UserUID.EQ(String(user.UserID)))).
 		RETURNING(UserItemCategory.AllColumns)
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return errors.New("error saving item category")
 	}
@@ -64,8 +65,8 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, itemCategory model.User
 	return nil
 }
 
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemCategory model.UserItemCategory) error {
-	tx, err := repo.db.Begin()
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, itemCategory model.UserItemCategory) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
@@ -74,7 +75,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemCategory model.User
 	itemsStmt := UserItemsCategorized.DELETE().
 		WHERE(UserItemsCategorized.CategoryID.EQ(String(itemCategory.ID)))
 
-	_, err = itemsStmt.Exec(tx)
+	_, err = itemsStmt.ExecContext(ctx, tx)
 	if err != nil {
 		return errors.New("error deleting categorized items")
 	}
@@ -83,7 +84,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemCategory model.User
 		WHERE(UserItemCategory.UserUID.EQ(String(user.UserID)).
 			AND(UserItemCategory.ID.EQ(String(itemCategory.ID))))
 
-	_, err = catStmt.Exec(tx)
+	_, err = catStmt.ExecContext(ctx, tx)
 	if err != nil {
 		return errors.New("error deleting item category")
 	}
@@ -96,8 +97,8 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemCategory model.User
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteAll(user *bootstrap.User) error {
-	tx, err := repo.db.Begin()
+func (repo *RepositoryImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
@@ -106,7 +107,7 @@ func (repo *RepositoryImpl) DeleteAll(user *bootstrap.User) error {
 	itemsStmt := UserItemsCategorized.DELETE().
 		WHERE(UserItemsCategorized.UserID.EQ(String(user.UserID)))
 
-	_, err = itemsStmt.Exec(tx)
+	_, err = itemsStmt.ExecContext(ctx, tx)
 	if err != nil {
 		return errors.New("error deleting all categorized items: " + err.Error())
 	}
@@ -114,7 +115,7 @@ func (repo *RepositoryImpl) DeleteAll(user *bootstrap.User) error {
 	catStmt := UserItemCategory.DELETE().
 		WHERE(UserItemCategory.UserUID.EQ(String(user.UserID)))
 
-	_, err = catStmt.Exec(tx)
+	_, err = catStmt.ExecContext(ctx, tx)
 	if err != nil {
 		return errors.New("error deleting all item categories: " + err.Error())
 	}
@@ -3,6 +3,7 @@ package categories
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/api/user_saves/shared"
 
@@ -25,14 +26,14 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getItemCategoriesByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	result, err := handler.service.GetByUser(user)
+	result, err := handler.service.GetByUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -46,18 +47,18 @@ func (handler *Handler) getItemCategoriesByUser(c *gin.Context) {
 func (handler *Handler) upsertItemCategoryByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var itemCategory model.UserItemCategory
-	if err := c.BindJSON(&itemCategory); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&itemCategory); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Upsert(user, itemCategory)
+	err = handler.service.Upsert(c.Request.Context(), user, itemCategory)
 	if err != nil {
 		c.Error(err)
 		return
@@ -69,18 +70,18 @@ func (handler *Handler) upsertItemCategoryByUser(c *gin.Context) {
 func (handler *Handler) deleteItemCategory(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var itemCategory model.UserItemCategory
-	if err := c.BindJSON(&itemCategory); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&itemCategory); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Delete(user, itemCategory)
+	err = handler.service.Delete(c.Request.Context(), user, itemCategory)
 	if err != nil {
 		c.Error(err)
 		return
@@ -92,12 +93,12 @@ func (handler *Handler) deleteItemCategory(c *gin.Context) {
 func (handler *Handler) deleteAllItemCategories(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	err = handler.service.DeleteAll(user)
+	err = handler.service.DeleteAll(c.Request.Context(), user)
 	if err != nil {
 		c.Error(err)
 		return
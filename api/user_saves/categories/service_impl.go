@@ -1,6 +1,7 @@
 package categories
 
 import (
+	"context"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/api/user_saves/categories/items"
@@ -19,28 +20,28 @@ func NewService(repo Repository, itemsRepo items.Repository, imagesRepo images.R
 	return &ServiceImpl{repo: repo, itemsRepo: itemsRepo, imagesRepo: imagesRepo}
 }
 
-func (service *ServiceImpl) GetByUser(user *bootstrap.User) ([]model.UserItemCategory, error) {
+func (service *ServiceImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserItemCategory, error) {
 	if user == nil {
 		return nil, shared.ErrUserNotFound
 	}
 
-	return service.repo.GetByUser(user)
+	return service.repo.GetByUser(ctx, user)
 }
 
-func (service *ServiceImpl) Upsert(user *bootstrap.User, category model.UserItemCategory) error {
+func (service *ServiceImpl) Upsert(ctx context.Context, user *bootstrap.User, category model.UserItemCategory) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	return service.repo.Upsert(user, category)
+	return service.repo.Upsert(ctx, user, category)
 }
 
-func (service *ServiceImpl) Delete(user *bootstrap.User, category model.UserItemCategory) error {
+func (service *ServiceImpl) Delete(ctx context.Context, user *bootstrap.User, category model.UserItemCategory) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	categorizedItems, err := service.itemsRepo.GetByCategory(user, category)
+	categorizedItems, err := service.itemsRepo.GetByCategory(ctx, user, category)
 	if err != nil {
 		slog.Error("Failed to retrieve categorized items with images", "error", err, "user_id", user.UserID, "category_id", category.ID)
 	} else {
@@ -48,7 +49,7 @@ func (service *ServiceImpl) Delete(user *bootstrap.User, category model.UserItem
 			if item.Image == nil || *item.Image == "" {
 				continue
 			}
-			err := service.imagesRepo.Delete(user, item.ID, "categorized")
+			err := service.imagesRepo.Delete(ctx, user, item.ID, "categorized")
 			if err != nil {
 				slog.Error("Failed to delete categorized item images from blob storage", "error", err, "user_id", user.UserID, "category_id", category.ID)
 			}
@@ -56,13 +57,13 @@ func (service *ServiceImpl) Delete(user *bootstrap.User, category model.UserItem
 	}
 
 	if category.Image != nil && *category.Image != "" {
-		err := service.imagesRepo.Delete(user, category.ID, "category")
+		err := service.imagesRepo.Delete(ctx, user, category.ID, "category")
 		if err != nil {
 			slog.Error("Failed to delete category image from blob storage", "error", err, "user_id", user.UserID, "category_id", category.ID)
 		}
 	}
 
-	err = service.repo.Delete(user, category)
+	err = service.repo.Delete(ctx, user, category)
 	if err != nil {
 		return err
 	}
@@ -71,17 +72,17 @@ func (service *ServiceImpl) Delete(user *bootstrap.User, category model.UserItem
 	return nil
 }
 
-func (service *ServiceImpl) DeleteAll(user *bootstrap.User) error {
+func (service *ServiceImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	categorizedItems, err := service.itemsRepo.GetByUser(user)
+	categorizedItems, err := service.itemsRepo.GetByUser(ctx, user)
 	if err != nil {
 		slog.Error("Failed to retrieve categorized items with images", "error", err, "user_id", user.UserID)
 	}
 
-	categories, err := service.repo.GetByUser(user)
+	categories, err := service.repo.GetByUser(ctx, user)
 	if err != nil {
 		slog.Error("Failed to retrieve categories with images", "error", err, "user_id", user.UserID)
 	}
@@ -91,7 +92,7 @@ func (service *ServiceImpl) DeleteAll(user *bootstrap.User) error {
 			continue
 		}
 
-		err := service.imagesRepo.Delete(user, item.ID, "categorized")
+		err := service.imagesRepo.Delete(ctx, user, item.ID, "categorized")
 		if err != nil {
 			slog.Error("Failed to delete images from blob storage", "error", err, "user_id", user.UserID)
 		}
@@ -102,13 +103,13 @@ func (service *ServiceImpl) DeleteAll(user *bootstrap.User) error {
 			continue
 		}
 
-		err := service.imagesRepo.Delete(user, category.ID, "category")
+		err := service.imagesRepo.Delete(ctx, user, category.ID, "category")
 		if err != nil {
 			slog.Error("Failed to delete images from blob storage", "error", err, "user_id", user.UserID)
 		}
 	}
 
-	err = service.repo.DeleteAll(user)
+	err = service.repo.DeleteAll(ctx, user)
 	if err != nil {
 		return err
 	}
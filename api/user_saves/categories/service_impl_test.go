@@ -1,6 +1,7 @@
 package categories
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -20,23 +21,23 @@ type categoriesRepoStub struct {
 	categories   []model.UserItemCategory
 }
 
-func (repo *categoriesRepoStub) GetByUser(user *bootstrap.User) ([]model.UserItemCategory, error) {
+func (repo *categoriesRepoStub) GetByUser(_ context.Context, user *bootstrap.User) ([]model.UserItemCategory, error) {
 	if repo.getErr != nil {
 		return nil, repo.getErr
 	}
 	return repo.categories, nil
 }
 
-func (repo *categoriesRepoStub) Upsert(user *bootstrap.User, category model.UserItemCategory) error {
+func (repo *categoriesRepoStub) Upsert(_ context.Context, user *bootstrap.User, category model.UserItemCategory) error {
 	return nil
 }
 
-func (repo *categoriesRepoStub) Delete(user *bootstrap.User, category model.UserItemCategory) error {
+func (repo *categoriesRepoStub) Delete(_ context.Context, user *bootstrap.User, category model.UserItemCategory) error {
 	repo.deleteCalled = true
 	return nil
 }
 
-func (repo *categoriesRepoStub) DeleteAll(user *bootstrap.User) error {
+func (repo *categoriesRepoStub) DeleteAll(_ context.Context, user *bootstrap.User) error {
 	repo.deleteAll = true
 	return nil
 }
@@ -55,14 +56,14 @@ func (repo *categoryItemsStub) GetByCategory(user *bootstrap.User, category mode
 	return repo.itemsByCategory, nil
 }
 
-func (repo *categoryItemsStub) GetByUser(user *bootstrap.User) ([]model.UserItemsCategorized, error) {
+func (repo *categoryItemsStub) GetByUser(_ context.Context, user *bootstrap.User) ([]model.UserItemsCategorized, error) {
 	if repo.getByUserErr != nil {
 		return nil, repo.getByUserErr
 	}
 	return repo.itemsByUser, nil
 }
 
-func (repo *categoryItemsStub) Upsert(user *bootstrap.User, item model.UserItemsCategorized) error {
+func (repo *categoryItemsStub) Upsert(_ context.Context, user *bootstrap.User, item model.UserItemsCategorized) error {
 	return nil
 }
 
@@ -70,11 +71,11 @@ func (repo *categoryItemsStub) UpsertBatch(user *bootstrap.User, items []model.U
 	return nil
 }
 
-func (repo *categoryItemsStub) Delete(user *bootstrap.User, item model.UserItemsCategorized) error {
+func (repo *categoryItemsStub) Delete(_ context.Context, user *bootstrap.User, item model.UserItemsCategorized) error {
 	return nil
 }
 
-func (repo *categoryItemsStub) DeleteAll(user *bootstrap.User) error {
+func (repo *categoryItemsStub) DeleteAll(_ context.Context, user *bootstrap.User) error {
 	return nil
 }
 
@@ -84,16 +85,16 @@ type categoriesImagesStub struct {
 	deletedIDs []string
 }
 
-func (repo *categoriesImagesStub) Upload(user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
+func (repo *categoriesImagesStub) Upload(_ context.Context, user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
 	return "", nil
 }
 
-func (repo *categoriesImagesStub) Delete(user *bootstrap.User, itemID string, tableType string) error {
+func (repo *categoriesImagesStub) Delete(_ context.Context, user *bootstrap.User, itemID string, tableType string) error {
 	repo.deletedIDs = append(repo.deletedIDs, itemID)
 	return nil
 }
 
-func (repo *categoriesImagesStub) Get(user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
+func (repo *categoriesImagesStub) Get(_ context.Context, user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
 	return nil, "", errors.New("not implemented")
 }
 
@@ -102,7 +103,7 @@ var _ images.Repository = (*categoriesImagesStub)(nil)
 func TestServiceImplGetByUserRequiresUser(t *testing.T) {
 	service := NewService(&categoriesRepoStub{}, &categoryItemsStub{}, &categoriesImagesStub{})
 
-	_, err := service.GetByUser(nil)
+	_, err := service.GetByUser(context.Background(), nil)
 	require.ErrorIs(t, err, shared.ErrUserNotFound)
 }
 
@@ -112,7 +113,7 @@ func TestServiceImplDeleteCallsRepoOnItemsError(t *testing.T) {
 	service := NewService(repo, itemsRepo, &categoriesImagesStub{})
 
 	category := model.UserItemCategory{ID: "cat"}
-	err := service.Delete(&bootstrap.User{UserID: "user"}, category)
+	err := service.Delete(context.Background(), &bootstrap.User{UserID: "user"}, category)
 	require.NoError(t, err)
 	require.True(t, repo.deleteCalled)
 }
@@ -124,7 +125,7 @@ func TestServiceImplDeleteAllDeletesImages(t *testing.T) {
 	imagesRepo := &categoriesImagesStub{}
 	service := NewService(repo, itemsRepo, imagesRepo)
 
-	err := service.DeleteAll(&bootstrap.User{UserID: "user"})
+	err := service.DeleteAll(context.Background(), &bootstrap.User{UserID: "user"})
 	require.NoError(t, err)
 	require.True(t, repo.deleteAll)
 	require.ElementsMatch(t, []string{"item", "cat"}, imagesRepo.deletedIDs)
@@ -0,0 +1,14 @@
+package user_saves
+
+import (
+	"slices"
+
+	"miltechserver/api/user_saves/categories"
+	"miltechserver/api/user_saves/categories/items"
+	"miltechserver/api/user_saves/images"
+	"miltechserver/api/user_saves/quick"
+	"miltechserver/api/user_saves/serialized"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(quick.Docs, serialized.Docs, categories.Docs, items.Docs, images.Docs)
@@ -1,6 +1,7 @@
 package facade
 
 import (
+	"context"
 	"testing"
 
 	"miltechserver/.gen/miltech_ng/public/model"
@@ -119,17 +120,17 @@ func (stub *itemsStub) DeleteAll(user *bootstrap.User) error {
 	return nil
 }
 
-func (stub *imagesStub) Upload(user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
+func (stub *imagesStub) Upload(_ context.Context, user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
 	stub.called = true
 	return "", nil
 }
 
-func (stub *imagesStub) Delete(user *bootstrap.User, itemID string, tableType string) error {
+func (stub *imagesStub) Delete(_ context.Context, user *bootstrap.User, itemID string, tableType string) error {
 	stub.called = true
 	return nil
 }
 
-func (stub *imagesStub) Get(user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
+func (stub *imagesStub) Get(_ context.Context, user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
 	stub.called = true
 	return nil, "", nil
 }
@@ -143,11 +144,11 @@ func TestFacadeDelegates(t *testing.T) {
 
 	service := NewService(quick, serialized, categories, items, images)
 
-	require.NoError(t, service.UpsertQuickSaveItemByUser(&bootstrap.User{}, model.UserItemsQuick{}))
-	require.NoError(t, service.UpsertSerializedSaveItemByUser(&bootstrap.User{}, model.UserItemsSerialized{}))
-	require.NoError(t, service.UpsertItemCategoryByUser(&bootstrap.User{}, model.UserItemCategory{}))
-	require.NoError(t, service.UpsertCategorizedItemByUser(&bootstrap.User{}, model.UserItemsCategorized{}))
-	require.NoError(t, service.DeleteItemImage(&bootstrap.User{}, "id", "quick"))
+	require.NoError(t, service.UpsertQuickSaveItemByUser(context.Background(), &bootstrap.User{}, model.UserItemsQuick{}))
+	require.NoError(t, service.UpsertSerializedSaveItemByUser(context.Background(), &bootstrap.User{}, model.UserItemsSerialized{}))
+	require.NoError(t, service.UpsertItemCategoryByUser(context.Background(), &bootstrap.User{}, model.UserItemCategory{}))
+	require.NoError(t, service.UpsertCategorizedItemByUser(context.Background(), &bootstrap.User{}, model.UserItemsCategorized{}))
+	require.NoError(t, service.DeleteItemImage(context.Background(), &bootstrap.User{}, "id", "quick"))
 
 	require.True(t, quick.called)
 	require.True(t, serialized.called)
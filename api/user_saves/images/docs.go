@@ -0,0 +1,41 @@
+package images
+
+import (
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Saves",
+	Operations: []openapi.Operation{
+		{
+			ID:       "uploadItemImage",
+			Method:   http.MethodPost,
+			Path:     "/user/saves/items/image/upload/:table_type",
+			Auth:     true,
+			Summary:  "Upload the image of a saved item",
+			Query:    []openapi.Param{{Name: "item_id", Required: true}},
+			Form:     []openapi.Param{{Name: "file", Type: "file", Required: true}},
+			Response: openapi.Object{"item_id": "", "table_type": "", "blob_url": ""},
+		},
+		{
+			ID:       "deleteItemImage",
+			Method:   http.MethodDelete,
+			Path:     "/user/saves/items/image/:table_type",
+			Auth:     true,
+			Summary:  "Delete the image of a saved item",
+			Query:    []openapi.Param{{Name: "item_id", Required: true}},
+			Response: openapi.Object{"item_id": "", "table_type": ""},
+		},
+		{
+			ID:          "getItemImage",
+			Method:      http.MethodGet,
+			Path:        "/user/saves/items/image/:table_type",
+			Auth:        true,
+			Summary:     "Download the image of a saved item",
+			Query:       []openapi.Param{{Name: "item_id", Required: true}},
+			ContentType: "image/*",
+		},
+	},
+}}
@@ -1,9 +1,12 @@
 package images
 
-import "miltechserver/bootstrap"
+import (
+	"context"
+	"miltechserver/bootstrap"
+)
 
 type Repository interface {
-	Upload(user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error)
-	Delete(user *bootstrap.User, itemID string, tableType string) error
-	Get(user *bootstrap.User, itemID string, tableType string) ([]byte, string, error)
+	Upload(ctx context.Context, user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error)
+	Delete(ctx context.Context, user *bootstrap.User, itemID string, tableType string) error
+	Get(ctx context.Context, user *bootstrap.User, itemID string, tableType string) ([]byte, string, error)
 }
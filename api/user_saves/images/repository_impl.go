@@ -9,39 +9,39 @@ import (
 	"miltechserver/.gen/miltech_ng/public/model"
 	. "miltechserver/.gen/miltech_ng/public/table"
 	"miltechserver/bootstrap"
+	"miltechserver/storage"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	. "github.com/go-jet/jet/v2/postgres"
 )
 
+const containerName = "user-item-images"
+
 type RepositoryImpl struct {
-	db         *sql.DB
-	blobClient *azblob.Client
-	env        *bootstrap.Env
+	db    *sql.DB
+	store storage.ObjectStore
+	env   *bootstrap.Env
 }
 
-func NewRepository(db *sql.DB, blobClient *azblob.Client, env *bootstrap.Env) *RepositoryImpl {
-	return &RepositoryImpl{db: db, blobClient: blobClient, env: env}
+func NewRepository(db *sql.DB, store storage.ObjectStore, env *bootstrap.Env) *RepositoryImpl {
+	return &RepositoryImpl{db: db, store: store, env: env}
 }
 
 // Upload uploads an item image to Azure Blob Storage and updates the database.
-func (repo *RepositoryImpl) Upload(user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
-	if repo.blobClient == nil {
+func (repo *RepositoryImpl) Upload(ctx context.Context, user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
+	if repo.store == nil {
 		return "", fmt.Errorf("blob client is not configured")
 	}
 
-	containerName := "user-item-images"
 	blobName := fmt.Sprintf("%s/%s.jpg", user.UserID, itemID)
 
-	_, err := repo.blobClient.UploadBuffer(context.TODO(), containerName, blobName, imageData, nil)
+	err := repo.store.Put(ctx, containerName, blobName, imageData)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload image: %w", err)
 	}
 
-	blobURL := fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s",
-		repo.env.BlobAccountName, containerName, blobName)
+	blobURL := repo.store.URL(containerName, blobName)
 
-	updated, err := repo.updateImageInTable(user, itemID, blobURL, tableType)
+	updated, err := repo.updateImageInTable(ctx, user, itemID, blobURL, tableType)
 	if err != nil {
 		slog.Error("Failed to update image URL in database", "error", err, "blob_url", blobURL, "table_type", tableType)
 		return "", fmt.Errorf("failed to update image URL in database for table %s: %w", tableType, err)
@@ -57,16 +57,14 @@ func (repo *RepositoryImpl) Upload(user *bootstrap.User, itemID string, tableTyp
 }
 
 // Delete deletes an item image from Azure Blob Storage and clears the database.
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemID string, tableType string) error {
-	if repo.blobClient == nil {
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, itemID string, tableType string) error {
+	if repo.store == nil {
 		return fmt.Errorf("blob client is not configured")
 	}
 
-	ctx := context.Background()
-	containerName := "user-item-images"
 	blobName := fmt.Sprintf("%s/%s.jpg", user.UserID, itemID)
 
-	updated, err := repo.updateImageInTable(user, itemID, "", tableType)
+	updated, err := repo.updateImageInTable(ctx, user, itemID, "", tableType)
 	if err != nil {
 		slog.Error("Failed to clear image URL in database", "error", err, "item_id", itemID, "table_type", tableType)
 		return fmt.Errorf("failed to clear image URL in database for table %s: %w", tableType, err)
@@ -77,7 +75,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemID string, tableTyp
 		return fmt.Errorf("item with ID %s not found in %s table for user %s", itemID, tableType, user.UserID)
 	}
 
-	_, err = repo.blobClient.DeleteBlob(ctx, containerName, blobName, nil)
+	err = repo.store.Delete(ctx, containerName, blobName)
 	if err != nil {
 		return fmt.Errorf("failed to delete image: %w", err)
 	}
@@ -87,12 +85,12 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemID string, tableTyp
 }
 
 // Get retrieves an item image from Azure Blob Storage.
-func (repo *RepositoryImpl) Get(user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
-	if repo.blobClient == nil {
+func (repo *RepositoryImpl) Get(ctx context.Context, user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
+	if repo.store == nil {
 		return nil, "", fmt.Errorf("blob client is not configured")
 	}
 
-	imageURL, err := repo.getImageURLFromTable(user, itemID, tableType)
+	imageURL, err := repo.getImageURLFromTable(ctx, user, itemID, tableType)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get image URL from database: %w", err)
 	}
@@ -101,11 +99,9 @@ func (repo *RepositoryImpl) Get(user *bootstrap.User, itemID string, tableType s
 		return nil, "", fmt.Errorf("no image found for item %s in %s table", itemID, tableType)
 	}
 
-	containerName := "user-item-images"
 	blobName := fmt.Sprintf("%s/%s.jpg", user.UserID, itemID)
 
-	ctx := context.Background()
-	response, err := repo.blobClient.DownloadStream(ctx, containerName, blobName, nil)
+	response, err := repo.store.Stream(ctx, containerName, blobName)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to download image from blob storage: %w", err)
 	}
@@ -117,15 +113,15 @@ func (repo *RepositoryImpl) Get(user *bootstrap.User, itemID string, tableType s
 	}
 
 	contentType := "image/jpeg"
-	if response.ContentType != nil {
-		contentType = *response.ContentType
+	if response.ContentType != "" {
+		contentType = response.ContentType
 	}
 
 	slog.Info("image retrieved successfully", "user_id", user.UserID, "item_id", itemID, "table_type", tableType, "content_type", contentType)
 	return imageData, contentType, nil
 }
 
-func (repo *RepositoryImpl) getImageURLFromTable(user *bootstrap.User, itemID string, tableType string) (string, error) {
+func (repo *RepositoryImpl) getImageURLFromTable(ctx context.Context, user *bootstrap.User, itemID string, tableType string) (string, error) {
 	validTables := map[string]bool{
 		"quick":       true,
 		"serialized":  true,
@@ -147,7 +143,7 @@ func (repo *RepositoryImpl) getImageURLFromTable(user *bootstrap.User, itemID st
 			FROM(UserItemsQuick).
 			WHERE(UserItemsQuick.UserID.EQ(String(user.UserID)).
 				AND(UserItemsQuick.ID.EQ(String(itemID))))
-		err = stmt.Query(repo.db, &item)
+		err = stmt.QueryContext(ctx, repo.db, &item)
 		if err == nil {
 			imageURL = item.Image
 		}
@@ -157,7 +153,7 @@ func (repo *RepositoryImpl) getImageURLFromTable(user *bootstrap.User, itemID st
 			FROM(UserItemsSerialized).
 			WHERE(UserItemsSerialized.UserID.EQ(String(user.UserID)).
 				AND(UserItemsSerialized.ID.EQ(String(itemID))))
-		err = stmt.Query(repo.db, &item)
+		err = stmt.QueryContext(ctx, repo.db, &item)
 		if err == nil {
 			imageURL = item.Image
 		}
@@ -167,7 +163,7 @@ func (repo *RepositoryImpl) getImageURLFromTable(user *bootstrap.User, itemID st
 			FROM(UserItemsCategorized).
 			WHERE(UserItemsCategorized.UserID.EQ(String(user.UserID)).
 				AND(UserItemsCategorized.ID.EQ(String(itemID))))
-		err = stmt.Query(repo.db, &item)
+		err = stmt.QueryContext(ctx, repo.db, &item)
 		if err == nil {
 			imageURL = item.Image
 		}
@@ -177,7 +173,7 @@ func (repo *RepositoryImpl) getImageURLFromTable(user *bootstrap.User, itemID st
 			FROM(UserItemCategory).
 			WHERE(UserItemCategory.UserUID.EQ(String(user.UserID)).
 				AND(UserItemCategory.ID.EQ(String(itemID))))
-		err = stmt.Query(repo.db, &item)
+		err = stmt.QueryContext(ctx, repo.db, &item)
 		if err == nil {
 			imageURL = item.Image
 		}
@@ -194,7 +190,7 @@ func (repo *RepositoryImpl) getImageURLFromTable(user *bootstrap.User, itemID st
 	return *imageURL, nil
 }
 
-func (repo *RepositoryImpl) updateImageInTable(user *bootstrap.User, itemID string, imageURL string, tableType string) (bool, error) {
+func (repo *RepositoryImpl) updateImageInTable(ctx context.Context, user *bootstrap.User, itemID string, imageURL string, tableType string) (bool, error) {
 	validTables := map[string]bool{
 		"quick":       true,
 		"serialized":  true,
@@ -215,25 +211,25 @@ func (repo *RepositoryImpl) updateImageInTable(user *bootstrap.User, itemID stri
 			SET(String(imageURL)).
 			WHERE(UserItemsQuick.UserID.EQ(String(user.UserID)).
 				AND(UserItemsQuick.ID.EQ(String(itemID))))
-		result, err = stmt.Exec(repo.db)
+		result, err = stmt.ExecContext(ctx, repo.db)
 	case "serialized":
 		stmt := UserItemsSerialized.UPDATE(UserItemsSerialized.Image).
 			SET(String(imageURL)).
 			WHERE(UserItemsSerialized.UserID.EQ(String(user.UserID)).
 				AND(UserItemsSerialized.ID.EQ(String(itemID))))
-		result, err = stmt.Exec(repo.db)
+		result, err = stmt.ExecContext(ctx, repo.db)
 	case "categorized":
 		stmt := UserItemsCategorized.UPDATE(UserItemsCategorized.Image).
 			SET(String(imageURL)).
 			WHERE(UserItemsCategorized.UserID.EQ(String(user.UserID)).
 				AND(UserItemsCategorized.ID.EQ(String(itemID))))
-		result, err = stmt.Exec(repo.db)
+		result, err = stmt.ExecContext(ctx, repo.db)
 	case "category":
 		stmt := UserItemCategory.UPDATE(UserItemCategory.Image).
 			SET(String(imageURL)).
 			WHERE(UserItemCategory.UserUID.EQ(String(user.UserID)).
 				AND(UserItemCategory.ID.EQ(String(itemID))))
-		result, err = stmt.Exec(repo.db)
+		result, err = stmt.ExecContext(ctx, repo.db)
 	default:
 		return false, fmt.Errorf("unknown table type: %s", tableType)
 	}
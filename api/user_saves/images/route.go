@@ -3,6 +3,7 @@ package images
 import (
 	"fmt"
 	"log/slog"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/api/user_saves/shared"
 
@@ -25,27 +26,27 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) uploadItemImage(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	tableType := c.Param("table_type")
 	if tableType == "" {
-		c.JSON(400, gin.H{"message": "table_type is required"})
+		c.Error(apperror.MissingParameter("table_type"))
 		return
 	}
 
 	itemID := c.Query("item_id")
 	if itemID == "" {
-		c.JSON(400, gin.H{"message": "item_id is required"})
+		c.Error(apperror.MissingParameter("item_id"))
 		return
 	}
 
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		slog.Error("Error getting uploaded file", "error", err)
-		c.JSON(400, gin.H{"message": "failed to get uploaded file"})
+		c.Error(apperror.MissingParameter("file").WithCause(err))
 		return
 	}
 	defer file.Close()
@@ -54,14 +55,14 @@ func (handler *Handler) uploadItemImage(c *gin.Context) {
 	_, err = file.Read(imageData)
 	if err != nil {
 		slog.Error("Error reading file data", "error", err)
-		c.JSON(500, gin.H{"message": "failed to read file data"})
+		c.Error(err)
 		return
 	}
 
-	blobURL, err := handler.service.Upload(user, itemID, tableType, imageData)
+	blobURL, err := handler.service.Upload(c.Request.Context(), user, itemID, tableType, imageData)
 	if err != nil {
 		slog.Error("Error uploading image to blob storage", "error", err)
-		c.JSON(500, gin.H{"message": "failed to upload image"})
+		c.Error(shared.ErrImageUploadFailed.WithCause(err))
 		return
 	}
 
@@ -76,27 +77,27 @@ func (handler *Handler) uploadItemImage(c *gin.Context) {
 func (handler *Handler) deleteItemImage(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	tableType := c.Param("table_type")
 	if tableType == "" {
-		c.JSON(400, gin.H{"message": "table_type is required"})
+		c.Error(apperror.MissingParameter("table_type"))
 		return
 	}
 
 	itemID := c.Query("item_id")
 	if itemID == "" {
-		c.JSON(400, gin.H{"message": "item_id is required"})
+		c.Error(apperror.MissingParameter("item_id"))
 		return
 	}
 
-	err = handler.service.Delete(user, itemID, tableType)
+	err = handler.service.Delete(c.Request.Context(), user, itemID, tableType)
 	if err != nil {
 		slog.Error("Error deleting image from blob storage", "error", err)
-		c.JSON(500, gin.H{"message": "failed to delete image"})
+		c.Error(shared.ErrImageDeleteFailed.WithCause(err))
 		return
 	}
 
@@ -111,27 +112,27 @@ func (handler *Handler) deleteItemImage(c *gin.Context) {
 func (handler *Handler) getItemImage(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	tableType := c.Param("table_type")
 	if tableType == "" {
-		c.JSON(400, gin.H{"message": "table_type is required"})
+		c.Error(apperror.MissingParameter("table_type"))
 		return
 	}
 
 	itemID := c.Query("item_id")
 	if itemID == "" {
-		c.JSON(400, gin.H{"message": "item_id is required"})
+		c.Error(apperror.MissingParameter("item_id"))
 		return
 	}
 
-	imageData, contentType, err := handler.service.Get(user, itemID, tableType)
+	imageData, contentType, err := handler.service.Get(c.Request.Context(), user, itemID, tableType)
 	if err != nil {
 		slog.Error("Error retrieving image from blob storage", "error", err, "table_type", tableType, "item_id", itemID)
-		c.JSON(404, gin.H{"message": "image not found"})
+		c.Error(shared.ErrImageNotFound.WithCause(err))
 		return
 	}
 
@@ -0,0 +1,53 @@
+package quick
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Saves",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getQuickSaveItemsByUser",
+			Method:   http.MethodGet,
+			Path:     "/user/saves/quick_items",
+			Auth:     true,
+			Summary:  "Get quick save items by user",
+			Response: []model.UserItemsQuick{},
+		},
+		{
+			ID:      "upsertQuickSaveItemByUser",
+			Method:  http.MethodPut,
+			Path:    "/user/saves/quick_items/add",
+			Auth:    true,
+			Summary: "Upsert quick save item by user",
+			Request: model.UserItemsQuick{},
+		},
+		{
+			ID:      "upsertQuickSaveItemListByUser",
+			Method:  http.MethodPut,
+			Path:    "/user/saves/quick_items/addlist",
+			Auth:    true,
+			Summary: "Upsert quick save item list by user",
+			Request: []model.UserItemsQuick{},
+		},
+		{
+			ID:      "deleteQuickSaveItemByUser",
+			Method:  http.MethodDelete,
+			Path:    "/user/saves/quick_items",
+			Auth:    true,
+			Summary: "Delete quick save item by user",
+			Request: model.UserItemsQuick{},
+		},
+		{
+			ID:      "deleteAllQuickSaveItemsByUser",
+			Method:  http.MethodDelete,
+			Path:    "/user/saves/quick_items/all",
+			Auth:    true,
+			Summary: "Delete all quick save items by user",
+		},
+	},
+}}
@@ -1,6 +1,7 @@
 package quick
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"log/slog"
@@ -19,14 +20,14 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetByUser(user *bootstrap.User) ([]model.UserItemsQuick, error) {
+func (repo *RepositoryImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserItemsQuick, error) {
 	var items []model.UserItemsQuick
 
 	stmt := SELECT(UserItemsQuick.AllColumns).
 		FROM(UserItemsQuick).
 		WHERE(UserItemsQuick.UserID.EQ(String(user.UserID)))
 
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, errors.New("user saves not found")
 	}
@@ -35,7 +36,7 @@ func (repo *RepositoryImpl) GetByUser(user *bootstrap.User) ([]model.UserItemsQu
 	return items, nil
 }
 
-func (repo *RepositoryImpl) Upsert(user *bootstrap.User, quick model.UserItemsQuick) error {
+func (repo *RepositoryImpl) Upsert(ctx context.Context, user *bootstrap.User, quick model.UserItemsQuick) error {
 	stmt := UserItemsQuick.INSERT(UserItemsQuick.UserID, UserItemsQuick.Niin, UserItemsQuick.ItemName,
 		UserItemsQuick.Image, UserItemsQuick.ItemComment, UserItemsQuick.SaveTime, UserItemsQuick.LastUpdated, UserItemsQuick.ID, UserItemsQuick.Nickname).
 		MODEL(quick).
@@ -52,7 +53,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, quick model.UserItemsQu
 					AND(UserItemsQuick.Niin.EQ(String(quick.Niin))))).
 		RETURNING(UserItemsQuick.AllColumns)
 
-	err := stmt.Query(repo.db, &quick)
+	err := stmt.QueryContext(ctx, repo.db, &quick)
 	if err != nil {
 		return errors.New("error saving quick item " + err.Error())
 	}
@@ -61,7 +62,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, quick model.UserItemsQu
 	return nil
 }
 
-func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, quickItems []model.UserItemsQuick) error {
+func (repo *RepositoryImpl) UpsertBatch(ctx context.Context, user *bootstrap.User, quickItems []model.UserItemsQuick) error {
 	for _, val := range quickItems {
 		stmt := UserItemsQuick.INSERT(UserItemsQuick.UserID, UserItemsQuick.Niin, UserItemsQuick.ItemName,
 			UserItemsQuick.Image, UserItemsQuick.ItemComment, UserItemsQuick.SaveTime, UserItemsQuick.LastUpdated, UserItemsQuick.ID, UserItemsQuick.Nickname).
@@ -78,7 +79,7 @@ func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, quickItems []model
 						AND(UserItemsQuick.Niin.EQ(String(val.Niin))))).
 			RETURNING(UserItemsQuick.AllColumns)
 
-		err := stmt.Query(repo.db, &val)
+		err := stmt.QueryContext(ctx, repo.db, &val)
 		if err != nil {
 			return errors.New("error saving quick items")
 		}
@@ -88,13 +89,13 @@ func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, quickItems []model
 	return nil
 }
 
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, quick model.UserItemsQuick) error {
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, quick model.UserItemsQuick) error {
 	stmt := UserItemsQuick.
 		DELETE().
 		WHERE(UserItemsQuick.UserID.EQ(String(user.UserID)).
 			AND(UserItemsQuick.Niin.EQ(String(quick.Niin))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return errors.New("error deleting quick item: " + err.Error())
 	}
@@ -103,11 +104,11 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, quick model.UserItemsQu
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteAll(user *bootstrap.User) error {
+func (repo *RepositoryImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
 	stmt := UserItemsQuick.DELETE().
 		WHERE(UserItemsQuick.UserID.EQ(String(user.UserID)))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return errors.New("error deleting all quick items: " + err.Error())
 	}
@@ -3,6 +3,7 @@ package quick
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/api/user_saves/shared"
 
@@ -26,14 +27,14 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getQuickSaveItemsByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	result, err := handler.service.GetByUser(user)
+	result, err := handler.service.GetByUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -47,19 +48,19 @@ func (handler *Handler) getQuickSaveItemsByUser(c *gin.Context) {
 func (handler *Handler) upsertQuickSaveItemByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var quick model.UserItemsQuick
-	if err := c.BindJSON(&quick); err != nil {
+	if err := c.ShouldBindJSON(&quick); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Upsert(user, quick)
+	err = handler.service.Upsert(c.Request.Context(), user, quick)
 	if err != nil {
 		c.Error(err)
 		return
@@ -71,18 +72,18 @@ func (handler *Handler) upsertQuickSaveItemByUser(c *gin.Context) {
 func (handler *Handler) deleteQuickSaveItemByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var quick model.UserItemsQuick
-	if err := c.BindJSON(&quick); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&quick); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Delete(user, quick)
+	err = handler.service.Delete(c.Request.Context(), user, quick)
 	if err != nil {
 		c.Error(err)
 		return
@@ -94,12 +95,12 @@ func (handler *Handler) deleteQuickSaveItemByUser(c *gin.Context) {
 func (handler *Handler) deleteAllQuickSaveItemsByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	err = handler.service.DeleteAll(user)
+	err = handler.service.DeleteAll(c.Request.Context(), user)
 	if err != nil {
 		c.Error(err)
 		return
@@ -111,18 +112,18 @@ func (handler *Handler) deleteAllQuickSaveItemsByUser(c *gin.Context) {
 func (handler *Handler) upsertQuickSaveItemListByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var quickItems []model.UserItemsQuick
-	if err := c.BindJSON(&quickItems); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&quickItems); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.UpsertBatch(user, quickItems)
+	err = handler.service.UpsertBatch(c.Request.Context(), user, quickItems)
 	if err != nil {
 		c.Error(err)
 		return
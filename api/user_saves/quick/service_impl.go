@@ -1,6 +1,7 @@
 package quick
 
 import (
+	"context"
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/api/user_saves/images"
@@ -17,51 +18,51 @@ func NewService(repo Repository, imagesRepo images.Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo, imagesRepo: imagesRepo}
 }
 
-func (service *ServiceImpl) GetByUser(user *bootstrap.User) ([]model.UserItemsQuick, error) {
+func (service *ServiceImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserItemsQuick, error) {
 	if user == nil {
 		return nil, shared.ErrUserNotFound
 	}
 
-	return service.repo.GetByUser(user)
+	return service.repo.GetByUser(ctx, user)
 }
 
-func (service *ServiceImpl) Upsert(user *bootstrap.User, item model.UserItemsQuick) error {
+func (service *ServiceImpl) Upsert(ctx context.Context, user *bootstrap.User, item model.UserItemsQuick) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	return service.repo.Upsert(user, item)
+	return service.repo.Upsert(ctx, user, item)
 }
 
-func (service *ServiceImpl) UpsertBatch(user *bootstrap.User, items []model.UserItemsQuick) error {
+func (service *ServiceImpl) UpsertBatch(ctx context.Context, user *bootstrap.User, items []model.UserItemsQuick) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	return service.repo.UpsertBatch(user, items)
+	return service.repo.UpsertBatch(ctx, user, items)
 }
 
-func (service *ServiceImpl) Delete(user *bootstrap.User, item model.UserItemsQuick) error {
+func (service *ServiceImpl) Delete(ctx context.Context, user *bootstrap.User, item model.UserItemsQuick) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
 	if item.Image != nil && *item.Image != "" {
-		err := service.imagesRepo.Delete(user, item.ID, "quick")
+		err := service.imagesRepo.Delete(ctx, user, item.ID, "quick")
 		if err != nil {
 			slog.Error("Failed to delete image from blob storage", "error", err, "user_id", user.UserID, "item_id", item.ID)
 		}
 	}
 
-	return service.repo.Delete(user, item)
+	return service.repo.Delete(ctx, user, item)
 }
 
-func (service *ServiceImpl) DeleteAll(user *bootstrap.User) error {
+func (service *ServiceImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
 	if user == nil {
 		return shared.ErrUserNotFound
 	}
 
-	items, err := service.repo.GetByUser(user)
+	items, err := service.repo.GetByUser(ctx, user)
 	if err != nil {
 		slog.Error("Failed to retrieve quick items with images", "error", err, "user_id", user.UserID)
 	} else {
@@ -70,12 +71,12 @@ func (service *ServiceImpl) DeleteAll(user *bootstrap.User) error {
 				continue
 			}
 
-			err := service.imagesRepo.Delete(user, item.ID, "quick")
+			err := service.imagesRepo.Delete(ctx, user, item.ID, "quick")
 			if err != nil {
 				slog.Error("Failed to delete image from blob storage", "error", err, "user_id", user.UserID, "item_id", item.ID)
 			}
 		}
 	}
 
-	return service.repo.DeleteAll(user)
+	return service.repo.DeleteAll(ctx, user)
 }
@@ -1,6 +1,7 @@
 package quick
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -18,26 +19,26 @@ type quickRepoStub struct {
 	deleteAll bool
 }
 
-func (repo *quickRepoStub) GetByUser(user *bootstrap.User) ([]model.UserItemsQuick, error) {
+func (repo *quickRepoStub) GetByUser(_ context.Context, user *bootstrap.User) ([]model.UserItemsQuick, error) {
 	if repo.getErr != nil {
 		return nil, repo.getErr
 	}
 	return repo.items, nil
 }
 
-func (repo *quickRepoStub) Upsert(user *bootstrap.User, item model.UserItemsQuick) error {
+func (repo *quickRepoStub) Upsert(_ context.Context, user *bootstrap.User, item model.UserItemsQuick) error {
 	return nil
 }
 
-func (repo *quickRepoStub) UpsertBatch(user *bootstrap.User, items []model.UserItemsQuick) error {
+func (repo *quickRepoStub) UpsertBatch(_ context.Context, user *bootstrap.User, items []model.UserItemsQuick) error {
 	return nil
 }
 
-func (repo *quickRepoStub) Delete(user *bootstrap.User, item model.UserItemsQuick) error {
+func (repo *quickRepoStub) Delete(_ context.Context, user *bootstrap.User, item model.UserItemsQuick) error {
 	return nil
 }
 
-func (repo *quickRepoStub) DeleteAll(user *bootstrap.User) error {
+func (repo *quickRepoStub) DeleteAll(_ context.Context, user *bootstrap.User) error {
 	repo.deleteAll = true
 	return nil
 }
@@ -46,16 +47,16 @@ type imagesRepoStub struct {
 	deletedIDs []string
 }
 
-func (repo *imagesRepoStub) Upload(user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
+func (repo *imagesRepoStub) Upload(_ context.Context, user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
 	return "", nil
 }
 
-func (repo *imagesRepoStub) Delete(user *bootstrap.User, itemID string, tableType string) error {
+func (repo *imagesRepoStub) Delete(_ context.Context, user *bootstrap.User, itemID string, tableType string) error {
 	repo.deletedIDs = append(repo.deletedIDs, itemID)
 	return nil
 }
 
-func (repo *imagesRepoStub) Get(user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
+func (repo *imagesRepoStub) Get(_ context.Context, user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
 	return nil, "", errors.New("not implemented")
 }
 
@@ -64,7 +65,7 @@ var _ images.Repository = (*imagesRepoStub)(nil)
 func TestServiceImplDeleteAllRequiresUser(t *testing.T) {
 	service := NewService(&quickRepoStub{}, &imagesRepoStub{})
 
-	_, err := service.GetByUser(nil)
+	_, err := service.GetByUser(context.Background(), nil)
 	require.ErrorIs(t, err, shared.ErrUserNotFound)
 }
 
@@ -79,7 +80,7 @@ func TestServiceImplDeleteAllDeletesImages(t *testing.T) {
 	imagesRepo := &imagesRepoStub{}
 	service := NewService(repo, imagesRepo)
 
-	err := service.DeleteAll(&bootstrap.User{UserID: "user"})
+	err := service.DeleteAll(context.Background(), &bootstrap.User{UserID: "user"})
 	require.NoError(t, err)
 	require.True(t, repo.deleteAll)
 	require.Equal(t, []string{"one"}, imagesRepo.deletedIDs)
@@ -90,7 +91,7 @@ func TestServiceImplDeleteAllContinuesOnGetError(t *testing.T) {
 	imagesRepo := &imagesRepoStub{}
 	service := NewService(repo, imagesRepo)
 
-	err := service.DeleteAll(&bootstrap.User{UserID: "user"})
+	err := service.DeleteAll(context.Background(), &bootstrap.User{UserID: "user"})
 	require.NoError(t, err)
 	require.True(t, repo.deleteAll)
 }
@@ -8,19 +8,19 @@ import (
 	"miltechserver/api/user_saves/quick"
 	"miltechserver/api/user_saves/serialized"
 	"miltechserver/bootstrap"
+	"miltechserver/storage"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 )
 
 type Dependencies struct {
-	DB         *sql.DB
-	BlobClient *azblob.Client
-	Env        *bootstrap.Env
+	DB    *sql.DB
+	Store storage.ObjectStore
+	Env   *bootstrap.Env
 }
 
 func RegisterRoutes(deps Dependencies, group *gin.RouterGroup) {
-	imagesRepository := images.NewRepository(deps.DB, deps.BlobClient, deps.Env)
+	imagesRepository := images.NewRepository(deps.DB, deps.Store, deps.Env)
 	imagesService := images.NewService(imagesRepository)
 	quickRepository := quick.NewRepository(deps.DB)
 	quickService := quick.NewService(quickRepository, imagesRepository)
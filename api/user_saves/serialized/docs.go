@@ -0,0 +1,53 @@
+package serialized
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Saves",
+	Operations: []openapi.Operation{
+		{
+			ID:       "getSerializedItemsByUser",
+			Method:   http.MethodGet,
+			Path:     "/user/saves/serialized_items",
+			Auth:     true,
+			Summary:  "Get serialized items by user",
+			Response: []model.UserItemsSerialized{},
+		},
+		{
+			ID:      "upsertSerializedSaveItemByUser",
+			Method:  http.MethodPut,
+			Path:    "/user/saves/serialized_items/add",
+			Auth:    true,
+			Summary: "Upsert serialized save item by user",
+			Request: model.UserItemsSerialized{},
+		},
+		{
+			ID:      "upsertSerializedSaveItemListByUser",
+			Method:  http.MethodPut,
+			Path:    "/user/saves/serialized_items/addlist",
+			Auth:    true,
+			Summary: "Upsert serialized save item list by user",
+			Request: []model.UserItemsSerialized{},
+		},
+		{
+			ID:      "deleteSerializedSaveItemByUser",
+			Method:  http.MethodDelete,
+			Path:    "/user/saves/serialized_items",
+			Auth:    true,
+			Summary: "Delete serialized save item by user",
+			Request: model.UserItemsSerialized{},
+		},
+		{
+			ID:      "deleteAllSerializedItemsByUser",
+			Method:  http.MethodDelete,
+			Path:    "/user/saves/serialized_items/all",
+			Auth:    true,
+			Summary: "Delete all serialized items by user",
+		},
+	},
+}}
@@ -1,6 +1,7 @@
 package serialized
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"log/slog"
@@ -19,14 +20,14 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetByUser(user *bootstrap.User) ([]model.UserItemsSerialized, error) {
+func (repo *RepositoryImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserItemsSerialized, error) {
 	var items []model.UserItemsSerialized
 
 	stmt := SELECT(UserItemsSerialized.AllColumns).
 		FROM(UserItemsSerialized).
 		WHERE(UserItemsSerialized.UserID.EQ(String(user.UserID)))
 
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, errors.New("user saves not found")
 	}
@@ -35,7 +36,7 @@ func (repo *RepositoryImpl) GetByUser(user *bootstrap.User) ([]model.UserItemsSe
 	return items, nil
 }
 
-func (repo *RepositoryImpl) Upsert(user *bootstrap.User, item model.UserItemsSerialized) error {
+func (repo *RepositoryImpl) Upsert(ctx context.Context, user *bootstrap.User, item model.UserItemsSerialized) error {
 	stmt := UserItemsSerialized.INSERT(UserItemsSerialized.UserID, UserItemsSerialized.Niin, UserItemsSerialized.ItemName,
 		UserItemsSerialized.Image, UserItemsSerialized.SaveTime, UserItemsSerialized.ItemComment, UserItemsSerialized.LastUpdated, UserItemsSerialized.ID, UserItemsSerialized.Nickname).
 		MODEL(item).
@@ -48,7 +49,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, item model.UserItemsSer
 				UserItemsSerialized.Nickname.SET(String(*item.Nickname)))).
 		RETURNING(UserItemsSerialized.AllColumns)
 
-	err := stmt.Query(repo.db, &item)
+	err := stmt.QueryContext(ctx, repo.db, &item)
 	if err != nil {
 		return errors.New("error saving serialized item " + err.Error())
 	}
@@ -57,7 +58,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, item model.UserItemsSer
 	return nil
 }
 
-func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, items []model.UserItemsSerialized) error {
+func (repo *RepositoryImpl) UpsertBatch(ctx context.Context, user *bootstrap.User, items []model.UserItemsSerialized) error {
 	for _, val := range items {
 		stmt := UserItemsSerialized.INSERT(UserItemsSerialized.UserID, UserItemsSerialized.Niin, UserItemsSerialized.ItemName,
 			UserItemsSerialized.Image, UserItemsSerialized.SaveTime, UserItemsSerialized.ItemComment, UserItemsSerialized.LastUpdated, UserItemsSerialized.ID, UserItemsSerialized.Nickname).
@@ -70,7 +71,7 @@ func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, items []model.User
 				UserItemsSerialized.Nickname.SET(String(*val.Nickname)))).
 			RETURNING(UserItemsSerialized.AllColumns)
 
-		err := stmt.Query(repo.db, &val)
+		err := stmt.QueryContext(ctx, repo.db, &val)
 		if err != nil {
 			return errors.New("error saving serialized items")
 		}
@@ -80,13 +81,13 @@ func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, items []model.User
 	return nil
 }
 
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, item model.UserItemsSerialized) error {
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, item model.UserItemsSerialized) error {
 	stmt := UserItemsSerialized.
 		DELETE().
 		WHERE(UserItemsSerialized.UserID.EQ(String(user.UserID)).
 			AND(UserItemsSerialized.Niin.EQ(String(item.Niin))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return errors.New("error deleting serialized item: " + err.Error())
 	}
@@ -95,11 +96,11 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, item model.UserItemsSer
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteAll(user *bootstrap.User) error {
+func (repo *RepositoryImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
 	stmt := UserItemsSerialized.DELETE().
 		WHERE(UserItemsSerialized.UserID.EQ(String(user.UserID)))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return errors.New("error deleting all serialized items: " + err.Error())
 	}
@@ -3,6 +3,7 @@ package serialized
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/api/user_saves/shared"
 
@@ -26,14 +27,14 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getSerializedItemsByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	result, err := handler.service.GetByUser(user)
+	result, err := handler.service.GetByUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -47,18 +48,18 @@ func (handler *Handler) getSerializedItemsByUser(c *gin.Context) {
 func (handler *Handler) upsertSerializedSaveItemByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var serializedItem model.UserItemsSerialized
-	if err := c.BindJSON(&serializedItem); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&serializedItem); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Upsert(user, serializedItem)
+	err = handler.service.Upsert(c.Request.Context(), user, serializedItem)
 	if err != nil {
 		c.Error(err)
 		return
@@ -70,18 +71,18 @@ func (handler *Handler) upsertSerializedSaveItemByUser(c *gin.Context) {
 func (handler *Handler) deleteSerializedSaveItemByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var serializedItem model.UserItemsSerialized
-	if err := c.BindJSON(&serializedItem); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&serializedItem); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Delete(user, serializedItem)
+	err = handler.service.Delete(c.Request.Context(), user, serializedItem)
 	if err != nil {
 		c.Error(err)
 		return
@@ -93,12 +94,12 @@ func (handler *Handler) deleteSerializedSaveItemByUser(c *gin.Context) {
 func (handler *Handler) deleteAllSerializedItemsByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	err = handler.service.DeleteAll(user)
+	err = handler.service.DeleteAll(c.Request.Context(), user)
 	if err != nil {
 		c.Error(err)
 		return
@@ -110,18 +111,18 @@ func (handler *Handler) deleteAllSerializedItemsByUser(c *gin.Context) {
 func (handler *Handler) upsertSerializedSaveItemListByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var serializedItems []model.UserItemsSerialized
-	if err := c.BindJSON(&serializedItems); err != nil {
-		c.JSON(400, gin.H{"message": "invalid request"})
+	if err := c.ShouldBindJSON(&serializedItems); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.UpsertBatch(user, serializedItems)
+	err = handler.service.UpsertBatch(c.Request.Context(), user, serializedItems)
 	if err != nil {
 		c.Error(err)
 		return
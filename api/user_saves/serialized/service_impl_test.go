@@ -1,6 +1,7 @@
 package serialized
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -18,26 +19,26 @@ type serializedRepoStub struct {
 	deleteAll bool
 }
 
-func (repo *serializedRepoStub) GetByUser(user *bootstrap.User) ([]model.UserItemsSerialized, error) {
+func (repo *serializedRepoStub) GetByUser(_ context.Context, user *bootstrap.User) ([]model.UserItemsSerialized, error) {
 	if repo.getErr != nil {
 		return nil, repo.getErr
 	}
 	return repo.items, nil
 }
 
-func (repo *serializedRepoStub) Upsert(user *bootstrap.User, item model.UserItemsSerialized) error {
+func (repo *serializedRepoStub) Upsert(_ context.Context, user *bootstrap.User, item model.UserItemsSerialized) error {
 	return nil
 }
 
-func (repo *serializedRepoStub) UpsertBatch(user *bootstrap.User, items []model.UserItemsSerialized) error {
+func (repo *serializedRepoStub) UpsertBatch(_ context.Context, user *bootstrap.User, items []model.UserItemsSerialized) error {
 	return nil
 }
 
-func (repo *serializedRepoStub) Delete(user *bootstrap.User, item model.UserItemsSerialized) error {
+func (repo *serializedRepoStub) Delete(_ context.Context, user *bootstrap.User, item model.UserItemsSerialized) error {
 	return nil
 }
 
-func (repo *serializedRepoStub) DeleteAll(user *bootstrap.User) error {
+func (repo *serializedRepoStub) DeleteAll(_ context.Context, user *bootstrap.User) error {
 	repo.deleteAll = true
 	return nil
 }
@@ -46,16 +47,16 @@ type serializedImagesStub struct {
 	deletedIDs []string
 }
 
-func (repo *serializedImagesStub) Upload(user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
+func (repo *serializedImagesStub) Upload(_ context.Context, user *bootstrap.User, itemID string, tableType string, imageData []byte) (string, error) {
 	return "", nil
 }
 
-func (repo *serializedImagesStub) Delete(user *bootstrap.User, itemID string, tableType string) error {
+func (repo *serializedImagesStub) Delete(_ context.Context, user *bootstrap.User, itemID string, tableType string) error {
 	repo.deletedIDs = append(repo.deletedIDs, itemID)
 	return nil
 }
 
-func (repo *serializedImagesStub) Get(user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
+func (repo *serializedImagesStub) Get(_ context.Context, user *bootstrap.User, itemID string, tableType string) ([]byte, string, error) {
 	return nil, "", errors.New("not implemented")
 }
 
@@ -64,7 +65,7 @@ var _ images.Repository = (*serializedImagesStub)(nil)
 func TestServiceImplGetByUserRequiresUser(t *testing.T) {
 	service := NewService(&serializedRepoStub{}, &serializedImagesStub{})
 
-	_, err := service.GetByUser(nil)
+	_, err := service.GetByUser(context.Background(), nil)
 	require.ErrorIs(t, err, shared.ErrUserNotFound)
 }
 
@@ -79,7 +80,7 @@ func TestServiceImplDeleteAllDeletesImages(t *testing.T) {
 	imagesRepo := &serializedImagesStub{}
 	service := NewService(repo, imagesRepo)
 
-	err := service.DeleteAll(&bootstrap.User{UserID: "user"})
+	err := service.DeleteAll(context.Background(), &bootstrap.User{UserID: "user"})
 	require.NoError(t, err)
 	require.True(t, repo.deleteAll)
 	require.Equal(t, []string{"one"}, imagesRepo.deletedIDs)
@@ -90,7 +91,7 @@ func TestServiceImplDeleteAllContinuesOnGetError(t *testing.T) {
 	imagesRepo := &serializedImagesStub{}
 	service := NewService(repo, imagesRepo)
 
-	err := service.DeleteAll(&bootstrap.User{UserID: "user"})
+	err := service.DeleteAll(context.Background(), &bootstrap.User{UserID: "user"})
 	require.NoError(t, err)
 	require.True(t, repo.deleteAll)
 }
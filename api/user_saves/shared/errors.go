@@ -1,31 +1,31 @@
 package shared
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrUserNotFound = errors.New("valid user not found")
+	ErrUserNotFound = apperror.Unauthorized("user_not_found", "valid user not found")
 )
 
 var (
-	ErrItemNotFound      = errors.New("item not found")
-	ErrItemAlreadyExists = errors.New("item already exists")
-	ErrInvalidItemID     = errors.New("invalid item ID")
+	ErrItemNotFound      = apperror.NotFound("item_not_found", "item not found")
+	ErrItemAlreadyExists = apperror.Conflict("item_already_exists", "item already exists")
+	ErrInvalidItemID     = apperror.Validation("invalid_item_id", "invalid item ID")
 )
 
 var (
-	ErrCategoryNotFound      = errors.New("category not found")
-	ErrCategoryAlreadyExists = errors.New("category already exists")
-	ErrCategoryNotEmpty      = errors.New("category is not empty")
+	ErrCategoryNotFound      = apperror.NotFound("category_not_found", "category not found")
+	ErrCategoryAlreadyExists = apperror.Conflict("category_already_exists", "category already exists")
+	ErrCategoryNotEmpty      = apperror.Conflict("category_not_empty", "category is not empty")
 )
 
 var (
-	ErrImageNotFound     = errors.New("image not found")
-	ErrImageUploadFailed = errors.New("failed to upload image")
-	ErrImageDeleteFailed = errors.New("failed to delete image")
-	ErrInvalidTableType  = errors.New("invalid table type")
-	ErrImageTooLarge     = errors.New("image exceeds maximum size")
+	ErrImageNotFound     = apperror.NotFound("image_not_found", "image not found")
+	ErrImageUploadFailed = apperror.Upstream("image_upload_failed", "failed to upload image", nil)
+	ErrImageDeleteFailed = apperror.Upstream("image_delete_failed", "failed to delete image", nil)
+	ErrInvalidTableType  = apperror.Validation("invalid_table_type", "invalid table type")
+	ErrImageTooLarge     = apperror.Validation("image_too_large", "image exceeds maximum size")
 )
 
 var (
-	ErrBulkOperationPartialFailure = errors.New("bulk operation partially failed")
+	ErrBulkOperationPartialFailure = apperror.New(apperror.KindInternal, "bulk_partial_failure", "bulk operation partially failed")
 )
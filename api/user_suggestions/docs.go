@@ -0,0 +1,77 @@
+package user_suggestions
+
+import (
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "Suggestions",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listSuggestions",
+			Method:   http.MethodGet,
+			Path:     "/suggestions",
+			Summary:  "List suggestions",
+			Response: []SuggestionResponse{},
+		},
+		{
+			ID:       "createSuggestion",
+			Method:   http.MethodPost,
+			Path:     "/suggestions",
+			Auth:     true,
+			Summary:  "Create suggestion",
+			Request:  CreateSuggestionRequest{},
+			Status:   http.StatusCreated,
+			Response: SuggestionResponse{},
+		},
+		{
+			ID:       "updateSuggestion",
+			Method:   http.MethodPut,
+			Path:     "/suggestions/:id",
+			Auth:     true,
+			Summary:  "Update suggestion",
+			Request:  UpdateSuggestionRequest{},
+			Response: SuggestionResponse{},
+		},
+		{
+			ID:      "deleteSuggestion",
+			Method:  http.MethodDelete,
+			Path:    "/suggestions/:id",
+			Auth:    true,
+			Summary: "Delete suggestion",
+		},
+		{
+			ID:      "voteOnSuggestion",
+			Method:  http.MethodPost,
+			Path:    "/suggestions/:id/vote",
+			Auth:    true,
+			Summary: "Vote on a suggestion",
+			Request: VoteRequest{},
+		},
+		{
+			ID:      "removeSuggestionVote",
+			Method:  http.MethodDelete,
+			Path:    "/suggestions/:id/vote",
+			Auth:    true,
+			Summary: "Remove a suggestion vote",
+		},
+	},
+}}
+
+// ModerationDocs describes the routes RegisterModerationRoutes registers.
+var ModerationDocs = []openapi.Section{{
+	Tag: "Moderation",
+	Operations: []openapi.Operation{
+		{
+			ID:       "setSuggestionStatus",
+			Method:   http.MethodPut,
+			Path:     "/moderation/suggestions/:id/status",
+			Auth:     true,
+			Summary:  "Move a suggestion to another status (moderators only)",
+			Request:  SetStatusRequest{},
+			Response: StatusResponse{},
+		},
+	},
+}}
@@ -1,13 +1,14 @@
 package user_suggestions
 
-import "errors"
+import "miltechserver/api/apperror"
 
 var (
-	ErrUnauthorized       = errors.New("unauthorized user")
-	ErrForbidden          = errors.New("not authorized to modify this suggestion")
-	ErrSuggestionNotFound = errors.New("suggestion not found")
-	ErrInvalidTitle       = errors.New("title must be between 1 and 200 characters")
-	ErrInvalidDescription = errors.New("description must be between 1 and 2000 characters")
-	ErrInvalidDirection   = errors.New("vote direction must be 1 or -1")
-	ErrInvalidID          = errors.New("invalid suggestion ID")
+	ErrUnauthorized       = apperror.Unauthorized("unauthorized", "unauthorized user")
+	ErrForbidden          = apperror.Forbidden("not_suggestion_owner", "not authorized to modify this suggestion")
+	ErrSuggestionNotFound = apperror.NotFound("suggestion_not_found", "suggestion not found")
+	ErrInvalidTitle       = apperror.Validation("invalid_title", "title must be between 1 and 200 characters")
+	ErrInvalidDescription = apperror.Validation("invalid_description", "description must be between 1 and 2000 characters")
+	ErrInvalidDirection   = apperror.Validation("invalid_vote_direction", "vote direction must be 1 or -1")
+	ErrInvalidID          = apperror.Validation("invalid_suggestion_id", "invalid suggestion ID")
+	ErrInvalidStatus      = apperror.Validation("invalid_suggestion_status", "status must be one of Submitted, Under Review, Planned, In Progress, Completed or Declined")
 )
@@ -1,18 +1,20 @@
 package user_suggestions
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 
 	"github.com/google/uuid"
 )
 
 type Repository interface {
-	GetAllWithScores(voterID string) ([]SuggestionWithScore, error)
-	GetByID(id uuid.UUID) (*model.UserSuggestions, error)
-	Create(suggestion model.UserSuggestions) (*model.UserSuggestions, error)
-	Update(id uuid.UUID, title, description string) (*model.UserSuggestions, error)
-	Delete(id uuid.UUID) error
-	GetVote(suggestionID uuid.UUID, voterID string) (*int16, error)
-	UpsertVote(suggestionID uuid.UUID, voterID string, direction int16) error
-	DeleteVote(suggestionID uuid.UUID, voterID string) error
+	GetAllWithScores(ctx context.Context, voterID string) ([]SuggestionWithScore, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*model.UserSuggestions, error)
+	Create(ctx context.Context, suggestion model.UserSuggestions) (*model.UserSuggestions, error)
+	Update(ctx context.Context, id uuid.UUID, title, description string) (*model.UserSuggestions, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+	GetVote(ctx context.Context, suggestionID uuid.UUID, voterID string) (*int16, error)
+	UpsertVote(ctx context.Context, suggestionID uuid.UUID, voterID string, direction int16) error
+	DeleteVote(ctx context.Context, suggestionID uuid.UUID, voterID string) error
 }
@@ -1,6 +1,7 @@
 package user_suggestions
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -21,7 +22,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (r *RepositoryImpl) GetAllWithScores(voterID string) ([]SuggestionWithScore, error) {
+func (r *RepositoryImpl) GetAllWithScores(ctx context.Context, voterID string) ([]SuggestionWithScore, error) {
 	rawSQL := `
 		SELECT
 			s.id, s.user_id, s.title, s.description, s.status,
@@ -39,7 +40,7 @@ func (r *RepositoryImpl) GetAllWithScores(voterID string) ([]SuggestionWithScore
 		ORDER BY score DESC, s.created_at DESC
 	`
 
-	rows, err := r.db.Query(rawSQL, voterID)
+	rows, err := r.db.QueryContext(ctx, rawSQL, voterID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get suggestions: %w", err)
 	}
@@ -100,7 +101,7 @@ func (r *RepositoryImpl) GetAllWithScores(voterID string) ([]SuggestionWithScore
 	return results, nil
 }
 
-func (r *RepositoryImpl) GetByID(id uuid.UUID) (*model.UserSuggestions, error) {
+func (r *RepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*model.UserSuggestions, error) {
 	stmt := SELECT(
 		table.UserSuggestions.AllColumns,
 	).FROM(
@@ -110,7 +111,7 @@ func (r *RepositoryImpl) GetByID(id uuid.UUID) (*model.UserSuggestions, error) {
 	)
 
 	var suggestion model.UserSuggestions
-	err := stmt.Query(r.db, &suggestion)
+	err := stmt.QueryContext(ctx, r.db, &suggestion)
 	if err != nil {
 		if err == qrm.ErrNoRows {
 			return nil, nil
@@ -121,7 +122,7 @@ func (r *RepositoryImpl) GetByID(id uuid.UUID) (*model.UserSuggestions, error) {
 	return &suggestion, nil
 }
 
-func (r *RepositoryImpl) Create(suggestion model.UserSuggestions) (*model.UserSuggestions, error) {
+func (r *RepositoryImpl) Create(ctx context.Context, suggestion model.UserSuggestions) (*model.UserSuggestions, error) {
 	stmt := table.UserSuggestions.INSERT(
 		table.UserSuggestions.UserID,
 		table.UserSuggestions.Title,
@@ -137,7 +138,7 @@ func (r *RepositoryImpl) Create(suggestion model.UserSuggestions) (*model.UserSu
 	)
 
 	var created model.UserSuggestions
-	err := stmt.Query(r.db, &created)
+	err := stmt.QueryContext(ctx, r.db, &created)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create suggestion: %w", err)
 	}
@@ -145,7 +146,7 @@ func (r *RepositoryImpl) Create(suggestion model.UserSuggestions) (*model.UserSu
 	return &created, nil
 }
 
-func (r *RepositoryImpl) Update(id uuid.UUID, title, description string) (*model.UserSuggestions, error) {
+func (r *RepositoryImpl) Update(ctx context.Context, id uuid.UUID, title, description string) (*model.UserSuggestions, error) {
 	now := time.Now()
 
 	stmt := table.UserSuggestions.UPDATE().
@@ -158,7 +159,7 @@ func (r *RepositoryImpl) Update(id uuid.UUID, title, description string) (*model
 		RETURNING(table.UserSuggestions.AllColumns)
 
 	var updated model.UserSuggestions
-	err := stmt.Query(r.db, &updated)
+	err := stmt.QueryContext(ctx, r.db, &updated)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update suggestion: %w", err)
 	}
@@ -166,11 +167,27 @@ func (r *RepositoryImpl) Update(id uuid.UUID, title, description string) (*model
 	return &updated, nil
 }
 
-func (r *RepositoryImpl) Delete(id uuid.UUID) error {
+func (r *RepositoryImpl) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	stmt := table.UserSuggestions.UPDATE().
+		SET(
+			table.UserSuggestions.Status.SET(String(status)),
+			table.UserSuggestions.UpdatedAt.SET(TimestampzT(time.Now())),
+		).
+		WHERE(table.UserSuggestions.ID.EQ(UUID(id)))
+
+	_, err := stmt.ExecContext(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to update suggestion status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	stmt := table.UserSuggestions.DELETE().
 		WHERE(table.UserSuggestions.ID.EQ(UUID(id)))
 
-	_, err := stmt.Exec(r.db)
+	_, err := stmt.ExecContext(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to delete suggestion: %w", err)
 	}
@@ -178,14 +195,14 @@ func (r *RepositoryImpl) Delete(id uuid.UUID) error {
 	return nil
 }
 
-func (r *RepositoryImpl) GetVote(suggestionID uuid.UUID, voterID string) (*int16, error) {
+func (r *RepositoryImpl) GetVote(ctx context.Context, suggestionID uuid.UUID, voterID string) (*int16, error) {
 	rawSQL := `
 		SELECT direction FROM user_suggestion_votes
 		WHERE suggestion_id = $1 AND voter_id = $2
 	`
 
 	var direction int16
-	err := r.db.QueryRow(rawSQL, suggestionID, voterID).Scan(&direction)
+	err := r.db.QueryRowContext(ctx, rawSQL, suggestionID, voterID).Scan(&direction)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -196,7 +213,7 @@ func (r *RepositoryImpl) GetVote(suggestionID uuid.UUID, voterID string) (*int16
 	return &direction, nil
 }
 
-func (r *RepositoryImpl) UpsertVote(suggestionID uuid.UUID, voterID string, direction int16) error {
+func (r *RepositoryImpl) UpsertVote(ctx context.Context, suggestionID uuid.UUID, voterID string, direction int16) error {
 	rawSQL := `
 		INSERT INTO user_suggestion_votes (suggestion_id, voter_id, direction)
 		VALUES ($1, $2, $3)
@@ -204,7 +221,7 @@ func (r *RepositoryImpl) UpsertVote(suggestionID uuid.UUID, voterID string, dire
 		DO UPDATE SET direction = EXCLUDED.direction, created_at = now()
 	`
 
-	_, err := r.db.Exec(rawSQL, suggestionID, voterID, direction)
+	_, err := r.db.ExecContext(ctx, rawSQL, suggestionID, voterID, direction)
 	if err != nil {
 		return fmt.Errorf("failed to upsert vote: %w", err)
 	}
@@ -212,13 +229,13 @@ func (r *RepositoryImpl) UpsertVote(suggestionID uuid.UUID, voterID string, dire
 	return nil
 }
 
-func (r *RepositoryImpl) DeleteVote(suggestionID uuid.UUID, voterID string) error {
+func (r *RepositoryImpl) DeleteVote(ctx context.Context, suggestionID uuid.UUID, voterID string) error {
 	rawSQL := `
 		DELETE FROM user_suggestion_votes
 		WHERE suggestion_id = $1 AND voter_id = $2
 	`
 
-	_, err := r.db.Exec(rawSQL, suggestionID, voterID)
+	_, err := r.db.ExecContext(ctx, rawSQL, suggestionID, voterID)
 	if err != nil {
 		return fmt.Errorf("failed to delete vote: %w", err)
 	}
@@ -2,20 +2,20 @@ package user_suggestions
 
 import (
 	"database/sql"
-	"errors"
 	"net/http"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/middleware"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
+	"miltechserver/identity"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
 )
 
 type Dependencies struct {
-	DB         *sql.DB
-	AuthClient *auth.Client
+	DB       *sql.DB
+	Verifier identity.TokenVerifier
 }
 
 type Handler struct {
@@ -25,17 +25,29 @@ type Handler struct {
 func RegisterRoutes(deps Dependencies, publicGroup, authGroup *gin.RouterGroup) {
 	repo := NewRepository(deps.DB)
 	svc := NewService(repo)
-	registerHandlers(publicGroup, authGroup, deps.AuthClient, svc)
+	registerHandlers(publicGroup, authGroup, deps.Verifier, svc)
 }
 
-func registerHandlers(publicGroup, authGroup *gin.RouterGroup, authClient *auth.Client, svc Service) {
+// RegisterModerationRoutes mounts the moderator routes on moderationGroup,
+// which must already require the moderator role.
+func RegisterModerationRoutes(deps Dependencies, moderationGroup *gin.RouterGroup) {
+	registerModerationHandlers(moderationGroup, NewService(NewRepository(deps.DB)))
+}
+
+func registerModerationHandlers(moderationGroup *gin.RouterGroup, svc Service) {
+	handler := Handler{service: svc}
+
+	moderationGroup.PUT("/suggestions/:id/status", handler.setStatus)
+}
+
+func registerHandlers(publicGroup, authGroup *gin.RouterGroup, verifier identity.TokenVerifier, svc Service) {
 	handler := Handler{service: svc}
 
 	// Public route with optional auth so authenticated users get MyVote field
-	if authClient != nil {
-		publicGroup.GET("/suggestions", middleware.OptionalAuthMiddleware(authClient), handler.listSuggestions)
+	if verifier != nil {
+		publicGroup.GET("/suggestions", middleware.OptionalAuthMiddleware(verifier), handler.listSuggestions)
 	} else {
-		// Testing path: no real auth client, skip optional auth middleware
+		// Testing path: no token verifier, skip optional auth middleware
 		publicGroup.GET("/suggestions", handler.listSuggestions)
 	}
 
@@ -54,9 +66,9 @@ func (h *Handler) listSuggestions(c *gin.Context) {
 		}
 	}
 
-	suggestions, err := h.service.GetAllSuggestions(currentUser)
+	suggestions, err := h.service.GetAllSuggestions(c.Request.Context(), currentUser)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -75,16 +87,13 @@ func (h *Handler) createSuggestion(c *gin.Context) {
 
 	var req CreateSuggestionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	suggestion, err := h.service.CreateSuggestion(currentUser, req.Title, req.Description)
+	suggestion, err := h.service.CreateSuggestion(c.Request.Context(), currentUser, req.Title, req.Description)
 	if err != nil {
-		if respondError(c, err) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -105,16 +114,13 @@ func (h *Handler) updateSuggestion(c *gin.Context) {
 
 	var req UpdateSuggestionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	suggestion, err := h.service.UpdateSuggestion(currentUser, suggestionID, req.Title, req.Description)
+	suggestion, err := h.service.UpdateSuggestion(c.Request.Context(), currentUser, suggestionID, req.Title, req.Description)
 	if err != nil {
-		if respondError(c, err) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -133,12 +139,9 @@ func (h *Handler) deleteSuggestion(c *gin.Context) {
 
 	suggestionID := c.Param("id")
 
-	err := h.service.DeleteSuggestion(currentUser, suggestionID)
+	err := h.service.DeleteSuggestion(c.Request.Context(), currentUser, suggestionID)
 	if err != nil {
-		if respondError(c, err) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -158,16 +161,13 @@ func (h *Handler) vote(c *gin.Context) {
 
 	var req VoteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err := h.service.Vote(currentUser, suggestionID, req.Direction)
+	err := h.service.Vote(c.Request.Context(), currentUser, suggestionID, req.Direction)
 	if err != nil {
-		if respondError(c, err) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -185,12 +185,9 @@ func (h *Handler) removeVote(c *gin.Context) {
 
 	suggestionID := c.Param("id")
 
-	err := h.service.RemoveVote(currentUser, suggestionID)
+	err := h.service.RemoveVote(c.Request.Context(), currentUser, suggestionID)
 	if err != nil {
-		if respondError(c, err) {
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.InternalErrorResponseMessage())
+		c.Error(err)
 		return
 	}
 
@@ -200,44 +197,38 @@ func (h *Handler) removeVote(c *gin.Context) {
 	})
 }
 
+func (h *Handler) setStatus(c *gin.Context) {
+	var req SetStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	status, err := h.service.SetStatus(c.Request.Context(), c.Param("id"), req.Status)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StandardResponse{
+		Status:  http.StatusOK,
+		Message: "Suggestion status updated",
+		Data:    status,
+	})
+}
+
 func getUser(c *gin.Context) (*bootstrap.User, bool) {
 	user, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return nil, false
 	}
 
 	currentUser, ok := user.(*bootstrap.User)
 	if !ok || currentUser == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		return nil, false
 	}
 
 	return currentUser, true
 }
-
-type errorMapping struct {
-	target  error
-	status  int
-	message string
-}
-
-var errorMappings = []errorMapping{
-	{target: ErrSuggestionNotFound, status: http.StatusNotFound, message: "suggestion not found"},
-	{target: ErrInvalidTitle, status: http.StatusBadRequest, message: "invalid title"},
-	{target: ErrInvalidDescription, status: http.StatusBadRequest, message: "invalid description"},
-	{target: ErrInvalidDirection, status: http.StatusBadRequest, message: "invalid vote direction"},
-	{target: ErrInvalidID, status: http.StatusBadRequest, message: "invalid suggestion ID"},
-	{target: ErrUnauthorized, status: http.StatusUnauthorized, message: "unauthorized"},
-	{target: ErrForbidden, status: http.StatusForbidden, message: "forbidden"},
-}
-
-func respondError(c *gin.Context, err error) bool {
-	for _, em := range errorMappings {
-		if errors.Is(err, em.target) {
-			c.JSON(em.status, gin.H{"message": em.message})
-			return true
-		}
-	}
-	return false
-}
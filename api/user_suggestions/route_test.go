@@ -2,11 +2,13 @@ package user_suggestions
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"miltechserver/api/middleware"
 	"miltechserver/api/response"
 	"miltechserver/bootstrap"
 
@@ -19,43 +21,50 @@ func init() {
 }
 
 type serviceStub struct {
-	suggestions   []SuggestionResponse
+	suggestions    []SuggestionResponse
 	suggestionsErr error
-	created       *SuggestionResponse
-	createErr     error
-	updated       *SuggestionResponse
-	updateErr     error
-	deleteErr     error
-	voteErr       error
-	removeVoteErr error
-}
-
-func (s *serviceStub) GetAllSuggestions(currentUser *bootstrap.User) ([]SuggestionResponse, error) {
+	created        *SuggestionResponse
+	createErr      error
+	updated        *SuggestionResponse
+	updateErr      error
+	deleteErr      error
+	voteErr        error
+	removeVoteErr  error
+	status         *StatusResponse
+	statusErr      error
+}
+
+func (s *serviceStub) GetAllSuggestions(_ context.Context, currentUser *bootstrap.User) ([]SuggestionResponse, error) {
 	return s.suggestions, s.suggestionsErr
 }
 
-func (s *serviceStub) CreateSuggestion(user *bootstrap.User, title, description string) (*SuggestionResponse, error) {
+func (s *serviceStub) CreateSuggestion(_ context.Context, user *bootstrap.User, title, description string) (*SuggestionResponse, error) {
 	return s.created, s.createErr
 }
 
-func (s *serviceStub) UpdateSuggestion(user *bootstrap.User, suggestionID, title, description string) (*SuggestionResponse, error) {
+func (s *serviceStub) UpdateSuggestion(_ context.Context, user *bootstrap.User, suggestionID, title, description string) (*SuggestionResponse, error) {
 	return s.updated, s.updateErr
 }
 
-func (s *serviceStub) DeleteSuggestion(user *bootstrap.User, suggestionID string) error {
+func (s *serviceStub) DeleteSuggestion(_ context.Context, user *bootstrap.User, suggestionID string) error {
 	return s.deleteErr
 }
 
-func (s *serviceStub) Vote(user *bootstrap.User, suggestionID string, direction int16) error {
+func (s *serviceStub) Vote(_ context.Context, user *bootstrap.User, suggestionID string, direction int16) error {
 	return s.voteErr
 }
 
-func (s *serviceStub) RemoveVote(user *bootstrap.User, suggestionID string) error {
+func (s *serviceStub) RemoveVote(_ context.Context, user *bootstrap.User, suggestionID string) error {
 	return s.removeVoteErr
 }
 
+func (s *serviceStub) SetStatus(_ context.Context, suggestionID, status string) (*StatusResponse, error) {
+	return s.status, s.statusErr
+}
+
 func setupRouter(svc Service) *gin.Engine {
 	router := gin.New()
+	router.Use(middleware.ErrorHandler)
 	publicGroup := router.Group("/api/v1")
 	authGroup := router.Group("/api/v1/auth")
 	// Simulate auth middleware setting user in context
@@ -194,3 +203,28 @@ func TestRemoveVote_200(t *testing.T) {
 	w := performRequest(router, "DELETE", "/api/v1/auth/suggestions/abc-123/vote", nil)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func setupModerationRouter(svc Service) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	moderationGroup := router.Group("/api/v1/auth/moderation")
+	registerModerationHandlers(moderationGroup, svc)
+	return router
+}
+
+func TestSetStatus_200(t *testing.T) {
+	svc := &serviceStub{status: &StatusResponse{ID: "abc-123", Status: "Planned"}}
+	router := setupModerationRouter(svc)
+
+	w := performRequest(router, "PUT", "/api/v1/auth/moderation/suggestions/abc-123/status", SetStatusRequest{Status: "Planned"})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"Planned"`)
+}
+
+func TestSetStatus_400_InvalidStatus(t *testing.T) {
+	svc := &serviceStub{statusErr: ErrInvalidStatus}
+	router := setupModerationRouter(svc)
+
+	w := performRequest(router, "PUT", "/api/v1/auth/moderation/suggestions/abc-123/status", SetStatusRequest{Status: "Shipped"})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -1,12 +1,17 @@
 package user_suggestions
 
-import "miltechserver/bootstrap"
+import (
+	"context"
+	"miltechserver/bootstrap"
+)
 
 type Service interface {
-	GetAllSuggestions(currentUser *bootstrap.User) ([]SuggestionResponse, error)
-	CreateSuggestion(user *bootstrap.User, title, description string) (*SuggestionResponse, error)
-	UpdateSuggestion(user *bootstrap.User, suggestionID, title, description string) (*SuggestionResponse, error)
-	DeleteSuggestion(user *bootstrap.User, suggestionID string) error
-	Vote(user *bootstrap.User, suggestionID string, direction int16) error
-	RemoveVote(user *bootstrap.User, suggestionID string) error
+	GetAllSuggestions(ctx context.Context, currentUser *bootstrap.User) ([]SuggestionResponse, error)
+	CreateSuggestion(ctx context.Context, user *bootstrap.User, title, description string) (*SuggestionResponse, error)
+	UpdateSuggestion(ctx context.Context, user *bootstrap.User, suggestionID, title, description string) (*SuggestionResponse, error)
+	DeleteSuggestion(ctx context.Context, user *bootstrap.User, suggestionID string) error
+	Vote(ctx context.Context, user *bootstrap.User, suggestionID string, direction int16) error
+	RemoveVote(ctx context.Context, user *bootstrap.User, suggestionID string) error
+	// SetStatus moves a suggestion to status. Only moderators reach it.
+	SetStatus(ctx context.Context, suggestionID, status string) (*StatusResponse, error)
 }
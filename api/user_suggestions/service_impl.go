@@ -1,6 +1,8 @@
 package user_suggestions
 
 import (
+	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,7 +20,7 @@ func NewService(repo Repository) *ServiceImpl {
 	return &ServiceImpl{repo: repo}
 }
 
-func (s *ServiceImpl) CreateSuggestion(user *bootstrap.User, title, description string) (*SuggestionResponse, error) {
+func (s *ServiceImpl) CreateSuggestion(ctx context.Context, user *bootstrap.User, title, description string) (*SuggestionResponse, error) {
 	if user == nil {
 		return nil, ErrUnauthorized
 	}
@@ -38,11 +40,11 @@ func (s *ServiceImpl) CreateSuggestion(user *bootstrap.User, title, description
 		UserID:      user.UserID,
 		Title:       trimmedTitle,
 		Description: trimmedDesc,
-		Status:      "Submitted",
+		Status:      StatusSubmitted,
 		Show:        &showFalse,
 	}
 
-	created, err := s.repo.Create(suggestion)
+	created, err := s.repo.Create(ctx, suggestion)
 	if err != nil {
 		return nil, err
 	}
@@ -51,13 +53,13 @@ func (s *ServiceImpl) CreateSuggestion(user *bootstrap.User, title, description
 	return &resp, nil
 }
 
-func (s *ServiceImpl) GetAllSuggestions(currentUser *bootstrap.User) ([]SuggestionResponse, error) {
+func (s *ServiceImpl) GetAllSuggestions(ctx context.Context, currentUser *bootstrap.User) ([]SuggestionResponse, error) {
 	voterID := ""
 	if currentUser != nil {
 		voterID = currentUser.UserID
 	}
 
-	suggestions, err := s.repo.GetAllWithScores(voterID)
+	suggestions, err := s.repo.GetAllWithScores(ctx, voterID)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +98,7 @@ func (s *ServiceImpl) GetAllSuggestions(currentUser *bootstrap.User) ([]Suggesti
 	return results, nil
 }
 
-func (s *ServiceImpl) UpdateSuggestion(user *bootstrap.User, suggestionID, title, description string) (*SuggestionResponse, error) {
+func (s *ServiceImpl) UpdateSuggestion(ctx context.Context, user *bootstrap.User, suggestionID, title, description string) (*SuggestionResponse, error) {
 	if user == nil {
 		return nil, ErrUnauthorized
 	}
@@ -106,7 +108,7 @@ func (s *ServiceImpl) UpdateSuggestion(user *bootstrap.User, suggestionID, title
 		return nil, err
 	}
 
-	existing, err := s.repo.GetByID(id)
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +129,7 @@ func (s *ServiceImpl) UpdateSuggestion(user *bootstrap.User, suggestionID, title
 		return nil, ErrInvalidDescription
 	}
 
-	updated, err := s.repo.Update(id, trimmedTitle, trimmedDesc)
+	updated, err := s.repo.Update(ctx, id, trimmedTitle, trimmedDesc)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +138,7 @@ func (s *ServiceImpl) UpdateSuggestion(user *bootstrap.User, suggestionID, title
 	return &resp, nil
 }
 
-func (s *ServiceImpl) DeleteSuggestion(user *bootstrap.User, suggestionID string) error {
+func (s *ServiceImpl) DeleteSuggestion(ctx context.Context, user *bootstrap.User, suggestionID string) error {
 	if user == nil {
 		return ErrUnauthorized
 	}
@@ -146,7 +148,7 @@ func (s *ServiceImpl) DeleteSuggestion(user *bootstrap.User, suggestionID string
 		return err
 	}
 
-	existing, err := s.repo.GetByID(id)
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -157,10 +159,10 @@ func (s *ServiceImpl) DeleteSuggestion(user *bootstrap.User, suggestionID string
 		return ErrForbidden
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.Delete(ctx, id)
 }
 
-func (s *ServiceImpl) Vote(user *bootstrap.User, suggestionID string, direction int16) error {
+func (s *ServiceImpl) Vote(ctx context.Context, user *bootstrap.User, suggestionID string, direction int16) error {
 	if user == nil {
 		return ErrUnauthorized
 	}
@@ -174,7 +176,7 @@ func (s *ServiceImpl) Vote(user *bootstrap.User, suggestionID string, direction
 		return ErrInvalidDirection
 	}
 
-	existing, err := s.repo.GetByID(id)
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -185,18 +187,18 @@ func (s *ServiceImpl) Vote(user *bootstrap.User, suggestionID string, direction
 	// Check if the user already has a vote on this suggestion.
 	// If they do, remove it (toggle off) to prevent ±2 score jumps
 	// when switching vote directions.
-	existingVote, err := s.repo.GetVote(id, user.UserID)
+	existingVote, err := s.repo.GetVote(ctx, id, user.UserID)
 	if err != nil {
 		return err
 	}
 	if existingVote != nil {
-		return s.repo.DeleteVote(id, user.UserID)
+		return s.repo.DeleteVote(ctx, id, user.UserID)
 	}
 
-	return s.repo.UpsertVote(id, user.UserID, direction)
+	return s.repo.UpsertVote(ctx, id, user.UserID, direction)
 }
 
-func (s *ServiceImpl) RemoveVote(user *bootstrap.User, suggestionID string) error {
+func (s *ServiceImpl) RemoveVote(ctx context.Context, user *bootstrap.User, suggestionID string) error {
 	if user == nil {
 		return ErrUnauthorized
 	}
@@ -206,7 +208,32 @@ func (s *ServiceImpl) RemoveVote(user *bootstrap.User, suggestionID string) erro
 		return err
 	}
 
-	return s.repo.DeleteVote(id, user.UserID)
+	return s.repo.DeleteVote(ctx, id, user.UserID)
+}
+
+func (s *ServiceImpl) SetStatus(ctx context.Context, suggestionID, status string) (*StatusResponse, error) {
+	id, err := parseID(suggestionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(statuses, status) {
+		return nil, ErrInvalidStatus
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrSuggestionNotFound
+	}
+
+	if err := s.repo.UpdateStatus(ctx, id, status); err != nil {
+		return nil, err
+	}
+
+	return &StatusResponse{ID: id.String(), Status: status}, nil
 }
 
 func mapSuggestionToResponse(s *model.UserSuggestions, username string, score int, myVote *int16) SuggestionResponse {
@@ -22,8 +22,25 @@ type VoteRequest struct {
 	Direction int16 `json:"direction"`
 }
 
+type SetStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// --- Statuses ---
+
+// StatusSubmitted is the status of a new suggestion. Moderators move it
+// through the others.
+const StatusSubmitted = "Submitted"
+
+var statuses = []string{StatusSubmitted, "Under Review", "Planned", "In Progress", "Completed", "Declined"}
+
 // --- Responses ---
 
+type StatusResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
 type SuggestionResponse struct {
 	ID          string  `json:"id"`
 	UserID      string  `json:"user_id"`
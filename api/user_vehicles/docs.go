@@ -0,0 +1,12 @@
+package user_vehicles
+
+import (
+	"slices"
+
+	"miltechserver/api/user_vehicles/notification_items"
+	"miltechserver/api/user_vehicles/notifications"
+	"miltechserver/api/user_vehicles/vehicles"
+)
+
+// Docs describes the routes RegisterRoutes registers, for the OpenAPI document.
+var Docs = slices.Concat(vehicles.Docs, notifications.Docs, notification_items.Docs)
@@ -0,0 +1,68 @@
+package notification_items
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Vehicles",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listUserNotificationItems",
+			Method:   http.MethodGet,
+			Path:     "/user/notification-items",
+			Auth:     true,
+			Summary:  "List the user's notification items",
+			Response: []model.UserNotificationItems{},
+		},
+		{
+			ID:       "listUserNotificationItemsByNotification",
+			Method:   http.MethodGet,
+			Path:     "/user/notification-items/notification/:notificationId",
+			Auth:     true,
+			Summary:  "List the items of a notification",
+			Response: []model.UserNotificationItems{},
+		},
+		{
+			ID:       "getUserNotificationItem",
+			Method:   http.MethodGet,
+			Path:     "/user/notification-items/:itemId",
+			Auth:     true,
+			Summary:  "Get a notification item",
+			Response: model.UserNotificationItems{},
+		},
+		{
+			ID:      "upsertUserNotificationItem",
+			Method:  http.MethodPut,
+			Path:    "/user/notification-items",
+			Auth:    true,
+			Summary: "Create or update a notification item",
+			Request: model.UserNotificationItems{},
+		},
+		{
+			ID:      "upsertUserNotificationItems",
+			Method:  http.MethodPut,
+			Path:    "/user/notification-items/list",
+			Auth:    true,
+			Summary: "Create or update several notification items",
+			Request: []model.UserNotificationItems{},
+		},
+		{
+			ID:      "deleteUserNotificationItem",
+			Method:  http.MethodDelete,
+			Path:    "/user/notification-items/:itemId",
+			Auth:    true,
+			Summary: "Delete a notification item",
+		},
+		{
+			ID:      "deleteUserNotificationItemsByNotification",
+			Method:  http.MethodDelete,
+			Path:    "/user/notification-items/notification/:notificationId",
+			Auth:    true,
+			Summary: "Delete all items of a notification",
+		},
+	},
+}}
@@ -1,16 +1,17 @@
 package notification_items
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
 
 type Repository interface {
-	GetByUserID(user *bootstrap.User) ([]model.UserNotificationItems, error)
-	GetByNotificationID(user *bootstrap.User, notificationID string) ([]model.UserNotificationItems, error)
-	GetByID(user *bootstrap.User, itemID string) (*model.UserNotificationItems, error)
-	Upsert(user *bootstrap.User, item model.UserNotificationItems) error
-	UpsertBatch(user *bootstrap.User, items []model.UserNotificationItems) error
-	Delete(user *bootstrap.User, itemID string) error
-	DeleteAllByNotification(user *bootstrap.User, notificationID string) error
+	GetByUserID(ctx context.Context, user *bootstrap.User) ([]model.UserNotificationItems, error)
+	GetByNotificationID(ctx context.Context, user *bootstrap.User, notificationID string) ([]model.UserNotificationItems, error)
+	GetByID(ctx context.Context, user *bootstrap.User, itemID string) (*model.UserNotificationItems, error)
+	Upsert(ctx context.Context, user *bootstrap.User, item model.UserNotificationItems) error
+	UpsertBatch(ctx context.Context, user *bootstrap.User, items []model.UserNotificationItems) error
+	Delete(ctx context.Context, user *bootstrap.User, itemID string) error
+	DeleteAllByNotification(ctx context.Context, user *bootstrap.User, notificationID string) error
 }
@@ -1,6 +1,7 @@
 package notification_items
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -20,7 +21,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserNotificationItems, error) {
+func (repo *RepositoryImpl) GetByUserID(ctx context.Context, user *bootstrap.User) ([]model.UserNotificationItems, error) {
 	var items []model.UserNotificationItems
 
 	if user == nil {
@@ -31,7 +32,7 @@ func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserNotif
 		FROM(UserNotificationItems).
 		WHERE(UserNotificationItems.UserID.EQ(String(user.UserID)))
 
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving notification items for user %s: %w", user.UserID, err)
 	}
@@ -40,7 +41,7 @@ func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserNotif
 	return items, nil
 }
 
-func (repo *RepositoryImpl) GetByNotificationID(user *bootstrap.User, notificationID string) ([]model.UserNotificationItems, error) {
+func (repo *RepositoryImpl) GetByNotificationID(ctx context.Context, user *bootstrap.User, notificationID string) ([]model.UserNotificationItems, error) {
 	var items []model.UserNotificationItems
 
 	if user == nil {
@@ -52,7 +53,7 @@ func (repo *RepositoryImpl) GetByNotificationID(user *bootstrap.User, notificati
 		WHERE(UserNotificationItems.UserID.EQ(String(user.UserID)).
 			AND(UserNotificationItems.NotificationID.EQ(String(notificationID))))
 
-	err := stmt.Query(repo.db, &items)
+	err := stmt.QueryContext(ctx, repo.db, &items)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving items for notification %s: %w", notificationID, err)
 	}
@@ -61,7 +62,7 @@ func (repo *RepositoryImpl) GetByNotificationID(user *bootstrap.User, notificati
 	return items, nil
 }
 
-func (repo *RepositoryImpl) GetByID(user *bootstrap.User, itemID string) (*model.UserNotificationItems, error) {
+func (repo *RepositoryImpl) GetByID(ctx context.Context, user *bootstrap.User, itemID string) (*model.UserNotificationItems, error) {
 	var item model.UserNotificationItems
 
 	if user == nil {
@@ -73,7 +74,7 @@ func (repo *RepositoryImpl) GetByID(user *bootstrap.User, itemID string) (*model
 		WHERE(UserNotificationItems.UserID.EQ(String(user.UserID)).
 			AND(UserNotificationItems.ID.EQ(String(itemID))))
 
-	err := stmt.Query(repo.db, &item)
+	err := stmt.QueryContext(ctx, repo.db, &item)
 	if err != nil {
 		return nil, fmt.Errorf("notification item not found for user %s: %w", user.UserID, err)
 	}
@@ -82,7 +83,7 @@ func (repo *RepositoryImpl) GetByID(user *bootstrap.User, itemID string) (*model
 	return &item, nil
 }
 
-func (repo *RepositoryImpl) Upsert(user *bootstrap.User, item model.UserNotificationItems) error {
+func (repo *RepositoryImpl) Upsert(ctx context.Context, user *bootstrap.User, item model.UserNotificationItems) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -102,7 +103,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, item model.UserNotifica
 				WHERE(UserNotificationItems.ID.EQ(String(item.ID)))).
 		RETURNING(UserNotificationItems.AllColumns)
 
-	err := stmt.Query(repo.db, &item)
+	err := stmt.QueryContext(ctx, repo.db, &item)
 	if err != nil {
 		return fmt.Errorf("error saving notification item: %w", err)
 	}
@@ -111,7 +112,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, item model.UserNotifica
 	return nil
 }
 
-func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, items []model.UserNotificationItems) error {
+func (repo *RepositoryImpl) UpsertBatch(ctx context.Context, user *bootstrap.User, items []model.UserNotificationItems) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -132,7 +133,7 @@ func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, items []model.User
 					UserNotificationItems.Quantity.SET(Int32(item.Quantity))).
 					WHERE(UserNotificationItems.ID.EQ(String(item.ID))))
 
-		_, err := stmt.Exec(repo.db)
+		_, err := stmt.ExecContext(ctx, repo.db)
 		if err != nil {
 			failedItems = append(failedItems, item.ID)
 		}
@@ -146,7 +147,7 @@ func (repo *RepositoryImpl) UpsertBatch(user *bootstrap.User, items []model.User
 	return nil
 }
 
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemID string) error {
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, itemID string) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -155,7 +156,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemID string) error {
 		WHERE(UserNotificationItems.UserID.EQ(String(user.UserID)).
 			AND(UserNotificationItems.ID.EQ(String(itemID))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error deleting notification item: %w", err)
 	}
@@ -164,7 +165,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, itemID string) error {
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteAllByNotification(user *bootstrap.User, notificationID string) error {
+func (repo *RepositoryImpl) DeleteAllByNotification(ctx context.Context, user *bootstrap.User, notificationID string) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -173,7 +174,7 @@ func (repo *RepositoryImpl) DeleteAllByNotification(user *bootstrap.User, notifi
 		WHERE(UserNotificationItems.UserID.EQ(String(user.UserID)).
 			AND(UserNotificationItems.NotificationID.EQ(String(notificationID))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error deleting items for notification: %w", err)
 	}
@@ -3,6 +3,7 @@ package notification_items
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/api/user_vehicles/shared"
 
@@ -28,14 +29,14 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	result, err := handler.service.GetByUser(user)
+	result, err := handler.service.GetByUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -49,20 +50,20 @@ func (handler *Handler) getByUser(c *gin.Context) {
 func (handler *Handler) getByNotification(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	notificationID := c.Param("notificationId")
 	if notificationID == "" {
-		c.JSON(400, gin.H{"message": "notification ID is required"})
+		c.Error(apperror.MissingParameter("notification_id"))
 		return
 	}
 
-	result, err := handler.service.GetByNotification(user, notificationID)
+	result, err := handler.service.GetByNotification(c.Request.Context(), user, notificationID)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -76,20 +77,20 @@ func (handler *Handler) getByNotification(c *gin.Context) {
 func (handler *Handler) getByID(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	itemID := c.Param("itemId")
 	if itemID == "" {
-		c.JSON(400, gin.H{"message": "item ID is required"})
+		c.Error(apperror.MissingParameter("item_id"))
 		return
 	}
 
-	result, err := handler.service.GetByID(user, itemID)
+	result, err := handler.service.GetByID(c.Request.Context(), user, itemID)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -103,19 +104,19 @@ func (handler *Handler) getByID(c *gin.Context) {
 func (handler *Handler) upsert(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var item model.UserNotificationItems
-	if err := c.BindJSON(&item); err != nil {
+	if err := c.ShouldBindJSON(&item); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Upsert(user, item)
+	err = handler.service.Upsert(c.Request.Context(), user, item)
 	if err != nil {
 		c.Error(err)
 		return
@@ -127,19 +128,19 @@ func (handler *Handler) upsert(c *gin.Context) {
 func (handler *Handler) upsertBatch(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var items []model.UserNotificationItems
-	if err := c.BindJSON(&items); err != nil {
+	if err := c.ShouldBindJSON(&items); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.UpsertBatch(user, items)
+	err = handler.service.UpsertBatch(c.Request.Context(), user, items)
 	if err != nil {
 		c.Error(err)
 		return
@@ -151,18 +152,18 @@ func (handler *Handler) upsertBatch(c *gin.Context) {
 func (handler *Handler) delete(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	itemID := c.Param("itemId")
 	if itemID == "" {
-		c.JSON(400, gin.H{"message": "item ID is required"})
+		c.Error(apperror.MissingParameter("item_id"))
 		return
 	}
 
-	err = handler.service.Delete(user, itemID)
+	err = handler.service.Delete(c.Request.Context(), user, itemID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -174,18 +175,18 @@ func (handler *Handler) delete(c *gin.Context) {
 func (handler *Handler) deleteAllByNotification(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	notificationID := c.Param("notificationId")
 	if notificationID == "" {
-		c.JSON(400, gin.H{"message": "notification ID is required"})
+		c.Error(apperror.MissingParameter("notification_id"))
 		return
 	}
 
-	err = handler.service.DeleteAllByNotification(user, notificationID)
+	err = handler.service.DeleteAllByNotification(c.Request.Context(), user, notificationID)
 	if err != nil {
 		c.Error(err)
 		return
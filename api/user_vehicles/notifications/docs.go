@@ -0,0 +1,60 @@
+package notifications
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Vehicles",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listUserVehicleNotifications",
+			Method:   http.MethodGet,
+			Path:     "/user/vehicle-notifications",
+			Auth:     true,
+			Summary:  "List the user's vehicle notifications",
+			Response: []model.UserVehicleNotifications{},
+		},
+		{
+			ID:       "listUserVehicleNotificationsByVehicle",
+			Method:   http.MethodGet,
+			Path:     "/user/vehicle-notifications/vehicle/:vehicleId",
+			Auth:     true,
+			Summary:  "List the notifications of one of the user's vehicles",
+			Response: []model.UserVehicleNotifications{},
+		},
+		{
+			ID:       "getUserVehicleNotification",
+			Method:   http.MethodGet,
+			Path:     "/user/vehicle-notifications/:notificationId",
+			Auth:     true,
+			Summary:  "Get a vehicle notification",
+			Response: model.UserVehicleNotifications{},
+		},
+		{
+			ID:      "upsertUserVehicleNotification",
+			Method:  http.MethodPut,
+			Path:    "/user/vehicle-notifications",
+			Auth:    true,
+			Summary: "Create or update a vehicle notification",
+			Request: model.UserVehicleNotifications{},
+		},
+		{
+			ID:      "deleteUserVehicleNotification",
+			Method:  http.MethodDelete,
+			Path:    "/user/vehicle-notifications/:notificationId",
+			Auth:    true,
+			Summary: "Delete a vehicle notification",
+		},
+		{
+			ID:      "deleteUserVehicleNotificationsByVehicle",
+			Method:  http.MethodDelete,
+			Path:    "/user/vehicle-notifications/vehicle/:vehicleId",
+			Auth:    true,
+			Summary: "Delete all notifications of a vehicle",
+		},
+	},
+}}
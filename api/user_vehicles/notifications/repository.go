@@ -1,15 +1,16 @@
 package notifications
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
 
 type Repository interface {
-	GetByUserID(user *bootstrap.User) ([]model.UserVehicleNotifications, error)
-	GetByVehicleID(user *bootstrap.User, vehicleID string) ([]model.UserVehicleNotifications, error)
-	GetByID(user *bootstrap.User, notificationID string) (*model.UserVehicleNotifications, error)
-	Upsert(user *bootstrap.User, notification model.UserVehicleNotifications) error
-	Delete(user *bootstrap.User, notificationID string) error
-	DeleteAllByVehicle(user *bootstrap.User, vehicleID string) error
+	GetByUserID(ctx context.Context, user *bootstrap.User) ([]model.UserVehicleNotifications, error)
+	GetByVehicleID(ctx context.Context, user *bootstrap.User, vehicleID string) ([]model.UserVehicleNotifications, error)
+	GetByID(ctx context.Context, user *bootstrap.User, notificationID string) (*model.UserVehicleNotifications, error)
+	Upsert(ctx context.Context, user *bootstrap.User, notification model.UserVehicleNotifications) error
+	Delete(ctx context.Context, user *bootstrap.User, notificationID string) error
+	DeleteAllByVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) error
 }
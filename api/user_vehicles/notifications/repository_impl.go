@@ -1,6 +1,7 @@
 package notifications
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -20,7 +21,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserVehicleNotifications, error) {
+func (repo *RepositoryImpl) GetByUserID(ctx context.Context, user *bootstrap.User) ([]model.UserVehicleNotifications, error) {
 	var notifications []model.UserVehicleNotifications
 
 	if user == nil {
@@ -31,7 +32,7 @@ func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserVehic
 		FROM(UserVehicleNotifications).
 		WHERE(UserVehicleNotifications.UserID.EQ(String(user.UserID)))
 
-	err := stmt.Query(repo.db, &notifications)
+	err := stmt.QueryContext(ctx, repo.db, &notifications)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving notifications for user %s: %w", user.UserID, err)
 	}
@@ -40,7 +41,7 @@ func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserVehic
 	return notifications, nil
 }
 
-func (repo *RepositoryImpl) GetByVehicleID(user *bootstrap.User, vehicleID string) ([]model.UserVehicleNotifications, error) {
+func (repo *RepositoryImpl) GetByVehicleID(ctx context.Context, user *bootstrap.User, vehicleID string) ([]model.UserVehicleNotifications, error) {
 	var notifications []model.UserVehicleNotifications
 
 	if user == nil {
@@ -52,7 +53,7 @@ func (repo *RepositoryImpl) GetByVehicleID(user *bootstrap.User, vehicleID strin
 		WHERE(UserVehicleNotifications.UserID.EQ(String(user.UserID)).
 			AND(UserVehicleNotifications.VehicleID.EQ(String(vehicleID))))
 
-	err := stmt.Query(repo.db, &notifications)
+	err := stmt.QueryContext(ctx, repo.db, &notifications)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving notifications for vehicle %s: %w", vehicleID, err)
 	}
@@ -61,7 +62,7 @@ func (repo *RepositoryImpl) GetByVehicleID(user *bootstrap.User, vehicleID strin
 	return notifications, nil
 }
 
-func (repo *RepositoryImpl) GetByID(user *bootstrap.User, notificationID string) (*model.UserVehicleNotifications, error) {
+func (repo *RepositoryImpl) GetByID(ctx context.Context, user *bootstrap.User, notificationID string) (*model.UserVehicleNotifications, error) {
 	var notification model.UserVehicleNotifications
 
 	if user == nil {
@@ -73,7 +74,7 @@ func (repo *RepositoryImpl) GetByID(user *bootstrap.User, notificationID string)
 		WHERE(UserVehicleNotifications.UserID.EQ(String(user.UserID)).
 			AND(UserVehicleNotifications.ID.EQ(String(notificationID))))
 
-	err := stmt.Query(repo.db, &notification)
+	err := stmt.QueryContext(ctx, repo.db, &notification)
 	if err != nil {
 		return nil, fmt.Errorf("notification not found for user %s: %w", user.UserID, err)
 	}
@@ -82,7 +83,7 @@ func (repo *RepositoryImpl) GetByID(user *bootstrap.User, notificationID string)
 	return &notification, nil
 }
 
-func (repo *RepositoryImpl) Upsert(user *bootstrap.User, notification model.UserVehicleNotifications) error {
+func (repo *RepositoryImpl) Upsert(ctx context.Context, user *bootstrap.User, notification model.UserVehicleNotifications) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -104,7 +105,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, notification model.User
 				WHERE(UserVehicleNotifications.ID.EQ(String(notification.ID)))).
 		RETURNING(UserVehicleNotifications.AllColumns)
 
-	err := stmt.Query(repo.db, &notification)
+	err := stmt.QueryContext(ctx, repo.db, &notification)
 	if err != nil {
 		return fmt.Errorf("error saving notification: %w", err)
 	}
@@ -113,7 +114,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, notification model.User
 	return nil
 }
 
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, notificationID string) error {
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, notificationID string) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -122,7 +123,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, notificationID string)
 		WHERE(UserVehicleNotifications.UserID.EQ(String(user.UserID)).
 			AND(UserVehicleNotifications.ID.EQ(String(notificationID))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error deleting notification: %w", err)
 	}
@@ -131,7 +132,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, notificationID string)
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteAllByVehicle(user *bootstrap.User, vehicleID string) error {
+func (repo *RepositoryImpl) DeleteAllByVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -140,7 +141,7 @@ func (repo *RepositoryImpl) DeleteAllByVehicle(user *bootstrap.User, vehicleID s
 		WHERE(UserVehicleNotifications.UserID.EQ(String(user.UserID)).
 			AND(UserVehicleNotifications.VehicleID.EQ(String(vehicleID))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error deleting notifications for vehicle: %w", err)
 	}
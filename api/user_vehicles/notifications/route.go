@@ -3,6 +3,7 @@ package notifications
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/api/user_vehicles/shared"
 
@@ -27,14 +28,14 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	result, err := handler.service.GetByUser(user)
+	result, err := handler.service.GetByUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -48,20 +49,20 @@ func (handler *Handler) getByUser(c *gin.Context) {
 func (handler *Handler) getByVehicle(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicleId")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle ID is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
-	result, err := handler.service.GetByVehicle(user, vehicleID)
+	result, err := handler.service.GetByVehicle(c.Request.Context(), user, vehicleID)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -75,20 +76,20 @@ func (handler *Handler) getByVehicle(c *gin.Context) {
 func (handler *Handler) getByID(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	notificationID := c.Param("notificationId")
 	if notificationID == "" {
-		c.JSON(400, gin.H{"message": "notification ID is required"})
+		c.Error(apperror.MissingParameter("notification_id"))
 		return
 	}
 
-	result, err := handler.service.GetByID(user, notificationID)
+	result, err := handler.service.GetByID(c.Request.Context(), user, notificationID)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -102,19 +103,19 @@ func (handler *Handler) getByID(c *gin.Context) {
 func (handler *Handler) upsert(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var notification model.UserVehicleNotifications
-	if err := c.BindJSON(&notification); err != nil {
+	if err := c.ShouldBindJSON(&notification); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Upsert(user, notification)
+	err = handler.service.Upsert(c.Request.Context(), user, notification)
 	if err != nil {
 		c.Error(err)
 		return
@@ -126,18 +127,18 @@ func (handler *Handler) upsert(c *gin.Context) {
 func (handler *Handler) delete(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	notificationID := c.Param("notificationId")
 	if notificationID == "" {
-		c.JSON(400, gin.H{"message": "notification ID is required"})
+		c.Error(apperror.MissingParameter("notification_id"))
 		return
 	}
 
-	err = handler.service.Delete(user, notificationID)
+	err = handler.service.Delete(c.Request.Context(), user, notificationID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -149,18 +150,18 @@ func (handler *Handler) delete(c *gin.Context) {
 func (handler *Handler) deleteAllByVehicle(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicleId")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle ID is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
-	err = handler.service.DeleteAllByVehicle(user, vehicleID)
+	err = handler.service.DeleteAllByVehicle(c.Request.Context(), user, vehicleID)
 	if err != nil {
 		c.Error(err)
 		return
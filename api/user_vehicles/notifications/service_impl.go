@@ -1,6 +1,7 @@
 package notifications
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
@@ -13,34 +14,34 @@ func NewService(repository Repository) *ServiceImpl {
 	return &ServiceImpl{repository: repository}
 }
 
-func (service *ServiceImpl) GetByUser(user *bootstrap.User) ([]model.UserVehicleNotifications, error) {
-	notifications, err := service.repository.GetByUserID(user)
+func (service *ServiceImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserVehicleNotifications, error) {
+	notifications, err := service.repository.GetByUserID(ctx, user)
 	if notifications == nil {
 		return []model.UserVehicleNotifications{}, nil
 	}
 	return notifications, err
 }
 
-func (service *ServiceImpl) GetByVehicle(user *bootstrap.User, vehicleID string) ([]model.UserVehicleNotifications, error) {
-	notifications, err := service.repository.GetByVehicleID(user, vehicleID)
+func (service *ServiceImpl) GetByVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) ([]model.UserVehicleNotifications, error) {
+	notifications, err := service.repository.GetByVehicleID(ctx, user, vehicleID)
 	if notifications == nil {
 		return []model.UserVehicleNotifications{}, nil
 	}
 	return notifications, err
 }
 
-func (service *ServiceImpl) GetByID(user *bootstrap.User, notificationID string) (*model.UserVehicleNotifications, error) {
-	return service.repository.GetByID(user, notificationID)
+func (service *ServiceImpl) GetByID(ctx context.Context, user *bootstrap.User, notificationID string) (*model.UserVehicleNotifications, error) {
+	return service.repository.GetByID(ctx, user, notificationID)
 }
 
-func (service *ServiceImpl) Upsert(user *bootstrap.User, notification model.UserVehicleNotifications) error {
-	return service.repository.Upsert(user, notification)
+func (service *ServiceImpl) Upsert(ctx context.Context, user *bootstrap.User, notification model.UserVehicleNotifications) error {
+	return service.repository.Upsert(ctx, user, notification)
 }
 
-func (service *ServiceImpl) Delete(user *bootstrap.User, notificationID string) error {
-	return service.repository.Delete(user, notificationID)
+func (service *ServiceImpl) Delete(ctx context.Context, user *bootstrap.User, notificationID string) error {
+	return service.repository.Delete(ctx, user, notificationID)
 }
 
-func (service *ServiceImpl) DeleteAllByVehicle(user *bootstrap.User, vehicleID string) error {
-	return service.repository.DeleteAllByVehicle(user, vehicleID)
+func (service *ServiceImpl) DeleteAllByVehicle(ctx context.Context, user *bootstrap.User, vehicleID string) error {
+	return service.repository.DeleteAllByVehicle(ctx, user, vehicleID)
 }
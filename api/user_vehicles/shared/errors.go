@@ -1,23 +1,23 @@
 package shared
 
-import "errors"
+import "miltechserver/api/apperror"
 
 // User errors
 var (
-	ErrUserNotFound = errors.New("valid user not found")
+	ErrUserNotFound = apperror.Unauthorized("user_not_found", "valid user not found")
 )
 
 // Vehicle errors
 var (
-	ErrVehicleNotFound = errors.New("vehicle not found")
+	ErrVehicleNotFound = apperror.NotFound("vehicle_not_found", "vehicle not found")
 )
 
 // Notification errors
 var (
-	ErrNotificationNotFound = errors.New("notification not found")
+	ErrNotificationNotFound = apperror.NotFound("notification_not_found", "notification not found")
 )
 
 // Item errors
 var (
-	ErrItemNotFound = errors.New("item not found")
+	ErrItemNotFound = apperror.NotFound("item_not_found", "item not found")
 )
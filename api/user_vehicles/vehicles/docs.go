@@ -0,0 +1,52 @@
+package vehicles
+
+import (
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/openapi"
+	"net/http"
+)
+
+// Docs describes the routes served by this package for the OpenAPI document.
+var Docs = []openapi.Section{{
+	Tag: "User Vehicles",
+	Operations: []openapi.Operation{
+		{
+			ID:       "listUserVehicles",
+			Method:   http.MethodGet,
+			Path:     "/user/vehicles",
+			Auth:     true,
+			Summary:  "List the user's vehicles",
+			Response: []model.UserVehicle{},
+		},
+		{
+			ID:       "getUserVehicle",
+			Method:   http.MethodGet,
+			Path:     "/user/vehicles/:vehicleId",
+			Auth:     true,
+			Summary:  "Get a vehicle",
+			Response: model.UserVehicle{},
+		},
+		{
+			ID:      "upsertUserVehicle",
+			Method:  http.MethodPut,
+			Path:    "/user/vehicles",
+			Auth:    true,
+			Summary: "Create or update a vehicle",
+			Request: model.UserVehicle{},
+		},
+		{
+			ID:      "deleteUserVehicle",
+			Method:  http.MethodDelete,
+			Path:    "/user/vehicles/:vehicleId",
+			Auth:    true,
+			Summary: "Delete a vehicle",
+		},
+		{
+			ID:      "deleteAllUserVehicles",
+			Method:  http.MethodDelete,
+			Path:    "/user/vehicles",
+			Auth:    true,
+			Summary: "Delete all of the user's vehicles",
+		},
+	},
+}}
@@ -1,6 +1,7 @@
 package vehicles
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -20,7 +21,7 @@ func NewRepository(db *sql.DB) *RepositoryImpl {
 	return &RepositoryImpl{db: db}
 }
 
-func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserVehicle, error) {
+func (repo *RepositoryImpl) GetByUserID(ctx context.Context, user *bootstrap.User) ([]model.UserVehicle, error) {
 	var vehicles []model.UserVehicle
 
 	if user == nil {
@@ -31,7 +32,7 @@ func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserVehic
 		FROM(UserVehicle).
 		WHERE(UserVehicle.UserID.EQ(String(user.UserID)))
 
-	err := stmt.Query(repo.db, &vehicles)
+	err := stmt.QueryContext(ctx, repo.db, &vehicles)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving vehicles for user %s: %w", user.UserID, err)
 	}
@@ -40,7 +41,7 @@ func (repo *RepositoryImpl) GetByUserID(user *bootstrap.User) ([]model.UserVehic
 	return vehicles, nil
 }
 
-func (repo *RepositoryImpl) GetByID(user *bootstrap.User, vehicleID string) (*model.UserVehicle, error) {
+func (repo *RepositoryImpl) GetByID(ctx context.Context, user *bootstrap.User, vehicleID string) (*model.UserVehicle, error) {
 	var vehicle model.UserVehicle
 
 	if user == nil {
@@ -52,7 +53,7 @@ func (repo *RepositoryImpl) GetByID(user *bootstrap.User, vehicleID string) (*mo
 		WHERE(UserVehicle.UserID.EQ(String(user.UserID)).
 			AND(UserVehicle.ID.EQ(String(vehicleID))))
 
-	err := stmt.Query(repo.db, &vehicle)
+	err := stmt.QueryContext(ctx, repo.db, &vehicle)
 	if err != nil {
 		return nil, fmt.Errorf("vehicle not found for user %s: %w", user.UserID, err)
 	}
@@ -61,7 +62,7 @@ func (repo *RepositoryImpl) GetByID(user *bootstrap.User, vehicleID string) (*mo
 	return &vehicle, nil
 }
 
-func (repo *RepositoryImpl) Upsert(user *bootstrap.User, vehicle model.UserVehicle) error {
+func (repo *RepositoryImpl) Upsert(ctx context.Context, user *bootstrap.User, vehicle model.UserVehicle) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -86,7 +87,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, vehicle model.UserVehic
 				WHERE(UserVehicle.ID.EQ(String(vehicle.ID)))).
 		RETURNING(UserVehicle.AllColumns)
 
-	err := stmt.Query(repo.db, &vehicle)
+	err := stmt.QueryContext(ctx, repo.db, &vehicle)
 	if err != nil {
 		return fmt.Errorf("error saving vehicle: %w", err)
 	}
@@ -95,7 +96,7 @@ func (repo *RepositoryImpl) Upsert(user *bootstrap.User, vehicle model.UserVehic
 	return nil
 }
 
-func (repo *RepositoryImpl) Delete(user *bootstrap.User, vehicleID string) error {
+func (repo *RepositoryImpl) Delete(ctx context.Context, user *bootstrap.User, vehicleID string) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -104,7 +105,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, vehicleID string) error
 		WHERE(UserVehicle.UserID.EQ(String(user.UserID)).
 			AND(UserVehicle.ID.EQ(String(vehicleID))))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error deleting vehicle: %w", err)
 	}
@@ -113,7 +114,7 @@ func (repo *RepositoryImpl) Delete(user *bootstrap.User, vehicleID string) error
 	return nil
 }
 
-func (repo *RepositoryImpl) DeleteAll(user *bootstrap.User) error {
+func (repo *RepositoryImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
 	if user == nil {
 		return errors.New("valid user not found")
 	}
@@ -121,7 +122,7 @@ func (repo *RepositoryImpl) DeleteAll(user *bootstrap.User) error {
 	stmt := UserVehicle.DELETE().
 		WHERE(UserVehicle.UserID.EQ(String(user.UserID)))
 
-	_, err := stmt.Exec(repo.db)
+	_, err := stmt.ExecContext(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("error deleting all vehicles: %w", err)
 	}
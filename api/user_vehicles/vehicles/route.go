@@ -3,6 +3,7 @@ package vehicles
 import (
 	"log/slog"
 	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/response"
 	"miltechserver/api/user_vehicles/shared"
 
@@ -26,14 +27,14 @@ func RegisterRoutes(router *gin.RouterGroup, service Service) {
 func (handler *Handler) getByUser(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	result, err := handler.service.GetByUser(user)
+	result, err := handler.service.GetByUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -47,20 +48,20 @@ func (handler *Handler) getByUser(c *gin.Context) {
 func (handler *Handler) getByID(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicleId")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle ID is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
-	result, err := handler.service.GetByID(user, vehicleID)
+	result, err := handler.service.GetByID(c.Request.Context(), user, vehicleID)
 	if err != nil {
-		c.JSON(404, response.EmptyResponseMessage())
+		c.Error(apperror.ErrNoItemsFound.WithCause(err))
 		return
 	}
 
@@ -74,19 +75,19 @@ func (handler *Handler) getByID(c *gin.Context) {
 func (handler *Handler) upsert(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	var vehicle model.UserVehicle
-	if err := c.BindJSON(&vehicle); err != nil {
+	if err := c.ShouldBindJSON(&vehicle); err != nil {
 		slog.Info("invalid request", "error", err)
-		c.JSON(400, gin.H{"message": "invalid request"})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	err = handler.service.Upsert(user, vehicle)
+	err = handler.service.Upsert(c.Request.Context(), user, vehicle)
 	if err != nil {
 		c.Error(err)
 		return
@@ -98,18 +99,18 @@ func (handler *Handler) upsert(c *gin.Context) {
 func (handler *Handler) delete(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
 	vehicleID := c.Param("vehicleId")
 	if vehicleID == "" {
-		c.JSON(400, gin.H{"message": "vehicle ID is required"})
+		c.Error(apperror.MissingParameter("vehicle_id"))
 		return
 	}
 
-	err = handler.service.Delete(user, vehicleID)
+	err = handler.service.Delete(c.Request.Context(), user, vehicleID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -121,12 +122,12 @@ func (handler *Handler) delete(c *gin.Context) {
 func (handler *Handler) deleteAll(c *gin.Context) {
 	user, err := shared.GetUserFromContext(c)
 	if err != nil {
-		c.JSON(401, gin.H{"message": "unauthorized"})
+		c.Error(apperror.ErrUnauthenticated)
 		slog.Info("Unauthorized request")
 		return
 	}
 
-	err = handler.service.DeleteAll(user)
+	err = handler.service.DeleteAll(c.Request.Context(), user)
 	if err != nil {
 		c.Error(err)
 		return
@@ -1,6 +1,7 @@
 package vehicles
 
 import (
+	"context"
 	"miltechserver/.gen/miltech_ng/public/model"
 	"miltechserver/bootstrap"
 )
@@ -13,26 +14,26 @@ func NewService(repository Repository) *ServiceImpl {
 	return &ServiceImpl{repository: repository}
 }
 
-func (service *ServiceImpl) GetByUser(user *bootstrap.User) ([]model.UserVehicle, error) {
-	vehicles, err := service.repository.GetByUserID(user)
+func (service *ServiceImpl) GetByUser(ctx context.Context, user *bootstrap.User) ([]model.UserVehicle, error) {
+	vehicles, err := service.repository.GetByUserID(ctx, user)
 	if vehicles == nil {
 		return []model.UserVehicle{}, nil
 	}
 	return vehicles, err
 }
 
-func (service *ServiceImpl) GetByID(user *bootstrap.User, vehicleID string) (*model.UserVehicle, error) {
-	return service.repository.GetByID(user, vehicleID)
+func (service *ServiceImpl) GetByID(ctx context.Context, user *bootstrap.User, vehicleID string) (*model.UserVehicle, error) {
+	return service.repository.GetByID(ctx, user, vehicleID)
 }
 
-func (service *ServiceImpl) Upsert(user *bootstrap.User, vehicle model.UserVehicle) error {
-	return service.repository.Upsert(user, vehicle)
+func (service *ServiceImpl) Upsert(ctx context.Context, user *bootstrap.User, vehicle model.UserVehicle) error {
+	return service.repository.Upsert(ctx, user, vehicle)
 }
 
-func (service *ServiceImpl) Delete(user *bootstrap.User, vehicleID string) error {
-	return service.repository.Delete(user, vehicleID)
+func (service *ServiceImpl) Delete(ctx context.Context, user *bootstrap.User, vehicleID string) error {
+	return service.repository.Delete(ctx, user, vehicleID)
 }
 
-func (service *ServiceImpl) DeleteAll(user *bootstrap.User) error {
-	return service.repository.DeleteAll(user)
+func (service *ServiceImpl) DeleteAll(ctx context.Context, user *bootstrap.User) error {
+	return service.repository.DeleteAll(ctx, user)
 }
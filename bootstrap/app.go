@@ -5,22 +5,55 @@ import (
 	"database/sql"
 	"log/slog"
 
+	"miltechserver/events"
+	"miltechserver/jobs"
+	"miltechserver/push"
+	"miltechserver/ratelimit"
+	"miltechserver/realtime"
+	"miltechserver/storage"
+
 	"firebase.google.com/go/v4/auth"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 )
 
 type Application struct {
-	Db         *sql.DB
-	FireAuth   *auth.Client
-	BlobClient *azblob.Client
+	Db          *sql.DB
+	FireAuth    *auth.Client
+	Store       storage.ObjectStore
+	RateLimiter *ratelimit.Limiter
+	// RequestLimiter counts every authenticated request, per replica
+	RequestLimiter *ratelimit.Limiter
+	Jobs           *jobs.Scheduler
+	Events         *events.Bus
+	Push           push.Sender
+	Stream         *realtime.Hub
+	Lifecycle      *Lifecycle
 }
 
 func App(ctx context.Context, env *Env) Application {
 	slog.Info("Starting application, or not, we'll see.")
 	app := &Application{}
+	app.Lifecycle = NewLifecycle()
 	app.Db = NewSqlClient(env)
-	app.FireAuth = NewFireAuth(ctx)
-	app.BlobClient = NewAzureBlobClient(env)
+	// Registered first so it runs last, after workers have flushed their writes.
+	app.Lifecycle.OnStop("database", func(context.Context) error {
+		return app.Db.Close()
+	})
+	if env.AuthProvider == "firebase" {
+		app.FireAuth = NewFireAuth(ctx)
+	}
+	app.Store = NewObjectStore(env)
+	app.Push = NewPushSender(ctx, env)
+	// Stopped before the database so runs in progress can record their outcome.
+	app.Jobs = NewScheduler(app.Db)
+	app.Lifecycle.OnStop("job scheduler", app.Jobs.Stop)
+	// Its dispatcher also stops before the database, recording the deliveries in progress.
+	app.Events = NewEventBus(app.Db, app.Jobs)
+	app.Lifecycle.OnStop("event dispatcher", app.Events.Stop)
+	app.Stream = NewStream(env, app.Db, app.Events, app.Lifecycle)
+	app.RateLimiter = NewRateLimiter(env, app.Db, app.Jobs)
+	app.Lifecycle.OnStop("rate limiter", app.RateLimiter.Stop)
+	app.RequestLimiter = NewRequestLimiter()
+	app.Lifecycle.OnStop("request rate limiter", app.RequestLimiter.Stop)
 
 	return *app
 }
@@ -11,11 +11,32 @@ import (
 	"google.golang.org/api/option"
 )
 
+// Application-wide roles. Shop roles are separate and live in shop_members.
+const (
+	RoleSiteAdmin = "site_admin"
+	RoleModerator = "moderator"
+)
+
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Email    string   `json:"email"`
+	Roles    []string `json:"roles"`
+}
+
+// HasRole reports whether the user holds any of roles. Site admins hold every role.
+func (u *User) HasRole(roles ...string) bool {
+	for _, held := range u.Roles {
+		if held == RoleSiteAdmin {
+			return true
+		}
+		for _, role := range roles {
+			if held == role {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func NewFirebaseApp(ctx context.Context) (*firebase.App, error) {
@@ -0,0 +1,19 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserHasRole(t *testing.T) {
+	moderator := &User{Roles: []string{RoleModerator}}
+	require.True(t, moderator.HasRole(RoleModerator))
+	require.True(t, moderator.HasRole(RoleSiteAdmin, RoleModerator))
+	require.False(t, moderator.HasRole(RoleSiteAdmin))
+
+	admin := &User{Roles: []string{RoleSiteAdmin}}
+	require.True(t, admin.HasRole(RoleModerator))
+
+	require.False(t, (&User{}).HasRole(RoleModerator))
+}
@@ -10,10 +10,14 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// DSN returns the connection string for the database in env.
+func DSN(env *Env) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", env.Host, env.Port, env.Username, env.Password, env.DBName, env.SslMode)
+}
+
 func NewSqlClient(env *Env) *sql.DB {
-	dsnStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", env.Host, env.Port, env.Username, env.Password, env.DBName, env.SslMode)
 	slog.Info("Connecting to Database")
-	db, err := sql.Open("postgres", dsnStr)
+	db, err := sql.Open("postgres", DSN(env))
 	helper.PanicOnError(err)
 
 	err = db.Ping()
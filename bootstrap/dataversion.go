@@ -0,0 +1,25 @@
+package bootstrap
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"miltechserver/dataversion"
+)
+
+// NewDataVersion reads the current FedLog data version and, unless
+// DATA_VERSION_POLL_SECONDS is 0, keeps polling it until shutdown. Call it
+// after the schema is up to date, since it reads the data_version table.
+func NewDataVersion(ctx context.Context, env *Env, db *sql.DB, lifecycle *Lifecycle) *dataversion.Watcher {
+	watcher := dataversion.New(db, env.DBDate)
+	if err := watcher.Poll(ctx); err != nil {
+		slog.Warn("Unable to read data version, using DB_DATE", "db_date", env.DBDate, "error", err)
+	}
+	if env.DataVersionPollInterval > 0 {
+		watcher.Start(time.Duration(env.DataVersionPollInterval) * time.Second)
+	}
+	lifecycle.OnStop("data version watcher", watcher.Stop)
+	return watcher
+}
@@ -17,22 +17,80 @@ func getEnvAsInt(key string, defaultVal int) int {
 	return defaultVal
 }
 
+func getEnvAsBool(key string, defaultVal bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if boolVal, err := strconv.ParseBool(val); err == nil {
+			return boolVal
+		}
+	}
+	return defaultVal
+}
+
+func getEnvAsString(key string, defaultVal string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultVal
+}
+
 type Env struct {
 	Host             string
 	Port             string
 	Username         string
 	Password         string
 	DBName           string
-	DBDate           string
+	DBDate           string // FedLog load date used until the data_version row records one
 	DBSchema         string
 	BlobAccountName  string
 	ServerAddress    string
 	SslMode          string
-	ContextTimeout   int
 	MobileAppVersion string
+	// Seconds a request may run before its context, and the queries using it,
+	// are cancelled; 0 disables the deadline
+	ContextTimeout int
+	// Seconds to wait for in-flight requests and background workers on shutdown
+	ShutdownTimeout int
 	// Connection pool settings for parallel query workloads
 	DBMaxOpenConns int
 	DBMaxIdleConns int
+	// Token verification: "firebase" (default), "oidc" or "local"
+	AuthProvider        string
+	OIDCIssuer          string
+	OIDCAudience        string
+	OIDCJWKSURL         string
+	AuthLocalHMACSecret string
+	AuthLocalRSAKeyFile string
+	// Seconds a user's display name is cached after reading it from the users table
+	UserProfileCacheTTL int
+	// Bearer token required to scrape /metrics; empty leaves it open
+	MetricsToken string
+	// Trace exporter: "none" (default), "stdout" or "otlp" (configured by OTEL_EXPORTER_OTLP_*)
+	TracingExporter string
+	// Apply pending migrations at startup instead of refusing to start
+	MigrateOnStart bool
+	// Object storage: "azure" (default) or "local"
+	StorageBackend          string
+	StorageLocalRoot        string
+	StorageLocalBaseURL     string
+	StorageSigningKey       string
+	StorageLocalPublicNames string
+	// Container listed by /readyz to confirm object storage is reachable
+	HealthBlobContainer string
+	// Rate limit counters: "postgres" (default, shared by replicas) or "memory"
+	RateLimitBackend string
+	// Seconds between polls of the data_version row; 0 reads it only at startup
+	DataVersionPollInterval int
+	// Whether this replica takes part in the job scheduler's leader election
+	JobSchedulerEnabled bool
+	// Seconds between job scheduler leader elections
+	JobLeaderPollInterval int
+	// Seconds between checks of the event outbox for undelivered events
+	EventDispatchPollInterval int
+	// Whether shop webhooks may use http URLs and private addresses, for
+	// local development
+	WebhookAllowPrivateURLs bool
+	// Push notifications: "fcm" (default) or "log" for local development
+	PushProvider string
 }
 
 func NewEnv() *Env {
@@ -58,11 +116,38 @@ func NewEnv() *Env {
 	env.DBDate = os.Getenv("DB_DATE")
 	env.DBSchema = os.Getenv("DB_SCHEMA")
 	env.MobileAppVersion = os.Getenv("MOBILE_APP_VERSION")
+	env.ServerAddress = getEnvAsString("SERVER_ADDRESS", ":8080")
+	env.ShutdownTimeout = getEnvAsInt("SHUTDOWN_TIMEOUT", 30)
+	env.ContextTimeout = getEnvAsInt("CONTEXT_TIMEOUT", 30)
 	// Connection pool settings (defaults optimized for parallel query workloads)
 	env.DBMaxOpenConns = getEnvAsInt("DB_MAX_OPEN_CONNS", 50)
 	env.DBMaxIdleConns = getEnvAsInt("DB_MAX_IDLE_CONNS", 25)
+	// Authentication
+	env.AuthProvider = getEnvAsString("AUTH_PROVIDER", "firebase")
+	env.OIDCIssuer = os.Getenv("OIDC_ISSUER")
+	env.OIDCAudience = os.Getenv("OIDC_AUDIENCE")
+	env.OIDCJWKSURL = os.Getenv("OIDC_JWKS_URL")
+	env.AuthLocalHMACSecret = os.Getenv("AUTH_LOCAL_HMAC_SECRET")
+	env.AuthLocalRSAKeyFile = os.Getenv("AUTH_LOCAL_RSA_KEY_FILE")
+	env.UserProfileCacheTTL = getEnvAsInt("USER_PROFILE_CACHE_TTL", 300)
+	env.MigrateOnStart = getEnvAsBool("MIGRATE_ON_START", false)
+	env.MetricsToken = os.Getenv("METRICS_TOKEN")
+	env.TracingExporter = getEnvAsString("TRACING_EXPORTER", "none")
 	// Blob Storage
 	env.BlobAccountName = os.Getenv("BLOB_ACCOUNT_NAME")
+	env.StorageBackend = getEnvAsString("STORAGE_BACKEND", "azure")
+	env.StorageLocalRoot = getEnvAsString("STORAGE_LOCAL_ROOT", "./.storage")
+	env.StorageLocalBaseURL = getEnvAsString("STORAGE_LOCAL_BASE_URL", "http://localhost:8080")
+	env.StorageSigningKey = os.Getenv("STORAGE_SIGNING_KEY")
+	env.StorageLocalPublicNames = getEnvAsString("STORAGE_LOCAL_PUBLIC_CONTAINERS", "material-images,shop-message-images,user-item-images")
+	env.HealthBlobContainer = getEnvAsString("HEALTH_BLOB_CONTAINER", "library")
+	env.RateLimitBackend = getEnvAsString("RATE_LIMIT_BACKEND", "postgres")
+	env.DataVersionPollInterval = getEnvAsInt("DATA_VERSION_POLL_SECONDS", 30)
+	env.JobSchedulerEnabled = getEnvAsBool("JOB_SCHEDULER_ENABLED", true)
+	env.JobLeaderPollInterval = getEnvAsInt("JOB_LEADER_POLL_SECONDS", 15)
+	env.EventDispatchPollInterval = getEnvAsInt("EVENT_DISPATCH_POLL_SECONDS", 5)
+	env.WebhookAllowPrivateURLs = getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_URLS", false)
+	env.PushProvider = getEnvAsString("PUSH_PROVIDER", "fcm")
 
 	log.Printf("DB_HOST: %s", env.Host)
 	log.Printf("DB_PORT: %s", env.Port)
@@ -72,6 +157,13 @@ func NewEnv() *Env {
 	log.Printf("DB_SCHEMA: %s", env.DBSchema)
 	log.Printf("SSL_MODE: %s", env.SslMode)
 	log.Printf("MOBILE_APP_VERSION: %s", env.MobileAppVersion)
+	log.Printf("SERVER_ADDRESS: %s", env.ServerAddress)
+	log.Printf("SHUTDOWN_TIMEOUT: %ds", env.ShutdownTimeout)
+	log.Printf("CONTEXT_TIMEOUT: %ds", env.ContextTimeout)
+	log.Printf("AUTH_PROVIDER: %s", env.AuthProvider)
+	log.Printf("MIGRATE_ON_START: %t", env.MigrateOnStart)
+	log.Printf("TRACING_EXPORTER: %s", env.TracingExporter)
+	log.Printf("STORAGE_BACKEND: %s", env.StorageBackend)
 	return &env
 
 }
@@ -0,0 +1,26 @@
+package bootstrap
+
+import (
+	"database/sql"
+	"time"
+
+	"miltechserver/events"
+	"miltechserver/jobs"
+)
+
+// outboxRetention is how long delivered and failed outbox events are kept.
+const outboxRetention = 14 * 24 * time.Hour
+
+// NewEventBus creates the domain event bus that services publish to and
+// feature packages subscribe to. It dispatches nothing until StartEventBus
+// is called.
+func NewEventBus(db *sql.DB, scheduler *jobs.Scheduler) *events.Bus {
+	scheduler.Register(events.PruneOutbox(db, outboxRetention))
+	return events.NewBus(db)
+}
+
+// StartEventBus starts dispatching stored events. Call it once every package
+// has subscribed, so no event is dispatched before its subscribers exist.
+func StartEventBus(env *Env, bus *events.Bus) {
+	bus.Start(time.Duration(env.EventDispatchPollInterval) * time.Second)
+}
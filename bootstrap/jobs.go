@@ -0,0 +1,32 @@
+package bootstrap
+
+import (
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"miltechserver/jobs"
+)
+
+// jobRunRetention is how long job_runs rows are kept.
+const jobRunRetention = 90 * 24 * time.Hour
+
+// NewScheduler creates the job scheduler that feature packages register
+// their jobs with. It fires nothing until StartScheduler is called.
+func NewScheduler(db *sql.DB) *jobs.Scheduler {
+	scheduler := jobs.New(db)
+	scheduler.Register(jobs.PruneRuns(db, jobRunRetention))
+	return scheduler
+}
+
+// StartScheduler enters this replica into the scheduler's leader election
+// unless JOB_SCHEDULER_ENABLED is false. Call it once every package has
+// registered its jobs. A disabled replica can still run jobs triggered
+// through the admin API.
+func StartScheduler(env *Env, scheduler *jobs.Scheduler) {
+	if !env.JobSchedulerEnabled {
+		slog.Info("Job scheduler disabled on this replica; jobs only run when triggered")
+		return
+	}
+	scheduler.Start(time.Duration(env.JobLeaderPollInterval) * time.Second)
+}
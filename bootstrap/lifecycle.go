@@ -0,0 +1,103 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type stopHook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+type drainHook struct {
+	name  string
+	drain func()
+}
+
+// Lifecycle collects stop functions from components that own background work
+// so the server can shut them down in order after it stops taking requests.
+// Components serving long-lived requests also register a drain function, run
+// when shutdown starts, that ends those requests so they do not hold it up.
+type Lifecycle struct {
+	mu      sync.Mutex
+	hooks   []stopHook
+	stopped bool
+	drains  []drainHook
+	drained bool
+}
+
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{}
+}
+
+// OnStop registers a stop function. Hooks run in reverse registration order,
+// so anything registered after the database is stopped before it closes.
+// Calling OnStop on a nil Lifecycle is a no-op, which keeps test wiring simple.
+func (l *Lifecycle) OnStop(name string, stop func(ctx context.Context) error) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, stopHook{name: name, stop: stop})
+}
+
+// OnDrain registers a function that ends a component's long-lived requests.
+// Calling OnDrain on a nil Lifecycle is a no-op.
+func (l *Lifecycle) OnDrain(name string, drain func()) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.drains = append(l.drains, drainHook{name: name, drain: drain})
+}
+
+// Drain runs every registered drain function once. The server calls it when
+// shutdown starts, before waiting for requests to finish.
+func (l *Lifecycle) Drain() {
+	l.mu.Lock()
+	if l.drained {
+		l.mu.Unlock()
+		return
+	}
+	l.drained = true
+	drains := l.drains
+	l.mu.Unlock()
+
+	for _, hook := range drains {
+		hook.drain()
+		slog.Info("Drained component", "component", hook.name)
+	}
+}
+
+// Stop runs every registered hook once, newest first. A failing or slow hook
+// does not prevent later hooks from running; ctx bounds the total time.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		return nil
+	}
+	l.stopped = true
+	hooks := l.hooks
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		start := time.Now()
+		if err := hook.stop(ctx); err != nil {
+			slog.Error("Failed to stop component", "component", hook.name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", hook.name, err))
+			continue
+		}
+		slog.Info("Stopped component", "component", hook.name, "duration", time.Since(start))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,53 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleStopsInReverseOrder(t *testing.T) {
+	lifecycle := NewLifecycle()
+	var order []string
+
+	lifecycle.OnStop("database", func(context.Context) error {
+		order = append(order, "database")
+		return nil
+	})
+	lifecycle.OnStop("analytics", func(context.Context) error {
+		order = append(order, "analytics")
+		return errors.New("flush failed")
+	})
+	lifecycle.OnStop("cache", func(context.Context) error {
+		order = append(order, "cache")
+		return nil
+	})
+
+	err := lifecycle.Stop(context.Background())
+	require.ErrorContains(t, err, "analytics: flush failed")
+	require.Equal(t, []string{"cache", "analytics", "database"}, order)
+
+	require.NoError(t, lifecycle.Stop(context.Background()))
+	require.Len(t, order, 3)
+}
+
+func TestLifecycleNilOnStop(t *testing.T) {
+	var lifecycle *Lifecycle
+	lifecycle.OnStop("ignored", func(context.Context) error { return nil })
+}
+
+func TestLifecycleDrainsOnce(t *testing.T) {
+	lifecycle := NewLifecycle()
+	var drained []string
+	lifecycle.OnDrain("shop streams", func() { drained = append(drained, "shop streams") })
+	lifecycle.OnDrain("exports", func() { drained = append(drained, "exports") })
+
+	lifecycle.Drain()
+	lifecycle.Drain()
+	require.Equal(t, []string{"shop streams", "exports"}, drained)
+
+	var nilLifecycle *Lifecycle
+	nilLifecycle.OnDrain("ignored", func() {})
+}
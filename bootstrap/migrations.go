@@ -0,0 +1,35 @@
+package bootstrap
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"miltechserver/migrations"
+)
+
+// EnsureSchema refuses to start the server against a database that is missing
+// embedded migrations. With MIGRATE_ON_START it applies them instead.
+func EnsureSchema(ctx context.Context, db *sql.DB, env *Env) {
+	runner, err := migrations.NewRunner(db)
+	if err != nil {
+		slog.Error("Unable to load embedded migrations", "error", err)
+		panic(err)
+	}
+
+	if env.MigrateOnStart {
+		applied, err := runner.Up(ctx)
+		if err != nil {
+			slog.Error("Unable to apply migrations", "error", err)
+			panic(err)
+		}
+		slog.Info("Database schema is current", "applied", len(applied))
+		return
+	}
+
+	if err := runner.EnsureCurrent(ctx); err != nil {
+		slog.Error("Refusing to start", "error", err)
+		panic(err)
+	}
+	slog.Info("Database schema is current")
+}
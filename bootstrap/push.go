@@ -0,0 +1,32 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+
+	"miltechserver/push"
+)
+
+// NewPushSender returns the push notification sender PUSH_PROVIDER names:
+// "fcm" sends through Firebase Cloud Messaging with the FIREBASE_AUTH_KEY
+// service account, "log" only logs. If FCM cannot be initialised the server
+// still starts, logging pushes instead of sending them.
+func NewPushSender(ctx context.Context, env *Env) push.Sender {
+	if env.PushProvider != "fcm" {
+		slog.Info("Push notifications are logged, not sent", "provider", env.PushProvider)
+		return push.Log{}
+	}
+	slog.Info("Creating Firebase Cloud Messaging client")
+	fireApp, err := NewFirebaseApp(ctx)
+	if err != nil {
+		slog.Error("Push notifications disabled", "error", err)
+		return push.Log{}
+	}
+	client, err := fireApp.Messaging(ctx)
+	if err != nil {
+		slog.Error("Push notifications disabled: error getting messaging client", "error", err)
+		return push.Log{}
+	}
+	slog.Info("Firebase Cloud Messaging client created")
+	return push.NewFCM(client)
+}
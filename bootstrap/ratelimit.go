@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"miltechserver/jobs"
+	"miltechserver/ratelimit"
+)
+
+// rateLimitEvictInterval is how often a replica drops its expired in-memory
+// rate limit buckets.
+const rateLimitEvictInterval = time.Minute
+
+// NewRateLimiter creates the limiter selected by RATE_LIMIT_BACKEND. "memory"
+// counts per replica and evicts in the background; anything else shares
+// counters through Postgres and evicts them with the rate-limit-evict job, so
+// only one replica runs the DELETE.
+func NewRateLimiter(env *Env, db *sql.DB, scheduler *jobs.Scheduler) *ratelimit.Limiter {
+	if env.RateLimitBackend == "memory" {
+		slog.Info("Using in-memory rate limits; limits are per replica")
+		return ratelimit.New(ratelimit.NewMemoryStore(), rateLimitEvictInterval)
+	}
+
+	limiter := ratelimit.New(ratelimit.NewPostgresStore(db), 0)
+	scheduler.Register(jobs.Job{
+		Name:     "rate-limit-evict",
+		Schedule: "*/5 * * * *",
+		Timeout:  time.Minute,
+		Run: func(ctx context.Context) error {
+			_, err := limiter.Evict(ctx)
+			return err
+		},
+	})
+	return limiter
+}
+
+// NewRequestLimiter creates the limiter for the policies every authenticated
+// request counts against. It always counts in memory, per replica, so the
+// busiest path costs no database write whatever RATE_LIMIT_BACKEND says.
+func NewRequestLimiter() *ratelimit.Limiter {
+	return ratelimit.New(ratelimit.NewMemoryStore(), rateLimitEvictInterval)
+}
@@ -0,0 +1,20 @@
+package bootstrap
+
+import (
+	"database/sql"
+
+	"miltechserver/events"
+	"miltechserver/realtime"
+)
+
+// NewStream creates the hub shop streams subscribe to on this replica. It
+// subscribes a Notifier to bus, so every replica hears of streamed events
+// through NOTIFY, and starts the Listener that relays them to the hub.
+func NewStream(env *Env, db *sql.DB, bus *events.Bus, lifecycle *Lifecycle) *realtime.Hub {
+	hub := realtime.NewHub()
+	realtime.NewNotifier(db).Subscribe(bus)
+	listener := realtime.Listen(hub, DSN(env))
+	lifecycle.OnDrain("shop streams", hub.Close)
+	lifecycle.OnStop("stream listener", listener.Stop)
+	return hub
+}
@@ -0,0 +1,43 @@
+package bootstrap
+
+import (
+	"crypto/rand"
+	"log/slog"
+	"strings"
+
+	"miltechserver/storage"
+)
+
+// NewObjectStore creates the object store selected by STORAGE_BACKEND.
+// "local" keeps objects on disk and serves signed URLs through this server;
+// anything else uses Azure Blob Storage.
+func NewObjectStore(env *Env) storage.ObjectStore {
+	if env.StorageBackend != "local" {
+		return storage.NewAzureStore(NewAzureBlobClient(env), env.BlobAccountName)
+	}
+
+	slog.Info("Creating local object store", "root", env.StorageLocalRoot, "baseURL", env.StorageLocalBaseURL)
+
+	signingKey := []byte(env.StorageSigningKey)
+	if len(signingKey) == 0 {
+		// Without a configured key, signed URLs stop validating after a restart.
+		slog.Warn("STORAGE_SIGNING_KEY not set, generating an ephemeral key")
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			panic(err)
+		}
+	}
+
+	store, err := storage.NewLocalStore(storage.LocalConfig{
+		Root:             env.StorageLocalRoot,
+		BaseURL:          env.StorageLocalBaseURL,
+		SigningKey:       signingKey,
+		PublicContainers: strings.Split(env.StorageLocalPublicNames, ","),
+	})
+	if err != nil {
+		slog.Error("Failed to create local object store", "error", err)
+		panic(err)
+	}
+
+	return store
+}
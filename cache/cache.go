@@ -0,0 +1,271 @@
+// Package cache is the in-process cache shared by services: a bounded LRU
+// with a per-entry TTL, explicit invalidation and loads that collapse
+// concurrent misses for the same key into one call. Every cache counts its
+// lookups and evictions under its name in miltech_cache_* metrics.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"miltechserver/metrics"
+)
+
+var errLoadPanicked = errors.New("cache: load panicked")
+
+// Options configure a cache.
+type Options struct {
+	// Name labels the cache's metrics. Caches that share a name share metrics.
+	Name string
+	// Capacity bounds the number of entries; past it the least recently used
+	// entry is evicted. Zero means unbounded, for caches whose keys are
+	// bounded by construction.
+	Capacity int
+	// TTL is how long an entry is served after it is stored. Zero means
+	// entries only leave by eviction or invalidation.
+	TTL time.Duration
+}
+
+// Stats are the counters of one cache since it was created.
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Entries   int    `json:"entries"`
+	Capacity  int    `json:"capacity"`
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// call is a load in progress. Followers wait on done and read value and err.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+	// stale is set, under the cache's mu, when the key is invalidated while
+	// the load runs, so its value is not stored.
+	stale bool
+}
+
+// Cache maps keys to values. It is safe for concurrent use; the zero value
+// is not usable, create caches with New.
+type Cache[K comparable, V any] struct {
+	opts    Options
+	lookups *metrics.CacheCounter
+
+	mu       sync.Mutex
+	entries  map[K]*list.Element
+	order    *list.List // front is most recently used
+	inflight map[K]*call[V]
+	stats    Stats
+}
+
+func New[K comparable, V any](opts Options) *Cache[K, V] {
+	return &Cache[K, V]{
+		opts:     opts,
+		lookups:  metrics.NewCacheCounter(opts.Name),
+		entries:  make(map[K]*list.Element),
+		order:    list.New(),
+		inflight: make(map[K]*call[V]),
+	}
+}
+
+// Get returns the value cached for key, if any and not expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookup(key)
+}
+
+// Set stores value for key, evicting the least recently used entry when the
+// cache is full.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(key, value)
+}
+
+// GetOrLoad returns the value cached for key, or calls load to produce and
+// store it. Concurrent calls for a key that is not cached share one load.
+// Errors are returned to every waiting caller and are not cached.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load func(ctx context.Context) (V, error)) (V, error) {
+	for {
+		c.mu.Lock()
+		if value, ok := c.lookup(key); ok {
+			c.mu.Unlock()
+			return value, nil
+		}
+
+		if pending, ok := c.inflight[key]; ok {
+			c.mu.Unlock()
+			select {
+			case <-pending.done:
+			case <-ctx.Done():
+				var zero V
+				return zero, ctx.Err()
+			}
+			// The load ran under the context of the caller that started it. If
+			// that request ended, this one is still live, so load again.
+			if isContextErr(pending.err) && ctx.Err() == nil {
+				continue
+			}
+			return pending.value, pending.err
+		}
+
+		pending := &call[V]{done: make(chan struct{})}
+		c.inflight[key] = pending
+		c.mu.Unlock()
+
+		c.run(ctx, key, pending, load)
+		return pending.value, pending.err
+	}
+}
+
+// run calls load for the callers waiting on pending and stores its value
+// unless key was invalidated meanwhile. If load panics, the waiting callers
+// get errLoadPanicked and the panic continues in the caller of run.
+func (c *Cache[K, V]) run(ctx context.Context, key K, pending *call[V], load func(ctx context.Context) (V, error)) {
+	defer func() {
+		c.mu.Lock()
+		if c.inflight[key] == pending {
+			delete(c.inflight, key)
+		}
+		if pending.err == nil && !pending.stale {
+			c.store(key, pending.value)
+		}
+		c.mu.Unlock()
+		close(pending.done)
+	}()
+
+	pending.err = errLoadPanicked
+	pending.value, pending.err = load(ctx)
+}
+
+// Invalidate drops the entry for key. A load for key already in progress
+// still returns its value to its callers but does not store it, and later
+// callers start a new load. Loads for other keys are unaffected.
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.abandon(key)
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// InvalidateFunc drops every entry whose key matches, and abandons the loads
+// in progress for matching keys as Invalidate does.
+func (c *Cache[K, V]) InvalidateFunc(match func(K) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.inflight {
+		if match(key) {
+			c.abandon(key)
+		}
+	}
+	for key, element := range c.entries {
+		if match(key) {
+			c.remove(element)
+		}
+	}
+}
+
+// Purge drops every entry and abandons every load in progress.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.inflight {
+		c.abandon(key)
+	}
+	clear(c.entries)
+	c.order.Init()
+}
+
+// abandon keeps the load in progress for key, if any, from storing its value
+// and from being joined by later callers. It must be called with mu held.
+func (c *Cache[K, V]) abandon(key K) {
+	if pending, ok := c.inflight[key]; ok {
+		pending.stale = true
+		delete(c.inflight, key)
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet removed.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Stats returns the cache's counters.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	stats.Capacity = c.opts.Capacity
+	return stats
+}
+
+// lookup must be called with mu held.
+func (c *Cache[K, V]) lookup(key K) (V, bool) {
+	element, ok := c.entries[key]
+	if ok {
+		e := element.Value.(*entry[K, V])
+		if e.expiresAt.IsZero() || time.Now().Before(e.expiresAt) {
+			c.order.MoveToFront(element)
+			c.stats.Hits++
+			c.lookups.Record(true)
+			return e.value, true
+		}
+		c.remove(element)
+		c.evicted(metrics.EvictExpired)
+	}
+
+	c.stats.Misses++
+	c.lookups.Record(false)
+	var zero V
+	return zero, false
+}
+
+// store must be called with mu held.
+func (c *Cache[K, V]) store(key K, value V) {
+	var expiresAt time.Time
+	if c.opts.TTL > 0 {
+		expiresAt = time.Now().Add(c.opts.TTL)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry[K, V])
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.opts.Capacity > 0 && c.order.Len() > c.opts.Capacity {
+		c.remove(c.order.Back())
+		c.evicted(metrics.EvictCapacity)
+	}
+}
+
+func (c *Cache[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[K, V]).key)
+}
+
+func (c *Cache[K, V]) evicted(reason string) {
+	c.stats.Evictions++
+	c.lookups.Evicted(reason)
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
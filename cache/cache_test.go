@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[string, int](Options{Name: "test_lru", Capacity: 2})
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	_, ok := c.Get("a") // a is now more recently used than b
+	require.True(t, ok)
+	c.Set("c", 3)
+
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+	require.Equal(t, Stats{Hits: 2, Misses: 1, Evictions: 1, Entries: 2, Capacity: 2}, c.Stats())
+}
+
+func TestExpiresAfterTTL(t *testing.T) {
+	c := New[string, int](Options{Name: "test_ttl", TTL: 20 * time.Millisecond})
+	c.Set("a", 1)
+
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Zero(t, c.Len())
+}
+
+func TestInvalidate(t *testing.T) {
+	c := New[string, int](Options{Name: "test_invalidate"})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	c.Invalidate("a")
+	_, ok := c.Get("a")
+	require.False(t, ok)
+
+	c.InvalidateFunc(func(key string) bool { return key == "b" })
+	require.Equal(t, 1, c.Len())
+
+	c.Purge()
+	require.Zero(t, c.Len())
+}
+
+func TestGetOrLoadCollapsesConcurrentMisses(t *testing.T) {
+	c := New[string, int](Options{Name: "test_singleflight"})
+	var loads atomic.Int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := c.GetOrLoad(context.Background(), "niin", func(context.Context) (int, error) {
+				loads.Add(1)
+				<-release
+				return 42, nil
+			})
+			require.NoError(t, err)
+			results[i] = value
+		}()
+	}
+
+	// Give every goroutine time to join the load before it finishes.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), loads.Load())
+	for _, value := range results {
+		require.Equal(t, 42, value)
+	}
+	value, ok := c.Get("niin")
+	require.True(t, ok)
+	require.Equal(t, 42, value)
+}
+
+func TestGetOrLoadDoesNotCacheErrors(t *testing.T) {
+	c := New[string, int](Options{Name: "test_load_error"})
+	boom := errors.New("boom")
+
+	_, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, error) { return 0, boom })
+	require.ErrorIs(t, err, boom)
+	require.Zero(t, c.Len())
+
+	value, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, error) { return 1, nil })
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+}
+
+func TestGetOrLoadDropsValueLoadedAcrossInvalidation(t *testing.T) {
+	c := New[string, int](Options{Name: "test_load_invalidate"})
+
+	value, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, error) {
+		c.Invalidate("a") // e.g. a write landed while the stale value was read
+		return 1, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+}
+
+func TestInvalidateLeavesOtherKeysLoadsAlone(t *testing.T) {
+	c := New[string, int](Options{Name: "test_load_invalidate_other"})
+
+	value, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, error) {
+		c.Invalidate("b")
+		c.InvalidateFunc(func(key string) bool { return key == "c" })
+		return 1, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+}
+
+func TestGetOrLoadAfterInvalidationStartsNewLoad(t *testing.T) {
+	c := New[string, int](Options{Name: "test_load_invalidate_rejoin"})
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	stale := make(chan int)
+	go func() {
+		value, _ := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+		stale <- value
+	}()
+	<-started
+
+	// A caller after the invalidation does not join the stale load
+	c.Invalidate("a")
+	value, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, error) { return 2, nil })
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+
+	close(release)
+	require.Equal(t, 1, <-stale)
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 2, value)
+}
+
+func TestGetOrLoadRetriesWhenLeaderContextEnds(t *testing.T) {
+	c := New[string, int](Options{Name: "test_load_cancel"})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+
+	go func() {
+		_, _ = c.GetOrLoad(leaderCtx, "a", func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+	}()
+	<-started
+
+	done := make(chan int)
+	go func() {
+		value, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, error) { return 7, nil })
+		require.NoError(t, err)
+		done <- value
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	require.Equal(t, 7, <-done)
+}
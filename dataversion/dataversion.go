@@ -0,0 +1,176 @@
+// Package dataversion tracks which FedLog dataset the database holds. Ops
+// record each load in the single data_version row (go run . admin
+// set-db-date); every replica polls that row and, when it changes, flushes
+// the caches registered with OnChange and serves the new DB date. The
+// DB_DATE environment variable is only the fallback for a database that has
+// never recorded a load.
+package dataversion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Version identifies one FedLog load. Serial is bumped by every load, so a
+// reload with the same DB date still counts as a change. LoadedAt is when the
+// load was recorded, and is zero for the DB_DATE fallback.
+type Version struct {
+	DBDate   string
+	Serial   int64
+	LoadedAt time.Time
+}
+
+type subscriber struct {
+	name  string
+	flush func()
+}
+
+// Watcher holds the current Version and notifies subscribers when it
+// changes. Its methods are safe to call on a nil Watcher, which reports an
+// empty DB date.
+type Watcher struct {
+	db      *sql.DB
+	current atomic.Pointer[Version]
+
+	mu          sync.Mutex
+	subscribers []subscriber
+
+	started  atomic.Bool
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a watcher whose DB date is fallback until the data_version
+// row says otherwise. It does not poll until Start is called.
+func New(db *sql.DB, fallback string) *Watcher {
+	w := &Watcher{db: db, stop: make(chan struct{}), done: make(chan struct{})}
+	w.current.Store(&Version{DBDate: fallback})
+	return w
+}
+
+// DBDate returns the date of the FedLog dataset currently loaded.
+func (w *Watcher) DBDate() string {
+	if w == nil {
+		return ""
+	}
+	return w.current.Load().DBDate
+}
+
+// Current returns the version currently loaded.
+func (w *Watcher) Current() Version {
+	if w == nil {
+		return Version{}
+	}
+	return *w.current.Load()
+}
+
+// OnChange registers flush to run whenever a new version is seen. name
+// identifies the subscriber in logs.
+func (w *Watcher) OnChange(name string, flush func()) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, subscriber{name: name, flush: flush})
+}
+
+// Poll reads the data_version row and, if it differs from the current
+// version, switches to it and runs the subscribers. A missing row keeps the
+// current version.
+func (w *Watcher) Poll(ctx context.Context) error {
+	var next Version
+	err := w.db.QueryRowContext(ctx, `SELECT db_date, version, updated_at FROM data_version`).Scan(&next.DBDate, &next.Serial, &next.LoadedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read data version: %w", err)
+	}
+
+	previous := w.current.Load()
+	if previous.DBDate == next.DBDate && previous.Serial == next.Serial {
+		return nil
+	}
+	w.current.Store(&next)
+	slog.Info("FedLog data version changed, flushing reference caches",
+		"db_date", next.DBDate, "version", next.Serial, "previous_db_date", previous.DBDate)
+	w.notify()
+	return nil
+}
+
+func (w *Watcher) notify() {
+	w.mu.Lock()
+	subscribers := append([]subscriber(nil), w.subscribers...)
+	w.mu.Unlock()
+
+	for _, s := range subscribers {
+		slog.Debug("Flushing cache for new data version", "subscriber", s.name)
+		s.flush()
+	}
+}
+
+// Start polls every interval in the background until Stop is called.
+func (w *Watcher) Start(every time.Duration) {
+	w.started.Store(true)
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), every)
+			if err := w.Poll(ctx); err != nil {
+				slog.Warn("Failed to poll data version", "error", err)
+			}
+			cancel()
+		}
+	}()
+}
+
+// Stop ends background polling. Safe to call more than once, on a nil
+// Watcher and on one that was never started.
+func (w *Watcher) Stop(ctx context.Context) error {
+	if w == nil {
+		return nil
+	}
+	w.stopOnce.Do(func() { close(w.stop) })
+	if !w.started.Load() {
+		return nil
+	}
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Record stores a new FedLog load of dbDate and returns its version.
+// Replicas pick it up on their next poll.
+func Record(ctx context.Context, db *sql.DB, dbDate string) (Version, error) {
+	v := Version{DBDate: dbDate}
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO data_version (id, db_date) VALUES (TRUE, $1)
+		ON CONFLICT (id) DO UPDATE
+		SET db_date = EXCLUDED.db_date, version = data_version.version + 1, updated_at = now()
+		RETURNING version, updated_at`, dbDate).Scan(&v.Serial, &v.LoadedAt)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to record data version: %w", err)
+	}
+	return v, nil
+}
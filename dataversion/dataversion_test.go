@@ -0,0 +1,81 @@
+package dataversion
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"testing"
+	"time"
+
+	"miltechserver/sqltest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// versionRow is the data_version row the test database answers with.
+type versionRow struct {
+	mu  sync.Mutex
+	row *Version
+}
+
+func (r *versionRow) set(v *Version) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.row = v
+}
+
+func newTestWatcher(t *testing.T, fallback string) (*Watcher, *versionRow) {
+	t.Helper()
+	row := &versionRow{}
+	db, _ := sqltest.Open(t, func(string, []driver.Value) (sqltest.Rows, error) {
+		row.mu.Lock()
+		defer row.mu.Unlock()
+		if row.row == nil {
+			return nil, nil
+		}
+		return sqltest.Rows{{row.row.DBDate, row.row.Serial, row.row.LoadedAt}}, nil
+	})
+	return New(db, fallback), row
+}
+
+func TestPollKeepsFallbackWithoutRow(t *testing.T) {
+	w, _ := newTestWatcher(t, "2026-09-30")
+	flushes := 0
+	w.OnChange("test", func() { flushes++ })
+
+	require.NoError(t, w.Poll(context.Background()))
+	require.Equal(t, "2026-09-30", w.DBDate())
+	require.Zero(t, flushes)
+}
+
+func TestPollFlushesOncePerNewVersion(t *testing.T) {
+	w, d := newTestWatcher(t, "2026-09-30")
+	flushes := 0
+	w.OnChange("test", func() { flushes++ })
+
+	loadedAt := time.Date(2026, 11, 2, 9, 30, 0, 0, time.UTC)
+	d.set(&Version{DBDate: "2026-10-31", Serial: 1, LoadedAt: loadedAt})
+	require.NoError(t, w.Poll(context.Background()))
+	require.NoError(t, w.Poll(context.Background()))
+	require.Equal(t, "2026-10-31", w.DBDate())
+	require.Equal(t, 1, flushes)
+
+	// A reload of the same date is still a new version.
+	d.set(&Version{DBDate: "2026-10-31", Serial: 2, LoadedAt: loadedAt.Add(time.Hour)})
+	require.NoError(t, w.Poll(context.Background()))
+	require.Equal(t, Version{DBDate: "2026-10-31", Serial: 2, LoadedAt: loadedAt.Add(time.Hour)}, w.Current())
+	require.Equal(t, 2, flushes)
+}
+
+func TestNilWatcher(t *testing.T) {
+	var w *Watcher
+	w.OnChange("test", func() {})
+	require.Empty(t, w.DBDate())
+	require.NoError(t, w.Stop(context.Background()))
+}
+
+func TestStopWithoutStart(t *testing.T) {
+	w := New(nil, "")
+	require.NoError(t, w.Stop(context.Background()))
+	require.NoError(t, w.Stop(context.Background()))
+}
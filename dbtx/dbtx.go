@@ -0,0 +1,102 @@
+// Package dbtx carries a database transaction in a context, so a service can
+// run several repository calls in one transaction without the repositories
+// taking a *sql.Tx. Repositories query through From(ctx, db), which is the
+// transaction when the caller started one and the pool otherwise.
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+)
+
+// Querier is what *sql.DB and *sql.Tx have in common. It satisfies jet's
+// qrm.Queryable and qrm.Executable.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// state is the transaction in progress and the hooks to run once it commits.
+type state struct {
+	tx          *sql.Tx
+	afterCommit []func()
+}
+
+func current(ctx context.Context) *state {
+	s, _ := ctx.Value(txKey{}).(*state)
+	return s
+}
+
+// From returns the transaction in ctx, or db if there is none.
+func From(ctx context.Context, db *sql.DB) Querier {
+	if s := current(ctx); s != nil {
+		return s.tx
+	}
+	return db
+}
+
+// Active reports whether ctx carries a transaction.
+func Active(ctx context.Context) bool {
+	return current(ctx) != nil
+}
+
+// Run calls fn with a context carrying a transaction and commits it if fn
+// returns nil; otherwise, or if fn panics, the transaction is rolled back.
+// If ctx already carries a transaction fn joins it, and the outermost Run
+// decides whether it commits.
+func Run(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) (err error) {
+	if Active(ctx) {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	s := &state{tx: tx}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			tx.Rollback()
+			panic(recovered)
+		}
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, s)); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	for _, hook := range s.afterCommit {
+		runHook(hook)
+	}
+	return nil
+}
+
+// AfterCommit schedules fn to run once the transaction in ctx commits; it is
+// dropped if the transaction rolls back. Without a transaction fn runs now.
+// Hooks must not fail the caller, so a panicking hook is logged.
+func AfterCommit(ctx context.Context, fn func()) {
+	if s := current(ctx); s != nil {
+		s.afterCommit = append(s.afterCommit, fn)
+		return
+	}
+	runHook(fn)
+}
+
+func runHook(fn func()) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			slog.Error("After-commit hook panicked", "panic", recovered)
+		}
+	}()
+	fn()
+}
@@ -0,0 +1,100 @@
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"miltechserver/sqltest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) (*sql.DB, *sqltest.Driver) {
+	t.Helper()
+	db, d := sqltest.Open(t, nil)
+	// One connection, so a statement outside the transaction would block
+	// on the one inside it.
+	db.SetMaxOpenConns(1)
+	return db, d
+}
+
+func exec(t *testing.T, ctx context.Context, db *sql.DB, query string) {
+	t.Helper()
+	_, err := From(ctx, db).ExecContext(ctx, query)
+	require.NoError(t, err)
+}
+
+func TestRunCommits(t *testing.T) {
+	db, d := newTestDB(t)
+	var committed bool
+
+	err := Run(context.Background(), db, func(ctx context.Context) error {
+		require.True(t, Active(ctx))
+		exec(t, ctx, db, "INSERT 1")
+		AfterCommit(ctx, func() { committed = true })
+		require.False(t, committed, "hooks wait for the commit")
+		exec(t, ctx, db, "INSERT 2")
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, committed)
+	require.Equal(t, []string{"BEGIN", "INSERT 1", "INSERT 2", "COMMIT"}, d.Log())
+}
+
+func TestRunRollsBackOnError(t *testing.T) {
+	db, d := newTestDB(t)
+	failure := errors.New("constraint violated")
+	var committed bool
+
+	err := Run(context.Background(), db, func(ctx context.Context) error {
+		exec(t, ctx, db, "INSERT 1")
+		AfterCommit(ctx, func() { committed = true })
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+	require.False(t, committed)
+	require.Equal(t, []string{"BEGIN", "INSERT 1", "ROLLBACK"}, d.Log())
+}
+
+func TestRunRollsBackOnPanic(t *testing.T) {
+	db, d := newTestDB(t)
+
+	require.PanicsWithValue(t, "nil map", func() {
+		Run(context.Background(), db, func(ctx context.Context) error {
+			panic("nil map")
+		})
+	})
+	require.Equal(t, []string{"BEGIN", "ROLLBACK"}, d.Log())
+}
+
+func TestNestedRunJoinsTransaction(t *testing.T) {
+	db, d := newTestDB(t)
+
+	err := Run(context.Background(), db, func(ctx context.Context) error {
+		exec(t, ctx, db, "INSERT 1")
+		return Run(ctx, db, func(ctx context.Context) error {
+			exec(t, ctx, db, "INSERT 2")
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"BEGIN", "INSERT 1", "INSERT 2", "COMMIT"}, d.Log())
+}
+
+func TestWithoutTransaction(t *testing.T) {
+	db, d := newTestDB(t)
+	ctx := context.Background()
+	require.False(t, Active(ctx))
+	require.Same(t, db, From(ctx, db))
+
+	var ran bool
+	AfterCommit(ctx, func() { ran = true })
+	require.True(t, ran, "hooks run at once without a transaction")
+
+	// A panicking hook does not reach the caller
+	require.NotPanics(t, func() { AfterCommit(ctx, func() { panic("boom") }) })
+	exec(t, ctx, db, "INSERT 1")
+	require.Equal(t, []string{"INSERT 1"}, d.Log())
+}
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"miltechserver/dbtx"
+)
+
+// Handler receives one event. An error, or a panic, schedules the event for
+// redelivery to this subscriber alone.
+type Handler func(ctx context.Context, env Envelope) error
+
+type subscription struct {
+	name    string
+	types   []string
+	handler Handler
+}
+
+func (sub *subscription) wants(eventType string) bool {
+	return len(sub.types) == 0 || slices.Contains(sub.types, eventType)
+}
+
+// Bus stores published events in the outbox and dispatches them to its
+// subscribers. Its methods are safe to call on a nil Bus, which runs
+// transactions but drops events.
+type Bus struct {
+	db       *sql.DB
+	instance string
+
+	mu   sync.RWMutex
+	subs []*subscription
+
+	// wake nudges the dispatcher when a transaction publishing events
+	// commits, so they do not wait for the next poll.
+	wake chan struct{}
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	started  atomic.Bool
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewBus returns a bus storing events in db. It dispatches nothing until
+// Start is called.
+func NewBus(db *sql.DB) *Bus {
+	instance, err := os.Hostname()
+	if err != nil {
+		instance = "unknown"
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Bus{
+		db:       db,
+		instance: instance,
+		wake:     make(chan struct{}, 1),
+		ctx:      ctx,
+		cancel:   cancel,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Subscribe registers handler for the given event types, or for every type
+// if none are given. The name records which subscribers have had an event,
+// so it must stay the same across deploys; renaming a subscriber makes it
+// receive every undelivered event again. A taken name or an unknown type is
+// a programming error and panics. Calling Subscribe on a nil Bus is a no-op.
+func (b *Bus) Subscribe(name string, handler Handler, types ...string) {
+	if b == nil {
+		return
+	}
+	if name == "" || handler == nil {
+		panic("events: a subscriber needs a name and a handler")
+	}
+	for _, eventType := range types {
+		if _, ok := catalog[eventType]; !ok {
+			panic(fmt.Sprintf("events: %s subscribes to unknown event type %q", name, eventType))
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range b.subs {
+		if sub.name == name {
+			panic(fmt.Sprintf("events: subscriber %s registered twice", name))
+		}
+	}
+	b.subs = append(b.subs, &subscription{name: name, types: types, handler: handler})
+}
+
+// Subscribe registers fn for events of type E, decoded from the envelope.
+func Subscribe[E Event](b *Bus, name string, fn func(ctx context.Context, event E, env Envelope) error) {
+	var zero E
+	b.Subscribe(name, func(ctx context.Context, env Envelope) error {
+		var event E
+		if err := json.Unmarshal(env.Payload, &event); err != nil {
+			return fmt.Errorf("decode %s event %d: %w", env.Type, env.ID, err)
+		}
+		return fn(ctx, event, env)
+	}, zero.EventType())
+}
+
+// subscribers returns the subscriptions that want eventType.
+func (b *Bus) subscribers(eventType string) []*subscription {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var subs []*subscription
+	for _, sub := range b.subs {
+		if sub.wants(eventType) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// InTx runs fn in a transaction, see dbtx.Run. On a nil Bus fn runs without
+// one.
+func (b *Bus) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if b == nil {
+		return fn(ctx)
+	}
+	return dbtx.Run(ctx, b.db, fn)
+}
+
+// Publish stores events in the outbox. Called inside InTx they are stored
+// only if the transaction commits; outside one each is stored at once.
+func (b *Bus) Publish(ctx context.Context, events ...Event) error {
+	if b == nil || len(events) == 0 {
+		return nil
+	}
+	occurredAt := time.Now().UTC()
+	q := dbtx.From(ctx, b.db)
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encode %s event: %w", event.EventType(), err)
+		}
+		_, err = q.ExecContext(ctx, `
+			INSERT INTO outbox_events (event_type, shop_id, payload, occurred_at)
+			VALUES ($1, NULLIF($2, ''), $3, $4)`,
+			event.EventType(), event.EventShopID(), string(payload), occurredAt)
+		if err != nil {
+			return fmt.Errorf("store %s event: %w", event.EventType(), err)
+		}
+	}
+	dbtx.AfterCommit(ctx, b.nudge)
+	return nil
+}
+
+func (b *Bus) nudge() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+var _ Publisher = (*Bus)(nil)
@@ -0,0 +1,314 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// batchSize is how many events one replica claims at a time.
+	batchSize = 50
+	// lease is the shortest time claimed events stay with their replica.
+	// Before each event the dispatcher renews the lease on the rest of its
+	// batch for at least as long as that event's handlers can take (see
+	// leaseFor), so a live replica keeps its batch however slow its handlers
+	// are, and a replica that dies mid-batch leaves its events to be claimed
+	// again once the last renewal runs out.
+	lease = 2 * time.Minute
+	// handlerTimeout bounds one subscriber's handling of one event.
+	handlerTimeout = 30 * time.Second
+	// maxAttempts is how many deliveries an event gets before it is marked
+	// failed and left for an operator.
+	maxAttempts = 10
+	// queryTimeout bounds the dispatcher's own outbox queries.
+	queryTimeout = 10 * time.Second
+)
+
+// Delivery outcomes recorded on an outbox row.
+const (
+	outcomeDelivered = "delivered"
+	outcomeRetry     = "retry"
+	outcomeFailed    = "failed"
+)
+
+// backoff is the delay before the next delivery of an event that has been
+// attempted attempt times: 5s doubling up to an hour.
+func backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 10 {
+		return time.Hour
+	}
+	return min(5*time.Second<<(attempt-1), time.Hour)
+}
+
+// Start dispatches stored events in the background until Stop is called,
+// checking the outbox every pollEvery and whenever this replica commits a
+// transaction that published events. Every replica dispatches; claimed rows
+// are leased so each batch goes to one replica at a time.
+func (b *Bus) Start(pollEvery time.Duration) {
+	if b == nil {
+		return
+	}
+	b.started.Store(true)
+	go b.loop(pollEvery)
+}
+
+func (b *Bus) loop(pollEvery time.Duration) {
+	defer close(b.done)
+	ticker := time.NewTicker(pollEvery)
+	defer ticker.Stop()
+
+	for {
+		b.drain()
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+		case <-b.wake:
+		}
+	}
+}
+
+// drain dispatches batches until the outbox has nothing due or Stop is
+// called.
+func (b *Bus) drain() {
+	for b.ctx.Err() == nil {
+		claimed, err := b.dispatchBatch()
+		if err != nil {
+			slog.Error("Failed to dispatch events", "instance", b.instance, "error", err)
+			return
+		}
+		if claimed < batchSize {
+			return
+		}
+	}
+}
+
+// claimedEvent is an outbox row leased by this replica.
+type claimedEvent struct {
+	env         Envelope
+	deliveredTo []string
+}
+
+// dispatchBatch claims a batch of due events, delivers each and records the
+// outcome. It returns how many events it claimed.
+func (b *Bus) dispatchBatch() (int, error) {
+	ctx, cancel := context.WithTimeout(b.ctx, queryTimeout)
+	batch, err := b.claim(ctx)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+	claimedCount := len(batch)
+
+	for len(batch) > 0 {
+		ctx, cancel := context.WithTimeout(b.ctx, queryTimeout)
+		batch, err = b.renew(ctx, batch, b.leaseFor(batch[0].env))
+		cancel()
+		if err != nil {
+			// The leases run out and the rest of the batch is claimed again.
+			return 0, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		claimed := batch[0]
+		batch = batch[1:]
+
+		delivered, err := b.deliver(claimed.env, claimed.deliveredTo)
+		outcome, errText := outcomeDelivered, ""
+		if err != nil {
+			errText = err.Error()
+			outcome = outcomeRetry
+			if claimed.env.Attempt >= maxAttempts {
+				outcome = outcomeFailed
+			}
+			slog.Warn("Event delivery failed",
+				"event_id", claimed.env.ID,
+				"type", claimed.env.Type,
+				"attempt", claimed.env.Attempt,
+				"outcome", outcome,
+				"error", err)
+		}
+		if err := b.finish(claimed.env, delivered, outcome, errText); err != nil {
+			// The lease runs out and the event is delivered again.
+			slog.Error("Failed to record event delivery", "event_id", claimed.env.ID, "error", err)
+		}
+	}
+	return claimedCount, nil
+}
+
+// leaseFor is how long the dispatcher may hold its batch while it delivers
+// env: every subscriber of env's type running to handlerTimeout, plus
+// recording the outcome.
+func (b *Bus) leaseFor(env Envelope) time.Duration {
+	handling := time.Duration(len(b.subscribers(env.Type))) * handlerTimeout
+	return max(lease, handling+queryTimeout)
+}
+
+// renew extends the lease on the events of batch to d from now, and returns
+// those still leased to this replica. An event whose lease already ran out
+// may have been claimed by another replica, so it is dropped from the batch.
+func (b *Bus) renew(ctx context.Context, batch []claimedEvent, d time.Duration) ([]claimedEvent, error) {
+	ids := make([]int64, len(batch))
+	for i, claimed := range batch {
+		ids[i] = claimed.env.ID
+	}
+	rows, err := b.db.QueryContext(ctx, `
+		UPDATE outbox_events
+		SET locked_until = now() + make_interval(secs => $2)
+		WHERE id = ANY($1) AND locked_until > now()
+		RETURNING id`,
+		pq.Array(ids), d.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("renew outbox event leases: %w", err)
+	}
+	defer rows.Close()
+
+	held := map[int64]bool{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan outbox event lease: %w", err)
+		}
+		held[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("renew outbox event leases: %w", err)
+	}
+	return slices.DeleteFunc(batch, func(claimed claimedEvent) bool {
+		if !held[claimed.env.ID] {
+			slog.Warn("Outbox event lease expired before delivery", "event_id", claimed.env.ID)
+			return true
+		}
+		return false
+	}), nil
+}
+
+// claim leases the oldest due events. SKIP LOCKED keeps replicas claiming at
+// the same moment from waiting on or taking each other's rows.
+func (b *Bus) claim(ctx context.Context) ([]claimedEvent, error) {
+	rows, err := b.db.QueryContext(ctx, `
+		UPDATE outbox_events
+		SET locked_until = now() + make_interval(secs => $2), attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE dispatched_at IS NULL AND failed_at IS NULL
+				AND available_at <= now()
+				AND (locked_until IS NULL OR locked_until < now())
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, COALESCE(shop_id, ''), payload, occurred_at, attempts, delivered_to`,
+		batchSize, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var batch []claimedEvent
+	for rows.Next() {
+		var claimed claimedEvent
+		var payload []byte
+		err := rows.Scan(&claimed.env.ID, &claimed.env.Type, &claimed.env.ShopID, &payload,
+			&claimed.env.OccurredAt, &claimed.env.Attempt, pq.Array(&claimed.deliveredTo))
+		if err != nil {
+			return nil, fmt.Errorf("scan outbox event: %w", err)
+		}
+		claimed.env.Payload = payload
+		batch = append(batch, claimed)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("claim outbox events: %w", err)
+	}
+	// RETURNING has no order; events go out oldest first.
+	sort.Slice(batch, func(i, j int) bool { return batch[i].env.ID < batch[j].env.ID })
+	return batch, nil
+}
+
+// deliver hands env to every subscriber that wants it and has not had it
+// yet. It returns the subscribers that now have it, and the failures.
+func (b *Bus) deliver(env Envelope, deliveredTo []string) ([]string, error) {
+	delivered := slices.Clone(deliveredTo)
+	var errs []error
+	for _, sub := range b.subscribers(env.Type) {
+		if slices.Contains(deliveredTo, sub.name) {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(b.ctx, handlerTimeout)
+		err := handleSafely(ctx, sub.handler, env)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+			continue
+		}
+		delivered = append(delivered, sub.name)
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// handleSafely turns a panicking handler into a failed delivery.
+func handleSafely(ctx context.Context, handler Handler, env Envelope) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return handler(ctx, env)
+}
+
+// finish records which subscribers have env and releases its lease. A retry
+// becomes due after the backoff for its attempt.
+func (b *Bus) finish(env Envelope, delivered []string, outcome string, errText string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	var lastError *string
+	if errText != "" {
+		lastError = &errText
+	}
+	_, err := b.db.ExecContext(ctx, `
+		UPDATE outbox_events SET
+			delivered_to = $2,
+			last_error = $3,
+			locked_until = NULL,
+			dispatched_at = CASE WHEN $4 = 'delivered' THEN now() END,
+			failed_at = CASE WHEN $4 = 'failed' THEN now() END,
+			available_at = now() + make_interval(secs => $5)
+		WHERE id = $1`,
+		env.ID, pq.Array(delivered), lastError, outcome, backoff(env.Attempt).Seconds())
+	return err
+}
+
+// Stop stops dispatching, cancelling the handlers in progress, and waits for
+// the dispatcher to finish its batch. Events it did not record are delivered
+// again once their lease runs out. Safe to call more than once, on a nil Bus
+// and on one never started.
+func (b *Bus) Stop(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	b.stopOnce.Do(func() {
+		close(b.stop)
+		b.cancel()
+	})
+	if !b.started.Load() {
+		return nil
+	}
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
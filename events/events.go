@@ -0,0 +1,279 @@
+// Package events is the domain event bus. Services publish typed Events in
+// the transaction that makes the change they describe: Publish writes them
+// to the outbox_events table, so an event is stored exactly when its change
+// commits. The dispatcher on every replica claims stored events and hands
+// each to the subscribers registered on the Bus, retrying a failed
+// subscriber with backoff. Delivery is at least once, so subscribers must
+// tolerate seeing an event twice.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnknownType = errors.New("unknown event type")
+
+// Event is a change to domain state. Its JSON encoding is the payload
+// subscribers receive, so fields are only ever added.
+type Event interface {
+	// EventType names the event, e.g. "shop.member.joined".
+	EventType() string
+	// EventShopID is the shop the change belongs to, or "" for none.
+	EventShopID() string
+}
+
+// Publisher stores events for dispatch. Services depend on it rather than
+// on the Bus so tests can record what they publish.
+type Publisher interface {
+	// InTx runs fn in a transaction that repositories and Publish join.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// Publish stores events in the transaction in ctx, if any.
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// Envelope is a stored event as subscribers receive it.
+type Envelope struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	ShopID     string          `json:"shop_id,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	// Attempt counts deliveries of the event, starting at 1.
+	Attempt int `json:"-"`
+}
+
+// Decode unmarshals the payload into its catalog type.
+func (env Envelope) Decode() (Event, error) {
+	newEvent, ok := catalog[env.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, env.Type)
+	}
+	event := newEvent()
+	if err := json.Unmarshal(env.Payload, event); err != nil {
+		return nil, fmt.Errorf("decode %s event %d: %w", env.Type, env.ID, err)
+	}
+	return event, nil
+}
+
+// Event types.
+const (
+	TypeMemberJoined               = "shop.member.joined"
+	TypeMemberLeft                 = "shop.member.left"
+	TypeMemberRemoved              = "shop.member.removed"
+	TypeMemberPromoted             = "shop.member.promoted"
+	TypeVehicleNotificationCreated = "shop.vehicle_notification.created"
+	TypeVehicleNotificationChanged = "shop.vehicle_notification.changed"
+	TypeEquipmentServiceCompleted  = "shop.equipment_service.completed"
+	TypeEquipmentServiceOverdue    = "shop.equipment_service.overdue"
+	TypeMessageCreated             = "shop.message.created"
+	TypeMessageUpdated             = "shop.message.updated"
+	TypeMessageDeleted             = "shop.message.deleted"
+	TypeListItemsChanged           = "shop.list_items.changed"
+	TypePMCSFaultRecorded          = "shop.pmcs_fault.recorded"
+)
+
+// catalog maps each event type to a constructor for its payload, for
+// decoding envelopes.
+var catalog = map[string]func() Event{
+	TypeMemberJoined:               func() Event { return &MemberJoined{} },
+	TypeMemberLeft:                 func() Event { return &MemberLeft{} },
+	TypeMemberRemoved:              func() Event { return &MemberRemoved{} },
+	TypeMemberPromoted:             func() Event { return &MemberPromoted{} },
+	TypeVehicleNotificationCreated: func() Event { return &VehicleNotificationCreated{} },
+	TypeVehicleNotificationChanged: func() Event { return &VehicleNotificationChanged{} },
+	TypeEquipmentServiceCompleted:  func() Event { return &EquipmentServiceCompleted{} },
+	TypeEquipmentServiceOverdue:    func() Event { return &EquipmentServiceOverdue{} },
+	TypeMessageCreated:             func() Event { return &MessageCreated{} },
+	TypeMessageUpdated:             func() Event { return &MessageUpdated{} },
+	TypeMessageDeleted:             func() Event { return &MessageDeleted{} },
+	TypeListItemsChanged:           func() Event { return &ListItemsChanged{} },
+	TypePMCSFaultRecorded:          func() Event { return &PMCSFaultRecorded{} },
+}
+
+// Types lists every event type.
+func Types() []string {
+	return []string{
+		TypeMemberJoined,
+		TypeMemberLeft,
+		TypeMemberRemoved,
+		TypeMemberPromoted,
+		TypeVehicleNotificationCreated,
+		TypeVehicleNotificationChanged,
+		TypeEquipmentServiceCompleted,
+		TypeEquipmentServiceOverdue,
+		TypeMessageCreated,
+		TypeMessageUpdated,
+		TypeMessageDeleted,
+		TypeListItemsChanged,
+		TypePMCSFaultRecorded,
+	}
+}
+
+// MemberJoined is a user joining a shop with an invite code.
+// The invite code is left out: payloads reach webhook endpoints, and the
+// code would let anyone who sees one join the shop.
+type MemberJoined struct {
+	ShopID string `json:"shop_id"`
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+func (MemberJoined) EventType() string     { return TypeMemberJoined }
+func (e MemberJoined) EventShopID() string { return e.ShopID }
+
+// MemberLeft is a member leaving a shop that still has other members.
+type MemberLeft struct {
+	ShopID string `json:"shop_id"`
+	UserID string `json:"user_id"`
+}
+
+func (MemberLeft) EventType() string     { return TypeMemberLeft }
+func (e MemberLeft) EventShopID() string { return e.ShopID }
+
+// MemberRemoved is a shop admin removing another member.
+type MemberRemoved struct {
+	ShopID    string `json:"shop_id"`
+	UserID    string `json:"user_id"`
+	RemovedBy string `json:"removed_by"`
+}
+
+func (MemberRemoved) EventType() string     { return TypeMemberRemoved }
+func (e MemberRemoved) EventShopID() string { return e.ShopID }
+
+// MemberPromoted is a shop admin making another member an admin.
+type MemberPromoted struct {
+	ShopID     string `json:"shop_id"`
+	UserID     string `json:"user_id"`
+	Role       string `json:"role"`
+	PromotedBy string `json:"promoted_by"`
+}
+
+func (MemberPromoted) EventType() string     { return TypeMemberPromoted }
+func (e MemberPromoted) EventShopID() string { return e.ShopID }
+
+// VehicleNotificationCreated is a new maintenance notification on a shop
+// vehicle.
+type VehicleNotificationCreated struct {
+	ShopID         string `json:"shop_id"`
+	VehicleID      string `json:"vehicle_id"`
+	NotificationID string `json:"notification_id"`
+	Type           string `json:"type"`
+	Title          string `json:"title"`
+	CreatedBy      string `json:"created_by"`
+}
+
+func (VehicleNotificationCreated) EventType() string     { return TypeVehicleNotificationCreated }
+func (e VehicleNotificationCreated) EventShopID() string { return e.ShopID }
+
+// VehicleNotificationChanged is an entry in a shop's notification change
+// feed: a notification created, updated or deleted, items added to or
+// removed from one, or a vehicle deleted. ChangeType is the feed's
+// change_type; NotificationID is empty when a vehicle is deleted.
+type VehicleNotificationChanged struct {
+	ShopID         string `json:"shop_id"`
+	VehicleID      string `json:"vehicle_id"`
+	NotificationID string `json:"notification_id,omitempty"`
+	ChangeType     string `json:"change_type"`
+	ChangedBy      string `json:"changed_by"`
+}
+
+func (VehicleNotificationChanged) EventType() string     { return TypeVehicleNotificationChanged }
+func (e VehicleNotificationChanged) EventShopID() string { return e.ShopID }
+
+// EquipmentServiceCompleted is a scheduled equipment service marked done.
+type EquipmentServiceCompleted struct {
+	ShopID         string     `json:"shop_id"`
+	ServiceID      string     `json:"service_id"`
+	EquipmentID    string     `json:"equipment_id"`
+	CompletedBy    string     `json:"completed_by"`
+	CompletionDate *time.Time `json:"completion_date"`
+}
+
+func (EquipmentServiceCompleted) EventType() string     { return TypeEquipmentServiceCompleted }
+func (e EquipmentServiceCompleted) EventShopID() string { return e.ShopID }
+
+// EquipmentServiceOverdue is a scheduled equipment service whose date has
+// passed without it being completed. It is published once per service date,
+// so rescheduling a service lets it fall overdue again.
+type EquipmentServiceOverdue struct {
+	ShopID      string    `json:"shop_id"`
+	ServiceID   string    `json:"service_id"`
+	EquipmentID string    `json:"equipment_id"`
+	ServiceType string    `json:"service_type"`
+	Description string    `json:"description"`
+	ServiceDate time.Time `json:"service_date"`
+}
+
+func (EquipmentServiceOverdue) EventType() string     { return TypeEquipmentServiceOverdue }
+func (e EquipmentServiceOverdue) EventShopID() string { return e.ShopID }
+
+// MessageCreated is a message posted to a shop's board. ParentID is set on
+// replies.
+type MessageCreated struct {
+	ShopID    string  `json:"shop_id"`
+	MessageID string  `json:"message_id"`
+	AuthorID  string  `json:"author_id"`
+	ParentID  *string `json:"parent_id"`
+}
+
+func (MessageCreated) EventType() string     { return TypeMessageCreated }
+func (e MessageCreated) EventShopID() string { return e.ShopID }
+
+// MessageUpdated is a message on a shop's board edited by its author.
+type MessageUpdated struct {
+	ShopID    string `json:"shop_id"`
+	MessageID string `json:"message_id"`
+	AuthorID  string `json:"author_id"`
+}
+
+func (MessageUpdated) EventType() string     { return TypeMessageUpdated }
+func (e MessageUpdated) EventShopID() string { return e.ShopID }
+
+// MessageDeleted is a message removed from a shop's board.
+type MessageDeleted struct {
+	ShopID    string `json:"shop_id"`
+	MessageID string `json:"message_id"`
+	DeletedBy string `json:"deleted_by"`
+}
+
+func (MessageDeleted) EventType() string     { return TypeMessageDeleted }
+func (e MessageDeleted) EventShopID() string { return e.ShopID }
+
+// List item changes.
+const (
+	ListItemsAdded   = "added"
+	ListItemsUpdated = "updated"
+	ListItemsRemoved = "removed"
+)
+
+// ListItemsChanged is items added to, updated on or removed from a shop
+// list. Batch operations publish one event for all their items.
+type ListItemsChanged struct {
+	ShopID    string   `json:"shop_id"`
+	ListID    string   `json:"list_id"`
+	ItemIDs   []string `json:"item_ids"`
+	Change    string   `json:"change"`
+	ChangedBy string   `json:"changed_by"`
+}
+
+func (ListItemsChanged) EventType() string     { return TypeListItemsChanged }
+func (e ListItemsChanged) EventShopID() string { return e.ShopID }
+
+// PMCSFaultRecorded is a fault recorded, or updated, on a PMCS inspection of
+// a shop vehicle.
+type PMCSFaultRecorded struct {
+	ShopID      string `json:"shop_id"`
+	EquipmentID string `json:"equipment_id"`
+	PmcsID      string `json:"pmcs_id"`
+	SectionID   string `json:"section_id"`
+	ItemIndex   int32  `json:"item_index"`
+	Status      string `json:"status"`
+	RecordedBy  string `json:"recorded_by"`
+}
+
+func (PMCSFaultRecorded) EventType() string     { return TypePMCSFaultRecorded }
+func (e PMCSFaultRecorded) EventShopID() string { return e.ShopID }
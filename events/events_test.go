@@ -0,0 +1,210 @@
+package events
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"miltechserver/sqltest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func envelope(t *testing.T, id int64, event Event) Envelope {
+	t.Helper()
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	return Envelope{ID: id, Type: event.EventType(), ShopID: event.EventShopID(), Payload: payload, Attempt: 1}
+}
+
+func TestCatalogCoversEveryType(t *testing.T) {
+	require.Len(t, catalog, len(Types()))
+	for _, eventType := range Types() {
+		newEvent, ok := catalog[eventType]
+		require.True(t, ok, eventType)
+		require.Equal(t, eventType, newEvent().EventType())
+	}
+}
+
+func TestEnvelopeDecode(t *testing.T) {
+	joined := MemberJoined{ShopID: "shop-1", UserID: "user-1", Role: "member"}
+	event, err := envelope(t, 1, joined).Decode()
+	require.NoError(t, err)
+	require.Equal(t, &joined, event)
+
+	_, err = Envelope{ID: 2, Type: "shop.deleted", Payload: []byte(`{}`)}.Decode()
+	require.ErrorIs(t, err, ErrUnknownType)
+}
+
+func TestSubscribeRejectsInvalidSubscribers(t *testing.T) {
+	bus := NewBus(nil)
+	handler := func(context.Context, Envelope) error { return nil }
+	bus.Subscribe("webhooks", handler)
+
+	require.Panics(t, func() { bus.Subscribe("webhooks", handler) })
+	require.Panics(t, func() { bus.Subscribe("feed", handler, "shop.deleted") })
+	require.Panics(t, func() { bus.Subscribe("", handler) })
+	require.Panics(t, func() { bus.Subscribe("push", nil) })
+}
+
+func TestSubscribersFilterByType(t *testing.T) {
+	bus := NewBus(nil)
+	handler := func(context.Context, Envelope) error { return nil }
+	bus.Subscribe("everything", handler)
+	bus.Subscribe("messages", handler, TypeMessageCreated)
+	bus.Subscribe("members", handler, TypeMemberJoined)
+
+	var names []string
+	for _, sub := range bus.subscribers(TypeMessageCreated) {
+		names = append(names, sub.name)
+	}
+	require.Equal(t, []string{"everything", "messages"}, names)
+}
+
+func TestTypedSubscribe(t *testing.T) {
+	bus := NewBus(nil)
+	var got MessageCreated
+	Subscribe(bus, "feed", func(_ context.Context, event MessageCreated, env Envelope) error {
+		got = event
+		require.Equal(t, int64(7), env.ID)
+		return nil
+	})
+
+	parent := "message-1"
+	sent := MessageCreated{ShopID: "shop-1", MessageID: "message-2", AuthorID: "user-1", ParentID: &parent}
+	delivered, err := bus.deliver(envelope(t, 7, sent), nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"feed"}, delivered)
+	require.Equal(t, sent, got)
+
+	// Other types never reach it
+	delivered, err = bus.deliver(envelope(t, 8, MemberJoined{ShopID: "shop-1"}), nil)
+	require.NoError(t, err)
+	require.Empty(t, delivered)
+}
+
+func TestDeliverRetriesOnlyFailedSubscribers(t *testing.T) {
+	bus := NewBus(nil)
+	calls := map[string]int{}
+	bus.Subscribe("push", func(context.Context, Envelope) error {
+		calls["push"]++
+		return nil
+	})
+	bus.Subscribe("webhooks", func(context.Context, Envelope) error {
+		calls["webhooks"]++
+		return errors.New("endpoint returned 503")
+	})
+	bus.Subscribe("feed", func(context.Context, Envelope) error {
+		calls["feed"]++
+		panic("nil map")
+	})
+	env := envelope(t, 1, MemberJoined{ShopID: "shop-1"})
+
+	delivered, err := bus.deliver(env, nil)
+	require.Equal(t, []string{"push"}, delivered)
+	require.ErrorContains(t, err, "webhooks: endpoint returned 503")
+	require.ErrorContains(t, err, "feed: panic: nil map")
+
+	// The redelivery skips the subscriber that already has the event
+	delivered, err = bus.deliver(env, delivered)
+	require.Error(t, err)
+	require.Equal(t, []string{"push"}, delivered)
+	require.Equal(t, map[string]int{"push": 1, "webhooks": 2, "feed": 2}, calls)
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, 5*time.Second, backoff(0))
+	require.Equal(t, 5*time.Second, backoff(1))
+	require.Equal(t, 10*time.Second, backoff(2))
+	require.Equal(t, 40*time.Second, backoff(4))
+	require.Equal(t, 2560*time.Second, backoff(10))
+	require.Equal(t, time.Hour, backoff(11))
+	require.Equal(t, time.Hour, backoff(100))
+}
+
+func TestLeaseCoversEverySubscriber(t *testing.T) {
+	bus := NewBus(nil)
+	handler := func(context.Context, Envelope) error { return nil }
+	require.Equal(t, lease, bus.leaseFor(Envelope{Type: TypeMessageCreated}))
+
+	for i := range 8 {
+		bus.Subscribe(fmt.Sprintf("subscriber-%d", i), handler)
+	}
+	got := bus.leaseFor(Envelope{Type: TypeMessageCreated})
+	require.Greater(t, got, 8*handlerTimeout)
+}
+
+func TestNilBus(t *testing.T) {
+	var bus *Bus
+	bus.Subscribe("feed", func(context.Context, Envelope) error { return nil })
+	bus.Start(time.Second)
+
+	var ran bool
+	err := bus.InTx(context.Background(), func(ctx context.Context) error {
+		ran = true
+		return bus.Publish(ctx, MemberJoined{ShopID: "shop-1"})
+	})
+	require.NoError(t, err)
+	require.True(t, ran)
+	require.NoError(t, bus.Stop(context.Background()))
+}
+
+func TestStopBeforeStart(t *testing.T) {
+	bus := NewBus(nil)
+	require.NoError(t, bus.Stop(context.Background()))
+	require.NoError(t, bus.Stop(context.Background()))
+}
+
+func woken(bus *Bus) bool {
+	select {
+	case <-bus.wake:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestPublishJoinsTransaction(t *testing.T) {
+	var inserted []string
+	db, d := sqltest.Open(t, func(query string, args []driver.Value) (sqltest.Rows, error) {
+		if !strings.Contains(query, "INSERT INTO outbox_events") {
+			return nil, errors.New("unexpected statement: " + query)
+		}
+		inserted = append(inserted, args[0].(string))
+		return nil, nil
+	})
+	bus := NewBus(db)
+
+	err := bus.InTx(context.Background(), func(ctx context.Context) error {
+		if err := bus.Publish(ctx, MemberJoined{ShopID: "shop-1"}, MessageCreated{ShopID: "shop-1"}); err != nil {
+			return err
+		}
+		require.False(t, woken(bus), "the dispatcher waits for the commit")
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, woken(bus))
+
+	failure := errors.New("insert failed")
+	err = bus.InTx(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, bus.Publish(ctx, MemberJoined{ShopID: "shop-1"}))
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+	require.False(t, woken(bus), "rolled back events are not dispatched")
+
+	var log []string
+	for _, entry := range d.Log() {
+		if strings.Contains(entry, "INSERT INTO outbox_events") {
+			entry = "INSERT"
+		}
+		log = append(log, entry)
+	}
+	require.Equal(t, []string{"BEGIN", "INSERT", "INSERT", "COMMIT", "BEGIN", "INSERT", "ROLLBACK"}, log)
+	require.Equal(t, []string{TypeMemberJoined, TypeMessageCreated, TypeMemberJoined}, inserted)
+}
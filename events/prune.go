@@ -0,0 +1,31 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"miltechserver/jobs"
+)
+
+// PruneOutbox returns the job that deletes outbox events delivered, or
+// given up on, more than retention ago.
+func PruneOutbox(db *sql.DB, retention time.Duration) jobs.Job {
+	return jobs.Job{
+		Name:     "outbox-prune",
+		Schedule: "45 3 * * *",
+		Run: func(ctx context.Context) error {
+			result, err := db.ExecContext(ctx,
+				`DELETE FROM outbox_events WHERE COALESCE(dispatched_at, failed_at) < $1`,
+				time.Now().Add(-retention).UTC())
+			if err != nil {
+				return fmt.Errorf("failed to prune outbox events: %w", err)
+			}
+			pruned, _ := result.RowsAffected()
+			slog.Info("Pruned outbox events", "deleted", pruned, "retention", retention)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"miltechserver/bootstrap"
+	"strconv"
+
+	"github.com/go-jet/jet/v2/generator/metadata"
+	"github.com/go-jet/jet/v2/generator/postgres"
+	"github.com/go-jet/jet/v2/generator/template"
+	postgres2 "github.com/go-jet/jet/v2/postgres"
+)
+
+// runGen implements the gen subcommand. Generated code is committed by
+// developers; the server never regenerates it at startup.
+func runGen(args []string) {
+	flags := flag.NewFlagSet("gen", flag.ExitOnError)
+	out := flags.String("out", "./.gen", "output directory for generated code")
+	_ = flags.Parse(args)
+
+	env := bootstrap.NewEnv()
+	generateSchema(env, *out)
+	log.Printf("Generated schema %s into %s", env.DBSchema, *out)
+}
+
+func generateSchema(env *bootstrap.Env, out string) {
+	port, err := strconv.Atoi(env.Port)
+	if err != nil {
+		port = 5432
+	}
+
+	err1 := postgres.Generate(
+		out,
+		postgres.DBConnection{
+			Host:       env.Host,
+			Port:       port,
+			User:       env.Username,
+			Password:   env.Password,
+			SslMode:    env.SslMode,
+			DBName:     env.DBName,
+			SchemaName: env.DBSchema,
+		},
+		template.Default(postgres2.Dialect).
+			UseSchema(func(schema metadata.Schema) template.Schema {
+				return template.DefaultSchema(schema).
+					UseModel(template.DefaultModel().
+						UseTable(func(table metadata.Table) template.TableModel {
+							return template.DefaultTableModel(table).
+								UseField(func(columnMetaData metadata.Column) template.TableModelField {
+									defaultTableModelField := template.DefaultTableModelField(columnMetaData)
+									return defaultTableModelField.UseTags(
+										fmt.Sprintf(`json:"%s"`, columnMetaData.Name),
+									)
+								})
+						}).UseView(func(table metadata.Table) template.TableModel {
+						return template.DefaultTableModel(table).
+							UseField(func(columnMetaData metadata.Column) template.TableModelField {
+								defaultTableModelField := template.DefaultTableModelField(columnMetaData)
+								return defaultTableModelField.UseTags(
+									fmt.Sprintf(`json:"%s"`, columnMetaData.Name),
+								)
+							})
+					}),
+					)
+			}),
+	)
+
+	if err1 != nil {
+		log.Fatalf("Error generating code: %s", err1)
+	}
+}
@@ -0,0 +1,148 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"miltechserver/migrations"
+	"miltechserver/storage"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/lib/pq"
+)
+
+// Database pings Postgres and reports pool usage.
+func Database(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		if err := db.PingContext(ctx); err != nil {
+			return nil, err
+		}
+		stats := db.Stats()
+		return map[string]any{
+			"open_connections": stats.OpenConnections,
+			"in_use":           stats.InUse,
+			"max_open":         stats.MaxOpenConnections,
+		}, nil
+	}
+}
+
+// Relation is a table or view as the jet models were generated from it.
+type Relation struct {
+	Schema  string
+	Name    string
+	Columns []string
+}
+
+// generated is satisfied by every jet generated table and view.
+type generated interface {
+	SchemaName() string
+	TableName() string
+}
+
+// Generated describes a jet generated table or view by its AllColumns.
+func Generated(relation generated, columns postgres.ColumnList) Relation {
+	r := Relation{Schema: relation.SchemaName(), Name: relation.TableName()}
+	for _, column := range columns {
+		r.Columns = append(r.Columns, column.Name())
+	}
+	return r
+}
+
+// maxReportedColumns bounds the missing columns listed in a failing report.
+const maxReportedColumns = 20
+
+// Schema fails while any embedded migration is unapplied, or while any of
+// relations lacks a column the jet models were generated with. Queries built
+// from those models select every generated column, so either one means the
+// generated code and the database disagree. Columns the database has beyond
+// the generated ones are not reported; the models never reference them.
+func Schema(db *sql.DB, relations ...Relation) CheckFunc {
+	runner, runnerErr := migrations.NewRunner(db)
+	return func(ctx context.Context) (map[string]any, error) {
+		if runnerErr != nil {
+			return nil, runnerErr
+		}
+		pending, err := runner.Pending(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) > 0 {
+			return map[string]any{"pending": len(pending)},
+				fmt.Errorf("%d pending migration(s), first is %03d_%s", len(pending), pending[0].Version, pending[0].Name)
+		}
+
+		present, err := columnsOf(ctx, db, relations)
+		if err != nil {
+			return nil, err
+		}
+		missing := missingColumns(relations, present)
+		if len(missing) > 0 {
+			reported := missing[:min(len(missing), maxReportedColumns)]
+			return map[string]any{"missing_columns": reported},
+				fmt.Errorf("%d generated column(s) missing from the database, first is %s", len(missing), missing[0])
+		}
+		return map[string]any{"relations": len(relations)}, nil
+	}
+}
+
+// columnsOf returns the columns the database has in the schemas of
+// relations, keyed "schema.table.column".
+func columnsOf(ctx context.Context, db *sql.DB, relations []Relation) (map[string]bool, error) {
+	present := map[string]bool{}
+	if len(relations) == 0 {
+		return present, nil
+	}
+	var schemas []string
+	for _, relation := range relations {
+		if !slices.Contains(schemas, relation.Schema) {
+			schemas = append(schemas, relation.Schema)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT table_schema, table_name, column_name
+		FROM information_schema.columns
+		WHERE table_schema = ANY($1)`, pq.Array(schemas))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read information_schema.columns: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var schema, table, column string
+		if err := rows.Scan(&schema, &table, &column); err != nil {
+			return nil, fmt.Errorf("failed to scan information_schema.columns: %w", err)
+		}
+		present[schema+"."+table+"."+column] = true
+	}
+	return present, rows.Err()
+}
+
+// missingColumns lists, as "schema.table.column", the generated columns of
+// relations that present lacks.
+func missingColumns(relations []Relation, present map[string]bool) []string {
+	var missing []string
+	for _, relation := range relations {
+		for _, column := range relation.Columns {
+			key := relation.Schema + "." + relation.Name + "." + column
+			if !present[key] {
+				missing = append(missing, key)
+			}
+		}
+	}
+	return missing
+}
+
+// probePrefix matches no real object, so listing it is a cheap round trip.
+const probePrefix = ".healthz/"
+
+// ObjectStore confirms container is reachable by listing an empty prefix.
+func ObjectStore(store storage.ObjectStore, container string) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		if _, err := store.List(ctx, container, probePrefix); err != nil {
+			return nil, err
+		}
+		return map[string]any{"container": container}, nil
+	}
+}
@@ -0,0 +1,125 @@
+// Package health runs the dependency checks behind /readyz. Components
+// register checks on a Registry at startup; a failing critical check makes the
+// instance unready, while a failing informational check only marks it
+// degraded.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Check statuses and overall report statuses.
+const (
+	StatusOK          = "ok"
+	StatusFail        = "fail"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+)
+
+// CheckFunc probes one dependency. details are reported as-is and may be nil.
+type CheckFunc func(ctx context.Context) (details map[string]any, err error)
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    string         `json:"status"`
+	Critical  bool           `json:"critical"`
+	LatencyMS int64          `json:"latency_ms"`
+	Error     string         `json:"error,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+// Report is the outcome of every registered check.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Ready reports whether every critical check passed.
+func (r Report) Ready() bool {
+	return r.Status != StatusUnavailable
+}
+
+type check struct {
+	name     string
+	critical bool
+	run      CheckFunc
+}
+
+// Registry holds the checks run by readiness probes.
+type Registry struct {
+	mu     sync.Mutex
+	checks []check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a check. Critical checks gate readiness; the rest are
+// reported for visibility only. Calling Register on a nil Registry is a
+// no-op, which keeps test wiring simple.
+func (r *Registry) Register(name string, critical bool, fn CheckFunc) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, check{name: name, critical: critical, run: fn})
+}
+
+// Run executes every check concurrently, each bounded by ctx.
+func (r *Registry) Run(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: map[string]Result{}}
+	if r == nil {
+		return report
+	}
+
+	r.mu.Lock()
+	checks := append([]check(nil), r.checks...)
+	r.mu.Unlock()
+	sort.Slice(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	for i, c := range checks {
+		result := results[i]
+		report.Checks[c.name] = result
+		if result.Status == StatusOK {
+			continue
+		}
+		if c.critical {
+			report.Status = StatusUnavailable
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func runCheck(ctx context.Context, c check) Result {
+	start := time.Now()
+	details, err := c.run(ctx)
+	result := Result{
+		Status:    StatusOK,
+		Critical:  c.critical,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Details:   details,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(context.Context) (map[string]any, error) { return nil, nil }
+
+func fail(context.Context) (map[string]any, error) { return nil, errors.New("down") }
+
+func TestRunAllPassing(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("database", true, ok)
+	registry.Register("cache", false, func(context.Context) (map[string]any, error) {
+		return map[string]any{"warm": true}, nil
+	})
+
+	report := registry.Run(context.Background())
+
+	require.Equal(t, StatusOK, report.Status)
+	require.True(t, report.Ready())
+	require.Equal(t, map[string]any{"warm": true}, report.Checks["cache"].Details)
+}
+
+func TestRunInformationalFailureDegrades(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("database", true, ok)
+	registry.Register("cache", false, fail)
+
+	report := registry.Run(context.Background())
+
+	require.Equal(t, StatusDegraded, report.Status)
+	require.True(t, report.Ready())
+	require.Equal(t, StatusFail, report.Checks["cache"].Status)
+	require.Equal(t, "down", report.Checks["cache"].Error)
+}
+
+func TestRunCriticalFailureIsUnavailable(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("database", true, fail)
+	registry.Register("cache", false, fail)
+
+	report := registry.Run(context.Background())
+
+	require.Equal(t, StatusUnavailable, report.Status)
+	require.False(t, report.Ready())
+}
+
+func TestNilRegistryIsReady(t *testing.T) {
+	var registry *Registry
+	registry.Register("ignored", true, fail)
+
+	require.True(t, registry.Run(context.Background()).Ready())
+}
+
+func TestMissingColumns(t *testing.T) {
+	relations := []Relation{
+		{Schema: "public", Name: "users", Columns: []string{"uid", "email", "roles"}},
+		{Schema: "public", Name: "niin_lookup", Columns: []string{"niin"}},
+	}
+	present := map[string]bool{
+		"public.users.uid":        true,
+		"public.users.email":      true,
+		"public.users.created_at": true,
+	}
+
+	require.Equal(t, []string{"public.users.roles", "public.niin_lookup.niin"}, missingColumns(relations, present))
+
+	present["public.users.roles"] = true
+	present["public.niin_lookup.niin"] = true
+	require.Empty(t, missingColumns(relations, present))
+}
+
+func TestGeneratedReadsAllColumns(t *testing.T) {
+	users := postgres.NewTable("public", "users", "", postgres.StringColumn("uid"), postgres.StringColumn("email"))
+	relation := Generated(users, postgres.ColumnList{postgres.StringColumn("uid"), postgres.StringColumn("email")})
+	require.Equal(t, Relation{Schema: "public", Name: "users", Columns: []string{"uid", "email"}}, relation)
+}
@@ -0,0 +1,56 @@
+package identity
+
+import (
+	"fmt"
+
+	"miltechserver/bootstrap"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// userFromClaims maps standard OIDC claims onto a User. The subject is the
+// user ID and an email is required, matching what Firebase tokens provide.
+func userFromClaims(claims jwt.MapClaims) (*bootstrap.User, error) {
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	email, ok := claims["email"].(string)
+	if !ok || email == "" {
+		return nil, ErrMissingEmail
+	}
+
+	user := &bootstrap.User{
+		UserID: subject,
+		Email:  email,
+		Roles:  rolesFromClaims(claims),
+	}
+	if name, ok := claims["name"].(string); ok && name != "" {
+		user.Username = name
+	} else if name, ok := claims["preferred_username"].(string); ok {
+		user.Username = name
+	}
+	return user, nil
+}
+
+// rolesFromClaims reads application roles from a "roles" array claim and/or a
+// single "role" string claim, the two shapes Firebase custom claims and most
+// OIDC providers use.
+func rolesFromClaims(claims map[string]any) []string {
+	var roles []string
+	if role, ok := claims["role"].(string); ok && role != "" {
+		roles = append(roles, role)
+	}
+	switch list := claims["roles"].(type) {
+	case []any:
+		for _, item := range list {
+			if role, ok := item.(string); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+	case []string:
+		roles = append(roles, list...)
+	}
+	return mergeRoles(roles)
+}
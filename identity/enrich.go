@@ -0,0 +1,73 @@
+package identity
+
+import (
+	"context"
+	"log/slog"
+
+	"miltechserver/bootstrap"
+)
+
+// nameResolver is implemented by verifiers that can look up a display name
+// their tokens do not carry.
+type nameResolver interface {
+	lookupName(ctx context.Context, uid string) (string, error)
+}
+
+// profileVerifier fills a verified user's display name and roles from the
+// database, so every provider yields the same bootstrap.User.
+type profileVerifier struct {
+	inner    TokenVerifier
+	profiles ProfileSource
+}
+
+// WithProfiles wraps verifier so users carry their stored display name and
+// the union of token and user_roles roles. The stored name wins over the
+// token's because users can rename themselves through user_general.
+func WithProfiles(verifier TokenVerifier, profiles ProfileSource) TokenVerifier {
+	return &profileVerifier{inner: verifier, profiles: profiles}
+}
+
+func (v *profileVerifier) Verify(ctx context.Context, rawToken string) (*bootstrap.User, error) {
+	user, err := v.inner.Verify(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := v.profiles.Profile(ctx, user.UserID)
+	if err != nil {
+		// Fall through with what the token says rather than failing the request.
+		slog.Warn("Failed to read cached user profile", "user_id", user.UserID, "error", err)
+	}
+	if profile.Found && profile.DisplayName != "" {
+		user.Username = profile.DisplayName
+	}
+	user.Roles = mergeRoles(user.Roles, profile.Roles)
+
+	// First-seen users have no row yet; ask the provider if it can tell us.
+	if !profile.Found && user.Username == "" {
+		if resolver, ok := v.inner.(nameResolver); ok {
+			name, err := resolver.lookupName(ctx, user.UserID)
+			if err != nil {
+				slog.Error("Error getting user: ", "error", err)
+			} else {
+				user.Username = name
+			}
+		}
+	}
+
+	return user, nil
+}
+
+func mergeRoles(lists ...[]string) []string {
+	var merged []string
+	seen := map[string]bool{}
+	for _, list := range lists {
+		for _, role := range list {
+			if role != "" && !seen[role] {
+				seen[role] = true
+				merged = append(merged, role)
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,68 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"miltechserver/bootstrap"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// NewFromEnv builds the verifier selected by AUTH_PROVIDER, wrapped with
+// WithProfiles when profiles is non-nil.
+func NewFromEnv(env *bootstrap.Env, fireAuth *auth.Client, profiles ProfileSource) (TokenVerifier, error) {
+	verifier, err := newProviderFromEnv(env, fireAuth)
+	if err != nil || profiles == nil {
+		return verifier, err
+	}
+	return WithProfiles(verifier, profiles), nil
+}
+
+func newProviderFromEnv(env *bootstrap.Env, fireAuth *auth.Client) (TokenVerifier, error) {
+	switch env.AuthProvider {
+	case "firebase":
+		if fireAuth == nil {
+			return nil, errors.New("AUTH_PROVIDER=firebase but the Firebase Auth client failed to initialize")
+		}
+		return NewFirebaseVerifier(fireAuth), nil
+
+	case "oidc":
+		if env.OIDCIssuer == "" || env.OIDCAudience == "" {
+			return nil, errors.New("AUTH_PROVIDER=oidc requires OIDC_ISSUER and OIDC_AUDIENCE")
+		}
+		return NewOIDCVerifier(OIDCConfig{
+			Issuer:   env.OIDCIssuer,
+			Audience: env.OIDCAudience,
+			JWKSURL:  env.OIDCJWKSURL,
+		})
+
+	case "local":
+		slog.Warn("Using the local token issuer; do not enable this in production")
+		return NewLocalIssuerFromEnv(env)
+
+	default:
+		return nil, fmt.Errorf("unknown AUTH_PROVIDER %q", env.AuthProvider)
+	}
+}
+
+// NewLocalIssuerFromEnv builds a LocalIssuer from AUTH_LOCAL_HMAC_SECRET or
+// AUTH_LOCAL_RSA_KEY_FILE.
+func NewLocalIssuerFromEnv(env *bootstrap.Env) (*LocalIssuer, error) {
+	cfg := LocalConfig{HMACSecret: []byte(env.AuthLocalHMACSecret)}
+	if env.AuthLocalRSAKeyFile != "" {
+		pem, err := os.ReadFile(env.AuthLocalRSAKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read local issuer key: %w", err)
+		}
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse local issuer key: %w", err)
+		}
+		cfg.RSAPrivateKey = key
+	}
+	return NewLocalIssuer(cfg)
+}
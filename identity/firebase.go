@@ -0,0 +1,50 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"miltechserver/bootstrap"
+
+	"firebase.google.com/go/v4/auth"
+)
+
+// FirebaseVerifier verifies Firebase ID tokens. Roles come from the "role"
+// and "roles" custom claims. Display names are not in the token; wrap the
+// verifier with WithProfiles to fill them.
+type FirebaseVerifier struct {
+	client *auth.Client
+}
+
+func NewFirebaseVerifier(client *auth.Client) *FirebaseVerifier {
+	return &FirebaseVerifier{client: client}
+}
+
+func (v *FirebaseVerifier) Verify(ctx context.Context, rawToken string) (*bootstrap.User, error) {
+	token, err := v.client.VerifyIDToken(ctx, rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	email, ok := token.Claims["email"].(string)
+	if !ok {
+		return nil, ErrMissingEmail
+	}
+
+	return &bootstrap.User{
+		UserID: token.UID,
+		Email:  email,
+		Roles:  rolesFromClaims(token.Claims),
+	}, nil
+}
+
+// lookupName reads the display name from the Firebase account record. It is
+// a network call, so WithProfiles only makes it for users the database has
+// not seen.
+func (v *FirebaseVerifier) lookupName(ctx context.Context, uid string) (string, error) {
+	record, err := v.client.GetUser(ctx, uid)
+	if err != nil {
+		return "", err
+	}
+	return record.DisplayName, nil
+}
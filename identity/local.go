@@ -0,0 +1,100 @@
+package identity
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"miltechserver/bootstrap"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultLocalIssuer is the iss claim used when LocalConfig.Issuer is empty.
+const DefaultLocalIssuer = "miltechserver-local"
+
+// LocalConfig configures a LocalIssuer. Exactly one of HMACSecret or
+// RSAPrivateKey must be set.
+type LocalConfig struct {
+	Issuer        string
+	HMACSecret    []byte
+	RSAPrivateKey *rsa.PrivateKey
+}
+
+// LocalIssuer mints and verifies its own tokens. It lets development servers
+// and tests authenticate without Firebase or an external IdP and must not be
+// enabled in production.
+type LocalIssuer struct {
+	issuer    string
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+	parser    *jwt.Parser
+}
+
+func NewLocalIssuer(cfg LocalConfig) (*LocalIssuer, error) {
+	issuer := cfg.Issuer
+	if issuer == "" {
+		issuer = DefaultLocalIssuer
+	}
+
+	local := &LocalIssuer{issuer: issuer}
+	switch {
+	case len(cfg.HMACSecret) > 0 && cfg.RSAPrivateKey != nil:
+		return nil, errors.New("local issuer takes an HMAC secret or an RSA key, not both")
+	case len(cfg.HMACSecret) > 0:
+		local.method = jwt.SigningMethodHS256
+		local.signKey = cfg.HMACSecret
+		local.verifyKey = cfg.HMACSecret
+	case cfg.RSAPrivateKey != nil:
+		local.method = jwt.SigningMethodRS256
+		local.signKey = cfg.RSAPrivateKey
+		local.verifyKey = &cfg.RSAPrivateKey.PublicKey
+	default:
+		return nil, errors.New("local issuer requires an HMAC secret or an RSA key")
+	}
+
+	local.parser = jwt.NewParser(
+		jwt.WithValidMethods([]string{local.method.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	return local, nil
+}
+
+// Issue mints a token for user that expires after ttl.
+func (i *LocalIssuer) Issue(user bootstrap.User, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   i.issuer,
+		"sub":   user.UserID,
+		"email": user.Email,
+		"iat":   now.Unix(),
+		"exp":   now.Add(ttl).Unix(),
+	}
+	if user.Username != "" {
+		claims["name"] = user.Username
+	}
+	if len(user.Roles) > 0 {
+		claims["roles"] = user.Roles
+	}
+
+	token, err := jwt.NewWithClaims(i.method, claims).SignedString(i.signKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign local token: %w", err)
+	}
+	return token, nil
+}
+
+func (i *LocalIssuer) Verify(_ context.Context, rawToken string) (*bootstrap.User, error) {
+	claims := jwt.MapClaims{}
+	_, err := i.parser.ParseWithClaims(rawToken, claims, func(*jwt.Token) (any, error) {
+		return i.verifyKey, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return userFromClaims(claims)
+}
@@ -0,0 +1,73 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"miltechserver/bootstrap"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalIssuerRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	configs := map[string]LocalConfig{
+		"hmac": {HMACSecret: []byte("test-secret")},
+		"rsa":  {RSAPrivateKey: rsaKey},
+	}
+
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			issuer, err := NewLocalIssuer(cfg)
+			require.NoError(t, err)
+
+			want := bootstrap.User{UserID: "user-1", Email: "a@example.com", Username: "Alpha", Roles: []string{bootstrap.RoleModerator}}
+			token, err := issuer.Issue(want, time.Minute)
+			require.NoError(t, err)
+
+			user, err := issuer.Verify(context.Background(), token)
+			require.NoError(t, err)
+			require.Equal(t, &want, user)
+		})
+	}
+}
+
+func TestLocalIssuerRejectsBadTokens(t *testing.T) {
+	issuer, err := NewLocalIssuer(LocalConfig{HMACSecret: []byte("test-secret")})
+	require.NoError(t, err)
+	other, err := NewLocalIssuer(LocalConfig{HMACSecret: []byte("other-secret")})
+	require.NoError(t, err)
+
+	expired, err := issuer.Issue(bootstrap.User{UserID: "user-1", Email: "a@example.com"}, -time.Minute)
+	require.NoError(t, err)
+	_, err = issuer.Verify(context.Background(), expired)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	forged, err := other.Issue(bootstrap.User{UserID: "user-1", Email: "a@example.com"}, time.Minute)
+	require.NoError(t, err)
+	_, err = issuer.Verify(context.Background(), forged)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	noEmail, err := issuer.Issue(bootstrap.User{UserID: "user-1"}, time.Minute)
+	require.NoError(t, err)
+	_, err = issuer.Verify(context.Background(), noEmail)
+	require.ErrorIs(t, err, ErrMissingEmail)
+
+	_, err = issuer.Verify(context.Background(), "not-a-token")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestNewLocalIssuerRequiresOneKey(t *testing.T) {
+	_, err := NewLocalIssuer(LocalConfig{})
+	require.Error(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = NewLocalIssuer(LocalConfig{HMACSecret: []byte("x"), RSAPrivateKey: rsaKey})
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"miltechserver/bootstrap"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDCConfig configures an OIDCVerifier.
+type OIDCConfig struct {
+	// Issuer must match the token's iss claim. It is also used to discover
+	// the JWKS when JWKSURL is empty.
+	Issuer string
+	// Audience must appear in the token's aud claim. It is required: the
+	// verifier trusts the role claims of the tokens it accepts, so it must not
+	// accept tokens the issuer minted for other clients.
+	Audience string
+	// JWKSURL overrides discovery through /.well-known/openid-configuration.
+	JWKSURL string
+	// HTTPClient fetches discovery and JWKS documents. Defaults to a client
+	// with a 10 second timeout.
+	HTTPClient *http.Client
+}
+
+// OIDCVerifier verifies JWTs from any OpenID Connect provider, such as an
+// enterprise or CAC-backed IdP, using the provider's published signing keys.
+type OIDCVerifier struct {
+	keys   *jwksCache
+	parser *jwt.Parser
+}
+
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+func NewOIDCVerifier(cfg OIDCConfig) (*OIDCVerifier, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("OIDC issuer is required")
+	}
+	if cfg.Audience == "" {
+		return nil, errors.New("OIDC audience is required")
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+		jwt.WithAudience(cfg.Audience),
+	}
+
+	return &OIDCVerifier{
+		keys: &jwksCache{
+			issuer:     cfg.Issuer,
+			url:        cfg.JWKSURL,
+			client:     client,
+			ttl:        time.Hour,
+			minRefresh: time.Minute,
+		},
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+func (v *OIDCVerifier) Verify(ctx context.Context, rawToken string) (*bootstrap.User, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return userFromClaims(claims)
+}
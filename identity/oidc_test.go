@@ -0,0 +1,144 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type testIdP struct {
+	server     *httptest.Server
+	keys       map[string]*rsa.PrivateKey
+	jwksHits   atomic.Int32
+	publishKid atomic.Value
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+
+	idp := &testIdP{keys: map[string]*rsa.PrivateKey{}}
+	for _, kid := range []string{"key-1", "key-2"} {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		idp.keys[kid] = key
+	}
+	idp.publishKid.Store("key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   idp.server.URL,
+			"jwks_uri": idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.jwksHits.Add(1)
+		kid := idp.publishKid.Load().(string)
+		key := idp.keys[kid]
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *testIdP) token(t *testing.T, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(idp.keys[kid])
+	require.NoError(t, err)
+	return signed
+}
+
+func (idp *testIdP) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                idp.server.URL,
+		"aud":                "miltech",
+		"sub":                "cac-1234",
+		"email":              "soldier@example.mil",
+		"preferred_username": "SGT Example",
+		"exp":                time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+func TestOIDCVerifierDiscoversKeysAndMapsClaims(t *testing.T) {
+	idp := newTestIdP(t)
+	verifier, err := NewOIDCVerifier(OIDCConfig{Issuer: idp.server.URL, Audience: "miltech"})
+	require.NoError(t, err)
+
+	user, err := verifier.Verify(context.Background(), idp.token(t, "key-1", idp.claims()))
+	require.NoError(t, err)
+	require.Equal(t, "cac-1234", user.UserID)
+	require.Equal(t, "soldier@example.mil", user.Email)
+	require.Equal(t, "SGT Example", user.Username)
+
+	// Keys are cached between requests.
+	_, err = verifier.Verify(context.Background(), idp.token(t, "key-1", idp.claims()))
+	require.NoError(t, err)
+	require.Equal(t, int32(1), idp.jwksHits.Load())
+}
+
+func TestOIDCVerifierRejectsWrongAudienceAndIssuer(t *testing.T) {
+	idp := newTestIdP(t)
+	verifier, err := NewOIDCVerifier(OIDCConfig{Issuer: idp.server.URL, Audience: "miltech"})
+	require.NoError(t, err)
+
+	claims := idp.claims()
+	claims["aud"] = "someone-else"
+	_, err = verifier.Verify(context.Background(), idp.token(t, "key-1", claims))
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	claims = idp.claims()
+	claims["iss"] = "https://evil.example.com"
+	_, err = verifier.Verify(context.Background(), idp.token(t, "key-1", claims))
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestOIDCVerifierRequiresIssuerAndAudience(t *testing.T) {
+	_, err := NewOIDCVerifier(OIDCConfig{Audience: "miltech"})
+	require.ErrorContains(t, err, "issuer is required")
+
+	_, err = NewOIDCVerifier(OIDCConfig{Issuer: "https://idp.example.com"})
+	require.ErrorContains(t, err, "audience is required")
+}
+
+func TestOIDCVerifierRefetchesOnKeyRotation(t *testing.T) {
+	idp := newTestIdP(t)
+	verifier, err := NewOIDCVerifier(OIDCConfig{Issuer: idp.server.URL, Audience: "miltech", JWKSURL: idp.server.URL + "/jwks"})
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), idp.token(t, "key-1", idp.claims()))
+	require.NoError(t, err)
+
+	// A new kid inside the refresh window is rejected without refetching.
+	idp.publishKid.Store("key-2")
+	_, err = verifier.Verify(context.Background(), idp.token(t, "key-2", idp.claims()))
+	require.ErrorIs(t, err, ErrUnknownKey)
+	require.Equal(t, int32(1), idp.jwksHits.Load())
+
+	// Once the window has passed, an unknown kid triggers a refetch.
+	verifier.keys.fetchedAt = time.Now().Add(-2 * time.Minute)
+	_, err = verifier.Verify(context.Background(), idp.token(t, "key-2", idp.claims()))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), idp.jwksHits.Load())
+}
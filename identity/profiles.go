@@ -0,0 +1,81 @@
+package identity
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"miltechserver/cache"
+
+	"github.com/lib/pq"
+)
+
+// Profile is what the database knows about a user beyond their token.
+// Found is false when the user has no users row yet, e.g. before their
+// first /user/general/refresh.
+type Profile struct {
+	DisplayName string
+	Roles       []string
+	Found       bool
+}
+
+// ProfileSource resolves stored profiles by user ID.
+type ProfileSource interface {
+	Profile(ctx context.Context, uid string) (Profile, error)
+}
+
+// profileCacheCapacity bounds the profiles kept per replica; the least
+// recently seen users are dropped first.
+const profileCacheCapacity = 50_000
+
+// ProfileCache reads display names from users and roles from user_roles and
+// keeps them for ttl. Writers of either table must call Invalidate. Other
+// replicas keep their copy until it expires, so ttl bounds how stale a
+// renamed user or a role change can be.
+type ProfileCache struct {
+	db       *sql.DB
+	profiles *cache.Cache[string, Profile]
+}
+
+func NewProfileCache(db *sql.DB, ttl time.Duration) *ProfileCache {
+	return &ProfileCache{
+		db: db,
+		profiles: cache.New[string, Profile](cache.Options{
+			Name:     "user_profiles",
+			Capacity: profileCacheCapacity,
+			TTL:      ttl,
+		}),
+	}
+}
+
+const profileQuery = `
+SELECT u.username,
+       COALESCE(array_agg(r.role ORDER BY r.role) FILTER (WHERE r.role IS NOT NULL), '{}')
+FROM users u
+LEFT JOIN user_roles r ON r.user_id = u.uid
+WHERE u.uid = $1
+GROUP BY u.uid, u.username`
+
+func (c *ProfileCache) Profile(ctx context.Context, uid string) (Profile, error) {
+	return c.profiles.GetOrLoad(ctx, uid, func(ctx context.Context) (Profile, error) {
+		var name sql.NullString
+		var roles pq.StringArray
+		err := c.db.QueryRowContext(ctx, profileQuery, uid).Scan(&name, &roles)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Profile{}, nil
+		} else if err != nil {
+			return Profile{}, fmt.Errorf("failed to read user profile: %w", err)
+		}
+		return Profile{DisplayName: name.String, Roles: roles, Found: true}, nil
+	})
+}
+
+// Invalidate drops the cached profile for uid. Safe to call on a nil cache.
+func (c *ProfileCache) Invalidate(uid string) {
+	if c == nil {
+		return
+	}
+	c.profiles.Invalidate(uid)
+}
@@ -0,0 +1,105 @@
+package identity
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"miltechserver/bootstrap"
+	"miltechserver/sqltest"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testUser struct {
+	name  string
+	roles string // Postgres array literal, e.g. "{moderator}"
+}
+
+// usersTable answers the profile lookup from memory and counts queries.
+type usersTable struct {
+	users   map[string]testUser
+	queries atomic.Int32
+}
+
+func newTestProfileCache(t *testing.T, users map[string]testUser) (*ProfileCache, *usersTable) {
+	t.Helper()
+	table := &usersTable{users: users}
+	db, _ := sqltest.Open(t, func(_ string, args []driver.Value) (sqltest.Rows, error) {
+		table.queries.Add(1)
+		user, ok := table.users[args[0].(string)]
+		if !ok {
+			return nil, nil
+		}
+		return sqltest.Rows{{user.name, user.roles}}, nil
+	})
+	return NewProfileCache(db, time.Hour), table
+}
+
+func TestProfileCacheCachesAndInvalidates(t *testing.T) {
+	ctx := context.Background()
+	cache, d := newTestProfileCache(t, map[string]testUser{"uid-1": {name: "Alpha", roles: "{}"}})
+
+	profile, err := cache.Profile(ctx, "uid-1")
+	require.NoError(t, err)
+	require.Equal(t, Profile{DisplayName: "Alpha", Roles: []string{}, Found: true}, profile)
+
+	_, err = cache.Profile(ctx, "uid-1")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), d.queries.Load())
+
+	d.users["uid-1"] = testUser{name: "Bravo", roles: "{moderator}"}
+	cache.Invalidate("uid-1")
+	profile, err = cache.Profile(ctx, "uid-1")
+	require.NoError(t, err)
+	require.Equal(t, "Bravo", profile.DisplayName)
+	require.Equal(t, []string{"moderator"}, profile.Roles)
+	require.Equal(t, int32(2), d.queries.Load())
+}
+
+func TestProfileCacheRemembersUnknownUsers(t *testing.T) {
+	ctx := context.Background()
+	cache, d := newTestProfileCache(t, map[string]testUser{})
+
+	profile, err := cache.Profile(ctx, "new-user")
+	require.NoError(t, err)
+	require.False(t, profile.Found)
+
+	profile, err = cache.Profile(ctx, "new-user")
+	require.NoError(t, err)
+	require.False(t, profile.Found)
+	require.Equal(t, int32(1), d.queries.Load())
+
+	var nilCache *ProfileCache
+	nilCache.Invalidate("new-user")
+}
+
+type stubProfiles map[string]Profile
+
+func (s stubProfiles) Profile(_ context.Context, uid string) (Profile, error) {
+	return s[uid], nil
+}
+
+func TestWithProfilesMergesStoredNameAndRoles(t *testing.T) {
+	issuer, err := NewLocalIssuer(LocalConfig{HMACSecret: []byte("test-secret")})
+	require.NoError(t, err)
+	verifier := WithProfiles(issuer, stubProfiles{
+		"known": {DisplayName: "Renamed", Roles: []string{bootstrap.RoleSiteAdmin}, Found: true},
+	})
+
+	token, err := issuer.Issue(bootstrap.User{UserID: "known", Email: "a@example.com", Username: "Token Name", Roles: []string{bootstrap.RoleModerator}}, time.Minute)
+	require.NoError(t, err)
+	user, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	require.Equal(t, "Renamed", user.Username)
+	require.Equal(t, []string{bootstrap.RoleModerator, bootstrap.RoleSiteAdmin}, user.Roles)
+
+	token, err = issuer.Issue(bootstrap.User{UserID: "unknown", Email: "b@example.com", Username: "Token Name"}, time.Minute)
+	require.NoError(t, err)
+	user, err = verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	require.Equal(t, "Token Name", user.Username)
+	require.Empty(t, user.Roles)
+}
@@ -0,0 +1,24 @@
+// Package identity verifies bearer tokens and maps them onto bootstrap.User.
+// The server can trust Firebase, any OIDC provider that publishes a JWKS, or
+// a local issuer used for development and tests; handlers never see which.
+package identity
+
+import (
+	"context"
+	"errors"
+
+	"miltechserver/bootstrap"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrMissingEmail = errors.New("email not found in token")
+	ErrUnknownKey   = errors.New("token signed with unknown key")
+)
+
+// TokenVerifier validates a raw bearer token and returns the user it names.
+// Implementations return an error wrapping ErrInvalidToken or ErrMissingEmail
+// when the token must be rejected.
+type TokenVerifier interface {
+	Verify(ctx context.Context, rawToken string) (*bootstrap.User, error)
+}
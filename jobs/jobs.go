@@ -0,0 +1,72 @@
+// Package jobs runs scheduled background work. Packages register Jobs with a
+// cron Schedule on the Scheduler during startup; every replica runs a
+// Scheduler, but only the one holding the leader advisory lock fires
+// schedules. Each run, scheduled or triggered by an admin, also holds a
+// per-job advisory lock so a job never runs twice at once, and is recorded
+// in the job_runs table.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnknownJob = errors.New("unknown job")
+	ErrJobRunning = errors.New("job is already running")
+	ErrStopped    = errors.New("job scheduler is stopping")
+)
+
+// defaultTimeout bounds a run when the Job sets no Timeout.
+const defaultTimeout = time.Hour
+
+// Job is a unit of scheduled work. Run should be safe to repeat: a run that
+// is interrupted by a shutdown is not retried until the next schedule.
+type Job struct {
+	// Name identifies the job in the registry, the admin API and job_runs.
+	Name string
+	// Schedule is a cron expression, see ParseSchedule.
+	Schedule string
+	// Timeout bounds one run; zero means an hour.
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+// Run triggers.
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// Run statuses.
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	// StatusAbandoned is a run whose replica stopped before recording its
+	// outcome.
+	StatusAbandoned = "abandoned"
+)
+
+// Run is one execution of a job as recorded in job_runs.
+type Run struct {
+	ID          int64      `json:"id"`
+	JobName     string     `json:"job_name"`
+	Trigger     string     `json:"trigger"`
+	TriggeredBy *string    `json:"triggered_by"`
+	Instance    string     `json:"instance"`
+	Status      string     `json:"status"`
+	Error       *string    `json:"error"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	DurationMS  *int64     `json:"duration_ms"`
+}
+
+// Info describes a registered job.
+type Info struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+	// NextRun is when the schedule next fires, whichever replica runs it.
+	NextRun time.Time `json:"next_run_at"`
+}
@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Advisory locks are session locks keyed by hashtext of these names, held
+// on a dedicated connection for as long as the lock is needed.
+const leaderLockKey = "jobs:leader"
+
+func runLockKey(name string) string {
+	return "jobs:run:" + name
+}
+
+// lockTimeout bounds lock and bookkeeping queries.
+const lockTimeout = 10 * time.Second
+
+// tryLock takes the advisory lock key on a connection of its own without
+// waiting. The caller owns the returned connection and must release it with
+// unlock.
+func tryLock(ctx context.Context, db *sql.DB, key string) (*sql.Conn, bool, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get connection for lock %s: %w", key, err)
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, key).Scan(&locked)
+	if err != nil {
+		discard(conn)
+		return nil, false, fmt.Errorf("failed to take lock %s: %w", key, err)
+	}
+	if !locked {
+		conn.Close()
+		return nil, false, nil
+	}
+	return conn, true, nil
+}
+
+// unlock releases key and returns the connection to the pool. If the lock
+// can't be released the connection is discarded instead, which ends the
+// session and releases the lock with it.
+func unlock(conn *sql.Conn, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock(hashtext($1))`, key); err != nil {
+		slog.Warn("Failed to release advisory lock, closing its connection", "lock", key, "error", err)
+		discard(conn)
+		return
+	}
+	conn.Close()
+}
+
+// discard closes conn's session rather than returning it to the pool.
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	conn.Close()
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// PruneRuns returns the job that deletes job_runs rows started more than
+// retention ago.
+func PruneRuns(db *sql.DB, retention time.Duration) Job {
+	return Job{
+		Name:     "job-runs-prune",
+		Schedule: "30 3 * * *",
+		Run: func(ctx context.Context) error {
+			result, err := db.ExecContext(ctx,
+				`DELETE FROM job_runs WHERE started_at < $1 AND status <> 'running'`,
+				time.Now().Add(-retention).UTC())
+			if err != nil {
+				return fmt.Errorf("failed to prune job runs: %w", err)
+			}
+			pruned, _ := result.RowsAffected()
+			slog.Info("Pruned job runs", "deleted", pruned, "retention", retention)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+		"@yearly",
+	} {
+		_, err := ParseSchedule(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02 15:04", value)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	tests := []struct {
+		expr  string
+		after string
+		want  string
+	}{
+		{"* * * * *", "2026-10-17 10:07", "2026-10-17 10:08"},
+		{"*/15 * * * *", "2026-10-17 10:07", "2026-10-17 10:15"},
+		{"5/20 * * * *", "2026-10-17 10:07", "2026-10-17 10:25"},
+		{"0,30 9-17 * * *", "2026-10-17 17:30", "2026-10-18 09:00"},
+		{"30 3 * * *", "2026-10-17 03:30", "2026-10-18 03:30"},
+		{"@daily", "2026-12-31 23:59", "2027-01-01 00:00"},
+		{"@monthly", "2026-01-31 12:00", "2026-02-01 00:00"},
+		// 2026-10-17 is a Saturday
+		{"0 12 * * 1-5", "2026-10-17 08:00", "2026-10-19 12:00"},
+		{"@weekly", "2026-10-17 08:00", "2026-10-18 00:00"},
+		{"0 0 * * 7", "2026-10-17 08:00", "2026-10-18 00:00"},
+		// With both day fields restricted either may match: the 13th or a Friday
+		{"0 0 13 * 5", "2026-10-17 08:00", "2026-10-23 00:00"},
+		{"0 0 13 * 5", "2026-11-07 08:00", "2026-11-13 00:00"},
+		{"0 0 29 2 *", "2026-03-01 00:00", "2028-02-29 00:00"},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.expr)
+		require.NoError(t, err, tt.expr)
+		require.Equal(t, at(tt.want), schedule.Next(at(tt.after)), "%s after %s", tt.expr, tt.after)
+	}
+}
+
+func TestScheduleNextNeverMatches(t *testing.T) {
+	schedule, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	require.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestScheduleNextUsesUTC(t *testing.T) {
+	schedule, err := ParseSchedule("0 3 * * *")
+	require.NoError(t, err)
+
+	eastern := time.FixedZone("EST", -5*60*60)
+	next := schedule.Next(time.Date(2026, 10, 16, 21, 0, 0, 0, eastern))
+	require.Equal(t, time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC), next)
+}
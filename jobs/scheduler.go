@@ -0,0 +1,401 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type entry struct {
+	job      Job
+	schedule Schedule
+	next     time.Time
+}
+
+// Scheduler holds the registered jobs and fires their schedules while this
+// replica is the leader. Its methods are safe to call on a nil Scheduler,
+// which has no jobs.
+type Scheduler struct {
+	db       *sql.DB
+	instance string
+	now      func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]*entry
+	stopping bool
+
+	// leader is the connection holding the leader lock; only the loop
+	// goroutine touches it.
+	leader   *sql.Conn
+	isLeader atomic.Bool
+
+	// ctx is the parent of every run and is cancelled by Stop.
+	ctx    context.Context
+	cancel context.CancelFunc
+	runs   sync.WaitGroup
+
+	started  atomic.Bool
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a scheduler that records runs in db. It fires nothing until
+// Start is called, but jobs can be triggered before that.
+func New(db *sql.DB) *Scheduler {
+	instance, err := os.Hostname()
+	if err != nil {
+		instance = "unknown"
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		db:       db,
+		instance: instance,
+		now:      time.Now,
+		entries:  map[string]*entry{},
+		ctx:      ctx,
+		cancel:   cancel,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Register adds job. A missing name or Run, a taken name and an invalid
+// schedule are programming errors, so Register panics on them rather than
+// starting without the job. Calling Register on a nil Scheduler is a no-op.
+func (s *Scheduler) Register(job Job) {
+	if s == nil {
+		return
+	}
+	if job.Name == "" || job.Run == nil {
+		panic("jobs: a job needs a Name and a Run function")
+	}
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		panic(fmt.Sprintf("jobs: %s: %s", job.Name, err))
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, taken := s.entries[job.Name]; taken {
+		panic(fmt.Sprintf("jobs: %s registered twice", job.Name))
+	}
+	s.entries[job.Name] = &entry{job: job, schedule: schedule, next: schedule.Next(s.now())}
+}
+
+// Jobs lists the registered jobs by name.
+func (s *Scheduler) Jobs() []Info {
+	if s == nil {
+		return nil
+	}
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos := make([]Info, 0, len(s.entries))
+	for _, e := range s.entries {
+		infos = append(infos, Info{Name: e.job.Name, Schedule: e.job.Schedule, NextRun: e.schedule.Next(now)})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// Leader reports whether this replica currently fires schedules.
+func (s *Scheduler) Leader() bool {
+	return s != nil && s.isLeader.Load()
+}
+
+// Start runs the scheduling loop in the background until Stop is called.
+// Every electEvery the replica confirms it still holds the leader lock, or
+// tries to take it if no replica does.
+func (s *Scheduler) Start(electEvery time.Duration) {
+	if s == nil {
+		return
+	}
+	s.started.Store(true)
+	go s.loop(electEvery)
+}
+
+func (s *Scheduler) loop(electEvery time.Duration) {
+	defer close(s.done)
+	defer s.resign()
+
+	var nextElection time.Time
+	for {
+		now := s.now()
+		if !now.Before(nextElection) {
+			s.elect()
+			nextElection = now.Add(electEvery)
+		}
+
+		wake := nextElection
+		if s.isLeader.Load() {
+			if due := s.fireDue(now); !due.IsZero() && due.Before(wake) {
+				wake = due
+			}
+		}
+
+		timer := time.NewTimer(wake.Sub(s.now()))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// elect confirms this replica's leadership or tries to take it.
+func (s *Scheduler) elect() {
+	ctx, cancel := context.WithTimeout(s.ctx, lockTimeout)
+	defer cancel()
+
+	if s.leader != nil {
+		_, err := s.leader.ExecContext(ctx, `SELECT 1`)
+		if err == nil {
+			return
+		}
+		// The lock went with the session, so another replica may lead now.
+		slog.Warn("Lost job scheduler leadership", "instance", s.instance, "error", err)
+		discard(s.leader)
+		s.leader = nil
+		s.isLeader.Store(false)
+		return
+	}
+
+	conn, ok, err := tryLock(ctx, s.db, leaderLockKey)
+	if err != nil {
+		slog.Warn("Failed to run job scheduler election", "error", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	s.leader = conn
+	s.isLeader.Store(true)
+	slog.Info("Became job scheduler leader", "instance", s.instance)
+
+	// Schedules that passed while no replica led are not made up.
+	now := s.now()
+	s.mu.Lock()
+	for _, e := range s.entries {
+		e.next = e.schedule.Next(now)
+	}
+	s.mu.Unlock()
+}
+
+// resign releases the leader lock so another replica can take over without
+// waiting for this session to close.
+func (s *Scheduler) resign() {
+	if s.leader == nil {
+		return
+	}
+	unlock(s.leader, leaderLockKey)
+	s.leader = nil
+	s.isLeader.Store(false)
+	slog.Info("Resigned job scheduler leadership", "instance", s.instance)
+}
+
+// fireDue starts every job whose schedule has come up and returns the
+// earliest time a job is due next, or the zero time if there are no jobs.
+func (s *Scheduler) fireDue(now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var earliest time.Time
+	for _, e := range s.entries {
+		if !now.Before(e.next) && !s.stopping {
+			s.runs.Add(1)
+			go func(job Job) {
+				defer s.runs.Done()
+				s.runScheduled(job)
+			}(e.job)
+			e.next = e.schedule.Next(now)
+		}
+		if earliest.IsZero() || (!e.next.IsZero() && e.next.Before(earliest)) {
+			earliest = e.next
+		}
+	}
+	return earliest
+}
+
+func (s *Scheduler) runScheduled(job Job) {
+	ctx, cancel := context.WithTimeout(s.ctx, lockTimeout)
+	claimed, err := s.claim(ctx, job, TriggerSchedule, "")
+	cancel()
+	if errors.Is(err, ErrJobRunning) {
+		slog.Info("Skipping scheduled job, the previous run has not finished", "job", job.Name)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to start scheduled job", "job", job.Name, "error", err)
+		return
+	}
+	s.execute(job, claimed)
+}
+
+// Trigger starts a run of the named job now, on this replica, whether or not
+// it leads. It returns once the run is recorded; the job itself runs in the
+// background. triggeredBy is recorded with the run.
+func (s *Scheduler) Trigger(ctx context.Context, name string, triggeredBy string) (Run, error) {
+	if s == nil {
+		return Run{}, ErrUnknownJob
+	}
+	s.mu.Lock()
+	e, ok := s.entries[name]
+	stopping := s.stopping
+	if ok && !stopping {
+		s.runs.Add(1)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return Run{}, ErrUnknownJob
+	}
+	if stopping {
+		return Run{}, ErrStopped
+	}
+
+	claimed, err := s.claim(ctx, e.job, TriggerManual, triggeredBy)
+	if err != nil {
+		s.runs.Done()
+		return Run{}, err
+	}
+	go func() {
+		defer s.runs.Done()
+		s.execute(e.job, claimed)
+	}()
+	return claimed.run, nil
+}
+
+// claimedRun is a recorded run that holds its job's lock.
+type claimedRun struct {
+	conn *sql.Conn
+	run  Run
+}
+
+// claim takes the job's lock and records the start of a run. It returns
+// ErrJobRunning if a run of the job holds the lock on any replica.
+func (s *Scheduler) claim(ctx context.Context, job Job, trigger string, triggeredBy string) (*claimedRun, error) {
+	key := runLockKey(job.Name)
+	conn, ok, err := tryLock(ctx, s.db, key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrJobRunning
+	}
+
+	run := Run{JobName: job.Name, Trigger: trigger, Instance: s.instance, Status: StatusRunning}
+	if triggeredBy != "" {
+		run.TriggeredBy = &triggeredBy
+	}
+
+	// Holding the lock means no run of this job is in progress anywhere, so a
+	// run still marked running died with its replica.
+	_, err = conn.ExecContext(ctx, `
+		UPDATE job_runs SET status = 'abandoned', finished_at = now()
+		WHERE job_name = $1 AND status = 'running'`, job.Name)
+	if err == nil {
+		err = conn.QueryRowContext(ctx, `
+			INSERT INTO job_runs (job_name, trigger, triggered_by, instance, status)
+			VALUES ($1, $2, $3, $4, 'running')
+			RETURNING id, started_at`,
+			run.JobName, run.Trigger, run.TriggeredBy, run.Instance).Scan(&run.ID, &run.StartedAt)
+	}
+	if err != nil {
+		unlock(conn, key)
+		return nil, fmt.Errorf("failed to record run of %s: %w", job.Name, err)
+	}
+	return &claimedRun{conn: conn, run: run}, nil
+}
+
+// execute runs the job, records its outcome and releases its lock.
+func (s *Scheduler) execute(job Job, claimed *claimedRun) {
+	defer unlock(claimed.conn, runLockKey(job.Name))
+
+	logger := slog.With("job", job.Name, "run_id", claimed.run.ID, "trigger", claimed.run.Trigger)
+	logger.Info("Running job")
+
+	ctx, cancel := context.WithTimeout(s.ctx, job.Timeout)
+	start := time.Now()
+	err := runSafely(ctx, job.Run)
+	duration := time.Since(start)
+	cancel()
+
+	status := StatusSucceeded
+	var errText *string
+	if err != nil {
+		status = StatusFailed
+		message := err.Error()
+		errText = &message
+		logger.Error("Job failed", "duration", duration, "error", err)
+	} else {
+		logger.Info("Job finished", "duration", duration)
+	}
+
+	recordCtx, cancelRecord := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancelRecord()
+	_, err = claimed.conn.ExecContext(recordCtx, `
+		UPDATE job_runs SET status = $2, error = $3, finished_at = now(), duration_ms = $4
+		WHERE id = $1`,
+		claimed.run.ID, status, errText, duration.Milliseconds())
+	if err != nil {
+		logger.Error("Failed to record job outcome", "status", status, "error", err)
+	}
+}
+
+// runSafely turns a panicking job into a failed run instead of a crash.
+func runSafely(ctx context.Context, run func(context.Context) error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return run(ctx)
+}
+
+// Stop stops firing schedules, gives up leadership, cancels the runs in
+// progress on this replica and waits for them to record their outcome. Safe
+// to call more than once, on a nil Scheduler and on one never started.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.stopping = true
+		s.mu.Unlock()
+		close(s.stop)
+	})
+
+	if s.started.Load() {
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	s.cancel()
+	finished := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
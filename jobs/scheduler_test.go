@@ -0,0 +1,272 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"miltechserver/sqltest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRun is a job_runs row.
+type fakeRun struct {
+	job, trigger, status, err string
+	triggeredBy               any
+}
+
+// jobsTable answers the scheduler's advisory lock and job_runs statements
+// from memory.
+type jobsTable struct {
+	mu    sync.Mutex
+	locks map[string]bool
+	runs  []*fakeRun
+}
+
+func (d *jobsTable) held(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.locks[key]
+}
+
+func (d *jobsTable) run(i int) fakeRun {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return *d.runs[i]
+}
+
+func (d *jobsTable) handle(query string, args []driver.Value) (sqltest.Rows, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch {
+	case strings.Contains(query, "pg_try_advisory_lock"):
+		key := args[0].(string)
+		locked := !d.locks[key]
+		d.locks[key] = true
+		return sqltest.Rows{{locked}}, nil
+	case strings.Contains(query, "pg_advisory_unlock"):
+		delete(d.locks, args[0].(string))
+	case strings.Contains(query, "INSERT INTO job_runs"):
+		d.runs = append(d.runs, &fakeRun{
+			job:         args[0].(string),
+			trigger:     args[1].(string),
+			triggeredBy: args[2],
+			status:      StatusRunning,
+		})
+		return sqltest.Rows{{int64(len(d.runs)), time.Now()}}, nil
+	case strings.Contains(query, "'abandoned'"):
+		for _, run := range d.runs {
+			if run.job == args[0] && run.status == StatusRunning {
+				run.status = StatusAbandoned
+			}
+		}
+	case strings.Contains(query, "UPDATE job_runs"):
+		run := d.runs[args[0].(int64)-1]
+		run.status = args[1].(string)
+		if args[2] != nil {
+			run.err = args[2].(string)
+		}
+	default:
+		return nil, errors.New("unexpected statement: " + query)
+	}
+	return nil, nil
+}
+
+func newTestDB(t *testing.T) (*sql.DB, *jobsTable) {
+	t.Helper()
+	d := &jobsTable{locks: map[string]bool{}}
+	db, _ := sqltest.Open(t, d.handle)
+	return db, d
+}
+
+func stop(t *testing.T, s *Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, s.Stop(ctx))
+}
+
+func TestTriggerRecordsRun(t *testing.T) {
+	db, d := newTestDB(t)
+	s := New(db)
+	ran := make(chan struct{})
+	s.Register(Job{Name: "cleanup", Schedule: "@daily", Run: func(context.Context) error {
+		close(ran)
+		return nil
+	}})
+
+	run, err := s.Trigger(context.Background(), "cleanup", "admin-1")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), run.ID)
+	require.Equal(t, TriggerManual, run.Trigger)
+	require.Equal(t, "admin-1", *run.TriggeredBy)
+	require.Equal(t, StatusRunning, run.Status)
+
+	<-ran
+	stop(t, s)
+	require.Equal(t, StatusSucceeded, d.run(0).status)
+	require.False(t, d.held(runLockKey("cleanup")), "the run lock is released")
+}
+
+func TestTriggerRecordsFailures(t *testing.T) {
+	db, d := newTestDB(t)
+	s := New(db)
+	s.Register(Job{Name: "failing", Schedule: "@daily", Run: func(context.Context) error {
+		return errors.New("blob store unreachable")
+	}})
+	s.Register(Job{Name: "panicking", Schedule: "@daily", Run: func(context.Context) error {
+		panic("nil map")
+	}})
+
+	_, err := s.Trigger(context.Background(), "failing", "")
+	require.NoError(t, err)
+	_, err = s.Trigger(context.Background(), "panicking", "")
+	require.NoError(t, err)
+	stop(t, s)
+
+	failing, panicking := d.run(0), d.run(1)
+	if failing.job != "failing" {
+		failing, panicking = panicking, failing
+	}
+	require.Equal(t, StatusFailed, failing.status)
+	require.Equal(t, "blob store unreachable", failing.err)
+	require.Nil(t, failing.triggeredBy)
+	require.Equal(t, StatusFailed, panicking.status)
+	require.Equal(t, "panic: nil map", panicking.err)
+}
+
+func TestTriggerRejectsJobRunningElsewhere(t *testing.T) {
+	db, d := newTestDB(t)
+	s := New(db)
+	s.Register(Job{Name: "rollup", Schedule: "@daily", Run: func(context.Context) error { return nil }})
+	d.locks[runLockKey("rollup")] = true
+
+	_, err := s.Trigger(context.Background(), "rollup", "admin-1")
+	require.ErrorIs(t, err, ErrJobRunning)
+	require.Empty(t, d.runs)
+	stop(t, s)
+}
+
+func TestTriggerMarksAbandonedRuns(t *testing.T) {
+	db, d := newTestDB(t)
+	d.runs = append(d.runs, &fakeRun{job: "rollup", status: StatusRunning})
+	s := New(db)
+	s.Register(Job{Name: "rollup", Schedule: "@daily", Run: func(context.Context) error { return nil }})
+
+	_, err := s.Trigger(context.Background(), "rollup", "")
+	require.NoError(t, err)
+	stop(t, s)
+
+	require.Equal(t, StatusAbandoned, d.run(0).status)
+	require.Equal(t, StatusSucceeded, d.run(1).status)
+}
+
+func TestTriggerUnknownOrStopped(t *testing.T) {
+	db, _ := newTestDB(t)
+	s := New(db)
+	s.Register(Job{Name: "rollup", Schedule: "@daily", Run: func(context.Context) error { return nil }})
+
+	_, err := s.Trigger(context.Background(), "missing", "")
+	require.ErrorIs(t, err, ErrUnknownJob)
+
+	stop(t, s)
+	_, err = s.Trigger(context.Background(), "rollup", "")
+	require.ErrorIs(t, err, ErrStopped)
+}
+
+func TestStopCancelsRuns(t *testing.T) {
+	db, d := newTestDB(t)
+	s := New(db)
+	started := make(chan struct{})
+	s.Register(Job{Name: "slow", Schedule: "@daily", Run: func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	_, err := s.Trigger(context.Background(), "slow", "")
+	require.NoError(t, err)
+	<-started
+	stop(t, s)
+
+	require.Equal(t, StatusFailed, d.run(0).status)
+	require.Equal(t, context.Canceled.Error(), d.run(0).err)
+}
+
+func TestOnlyOneReplicaLeads(t *testing.T) {
+	db, d := newTestDB(t)
+	first, second := New(db), New(db)
+
+	first.elect()
+	second.elect()
+	require.True(t, first.Leader())
+	require.False(t, second.Leader())
+
+	// Leadership passes on once the leader resigns
+	first.resign()
+	require.False(t, d.held(leaderLockKey))
+	second.elect()
+	require.True(t, second.Leader())
+	second.resign()
+}
+
+func TestLeaderFiresDueJobs(t *testing.T) {
+	db, d := newTestDB(t)
+	now := time.Date(2026, 10, 17, 10, 7, 0, 0, time.UTC)
+	s := New(db)
+	s.now = func() time.Time { return now }
+
+	runs := make(chan string, 2)
+	for _, name := range []string{"hourly", "daily"} {
+		s.Register(Job{Name: name, Schedule: "@" + name, Run: func(context.Context) error {
+			runs <- name
+			return nil
+		}})
+	}
+
+	require.Equal(t, time.Date(2026, 10, 17, 11, 0, 0, 0, time.UTC), s.fireDue(now))
+	require.Empty(t, runs)
+
+	next := s.fireDue(time.Date(2026, 10, 17, 11, 0, 0, 0, time.UTC))
+	require.Equal(t, "hourly", <-runs)
+	require.Equal(t, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), next)
+	stop(t, s)
+	require.Equal(t, TriggerSchedule, d.run(0).trigger)
+	require.Equal(t, StatusSucceeded, d.run(0).status)
+}
+
+func TestRegisterRejectsInvalidJobs(t *testing.T) {
+	s := New(nil)
+	run := func(context.Context) error { return nil }
+	s.Register(Job{Name: "rollup", Schedule: "@daily", Run: run})
+
+	require.Panics(t, func() { s.Register(Job{Name: "rollup", Schedule: "@daily", Run: run}) })
+	require.Panics(t, func() { s.Register(Job{Name: "bad", Schedule: "daily", Run: run}) })
+	require.Panics(t, func() { s.Register(Job{Name: "empty", Schedule: "@daily"}) })
+	require.Equal(t, []string{"rollup"}, names(s.Jobs()))
+}
+
+func TestNilScheduler(t *testing.T) {
+	var s *Scheduler
+	s.Register(Job{Name: "rollup", Schedule: "@daily", Run: func(context.Context) error { return nil }})
+	s.Start(time.Second)
+	require.Empty(t, s.Jobs())
+	require.False(t, s.Leader())
+	_, err := s.Trigger(context.Background(), "rollup", "")
+	require.ErrorIs(t, err, ErrUnknownJob)
+	require.NoError(t, s.Stop(context.Background()))
+}
+
+func names(infos []Info) []string {
+	var out []string
+	for _, info := range infos {
+		out = append(out, info.Name)
+	}
+	return out
+}
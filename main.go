@@ -1,96 +1,58 @@
 package main
 
 import (
-	"context"
 	"fmt"
-	"log"
-	"miltechserver/api/route"
-	"miltechserver/bootstrap"
-	"miltechserver/helper"
 	"os"
-	"os/signal"
-	"syscall"
-
-	"github.com/gin-gonic/gin"
-	"github.com/go-jet/jet/v2/generator/metadata"
-	"github.com/go-jet/jet/v2/generator/postgres"
-	"github.com/go-jet/jet/v2/generator/template"
-	postgres2 "github.com/go-jet/jet/v2/postgres"
+	"sort"
 )
 
-func main() {
-	// Start the engine
-	engine := SetupEngine()
-	err := engine.Run(":8080")
-	helper.PanicOnError(err)
-
+// command is one top-level subcommand of the server binary.
+type command struct {
+	summary string
+	run     func(args []string)
 }
 
-func SetupEngine() *gin.Engine {
-	ctx := context.Background()
-	env := bootstrap.NewEnv()
-	generateSchema(env)
-	app := bootstrap.App(ctx, env)
-	db := app.Db
-
-	server := gin.Default()
-
-	route.Setup(db, server, app.FireAuth, env, app.BlobClient)
+var commands = map[string]command{
+	"serve":   {summary: "start the HTTP server (default)", run: runServe},
+	"gen":     {summary: "regenerate go-jet models in ./.gen from the live schema", run: runGen},
+	"migrate": {summary: "apply, roll back or list database migrations", run: runMigrate},
+	"import":  {summary: "run a data import task", run: runImport},
+	"admin":   {summary: "run an administrative task", run: runAdmin},
+}
 
-	// Cleanup server on crash or interrupt
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+func main() {
+	// No arguments keeps the historical behaviour of starting the server.
+	if len(os.Args) < 2 {
+		runServe(nil)
+		return
+	}
 
-	go func() {
-		<-c
-		if err := db.Close(); err != nil {
-			log.Fatalf("Unable to disconnect from database: %s", err)
-		}
-		log.Println("Disconnected from database")
-		os.Exit(1)
-	}()
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return
+	}
 
-	return server
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage()
+		os.Exit(2)
+	}
+	cmd.run(os.Args[2:])
 }
 
-func generateSchema(env *bootstrap.Env) {
-	err1 := postgres.Generate(
-		"./.gen",
-		postgres.DBConnection{
-			Host:       env.Host,
-			Port:       5432,
-			User:       env.Username,
-			Password:   env.Password,
-			SslMode:    env.SslMode,
-			DBName:     env.DBName,
-			SchemaName: env.DBSchema,
-		},
-		template.Default(postgres2.Dialect).
-			UseSchema(func(schema metadata.Schema) template.Schema {
-				return template.DefaultSchema(schema).
-					UseModel(template.DefaultModel().
-						UseTable(func(table metadata.Table) template.TableModel {
-							return template.DefaultTableModel(table).
-								UseField(func(columnMetaData metadata.Column) template.TableModelField {
-									defaultTableModelField := template.DefaultTableModelField(columnMetaData)
-									return defaultTableModelField.UseTags(
-										fmt.Sprintf(`json:"%s"`, columnMetaData.Name),
-									)
-								})
-						}).UseView(func(table metadata.Table) template.TableModel {
-						return template.DefaultTableModel(table).
-							UseField(func(columnMetaData metadata.Column) template.TableModelField {
-								defaultTableModelField := template.DefaultTableModelField(columnMetaData)
-								return defaultTableModelField.UseTags(
-									fmt.Sprintf(`json:"%s"`, columnMetaData.Name),
-								)
-							})
-					}),
-					)
-			}),
-	)
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage: miltechserver <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
 
-	if err1 != nil {
-		log.Fatalf("Error generating code: %s", err1)
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-9s %s\n", name, commands[name].summary)
 	}
 }
@@ -0,0 +1,54 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "cache",
+	Name:      "requests_total",
+	Help:      "Cache lookups by cache name and result (hit or miss).",
+}, []string{"cache", "result"})
+
+var cacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "cache",
+	Name:      "evictions_total",
+	Help:      "Entries removed from a cache by cache name and reason (capacity or expired).",
+}, []string{"cache", "reason"})
+
+// Eviction reasons recorded by CacheCounter.Evicted.
+const (
+	EvictCapacity = "capacity"
+	EvictExpired  = "expired"
+)
+
+// CacheCounter counts hits, misses and evictions for one named cache.
+type CacheCounter struct {
+	name   string
+	hits   prometheus.Counter
+	misses prometheus.Counter
+}
+
+// NewCacheCounter returns the counters for the cache called name. Caches that
+// share a name share counters.
+func NewCacheCounter(name string) *CacheCounter {
+	return &CacheCounter{
+		name:   name,
+		hits:   cacheRequests.WithLabelValues(name, "hit"),
+		misses: cacheRequests.WithLabelValues(name, "miss"),
+	}
+}
+
+// Record counts one lookup as a hit or a miss.
+func (c *CacheCounter) Record(hit bool) {
+	if hit {
+		c.hits.Inc()
+		return
+	}
+	c.misses.Inc()
+}
+
+// Evicted counts one entry removed for reason.
+func (c *CacheCounter) Evicted(reason string) {
+	cacheEvictions.WithLabelValues(c.name, reason).Inc()
+}
@@ -0,0 +1,37 @@
+// Package metrics holds the Prometheus collectors the server exports on
+// /metrics. Components record through the small helpers here rather than
+// touching collectors directly, so metric names and labels stay in one place.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "miltech"
+
+// Registry holds every collector exported by the server. It is separate from
+// the prometheus default registry so tests and libraries cannot leak into it.
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		cacheRequests,
+		cacheEvictions,
+		queueDepth,
+		queueDropped,
+		rateLimitRejected,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveRequestLabelsUnmatchedRoutes(t *testing.T) {
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("GET", UnmatchedRoute, "404"))
+
+	ObserveRequest("GET", "", http.StatusNotFound, time.Millisecond)
+
+	require.Equal(t, before+1, testutil.ToFloat64(httpRequests.WithLabelValues("GET", UnmatchedRoute, "404")))
+}
+
+func TestCacheCounterRecordsLookupsAndEvictions(t *testing.T) {
+	counter := NewCacheCounter("test_cache")
+
+	counter.Record(true)
+	counter.Record(false)
+	counter.Record(false)
+	counter.Evicted(EvictCapacity)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(cacheRequests.WithLabelValues("test_cache", "hit")))
+	require.Equal(t, 2.0, testutil.ToFloat64(cacheRequests.WithLabelValues("test_cache", "miss")))
+	require.Equal(t, 1.0, testutil.ToFloat64(cacheEvictions.WithLabelValues("test_cache", EvictCapacity)))
+}
+
+func TestQueueGaugeTracksDepthAndDrops(t *testing.T) {
+	queue := NewQueueGauge("test_queue")
+
+	queue.SetDepth(7)
+	queue.Dropped(DropFull)
+
+	require.Equal(t, 7.0, testutil.ToFloat64(queueDepth.WithLabelValues("test_queue")))
+	require.Equal(t, 1.0, testutil.ToFloat64(queueDropped.WithLabelValues("test_queue", DropFull)))
+}
+
+func TestRateLimitRejectedCountsByPolicy(t *testing.T) {
+	before := testutil.ToFloat64(rateLimitRejected.WithLabelValues("test_policy"))
+
+	RateLimitRejected("test_policy")
+
+	require.Equal(t, before+1, testutil.ToFloat64(rateLimitRejected.WithLabelValues("test_policy")))
+}
+
+func TestHandlerExposesRegistry(t *testing.T) {
+	ObserveRequest("GET", "/api/v1/test", http.StatusOK, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `miltech_http_requests_total{method="GET",route="/api/v1/test",status="200"}`)
+	require.Contains(t, rec.Body.String(), "go_goroutines")
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "queue",
+	Name:      "depth",
+	Help:      "Items waiting in an in-process background queue.",
+}, []string{"queue"})
+
+var queueDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "queue",
+	Name:      "dropped_total",
+	Help:      "Items discarded by an in-process background queue, by reason.",
+}, []string{"queue", "reason"})
+
+// Drop reasons recorded by QueueGauge.Dropped.
+const (
+	DropFull    = "full"
+	DropStopped = "stopped"
+)
+
+// QueueGauge tracks the depth and drops of one named queue.
+type QueueGauge struct {
+	name  string
+	depth prometheus.Gauge
+}
+
+// NewQueueGauge returns the collectors for the queue called name.
+func NewQueueGauge(name string) *QueueGauge {
+	return &QueueGauge{name: name, depth: queueDepth.WithLabelValues(name)}
+}
+
+// SetDepth records the number of items currently queued.
+func (q *QueueGauge) SetDepth(n int) {
+	q.depth.Set(float64(n))
+}
+
+// Dropped counts one discarded item.
+func (q *QueueGauge) Dropped(reason string) {
+	queueDropped.WithLabelValues(q.name, reason).Inc()
+}
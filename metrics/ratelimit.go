@@ -0,0 +1,15 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var rateLimitRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "rate_limit",
+	Name:      "rejected_total",
+	Help:      "Requests rejected by a rate limit policy.",
+}, []string{"policy"})
+
+// RateLimitRejected counts one request rejected by the named policy.
+func RateLimitRejected(policy string) {
+	rateLimitRejected.WithLabelValues(policy).Inc()
+}
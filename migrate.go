@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"miltechserver/bootstrap"
+	"miltechserver/migrations"
+)
+
+const migrateUsage = `usage: miltechserver migrate <command>
+
+commands:
+  up            apply every pending migration
+  down N        roll back the N most recently applied migrations
+  status        list migrations and whether each has been applied
+  baseline N    record migrations up to N as applied without running them`
+
+// runMigrate implements the migrate subcommand.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	ctx := context.Background()
+	env := bootstrap.NewEnv()
+	db := bootstrap.NewSqlClient(env)
+	defer db.Close()
+
+	runner, err := migrations.NewRunner(db)
+	if err != nil {
+		log.Fatalf("Unable to load migrations: %s", err)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := runner.Up(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed: %s", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("Database schema is already current")
+		}
+		for _, migration := range applied {
+			fmt.Printf("applied %03d_%s\n", migration.Version, migration.Name)
+		}
+
+	case "down":
+		n := parseCount(args)
+		rolledBack, err := runner.Down(ctx, n)
+		if err != nil {
+			log.Fatalf("Rollback failed: %s", err)
+		}
+		for _, migration := range rolledBack {
+			fmt.Printf("rolled back %03d_%s\n", migration.Version, migration.Name)
+		}
+
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			log.Fatalf("Unable to read migration status: %s", err)
+		}
+		printStatus(statuses)
+
+	case "baseline":
+		version := parseCount(args)
+		if err := runner.Baseline(ctx, version); err != nil {
+			log.Fatalf("Baseline failed: %s", err)
+		}
+		fmt.Printf("recorded migrations up to %03d as applied\n", version)
+
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
+
+func parseCount(args []string) int {
+	if len(args) != 2 {
+		log.Fatal(migrateUsage)
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n <= 0 {
+		log.Fatalf("%s must be a positive number, got %q", args[0], args[1])
+	}
+	return n
+}
+
+func printStatus(statuses []migrations.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tROLLBACK\tNOTE")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05Z07:00")
+		}
+		rollback := "yes"
+		if !status.HasRollback() {
+			rollback = "no"
+		}
+		note := ""
+		if status.Modified {
+			note = "file changed since applied"
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\t%s\n", status.Version, status.Name, appliedAt, rollback, note)
+	}
+	w.Flush()
+}
@@ -0,0 +1,21 @@
+-- Application-Wide User Roles
+-- Migration: 009_create_user_roles.sql
+--
+-- Global roles (site admin, moderator) granted to users independently of
+-- any shop membership. Roles can also arrive as token claims; the server
+-- merges both sources into bootstrap.User.Roles. Manage rows with
+-- `miltechserver admin grant-role` / `revoke-role`.
+
+CREATE TABLE user_roles (
+    user_id     TEXT NOT NULL,
+    role        TEXT NOT NULL,
+    granted_by  TEXT,
+    granted_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (user_id, role),
+    CONSTRAINT fk_user_roles_user_id
+        FOREIGN KEY (user_id) REFERENCES users(uid)
+        ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT user_roles_role_check
+        CHECK (role IN ('site_admin', 'moderator'))
+);
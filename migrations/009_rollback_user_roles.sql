@@ -0,0 +1,3 @@
+-- Rollback: 009_rollback_user_roles.sql
+
+DROP TABLE IF EXISTS user_roles;
@@ -0,0 +1,20 @@
+-- Shared Rate Limit Counters
+-- Migration: 010_create_rate_limit_buckets.sql
+--
+-- Fixed-window request counters for ratelimit.PostgresStore, one row per
+-- policy and key, so every replica enforces the same limits. Rows whose
+-- window has ended are deleted by the limiter's eviction loop.
+--
+-- Material image uploads now count against the material_image_upload policy,
+-- which replaces material_images_upload_limits.
+
+CREATE TABLE rate_limit_buckets (
+    key           TEXT PRIMARY KEY,
+    window_start  TIMESTAMPTZ NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL,
+    count         INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX idx_rate_limit_buckets_expires_at ON rate_limit_buckets(expires_at);
+
+DROP TABLE IF EXISTS material_images_upload_limits;
@@ -0,0 +1,14 @@
+-- Rollback: 010_rollback_rate_limit_buckets.sql
+
+CREATE TABLE IF NOT EXISTS material_images_upload_limits (
+    user_id TEXT NOT NULL,
+    niin VARCHAR(9) NOT NULL,
+    last_upload_time TIMESTAMP NOT NULL,
+    upload_count INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (user_id, niin),
+    CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(uid) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_limits_time ON material_images_upload_limits(last_upload_time);
+
+DROP TABLE IF EXISTS rate_limit_buckets;
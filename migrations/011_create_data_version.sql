@@ -0,0 +1,14 @@
+-- FedLog Data Version
+-- Migration: 011_create_data_version.sql
+--
+-- The single row records the FedLog dataset currently loaded. Ops update it
+-- with `go run . admin set-db-date DATE` after each load; every replica polls
+-- it, flushes its reference caches when version changes and serves db_date
+-- from /general/db_date. Until the row exists, replicas use DB_DATE.
+
+CREATE TABLE data_version (
+    id          BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    db_date     TEXT NOT NULL,
+    version     BIGINT NOT NULL DEFAULT 1,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,3 @@
+-- Rollback: 011_rollback_data_version.sql
+
+DROP TABLE IF EXISTS data_version;
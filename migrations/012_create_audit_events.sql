@@ -0,0 +1,33 @@
+-- Shop Audit Log
+-- Migration: 012_create_audit_events.sql
+--
+-- One row per shop-scoped mutation: who did what to which entity, with the
+-- entity's JSON before and after the change. Rows are written by
+-- audit.Recorder and read by GET /shops/:shop_id/audit. The table is
+-- append-only: a trigger rejects UPDATE and DELETE. shop_id has no foreign
+-- key so a deleted shop keeps its history.
+
+CREATE TABLE audit_events (
+    id           BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    shop_id      TEXT NOT NULL,
+    actor_id     TEXT NOT NULL,
+    entity_type  TEXT NOT NULL,
+    entity_id    TEXT NOT NULL,
+    action       TEXT NOT NULL,
+    before       JSONB,
+    after        JSONB,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_events_shop_id ON audit_events(shop_id, id DESC);
+CREATE INDEX idx_audit_events_entity ON audit_events(shop_id, entity_type, entity_id, id DESC);
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
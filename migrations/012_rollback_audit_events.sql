@@ -0,0 +1,4 @@
+-- Rollback: 012_rollback_audit_events.sql
+
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
@@ -0,0 +1,24 @@
+-- Scheduled Job Runs
+-- Migration: 013_create_job_runs.sql
+--
+-- One row per run of a job registered with the jobs.Scheduler, whether it
+-- was started by its schedule or by an admin through
+-- POST /auth/admin/jobs/:name/run. A run stays 'running' until it finishes;
+-- a run whose replica died is marked 'abandoned' by the next run of that
+-- job. Rows older than 90 days are deleted by the job-runs-prune job.
+
+CREATE TABLE job_runs (
+    id            BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    job_name      TEXT NOT NULL,
+    trigger       TEXT NOT NULL CHECK (trigger IN ('schedule', 'manual')),
+    triggered_by  TEXT,
+    instance      TEXT NOT NULL,
+    status        TEXT NOT NULL CHECK (status IN ('running', 'succeeded', 'failed', 'abandoned')),
+    error         TEXT,
+    started_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at   TIMESTAMPTZ,
+    duration_ms   BIGINT
+);
+
+CREATE INDEX idx_job_runs_job_name ON job_runs(job_name, id DESC);
+CREATE INDEX idx_job_runs_started_at ON job_runs(started_at);
@@ -0,0 +1,3 @@
+-- Rollback: 013_rollback_job_runs.sql
+
+DROP TABLE IF EXISTS job_runs;
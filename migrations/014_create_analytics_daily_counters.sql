@@ -0,0 +1,20 @@
+-- Daily Analytics Rollup
+-- Migration: 014_create_analytics_daily_counters.sql
+--
+-- analytics_event_counters only holds running totals. The
+-- analytics-daily-rollup job snapshots them shortly after midnight UTC into
+-- one row per day and counter, recording the total at the end of that day
+-- and how much it grew since the previous snapshot. Counters that did not
+-- change since their last snapshot get no row for the day.
+
+CREATE TABLE analytics_daily_counters (
+    day           DATE NOT NULL,
+    event_type    TEXT NOT NULL,
+    entity_key    TEXT NOT NULL,
+    entity_label  TEXT,
+    total         BIGINT NOT NULL,
+    count         BIGINT NOT NULL,
+    PRIMARY KEY (day, event_type, entity_key)
+);
+
+CREATE INDEX idx_analytics_daily_counters_key ON analytics_daily_counters(event_type, entity_key, day DESC);
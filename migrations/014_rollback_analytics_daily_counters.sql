@@ -0,0 +1,3 @@
+-- Rollback: 014_rollback_analytics_daily_counters.sql
+
+DROP TABLE IF EXISTS analytics_daily_counters;
@@ -0,0 +1,30 @@
+-- Domain Event Outbox
+-- Migration: 015_create_outbox_events.sql
+--
+-- Events published by services through events.Bus, written in the same
+-- transaction as the change they describe. The dispatcher on each replica
+-- leases due rows (locked_until), delivers them to the bus's subscribers
+-- and records which subscribers have them in delivered_to, so a retry only
+-- reaches the ones that failed. A row is done once dispatched_at is set, or
+-- failed_at after its last attempt. Done rows older than 14 days are
+-- deleted by the outbox-prune job.
+
+CREATE TABLE outbox_events (
+    id             BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    event_type     TEXT NOT NULL,
+    shop_id        TEXT,
+    payload        JSONB NOT NULL,
+    occurred_at    TIMESTAMPTZ NOT NULL,
+    attempts       INTEGER NOT NULL DEFAULT 0,
+    available_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until   TIMESTAMPTZ,
+    delivered_to   TEXT[] NOT NULL DEFAULT '{}',
+    last_error     TEXT,
+    dispatched_at  TIMESTAMPTZ,
+    failed_at      TIMESTAMPTZ
+);
+
+-- The dispatcher's claim query only looks at undelivered rows
+CREATE INDEX idx_outbox_events_pending ON outbox_events(available_at, id)
+    WHERE dispatched_at IS NULL AND failed_at IS NULL;
+CREATE INDEX idx_outbox_events_shop_id ON outbox_events(shop_id, id DESC);
@@ -0,0 +1,3 @@
+-- Rollback: 015_rollback_outbox_events.sql
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,46 @@
+-- Shop Webhooks
+-- Migration: 016_create_shop_webhooks.sql
+--
+-- Endpoints shop admins register to receive the shop's domain events, and
+-- the log of every request sent to them. Deliveries run as the
+-- "shop-webhooks" subscriber of the event outbox, so a failed request is
+-- retried with the outbox's backoff; an event already logged as succeeded
+-- for a webhook is not sent to it again. Deliveries older than 30 days are
+-- deleted by the webhook-deliveries-prune job.
+
+CREATE TABLE shop_webhooks (
+    id           TEXT PRIMARY KEY,
+    shop_id      TEXT NOT NULL REFERENCES shops(id) ON DELETE CASCADE,
+    url          TEXT NOT NULL,
+    -- HMAC-SHA256 signing key, returned to the admin only on creation
+    secret       TEXT NOT NULL,
+    description  TEXT,
+    event_types  TEXT[] NOT NULL,
+    active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by   TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_shop_webhooks_shop_id ON shop_webhooks(shop_id);
+
+CREATE TABLE shop_webhook_deliveries (
+    id               BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    webhook_id       TEXT NOT NULL REFERENCES shop_webhooks(id) ON DELETE CASCADE,
+    event_id         BIGINT NOT NULL,
+    event_type       TEXT NOT NULL,
+    trigger          TEXT NOT NULL CHECK (trigger IN ('event', 'replay')),
+    attempt          INTEGER NOT NULL,
+    status           TEXT NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    response_status  INTEGER,
+    error            TEXT,
+    duration_ms      BIGINT NOT NULL,
+    payload          JSONB NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_shop_webhook_deliveries_webhook_id ON shop_webhook_deliveries(webhook_id, id DESC);
+-- Redeliveries look up which webhooks already have an event
+CREATE INDEX idx_shop_webhook_deliveries_succeeded ON shop_webhook_deliveries(event_id)
+    WHERE status = 'succeeded';
+CREATE INDEX idx_shop_webhook_deliveries_created_at ON shop_webhook_deliveries(created_at);
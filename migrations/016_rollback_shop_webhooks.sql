@@ -0,0 +1,4 @@
+-- Rollback: 016_rollback_shop_webhooks.sql
+
+DROP TABLE IF EXISTS shop_webhook_deliveries;
+DROP TABLE IF EXISTS shop_webhooks;
@@ -0,0 +1,41 @@
+-- Mobile Push Notifications
+-- Migration: 017_create_push_notifications.sql
+--
+-- Device tokens registered through /user/devices, each member's per-shop
+-- push preferences, and the marks that make the equipment-services-overdue
+-- job announce each service once per service date. Pushes themselves go
+-- out through the "shop-push" event bus subscriber and are not stored.
+
+CREATE TABLE user_device_tokens (
+    token         TEXT PRIMARY KEY,
+    user_id       VARCHAR(255) NOT NULL REFERENCES users(uid) ON DELETE CASCADE,
+    platform      TEXT NOT NULL CHECK (platform IN ('android', 'ios', 'web')),
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_seen_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_user_device_tokens_user_id ON user_device_tokens(user_id, last_seen_at DESC);
+
+-- A member without a row gets every kind of push and no quiet hours
+CREATE TABLE shop_push_preferences (
+    user_id                VARCHAR(255) NOT NULL REFERENCES users(uid) ON DELETE CASCADE,
+    shop_id                VARCHAR(36) NOT NULL REFERENCES shops(id) ON DELETE CASCADE,
+    messages               BOOLEAN NOT NULL DEFAULT TRUE,
+    vehicle_notifications  BOOLEAN NOT NULL DEFAULT TRUE,
+    overdue_services       BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Minutes after midnight in time_zone; an end before the start runs past midnight
+    quiet_start_minute     SMALLINT CHECK (quiet_start_minute BETWEEN 0 AND 1439),
+    quiet_end_minute       SMALLINT CHECK (quiet_end_minute BETWEEN 0 AND 1439),
+    time_zone              TEXT NOT NULL DEFAULT 'UTC',
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, shop_id),
+    CONSTRAINT chk_quiet_hours_pair
+        CHECK ((quiet_start_minute IS NULL) = (quiet_end_minute IS NULL))
+);
+
+CREATE TABLE equipment_service_overdue_marks (
+    service_id    VARCHAR(36) NOT NULL REFERENCES equipment_services(id) ON DELETE CASCADE,
+    service_date  TIMESTAMP NOT NULL,
+    marked_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (service_id, service_date)
+);
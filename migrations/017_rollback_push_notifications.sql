@@ -0,0 +1,5 @@
+-- Rollback: 017_rollback_push_notifications.sql
+
+DROP TABLE IF EXISTS equipment_service_overdue_marks;
+DROP TABLE IF EXISTS shop_push_preferences;
+DROP TABLE IF EXISTS user_device_tokens;
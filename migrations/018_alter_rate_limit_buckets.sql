@@ -0,0 +1,8 @@
+-- Sliding-Window Rate Limit Counters
+-- Migration: 018_alter_rate_limit_buckets.sql
+--
+-- ratelimit.PostgresStore now counts in sliding windows: each row also holds
+-- the count of the window before its current one, and stays until the window
+-- after its own has ended. Existing rows start with no previous count.
+
+ALTER TABLE rate_limit_buckets ADD COLUMN previous_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Rollback: 018_rollback_rate_limit_buckets.sql
+
+ALTER TABLE rate_limit_buckets DROP COLUMN IF EXISTS previous_count;
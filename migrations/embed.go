@@ -0,0 +1,11 @@
+// Package migrations embeds the numbered SQL files in this directory and
+// applies them to the database, tracking progress in schema_migrations.
+//
+// Files are named NNN_description.sql with an optional matching
+// NNN_rollback_description.sql. Versions must be unique.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var files embed.FS
@@ -0,0 +1,12 @@
+package migrations
+
+import "errors"
+
+var (
+	ErrInvalidFilename  = errors.New("invalid migration filename")
+	ErrDuplicateVersion = errors.New("duplicate migration version")
+	ErrOrphanRollback   = errors.New("rollback has no matching migration")
+	ErrNoRollback       = errors.New("migration has no rollback file")
+	ErrUnknownVersion   = errors.New("unknown migration version")
+	ErrSchemaBehind     = errors.New("database schema is behind")
+)
@@ -0,0 +1,113 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var filenamePattern = regexp.MustCompile(`^(\d+)_(rollback_)?([a-z0-9_]+)\.sql$`)
+
+// concurrentPattern matches statements that Postgres refuses to run inside a
+// transaction block, such as CREATE INDEX CONCURRENTLY.
+var concurrentPattern = regexp.MustCompile(`(?i)\bCONCURRENTLY\b`)
+
+// Migration is one numbered schema change and its optional rollback.
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// HasRollback reports whether a rollback file exists for the migration.
+func (m Migration) HasRollback() bool {
+	return m.Down != ""
+}
+
+// transactional reports whether a script can run in a single transaction.
+// Scripts that build or drop indexes concurrently are applied statement by statement.
+func transactional(sql string) bool {
+	return !concurrentPattern.MatchString(sql)
+}
+
+// Embedded returns the migrations compiled into the binary, ordered by version.
+func Embedded() ([]Migration, error) {
+	return Load(files)
+}
+
+// Load reads every .sql file at the root of fsys and pairs migrations with
+// their rollbacks. It rejects unparseable names, repeated version numbers and
+// rollbacks without a migration.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	upFiles := map[int]string{}
+	downFiles := map[int]string{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := filenamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFilename, entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFilename, entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version}
+			byVersion[version] = migration
+		}
+
+		isRollback := match[2] != ""
+		if isRollback {
+			if existing, ok := downFiles[version]; ok {
+				return nil, fmt.Errorf("%w: %s and %s", ErrDuplicateVersion, existing, entry.Name())
+			}
+			downFiles[version] = entry.Name()
+			migration.Down = string(content)
+			continue
+		}
+
+		if existing, ok := upFiles[version]; ok {
+			return nil, fmt.Errorf("%w: %s and %s", ErrDuplicateVersion, existing, entry.Name())
+		}
+		upFiles[version] = entry.Name()
+		migration.Name = match[3]
+		migration.Up = string(content)
+		sum := sha256.Sum256(content)
+		migration.Checksum = hex.EncodeToString(sum[:])
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, migration := range byVersion {
+		if _, ok := upFiles[version]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrOrphanRollback, downFiles[version])
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
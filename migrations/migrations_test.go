@@ -0,0 +1,117 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrations, err := Embedded()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		require.NotEmpty(t, migration.Up, migration.Name)
+		if i > 0 {
+			require.Greater(t, migration.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestLoadPairsRollbacks(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"002_add_column.sql":          {Data: []byte("ALTER TABLE a ADD COLUMN b TEXT;")},
+		"001_create_table.sql":        {Data: []byte("CREATE TABLE a (id INT);")},
+		"001_rollback_drop_table.sql": {Data: []byte("DROP TABLE a;")},
+		"README.md":                   {Data: []byte("ignored")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	require.Equal(t, 1, migrations[0].Version)
+	require.Equal(t, "create_table", migrations[0].Name)
+	require.Equal(t, "DROP TABLE a;", migrations[0].Down)
+	require.True(t, migrations[0].HasRollback())
+	require.NotEmpty(t, migrations[0].Checksum)
+
+	require.Equal(t, 2, migrations[1].Version)
+	require.False(t, migrations[1].HasRollback())
+}
+
+func TestLoadRejectsInvalidSets(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		want  error
+	}{
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"003_first.sql":  {Data: []byte("SELECT 1;")},
+				"003_second.sql": {Data: []byte("SELECT 2;")},
+			},
+			want: ErrDuplicateVersion,
+		},
+		{
+			name: "duplicate rollback",
+			files: fstest.MapFS{
+				"003_first.sql":          {Data: []byte("SELECT 1;")},
+				"003_rollback_first.sql": {Data: []byte("SELECT 1;")},
+				"03_rollback_other.sql":  {Data: []byte("SELECT 2;")},
+			},
+			want: ErrDuplicateVersion,
+		},
+		{
+			name: "orphan rollback",
+			files: fstest.MapFS{
+				"001_first.sql":          {Data: []byte("SELECT 1;")},
+				"002_rollback_other.sql": {Data: []byte("SELECT 2;")},
+			},
+			want: ErrOrphanRollback,
+		},
+		{
+			name:  "unnumbered file",
+			files: fstest.MapFS{"create_table.sql": {Data: []byte("SELECT 1;")}},
+			want:  ErrInvalidFilename,
+		},
+		{
+			name:  "zero version",
+			files: fstest.MapFS{"000_create_table.sql": {Data: []byte("SELECT 1;")}},
+			want:  ErrInvalidFilename,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.files)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestTransactional(t *testing.T) {
+	require.True(t, transactional("CREATE INDEX idx_a ON a (b);"))
+	require.False(t, transactional("CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_a ON a (b);"))
+	require.False(t, transactional("drop index concurrently if exists idx_a;"))
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- header comment; with a semicolon
+CREATE INDEX CONCURRENTLY idx_a ON a (b);
+
+/* block; comment */
+INSERT INTO notes (text) VALUES ('it''s; fine');
+CREATE FUNCTION f() RETURNS void AS $body$ BEGIN PERFORM 1; END; $body$ LANGUAGE plpgsql;
+SELECT "odd;name" FROM t
+-- trailing comment only;
+`
+
+	statements := splitStatements(script)
+	require.Len(t, statements, 4)
+	require.Contains(t, statements[0], "CREATE INDEX CONCURRENTLY idx_a ON a (b);")
+	require.Contains(t, statements[1], "VALUES ('it''s; fine');")
+	require.Contains(t, statements[2], "$body$ BEGIN PERFORM 1; END; $body$ LANGUAGE plpgsql;")
+	require.Contains(t, statements[3], `SELECT "odd;name" FROM t`)
+}
@@ -0,0 +1,303 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// lockID is the pg_advisory_lock key held while migrations run so that
+// replicas starting at the same time apply each migration once.
+const lockID int64 = 70002
+
+const createTrackingTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    INTEGER PRIMARY KEY,
+    name       TEXT NOT NULL,
+    checksum   TEXT NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// Status is the applied state of one known migration.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+	// Modified is true when the embedded file no longer matches the checksum
+	// recorded when it was applied.
+	Modified bool
+}
+
+type appliedRow struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// Runner applies and rolls back migrations against a database.
+type Runner struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewRunner returns a Runner for the migrations embedded in the binary.
+func NewRunner(db *sql.DB) (*Runner, error) {
+	migrations, err := Embedded()
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order and returns the ones it applied.
+func (r *Runner) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range r.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			slog.Info("Applying migration", "version", migration.Version, "name", migration.Name)
+			start := time.Now()
+			if err := execScript(ctx, conn, migration.Up,
+				`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+				migration.Version, migration.Name, migration.Checksum); err != nil {
+				return fmt.Errorf("migration %03d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			slog.Info("Applied migration", "version", migration.Version, "name", migration.Name, "duration", time.Since(start))
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the n most recently applied migrations, newest first. It
+// refuses to start if any of them lacks a rollback file.
+func (r *Runner) Down(ctx context.Context, n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("rollback count must be positive, got %d", n)
+	}
+
+	var rolledBack []Migration
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		var targets []Migration
+		for i := len(r.migrations) - 1; i >= 0 && len(targets) < n; i-- {
+			if _, ok := done[r.migrations[i].Version]; ok {
+				targets = append(targets, r.migrations[i])
+			}
+		}
+		for version := range done {
+			if r.find(version) == nil {
+				return fmt.Errorf("%w: %d is recorded in schema_migrations but not embedded", ErrUnknownVersion, version)
+			}
+		}
+		for _, migration := range targets {
+			if !migration.HasRollback() {
+				return fmt.Errorf("%w: %03d_%s", ErrNoRollback, migration.Version, migration.Name)
+			}
+		}
+
+		for _, migration := range targets {
+			slog.Info("Rolling back migration", "version", migration.Version, "name", migration.Name)
+			if err := execScript(ctx, conn, migration.Down,
+				`DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+				return fmt.Errorf("rollback of %03d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Baseline records every migration up to and including version as applied
+// without running it. It is used once on databases whose schema was migrated
+// by hand before schema_migrations existed.
+func (r *Runner) Baseline(ctx context.Context, version int) error {
+	if r.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return r.withLock(ctx, func(conn *sql.Conn) error {
+		for _, migration := range r.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, err := conn.ExecContext(ctx,
+				`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3) ON CONFLICT (version) DO NOTHING`,
+				migration.Version, migration.Name, migration.Checksum); err != nil {
+				return fmt.Errorf("failed to record baseline migration %d: %w", migration.Version, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Status reports every embedded migration and whether it has been applied.
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]Status, 0, len(r.migrations))
+		for _, migration := range r.migrations {
+			status := Status{Migration: migration}
+			if row, ok := done[migration.Version]; ok {
+				appliedAt := row.appliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = row.checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// EnsureCurrent returns ErrSchemaBehind if any embedded migration has not
+// been applied. The server calls it at startup so it never runs against a
+// schema older than its code.
+func (r *Runner) EnsureCurrent(ctx context.Context) error {
+	statuses, err := r.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, status.Migration)
+		}
+		if status.Modified {
+			slog.Warn("Applied migration differs from embedded file", "version", status.Version, "name", status.Name)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending migration(s), first is %03d_%s; run `migrate up`",
+			ErrSchemaBehind, len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+// Pending returns the embedded migrations not yet recorded in
+// schema_migrations. Unlike Status it does not take the migration lock, so
+// readiness probes never queue behind a running migration.
+func (r *Runner) Pending(ctx context.Context) ([]Migration, error) {
+	done, err := r.applied(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range r.migrations {
+		if _, ok := done[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory
+// lock, creating the tracking table first if needed.
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reserve migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			slog.Error("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createTrackingTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Conn.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func (r *Runner) applied(ctx context.Context, conn queryer) (map[int]appliedRow, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := map[int]appliedRow{}
+	for rows.Next() {
+		var version int
+		var row appliedRow
+		if err := rows.Scan(&version, &row.checksum, &row.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		done[version] = row
+	}
+	return done, rows.Err()
+}
+
+func (r *Runner) find(version int) *Migration {
+	for i := range r.migrations {
+		if r.migrations[i].Version == version {
+			return &r.migrations[i]
+		}
+	}
+	return nil
+}
+
+// execScript runs a migration script and then the bookkeeping statement. Both
+// share one transaction unless the script contains statements Postgres forbids
+// in a transaction block; those scripts run statement by statement and must be
+// safe to re-run (IF [NOT] EXISTS) in case they fail partway.
+func execScript(ctx context.Context, conn *sql.Conn, script string, record string, args ...any) error {
+	if !transactional(script) {
+		for _, statement := range splitStatements(script) {
+			if _, err := conn.ExecContext(ctx, statement); err != nil {
+				return err
+			}
+		}
+		_, err := conn.ExecContext(ctx, record, args...)
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,109 @@
+package migrations
+
+import "strings"
+
+// splitStatements breaks a script into individual statements on top-level
+// semicolons. Quoted strings, quoted identifiers, dollar-quoted bodies and
+// comments are kept intact. Empty and comment-only statements are dropped.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	hasCode := false
+
+	flush := func() {
+		if hasCode {
+			statements = append(statements, strings.TrimSpace(current.String()))
+		}
+		current.Reset()
+		hasCode = false
+	}
+
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+
+		switch {
+		case ch == '-' && i+1 < len(script) && script[i+1] == '-':
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script) - i
+			}
+			current.WriteString(script[i : i+end])
+			i += end - 1
+
+		case ch == '/' && i+1 < len(script) && script[i+1] == '*':
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				end = len(script) - i - 2
+			} else {
+				end += 2
+			}
+			current.WriteString(script[i : i+2+end])
+			i += 2 + end - 1
+
+		case ch == '\'' || ch == '"':
+			end := i + 1
+			for end < len(script) {
+				if script[end] == ch {
+					// A doubled quote is an escaped quote, not the terminator.
+					if end+1 < len(script) && script[end+1] == ch {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			if end >= len(script) {
+				end = len(script) - 1
+			}
+			current.WriteString(script[i : end+1])
+			hasCode = true
+			i = end
+
+		case ch == '$':
+			tag := dollarTag(script[i:])
+			if tag == "" {
+				current.WriteByte(ch)
+				hasCode = true
+				continue
+			}
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				end = len(script) - i - len(tag)
+			} else {
+				end += len(tag)
+			}
+			current.WriteString(script[i : i+len(tag)+end])
+			hasCode = true
+			i += len(tag) + end - 1
+
+		case ch == ';':
+			current.WriteByte(ch)
+			flush()
+
+		default:
+			current.WriteByte(ch)
+			if ch != ' ' && ch != '\t' && ch != '\n' && ch != '\r' {
+				hasCode = true
+			}
+		}
+	}
+	flush()
+
+	return statements
+}
+
+// dollarTag returns the opening dollar-quote tag at the start of s, such as
+// "$$" or "$body$", or "" if s does not start with one.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		ch := s[i]
+		if ch == '$' {
+			return s[:i+1]
+		}
+		if !(ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || i > 1 && ch >= '0' && ch <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
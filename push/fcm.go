@@ -0,0 +1,59 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"firebase.google.com/go/v4/messaging"
+)
+
+// maxTokensPerBatch is FCM's limit on tokens in one multicast message.
+const maxTokensPerBatch = 500
+
+// FCM sends through Firebase Cloud Messaging.
+type FCM struct {
+	client *messaging.Client
+}
+
+func NewFCM(client *messaging.Client) *FCM {
+	return &FCM{client: client}
+}
+
+func (f *FCM) Send(ctx context.Context, tokens []string, notification Notification) (Result, error) {
+	var result Result
+	for start := 0; start < len(tokens); start += maxTokensPerBatch {
+		batch := tokens[start:min(start+maxTokensPerBatch, len(tokens))]
+		response, err := f.client.SendEachForMulticast(ctx, &messaging.MulticastMessage{
+			Tokens: batch,
+			Notification: &messaging.Notification{
+				Title: notification.Title,
+				Body:  notification.Body,
+			},
+			Data: notification.Data,
+		})
+		if err != nil && start == 0 {
+			return Result{}, fmt.Errorf("failed to send push notification: %w", err)
+		}
+		if err != nil {
+			// Earlier batches went out; report them rather than have the
+			// caller resend to their devices.
+			slog.Error("Failed to send push notification batch", "error", err, "devices", len(batch))
+			result.Failed += len(batch)
+			continue
+		}
+		for i, sent := range response.Responses {
+			switch {
+			case sent.Success:
+				result.Sent++
+			case messaging.IsUnregistered(sent.Error):
+				result.Invalid = append(result.Invalid, batch[i])
+			default:
+				result.Failed++
+			}
+		}
+	}
+	return result, nil
+}
+
+var _ Sender = (*FCM)(nil)
@@ -0,0 +1,92 @@
+// Package push sends mobile push notifications. Callers depend on the
+// Sender interface: FCM delivers through Firebase Cloud Messaging, Log
+// only logs for local development, and Fake records notifications for
+// tests.
+package push
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Notification is what a device shows. Data reaches the app alongside it,
+// for routing the tap to the right screen.
+type Notification struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Result is the outcome of sending one notification to many devices.
+type Result struct {
+	Sent int
+	// Invalid lists tokens the push service no longer recognises, for the
+	// caller to forget.
+	Invalid []string
+	// Failed counts tokens that could not be reached this time.
+	Failed int
+}
+
+// Sender delivers a notification to device registration tokens. An error
+// means nothing was sent; per-device outcomes are in the Result.
+type Sender interface {
+	Send(ctx context.Context, tokens []string, notification Notification) (Result, error)
+}
+
+// Log is the Sender used when no push service is configured. It logs each
+// notification instead of sending it.
+type Log struct{}
+
+func (Log) Send(_ context.Context, tokens []string, notification Notification) (Result, error) {
+	slog.Info("Push notification not sent: no push service configured",
+		"title", notification.Title,
+		"devices", len(tokens))
+	return Result{Sent: len(tokens)}, nil
+}
+
+// Delivery is one Send call recorded by a Fake.
+type Delivery struct {
+	Tokens       []string
+	Notification Notification
+}
+
+// Fake is a Sender that records what it is asked to send. Tokens in Invalid
+// are reported invalid, and Err, when set, fails every send.
+type Fake struct {
+	mu         sync.Mutex
+	deliveries []Delivery
+	Invalid    map[string]bool
+	Err        error
+}
+
+func (f *Fake) Send(_ context.Context, tokens []string, notification Notification) (Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return Result{}, f.Err
+	}
+	f.deliveries = append(f.deliveries, Delivery{Tokens: append([]string(nil), tokens...), Notification: notification})
+
+	var result Result
+	for _, token := range tokens {
+		if f.Invalid[token] {
+			result.Invalid = append(result.Invalid, token)
+		} else {
+			result.Sent++
+		}
+	}
+	return result, nil
+}
+
+// Deliveries returns the sends recorded so far.
+func (f *Fake) Deliveries() []Delivery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Delivery(nil), f.deliveries...)
+}
+
+var (
+	_ Sender = Log{}
+	_ Sender = (*Fake)(nil)
+)
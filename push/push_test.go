@@ -0,0 +1,66 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHoursContains(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := func(clock string) time.Time {
+		minute, err := ParseClock(clock)
+		require.NoError(t, err)
+		return time.Date(2026, 10, 17, minute/60, minute%60, 0, 0, newYork)
+	}
+
+	overnight := QuietHours{Start: 22 * 60, End: 6*60 + 30, Location: newYork}
+	require.True(t, overnight.Contains(at("22:00")))
+	require.True(t, overnight.Contains(at("23:59")))
+	require.True(t, overnight.Contains(at("00:00")))
+	require.True(t, overnight.Contains(at("06:29")))
+	require.False(t, overnight.Contains(at("06:30")))
+	require.False(t, overnight.Contains(at("12:00")))
+
+	// The window is in the user's zone, whatever zone the time is given in
+	require.True(t, overnight.Contains(at("23:00").UTC()))
+
+	lunch := QuietHours{Start: 12 * 60, End: 13 * 60, Location: newYork}
+	require.True(t, lunch.Contains(at("12:30")))
+	require.False(t, lunch.Contains(at("13:00")))
+	require.False(t, lunch.Contains(at("11:59")))
+
+	require.False(t, QuietHours{}.Contains(at("03:00")))
+}
+
+func TestParseClock(t *testing.T) {
+	minute, err := ParseClock("06:30")
+	require.NoError(t, err)
+	require.Equal(t, 390, minute)
+	require.Equal(t, "06:30", FormatClock(minute))
+	require.Equal(t, "23:59", FormatClock(1439))
+
+	for _, clock := range []string{"24:00", "6:30pm", "06:60", ""} {
+		_, err := ParseClock(clock)
+		require.ErrorIs(t, err, ErrInvalidClock, clock)
+	}
+}
+
+func TestFake(t *testing.T) {
+	fake := &Fake{Invalid: map[string]bool{"stale": true}}
+	notification := Notification{Title: "Shop", Body: "New message", Data: map[string]string{"shop_id": "shop-1"}}
+
+	result, err := fake.Send(context.Background(), []string{"device-1", "stale", "device-2"}, notification)
+	require.NoError(t, err)
+	require.Equal(t, Result{Sent: 2, Invalid: []string{"stale"}}, result)
+	require.Equal(t, []Delivery{{Tokens: []string{"device-1", "stale", "device-2"}, Notification: notification}}, fake.Deliveries())
+
+	fake.Err = errors.New("fcm unavailable")
+	_, err = fake.Send(context.Background(), []string{"device-1"}, notification)
+	require.EqualError(t, err, "fcm unavailable")
+	require.Len(t, fake.Deliveries(), 1)
+}
@@ -0,0 +1,50 @@
+package push
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidClock = errors.New(`time of day must be "HH:MM"`)
+
+// QuietHours is a daily window in which a user is not sent notifications.
+// Start and End are minutes after midnight in Location; a window whose End
+// comes before its Start runs past midnight. The zero value is never quiet.
+type QuietHours struct {
+	Start    int
+	End      int
+	Location *time.Location
+}
+
+// Contains reports whether t falls in the window. Start is inside it and
+// End is not.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.Start == q.End {
+		return false
+	}
+	location := q.Location
+	if location == nil {
+		location = time.UTC
+	}
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	if q.Start < q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+// ParseClock parses "HH:MM", 24-hour, into minutes after midnight.
+func ParseClock(clock string) (int, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidClock, clock)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// FormatClock formats minutes after midnight as "HH:MM".
+func FormatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
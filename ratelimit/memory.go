@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryBucket struct {
+	windowStart time.Time
+	expiresAt   time.Time
+	count       int
+	previous    int
+}
+
+// MemoryStore keeps counters in the process. Limits are per replica.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*memoryBucket)}
+}
+
+func (s *MemoryStore) Increment(_ context.Context, key string, windowStart time.Time, window time.Duration) (int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets[key]
+	if !ok || !bucket.windowStart.Equal(windowStart) {
+		next := &memoryBucket{windowStart: windowStart, expiresAt: windowStart.Add(2 * window)}
+		if ok && bucket.windowStart.Equal(windowStart.Add(-window)) {
+			next.previous = bucket.count
+		}
+		bucket = next
+		s.buckets[key] = bucket
+	}
+	bucket.count++
+	return bucket.count, bucket.previous, nil
+}
+
+func (s *MemoryStore) Evict(_ context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	evicted := 0
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.expiresAt) {
+			delete(s.buckets, key)
+			evicted++
+		}
+	}
+	return evicted, nil
+}
+
+func (s *MemoryStore) Decrement(_ context.Context, key string, windowStart time.Time, window time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets[key]
+	switch {
+	case !ok:
+	case bucket.windowStart.Equal(windowStart):
+		bucket.count = max(bucket.count-1, 0)
+	case bucket.windowStart.Equal(windowStart.Add(window)):
+		bucket.previous = max(bucket.previous-1, 0)
+	}
+	return nil
+}
+
+// Len returns the number of buckets held, including expired ones not yet
+// evicted.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PostgresStore keeps counters in the rate_limit_buckets table so every
+// replica enforces the same limits. Each request costs one upsert.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// The counter restarts at 1 when the stored row belongs to an earlier window,
+// keeping its count as previous_count when that window is the one just
+// before ($4). The SET expressions all read the row as it was.
+const incrementQuery = `
+INSERT INTO rate_limit_buckets (key, window_start, expires_at, count, previous_count)
+VALUES ($1, $2, $3, 1, 0)
+ON CONFLICT (key) DO UPDATE SET
+    count = CASE
+        WHEN rate_limit_buckets.window_start = EXCLUDED.window_start THEN rate_limit_buckets.count + 1
+        ELSE 1
+    END,
+    previous_count = CASE
+        WHEN rate_limit_buckets.window_start = EXCLUDED.window_start THEN rate_limit_buckets.previous_count
+        WHEN rate_limit_buckets.window_start = $4 THEN rate_limit_buckets.count
+        ELSE 0
+    END,
+    window_start = EXCLUDED.window_start,
+    expires_at = EXCLUDED.expires_at
+RETURNING count, previous_count`
+
+func (s *PostgresStore) Increment(ctx context.Context, key string, windowStart time.Time, window time.Duration) (int, int, error) {
+	var current, previous int
+	err := s.db.QueryRowContext(ctx, incrementQuery,
+		key, windowStart.UTC(), windowStart.Add(2*window).UTC(), windowStart.Add(-window).UTC(),
+	).Scan(&current, &previous)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to increment rate limit bucket: %w", err)
+	}
+	return current, previous, nil
+}
+
+// A bucket that has moved past both windows has nothing left to refund.
+const decrementQuery = `
+UPDATE rate_limit_buckets SET
+    count = CASE WHEN window_start = $2 THEN GREATEST(count - 1, 0) ELSE count END,
+    previous_count = CASE WHEN window_start = $3 THEN GREATEST(previous_count - 1, 0) ELSE previous_count END
+WHERE key = $1 AND window_start IN ($2, $3)`
+
+func (s *PostgresStore) Decrement(ctx context.Context, key string, windowStart time.Time, window time.Duration) error {
+	_, err := s.db.ExecContext(ctx, decrementQuery, key, windowStart.UTC(), windowStart.Add(window).UTC())
+	if err != nil {
+		return fmt.Errorf("failed to decrement rate limit bucket: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Evict(ctx context.Context, now time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_buckets WHERE expires_at <= $1`, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to evict rate limit buckets: %w", err)
+	}
+	evicted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(evicted), nil
+}
@@ -0,0 +1,221 @@
+// Package ratelimit counts requests against named policies in sliding
+// windows. Each bucket keeps the count of the current fixed window and of the
+// one before it; a request is weighed against the current count plus the
+// share of the previous count that still falls inside the last Window, so a
+// client cannot spend a full limit at the end of one window and another at the
+// start of the next. Counters live in a Store: MemoryStore keeps them in the process, while
+// PostgresStore shares them between replicas. The gin middleware that applies
+// policies to routes lives in api/middleware.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"miltechserver/metrics"
+)
+
+// Policy is a limit of Limit requests in any Window. Name namespaces the
+// counters, so two policies never share a bucket even for the same key.
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// Result describes the bucket a request was counted against.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is when the current window ends for an allowed request, and
+	// when the next request would be allowed for a rejected one.
+	Reset time.Time
+
+	windowStart time.Time
+}
+
+// RetryAfter is how long a rejected caller should wait, rounded up to whole
+// seconds so it can be sent in headers.
+func (r Result) RetryAfter(now time.Time) time.Duration {
+	wait := r.Reset.Sub(now)
+	if wait <= 0 {
+		return 0
+	}
+	return (wait + time.Second - 1).Truncate(time.Second)
+}
+
+// Store holds the request counters.
+type Store interface {
+	// Increment adds one to the bucket for key in the window starting at
+	// windowStart and returns the new count, with the count of the window
+	// just before it (zero when the bucket skipped that window). Buckets move
+	// on to windowStart as they are incremented.
+	Increment(ctx context.Context, key string, windowStart time.Time, window time.Duration) (current, previous int, err error)
+	// Evict removes buckets whose counts no longer fall in any sliding window,
+	// the window after their last one having ended before now, and reports
+	// how many were dropped.
+	Evict(ctx context.Context, now time.Time) (int, error)
+	// Decrement takes back one request counted for key in the window
+	// starting at windowStart: from the current count while the bucket is
+	// still in that window, or from the previous count once it has moved on
+	// to the next. Counts never drop below zero.
+	Decrement(ctx context.Context, key string, windowStart time.Time, window time.Duration) error
+}
+
+// Limiter checks requests against policies and periodically evicts idle
+// buckets from its store.
+type Limiter struct {
+	store    Store
+	now      func() time.Time
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a limiter over store. When evictEvery is positive a background
+// goroutine evicts expired buckets at that interval until Stop is called.
+func New(store Store, evictEvery time.Duration) *Limiter {
+	l := &Limiter{
+		store: store,
+		now:   time.Now,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if evictEvery > 0 {
+		go l.evictLoop(evictEvery)
+	} else {
+		close(l.done)
+	}
+	return l
+}
+
+// Allow counts one request for key against policy. Requests over the limit
+// are still counted, so a client that keeps retrying stays blocked until
+// enough of its requests slide out of the window. A nil Limiter allows
+// everything, which keeps test wiring simple.
+func (l *Limiter) Allow(ctx context.Context, policy Policy, key string) (Result, error) {
+	if l == nil {
+		return Result{Allowed: true, Limit: policy.Limit, Remaining: policy.Limit}, nil
+	}
+
+	now := l.now()
+	windowStart := now.Truncate(policy.Window)
+	current, previous, err := l.store.Increment(ctx, policy.Name+":"+key, windowStart, policy.Window)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to count request for %s: %w", policy.Name, err)
+	}
+
+	count := weighted(previous, now.Sub(windowStart), policy.Window) + current
+	result := Result{
+		Allowed:   count <= policy.Limit,
+		Limit:     policy.Limit,
+		Remaining: max(policy.Limit-count, 0),
+		Reset:     windowStart.Add(policy.Window),
+
+		windowStart: windowStart,
+	}
+	if !result.Allowed {
+		result.Reset = nextAllowed(policy, windowStart, current, previous)
+	}
+	if !result.Allowed {
+		metrics.RateLimitRejected(policy.Name)
+	}
+	return result, nil
+}
+
+// Refund takes back a request that Allow counted as result, for callers that
+// only charge for work that succeeds. A nil Limiter has nothing to refund.
+func (l *Limiter) Refund(ctx context.Context, policy Policy, key string, result Result) error {
+	if l == nil || result.windowStart.IsZero() {
+		return nil
+	}
+	if err := l.store.Decrement(ctx, policy.Name+":"+key, result.windowStart, policy.Window); err != nil {
+		return fmt.Errorf("failed to refund request for %s: %w", policy.Name, err)
+	}
+	return nil
+}
+
+// weighted is the share of previous, the count of the window before the
+// current one, that still falls in a sliding window elapsed into the current
+// one. It rounds up, so a request still inside the window is never dropped.
+func weighted(previous int, elapsed, window time.Duration) int {
+	if previous == 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(previous) * float64(window-elapsed) / float64(window)))
+}
+
+// nextAllowed is when one more request would fit in policy, given the counts
+// of the current window starting at windowStart and of the previous one,
+// assuming no other requests arrive meanwhile.
+func nextAllowed(policy Policy, windowStart time.Time, current, previous int) time.Time {
+	// Within the current window the previous count has to fall to fit one
+	// more request; failing that, the current count does in the next window.
+	space := policy.Limit - current - 1
+	if space < 0 {
+		windowStart = windowStart.Add(policy.Window)
+		space, previous = policy.Limit-1, current
+	}
+	if previous == 0 || space >= previous {
+		return windowStart
+	}
+	// weighted(previous, elapsed) <= space once elapsed reaches this
+	share := float64(previous-space) / float64(previous)
+	return windowStart.Add(time.Duration(math.Ceil(share * float64(policy.Window))))
+}
+
+// Stop ends background eviction. Safe to call more than once and on a nil
+// Limiter.
+func (l *Limiter) Stop(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.stopOnce.Do(func() { close(l.stop) })
+
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) evictLoop(every time.Duration) {
+	defer close(l.done)
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := l.Evict(context.Background()); err != nil {
+			slog.Warn("Failed to evict rate limit buckets", "error", err)
+		}
+	}
+}
+
+// Evict drops the buckets whose window has ended and reports how many were
+// dropped. A limiter sharing a store between replicas runs it as a
+// scheduled job instead of in every replica's eviction loop.
+func (l *Limiter) Evict(ctx context.Context) (int, error) {
+	if l == nil {
+		return 0, nil
+	}
+	evicted, err := l.store.Evict(ctx, l.now())
+	if err != nil {
+		return 0, err
+	}
+	if evicted > 0 {
+		slog.Debug("Evicted rate limit buckets", "count", evicted)
+	}
+	return evicted, nil
+}
@@ -0,0 +1,205 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(now *time.Time) (*Limiter, *MemoryStore) {
+	store := NewMemoryStore()
+	limiter := New(store, 0)
+	limiter.now = func() time.Time { return *now }
+	return limiter, store
+}
+
+func TestAllowBlocksOverLimitUntilRequestsSlideOut(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter, _ := newTestLimiter(&now)
+	policy := Policy{Name: "test", Limit: 2, Window: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Allow(context.Background(), policy, "client")
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, 1-i, result.Remaining)
+		require.Equal(t, now.Add(time.Minute), result.Reset)
+	}
+
+	result, err := limiter.Allow(context.Background(), policy, "client")
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+	// The three requests must weigh no more than one for the next to fit
+	require.Equal(t, now.Add(time.Minute+40*time.Second), result.Reset)
+
+	// At the start of the next window they still fill it
+	now = now.Add(time.Minute)
+	result, err = limiter.Allow(context.Background(), policy, "client")
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+
+	// Once two windows have passed they are gone
+	now = now.Add(time.Minute)
+	result, err = limiter.Allow(context.Background(), policy, "client")
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+}
+
+func TestAllowPreventsBurstsAcrossWindowBoundary(t *testing.T) {
+	windowStart := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := windowStart.Add(55 * time.Second)
+	limiter, _ := newTestLimiter(&now)
+	policy := Policy{Name: "test", Limit: 10, Window: time.Minute}
+
+	allowed := 0
+	for range 10 {
+		result, err := limiter.Allow(context.Background(), policy, "client")
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+	}
+
+	// A fixed window would allow ten more right after the boundary
+	now = windowStart.Add(time.Minute + 5*time.Second)
+	for range 10 {
+		result, err := limiter.Allow(context.Background(), policy, "client")
+		require.NoError(t, err)
+		if result.Allowed {
+			allowed++
+		}
+	}
+	require.Equal(t, 0, allowed)
+
+	// Halfway through the window half the earlier requests have slid out
+	now = windowStart.Add(time.Minute + 30*time.Second)
+	result, err := limiter.Allow(context.Background(), policy, "other")
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+}
+
+func TestWeighted(t *testing.T) {
+	require.Equal(t, 0, weighted(0, 0, time.Minute))
+	require.Equal(t, 10, weighted(10, 0, time.Minute))
+	require.Equal(t, 5, weighted(10, 30*time.Second, time.Minute))
+	require.Equal(t, 1, weighted(10, 59*time.Second, time.Minute))
+}
+
+func TestMemoryStoreCarriesOnlyTheAdjacentWindow(t *testing.T) {
+	store := NewMemoryStore()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for range 3 {
+		_, _, err := store.Increment(context.Background(), "a", start, time.Minute)
+		require.NoError(t, err)
+	}
+	current, previous, err := store.Increment(context.Background(), "a", start.Add(time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, current)
+	require.Equal(t, 3, previous)
+
+	current, previous, err = store.Increment(context.Background(), "a", start.Add(5*time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, current)
+	require.Equal(t, 0, previous)
+}
+
+func TestAllowSeparatesKeysAndPolicies(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter, _ := newTestLimiter(&now)
+	first := Policy{Name: "first", Limit: 1, Window: time.Minute}
+	second := Policy{Name: "second", Limit: 1, Window: time.Minute}
+
+	for _, call := range []struct {
+		policy Policy
+		key    string
+	}{{first, "a"}, {first, "b"}, {second, "a"}} {
+		result, err := limiter.Allow(context.Background(), call.policy, call.key)
+		require.NoError(t, err)
+		require.True(t, result.Allowed, "%s/%s", call.policy.Name, call.key)
+	}
+}
+
+func TestMemoryStoreEvictsExpiredBuckets(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter, store := newTestLimiter(&now)
+
+	_, err := limiter.Allow(context.Background(), Policy{Name: "short", Limit: 5, Window: time.Second}, "a")
+	require.NoError(t, err)
+	_, err = limiter.Allow(context.Background(), Policy{Name: "long", Limit: 5, Window: time.Hour}, "a")
+	require.NoError(t, err)
+	require.Equal(t, 2, store.Len())
+
+	evicted, err := store.Evict(context.Background(), now.Add(time.Second))
+	require.NoError(t, err)
+	require.Zero(t, evicted, "the short bucket still counts in the next window")
+
+	evicted, err = store.Evict(context.Background(), now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, evicted)
+	require.Equal(t, 1, store.Len())
+}
+
+func TestRefundTakesBackACountedRequest(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter, _ := newTestLimiter(&now)
+	policy := Policy{Name: "test", Limit: 1, Window: time.Minute}
+
+	result, err := limiter.Allow(context.Background(), policy, "client")
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.NoError(t, limiter.Refund(context.Background(), policy, "client", result))
+
+	result, err = limiter.Allow(context.Background(), policy, "client")
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	// Refunded after the bucket moved on, the request leaves the previous count
+	now = now.Add(time.Minute)
+	blocked, err := limiter.Allow(context.Background(), policy, "client")
+	require.NoError(t, err)
+	require.False(t, blocked.Allowed)
+	require.NoError(t, limiter.Refund(context.Background(), policy, "client", blocked))
+	require.NoError(t, limiter.Refund(context.Background(), policy, "client", result))
+
+	result, err = limiter.Allow(context.Background(), policy, "client")
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+}
+
+func TestMemoryStoreDecrementStopsAtZero(t *testing.T) {
+	store := NewMemoryStore()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, _, err := store.Increment(context.Background(), "a", start, time.Minute)
+	require.NoError(t, err)
+	for range 2 {
+		require.NoError(t, store.Decrement(context.Background(), "a", start, time.Minute))
+	}
+	require.NoError(t, store.Decrement(context.Background(), "missing", start, time.Minute))
+
+	current, previous, err := store.Increment(context.Background(), "a", start, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, current)
+	require.Equal(t, 0, previous)
+}
+
+func TestNilLimiterAllows(t *testing.T) {
+	var limiter *Limiter
+
+	result, err := limiter.Allow(context.Background(), Policy{Name: "test", Limit: 1, Window: time.Second}, "a")
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.NoError(t, limiter.Refund(context.Background(), Policy{Name: "test", Limit: 1, Window: time.Second}, "a", result))
+	require.NoError(t, limiter.Stop(context.Background()))
+}
+
+func TestRetryAfterRoundsUpToWholeSeconds(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := Result{Reset: now.Add(1500 * time.Millisecond)}
+
+	require.Equal(t, 2*time.Second, result.RetryAfter(now))
+	require.Equal(t, time.Second, result.RetryAfter(now.Add(500*time.Millisecond)))
+	require.Zero(t, result.RetryAfter(now.Add(time.Minute)))
+}
@@ -0,0 +1,153 @@
+package realtime
+
+import (
+	"errors"
+	"sync"
+)
+
+// subscriptionBuffer is how many frames a subscription holds for a client
+// that is slow to read them. One that falls further behind is ended; the
+// client reconnects and resumes from its last frame.
+const subscriptionBuffer = 64
+
+// Reasons a subscription ends.
+var (
+	ErrSlowClient = errors.New("client fell too far behind")
+	ErrRevoked    = errors.New("shop membership ended")
+	ErrHubClosed  = errors.New("server is shutting down")
+)
+
+// Hub fans frames out to the subscriptions on one replica.
+type Hub struct {
+	mu     sync.Mutex
+	shops  map[string]map[*Subscription]struct{}
+	closed bool
+}
+
+func NewHub() *Hub {
+	return &Hub{shops: map[string]map[*Subscription]struct{}{}}
+}
+
+// Subscription is one client's stream of a shop's frames.
+type Subscription struct {
+	hub    *Hub
+	shopID string
+	userID string
+	frames chan Frame
+	// err is why the subscription ended; guarded by hub.mu
+	err error
+}
+
+// Subscribe starts a subscription to shopID's frames for userID, who the
+// caller has checked is a member.
+func (h *Hub) Subscribe(shopID, userID string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+	sub := &Subscription{
+		hub:    h,
+		shopID: shopID,
+		userID: userID,
+		frames: make(chan Frame, subscriptionBuffer),
+	}
+	if h.shops[shopID] == nil {
+		h.shops[shopID] = map[*Subscription]struct{}{}
+	}
+	h.shops[shopID][sub] = struct{}{}
+	return sub, nil
+}
+
+// Frames delivers the subscription's frames. It is closed when the
+// subscription ends.
+func (s *Subscription) Frames() <-chan Frame {
+	return s.frames
+}
+
+// Err is why the subscription ended, or nil if it is open or was closed by
+// its client.
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+// Close ends the subscription.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.end(s, nil)
+}
+
+// end removes sub and closes its frames. The caller holds h.mu.
+func (h *Hub) end(sub *Subscription, err error) {
+	subs, ok := h.shops[sub.shopID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.shops, sub.shopID)
+	}
+	sub.err = err
+	close(sub.frames)
+}
+
+// Publish sends frame to its shop's subscriptions. It never blocks: a
+// subscription with a full buffer is ended with ErrSlowClient.
+func (h *Hub) Publish(frame Frame) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.shops[frame.ShopID] {
+		h.send(sub, frame)
+	}
+}
+
+func (h *Hub) send(sub *Subscription, frame Frame) {
+	select {
+	case sub.frames <- frame:
+	default:
+		h.end(sub, ErrSlowClient)
+	}
+}
+
+// Revoke ends userID's subscriptions to shopID with ErrRevoked.
+func (h *Hub) Revoke(shopID, userID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.shops[shopID] {
+		if sub.userID == userID {
+			h.end(sub, ErrRevoked)
+		}
+	}
+}
+
+// Resync sends a TypeResync frame to every subscription, for when frames
+// may have been lost on their way to the hub.
+func (h *Hub) Resync() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for shopID, subs := range h.shops {
+		frame := ResyncFrame(shopID)
+		for sub := range subs {
+			h.send(sub, frame)
+		}
+	}
+}
+
+// Close ends every subscription with ErrHubClosed and refuses new ones, so
+// open streams finish before the server waits for requests to drain.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for _, subs := range h.shops {
+		for sub := range subs {
+			h.end(sub, ErrHubClosed)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package realtime
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = 30 * time.Second
+	// pingInterval is how often an idle Listener checks its connection, so
+	// a dead one is noticed and replaced.
+	pingInterval = 90 * time.Second
+)
+
+// Listener relays notices on Channel to a replica's Hub. It holds its own
+// database connection, outside the pool, and reconnects when it drops.
+type Listener struct {
+	hub      *Hub
+	listener *pq.Listener
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// Listen connects to the database at dsn and relays Channel to hub until
+// Stop is called.
+func Listen(hub *Hub, dsn string) *Listener {
+	l := &Listener{
+		hub:     hub,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	l.listener = pq.NewListener(dsn, minReconnectInterval, maxReconnectInterval, logListenerEvent)
+	go l.listen()
+	go l.run()
+	return l
+}
+
+func logListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		slog.Warn("Stream listener disconnected", "error", err)
+	case pq.ListenerEventConnectionAttemptFailed:
+		slog.Warn("Stream listener failed to connect", "error", err)
+	case pq.ListenerEventReconnected:
+		slog.Info("Stream listener reconnected")
+	}
+}
+
+// listen subscribes to Channel. It blocks until the first connection is up,
+// after which the pq listener re-subscribes on every reconnect by itself.
+func (l *Listener) listen() {
+	err := l.listener.Listen(Channel)
+	select {
+	case <-l.done:
+		// Stopped before the connection came up
+	default:
+		if err != nil {
+			slog.Error("Stream listener failed to listen", "channel", Channel, "error", err)
+		}
+	}
+}
+
+func (l *Listener) run() {
+	defer close(l.stopped)
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case n, ok := <-l.listener.Notify:
+			if !ok {
+				return
+			}
+			if n == nil {
+				// Reconnected; notices sent while the connection was down are lost
+				l.hub.Resync()
+				continue
+			}
+			if err := relay(l.hub, n.Extra); err != nil {
+				slog.Warn("Dropped stream notice", "error", err)
+			}
+		case <-ticker.C:
+			go l.listener.Ping()
+		}
+	}
+}
+
+// Stop closes the connection and waits for the relay to finish.
+func (l *Listener) Stop(ctx context.Context) error {
+	close(l.done)
+	err := l.listener.Close()
+	select {
+	case <-l.stopped:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
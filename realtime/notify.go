@@ -0,0 +1,102 @@
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"miltechserver/events"
+)
+
+// SubscriberName is the Notifier's name on the event bus. It is stored with
+// every outbox event, so it must not change.
+const SubscriberName = "shop-stream"
+
+// maxNotifyPayload keeps a notice under Postgres' 8000 byte NOTIFY limit.
+const maxNotifyPayload = 7900
+
+// Notifier sends streamed events, and the ends of memberships, to every
+// replica through NOTIFY.
+type Notifier struct {
+	db *sql.DB
+}
+
+func NewNotifier(db *sql.DB) *Notifier {
+	return &Notifier{db: db}
+}
+
+// Subscribe registers the Notifier on bus for the streamed event types and
+// the events that end a membership.
+func (n *Notifier) Subscribe(bus *events.Bus) {
+	types := append(slices.Clone(Types), events.TypeMemberLeft, events.TypeMemberRemoved)
+	bus.Subscribe(SubscriberName, n.Handle, types...)
+}
+
+// Handle notifies Channel of env.
+func (n *Notifier) Handle(ctx context.Context, env events.Envelope) error {
+	if env.ShopID == "" {
+		return nil
+	}
+	payload, err := encodeNotice(env)
+	if err != nil {
+		return err
+	}
+	if _, err := n.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, Channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify %s: %w", Channel, err)
+	}
+	return nil
+}
+
+// encodeNotice returns the NOTIFY payload for env. An event too large to
+// send whole goes without its data.
+func encodeNotice(env events.Envelope) ([]byte, error) {
+	var n notice
+	switch env.Type {
+	case events.TypeMemberLeft, events.TypeMemberRemoved:
+		event, err := env.Decode()
+		if err != nil {
+			return nil, err
+		}
+		var userID string
+		switch event := event.(type) {
+		case *events.MemberLeft:
+			userID = event.UserID
+		case *events.MemberRemoved:
+			userID = event.UserID
+		}
+		n.Revoke = &revocation{ShopID: env.ShopID, UserID: userID}
+	default:
+		frame := FrameOf(env)
+		n.Frame = &frame
+	}
+
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode stream notice: %w", err)
+	}
+	if len(payload) > maxNotifyPayload && n.Frame != nil {
+		n.Frame.Data = nil
+		n.Frame.Truncated = true
+		if payload, err = json.Marshal(n); err != nil {
+			return nil, fmt.Errorf("failed to encode stream notice: %w", err)
+		}
+	}
+	return payload, nil
+}
+
+// relay hands a NOTIFY payload to hub.
+func relay(hub *Hub, payload string) error {
+	var n notice
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return fmt.Errorf("failed to decode stream notice: %w", err)
+	}
+	if n.Revoke != nil {
+		hub.Revoke(n.Revoke.ShopID, n.Revoke.UserID)
+	}
+	if n.Frame != nil {
+		hub.Publish(*n.Frame)
+	}
+	return nil
+}
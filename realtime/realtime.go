@@ -0,0 +1,83 @@
+// Package realtime pushes shop events to connected members. A Notifier
+// subscribed to the event bus sends each streamed event through Postgres
+// NOTIFY on Channel; a Listener on every replica receives it and hands it to
+// the replica's Hub, which fans it out to the subscriptions for the event's
+// shop. Clients read a subscription over a WebSocket or as Server-Sent
+// Events.
+//
+// Frames carry their outbox event ID, so a client that reconnects resumes
+// from the last one it saw. Delivery follows the bus: at least once, so
+// clients ignore a frame ID they have already handled.
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+
+	"miltechserver/events"
+)
+
+// Channel is the NOTIFY channel events travel on between replicas.
+const Channel = "shop_stream"
+
+// Types lists the event types streamed to clients.
+var Types = []string{
+	events.TypeMessageCreated,
+	events.TypeMessageUpdated,
+	events.TypeMessageDeleted,
+	events.TypeVehicleNotificationCreated,
+	events.TypeVehicleNotificationChanged,
+	events.TypeListItemsChanged,
+	events.TypeEquipmentServiceCompleted,
+}
+
+// Frame types the stream sends besides event types.
+const (
+	// TypeResync tells the client that frames may have been missed. It
+	// refetches what it shows instead of resuming.
+	TypeResync = "stream.resync"
+	// TypeClosed is the last frame before the server ends the stream. Its
+	// data holds the reason.
+	TypeClosed = "stream.closed"
+)
+
+// Frame is one message to a stream client.
+type Frame struct {
+	// ID is the outbox event ID; zero for control frames.
+	ID         int64     `json:"id,omitempty"`
+	Type       string    `json:"type"`
+	ShopID     string    `json:"shop_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Data is the event's payload. It is left out of events too large for
+	// NOTIFY, which have Truncated set; clients refetch what they describe.
+	Data      json.RawMessage `json:"data,omitempty"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+// FrameOf returns the frame that streams env.
+func FrameOf(env events.Envelope) Frame {
+	return Frame{
+		ID:         env.ID,
+		Type:       env.Type,
+		ShopID:     env.ShopID,
+		OccurredAt: env.OccurredAt,
+		Data:       env.Payload,
+	}
+}
+
+// ResyncFrame returns a TypeResync frame for shopID.
+func ResyncFrame(shopID string) Frame {
+	return Frame{Type: TypeResync, ShopID: shopID, OccurredAt: time.Now().UTC()}
+}
+
+// notice is the NOTIFY payload. It carries a frame for the shop's streams,
+// or the end of a member's access to them.
+type notice struct {
+	Frame  *Frame      `json:"frame,omitempty"`
+	Revoke *revocation `json:"revoke,omitempty"`
+}
+
+type revocation struct {
+	ShopID string `json:"shop_id"`
+	UserID string `json:"user_id"`
+}
@@ -0,0 +1,253 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"miltechserver/events"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func envelope(t *testing.T, id int64, event events.Event) events.Envelope {
+	t.Helper()
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	return events.Envelope{
+		ID:         id,
+		Type:       event.EventType(),
+		ShopID:     event.EventShopID(),
+		Payload:    payload,
+		OccurredAt: time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func subscribe(t *testing.T, hub *Hub, shopID, userID string) *Subscription {
+	t.Helper()
+	sub, err := hub.Subscribe(shopID, userID)
+	require.NoError(t, err)
+	t.Cleanup(sub.Close)
+	return sub
+}
+
+func TestStreamedTypesAreEventTypes(t *testing.T) {
+	for _, eventType := range Types {
+		require.Contains(t, events.Types(), eventType)
+	}
+}
+
+func TestHubPublishesToTheFramesShop(t *testing.T) {
+	hub := NewHub()
+	first := subscribe(t, hub, "shop-1", "user-1")
+	second := subscribe(t, hub, "shop-1", "user-2")
+	other := subscribe(t, hub, "shop-2", "user-1")
+
+	frame := Frame{ID: 7, Type: events.TypeMessageCreated, ShopID: "shop-1"}
+	hub.Publish(frame)
+	require.Equal(t, frame, <-first.Frames())
+	require.Equal(t, frame, <-second.Frames())
+	require.Empty(t, other.Frames())
+}
+
+func TestHubEndsSlowSubscriptions(t *testing.T) {
+	hub := NewHub()
+	slow := subscribe(t, hub, "shop-1", "user-1")
+	for i := range subscriptionBuffer + 1 {
+		hub.Publish(Frame{ID: int64(i + 1), ShopID: "shop-1"})
+	}
+
+	var received int
+	for range slow.Frames() {
+		received++
+	}
+	require.Equal(t, subscriptionBuffer, received)
+	require.ErrorIs(t, slow.Err(), ErrSlowClient)
+}
+
+func TestHubRevokeEndsOnlyThatMembersSubscriptions(t *testing.T) {
+	hub := NewHub()
+	leaving := subscribe(t, hub, "shop-1", "user-1")
+	elsewhere := subscribe(t, hub, "shop-2", "user-1")
+	staying := subscribe(t, hub, "shop-1", "user-2")
+
+	hub.Revoke("shop-1", "user-1")
+	_, open := <-leaving.Frames()
+	require.False(t, open)
+	require.ErrorIs(t, leaving.Err(), ErrRevoked)
+	require.NoError(t, elsewhere.Err())
+	require.NoError(t, staying.Err())
+}
+
+func TestHubResyncAndClose(t *testing.T) {
+	hub := NewHub()
+	sub := subscribe(t, hub, "shop-1", "user-1")
+
+	hub.Resync()
+	frame := <-sub.Frames()
+	require.Equal(t, TypeResync, frame.Type)
+	require.Equal(t, "shop-1", frame.ShopID)
+
+	hub.Close()
+	_, open := <-sub.Frames()
+	require.False(t, open)
+	require.ErrorIs(t, sub.Err(), ErrHubClosed)
+
+	_, err := hub.Subscribe("shop-1", "user-1")
+	require.ErrorIs(t, err, ErrHubClosed)
+}
+
+func TestSubscriptionCloseIsIdempotent(t *testing.T) {
+	hub := NewHub()
+	sub := subscribe(t, hub, "shop-1", "user-1")
+	sub.Close()
+	sub.Close()
+	hub.Close()
+	require.NoError(t, sub.Err())
+}
+
+func TestNoticesRelayFramesAndRevocations(t *testing.T) {
+	hub := NewHub()
+	author := subscribe(t, hub, "shop-1", "user-1")
+	removed := subscribe(t, hub, "shop-1", "user-2")
+
+	created := envelope(t, 11, events.MessageCreated{ShopID: "shop-1", MessageID: "message-1", AuthorID: "user-1"})
+	payload, err := encodeNotice(created)
+	require.NoError(t, err)
+	require.NoError(t, relay(hub, string(payload)))
+
+	frame := <-author.Frames()
+	require.Equal(t, FrameOf(created).ID, frame.ID)
+	require.Equal(t, events.TypeMessageCreated, frame.Type)
+	require.JSONEq(t, string(created.Payload), string(frame.Data))
+	require.True(t, created.OccurredAt.Equal(frame.OccurredAt))
+
+	payload, err = encodeNotice(envelope(t, 12, events.MemberRemoved{ShopID: "shop-1", UserID: "user-2", RemovedBy: "user-1"}))
+	require.NoError(t, err)
+	require.NoError(t, relay(hub, string(payload)))
+	<-removed.Frames()
+	_, open := <-removed.Frames()
+	require.False(t, open)
+	require.ErrorIs(t, removed.Err(), ErrRevoked)
+	require.NoError(t, author.Err())
+
+	require.Error(t, relay(hub, "not json"))
+}
+
+func TestLargeNoticesGoWithoutData(t *testing.T) {
+	env := envelope(t, 13, events.ListItemsChanged{
+		ShopID:  "shop-1",
+		ListID:  "list-1",
+		ItemIDs: strings.Split(strings.Repeat("c0a8012e-7d41-4b8e-9f3a-2d6b5e1f0a77,", 250), ","),
+		Change:  events.ListItemsRemoved,
+	})
+	payload, err := encodeNotice(env)
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(payload), maxNotifyPayload)
+
+	var n notice
+	require.NoError(t, json.Unmarshal(payload, &n))
+	require.True(t, n.Frame.Truncated)
+	require.Empty(t, n.Frame.Data)
+	require.Equal(t, int64(13), n.Frame.ID)
+}
+
+// readEvents reads SSE events from body until it has n of them.
+func readEvents(t *testing.T, body *bufio.Reader, n int) []map[string]string {
+	t.Helper()
+	var got []map[string]string
+	current := map[string]string{}
+	for len(got) < n {
+		line, err := body.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if len(current) > 0 {
+				got = append(got, current)
+				current = map[string]string{}
+			}
+		case strings.HasPrefix(line, ":"):
+		default:
+			field, value, _ := strings.Cut(line, ": ")
+			current[field] = value
+		}
+	}
+	return got
+}
+
+func TestServeSSE(t *testing.T) {
+	hub := NewHub()
+	sub := subscribe(t, hub, "shop-1", "user-1")
+	backlog := []Frame{{ID: 3, Type: events.TypeMessageUpdated, ShopID: "shop-1"}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeSSE(w, r, sub, backlog)
+	}))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	body := bufio.NewReader(resp.Body)
+
+	// A live frame that was already replayed is skipped
+	hub.Publish(Frame{ID: 3, Type: events.TypeMessageUpdated, ShopID: "shop-1"})
+	hub.Publish(Frame{ID: 4, Type: events.TypeMessageDeleted, ShopID: "shop-1"})
+	hub.Revoke("shop-1", "user-1")
+
+	got := readEvents(t, body, 3)
+	require.Equal(t, "3", got[0]["id"])
+	require.Equal(t, events.TypeMessageUpdated, got[0]["event"])
+	require.Equal(t, "4", got[1]["id"])
+	require.Equal(t, events.TypeMessageDeleted, got[1]["event"])
+
+	require.Equal(t, TypeClosed, got[2]["event"])
+	var closed Frame
+	require.NoError(t, json.Unmarshal([]byte(got[2]["data"]), &closed))
+	require.JSONEq(t, `{"reason":"membership_ended"}`, string(closed.Data))
+}
+
+func TestServeWebSocket(t *testing.T) {
+	hub := NewHub()
+	sub := subscribe(t, hub, "shop-1", "user-1")
+	served := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(served)
+		ServeWebSocket(w, r, sub, nil)
+	}))
+	t.Cleanup(server.Close)
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", "http://localhost/")
+	require.NoError(t, err)
+
+	hub.Publish(Frame{ID: 5, Type: events.TypeEquipmentServiceCompleted, ShopID: "shop-1"})
+	var frame Frame
+	require.NoError(t, websocket.JSON.Receive(ws, &frame))
+	require.Equal(t, int64(5), frame.ID)
+	require.Equal(t, events.TypeEquipmentServiceCompleted, frame.Type)
+
+	// Closing the socket ends the stream
+	require.NoError(t, ws.Close())
+	select {
+	case <-served:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream still running after the client closed")
+	}
+}
+
+func TestPumpStopsWithContext(t *testing.T) {
+	hub := NewHub()
+	sub := subscribe(t, hub, "shop-1", "user-1")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, pump(ctx, sub, nil, nil))
+}
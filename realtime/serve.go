@@ -0,0 +1,190 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// heartbeatInterval keeps idle streams from being cut by proxies and
+	// finds clients that went away without closing.
+	heartbeatInterval = 25 * time.Second
+	// writeTimeout bounds writing one frame to a client.
+	writeTimeout = 10 * time.Second
+)
+
+// Close reasons sent in the TypeClosed frame.
+const (
+	ReasonSlowClient = "slow_client"
+	ReasonRevoked    = "membership_ended"
+	ReasonShutdown   = "server_shutdown"
+)
+
+// IsWebSocket reports whether r asks to upgrade to a WebSocket.
+func IsWebSocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// frameWriter sends frames to one client.
+type frameWriter interface {
+	frame(Frame) error
+	heartbeat() error
+}
+
+// pump writes backlog, then sub's frames, to w until the subscription ends,
+// ctx is done or a write fails. Live frames already sent in the backlog are
+// skipped.
+func pump(ctx context.Context, sub *Subscription, backlog []Frame, w frameWriter) error {
+	replayed := make(map[int64]bool, len(backlog))
+	for _, frame := range backlog {
+		if err := w.frame(frame); err != nil {
+			return err
+		}
+		replayed[frame.ID] = true
+	}
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case frame, ok := <-sub.Frames():
+			if !ok {
+				return closeStream(sub, w)
+			}
+			if frame.ID != 0 && replayed[frame.ID] {
+				continue
+			}
+			if err := w.frame(frame); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := w.heartbeat(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// closeStream tells the client why its subscription ended.
+func closeStream(sub *Subscription, w frameWriter) error {
+	var reason string
+	switch err := sub.Err(); {
+	case errors.Is(err, ErrSlowClient):
+		reason = ReasonSlowClient
+	case errors.Is(err, ErrRevoked):
+		reason = ReasonRevoked
+	case errors.Is(err, ErrHubClosed):
+		reason = ReasonShutdown
+	default:
+		return nil
+	}
+	data, _ := json.Marshal(map[string]string{"reason": reason})
+	return w.frame(Frame{Type: TypeClosed, ShopID: sub.shopID, OccurredAt: time.Now().UTC(), Data: data})
+}
+
+// ServeSSE streams sub to the client as Server-Sent Events, starting with
+// backlog. Each frame is an event named after its type, with its ID as the
+// event ID so the browser sends it back in Last-Event-ID on reconnect.
+func ServeSSE(w http.ResponseWriter, r *http.Request, sub *Subscription, backlog []Frame) {
+	rc := http.NewResponseController(w)
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	// Stops nginx buffering the stream
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		slog.Warn("Shop stream ended", "transport", "sse", "shop_id", sub.shopID, "error", err)
+		return
+	}
+
+	err := pump(r.Context(), sub, backlog, &sseWriter{w: w, rc: rc})
+	if err != nil {
+		slog.Info("Shop stream ended", "transport", "sse", "shop_id", sub.shopID, "error", err)
+	}
+}
+
+type sseWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (s *sseWriter) frame(frame Frame) error {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return fmt.Errorf("failed to encode frame: %w", err)
+	}
+	var b strings.Builder
+	if frame.ID != 0 {
+		fmt.Fprintf(&b, "id: %d\n", frame.ID)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", frame.Type, data)
+	return s.write(b.String())
+}
+
+func (s *sseWriter) heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+func (s *sseWriter) write(chunk string) error {
+	// Not every writer supports deadlines; the heartbeat still finds dead clients
+	_ = s.rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := s.w.Write([]byte(chunk)); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// ServeWebSocket upgrades the request and streams sub over the WebSocket,
+// starting with backlog. Each frame is a JSON text message. Messages from
+// the client are read and discarded, to notice it closing.
+func ServeWebSocket(w http.ResponseWriter, r *http.Request, sub *Subscription, backlog []Frame) {
+	server := websocket.Server{
+		// Clients authenticate with a bearer token rather than cookies, so a
+		// page on another origin gains nothing by connecting.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			go func() {
+				defer cancel()
+				var discard []byte
+				for websocket.Message.Receive(ws, &discard) == nil {
+				}
+			}()
+
+			err := pump(ctx, sub, backlog, wsWriter{ws: ws})
+			if err != nil {
+				slog.Info("Shop stream ended", "transport", "websocket", "shop_id", sub.shopID, "error", err)
+			}
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+type wsWriter struct {
+	ws *websocket.Conn
+}
+
+func (s wsWriter) frame(frame Frame) error {
+	s.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return websocket.JSON.Send(s.ws, frame)
+}
+
+func (s wsWriter) heartbeat() error {
+	s.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	s.ws.PayloadType = websocket.PingFrame
+	_, err := s.ws.Write(nil)
+	return err
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"miltechserver/api/route"
+	"miltechserver/bootstrap"
+	"miltechserver/health"
+	"miltechserver/identity"
+	"miltechserver/metrics"
+	"miltechserver/tracing"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// runServe implements the serve subcommand.
+func runServe(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", "", "listen address (overrides SERVER_ADDRESS)")
+	_ = flags.Parse(args)
+
+	env := bootstrap.NewEnv()
+	if *addr != "" {
+		env.ServerAddress = *addr
+	}
+
+	// Start the engine
+	engine, lifecycle := SetupEngine(env)
+	server := &http.Server{
+		Addr:    env.ServerAddress,
+		Handler: engine,
+	}
+	// Long-lived responses such as shop streams end as soon as shutdown
+	// starts, instead of holding it up until the timeout
+	server.RegisterOnShutdown(lifecycle.Drain)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Listening on %s", env.ServerAddress)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// Wait for an interrupt or for the listener to fail
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	exitCode := 0
+	select {
+	case sig := <-stop:
+		log.Printf("Received %s, shutting down", sig)
+	case err := <-serverErr:
+		log.Printf("Server stopped unexpectedly: %s", err)
+		exitCode = 1
+	}
+	signal.Stop(stop)
+
+	timeout := time.Duration(env.ShutdownTimeout) * time.Second
+
+	// Stop accepting connections and let in-flight requests finish
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), timeout)
+	defer cancelDrain()
+	if err := server.Shutdown(drainCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("Requests still in flight after %s: %s", timeout, err)
+		exitCode = 1
+	}
+
+	// Stop background workers, then close the database
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), timeout)
+	defer cancelStop()
+	if err := lifecycle.Stop(stopCtx); err != nil {
+		log.Printf("Shutdown completed with errors: %s", err)
+		exitCode = 1
+	}
+
+	log.Println("Shutdown complete")
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+func SetupEngine(env *bootstrap.Env) (*gin.Engine, *bootstrap.Lifecycle) {
+	ctx := context.Background()
+	app := bootstrap.App(ctx, env)
+	db := app.Db
+	bootstrap.EnsureSchema(ctx, db, env)
+	if err := metrics.RegisterDB(db, env.DBName); err != nil {
+		log.Fatalf("Unable to export database pool metrics: %s", err)
+	}
+	stopTracing, err := tracing.Setup(ctx, env.TracingExporter)
+	if err != nil {
+		log.Fatalf("Unable to configure tracing: %s", err)
+	}
+	// Registered after the database hook so buffered spans are exported before it closes.
+	app.Lifecycle.OnStop("tracing", stopTracing)
+
+	profiles := identity.NewProfileCache(db, time.Duration(env.UserProfileCacheTTL)*time.Second)
+	verifier, err := identity.NewFromEnv(env, app.FireAuth, profiles)
+	if err != nil {
+		log.Fatalf("Unable to configure token verification: %s", err)
+	}
+
+	dataVersion := bootstrap.NewDataVersion(ctx, env, db, app.Lifecycle)
+
+	server := gin.Default()
+
+	route.Setup(server, route.Dependencies{
+		DB:             db,
+		Verifier:       verifier,
+		Env:            env,
+		Store:          app.Store,
+		Lifecycle:      app.Lifecycle,
+		Profiles:       profiles,
+		Health:         health.NewRegistry(),
+		Limiter:        app.RateLimiter,
+		RequestLimiter: app.RequestLimiter,
+		DataVersion:    dataVersion,
+		Jobs:           app.Jobs,
+		Events:         app.Events,
+		Push:           app.Push,
+		Stream:         app.Stream,
+	})
+	// Started once every package has registered its jobs and subscribers
+	bootstrap.StartScheduler(env, app.Jobs)
+	bootstrap.StartEventBus(env, app.Events)
+
+	return server, app.Lifecycle
+}
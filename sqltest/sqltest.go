@@ -0,0 +1,126 @@
+// Package sqltest provides a database/sql driver for unit tests of code that
+// runs a handful of known statements. A Handler answers each statement from
+// memory, and the driver logs the statements and transaction boundaries it
+// sees. Tests of queries against the real schema belong in tests/, against
+// Postgres.
+package sqltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// Rows are the rows a query returns, each one value per column.
+type Rows [][]driver.Value
+
+// Handler answers a statement. The rows it returns are the query's result
+// and are ignored for statements run with Exec; an error fails the statement.
+type Handler func(query string, args []driver.Value) (Rows, error)
+
+// Driver is a database/sql driver whose statements are answered by a Handler.
+type Driver struct {
+	handle Handler
+
+	mu  sync.Mutex
+	log []string
+}
+
+// Open returns a database whose statements are answered by handle, closed
+// when the test ends. A nil handle accepts every Exec and fails every query.
+func Open(t testing.TB, handle Handler) (*sql.DB, *Driver) {
+	t.Helper()
+	d := &Driver{handle: handle}
+	db := sql.OpenDB(d)
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+// Log returns the statements run so far, as their query text, with BEGIN,
+// COMMIT and ROLLBACK for transaction boundaries.
+func (d *Driver) Log() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.log...)
+}
+
+func (d *Driver) record(entry string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, entry)
+}
+
+func (d *Driver) Open(string) (driver.Conn, error)             { return conn{d: d}, nil }
+func (d *Driver) Connect(context.Context) (driver.Conn, error) { return conn{d: d}, nil }
+func (d *Driver) Driver() driver.Driver                        { return d }
+
+type conn struct{ d *Driver }
+
+func (c conn) Prepare(query string) (driver.Stmt, error) { return stmt{d: c.d, query: query}, nil }
+func (c conn) Close() error                              { return nil }
+func (c conn) Begin() (driver.Tx, error) {
+	c.d.record("BEGIN")
+	return tx{d: c.d}, nil
+}
+
+type tx struct{ d *Driver }
+
+func (t tx) Commit() error   { t.d.record("COMMIT"); return nil }
+func (t tx) Rollback() error { t.d.record("ROLLBACK"); return nil }
+
+type stmt struct {
+	d     *Driver
+	query string
+}
+
+func (s stmt) Close() error  { return nil }
+func (s stmt) NumInput() int { return -1 }
+
+func (s stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.record(s.query)
+	if s.d.handle != nil {
+		if _, err := s.d.handle(s.query, args); err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s stmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.record(s.query)
+	if s.d.handle == nil {
+		return nil, errors.New("unexpected query: " + s.query)
+	}
+	result, err := s.d.handle(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{rows: result}, nil
+}
+
+type rows struct {
+	rows Rows
+	next int
+}
+
+func (r *rows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *rows) Close() error { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"miltechserver/tracing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AzureStore is the production ObjectStore backed by Azure Blob Storage.
+type AzureStore struct {
+	client      *azblob.Client
+	accountName string
+}
+
+// NewAzureStore wraps an authenticated Azure Blob client. accountName is used
+// to build the unsigned object URLs returned by URL.
+func NewAzureStore(client *azblob.Client, accountName string) *AzureStore {
+	return &AzureStore{client: client, accountName: accountName}
+}
+
+func (s *AzureStore) Put(ctx context.Context, containerName, name string, data []byte) (err error) {
+	ctx, span := startBlobSpan(ctx, "Put", containerName, name)
+	defer func() { tracing.End(span, err) }()
+
+	if _, err = s.client.UploadBuffer(ctx, containerName, name, data, nil); err != nil {
+		return fmt.Errorf("failed to upload to blob storage: %w", mapAzureError(err))
+	}
+	return nil
+}
+
+func (s *AzureStore) Get(ctx context.Context, containerName, name string) ([]byte, error) {
+	object, err := s.Stream(ctx, containerName, name)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Body.Close()
+
+	data, err := io.ReadAll(object.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob data: %w", err)
+	}
+	return data, nil
+}
+
+func (s *AzureStore) Stream(ctx context.Context, containerName, name string) (_ *Object, err error) {
+	ctx, span := startBlobSpan(ctx, "Stream", containerName, name)
+	defer func() { tracing.End(span, err) }()
+
+	response, err := s.client.DownloadStream(ctx, containerName, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download from blob storage: %w", mapAzureError(err))
+	}
+
+	object := &Object{Body: response.Body, ContentLength: -1}
+	if response.ContentLength != nil {
+		object.ContentLength = *response.ContentLength
+	}
+	if response.ContentType != nil {
+		object.ContentType = *response.ContentType
+	}
+	return object, nil
+}
+
+func (s *AzureStore) Stat(ctx context.Context, containerName, name string) (_ *ObjectInfo, err error) {
+	ctx, span := startBlobSpan(ctx, "Stat", containerName, name)
+	defer func() { tracing.End(span, err) }()
+
+	blobClient := s.client.ServiceClient().NewContainerClient(containerName).NewBlobClient(name)
+	properties, err := blobClient.GetProperties(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob properties: %w", mapAzureError(err))
+	}
+
+	info := &ObjectInfo{Name: name}
+	if properties.ContentLength != nil {
+		info.Size = *properties.ContentLength
+	}
+	if properties.ContentType != nil {
+		info.ContentType = *properties.ContentType
+	}
+	if properties.LastModified != nil {
+		info.LastModified = *properties.LastModified
+	}
+	return info, nil
+}
+
+func (s *AzureStore) List(ctx context.Context, containerName, prefix string) (_ []ObjectInfo, err error) {
+	ctx, span := startBlobSpan(ctx, "List", containerName, prefix)
+	defer func() { tracing.End(span, err) }()
+
+	pager := s.client.NewListBlobsFlatPager(containerName, &container.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+
+	objects := []ObjectInfo{}
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blobs: %w", mapAzureError(err))
+		}
+
+		for _, blob := range page.Segment.BlobItems {
+			if blob.Name == nil {
+				continue
+			}
+
+			info := ObjectInfo{Name: *blob.Name}
+			if blob.Properties != nil {
+				if blob.Properties.ContentLength != nil {
+					info.Size = *blob.Properties.ContentLength
+				}
+				if blob.Properties.ContentType != nil {
+					info.ContentType = *blob.Properties.ContentType
+				}
+				if blob.Properties.LastModified != nil {
+					info.LastModified = *blob.Properties.LastModified
+				}
+			}
+			objects = append(objects, info)
+		}
+	}
+
+	return objects, nil
+}
+
+func (s *AzureStore) ListPrefixes(ctx context.Context, containerName, prefix string) (_ []string, err error) {
+	ctx, span := startBlobSpan(ctx, "ListPrefixes", containerName, prefix)
+	defer func() { tracing.End(span, err) }()
+
+	containerClient := s.client.ServiceClient().NewContainerClient(containerName)
+	pager := containerClient.NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{
+		Prefix: &prefix,
+	})
+
+	prefixes := []string{}
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blob prefixes: %w", mapAzureError(err))
+		}
+
+		for _, p := range page.Segment.BlobPrefixes {
+			if p.Name == nil {
+				continue
+			}
+			prefixes = append(prefixes, *p.Name)
+		}
+	}
+
+	sort.Strings(prefixes)
+	return prefixes, nil
+}
+
+func (s *AzureStore) Delete(ctx context.Context, containerName, name string) (err error) {
+	ctx, span := startBlobSpan(ctx, "Delete", containerName, name)
+	defer func() { tracing.End(span, err) }()
+
+	if _, err = s.client.DeleteBlob(ctx, containerName, name, nil); err != nil {
+		return fmt.Errorf("failed to delete blob: %w", mapAzureError(err))
+	}
+	return nil
+}
+
+func (s *AzureStore) SignedURL(ctx context.Context, containerName, name string, ttl time.Duration) (_ *SignedURL, err error) {
+	ctx, span := startBlobSpan(ctx, "SignedURL", containerName, name)
+	defer func() { tracing.End(span, err) }()
+
+	return generateBlobSASURL(ctx, s.client.ServiceClient(), containerName, name, ttl)
+}
+
+func (s *AzureStore) URL(containerName, name string) string {
+	return fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s", s.accountName, containerName, name)
+}
+
+// startBlobSpan starts a client span for one Azure call. Get is covered by
+// the Stream span it delegates to.
+func startBlobSpan(ctx context.Context, operation, containerName, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "AzureStore."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("blob.container", containerName),
+			attribute.String("blob.name", name),
+		),
+	)
+}
+
+// mapAzureError wraps missing-blob and missing-container responses with
+// ErrNotFound so callers can use errors.Is without importing the Azure SDK.
+func mapAzureError(err error) error {
+	if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
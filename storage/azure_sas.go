@@ -1,5 +1,4 @@
-// Package shared provides common utilities for the library feature packages.
-package shared
+package storage
 
 import (
 	"context"
@@ -7,7 +6,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
 )
@@ -23,12 +21,6 @@ type udkEntry struct {
 // packageUDKCache is the module-level UDK cache shared across all callers.
 var packageUDKCache udkEntry
 
-// SASResult holds a generated SAS URL and its expiry time.
-type SASResult struct {
-	URL       string
-	ExpiresAt time.Time
-}
-
 // strPtr is a convenience helper to get a *string from a string literal.
 func strPtr(s string) *string { return &s }
 
@@ -62,28 +54,28 @@ func getOrRefreshUDK(ctx context.Context, svcClient *service.Client) (*service.U
 	return udk, nil
 }
 
-// GenerateBlobSASURL creates a 1-hour, read-only, HTTPS-only User Delegation SAS URL
+// generateBlobSASURL creates a read-only, HTTPS-only User Delegation SAS URL
 // for a specific blob. The server must have a Managed Identity with the
 // Storage Blob Delegator role assigned on the storage account.
 //
 // SAS parameters:
 //   - No StartTime set — valid immediately (avoids clock-skew per Azure best practices).
-//   - ExpiryTime: 1 hour from now.
+//   - ExpiryTime: ttl from now.
 //   - Permissions: read-only (sp=r).
 //   - Protocol: HTTPS only (spr=https).
 //   - Scope: blob-level (sr=b), not container-level.
 //
-// The ctx parameter should be the request context so the Azure credential call
-// is cancelled if the client disconnects.
-func GenerateBlobSASURL(
+// The User Delegation Key is cached, so signing many blobs in a row costs a
+// single Azure AD call.
+func generateBlobSASURL(
 	ctx context.Context,
-	blobClient *azblob.Client,
+	svcClient *service.Client,
 	containerName string,
 	blobPath string,
-) (*SASResult, error) {
-	expiresAt := time.Now().UTC().Add(1 * time.Hour)
+	ttl time.Duration,
+) (*SignedURL, error) {
+	expiresAt := time.Now().UTC().Add(ttl)
 
-	svcClient := blobClient.ServiceClient()
 	udk, err := getOrRefreshUDK(ctx, svcClient)
 	if err != nil {
 		return nil, err
@@ -105,7 +97,7 @@ func GenerateBlobSASURL(
 
 	downloadURL := fmt.Sprintf("%s?%s", bc.URL(), sasQueryParams.Encode())
 
-	return &SASResult{
+	return &SignedURL{
 		URL:       downloadURL,
 		ExpiresAt: expiresAt,
 	}, nil
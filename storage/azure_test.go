@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAzureError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantNotFound bool
+	}{
+		{
+			name:         "blob not found response error",
+			err:          &azcore.ResponseError{ErrorCode: string(bloberror.BlobNotFound)},
+			wantNotFound: true,
+		},
+		{
+			name:         "container not found response error",
+			err:          &azcore.ResponseError{ErrorCode: string(bloberror.ContainerNotFound)},
+			wantNotFound: true,
+		},
+		{
+			name:         "authentication failure response error",
+			err:          &azcore.ResponseError{ErrorCode: string(bloberror.AuthenticationFailed)},
+			wantNotFound: false,
+		},
+		{
+			name:         "generic error",
+			err:          errors.New("network unavailable"),
+			wantNotFound: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mapped := mapAzureError(tc.err)
+			require.Equal(t, tc.wantNotFound, errors.Is(mapped, ErrNotFound))
+			require.ErrorIs(t, mapped, tc.err)
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalRoutePrefix is the path the local backend serves objects from.
+const LocalRoutePrefix = "/storage"
+
+// LocalConfig configures a LocalStore.
+type LocalConfig struct {
+	// Root is the directory objects are written under, one subdirectory per container.
+	Root string
+	// BaseURL is the externally reachable server address used to build object URLs,
+	// e.g. "http://localhost:8080".
+	BaseURL string
+	// SigningKey authenticates signed URLs. It must be shared by every replica
+	// that serves the same Root.
+	SigningKey []byte
+	// PublicContainers are served without a signature, mirroring Azure
+	// containers configured for anonymous blob read access.
+	PublicContainers []string
+}
+
+// LocalStore is an ObjectStore backed by the local filesystem. Signed URLs
+// point back at this server and are verified by Handler, so development and
+// integration environments can run without an Azure account.
+type LocalStore struct {
+	root             string
+	baseURL          string
+	signingKey       []byte
+	publicContainers map[string]bool
+}
+
+func NewLocalStore(cfg LocalConfig) (*LocalStore, error) {
+	if strings.TrimSpace(cfg.Root) == "" {
+		return nil, errors.New("local storage root is required")
+	}
+	if len(cfg.SigningKey) == 0 {
+		return nil, errors.New("local storage signing key is required")
+	}
+
+	root, err := filepath.Abs(cfg.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local storage root: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage root: %w", err)
+	}
+
+	public := make(map[string]bool, len(cfg.PublicContainers))
+	for _, name := range cfg.PublicContainers {
+		if name = strings.TrimSpace(name); name != "" {
+			public[name] = true
+		}
+	}
+
+	return &LocalStore{
+		root:             root,
+		baseURL:          strings.TrimSuffix(cfg.BaseURL, "/"),
+		signingKey:       cfg.SigningKey,
+		publicContainers: public,
+	}, nil
+}
+
+func (s *LocalStore) Put(_ context.Context, container, name string, data []byte) error {
+	fullPath, err := s.objectPath(container, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	// Write to a temporary file and rename so readers never observe a partial object.
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary object: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, container, name string) ([]byte, error) {
+	object, err := s.Stream(ctx, container, name)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Body.Close()
+
+	data, err := io.ReadAll(object.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return data, nil
+}
+
+func (s *LocalStore) Stream(_ context.Context, container, name string) (*Object, error) {
+	file, info, err := s.open(container, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Object{
+		Body:          file,
+		ContentLength: info.Size(),
+		ContentType:   contentTypeFor(name),
+	}, nil
+}
+
+func (s *LocalStore) Stat(_ context.Context, container, name string) (*ObjectInfo, error) {
+	fullPath, err := s.objectPath(container, name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(fullPath)
+	if err != nil || info.IsDir() {
+		return nil, notFound(container, name, err)
+	}
+	return &ObjectInfo{
+		Name:         name,
+		Size:         info.Size(),
+		ContentType:  contentTypeFor(name),
+		LastModified: info.ModTime().UTC(),
+	}, nil
+}
+
+func (s *LocalStore) List(_ context.Context, container, prefix string) ([]ObjectInfo, error) {
+	containerPath, err := s.containerPath(container)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only walk the deepest directory the prefix fully names.
+	walkRoot := containerPath
+	if dir := path.Dir(prefix); strings.Contains(prefix, "/") && dir != "." {
+		walkRoot = filepath.Join(containerPath, filepath.FromSlash(dir))
+	}
+
+	objects := []ObjectInfo{}
+	err = filepath.WalkDir(walkRoot, func(fullPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if errors.Is(walkErr, fs.ErrNotExist) {
+				return nil
+			}
+			return walkErr
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(containerPath, fullPath)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{
+			Name:         name,
+			Size:         info.Size(),
+			ContentType:  contentTypeFor(name),
+			LastModified: info.ModTime().UTC(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+	return objects, nil
+}
+
+func (s *LocalStore) ListPrefixes(_ context.Context, container, prefix string) ([]string, error) {
+	containerPath, err := s.containerPath(container)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := ""
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dir = prefix[:i+1]
+	}
+
+	entries, err := os.ReadDir(filepath.Join(containerPath, filepath.FromSlash(dir)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list object prefixes: %w", err)
+	}
+
+	prefixes := []string{}
+	for _, entry := range entries {
+		name := dir + entry.Name()
+		if entry.IsDir() && strings.HasPrefix(name, prefix) {
+			prefixes = append(prefixes, name+"/")
+		}
+	}
+
+	sort.Strings(prefixes)
+	return prefixes, nil
+}
+
+func (s *LocalStore) Delete(_ context.Context, container, name string) error {
+	fullPath, err := s.objectPath(container, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return notFound(container, name, err)
+		}
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) SignedURL(ctx context.Context, container, name string, ttl time.Duration) (*SignedURL, error) {
+	if _, err := s.objectPath(container, name); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().UTC().Add(ttl).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("sig", s.sign(container, name, expires))
+
+	return &SignedURL{
+		URL:       s.URL(container, name) + "?" + query.Encode(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *LocalStore) URL(container, name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return fmt.Sprintf("%s%s/%s/%s", s.baseURL, LocalRoutePrefix, url.PathEscape(container), strings.Join(segments, "/"))
+}
+
+// verify checks a signature produced by SignedURL. Objects in public
+// containers need no signature.
+func (s *LocalStore) verify(container, name, expires, signature string, now time.Time) error {
+	if s.publicContainers[container] {
+		return nil
+	}
+
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > expiresUnix {
+		return ErrInvalidSignature
+	}
+
+	expected := s.sign(container, name, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStore) sign(container, name, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(container + "\n" + name + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStore) open(container, name string) (*os.File, os.FileInfo, error) {
+	fullPath, err := s.objectPath(container, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return nil, nil, notFound(container, name, err)
+	}
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		file.Close()
+		return nil, nil, notFound(container, name, err)
+	}
+	return file, info, nil
+}
+
+func (s *LocalStore) containerPath(container string) (string, error) {
+	if container == "" || container == "." || container == ".." || strings.ContainsAny(container, `/\`) {
+		return "", fmt.Errorf("%w: container %q", ErrInvalidName, container)
+	}
+	return filepath.Join(s.root, container), nil
+}
+
+// objectPath resolves an object name inside its container directory and
+// rejects names that would escape it.
+func (s *LocalStore) objectPath(container, name string) (string, error) {
+	containerPath, err := s.containerPath(container)
+	if err != nil {
+		return "", err
+	}
+	if name == "" || strings.Contains(name, `\`) || path.Clean("/"+name) != "/"+name {
+		return "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return filepath.Join(containerPath, filepath.FromSlash(name)), nil
+}
+
+func notFound(container, name string, cause error) error {
+	if cause == nil {
+		return fmt.Errorf("%w: %s/%s", ErrNotFound, container, name)
+	}
+	return fmt.Errorf("%w: %s/%s: %v", ErrNotFound, container, name, cause)
+}
+
+func contentTypeFor(name string) string {
+	if contentType := mime.TypeByExtension(strings.ToLower(path.Ext(name))); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
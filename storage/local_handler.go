@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes serves local objects at LocalRoutePrefix so the URLs returned
+// by SignedURL and URL resolve against this server.
+func (s *LocalStore) RegisterRoutes(router gin.IRouter) {
+	router.GET(LocalRoutePrefix+"/:container/*name", s.serveObject)
+}
+
+// serveObject streams a stored object after checking its signature.
+// GET /storage/:container/*name?expires=...&sig=...
+func (s *LocalStore) serveObject(c *gin.Context) {
+	container := c.Param("container")
+	name := strings.TrimPrefix(c.Param("name"), "/")
+
+	if err := s.verify(container, name, c.Query("expires"), c.Query("sig"), time.Now()); err != nil {
+		slog.Warn("Rejected local storage request", "container", container, "name", name, "error", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid or expired signature"})
+		return
+	}
+
+	file, info, err := s.open(container, name)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidName) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Object not found"})
+			return
+		}
+		slog.Error("Failed to open local storage object", "container", container, "name", name, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read object"})
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Type", contentTypeFor(name))
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
+}
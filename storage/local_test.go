@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+
+	store, err := NewLocalStore(LocalConfig{
+		Root:             t.TempDir(),
+		BaseURL:          "http://localhost:8080/",
+		SigningKey:       []byte("test-signing-key"),
+		PublicContainers: []string{"material-images"},
+	})
+	require.NoError(t, err)
+	return store
+}
+
+func TestLocalStorePutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+
+	require.NoError(t, store.Put(ctx, "library", "pmcs/TRACK/m1.pdf", []byte("pdf-bytes")))
+
+	data, err := store.Get(ctx, "library", "pmcs/TRACK/m1.pdf")
+	require.NoError(t, err)
+	require.Equal(t, []byte("pdf-bytes"), data)
+
+	info, err := store.Stat(ctx, "library", "pmcs/TRACK/m1.pdf")
+	require.NoError(t, err)
+	require.Equal(t, int64(9), info.Size)
+	require.Equal(t, "application/pdf", info.ContentType)
+
+	require.NoError(t, store.Delete(ctx, "library", "pmcs/TRACK/m1.pdf"))
+
+	_, err = store.Get(ctx, "library", "pmcs/TRACK/m1.pdf")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, store.Delete(ctx, "library", "pmcs/TRACK/m1.pdf"), ErrNotFound)
+}
+
+func TestLocalStoreListAndPrefixes(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+
+	for _, name := range []string{"pmcs/TRACK/b.pdf", "pmcs/TRACK/a.pdf", "pmcs/WHEEL/c.pdf", "ps-mag/issue.pdf"} {
+		require.NoError(t, store.Put(ctx, "library", name, []byte("x")))
+	}
+
+	objects, err := store.List(ctx, "library", "pmcs/TRACK/")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	require.Equal(t, "pmcs/TRACK/a.pdf", objects[0].Name)
+	require.Equal(t, "pmcs/TRACK/b.pdf", objects[1].Name)
+
+	objects, err = store.List(ctx, "library", "pmcs/")
+	require.NoError(t, err)
+	require.Len(t, objects, 3)
+
+	prefixes, err := store.ListPrefixes(ctx, "library", "pmcs/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"pmcs/TRACK/", "pmcs/WHEEL/"}, prefixes)
+
+	objects, err = store.List(ctx, "library", "missing/")
+	require.NoError(t, err)
+	require.Empty(t, objects)
+}
+
+func TestLocalStoreRejectsEscapingNames(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+
+	for _, name := range []string{"../secret", "a/../../secret", "/abs", `a\b`, "", "dir/"} {
+		require.ErrorIs(t, store.Put(ctx, "library", name, []byte("x")), ErrInvalidName, name)
+	}
+	require.ErrorIs(t, store.Put(ctx, "../library", "a.pdf", []byte("x")), ErrInvalidName)
+}
+
+func TestLocalStoreServesSignedURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+	router := gin.New()
+	store.RegisterRoutes(router)
+
+	require.NoError(t, store.Put(ctx, "library", "pmcs/TRACK/m1 abrams.pdf", []byte("pdf-bytes")))
+
+	signed, err := store.SignedURL(ctx, "library", "pmcs/TRACK/m1 abrams.pdf", time.Hour)
+	require.NoError(t, err)
+	require.Contains(t, signed.URL, "http://localhost:8080/storage/library/pmcs/TRACK/m1%20abrams.pdf?")
+
+	parsed, err := url.Parse(signed.URL)
+	require.NoError(t, err)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, parsed.RequestURI(), nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "pdf-bytes", resp.Body.String())
+	require.Equal(t, "application/pdf", resp.Header().Get("Content-Type"))
+
+	tampered := parsed.Query()
+	tampered.Set("sig", "00")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, parsed.EscapedPath()+"?"+tampered.Encode(), nil))
+	require.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, parsed.EscapedPath(), nil))
+	require.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestLocalStoreVerifyExpired(t *testing.T) {
+	store := newTestLocalStore(t)
+
+	signed, err := store.SignedURL(context.Background(), "library", "a.pdf", time.Minute)
+	require.NoError(t, err)
+	parsed, err := url.Parse(signed.URL)
+	require.NoError(t, err)
+
+	query := parsed.Query()
+	require.NoError(t, store.verify("library", "a.pdf", query.Get("expires"), query.Get("sig"), time.Now()))
+	require.ErrorIs(t,
+		store.verify("library", "a.pdf", query.Get("expires"), query.Get("sig"), time.Now().Add(2*time.Minute)),
+		ErrInvalidSignature)
+}
+
+func TestLocalStoreServesPublicContainerWithoutSignature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := newTestLocalStore(t)
+	router := gin.New()
+	store.RegisterRoutes(router)
+
+	require.NoError(t, store.Put(context.Background(), "material-images", "abc.png", []byte("png")))
+	require.Equal(t, "http://localhost:8080/storage/material-images/abc.png", store.URL("material-images", "abc.png"))
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/storage/material-images/abc.png", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/storage/material-images/missing.png", nil))
+	require.Equal(t, http.StatusNotFound, resp.Code)
+}
@@ -0,0 +1,71 @@
+// Package storage provides the object store used by every blob-backed feature.
+// ObjectStore hides whether objects live in Azure Blob Storage or on local disk,
+// so feature packages never talk to the Azure SDK directly.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrNotFound         = errors.New("object not found")
+	ErrInvalidName      = errors.New("invalid object name")
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+)
+
+// ObjectInfo describes a stored object as returned by List and Stat.
+type ObjectInfo struct {
+	Name         string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// Object is an open object body. Callers must close Body.
+// ContentLength is -1 when the backend does not report a size.
+type Object struct {
+	Body          io.ReadCloser
+	ContentLength int64
+	ContentType   string
+}
+
+// SignedURL is a time-limited, read-only download URL for a single object.
+type SignedURL struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// ObjectStore is the storage contract shared by the Azure and local backends.
+// Object names use forward slashes; a "folder" is any name prefix ending in "/".
+type ObjectStore interface {
+	// Put creates or overwrites an object.
+	Put(ctx context.Context, container, name string, data []byte) error
+
+	// Get reads an entire object into memory. Returns ErrNotFound if it does not exist.
+	Get(ctx context.Context, container, name string) ([]byte, error)
+
+	// Stream opens an object for reading. Returns ErrNotFound if it does not exist.
+	Stream(ctx context.Context, container, name string) (*Object, error)
+
+	// Stat returns object metadata without reading the body. Returns ErrNotFound if it does not exist.
+	Stat(ctx context.Context, container, name string) (*ObjectInfo, error)
+
+	// List returns every object whose name starts with prefix, ordered by name.
+	List(ctx context.Context, container, prefix string) ([]ObjectInfo, error)
+
+	// ListPrefixes returns the immediate "folders" below prefix, each ending in "/".
+	ListPrefixes(ctx context.Context, container, prefix string) ([]string, error)
+
+	// Delete removes an object. Returns ErrNotFound if it does not exist.
+	Delete(ctx context.Context, container, name string) error
+
+	// SignedURL returns a read-only URL for an object that expires after ttl.
+	SignedURL(ctx context.Context, container, name string, ttl time.Duration) (*SignedURL, error)
+
+	// URL returns the unsigned address of an object, as persisted alongside
+	// user-uploaded images.
+	URL(container, name string) string
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"miltechserver/bootstrap"
+	"os"
+	"sort"
+)
+
+// task is a named one-off job run from the import or admin subcommands.
+// Tasks get a connected database and their remaining arguments.
+type task struct {
+	usage   string
+	summary string
+	run     func(ctx context.Context, env *bootstrap.Env, db *sql.DB, args []string) error
+}
+
+// importTasks load reference data into the database. Register new imports here.
+var importTasks = map[string]task{}
+
+// adminTasks perform operational changes such as granting access. Register
+// new tasks here.
+var adminTasks = map[string]task{}
+
+// runImport implements the import subcommand.
+func runImport(args []string) {
+	runTask("import", importTasks, args)
+}
+
+// runAdmin implements the admin subcommand.
+func runAdmin(args []string) {
+	runTask("admin", adminTasks, args)
+}
+
+func runTask(group string, tasks map[string]task, args []string) {
+	if len(args) == 0 {
+		printTaskUsage(group, tasks)
+		os.Exit(2)
+	}
+
+	t, ok := tasks[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown %s task %q\n\n", group, args[0])
+		printTaskUsage(group, tasks)
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	env := bootstrap.NewEnv()
+	db := bootstrap.NewSqlClient(env)
+	defer db.Close()
+
+	if err := t.run(ctx, env, db, args[1:]); err != nil {
+		log.Fatalf("%s %s failed: %s", group, args[0], err)
+	}
+}
+
+func printTaskUsage(group string, tasks map[string]task) {
+	fmt.Fprintf(os.Stderr, "usage: miltechserver %s <task> [arguments]\n\n", group)
+	if len(tasks) == 0 {
+		fmt.Fprintln(os.Stderr, "no tasks are registered")
+		return
+	}
+
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "tasks:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s %s\n      %s\n", name, tasks[name].usage, tasks[name].summary)
+	}
+}
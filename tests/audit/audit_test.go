@@ -0,0 +1,157 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShopMutationsAreRecorded(t *testing.T) {
+	router := newTestRouter(t)
+	adminID := newUser(t, testDB)
+
+	shopID := createShop(t, router, adminID, "Audit Shop")
+	renameShop(t, router, adminID, shopID, "Renamed Shop")
+
+	page := getAuditPage(t, router, adminID, shopID, nil)
+	require.Len(t, page.Items, 2)
+	require.Nil(t, page.NextCursor)
+
+	updated, created := page.Items[0], page.Items[1]
+	require.Greater(t, updated.ID, created.ID)
+
+	require.Equal(t, "shop", created.EntityType)
+	require.Equal(t, "create", created.Action)
+	require.Equal(t, shopID, created.EntityID)
+	require.Equal(t, adminID, created.ActorID)
+	require.NotNil(t, created.ActorUsername)
+	require.Equal(t, "test-user", *created.ActorUsername)
+	require.Equal(t, "null", string(created.Before))
+	require.Equal(t, "Audit Shop", shopName(t, created.After))
+
+	require.Equal(t, "update", updated.Action)
+	require.Equal(t, "Audit Shop", shopName(t, updated.Before))
+	require.Equal(t, "Renamed Shop", shopName(t, updated.After))
+}
+
+func TestAuditFilters(t *testing.T) {
+	router := newTestRouter(t)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+
+	shopID := createShop(t, router, adminID, "Filter Shop")
+	renameShop(t, router, adminID, shopID, "Filter Shop 2")
+	joinShop(t, router, adminID, memberID, shopID)
+
+	updates := getAuditPage(t, router, adminID, shopID, url.Values{
+		"entity_type": {"shop"},
+		"action":      {"update"},
+	})
+	require.Len(t, updates.Items, 1)
+	require.Equal(t, "Filter Shop 2", shopName(t, updates.Items[0].After))
+
+	joins := getAuditPage(t, router, adminID, shopID, url.Values{"actor_id": {memberID}})
+	require.Len(t, joins.Items, 1)
+	require.Equal(t, "shop_member", joins.Items[0].EntityType)
+	require.Equal(t, "join", joins.Items[0].Action)
+	require.Equal(t, memberID, joins.Items[0].EntityID)
+
+	future := getAuditPage(t, router, adminID, shopID, url.Values{
+		"since": {time.Now().Add(time.Hour).UTC().Format(time.RFC3339)},
+	})
+	require.Empty(t, future.Items)
+
+	badType := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/audit?entity_type=spaceship", nil, adminID)
+	require.Equal(t, http.StatusBadRequest, badType.Code)
+	require.Equal(t, "invalid_parameter", decodeProblem(t, badType.Body).Code)
+
+	badSince := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/audit?since=yesterday", nil, adminID)
+	require.Equal(t, http.StatusBadRequest, badSince.Code)
+}
+
+func TestAuditPagination(t *testing.T) {
+	router := newTestRouter(t)
+	adminID := newUser(t, testDB)
+
+	shopID := createShop(t, router, adminID, "Paged Shop")
+	for _, name := range []string{"Paged 1", "Paged 2", "Paged 3"} {
+		renameShop(t, router, adminID, shopID, name)
+	}
+
+	first := getAuditPage(t, router, adminID, shopID, url.Values{
+		"limit":         {"3"},
+		"include_total": {"true"},
+	})
+	require.Len(t, first.Items, 3)
+	require.NotNil(t, first.NextCursor)
+	require.NotNil(t, first.Total)
+	require.Equal(t, 4, *first.Total)
+	require.Equal(t, "Paged 3", shopName(t, first.Items[0].After))
+
+	second := getAuditPage(t, router, adminID, shopID, url.Values{
+		"limit":  {"3"},
+		"cursor": {*first.NextCursor},
+	})
+	require.Len(t, second.Items, 1)
+	require.Nil(t, second.NextCursor)
+	require.Equal(t, "create", second.Items[0].Action)
+}
+
+func TestAuditRequiresShopAdmin(t *testing.T) {
+	router := newTestRouter(t)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+
+	shopID := createShop(t, router, adminID, "Private Shop")
+	joinShop(t, router, adminID, memberID, shopID)
+
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/audit", nil, memberID)
+	require.Equal(t, http.StatusForbidden, resp.Code)
+	require.Equal(t, "shop_admin_required", decodeProblem(t, resp.Body).Code)
+
+	anonymous := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/audit", nil, "")
+	require.Equal(t, http.StatusUnauthorized, anonymous.Code)
+}
+
+func TestAuditEventsAreAppendOnly(t *testing.T) {
+	router := newTestRouter(t)
+	adminID := newUser(t, testDB)
+
+	shopID := createShop(t, router, adminID, "Immutable Shop")
+
+	_, err := testDB.Exec(`UPDATE audit_events SET action = 'delete' WHERE shop_id = $1`, shopID)
+	require.ErrorContains(t, err, "append-only")
+
+	_, err = testDB.Exec(`DELETE FROM audit_events WHERE shop_id = $1`, shopID)
+	require.ErrorContains(t, err, "append-only")
+
+	// Deleting the shop keeps its history
+	deleteResp := doJSONRequest(t, router, http.MethodDelete, "/api/v1/auth/shops/"+shopID, nil, adminID)
+	require.Equal(t, http.StatusOK, deleteResp.Code)
+
+	var actions []string
+	rows, err := testDB.Query(`SELECT action FROM audit_events WHERE shop_id = $1 ORDER BY id`, shopID)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var action string
+		require.NoError(t, rows.Scan(&action))
+		actions = append(actions, action)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{"create", "delete"}, actions)
+}
+
+func shopName(t *testing.T, state json.RawMessage) string {
+	t.Helper()
+
+	var shop struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, json.Unmarshal(state, &shop))
+	return shop.Name
+}
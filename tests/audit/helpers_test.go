@@ -0,0 +1,211 @@
+package audit_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/middleware"
+	"miltechserver/api/shops"
+	"miltechserver/bootstrap"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type standardResponse struct {
+	Status  int             `json:"status"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+}
+
+type auditPage struct {
+	Items      []auditEvent `json:"items"`
+	NextCursor *string      `json:"next_cursor"`
+	Total      *int         `json:"total"`
+}
+
+type auditEvent struct {
+	ID            int64           `json:"id"`
+	ShopID        string          `json:"shop_id"`
+	ActorID       string          `json:"actor_id"`
+	ActorUsername *string         `json:"actor_username"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      string          `json:"entity_id"`
+	Action        string          `json:"action"`
+	Before        json.RawMessage `json:"before"`
+	After         json.RawMessage `json:"after"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	router.Use(testUserMiddleware())
+
+	group := router.Group("/api/v1/auth")
+
+	deps := shops.Dependencies{
+		DB:  testDB,
+		Env: &bootstrap.Env{BlobAccountName: "test-account"},
+	}
+
+	shops.RegisterRoutes(deps, group)
+
+	return router
+}
+
+func testUserMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetHeader("X-User-ID")
+		if userID == "" {
+			c.Next()
+			return
+		}
+
+		c.Set("user", &bootstrap.User{
+			UserID:   userID,
+			Username: "test-user",
+			Email:    userID + "@example.com",
+		})
+		c.Next()
+	}
+}
+
+func doJSONRequest(t *testing.T, router *gin.Engine, method string, path string, body interface{}, userID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	reader := strings.NewReader("")
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = strings.NewReader(string(payload))
+	}
+
+	req, err := http.NewRequest(method, path, reader)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeStandardResponse(t *testing.T, body *bytes.Buffer) standardResponse {
+	t.Helper()
+
+	var resp standardResponse
+	err := json.Unmarshal(body.Bytes(), &resp)
+	require.NoError(t, err)
+	return resp
+}
+
+func decodeProblem(t *testing.T, body *bytes.Buffer) apperror.Problem {
+	t.Helper()
+
+	var problem apperror.Problem
+	err := json.Unmarshal(body.Bytes(), &problem)
+	require.NoError(t, err)
+	return problem
+}
+
+func decodeMap(t *testing.T, data json.RawMessage) map[string]interface{} {
+	t.Helper()
+
+	var result map[string]interface{}
+	err := json.Unmarshal(data, &result)
+	require.NoError(t, err)
+	return result
+}
+
+// newUser inserts a user with a fresh ID, so tests never share audit rows.
+func newUser(t *testing.T, db *sql.DB) string {
+	t.Helper()
+
+	userID := "audit-" + uuid.New().String()
+	_, err := db.Exec(
+		`INSERT INTO users (uid, email, username, created_at, is_enabled)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		userID,
+		userID+"@example.com",
+		"test-user",
+		time.Now().UTC(),
+		true,
+	)
+	require.NoError(t, err)
+	return userID
+}
+
+func createShop(t *testing.T, router *gin.Engine, userID string, name string) string {
+	t.Helper()
+
+	body := map[string]interface{}{
+		"name":    name,
+		"details": "Details",
+	}
+
+	resp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops", body, userID)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	data := decodeMap(t, decodeStandardResponse(t, resp.Body).Data)
+	shopID, ok := data["id"].(string)
+	require.True(t, ok)
+	require.NotEmpty(t, shopID)
+
+	return shopID
+}
+
+func renameShop(t *testing.T, router *gin.Engine, userID string, shopID string, name string) {
+	t.Helper()
+
+	body := map[string]interface{}{"name": name}
+	resp := doJSONRequest(t, router, http.MethodPut, "/api/v1/auth/shops/"+shopID, body, userID)
+	require.Equal(t, http.StatusOK, resp.Code)
+}
+
+func joinShop(t *testing.T, router *gin.Engine, adminID string, userID string, shopID string) {
+	t.Helper()
+
+	inviteResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/invite-codes", map[string]interface{}{"shop_id": shopID}, adminID)
+	require.Equal(t, http.StatusCreated, inviteResp.Code)
+
+	code, ok := decodeMap(t, decodeStandardResponse(t, inviteResp.Body).Data)["code"].(string)
+	require.True(t, ok)
+
+	joinResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/join", map[string]interface{}{"invite_code": code}, userID)
+	require.Equal(t, http.StatusOK, joinResp.Code)
+}
+
+// getAuditPage reads the shop's audit log with the given query parameters
+// and expects it to succeed.
+func getAuditPage(t *testing.T, router *gin.Engine, userID string, shopID string, query url.Values) auditPage {
+	t.Helper()
+
+	path := "/api/v1/auth/shops/" + shopID + "/audit"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp := doJSONRequest(t, router, http.MethodGet, path, nil, userID)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var page auditPage
+	err := json.Unmarshal(decodeStandardResponse(t, resp.Body).Data, &page)
+	require.NoError(t, err)
+	return page
+}
@@ -3,12 +3,13 @@ package docs_equipment_test
 import (
 	"database/sql"
 	"encoding/json"
-	"math"
 	"net/http"
 	"testing"
 
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/docs_equipment"
-	"miltechserver/api/response"
+	"miltechserver/api/pagination"
 
 	"github.com/stretchr/testify/require"
 )
@@ -16,11 +17,14 @@ import (
 func TestEquipmentDetailsBlankParams(t *testing.T) {
 	router := newTestRouter(t)
 
-	invalidPageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?page=bad")
-	require.Equal(t, http.StatusBadRequest, invalidPageResp.Code)
+	invalidLimitResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?limit=bad")
+	require.Equal(t, http.StatusBadRequest, invalidLimitResp.Code)
 
-	zeroPageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?page=0")
-	require.Equal(t, http.StatusBadRequest, zeroPageResp.Code)
+	zeroLimitResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?limit=0")
+	require.Equal(t, http.StatusBadRequest, zeroLimitResp.Code)
+
+	invalidCursorResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?cursor=bad")
+	require.Equal(t, http.StatusBadRequest, invalidCursorResp.Code)
 
 	emptySearchResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details/search")
 	require.Equal(t, http.StatusBadRequest, emptySearchResp.Code)
@@ -34,26 +38,30 @@ func TestEquipmentDetailsPaginated(t *testing.T) {
 	}
 
 	rowCount := countRows(t, testDB, "docs_equipment_details")
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?page=1")
-
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
-
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 
-	var data docs_equipment.EquipmentDetailsPageResponse
+	var data pagination.Page[model.DocsEquipmentDetails]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
-	require.Equal(t, 1, data.Page)
+	require.Len(t, data.Items, min(rowCount, 40))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 40, data.NextCursor != nil)
+	if data.NextCursor == nil {
+		return
+	}
+
+	resp = doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?cursor="+*data.NextCursor)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 
-	expectedTotalPages := int(math.Ceil(float64(rowCount) / 40.0))
-	require.Equal(t, expectedTotalPages, data.TotalPages)
-	require.Equal(t, 1 >= expectedTotalPages, data.IsLastPage)
+	var next pagination.Page[model.DocsEquipmentDetails]
+	require.NoError(t, json.Unmarshal(payload.Data, &next))
+	require.NotEmpty(t, next.Items)
+	require.Greater(t, next.Items[0].ID, data.Items[len(data.Items)-1].ID)
 }
 
 func TestEquipmentFamilies(t *testing.T) {
@@ -90,13 +98,13 @@ func TestEquipmentByFamily(t *testing.T) {
 		t.Skip("no family data found")
 	}
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details/family/"+family+"?page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details/family/"+family)
 	require.Equal(t, http.StatusOK, resp.Code)
 
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 
-	var data docs_equipment.EquipmentDetailsPageResponse
+	var data pagination.Page[model.DocsEquipmentDetails]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
 	require.NotEmpty(t, data.Items)
 }
@@ -113,13 +121,13 @@ func TestEquipmentSearch(t *testing.T) {
 		t.Skip("no model data found")
 	}
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details/search?q="+modelValue+"&page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details/search?q="+modelValue)
 	require.Equal(t, http.StatusOK, resp.Code)
 
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 
-	var data docs_equipment.EquipmentDetailsPageResponse
+	var data pagination.Page[model.DocsEquipmentDetails]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
 	require.NotEmpty(t, data.Items)
 }
@@ -130,10 +138,12 @@ func TestEquipmentInternalError(t *testing.T) {
 	t.Cleanup(func() { _ = db.Close() })
 
 	router := newTestRouterWithDB(t, db)
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details?page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/equipment-details")
 	require.Equal(t, http.StatusInternalServerError, resp.Code)
 
-	var payload response.ErrorResponse
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+	var payload apperror.Problem
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 	require.Equal(t, http.StatusInternalServerError, payload.Status)
+	require.Equal(t, "internal_error", payload.Code)
 }
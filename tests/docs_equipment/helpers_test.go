@@ -33,7 +33,7 @@ func newTestRouterWithDB(t *testing.T, db *sql.DB) *gin.Engine {
 	router.Use(middleware.ErrorHandler)
 	publicGroup := router.Group("/api/v1")
 	// Register only data routes (no blob client in test)
-	docs_equipment.RegisterRoutes(docs_equipment.Dependencies{DB: db, BlobClient: nil}, publicGroup)
+	docs_equipment.RegisterRoutes(docs_equipment.Dependencies{DB: db, Store: nil}, publicGroup)
 	return router
 }
 
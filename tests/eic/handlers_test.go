@@ -3,10 +3,11 @@ package eic_test
 import (
 	"database/sql"
 	"encoding/json"
-	"math"
 	"net/http"
 	"testing"
 
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 
 	"github.com/stretchr/testify/require"
@@ -24,11 +25,14 @@ func TestEICBlankParams(t *testing.T) {
 	blankFscResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/fsc/%20%20")
 	require.Equal(t, http.StatusBadRequest, blankFscResp.Code)
 
-	invalidPageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/fsc/ABCD?page=bad")
-	require.Equal(t, http.StatusBadRequest, invalidPageResp.Code)
+	invalidLimitResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/fsc/ABCD?limit=bad")
+	require.Equal(t, http.StatusBadRequest, invalidLimitResp.Code)
 
-	zeroPageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?page=0")
-	require.Equal(t, http.StatusBadRequest, zeroPageResp.Code)
+	zeroLimitResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?limit=0")
+	require.Equal(t, http.StatusBadRequest, zeroLimitResp.Code)
+
+	invalidCursorResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?cursor=bad")
+	require.Equal(t, http.StatusBadRequest, invalidCursorResp.Code)
 }
 
 func TestEICLookupByNIINAndLIN(t *testing.T) {
@@ -70,18 +74,18 @@ func TestEICBrowseByFSC(t *testing.T) {
 	}
 
 	_, _, fscValue, ok := fetchEicSample(t, testDB)
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/fsc/"+fscValue+"?page=1")
-	if ok {
-		require.Equal(t, http.StatusOK, resp.Code)
-
-		var payload standardResponse
-		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-		var data response.EICPageResponse
-		require.NoError(t, json.Unmarshal(payload.Data, &data))
-		require.NotEmpty(t, data.Items)
-	} else {
-		require.Equal(t, http.StatusNotFound, resp.Code)
+	if !ok {
+		t.Skip("no EIC data available")
 	}
+
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/fsc/"+fscValue)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var payload standardResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
+	var data pagination.Page[response.EICConsolidatedItem]
+	require.NoError(t, json.Unmarshal(payload.Data, &data))
+	require.NotEmpty(t, data.Items)
 }
 
 func TestEICBrowseAll(t *testing.T) {
@@ -91,24 +95,31 @@ func TestEICBrowseAll(t *testing.T) {
 		t.Skip("eic table missing in test DB")
 	}
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?limit=2&include_total=true")
 	consolidatedCount := countConsolidatedEic(t, testDB, "")
-	if consolidatedCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var payload standardResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
+	var data pagination.Page[response.EICConsolidatedItem]
+	require.NoError(t, json.Unmarshal(payload.Data, &data))
+	require.Len(t, data.Items, min(consolidatedCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, consolidatedCount, *data.Total)
+	require.Equal(t, consolidatedCount > 2, data.NextCursor != nil)
+	if data.NextCursor == nil {
 		return
 	}
 
+	resp = doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?limit=2&cursor="+*data.NextCursor)
 	require.Equal(t, http.StatusOK, resp.Code)
-	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data response.EICPageResponse
-	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
 
-	expectedTotalPages := int(math.Ceil(float64(consolidatedCount) / 40.0))
-	require.Equal(t, 1, data.Page)
-	require.Equal(t, expectedTotalPages, data.TotalPages)
-	require.Equal(t, 1 >= expectedTotalPages, data.IsLastPage)
+	var next pagination.Page[response.EICConsolidatedItem]
+	require.NoError(t, json.Unmarshal(payload.Data, &next))
+	require.NotEmpty(t, next.Items)
+	require.GreaterOrEqual(t, next.Items[0].Niin, data.Items[1].Niin)
+	require.NotEqual(t, data.Items[1], next.Items[0])
 }
 
 func TestEICSearchAll(t *testing.T) {
@@ -123,12 +134,14 @@ func TestEICSearchAll(t *testing.T) {
 		searchValue = niinValue
 	}
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?page=1&search="+searchValue)
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?search="+searchValue)
 	require.Equal(t, http.StatusInternalServerError, resp.Code)
 
-	var payload response.ErrorResponse
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+	var payload apperror.Problem
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 	require.Equal(t, http.StatusInternalServerError, payload.Status)
+	require.Equal(t, "internal_error", payload.Code)
 }
 
 func TestEICInternalError(t *testing.T) {
@@ -139,10 +152,12 @@ func TestEICInternalError(t *testing.T) {
 	})
 
 	router := newTestRouterWithDB(t, db)
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items?page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/eic/items")
 	require.Equal(t, http.StatusInternalServerError, resp.Code)
 
-	var payload response.ErrorResponse
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+	var payload apperror.Problem
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 	require.Equal(t, http.StatusInternalServerError, payload.Status)
+	require.Equal(t, "internal_error", payload.Code)
 }
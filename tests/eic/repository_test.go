@@ -1,10 +1,12 @@
 package eic_test
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
 	"miltechserver/api/eic"
+	"miltechserver/api/pagination"
 
 	"github.com/stretchr/testify/require"
 )
@@ -12,18 +14,19 @@ import (
 func TestEICRepositoryValidationErrors(t *testing.T) {
 	repo := eic.NewRepository((*sql.DB)(nil))
 
-	_, err := repo.GetByNIIN(" ")
+	_, err := repo.GetByNIIN(context.Background(), " ")
 	require.ErrorIs(t, err, eic.ErrEmptyParam)
 
-	_, err = repo.GetByLIN("\t")
+	_, err = repo.GetByLIN(context.Background(), "\t")
 	require.ErrorIs(t, err, eic.ErrEmptyParam)
 
-	_, err = repo.GetByFSCPaginated("", 1)
+	_, err = repo.GetByFSCPaginated(context.Background(), "", pagination.Params{Limit: 10})
 	require.ErrorIs(t, err, eic.ErrEmptyParam)
 
-	_, err = repo.GetByFSCPaginated("ABCD", 0)
-	require.ErrorIs(t, err, eic.ErrInvalidPage)
+	badCursor := pagination.Params{Cursor: "bad", Limit: 10}
+	_, err = repo.GetByFSCPaginated(context.Background(), "ABCD", badCursor)
+	require.ErrorIs(t, err, pagination.ErrInvalidCursor)
 
-	_, err = repo.GetAllPaginated(0, "")
-	require.ErrorIs(t, err, eic.ErrInvalidPage)
+	_, err = repo.GetAllPaginated(context.Background(), "", badCursor)
+	require.ErrorIs(t, err, pagination.ErrInvalidCursor)
 }
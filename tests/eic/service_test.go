@@ -1,10 +1,12 @@
 package eic_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	"miltechserver/api/eic"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 
 	"github.com/stretchr/testify/require"
@@ -14,51 +16,51 @@ type captureRepository struct {
 	niin   string
 	lin    string
 	fsc    string
-	page   int
+	params pagination.Params
 	search string
 }
 
-func (repo *captureRepository) GetByNIIN(niin string) ([]response.EICConsolidatedItem, error) {
+func (repo *captureRepository) GetByNIIN(_ context.Context, niin string) ([]response.EICConsolidatedItem, error) {
 	repo.niin = niin
 	return []response.EICConsolidatedItem{{Niin: "TEST"}}, nil
 }
 
-func (repo *captureRepository) GetByLIN(lin string) ([]response.EICConsolidatedItem, error) {
+func (repo *captureRepository) GetByLIN(_ context.Context, lin string) ([]response.EICConsolidatedItem, error) {
 	repo.lin = lin
 	return []response.EICConsolidatedItem{{Niin: "TEST"}}, nil
 }
 
-func (repo *captureRepository) GetByFSCPaginated(fsc string, page int) (response.EICPageResponse, error) {
+func (repo *captureRepository) GetByFSCPaginated(_ context.Context, fsc string, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error) {
 	repo.fsc = fsc
-	repo.page = page
-	return response.EICPageResponse{}, nil
+	repo.params = params
+	return pagination.Page[response.EICConsolidatedItem]{}, nil
 }
 
-func (repo *captureRepository) GetAllPaginated(page int, search string) (response.EICPageResponse, error) {
-	repo.page = page
+func (repo *captureRepository) GetAllPaginated(_ context.Context, search string, params pagination.Params) (pagination.Page[response.EICConsolidatedItem], error) {
+	repo.params = params
 	repo.search = search
-	return response.EICPageResponse{}, nil
+	return pagination.Page[response.EICConsolidatedItem]{}, nil
 }
 
 func TestEICServiceTrimsAndUppercases(t *testing.T) {
 	repo := &captureRepository{}
 	svc := eic.NewService(repo)
 
-	_, err := svc.LookupByNIIN("  abcd ")
+	_, err := svc.LookupByNIIN(context.Background(), "  abcd ")
 	require.NoError(t, err)
 	require.Equal(t, "ABCD", repo.niin)
 
-	_, err = svc.LookupByLIN("  l123 ")
+	_, err = svc.LookupByLIN(context.Background(), "  l123 ")
 	require.NoError(t, err)
 	require.Equal(t, "L123", repo.lin)
 
-	_, err = svc.LookupByFSCPaginated("  fsc ", 2)
+	_, err = svc.LookupByFSCPaginated(context.Background(), "  fsc ", pagination.Params{Limit: 2})
 	require.NoError(t, err)
 	require.Equal(t, "FSC", repo.fsc)
-	require.Equal(t, 2, repo.page)
+	require.Equal(t, 2, repo.params.Limit)
 
-	_, err = svc.LookupAllPaginated(3, "  search  ")
+	_, err = svc.LookupAllPaginated(context.Background(), "  search  ", pagination.Params{Limit: 3})
 	require.NoError(t, err)
-	require.Equal(t, 3, repo.page)
+	require.Equal(t, 3, repo.params.Limit)
 	require.Equal(t, strings.TrimSpace("  search  "), repo.search)
 }
@@ -31,7 +31,7 @@ func TestEquipmentServicesAccessDenied(t *testing.T) {
 	serviceID := createEquipmentService(t, router, "user-1", shopID, equipmentID, "", "Access test", &serviceDate, false)
 
 	listResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/equipment-services", nil, "user-2")
-	require.Equal(t, http.StatusInternalServerError, listResp.Code)
+	require.Equal(t, http.StatusForbidden, listResp.Code)
 
 	updateBody := map[string]interface{}{
 		"service_id":   serviceID,
@@ -43,10 +43,10 @@ func TestEquipmentServicesAccessDenied(t *testing.T) {
 	}
 
 	updateResp := doJSONRequest(t, router, http.MethodPut, "/api/v1/auth/shops/"+shopID+"/equipment-services/"+serviceID, updateBody, "user-2")
-	require.Equal(t, http.StatusInternalServerError, updateResp.Code)
+	require.Equal(t, http.StatusForbidden, updateResp.Code)
 
 	deleteResp := doJSONRequest(t, router, http.MethodDelete, "/api/v1/auth/shops/"+shopID+"/equipment-services/"+serviceID, nil, "user-2")
-	require.Equal(t, http.StatusInternalServerError, deleteResp.Code)
+	require.Equal(t, http.StatusForbidden, deleteResp.Code)
 }
 
 func TestEquipmentServicesValidationErrors(t *testing.T) {
@@ -99,7 +99,7 @@ func TestEquipmentServicesDateValidation(t *testing.T) {
 	params.Set("end_date", time.Now().AddDate(0, 0, 1).Format(time.RFC3339))
 
 	calendarResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/equipment-services/calendar?"+params.Encode(), nil, "user-1")
-	require.Equal(t, http.StatusInternalServerError, calendarResp.Code)
+	require.Equal(t, http.StatusBadRequest, calendarResp.Code)
 
 	queryParams := url.Values{}
 	queryParams.Set("start_date", "bad-date")
@@ -157,5 +157,5 @@ func TestEquipmentServicesNotFound(t *testing.T) {
 	shopID := createShop(t, router, "user-1", "NotFound Shop")
 
 	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/equipment-services/non-existent", nil, "user-1")
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusNotFound, resp.Code)
 }
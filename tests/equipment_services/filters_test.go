@@ -29,7 +29,7 @@ func TestEquipmentServicesListMismatch(t *testing.T) {
 	}
 
 	createResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/"+shopID+"/equipment-services", createBody, "user-1")
-	require.Equal(t, http.StatusInternalServerError, createResp.Code)
+	require.Equal(t, http.StatusBadRequest, createResp.Code)
 }
 
 func TestEquipmentServicesPaginationAndFilters(t *testing.T) {
@@ -69,5 +69,5 @@ func TestEquipmentServicesEquipmentAccessDenied(t *testing.T) {
 	equipmentID := createVehicle(t, router, "user-1", shopID)
 
 	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/equipment/"+equipmentID+"/services", nil, "user-2")
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusNotFound, resp.Code)
 }
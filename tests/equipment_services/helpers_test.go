@@ -16,7 +16,6 @@ import (
 	"miltechserver/api/shops"
 	"miltechserver/bootstrap"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 )
@@ -38,9 +37,9 @@ func newTestRouter(t *testing.T) *gin.Engine {
 	group := router.Group("/api/v1/auth")
 
 	shops.RegisterRoutes(shops.Dependencies{
-		DB:         testDB,
-		BlobClient: (*azblob.Client)(nil),
-		Env:        &bootstrap.Env{BlobAccountName: "test-account"},
+		DB:    testDB,
+		Store: nil,
+		Env:   &bootstrap.Env{BlobAccountName: "test-account"},
 	}, group)
 
 	equipment_services.RegisterRoutes(equipment_services.Dependencies{DB: testDB}, group)
@@ -60,7 +59,6 @@ func testUserMiddleware() gin.HandlerFunc {
 			UserID:   userID,
 			Username: c.GetHeader("X-User-Name"),
 			Email:    c.GetHeader("X-User-Email"),
-			Role:     "user",
 		}
 
 		if user.Username == "" {
@@ -51,7 +51,6 @@ func testUserMiddleware() gin.HandlerFunc {
 			UserID:   userID,
 			Username: c.GetHeader("X-User-Name"),
 			Email:    c.GetHeader("X-User-Email"),
-			Role:     "user",
 		}
 
 		if user.Username == "" {
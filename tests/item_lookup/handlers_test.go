@@ -3,11 +3,13 @@ package item_lookup_test
 import (
 	"database/sql"
 	"encoding/json"
-	"math"
 	"net/http"
 	"testing"
 
+	"miltechserver/.gen/miltech_ng/public/model"
+	"miltechserver/api/apperror"
 	"miltechserver/api/item_lookup/shared"
+	"miltechserver/api/pagination"
 	"miltechserver/api/response"
 
 	"github.com/stretchr/testify/require"
@@ -16,31 +18,27 @@ import (
 func TestItemLookupLinRoutes(t *testing.T) {
 	router := newTestRouter(t)
 
-	missingPage := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin")
-	require.Equal(t, http.StatusBadRequest, missingPage.Code)
+	invalidLimit := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?limit=bad")
+	require.Equal(t, http.StatusBadRequest, invalidLimit.Code)
 
-	invalidPage := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?page=bad")
-	require.Equal(t, http.StatusBadRequest, invalidPage.Code)
+	zeroLimit := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?limit=0")
+	require.Equal(t, http.StatusBadRequest, zeroLimit.Code)
 
-	zeroPage := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?page=0")
-	require.Equal(t, http.StatusBadRequest, zeroPage.Code)
+	invalidCursor := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?cursor=bad")
+	require.Equal(t, http.StatusBadRequest, invalidCursor.Code)
 
 	if !hasRelation(t, testDB, "lookup_lin_niin_mat") {
 		t.Skip("lookup_lin_niin_mat view missing in test DB")
 	}
 
 	rowCount := countRows(t, testDB, "lookup_lin_niin_mat")
-	pageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, pageResp.Code)
-	} else {
-		require.Equal(t, http.StatusOK, pageResp.Code)
-		var payload standardResponse
-		require.NoError(t, json.Unmarshal(pageResp.Body.Bytes(), &payload))
-		var data response.LINPageResponse
-		require.NoError(t, json.Unmarshal(payload.Data, &data))
-		require.NotEmpty(t, data.Lins)
-	}
+	pageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin")
+	require.Equal(t, http.StatusOK, pageResp.Code)
+	var pagePayload standardResponse
+	require.NoError(t, json.Unmarshal(pageResp.Body.Bytes(), &pagePayload))
+	var page pagination.Page[model.LookupLinNiinMat]
+	require.NoError(t, json.Unmarshal(pagePayload.Data, &page))
+	require.Len(t, page.Items, min(rowCount, shared.DefaultLimit))
 
 	linValue, niinValue, ok := fetchLinSample(t, testDB)
 	if ok {
@@ -74,31 +72,27 @@ func TestItemLookupLinRoutes(t *testing.T) {
 func TestItemLookupUocRoutes(t *testing.T) {
 	router := newTestRouter(t)
 
-	missingPage := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc")
-	require.Equal(t, http.StatusBadRequest, missingPage.Code)
+	invalidLimit := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?limit=bad")
+	require.Equal(t, http.StatusBadRequest, invalidLimit.Code)
 
-	invalidPage := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?page=bad")
-	require.Equal(t, http.StatusBadRequest, invalidPage.Code)
+	zeroLimit := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?limit=0")
+	require.Equal(t, http.StatusBadRequest, zeroLimit.Code)
 
-	zeroPage := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?page=0")
-	require.Equal(t, http.StatusBadRequest, zeroPage.Code)
+	invalidCursor := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?cursor=bad")
+	require.Equal(t, http.StatusBadRequest, invalidCursor.Code)
 
 	if !hasRelation(t, testDB, "lookup_uoc") {
 		t.Skip("lookup_uoc table missing in test DB")
 	}
 
 	rowCount := countRows(t, testDB, "lookup_uoc")
-	pageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, pageResp.Code)
-	} else {
-		require.Equal(t, http.StatusOK, pageResp.Code)
-		var payload standardResponse
-		require.NoError(t, json.Unmarshal(pageResp.Body.Bytes(), &payload))
-		var data response.UOCPageResponse
-		require.NoError(t, json.Unmarshal(payload.Data, &data))
-		require.NotEmpty(t, data.UOCs)
-	}
+	pageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc")
+	require.Equal(t, http.StatusOK, pageResp.Code)
+	var pagePayload standardResponse
+	require.NoError(t, json.Unmarshal(pageResp.Body.Bytes(), &pagePayload))
+	var page pagination.Page[model.LookupUoc]
+	require.NoError(t, json.Unmarshal(pagePayload.Data, &page))
+	require.Len(t, page.Items, min(rowCount, shared.DefaultLimit))
 
 	uocValue, modelValue, ok := fetchUocSample(t, testDB)
 	if ok {
@@ -200,12 +194,14 @@ func TestItemLookupInternalError(t *testing.T) {
 	})
 
 	router := newTestRouterWithDB(t, db)
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin")
 	require.Equal(t, http.StatusInternalServerError, resp.Code)
 
-	var payload response.ErrorResponse
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+	var payload apperror.Problem
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 	require.Equal(t, http.StatusInternalServerError, payload.Status)
+	require.Equal(t, "internal_error", payload.Code)
 }
 
 func TestItemLookupPaginationMetadata(t *testing.T) {
@@ -220,19 +216,29 @@ func TestItemLookupPaginationMetadata(t *testing.T) {
 		t.Skip("no LIN data available for pagination metadata test")
 	}
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?limit=1&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data response.LINPageResponse
+	var data pagination.Page[model.LookupLinNiinMat]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
 
-	expectedTotalPages := int(math.Ceil(float64(total) / float64(shared.DefaultPageSize)))
-	require.Equal(t, total, data.Count)
-	require.Equal(t, 1, data.Page)
-	require.Equal(t, expectedTotalPages, data.TotalPages)
-	require.Equal(t, 1 >= expectedTotalPages, data.IsLastPage)
+	require.Len(t, data.Items, 1)
+	require.NotNil(t, data.Total)
+	require.Equal(t, total, *data.Total)
+	require.Equal(t, total > 1, data.NextCursor != nil)
+	if data.NextCursor == nil {
+		return
+	}
+
+	resp = doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/lin?limit=1&cursor="+*data.NextCursor)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
+	var next pagination.Page[model.LookupLinNiinMat]
+	require.NoError(t, json.Unmarshal(payload.Data, &next))
+	require.Len(t, next.Items, 1)
+	require.NotEqual(t, data.Items[0], next.Items[0])
 }
 
 func TestItemLookupUocPaginationMetadata(t *testing.T) {
@@ -247,17 +253,27 @@ func TestItemLookupUocPaginationMetadata(t *testing.T) {
 		t.Skip("no UOC data available for pagination metadata test")
 	}
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?limit=1&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data response.UOCPageResponse
+	var data pagination.Page[model.LookupUoc]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
 
-	expectedTotalPages := int(math.Ceil(float64(total) / float64(shared.DefaultPageSize)))
-	require.Equal(t, total, data.Count)
-	require.Equal(t, 1, data.Page)
-	require.Equal(t, expectedTotalPages, data.TotalPages)
-	require.Equal(t, 1 >= expectedTotalPages, data.IsLastPage)
+	require.Len(t, data.Items, 1)
+	require.NotNil(t, data.Total)
+	require.Equal(t, total, *data.Total)
+	require.Equal(t, total > 1, data.NextCursor != nil)
+	if data.NextCursor == nil {
+		return
+	}
+
+	resp = doJSONRequest(t, router, http.MethodGet, "/api/v1/lookup/uoc?limit=1&cursor="+*data.NextCursor)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
+	var next pagination.Page[model.LookupUoc]
+	require.NoError(t, json.Unmarshal(payload.Data, &next))
+	require.Len(t, next.Items, 1)
+	require.NotEqual(t, data.Items[0], next.Items[0])
 }
@@ -0,0 +1,175 @@
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"miltechserver/api/admin/scheduled_jobs"
+	"miltechserver/jobs"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerRecordsRun(t *testing.T) {
+	name := jobName()
+	release := make(chan struct{})
+	job, started := blockingJob(name, release)
+	router := newTestRouter(t, newScheduler(t, job))
+
+	resp := doAdminRequest(t, router, http.MethodPost, "/api/v1/auth/admin/jobs/"+name+"/run")
+	require.Equal(t, http.StatusAccepted, resp.Code)
+
+	run := decodeRun(t, resp.Body)
+	require.NotZero(t, run.ID)
+	require.Equal(t, name, run.JobName)
+	require.Equal(t, jobs.TriggerManual, run.Trigger)
+	require.Equal(t, jobs.StatusRunning, run.Status)
+	require.NotNil(t, run.TriggeredBy)
+	require.Equal(t, adminUserID, *run.TriggeredBy)
+
+	<-started
+	require.Equal(t, jobs.StatusRunning, getRun(t, testDB, run.ID).Status)
+	close(release)
+
+	finished := waitForRun(t, testDB, run.ID)
+	require.Equal(t, jobs.StatusSucceeded, finished.Status)
+	require.Nil(t, finished.Error)
+	require.NotNil(t, finished.FinishedAt)
+	require.NotNil(t, finished.DurationMS)
+}
+
+func TestTriggerRecordsFailures(t *testing.T) {
+	failing := jobs.Job{
+		Name:     jobName(),
+		Schedule: "@daily",
+		Run:      func(context.Context) error { return errors.New("disk full") },
+	}
+	panicking := jobs.Job{
+		Name:     jobName(),
+		Schedule: "@daily",
+		Run:      func(context.Context) error { panic("nil map") },
+	}
+	router := newTestRouter(t, newScheduler(t, failing, panicking))
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: failing.Name, want: "disk full"},
+		{name: panicking.Name, want: "panic: nil map"},
+	}
+	for _, tt := range tests {
+		resp := doAdminRequest(t, router, http.MethodPost, "/api/v1/auth/admin/jobs/"+tt.name+"/run")
+		require.Equal(t, http.StatusAccepted, resp.Code)
+
+		run := waitForRun(t, testDB, decodeRun(t, resp.Body).ID)
+		require.Equal(t, jobs.StatusFailed, run.Status)
+		require.NotNil(t, run.Error)
+		require.Equal(t, tt.want, *run.Error)
+	}
+}
+
+func TestTriggerWhileRunningConflicts(t *testing.T) {
+	name := jobName()
+	release := make(chan struct{})
+	job, started := blockingJob(name, release)
+	router := newTestRouter(t, newScheduler(t, job))
+
+	first := doAdminRequest(t, router, http.MethodPost, "/api/v1/auth/admin/jobs/"+name+"/run")
+	require.Equal(t, http.StatusAccepted, first.Code)
+	<-started
+
+	second := doAdminRequest(t, router, http.MethodPost, "/api/v1/auth/admin/jobs/"+name+"/run")
+	require.Equal(t, http.StatusConflict, second.Code)
+	require.Equal(t, "job_running", decodeProblem(t, second.Body).Code)
+
+	close(release)
+	waitForRun(t, testDB, decodeRun(t, first.Body).ID)
+
+	third := doAdminRequest(t, router, http.MethodPost, "/api/v1/auth/admin/jobs/"+name+"/run")
+	require.Equal(t, http.StatusAccepted, third.Code)
+}
+
+func TestListJobsAndRuns(t *testing.T) {
+	name := jobName()
+	idle := jobName()
+	job := jobs.Job{Name: name, Schedule: "15 4 * * *", Run: func(context.Context) error { return nil }}
+	idleJob := jobs.Job{Name: idle, Schedule: "@hourly", Run: func(context.Context) error { return nil }}
+	router := newTestRouter(t, newScheduler(t, job, idleJob))
+
+	var runIDs []int64
+	for range 3 {
+		resp := doAdminRequest(t, router, http.MethodPost, "/api/v1/auth/admin/jobs/"+name+"/run")
+		require.Equal(t, http.StatusAccepted, resp.Code)
+		runIDs = append(runIDs, waitForRun(t, testDB, decodeRun(t, resp.Body).ID).ID)
+	}
+
+	listResp := doAdminRequest(t, router, http.MethodGet, "/api/v1/auth/admin/jobs")
+	require.Equal(t, http.StatusOK, listResp.Code)
+
+	var statuses []scheduled_jobs.JobStatus
+	require.NoError(t, json.Unmarshal(decodeStandardResponse(t, listResp.Body).Data, &statuses))
+	require.Len(t, statuses, 2)
+
+	byName := map[string]scheduled_jobs.JobStatus{}
+	for _, status := range statuses {
+		byName[status.Name] = status
+	}
+	require.Equal(t, "15 4 * * *", byName[name].Schedule)
+	require.False(t, byName[name].NextRun.IsZero())
+	require.NotNil(t, byName[name].LastRun)
+	require.Equal(t, runIDs[2], byName[name].LastRun.ID)
+	require.Nil(t, byName[idle].LastRun)
+
+	pageResp := doAdminRequest(t, router, http.MethodGet, "/api/v1/auth/admin/jobs/"+name+"/runs?limit=2&include_total=true")
+	require.Equal(t, http.StatusOK, pageResp.Code)
+
+	var page struct {
+		Items      []jobs.Run `json:"items"`
+		NextCursor *string    `json:"next_cursor"`
+		Total      *int       `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(decodeStandardResponse(t, pageResp.Body).Data, &page))
+	require.Len(t, page.Items, 2)
+	require.Equal(t, runIDs[2], page.Items[0].ID)
+	require.Equal(t, runIDs[1], page.Items[1].ID)
+	require.NotNil(t, page.NextCursor)
+	require.NotNil(t, page.Total)
+	require.Equal(t, 3, *page.Total)
+}
+
+func TestUnknownJob(t *testing.T) {
+	router := newTestRouter(t, newScheduler(t))
+
+	runResp := doAdminRequest(t, router, http.MethodPost, "/api/v1/auth/admin/jobs/missing/run")
+	require.Equal(t, http.StatusNotFound, runResp.Code)
+	require.Equal(t, "job_not_found", decodeProblem(t, runResp.Body).Code)
+
+	runsResp := doAdminRequest(t, router, http.MethodGet, "/api/v1/auth/admin/jobs/missing/runs")
+	require.Equal(t, http.StatusNotFound, runsResp.Code)
+}
+
+func TestAdminJobsRequireSiteAdmin(t *testing.T) {
+	name := jobName()
+	ran := make(chan struct{}, 1)
+	job := jobs.Job{Name: name, Schedule: "@daily", Run: func(context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}}
+	router := newTestRouter(t, newScheduler(t, job))
+
+	anonymous := doRequest(t, router, http.MethodGet, "/api/v1/auth/admin/jobs", "")
+	require.Equal(t, http.StatusUnauthorized, anonymous.Code)
+
+	moderator := doRequest(t, router, http.MethodPost, "/api/v1/auth/admin/jobs/"+name+"/run", "jobs-moderator", "moderator")
+	require.Equal(t, http.StatusForbidden, moderator.Code)
+	require.Equal(t, "role_required", decodeProblem(t, moderator.Body).Code)
+
+	require.Empty(t, ran)
+	var count int
+	require.NoError(t, testDB.QueryRow(`SELECT COUNT(*) FROM job_runs WHERE job_name = $1`, name).Scan(&count))
+	require.Zero(t, count)
+}
@@ -0,0 +1,209 @@
+package jobs_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"miltechserver/api/admin"
+	"miltechserver/api/apperror"
+	"miltechserver/api/middleware"
+	"miltechserver/bootstrap"
+	"miltechserver/jobs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const adminUserID = "jobs-admin"
+
+type standardResponse struct {
+	Status  int             `json:"status"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+}
+
+func newTestRouter(t *testing.T, scheduler *jobs.Scheduler) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	router.Use(testUserMiddleware())
+
+	group := router.Group("/api/v1/auth")
+
+	admin.RegisterRoutes(admin.Dependencies{DB: testDB, Jobs: scheduler}, group)
+
+	return router
+}
+
+// testUserMiddleware authenticates X-User-ID with the comma-separated roles
+// in X-User-Roles.
+func testUserMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetHeader("X-User-ID")
+		if userID == "" {
+			c.Next()
+			return
+		}
+
+		user := &bootstrap.User{
+			UserID:   userID,
+			Username: "test-user",
+			Email:    userID + "@example.com",
+		}
+		if roles := c.GetHeader("X-User-Roles"); roles != "" {
+			user.Roles = strings.Split(roles, ",")
+		}
+
+		c.Set("user", user)
+		c.Next()
+	}
+}
+
+func doRequest(t *testing.T, router *gin.Engine, method string, path string, userID string, roles ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, path, nil)
+	require.NoError(t, err)
+
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	if len(roles) > 0 {
+		req.Header.Set("X-User-Roles", strings.Join(roles, ","))
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func doAdminRequest(t *testing.T, router *gin.Engine, method string, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	return doRequest(t, router, method, path, adminUserID, bootstrap.RoleSiteAdmin)
+}
+
+func decodeStandardResponse(t *testing.T, body *bytes.Buffer) standardResponse {
+	t.Helper()
+
+	var resp standardResponse
+	err := json.Unmarshal(body.Bytes(), &resp)
+	require.NoError(t, err)
+	return resp
+}
+
+func decodeProblem(t *testing.T, body *bytes.Buffer) apperror.Problem {
+	t.Helper()
+
+	var problem apperror.Problem
+	err := json.Unmarshal(body.Bytes(), &problem)
+	require.NoError(t, err)
+	return problem
+}
+
+func decodeRun(t *testing.T, body *bytes.Buffer) jobs.Run {
+	t.Helper()
+
+	var run jobs.Run
+	err := json.Unmarshal(decodeStandardResponse(t, body).Data, &run)
+	require.NoError(t, err)
+	return run
+}
+
+// jobName returns a name no other test uses, so each test sees only its own
+// job_runs rows.
+func jobName() string {
+	return "test-" + uuid.New().String()[:8]
+}
+
+// newScheduler returns an unstarted scheduler that is stopped when the test
+// ends.
+func newScheduler(t *testing.T, registered ...jobs.Job) *jobs.Scheduler {
+	t.Helper()
+
+	scheduler := jobs.New(testDB)
+	for _, job := range registered {
+		scheduler.Register(job)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, scheduler.Stop(ctx))
+	})
+	return scheduler
+}
+
+// blockingJob returns a job that runs until release is closed, and a
+// channel that receives once the job has started.
+func blockingJob(name string, release <-chan struct{}) (jobs.Job, <-chan struct{}) {
+	started := make(chan struct{}, 1)
+	return jobs.Job{
+		Name:     name,
+		Schedule: "@daily",
+		Run: func(ctx context.Context) error {
+			started <- struct{}{}
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}, started
+}
+
+// waitForRun polls job_runs until the run has left the running status and
+// returns it as stored.
+func waitForRun(t *testing.T, db *sql.DB, id int64) jobs.Run {
+	t.Helper()
+
+	var run jobs.Run
+	require.Eventually(t, func() bool {
+		run = getRun(t, db, id)
+		return run.Status != jobs.StatusRunning
+	}, 5*time.Second, 20*time.Millisecond)
+	return run
+}
+
+func getRun(t *testing.T, db *sql.DB, id int64) jobs.Run {
+	t.Helper()
+
+	var run jobs.Run
+	err := db.QueryRow(`
+		SELECT id, job_name, trigger, triggered_by, instance, status, error, started_at, finished_at, duration_ms
+		FROM job_runs WHERE id = $1`, id).Scan(
+		&run.ID,
+		&run.JobName,
+		&run.Trigger,
+		&run.TriggeredBy,
+		&run.Instance,
+		&run.Status,
+		&run.Error,
+		&run.StartedAt,
+		&run.FinishedAt,
+		&run.DurationMS,
+	)
+	require.NoError(t, err)
+	return run
+}
+
+func insertRun(t *testing.T, db *sql.DB, name string, status string, startedAt time.Time) int64 {
+	t.Helper()
+
+	var id int64
+	err := db.QueryRow(`
+		INSERT INTO job_runs (job_name, trigger, instance, status, started_at)
+		VALUES ($1, 'schedule', 'test-instance', $2, $3)
+		RETURNING id`, name, status, startedAt).Scan(&id)
+	require.NoError(t, err)
+	return id
+}
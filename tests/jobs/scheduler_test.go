@@ -0,0 +1,119 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"miltechserver/jobs"
+
+	"github.com/stretchr/testify/require"
+)
+
+const electEvery = 50 * time.Millisecond
+
+func TestRunLockIsSharedAcrossReplicas(t *testing.T) {
+	name := jobName()
+	release := make(chan struct{})
+	job, started := blockingJob(name, release)
+
+	first := newScheduler(t, job)
+	second := newScheduler(t, job)
+
+	run, err := first.Trigger(context.Background(), name, adminUserID)
+	require.NoError(t, err)
+	<-started
+
+	_, err = second.Trigger(context.Background(), name, adminUserID)
+	require.ErrorIs(t, err, jobs.ErrJobRunning)
+
+	close(release)
+	require.Equal(t, jobs.StatusSucceeded, waitForRun(t, testDB, run.ID).Status)
+
+	// The lock went back with the run, so the other replica can take it
+	again, err := second.Trigger(context.Background(), name, adminUserID)
+	require.NoError(t, err)
+	require.Equal(t, jobs.StatusSucceeded, waitForRun(t, testDB, again.ID).Status)
+}
+
+func TestClaimAbandonsRunsLeftRunning(t *testing.T) {
+	name := jobName()
+	stale := insertRun(t, testDB, name, jobs.StatusRunning, time.Now().Add(-time.Hour))
+	scheduler := newScheduler(t, jobs.Job{Name: name, Schedule: "@daily", Run: func(context.Context) error { return nil }})
+
+	run, err := scheduler.Trigger(context.Background(), name, adminUserID)
+	require.NoError(t, err)
+
+	abandoned := getRun(t, testDB, stale)
+	require.Equal(t, jobs.StatusAbandoned, abandoned.Status)
+	require.NotNil(t, abandoned.FinishedAt)
+
+	require.Equal(t, jobs.StatusSucceeded, waitForRun(t, testDB, run.ID).Status)
+}
+
+func TestStopCancelsRunsInProgress(t *testing.T) {
+	name := jobName()
+	job, started := blockingJob(name, make(chan struct{}))
+	scheduler := jobs.New(testDB)
+	scheduler.Register(job)
+
+	run, err := scheduler.Trigger(context.Background(), name, adminUserID)
+	require.NoError(t, err)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, scheduler.Stop(ctx))
+
+	stopped := getRun(t, testDB, run.ID)
+	require.Equal(t, jobs.StatusFailed, stopped.Status)
+	require.NotNil(t, stopped.Error)
+	require.Equal(t, context.Canceled.Error(), *stopped.Error)
+
+	_, err = scheduler.Trigger(context.Background(), name, adminUserID)
+	require.ErrorIs(t, err, jobs.ErrStopped)
+}
+
+func TestOneReplicaLeadsAtATime(t *testing.T) {
+	first := newScheduler(t)
+	second := newScheduler(t)
+
+	first.Start(electEvery)
+	require.Eventually(t, first.Leader, 5*time.Second, 10*time.Millisecond)
+
+	second.Start(electEvery)
+	require.Never(t, second.Leader, 5*electEvery, 10*time.Millisecond)
+	require.True(t, first.Leader())
+
+	// Stopping the leader resigns, and the other replica takes over at its
+	// next election
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, first.Stop(ctx))
+	require.False(t, first.Leader())
+	require.Eventually(t, second.Leader, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestPruneRunsKeepsRecentAndRunningRuns(t *testing.T) {
+	name := jobName()
+	old := insertRun(t, testDB, name, jobs.StatusSucceeded, time.Now().Add(-100*24*time.Hour))
+	oldRunning := insertRun(t, testDB, name, jobs.StatusRunning, time.Now().Add(-100*24*time.Hour))
+	recent := insertRun(t, testDB, name, jobs.StatusFailed, time.Now().Add(-time.Hour))
+
+	prune := jobs.PruneRuns(testDB, 90*24*time.Hour)
+	require.NoError(t, prune.Run(context.Background()))
+
+	rows, err := testDB.Query(`SELECT id FROM job_runs WHERE job_name = $1 ORDER BY id`, name)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var remaining []int64
+	for rows.Next() {
+		var id int64
+		require.NoError(t, rows.Scan(&id))
+		remaining = append(remaining, id)
+	}
+	require.NoError(t, rows.Err())
+	require.NotContains(t, remaining, old)
+	require.Equal(t, []int64{oldRunning, recent}, remaining)
+}
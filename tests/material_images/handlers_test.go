@@ -148,6 +148,40 @@ func TestMaterialImagesRateLimit(t *testing.T) {
 		[]byte("image-bytes"),
 		userID,
 	)
-	require.Equal(t, http.StatusBadRequest, rateResp.Code)
+	require.Equal(t, http.StatusTooManyRequests, rateResp.Code)
 	require.Contains(t, rateResp.Body.String(), "rate limit exceeded")
+	require.Equal(t, "3", rateResp.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "0", rateResp.Header().Get("RateLimit-Remaining"))
+	require.NotEmpty(t, rateResp.Header().Get("Retry-After"))
+}
+
+func TestMaterialImagesFailedUploadsDoNotCount(t *testing.T) {
+	clearMaterialImagesTables(t, testDB)
+	userID := "user-rate-failed"
+	ensureUser(t, testDB, userID)
+
+	router := newTestRouter(t)
+	upload := func(fileField string) int {
+		return doMultipartRequest(
+			t,
+			router,
+			http.MethodPost,
+			"/api/v1/auth/material-images/upload",
+			map[string]string{"niin": "555555555"},
+			fileField,
+			"test.jpg",
+			[]byte("image-bytes"),
+			userID,
+		).Code
+	}
+
+	// Uploads without an image fail before anything is stored
+	for i := 0; i < 4; i++ {
+		require.Equal(t, http.StatusBadRequest, upload("photo"))
+	}
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusCreated, upload("image"))
+	}
+	require.Equal(t, http.StatusTooManyRequests, upload("image"))
 }
@@ -14,8 +14,8 @@ import (
 	"miltechserver/api/material_images"
 	"miltechserver/api/middleware"
 	"miltechserver/bootstrap"
+	"miltechserver/ratelimit"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 )
@@ -32,10 +32,11 @@ func newTestRouter(t *testing.T) *gin.Engine {
 	authGroup := router.Group("/api/v1/auth")
 
 	deps := material_images.Dependencies{
-		DB:         testDB,
-		BlobClient: (*azblob.Client)(nil),
-		Env:        &bootstrap.Env{BlobAccountName: "test-account"},
-		AuthClient: nil,
+		DB:       testDB,
+		Store:    nil,
+		Env:      &bootstrap.Env{BlobAccountName: "test-account"},
+		Verifier: nil,
+		Limiter:  ratelimit.New(ratelimit.NewMemoryStore(), 0),
 	}
 
 	material_images.RegisterRoutes(deps, publicGroup, authGroup)
@@ -55,7 +56,6 @@ func testUserMiddleware() gin.HandlerFunc {
 			UserID:   userID,
 			Username: c.GetHeader("X-User-Name"),
 			Email:    c.GetHeader("X-User-Email"),
-			Role:     "user",
 		}
 
 		if user.Username == "" {
@@ -147,15 +147,14 @@ func ensureUser(t *testing.T, db *sql.DB, userID string) {
 func clearMaterialImagesTables(t *testing.T, db *sql.DB) {
 	t.Helper()
 
-	if !hasTable(t, db, "material_images_upload_limits") {
-		t.Skip("material_images_upload_limits table missing in test DB")
+	if !hasTable(t, db, "material_images") {
+		t.Skip("material_images table missing in test DB")
 	}
 
 	_, err := db.Exec(
 		`TRUNCATE TABLE
 			material_images_flags,
 			material_images_votes,
-			material_images_upload_limits,
 			material_images
 		RESTART IDENTITY CASCADE`,
 	)
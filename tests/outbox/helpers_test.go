@@ -0,0 +1,158 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"miltechserver/events"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+const pollEvery = 50 * time.Millisecond
+
+// outboxRow is an outbox_events row as the dispatcher left it.
+type outboxRow struct {
+	ID           int64
+	EventType    string
+	ShopID       sql.NullString
+	Payload      []byte
+	Attempts     int
+	AvailableAt  time.Time
+	LockedUntil  *time.Time
+	DeliveredTo  []string
+	LastError    *string
+	DispatchedAt *time.Time
+	FailedAt     *time.Time
+}
+
+func clearOutbox(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`TRUNCATE TABLE outbox_events RESTART IDENTITY`)
+	require.NoError(t, err)
+}
+
+// newBus returns a bus on testDB that is stopped when the test ends.
+func newBus(t *testing.T) *events.Bus {
+	t.Helper()
+
+	bus := events.NewBus(testDB)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, bus.Stop(ctx))
+	})
+	return bus
+}
+
+// publish stores event outside a transaction and returns its outbox ID.
+func publish(t *testing.T, bus *events.Bus, event events.Event) int64 {
+	t.Helper()
+
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	var id int64
+	err := testDB.QueryRow(`SELECT max(id) FROM outbox_events`).Scan(&id)
+	require.NoError(t, err)
+	return id
+}
+
+// insertEvent stores a due event directly, with the given attempts and
+// lease, as another replica might have left it.
+func insertEvent(t *testing.T, db *sql.DB, event events.Event, attempts int, lockedUntil *time.Time) int64 {
+	t.Helper()
+
+	var id int64
+	err := db.QueryRow(`
+		INSERT INTO outbox_events (event_type, shop_id, payload, occurred_at, attempts, locked_until)
+		VALUES ($1, $2, $3, now(), $4, $5)
+		RETURNING id`,
+		event.EventType(), event.EventShopID(), `{"shop_id":"`+event.EventShopID()+`"}`, attempts, lockedUntil).Scan(&id)
+	require.NoError(t, err)
+	return id
+}
+
+func getOutboxRow(t *testing.T, db *sql.DB, id int64) outboxRow {
+	t.Helper()
+
+	var row outboxRow
+	err := db.QueryRow(`
+		SELECT id, event_type, shop_id, payload, attempts, available_at, locked_until,
+		       delivered_to, last_error, dispatched_at, failed_at
+		FROM outbox_events WHERE id = $1`, id).Scan(
+		&row.ID,
+		&row.EventType,
+		&row.ShopID,
+		&row.Payload,
+		&row.Attempts,
+		&row.AvailableAt,
+		&row.LockedUntil,
+		pq.Array(&row.DeliveredTo),
+		&row.LastError,
+		&row.DispatchedAt,
+		&row.FailedAt,
+	)
+	require.NoError(t, err)
+	return row
+}
+
+func countOutbox(t *testing.T, db *sql.DB) int {
+	t.Helper()
+
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM outbox_events`).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+// waitForOutbox polls the row until done reports true and returns it.
+func waitForOutbox(t *testing.T, db *sql.DB, id int64, done func(outboxRow) bool) outboxRow {
+	t.Helper()
+
+	var row outboxRow
+	require.Eventually(t, func() bool {
+		row = getOutboxRow(t, db, id)
+		return done(row)
+	}, 5*time.Second, 20*time.Millisecond)
+	return row
+}
+
+func dispatched(row outboxRow) bool {
+	return row.DispatchedAt != nil
+}
+
+// recorder is a subscriber that records the envelopes it receives and
+// fails while failures is positive.
+type recorder struct {
+	received chan events.Envelope
+	failures int
+}
+
+func newRecorder(failures int) *recorder {
+	return &recorder{received: make(chan events.Envelope, 16), failures: failures}
+}
+
+func (r *recorder) handle(_ context.Context, env events.Envelope) error {
+	r.received <- env
+	if r.failures > 0 {
+		r.failures--
+		return errTransient
+	}
+	return nil
+}
+
+func (r *recorder) next(t *testing.T) events.Envelope {
+	t.Helper()
+
+	select {
+	case env := <-r.received:
+		return env
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return events.Envelope{}
+	}
+}
@@ -0,0 +1,94 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+// outboxLockID is shared with every test package that runs a dispatcher.
+// A dispatcher claims any due event in outbox_events, so two packages
+// dispatching at once would take each other's events.
+const outboxLockID int64 = 70021
+
+var testDB *sql.DB
+
+func TestMain(m *testing.M) {
+	_ = loadEnv()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		log.Fatal("TEST_DATABASE_URL is not set")
+	}
+
+	var err error
+	testDB, err = sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatalf("failed to open test database: %v", err)
+	}
+
+	if err := testDB.Ping(); err != nil {
+		log.Fatalf("failed to ping test database: %v", err)
+	}
+
+	unlock := lockOutbox(testDB)
+	exitCode := m.Run()
+	unlock()
+
+	if err := testDB.Close(); err != nil {
+		log.Printf("failed to close test database: %v", err)
+	}
+
+	os.Exit(exitCode)
+}
+
+func lockOutbox(db *sql.DB) func() {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		log.Fatalf("failed to reserve outbox lock connection: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, outboxLockID); err != nil {
+		_ = conn.Close()
+		log.Fatalf("failed to lock the outbox: %v", err)
+	}
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, outboxLockID); err != nil {
+			log.Printf("failed to unlock the outbox: %v", err)
+		}
+		if err := conn.Close(); err != nil {
+			log.Printf("failed to close outbox lock connection: %v", err)
+		}
+	}
+}
+
+func loadEnv() error {
+	if os.Getenv("TEST_DATABASE_URL") != "" {
+		return nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	current := wd
+	for {
+		envPath := filepath.Join(current, ".env")
+		if _, statErr := os.Stat(envPath); statErr == nil {
+			return godotenv.Load(envPath)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil
+		}
+		current = parent
+	}
+}
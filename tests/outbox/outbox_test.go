@@ -0,0 +1,251 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"miltechserver/dbtx"
+	"miltechserver/events"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = errors.New("subscriber unavailable")
+
+func TestPublishStoresEventsWithTheTransaction(t *testing.T) {
+	clearOutbox(t, testDB)
+	bus := newBus(t)
+
+	joined := events.MemberJoined{ShopID: "shop-1", UserID: "user-1", Role: "member"}
+	err := bus.InTx(context.Background(), func(ctx context.Context) error {
+		require.True(t, dbtx.Active(ctx))
+		return bus.Publish(ctx, joined)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, countOutbox(t, testDB))
+
+	row := getOutboxRow(t, testDB, 1)
+	require.Equal(t, events.TypeMemberJoined, row.EventType)
+	require.Equal(t, "shop-1", row.ShopID.String)
+	require.Zero(t, row.Attempts)
+	require.Empty(t, row.DeliveredTo)
+	require.Nil(t, row.DispatchedAt)
+
+	var stored events.MemberJoined
+	require.NoError(t, json.Unmarshal(row.Payload, &stored))
+	require.Equal(t, joined, stored)
+
+	// A rolled back transaction takes its events with it
+	err = bus.InTx(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, bus.Publish(ctx, events.MemberLeft{ShopID: "shop-1", UserID: "user-1"}))
+		return errTransient
+	})
+	require.ErrorIs(t, err, errTransient)
+	require.Equal(t, 1, countOutbox(t, testDB))
+}
+
+func TestDispatchDeliversToEachSubscriber(t *testing.T) {
+	clearOutbox(t, testDB)
+	bus := newBus(t)
+
+	joins := newRecorder(0)
+	all := newRecorder(0)
+	bus.Subscribe("test-joins", joins.handle, events.TypeMemberJoined)
+	bus.Subscribe("test-all", all.handle)
+
+	typed := make(chan events.MemberJoined, 1)
+	events.Subscribe(bus, "test-typed", func(_ context.Context, event events.MemberJoined, _ events.Envelope) error {
+		typed <- event
+		return nil
+	})
+
+	bus.Start(pollEvery)
+
+	joinedID := publish(t, bus, events.MemberJoined{ShopID: "shop-1", UserID: "user-1", Role: "member"})
+	leftID := publish(t, bus, events.MemberLeft{ShopID: "shop-1", UserID: "user-2"})
+
+	joined := waitForOutbox(t, testDB, joinedID, dispatched)
+	require.ElementsMatch(t, []string{"test-joins", "test-all", "test-typed"}, joined.DeliveredTo)
+	require.Equal(t, 1, joined.Attempts)
+	require.Nil(t, joined.LockedUntil)
+	require.Nil(t, joined.LastError)
+
+	left := waitForOutbox(t, testDB, leftID, dispatched)
+	require.Equal(t, []string{"test-all"}, left.DeliveredTo)
+
+	env := joins.next(t)
+	require.Equal(t, joinedID, env.ID)
+	require.Equal(t, "shop-1", env.ShopID)
+	require.Equal(t, 1, env.Attempt)
+	require.Equal(t, "user-1", (<-typed).UserID)
+
+	require.Equal(t, joinedID, all.next(t).ID)
+	require.Equal(t, leftID, all.next(t).ID)
+	require.Empty(t, joins.received)
+}
+
+func TestFailedSubscriberIsRetriedAlone(t *testing.T) {
+	clearOutbox(t, testDB)
+	bus := newBus(t)
+
+	healthy := newRecorder(0)
+	flaky := newRecorder(1)
+	bus.Subscribe("test-healthy", healthy.handle)
+	bus.Subscribe("test-flaky", flaky.handle)
+	bus.Start(pollEvery)
+
+	id := publish(t, bus, events.MemberLeft{ShopID: "shop-1", UserID: "user-1"})
+
+	retry := waitForOutbox(t, testDB, id, func(row outboxRow) bool { return row.LastError != nil })
+	require.Equal(t, []string{"test-healthy"}, retry.DeliveredTo)
+	require.Contains(t, *retry.LastError, "test-flaky: subscriber unavailable")
+	require.Equal(t, 1, retry.Attempts)
+	require.Nil(t, retry.DispatchedAt)
+	require.Nil(t, retry.FailedAt)
+	require.True(t, retry.AvailableAt.After(time.Now()), "a retry waits out its backoff")
+
+	// Skip the backoff rather than wait for it
+	_, err := testDB.Exec(`UPDATE outbox_events SET available_at = now() WHERE id = $1`, id)
+	require.NoError(t, err)
+
+	done := waitForOutbox(t, testDB, id, dispatched)
+	require.ElementsMatch(t, []string{"test-healthy", "test-flaky"}, done.DeliveredTo)
+	require.Equal(t, 2, done.Attempts)
+	require.Nil(t, done.LastError)
+
+	require.Equal(t, 1, flaky.next(t).Attempt)
+	require.Equal(t, 2, flaky.next(t).Attempt)
+	require.Equal(t, id, healthy.next(t).ID)
+	require.Empty(t, healthy.received)
+}
+
+func TestEventFailsAfterLastAttempt(t *testing.T) {
+	clearOutbox(t, testDB)
+	bus := newBus(t)
+
+	broken := newRecorder(1)
+	bus.Subscribe("test-broken", broken.handle)
+
+	// Nine earlier deliveries failed; the dispatcher makes the tenth and last
+	id := insertEvent(t, testDB, events.MemberLeft{ShopID: "shop-1"}, 9, nil)
+	bus.Start(pollEvery)
+
+	failed := waitForOutbox(t, testDB, id, func(row outboxRow) bool { return row.FailedAt != nil })
+	require.Equal(t, 10, failed.Attempts)
+	require.Nil(t, failed.DispatchedAt)
+	require.NotNil(t, failed.LastError)
+	require.Empty(t, failed.DeliveredTo)
+
+	// A failed event is left for an operator
+	_, err := testDB.Exec(`UPDATE outbox_events SET available_at = now() WHERE id = $1`, id)
+	require.NoError(t, err)
+	require.Never(t, func() bool { return getOutboxRow(t, testDB, id).Attempts > 10 }, 5*pollEvery, pollEvery)
+}
+
+func TestDispatchRespectsLeases(t *testing.T) {
+	clearOutbox(t, testDB)
+	bus := newBus(t)
+
+	received := newRecorder(0)
+	bus.Subscribe("test-leases", received.handle)
+
+	// One event is leased by a live replica, the other by one whose lease
+	// ran out
+	leasedUntil := time.Now().Add(time.Hour)
+	expiredAt := time.Now().Add(-time.Minute)
+	leased := insertEvent(t, testDB, events.MemberLeft{ShopID: "shop-leased"}, 1, &leasedUntil)
+	expired := insertEvent(t, testDB, events.MemberLeft{ShopID: "shop-expired"}, 1, &expiredAt)
+
+	bus.Start(pollEvery)
+
+	reclaimed := waitForOutbox(t, testDB, expired, dispatched)
+	require.Equal(t, 2, reclaimed.Attempts)
+	require.Equal(t, expired, received.next(t).ID)
+
+	require.Never(t, func() bool { return len(received.received) > 0 }, 5*pollEvery, pollEvery)
+	untouched := getOutboxRow(t, testDB, leased)
+	require.Nil(t, untouched.DispatchedAt)
+	require.Equal(t, 1, untouched.Attempts)
+}
+
+func TestDispatcherRenewsLeasesThroughASlowBatch(t *testing.T) {
+	clearOutbox(t, testDB)
+	first := newBus(t)
+	second := newBus(t)
+
+	started := make(chan int64, 4)
+	release := make(chan struct{})
+	slow := func(_ context.Context, env events.Envelope) error {
+		started <- env.ID
+		<-release
+		return nil
+	}
+	var secondGot []int64
+	first.Subscribe("test-slow", slow)
+	second.Subscribe("test-slow", func(_ context.Context, env events.Envelope) error {
+		secondGot = append(secondGot, env.ID)
+		return nil
+	})
+
+	one := publish(t, first, events.MemberLeft{ShopID: "shop-1"})
+	two := publish(t, first, events.MemberLeft{ShopID: "shop-2"})
+	three := publish(t, first, events.MemberLeft{ShopID: "shop-3"})
+
+	first.Start(pollEvery)
+	require.Equal(t, one, <-started)
+	leaseDuringOne := *getOutboxRow(t, testDB, three).LockedUntil
+
+	// The other replica polls while the first is stuck on its batch
+	second.Start(pollEvery)
+	time.Sleep(5 * pollEvery)
+
+	release <- struct{}{}
+	require.Equal(t, two, <-started)
+	leaseDuringTwo := *getOutboxRow(t, testDB, three).LockedUntil
+	require.True(t, leaseDuringTwo.After(leaseDuringOne), "the lease on the rest of the batch is renewed before each event")
+
+	close(release)
+	for _, id := range []int64{one, two, three} {
+		row := waitForOutbox(t, testDB, id, dispatched)
+		require.Equal(t, 1, row.Attempts)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, second.Stop(ctx))
+	require.Empty(t, secondGot)
+}
+
+func TestPruneOutboxDeletesOnlyFinishedEvents(t *testing.T) {
+	clearOutbox(t, testDB)
+
+	oldDispatched := insertEvent(t, testDB, events.MemberLeft{ShopID: "shop-1"}, 1, nil)
+	oldFailed := insertEvent(t, testDB, events.MemberLeft{ShopID: "shop-1"}, 10, nil)
+	recent := insertEvent(t, testDB, events.MemberLeft{ShopID: "shop-1"}, 1, nil)
+	pending := insertEvent(t, testDB, events.MemberLeft{ShopID: "shop-1"}, 0, nil)
+
+	_, err := testDB.Exec(`UPDATE outbox_events SET dispatched_at = now() - interval '30 days' WHERE id = $1`, oldDispatched)
+	require.NoError(t, err)
+	_, err = testDB.Exec(`UPDATE outbox_events SET failed_at = now() - interval '30 days' WHERE id = $1`, oldFailed)
+	require.NoError(t, err)
+	_, err = testDB.Exec(`UPDATE outbox_events SET dispatched_at = now() - interval '1 day' WHERE id = $1`, recent)
+	require.NoError(t, err)
+
+	prune := events.PruneOutbox(testDB, 14*24*time.Hour)
+	require.NoError(t, prune.Run(context.Background()))
+
+	var remaining []int64
+	rows, err := testDB.Query(`SELECT id FROM outbox_events ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		require.NoError(t, rows.Scan(&id))
+		remaining = append(remaining, id)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []int64{recent, pending}, remaining)
+}
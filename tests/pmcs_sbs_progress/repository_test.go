@@ -1,6 +1,7 @@
 package pmcs_sbs_progress_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -19,7 +20,7 @@ func TestRepositoryEnsureInspectionCreatesRecord(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	saved, err := repo.EnsureInspection(user, inspection)
+	saved, err := repo.EnsureInspection(context.Background(), user, inspection)
 
 	require.NoError(t, err)
 	require.Equal(t, inspection.ID, saved.ID)
@@ -38,12 +39,12 @@ func TestRepositoryEnsureInspectionIsIdempotentAndUpdatesPerformedDate(t *testin
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	first, err := repo.EnsureInspection(user, inspection)
+	first, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 
 	corrected := inspection
 	corrected.PerformedDate = first.PerformedDate.Add(-24 * time.Hour)
-	second, err := repo.EnsureInspection(user, corrected)
+	second, err := repo.EnsureInspection(context.Background(), user, corrected)
 
 	require.NoError(t, err)
 	require.Equal(t, first.ID, second.ID)
@@ -59,12 +60,12 @@ func TestRepositoryEnsureInspectionRejectsGuideManualMismatch(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.EnsureInspection(user, inspection)
+	_, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 
 	mismatched := inspection
 	mismatched.GuideManual = "pmcs_sbs/hmmwv/other.json"
-	_, err = repo.EnsureInspection(user, mismatched)
+	_, err = repo.EnsureInspection(context.Background(), user, mismatched)
 
 	require.ErrorIs(t, err, pmcs_sbs_progress.ErrInspectionConflict)
 }
@@ -79,7 +80,7 @@ func TestRepositoryEnsureInspectionDeniesNonMember(t *testing.T) {
 	vehicleID := createShopVehicle(t, testDB, shopID, owner, "B4")
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
-	_, err := repo.EnsureInspection(other, sampleInspection(vehicleID, other.UserID))
+	_, err := repo.EnsureInspection(context.Background(), other, sampleInspection(vehicleID, other.UserID))
 
 	require.ErrorIs(t, err, pmcs_sbs_progress.ErrNotFound)
 }
@@ -95,11 +96,11 @@ func TestRepositoryUpsertFaultCreatesInspectionImplicitly(t *testing.T) {
 	inspection := sampleInspection(vehicleID, user.UserID)
 	fault := sampleFault(inspection.ID)
 
-	saved, err := repo.UpsertFault(user, inspection, fault)
+	saved, err := repo.UpsertFault(context.Background(), user, inspection, fault)
 	require.NoError(t, err)
 	require.Equal(t, inspection.ID, saved.PmcsID)
 
-	fetched, faults, _, err := repo.GetInspection(user, vehicleID, inspection.ID)
+	fetched, faults, _, err := repo.GetInspection(context.Background(), user, vehicleID, inspection.ID)
 	require.NoError(t, err)
 	require.Equal(t, inspection.GuideManual, fetched.GuideManual)
 	require.Len(t, faults, 1)
@@ -116,17 +117,17 @@ func TestRepositoryUpsertFaultReusesExistingInspectionForSamePmcsID(t *testing.T
 
 	inspection := sampleInspection(vehicleID, user.UserID)
 	first := sampleFault(inspection.ID)
-	_, err := repo.UpsertFault(user, inspection, first)
+	_, err := repo.UpsertFault(context.Background(), user, inspection, first)
 	require.NoError(t, err)
 
 	second := sampleFault(inspection.ID)
 	second.SectionID = "during"
 	second.ItemIndex = 1
 	second.FaultText = "second fault"
-	_, err = repo.UpsertFault(user, inspection, second)
+	_, err = repo.UpsertFault(context.Background(), user, inspection, second)
 	require.NoError(t, err)
 
-	_, faults, _, err := repo.GetInspection(user, vehicleID, inspection.ID)
+	_, faults, _, err := repo.GetInspection(context.Background(), user, vehicleID, inspection.ID)
 	require.NoError(t, err)
 	require.Len(t, faults, 2)
 }
@@ -140,12 +141,12 @@ func TestRepositoryUpsertFaultRejectsGuideManualMismatch(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.UpsertFault(user, inspection, sampleFault(inspection.ID))
+	_, err := repo.UpsertFault(context.Background(), user, inspection, sampleFault(inspection.ID))
 	require.NoError(t, err)
 
 	mismatched := inspection
 	mismatched.GuideManual = "pmcs_sbs/hmmwv/other.json"
-	_, err = repo.UpsertFault(user, mismatched, sampleFault(inspection.ID))
+	_, err = repo.UpsertFault(context.Background(), user, mismatched, sampleFault(inspection.ID))
 
 	require.ErrorIs(t, err, pmcs_sbs_progress.ErrInspectionConflict)
 }
@@ -162,16 +163,16 @@ func TestRepositoryGetInspectionReturnsFaultsOrderedBySectionAndItem(t *testing.
 	late := sampleFault(inspection.ID)
 	late.SectionID = "during"
 	late.ItemIndex = 2
-	_, err := repo.UpsertFault(user, inspection, late)
+	_, err := repo.UpsertFault(context.Background(), user, inspection, late)
 	require.NoError(t, err)
 
 	early := sampleFault(inspection.ID)
 	early.SectionID = "before"
 	early.ItemIndex = 0
-	_, err = repo.UpsertFault(user, inspection, early)
+	_, err = repo.UpsertFault(context.Background(), user, inspection, early)
 	require.NoError(t, err)
 
-	_, faults, _, err := repo.GetInspection(user, vehicleID, inspection.ID)
+	_, faults, _, err := repo.GetInspection(context.Background(), user, vehicleID, inspection.ID)
 	require.NoError(t, err)
 	require.Len(t, faults, 2)
 	require.Equal(t, "before", faults[0].SectionID)
@@ -187,10 +188,10 @@ func TestRepositoryGetInspectionReturnsCleanInspectionWithEmptyFaults(t *testing
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.EnsureInspection(user, inspection)
+	_, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 
-	fetched, faults, _, err := repo.GetInspection(user, vehicleID, inspection.ID)
+	fetched, faults, _, err := repo.GetInspection(context.Background(), user, vehicleID, inspection.ID)
 	require.NoError(t, err)
 	require.Equal(t, inspection.ID, fetched.ID)
 	require.Empty(t, faults)
@@ -206,10 +207,10 @@ func TestRepositoryGetInspectionRejectsCrossVehiclePmcsID(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.EnsureInspection(user, inspection)
+	_, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 
-	_, _, _, err = repo.GetInspection(user, otherVehicleID, inspection.ID)
+	_, _, _, err = repo.GetInspection(context.Background(), user, otherVehicleID, inspection.ID)
 
 	require.ErrorIs(t, err, pmcs_sbs_progress.ErrInspectionNotFound)
 }
@@ -224,10 +225,10 @@ func TestRepositoryGetInspectionIncludesPerformedByUsername(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.EnsureInspection(user, inspection)
+	_, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 
-	detail, _, _, err := repo.GetInspection(user, vehicleID, inspection.ID)
+	detail, _, _, err := repo.GetInspection(context.Background(), user, vehicleID, inspection.ID)
 	require.NoError(t, err)
 	require.NotNil(t, detail.PerformedBy)
 	require.Equal(t, user.UserID, *detail.PerformedBy)
@@ -253,13 +254,13 @@ func TestRepositoryGetInspectionReturnsNilUsernameWhenPerformerDeleted(t *testin
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, performer.UserID)
-	_, err := repo.EnsureInspection(performer, inspection)
+	_, err := repo.EnsureInspection(context.Background(), performer, inspection)
 	require.NoError(t, err)
 
 	_, err = testDB.Exec(`DELETE FROM users WHERE uid=$1`, performer.UserID)
 	require.NoError(t, err)
 
-	detail, _, _, err := repo.GetInspection(viewer, vehicleID, inspection.ID)
+	detail, _, _, err := repo.GetInspection(context.Background(), viewer, vehicleID, inspection.ID)
 	require.NoError(t, err)
 	require.Nil(t, detail.PerformedBy)
 	require.Nil(t, detail.PerformedByUsername)
@@ -275,10 +276,10 @@ func TestRepositoryListInspectionsIncludesPerformedByUsername(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.EnsureInspection(user, inspection)
+	_, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 
-	summaries, err := repo.ListInspections(user, vehicleID, "", 10, 0)
+	summaries, err := repo.ListInspections(context.Background(), user, vehicleID, "", 10, 0)
 	require.NoError(t, err)
 	require.Len(t, summaries, 1)
 	require.NotNil(t, summaries[0].PerformedBy)
@@ -297,15 +298,15 @@ func TestRepositoryListInspectionsOrdersByPerformedDateDescWithFaultCounts(t *te
 
 	older := sampleInspection(vehicleID, user.UserID)
 	older.PerformedDate = time.Now().UTC().Add(-48 * time.Hour)
-	_, err := repo.EnsureInspection(user, older)
+	_, err := repo.EnsureInspection(context.Background(), user, older)
 	require.NoError(t, err)
 
 	newer := sampleInspection(vehicleID, user.UserID)
 	newer.PerformedDate = time.Now().UTC()
-	_, err = repo.UpsertFault(user, newer, sampleFault(newer.ID))
+	_, err = repo.UpsertFault(context.Background(), user, newer, sampleFault(newer.ID))
 	require.NoError(t, err)
 
-	summaries, err := repo.ListInspections(user, vehicleID, "", 10, 0)
+	summaries, err := repo.ListInspections(context.Background(), user, vehicleID, "", 10, 0)
 	require.NoError(t, err)
 	require.Len(t, summaries, 2)
 	require.Equal(t, newer.ID, summaries[0].ID)
@@ -324,15 +325,15 @@ func TestRepositoryListInspectionsFiltersByGuideManual(t *testing.T) {
 
 	first := sampleInspection(vehicleID, user.UserID)
 	first.GuideManual = "pmcs_sbs/hmmwv/first.json"
-	_, err := repo.EnsureInspection(user, first)
+	_, err := repo.EnsureInspection(context.Background(), user, first)
 	require.NoError(t, err)
 
 	second := sampleInspection(vehicleID, user.UserID)
 	second.GuideManual = "pmcs_sbs/hmmwv/second.json"
-	_, err = repo.EnsureInspection(user, second)
+	_, err = repo.EnsureInspection(context.Background(), user, second)
 	require.NoError(t, err)
 
-	summaries, err := repo.ListInspections(user, vehicleID, "pmcs_sbs/hmmwv/first.json", 10, 0)
+	summaries, err := repo.ListInspections(context.Background(), user, vehicleID, "pmcs_sbs/hmmwv/first.json", 10, 0)
 	require.NoError(t, err)
 	require.Len(t, summaries, 1)
 	require.Equal(t, first.ID, summaries[0].ID)
@@ -347,14 +348,14 @@ func TestRepositoryDeleteInspectionCascadesFaultsButNotSiblings(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	toDelete := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.UpsertFault(user, toDelete, sampleFault(toDelete.ID))
+	_, err := repo.UpsertFault(context.Background(), user, toDelete, sampleFault(toDelete.ID))
 	require.NoError(t, err)
 
 	sibling := sampleInspection(vehicleID, user.UserID)
-	_, err = repo.UpsertFault(user, sibling, sampleFault(sibling.ID))
+	_, err = repo.UpsertFault(context.Background(), user, sibling, sampleFault(sibling.ID))
 	require.NoError(t, err)
 
-	err = repo.DeleteInspection(user, vehicleID, toDelete.ID)
+	err = repo.DeleteInspection(context.Background(), user, vehicleID, toDelete.ID)
 	require.NoError(t, err)
 
 	var faultCount int
@@ -362,7 +363,7 @@ func TestRepositoryDeleteInspectionCascadesFaultsButNotSiblings(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, faultCount)
 
-	_, siblingFaults, _, err := repo.GetInspection(user, vehicleID, sibling.ID)
+	_, siblingFaults, _, err := repo.GetInspection(context.Background(), user, vehicleID, sibling.ID)
 	require.NoError(t, err)
 	require.Len(t, siblingFaults, 1)
 }
@@ -379,26 +380,26 @@ func TestRepositoryDeleteFaultAndBulkDeleteFaultsScopedToInspection(t *testing.T
 	first := sampleFault(inspection.ID)
 	first.SectionID = "before"
 	first.ItemIndex = 0
-	_, err := repo.UpsertFault(user, inspection, first)
+	_, err := repo.UpsertFault(context.Background(), user, inspection, first)
 	require.NoError(t, err)
 
 	second := sampleFault(inspection.ID)
 	second.SectionID = "during"
 	second.ItemIndex = 1
-	_, err = repo.UpsertFault(user, inspection, second)
+	_, err = repo.UpsertFault(context.Background(), user, inspection, second)
 	require.NoError(t, err)
 
-	err = repo.DeleteFault(user, vehicleID, pmcs_sbs_progress.FaultKey{PmcsID: inspection.ID, SectionID: "before", ItemIndex: 0})
+	err = repo.DeleteFault(context.Background(), user, vehicleID, pmcs_sbs_progress.FaultKey{PmcsID: inspection.ID, SectionID: "before", ItemIndex: 0})
 	require.NoError(t, err)
 
-	deletedCount, err := repo.DeleteFaults(user, vehicleID, inspection.ID, []pmcs_sbs_progress.FaultKey{
+	deletedCount, err := repo.DeleteFaults(context.Background(), user, vehicleID, inspection.ID, []pmcs_sbs_progress.FaultKey{
 		{PmcsID: inspection.ID, SectionID: "during", ItemIndex: 1},
 		{PmcsID: inspection.ID, SectionID: "missing", ItemIndex: 99},
 	})
 	require.NoError(t, err)
 	require.Equal(t, int64(1), deletedCount)
 
-	_, faults, _, err := repo.GetInspection(user, vehicleID, inspection.ID)
+	_, faults, _, err := repo.GetInspection(context.Background(), user, vehicleID, inspection.ID)
 	require.NoError(t, err)
 	require.Empty(t, faults)
 }
@@ -414,14 +415,14 @@ func TestRepositoryEnsureInspectionPersistsAndClearsNotes(t *testing.T) {
 	inspection := sampleInspection(vehicleID, user.UserID)
 	notes := "clean inspection, no issues found"
 	inspection.Notes = &notes
-	saved, err := repo.EnsureInspection(user, inspection)
+	saved, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 	require.NotNil(t, saved.Notes)
 	require.Equal(t, notes, *saved.Notes)
 
 	cleared := inspection
 	cleared.Notes = nil
-	saved, err = repo.EnsureInspection(user, cleared)
+	saved, err = repo.EnsureInspection(context.Background(), user, cleared)
 	require.NoError(t, err)
 	require.Nil(t, saved.Notes)
 }
@@ -436,10 +437,10 @@ func TestRepositoryCreateCommentAndGetInspectionReturnsOrderedWithAuthor(t *test
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, author.UserID)
-	_, err := repo.EnsureInspection(author, inspection)
+	_, err := repo.EnsureInspection(context.Background(), author, inspection)
 	require.NoError(t, err)
 
-	first, err := repo.CreateComment(author, vehicleID, inspection.ID, "first comment")
+	first, err := repo.CreateComment(context.Background(), author, vehicleID, inspection.ID, "first comment")
 	require.NoError(t, err)
 	require.Equal(t, inspection.ID, first.PmcsID)
 	require.Equal(t, author.UserID, first.AuthorID)
@@ -447,10 +448,10 @@ func TestRepositoryCreateCommentAndGetInspectionReturnsOrderedWithAuthor(t *test
 	require.Equal(t, "jsmith", *first.AuthorUsername)
 
 	time.Sleep(10 * time.Millisecond)
-	_, err = repo.CreateComment(author, vehicleID, inspection.ID, "second comment")
+	_, err = repo.CreateComment(context.Background(), author, vehicleID, inspection.ID, "second comment")
 	require.NoError(t, err)
 
-	_, _, comments, err := repo.GetInspection(author, vehicleID, inspection.ID)
+	_, _, comments, err := repo.GetInspection(context.Background(), author, vehicleID, inspection.ID)
 	require.NoError(t, err)
 	require.Len(t, comments, 2)
 	require.Equal(t, "first comment", comments[0].Text)
@@ -467,10 +468,10 @@ func TestRepositoryCreateCommentRejectsCrossVehiclePmcsID(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.EnsureInspection(user, inspection)
+	_, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 
-	_, err = repo.CreateComment(user, otherVehicleID, inspection.ID, "should fail")
+	_, err = repo.CreateComment(context.Background(), user, otherVehicleID, inspection.ID, "should fail")
 
 	require.ErrorIs(t, err, pmcs_sbs_progress.ErrInspectionNotFound)
 }
@@ -484,14 +485,14 @@ func TestRepositoryUpdateCommentPersistsTextAndSetsUpdatedAt(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, author.UserID)
-	_, err := repo.EnsureInspection(author, inspection)
+	_, err := repo.EnsureInspection(context.Background(), author, inspection)
 	require.NoError(t, err)
 
-	created, err := repo.CreateComment(author, vehicleID, inspection.ID, "original text")
+	created, err := repo.CreateComment(context.Background(), author, vehicleID, inspection.ID, "original text")
 	require.NoError(t, err)
 	require.Nil(t, created.UpdatedAt)
 
-	updated, err := repo.UpdateComment(created.ID, "Deleted by user")
+	updated, err := repo.UpdateComment(context.Background(), created.ID, "Deleted by user")
 	require.NoError(t, err)
 	require.Equal(t, "Deleted by user", updated.Text)
 	require.NotNil(t, updated.UpdatedAt)
@@ -501,7 +502,7 @@ func TestRepositoryGetCommentReturnsNotFoundForMissingID(t *testing.T) {
 	clearPmcsSbsTables(t, testDB)
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
-	_, err := repo.GetComment(uuid.New())
+	_, err := repo.GetComment(context.Background(), uuid.New())
 
 	require.ErrorIs(t, err, pmcs_sbs_progress.ErrCommentNotFound)
 }
@@ -515,15 +516,15 @@ func TestRepositoryListInspectionsIncludesCommentCount(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.EnsureInspection(user, inspection)
+	_, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
 
-	_, err = repo.CreateComment(user, vehicleID, inspection.ID, "one")
+	_, err = repo.CreateComment(context.Background(), user, vehicleID, inspection.ID, "one")
 	require.NoError(t, err)
-	_, err = repo.CreateComment(user, vehicleID, inspection.ID, "two")
+	_, err = repo.CreateComment(context.Background(), user, vehicleID, inspection.ID, "two")
 	require.NoError(t, err)
 
-	summaries, err := repo.ListInspections(user, vehicleID, "", 10, 0)
+	summaries, err := repo.ListInspections(context.Background(), user, vehicleID, "", 10, 0)
 	require.NoError(t, err)
 	require.Len(t, summaries, 1)
 	require.Equal(t, 2, summaries[0].CommentCount)
@@ -538,12 +539,12 @@ func TestRepositoryDeleteInspectionCascadesComments(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.EnsureInspection(user, inspection)
+	_, err := repo.EnsureInspection(context.Background(), user, inspection)
 	require.NoError(t, err)
-	_, err = repo.CreateComment(user, vehicleID, inspection.ID, "will be cascaded")
+	_, err = repo.CreateComment(context.Background(), user, vehicleID, inspection.ID, "will be cascaded")
 	require.NoError(t, err)
 
-	err = repo.DeleteInspection(user, vehicleID, inspection.ID)
+	err = repo.DeleteInspection(context.Background(), user, vehicleID, inspection.ID)
 	require.NoError(t, err)
 
 	var commentCount int
@@ -561,7 +562,7 @@ func TestRepositoryVehicleDeleteCascadesInspectionsAndFaults(t *testing.T) {
 	repo := pmcs_sbs_progress.NewRepository(testDB)
 
 	inspection := sampleInspection(vehicleID, user.UserID)
-	_, err := repo.UpsertFault(user, inspection, sampleFault(inspection.ID))
+	_, err := repo.UpsertFault(context.Background(), user, inspection, sampleFault(inspection.ID))
 	require.NoError(t, err)
 
 	_, err = testDB.Exec(`DELETE FROM shop_vehicle WHERE id=$1`, vehicleID)
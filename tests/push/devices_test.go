@@ -0,0 +1,90 @@
+package push_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAndUnregisterDevice(t *testing.T) {
+	app := newTestApp(t)
+	userID := newUser(t, testDB)
+
+	token := registerDevice(t, app.router, userID)
+	require.Equal(t, []string{token}, userTokens(t, testDB, userID))
+
+	// Registering again only refreshes the device
+	registerToken(t, app.router, userID, token, "ios")
+	var platform string
+	require.NoError(t, testDB.QueryRow(`SELECT platform FROM user_device_tokens WHERE token = $1`, token).Scan(&platform))
+	require.Equal(t, "ios", platform)
+
+	resp := doJSONRequest(t, app.router, http.MethodDelete, "/api/v1/auth/user/devices", map[string]interface{}{"token": token}, userID)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Empty(t, userTokens(t, testDB, userID))
+
+	// Unknown tokens are ignored so apps can retry
+	resp = doJSONRequest(t, app.router, http.MethodDelete, "/api/v1/auth/user/devices", map[string]interface{}{"token": token}, userID)
+	require.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestDeviceMovesToTheUserSignedInOnIt(t *testing.T) {
+	app := newTestApp(t)
+	firstID := newUser(t, testDB)
+	secondID := newUser(t, testDB)
+
+	token := registerDevice(t, app.router, firstID)
+	registerToken(t, app.router, secondID, token, "android")
+
+	require.Empty(t, userTokens(t, testDB, firstID))
+	require.Equal(t, []string{token}, userTokens(t, testDB, secondID))
+
+	// The previous user can't remove a device that is no longer theirs
+	resp := doJSONRequest(t, app.router, http.MethodDelete, "/api/v1/auth/user/devices", map[string]interface{}{"token": token}, firstID)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, []string{token}, userTokens(t, testDB, secondID))
+}
+
+func TestOldestDevicesAreForgottenBeyondTheLimit(t *testing.T) {
+	app := newTestApp(t)
+	userID := newUser(t, testDB)
+
+	var tokens []string
+	for i := range 21 {
+		token := fmt.Sprintf("token-%s-%02d", userID, i)
+		registerToken(t, app.router, userID, token, "android")
+		tokens = append(tokens, token)
+	}
+
+	remaining := userTokens(t, testDB, userID)
+	require.Len(t, remaining, 20)
+	require.NotContains(t, remaining, tokens[0])
+	require.Contains(t, remaining, tokens[20])
+}
+
+func TestRegisterDeviceValidation(t *testing.T) {
+	app := newTestApp(t)
+	userID := newUser(t, testDB)
+
+	blank := doJSONRequest(t, app.router, http.MethodPost, "/api/v1/auth/user/devices", map[string]interface{}{
+		"token":    "   ",
+		"platform": "android",
+	}, userID)
+	require.Equal(t, http.StatusBadRequest, blank.Code)
+	require.Equal(t, "invalid_device_token", decodeProblem(t, blank.Body).Code)
+
+	platform := doJSONRequest(t, app.router, http.MethodPost, "/api/v1/auth/user/devices", map[string]interface{}{
+		"token":    newDeviceToken(),
+		"platform": "pager",
+	}, userID)
+	require.Equal(t, http.StatusBadRequest, platform.Code)
+
+	anonymous := doJSONRequest(t, app.router, http.MethodPost, "/api/v1/auth/user/devices", map[string]interface{}{
+		"token":    newDeviceToken(),
+		"platform": "android",
+	}, "")
+	require.Equal(t, http.StatusUnauthorized, anonymous.Code)
+	require.Empty(t, userTokens(t, testDB, userID))
+}
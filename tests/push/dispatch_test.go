@@ -0,0 +1,113 @@
+package push_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"miltechserver/events"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagePushedToOtherMembers(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+	outsiderID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+	joinShop(t, app.router, adminID, memberID, shopID)
+
+	adminPhone := registerDevice(t, app.router, adminID)
+	adminTablet := registerDevice(t, app.router, adminID)
+	registerDevice(t, app.router, memberID)
+	registerDevice(t, app.router, outsiderID)
+
+	messageID := postMessage(t, app.router, memberID, shopID, "Motor pool at 0600")
+	waitForDispatch(t, testDB)
+
+	deliveries := app.sender.Deliveries()
+	require.Len(t, deliveries, 1)
+	require.ElementsMatch(t, []string{adminPhone, adminTablet}, deliveries[0].Tokens)
+
+	notification := deliveries[0].Notification
+	require.Equal(t, "Push Shop", notification.Title)
+	require.Equal(t, "test-user: Motor pool at 0600", notification.Body)
+	require.Equal(t, map[string]string{
+		"type":       events.TypeMessageCreated,
+		"shop_id":    shopID,
+		"message_id": messageID,
+	}, notification.Data)
+}
+
+func TestPushFollowsPerShopPreferences(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+	joinShop(t, app.router, adminID, memberID, shopID)
+
+	memberPhone := registerDevice(t, app.router, memberID)
+	updatePreferences(t, app.router, memberID, shopID, map[string]interface{}{"messages": false})
+
+	postMessage(t, app.router, adminID, shopID, "Nobody hears this")
+	vehicleID := createVehicleNotification(t, app.router, adminID, shopID, "Oil change")
+	waitForDispatch(t, testDB)
+
+	// Messages are off, vehicle notifications are still on
+	deliveries := app.sender.Deliveries()
+	require.Len(t, deliveries, 1)
+	require.Equal(t, []string{memberPhone}, deliveries[0].Tokens)
+	require.Equal(t, "New vehicle notification: Oil change", deliveries[0].Notification.Body)
+	require.Equal(t, events.TypeVehicleNotificationCreated, deliveries[0].Notification.Data["type"])
+	require.Equal(t, vehicleID, deliveries[0].Notification.Data["vehicle_id"])
+}
+
+func TestNoPushDuringQuietHours(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	quietID := newUser(t, testDB)
+	awakeID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+	joinShop(t, app.router, adminID, quietID, shopID)
+	joinShop(t, app.router, adminID, awakeID, shopID)
+
+	registerDevice(t, app.router, quietID)
+	awakePhone := registerDevice(t, app.router, awakeID)
+
+	// Quiet from an hour ago to an hour from now, in the member's zone
+	location, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Now().In(location)
+	clock := func(at time.Time) string { return fmt.Sprintf("%02d:%02d", at.Hour(), at.Minute()) }
+	updatePreferences(t, app.router, quietID, shopID, map[string]interface{}{
+		"quiet_hours_start": clock(now.Add(-time.Hour)),
+		"quiet_hours_end":   clock(now.Add(time.Hour)),
+		"time_zone":         "Asia/Tokyo",
+	})
+
+	postMessage(t, app.router, adminID, shopID, "Late night update")
+	waitForDispatch(t, testDB)
+
+	deliveries := app.sender.Deliveries()
+	require.Len(t, deliveries, 1)
+	require.Equal(t, []string{awakePhone}, deliveries[0].Tokens)
+}
+
+func TestInvalidTokensAreForgotten(t *testing.T) {
+	staleToken := newDeviceToken()
+	app := newTestApp(t, staleToken)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+	joinShop(t, app.router, adminID, memberID, shopID)
+
+	registerToken(t, app.router, memberID, staleToken, "ios")
+	livePhone := registerDevice(t, app.router, memberID)
+
+	postMessage(t, app.router, adminID, shopID, "Formation at 0700")
+	waitForDispatch(t, testDB)
+
+	require.Len(t, app.sender.Deliveries(), 1)
+	require.Equal(t, []string{livePhone}, userTokens(t, testDB, memberID))
+}
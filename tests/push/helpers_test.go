@@ -0,0 +1,305 @@
+package push_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/middleware"
+	"miltechserver/api/shops"
+	"miltechserver/api/user_devices"
+	"miltechserver/bootstrap"
+	"miltechserver/events"
+	"miltechserver/push"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+const pollEvery = 50 * time.Millisecond
+
+type standardResponse struct {
+	Status  int             `json:"status"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+}
+
+type preferencesResponse struct {
+	ShopID               string  `json:"shop_id"`
+	Messages             bool    `json:"messages"`
+	VehicleNotifications bool    `json:"vehicle_notifications"`
+	OverdueServices      bool    `json:"overdue_services"`
+	QuietHoursStart      *string `json:"quiet_hours_start"`
+	QuietHoursEnd        *string `json:"quiet_hours_end"`
+	TimeZone             string  `json:"time_zone"`
+}
+
+// testApp is the shop and device routes with a running event dispatcher
+// whose pushes go to sender.
+type testApp struct {
+	router *gin.Engine
+	sender *push.Fake
+}
+
+// newTestApp starts the app with a push sender that reports invalidTokens
+// as no longer registered.
+func newTestApp(t *testing.T, invalidTokens ...string) testApp {
+	t.Helper()
+
+	clearOutbox(t, testDB)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	router.Use(testUserMiddleware())
+
+	group := router.Group("/api/v1/auth")
+
+	bus := events.NewBus(testDB)
+	sender := &push.Fake{Invalid: map[string]bool{}}
+	for _, token := range invalidTokens {
+		sender.Invalid[token] = true
+	}
+	deps := shops.Dependencies{
+		DB:     testDB,
+		Env:    &bootstrap.Env{BlobAccountName: "test-account"},
+		Events: bus,
+		Push:   sender,
+	}
+
+	shops.RegisterRoutes(deps, group)
+	user_devices.RegisterRoutes(user_devices.Dependencies{DB: testDB}, group)
+	bus.Start(pollEvery)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, bus.Stop(ctx))
+	})
+
+	return testApp{router: router, sender: sender}
+}
+
+func testUserMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetHeader("X-User-ID")
+		if userID == "" {
+			c.Next()
+			return
+		}
+
+		c.Set("user", &bootstrap.User{
+			UserID:   userID,
+			Username: "test-user",
+			Email:    userID + "@example.com",
+		})
+		c.Next()
+	}
+}
+
+func doJSONRequest(t *testing.T, router *gin.Engine, method string, path string, body interface{}, userID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	reader := strings.NewReader("")
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = strings.NewReader(string(payload))
+	}
+
+	req, err := http.NewRequest(method, path, reader)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeStandardResponse(t *testing.T, body *bytes.Buffer) standardResponse {
+	t.Helper()
+
+	var resp standardResponse
+	err := json.Unmarshal(body.Bytes(), &resp)
+	require.NoError(t, err)
+	return resp
+}
+
+func decodeProblem(t *testing.T, body *bytes.Buffer) apperror.Problem {
+	t.Helper()
+
+	var problem apperror.Problem
+	err := json.Unmarshal(body.Bytes(), &problem)
+	require.NoError(t, err)
+	return problem
+}
+
+func decodeData[T any](t *testing.T, body *bytes.Buffer) T {
+	t.Helper()
+
+	var data T
+	err := json.Unmarshal(decodeStandardResponse(t, body).Data, &data)
+	require.NoError(t, err)
+	return data
+}
+
+func clearOutbox(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`TRUNCATE TABLE outbox_events RESTART IDENTITY`)
+	require.NoError(t, err)
+}
+
+// newUser inserts a user with a fresh ID, so tests never share shops or
+// devices.
+func newUser(t *testing.T, db *sql.DB) string {
+	t.Helper()
+
+	userID := "push-" + uuid.New().String()
+	_, err := db.Exec(
+		`INSERT INTO users (uid, email, username, created_at, is_enabled)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		userID,
+		userID+"@example.com",
+		"test-user",
+		time.Now().UTC(),
+		true,
+	)
+	require.NoError(t, err)
+	return userID
+}
+
+func createShop(t *testing.T, router *gin.Engine, userID string) string {
+	t.Helper()
+
+	body := map[string]interface{}{
+		"name":    "Push Shop",
+		"details": "Details",
+	}
+
+	resp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops", body, userID)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	shopID, ok := decodeData[map[string]interface{}](t, resp.Body)["id"].(string)
+	require.True(t, ok)
+	return shopID
+}
+
+func joinShop(t *testing.T, router *gin.Engine, adminID string, userID string, shopID string) {
+	t.Helper()
+
+	inviteResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/invite-codes", map[string]interface{}{"shop_id": shopID}, adminID)
+	require.Equal(t, http.StatusCreated, inviteResp.Code)
+
+	code, ok := decodeData[map[string]interface{}](t, inviteResp.Body)["code"].(string)
+	require.True(t, ok)
+
+	joinResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/join", map[string]interface{}{"invite_code": code}, userID)
+	require.Equal(t, http.StatusOK, joinResp.Code)
+}
+
+func newDeviceToken() string {
+	return "token-" + uuid.New().String()
+}
+
+// registerDevice registers a fresh token for userID and returns it.
+func registerDevice(t *testing.T, router *gin.Engine, userID string) string {
+	t.Helper()
+
+	token := newDeviceToken()
+	registerToken(t, router, userID, token, "android")
+	return token
+}
+
+func registerToken(t *testing.T, router *gin.Engine, userID string, token string, platform string) {
+	t.Helper()
+
+	resp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/user/devices", map[string]interface{}{
+		"token":    token,
+		"platform": platform,
+	}, userID)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+}
+
+func userTokens(t *testing.T, db *sql.DB, userID string) []string {
+	t.Helper()
+
+	var tokens []string
+	err := db.QueryRow(`SELECT COALESCE(array_agg(token ORDER BY token), '{}') FROM user_device_tokens WHERE user_id = $1`, userID).
+		Scan(pq.Array(&tokens))
+	require.NoError(t, err)
+	return tokens
+}
+
+// postMessage posts a shop message, which publishes shop.message.created.
+func postMessage(t *testing.T, router *gin.Engine, userID string, shopID string, message string) string {
+	t.Helper()
+
+	resp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/messages", map[string]interface{}{
+		"shop_id": shopID,
+		"message": message,
+	}, userID)
+	require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+
+	messageID, ok := decodeData[map[string]interface{}](t, resp.Body)["id"].(string)
+	require.True(t, ok)
+	return messageID
+}
+
+// createVehicleNotification adds a vehicle and a notification on it, which
+// publishes shop.vehicle_notification.created.
+func createVehicleNotification(t *testing.T, router *gin.Engine, userID string, shopID string, title string) string {
+	t.Helper()
+
+	vehicleResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/vehicles", map[string]interface{}{
+		"shop_id": shopID,
+		"admin":   "admin",
+	}, userID)
+	require.Equal(t, http.StatusCreated, vehicleResp.Code, vehicleResp.Body.String())
+	vehicleID, ok := decodeData[map[string]interface{}](t, vehicleResp.Body)["id"].(string)
+	require.True(t, ok)
+
+	notificationResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/vehicles/notifications", map[string]interface{}{
+		"shop_id":     shopID,
+		"vehicle_id":  vehicleID,
+		"title":       title,
+		"description": "desc",
+		"type":        "PM",
+	}, userID)
+	require.Equal(t, http.StatusCreated, notificationResp.Code, notificationResp.Body.String())
+	return vehicleID
+}
+
+func updatePreferences(t *testing.T, router *gin.Engine, userID string, shopID string, body map[string]interface{}) preferencesResponse {
+	t.Helper()
+
+	resp := doJSONRequest(t, router, http.MethodPut, "/api/v1/auth/shops/"+shopID+"/push-preferences", body, userID)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	return decodeData[preferencesResponse](t, resp.Body)
+}
+
+// waitForDispatch waits until the dispatcher has handled every stored
+// event.
+func waitForDispatch(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		var pending int
+		err := db.QueryRow(`SELECT COUNT(*) FROM outbox_events WHERE dispatched_at IS NULL AND failed_at IS NULL`).Scan(&pending)
+		require.NoError(t, err)
+		return pending == 0
+	}, 5*time.Second, 20*time.Millisecond)
+}
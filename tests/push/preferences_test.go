@@ -0,0 +1,111 @@
+package push_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferencesDefaultToEverything(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+
+	resp := doJSONRequest(t, app.router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/push-preferences", nil, adminID)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	prefs := decodeData[preferencesResponse](t, resp.Body)
+	require.Equal(t, preferencesResponse{
+		ShopID:               shopID,
+		Messages:             true,
+		VehicleNotifications: true,
+		OverdueServices:      true,
+		TimeZone:             "UTC",
+	}, prefs)
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+
+	prefs := updatePreferences(t, app.router, adminID, shopID, map[string]interface{}{
+		"messages":          false,
+		"quiet_hours_start": "22:00",
+		"quiet_hours_end":   "6:30",
+		"time_zone":         "America/Chicago",
+	})
+	require.False(t, prefs.Messages)
+	require.True(t, prefs.VehicleNotifications)
+	require.NotNil(t, prefs.QuietHoursStart)
+	require.Equal(t, "22:00", *prefs.QuietHoursStart)
+	require.Equal(t, "06:30", *prefs.QuietHoursEnd)
+	require.Equal(t, "America/Chicago", prefs.TimeZone)
+
+	// Omitted fields keep their value
+	prefs = updatePreferences(t, app.router, adminID, shopID, map[string]interface{}{"overdue_services": false})
+	require.False(t, prefs.Messages)
+	require.False(t, prefs.OverdueServices)
+	require.Equal(t, "22:00", *prefs.QuietHoursStart)
+
+	prefs = updatePreferences(t, app.router, adminID, shopID, map[string]interface{}{
+		"quiet_hours_start": nil,
+		"quiet_hours_end":   nil,
+	})
+	require.Nil(t, prefs.QuietHoursStart)
+	require.Nil(t, prefs.QuietHoursEnd)
+
+	var start, end *int
+	var timeZone string
+	err := testDB.QueryRow(`
+		SELECT quiet_start_minute, quiet_end_minute, time_zone
+		FROM shop_push_preferences WHERE user_id = $1 AND shop_id = $2`, adminID, shopID).Scan(&start, &end, &timeZone)
+	require.NoError(t, err)
+	require.Nil(t, start)
+	require.Nil(t, end)
+	require.Equal(t, "America/Chicago", timeZone)
+}
+
+func TestUpdatePreferencesValidation(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+
+	tests := []struct {
+		name string
+		body map[string]interface{}
+		code string
+	}{
+		{name: "start without end", body: map[string]interface{}{"quiet_hours_start": "22:00"}, code: "invalid_quiet_hours"},
+		{name: "bad clock", body: map[string]interface{}{"quiet_hours_start": "25:00", "quiet_hours_end": "06:00"}, code: "invalid_quiet_hours"},
+		{name: "empty window", body: map[string]interface{}{"quiet_hours_start": "06:00", "quiet_hours_end": "06:00"}, code: "invalid_quiet_hours"},
+		{name: "unknown time zone", body: map[string]interface{}{"time_zone": "Mars/Olympus_Mons"}, code: "invalid_time_zone"},
+		{name: "server time zone", body: map[string]interface{}{"time_zone": "Local"}, code: "invalid_time_zone"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doJSONRequest(t, app.router, http.MethodPut, "/api/v1/auth/shops/"+shopID+"/push-preferences", tt.body, adminID)
+			require.Equal(t, http.StatusBadRequest, resp.Code)
+			require.Equal(t, tt.code, decodeProblem(t, resp.Body).Code)
+		})
+	}
+
+	var saved int
+	require.NoError(t, testDB.QueryRow(`SELECT COUNT(*) FROM shop_push_preferences WHERE shop_id = $1`, shopID).Scan(&saved))
+	require.Zero(t, saved)
+}
+
+func TestPreferencesRequireMembership(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	outsiderID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+
+	getResp := doJSONRequest(t, app.router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/push-preferences", nil, outsiderID)
+	require.Equal(t, http.StatusForbidden, getResp.Code)
+
+	putResp := doJSONRequest(t, app.router, http.MethodPut, "/api/v1/auth/shops/"+shopID+"/push-preferences", map[string]interface{}{"messages": false}, outsiderID)
+	require.Equal(t, http.StatusForbidden, putResp.Code)
+	require.Equal(t, "shop_access_denied", decodeProblem(t, putResp.Body).Code)
+}
@@ -3,13 +3,12 @@ package sb_700_20_test
 import (
 	"database/sql"
 	"encoding/json"
-	"math"
 	"net/http"
 	"testing"
 
 	"miltechserver/.gen/miltech_ng/public/model"
-	"miltechserver/api/response"
-	sb700 "miltechserver/api/sb_700_20"
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 
 	"github.com/stretchr/testify/require"
 )
@@ -44,7 +43,7 @@ func TestBlankSearchParams(t *testing.T) {
 	}
 }
 
-func TestInvalidPageParams(t *testing.T) {
+func TestInvalidPaginationParams(t *testing.T) {
 	router := newTestRouter(t)
 	endpoints := []string{
 		"/api/v1/sb700-20/app-b/list",
@@ -62,8 +61,9 @@ func TestInvalidPageParams(t *testing.T) {
 		"/api/v1/sb700-20/chp-8/list",
 	}
 	for _, ep := range endpoints {
-		require.Equal(t, http.StatusBadRequest, doJSONRequest(t, router, http.MethodGet, ep+"?page=bad").Code, ep)
-		require.Equal(t, http.StatusBadRequest, doJSONRequest(t, router, http.MethodGet, ep+"?page=0").Code, ep)
+		for _, query := range []string{"?limit=bad", "?limit=0", "?cursor=bad"} {
+			require.Equal(t, http.StatusBadRequest, doJSONRequest(t, router, http.MethodGet, ep+query).Code, ep+query)
+		}
 	}
 }
 
@@ -85,25 +85,24 @@ func TestAppBEndpoints(t *testing.T) {
 	}
 	resp404 := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-b/search/NOTREAL999")
 	require.Equal(t, http.StatusNotFound, resp404.Code)
-	var nf response.NoItemFoundResponse
+	var nf apperror.Problem
 	require.NoError(t, json.Unmarshal(resp404.Body.Bytes(), &nf))
 	require.Equal(t, http.StatusNotFound, nf.Status)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-b/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-b/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppB]
+	var data pagination.Page[model.Sb70020AppB]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
-	require.Equal(t, 1, data.Page)
-	require.Equal(t, int(math.Ceil(float64(rowCount)/100.0)), data.TotalPages)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-b/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-b/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-b/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppCEndpoints(t *testing.T) {
@@ -123,19 +122,20 @@ func TestAppCEndpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-c/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-c/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-c/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppC]
+	var data pagination.Page[model.Sb70020AppC]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-c/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-c/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-c/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppDEndpoints(t *testing.T) {
@@ -155,19 +155,20 @@ func TestAppDEndpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-d/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-d/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-d/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppD]
+	var data pagination.Page[model.Sb70020AppD]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-d/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-d/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-d/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppEEndpoints(t *testing.T) {
@@ -187,19 +188,20 @@ func TestAppEEndpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-e/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-e/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-e/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppE]
+	var data pagination.Page[model.Sb70020AppE]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-e/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-e/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-e/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppFEndpoints(t *testing.T) {
@@ -219,19 +221,20 @@ func TestAppFEndpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-f/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-f/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-f/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppF]
+	var data pagination.Page[model.Sb70020AppF]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-f/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-f/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-f/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppGEndpoints(t *testing.T) {
@@ -251,19 +254,20 @@ func TestAppGEndpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-g/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-g/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-g/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppG]
+	var data pagination.Page[model.Sb70020AppG]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-g/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-g/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-g/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppH1Endpoints(t *testing.T) {
@@ -284,19 +288,20 @@ func TestAppH1Endpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h1/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h1/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h1/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppH1]
+	var data pagination.Page[model.Sb70020AppH1]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h1/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h1/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h1/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppH2Endpoints(t *testing.T) {
@@ -317,19 +322,20 @@ func TestAppH2Endpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h2/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h2/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h2/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppH2]
+	var data pagination.Page[model.Sb70020AppH2]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h2/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h2/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-h2/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppIEndpoints(t *testing.T) {
@@ -349,19 +355,20 @@ func TestAppIEndpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-i/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-i/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-i/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppI]
+	var data pagination.Page[model.Sb70020AppI]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-i/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-i/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-i/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestAppJEndpoints(t *testing.T) {
@@ -381,19 +388,20 @@ func TestAppJEndpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-j/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-j/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-j/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020AppJ]
+	var data pagination.Page[model.Sb70020AppJ]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-j/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-j/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/app-j/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestChp4Endpoints(t *testing.T) {
@@ -413,19 +421,20 @@ func TestChp4Endpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-4/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-4/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-4/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020Chp4]
+	var data pagination.Page[model.Sb70020Chp4]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-4/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-4/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-4/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestChp6Endpoints(t *testing.T) {
@@ -445,19 +454,20 @@ func TestChp6Endpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-6/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-6/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-6/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020Chp6]
+	var data pagination.Page[model.Sb70020Chp6]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-6/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-6/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-6/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestChp8Endpoints(t *testing.T) {
@@ -477,19 +487,20 @@ func TestChp8Endpoints(t *testing.T) {
 	}
 	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-8/search/NOTREAL999").Code)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-8/list?page=1")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-8/list?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
-	var data sb700.PageResponse[model.Sb70020Chp8]
+	var data pagination.Page[model.Sb70020Chp8]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
 	require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-8/list").Code)
-	require.Equal(t, http.StatusNotFound, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-8/list?page=999999").Code)
+	if data.NextCursor != nil {
+		require.Equal(t, http.StatusOK, doJSONRequest(t, router, http.MethodGet, "/api/v1/sb700-20/chp-8/list?limit=2&cursor="+*data.NextCursor).Code)
+	}
 }
 
 func TestInternalError(t *testing.T) {
@@ -499,15 +510,16 @@ func TestInternalError(t *testing.T) {
 	router := newTestRouterWithDB(t, db)
 
 	for _, ep := range []string{
-		"/api/v1/sb700-20/app-b/list?page=1",
-		"/api/v1/sb700-20/app-c/list?page=1",
-		"/api/v1/sb700-20/chp-4/list?page=1",
+		"/api/v1/sb700-20/app-b/list",
+		"/api/v1/sb700-20/app-c/list",
+		"/api/v1/sb700-20/chp-4/list",
 	} {
 		resp := doJSONRequest(t, router, http.MethodGet, ep)
 		require.Equal(t, http.StatusInternalServerError, resp.Code, "endpoint: %s", ep)
-		var payload response.ErrorResponse
+		var payload apperror.Problem
 		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 		require.Equal(t, http.StatusInternalServerError, payload.Status)
+		require.Equal(t, "internal_error", payload.Code)
 	}
 }
 
@@ -10,11 +10,11 @@ import (
 	"testing"
 	"time"
 
+	"miltechserver/api/apperror"
 	"miltechserver/api/middleware"
 	"miltechserver/api/shops"
 	"miltechserver/bootstrap"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -37,9 +37,9 @@ func newTestRouter(t *testing.T) *gin.Engine {
 	group := router.Group("/api/v1/auth")
 
 	deps := shops.Dependencies{
-		DB:         testDB,
-		BlobClient: (*azblob.Client)(nil),
-		Env:        &bootstrap.Env{BlobAccountName: "test-account"},
+		DB:    testDB,
+		Store: nil,
+		Env:   &bootstrap.Env{BlobAccountName: "test-account"},
 	}
 
 	shops.RegisterRoutes(deps, group)
@@ -59,7 +59,6 @@ func testUserMiddleware() gin.HandlerFunc {
 			UserID:   userID,
 			Username: c.GetHeader("X-User-Name"),
 			Email:    c.GetHeader("X-User-Email"),
-			Role:     "user",
 		}
 
 		if user.Username == "" {
@@ -108,6 +107,15 @@ func decodeStandardResponse(t *testing.T, body *bytes.Buffer) standardResponse {
 	return resp
 }
 
+func decodeProblem(t *testing.T, body *bytes.Buffer) apperror.Problem {
+	t.Helper()
+
+	var problem apperror.Problem
+	err := json.Unmarshal(body.Bytes(), &problem)
+	require.NoError(t, err)
+	return problem
+}
+
 func decodeMap(t *testing.T, data json.RawMessage) map[string]interface{} {
 	t.Helper()
 
@@ -46,7 +46,7 @@ func TestAdminOnlyListsEnforcement(t *testing.T) {
 	}
 
 	createListResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/lists", createListBody, "user-2")
-	require.Equal(t, http.StatusInternalServerError, createListResp.Code)
+	require.Equal(t, http.StatusForbidden, createListResp.Code)
 
 	promoteBody := map[string]interface{}{"shop_id": shopID, "target_user_id": "user-2"}
 	promoteResp := doJSONRequest(t, router, http.MethodPut, "/api/v1/auth/shops/members/promote", promoteBody, "user-1")
@@ -109,11 +109,11 @@ func TestMemberAdminActionsRestricted(t *testing.T) {
 
 	removeBody := map[string]interface{}{"shop_id": shopID, "target_user_id": "user-1"}
 	removeResp := doJSONRequest(t, router, http.MethodDelete, "/api/v1/auth/shops/members/remove", removeBody, "user-2")
-	require.Equal(t, http.StatusInternalServerError, removeResp.Code)
+	require.Equal(t, http.StatusForbidden, removeResp.Code)
 
 	promoteBody := map[string]interface{}{"shop_id": shopID, "target_user_id": "user-2"}
 	promoteResp := doJSONRequest(t, router, http.MethodPut, "/api/v1/auth/shops/members/promote", promoteBody, "user-2")
-	require.Equal(t, http.StatusInternalServerError, promoteResp.Code)
+	require.Equal(t, http.StatusForbidden, promoteResp.Code)
 }
 
 func TestJoinWithInvalidOrInactiveCode(t *testing.T) {
@@ -127,13 +127,13 @@ func TestJoinWithInvalidOrInactiveCode(t *testing.T) {
 	codeID, inviteCode := createInviteCode(t, router, "user-1", shopID)
 
 	invalidResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/join", map[string]interface{}{"invite_code": "bad-code"}, "user-2")
-	require.Equal(t, http.StatusInternalServerError, invalidResp.Code)
+	require.Equal(t, http.StatusNotFound, invalidResp.Code)
 
 	deactivateResp := doJSONRequest(t, router, http.MethodDelete, "/api/v1/auth/shops/invite-codes/"+codeID, nil, "user-1")
 	require.Equal(t, http.StatusOK, deactivateResp.Code)
 
 	inactiveResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/join", map[string]interface{}{"invite_code": inviteCode}, "user-2")
-	require.Equal(t, http.StatusInternalServerError, inactiveResp.Code)
+	require.Equal(t, http.StatusConflict, inactiveResp.Code)
 }
 
 func TestUserDataWithShops(t *testing.T) {
@@ -167,5 +167,5 @@ func TestNonMemberNotificationAccessDenied(t *testing.T) {
 	_ = createNotification(t, router, "user-1", shopID, vehicleID, "PM")
 
 	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/vehicles/"+vehicleID+"/notifications", nil, "user-2")
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusForbidden, resp.Code)
 }
@@ -44,7 +44,9 @@ func TestListsWithItemsAggregateRejectsNonMember(t *testing.T) {
 	shopID := createShop(t, router, "user-1", "Private Lists")
 	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/lists-with-items", nil, "user-2")
 	require.Equal(t, http.StatusForbidden, resp.Code)
-	require.Equal(t, "access denied", decodeStandardResponse(t, resp.Body).Message)
+	problem := decodeProblem(t, resp.Body)
+	require.Equal(t, "shop_access_denied", problem.Code)
+	require.Equal(t, "access denied", problem.Detail)
 }
 
 func TestListsWithItemsAggregatePreservesEmptyLists(t *testing.T) {
@@ -174,7 +174,9 @@ func TestShopSnapshotRejectsInvalidIncludeAndLimits(t *testing.T) {
 
 	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/shop-1/snapshot?include=vehicles,bad", nil, "user-1")
 	require.Equal(t, http.StatusBadRequest, resp.Code)
-	require.Equal(t, "invalid include", decodeStandardResponse(t, resp.Body).Message)
+	problem := decodeProblem(t, resp.Body)
+	require.Equal(t, "invalid_include", problem.Code)
+	require.Equal(t, "invalid include", problem.Detail)
 
 	for _, path := range []string{
 		"/api/v1/auth/shops/shop-1/snapshot?message_limit=0",
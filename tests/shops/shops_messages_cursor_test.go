@@ -8,24 +8,24 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestMessagesPaginatedCursorBefore(t *testing.T) {
+func TestMessagesPaginatedCursor(t *testing.T) {
 	clearShopTables(t, testDB)
 	ensureUser(t, testDB, "user-1")
 
 	router := newTestRouter(t)
 
 	shopID := createShop(t, router, "user-1", "Cursor Shop")
-	_ = createMessage(t, router, "user-1", shopID, "First message")
+	firstMessageID := createMessage(t, router, "user-1", shopID, "First message")
 	time.Sleep(1 * time.Millisecond)
 	_ = createMessage(t, router, "user-1", shopID, "Second message")
 	time.Sleep(1 * time.Millisecond)
-	cursorMessageID := createMessage(t, router, "user-1", shopID, "Third message")
+	newestMessageID := createMessage(t, router, "user-1", shopID, "Third message")
 
 	getPagedResp := doJSONRequest(
 		t,
 		router,
 		http.MethodGet,
-		"/api/v1/auth/shops/"+shopID+"/messages/paginated?before_id="+cursorMessageID+"&limit=1",
+		"/api/v1/auth/shops/"+shopID+"/messages/paginated?limit=2&include_total=true",
 		nil,
 		"user-1",
 	)
@@ -34,18 +34,56 @@ func TestMessagesPaginatedCursorBefore(t *testing.T) {
 	decoded := decodeStandardResponse(t, getPagedResp.Body)
 	data := decodeMap(t, decoded.Data)
 
-	messages, ok := data["messages"].([]interface{})
+	items, ok := data["items"].([]interface{})
 	require.True(t, ok)
-	require.Len(t, messages, 1)
+	require.Len(t, items, 2)
 
-	message, ok := messages[0].(map[string]interface{})
+	message, ok := items[0].(map[string]interface{})
 	require.True(t, ok)
-	require.NotEqual(t, cursorMessageID, message["id"])
+	require.Equal(t, newestMessageID, message["id"])
+	require.Equal(t, float64(3), data["total"])
 
 	nextCursor, ok := data["next_cursor"].(string)
 	require.True(t, ok)
 	require.NotEmpty(t, nextCursor)
 
-	_, hasPagination := data["pagination"]
-	require.False(t, hasPagination)
+	getPagedResp = doJSONRequest(
+		t,
+		router,
+		http.MethodGet,
+		"/api/v1/auth/shops/"+shopID+"/messages/paginated?limit=2&cursor="+nextCursor,
+		nil,
+		"user-1",
+	)
+	require.Equal(t, http.StatusOK, getPagedResp.Code)
+
+	decoded = decodeStandardResponse(t, getPagedResp.Body)
+	data = decodeMap(t, decoded.Data)
+
+	items, ok = data["items"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, items, 1)
+
+	message, ok = items[0].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, firstMessageID, message["id"])
+	require.Nil(t, data["next_cursor"])
+
+	_, hasTotal := data["total"]
+	require.False(t, hasTotal)
+}
+
+func TestMessagesPaginatedRejectsInvalidCursor(t *testing.T) {
+	clearShopTables(t, testDB)
+	ensureUser(t, testDB, "user-1")
+
+	router := newTestRouter(t)
+
+	shopID := createShop(t, router, "user-1", "Cursor Shop")
+
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/messages/paginated?cursor=bad", nil, "user-1")
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/messages/paginated?limit=0", nil, "user-1")
+	require.Equal(t, http.StatusBadRequest, resp.Code)
 }
@@ -17,7 +17,7 @@ func TestMessagesPaginatedUpdateDelete(t *testing.T) {
 	messageID := createMessage(t, router, "user-1", shopID, "First message")
 	_ = createMessage(t, router, "user-1", shopID, "Second message")
 
-	getPagedResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/messages/paginated?page=1&limit=1", nil, "user-1")
+	getPagedResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/messages/paginated?limit=1", nil, "user-1")
 	require.Equal(t, http.StatusOK, getPagedResp.Code)
 
 	updateBody := map[string]interface{}{
@@ -290,10 +290,10 @@ func TestVehicleNotificationAttachedShopList(t *testing.T) {
 
 		crossShopResp := doJSONRequest(t, router, http.MethodPut, "/api/v1/auth/shops/vehicles/notifications", crossShopUpdate, "user-1")
 		require.NotContains(t, []int{http.StatusOK, http.StatusCreated, http.StatusNoContent}, crossShopResp.Code)
-		// Current shared error handling maps this service validation error to 500.
-		require.Equal(t, http.StatusInternalServerError, crossShopResp.Code)
-		errorResponse := decodeStandardResponse(t, crossShopResp.Body)
-		require.Equal(t, "invalid attached_shop_list", errorResponse.Message)
+		require.Equal(t, http.StatusBadRequest, crossShopResp.Code)
+		problem := decodeProblem(t, crossShopResp.Body)
+		require.Equal(t, "invalid_attached_shop_list", problem.Code)
+		require.Equal(t, "invalid attached_shop_list", problem.Detail)
 
 		getByIDResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/vehicles/notifications/"+notificationID, nil, "user-1")
 		require.Equal(t, http.StatusOK, getByIDResp.Code)
@@ -31,7 +31,7 @@ func TestNonMemberCannotAccessShop(t *testing.T) {
 	shopID := createShop(t, router, "user-1", "Member Shop")
 
 	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID, nil, "user-2")
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusForbidden, resp.Code)
 }
 
 func TestNonAdminCannotUpdateSettings(t *testing.T) {
@@ -48,7 +48,7 @@ func TestNonAdminCannotUpdateSettings(t *testing.T) {
 	}
 
 	resp := doJSONRequest(t, router, http.MethodPut, "/api/v1/auth/shops/"+shopID+"/settings", updateSettingsBody, "user-2")
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusForbidden, resp.Code)
 }
 
 func TestInvalidNotificationTypeRejected(t *testing.T) {
@@ -69,5 +69,5 @@ func TestInvalidNotificationTypeRejected(t *testing.T) {
 	}
 
 	resp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/vehicles/notifications", notificationBody, "user-1")
-	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
 }
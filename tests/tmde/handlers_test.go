@@ -3,13 +3,12 @@ package tmde_test
 import (
 	"database/sql"
 	"encoding/json"
-	"math"
 	"net/http"
 	"testing"
 
 	"miltechserver/.gen/miltech_ng/public/model"
-	"miltechserver/api/response"
-	"miltechserver/api/tmde"
+	"miltechserver/api/apperror"
+	"miltechserver/api/pagination"
 
 	"github.com/stretchr/testify/require"
 )
@@ -25,11 +24,14 @@ func TestTmdeBlankParams(t *testing.T) {
 	require.NoError(t, json.Unmarshal(blankNiinResp.Body.Bytes(), &niinErrBody))
 	require.NotEmpty(t, niinErrBody.Error)
 
-	invalidPageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?page=bad")
-	require.Equal(t, http.StatusBadRequest, invalidPageResp.Code)
+	invalidLimitResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?limit=bad")
+	require.Equal(t, http.StatusBadRequest, invalidLimitResp.Code)
 
-	zeroPageResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?page=0")
-	require.Equal(t, http.StatusBadRequest, zeroPageResp.Code)
+	zeroLimitResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?limit=0")
+	require.Equal(t, http.StatusBadRequest, zeroLimitResp.Code)
+
+	invalidCursorResp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?cursor=bad")
+	require.Equal(t, http.StatusBadRequest, invalidCursorResp.Code)
 }
 
 func TestTmdeLookupByNIIN(t *testing.T) {
@@ -69,9 +71,10 @@ func TestTmdeNiinNotFound(t *testing.T) {
 	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/niin/000000000NOTREAL")
 	require.Equal(t, http.StatusNotFound, resp.Code)
 
-	var payload response.NoItemFoundResponse
+	var payload apperror.Problem
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 	require.Equal(t, http.StatusNotFound, payload.Status)
+	require.Equal(t, "no_items_found", payload.Code)
 }
 
 func TestTmdeListPaginated(t *testing.T) {
@@ -82,29 +85,34 @@ func TestTmdeListPaginated(t *testing.T) {
 	}
 
 	rowCount := countRows(t, testDB, "tmde_interval_mat")
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?page=1")
-
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
-
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?limit=2&include_total=true")
 	require.Equal(t, http.StatusOK, resp.Code)
 
 	var payload standardResponse
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 
-	var data tmde.TmdePageResponse
+	var data pagination.Page[model.TmdeIntervalMat]
 	require.NoError(t, json.Unmarshal(payload.Data, &data))
-	require.NotEmpty(t, data.Items)
-	require.Equal(t, 1, data.Page)
+	require.Len(t, data.Items, min(rowCount, 2))
+	require.NotNil(t, data.Total)
+	require.Equal(t, rowCount, *data.Total)
+	require.Equal(t, rowCount > 2, data.NextCursor != nil)
+	if data.NextCursor == nil {
+		return
+	}
+
+	resp = doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?limit=2&cursor="+*data.NextCursor)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 
-	expectedTotalPages := int(math.Ceil(float64(rowCount) / 100.0))
-	require.Equal(t, expectedTotalPages, data.TotalPages)
-	require.Equal(t, 1 >= expectedTotalPages, data.IsLastPage)
+	var next pagination.Page[model.TmdeIntervalMat]
+	require.NoError(t, json.Unmarshal(payload.Data, &next))
+	require.NotEmpty(t, next.Items)
+	require.Nil(t, next.Total)
+	require.Greater(t, *next.Items[0].Niin, *data.Items[1].Niin)
 }
 
-func TestTmdeListDefaultPage(t *testing.T) {
+func TestTmdeListDefaultLimit(t *testing.T) {
 	router := newTestRouter(t)
 
 	if !hasRelation(t, testDB, "tmde_interval_mat") {
@@ -113,24 +121,15 @@ func TestTmdeListDefaultPage(t *testing.T) {
 
 	rowCount := countRows(t, testDB, "tmde_interval_mat")
 
-	// omitting ?page should default to page 1
 	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements")
-	if rowCount == 0 {
-		require.Equal(t, http.StatusNotFound, resp.Code)
-		return
-	}
 	require.Equal(t, http.StatusOK, resp.Code)
-}
-
-func TestTmdeListBeyondLastPage(t *testing.T) {
-	router := newTestRouter(t)
 
-	if !hasRelation(t, testDB, "tmde_interval_mat") {
-		t.Skip("tmde_interval_mat view missing in test DB")
-	}
+	var payload standardResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?page=999999")
-	require.Equal(t, http.StatusNotFound, resp.Code)
+	var data pagination.Page[model.TmdeIntervalMat]
+	require.NoError(t, json.Unmarshal(payload.Data, &data))
+	require.Len(t, data.Items, min(rowCount, 100))
 }
 
 func TestTmdeInternalError(t *testing.T) {
@@ -140,10 +139,12 @@ func TestTmdeInternalError(t *testing.T) {
 
 	router := newTestRouterWithDB(t, db)
 
-	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements?page=1")
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/tmde/requirements")
 	require.Equal(t, http.StatusInternalServerError, resp.Code)
 
-	var payload response.ErrorResponse
+	require.Equal(t, apperror.ContentType, resp.Header().Get("Content-Type"))
+	var payload apperror.Problem
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
 	require.Equal(t, http.StatusInternalServerError, payload.Status)
+	require.Equal(t, "internal_error", payload.Code)
 }
@@ -1,9 +1,11 @@
 package tmde_test
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
+	"miltechserver/api/pagination"
 	"miltechserver/api/tmde"
 
 	"github.com/stretchr/testify/require"
@@ -12,15 +14,12 @@ import (
 func TestRepositoryValidationErrors(t *testing.T) {
 	repo := tmde.NewRepository((*sql.DB)(nil))
 
-	_, err := repo.GetByNIIN("  ")
+	_, err := repo.GetByNIIN(context.Background(), "  ")
 	require.ErrorIs(t, err, tmde.ErrEmptyParam)
 
-	_, err = repo.GetByNIIN("\t")
+	_, err = repo.GetByNIIN(context.Background(), "\t")
 	require.ErrorIs(t, err, tmde.ErrEmptyParam)
 
-	_, err = repo.GetAllPaginated(0)
-	require.ErrorIs(t, err, tmde.ErrInvalidPage)
-
-	_, err = repo.GetAllPaginated(-1)
-	require.ErrorIs(t, err, tmde.ErrInvalidPage)
+	_, err = repo.GetAllPaginated(context.Background(), pagination.Params{Cursor: "bad", Limit: 10})
+	require.ErrorIs(t, err, pagination.ErrInvalidCursor)
 }
@@ -14,7 +14,6 @@ import (
 	"miltechserver/api/user_saves"
 	"miltechserver/bootstrap"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 )
@@ -36,9 +35,9 @@ func newTestRouter(t *testing.T) *gin.Engine {
 	group := router.Group("/api/v1/auth")
 
 	deps := user_saves.Dependencies{
-		DB:         testDB,
-		BlobClient: (*azblob.Client)(nil),
-		Env:        &bootstrap.Env{BlobAccountName: "test-account"},
+		DB:    testDB,
+		Store: nil,
+		Env:   &bootstrap.Env{BlobAccountName: "test-account"},
 	}
 
 	user_saves.RegisterRoutes(deps, group)
@@ -58,7 +57,6 @@ func testUserMiddleware() gin.HandlerFunc {
 			UserID:   userID,
 			Username: c.GetHeader("X-User-Name"),
 			Email:    c.GetHeader("X-User-Email"),
-			Role:     "user",
 		}
 
 		if user.Username == "" {
@@ -1,6 +1,7 @@
 package user_saves_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -38,14 +39,14 @@ func TestQuickRepositoryCRUD(t *testing.T) {
 	}
 
 	repo := quick.NewRepository(testDB)
-	require.NoError(t, repo.Upsert(user, item))
+	require.NoError(t, repo.Upsert(context.Background(), user, item))
 
-	items, err := repo.GetByUser(user)
+	items, err := repo.GetByUser(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 
-	require.NoError(t, repo.Delete(user, item))
-	items, err = repo.GetByUser(user)
+	require.NoError(t, repo.Delete(context.Background(), user, item))
+	items, err = repo.GetByUser(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
@@ -92,12 +93,12 @@ func TestSerializedRepositoryCRUD(t *testing.T) {
 
 	repo := serialized.NewRepository(testDB)
 
-	items, err := repo.GetByUser(user)
+	items, err := repo.GetByUser(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 
-	require.NoError(t, repo.Delete(user, item))
-	items, err = repo.GetByUser(user)
+	require.NoError(t, repo.Delete(context.Background(), user, item))
+	items, err = repo.GetByUser(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
@@ -121,7 +122,7 @@ func TestCategoriesRepositoryDeleteCascade(t *testing.T) {
 
 	categoryRepo := categories.NewRepository(testDB)
 	itemsRepo := categoryitems.NewRepository(testDB)
-	require.NoError(t, categoryRepo.Upsert(user, category))
+	require.NoError(t, categoryRepo.Upsert(context.Background(), user, category))
 
 	itemName := "Item"
 	quantity := int32(2)
@@ -142,15 +143,15 @@ func TestCategoriesRepositoryDeleteCascade(t *testing.T) {
 		LastUpdated: &now,
 		Nickname:    &nickname,
 	}
-	require.NoError(t, itemsRepo.Upsert(user, item))
+	require.NoError(t, itemsRepo.Upsert(context.Background(), user, item))
 
-	require.NoError(t, categoryRepo.Delete(user, category))
+	require.NoError(t, categoryRepo.Delete(context.Background(), user, category))
 
-	cats, err := categoryRepo.GetByUser(user)
+	cats, err := categoryRepo.GetByUser(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, cats, 0)
 
-	items, err := itemsRepo.GetByUser(user)
+	items, err := itemsRepo.GetByUser(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
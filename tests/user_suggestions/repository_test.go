@@ -1,6 +1,7 @@
 package user_suggestions_test
 
 import (
+	"context"
 	"testing"
 
 	user_suggestions "miltechserver/api/user_suggestions"
@@ -21,7 +22,7 @@ func TestGetAllWithScores_ShowFilter(t *testing.T) {
 	insertSuggestion(t, testDB, "Unset Feature", userID, nil)
 
 	repo := user_suggestions.NewRepository(testDB)
-	results, err := repo.GetAllWithScores("")
+	results, err := repo.GetAllWithScores(context.Background(), "")
 	require.NoError(t, err)
 	require.Len(t, results, 1)
 	require.Equal(t, "Visible Feature", results[0].Title)
@@ -39,7 +40,7 @@ func TestGetAllWithScores_EmptyWhenAllHidden(t *testing.T) {
 	insertSuggestion(t, testDB, "Hidden B", userID, boolPtr(false))
 
 	repo := user_suggestions.NewRepository(testDB)
-	results, err := repo.GetAllWithScores("")
+	results, err := repo.GetAllWithScores(context.Background(), "")
 	require.NoError(t, err)
 	require.Empty(t, results)
 }
@@ -56,7 +57,7 @@ func TestGetAllWithScores_MultipleVisible(t *testing.T) {
 	insertSuggestion(t, testDB, "Feature Hidden", userID, boolPtr(false))
 
 	repo := user_suggestions.NewRepository(testDB)
-	results, err := repo.GetAllWithScores("")
+	results, err := repo.GetAllWithScores(context.Background(), "")
 	require.NoError(t, err)
 	require.Len(t, results, 2)
 }
@@ -1,6 +1,7 @@
 package user_vehicles_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -36,18 +37,18 @@ func TestVehiclesRepositoryCRUD(t *testing.T) {
 	}
 
 	repo := vehicles.NewRepository(testDB)
-	require.NoError(t, repo.Upsert(user, vehicle))
+	require.NoError(t, repo.Upsert(context.Background(), user, vehicle))
 
-	items, err := repo.GetByUserID(user)
+	items, err := repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 
-	found, err := repo.GetByID(user, vehicle.ID)
+	found, err := repo.GetByID(context.Background(), user, vehicle.ID)
 	require.NoError(t, err)
 	require.Equal(t, vehicle.ID, found.ID)
 
-	require.NoError(t, repo.Delete(user, vehicle.ID))
-	items, err = repo.GetByUserID(user)
+	require.NoError(t, repo.Delete(context.Background(), user, vehicle.ID))
+	items, err = repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
@@ -89,15 +90,15 @@ func TestVehiclesRepositoryDeleteAll(t *testing.T) {
 		LastUpdated: now,
 	}
 
-	require.NoError(t, repo.Upsert(user, first))
-	require.NoError(t, repo.Upsert(user, second))
+	require.NoError(t, repo.Upsert(context.Background(), user, first))
+	require.NoError(t, repo.Upsert(context.Background(), user, second))
 
-	items, err := repo.GetByUserID(user)
+	items, err := repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 2)
 
-	require.NoError(t, repo.DeleteAll(user))
-	items, err = repo.GetByUserID(user)
+	require.NoError(t, repo.DeleteAll(context.Background(), user))
+	items, err = repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
@@ -124,7 +125,7 @@ func TestNotificationsRepositoryCRUD(t *testing.T) {
 	}
 
 	vehicleRepo := vehicles.NewRepository(testDB)
-	require.NoError(t, vehicleRepo.Upsert(user, vehicle))
+	require.NoError(t, vehicleRepo.Upsert(context.Background(), user, vehicle))
 
 	notification := model.UserVehicleNotifications{
 		ID:          uuid.New().String(),
@@ -139,22 +140,22 @@ func TestNotificationsRepositoryCRUD(t *testing.T) {
 	}
 
 	repo := notifications.NewRepository(testDB)
-	require.NoError(t, repo.Upsert(user, notification))
+	require.NoError(t, repo.Upsert(context.Background(), user, notification))
 
-	items, err := repo.GetByUserID(user)
+	items, err := repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 
-	byVehicle, err := repo.GetByVehicleID(user, vehicle.ID)
+	byVehicle, err := repo.GetByVehicleID(context.Background(), user, vehicle.ID)
 	require.NoError(t, err)
 	require.Len(t, byVehicle, 1)
 
-	found, err := repo.GetByID(user, notification.ID)
+	found, err := repo.GetByID(context.Background(), user, notification.ID)
 	require.NoError(t, err)
 	require.Equal(t, notification.ID, found.ID)
 
-	require.NoError(t, repo.Delete(user, notification.ID))
-	items, err = repo.GetByUserID(user)
+	require.NoError(t, repo.Delete(context.Background(), user, notification.ID))
+	items, err = repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
@@ -181,7 +182,7 @@ func TestNotificationsRepositoryDeleteAllByVehicle(t *testing.T) {
 	}
 
 	vehicleRepo := vehicles.NewRepository(testDB)
-	require.NoError(t, vehicleRepo.Upsert(user, vehicle))
+	require.NoError(t, vehicleRepo.Upsert(context.Background(), user, vehicle))
 
 	repo := notifications.NewRepository(testDB)
 	first := model.UserVehicleNotifications{
@@ -207,15 +208,15 @@ func TestNotificationsRepositoryDeleteAllByVehicle(t *testing.T) {
 		LastUpdated: now,
 	}
 
-	require.NoError(t, repo.Upsert(user, first))
-	require.NoError(t, repo.Upsert(user, second))
+	require.NoError(t, repo.Upsert(context.Background(), user, first))
+	require.NoError(t, repo.Upsert(context.Background(), user, second))
 
-	items, err := repo.GetByVehicleID(user, vehicle.ID)
+	items, err := repo.GetByVehicleID(context.Background(), user, vehicle.ID)
 	require.NoError(t, err)
 	require.Len(t, items, 2)
 
-	require.NoError(t, repo.DeleteAllByVehicle(user, vehicle.ID))
-	items, err = repo.GetByVehicleID(user, vehicle.ID)
+	require.NoError(t, repo.DeleteAllByVehicle(context.Background(), user, vehicle.ID))
+	items, err = repo.GetByVehicleID(context.Background(), user, vehicle.ID)
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
@@ -242,7 +243,7 @@ func TestNotificationItemsRepositoryCRUD(t *testing.T) {
 	}
 
 	vehicleRepo := vehicles.NewRepository(testDB)
-	require.NoError(t, vehicleRepo.Upsert(user, vehicle))
+	require.NoError(t, vehicleRepo.Upsert(context.Background(), user, vehicle))
 
 	notification := model.UserVehicleNotifications{
 		ID:          uuid.New().String(),
@@ -257,7 +258,7 @@ func TestNotificationItemsRepositoryCRUD(t *testing.T) {
 	}
 
 	notificationsRepo := notifications.NewRepository(testDB)
-	require.NoError(t, notificationsRepo.Upsert(user, notification))
+	require.NoError(t, notificationsRepo.Upsert(context.Background(), user, notification))
 
 	item := model.UserNotificationItems{
 		ID:             uuid.New().String(),
@@ -270,17 +271,17 @@ func TestNotificationItemsRepositoryCRUD(t *testing.T) {
 	}
 
 	repo := notification_items.NewRepository(testDB)
-	require.NoError(t, repo.Upsert(user, item))
+	require.NoError(t, repo.Upsert(context.Background(), user, item))
 
-	items, err := repo.GetByUserID(user)
+	items, err := repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 
-	byNotification, err := repo.GetByNotificationID(user, notification.ID)
+	byNotification, err := repo.GetByNotificationID(context.Background(), user, notification.ID)
 	require.NoError(t, err)
 	require.Len(t, byNotification, 1)
 
-	found, err := repo.GetByID(user, item.ID)
+	found, err := repo.GetByID(context.Background(), user, item.ID)
 	require.NoError(t, err)
 	require.Equal(t, item.ID, found.ID)
 
@@ -294,14 +295,14 @@ func TestNotificationItemsRepositoryCRUD(t *testing.T) {
 		SaveTime:       now,
 	}
 
-	require.NoError(t, repo.UpsertBatch(user, []model.UserNotificationItems{item, second}))
+	require.NoError(t, repo.UpsertBatch(context.Background(), user, []model.UserNotificationItems{item, second}))
 
-	items, err = repo.GetByUserID(user)
+	items, err = repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 2)
 
-	require.NoError(t, repo.Delete(user, item.ID))
-	items, err = repo.GetByUserID(user)
+	require.NoError(t, repo.Delete(context.Background(), user, item.ID))
+	items, err = repo.GetByUserID(context.Background(), user)
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 }
@@ -328,7 +329,7 @@ func TestNotificationItemsRepositoryDeleteAllByNotification(t *testing.T) {
 	}
 
 	vehicleRepo := vehicles.NewRepository(testDB)
-	require.NoError(t, vehicleRepo.Upsert(user, vehicle))
+	require.NoError(t, vehicleRepo.Upsert(context.Background(), user, vehicle))
 
 	notification := model.UserVehicleNotifications{
 		ID:          uuid.New().String(),
@@ -343,7 +344,7 @@ func TestNotificationItemsRepositoryDeleteAllByNotification(t *testing.T) {
 	}
 
 	notificationsRepo := notifications.NewRepository(testDB)
-	require.NoError(t, notificationsRepo.Upsert(user, notification))
+	require.NoError(t, notificationsRepo.Upsert(context.Background(), user, notification))
 
 	repo := notification_items.NewRepository(testDB)
 	first := model.UserNotificationItems{
@@ -365,15 +366,15 @@ func TestNotificationItemsRepositoryDeleteAllByNotification(t *testing.T) {
 		SaveTime:       now,
 	}
 
-	require.NoError(t, repo.Upsert(user, first))
-	require.NoError(t, repo.Upsert(user, second))
+	require.NoError(t, repo.Upsert(context.Background(), user, first))
+	require.NoError(t, repo.Upsert(context.Background(), user, second))
 
-	items, err := repo.GetByNotificationID(user, notification.ID)
+	items, err := repo.GetByNotificationID(context.Background(), user, notification.ID)
 	require.NoError(t, err)
 	require.Len(t, items, 2)
 
-	require.NoError(t, repo.DeleteAllByNotification(user, notification.ID))
-	items, err = repo.GetByNotificationID(user, notification.ID)
+	require.NoError(t, repo.DeleteAllByNotification(context.Background(), user, notification.ID))
+	items, err = repo.GetByNotificationID(context.Background(), user, notification.ID)
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
@@ -0,0 +1,208 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"miltechserver/events"
+	"miltechserver/jobs"
+	"miltechserver/webhook"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventIsDeliveredSigned(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+
+	endpoint := newReceiver(t)
+	hook := createWebhook(t, app.router, adminID, shopID, endpoint.URL+"/hook", events.TypeMemberJoined)
+
+	joinShop(t, app.router, adminID, memberID, shopID)
+
+	req := endpoint.next(t)
+	require.Equal(t, events.TypeMemberJoined, req.Header.Get(webhook.HeaderEvent))
+	require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	timestamp, err := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.True(t, webhook.Verify(hook.Secret, timestamp, req.Body, req.Header.Get(webhook.HeaderSignature)))
+	require.False(t, webhook.Verify("whsec_wrong", timestamp, req.Body, req.Header.Get(webhook.HeaderSignature)))
+
+	var body struct {
+		ID     int64               `json:"id"`
+		Type   string              `json:"type"`
+		ShopID string              `json:"shop_id"`
+		Data   events.MemberJoined `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(req.Body, &body))
+	require.Equal(t, events.TypeMemberJoined, body.Type)
+	require.Equal(t, shopID, body.ShopID)
+	require.Equal(t, memberID, body.Data.UserID)
+	require.Equal(t, strconv.FormatInt(body.ID, 10), req.Header.Get(webhook.HeaderEventID))
+
+	deliveries := waitForDeliveries(t, app.router, adminID, shopID, hook.ID, 1)
+	delivery := deliveries[0]
+	require.Equal(t, body.ID, delivery.EventID)
+	require.Equal(t, events.TypeMemberJoined, delivery.EventType)
+	require.Equal(t, "event", delivery.Trigger)
+	require.Equal(t, 1, delivery.Attempt)
+	require.Equal(t, "succeeded", delivery.Status)
+	require.NotNil(t, delivery.ResponseStatus)
+	require.Equal(t, http.StatusOK, *delivery.ResponseStatus)
+	require.Nil(t, delivery.Error)
+	require.JSONEq(t, string(req.Body), string(delivery.Payload))
+}
+
+func TestFailedWebhookIsRetriedAlone(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+
+	healthy := newReceiver(t)
+	flaky := newReceiver(t, http.StatusInternalServerError)
+	healthyHook := createWebhook(t, app.router, adminID, shopID, healthy.URL, events.TypeMemberJoined)
+	flakyHook := createWebhook(t, app.router, adminID, shopID, flaky.URL, events.TypeMemberJoined)
+
+	joinShop(t, app.router, adminID, memberID, shopID)
+	healthy.next(t)
+	flaky.next(t)
+
+	failed := waitForDeliveries(t, app.router, adminID, shopID, flakyHook.ID, 1)[0]
+	require.Equal(t, "failed", failed.Status)
+	require.NotNil(t, failed.ResponseStatus)
+	require.Equal(t, http.StatusInternalServerError, *failed.ResponseStatus)
+	require.NotNil(t, failed.Error)
+	require.Equal(t, "endpoint returned 500", *failed.Error)
+
+	// The outbox schedules the event again once the deliverer reports the
+	// failure
+	require.Eventually(t, func() bool {
+		var pending bool
+		err := testDB.QueryRow(`
+			SELECT EXISTS (SELECT 1 FROM outbox_events WHERE last_error IS NOT NULL AND dispatched_at IS NULL)`).Scan(&pending)
+		require.NoError(t, err)
+		return pending
+	}, 5*time.Second, 20*time.Millisecond)
+	retryNow(t, testDB)
+
+	flaky.next(t)
+	deliveries := waitForDeliveries(t, app.router, adminID, shopID, flakyHook.ID, 2)
+	require.Equal(t, "succeeded", deliveries[0].Status)
+	require.Equal(t, 2, deliveries[0].Attempt)
+	require.Equal(t, failed.EventID, deliveries[0].EventID)
+
+	// The endpoint that already had the event is not sent it again
+	require.Never(t, func() bool { return len(healthy.requests) > 0 }, 5*pollEvery, pollEvery)
+	require.Len(t, getDeliveries(t, app.router, adminID, shopID, healthyHook.ID), 1)
+}
+
+func TestOnlyActiveSubscribedWebhooksReceiveEvents(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+
+	endpoint := newReceiver(t)
+	createWebhook(t, app.router, adminID, shopID, endpoint.URL+"/left", events.TypeMemberLeft)
+	inactive := createWebhook(t, app.router, adminID, shopID, endpoint.URL+"/inactive", events.TypeMemberJoined)
+	resp := doJSONRequest(t, app.router, http.MethodPut, "/api/v1/auth/shops/"+shopID+"/webhooks/"+inactive.ID, map[string]interface{}{"active": false}, adminID)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	joinShop(t, app.router, adminID, memberID, shopID)
+
+	require.Eventually(t, func() bool {
+		var pending int
+		err := testDB.QueryRow(`SELECT COUNT(*) FROM outbox_events WHERE dispatched_at IS NULL`).Scan(&pending)
+		require.NoError(t, err)
+		return pending == 0
+	}, 5*time.Second, 20*time.Millisecond)
+	require.Empty(t, endpoint.requests)
+}
+
+func TestReplayDelivery(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	memberID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+
+	endpoint := newReceiver(t)
+	hook := createWebhook(t, app.router, adminID, shopID, endpoint.URL, events.TypeMemberJoined)
+	joinShop(t, app.router, adminID, memberID, shopID)
+
+	original := endpoint.next(t)
+	delivery := waitForDeliveries(t, app.router, adminID, shopID, hook.ID, 1)[0]
+	base := "/api/v1/auth/shops/" + shopID + "/webhooks/" + hook.ID + "/deliveries/"
+
+	replayResp := doJSONRequest(t, app.router, http.MethodPost, base+strconv.FormatInt(delivery.ID, 10)+"/replay", nil, adminID)
+	require.Equal(t, http.StatusOK, replayResp.Code, replayResp.Body.String())
+	replayed := decodeData[deliveryResponse](t, replayResp.Body)
+	require.NotEqual(t, delivery.ID, replayed.ID)
+	require.Equal(t, "replay", replayed.Trigger)
+	require.Equal(t, 1, replayed.Attempt)
+	require.Equal(t, "succeeded", replayed.Status)
+	require.Equal(t, delivery.EventID, replayed.EventID)
+
+	again := endpoint.next(t)
+	require.Equal(t, original.Header.Get(webhook.HeaderEventID), again.Header.Get(webhook.HeaderEventID))
+	require.JSONEq(t, string(original.Body), string(again.Body))
+	timestamp, err := strconv.ParseInt(again.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.True(t, webhook.Verify(hook.Secret, timestamp, again.Body, again.Header.Get(webhook.HeaderSignature)))
+
+	require.Len(t, getDeliveries(t, app.router, adminID, shopID, hook.ID), 2)
+
+	missingResp := doJSONRequest(t, app.router, http.MethodPost, base+"999999999/replay", nil, adminID)
+	require.Equal(t, http.StatusNotFound, missingResp.Code)
+	require.Equal(t, "webhook_delivery_not_found", decodeProblem(t, missingResp.Body).Code)
+
+	invalidResp := doJSONRequest(t, app.router, http.MethodPost, base+"abc/replay", nil, adminID)
+	require.Equal(t, http.StatusBadRequest, invalidResp.Code)
+
+	deactivate := doJSONRequest(t, app.router, http.MethodPut, "/api/v1/auth/shops/"+shopID+"/webhooks/"+hook.ID, map[string]interface{}{"active": false}, adminID)
+	require.Equal(t, http.StatusOK, deactivate.Code)
+
+	inactiveResp := doJSONRequest(t, app.router, http.MethodPost, base+strconv.FormatInt(delivery.ID, 10)+"/replay", nil, adminID)
+	require.Equal(t, http.StatusConflict, inactiveResp.Code)
+	require.Equal(t, "webhook_inactive", decodeProblem(t, inactiveResp.Body).Code)
+}
+
+func TestPruneDeletesOldDeliveries(t *testing.T) {
+	app := newTestApp(t)
+	adminID := newUser(t, testDB)
+	shopID := createShop(t, app.router, adminID)
+	hook := createWebhook(t, app.router, adminID, shopID, "https://example.com/hook", events.TypeMemberPromoted)
+
+	insert := func(age time.Duration) int64 {
+		var id int64
+		err := testDB.QueryRow(`
+			INSERT INTO shop_webhook_deliveries
+				(webhook_id, event_id, event_type, trigger, attempt, status, duration_ms, payload, created_at)
+			VALUES ($1, 1, $2, 'event', 1, 'succeeded', 5, '{}', now() - make_interval(secs => $3))
+			RETURNING id`, hook.ID, events.TypeMemberPromoted, age.Seconds()).Scan(&id)
+		require.NoError(t, err)
+		return id
+	}
+	insert(31 * 24 * time.Hour)
+	recent := insert(24 * time.Hour)
+
+	run, err := app.scheduler.Trigger(context.Background(), "webhook-deliveries-prune", adminID)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		var status string
+		err := testDB.QueryRow(`SELECT status FROM job_runs WHERE id = $1`, run.ID).Scan(&status)
+		require.NoError(t, err)
+		return status == jobs.StatusSucceeded
+	}, 5*time.Second, 20*time.Millisecond)
+
+	deliveries := getDeliveries(t, app.router, adminID, shopID, hook.ID)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, recent, deliveries[0].ID)
+}
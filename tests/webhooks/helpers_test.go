@@ -0,0 +1,322 @@
+package webhooks_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"miltechserver/api/apperror"
+	"miltechserver/api/middleware"
+	"miltechserver/api/shops"
+	"miltechserver/bootstrap"
+	"miltechserver/events"
+	"miltechserver/jobs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const pollEvery = 50 * time.Millisecond
+
+type standardResponse struct {
+	Status  int             `json:"status"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+}
+
+type webhookResponse struct {
+	ID          string   `json:"id"`
+	ShopID      string   `json:"shop_id"`
+	URL         string   `json:"url"`
+	Description *string  `json:"description"`
+	EventTypes  []string `json:"event_types"`
+	Active      bool     `json:"active"`
+	CreatedBy   string   `json:"created_by"`
+	Secret      string   `json:"secret"`
+}
+
+type deliveryResponse struct {
+	ID             int64           `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Trigger        string          `json:"trigger"`
+	Attempt        int             `json:"attempt"`
+	Status         string          `json:"status"`
+	ResponseStatus *int            `json:"response_status"`
+	Error          *string         `json:"error"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+// testApp is the shop routes with a running event dispatcher, so events
+// published by requests reach the webhook deliverer.
+type testApp struct {
+	router    *gin.Engine
+	scheduler *jobs.Scheduler
+}
+
+func newTestApp(t *testing.T) testApp {
+	t.Helper()
+
+	clearOutbox(t, testDB)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler)
+	router.Use(testUserMiddleware())
+
+	group := router.Group("/api/v1/auth")
+
+	bus := events.NewBus(testDB)
+	scheduler := jobs.New(testDB)
+	deps := shops.Dependencies{
+		DB: testDB,
+		Env: &bootstrap.Env{
+			BlobAccountName: "test-account",
+			// The receivers are httptest servers on loopback
+			WebhookAllowPrivateURLs: true,
+		},
+		Events: bus,
+		Jobs:   scheduler,
+	}
+
+	shops.RegisterRoutes(deps, group)
+	bus.Start(pollEvery)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, bus.Stop(ctx))
+		require.NoError(t, scheduler.Stop(ctx))
+	})
+
+	return testApp{router: router, scheduler: scheduler}
+}
+
+func testUserMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetHeader("X-User-ID")
+		if userID == "" {
+			c.Next()
+			return
+		}
+
+		c.Set("user", &bootstrap.User{
+			UserID:   userID,
+			Username: "test-user",
+			Email:    userID + "@example.com",
+		})
+		c.Next()
+	}
+}
+
+func doJSONRequest(t *testing.T, router *gin.Engine, method string, path string, body interface{}, userID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	reader := strings.NewReader("")
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = strings.NewReader(string(payload))
+	}
+
+	req, err := http.NewRequest(method, path, reader)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeStandardResponse(t *testing.T, body *bytes.Buffer) standardResponse {
+	t.Helper()
+
+	var resp standardResponse
+	err := json.Unmarshal(body.Bytes(), &resp)
+	require.NoError(t, err)
+	return resp
+}
+
+func decodeProblem(t *testing.T, body *bytes.Buffer) apperror.Problem {
+	t.Helper()
+
+	var problem apperror.Problem
+	err := json.Unmarshal(body.Bytes(), &problem)
+	require.NoError(t, err)
+	return problem
+}
+
+func decodeData[T any](t *testing.T, body *bytes.Buffer) T {
+	t.Helper()
+
+	var data T
+	err := json.Unmarshal(decodeStandardResponse(t, body).Data, &data)
+	require.NoError(t, err)
+	return data
+}
+
+func clearOutbox(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`TRUNCATE TABLE outbox_events RESTART IDENTITY`)
+	require.NoError(t, err)
+}
+
+// newUser inserts a user with a fresh ID, so tests never share shops.
+func newUser(t *testing.T, db *sql.DB) string {
+	t.Helper()
+
+	userID := "webhooks-" + uuid.New().String()
+	_, err := db.Exec(
+		`INSERT INTO users (uid, email, username, created_at, is_enabled)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		userID,
+		userID+"@example.com",
+		"test-user",
+		time.Now().UTC(),
+		true,
+	)
+	require.NoError(t, err)
+	return userID
+}
+
+func createShop(t *testing.T, router *gin.Engine, userID string) string {
+	t.Helper()
+
+	body := map[string]interface{}{
+		"name":    "Webhook Shop",
+		"details": "Details",
+	}
+
+	resp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops", body, userID)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	shop := decodeData[map[string]interface{}](t, resp.Body)
+	shopID, ok := shop["id"].(string)
+	require.True(t, ok)
+	return shopID
+}
+
+// joinShop adds userID to the shop with an invite code, which publishes a
+// shop.member.joined event.
+func joinShop(t *testing.T, router *gin.Engine, adminID string, userID string, shopID string) {
+	t.Helper()
+
+	inviteResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/invite-codes", map[string]interface{}{"shop_id": shopID}, adminID)
+	require.Equal(t, http.StatusCreated, inviteResp.Code)
+
+	code, ok := decodeData[map[string]interface{}](t, inviteResp.Body)["code"].(string)
+	require.True(t, ok)
+
+	joinResp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/join", map[string]interface{}{"invite_code": code}, userID)
+	require.Equal(t, http.StatusOK, joinResp.Code)
+}
+
+func createWebhook(t *testing.T, router *gin.Engine, userID string, shopID string, url string, eventTypes ...string) webhookResponse {
+	t.Helper()
+
+	body := map[string]interface{}{
+		"url":         url,
+		"event_types": eventTypes,
+	}
+
+	resp := doJSONRequest(t, router, http.MethodPost, "/api/v1/auth/shops/"+shopID+"/webhooks", body, userID)
+	require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+	return decodeData[webhookResponse](t, resp.Body)
+}
+
+func getDeliveries(t *testing.T, router *gin.Engine, userID string, shopID string, webhookID string) []deliveryResponse {
+	t.Helper()
+
+	resp := doJSONRequest(t, router, http.MethodGet, "/api/v1/auth/shops/"+shopID+"/webhooks/"+webhookID+"/deliveries", nil, userID)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	page := decodeData[struct {
+		Items []deliveryResponse `json:"items"`
+	}](t, resp.Body)
+	return page.Items
+}
+
+// waitForDeliveries polls the webhook's delivery log until it has n
+// entries and returns them, newest first.
+func waitForDeliveries(t *testing.T, router *gin.Engine, userID string, shopID string, webhookID string, n int) []deliveryResponse {
+	t.Helper()
+
+	var deliveries []deliveryResponse
+	require.Eventually(t, func() bool {
+		deliveries = getDeliveries(t, router, userID, shopID, webhookID)
+		return len(deliveries) >= n
+	}, 5*time.Second, 20*time.Millisecond)
+	require.Len(t, deliveries, n)
+	return deliveries
+}
+
+// retryNow makes every pending event due, skipping the outbox backoff.
+func retryNow(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	_, err := db.Exec(`UPDATE outbox_events SET available_at = now() WHERE dispatched_at IS NULL AND failed_at IS NULL`)
+	require.NoError(t, err)
+}
+
+// receivedRequest is a request a receiver was sent.
+type receivedRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+// receiver is a webhook endpoint that answers with the queued statuses,
+// then 200.
+type receiver struct {
+	*httptest.Server
+	requests chan receivedRequest
+
+	mu       sync.Mutex
+	statuses []int
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	t.Helper()
+
+	r := &receiver{requests: make(chan receivedRequest, 16), statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.requests <- receivedRequest{Header: req.Header.Clone(), Body: body}
+
+		r.mu.Lock()
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) next(t *testing.T) receivedRequest {
+	t.Helper()
+
+	select {
+	case req := <-r.requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a webhook request")
+		return receivedRequest{}
+	}
+}
@@ -0,0 +1,101 @@
+package webhooks_test
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+// These advisory locks are shared with the other test packages: the shop
+// lock with tests/shops, whose TRUNCATE of the shop tables would race these
+// tests, and the outbox lock with every package that runs a dispatcher,
+// which would take this package's events. They are always taken in this
+// order.
+const (
+	sharedShopTablesLockID int64 = 70020
+	outboxLockID           int64 = 70021
+)
+
+var testDB *sql.DB
+
+func TestMain(m *testing.M) {
+	_ = loadEnv()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		log.Fatal("TEST_DATABASE_URL is not set")
+	}
+
+	var err error
+	testDB, err = sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatalf("failed to open test database: %v", err)
+	}
+
+	if err := testDB.Ping(); err != nil {
+		log.Fatalf("failed to ping test database: %v", err)
+	}
+
+	unlockShopTables := lockShared(testDB, sharedShopTablesLockID, "shared shop tables")
+	unlockOutbox := lockShared(testDB, outboxLockID, "outbox")
+	exitCode := m.Run()
+	unlockOutbox()
+	unlockShopTables()
+
+	if err := testDB.Close(); err != nil {
+		log.Printf("failed to close test database: %v", err)
+	}
+
+	os.Exit(exitCode)
+}
+
+func lockShared(db *sql.DB, lockID int64, name string) func() {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		log.Fatalf("failed to reserve %s lock connection: %v", name, err)
+	}
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		_ = conn.Close()
+		log.Fatalf("failed to lock %s: %v", name, err)
+	}
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			log.Printf("failed to unlock %s: %v", name, err)
+		}
+		if err := conn.Close(); err != nil {
+			log.Printf("failed to close %s lock connection: %v", name, err)
+		}
+	}
+}
+
+func loadEnv() error {
+	if os.Getenv("TEST_DATABASE_URL") != "" {
+		return nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	current := wd
+	for {
+		envPath := filepath.Join(current, ".env")
+		if _, statErr := os.Stat(envPath); statErr == nil {
+			return godotenv.Load(envPath)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil
+		}
+		current = parent
+	}
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// installQueryTracing hooks jet so that every statement run through
+// Query/QueryContext/Exec/ExecContext becomes a span. Jet reports a statement
+// after it finishes, so the span is back-dated by the measured duration.
+//
+// Statements run without a traced context (plain stmt.Query(db, ...)) are
+// skipped rather than reported as orphan root spans.
+func installQueryTracing() {
+	postgres.SetQueryLogger(traceQuery)
+}
+
+func traceQuery(ctx context.Context, info postgres.QueryInfo) {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return
+	}
+
+	end := time.Now()
+	query, _ := info.Statement.Sql()
+	_, _, function := info.Caller()
+
+	_, span := Tracer().Start(ctx, queryName(query, function),
+		trace.WithTimestamp(end.Add(-info.Duration)),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBQueryText(query),
+			semconv.CodeFunction(function),
+			attribute.Int64("db.rows_processed", info.RowsProcessed),
+		),
+	)
+	if info.Err != nil && !errors.Is(info.Err, qrm.ErrNoRows) {
+		span.RecordError(info.Err)
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// queryName names a query span after the SQL verb and the calling function,
+// e.g. "SELECT queries.GetFreight", which stays low-cardinality.
+func queryName(query string, function string) string {
+	verb, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	verb = strings.ToUpper(verb)
+	if function == "" {
+		return verb
+	}
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		function = function[i+1:]
+	}
+	return verb + " " + function
+}
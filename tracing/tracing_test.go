@@ -0,0 +1,25 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), "zipkin")
+	require.ErrorIs(t, err, ErrUnknownExporter)
+}
+
+func TestSetupNoneIsNoop(t *testing.T) {
+	stop, err := Setup(context.Background(), ExporterNone)
+	require.NoError(t, err)
+	require.NoError(t, stop(context.Background()))
+}
+
+func TestQueryName(t *testing.T) {
+	require.Equal(t, "SELECT queries.GetFreight",
+		queryName("\nSELECT flis_freight.niin FROM flis_freight", "miltechserver/api/item_query/detailed/queries.GetFreight"))
+	require.Equal(t, "INSERT", queryName("insert into t values ($1)", ""))
+}
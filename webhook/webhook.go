@@ -0,0 +1,208 @@
+// Package webhook signs and sends outbound webhook requests. A request body
+// is signed with HMAC-SHA256 over "<timestamp>.<body>" using the endpoint's
+// secret, so receivers can check both who sent it and that it is recent.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrInvalidURL     = errors.New("invalid webhook url")
+	ErrPrivateAddress = errors.New("webhook url resolves to a private address")
+)
+
+// Request headers.
+const (
+	HeaderEvent     = "X-Miltech-Event"
+	HeaderEventID   = "X-Miltech-Event-Id"
+	HeaderTimestamp = "X-Miltech-Timestamp"
+	HeaderSignature = "X-Miltech-Signature"
+)
+
+const (
+	// requestTimeout bounds one request, including reading the response.
+	requestTimeout = 10 * time.Second
+	// maxResponseBody is how much of a response is read; the rest is
+	// discarded.
+	maxResponseBody = 4 << 10
+	secretPrefix    = "whsec_"
+)
+
+// NewSecret returns a random signing secret.
+func NewSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(raw), nil
+}
+
+// Sign returns the signature header value for body sent at timestamp, in
+// Unix seconds.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is body's signature at timestamp. It is
+// what receivers do, in whatever language they use.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// ValidateURL checks that raw is an absolute https URL without credentials.
+// allowInsecure also accepts http, for local development.
+func ValidateURL(raw string, allowInsecure bool) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidURL, err)
+	}
+	switch {
+	case parsed.Scheme != "https" && !(allowInsecure && parsed.Scheme == "http"):
+		return fmt.Errorf("%w: must use https", ErrInvalidURL)
+	case parsed.Hostname() == "":
+		return fmt.Errorf("%w: missing host", ErrInvalidURL)
+	case parsed.User != nil:
+		return fmt.Errorf("%w: must not contain credentials", ErrInvalidURL)
+	}
+	return nil
+}
+
+// Message is one webhook request.
+type Message struct {
+	EventID   int64
+	EventType string
+	Body      []byte
+}
+
+// Result is the outcome of sending a Message. Err is nil only for a 2xx
+// response.
+type Result struct {
+	// StatusCode is zero if no response arrived.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// Sender posts signed messages.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender returns a Sender that refuses to connect to loopback, private,
+// link-local and other non-public addresses, so a registered URL cannot
+// reach the server's own network. allowPrivate lifts that for local development and tests.
+// Redirects are not followed.
+func NewSender(allowPrivate bool) *Sender {
+	dialer := &net.Dialer{Timeout: requestTimeout}
+	if !allowPrivate {
+		// Checked on the address actually dialled, so a hostname that
+		// resolves to a private address is caught too.
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if addr, err := netip.ParseAddr(host); err != nil || isPrivate(addr) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &Sender{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   requestTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// privatePrefixes are the ranges a webhook may not reach: anything that
+// isn't a public unicast address, plus the cloud metadata endpoints.
+var privatePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),        // "this network"
+	netip.MustParsePrefix("10.0.0.0/8"),       // private
+	netip.MustParsePrefix("100.64.0.0/10"),    // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),      // loopback
+	netip.MustParsePrefix("168.63.129.16/32"), // Azure host endpoint
+	netip.MustParsePrefix("169.254.0.0/16"),   // link-local, including 169.254.169.254
+	netip.MustParsePrefix("172.16.0.0/12"),    // private
+	netip.MustParsePrefix("192.168.0.0/16"),   // private
+	netip.MustParsePrefix("224.0.0.0/4"),      // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),      // reserved and broadcast
+	netip.MustParsePrefix("::/128"),           // unspecified
+	netip.MustParsePrefix("::1/128"),          // loopback
+	netip.MustParsePrefix("64:ff9b::/96"),     // NAT64, which embeds an IPv4 address
+	netip.MustParsePrefix("64:ff9b:1::/48"),   // local-use NAT64
+	netip.MustParsePrefix("fc00::/7"),         // unique local
+	netip.MustParsePrefix("fe80::/10"),        // link-local
+	netip.MustParsePrefix("ff00::/8"),         // multicast
+}
+
+func isPrivate(addr netip.Addr) bool {
+	// An IPv4-mapped address (::ffff:10.0.0.1) reaches the IPv4 host, and a
+	// zone would stop any prefix from matching.
+	addr = addr.WithZone("").Unmap()
+	for _, prefix := range privatePrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Send posts msg to endpoint, signed with secret.
+func (s *Sender) Send(ctx context.Context, endpoint string, secret string, msg Message) Result {
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(msg.Body))
+	if err != nil {
+		return Result{Err: fmt.Errorf("%w: %s", ErrInvalidURL, err)}
+	}
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "miltechserver-webhooks/1")
+	req.Header.Set(HeaderEvent, msg.EventType)
+	req.Header.Set(HeaderEventID, strconv.FormatInt(msg.EventID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, msg.Body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Result{Duration: time.Since(start), Err: err}
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	resp.Body.Close()
+
+	result := Result{StatusCode: resp.StatusCode, Duration: time.Since(start)}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		result.Err = fmt.Errorf("endpoint returned %d", resp.StatusCode)
+	}
+	return result
+}